@@ -21,7 +21,12 @@ func main() {
 		printUsage()
 		log.Fatal("no server URL found: set CONTAINARIUM_SERVER_URL, or run `containarium login` (writes ~/.containarium/credentials.json with a default_server)")
 	}
-	if config.JWTToken == "" && config.JWTTokenFile == "" {
+	if config.AuthMode == mcp.AuthModeAPIKey {
+		if config.APIKey == "" {
+			printUsage()
+			log.Fatal("CONTAINARIUM_AUTH_MODE=apikey needs CONTAINARIUM_API_KEY")
+		}
+	} else if config.JWTToken == "" && config.JWTTokenFile == "" {
 		printUsage()
 		log.Fatal("no token found: set CONTAINARIUM_JWT_TOKEN or CONTAINARIUM_JWT_TOKEN_FILE, or run `containarium login` (writes ~/.containarium/credentials.json)")
 	}
@@ -64,6 +69,12 @@ func printUsage() {
 	log.Println("")
 	log.Println("Optional environment variables:")
 	log.Println("  CONTAINARIUM_DEBUG           - Enable debug logging (true/false)")
+	log.Println("  CONTAINARIUM_AUTH_MODE       - jwt (default) or apikey, for servers fronted by an API-key proxy")
+	log.Println("  CONTAINARIUM_API_KEY         - Key sent in apikey mode (replaces the JWT)")
+	log.Println("  CONTAINARIUM_API_KEY_HEADER  - Header carrying the key (default: X-API-Key)")
+	log.Println("  CONTAINARIUM_ENABLED_TOOLS   - Comma-separated tools to expose (default: all)")
+	log.Println("  CONTAINARIUM_DISABLED_TOOLS  - Comma-separated tools to hide; wins over the allowlist")
+	log.Println("  CONTAINARIUM_READ_ONLY       - Expose only read-only tools (true/false)")
 	log.Println("")
 	log.Println("Example usage:")
 	log.Println("  export CONTAINARIUM_SERVER_URL='http://localhost:8080'")
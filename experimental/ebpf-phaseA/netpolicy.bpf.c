@@ -64,34 +64,37 @@ struct {
 // Egress allow-list as an LPM trie, scoped per tenant: the key carries the
 // tenant_id in its high bits followed by the destination prefix, so a lookup
 // only matches CIDRs the sender's tenant is allowed to reach. prefixlen counts
-// the full tenant_id (32 bits) + the IPv4 prefix bits.
+// the full tenant_id (32 bits) + the IPv4 prefix bits. The value is the same
+// port/proto scope as a deny rule's (struct deny_val, below): an allow rule can
+// open one service on a host rather than the whole host, and a plain egress
+// CIDR is port 0 / proto 0 (any).
 struct egress_key {
     __u32 prefixlen;     // 32 + cidr_bits
     __u32 tenant_id;     // big-endian-irrelevant: exact-matched, 32 prefix bits
     __u32 addr;          // network byte order, masked to cidr_bits
 };
 
-struct {
-    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
-    __type(key, struct egress_key);
-    __type(value, __u8);
-    __uint(max_entries, 65536);
-    __uint(map_flags, BPF_F_NO_PREALLOC);
-} egress_cidr SEC(".maps");
-
 // Virtual-patch deny rules (#660). Same tenant-scoped LPM key as egress_cidr,
-// but a richer value so a rule can be scoped to a destination port/proto: a CVE
-// in a service on a known port can be blocked without blackholing the whole
-// host. Evaluated BEFORE the allow logic — deny beats allow. The LPM key is
-// CIDR-only (port/proto live in the value), so there is at most ONE deny entry
-// per (tenant, CIDR); to block two ports on the same host, deny the host
-// outright (port 0 = any) — a documented Tier-1 limitation.
+// and a value scoped to a destination port/proto: a CVE in a service on a known
+// port can be blocked without blackholing the whole host. Evaluated BEFORE the
+// allow logic — deny beats allow. The LPM key is CIDR-only (port/proto live in
+// the value), so there is at most ONE deny (or allow) entry per (tenant, CIDR);
+// to block two ports on the same host, deny the host outright (port 0 = any) —
+// a documented Tier-1 limitation.
 struct deny_val {
     __u16 port;   // host byte order; 0 = any port
     __u8  proto;  // IP protocol number; 0 = any proto
     __u8  flags;  // reserved (0)
 };
 
+struct {
+    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
+    __type(key, struct egress_key);
+    __type(value, struct deny_val);
+    __uint(max_entries, 65536);
+    __uint(map_flags, BPF_F_NO_PREALLOC);
+} egress_cidr SEC(".maps");
+
 struct {
     __uint(type, BPF_MAP_TYPE_LPM_TRIE);
     __type(key, struct egress_key);
@@ -494,12 +497,15 @@ int netpolicy_ingress(struct __sk_buff *skb) {
                 allowed = 1;
         } else {
             // External destination: allowed iff it matches the tenant's egress
-            // allow-list.
+            // allow-list, within the matched entry's port/proto scope.
             struct egress_key k = {};
             k.prefixlen = 32 + 32; // full tenant match + full /32 dst (LPM shortens)
             k.tenant_id = cfg->tenant_id;
             k.addr = daddr;
-            if (bpf_map_lookup_elem(&egress_cidr, &k))
+            struct deny_val *av = bpf_map_lookup_elem(&egress_cidr, &k);
+            if (av &&
+                (av->port == 0 || av->port == dport) &&
+                (av->proto == 0 || av->proto == ip->protocol))
                 allowed = 1;
         }
     }
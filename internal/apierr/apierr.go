@@ -0,0 +1,136 @@
+// Package apierr defines the JSON error envelope every daemon REST endpoint
+// returns, so clients (the CLI's HTTP mode, the MCP server, the web UI) can
+// tell "container not found" from "quota exceeded" without parsing prose.
+//
+// The envelope keeps the historical {"error", "code"} fields — code is the
+// HTTP status — and adds a machine-readable reason (the gRPC code name in
+// UPPER_SNAKE form, e.g. NOT_FOUND, RESOURCE_EXHAUSTED) and the request ID
+// the daemon logged the failure under.
+package apierr
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+)
+
+// RequestIDHeader carries the request ID on both the request (a caller may
+// supply its own) and the error response.
+const RequestIDHeader = "X-Request-Id"
+
+// Envelope is the body of every REST error response.
+type Envelope struct {
+	// Error is the human-readable message.
+	Error string `json:"error"`
+	// Code is the HTTP status, repeated for clients that only see the body.
+	Code int `json:"code"`
+	// Reason is the machine-readable error class (see ReasonForCode).
+	Reason string `json:"reason"`
+	// RequestID correlates the response with the daemon log line.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Write sends an error envelope whose reason is derived from httpStatus.
+func Write(w http.ResponseWriter, r *http.Request, httpStatus int, msg string) {
+	WriteReason(w, r, httpStatus, ReasonForStatus(httpStatus), msg)
+}
+
+// WriteReason sends an error envelope with an explicit reason, for callers
+// that know more than the status implies (e.g. a translated gRPC code).
+func WriteReason(w http.ResponseWriter, r *http.Request, httpStatus int, reason, msg string) {
+	id := RequestID(w, r)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(Envelope{
+		Error:     msg,
+		Code:      httpStatus,
+		Reason:    reason,
+		RequestID: id,
+	})
+}
+
+// RequestID returns the request's ID — the caller's X-Request-Id when it
+// supplied a sane one, otherwise a fresh random ID — and echoes it on the
+// response. Calling it again for the same response returns the same ID.
+func RequestID(w http.ResponseWriter, r *http.Request) string {
+	if id := w.Header().Get(RequestIDHeader); id != "" {
+		return id
+	}
+	id := ""
+	if r != nil {
+		id = r.Header.Get(RequestIDHeader)
+	}
+	if !validRequestID(id) {
+		b := make([]byte, 8)
+		_, _ = rand.Read(b)
+		id = hex.EncodeToString(b)
+	}
+	w.Header().Set(RequestIDHeader, id)
+	return id
+}
+
+// validRequestID bounds a client-supplied ID so it can't smuggle anything
+// odd into logs or headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ReasonForCode renders a gRPC code the way the envelope reports it:
+// NotFound becomes NOT_FOUND, ResourceExhausted RESOURCE_EXHAUSTED.
+func ReasonForCode(c codes.Code) string {
+	var b strings.Builder
+	prev := rune(0)
+	for _, ch := range c.String() {
+		if unicode.IsUpper(ch) && unicode.IsLower(prev) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(ch))
+		prev = ch
+	}
+	return b.String()
+}
+
+// ReasonForStatus picks the reason for a handler that only has an HTTP
+// status: the matching gRPC code where there is one, otherwise the status
+// text (METHOD_NOT_ALLOWED, BAD_GATEWAY).
+func ReasonForStatus(httpStatus int) string {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return ReasonForCode(codes.InvalidArgument)
+	case http.StatusUnauthorized:
+		return ReasonForCode(codes.Unauthenticated)
+	case http.StatusForbidden:
+		return ReasonForCode(codes.PermissionDenied)
+	case http.StatusNotFound:
+		return ReasonForCode(codes.NotFound)
+	case http.StatusConflict:
+		return ReasonForCode(codes.AlreadyExists)
+	case http.StatusTooManyRequests:
+		return ReasonForCode(codes.ResourceExhausted)
+	case http.StatusInternalServerError:
+		return ReasonForCode(codes.Internal)
+	case http.StatusNotImplemented:
+		return ReasonForCode(codes.Unimplemented)
+	case http.StatusServiceUnavailable:
+		return ReasonForCode(codes.Unavailable)
+	case http.StatusGatewayTimeout:
+		return ReasonForCode(codes.DeadlineExceeded)
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(httpStatus), " ", "_"))
+}
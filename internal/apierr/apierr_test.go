@@ -0,0 +1,50 @@
+package apierr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestReasonForCode(t *testing.T) {
+	for c, want := range map[codes.Code]string{
+		codes.NotFound:          "NOT_FOUND",
+		codes.ResourceExhausted: "RESOURCE_EXHAUSTED",
+		codes.OK:                "OK",
+		codes.Internal:          "INTERNAL",
+	} {
+		if got := ReasonForCode(c); got != want {
+			t.Errorf("ReasonForCode(%v) = %q; want %q", c, got, want)
+		}
+	}
+	if got := ReasonForStatus(http.StatusMethodNotAllowed); got != "METHOD_NOT_ALLOWED" {
+		t.Errorf("ReasonForStatus(405) = %q", got)
+	}
+}
+
+func TestWrite_EnvelopeAndRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/containers/bob", nil)
+	req.Header.Set(RequestIDHeader, "client-supplied-1")
+	rec := httptest.NewRecorder()
+	Write(rec, req, http.StatusNotFound, "container bob-container not found")
+
+	var env Envelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil {
+		t.Fatalf("body %q: %v", rec.Body.String(), err)
+	}
+	want := Envelope{Error: "container bob-container not found", Code: 404, Reason: "NOT_FOUND", RequestID: "client-supplied-1"}
+	if env != want || rec.Code != 404 || rec.Header().Get(RequestIDHeader) != "client-supplied-1" {
+		t.Errorf("got %d %+v (header %q); want 404 %+v", rec.Code, env, rec.Header().Get(RequestIDHeader), want)
+	}
+
+	// A malformed client ID is replaced, not echoed.
+	req.Header.Set(RequestIDHeader, "bad id\r\nX-Evil: 1")
+	rec = httptest.NewRecorder()
+	Write(rec, req, http.StatusBadRequest, "invalid path")
+	if id := rec.Header().Get(RequestIDHeader); id == "" || id == req.Header.Get(RequestIDHeader) {
+		t.Errorf("request ID = %q; want a fresh one", id)
+	}
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CertificateInfo describes a certificate Caddy keeps in its storage.
+type CertificateInfo struct {
+	// Subject is the name the certificate was stored under: the domain
+	// itself or a wildcard ("*.example.com") covering it.
+	Subject string
+	// Issuer is the issuer directory, e.g.
+	// "acme-v02.api.letsencrypt.org-directory".
+	Issuer   string
+	NotAfter time.Time
+}
+
+// FindCertificate looks up the certificate serving domain in Caddy's
+// storage directory (the daemon's --caddy-cert-dir). Caddy keeps one
+// directory per issuer and name:
+//
+//	<certDir>/certificates/<issuer>/<name>/<name>.crt
+//
+// with wildcards stored as "wildcard_.example.com". An exact match wins
+// over a wildcard; among issuers the certificate that expires last wins,
+// since that is the one Caddy serves. ok is false when Caddy holds no
+// certificate for domain yet.
+func FindCertificate(certDir, domain string) (info CertificateInfo, ok bool, err error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	issuers, err := os.ReadDir(filepath.Join(certDir, "certificates"))
+	if os.IsNotExist(err) {
+		return CertificateInfo{}, false, nil
+	}
+	if err != nil {
+		return CertificateInfo{}, false, fmt.Errorf("failed to read certificate storage: %w", err)
+	}
+
+	names := []string{domain}
+	if _, parent, found := strings.Cut(domain, "."); found {
+		names = append(names, "*."+parent)
+	}
+	for _, name := range names {
+		stored := strings.Replace(name, "*", "wildcard_", 1)
+		for _, issuer := range issuers {
+			if !issuer.IsDir() {
+				continue
+			}
+			path := filepath.Join(certDir, "certificates", issuer.Name(), stored, stored+".crt")
+			notAfter, err := certificateNotAfter(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return CertificateInfo{}, false, err
+			}
+			if !ok || notAfter.After(info.NotAfter) {
+				info, ok = CertificateInfo{Subject: name, Issuer: issuer.Name(), NotAfter: notAfter}, true
+			}
+		}
+		if ok {
+			return info, true, nil
+		}
+	}
+	return CertificateInfo{}, false, nil
+}
+
+// certificateNotAfter returns the expiry of the leaf certificate in a PEM
+// bundle.
+func certificateNotAfter(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return time.Time{}, fmt.Errorf("no certificate in %s", path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cert.NotAfter, nil
+}
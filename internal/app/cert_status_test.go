@@ -0,0 +1,97 @@
+package app
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCaddyCert stores a self-signed certificate the way Caddy lays out
+// its storage.
+func writeCaddyCert(t *testing.T, certDir, issuer, name string, notAfter time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := name
+	if len(name) > 1 && name[0] == '*' {
+		stored = "wildcard_" + name[1:]
+	}
+	dir := filepath.Join(certDir, "certificates", issuer, stored)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, stored+".crt"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindCertificate(t *testing.T) {
+	const le = "acme-v02.api.letsencrypt.org-directory"
+	const zerossl = "acme.zerossl.com-v2-dv90"
+	certDir := t.TempDir()
+	soon := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	writeCaddyCert(t, certDir, le, "app.example.com", soon)
+	writeCaddyCert(t, certDir, zerossl, "app.example.com", later)
+	writeCaddyCert(t, certDir, le, "*.example.com", soon)
+
+	tests := []struct {
+		domain  string
+		wantOK  bool
+		subject string
+		issuer  string
+		expiry  time.Time
+	}{
+		// Both issuers hold one; Caddy serves the longer-lived.
+		{domain: "app.example.com", wantOK: true, subject: "app.example.com", issuer: zerossl, expiry: later},
+		{domain: "APP.example.com.", wantOK: true, subject: "app.example.com", issuer: zerossl, expiry: later},
+		{domain: "api.example.com", wantOK: true, subject: "*.example.com", issuer: le, expiry: soon},
+		// A wildcard only covers one label.
+		{domain: "a.b.example.com", wantOK: false},
+		{domain: "example.org", wantOK: false},
+	}
+	for _, tt := range tests {
+		info, ok, err := FindCertificate(certDir, tt.domain)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.domain, err)
+		}
+		if ok != tt.wantOK {
+			t.Fatalf("%s: ok = %v, want %v", tt.domain, ok, tt.wantOK)
+		}
+		if !ok {
+			continue
+		}
+		if info.Subject != tt.subject || info.Issuer != tt.issuer || !info.NotAfter.Equal(tt.expiry) {
+			t.Errorf("%s: got %+v, want %s from %s until %v", tt.domain, info, tt.subject, tt.issuer, tt.expiry)
+		}
+	}
+}
+
+func TestFindCertificate_NoStorageYet(t *testing.T) {
+	if _, ok, err := FindCertificate(t.TempDir(), "app.example.com"); ok || err != nil {
+		t.Fatalf("empty storage: ok=%v err=%v, want pending without error", ok, err)
+	}
+}
@@ -3,6 +3,8 @@ package audit
 import (
 	"context"
 	"log"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/footprintai/containarium/internal/events"
@@ -70,7 +72,7 @@ func (es *EventSubscriber) Stop() {
 func (es *EventSubscriber) writeEvent(event *pb.Event) {
 	entry := &AuditEntry{
 		Timestamp:    event.Timestamp.AsTime(),
-		Username:     "", // Events don't carry user identity; the corresponding API request log does
+		Username:     event.GetContainerEvent().GetActor(), // only container events carry the actor; for the rest the API request log does
 		Action:       event.Type.String(),
 		ResourceType: resourceTypeString(event.ResourceType),
 		ResourceID:   event.ResourceId,
@@ -109,7 +111,12 @@ func eventDetail(event *pb.Event) string {
 	case *pb.Event_ContainerEvent:
 		if p.ContainerEvent != nil && p.ContainerEvent.Container != nil {
 			c := p.ContainerEvent.Container
-			return "image=" + c.Image + " state=" + c.State.String()
+			detail := "image=" + c.Image + " state=" + c.State.String()
+			keys := slices.Sorted(maps.Keys(p.ContainerEvent.Details))
+			for _, k := range keys {
+				detail += " " + k + "=" + p.ContainerEvent.Details[k]
+			}
+			return detail
 		}
 	case *pb.Event_AppEvent:
 		if p.AppEvent != nil && p.AppEvent.App != nil {
@@ -16,6 +16,7 @@ import (
 var skipPaths = []string{
 	"/health",
 	"/v1/events/subscribe",
+	"/v1/events/poll",
 	"/swagger-ui/",
 	"/webui/",
 	"/grafana/",
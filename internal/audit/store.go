@@ -32,6 +32,13 @@ type QueryParams struct {
 	To           time.Time
 	Limit        int
 	Offset       int
+
+	// ResourceID matches resource_id exactly; ResourceIDLike is a SQL LIKE
+	// pattern over it (e.g. "% /v1/containers/bob%" for API rows).
+	ResourceID     string
+	ResourceIDLike string
+	// Actions restricts to any of the listed actions, on top of Action.
+	Actions []string
 }
 
 // Store handles persistent storage of audit log entries
@@ -257,6 +264,27 @@ func (s *Store) Query(ctx context.Context, params QueryParams) ([]AuditEntry, in
 		argIdx++
 	}
 
+	if params.ResourceID != "" {
+		baseQuery += fmt.Sprintf(" AND resource_id = $%d", argIdx)
+		countQuery += fmt.Sprintf(" AND resource_id = $%d", argIdx)
+		args = append(args, params.ResourceID)
+		argIdx++
+	}
+
+	if params.ResourceIDLike != "" {
+		baseQuery += fmt.Sprintf(" AND resource_id LIKE $%d", argIdx)
+		countQuery += fmt.Sprintf(" AND resource_id LIKE $%d", argIdx)
+		args = append(args, params.ResourceIDLike)
+		argIdx++
+	}
+
+	if len(params.Actions) > 0 {
+		baseQuery += fmt.Sprintf(" AND action = ANY($%d)", argIdx)
+		countQuery += fmt.Sprintf(" AND action = ANY($%d)", argIdx)
+		args = append(args, params.Actions)
+		argIdx++
+	}
+
 	if !params.From.IsZero() {
 		baseQuery += fmt.Sprintf(" AND timestamp >= $%d", argIdx)
 		countQuery += fmt.Sprintf(" AND timestamp >= $%d", argIdx)
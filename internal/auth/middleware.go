@@ -8,6 +8,8 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+
+	"github.com/footprintai/containarium/internal/apierr"
 )
 
 // AuthMiddleware handles authentication for HTTP and gRPC requests
@@ -79,14 +81,14 @@ func (am *AuthMiddleware) HTTPMiddleware(next http.Handler) http.Handler {
 		if authHeader := r.Header.Get("Authorization"); authHeader != "" {
 			parts := strings.SplitN(authHeader, " ", 2)
 			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-				http.Error(w, `{"error": "invalid authorization header format, expected 'Bearer <token>'", "code": 401}`, http.StatusUnauthorized)
+				apierr.Write(w, r, http.StatusUnauthorized, "invalid authorization header format, expected 'Bearer <token>'")
 				return
 			}
 			token = parts[1]
 		} else if c, err := r.Cookie(SessionCookieName); err == nil && c.Value != "" {
 			token = c.Value
 		} else {
-			http.Error(w, `{"error": "missing authorization header", "code": 401}`, http.StatusUnauthorized)
+			apierr.Write(w, r, http.StatusUnauthorized, "missing authorization header")
 			return
 		}
 
@@ -111,10 +113,10 @@ func (am *AuthMiddleware) HTTPMiddleware(next http.Handler) http.Handler {
 			// burst.
 			ip := clientIPFromRequest(r)
 			if ip != "" && !am.failureLimiter.Allow(ip, time.Now()) {
-				http.Error(w, `{"error": "too many failed authentication attempts; try again later", "code": 429}`, http.StatusTooManyRequests)
+				apierr.Write(w, r, http.StatusTooManyRequests, "too many failed authentication attempts; try again later")
 				return
 			}
-			http.Error(w, `{"error": "invalid token", "code": 401}`, http.StatusUnauthorized)
+			apierr.Write(w, r, http.StatusUnauthorized, "invalid token")
 			return
 		}
 
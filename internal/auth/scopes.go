@@ -45,6 +45,10 @@ const (
 	// this scope just narrows what an admin token CAN do.
 	ScopeKMSAdmin = "kms:admin"
 
+	// daemon process administration (AdminService, e.g. config reload).
+	// Like kms:admin it is host-wide and the admin role is ALSO required.
+	ScopeDaemonAdmin = "daemon:admin"
+
 	// database backups (BackupServer). Separate from containers:
 	// a backup read returns dump locations/checksums, and a write
 	// can exfiltrate a tenant's whole database off-host or restore
@@ -115,7 +119,7 @@ const (
 var AllScopes = []string{
 	ScopeContainersRead, ScopeContainersWrite,
 	ScopeSecretsRead, ScopeSecretsWrite,
-	ScopeKMSAdmin,
+	ScopeKMSAdmin, ScopeDaemonAdmin,
 	ScopeBackupsRead, ScopeBackupsWrite,
 	ScopeVolumesRead, ScopeVolumesWrite,
 	ScopeRoutesRead, ScopeRoutesWrite,
@@ -160,7 +160,7 @@ func (c *GRPCClient) ListContainers() ([]incus.ContainerInfo, error) {
 }
 
 // CreateContainer creates a container via gRPC
-func (c *GRPCClient) CreateContainer(username, image, cpu, memory, disk string, sshKeys []string, enablePodman bool, stack string, gpus []string, osType pb.OSType, monitoring bool, pool, backendID string, git GitSourceOpts, ttlSeconds int64, idleStopMinutes int32, deleteAfterStoppedSeconds int64, storageClass string) (*incus.ContainerInfo, error) {
+func (c *GRPCClient) CreateContainer(username, image, cpu, memory, disk string, sshKeys []string, enablePodman bool, stack string, gpus []string, osType pb.OSType, monitoring bool, pool, backendID string, git GitSourceOpts, ttlSeconds int64, idleStopMinutes int32, deleteAfterStoppedSeconds int64, storageClass, template string) (*incus.ContainerInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute) // Container creation can take time (includes ultra-aggressive retry logic for google_guest_agent)
 	defer cancel()
 
@@ -188,6 +188,7 @@ func (c *GRPCClient) CreateContainer(username, image, cpu, memory, disk string,
 		TtlSeconds:                ttlSeconds,
 		IdleStopMinutes:           idleStopMinutes,
 		DeleteAfterStoppedSeconds: deleteAfterStoppedSeconds,
+		Template:                  template,
 	}
 
 	resp, err := c.client.CreateContainer(ctx, req)
@@ -376,20 +377,43 @@ func (c *GRPCClient) StopContainer(username string, force bool) (*pb.StopContain
 
 // DeleteContainer deletes a container via gRPC
 func (c *GRPCClient) DeleteContainer(username string, force bool) error {
+	_, err := c.DeleteContainerReport(username, force, false)
+	return err
+}
+
+// DeleteContainerReport deletes a container via gRPC and returns the
+// teardown report: the dependent resources removed with it and, on a forced
+// delete, those left behind. With dryRun the daemon only plans the delete
+// and the report lists what it would remove.
+func (c *GRPCClient) DeleteContainerReport(username string, force, dryRun bool) (*pb.DeleteContainerResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	req := &pb.DeleteContainerRequest{
 		Username: username,
 		Force:    force,
+		DryRun:   dryRun,
 	}
 
-	_, err := c.client.DeleteContainer(ctx, req)
+	resp, err := c.client.DeleteContainer(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to delete container: %w", err)
+		return nil, fmt.Errorf("failed to delete container: %w", err)
 	}
 
-	return nil
+	return resp, nil
+}
+
+// GarbageCollect finds (and unless dryRun, removes) dependent resources
+// whose container no longer exists via gRPC. Admin only.
+func (c *GRPCClient) GarbageCollect(dryRun bool) (*pb.GarbageCollectResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	resp, err := c.client.GarbageCollect(ctx, &pb.GarbageCollectRequest{DryRun: dryRun})
+	if err != nil {
+		return nil, fmt.Errorf("failed to garbage-collect: %w", err)
+	}
+	return resp, nil
 }
 
 // SetSecret creates or updates a tenant secret via gRPC. Idempotent —
@@ -1143,6 +1167,112 @@ func (c *GRPCClient) DeleteRoute(domain string) error {
 	return nil
 }
 
+// ListPassthroughRoutes lists TCP/UDP passthrough routes via gRPC
+func (c *GRPCClient) ListPassthroughRoutes() ([]*pb.PassthroughRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.networkClient.ListPassthroughRoutes(ctx, &pb.ListPassthroughRoutesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passthrough routes: %w", err)
+	}
+
+	return resp.Routes, nil
+}
+
+// AddPassthroughRoute adds a TCP/UDP passthrough route via gRPC
+func (c *GRPCClient) AddPassthroughRoute(req *pb.AddPassthroughRouteRequest) (*pb.PassthroughRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.networkClient.AddPassthroughRoute(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add passthrough route: %w", err)
+	}
+
+	return resp.Route, nil
+}
+
+// UpdatePassthroughRoute updates a TCP/UDP passthrough route via gRPC
+func (c *GRPCClient) UpdatePassthroughRoute(req *pb.UpdatePassthroughRouteRequest) (*pb.PassthroughRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.networkClient.UpdatePassthroughRoute(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update passthrough route: %w", err)
+	}
+
+	return resp.Route, nil
+}
+
+// DeletePassthroughRoute removes (or, with req.DryRun, looks up) a TCP/UDP
+// passthrough route via gRPC
+func (c *GRPCClient) DeletePassthroughRoute(req *pb.DeletePassthroughRouteRequest) (*pb.DeletePassthroughRouteResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.networkClient.DeletePassthroughRoute(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove passthrough route: %w", err)
+	}
+
+	return resp, nil
+}
+
+// AdoptPassthroughRoute brings an unmanaged DNAT rule under management as
+// a passthrough route via gRPC
+func (c *GRPCClient) AdoptPassthroughRoute(req *pb.AdoptPassthroughRouteRequest) (*pb.PassthroughRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.networkClient.AdoptPassthroughRoute(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adopt passthrough route: %w", err)
+	}
+
+	return resp.Route, nil
+}
+
+// SetBandwidthLimit caps a container's throughput via gRPC
+func (c *GRPCClient) SetBandwidthLimit(req *pb.SetBandwidthLimitRequest) (*pb.BandwidthLimit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.networkClient.SetBandwidthLimit(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set bandwidth limit: %w", err)
+	}
+
+	return resp.Limit, nil
+}
+
+// ListBandwidthLimits lists the bandwidth limits in force via gRPC
+func (c *GRPCClient) ListBandwidthLimits() ([]*pb.BandwidthLimit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.networkClient.ListBandwidthLimits(ctx, &pb.ListBandwidthLimitsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bandwidth limits: %w", err)
+	}
+
+	return resp.Limits, nil
+}
+
+// ClearBandwidthLimit removes a container's bandwidth limit via gRPC
+func (c *GRPCClient) ClearBandwidthLimit(containerName string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.networkClient.ClearBandwidthLimit(ctx, &pb.ClearBandwidthLimitRequest{ContainerName: containerName})
+	if err != nil {
+		return "", fmt.Errorf("failed to clear bandwidth limit: %w", err)
+	}
+
+	return resp.Message, nil
+}
+
 // StartEgressProxy asks the daemon to bridge a host-loopback SOCKS (exposed by
 // the caller via `ssh -R`) into a box's netns (#808 egress-via-client). Returns
 // the in-box SOCKS address to point the box's apps at.
@@ -284,7 +284,7 @@ type GitSourceOpts struct {
 	WorkspacePath string // empty defaults to /workspace
 }
 
-func (c *HTTPClient) CreateContainer(username, image, cpu, memory, disk string, sshKeys []string, enablePodman bool, stack string, gpus []string, osType pb.OSType, monitoring bool, pool, backendID string, git GitSourceOpts, ttlSeconds int64, idleStopMinutes int32, deleteAfterStoppedSeconds int64, storageClass string) (*incus.ContainerInfo, error) {
+func (c *HTTPClient) CreateContainer(username, image, cpu, memory, disk string, sshKeys []string, enablePodman bool, stack string, gpus []string, osType pb.OSType, monitoring bool, pool, backendID string, git GitSourceOpts, ttlSeconds int64, idleStopMinutes int32, deleteAfterStoppedSeconds int64, storageClass, template string) (*incus.ContainerInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
 	defer cancel()
 
@@ -309,6 +309,9 @@ func (c *HTTPClient) CreateContainer(username, image, cpu, memory, disk string,
 		"pool":         pool,
 		"backendId":    backendID,
 	}
+	if template != "" {
+		reqBody["template"] = template
+	}
 	if git.Source != "" {
 		reqBody["gitSource"] = git.Source
 		reqBody["gitRef"] = git.Ref
@@ -988,32 +991,81 @@ func (c *HTTPClient) ResizeContainer(username, cpu, memory, disk string) (string
 
 // DeleteContainer deletes a container via HTTP
 func (c *HTTPClient) DeleteContainer(username string, force bool) error {
+	_, err := c.DeleteContainerReport(username, force, false)
+	return err
+}
+
+// DeleteContainerReport deletes a container via HTTP and returns the
+// teardown report (dependents removed, and those a forced delete left
+// behind). With dryRun the daemon only plans the delete.
+func (c *HTTPClient) DeleteContainerReport(username string, force, dryRun bool) (*pb.DeleteContainerResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	path := fmt.Sprintf("/v1/containers/%s", url.PathEscape(username))
+	q := url.Values{}
 	if force {
-		path += "?force=true"
+		q.Set("force", "true")
+	}
+	if dryRun {
+		q.Set("dry_run", "true")
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
 	}
 
 	resp, err := c.doRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete container: %w", err)
+		return nil, fmt.Errorf("failed to delete container: %w", err)
 	}
 	defer drainClose(resp)
 
+	bodyBytes, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
 		var errResp struct {
 			Error string `json:"error"`
 		}
 		if json.Unmarshal(bodyBytes, &errResp) == nil && errResp.Error != "" {
-			return fmt.Errorf("%s", errResp.Error)
+			return nil, fmt.Errorf("%s", errResp.Error)
 		}
-		return fmt.Errorf("failed to delete container: status %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to delete container: status %d", resp.StatusCode)
 	}
 
-	return nil
+	out := &pb.DeleteContainerResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// GarbageCollect finds (and unless dryRun, removes) dependent resources
+// whose container no longer exists via HTTP. Admin only.
+func (c *HTTPClient) GarbageCollect(dryRun bool) (*pb.GarbageCollectResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/gc", map[string]interface{}{"dry_run": dryRun})
+	if err != nil {
+		return nil, fmt.Errorf("failed to garbage-collect: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		var errResp struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(bodyBytes, &errResp) == nil && errResp.Error != "" {
+			return nil, fmt.Errorf("%s", errResp.Error)
+		}
+		return nil, fmt.Errorf("failed to garbage-collect: status %d", resp.StatusCode)
+	}
+
+	out := &pb.GarbageCollectResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
 }
 
 // StartEgressProxy asks the control plane (or daemon) to bridge a host-loopback
@@ -1906,6 +1958,222 @@ func (c *HTTPClient) DeleteRoute(domain string) error {
 	return nil
 }
 
+// --- Bandwidth limits ------------------------------------------------------
+
+// SetBandwidthLimit caps a container's throughput (POST
+// /v1/network/bandwidth-limits). Mirrors GRPCClient.SetBandwidthLimit.
+func (c *HTTPClient) SetBandwidthLimit(req *pb.SetBandwidthLimitRequest) (*pb.BandwidthLimit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode set-bandwidth-limit request: %w", err)
+	}
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/network/bandwidth-limits", json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("set bandwidth limit: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpErr("set bandwidth limit", resp.StatusCode, bodyBytes)
+	}
+	out := &pb.SetBandwidthLimitResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode set-bandwidth-limit response: %w", err)
+	}
+	return out.GetLimit(), nil
+}
+
+// ListBandwidthLimits returns the bandwidth limits in force (GET
+// /v1/network/bandwidth-limits). Mirrors GRPCClient.ListBandwidthLimits.
+func (c *HTTPClient) ListBandwidthLimits() ([]*pb.BandwidthLimit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v1/network/bandwidth-limits", nil)
+	if err != nil {
+		return nil, fmt.Errorf("list bandwidth limits: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpErr("list bandwidth limits", resp.StatusCode, bodyBytes)
+	}
+	out := &pb.ListBandwidthLimitsResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode list-bandwidth-limits response: %w", err)
+	}
+	return out.GetLimits(), nil
+}
+
+// ClearBandwidthLimit removes a container's bandwidth limit (DELETE
+// /v1/network/bandwidth-limits/{container_name}). Mirrors
+// GRPCClient.ClearBandwidthLimit.
+func (c *HTTPClient) ClearBandwidthLimit(containerName string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.doRequest(ctx, http.MethodDelete, "/v1/network/bandwidth-limits/"+url.PathEscape(containerName), nil)
+	if err != nil {
+		return "", fmt.Errorf("clear bandwidth limit: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return "", httpErr("clear bandwidth limit", resp.StatusCode, bodyBytes)
+	}
+	out := &pb.ClearBandwidthLimitResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return "", fmt.Errorf("decode clear-bandwidth-limit response: %w", err)
+	}
+	return out.GetMessage(), nil
+}
+
+// --- Passthrough routes ----------------------------------------------------
+
+// ListPassthroughRoutes returns the TCP/UDP passthrough routes (GET
+// /v1/network/passthrough). Mirrors GRPCClient.ListPassthroughRoutes.
+func (c *HTTPClient) ListPassthroughRoutes() ([]*pb.PassthroughRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v1/network/passthrough", nil)
+	if err != nil {
+		return nil, fmt.Errorf("list passthrough routes: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpErr("list passthrough routes", resp.StatusCode, bodyBytes)
+	}
+	out := &pb.ListPassthroughRoutesResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode list-passthrough response: %w", err)
+	}
+	return out.GetRoutes(), nil
+}
+
+// AddPassthroughRoute creates a passthrough route (POST
+// /v1/network/passthrough). Mirrors GRPCClient.AddPassthroughRoute.
+func (c *HTTPClient) AddPassthroughRoute(req *pb.AddPassthroughRouteRequest) (*pb.PassthroughRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode add-passthrough request: %w", err)
+	}
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/network/passthrough", json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("add passthrough route: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpErr("add passthrough route", resp.StatusCode, bodyBytes)
+	}
+	out := &pb.AddPassthroughRouteResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode add-passthrough response: %w", err)
+	}
+	return out.GetRoute(), nil
+}
+
+// UpdatePassthroughRoute updates a passthrough route (PUT
+// /v1/network/passthrough/{external_port}). Mirrors
+// GRPCClient.UpdatePassthroughRoute.
+func (c *HTTPClient) UpdatePassthroughRoute(req *pb.UpdatePassthroughRouteRequest) (*pb.PassthroughRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode update-passthrough request: %w", err)
+	}
+	path := fmt.Sprintf("/v1/network/passthrough/%d", req.GetExternalPort())
+	resp, err := c.doRequest(ctx, http.MethodPut, path, json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("update passthrough route: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpErr("update passthrough route", resp.StatusCode, bodyBytes)
+	}
+	out := &pb.UpdatePassthroughRouteResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode update-passthrough response: %w", err)
+	}
+	return out.GetRoute(), nil
+}
+
+// AdoptPassthroughRoute brings an unmanaged DNAT rule under management
+// (POST /v1/network/passthrough/{external_port}/adopt). Mirrors
+// GRPCClient.AdoptPassthroughRoute.
+func (c *HTTPClient) AdoptPassthroughRoute(req *pb.AdoptPassthroughRouteRequest) (*pb.PassthroughRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode adopt-passthrough request: %w", err)
+	}
+	path := fmt.Sprintf("/v1/network/passthrough/%d/adopt", req.GetExternalPort())
+	resp, err := c.doRequest(ctx, http.MethodPost, path, json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("adopt passthrough route: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpErr("adopt passthrough route", resp.StatusCode, bodyBytes)
+	}
+	out := &pb.AdoptPassthroughRouteResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode adopt-passthrough response: %w", err)
+	}
+	return out.GetRoute(), nil
+}
+
+// DeletePassthroughRoute removes (or, with req.DryRun, looks up) a
+// passthrough route (DELETE /v1/network/passthrough/{port}). Mirrors
+// GRPCClient.DeletePassthroughRoute.
+func (c *HTTPClient) DeletePassthroughRoute(req *pb.DeletePassthroughRouteRequest) (*pb.DeletePassthroughRouteResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	q := url.Values{}
+	q.Set("protocol", req.GetProtocol().String())
+	if req.GetDryRun() {
+		q.Set("dry_run", "true")
+	}
+	path := fmt.Sprintf("/v1/network/passthrough/%d?%s", req.GetExternalPort(), q.Encode())
+	resp, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("remove passthrough route: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpErr("remove passthrough route", resp.StatusCode, bodyBytes)
+	}
+	out := &pb.DeletePassthroughRouteResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode remove-passthrough response: %w", err)
+	}
+	return out, nil
+}
+
 // httpErr builds an error from a >=400 REST response, preferring the daemon's
 // {"error": ...} body over a bare status code.
 func httpErr(op string, status int, body []byte) error {
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+// maxWaitPollInterval caps how far WaitForState backs off between polls.
+const maxWaitPollInterval = 30 * time.Second
+
+// ErrContainerFailed is returned by WaitForState when the box lands in
+// ERROR while waiting for some other state; waiting longer will not help.
+var ErrContainerFailed = errors.New("container is in ERROR state")
+
+// WaitForState polls GetContainer until username's box reaches target
+// ("RUNNING", "stopped", or the full "CONTAINER_STATE_RUNNING") or ctx
+// expires. It returns the container as last seen in the target state.
+// Polls start at pollInterval and back off with jitter, so many concurrent
+// waits don't hit the daemon in lockstep.
+func (c *GRPCClient) WaitForState(ctx context.Context, username, target string, pollInterval time.Duration) (*incus.ContainerInfo, error) {
+	return waitForState(ctx, c.GetContainer, username, target, pollInterval)
+}
+
+// WaitForState polls GetContainer until username's box reaches target or
+// ctx expires; see GRPCClient.WaitForState.
+func (c *HTTPClient) WaitForState(ctx context.Context, username, target string, pollInterval time.Duration) (*incus.ContainerInfo, error) {
+	return waitForState(ctx, c.GetContainer, username, target, pollInterval)
+}
+
+// waitForState is the transport-independent poll loop. Get errors are
+// tolerated — a box can briefly 404 between the daemon's pending bookkeeping
+// and Incus visibility — and only reported if the wait times out.
+func waitForState(ctx context.Context, get func(string) (*incus.ContainerInfo, error), username, target string, pollInterval time.Duration) (*incus.ContainerInfo, error) {
+	want := normalizeState(target)
+	if want == "" {
+		return nil, fmt.Errorf("target state is required")
+	}
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("poll interval must be positive, got %s", pollInterval)
+	}
+
+	lastState := "unknown"
+	var lastErr error
+	delay := pollInterval
+	for {
+		info, err := get(username)
+		if err == nil {
+			lastState, lastErr = normalizeState(info.State), nil
+			switch {
+			case lastState == want:
+				return info, nil
+			case lastState == "ERROR":
+				return info, fmt.Errorf("%w: %s never reached %s", ErrContainerFailed, username, want)
+			}
+		} else {
+			lastErr = err
+		}
+
+		timer := time.NewTimer(jitter(delay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if lastErr != nil {
+				return nil, fmt.Errorf("timed out waiting for %s to reach %s (last state: %s, last error: %v): %w", username, want, lastState, lastErr, ctx.Err())
+			}
+			return nil, fmt.Errorf("timed out waiting for %s to reach %s (last state: %s): %w", username, want, lastState, ctx.Err())
+		case <-timer.C:
+		}
+		delay = min(delay*3/2, max(maxWaitPollInterval, pollInterval))
+	}
+}
+
+// normalizeState maps "running", "RUNNING" and "CONTAINER_STATE_RUNNING" to
+// the same short form; both clients report the proto enum name.
+func normalizeState(s string) string {
+	return strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "CONTAINER_STATE_")
+}
+
+// jitter spreads d by ±20%.
+func jitter(d time.Duration) time.Duration {
+	// #nosec G404 -- jitter for poll spacing; not security-sensitive.
+	return time.Duration(float64(d) * (0.8 + 0.4*rand.Float64()))
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+func TestWaitForState_PollsUntilTarget(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/containers/alice" {
+			t.Errorf("path = %q; want /v1/containers/alice", r.URL.Path)
+		}
+		calls++
+		state := "CONTAINER_STATE_CREATING"
+		if calls >= 3 {
+			state = "CONTAINER_STATE_RUNNING"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"container":{"name":"alice-container","username":"alice","state":"` + state + `"}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewHTTPClient(srv.URL, "tok")
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	info, err := c.WaitForState(ctx, "alice", "running", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForState: %v", err)
+	}
+	if calls != 3 || info.Name != "alice-container" {
+		t.Errorf("got %s after %d polls; want alice-container after 3", info.Name, calls)
+	}
+}
+
+func TestWaitForState_TimeoutReportsLastState(t *testing.T) {
+	var failures int
+	get := func(string) (*incus.ContainerInfo, error) {
+		failures++
+		if failures == 1 {
+			return &incus.ContainerInfo{State: "CONTAINER_STATE_STOPPED"}, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := waitForState(ctx, get, "alice", "CONTAINER_STATE_RUNNING", time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v; want a deadline error", err)
+	}
+	for _, want := range []string{"alice to reach RUNNING", "last state: STOPPED", "connection refused"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestWaitForState_StopsOnError(t *testing.T) {
+	calls := 0
+	get := func(string) (*incus.ContainerInfo, error) {
+		calls++
+		return &incus.ContainerInfo{State: "CONTAINER_STATE_ERROR"}, nil
+	}
+	_, err := waitForState(context.Background(), get, "alice", "RUNNING", time.Millisecond)
+	if !errors.Is(err, ErrContainerFailed) || calls != 1 {
+		t.Errorf("err = %v after %d polls; want ErrContainerFailed after 1", err, calls)
+	}
+
+	// Waiting for ERROR itself is allowed.
+	if _, err := waitForState(context.Background(), get, "alice", "error", time.Millisecond); err != nil {
+		t.Errorf("waiting for ERROR: %v", err)
+	}
+}
+
+func TestJitter_StaysWithinSpread(t *testing.T) {
+	for range 100 {
+		if d := jitter(time.Second); d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("jitter(1s) = %s; want within ±20%%", d)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -44,6 +45,7 @@ var (
 	createStorageClass       string
 	createWait               bool
 	createWaitTimeout        time.Duration
+	createTemplate           string
 )
 
 var createCmd = &cobra.Command{
@@ -80,7 +82,10 @@ Examples:
   containarium create charlie --ssh-key ~/.ssh/id_rsa.pub --labels team=dev,project=web
 
   # Force recreate if container already exists
-  containarium create alice --ssh-key ~/.ssh/id_rsa.pub --force`,
+  containarium create alice --ssh-key ~/.ssh/id_rsa.pub --force
+
+  # Start from the daemon's "small" template; flags given override it
+  containarium create --template small alice --server <host:port>`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCreate,
 }
@@ -130,6 +135,7 @@ func init() {
 	createCmd.Flags().StringVar(&createStorageClass, "storage-class", "", "K8s StorageClass for the box's data PVC (K8s backend only). Empty = use the cluster's default StorageClass. Example: 'fast-nvme', 'standard', 'ceph-block'. Ignored on the LXC backend.")
 	createCmd.Flags().BoolVar(&createWait, "wait", false, "Block until the box finishes provisioning (remote mode). A remote create is async: the daemon returns CREATING immediately and provisions for minutes — SSH only works once the state reaches RUNNING. --wait polls the daemon until then (or --wait-timeout), exiting non-zero if provisioning fails. Local mode provisions synchronously; --wait is a no-op there.")
 	createCmd.Flags().DurationVar(&createWaitTimeout, "wait-timeout", 5*time.Minute, "How long --wait polls before giving up (Go duration).")
+	createCmd.Flags().StringVar(&createTemplate, "template", "", "Start from this daemon-side container template. Only flags given explicitly override it; --ssh-key is optional when the template has keys. Requires --server.")
 }
 
 // validateSSHKeyMode enforces "exactly one of --ssh-key / --no-ssh-key".
@@ -155,6 +161,12 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if (createPool != "" || createBackendID != "") && serverAddr == "" {
 		return fmt.Errorf("--pool and --backend-id require --server (cluster mode); they are not supported in local Incus mode")
 	}
+	if createTemplate != "" {
+		if serverAddr == "" {
+			return fmt.Errorf("--template requires --server: templates live on the daemon")
+		}
+		clearTemplatedDefaults(cmd)
+	}
 
 	// Parse labels from key=value format
 	parsedLabels := parseLabels(labels)
@@ -239,9 +251,12 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve the SSH key, unless this is a keyless (platform-managed) service
-	// tenant. Exactly one of --ssh-key / --no-ssh-key must be supplied.
-	if err := validateSSHKeyMode(noSSHKey, sshKeyPath); err != nil {
-		return err
+	// tenant. Exactly one of --ssh-key / --no-ssh-key must be supplied,
+	// unless a template may supply the keys.
+	if createTemplate == "" {
+		if err := validateSSHKeyMode(noSSHKey, sshKeyPath); err != nil {
+			return err
+		}
 	}
 
 	var sshKey string
@@ -253,7 +268,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		if verbose {
 			fmt.Println("  Keyless mode (--no-ssh-key): no SSH key will be seeded; access via platform only")
 		}
-	} else {
+	} else if sshKeyPath != "" {
 		// Expand home directory
 		expandedPath := sshKeyPath
 		if len(sshKeyPath) >= 2 && sshKeyPath[:2] == "~/" {
@@ -393,13 +408,13 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	if httpMode && serverAddr != "" {
 		// Remote mode via HTTP
-		info, err = createRemoteHTTP(username, containerImage, cpuLimit, memoryLimit, diskLimit, sshKeys, enablePodman, stackID, gpuDevices, osType, monitoring, createPool, createBackendID, gitOpts, ttlSeconds, idleStopMinutes, deleteAfterStoppedSeconds, createStorageClass)
+		info, err = createRemoteHTTP(username, containerImage, cpuLimit, memoryLimit, diskLimit, sshKeys, enablePodman, stackID, gpuDevices, osType, monitoring, createPool, createBackendID, gitOpts, ttlSeconds, idleStopMinutes, deleteAfterStoppedSeconds, createStorageClass, createTemplate)
 		if err != nil {
 			return fmt.Errorf("failed to create container via HTTP API: %w", err)
 		}
 	} else if serverAddr != "" {
 		// Remote mode via gRPC
-		info, err = createRemote(username, containerImage, cpuLimit, memoryLimit, diskLimit, sshKeys, enablePodman, stackID, gpuDevices, osType, monitoring, createPool, createBackendID, gitOpts, ttlSeconds, idleStopMinutes, deleteAfterStoppedSeconds, createStorageClass)
+		info, err = createRemote(username, containerImage, cpuLimit, memoryLimit, diskLimit, sshKeys, enablePodman, stackID, gpuDevices, osType, monitoring, createPool, createBackendID, gitOpts, ttlSeconds, idleStopMinutes, deleteAfterStoppedSeconds, createStorageClass, createTemplate)
 		if err != nil {
 			return fmt.Errorf("failed to create container via remote server: %w", err)
 		}
@@ -423,12 +438,12 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	// the settled state + IP instead of CREATING. Local mode provisions
 	// synchronously inside createLocal, so there is nothing to wait for.
 	if createWait && serverAddr != "" {
-		getFn, closeFn, cerr := containerGetter()
+		waiter, closeFn, cerr := containerWaiter()
 		if cerr != nil {
 			return cerr
 		}
 		defer closeFn()
-		final, werr := waitForContainerReady(getFn, username, createWaitTimeout)
+		final, werr := waitForContainerReady(waiter, username, createWaitTimeout)
 		if werr != nil {
 			return werr
 		}
@@ -548,6 +563,22 @@ func enableAutoRestartCompose(username, dir string) error {
 	return nil
 }
 
+// clearTemplatedDefaults blanks the create flags a template can fill in
+// that were left at their defaults, so the template's values apply and
+// only flags given on the command line override it.
+func clearTemplatedDefaults(cmd *cobra.Command) {
+	for name, v := range map[string]*string{
+		"cpu": &cpuLimit, "memory": &memoryLimit, "disk": &diskLimit, "image": &containerImage,
+	} {
+		if !cmd.Flags().Changed(name) {
+			*v = ""
+		}
+	}
+	if !cmd.Flags().Changed("podman") {
+		enablePodman = false
+	}
+}
+
 // resolveGitSourceOpts builds the git-source options from the create
 // flags, reading the credential file if one was supplied. Returns the
 // zero value (Source == "") when --git-source wasn't set.
@@ -646,55 +677,48 @@ func createLocal(username, image, cpu, memory, disk, staticIP string, sshKeys []
 }
 
 // parseLabels parses labels from key=value format
-// containerGetter builds a GetContainer function against the configured
-// remote (HTTP or gRPC, same mode selection as the create itself), plus a
-// close function for the underlying client. Used by --wait.
-func containerGetter() (func(string) (*incus.ContainerInfo, error), func(), error) {
+// stateWaiter is the slice of the remote clients --wait uses; both
+// client.GRPCClient and client.HTTPClient implement it.
+type stateWaiter interface {
+	WaitForState(ctx context.Context, username, target string, pollInterval time.Duration) (*incus.ContainerInfo, error)
+}
+
+// containerWaiter builds a stateWaiter against the configured remote (HTTP
+// or gRPC, same mode selection as the create itself), plus a close function
+// for the underlying client. Used by --wait.
+func containerWaiter() (stateWaiter, func(), error) {
 	if httpMode {
 		httpClient, err := client.NewHTTPClient(serverAddr, authToken)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create HTTP client: %w", err)
 		}
-		return httpClient.GetContainer, func() { _ = httpClient.Close() }, nil
+		return httpClient, func() { _ = httpClient.Close() }, nil
 	}
 	grpcClient, err := client.NewGRPCClient(serverAddr, certsDir, insecure)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to remote server: %w", err)
 	}
-	return grpcClient.GetContainer, func() { _ = grpcClient.Close() }, nil
+	return grpcClient, func() { _ = grpcClient.Close() }, nil
 }
 
-// waitForContainerReady polls getFn until the box reaches a settled state
-// (#1036). Both clients report the proto enum name ("CONTAINER_STATE_RUNNING",
-// "...PROVISIONING", ...); normalize by trimming the prefix. Transient get
-// errors are tolerated (the box can briefly 404 between the daemon's pending
-// bookkeeping and incus visibility) — only the deadline or an explicit ERROR
-// state ends the wait early.
-func waitForContainerReady(getFn func(string) (*incus.ContainerInfo, error), username string, timeout time.Duration) (*incus.ContainerInfo, error) {
-	const pollInterval = 5 * time.Second
-	deadline := time.Now().Add(timeout)
-	lastState := ""
+// waitForContainerReady waits for the box to reach RUNNING (#1036). Only the
+// deadline or an explicit ERROR state ends the wait early; transient get
+// errors are retried by WaitForState.
+func waitForContainerReady(w stateWaiter, username string, timeout time.Duration) (*incus.ContainerInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 	fmt.Printf("Waiting for provisioning to finish (timeout %s)...\n", timeout)
-	for {
-		info, err := getFn(username)
-		if err == nil {
-			state := strings.TrimPrefix(info.State, "CONTAINER_STATE_")
-			if state != lastState {
-				fmt.Printf("  state: %s\n", state)
-				lastState = state
-			}
-			switch state {
-			case "RUNNING", "STOPPED":
-				return info, nil
-			case "ERROR":
-				return nil, fmt.Errorf("provisioning failed for %s: daemon reports ERROR (see the daemon log for the cause)", username)
-			}
-		}
-		if time.Now().After(deadline) {
-			return nil, fmt.Errorf("timed out after %s waiting for %s to finish provisioning (last state: %s); the box may still come up — check with 'containarium list'", timeout, username, lastState)
-		}
-		time.Sleep(pollInterval)
+	info, err := w.WaitForState(ctx, username, "RUNNING", 5*time.Second)
+	switch {
+	case errors.Is(err, client.ErrContainerFailed):
+		return nil, fmt.Errorf("provisioning failed for %s: daemon reports ERROR (see the daemon log for the cause)", username)
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, fmt.Errorf("%w; the box may still come up — check with 'containarium list'", err)
+	case err != nil:
+		return nil, err
 	}
+	fmt.Printf("  state: %s\n", strings.TrimPrefix(info.State, "CONTAINER_STATE_"))
+	return info, nil
 }
 
 func parseLabels(labelSlice []string) map[string]string {
@@ -713,23 +737,23 @@ func parseLabels(labelSlice []string) map[string]string {
 }
 
 // createRemote creates a container using remote gRPC server
-func createRemote(username, image, cpu, memory, disk string, sshKeys []string, enablePodman bool, stack string, gpus []string, osType pb.OSType, monitoring bool, pool, backendID string, git client.GitSourceOpts, ttlSeconds int64, idleStopMinutes int32, deleteAfterStoppedSeconds int64, storageClass string) (*incus.ContainerInfo, error) {
+func createRemote(username, image, cpu, memory, disk string, sshKeys []string, enablePodman bool, stack string, gpus []string, osType pb.OSType, monitoring bool, pool, backendID string, git client.GitSourceOpts, ttlSeconds int64, idleStopMinutes int32, deleteAfterStoppedSeconds int64, storageClass, template string) (*incus.ContainerInfo, error) {
 	grpcClient, err := client.NewGRPCClient(serverAddr, certsDir, insecure)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = grpcClient.Close() }()
 
-	return grpcClient.CreateContainer(username, image, cpu, memory, disk, sshKeys, enablePodman, stack, gpus, osType, monitoring, pool, backendID, git, ttlSeconds, idleStopMinutes, deleteAfterStoppedSeconds, storageClass)
+	return grpcClient.CreateContainer(username, image, cpu, memory, disk, sshKeys, enablePodman, stack, gpus, osType, monitoring, pool, backendID, git, ttlSeconds, idleStopMinutes, deleteAfterStoppedSeconds, storageClass, template)
 }
 
 // createRemoteHTTP creates a container using remote HTTP API
-func createRemoteHTTP(username, image, cpu, memory, disk string, sshKeys []string, enablePodman bool, stack string, gpus []string, osType pb.OSType, monitoring bool, pool, backendID string, git client.GitSourceOpts, ttlSeconds int64, idleStopMinutes int32, deleteAfterStoppedSeconds int64, storageClass string) (*incus.ContainerInfo, error) {
+func createRemoteHTTP(username, image, cpu, memory, disk string, sshKeys []string, enablePodman bool, stack string, gpus []string, osType pb.OSType, monitoring bool, pool, backendID string, git client.GitSourceOpts, ttlSeconds int64, idleStopMinutes int32, deleteAfterStoppedSeconds int64, storageClass, template string) (*incus.ContainerInfo, error) {
 	httpClient, err := client.NewHTTPClient(serverAddr, authToken)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = httpClient.Close() }()
 
-	return httpClient.CreateContainer(username, image, cpu, memory, disk, sshKeys, enablePodman, stack, gpus, osType, monitoring, pool, backendID, git, ttlSeconds, idleStopMinutes, deleteAfterStoppedSeconds, storageClass)
+	return httpClient.CreateContainer(username, image, cpu, memory, disk, sshKeys, enablePodman, stack, gpus, osType, monitoring, pool, backendID, git, ttlSeconds, idleStopMinutes, deleteAfterStoppedSeconds, storageClass, template)
 }
@@ -8,11 +8,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log"
+	"maps"
 	"net"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/exec"
 	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -24,10 +27,12 @@ import (
 	"github.com/footprintai/containarium/internal/config"
 	"github.com/footprintai/containarium/internal/mtls"
 	"github.com/footprintai/containarium/internal/server"
+	"github.com/footprintai/containarium/internal/traffic"
 	"github.com/footprintai/containarium/pkg/core/container"
 	"github.com/footprintai/containarium/pkg/core/incus"
 	"github.com/footprintai/containarium/pkg/core/network"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -48,6 +53,9 @@ var (
 	baseDomain           string
 	caddyAdminURL        string
 	caddyCertDir         string
+	caddyBackends        []string
+	caddyBalance         string
+	passthroughGCEvery   time.Duration
 	alertWebhookURL      string
 	alertWebhookSecret   string
 	sentinelURL          string
@@ -59,10 +67,12 @@ var (
 	cpuOvercommitFactor  float64
 	cpuOvercommitEnforce bool
 	placementCPUAware    bool
+	idempotencyKeyTTL    time.Duration
 	publicHostname       string
 	publicAliases        []string
 	publicBaseDomains    []string
 	publicPort           int
+	reservedPorts        []int
 
 	proxyProtocol        bool
 	proxyProtocolTrusted []string
@@ -70,6 +80,40 @@ var (
 	otelDropLabels []string
 
 	daemonRuntime string
+
+	trafficReplay           bool
+	trafficReplayWindow     time.Duration
+	trafficReplaySpeed      float64
+	trafficReplayContainer  string
+	trafficStoreBackend     string
+	trafficMemoryCapacity   int
+	trafficMigrate          string
+	trafficSampleRate       int
+	trafficSampleMinBytes   int64
+	trafficSampleKeepPorts  []uint
+	trafficSampleKeepNets   []string
+	trafficSampleOverrides  map[string]int
+	trafficRecordStates     bool
+	trafficDNSLog           string
+	trafficConntrackNetNS   string
+	trafficSSHLog           string
+	trafficGeoIPDBs         []string
+	trafficAllowlist        []string
+	trafficAllowlistFile    string
+	trafficAllowlistBlock   bool
+	jobSchedules            = jobSchedulesValue{}
+	trafficServicesFile     string
+	containerTemplatesFile  string
+	trafficListenerInterval time.Duration
+	trafficUDPIdleTimeout   time.Duration
+	trafficSnapshotDebounce time.Duration
+	trafficHistoryWindow    time.Duration
+	trafficHistoryTimeout   time.Duration
+	trafficHistoryMaxRows   int64
+	trafficRetentionDays    int
+	trafficCompactDays      int
+
+	daemonConfigFile string
 )
 
 var daemonCmd = &cobra.Command{
@@ -112,6 +156,8 @@ Examples:
 func init() {
 	rootCmd.AddCommand(daemonCmd)
 
+	daemonCmd.Flags().StringVar(&daemonConfigFile, "config", "", "YAML file of daemon settings keyed by flag name (e.g. traffic-retention-days: 30); flags on the command line override it, and it overrides settings saved in PostgreSQL. SIGHUP or AdminService.ReloadConfig re-reads it and applies the traffic, reserved-ports and alert-webhook-url settings without a restart")
+
 	// gRPC settings
 	daemonCmd.Flags().StringVar(&daemonAddress, "address", "0.0.0.0", "Address to listen on")
 	daemonCmd.Flags().IntVar(&daemonPort, "port", 50051, "gRPC port to listen on")
@@ -133,6 +179,7 @@ func init() {
 	daemonCmd.Flags().StringVar(&networkSubnet, "network-subnet", "10.100.0.1/24", "IPv4 subnet for container network (CIDR format, e.g., 10.100.0.1/24)")
 	daemonCmd.Flags().BoolVar(&skipInfraInit, "skip-infra-init", false, "Skip automatic infrastructure initialization (storage, network, profile)")
 	daemonCmd.Flags().BoolVar(&standaloneMode, "standalone", false, "Standalone mode: skip core containers (PostgreSQL, Caddy) and start immediately")
+	daemonCmd.Flags().StringVar(&containerTemplatesFile, "container-templates", "", "YAML file of container templates (presets for create --template); they are read-only through the API, which can add more")
 
 	// App hosting settings
 	daemonCmd.Flags().BoolVar(&enableAppHosting, "app-hosting", false, "Enable app hosting feature (requires PostgreSQL)")
@@ -140,6 +187,9 @@ func init() {
 	daemonCmd.Flags().StringVar(&baseDomain, "base-domain", "example.org", "Base domain for app subdomains (e.g., example.org)")
 	daemonCmd.Flags().StringVar(&caddyAdminURL, "caddy-admin-url", "", "Caddy admin API URL for reverse proxy configuration (leave empty for auto-setup with --app-hosting)")
 	daemonCmd.Flags().StringVar(&caddyCertDir, "caddy-cert-dir", "/var/lib/caddy/.local/share/caddy", "Caddy certificate directory (for sentinel cert sync via /certs endpoint)")
+	daemonCmd.Flags().StringSliceVar(&caddyBackends, "caddy-backends", nil, "Additional Caddy IPs (e.g. a second HA instance) that host ports 80/443 are forwarded to alongside the core Caddy container; unreachable ones are taken out of rotation until they recover")
+	daemonCmd.Flags().StringVar(&caddyBalance, "caddy-balance", string(network.BalanceRoundRobin), "How host ports 80/443 are spread over the Caddy backends: round-robin, or failover (first healthy backend, core Caddy first)")
+	daemonCmd.Flags().DurationVar(&passthroughGCEvery, "passthrough-gc-interval", 0, "How often to remove passthrough iptables rules whose container no longer exists, e.g. 10m (default off; see `containarium passthrough gc`)")
 
 	// Alerting settings
 	daemonCmd.Flags().StringVar(&alertWebhookURL, "alert-webhook-url", "", "Webhook URL for alert notifications (optional)")
@@ -157,14 +207,52 @@ func init() {
 	daemonCmd.Flags().BoolVar(&proxyProtocol, "proxy-protocol", false, "Configure Caddy to accept PROXY v2 headers from --proxy-protocol-trusted CIDRs so containers receive the real client IP. Pair with --proxy-protocol on the sentinel.")
 	daemonCmd.Flags().StringSliceVar(&proxyProtocolTrusted, "proxy-protocol-trusted", []string{"127.0.0.0/8"}, "CIDRs allowed to send PROXY headers (typically the sentinel VPC IP/32). Wildcard 0.0.0.0/0 is rejected.")
 	daemonCmd.Flags().IntVar(&publicPort, "public-port", 443, "Public TLS port the sentinel forwards to (default 443)")
+	daemonCmd.Flags().IntSliceVar(&reservedPorts, "reserved-ports", nil, "Extra host ports passthrough routes may not use (comma-separated), on top of the always-reserved gRPC/REST ports, SSH 22 and --public-port")
 
 	// OTel collector settings
 	daemonCmd.Flags().StringSliceVar(&otelDropLabels, "otel-drop-labels", nil, "Extra attribute keys (comma-separated) the app-side OTel collector drops on top of the built-in PII/cardinality defaults (request_id, trace_id, user_email, session_id, correlation_id).")
 
+	// Traffic replay (test/staging only)
+	daemonCmd.Flags().BoolVar(&trafficReplay, "traffic-replay", false, "TEST/STAGING ONLY: re-emit stored traffic history as live traffic events on startup, for exercising dashboards and alert rules. Subscribers cannot tell replayed events from live ones except by their replay- connection IDs; never enable in production.")
+	daemonCmd.Flags().DurationVar(&trafficReplayWindow, "traffic-replay-window", time.Hour, "With --traffic-replay: how far back to replay history from")
+	daemonCmd.Flags().Float64Var(&trafficReplaySpeed, "traffic-replay-speed", 1, "With --traffic-replay: pacing multiplier (1 = real time, 60 = an hour per minute, 0 = no pacing)")
+	daemonCmd.Flags().StringVar(&trafficReplayContainer, "traffic-replay-container", "", "With --traffic-replay: replay only this container (default: all)")
+
+	// Traffic history backend
+	daemonCmd.Flags().StringVar(&trafficStoreBackend, "traffic-store", "postgres", `Traffic history backend: "postgres" (the app-hosting database, when enabled), "memory" (in-process ring buffer, lost on restart; for development without PostgreSQL) or "none" (live monitoring only: no history, nothing written)`)
+	daemonCmd.Flags().IntVar(&trafficMemoryCapacity, "traffic-store-capacity", traffic.DefaultMemoryStoreCapacity, "With --traffic-store memory: connections kept before the oldest are evicted")
+	daemonCmd.Flags().StringVar(&trafficMigrate, "migrate", string(traffic.MigrateAuto), `Traffic history schema migrations at startup: "auto" (apply pending ones), "check" (refuse to start while any are pending; apply them with containarium db migrate) or "off" (don't look)`)
+
+	// Traffic sampling (very high-traffic hosts)
+	daemonCmd.Flags().IntVar(&trafficSampleRate, "traffic-sample-rate", 1, "Persist only 1 in N short flows to traffic history, weighting each kept row by N so aggregates and billing stay accurate (1 = keep every flow)")
+	daemonCmd.Flags().Int64Var(&trafficSampleMinBytes, "traffic-sample-min-bytes", 1<<20, "With --traffic-sample-rate: always keep flows that moved at least this many bytes (0 = no size exemption)")
+	daemonCmd.Flags().UintSliceVar(&trafficSampleKeepPorts, "traffic-sample-keep-ports", nil, "With --traffic-sample-rate: always keep flows to these destination ports, e.g. the ports alert rules watch (comma-separated)")
+	daemonCmd.Flags().StringSliceVar(&trafficSampleKeepNets, "traffic-sample-keep-cidrs", nil, "With --traffic-sample-rate: always keep flows whose peer is in these CIDRs (comma-separated)")
+	daemonCmd.Flags().StringToIntVar(&trafficSampleOverrides, "traffic-sample-container", nil, "Per-container sampling rate overriding --traffic-sample-rate, as name=N (1 exempts the container; repeatable)")
+	daemonCmd.Flags().BoolVar(&trafficRecordStates, "traffic-record-states", false, "Record every connection state change (e.g. SYN_SENT → ESTABLISHED → TIME_WAIT) for GetConnectionTimeline; one row per transition, so off by default")
+	daemonCmd.Flags().DurationVar(&trafficSnapshotDebounce, "traffic-snapshot-debounce", time.Second, "Reuse a conntrack snapshot this recent for live connection queries instead of dumping the table on every request (0 = always dump)")
+	daemonCmd.Flags().DurationVar(&trafficListenerInterval, "traffic-listener-interval", 0, "How often to list each running container's listening ports (ss, or /proc/net without it) for GetListeningPorts and new-listener events, e.g. 1m (0 = off; the scan execs into every running container)")
+	daemonCmd.Flags().DurationVar(&trafficUDPIdleTimeout, "traffic-udp-idle-timeout", 30*time.Second, "Record a UDP or ICMP flow as closed once it has carried no packet for this long, rather than when conntrack expires it minutes later (0 = wait for conntrack)")
+	daemonCmd.Flags().DurationVar(&trafficHistoryWindow, "traffic-history-max-window", traffic.DefaultHistoryLimits().MaxUnfilteredWindow, "Refuse traffic history queries over a longer range unless they filter by dest IP, dest port or state; admin exports (StreamTrafficHistory) are exempt (0 = no limit)")
+	daemonCmd.Flags().DurationVar(&trafficHistoryTimeout, "traffic-history-timeout", traffic.DefaultHistoryLimits().StatementTimeout, "PostgreSQL statement_timeout for each traffic history query; admin exports are exempt (0 = none)")
+	daemonCmd.Flags().Int64Var(&trafficHistoryMaxRows, "traffic-history-max-rows", traffic.DefaultHistoryLimits().MaxEstimatedRows, "Refuse unfiltered traffic history queries whose range holds more connections than this, per the daily rollup; admin exports are exempt (0 = no limit)")
+	daemonCmd.Flags().IntVar(&trafficRetentionDays, "traffic-retention-days", 7, "Delete traffic history older than this many days")
+	daemonCmd.Flags().IntVar(&trafficCompactDays, "traffic-compact-after-days", 0, "Roll connections older than this many days up into hourly aggregates and delete the raw rows; must be below --traffic-retention-days (0 = off; PostgreSQL store only; runs as the traffic-compaction job)")
+	daemonCmd.Flags().StringVar(&trafficConntrackNetNS, "traffic-conntrack-netns", "", "Watch the conntrack table of this network namespace (e.g. /run/netns/containers or /proc/<pid>/ns/net) instead of the host's, where containers' flows are tracked in their own netns (empty = host)")
+	daemonCmd.Flags().StringVar(&trafficDNSLog, "traffic-dns-log", "", "Follow this dnsmasq query log (log-queries=extra on the Incus bridge) to record DNS queries and name connection destinations for boxes opted in with 'containarium traffic dns-logging' (empty = off)")
+	daemonCmd.Flags().StringSliceVar(&trafficGeoIPDBs, "traffic-geoip-db", nil, "Annotate closed connections with the remote country and autonomous system from this MaxMind DB file (repeatable; e.g. GeoLite2-Country.mmdb and GeoLite2-ASN.mmdb). Re-read when replaced; a missing or stale file is reported in /v1/traffic/status and never blocks persistence")
+	daemonCmd.Flags().StringSliceVar(&trafficAllowlist, "traffic-egress-allowlist", nil, "Addresses or CIDRs containers may reach outside the container network (repeatable); egress anywhere else is flagged as a policy violation in traffic history and sent as a POLICY_VIOLATION traffic event (empty = no check)")
+	daemonCmd.Flags().StringVar(&trafficAllowlistFile, "traffic-egress-allowlist-file", "", "Read more --traffic-egress-allowlist entries from this file, one address or CIDR per line (# comments)")
+	daemonCmd.Flags().BoolVar(&trafficAllowlistBlock, "traffic-egress-allowlist-block", false, "Also add a deny rule for each destination outside the egress allowlist to the tenant's network policy; drops only happen with the network-policy enforcer armed")
+	daemonCmd.Flags().Var(jobSchedules, "job-schedule", "Schedule of a maintenance job as name=schedule, overriding its default (repeatable): a cron expression (\"0 3 * * *\"), @hourly, @daily, @weekly, \"@every 30m\" or off. Jobs: traffic-cleanup (default 0 3 * * *), traffic-rollup (@every 1h), traffic-compaction (0 4 * * *), snapshot-schedule (@every 5m), orphan-gc (off); see `containarium jobs list`")
+	daemonCmd.Flags().StringVar(&trafficSSHLog, "traffic-ssh-log", "", "Follow this sshd auth log (e.g. /var/log/auth.log) to record SSH sessions to containers and tie port-22 connections to the user and key that logged in (empty = off)")
+	daemonCmd.Flags().StringVar(&trafficServicesFile, "traffic-services-file", "", "Extra port → service names for labelling connections, in /etc/services format (e.g. \"grafana 3000/tcp\"); entries override the built-in map")
+
 	// Runtime selection
 	daemonCmd.Flags().StringVar(&daemonRuntime, "runtime", "", `Box backend: "lxc" (default) or "k8s". Falls back to CONTAINARIUM_RUNTIME env when unset.`)
 	daemonCmd.Flags().Float64Var(&cpuOvercommitFactor, "cpu-overcommit-factor", envFloat("CONTAINARIUM_CPU_OVERCOMMIT_FACTOR", 0), "Max CPU overcommit: refuse a create when committed cores would exceed logical-CPUs (vCPUs, incl. SMT threads) × this factor. 0 (default) disables the check. Env: CONTAINARIUM_CPU_OVERCOMMIT_FACTOR (#1029).")
 	daemonCmd.Flags().BoolVar(&cpuOvercommitEnforce, "cpu-overcommit-enforce", envBool("CONTAINARIUM_CPU_OVERCOMMIT_ENFORCE", false), "With --cpu-overcommit-factor > 0, actually reject over-ceiling creates. When false (default), the check is advisory (logs what it would reject). Env: CONTAINARIUM_CPU_OVERCOMMIT_ENFORCE (#1029).")
+	daemonCmd.Flags().DurationVar(&idempotencyKeyTTL, "idempotency-key-ttl", envDuration("CONTAINARIUM_IDEMPOTENCY_KEY_TTL", server.DefaultIdempotencyKeyTTL), "How long a create's Idempotency-Key is remembered; a retry with the same key inside this window replays the original result instead of provisioning again. Env: CONTAINARIUM_IDEMPOTENCY_KEY_TTL.")
 	daemonCmd.Flags().BoolVar(&placementCPUAware, "placement-cpu-aware", envBool("CONTAINARIUM_PLACEMENT_CPU_AWARE", false), "When a pool create has no explicit backend, place it on the least CPU-committed healthy peer instead of an arbitrary one. Off by default (first-healthy). Env: CONTAINARIUM_PLACEMENT_CPU_AWARE (#1029).")
 }
 
@@ -178,6 +266,17 @@ func envFloat(name string, def float64) float64 {
 	return def
 }
 
+// envDuration reads name as a time.Duration, returning def when unset or
+// unparseable.
+func envDuration(name string, def time.Duration) time.Duration {
+	if v := os.Getenv(name); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return def
+}
+
 // envBool reads name as a bool (strconv.ParseBool), returning def when unset or
 // unparseable.
 func envBool(name string, def bool) bool {
@@ -195,6 +294,21 @@ func runDaemon(cmd *cobra.Command, args []string) error {
 	// under this process's (the unit's) real caps. Non-fatal.
 	logStartupSelfCheck()
 
+	var daemonCfg *daemonConfig
+	if daemonConfigFile != "" {
+		log.Printf("Loading config file %s", daemonConfigFile)
+		dc, err := loadDaemonConfig(daemonConfigFile, cmd.Flags())
+		if err != nil {
+			return err
+		}
+		daemonCfg = dc
+	}
+
+	balance, err := network.ParseBalanceMode(caddyBalance)
+	if err != nil {
+		return fmt.Errorf("invalid --caddy-balance: %w", err)
+	}
+
 	// Resolve runtime: --runtime flag takes precedence, then CONTAINARIUM_RUNTIME
 	// env, then the default "lxc".
 	runtime := daemonRuntime
@@ -293,12 +407,14 @@ func runDaemon(cmd *cobra.Command, args []string) error {
 
 			// Set up port forwarding from host to Caddy for Let's Encrypt and HTTPS
 			if network.CheckIPTablesAvailable() {
-				portForwarder := network.NewPortForwarder(caddyInfo.IPAddress)
+				backends := append([]string{caddyInfo.IPAddress}, caddyBackends...)
+				portForwarder := network.NewPortForwarderWithBackends(backends, "", balance)
 				if err := portForwarder.SetupPortForwarding(); err != nil {
 					log.Printf("Warning: Failed to setup port forwarding: %v", err)
 					log.Printf("  External HTTPS for app domains may not work")
 					log.Printf("  You may need to manually configure iptables - see docs/CADDY-SETUP.md")
 				}
+				go portForwarder.WatchBackends(context.Background(), network.DefaultBackendCheckInterval)
 			} else {
 				log.Printf("Warning: iptables not available, skipping port forwarding setup")
 				log.Printf("  External HTTPS for app domains may not work without manual configuration")
@@ -536,6 +652,8 @@ func runDaemon(cmd *cobra.Command, args []string) error {
 		HostIP:               hostIPFromCIDR(networkSubnet),
 		DaemonConfigStore:    daemonConfigStore,
 		CaddyCertDir:         caddyCertDir,
+		CaddyBackends:        caddyBackends,
+		CaddyBalance:         balance,
 		VictoriaMetricsURL:   victoriaMetricsURL,
 		Standalone:           standaloneMode,
 		AlertWebhookURL:      alertWebhookURL,
@@ -549,15 +667,90 @@ func runDaemon(cmd *cobra.Command, args []string) error {
 		CPUOvercommitFactor:  cpuOvercommitFactor,
 		CPUOvercommitEnforce: cpuOvercommitEnforce,
 		PlacementCPUAware:    placementCPUAware,
+		IdempotencyKeyTTL:    idempotencyKeyTTL,
 		PublicHostname:       publicHostname,
 		PublicAliases:        publicAliases,
 		PublicBaseDomains:    resolvePublicBaseDomains(publicBaseDomains, baseDomain),
 		PublicPort:           publicPort,
+		ReservedPorts:        reservedPorts,
 		ProxyProtocol:        proxyProtocol,
 		ProxyProtocolTrusted: proxyProtocolTrusted,
 		OTelDropLabels:       otelDropLabels,
 		Runtime:              runtime,
 	}
+	config.PassthroughGCInterval = passthroughGCEvery
+	switch trafficStoreBackend {
+	case "postgres", "memory", "none":
+		config.TrafficStore = trafficStoreBackend
+		config.TrafficMemoryCapacity = trafficMemoryCapacity
+	default:
+		return fmt.Errorf("invalid --traffic-store %q: must be postgres, memory or none", trafficStoreBackend)
+	}
+	migrateMode, err := traffic.ParseMigrateMode(trafficMigrate)
+	if err != nil {
+		return err
+	}
+	config.TrafficMigrate = migrateMode
+	sampling, err := trafficSamplingConfig(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	if trafficRetentionDays < 1 {
+		return fmt.Errorf("invalid --traffic-retention-days %d: must be at least 1", trafficRetentionDays)
+	}
+	config.TrafficRetentionDays = trafficRetentionDays
+	if trafficCompactDays < 0 || (trafficCompactDays > 0 && trafficCompactDays >= trafficRetentionDays) {
+		return fmt.Errorf("invalid --traffic-compact-after-days %d: must be 0 (off) or below --traffic-retention-days (%d)", trafficCompactDays, trafficRetentionDays)
+	}
+	config.TrafficCompactAfterDays = trafficCompactDays
+	config.TrafficSampling = sampling
+	config.TrafficRecordStates = trafficRecordStates
+	config.TrafficDNSLog = trafficDNSLog
+	config.TrafficConntrackNetNS = trafficConntrackNetNS
+	config.TrafficSSHLog = trafficSSHLog
+	config.TrafficGeoIPDatabases = trafficGeoIPDBs
+	allowlist := slices.Clone(trafficAllowlist)
+	for _, entry := range allowlist {
+		if _, err := traffic.ParseDestination(entry); err != nil {
+			return fmt.Errorf("invalid --traffic-egress-allowlist: %w", err)
+		}
+	}
+	if trafficAllowlistFile != "" {
+		entries, err := traffic.LoadEgressAllowlist(trafficAllowlistFile)
+		if err != nil {
+			return fmt.Errorf("invalid --traffic-egress-allowlist-file: %w", err)
+		}
+		allowlist = append(allowlist, entries...)
+	}
+	config.TrafficEgressAllowlist = allowlist
+	config.TrafficEgressAllowlistBlock = trafficAllowlistBlock
+	if err := server.ValidateJobSchedules(jobSchedules); err != nil {
+		return fmt.Errorf("invalid --job-schedule: %w", err)
+	}
+	config.JobSchedules = maps.Clone(jobSchedules)
+	config.TrafficServicesFile = trafficServicesFile
+	config.ContainerTemplatesFile = containerTemplatesFile
+	config.TrafficListenerInterval = trafficListenerInterval
+	config.TrafficUDPIdleTimeout = trafficUDPIdleTimeout
+	config.TrafficSnapshotDebounce = trafficSnapshotDebounce
+	config.TrafficHistoryLimits = traffic.HistoryLimits{
+		MaxUnfilteredWindow: trafficHistoryWindow,
+		StatementTimeout:    trafficHistoryTimeout,
+		MaxEstimatedRows:    trafficHistoryMaxRows,
+	}
+	if trafficReplay {
+		now := time.Now()
+		config.TrafficReplay = &traffic.ReplayConfig{
+			Params: traffic.QueryParams{
+				StartTime: now.Add(-trafficReplayWindow),
+				EndTime:   now,
+			},
+			Speed: trafficReplaySpeed,
+		}
+		if trafficReplayContainer != "" {
+			config.TrafficReplay.Params.ContainerNames = []string{trafficReplayContainer}
+		}
+	}
 
 	// Create dual server
 	dualServer, err := server.NewDualServer(config)
@@ -578,6 +771,34 @@ func runDaemon(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
+	// SIGHUP re-reads --config; AdminService.ReloadConfig does the same.
+	if daemonCfg != nil {
+		daemonCfg.started(cmd.Flags())
+		reload := func(ctx context.Context) (server.ConfigReload, error) {
+			return daemonCfg.reload(ctx, dualServer)
+		}
+		dualServer.SetConfigReloader(reload)
+
+		hupChan := make(chan os.Signal, 1)
+		signal.Notify(hupChan, syscall.SIGHUP)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-hupChan:
+					log.Printf("Received SIGHUP, reloading %s", daemonConfigFile)
+					result, err := reload(ctx)
+					if err != nil {
+						log.Printf("Warning: config reload failed: %v", err)
+						continue
+					}
+					log.Printf("Config reload: %d change(s) applied, %d rejected", len(result.Applied), len(result.Rejected))
+				}
+			}
+		}()
+	}
+
 	// Start servers
 	log.Printf("Containarium daemon starting...")
 	log.Printf("  gRPC: %s:%d", daemonAddress, daemonPort)
@@ -890,3 +1111,67 @@ func saveRecoveryConfigToPersistentStorage(networkCIDR, baseDomain, caddyAdminUR
 
 	return SaveRecoveryConfig(config, DefaultRecoveryConfigPath)
 }
+
+// jobSchedulesValue is --job-schedule: name=schedule pairs. Unlike a
+// StringToString flag it doesn't split a cron list such as "0,30 * * * *"
+// at its comma: a comma only starts a new pair when what follows it has
+// an "=", which no schedule does. The config file joins a map's pairs
+// with commas, so this is what lets it hold lists too.
+type jobSchedulesValue map[string]string
+
+func (v jobSchedulesValue) String() string {
+	pairs := make([]string, 0, len(v))
+	for _, name := range slices.Sorted(maps.Keys(v)) {
+		pairs = append(pairs, name+"="+v[name])
+	}
+	return "[" + strings.Join(pairs, ",") + "]"
+}
+
+func (v jobSchedulesValue) Set(s string) error {
+	var pairs []string
+	for _, part := range strings.Split(s, ",") {
+		if strings.Contains(part, "=") || len(pairs) == 0 {
+			pairs = append(pairs, part)
+		} else {
+			pairs[len(pairs)-1] += "," + part
+		}
+	}
+	for _, pair := range pairs {
+		name, schedule, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%q must be formatted as name=schedule", pair)
+		}
+		v[strings.TrimSpace(name)] = strings.TrimSpace(schedule)
+	}
+	return nil
+}
+
+func (jobSchedulesValue) Type() string { return "name=schedule" }
+
+// trafficSamplingConfig builds the collector's sampling settings from the
+// --traffic-sample-* flags in flags.
+func trafficSamplingConfig(flags *pflag.FlagSet) (traffic.SamplingConfig, error) {
+	var cfg traffic.SamplingConfig
+	cfg.Rate, _ = flags.GetInt("traffic-sample-rate")
+	cfg.MinBytes, _ = flags.GetInt64("traffic-sample-min-bytes")
+	cfg.ContainerRates, _ = flags.GetStringToInt("traffic-sample-container")
+	keepPorts, _ := flags.GetUintSlice("traffic-sample-keep-ports")
+	keepNets, _ := flags.GetStringSlice("traffic-sample-keep-cidrs")
+	for _, port := range keepPorts {
+		if port == 0 || port > 65535 {
+			return cfg, fmt.Errorf("invalid --traffic-sample-keep-ports entry %d: must be 1-65535", port)
+		}
+		cfg.KeepPorts = append(cfg.KeepPorts, uint32(port))
+	}
+	for _, cidr := range keepNets {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return cfg, fmt.Errorf("invalid --traffic-sample-keep-cidrs entry %q: %w", cidr, err)
+		}
+		cfg.KeepNets = append(cfg.KeepNets, prefix.Masked())
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid traffic sampling: %w", err)
+	}
+	return cfg, nil
+}
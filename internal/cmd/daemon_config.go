@@ -0,0 +1,284 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/footprintai/containarium/internal/server"
+)
+
+// reloadableDaemonFlags are the daemon flags a config reload (SIGHUP or
+// AdminService.ReloadConfig) applies to the running daemon. Any other flag
+// in the config file only takes effect at startup. Alert rules and the
+// failed-auth rate limit aren't flags: the rules are stored and synced
+// by AlertService as they change, and the limit is fixed in code.
+var reloadableDaemonFlags = map[string]bool{
+	"traffic-retention-days":     true,
+	"traffic-snapshot-debounce":  true,
+	"traffic-listener-interval":  true,
+	"traffic-udp-idle-timeout":   true,
+	"traffic-sample-rate":        true,
+	"traffic-sample-min-bytes":   true,
+	"traffic-sample-keep-ports":  true,
+	"traffic-sample-keep-cidrs":  true,
+	"traffic-sample-container":   true,
+	"traffic-history-max-window": true,
+	"traffic-history-timeout":    true,
+	"traffic-history-max-rows":   true,
+	"reserved-ports":             true,
+	"alert-webhook-url":          true,
+}
+
+// daemonConfig is the daemon's --config file and the flag values it
+// produced. The file maps flag names to values; flags given on the command
+// line win over it.
+type daemonConfig struct {
+	path string
+
+	mu sync.Mutex
+	// commandLine holds the flags set on the command line.
+	commandLine map[string]bool
+	// running is the flags the daemon runs with.
+	running *pflag.FlagSet
+}
+
+// loadDaemonConfig reads the config file at path into flags, skipping the
+// flags set on the command line.
+func loadDaemonConfig(path string, flags *pflag.FlagSet) (*daemonConfig, error) {
+	values, err := readDaemonConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dc := &daemonConfig{path: path, commandLine: map[string]bool{}}
+	flags.Visit(func(f *pflag.Flag) { dc.commandLine[f.Name] = true })
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if err := checkConfigKey(cloneFlags(flags), name); err != nil {
+			return nil, err
+		}
+		if dc.commandLine[name] {
+			log.Printf("  %s: the command line overrides %s", name, path)
+			continue
+		}
+		if err := flags.Set(name, values[name]); err != nil {
+			return nil, fmt.Errorf("invalid %s in %s: %w", name, path, err)
+		}
+	}
+	return dc, nil
+}
+
+// started records flags as the values the daemon started with, once
+// startup has settled them (settings saved in PostgreSQL fill in flags
+// neither the command line nor the file set).
+func (dc *daemonConfig) started(flags *pflag.FlagSet) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.running = cloneFlags(flags)
+}
+
+// configTarget is what a reload applies settings to: the running
+// *server.DualServer.
+type configTarget interface {
+	Reload(ctx context.Context, cfg server.ReloadableConfig) server.ConfigReload
+}
+
+// reload re-reads the config file and applies the settings that changed
+// to ds. A changed setting that only takes effect at startup, or that the
+// command line set, is reported as rejected. A setting removed from the
+// file keeps its running value. An unreadable file or an invalid value
+// fails the whole reload, leaving everything as it was.
+func (dc *daemonConfig) reload(ctx context.Context, ds configTarget) (server.ConfigReload, error) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	values, err := readDaemonConfigFile(dc.path)
+	if err != nil {
+		return server.ConfigReload{}, err
+	}
+	wanted := cloneFlags(dc.running)
+	var rejected []string
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if err := checkConfigKey(wanted, name); err != nil {
+			return server.ConfigReload{}, err
+		}
+		target := wanted
+		if dc.commandLine[name] {
+			target = pflag.NewFlagSet("", pflag.ContinueOnError)
+			cloneFlag(target, dc.running, dc.running.Lookup(name))
+		}
+		if err := target.Set(name, values[name]); err != nil {
+			return server.ConfigReload{}, fmt.Errorf("invalid %s in %s: %w", name, dc.path, err)
+		}
+		if target != wanted && flagString(target, name) != flagString(dc.running, name) {
+			rejected = append(rejected, fmt.Sprintf("--%s: set on the command line, which overrides %s", name, dc.path))
+		}
+	}
+
+	next := pflag.NewFlagSet("", pflag.ContinueOnError)
+	dc.running.VisitAll(func(f *pflag.Flag) {
+		from := dc.running
+		if flagString(wanted, f.Name) != flagString(dc.running, f.Name) {
+			if reloadableDaemonFlags[f.Name] {
+				from = wanted
+			} else {
+				rejected = append(rejected, fmt.Sprintf("--%s can only be changed at startup; restart the daemon to apply it", f.Name))
+			}
+		}
+		cloneFlag(next, from, from.Lookup(f.Name))
+	})
+
+	cfg, err := reloadableConfig(next)
+	if err != nil {
+		return server.ConfigReload{}, err
+	}
+	for _, line := range rejected {
+		log.Printf("Warning: config reload: %s", line)
+	}
+	result := ds.Reload(ctx, cfg)
+	result.ConfigFile = dc.path
+	result.Rejected = append(rejected, result.Rejected...)
+	dc.running = next
+	return result, nil
+}
+
+// reloadableConfig builds the runtime-changeable settings from flags.
+func reloadableConfig(flags *pflag.FlagSet) (server.ReloadableConfig, error) {
+	sampling, err := trafficSamplingConfig(flags)
+	if err != nil {
+		return server.ReloadableConfig{}, err
+	}
+	cfg := server.ReloadableConfig{TrafficSampling: sampling}
+	cfg.TrafficRetentionDays, _ = flags.GetInt("traffic-retention-days")
+	if cfg.TrafficRetentionDays < 1 {
+		return server.ReloadableConfig{}, fmt.Errorf("invalid --traffic-retention-days %d: must be at least 1", cfg.TrafficRetentionDays)
+	}
+	cfg.TrafficSnapshotDebounce, _ = flags.GetDuration("traffic-snapshot-debounce")
+	cfg.TrafficListenerInterval, _ = flags.GetDuration("traffic-listener-interval")
+	cfg.TrafficUDPIdleTimeout, _ = flags.GetDuration("traffic-udp-idle-timeout")
+	cfg.TrafficHistoryLimits.MaxUnfilteredWindow, _ = flags.GetDuration("traffic-history-max-window")
+	cfg.TrafficHistoryLimits.StatementTimeout, _ = flags.GetDuration("traffic-history-timeout")
+	cfg.TrafficHistoryLimits.MaxEstimatedRows, _ = flags.GetInt64("traffic-history-max-rows")
+	cfg.ReservedPorts, _ = flags.GetIntSlice("reserved-ports")
+	cfg.AlertWebhookURL, _ = flags.GetString("alert-webhook-url")
+	return cfg, nil
+}
+
+// readDaemonConfigFile reads a YAML file of flag name → value. Lists
+// become comma-separated values and maps name=value pairs, the forms the
+// flags take on the command line.
+func readDaemonConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	values := make(map[string]string, len(raw))
+	for name, v := range raw {
+		switch v := v.(type) {
+		case nil:
+			values[name] = ""
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[name] = strings.Join(items, ",")
+		case map[string]any:
+			pairs := make([]string, 0, len(v))
+			for _, k := range slices.Sorted(maps.Keys(v)) {
+				pairs = append(pairs, fmt.Sprintf("%s=%v", k, v[k]))
+			}
+			values[name] = strings.Join(pairs, ",")
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+	return values, nil
+}
+
+// checkConfigKey rejects config file keys that name no daemon flag.
+func checkConfigKey(flags *pflag.FlagSet, name string) error {
+	if name == "config" {
+		return fmt.Errorf("config file cannot set --config")
+	}
+	if flags.Lookup(name) == nil {
+		return fmt.Errorf("unknown setting %q in config file: keys are daemon flag names without the leading --", name)
+	}
+	return nil
+}
+
+// cloneFlags copies flags, with their current values as the copies'
+// defaults, so setting a value on the copy leaves the original alone.
+func cloneFlags(flags *pflag.FlagSet) *pflag.FlagSet {
+	clone := pflag.NewFlagSet("", pflag.ContinueOnError)
+	flags.VisitAll(func(f *pflag.Flag) { cloneFlag(clone, flags, f) })
+	return clone
+}
+
+// cloneFlag adds to dst a flag named f.Name holding its current value in
+// src. Flags of a type the daemon doesn't use are left out, so a config
+// file can't set them.
+func cloneFlag(dst, src *pflag.FlagSet, f *pflag.Flag) {
+	name := f.Name
+	switch f.Value.Type() {
+	case "string":
+		v, _ := src.GetString(name)
+		dst.String(name, v, "")
+	case "bool":
+		v, _ := src.GetBool(name)
+		dst.Bool(name, v, "")
+	case "int":
+		v, _ := src.GetInt(name)
+		dst.Int(name, v, "")
+	case "int64":
+		v, _ := src.GetInt64(name)
+		dst.Int64(name, v, "")
+	case "float64":
+		v, _ := src.GetFloat64(name)
+		dst.Float64(name, v, "")
+	case "duration":
+		v, _ := src.GetDuration(name)
+		dst.Duration(name, v, "")
+	case "stringSlice":
+		v, _ := src.GetStringSlice(name)
+		dst.StringSlice(name, slices.Clone(v), "")
+	case "intSlice":
+		v, _ := src.GetIntSlice(name)
+		dst.IntSlice(name, slices.Clone(v), "")
+	case "uintSlice":
+		v, _ := src.GetUintSlice(name)
+		dst.UintSlice(name, slices.Clone(v), "")
+	case "stringToInt":
+		v, _ := src.GetStringToInt(name)
+		dst.StringToInt(name, maps.Clone(v), "")
+	case jobSchedulesValue{}.Type():
+		dst.Var(maps.Clone(f.Value.(jobSchedulesValue)), name, "")
+	}
+}
+
+// flagString formats a flag's value for comparison, "" for a flag flags
+// lacks. Maps print sorted, unlike stringToInt's own String.
+func flagString(flags *pflag.FlagSet, name string) string {
+	f := flags.Lookup(name)
+	if f == nil {
+		return ""
+	}
+	if f.Value.Type() == "stringToInt" {
+		v, _ := flags.GetStringToInt(name)
+		return fmt.Sprint(v)
+	}
+	return f.Value.String()
+}
@@ -0,0 +1,184 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/footprintai/containarium/internal/server"
+)
+
+// recordingTarget stands in for the DualServer, keeping the last config
+// it was asked to apply.
+type recordingTarget struct {
+	got   server.ReloadableConfig
+	calls int
+}
+
+func (r *recordingTarget) Reload(_ context.Context, cfg server.ReloadableConfig) server.ConfigReload {
+	r.got = cfg
+	r.calls++
+	return server.ConfigReload{}
+}
+
+// testDaemonFlags returns a copy of the daemon's flags, which no test
+// parses, with args parsed as the command line.
+func testDaemonFlags(t *testing.T, args ...string) *pflag.FlagSet {
+	t.Helper()
+	flags := cloneFlags(daemonCmd.Flags())
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("parse %v: %v", args, err)
+	}
+	return flags
+}
+
+func writeDaemonConfig(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadDaemonConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.yaml")
+	writeDaemonConfig(t, path, `
+traffic-retention-days: 30
+traffic-snapshot-debounce: 2s
+reserved-ports: [8443, 9000]
+traffic-sample-container:
+  bob-container: 10
+  alice-container: 1
+alert-webhook-url:
+`)
+	values, err := readDaemonConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"traffic-retention-days":    "30",
+		"traffic-snapshot-debounce": "2s",
+		"reserved-ports":            "8443,9000",
+		"traffic-sample-container":  "alice-container=1,bob-container=10",
+		"alert-webhook-url":         "",
+	}
+	for name, v := range want {
+		if values[name] != v {
+			t.Errorf("%s = %q, want %q", name, values[name], v)
+		}
+	}
+}
+
+func TestLoadDaemonConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.yaml")
+	writeDaemonConfig(t, path, "traffic-retention-days: 30\nport: 50052\nreserved-ports: [8443]\n")
+
+	flags := testDaemonFlags(t, "--port=50051", "--config="+path)
+	if _, err := loadDaemonConfig(path, flags); err != nil {
+		t.Fatal(err)
+	}
+	if days, _ := flags.GetInt("traffic-retention-days"); days != 30 {
+		t.Errorf("traffic-retention-days = %d, want 30 from the file", days)
+	}
+	if port, _ := flags.GetInt("port"); port != 50051 {
+		t.Errorf("port = %d, want the command line's 50051", port)
+	}
+	if ports, _ := flags.GetIntSlice("reserved-ports"); !slices.Equal(ports, []int{8443}) {
+		t.Errorf("reserved-ports = %v, want [8443]", ports)
+	}
+
+	for name, body := range map[string]string{
+		"unknown key":   "traffic-retention: 30\n",
+		"invalid value": "traffic-retention-days: lots\n",
+		"config itself": "config: /etc/other.yaml\n",
+	} {
+		writeDaemonConfig(t, path, body)
+		if _, err := loadDaemonConfig(path, testDaemonFlags(t)); err == nil {
+			t.Errorf("%s: loadDaemonConfig succeeded, want an error", name)
+		}
+	}
+}
+
+func TestDaemonConfigReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.yaml")
+	writeDaemonConfig(t, path, "traffic-retention-days: 30\ntraffic-udp-idle-timeout: 10s\n")
+
+	flags := testDaemonFlags(t, "--traffic-snapshot-debounce=5s", "--config="+path)
+	dc, err := loadDaemonConfig(path, flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc.started(flags)
+
+	// Retention changes, the idle timeout is dropped from the file, and
+	// the rest asks for what the daemon can't do at runtime.
+	writeDaemonConfig(t, path, `
+traffic-retention-days: 14
+traffic-snapshot-debounce: 1s
+postgres: postgres://alice@db.example.com/containarium
+reserved-ports: [8443]
+`)
+	target := &recordingTarget{}
+	result, err := dc.reload(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ConfigFile != path {
+		t.Errorf("ConfigFile = %q, want %q", result.ConfigFile, path)
+	}
+	got := target.got
+	if got.TrafficRetentionDays != 14 {
+		t.Errorf("retention = %d, want 14", got.TrafficRetentionDays)
+	}
+	if got.TrafficUDPIdleTimeout != 10*time.Second {
+		t.Errorf("UDP idle timeout = %v, want the running 10s once dropped from the file", got.TrafficUDPIdleTimeout)
+	}
+	if got.TrafficSnapshotDebounce != 5*time.Second {
+		t.Errorf("snapshot debounce = %v, want the command line's 5s", got.TrafficSnapshotDebounce)
+	}
+	if !slices.Equal(got.ReservedPorts, []int{8443}) {
+		t.Errorf("reserved ports = %v, want [8443]", got.ReservedPorts)
+	}
+	if len(result.Rejected) != 2 ||
+		!strings.Contains(result.Rejected[0], "--traffic-snapshot-debounce: set on the command line") ||
+		!strings.Contains(result.Rejected[1], "--postgres can only be changed at startup") {
+		t.Errorf("Rejected = %q, want the command-line debounce and --postgres", result.Rejected)
+	}
+
+	// A bad value fails the reload without touching the daemon.
+	writeDaemonConfig(t, path, "traffic-retention-days: 0\n")
+	if _, err := dc.reload(context.Background(), target); err == nil {
+		t.Error("reload with retention 0 succeeded, want an error")
+	}
+	writeDaemonConfig(t, path, "traffic-sample-keep-cidrs: [not-a-cidr]\n")
+	if _, err := dc.reload(context.Background(), target); err == nil {
+		t.Error("reload with a bad CIDR succeeded, want an error")
+	}
+	if target.calls != 1 {
+		t.Errorf("Reload called %d times, want once", target.calls)
+	}
+}
+
+func TestJobSchedulesValue_KeepsCronLists(t *testing.T) {
+	v := jobSchedulesValue{}
+	if err := v.Set("traffic-rollup=0,30 * * * *,orphan-gc=@weekly"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Set("traffic-cleanup= 0 3 * * * "); err != nil {
+		t.Fatal(err)
+	}
+	want := "[orphan-gc=@weekly,traffic-cleanup=0 3 * * *,traffic-rollup=0,30 * * * *]"
+	if got := v.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if err := v.Set("0 3 * * *"); err == nil {
+		t.Error("a schedule without a job name should fail")
+	}
+}
@@ -0,0 +1,202 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/footprintai/containarium/internal/traffic"
+	"github.com/spf13/cobra"
+)
+
+// Operator control over the traffic history schema. The daemon applies
+// pending migrations at startup by default (--migrate=auto); on large
+// tables operators run them here instead, in a maintenance window, and
+// start the daemon with --migrate=check so it never migrates on its own.
+//
+// Direct Postgres access, like `containarium audit`: the store is opened
+// with migrations off and driven through the same code the daemon uses.
+
+var (
+	dbMigrateTo       int
+	dbMigrateDryRun   bool
+	dbBaselineVersion int
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Inspect and migrate the traffic history schema",
+	Long: `Inspect and migrate the PostgreSQL schema that holds traffic history.
+
+Direct Postgres access — needs CONTAINARIUM_POSTGRES_URL /
+_URL_FILE / _PASSWORD_FILE configured the same way the daemon
+reads it.`,
+}
+
+var dbStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schema version and pending migrations",
+	Long: `Show which traffic schema migrations the database has applied and
+which are pending, with the estimated row count of each table a pending
+migration touches (from the planner statistics), to judge how long it
+will lock them.`,
+	Args: cobra.NoArgs,
+	RunE: runDBStatus,
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply or revert migrations up to a version",
+	Long: `Bring the traffic schema to --to (default: the latest version this
+binary knows), applying pending migrations in order or reverting newer
+ones newest first. Each migration runs in its own transaction.
+
+--dry-run prints the SQL that would run and changes nothing.`,
+	Example: `  # See what the next migration would do
+  containarium db migrate --dry-run
+
+  # Apply everything pending
+  containarium db migrate
+
+  # Revert back to version 5
+  containarium db migrate --to 5`,
+	Args: cobra.NoArgs,
+	RunE: runDBMigrate,
+}
+
+var dbBaselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Mark an existing schema as migrated without running anything",
+	Long: `Record migrations 1 through --version as applied without running
+them. For databases created before migrations were versioned, whose
+tables already match: afterwards only newer migrations run.`,
+	Args: cobra.NoArgs,
+	RunE: runDBBaseline,
+}
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbStatusCmd, dbMigrateCmd, dbBaselineCmd)
+
+	dbMigrateCmd.Flags().IntVar(&dbMigrateTo, "to", traffic.LatestSchemaVersion(), "Schema version to migrate to")
+	dbMigrateCmd.Flags().BoolVar(&dbMigrateDryRun, "dry-run", false, "Print the SQL that would run without executing it")
+	dbBaselineCmd.Flags().IntVar(&dbBaselineVersion, "version", 1, "Highest migration the existing schema already matches")
+}
+
+// openTrafficStore opens the traffic store without touching its schema.
+func openTrafficStore(ctx context.Context) (*traffic.Store, error) {
+	dsn := getPostgresConnString()
+	if dsn == "" {
+		return nil, fmt.Errorf("no Postgres DSN configured")
+	}
+	store, err := traffic.NewStore(ctx, dsn, traffic.WithMigrateMode(traffic.MigrateOff))
+	if err != nil {
+		return nil, fmt.Errorf("open traffic store: %w", err)
+	}
+	return store, nil
+}
+
+func runDBStatus(cmd *cobra.Command, args []string) error {
+	store, err := openTrafficStore(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	status, err := store.SchemaStatus(cmd.Context())
+	if err != nil {
+		return err
+	}
+	printSchemaStatus(cmd.OutOrStdout(), status)
+	return nil
+}
+
+func printSchemaStatus(out io.Writer, status *traffic.SchemaStatus) {
+	fmt.Fprintf(out, "Schema version: %d (latest %d)\n", status.Version, status.Latest)
+	if status.Unbaselined {
+		fmt.Fprintln(out, "\nThe traffic tables predate versioned migrations. If they are up to date, run:")
+		fmt.Fprintln(out, "  containarium db baseline")
+	}
+	if len(status.Applied) > 0 {
+		fmt.Fprintln(out, "\nApplied:")
+		tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+		for _, m := range status.Applied {
+			fmt.Fprintf(tw, "  %d\t%s\t%s\n", m.Version, m.Name, m.AppliedAt.UTC().Format("2006-01-02 15:04:05Z"))
+		}
+		_ = tw.Flush()
+	}
+	if len(status.Pending) == 0 {
+		fmt.Fprintln(out, "\nNo pending migrations.")
+		return
+	}
+	fmt.Fprintln(out, "\nPending:")
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	for _, m := range status.Pending {
+		tables := make([]string, 0, len(m.Tables))
+		for _, t := range m.Tables {
+			tables = append(tables, t+" ("+estimatedRows(status.TableRows, t)+")")
+		}
+		fmt.Fprintf(tw, "  %d\t%s\t%s\n", m.Version, m.Name, strings.Join(tables, ", "))
+	}
+	_ = tw.Flush()
+}
+
+// estimatedRows renders a table's row estimate for db status.
+func estimatedRows(rows map[string]int64, table string) string {
+	n, ok := rows[table]
+	switch {
+	case !ok:
+		return "new"
+	case n < 0:
+		return "size unknown"
+	default:
+		return fmt.Sprintf("~%d rows", n)
+	}
+}
+
+func runDBMigrate(cmd *cobra.Command, args []string) error {
+	store, err := openTrafficStore(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	out := cmd.OutOrStdout()
+	steps, err := store.MigrateTo(cmd.Context(), dbMigrateTo, dbMigrateDryRun)
+	for _, step := range steps {
+		verb := "apply"
+		if step.Down {
+			verb = "revert"
+		}
+		if dbMigrateDryRun {
+			fmt.Fprintf(out, "-- %s %d (%s)\n%s\n\n", verb, step.Version, step.Name, strings.TrimSpace(step.SQL))
+			continue
+		}
+		fmt.Fprintf(out, "✓ %s %d (%s)\n", verb, step.Version, step.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		fmt.Fprintf(out, "Schema already at version %d.\n", dbMigrateTo)
+	}
+	return nil
+}
+
+func runDBBaseline(cmd *cobra.Command, args []string) error {
+	store, err := openTrafficStore(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := store.Baseline(cmd.Context(), dbBaselineVersion); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ recorded migrations 1-%d as applied\n", dbBaselineVersion)
+	return nil
+}
@@ -5,11 +5,13 @@ import (
 
 	"github.com/footprintai/containarium/internal/client"
 	"github.com/footprintai/containarium/pkg/core/container"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 	"github.com/spf13/cobra"
 )
 
 var (
-	forceDelete bool
+	forceDelete  bool
+	dryRunDelete bool
 )
 
 var deleteCmd = &cobra.Command{
@@ -20,12 +22,24 @@ var deleteCmd = &cobra.Command{
 By default, the container must be stopped before deletion.
 Use --force to delete a running container.
 
+In remote mode the daemon also removes what depends on the container — its
+routes, passthrough routes, collaborators and host account — before and
+after deleting it. If one of those can't be removed the delete stops and the
+container is kept; with --force it carries on and reports what was left
+behind (see 'containarium gc').
+
+--dry-run runs the same checks and lists what the delete would remove,
+without removing anything.
+
 Examples:
   # Delete a stopped container
   containarium delete alice
 
   # Force delete a running container
-  containarium delete bob --force`,
+  containarium delete bob --force
+
+  # Show what deleting carol's container would remove
+  containarium delete carol --dry-run`,
 	Args:    cobra.ExactArgs(1),
 	Aliases: []string{"rm", "remove"},
 	RunE:    runDelete,
@@ -35,6 +49,7 @@ func init() {
 	rootCmd.AddCommand(deleteCmd)
 
 	deleteCmd.Flags().BoolVarP(&forceDelete, "force", "f", false, "Force delete even if container is running")
+	deleteCmd.Flags().BoolVar(&dryRunDelete, "dry-run", false, "List what would be removed without deleting anything")
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
@@ -50,14 +65,17 @@ func runDelete(cmd *cobra.Command, args []string) error {
 
 	// Delete container - use remote or local mode
 	var err error
+	var resp *pb.DeleteContainerResponse
 	if httpMode && serverAddr != "" {
 		// Remote mode via HTTP
-		err = deleteRemoteHTTP(username, forceDelete)
+		resp, err = deleteRemoteHTTP(username, forceDelete, dryRunDelete)
 	} else if serverAddr != "" {
 		// Remote mode via gRPC
-		err = deleteRemote(username, forceDelete)
-	} else {
+		resp, err = deleteRemote(username, forceDelete, dryRunDelete)
+	} else if dryRunDelete {
 		// Local mode via Incus
+		resp, err = planDeleteLocal(username)
+	} else {
 		err = deleteLocal(username, forceDelete)
 	}
 
@@ -65,7 +83,32 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to delete container: %w", err)
 	}
 
+	if dryRunDelete {
+		fmt.Printf("dry-run: deleting container %s would remove:\n", containerName)
+		printTeardownItems(resp.Removed, "-")
+		if len(resp.LeftBehind) > 0 {
+			fmt.Printf("Warning: %d registry(ies) could not be listed; what they hold would be left behind:\n", len(resp.LeftBehind))
+			for _, it := range resp.LeftBehind {
+				fmt.Printf("  - %s: %s\n", it.Resource, it.Error)
+			}
+		}
+		fmt.Println("Re-run without --dry-run to delete.")
+		return nil
+	}
+
 	fmt.Printf("✓ Container %s deleted successfully\n", containerName)
+	if resp != nil {
+		if verbose {
+			printTeardownItems(resp.Removed, "removed")
+		}
+		if len(resp.LeftBehind) > 0 {
+			fmt.Printf("Warning: %d dependent resource(s) were left behind:\n", len(resp.LeftBehind))
+			for _, it := range resp.LeftBehind {
+				fmt.Printf("  - %s %s: %s\n", it.Resource, it.Name, it.Error)
+			}
+			fmt.Println("Run 'containarium gc' once the cause is fixed to remove them.")
+		}
+	}
 
 	// Delete jump server account (only in local mode)
 	// This removes the proxy-only user account from the jump server
@@ -87,6 +130,36 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printTeardownItems lists teardown items one per line, each prefixed with
+// mark ("removed" for a real run, "-" for a plan).
+func printTeardownItems(items []*pb.TeardownItem, mark string) {
+	for _, it := range items {
+		fmt.Printf("  %s %s %s\n", mark, it.Resource, it.Name)
+	}
+}
+
+// planDeleteLocal checks the container exists and lists what a local
+// delete removes: the container and its jump server account. Local mode
+// has no route or collaborator registries to enumerate.
+func planDeleteLocal(username string) (*pb.DeleteContainerResponse, error) {
+	mgr, err := container.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Incus: %w (is Incus running?)", err)
+	}
+	if _, err := mgr.Get(username); err != nil {
+		return nil, err
+	}
+	containerName := username + "-container"
+	return &pb.DeleteContainerResponse{
+		ContainerName: containerName,
+		Removed: []*pb.TeardownItem{
+			{Resource: "container", Name: containerName, ContainerName: containerName},
+			{Resource: "host-account", Name: username, ContainerName: containerName},
+		},
+		DryRun: true,
+	}, nil
+}
+
 // deleteLocal deletes a container using local Incus daemon
 func deleteLocal(username string, force bool) error {
 	mgr, err := container.New()
@@ -98,23 +171,23 @@ func deleteLocal(username string, force bool) error {
 }
 
 // deleteRemote deletes a container using remote gRPC server
-func deleteRemote(username string, force bool) error {
+func deleteRemote(username string, force, dryRun bool) (*pb.DeleteContainerResponse, error) {
 	grpcClient, err := client.NewGRPCClient(serverAddr, certsDir, insecure)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = grpcClient.Close() }()
 
-	return grpcClient.DeleteContainer(username, force)
+	return grpcClient.DeleteContainerReport(username, force, dryRun)
 }
 
 // deleteRemoteHTTP deletes a container using remote HTTP API
-func deleteRemoteHTTP(username string, force bool) error {
+func deleteRemoteHTTP(username string, force, dryRun bool) (*pb.DeleteContainerResponse, error) {
 	httpClient, err := client.NewHTTPClient(serverAddr, authToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = httpClient.Close() }()
 
-	return httpClient.DeleteContainer(username, force)
+	return httpClient.DeleteContainerReport(username, force, dryRun)
 }
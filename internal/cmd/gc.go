@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/footprintai/containarium/internal/client"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"github.com/spf13/cobra"
+)
+
+var gcDryRun bool
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove resources left behind by deleted containers (admin)",
+	Long: `Find routes, passthrough routes, collaborators and host accounts whose
+container no longer exists, and remove them.
+
+Deletes clean these up as part of their teardown; gc catches what older
+daemons left behind and what a forced delete reported it couldn't remove.
+The daemon cross-references every registry against the containers on itself
+and its peers, and refuses to collect while a peer is unreachable.
+
+Examples:
+  # List orphaned resources without removing anything
+  containarium gc --dry-run --server <host:port>
+
+  # Remove them
+  containarium gc --server <host:port>`,
+	Args: cobra.NoArgs,
+	RunE: runGC,
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+	gcCmd.Flags().BoolVar(&gcDryRun, "dry-run", false, "List orphaned resources without removing them")
+}
+
+func runGC(_ *cobra.Command, _ []string) error {
+	if serverAddr == "" {
+		return fmt.Errorf("gc requires --server (remote mode); the daemon owns the registries it cross-references")
+	}
+
+	var resp *pb.GarbageCollectResponse
+	if httpMode {
+		httpClient, err := client.NewHTTPClient(serverAddr, authToken)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = httpClient.Close() }()
+		resp, err = httpClient.GarbageCollect(gcDryRun)
+		if err != nil {
+			return err
+		}
+	} else {
+		grpcClient, err := client.NewGRPCClient(serverAddr, certsDir, insecure)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = grpcClient.Close() }()
+		resp, err = grpcClient.GarbageCollect(gcDryRun)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(resp.Orphans) == 0 {
+		fmt.Println("No orphaned resources found.")
+		return nil
+	}
+
+	var failed int
+	for _, o := range resp.Orphans {
+		switch {
+		case resp.DryRun:
+			fmt.Printf("  - %-13s %-32s (container %s)\n", o.Resource, o.Name, o.ContainerName)
+		case o.Error != "":
+			fmt.Printf("  ✗ %-13s %-32s %s\n", o.Resource, o.Name, o.Error)
+			failed++
+		default:
+			fmt.Printf("  ✓ %-13s %-32s removed\n", o.Resource, o.Name)
+		}
+	}
+	if resp.DryRun {
+		fmt.Printf("\ndry-run: %d orphaned resource(s). Re-run without --dry-run to remove them.\n", len(resp.Orphans))
+		return nil
+	}
+	fmt.Printf("\nRemoved %d/%d orphaned resource(s).\n", len(resp.Orphans)-failed, len(resp.Orphans))
+	if failed > 0 {
+		return fmt.Errorf("%d orphaned resource(s) could not be removed", failed)
+	}
+	return nil
+}
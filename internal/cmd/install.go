@@ -0,0 +1,174 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/footprintai/containarium/internal/install"
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+var (
+	installCheckOnly   bool
+	installOnly        []string
+	installSkip        []string
+	installNetworkName string
+	installNetworkCIDR string
+	installBaseDomain  string
+)
+
+var installCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Provision this machine as a Containarium host",
+	Long: `Bring a fresh machine up as a Containarium host, one ordered step at a time:
+
+  preflight  root and a reachable Incus daemon
+  storage    Incus storage pool "default"
+  bridge     Incus bridge for containers (--network-name, --network-cidr)
+  profile    default profile root disk and NIC
+  sysctls    IP forwarding and conntrack accounting, persisted in /etc/sysctl.d
+  postgres   core PostgreSQL container (skipped with CONTAINARIUM_POSTGRES_URL)
+  schema     database schema, plus --base-domain saved for the daemon
+  caddy      core Caddy container (needs --base-domain)
+  firewall   ports 80/443 forwarded to Caddy
+  sshpiper   SSHPiper binary, keys and unit (enabled, not started)
+  daemon     containarium systemd unit and JWT secret, enabled and started
+
+Every step checks the host first and only changes what is missing, so
+running install again resumes after a failure and is a no-op on a finished
+host. --check reports each step without changing anything and exits
+non-zero if any step is pending.
+
+Examples:
+  # Full install
+  sudo containarium install --base-domain containarium.example.com
+
+  # What would change?
+  sudo containarium install --check
+
+  # Host without a public domain: no Caddy or port forwarding
+  sudo containarium install --skip caddy,firewall
+
+  # Re-run one step
+  sudo containarium install --only firewall`,
+	Args: cobra.NoArgs,
+	RunE: runInstall,
+}
+
+func init() {
+	rootCmd.AddCommand(installCmd)
+
+	def := incus.DefaultNetworkConfig()
+	installCmd.Flags().BoolVar(&installCheckOnly, "check", false, "Report each step's state without changing anything")
+	installCmd.Flags().StringSliceVar(&installOnly, "only", nil, "Run only these steps (comma-separated)")
+	installCmd.Flags().StringSliceVar(&installSkip, "skip", nil, "Skip these steps (comma-separated)")
+	installCmd.Flags().StringVar(&installNetworkName, "network-name", def.Name, "Incus bridge containers attach to")
+	installCmd.Flags().StringVar(&installNetworkCIDR, "network-cidr", def.IPv4Address, "Gateway address and prefix for a new bridge")
+	installCmd.Flags().StringVar(&installBaseDomain, "base-domain", "", "Domain Caddy serves apps under (required for the caddy step)")
+}
+
+func runInstall(cmd *cobra.Command, args []string) error {
+	tasks, err := install.Select(append(install.HostTasks(), daemonUnitTask()), installOnly, installSkip)
+	if err != nil {
+		return err
+	}
+	if !installCheckOnly && os.Geteuid() != 0 {
+		return fmt.Errorf("this command requires root privileges (use sudo, or --check to only inspect)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	env := install.NewEnv(install.Options{
+		NetworkName: installNetworkName,
+		NetworkCIDR: installNetworkCIDR,
+		BaseDomain:  installBaseDomain,
+	})
+	out := cmd.OutOrStdout()
+	err = install.Run(ctx, env, tasks, out, installCheckOnly)
+
+	var stepErr *install.StepError
+	switch {
+	case err == nil && installCheckOnly:
+		fmt.Fprintln(out, "\nAll steps are in place.")
+	case err == nil:
+		fmt.Fprintln(out, "\nContainarium is installed.")
+		fmt.Fprintln(out, "  Status: sudo systemctl status containarium")
+		fmt.Fprintln(out, "  Logs:   sudo journalctl -u containarium -f")
+	case errors.Is(err, install.ErrPending):
+		fmt.Fprintln(out, "\nRun without --check to apply the pending steps.")
+	case errors.As(err, &stepErr):
+		fmt.Fprintf(out, "\nStep %q failed; steps before it are done and will be skipped on the next run.\n", stepErr.Step)
+		fmt.Fprintf(out, "Fix the cause above, then resume with:\n  sudo %s\n", installCommandLine(installOnly))
+		fmt.Fprintf(out, "or retry just that step with:\n  sudo %s\n", installCommandLine([]string{stepErr.Step}))
+	}
+	return err
+}
+
+// installCommandLine rebuilds the current invocation with only as --only,
+// for the resume hint.
+func installCommandLine(only []string) string {
+	parts := []string{"containarium", "install"}
+	if installNetworkName != incus.DefaultNetworkConfig().Name {
+		parts = append(parts, "--network-name", installNetworkName)
+	}
+	if installNetworkCIDR != incus.DefaultNetworkConfig().IPv4Address {
+		parts = append(parts, "--network-cidr", installNetworkCIDR)
+	}
+	if installBaseDomain != "" {
+		parts = append(parts, "--base-domain", installBaseDomain)
+	}
+	if len(installSkip) > 0 {
+		parts = append(parts, "--skip", strings.Join(installSkip, ","))
+	}
+	if len(only) > 0 {
+		parts = append(parts, "--only", strings.Join(only, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// daemonUnitTask installs and starts the daemon's systemd unit. It lives
+// here rather than in the install package because the unit template is
+// shared with `service install` and `pool join`.
+func daemonUnitTask() install.Task {
+	return install.Task{
+		Name:        "daemon",
+		Description: "containarium systemd unit and JWT secret",
+		Check: func(ctx context.Context, _ *install.Env) (bool, string, error) {
+			if raw, err := os.ReadFile(systemdServicePath); err != nil || string(raw) != systemdServiceTemplate {
+				return false, systemdServicePath + " missing or out of date", nil
+			}
+			if _, err := os.Stat("/etc/containarium/jwt.secret"); err != nil {
+				return false, "no JWT secret", nil
+			}
+			if exec.CommandContext(ctx, "systemctl", "is-enabled", "--quiet", "containarium").Run() != nil {
+				return false, "containarium is not enabled", nil
+			}
+			if exec.CommandContext(ctx, "systemctl", "is-active", "--quiet", "containarium").Run() != nil {
+				return false, "containarium is not running", nil
+			}
+			return true, "enabled and running", nil
+		},
+		Apply: func(ctx context.Context, _ *install.Env) error {
+			if err := ensureDaemonUnitAndSecret(); err != nil {
+				return err
+			}
+			for _, args := range [][]string{{"daemon-reload"}, {"enable", "containarium"}, {"restart", "containarium"}} {
+				if out, err := exec.CommandContext(ctx, "systemctl", args...).CombinedOutput(); err != nil {
+					return fmt.Errorf("systemctl %s failed: %w, output: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// `containarium jobs` shows and runs the daemon's scheduled maintenance
+// jobs (GET /v1/jobs, POST /v1/jobs/{name}/run, GET
+// /v1/jobs/{name}/history). It talks to the daemon like `traffic` and
+// shares its --server/--format handling.
+var jobsHistoryLimit int32
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Show and run the daemon's scheduled maintenance jobs (admin)",
+	Long: `The daemon runs its periodic maintenance as named jobs, each on a
+schedule set with the daemon's --job-schedule flag:
+
+  traffic-cleanup    delete traffic history past --traffic-retention-days
+  traffic-rollup     bill newly recorded traffic into the daily usage rollup
+  traffic-compaction fold connections past --traffic-compact-after-days into
+                     hourly aggregates and delete the raw rows
+  snapshot-schedule  snapshot conntrack and checkpoint long-lived connections
+  orphan-gc          remove resources left behind by deleted containers
+
+Every run is recorded with its start, end, result and rows affected.
+A job never runs twice at once: 'jobs run' fails while the job is
+running, and a scheduled run that comes due during a manual one is
+recorded as skipped.
+
+  containarium jobs list
+  containarium jobs run traffic-cleanup
+  containarium jobs history traffic-cleanup --limit 50`,
+}
+
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List jobs with their schedule and latest run",
+	Args:  cobra.NoArgs,
+	RunE:  runJobsList,
+}
+
+var jobsRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "Run a job now, outside its schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runJobsRun,
+}
+
+var jobsHistoryCmd = &cobra.Command{
+	Use:   "history <name>",
+	Short: "Show a job's recent runs, newest first",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runJobsHistory,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{jobsListCmd, jobsRunCmd, jobsHistoryCmd} {
+		c.Flags().StringVar(&trafficServerFlag, "server", "", "server to query (default: the logged-in server)")
+		c.Flags().StringVarP(&trafficFormat, "format", "f", "table", "output format: table, json")
+		jobsCmd.AddCommand(c)
+	}
+	jobsHistoryCmd.Flags().Int32Var(&jobsHistoryLimit, "limit", 0, "max runs to show (0 = server default, 20)")
+	rootCmd.AddCommand(jobsCmd)
+}
+
+type jobRun struct {
+	ID           flexInt64 `json:"id"`
+	Job          string    `json:"job"`
+	Trigger      string    `json:"trigger"`
+	StartedAt    string    `json:"startedAt"`
+	EndedAt      string    `json:"endedAt"`
+	Result       string    `json:"result"`
+	RowsAffected flexInt64 `json:"rowsAffected"`
+	Error        string    `json:"error"`
+}
+
+// took is how long the run took, "-" while it runs.
+func (r *jobRun) took() string {
+	start, err1 := time.Parse(time.RFC3339Nano, r.StartedAt)
+	end, err2 := time.Parse(time.RFC3339Nano, r.EndedAt)
+	if err1 != nil || err2 != nil {
+		return "-"
+	}
+	return end.Sub(start).Round(time.Millisecond).String()
+}
+
+type jobStatus struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Schedule    string  `json:"schedule"`
+	NextRun     string  `json:"nextRun"`
+	Running     bool    `json:"running"`
+	LastRun     *jobRun `json:"lastRun"`
+}
+
+type listJobsResp struct {
+	Jobs []jobStatus `json:"jobs"`
+}
+
+type runJobResp struct {
+	Run jobRun `json:"run"`
+}
+
+type jobHistoryResp struct {
+	Runs []jobRun `json:"runs"`
+}
+
+// jobTime renders an RFC 3339 timestamp in local time, "-" when unset.
+func jobTime(s string) string {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return cmp.Or(s, "-")
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func runJobsList(cmd *cobra.Command, _ []string) error {
+	var resp listJobsResp
+	if err := trafficGet(cmd.Context(), "/v1/jobs", nil, &resp); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	if len(resp.Jobs) == 0 {
+		fmt.Fprintln(out, "The daemon runs no jobs.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSCHEDULE\tNEXT RUN\tLAST RUN\tRESULT\tROWS")
+	for _, j := range resp.Jobs {
+		last, result, rows := "-", "-", "-"
+		if r := j.LastRun; r != nil {
+			last, result, rows = jobTime(r.StartedAt), shortEnum(r.Result), strconv.FormatInt(int64(r.RowsAffected), 10)
+		}
+		if j.Running {
+			result = "running"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", j.Name, j.Schedule, jobTime(j.NextRun), last, result, rows)
+	}
+	return tw.Flush()
+}
+
+func runJobsRun(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	var resp runJobResp
+	if err := trafficDo(cmd.Context(), http.MethodPost, "/v1/jobs/"+url.PathEscape(name)+"/run", nil, nil, &resp); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	fmt.Fprintf(out, "Started %s (run %d). See how it ends with: containarium jobs history %s\n", name, resp.Run.ID, name)
+	return nil
+}
+
+func runJobsHistory(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	q := url.Values{}
+	if jobsHistoryLimit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(jobsHistoryLimit), 10))
+	}
+	var resp jobHistoryResp
+	if err := trafficGet(cmd.Context(), "/v1/jobs/"+url.PathEscape(name)+"/history", q, &resp); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	if len(resp.Runs) == 0 {
+		fmt.Fprintf(out, "%s has not run yet.\n", name)
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTRIGGER\tSTARTED\tTOOK\tRESULT\tROWS\tERROR")
+	for _, r := range resp.Runs {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			r.ID, shortEnum(r.Trigger), jobTime(r.StartedAt), r.took(),
+			shortEnum(r.Result), r.RowsAffected, cmp.Or(r.Error, "-"))
+	}
+	return tw.Flush()
+}
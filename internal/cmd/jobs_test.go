@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/footprintai/containarium/internal/credentials"
+	"github.com/spf13/cobra"
+)
+
+func TestJobs_ListRunHistory(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotMethod, gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotQuery = r.Method, r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/jobs":
+			_, _ = w.Write([]byte(`{"jobs":[` +
+				`{"name":"orphan-gc","schedule":"off"},` +
+				`{"name":"traffic-cleanup","schedule":"0 3 * * *","nextRun":"2026-03-11T03:00:00Z",` +
+				`"lastRun":{"id":"7","result":"JOB_RESULT_SUCCEEDED","startedAt":"2026-03-10T03:00:00Z","rowsAffected":"1234"}}]}`))
+		case "/v1/jobs/traffic-cleanup/run":
+			_, _ = w.Write([]byte(`{"run":{"id":"8","job":"traffic-cleanup","trigger":"JOB_TRIGGER_MANUAL","result":"JOB_RESULT_RUNNING"}}`))
+		case "/v1/jobs/traffic-cleanup/history":
+			_, _ = w.Write([]byte(`{"runs":[` +
+				`{"id":"9","trigger":"JOB_TRIGGER_SCHEDULE","result":"JOB_RESULT_SKIPPED","startedAt":"2026-03-11T03:00:00Z",` +
+				`"endedAt":"2026-03-11T03:00:00Z","error":"the previous run was still in progress"},` +
+				`{"id":"8","trigger":"JOB_TRIGGER_MANUAL","result":"JOB_RESULT_FAILED","startedAt":"2026-03-11T02:59:00Z",` +
+				`"endedAt":"2026-03-11T03:01:30.250Z","rowsAffected":"10","error":"database unreachable"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-jobs"}})
+	trafficServerFlag, trafficFormat = "", "table"
+
+	run := func(fn func(*cobra.Command, []string) error, args ...string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&buf)
+		cmd.SetContext(context.Background())
+		if err := fn(cmd, args); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	out := run(runJobsList)
+	for _, want := range []string{"orphan-gc", "off", "0 3 * * *", "succeeded", "1234"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("list output missing %q; got:\n%s", want, out)
+		}
+	}
+
+	out = run(runJobsRun, "traffic-cleanup")
+	if gotMethod != http.MethodPost || gotPath != "/v1/jobs/traffic-cleanup/run" || !strings.Contains(out, "run 8") {
+		t.Errorf("run: %s %s, output %q", gotMethod, gotPath, out)
+	}
+
+	jobsHistoryLimit = 5
+	t.Cleanup(func() { jobsHistoryLimit = 0 })
+	out = run(runJobsHistory, "traffic-cleanup")
+	if gotQuery != "limit=5" {
+		t.Errorf("history query = %q", gotQuery)
+	}
+	for _, want := range []string{"skipped", "still in progress", "manual", "failed", "2m30.25s", "database unreachable"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("history output missing %q; got:\n%s", want, out)
+		}
+	}
+}
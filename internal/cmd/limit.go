@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/footprintai/containarium/internal/client"
+	"github.com/footprintai/containarium/internal/safecast"
+	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	limitIngress string
+	limitEgress  string
+	limitFor     time.Duration
+)
+
+var limitCmd = &cobra.Command{
+	Use:   "limit",
+	Short: "Manage per-container bandwidth limits (remote)",
+	Long: `Cap a container's network throughput.
+
+Limits are enforced with tc on the container's host-side veth and kept by
+the daemon, which re-applies them whenever the container starts. Ingress is
+traffic toward the container (downloads), egress traffic leaving it
+(uploads). Requires --server and an admin token.
+
+Rates take a unit: 50mbit, 50mbps, 1gbit. A bare number is Mbit/s.
+
+Examples:
+  # Cap alice's uploads at 50 Mbit/s
+  containarium limit set alice --egress 50mbit --server <host:port>
+
+  # Cap both directions for two hours
+  containarium limit set alice --ingress 200mbit --egress 20mbit --for 2h --server <host:port>
+
+  # Show every limit in force
+  containarium limit list --server <host:port>
+
+  # Lift alice's limit
+  containarium limit clear alice --server <host:port>`,
+}
+
+var limitSetCmd = &cobra.Command{
+	Use:   "set <container>",
+	Short: "Set a container's bandwidth limit",
+	Long: `Set a container's bandwidth limit, replacing any it already has.
+
+A direction left out is unlimited. A stopped container keeps the limit and
+gets it when it next starts.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLimitSet,
+}
+
+var limitListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List bandwidth limits in force",
+	Args:  cobra.NoArgs,
+	RunE:  runLimitList,
+}
+
+var limitClearCmd = &cobra.Command{
+	Use:   "clear <container>",
+	Short: "Remove a container's bandwidth limit",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runLimitClear,
+}
+
+func init() {
+	rootCmd.AddCommand(limitCmd)
+	limitCmd.AddCommand(limitSetCmd)
+	limitCmd.AddCommand(limitListCmd)
+	limitCmd.AddCommand(limitClearCmd)
+
+	limitSetCmd.Flags().StringVar(&limitIngress, "ingress", "", "Cap on traffic toward the container, e.g. 100mbit")
+	limitSetCmd.Flags().StringVar(&limitEgress, "egress", "", "Cap on traffic leaving the container, e.g. 50mbit")
+	limitSetCmd.Flags().DurationVar(&limitFor, "for", 0, "Lift the limit after this long, e.g. 2h (default: permanent)")
+}
+
+// limitAPI is the subset of client methods the limit verbs use. Both
+// *client.GRPCClient and *client.HTTPClient satisfy it.
+type limitAPI interface {
+	SetBandwidthLimit(req *pb.SetBandwidthLimitRequest) (*pb.BandwidthLimit, error)
+	ListBandwidthLimits() ([]*pb.BandwidthLimit, error)
+	ClearBandwidthLimit(containerName string) (string, error)
+	Close() error
+}
+
+// newLimitClient picks the transport the same way newPassthroughClient
+// does. Caller must Close() the result.
+func newLimitClient() (limitAPI, error) {
+	if serverAddr == "" {
+		return nil, fmt.Errorf("--server is required for limit commands")
+	}
+	if httpMode {
+		return client.NewHTTPClient(serverAddr, authToken)
+	}
+	return client.NewGRPCClient(serverAddr, certsDir, insecure)
+}
+
+// parseLimitFlag parses an optional --ingress/--egress value.
+func parseLimitFlag(name, value string) (int32, error) {
+	if value == "" {
+		return 0, nil
+	}
+	mbps, err := network.ParseMbps(value)
+	if err != nil {
+		return 0, fmt.Errorf("--%s: %w", name, err)
+	}
+	return safecast.I32(mbps), nil
+}
+
+func runLimitSet(cmd *cobra.Command, args []string) error {
+	ingress, err := parseLimitFlag("ingress", limitIngress)
+	if err != nil {
+		return err
+	}
+	egress, err := parseLimitFlag("egress", limitEgress)
+	if err != nil {
+		return err
+	}
+	if ingress == 0 && egress == 0 {
+		return fmt.Errorf("set --ingress, --egress, or both")
+	}
+	if limitFor < 0 {
+		return fmt.Errorf("--for must not be negative")
+	}
+
+	apiClient, err := newLimitClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = apiClient.Close() }()
+
+	limit, err := apiClient.SetBandwidthLimit(&pb.SetBandwidthLimitRequest{
+		ContainerName:   args[0],
+		IngressMbps:     ingress,
+		EgressMbps:      egress,
+		DurationSeconds: int64(limitFor / time.Second),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bandwidth limit set on %s: ingress %s, egress %s\n", limit.ContainerName,
+		network.FormatMbps(int(limit.IngressMbps)), network.FormatMbps(int(limit.EgressMbps)))
+	if limit.ExpiresAt != nil {
+		fmt.Printf("Expires: %s\n", limit.ExpiresAt.AsTime().Local().Format(time.RFC3339))
+	}
+	if !limit.Applied {
+		fmt.Println("Not applied yet: the daemon puts it on the container once it is running.")
+	}
+	return nil
+}
+
+func runLimitList(cmd *cobra.Command, args []string) error {
+	apiClient, err := newLimitClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = apiClient.Close() }()
+
+	limits, err := apiClient.ListBandwidthLimits()
+	if err != nil {
+		return err
+	}
+	if len(limits) == 0 {
+		fmt.Println("No bandwidth limits set")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTAINER\tINGRESS\tEGRESS\tREASON\tEXPIRES\tSTATUS")
+	fmt.Fprintln(w, "---------\t-------\t------\t------\t-------\t------")
+	for _, l := range limits {
+		expires := "never"
+		if l.ExpiresAt != nil {
+			expires = l.ExpiresAt.AsTime().Local().Format(time.RFC3339)
+		}
+		status := "Pending"
+		if l.Applied {
+			status = "Applied"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			l.ContainerName,
+			network.FormatMbps(int(l.IngressMbps)),
+			network.FormatMbps(int(l.EgressMbps)),
+			l.Reason,
+			expires,
+			status,
+		)
+	}
+	_ = w.Flush()
+
+	fmt.Printf("\nTotal: %d limit(s)\n", len(limits))
+	return nil
+}
+
+func runLimitClear(cmd *cobra.Command, args []string) error {
+	apiClient, err := newLimitClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = apiClient.Close() }()
+
+	msg, err := apiClient.ClearBandwidthLimit(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println(msg)
+	return nil
+}
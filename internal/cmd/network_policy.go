@@ -21,13 +21,18 @@ import (
 var networkPolicyCmd = &cobra.Command{
 	Use:     "network-policy",
 	Short:   "Manage per-tenant network isolation policies (admin)",
-	Aliases: []string{"netpolicy", "np"},
+	Aliases: []string{"netpolicy", "np", "policy"},
 	Long: `Manage per-tenant network-isolation policies (#315, Phase A).
 
-A network policy declares a tenant's allowed egress (CIDRs + domains) and
-whether same-tenant containers may talk to each other. Phase A ships in
+A network policy declares a tenant's allowed egress (CIDRs, domains and
+port/proto-scoped allow rules) and whether same-tenant containers may talk
+to each other. Phase A ships in
 log_only mode: denied flows are observed and audited, nothing is dropped.
 
+By default the egress CIDRs and domains are an allow-list. With
+--default allow every external destination is reachable and only the
+virtual-patch deny rules (network-policy patch) restrict the tenant.
+
 All subcommands are admin-only and talk to the daemon's HTTP API, so they
 require --server (the daemon's HTTP address) and an admin --token.`,
 }
@@ -42,6 +47,8 @@ var (
 	npEgressDomains    []string
 	npMode             string
 	npAllowMetadata    bool
+	npDefaultEgress    string
+	npAllowRules       []string
 )
 
 var networkPolicySetCmd = &cobra.Command{
@@ -52,10 +59,11 @@ var networkPolicySetCmd = &cobra.Command{
 }
 
 var networkPolicyGetCmd = &cobra.Command{
-	Use:   "get <tenant>",
-	Short: "Show a tenant's network policy",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runNetworkPolicyGet,
+	Use:     "get <tenant>",
+	Short:   "Show a tenant's network policy",
+	Aliases: []string{"show"},
+	Args:    cobra.ExactArgs(1),
+	RunE:    runNetworkPolicyGet,
 }
 
 var networkPolicyListCmd = &cobra.Command{
@@ -129,10 +137,14 @@ func init() {
 		"Allowed egress destination CIDR (repeatable, e.g. --egress-cidr 10.0.0.0/8)")
 	networkPolicySetCmd.Flags().StringSliceVar(&npEgressDomains, "egress-domain", nil,
 		"Allowed egress domain (repeatable, e.g. --egress-domain api.github.com)")
+	networkPolicySetCmd.Flags().StringSliceVar(&npAllowRules, "allow-rule", nil,
+		"Allowed egress scoped to a port/proto: <cidr>[:port[/tcp|udp]] (repeatable, e.g. --allow-rule 203.0.113.7:443/tcp)")
 	networkPolicySetCmd.Flags().StringVar(&npMode, "mode", "log_only",
 		"Enforcement mode: log_only | enforce")
 	networkPolicySetCmd.Flags().BoolVar(&npAllowMetadata, "allow-metadata", false,
 		"Allow reaching the cloud metadata service (169.254.169.254); default deny even if a CIDR would cover it")
+	networkPolicySetCmd.Flags().StringVar(&npDefaultEgress, "default", "deny",
+		"Egress no rule matches: deny (egress CIDRs/domains are an allow-list) | allow (only deny rules block)")
 	networkPolicySetCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output the stored policy as JSON")
 
 	networkPolicyGetCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output as JSON")
@@ -286,14 +298,23 @@ func runNetworkPolicySignatureList(cmd *cobra.Command, args []string) error {
 // grpc-gateway). Local so a server-side schema change surfaces as a decode
 // failure here, not a silent field-drop.
 type netPolicyJSON struct {
-	Tenant           string         `json:"tenant"`
-	AllowIntraTenant bool           `json:"allowIntraTenant"`
-	EgressCidrs      []string       `json:"egressCidrs"`
-	EgressDomains    []string       `json:"egressDomains"`
-	AllowMetadata    bool           `json:"allowMetadata"`
-	Mode             string         `json:"mode"`
-	Source           string         `json:"source"`
-	DenyRules        []denyRuleJSON `json:"denyRules,omitempty"`
+	Tenant           string          `json:"tenant"`
+	AllowIntraTenant bool            `json:"allowIntraTenant"`
+	EgressCidrs      []string        `json:"egressCidrs"`
+	EgressDomains    []string        `json:"egressDomains"`
+	AllowMetadata    bool            `json:"allowMetadata"`
+	Mode             string          `json:"mode"`
+	Source           string          `json:"source"`
+	DenyRules        []denyRuleJSON  `json:"denyRules,omitempty"`
+	DefaultEgress    string          `json:"defaultEgress,omitempty"`
+	AllowRules       []allowRuleJSON `json:"allowRules,omitempty"`
+}
+
+// allowRuleJSON mirrors NetworkPolicyAllowRule, grpc-gateway camelCase.
+type allowRuleJSON struct {
+	Cidr  string `json:"cidr"`
+	Port  uint32 `json:"port,omitempty"`
+	Proto string `json:"proto,omitempty"`
 }
 
 // denyRuleJSON mirrors NetworkPolicyDenyRule (#660), grpc-gateway camelCase.
@@ -328,6 +349,45 @@ func normalizeMode(m string) (string, error) {
 	}
 }
 
+// normalizeDefaultEgress maps --default to the proto enum name.
+func normalizeDefaultEgress(d string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(d)) {
+	case "", "deny":
+		return "NETWORK_POLICY_DEFAULT_ACTION_DENY", nil
+	case "allow":
+		return "NETWORK_POLICY_DEFAULT_ACTION_ALLOW", nil
+	default:
+		return "", fmt.Errorf("invalid --default %q (want deny or allow)", d)
+	}
+}
+
+// parseAllowRule parses an --allow-rule value, <cidr>[:port[/proto]], validating
+// it client-side (the server re-validates authoritatively).
+func parseAllowRule(v string) (allowRuleJSON, error) {
+	v = strings.TrimSpace(v)
+	cidr, scope, scoped := strings.Cut(v, ":")
+	if cidr == "" {
+		return allowRuleJSON{}, fmt.Errorf("--allow-rule %q: cidr is required", v)
+	}
+	r := allowRuleJSON{Cidr: cidr}
+	if !scoped {
+		return r, nil
+	}
+	port, proto, _ := strings.Cut(scope, "/")
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return allowRuleJSON{}, fmt.Errorf("--allow-rule %q: invalid port %q (want 0-65535)", v, port)
+	}
+	r.Port = uint32(n)
+	r.Proto = strings.ToLower(proto)
+	switch r.Proto {
+	case "", "tcp", "udp":
+	default:
+		return allowRuleJSON{}, fmt.Errorf("--allow-rule %q: proto must be tcp, udp, or empty (got %q)", v, proto)
+	}
+	return r, nil
+}
+
 func runNetworkPolicySet(cmd *cobra.Command, args []string) error {
 	if serverAddr == "" {
 		return errServerRequired()
@@ -336,6 +396,18 @@ func runNetworkPolicySet(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defaultEgress, err := normalizeDefaultEgress(npDefaultEgress)
+	if err != nil {
+		return err
+	}
+	allowRules := make([]allowRuleJSON, 0, len(npAllowRules))
+	for _, v := range npAllowRules {
+		r, err := parseAllowRule(v)
+		if err != nil {
+			return err
+		}
+		allowRules = append(allowRules, r)
+	}
 	// `set` declares the allow-policy only; virtual-patch deny rules (#660) are
 	// owned by `network-policy patch` and preserved server-side across a set, so
 	// no client round-trip is needed to keep them.
@@ -346,6 +418,8 @@ func runNetworkPolicySet(cmd *cobra.Command, args []string) error {
 		EgressDomains:    npEgressDomains,
 		AllowMetadata:    npAllowMetadata,
 		Mode:             mode,
+		DefaultEgress:    defaultEgress,
+		AllowRules:       allowRules,
 	}}
 	var out policyEnvelope
 	if err := doJSON("POST", strings.TrimSuffix(serverAddr, "/")+"/v1/network-policies", body, &out); err != nil {
@@ -405,11 +479,12 @@ func runNetworkPolicyDelete(cmd *cobra.Command, args []string) error {
 	if serverAddr == "" {
 		return errServerRequired()
 	}
-	url := strings.TrimSuffix(serverAddr, "/") + "/v1/network-policies/" + args[0]
+	tenant := args[0]
+	url := strings.TrimSuffix(serverAddr, "/") + "/v1/network-policies/" + tenant
 	if err := doJSON("DELETE", url, nil, nil); err != nil {
 		return err
 	}
-	fmt.Fprintf(cmd.OutOrStdout(), "✓ network policy deleted for %q\n", args[0])
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ network policy deleted for %q\n", tenant)
 	return nil
 }
 
@@ -615,10 +690,25 @@ func shortMode(m string) string {
 	return strings.TrimPrefix(m, "NETWORK_POLICY_MODE_")
 }
 
+// shortDefault renders default_egress; a policy stored before the field
+// existed reads as DENY, which is what it enforces.
+func shortDefault(d string) string {
+	if d == "" || d == "NETWORK_POLICY_DEFAULT_ACTION_UNSPECIFIED" {
+		return "DENY"
+	}
+	return strings.TrimPrefix(d, "NETWORK_POLICY_DEFAULT_ACTION_")
+}
+
 func egressSummary(p netPolicyJSON) string {
-	parts := make([]string, 0, len(p.EgressCidrs)+len(p.EgressDomains))
+	parts := make([]string, 0, len(p.EgressCidrs)+len(p.EgressDomains)+len(p.AllowRules))
 	parts = append(parts, p.EgressCidrs...)
 	parts = append(parts, p.EgressDomains...)
+	for _, r := range p.AllowRules {
+		parts = append(parts, allowRuleSummary(r))
+	}
+	if shortDefault(p.DefaultEgress) == "ALLOW" {
+		return "(all)"
+	}
 	if len(parts) == 0 {
 		return "(none)"
 	}
@@ -628,6 +718,7 @@ func egressSummary(p netPolicyJSON) string {
 func printPolicy(w io.Writer, p netPolicyJSON) {
 	fmt.Fprintf(w, "  tenant:             %s\n", p.Tenant)
 	fmt.Fprintf(w, "  mode:               %s\n", shortMode(p.Mode))
+	fmt.Fprintf(w, "  default-egress:     %s\n", shortDefault(p.DefaultEgress))
 	fmt.Fprintf(w, "  allow-intra-tenant: %v\n", p.AllowIntraTenant)
 	fmt.Fprintf(w, "  allow-metadata:     %v\n", p.AllowMetadata)
 	if p.Source != "" {
@@ -639,9 +730,28 @@ func printPolicy(w io.Writer, p netPolicyJSON) {
 	if len(p.EgressDomains) > 0 {
 		fmt.Fprintf(w, "  egress-domains:     %s\n", strings.Join(p.EgressDomains, ", "))
 	}
+	if len(p.AllowRules) > 0 {
+		rules := make([]string, 0, len(p.AllowRules))
+		for _, r := range p.AllowRules {
+			rules = append(rules, allowRuleSummary(r))
+		}
+		fmt.Fprintf(w, "  allow-rules:        %s\n", strings.Join(rules, ", "))
+	}
 	printDenyRules(w, p.DenyRules)
 }
 
+// allowRuleSummary renders an allow rule the way --allow-rule takes it.
+func allowRuleSummary(r allowRuleJSON) string {
+	s := r.Cidr
+	if r.Port != 0 || r.Proto != "" {
+		s += ":" + strconv.Itoa(int(r.Port))
+	}
+	if r.Proto != "" {
+		s += "/" + r.Proto
+	}
+	return s
+}
+
 // doJSON does an admin-authenticated request with an optional JSON body and
 // decodes the JSON response into out (out may be nil to discard the body).
 func doJSON(method, url string, body, out interface{}) error {
@@ -0,0 +1,44 @@
+package cmd
+
+import "testing"
+
+func TestParseAllowRule(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    allowRuleJSON
+		wantErr bool
+	}{
+		{in: "203.0.113.7", want: allowRuleJSON{Cidr: "203.0.113.7"}},
+		{in: "203.0.113.0/24:443", want: allowRuleJSON{Cidr: "203.0.113.0/24", Port: 443}},
+		{in: "203.0.113.7:443/TCP", want: allowRuleJSON{Cidr: "203.0.113.7", Port: 443, Proto: "tcp"}},
+		{in: "203.0.113.7:0/udp", want: allowRuleJSON{Cidr: "203.0.113.7", Proto: "udp"}},
+		{in: ":443", wantErr: true},
+		{in: "203.0.113.7:http", wantErr: true},
+		{in: "203.0.113.7:70000", wantErr: true},
+		{in: "203.0.113.7:443/sctp", wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := parseAllowRule(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseAllowRule(%q) = %+v, want error", tc.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("parseAllowRule(%q) = (%+v, %v), want %+v", tc.in, got, err, tc.want)
+		}
+	}
+}
+
+func TestAllowRuleSummary(t *testing.T) {
+	for in, want := range map[allowRuleJSON]string{
+		{Cidr: "203.0.113.7"}:                          "203.0.113.7",
+		{Cidr: "203.0.113.7", Port: 443, Proto: "tcp"}: "203.0.113.7:443/tcp",
+		{Cidr: "203.0.113.7", Proto: "udp"}:            "203.0.113.7:0/udp",
+	} {
+		if got := allowRuleSummary(in); got != want {
+			t.Errorf("allowRuleSummary(%+v) = %q, want %q", in, got, want)
+		}
+	}
+}
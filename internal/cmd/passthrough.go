@@ -26,6 +26,15 @@ Examples:
   # Add a passthrough route with different external/internal ports
   containarium passthrough add --port 9443 --target-ip 10.0.3.150 --target-port 50051 --protocol tcp
 
+  # Route to alice's container through the daemon (IP resolved for you)
+  containarium passthrough add --port 50051 --container alice --target-port 50051 --server <host:port>
+
+  # Re-point a route after the container was recreated
+  containarium passthrough update --port 50051 --container alice --server <host:port>
+
+  # Take over a hand-written DNAT rule for port 5432
+  containarium passthrough adopt --port 5432 --container alice --server <host:port>
+
   # Remove a passthrough route
   containarium passthrough remove --port 50051`,
 }
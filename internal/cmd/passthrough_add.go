@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/footprintai/containarium/internal/safecast"
 	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +16,12 @@ var (
 	passthroughAddTargetPort  int
 	passthroughAddProtocol    string
 	passthroughAddNetworkCIDR string
+	passthroughAddContainer   string
+	passthroughAddDescription string
+	passthroughAddForce       bool
+	passthroughAddInInterface string
+	passthroughAddSNATSource  string
+	passthroughAddAllowSource []string
 )
 
 var passthroughAddCmd = &cobra.Command{
@@ -23,6 +32,29 @@ var passthroughAddCmd = &cobra.Command{
 Creates DNAT and MASQUERADE rules to forward traffic from an external port
 directly to a container's IP and port without TLS termination.
 
+Without --server the rules are written to this host's iptables directly.
+With --server the route is created through the daemon, which records it in
+its registry. There --container can replace --target-ip: the daemon resolves
+the container's current IP, and non-admin tokens may only target their own
+container. If both are given they must agree.
+
+The external port must not already be in use on the host: a process
+listening on it, or a NAT rule Containarium didn't create (e.g. a Docker
+published port), is reported as a conflict. --force adds the route anyway.
+Through the daemon, ports it depends on (its gRPC and REST ports, SSH 22,
+the sentinel's --public-port, 80/443 with app hosting, and the daemon's
+--reserved-ports) are refused outright, even with --force.
+
+--in-interface restricts the DNAT rule to traffic arriving on one interface
+("auto" picks the default-route interface), so the port isn't also reachable
+from internal or VPN networks. --snat-source rewrites forwarded packets'
+source to a fixed address ("host" uses the host's address on the container
+network) instead of MASQUERADE.
+
+--allow-source (repeatable) only forwards connections from the given IPv4
+addresses or CIDRs; everything else never reaches the container. Without it
+the route is open to any source.
+
 Examples:
   # Forward port 50051 to container
   containarium passthrough add --port 50051 --target-ip 10.0.3.150 --target-port 50051
@@ -31,7 +63,17 @@ Examples:
   containarium passthrough add --port 9443 --target-ip 10.0.3.150 --target-port 50051
 
   # Add UDP passthrough
-  containarium passthrough add --port 53 --target-ip 10.0.3.150 --target-port 53 --protocol udp`,
+  containarium passthrough add --port 53 --target-ip 10.0.3.150 --target-port 53 --protocol udp
+
+  # Only forward traffic arriving on the public interface
+  containarium passthrough add --port 2222 --target-ip 10.0.3.150 --target-port 22 --in-interface auto
+
+  # Only accept gRPC from a partner network
+  containarium passthrough add --port 50051 --target-ip 10.0.3.150 --target-port 50051 \
+    --allow-source 198.51.100.0/24 --allow-source 203.0.113.7
+
+  # Forward to alice's container, resolved by the daemon
+  containarium passthrough add --port 50051 --container alice --target-port 50051 --server <host:port>`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runPassthroughAdd()
 	},
@@ -39,13 +81,18 @@ Examples:
 
 func init() {
 	passthroughAddCmd.Flags().IntVar(&passthroughAddPort, "port", 0, "External port to expose (required)")
-	passthroughAddCmd.Flags().StringVar(&passthroughAddTargetIP, "target-ip", "", "Target container IP address (required)")
+	passthroughAddCmd.Flags().StringVar(&passthroughAddTargetIP, "target-ip", "", "Target container IP address (required unless --container is set)")
 	passthroughAddCmd.Flags().IntVar(&passthroughAddTargetPort, "target-port", 0, "Target port on the container (required)")
 	passthroughAddCmd.Flags().StringVar(&passthroughAddProtocol, "protocol", "tcp", "Protocol: tcp or udp")
 	passthroughAddCmd.Flags().StringVar(&passthroughAddNetworkCIDR, "network-cidr", "10.0.3.0/24", "Container network CIDR to exclude from forwarding")
+	passthroughAddCmd.Flags().StringVar(&passthroughAddContainer, "container", "", "Target container (username); its IP is resolved by the daemon (requires --server)")
+	passthroughAddCmd.Flags().StringVar(&passthroughAddDescription, "description", "", "Route description (daemon mode only)")
+	passthroughAddCmd.Flags().BoolVar(&passthroughAddForce, "force", false, "Add the route even if the external port is already in use on the host")
+	passthroughAddCmd.Flags().StringVar(&passthroughAddInInterface, "in-interface", "", "Only forward traffic arriving on this interface (e.g. eth0, or 'auto' for the default-route interface)")
+	passthroughAddCmd.Flags().StringVar(&passthroughAddSNATSource, "snat-source", "", "SNAT forwarded traffic to this IPv4 address ('host' for the host's container-network address) instead of MASQUERADE")
+	passthroughAddCmd.Flags().StringArrayVar(&passthroughAddAllowSource, "allow-source", nil, "Only forward connections from this IPv4 address or CIDR (repeatable; default: any source)")
 
 	_ = passthroughAddCmd.MarkFlagRequired("port")
-	_ = passthroughAddCmd.MarkFlagRequired("target-ip")
 	_ = passthroughAddCmd.MarkFlagRequired("target-port")
 
 	passthroughCmd.AddCommand(passthroughAddCmd)
@@ -59,13 +106,20 @@ func runPassthroughAdd() error {
 	if passthroughAddTargetPort <= 0 || passthroughAddTargetPort > 65535 {
 		return fmt.Errorf("target-port must be between 1 and 65535")
 	}
-	if passthroughAddTargetIP == "" {
-		return fmt.Errorf("target-ip is required")
+	if passthroughAddTargetIP == "" && passthroughAddContainer == "" {
+		return fmt.Errorf("--target-ip or --container is required")
 	}
 	if passthroughAddProtocol != "tcp" && passthroughAddProtocol != "udp" {
 		return fmt.Errorf("protocol must be 'tcp' or 'udp'")
 	}
 
+	if serverAddr != "" {
+		return runPassthroughAddRemote()
+	}
+	if passthroughAddContainer != "" {
+		return fmt.Errorf("--container needs --server: the daemon resolves the container's IP")
+	}
+
 	// Check if iptables is available
 	if !network.CheckIPTablesAvailable() {
 		return fmt.Errorf("iptables not available on this system")
@@ -74,13 +128,77 @@ func runPassthroughAdd() error {
 	// Create passthrough manager
 	pm := network.NewPassthroughManager(passthroughAddNetworkCIDR)
 
+	if !passthroughAddForce {
+		if err := pm.CheckPortConflict(passthroughAddPort, passthroughAddProtocol); err != nil {
+			return fmt.Errorf("%w (use --force to add the route anyway)", err)
+		}
+	}
+
+	opts, err := pm.ResolveRouteOptions(passthroughAddInInterface, passthroughAddSNATSource, passthroughAddAllowSource)
+	if err != nil {
+		return err
+	}
+
 	// Add the route
-	if err := pm.AddRoute(passthroughAddPort, passthroughAddTargetIP, passthroughAddTargetPort, passthroughAddProtocol); err != nil {
+	if err := pm.AddRouteWithOptions(passthroughAddPort, passthroughAddTargetIP, passthroughAddTargetPort, passthroughAddProtocol, opts); err != nil {
 		return fmt.Errorf("failed to add passthrough route: %w", err)
 	}
 
 	fmt.Printf("✓ Passthrough route added: %s:%d -> %s:%d\n",
 		passthroughAddProtocol, passthroughAddPort, passthroughAddTargetIP, passthroughAddTargetPort)
+	printPassthroughOptions(opts.InInterface, opts.SNATSource, opts.AllowSources)
 
 	return nil
 }
+
+func runPassthroughAddRemote() error {
+	protocol, err := passthroughProtocol(passthroughAddProtocol)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := newPassthroughClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer func() { _ = apiClient.Close() }()
+
+	route, err := apiClient.AddPassthroughRoute(&pb.AddPassthroughRouteRequest{
+		ExternalPort:  safecast.I32(passthroughAddPort),
+		TargetIp:      passthroughAddTargetIP,
+		TargetPort:    safecast.I32(passthroughAddTargetPort),
+		Protocol:      protocol,
+		ContainerName: passthroughAddContainer,
+		Description:   passthroughAddDescription,
+		Force:         passthroughAddForce,
+		InInterface:   passthroughAddInInterface,
+		SnatSource:    passthroughAddSNATSource,
+		AllowSources:  passthroughAddAllowSource,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Passthrough route added: %s:%d -> %s:%d\n",
+		passthroughAddProtocol, route.ExternalPort, route.TargetIp, route.TargetPort)
+	if route.ContainerName != "" {
+		fmt.Printf("  Container: %s\n", route.ContainerName)
+	}
+	printPassthroughOptions(route.InInterface, route.SnatSource, route.AllowSources)
+
+	return nil
+}
+
+// printPassthroughOptions prints the route options that differ from the
+// defaults.
+func printPassthroughOptions(inIface, snatSource string, allowSources []string) {
+	if inIface != "" {
+		fmt.Printf("  In interface: %s\n", inIface)
+	}
+	if snatSource != "" {
+		fmt.Printf("  SNAT source: %s\n", snatSource)
+	}
+	if len(allowSources) > 0 {
+		fmt.Printf("  Allowed sources: %s\n", strings.Join(allowSources, ", "))
+	}
+}
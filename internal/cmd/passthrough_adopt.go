@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/footprintai/containarium/internal/safecast"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	passthroughAdoptPort          int
+	passthroughAdoptProtocol      string
+	passthroughAdoptContainer     string
+	passthroughAdoptDescription   string
+	passthroughAdoptAllowExternal bool
+)
+
+var passthroughAdoptCmd = &cobra.Command{
+	Use:   "adopt",
+	Short: "Bring an existing hand-written DNAT rule under management",
+	Long: `Adopt an existing PREROUTING DNAT rule that Containarium didn't create,
+so the daemon manages it like any passthrough route from then on.
+
+The daemon finds the unmanaged rule(s) forwarding --port, replaces them with
+the equivalent tagged rule — the new rule goes in before the old one comes
+out, so forwarding never stops — and records the route in its registry.
+Rules with matches a passthrough route can't express (a destination
+address, port ranges, other modules) are refused rather than changed.
+
+The rule's target must be the IP of a known container: the one named with
+--container, or whichever container has it. Pass --allow-external-target
+to adopt a rule forwarding somewhere else.
+
+Examples:
+  # Adopt the forward on 5432, checking it goes to alice's container
+  containarium passthrough adopt --port 5432 --container alice --server <host:port>
+
+  # Adopt a UDP forward to a host outside the container network
+  containarium passthrough adopt --port 5353 --protocol udp --allow-external-target --server <host:port>`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPassthroughAdopt()
+	},
+}
+
+func init() {
+	passthroughAdoptCmd.Flags().IntVar(&passthroughAdoptPort, "port", 0, "External port of the rule to adopt (required)")
+	passthroughAdoptCmd.Flags().StringVar(&passthroughAdoptProtocol, "protocol", "tcp", "Protocol: tcp or udp")
+	passthroughAdoptCmd.Flags().StringVar(&passthroughAdoptContainer, "container", "", "Container (username) the rule must forward to (default: found by the target IP)")
+	passthroughAdoptCmd.Flags().StringVar(&passthroughAdoptDescription, "description", "", "Route description")
+	passthroughAdoptCmd.Flags().BoolVar(&passthroughAdoptAllowExternal, "allow-external-target", false, "Adopt the rule even if its target is not a known container's IP")
+
+	_ = passthroughAdoptCmd.MarkFlagRequired("port")
+
+	passthroughCmd.AddCommand(passthroughAdoptCmd)
+}
+
+func runPassthroughAdopt() error {
+	if serverAddr == "" {
+		return fmt.Errorf("--server is required")
+	}
+	if passthroughAdoptPort <= 0 || passthroughAdoptPort > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+	protocol, err := passthroughProtocol(passthroughAdoptProtocol)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := newPassthroughClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer func() { _ = apiClient.Close() }()
+
+	route, err := apiClient.AdoptPassthroughRoute(&pb.AdoptPassthroughRouteRequest{
+		ExternalPort:        safecast.I32(passthroughAdoptPort),
+		Protocol:            protocol,
+		ContainerName:       passthroughAdoptContainer,
+		AllowExternalTarget: passthroughAdoptAllowExternal,
+		Description:         passthroughAdoptDescription,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Passthrough route adopted: %s:%d -> %s:%d\n",
+		passthroughAdoptProtocol, route.ExternalPort, route.TargetIp, route.TargetPort)
+	if route.ContainerName != "" {
+		fmt.Printf("  Container: %s\n", route.ContainerName)
+	}
+	printPassthroughOptions(route.InInterface, route.SnatSource, route.AllowSources)
+
+	return nil
+}
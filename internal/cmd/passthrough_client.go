@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/footprintai/containarium/internal/client"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// passthroughAPI is the subset of client methods the passthrough verbs use
+// when talking to a daemon instead of the local iptables. Both
+// *client.GRPCClient and *client.HTTPClient satisfy it.
+type passthroughAPI interface {
+	ListPassthroughRoutes() ([]*pb.PassthroughRoute, error)
+	AddPassthroughRoute(req *pb.AddPassthroughRouteRequest) (*pb.PassthroughRoute, error)
+	UpdatePassthroughRoute(req *pb.UpdatePassthroughRouteRequest) (*pb.PassthroughRoute, error)
+	DeletePassthroughRoute(req *pb.DeletePassthroughRouteRequest) (*pb.DeletePassthroughRouteResponse, error)
+	AdoptPassthroughRoute(req *pb.AdoptPassthroughRouteRequest) (*pb.PassthroughRoute, error)
+	Close() error
+}
+
+// newPassthroughClient picks the transport the same way newRouteClient does.
+// Caller must Close() the result.
+func newPassthroughClient() (passthroughAPI, error) {
+	if httpMode && serverAddr != "" {
+		return client.NewHTTPClient(serverAddr, authToken)
+	}
+	return client.NewGRPCClient(serverAddr, certsDir, insecure)
+}
+
+// passthroughProtocol maps the --protocol flag to the API enum.
+func passthroughProtocol(protocol string) (pb.RouteProtocol, error) {
+	switch protocol {
+	case "tcp":
+		return pb.RouteProtocol_ROUTE_PROTOCOL_TCP, nil
+	case "udp":
+		return pb.RouteProtocol_ROUTE_PROTOCOL_UDP, nil
+	default:
+		return pb.RouteProtocol_ROUTE_PROTOCOL_UNSPECIFIED, fmt.Errorf("protocol must be 'tcp' or 'udp'")
+	}
+}
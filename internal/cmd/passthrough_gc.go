@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/network"
+	"github.com/spf13/cobra"
+)
+
+var (
+	passthroughGCNetworkCIDR string
+	passthroughGCDryRun      bool
+)
+
+var passthroughGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove passthrough rules for containers that no longer exist",
+	Long: `Remove the passthrough iptables rules left behind for containers that no
+longer exist, e.g. after a crash in the middle of deleting one.
+
+Only rules carrying the Containarium comment tag are considered; rules
+added by hand are never touched. A rule naming its container is removed
+once incus no longer lists that container, running or not. Rules from
+before containers were recorded in the tag are checked by target IP, only
+when that IP is inside --network-cidr (a route to a LAN host or VM is
+never removed), and only while every container has an address.
+
+The daemon can do the same periodically (--passthrough-gc-interval, off
+by default). Runs locally on the host.
+
+Examples:
+  # Show what would be removed
+  containarium passthrough gc --dry-run
+
+  # Remove the orphaned rules
+  containarium passthrough gc`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPassthroughGC()
+	},
+}
+
+func init() {
+	passthroughGCCmd.Flags().StringVar(&passthroughGCNetworkCIDR, "network-cidr", "10.0.3.0/24", "Container network CIDR")
+	passthroughGCCmd.Flags().BoolVar(&passthroughGCDryRun, "dry-run", false, "Show the rules that would be removed without removing them")
+
+	passthroughCmd.AddCommand(passthroughGCCmd)
+}
+
+func runPassthroughGC() error {
+	if !network.CheckIPTablesAvailable() {
+		return fmt.Errorf("iptables not available on this system")
+	}
+
+	incusClient, err := incus.New()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Incus: %w", err)
+	}
+	containers, err := incusClient.ListContainers()
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	live := network.NewLiveContainers()
+	for _, c := range containers {
+		live.Add(c.Name, c.IPAddress)
+	}
+
+	pm := network.NewPassthroughManager(passthroughGCNetworkCIDR)
+	swept, err := pm.SweepOrphans(live, passthroughGCDryRun)
+	for _, r := range swept {
+		if passthroughGCDryRun {
+			fmt.Printf("dry-run: would remove %s\n", r)
+		} else {
+			fmt.Printf("✓ Removed %s\n", r)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(swept) == 0 {
+		fmt.Println("No orphaned passthrough rules.")
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/footprintai/containarium/pkg/core/network"
@@ -14,7 +15,9 @@ var passthroughListCmd = &cobra.Command{
 	Short: "List all passthrough routes",
 	Long: `List all TCP/UDP passthrough routes currently configured via iptables.
 
-Shows the external port, target IP:port, protocol, and status for each route.`,
+Shows the external port, target IP:port, protocol, container, interface
+binding, allowed sources, source NAT, and status for each route. The container column is only known to the daemon's registry, so
+it is populated when listing with --server.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runPassthroughList()
 	},
@@ -24,45 +27,117 @@ func init() {
 	passthroughCmd.AddCommand(passthroughListCmd)
 }
 
+// passthroughListRow is one table row, from either iptables or the daemon.
+type passthroughListRow struct {
+	externalPort int
+	targetIP     string
+	targetPort   int
+	protocol     string
+	container    string
+	inInterface  string
+	snatSource   string
+	allowSources []string
+	active       bool
+}
+
 func runPassthroughList() error {
-	// Check if iptables is available
-	if !network.CheckIPTablesAvailable() {
-		return fmt.Errorf("iptables not available on this system")
-	}
+	var rows []passthroughListRow
+	if serverAddr != "" {
+		apiClient, err := newPassthroughClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to server: %w", err)
+		}
+		defer func() { _ = apiClient.Close() }()
+
+		routes, err := apiClient.ListPassthroughRoutes()
+		if err != nil {
+			return err
+		}
+		for _, r := range routes {
+			rows = append(rows, passthroughListRow{
+				externalPort: int(r.ExternalPort),
+				targetIP:     r.TargetIp,
+				targetPort:   int(r.TargetPort),
+				protocol:     strings.ToLower(strings.TrimPrefix(r.Protocol.String(), "ROUTE_PROTOCOL_")),
+				container:    r.ContainerName,
+				inInterface:  r.InInterface,
+				snatSource:   r.SnatSource,
+				allowSources: r.AllowSources,
+				active:       r.Active,
+			})
+		}
+	} else {
+		// Check if iptables is available
+		if !network.CheckIPTablesAvailable() {
+			return fmt.Errorf("iptables not available on this system")
+		}
 
-	// Create passthrough manager (network CIDR not needed for listing)
-	pm := network.NewPassthroughManager("0.0.0.0/0")
+		// Create passthrough manager (network CIDR not needed for listing)
+		pm := network.NewPassthroughManager("0.0.0.0/0")
 
-	routes, err := pm.ListRoutes()
-	if err != nil {
-		return fmt.Errorf("failed to list passthrough routes: %w", err)
+		routes, err := pm.ListRoutes()
+		if err != nil {
+			return fmt.Errorf("failed to list passthrough routes: %w", err)
+		}
+		for _, r := range routes {
+			rows = append(rows, passthroughListRow{
+				externalPort: r.ExternalPort,
+				targetIP:     r.TargetIP,
+				targetPort:   r.TargetPort,
+				protocol:     r.Protocol,
+				inInterface:  r.InInterface,
+				snatSource:   r.SNATSource,
+				allowSources: r.AllowSources,
+				active:       r.Active,
+			})
+		}
 	}
 
-	if len(routes) == 0 {
+	if len(rows) == 0 {
 		fmt.Println("No passthrough routes configured")
 		return nil
 	}
 
 	// Print routes in a table format
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "EXT PORT\tTARGET\tPROTOCOL\tSTATUS")
-	fmt.Fprintln(w, "--------\t------\t--------\t------")
+	fmt.Fprintln(w, "EXT PORT\tTARGET\tPROTOCOL\tCONTAINER\tIN IFACE\tALLOW FROM\tSOURCE NAT\tSTATUS")
+	fmt.Fprintln(w, "--------\t------\t--------\t---------\t--------\t----------\t----------\t------")
 
-	for _, route := range routes {
+	for _, route := range rows {
 		status := "Inactive"
-		if route.Active {
+		if route.active {
 			status = "Active"
 		}
-		fmt.Fprintf(w, "%d\t%s:%d\t%s\t%s\n",
-			route.ExternalPort,
-			route.TargetIP,
-			route.TargetPort,
-			route.Protocol,
+		container := route.container
+		if container == "" {
+			container = "-"
+		}
+		inIface := route.inInterface
+		if inIface == "" {
+			inIface = "any"
+		}
+		allow := "any"
+		if len(route.allowSources) > 0 {
+			allow = strings.Join(route.allowSources, ",")
+		}
+		snat := "masquerade"
+		if route.snatSource != "" {
+			snat = route.snatSource
+		}
+		fmt.Fprintf(w, "%d\t%s:%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			route.externalPort,
+			route.targetIP,
+			route.targetPort,
+			route.protocol,
+			container,
+			inIface,
+			allow,
+			snat,
 			status,
 		)
 	}
 	_ = w.Flush()
 
-	fmt.Printf("\nTotal: %d passthrough route(s)\n", len(routes))
+	fmt.Printf("\nTotal: %d passthrough route(s)\n", len(rows))
 	return nil
 }
@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/footprintai/containarium/internal/safecast"
 	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 	"github.com/spf13/cobra"
 )
 
@@ -11,6 +13,7 @@ var (
 	passthroughRemovePort        int
 	passthroughRemoveProtocol    string
 	passthroughRemoveNetworkCIDR string
+	passthroughRemoveDryRun      bool
 )
 
 var passthroughRemoveCmd = &cobra.Command{
@@ -18,12 +21,19 @@ var passthroughRemoveCmd = &cobra.Command{
 	Short: "Remove a passthrough route",
 	Long: `Remove a TCP/UDP passthrough route from iptables.
 
+With --server the daemon removes the route from its registry and its sync
+job drops the iptables rules; without it the rules are removed locally.
+--dry-run shows the route that would be removed and changes nothing.
+
 Examples:
   # Remove TCP passthrough on port 50051
   containarium passthrough remove --port 50051
 
   # Remove UDP passthrough on port 53
-  containarium passthrough remove --port 53 --protocol udp`,
+  containarium passthrough remove --port 53 --protocol udp
+
+  # Show what would be removed
+  containarium passthrough remove --port 5432 --dry-run --server <host:port>`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runPassthroughRemove()
 	},
@@ -33,6 +43,7 @@ func init() {
 	passthroughRemoveCmd.Flags().IntVar(&passthroughRemovePort, "port", 0, "External port to remove (required)")
 	passthroughRemoveCmd.Flags().StringVar(&passthroughRemoveProtocol, "protocol", "tcp", "Protocol: tcp or udp")
 	passthroughRemoveCmd.Flags().StringVar(&passthroughRemoveNetworkCIDR, "network-cidr", "10.0.3.0/24", "Container network CIDR")
+	passthroughRemoveCmd.Flags().BoolVar(&passthroughRemoveDryRun, "dry-run", false, "Show the route that would be removed without removing it")
 
 	_ = passthroughRemoveCmd.MarkFlagRequired("port")
 
@@ -48,6 +59,10 @@ func runPassthroughRemove() error {
 		return fmt.Errorf("protocol must be 'tcp' or 'udp'")
 	}
 
+	if serverAddr != "" {
+		return runPassthroughRemoveRemote()
+	}
+
 	// Check if iptables is available
 	if !network.CheckIPTablesAvailable() {
 		return fmt.Errorf("iptables not available on this system")
@@ -56,6 +71,21 @@ func runPassthroughRemove() error {
 	// Create passthrough manager
 	pm := network.NewPassthroughManager(passthroughRemoveNetworkCIDR)
 
+	if passthroughRemoveDryRun {
+		routes, err := pm.ListRoutes()
+		if err != nil {
+			return fmt.Errorf("failed to list passthrough routes: %w", err)
+		}
+		for _, r := range routes {
+			if r.ExternalPort == passthroughRemovePort && r.Protocol == passthroughRemoveProtocol {
+				printPassthroughRemoval(true, passthroughRemoveProtocol, r.ExternalPort, r.TargetIP, r.TargetPort)
+				return nil
+			}
+		}
+		fmt.Printf("dry-run: no passthrough route on %s:%d; nothing would be removed\n", passthroughRemoveProtocol, passthroughRemovePort)
+		return nil
+	}
+
 	// Remove the route
 	if err := pm.RemoveRoute(passthroughRemovePort, passthroughRemoveProtocol); err != nil {
 		return fmt.Errorf("failed to remove passthrough route: %w", err)
@@ -65,3 +95,51 @@ func runPassthroughRemove() error {
 
 	return nil
 }
+
+func runPassthroughRemoveRemote() error {
+	protocol, err := passthroughProtocol(passthroughRemoveProtocol)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := newPassthroughClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer func() { _ = apiClient.Close() }()
+
+	resp, err := apiClient.DeletePassthroughRoute(&pb.DeletePassthroughRouteRequest{
+		ExternalPort: safecast.I32(passthroughRemovePort),
+		Protocol:     protocol,
+		DryRun:       passthroughRemoveDryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	route := resp.GetRoute()
+	if route == nil {
+		if resp.DryRun {
+			fmt.Printf("dry-run: no passthrough route on %s:%d; nothing would be removed\n", passthroughRemoveProtocol, passthroughRemovePort)
+		} else {
+			fmt.Printf("✓ Passthrough route removed: %s:%d\n", passthroughRemoveProtocol, passthroughRemovePort)
+		}
+		return nil
+	}
+	printPassthroughRemoval(resp.DryRun, passthroughRemoveProtocol, int(route.ExternalPort), route.TargetIp, int(route.TargetPort))
+	if route.ContainerName != "" {
+		fmt.Printf("  Container: %s\n", route.ContainerName)
+	}
+	printPassthroughOptions(route.InInterface, route.SnatSource, route.AllowSources)
+	return nil
+}
+
+// printPassthroughRemoval prints the removed route, or the one a dry run
+// would remove, in the same shape.
+func printPassthroughRemoval(dryRun bool, protocol string, port int, targetIP string, targetPort int) {
+	if dryRun {
+		fmt.Printf("dry-run: would remove passthrough route: %s:%d -> %s:%d\n", protocol, port, targetIP, targetPort)
+		return
+	}
+	fmt.Printf("✓ Passthrough route removed: %s:%d -> %s:%d\n", protocol, port, targetIP, targetPort)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/footprintai/containarium/internal/safecast"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	passthroughUpdatePort        int
+	passthroughUpdateTargetIP    string
+	passthroughUpdateTargetPort  int
+	passthroughUpdateProtocol    string
+	passthroughUpdateContainer   string
+	passthroughUpdateDescription string
+	passthroughUpdateInInterface string
+	passthroughUpdateSNATSource  string
+)
+
+var passthroughUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a passthrough route",
+	Long: `Update an existing TCP/UDP passthrough route through the daemon.
+
+--container re-resolves the container's current IP, which is how a route
+follows a container that was recreated with a new address. Non-admin tokens
+may only point routes at their own container. If both --container and
+--target-ip are given they must agree.
+
+--in-interface and --snat-source take the same values as in "passthrough
+add"; pass an empty string to clear them. Left off, they are unchanged.
+
+Examples:
+  # Re-point port 50051 at alice's container's current IP
+  containarium passthrough update --port 50051 --container alice --server <host:port>
+
+  # Change the target port
+  containarium passthrough update --port 50051 --container alice --target-port 50052 --server <host:port>`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPassthroughUpdate(cmd)
+	},
+}
+
+func init() {
+	passthroughUpdateCmd.Flags().IntVar(&passthroughUpdatePort, "port", 0, "External port of the route (required)")
+	passthroughUpdateCmd.Flags().StringVar(&passthroughUpdateTargetIP, "target-ip", "", "New target container IP address")
+	passthroughUpdateCmd.Flags().IntVar(&passthroughUpdateTargetPort, "target-port", 0, "New target port (default: unchanged)")
+	passthroughUpdateCmd.Flags().StringVar(&passthroughUpdateProtocol, "protocol", "tcp", "Protocol: tcp or udp")
+	passthroughUpdateCmd.Flags().StringVar(&passthroughUpdateContainer, "container", "", "Target container (username); its IP is resolved by the daemon")
+	passthroughUpdateCmd.Flags().StringVar(&passthroughUpdateDescription, "description", "", "Route description")
+	passthroughUpdateCmd.Flags().StringVar(&passthroughUpdateInInterface, "in-interface", "", "Interface to bind the route to ('auto' for the default-route interface, '' to clear; default: unchanged)")
+	passthroughUpdateCmd.Flags().StringVar(&passthroughUpdateSNATSource, "snat-source", "", "SNAT source address ('host' for the host's container-network address, '' for MASQUERADE; default: unchanged)")
+
+	_ = passthroughUpdateCmd.MarkFlagRequired("port")
+
+	passthroughCmd.AddCommand(passthroughUpdateCmd)
+}
+
+func runPassthroughUpdate(cmd *cobra.Command) error {
+	if serverAddr == "" {
+		return fmt.Errorf("--server is required")
+	}
+	if passthroughUpdatePort <= 0 || passthroughUpdatePort > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+	if passthroughUpdateTargetPort < 0 || passthroughUpdateTargetPort > 65535 {
+		return fmt.Errorf("target-port must be between 1 and 65535")
+	}
+	if passthroughUpdateTargetIP == "" && passthroughUpdateContainer == "" {
+		return fmt.Errorf("--target-ip or --container is required")
+	}
+	protocol, err := passthroughProtocol(passthroughUpdateProtocol)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := newPassthroughClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer func() { _ = apiClient.Close() }()
+
+	req := &pb.UpdatePassthroughRouteRequest{
+		ExternalPort:  safecast.I32(passthroughUpdatePort),
+		Protocol:      protocol,
+		TargetIp:      passthroughUpdateTargetIP,
+		TargetPort:    safecast.I32(passthroughUpdateTargetPort),
+		ContainerName: passthroughUpdateContainer,
+		Description:   passthroughUpdateDescription,
+	}
+	// Unset flags leave the stored options alone; an explicit "" clears them.
+	if cmd.Flags().Changed("in-interface") {
+		req.InInterface = &passthroughUpdateInInterface
+	}
+	if cmd.Flags().Changed("snat-source") {
+		req.SnatSource = &passthroughUpdateSNATSource
+	}
+	route, err := apiClient.UpdatePassthroughRoute(req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Passthrough route updated: %s:%d -> %s:%d\n",
+		passthroughUpdateProtocol, route.ExternalPort, route.TargetIp, route.TargetPort)
+	if route.ContainerName != "" {
+		fmt.Printf("  Container: %s\n", route.ContainerName)
+	}
+	printPassthroughOptions(route.InInterface, route.SnatSource, route.AllowSources)
+
+	return nil
+}
@@ -9,7 +9,7 @@ import (
 )
 
 var (
-	portforwardRemoveCaddyIP    string
+	portforwardRemoveCaddyIPs   []string
 	portforwardRemoveAutoDetect bool
 )
 
@@ -22,6 +22,9 @@ This command will remove:
 1. PREROUTING rules for ports 80 and 443
 2. MASQUERADE rule for return traffic
 
+Rules for every listed Caddy IP are removed, including the balanced rule
+sets created when forwarding to several Caddy instances.
+
 Note: This does NOT disable IP forwarding, as it may be used by other services.
 
 Examples:
@@ -35,7 +38,7 @@ Examples:
 
 func init() {
 	portforwardCmd.AddCommand(portforwardRemoveCmd)
-	portforwardRemoveCmd.Flags().StringVar(&portforwardRemoveCaddyIP, "caddy-ip", "", "IP address of the Caddy container")
+	portforwardRemoveCmd.Flags().StringSliceVar(&portforwardRemoveCaddyIPs, "caddy-ip", nil, "IP address of the Caddy container (repeatable for multiple Caddy instances)")
 	portforwardRemoveCmd.Flags().BoolVar(&portforwardRemoveAutoDetect, "auto", false, "Auto-detect Caddy container IP from Incus")
 }
 
@@ -45,11 +48,11 @@ func runPortforwardRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("iptables is not available on this system")
 	}
 
-	caddyIP := portforwardRemoveCaddyIP
+	caddyIPs := portforwardRemoveCaddyIPs
 
 	// Auto-detect if requested
 	if portforwardRemoveAutoDetect {
-		if caddyIP != "" {
+		if len(caddyIPs) > 0 {
 			return fmt.Errorf("cannot use both --caddy-ip and --auto")
 		}
 
@@ -63,16 +66,16 @@ func runPortforwardRemove(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to auto-detect Caddy IP: %w", err)
 		}
-		caddyIP = detectedIP
-		fmt.Printf("Detected Caddy at: %s\n", caddyIP)
+		caddyIPs = []string{detectedIP}
+		fmt.Printf("Detected Caddy at: %s\n", detectedIP)
 	}
 
-	if caddyIP == "" {
+	if len(caddyIPs) == 0 {
 		return fmt.Errorf("--caddy-ip or --auto is required")
 	}
 
 	// Remove port forwarding
-	portForwarder := network.NewPortForwarder(caddyIP)
+	portForwarder := network.NewPortForwarderWithBackends(caddyIPs, "", network.BalanceRoundRobin)
 	if err := portForwarder.RemovePortForwarding(); err != nil {
 		return fmt.Errorf("failed to remove port forwarding: %w", err)
 	}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/footprintai/containarium/pkg/core/incus"
 	"github.com/footprintai/containarium/pkg/core/network"
@@ -9,8 +10,9 @@ import (
 )
 
 var (
-	portforwardCaddyIP    string
+	portforwardCaddyIPs   []string
 	portforwardAutoDetect bool
+	portforwardBalance    string
 )
 
 var portforwardSetupCmd = &cobra.Command{
@@ -26,10 +28,22 @@ This command will:
 The rules exclude traffic from Caddy's own IP to allow outbound HTTPS
 connections (e.g., to Let's Encrypt servers).
 
+Repeat --caddy-ip (or comma-separate) to forward to several Caddy instances.
+--balance round-robin spreads new connections evenly over them; --balance
+failover sends everything to the first one listed. Health-based removal of a
+dead instance is done by the daemon (--caddy-backends), not by this
+one-shot command.
+
 Examples:
   # Setup with explicit Caddy IP
   containarium portforward setup --caddy-ip 10.0.3.111
 
+  # Round-robin across two Caddy instances
+  containarium portforward setup --caddy-ip 10.0.3.111,10.0.3.112
+
+  # Primary/backup
+  containarium portforward setup --caddy-ip 10.0.3.111,10.0.3.112 --balance failover
+
   # Setup with auto-detection (requires Incus)
   containarium portforward setup --auto`,
 	RunE: runPortforwardSetup,
@@ -37,8 +51,9 @@ Examples:
 
 func init() {
 	portforwardCmd.AddCommand(portforwardSetupCmd)
-	portforwardSetupCmd.Flags().StringVar(&portforwardCaddyIP, "caddy-ip", "", "IP address of the Caddy container")
+	portforwardSetupCmd.Flags().StringSliceVar(&portforwardCaddyIPs, "caddy-ip", nil, "IP address of the Caddy container (repeatable for multiple Caddy instances)")
 	portforwardSetupCmd.Flags().BoolVar(&portforwardAutoDetect, "auto", false, "Auto-detect Caddy container IP from Incus")
+	portforwardSetupCmd.Flags().StringVar(&portforwardBalance, "balance", string(network.BalanceRoundRobin), "How to spread traffic over multiple Caddy IPs: round-robin or failover")
 }
 
 func runPortforwardSetup(cmd *cobra.Command, args []string) error {
@@ -47,11 +62,15 @@ func runPortforwardSetup(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("iptables is not available on this system")
 	}
 
-	caddyIP := portforwardCaddyIP
+	mode, err := network.ParseBalanceMode(portforwardBalance)
+	if err != nil {
+		return err
+	}
+	caddyIPs := portforwardCaddyIPs
 
 	// Auto-detect if requested
 	if portforwardAutoDetect {
-		if caddyIP != "" {
+		if len(caddyIPs) > 0 {
 			return fmt.Errorf("cannot use both --caddy-ip and --auto")
 		}
 
@@ -65,23 +84,26 @@ func runPortforwardSetup(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to auto-detect Caddy IP: %w", err)
 		}
-		caddyIP = detectedIP
-		fmt.Printf("Detected Caddy at: %s\n", caddyIP)
+		caddyIPs = []string{detectedIP}
+		fmt.Printf("Detected Caddy at: %s\n", detectedIP)
 	}
 
-	if caddyIP == "" {
+	if len(caddyIPs) == 0 {
 		return fmt.Errorf("--caddy-ip or --auto is required")
 	}
 
 	// Setup port forwarding
-	portForwarder := network.NewPortForwarder(caddyIP)
+	portForwarder := network.NewPortForwarderWithBackends(caddyIPs, "", mode)
 	if err := portForwarder.SetupPortForwarding(); err != nil {
 		return fmt.Errorf("failed to setup port forwarding: %w", err)
 	}
 
 	fmt.Println()
 	fmt.Println("Port forwarding setup complete!")
-	fmt.Printf("  Ports 80, 443 -> %s\n", caddyIP)
+	fmt.Printf("  Ports 80, 443 -> %s\n", strings.Join(portForwarder.ActiveBackends(), ", "))
+	if len(portForwarder.Backends()) > 1 {
+		fmt.Printf("  Balance: %s\n", mode)
+	}
 	fmt.Println()
 	fmt.Println("Run 'containarium portforward show' to verify the rules.")
 
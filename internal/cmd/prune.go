@@ -219,10 +219,12 @@ func pruneList() ([]incus.ContainerInfo, error) {
 
 func pruneDelete(username string, force bool) error {
 	if httpMode && serverAddr != "" {
-		return deleteRemoteHTTP(username, force)
+		_, err := deleteRemoteHTTP(username, force, false)
+		return err
 	}
 	if serverAddr != "" {
-		return deleteRemote(username, force)
+		_, err := deleteRemote(username, force, false)
+		return err
 	}
 	return deleteLocal(username, force)
 }
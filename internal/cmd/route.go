@@ -6,15 +6,21 @@ import (
 
 // routeCmd represents the route command
 var routeCmd = &cobra.Command{
-	Use:   "route",
-	Short: "Manage proxy routes (domain to container mappings)",
+	Use:     "route",
+	Aliases: []string{"proxy"},
+	Short:   "Manage proxy routes (domain to container mappings)",
 	Long: `Manage proxy routes in Containarium.
 
 Routes map external domains to internal container IPs, enabling external access
-to services running in containers.
+to services running in containers. The daemon stores them and keeps Caddy in
+sync, obtaining a TLS certificate for each domain; "route list" shows where
+each certificate stands.
 
 Examples:
-  # Add a route
+  # Add a route to alice's container; it follows the container's IP
+  containarium route add app.example.com --container alice --port 3000
+
+  # Add a route to a fixed IP:port
   containarium route add test.example.com --target 10.0.3.136:8080
 
   # List all routes
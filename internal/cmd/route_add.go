@@ -5,61 +5,99 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/footprintai/containarium/internal/safecast"
 	"github.com/spf13/cobra"
 )
 
 var (
+	routeAddHostname    string
 	routeAddTarget      string
 	routeAddContainer   string
+	routeAddPort        int
 	routeAddDescription string
 )
 
 var routeAddCmd = &cobra.Command{
-	Use:   "add <domain>",
+	Use:   "add [domain]",
 	Short: "Add a proxy route",
 	Long: `Add a new proxy route (domain to container mapping).
 
-The route maps an external domain to an internal container IP:port.
+The route maps an external domain to a container port. Give either the
+container and port — the daemon resolves the container's IP and repoints the
+route whenever that changes — or a fixed --target IP:port. The domain is the
+argument or --hostname.
 
 Examples:
-  # Add a route
+  # Route to alice's container on port 3000
+  containarium proxy add --hostname app.example.com --container alice --port 3000
+
+  # Add a route to a fixed target
   containarium route add test.example.com --target 10.0.3.136:8080 --server <host:port>
 
   # Add with container name and description
   containarium route add api.example.com --target 10.0.3.140:3000 \
     --container myapp-container \
     --description "API server"`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: runRouteAdd,
 }
 
 func init() {
 	routeCmd.AddCommand(routeAddCmd)
 
-	routeAddCmd.Flags().StringVarP(&routeAddTarget, "target", "t", "", "Target IP:port (required)")
-	routeAddCmd.Flags().StringVarP(&routeAddContainer, "container", "c", "", "Associated container name (optional)")
+	routeAddCmd.Flags().StringVar(&routeAddHostname, "hostname", "", "Domain to route (instead of the argument)")
+	routeAddCmd.Flags().StringVarP(&routeAddTarget, "target", "t", "", "Target IP:port (instead of --container and --port)")
+	routeAddCmd.Flags().StringVarP(&routeAddContainer, "container", "c", "", "Container (or username) the route forwards to")
+	routeAddCmd.Flags().IntVarP(&routeAddPort, "port", "p", 0, "Container port (with --container)")
 	routeAddCmd.Flags().StringVarP(&routeAddDescription, "description", "d", "", "Route description (optional)")
-
-	_ = routeAddCmd.MarkFlagRequired("target")
 }
 
-func runRouteAdd(cmd *cobra.Command, args []string) error {
-	domain := args[0]
-
-	if serverAddr == "" {
-		return fmt.Errorf("--server is required")
+// routeAddTargetFlags resolves --target or --port into the target IP
+// (empty: the daemon resolves --container) and port.
+func routeAddTargetFlags() (string, int32, error) {
+	if routeAddTarget == "" {
+		if routeAddContainer == "" || routeAddPort == 0 {
+			return "", 0, fmt.Errorf("either --target IP:port or --container with --port is required")
+		}
+		if routeAddPort < 1 || routeAddPort > 65535 {
+			return "", 0, fmt.Errorf("invalid port: %d", routeAddPort)
+		}
+		return "", safecast.I32(routeAddPort), nil
+	}
+	if routeAddPort != 0 {
+		return "", 0, fmt.Errorf("--port cannot be combined with --target (the target carries the port)")
 	}
 
 	// Parse target IP:port
 	parts := strings.Split(routeAddTarget, ":")
 	if len(parts) != 2 {
-		return fmt.Errorf("invalid target format: expected IP:port, got %s", routeAddTarget)
+		return "", 0, fmt.Errorf("invalid target format: expected IP:port, got %s", routeAddTarget)
 	}
-
-	targetIP := parts[0]
 	targetPort, err := strconv.ParseInt(parts[1], 10, 32)
 	if err != nil {
-		return fmt.Errorf("invalid port: %s", parts[1])
+		return "", 0, fmt.Errorf("invalid port: %s", parts[1])
+	}
+	return parts[0], int32(targetPort), nil
+}
+
+func runRouteAdd(cmd *cobra.Command, args []string) error {
+	domain := routeAddHostname
+	switch {
+	case len(args) == 1 && domain != "":
+		return fmt.Errorf("give the domain as the argument or --hostname, not both")
+	case len(args) == 1:
+		domain = args[0]
+	case domain == "":
+		return fmt.Errorf("a domain is required (argument or --hostname)")
+	}
+
+	if serverAddr == "" {
+		return fmt.Errorf("--server is required")
+	}
+
+	targetIP, targetPort, err := routeAddTargetFlags()
+	if err != nil {
+		return err
 	}
 
 	apiClient, err := newRouteClient()
@@ -68,7 +106,7 @@ func runRouteAdd(cmd *cobra.Command, args []string) error {
 	}
 	defer func() { _ = apiClient.Close() }()
 
-	route, err := apiClient.AddRoute(domain, targetIP, int32(targetPort), routeAddContainer, routeAddDescription)
+	route, err := apiClient.AddRoute(domain, targetIP, targetPort, routeAddContainer, routeAddDescription)
 	if err != nil {
 		return fmt.Errorf("failed to add route: %w", err)
 	}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRouteAddTargetFlags(t *testing.T) {
+	defer func() { routeAddTarget, routeAddContainer, routeAddPort = "", "", 0 }()
+	tests := []struct {
+		target, container string
+		port              int
+		wantIP            string
+		wantPort          int32
+		wantErr           string
+	}{
+		{container: "alice", port: 3000, wantPort: 3000},
+		{target: "10.0.3.136:8080", wantIP: "10.0.3.136", wantPort: 8080},
+		{target: "10.0.3.136:8080", container: "alice", wantIP: "10.0.3.136", wantPort: 8080},
+		{container: "alice", wantErr: "either --target"},
+		{port: 3000, wantErr: "either --target"},
+		{container: "alice", port: 70000, wantErr: "invalid port"},
+		{target: "10.0.3.136:8080", port: 3000, wantErr: "cannot be combined"},
+		{target: "10.0.3.136", wantErr: "invalid target format"},
+	}
+	for _, tt := range tests {
+		routeAddTarget, routeAddContainer, routeAddPort = tt.target, tt.container, tt.port
+		ip, port, err := routeAddTargetFlags()
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%+v: err = %v, want %q", tt, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || ip != tt.wantIP || port != tt.wantPort {
+			t.Errorf("%+v: got %q:%d, %v", tt, ip, port, err)
+		}
+	}
+}
+
+func TestRouteCertificateLabel(t *testing.T) {
+	until := timestamppb.New(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		cert *pb.RouteCertificate
+		want string
+	}{
+		{nil, "-"},
+		{&pb.RouteCertificate{Status: pb.CertificateStatus_CERTIFICATE_STATUS_PENDING}, "pending"},
+		{&pb.RouteCertificate{Status: pb.CertificateStatus_CERTIFICATE_STATUS_ISSUED, Subject: "app.example.com", NotAfter: until}, "issued until 2026-12-01"},
+		{&pb.RouteCertificate{Status: pb.CertificateStatus_CERTIFICATE_STATUS_ISSUED, Subject: "*.example.com", NotAfter: until}, "issued until 2026-12-01 (*.example.com)"},
+		{&pb.RouteCertificate{Status: pb.CertificateStatus_CERTIFICATE_STATUS_EXPIRED, Subject: "app.example.com", NotAfter: until}, "expired 2026-12-01"},
+	}
+	for _, tt := range tests {
+		if got := routeCertificateLabel(tt.cert); got != tt.want {
+			t.Errorf("routeCertificateLabel(%v) = %q, want %q", tt.cert, got, tt.want)
+		}
+	}
+}
@@ -64,12 +64,13 @@ func runRouteList(cmd *cobra.Command, args []string) error {
 }
 
 func printRouteTableFormat(routes []*pb.ProxyRoute, totalCount int32) {
-	fmt.Printf("%-35s %-25s %-8s %-12s\n", "DOMAIN", "TARGET", "PORT", "STATUS")
-	fmt.Printf("%-35s %-25s %-8s %-12s\n",
+	fmt.Printf("%-35s %-25s %-8s %-12s %s\n", "DOMAIN", "TARGET", "PORT", "STATUS", "CERTIFICATE")
+	fmt.Printf("%-35s %-25s %-8s %-12s %s\n",
 		strings.Repeat("-", 35),
 		strings.Repeat("-", 25),
 		strings.Repeat("-", 8),
-		strings.Repeat("-", 12))
+		strings.Repeat("-", 12),
+		strings.Repeat("-", 24))
 
 	if len(routes) == 0 {
 		fmt.Println("No routes configured.")
@@ -87,17 +88,40 @@ func printRouteTableFormat(routes []*pb.ProxyRoute, totalCount int32) {
 			domain = route.Subdomain
 		}
 
-		fmt.Printf("%-35s %-25s %-8d %-12s\n",
+		fmt.Printf("%-35s %-25s %-8d %-12s %s\n",
 			truncateRoute(domain, 35),
 			truncateRoute(route.ContainerIp, 25),
 			route.Port,
-			status)
+			status,
+			routeCertificateLabel(route.Certificate))
 	}
 
 	fmt.Println()
 	fmt.Printf("Total: %d routes\n", totalCount)
 }
 
+// routeCertificateLabel summarizes a route's certificate for the table:
+// "issued until 2026-12-01", noting a covering wildcard, or "pending" while
+// Caddy is still obtaining one. "-" when the daemon didn't report it.
+func routeCertificateLabel(cert *pb.RouteCertificate) string {
+	notAfter := cert.GetNotAfter().AsTime().Format("2006-01-02")
+	var label string
+	switch cert.GetStatus() {
+	case pb.CertificateStatus_CERTIFICATE_STATUS_PENDING:
+		return "pending"
+	case pb.CertificateStatus_CERTIFICATE_STATUS_ISSUED:
+		label = "issued until " + notAfter
+	case pb.CertificateStatus_CERTIFICATE_STATUS_EXPIRED:
+		label = "expired " + notAfter
+	default:
+		return "-"
+	}
+	if strings.HasPrefix(cert.GetSubject(), "*.") {
+		label += " (" + cert.GetSubject() + ")"
+	}
+	return label
+}
+
 func printRouteJSONFormat(routes []*pb.ProxyRoute) error {
 	output := map[string]interface{}{
 		"routes":      routes,
@@ -385,6 +385,7 @@ func buildDaemonAPI() (runner.DaemonAPI, runner.DaemonCreator, error) {
 				0,                      // idle-stop: runner boxes are long-lived; not auto-slept
 				0,                      // delete-after-stopped: not applicable to runner boxes
 				"",                     // storage-class: runner boxes use cluster default
+				"",                     // template: runner boxes are sized explicitly
 			)
 			if err != nil {
 				return "", "", err
@@ -423,6 +424,7 @@ func buildDaemonAPI() (runner.DaemonAPI, runner.DaemonCreator, error) {
 			0,                      // idle-stop: runner boxes are long-lived; not auto-slept
 			0,                      // delete-after-stopped: not applicable to runner boxes
 			"",                     // storage-class: runner boxes use cluster default
+			"",                     // template: runner boxes are sized explicitly
 		)
 		if err != nil {
 			return "", "", err
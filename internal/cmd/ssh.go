@@ -59,9 +59,27 @@ var (
 )
 
 var sshCmd = &cobra.Command{
-	Use:   "ssh",
-	Short: "Manage per-machine SSH keys registered with your account",
-	Long: `Register, list, and remove the SSH public key(s) the cloud knows about
+	Use:   "ssh [<username> [-- <ssh args>...]]",
+	Short: "SSH into a box, or manage the SSH keys registered with your account",
+	Long: `With a username, SSH into that user's box: the daemon supplies the
+sentinel address and the box-user to log in as, and the local key is
+checked against the keys registered with your account before ssh runs —
+a key the sentinel would reject is reported by fingerprint. Arguments
+after -- are passed to ssh. --print-command prints the ssh command
+instead; --proxyjump prints an ~/.ssh/config stanza.
+
+  containarium ssh alice                       # connect
+  containarium ssh alice -- -L 8080:localhost:8080
+  containarium ssh alice --print-command
+  containarium ssh alice --proxyjump >> ~/.ssh/config
+
+` + "`containarium ssh sessions <box>`" + ` lists who logged in to a box, from
+where and with which key (needs --traffic-ssh-log on the daemon).
+
+(A box named like a subcommand below — setup, list, remove, propagate,
+sessions — is reached with ` + "`containarium connect`" + `.)
+
+Register, list, and remove the SSH public key(s) the cloud knows about
 for your user. Once a key is registered with ` + "`containarium ssh setup`" + `,
 it gets installed in the authorized_keys file of every box you create
 (or, with ` + "`containarium ssh propagate`" + `, every box you already own).
@@ -79,6 +97,8 @@ Typical first-run, post-login flow:
 
 To register the same machine's key without re-running setup on every
 fresh box, run ` + "`containarium ssh propagate`" + ` once.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runSSHTo,
 }
 
 var sshSetupCmd = &cobra.Command{
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// `containarium ssh sessions` lists the logins the daemon recorded from
+// the sshd auth log (GET /v1/containers/{name}/ssh/sessions). It talks to
+// the daemon like `traffic`, not to the cloud like the key subcommands,
+// and shares traffic's --server/--format handling.
+var (
+	sshSessionsSince time.Duration
+	sshSessionsIP    string
+	sshSessionsLimit int32
+)
+
+var sshSessionsCmd = &cobra.Command{
+	Use:   "sessions <box>",
+	Short: "List who logged in to a box over SSH",
+	Long: `List the SSH logins to a box, newest first: the box user, the client
+address, the key that authenticated, and how much the session's
+connections uploaded and downloaded.
+
+  containarium ssh sessions alice-container --since 168h
+  containarium ssh sessions alice-container --ip 203.0.113.7
+
+Requires SSH session logging on the daemon (--traffic-ssh-log); the
+traffic columns also need traffic persistence.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSSHSessions,
+}
+
+func init() {
+	sshSessionsCmd.Flags().StringVar(&trafficServerFlag, "server", "", "server to query (default: the logged-in server)")
+	sshSessionsCmd.Flags().StringVarP(&trafficFormat, "format", "f", "table", "output format: table, json")
+	sshSessionsCmd.Flags().DurationVar(&sshSessionsSince, "since", 24*time.Hour, "look back this far (e.g. 1h, 168h)")
+	sshSessionsCmd.Flags().StringVar(&sshSessionsIP, "ip", "", "only sessions from this client address")
+	sshSessionsCmd.Flags().Int32Var(&sshSessionsLimit, "limit", 0, "max rows to return (0 = server default)")
+	sshCmd.AddCommand(sshSessionsCmd)
+}
+
+type sshSession struct {
+	ContainerName   string    `json:"containerName"`
+	BoxUser         string    `json:"boxUser"`
+	ClientIP        string    `json:"clientIp"`
+	ClientPort      uint32    `json:"clientPort"`
+	AuthMethod      string    `json:"authMethod"`
+	KeyType         string    `json:"keyType"`
+	KeyFingerprint  string    `json:"keyFingerprint"`
+	StartedAt       string    `json:"startedAt"`
+	EndedAt         string    `json:"endedAt"`
+	BytesSent       flexInt64 `json:"bytesSent"`
+	BytesReceived   flexInt64 `json:"bytesReceived"`
+	ConnectionCount int32     `json:"connectionCount"`
+}
+
+// credential is how the session authenticated: the key fingerprint for
+// public keys, otherwise the method.
+func (s *sshSession) credential() string {
+	return cmp.Or(s.KeyFingerprint, s.AuthMethod, "-")
+}
+
+type getSSHSessionsResp struct {
+	Sessions []sshSession `json:"sessions"`
+}
+
+func runSSHSessions(cmd *cobra.Command, args []string) error {
+	box := args[0]
+	q := url.Values{}
+	q.Set("startTime", time.Now().Add(-sshSessionsSince).UTC().Format(time.RFC3339))
+	if sshSessionsIP != "" {
+		q.Set("clientIp", sshSessionsIP)
+	}
+	if sshSessionsLimit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(sshSessionsLimit), 10))
+	}
+
+	var resp getSSHSessionsResp
+	if err := trafficGet(cmd.Context(), "/v1/containers/"+url.PathEscape(box)+"/ssh/sessions", q, &resp); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	if len(resp.Sessions) == 0 {
+		fmt.Fprintf(out, "No SSH sessions to %q in the last %s.\n", box, sshSessionsSince)
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "STARTED\tENDED\tUSER\tFROM\tKEY\tUPLOADED\tDOWNLOADED")
+	for _, s := range resp.Sessions {
+		// Bytes are from the box's side: what it received, the client
+		// uploaded.
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.StartedAt, cmp.Or(s.EndedAt, "(open)"), s.BoxUser,
+			hostPort(s.ClientIP, s.ClientPort), s.credential(),
+			humanBytes(int64(s.BytesReceived)), humanBytes(int64(s.BytesSent)))
+	}
+	_ = tw.Flush()
+	fmt.Fprintf(out, "\n%d SSH session(s).\n", len(resp.Sessions))
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/footprintai/containarium/internal/credentials"
+	"github.com/spf13/cobra"
+)
+
+func TestSSHSessions_RendersWhoAndTraffic(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sessions":[` +
+			`{"containerName":"alice-container","boxUser":"alice","clientIp":"203.0.113.7","clientPort":51234,` +
+			`"authMethod":"publickey","keyType":"ED25519","keyFingerprint":"SHA256:abc",` +
+			`"startedAt":"2026-03-01T10:00:00Z","bytesSent":"4096","bytesReceived":"2469606195","connectionCount":1},` +
+			`{"containerName":"alice-container","boxUser":"alice","clientIp":"203.0.113.7","clientPort":50000,` +
+			`"authMethod":"password","startedAt":"2026-03-01T09:00:00Z","endedAt":"2026-03-01T09:05:00Z"}]}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, sshSessionsIP = "", "table", "203.0.113.7"
+	t.Cleanup(func() { sshSessionsIP = "" })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runSSHSessions(cmd, []string{"alice-container"}); err != nil {
+		t.Fatalf("runSSHSessions: %v", err)
+	}
+	if gotPath != "/v1/containers/alice-container/ssh/sessions" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if !strings.Contains(gotQuery, "clientIp=203.0.113.7") || !strings.Contains(gotQuery, "startTime=") {
+		t.Errorf("query = %q", gotQuery)
+	}
+	out := buf.String()
+	for _, want := range []string{"SHA256:abc", "(open)", "203.0.113.7:51234", "2.3 GiB", "password", "2 SSH session(s)."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q; got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/footprintai/containarium/internal/connectcore"
+	"github.com/footprintai/containarium/internal/sshkey"
+	"github.com/spf13/cobra"
+)
+
+// `containarium ssh <username>` answers "how do I actually SSH into my
+// box": it asks the daemon where the box is reached (ssh_host — the
+// sentinel in sentinel-jump mode) and as whom (the box-user, which in
+// hosted mode is not the name you created the box under), checks that
+// the local key is one the sentinel will accept, then execs ssh.
+//
+// Unlike `containarium connect` it authorizes nothing: it uses the key
+// you registered with `containarium ssh setup`, and when that key is
+// missing from your account it says which fingerprint is missing rather
+// than letting the sentinel answer "Permission denied (publickey)".
+var (
+	sshToServer       string
+	sshToIdentity     string
+	sshToPrintCommand bool
+	sshToProxyJump    bool
+)
+
+func init() {
+	sshCmd.Flags().StringVar(&sshToServer, "server", "", "server to resolve the box on (default: your logged-in server)")
+	sshCmd.Flags().StringVarP(&sshToIdentity, "identity", "i", "", "private key to connect with (default: the key `ssh setup` registers, ~/.ssh/id_ed25519 first)")
+	sshCmd.Flags().BoolVar(&sshToPrintCommand, "print-command", false, "print the ssh command instead of running it")
+	sshCmd.Flags().BoolVar(&sshToProxyJump, "proxyjump", false, "print an ~/.ssh/config Host stanza for the box instead of connecting")
+	sshCmd.MarkFlagsMutuallyExclusive("print-command", "proxyjump")
+}
+
+func runSSHTo(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 || cmd.ArgsLenAtDash() == 0 {
+		return cmd.Help()
+	}
+	return sshTo(cmd, args, cmd.ArgsLenAtDash())
+}
+
+// sshTo is runSSHTo with the `--` position passed in (cobra only records
+// it while parsing), so tests can drive it directly.
+func sshTo(cmd *cobra.Command, args []string, dash int) error {
+	positional, extra := args, []string(nil)
+	if dash >= 0 {
+		positional, extra = args[:dash], args[dash:]
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("expected one username (ssh arguments go after --), got %q", positional)
+	}
+	box := positional[0]
+	if err := validateBoxName(box); err != nil {
+		return err
+	}
+	diag := cmd.ErrOrStderr()
+
+	server := pickSSHServer(sshToServer)
+	api, err := newConnectAPI(server)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c, err := api.GetContainer(ctx, box)
+	if err != nil {
+		return err
+	}
+	target, err := connectcore.BuildTarget(c, "", "", 22)
+	if err != nil {
+		return err
+	}
+	pubPath, pub, privPath, err := sshToKey()
+	if err != nil {
+		return err
+	}
+	fp, err := sshkey.Fingerprint(pub)
+	if err != nil {
+		return fmt.Errorf("fingerprint %s: %w", pubPath, err)
+	}
+	// Direct mode has no sentinel: the box's own authorized_keys decides,
+	// and the account's key list says nothing about it.
+	if viaSentinel := c.SshHost != "" && c.SshHost != c.Network.IpAddress; viaSentinel {
+		if err := checkSentinelKey(ctx, diag, server, target.Host, pubPath, fp); err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if sshToProxyJump {
+		writeSSHStanza(out, box, target, privPath)
+		return nil
+	}
+	if !connectcore.IsRunning(c.State) {
+		return fmt.Errorf("box %q is %s, not running — start it first (`containarium start %s`)",
+			box, connectcore.PrettyState(c.State), box)
+	}
+	sshArgs := append(connectcore.BuildSSHArgs(target, privPath, ""), extra...)
+	if sshToPrintCommand {
+		fmt.Fprintln(out, "ssh "+shellJoin(sshArgs))
+		return nil
+	}
+	fmt.Fprintf(diag, "→ %s@%s (key %s)\n", target.User, target.Host, fp)
+	return runSSH(sshArgs)
+}
+
+// sshToKey resolves the key pair to connect with: --identity (its public
+// half beside it as <identity>.pub), else the first key Locate finds —
+// the same search `ssh setup` registers from. It never generates a key: a
+// fresh one could not be registered yet.
+func sshToKey() (pubPath, pub, privPath string, err error) {
+	if sshToIdentity != "" {
+		pubPath = sshToIdentity + ".pub"
+		pub, err = sshkey.ReadPublicKey(pubPath)
+		if err != nil {
+			return "", "", "", fmt.Errorf("read public half of --identity: %w", err)
+		}
+		return pubPath, pub, sshToIdentity, nil
+	}
+	pubPath, pub, err = sshkey.Locate(sshkey.LocateOpts{})
+	if errors.Is(err, os.ErrNotExist) {
+		return "", "", "", fmt.Errorf("no SSH key found in ~/.ssh — run `containarium ssh setup` to create and register one")
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+	return pubPath, pub, strings.TrimSuffix(pubPath, ".pub"), nil
+}
+
+// checkSentinelKey verifies fp is among the keys registered with the
+// account, which is the set the sentinel admits. A server without the
+// key-listing endpoint (a self-hosted daemon) can't be checked; that is
+// a warning, not a failure.
+func checkSentinelKey(ctx context.Context, diag io.Writer, server, sentinel, pubPath, fp string) error {
+	client, err := newSSHHTTPClient(server)
+	if err != nil {
+		return err
+	}
+	keys, err := client.ListSSHKeys(ctx)
+	if isUnimplemented(err) {
+		fmt.Fprintf(diag, "⚠ %s does not list registered keys; not checking that %s is admitted by the sentinel\n", server, fp)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("list registered keys: %w", err)
+	}
+	var registered []string
+	for _, k := range keys {
+		kfp := k.Fingerprint
+		if kfp == "" && k.PublicKey != "" {
+			kfp, _ = sshkey.Fingerprint(k.PublicKey)
+		}
+		if kfp == fp {
+			return nil
+		}
+		registered = append(registered, fmt.Sprintf("%s (%s)", kfp, k.Name))
+	}
+	msg := fmt.Sprintf("key %s (%s) is not registered with your account, so the sentinel at %s will reject it", fp, pubPath, sentinel)
+	if len(registered) > 0 {
+		msg += "\n  registered: " + strings.Join(registered, ", ")
+		msg += "\n  connect with one of those via --identity, or register this one:"
+	} else {
+		msg += "\n  no keys are registered; register this one:"
+	}
+	return errors.New(msg + "\n    containarium ssh setup --key " + pubPath)
+}
+
+// writeSSHStanza prints a Host block matching `containarium ssh-config`:
+// HostName is the sentinel (or the box IP in direct mode) and User the
+// box-user the sentinel routes on.
+func writeSSHStanza(w io.Writer, box string, t connectcore.Target, identity string) {
+	fmt.Fprintf(w, "Host %s\n", box)
+	fmt.Fprintf(w, "    HostName %s\n", t.Host)
+	fmt.Fprintf(w, "    Port %d\n", t.Port)
+	fmt.Fprintf(w, "    User %s\n", t.User)
+	fmt.Fprintf(w, "    IdentityFile %s\n", identity)
+	fmt.Fprintln(w, "    IdentitiesOnly yes")
+}
+
+// shellJoin renders argv so it can be pasted into a POSIX shell.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a != "" && strings.IndexFunc(a, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("@%+=:,./_-", r))
+		}) < 0 {
+			quoted[i] = a
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/footprintai/containarium/internal/sshkey"
+)
+
+// wireBox serves GET /v1/containers/alice the way the daemon does in
+// sentinel-jump mode: the box-user differs from the name it was created
+// under, and ssh_host is the sentinel.
+func (f *fakeSSHKeysServer) wireBox(state string) {
+	f.mux.HandleFunc("/v1/containers/alice", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"container":{"username":"cld-a7b3c1d2","state":"` + state +
+			`","sshHost":"sentinel.example.com","network":{"ipAddress":"10.0.3.20"}}}`))
+	})
+}
+
+func setupSSHTo(t *testing.T, registerKey bool) (*fakeSSHKeysServer, string) {
+	t.Helper()
+	home := withSSHHome(t)
+	t.Cleanup(func() {
+		sshToServer, sshToIdentity = "", ""
+		sshToPrintCommand, sshToProxyJump = false, false
+	})
+	pubPath, pub, err := sshkey.Generate(sshkey.LocateOpts{HomeDir: home}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newFakeSSHKeysServer(t)
+	f.wireAdd()
+	f.wireBox("CONTAINER_STATE_RUNNING")
+	if registerKey {
+		fp, _ := sshkey.Fingerprint(pub)
+		f.keys = append(f.keys, sshkey.SSHKey{Name: "alice@laptop", PublicKey: pub, Fingerprint: fp})
+	}
+	seedTokenFor(t, home, f.srv.URL, "tok")
+	sshToServer = f.srv.URL
+	return f, pubPath
+}
+
+func TestSSHTo_PrintCommandUsesSentinelAndBoxUser(t *testing.T) {
+	_, pubPath := setupSSHTo(t, true)
+	sshToPrintCommand = true
+
+	var out bytes.Buffer
+	sshCmd.SetOut(&out)
+	if err := sshTo(sshCmd, []string{"alice", "-L", "8080:localhost:8080", "echo hi"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+	priv := strings.TrimSuffix(pubPath, ".pub")
+	for _, want := range []string{"ssh ", "-i " + priv, "cld-a7b3c1d2@sentinel.example.com -L 8080:localhost:8080 'echo hi'"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("command %q missing %q", got, want)
+		}
+	}
+}
+
+func TestSSHTo_UnregisteredKeyNamesFingerprint(t *testing.T) {
+	f, pubPath := setupSSHTo(t, false)
+	f.keys = append(f.keys, sshkey.SSHKey{Name: "old-laptop", Fingerprint: "SHA256:other"})
+	sshToPrintCommand = true
+
+	err := sshTo(sshCmd, []string{"alice"}, -1)
+	if err == nil {
+		t.Fatal("expected an unregistered-key error")
+	}
+	pub, _ := sshkey.ReadPublicKey(pubPath)
+	fp, _ := sshkey.Fingerprint(pub)
+	for _, want := range []string{fp, "sentinel.example.com", "SHA256:other (old-laptop)", "containarium ssh setup --key " + pubPath} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestSSHTo_ProxyJumpStanza(t *testing.T) {
+	setupSSHTo(t, true)
+	sshToProxyJump = true
+
+	var out bytes.Buffer
+	sshCmd.SetOut(&out)
+	if err := sshTo(sshCmd, []string{"alice"}, -1); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Host alice\n", "HostName sentinel.example.com\n", "User cld-a7b3c1d2\n", "IdentitiesOnly yes\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("stanza %q missing %q", out.String(), want)
+		}
+	}
+}
+
+func TestSSHTo_StoppedBox(t *testing.T) {
+	home := withSSHHome(t)
+	t.Cleanup(func() { sshToServer, sshToPrintCommand = "", false })
+	if _, _, err := sshkey.Generate(sshkey.LocateOpts{HomeDir: home}, false); err != nil {
+		t.Fatal(err)
+	}
+	f := newFakeSSHKeysServer(t) // no key-listing route: the check is skipped
+	f.wireBox("CONTAINER_STATE_STOPPED")
+	seedTokenFor(t, home, f.srv.URL, "tok")
+	sshToServer = f.srv.URL
+	sshToPrintCommand = true
+
+	var diag bytes.Buffer
+	sshCmd.SetErr(&diag)
+	err := sshTo(sshCmd, []string{"alice"}, -1)
+	if err == nil || !strings.Contains(err.Error(), "is stopped, not running") {
+		t.Fatalf("err = %v, want not-running", err)
+	}
+	if !strings.Contains(diag.String(), "does not list registered keys") {
+		t.Errorf("missing skipped-check warning: %q", diag.String())
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	got := shellJoin([]string{"-p", "2222", "bob@203.0.113.7", "ls -la", "it's", ""})
+	want := `-p 2222 bob@203.0.113.7 'ls -la' 'it'\''s' ''`
+	if got != want {
+		t.Errorf("shellJoin = %s, want %s", got, want)
+	}
+}
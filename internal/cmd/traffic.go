@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -23,6 +27,17 @@ import (
 //	GET /v1/containers/{name}/connections          → connections
 //	GET /v1/containers/{name}/connections/summary  → summary
 //	GET /v1/containers/{name}/traffic/history      → history
+//	GET /v1/traffic/history?username=…             → history --username
+//	GET /v1/containers/{name}/traffic/aggregates   → aggregates
+//	GET /v1/containers/{name}/traffic/dns          → dns
+//	GET /v1/containers/{name}/traffic/listeners    → listeners
+//	GET /v1/traffic/destinations?destination=…     → who-talked-to (admin)
+//	GET /v1/containers/{name}/traffic/usage        → usage --container
+//	GET /v1/traffic/usage                          → usage (admin)
+//	POST /v1/traffic/usage/backfill                → usage backfill (admin)
+//
+// `traffic report` combines the usage and aggregates routes (see
+// traffic_report.go).
 //
 // Server + token resolution mirrors the ssh/connect commands (pickSSHServer +
 // the bearer token auto-filled by root's PersistentPreRunE), so `traffic` works
@@ -35,6 +50,31 @@ var (
 	trafficDestPort   uint32
 	trafficLimit      int32
 	trafficSince      time.Duration
+	trafficOpen       bool
+	trafficState      string
+	trafficViolations bool
+	trafficInterval   string
+	trafficByDir      bool
+	trafficUsername   string
+	trafficMonth      string
+	trafficContainer  string
+	trafficQName      string
+	trafficAnswerIP   string
+	trafficMinBytes   string
+	trafficMinAge     time.Duration
+	// trafficChanges is listeners' own look-back: --since is shared by
+	// several subcommands with different defaults.
+	trafficChanges time.Duration
+	trafficTZ      string
+	// trafficSourceIP filters history by source address.
+	trafficSourceIP string
+	trafficMinRate  string
+	trafficWatch    time.Duration
+	trafficService  string
+	// trafficFresh skips the daemon's snapshot debounce.
+	trafficFresh bool
+	// trafficSeparateDNS reports DNS on its own summary line.
+	trafficSeparateDNS bool
 )
 
 var trafficCmd = &cobra.Command{
@@ -44,8 +84,15 @@ var trafficCmd = &cobra.Command{
 
 Subcommands:
   connections <box>   active connections (source/dest IP, port, proto, bytes)
-  summary <box>       per-box totals + top destinations
+  summary <box>       per-box totals, rates + top destinations
   history <box>       closed connections recorded in the traffic history
+                      (--include-open adds long-lived connections still open)
+  aggregates <box>    bytes per time bucket, split into ingress / egress
+  dns <box>           DNS queries the box made (when DNS logging is on)
+  listeners <box>     ports the box listens on, and which process owns each
+  who-talked-to <ip>  boxes that connected to an address or CIDR (admin)
+  usage               billed traffic per day for a month
+  report              weekly summary to paste into a PR or chat, or email
 
 Reads the platform daemon's TrafficService over its HTTP API, using the
 server + token you logged in with (override with --server / --token).`,
@@ -55,38 +102,233 @@ var trafficConnectionsCmd = &cobra.Command{
 	Use:     "connections <box>",
 	Aliases: []string{"conns", "ls"},
 	Short:   "List active connections for a box",
-	Args:    cobra.ExactArgs(1),
-	RunE:    runTrafficConnections,
+	Long: `List a box's active connections.
+
+With --min-bytes only connections that moved at least that much (sent +
+received) are listed, heaviest first; add --limit for the top talkers:
+
+  containarium traffic connections alice-container --min-bytes 10MB --limit 5
+
+--min-age lists only connections open at least that long, to spot stuck or
+unexpectedly long-lived flows:
+
+  containarium traffic connections alice-container --min-age 24h
+
+--service picks connections by the service on their destination port, as
+named in the SERVICE column; combine with --protocol for one side of a
+service that runs over both:
+
+  containarium traffic connections alice-container --service dns --protocol udp
+
+--watch redraws the list every 2s (or --watch=5s) with each connection's
+current throughput, measured between the daemon's last two snapshots; a
+connection shows "-" until it has been in two. --min-rate keeps only
+transfers at least that fast, fastest first:
+
+  containarium traffic connections alice-container --watch --min-rate 100KB
+
+The daemon reuses a conntrack snapshot up to --traffic-snapshot-debounce
+old (default 1s) between queries; --fresh dumps the table for this one:
+
+  containarium traffic connections alice-container --fresh`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrafficConnections,
 }
 
 var trafficSummaryCmd = &cobra.Command{
-	Use:   "summary <box>",
+	Use:   "summary [<box>]",
 	Short: "Show connection totals + top destinations for a box",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runTrafficSummary,
+	Long: `Show a box's active connections at a glance: the count split into
+TCP, UDP and ICMP, bytes sent and received, the current send and receive
+rates, and the top destinations and ports. Name the box as an argument or
+with --container:
+
+  containarium traffic summary --container alice-container
+
+Rates are summed over connections the daemon has seen in its last two
+snapshots, so they read zero right after it starts.
+
+--watch redraws the summary every 2s (or --watch=5s), like watch(1).
+--output is the same as --format:
+
+  containarium traffic summary alice-container --watch
+  containarium traffic summary alice-container --output json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTrafficSummary,
 }
 
 var trafficHistoryCmd = &cobra.Command{
-	Use:   "history <box>",
+	Use:   "history [<box>]",
 	Short: "List closed connections from the traffic history",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runTrafficHistory,
+	Long: `List closed connections from a box's traffic history.
+
+With --username the box can be omitted: history is looked up by the owning
+user (e.g. alice) instead of the container name (alice-container).
+
+Admins can omit both to search every box for an address, or for every
+connection outside the daemon's egress allowlist:
+
+  containarium traffic history --dest-ip 203.0.113.7 --since 720h
+  containarium traffic history --violations --since 168h`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if trafficUsername == "" && trafficDestIP == "" && trafficSourceIP == "" && !trafficViolations {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
+	RunE: runTrafficHistory,
+}
+
+var trafficAggregatesCmd = &cobra.Command{
+	Use:   "aggregates <box>",
+	Short: "Show traffic per time bucket with the ingress/egress split",
+	Long: `Show a box's recorded traffic per time bucket.
+
+Each row carries the ingress/egress split: bytes on connections initiated
+towards the box vs. by the box. With --by-direction every bucket is broken
+into one row per direction instead; connections recorded before direction
+was tracked show up as "-" rather than being assigned a side.
+
+Buckets follow UTC unless --tz names a zone; with --interval 1d each row
+is then one local calendar day:
+
+  containarium traffic aggregates alice-container --interval 1d --tz Europe/Berlin`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrafficAggregates,
+}
+
+var trafficDNSCmd = &cobra.Command{
+	Use:   "dns <box>",
+	Short: "List the DNS queries a box made",
+	Long: `List the names a box looked up through the host resolver and the
+addresses they resolved to, newest first. Use it to tell which domain a
+destination IP (often a shared CDN address) belongs to:
+
+  containarium traffic dns alice-container --ip 203.0.113.7
+
+Requires DNS logging on the daemon (--traffic-dns-log) and on the box
+(containarium traffic dns-logging).`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrafficDNS,
+}
+
+var trafficDNSLoggingCmd = &cobra.Command{
+	Use:   "dns-logging <username> on|off",
+	Short: "Turn DNS query logging on or off for a box",
+	Long: `Opt a box into (or out of) DNS query logging. The daemon follows the
+bridge resolver's log for every box (--traffic-dns-log) but only records the
+lookups of boxes that are opted in; the change takes effect on the traffic
+collector's next container refresh.
+
+  containarium traffic dns-logging alice on`,
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: []string{"on", "off"},
+	RunE:      runTrafficDNSLogging,
+}
+
+var trafficListenersCmd = &cobra.Command{
+	Use:   "listeners <box>",
+	Short: "List the ports a box is listening on",
+	Long: `List the TCP and UDP ports a box listens on, with the owning process and
+when each was first seen. The daemon only scans when started with
+--traffic-listener-interval, and rescans at that interval, so a port opened
+since the last scan may be missing.
+
+--changes adds the ports opened and closed over that window:
+
+  containarium traffic listeners alice-container --changes 24h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrafficListeners,
+}
+
+var trafficWhoTalkedToCmd = &cobra.Command{
+	Use:   "who-talked-to <ip|cidr>",
+	Short: "List the boxes that connected to an address or network (admin)",
+	Long: `Search every box's traffic history for connections to an address or
+network, one row per box with connection and byte totals and when it first
+and last talked to it:
+
+  containarium traffic who-talked-to 203.0.113.7 --since 720h
+  containarium traffic who-talked-to 203.0.113.0/24 --port 22
+
+Admin only, since the answer spans tenants.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrafficWhoTalkedTo,
+}
+
+var trafficUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show billed traffic per day for a month",
+	Long: `Show the daily traffic rollup used for billing: bytes in/out, bytes
+exchanged with peers outside the container network, connections and peak
+concurrent connections, one row per UTC day plus a monthly total.
+
+Without --container every box is listed (admin only).
+
+Examples:
+  containarium traffic usage --month 2025-05 --container alice-container
+  containarium traffic usage --month 2025-05`,
+	Args: cobra.NoArgs,
+	RunE: runTrafficUsage,
+}
+
+var trafficUsageBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Bill traffic recorded before the daily rollup existed (admin)",
+	Long: `One-shot admin command that adds the connection history recorded before
+the daily rollup was introduced to the usage table. The regular rollup job
+only bills connections recorded after the upgrade. Safe to repeat: bytes
+already billed are never counted again.`,
+	Args: cobra.NoArgs,
+	RunE: runTrafficUsageBackfill,
 }
 
 func init() {
 	rootCmd.AddCommand(trafficCmd)
-	trafficCmd.AddCommand(trafficConnectionsCmd, trafficSummaryCmd, trafficHistoryCmd)
+	trafficCmd.AddCommand(trafficConnectionsCmd, trafficSummaryCmd, trafficHistoryCmd, trafficAggregatesCmd, trafficDNSCmd, trafficDNSLoggingCmd, trafficListenersCmd, trafficWhoTalkedToCmd, trafficUsageCmd)
+	trafficUsageCmd.AddCommand(trafficUsageBackfillCmd)
 
-	for _, c := range []*cobra.Command{trafficConnectionsCmd, trafficSummaryCmd, trafficHistoryCmd} {
+	for _, c := range []*cobra.Command{trafficConnectionsCmd, trafficSummaryCmd, trafficHistoryCmd, trafficAggregatesCmd, trafficDNSCmd, trafficDNSLoggingCmd, trafficListenersCmd, trafficWhoTalkedToCmd, trafficUsageCmd, trafficUsageBackfillCmd} {
 		c.Flags().StringVar(&trafficServerFlag, "server", "", "server to query (default: the logged-in server)")
 		c.Flags().StringVarP(&trafficFormat, "format", "f", "table", "output format: table, json")
 	}
-	trafficConnectionsCmd.Flags().StringVar(&trafficProtocol, "protocol", "", "filter by protocol: tcp, udp, icmp")
+	trafficConnectionsCmd.Flags().StringVar(&trafficProtocol, "protocol", "", "filter by protocol: tcp, udp, icmp, sctp, icmpv6, dccp, udplite, gre")
 	trafficConnectionsCmd.Flags().StringVar(&trafficDestIP, "dest-ip", "", "filter by destination IP prefix")
 	trafficConnectionsCmd.Flags().Uint32Var(&trafficDestPort, "dest-port", 0, "filter by destination port")
+	trafficConnectionsCmd.Flags().StringVar(&trafficService, "service", "", "filter by well-known service on the destination port (e.g. dns, https)")
 	trafficConnectionsCmd.Flags().Int32Var(&trafficLimit, "limit", 0, "max rows to return (0 = server default)")
+	trafficConnectionsCmd.Flags().StringVar(&trafficMinBytes, "min-bytes", "", "only connections that moved at least this much, heaviest first (e.g. 500KB, 10MB)")
+	trafficConnectionsCmd.Flags().DurationVar(&trafficMinAge, "min-age", 0, "only connections open at least this long (e.g. 1h, 24h)")
+	trafficConnectionsCmd.Flags().StringVar(&trafficMinRate, "min-rate", "", "only connections moving at least this much per second, fastest first (e.g. 100KB)")
+	trafficConnectionsCmd.Flags().BoolVar(&trafficFresh, "fresh", false, "dump conntrack for this query instead of reusing the daemon's last snapshot (up to --traffic-snapshot-debounce old)")
+	trafficConnectionsCmd.Flags().DurationVar(&trafficWatch, "watch", 0, "redraw every interval with per-second rates (--watch alone: 2s)")
+	trafficConnectionsCmd.Flags().Lookup("watch").NoOptDefVal = "2s"
+	trafficSummaryCmd.Flags().DurationVar(&trafficWatch, "watch", 0, "redraw every interval (--watch alone: 2s)")
+	trafficSummaryCmd.Flags().Lookup("watch").NoOptDefVal = "2s"
+	trafficSummaryCmd.Flags().StringVar(&trafficContainer, "container", "", "box to summarize, instead of the argument")
+	trafficSummaryCmd.Flags().StringVar(&trafficFormat, "output", "table", "same as --format")
 	trafficHistoryCmd.Flags().DurationVar(&trafficSince, "since", time.Hour, "look back this far (e.g. 30m, 24h)")
 	trafficHistoryCmd.Flags().Int32Var(&trafficLimit, "limit", 0, "max rows to return (0 = server default)")
+	trafficHistoryCmd.Flags().BoolVar(&trafficOpen, "include-open", false, "also list long-lived connections that are still open")
+	trafficHistoryCmd.Flags().StringVar(&trafficUsername, "username", "", "look up history by owning username instead of box name")
+	trafficHistoryCmd.Flags().StringVar(&trafficDestIP, "dest-ip", "", "only connections to this IP; without a box or --username, searches every box (admin)")
+	trafficHistoryCmd.Flags().StringVar(&trafficSourceIP, "source-ip", "", "only connections from this IP; without a box or --username, searches every box (admin)")
+	trafficHistoryCmd.Flags().BoolVar(&trafficViolations, "violations", false, "only connections outside the daemon's egress allowlist (--traffic-egress-allowlist); without a box or --username, searches every box (admin)")
+	trafficHistoryCmd.Flags().StringVar(&trafficState, "state", "", "filter by TCP state at close, e.g. syn_sent (destination never answered), established, time_wait")
+	trafficAggregatesCmd.Flags().DurationVar(&trafficSince, "since", 24*time.Hour, "look back this far (e.g. 6h, 168h)")
+	trafficAggregatesCmd.Flags().StringVar(&trafficInterval, "interval", "1h", "bucket size: 1h, 6h, 12h, 1d")
+	trafficAggregatesCmd.Flags().BoolVar(&trafficByDir, "by-direction", false, "one row per direction in each bucket")
+	trafficAggregatesCmd.Flags().StringVar(&trafficTZ, "tz", "", "IANA time zone buckets align to, e.g. Europe/Berlin (default UTC)")
+	trafficDNSCmd.Flags().DurationVar(&trafficSince, "since", time.Hour, "look back this far (e.g. 30m, 24h)")
+	trafficDNSCmd.Flags().Int32Var(&trafficLimit, "limit", 0, "max rows to return (0 = server default)")
+	trafficDNSCmd.Flags().StringVar(&trafficQName, "name", "", "only names containing this, e.g. example.com")
+	trafficDNSCmd.Flags().StringVar(&trafficAnswerIP, "ip", "", "only lookups that resolved to this address")
+	trafficListenersCmd.Flags().DurationVar(&trafficChanges, "changes", 0, "also list ports opened and closed this far back (e.g. 24h)")
+	trafficWhoTalkedToCmd.Flags().DurationVar(&trafficSince, "since", 7*24*time.Hour, "look back this far (e.g. 24h, 720h)")
+	trafficWhoTalkedToCmd.Flags().Uint32Var(&trafficDestPort, "port", 0, "only connections to this destination port")
+	trafficSummaryCmd.Flags().BoolVar(&trafficSeparateDNS, "separate-dns", false, "report DNS lookups (port 53) on their own line instead of in the counts and top lists")
+	trafficUsageCmd.Flags().StringVar(&trafficMonth, "month", "", "month as YYYY-MM (default: the current month)")
+	trafficUsageCmd.Flags().StringVar(&trafficContainer, "container", "", "box to report on (default: all boxes, admin only)")
 }
 
 // flexInt64 decodes a proto3-JSON int64, which grpc-gateway emits as a QUOTED
@@ -117,16 +359,50 @@ type trafficConnection struct {
 	SourcePort    uint32    `json:"sourcePort"`
 	DestIP        string    `json:"destIp"`
 	DestPort      uint32    `json:"destPort"`
+	DestHostname  string    `json:"destHostname"`
+	ServiceName   string    `json:"serviceName"`
 	State         string    `json:"state"`
 	Direction     string    `json:"direction"`
 	BytesSent     flexInt64 `json:"bytesSent"`
 	BytesReceived flexInt64 `json:"bytesReceived"`
 	LastSeen      string    `json:"lastSeen"`
+	// Per-second rates, absent until the daemon has sampled the
+	// connection twice.
+	BytesSentPerSec     *float64 `json:"bytesSentPerSec,omitempty"`
+	BytesReceivedPerSec *float64 `json:"bytesReceivedPerSec,omitempty"`
 }
 
 type getConnectionsResp struct {
-	Connections []trafficConnection `json:"connections"`
-	TotalCount  int32               `json:"totalCount"`
+	Connections             []trafficConnection   `json:"connections"`
+	TotalCount              int32                 `json:"totalCount"`
+	Cache                   *containerCacheStatus `json:"cache,omitempty"`
+	SnapshotIntervalSeconds float64               `json:"snapshotIntervalSeconds"`
+}
+
+type containerCacheStatus struct {
+	Stale           bool   `json:"stale"`
+	BreakerOpen     bool   `json:"breakerOpen"`
+	LastRefreshTime string `json:"lastRefreshTime"`
+	LastError       string `json:"lastError"`
+}
+
+// staleWarning is the note printed under a connections table attributed
+// from an out-of-date container listing, or "" when it is current.
+func (s *containerCacheStatus) staleWarning() string {
+	if s == nil || !s.Stale {
+		return ""
+	}
+	msg := "Warning: the daemon cannot refresh its container list from Incus"
+	if s.BreakerOpen {
+		msg += " (circuit breaker open)"
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s.LastRefreshTime); err == nil {
+		msg += "; connections are attributed from the list as of " + t.Local().Format(time.DateTime)
+	}
+	if s.LastError != "" {
+		msg += "\n  last error: " + s.LastError
+	}
+	return msg
 }
 
 type destinationStats struct {
@@ -135,6 +411,14 @@ type destinationStats struct {
 	BytesTotal      flexInt64 `json:"bytesTotal"`
 }
 
+type serviceStats struct {
+	Port            uint32    `json:"port"`
+	Protocol        string    `json:"protocol"`
+	ServiceName     string    `json:"serviceName"`
+	ConnectionCount int32     `json:"connectionCount"`
+	BytesTotal      flexInt64 `json:"bytesTotal"`
+}
+
 type connectionSummaryResp struct {
 	ContainerName      string             `json:"containerName"`
 	ActiveConnections  int32              `json:"activeConnections"`
@@ -143,18 +427,63 @@ type connectionSummaryResp struct {
 	TotalBytesSent     flexInt64          `json:"totalBytesSent"`
 	TotalBytesReceived flexInt64          `json:"totalBytesReceived"`
 	TopDestinations    []destinationStats `json:"topDestinations"`
+	TopServices        []serviceStats     `json:"topServices"`
+	DNS                *dnsStats          `json:"dns"`
+	StateCounts        map[string]int32   `json:"stateCounts"`
+	ICMPConnections    int32              `json:"icmpConnections"`
+	BytesSentPerSec    float64            `json:"bytesSentPerSec"`
+	BytesRecvPerSec    float64            `json:"bytesReceivedPerSec"`
+}
+
+type getConnectionSummaryResp struct {
+	Summary connectionSummaryResp `json:"summary"`
+}
+
+// formatStateCounts renders state counts most common first, e.g.
+// "ESTABLISHED 12, TIME_WAIT 3, SYN_SENT 1".
+func formatStateCounts(counts map[string]int32) string {
+	states := make([]string, 0, len(counts))
+	for st := range counts {
+		states = append(states, st)
+	}
+	slices.SortFunc(states, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	parts := make([]string, len(states))
+	for i, st := range states {
+		parts[i] = fmt.Sprintf("%s %d", st, counts[st])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// dnsStats mirrors DNSStats, the summary's DNS line with --separate-dns.
+type dnsStats struct {
+	ConnectionCount int32     `json:"connectionCount"`
+	BytesTotal      flexInt64 `json:"bytesTotal"`
+	Resolvers       []string  `json:"resolvers"`
 }
 
 type historicalConnection struct {
+	ContainerName string    `json:"containerName"`
 	Protocol      string    `json:"protocol"`
+	FinalState    string    `json:"finalState"`
+	CloseReason   string    `json:"closeReason"`
 	SourceIP      string    `json:"sourceIp"`
 	SourcePort    uint32    `json:"sourcePort"`
 	DestIP        string    `json:"destIp"`
 	DestPort      uint32    `json:"destPort"`
+	DestHostname  string    `json:"destHostname"`
 	BytesSent     flexInt64 `json:"bytesSent"`
 	BytesReceived flexInt64 `json:"bytesReceived"`
 	StartedAt     string    `json:"startedAt"`
 	EndedAt       string    `json:"endedAt"`
+	// PolicyViolation marks egress outside the daemon's allowlist.
+	PolicyViolation bool `json:"policyViolation"`
+	// SSHSession is the login an ingress port-22 connection carried.
+	SSHSession *sshSession `json:"sshSession,omitempty"`
 }
 
 type queryHistoryResp struct {
@@ -162,18 +491,135 @@ type queryHistoryResp struct {
 	TotalCount  int32                  `json:"totalCount"`
 }
 
+type trafficAggregate struct {
+	Timestamp       string    `json:"timestamp"`
+	ContainerName   string    `json:"containerName,omitempty"`
+	DestIP          string    `json:"destIp,omitempty"`
+	DestHostname    string    `json:"destHostname,omitempty"`
+	Direction       string    `json:"direction"`
+	BytesSent       flexInt64 `json:"bytesSent"`
+	BytesReceived   flexInt64 `json:"bytesReceived"`
+	ConnectionCount int32     `json:"connectionCount"`
+	IngressBytes    flexInt64 `json:"ingressBytes"`
+	EgressBytes     flexInt64 `json:"egressBytes"`
+	RemoteCountry   string    `json:"remoteCountry,omitempty"`
+	RemoteASN       uint32    `json:"remoteAsn,omitempty"`
+	RemoteASOrg     string    `json:"remoteAsOrg,omitempty"`
+}
+
+type trafficAggregatesResp struct {
+	Aggregates []trafficAggregate `json:"aggregates"`
+}
+
+type dnsQuery struct {
+	Qname     string   `json:"qname"`
+	Qtype     string   `json:"qtype"`
+	Answers   []string `json:"answers"`
+	Status    string   `json:"status"`
+	Timestamp string   `json:"timestamp"`
+}
+
+type queryDNSHistoryResp struct {
+	Queries []dnsQuery `json:"queries"`
+}
+
+type toggleDNSLoggingResp struct {
+	Message           string `json:"message"`
+	DNSLoggingEnabled bool   `json:"dnsLoggingEnabled"`
+}
+
+type listeningPort struct {
+	Protocol    string `json:"protocol"`
+	Address     string `json:"address"`
+	Port        uint32 `json:"port"`
+	ProcessName string `json:"processName"`
+	Pid         int32  `json:"pid"`
+	FirstSeen   string `json:"firstSeen"`
+}
+
+type listenerChange struct {
+	Type      string        `json:"type"`
+	Listener  listeningPort `json:"listener"`
+	Timestamp string        `json:"timestamp"`
+}
+
+type getListeningPortsResp struct {
+	Listeners []listeningPort  `json:"listeners"`
+	ScannedAt string           `json:"scannedAt"`
+	Changes   []listenerChange `json:"changes"`
+}
+
+type destinationContact struct {
+	ContainerName   string    `json:"containerName"`
+	Username        string    `json:"username"`
+	ConnectionCount flexInt64 `json:"connectionCount"`
+	BytesSent       flexInt64 `json:"bytesSent"`
+	BytesReceived   flexInt64 `json:"bytesReceived"`
+	FirstSeen       string    `json:"firstSeen"`
+	LastSeen        string    `json:"lastSeen"`
+	DestIPs         []string  `json:"destIps"`
+}
+
+type queryByDestinationResp struct {
+	Containers []destinationContact `json:"containers"`
+}
+
+type dailyUsage struct {
+	ContainerName    string    `json:"containerName"`
+	Day              string    `json:"day"`
+	BytesIn          flexInt64 `json:"bytesIn"`
+	BytesOut         flexInt64 `json:"bytesOut"`
+	ExternalBytesIn  flexInt64 `json:"externalBytesIn"`
+	ExternalBytesOut flexInt64 `json:"externalBytesOut"`
+	ConnectionCount  flexInt64 `json:"connectionCount"`
+	PeakConnections  int32     `json:"peakConnections"`
+}
+
+// dailyUsageResp covers both usage responses: one box carries Total, all
+// boxes carry Totals.
+type dailyUsageResp struct {
+	Days   []dailyUsage `json:"days"`
+	Total  *dailyUsage  `json:"total,omitempty"`
+	Totals []dailyUsage `json:"totals,omitempty"`
+}
+
+type backfillUsageResp struct {
+	ConnectionsBilled flexInt64 `json:"connectionsBilled"`
+	DaysRecomputed    int32     `json:"daysRecomputed"`
+}
+
 // trafficGet performs an authenticated GET against the resolved traffic server
 // and decodes the JSON body into out.
 func trafficGet(ctx context.Context, path string, query url.Values, out any) error {
+	return trafficDo(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// trafficDo is trafficGet for any method. Non-GET requests send reqBody as
+// JSON, or an empty object when it is nil.
+func trafficDo(ctx context.Context, method, path string, query url.Values, reqBody, out any) error {
 	srv := pickSSHServer(trafficServerFlag) // creds-aware: explicit flag → default_server → cloud
 	u := strings.TrimRight(srv, "/") + path
 	if len(query) > 0 {
 		u += "?" + query.Encode()
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	var body io.Reader
+	if method != http.MethodGet {
+		b := []byte("{}")
+		if reqBody != nil {
+			var err error
+			if b, err = json.Marshal(reqBody); err != nil {
+				return err
+			}
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
 	if err != nil {
 		return err
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	if tok := resolveAuthToken(srv); tok != "" {
 		req.Header.Set("Authorization", "Bearer "+tok)
 	}
@@ -182,11 +628,11 @@ func trafficGet(ctx context.Context, path string, query url.Values, out any) err
 		return fmt.Errorf("request %s: %w", u, err)
 	}
 	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
+	respBody, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("api error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		return fmt.Errorf("api error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
 	}
-	if err := json.Unmarshal(body, out); err != nil {
+	if err := json.Unmarshal(respBody, out); err != nil {
 		return fmt.Errorf("decode response: %w", err)
 	}
 	return nil
@@ -204,19 +650,49 @@ func protocolEnum(s string) (string, error) {
 		return "PROTOCOL_UDP", nil
 	case "icmp":
 		return "PROTOCOL_ICMP", nil
+	case "sctp":
+		return "PROTOCOL_SCTP", nil
+	case "icmpv6":
+		return "PROTOCOL_ICMPV6", nil
+	case "dccp":
+		return "PROTOCOL_DCCP", nil
+	case "udplite":
+		return "PROTOCOL_UDPLITE", nil
+	case "gre":
+		return "PROTOCOL_GRE", nil
 	default:
-		return "", fmt.Errorf("unknown protocol %q (want tcp, udp, or icmp)", s)
+		return "", fmt.Errorf("unknown protocol %q (want tcp, udp, icmp, sctp, icmpv6, dccp, udplite, or gre)", s)
+	}
+}
+
+// connectionStates are the user-facing --state values, in enum order.
+var connectionStates = []string{"new", "established", "related", "time_wait", "close_wait", "fin_wait", "closed", "syn_sent", "syn_recv"}
+
+// stateEnum maps a user-facing TCP state ("syn_sent", "SYN-SENT") to the
+// proto enum NAME the grpc-gateway query param expects. Empty input → ""
+// (no filter).
+func stateEnum(s string) (string, error) {
+	v := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_")
+	if v == "" {
+		return "", nil
+	}
+	for _, st := range connectionStates {
+		if v == st {
+			return "CONNECTION_STATE_" + strings.ToUpper(st), nil
+		}
 	}
+	return "", fmt.Errorf("unknown state %q (want one of %s)", s, strings.Join(connectionStates, ", "))
 }
 
 // shortEnum trims the proto enum prefix for display: "PROTOCOL_TCP" → "tcp",
 // "TRAFFIC_DIRECTION_EGRESS" → "egress", "CONNECTION_STATE_ESTABLISHED" →
-// "established". An empty / unspecified value renders as "-".
+// "established", "JOB_RESULT_FAILED" → "failed". An empty / unspecified
+// value renders as "-".
 func shortEnum(v string) string {
 	if v == "" {
 		return "-"
 	}
-	for _, p := range []string{"PROTOCOL_", "TRAFFIC_DIRECTION_", "CONNECTION_STATE_"} {
+	for _, p := range []string{"PROTOCOL_", "TRAFFIC_DIRECTION_", "CONNECTION_STATE_", "JOB_TRIGGER_", "JOB_RESULT_"} {
 		v = strings.TrimPrefix(v, p)
 	}
 	if strings.HasSuffix(v, "UNSPECIFIED") {
@@ -241,52 +717,194 @@ func runTrafficConnections(cmd *cobra.Command, args []string) error {
 	if trafficDestPort != 0 {
 		q.Set("destPort", strconv.FormatUint(uint64(trafficDestPort), 10))
 	}
+	if trafficService != "" {
+		q.Set("serviceName", trafficService)
+	}
 	if trafficLimit != 0 {
 		q.Set("limit", strconv.FormatInt(int64(trafficLimit), 10))
 	}
+	if trafficMinBytes != "" {
+		n, err := parseSizeBytes(trafficMinBytes)
+		if err != nil {
+			return fmt.Errorf("invalid --min-bytes: %w", err)
+		}
+		q.Set("minBytes", strconv.FormatInt(n, 10))
+	}
+	if trafficMinAge > 0 {
+		q.Set("minAgeSeconds", strconv.FormatInt(int64(trafficMinAge/time.Second), 10))
+	}
+	if trafficMinRate != "" {
+		n, err := parseSizeBytes(trafficMinRate)
+		if err != nil {
+			return fmt.Errorf("invalid --min-rate: %w", err)
+		}
+		q.Set("minRateBps", strconv.FormatInt(n, 10))
+	}
+	if trafficFresh {
+		q.Set("fresh", "true")
+	}
 
-	var resp getConnectionsResp
-	if err := trafficGet(cmd.Context(), "/v1/containers/"+url.PathEscape(box)+"/connections", q, &resp); err != nil {
+	path := "/v1/containers/" + url.PathEscape(box) + "/connections"
+	out := cmd.OutOrStdout()
+	if trafficWatch <= 0 {
+		var resp getConnectionsResp
+		if err := trafficGet(cmd.Context(), path, q, &resp); err != nil {
+			return err
+		}
+		if trafficFormat == "json" {
+			return writeJSON(out, resp)
+		}
+		renderConnections(out, box, resp, trafficMinRate != "")
+		return nil
+	}
+
+	if err := checkTrafficWatch(); err != nil {
 		return err
 	}
+	ticker := time.NewTicker(trafficWatch)
+	defer ticker.Stop()
+	for {
+		var resp getConnectionsResp
+		if err := trafficGet(cmd.Context(), path, q, &resp); err != nil {
+			return err
+		}
+		// Clear the screen and home the cursor, like watch(1).
+		fmt.Fprint(out, "\033[H\033[2J")
+		fmt.Fprintf(out, "Every %s: %s at %s", trafficWatch, box, time.Now().Format(time.TimeOnly))
+		if resp.SnapshotIntervalSeconds > 0 {
+			fmt.Fprintf(out, ", rates over the last %.1fs", resp.SnapshotIntervalSeconds)
+		}
+		fmt.Fprint(out, "\n\n")
+		renderConnections(out, box, resp, true)
 
-	out := cmd.OutOrStdout()
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkTrafficWatch validates --watch for the commands that redraw.
+func checkTrafficWatch() error {
+	if trafficWatch < time.Second {
+		return fmt.Errorf("--watch interval must be at least 1s")
+	}
 	if trafficFormat == "json" {
-		return writeJSON(out, resp)
+		return fmt.Errorf("--watch only supports table output")
 	}
+	return nil
+}
+
+// renderConnections prints a connections table, with per-second rate
+// columns when withRates is set.
+func renderConnections(out io.Writer, box string, resp getConnectionsResp, withRates bool) {
 	if len(resp.Connections) == 0 {
 		fmt.Fprintf(out, "No active connections for %q.\n", box)
-		return nil
+		if w := resp.Cache.staleWarning(); w != "" {
+			fmt.Fprintln(out, w)
+		}
+		return
 	}
 	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
-	fmt.Fprintln(tw, "PROTO\tSOURCE\tDESTINATION\tDIR\tSTATE\tSENT\tRECV")
+	header := "PROTO\tSOURCE\tDESTINATION\tSERVICE\tDIR\tSTATE\tSENT\tRECV"
+	if withRates {
+		header += "\tSENT/S\tRECV/S"
+	}
+	fmt.Fprintln(tw, header)
 	for _, c := range resp.Connections {
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s",
 			shortEnum(c.Protocol),
 			hostPort(c.SourceIP, c.SourcePort),
-			hostPort(c.DestIP, c.DestPort),
+			namedHostPort(c.DestIP, c.DestPort, c.DestHostname),
+			cmp.Or(c.ServiceName, "-"),
 			shortEnum(c.Direction), shortEnum(c.State),
 			humanBytes(int64(c.BytesSent)), humanBytes(int64(c.BytesReceived)))
+		if withRates {
+			fmt.Fprintf(tw, "\t%s\t%s", humanRate(c.BytesSentPerSec), humanRate(c.BytesReceivedPerSec))
+		}
+		fmt.Fprintln(tw)
 	}
 	_ = tw.Flush()
 	fmt.Fprintf(out, "\n%d connection(s).\n", resp.TotalCount)
-	return nil
+	if w := resp.Cache.staleWarning(); w != "" {
+		fmt.Fprintln(out, w)
+	}
+}
+
+// humanRate renders a bytes-per-second rate, or "-" when none was
+// measured.
+func humanRate(r *float64) string {
+	if r == nil {
+		return "-"
+	}
+	return humanBytes(int64(*r)) + "/s"
 }
 
 func runTrafficSummary(cmd *cobra.Command, args []string) error {
-	box := args[0]
-	var resp connectionSummaryResp
-	if err := trafficGet(cmd.Context(), "/v1/containers/"+url.PathEscape(box)+"/connections/summary", nil, &resp); err != nil {
-		return err
+	box := trafficContainer
+	switch {
+	case len(args) == 1 && box != "" && args[0] != box:
+		return fmt.Errorf("box given twice: %q and --container %q", args[0], box)
+	case len(args) == 1:
+		box = args[0]
+	case box == "":
+		return fmt.Errorf("name the box to summarize, as an argument or with --container")
 	}
+	q := url.Values{}
+	if trafficSeparateDNS {
+		q.Set("separateDns", "true")
+	}
+	path := "/v1/containers/" + url.PathEscape(box) + "/connections/summary"
 
 	out := cmd.OutOrStdout()
-	if trafficFormat == "json" {
-		return writeJSON(out, resp)
+	if trafficWatch <= 0 {
+		var resp getConnectionSummaryResp
+		if err := trafficGet(cmd.Context(), path, q, &resp); err != nil {
+			return err
+		}
+		if trafficFormat == "json" {
+			return writeJSON(out, resp.Summary)
+		}
+		renderSummary(out, box, resp.Summary)
+		return nil
+	}
+
+	if err := checkTrafficWatch(); err != nil {
+		return err
 	}
+	ticker := time.NewTicker(trafficWatch)
+	defer ticker.Stop()
+	for {
+		var resp getConnectionSummaryResp
+		if err := trafficGet(cmd.Context(), path, q, &resp); err != nil {
+			return err
+		}
+		fmt.Fprint(out, "\033[H\033[2J")
+		fmt.Fprintf(out, "Every %s: %s at %s\n\n", trafficWatch, box, time.Now().Format(time.TimeOnly))
+		renderSummary(out, box, resp.Summary)
+
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// renderSummary prints a connection summary: the totals, then the top
+// destinations and ports as tables.
+func renderSummary(out io.Writer, box string, resp connectionSummaryResp) {
 	fmt.Fprintf(out, "Box:               %s\n", box)
-	fmt.Fprintf(out, "Active connections: %d (tcp %d, udp %d)\n", resp.ActiveConnections, resp.TCPConnections, resp.UDPConnections)
+	fmt.Fprintf(out, "Active connections: %d (tcp %d, udp %d, icmp %d)\n", resp.ActiveConnections, resp.TCPConnections, resp.UDPConnections, resp.ICMPConnections)
 	fmt.Fprintf(out, "Bytes sent / recv:  %s / %s\n", humanBytes(int64(resp.TotalBytesSent)), humanBytes(int64(resp.TotalBytesReceived)))
+	fmt.Fprintf(out, "Rate sent / recv:   %s / %s\n", humanRate(&resp.BytesSentPerSec), humanRate(&resp.BytesRecvPerSec))
+	if d := resp.DNS; d != nil {
+		fmt.Fprintf(out, "DNS lookups:        %d (%s) via %s\n", d.ConnectionCount, humanBytes(int64(d.BytesTotal)), strings.Join(d.Resolvers, ", "))
+	}
+	if len(resp.StateCounts) > 0 {
+		fmt.Fprintf(out, "States:             %s\n", formatStateCounts(resp.StateCounts))
+	}
 	if len(resp.TopDestinations) > 0 {
 		fmt.Fprintln(out, "\nTop destinations:")
 		tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
@@ -296,20 +914,62 @@ func runTrafficSummary(cmd *cobra.Command, args []string) error {
 		}
 		_ = tw.Flush()
 	}
-	return nil
+	if len(resp.TopServices) > 0 {
+		fmt.Fprintln(out, "\nTop ports:")
+		tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+		fmt.Fprintln(tw, "  PORT\tSERVICE\tCONNS\tBYTES")
+		for _, st := range resp.TopServices {
+			fmt.Fprintf(tw, "  %d/%s\t%s\t%d\t%s\n", st.Port, strings.ToLower(shortEnum(st.Protocol)), cmp.Or(st.ServiceName, "-"),
+				st.ConnectionCount, humanBytes(int64(st.BytesTotal)))
+		}
+		_ = tw.Flush()
+	}
 }
 
 func runTrafficHistory(cmd *cobra.Command, args []string) error {
-	box := args[0]
 	q := url.Values{}
+	path := "/v1/traffic/history"
+	box := trafficUsername
+	if len(args) == 1 {
+		box = args[0]
+		path = "/v1/containers/" + url.PathEscape(box) + "/traffic/history"
+	}
+	if trafficUsername != "" {
+		q.Set("username", trafficUsername)
+	}
+	if trafficDestIP != "" {
+		q.Set("destIp", trafficDestIP)
+	}
+	if trafficSourceIP != "" {
+		q.Set("sourceIp", trafficSourceIP)
+	}
+	if trafficViolations {
+		q.Set("policyViolationsOnly", "true")
+	}
+	// Without a box or username the search spans every box, so each row
+	// says whose it is.
+	fleet := box == ""
+	if fleet {
+		box = cmp.Or(trafficDestIP, trafficSourceIP, "any box")
+	}
 	// google.protobuf.Timestamp query params are RFC3339 via grpc-gateway.
 	q.Set("startTime", time.Now().Add(-trafficSince).UTC().Format(time.RFC3339))
 	if trafficLimit != 0 {
 		q.Set("limit", strconv.FormatInt(int64(trafficLimit), 10))
 	}
+	if trafficOpen {
+		q.Set("includeOpen", "true")
+	}
+	state, err := stateEnum(trafficState)
+	if err != nil {
+		return err
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
 
 	var resp queryHistoryResp
-	if err := trafficGet(cmd.Context(), "/v1/containers/"+url.PathEscape(box)+"/traffic/history", q, &resp); err != nil {
+	if err := trafficGet(cmd.Context(), path, q, &resp); err != nil {
 		return err
 	}
 
@@ -321,24 +981,373 @@ func runTrafficHistory(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(out, "No history for %q in the last %s.\n", box, trafficSince)
 		return nil
 	}
+	// The SSH column only appears when the daemon matched a login.
+	withSSH := slices.ContainsFunc(resp.Connections, func(c historicalConnection) bool { return c.SSHSession != nil })
+	// Likewise the POLICY column, for rows outside the egress allowlist.
+	withPolicy := slices.ContainsFunc(resp.Connections, func(c historicalConnection) bool { return c.PolicyViolation })
 	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
-	fmt.Fprintln(tw, "PROTO\tSOURCE\tDESTINATION\tSENT\tRECV\tENDED")
+	if fleet {
+		fmt.Fprint(tw, "BOX\t")
+	}
+	fmt.Fprint(tw, "PROTO\tSOURCE\tDESTINATION\tSTATE\tREASON\tSENT\tRECV\tENDED")
+	if withSSH {
+		fmt.Fprint(tw, "\tSSH")
+	}
+	if withPolicy {
+		fmt.Fprint(tw, "\tPOLICY")
+	}
+	fmt.Fprintln(tw)
 	for _, c := range resp.Connections {
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		ended := c.EndedAt
+		if ended == "" {
+			ended = "(open)" // checkpointed, still active
+		}
+		reason := c.CloseReason
+		if reason == "" {
+			reason = "-"
+		}
+		if fleet {
+			fmt.Fprintf(tw, "%s\t", c.ContainerName)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s",
 			shortEnum(c.Protocol),
 			hostPort(c.SourceIP, c.SourcePort),
-			hostPort(c.DestIP, c.DestPort),
+			namedHostPort(c.DestIP, c.DestPort, c.DestHostname),
+			shortEnum(c.FinalState), reason,
 			humanBytes(int64(c.BytesSent)), humanBytes(int64(c.BytesReceived)),
-			c.EndedAt)
+			ended)
+		if withSSH {
+			session := "-"
+			if s := c.SSHSession; s != nil {
+				session = s.BoxUser + " " + s.credential()
+			}
+			fmt.Fprintf(tw, "\t%s", session)
+		}
+		if withPolicy {
+			policy := "-"
+			if c.PolicyViolation {
+				policy = "violation"
+			}
+			fmt.Fprintf(tw, "\t%s", policy)
+		}
+		fmt.Fprintln(tw)
 	}
 	_ = tw.Flush()
 	fmt.Fprintf(out, "\n%d historical connection(s).\n", resp.TotalCount)
 	return nil
 }
 
+func runTrafficAggregates(cmd *cobra.Command, args []string) error {
+	box := args[0]
+	now := time.Now().UTC()
+	q := url.Values{}
+	q.Set("startTime", now.Add(-trafficSince).Format(time.RFC3339))
+	q.Set("endTime", now.Format(time.RFC3339))
+	q.Set("interval", trafficInterval)
+	if trafficByDir {
+		q.Set("groupByDirection", "true")
+	}
+	if trafficTZ != "" {
+		q.Set("timezone", trafficTZ)
+	}
+
+	var resp trafficAggregatesResp
+	if err := trafficGet(cmd.Context(), "/v1/containers/"+url.PathEscape(box)+"/traffic/aggregates", q, &resp); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	if len(resp.Aggregates) == 0 {
+		fmt.Fprintf(out, "No traffic recorded for %q in the last %s.\n", box, trafficSince)
+		return nil
+	}
+	// The server returns buckets newest first; keep that order.
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	if trafficByDir {
+		fmt.Fprintln(tw, "BUCKET\tDIR\tCONNS\tSENT\tRECV")
+	} else {
+		fmt.Fprintln(tw, "BUCKET\tCONNS\tSENT\tRECV\tINGRESS\tEGRESS")
+	}
+	// The server sends bucket starts in UTC; show them on the --tz clock
+	// the buckets were cut on.
+	loc, err := time.LoadLocation(trafficTZ)
+	if err != nil {
+		loc = time.UTC
+	}
+	var ingress, egress int64
+	for _, a := range resp.Aggregates {
+		ingress += int64(a.IngressBytes)
+		egress += int64(a.EgressBytes)
+		bucket := a.Timestamp
+		if ts, err := time.Parse(time.RFC3339, a.Timestamp); err == nil {
+			bucket = ts.In(loc).Format(time.RFC3339)
+		}
+		if trafficByDir {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n",
+				bucket, shortEnum(a.Direction), a.ConnectionCount,
+				humanBytes(int64(a.BytesSent)), humanBytes(int64(a.BytesReceived)))
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			bucket, a.ConnectionCount,
+			humanBytes(int64(a.BytesSent)), humanBytes(int64(a.BytesReceived)),
+			humanBytes(int64(a.IngressBytes)), humanBytes(int64(a.EgressBytes)))
+	}
+	_ = tw.Flush()
+	fmt.Fprintf(out, "\nIngress %s, egress %s over the last %s.\n", humanBytes(ingress), humanBytes(egress), trafficSince)
+	return nil
+}
+
+func runTrafficDNS(cmd *cobra.Command, args []string) error {
+	box := args[0]
+	q := url.Values{}
+	q.Set("startTime", time.Now().Add(-trafficSince).UTC().Format(time.RFC3339))
+	if trafficLimit != 0 {
+		q.Set("limit", strconv.FormatInt(int64(trafficLimit), 10))
+	}
+	if trafficQName != "" {
+		q.Set("qname", trafficQName)
+	}
+	if trafficAnswerIP != "" {
+		q.Set("answerIp", trafficAnswerIP)
+	}
+
+	var resp queryDNSHistoryResp
+	if err := trafficGet(cmd.Context(), "/v1/containers/"+url.PathEscape(box)+"/traffic/dns", q, &resp); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	if len(resp.Queries) == 0 {
+		fmt.Fprintf(out, "No DNS queries for %q in the last %s.\n", box, trafficSince)
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tTYPE\tNAME\tANSWER")
+	for _, d := range resp.Queries {
+		answer := strings.Join(d.Answers, ", ")
+		if answer == "" {
+			answer = d.Status
+		}
+		if answer == "" {
+			answer = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Timestamp, d.Qtype, d.Qname, answer)
+	}
+	_ = tw.Flush()
+	fmt.Fprintf(out, "\n%d DNS lookup(s).\n", len(resp.Queries))
+	return nil
+}
+
+func runTrafficDNSLogging(cmd *cobra.Command, args []string) error {
+	username := args[0]
+	var enabled bool
+	switch args[1] {
+	case "on":
+		enabled = true
+	case "off":
+	default:
+		return fmt.Errorf("want on or off, got %q", args[1])
+	}
+
+	var resp toggleDNSLoggingResp
+	body := map[string]any{"enabled": enabled}
+	if err := trafficDo(cmd.Context(), http.MethodPost, "/v1/containers/"+url.PathEscape(username)+"/dns-logging", nil, body, &resp); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	fmt.Fprintf(out, "✓ %s for %s\n", resp.Message, username)
+	return nil
+}
+
+func runTrafficListeners(cmd *cobra.Command, args []string) error {
+	box := args[0]
+	q := url.Values{}
+	if trafficChanges > 0 {
+		q.Set("historySince", time.Now().Add(-trafficChanges).UTC().Format(time.RFC3339))
+	}
+
+	var resp getListeningPortsResp
+	if err := trafficGet(cmd.Context(), "/v1/containers/"+url.PathEscape(box)+"/traffic/listeners", q, &resp); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	if resp.ScannedAt == "" {
+		fmt.Fprintf(out, "%q has not been scanned yet (stopped, or started less than a scan interval ago).\n", box)
+	} else {
+		tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+		fmt.Fprintln(tw, "PROTO\tADDRESS\tPROCESS\tPID\tFIRST SEEN")
+		for _, l := range resp.Listeners {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", shortEnum(l.Protocol), listenAddr(l), orDash(l.ProcessName), pidOrDash(l.Pid), l.FirstSeen)
+		}
+		_ = tw.Flush()
+		fmt.Fprintf(out, "\n%d listening port(s) as of %s.\n", len(resp.Listeners), resp.ScannedAt)
+	}
+
+	if trafficChanges > 0 {
+		if len(resp.Changes) == 0 {
+			fmt.Fprintf(out, "\nNo ports opened or closed in the last %s.\n", trafficChanges)
+			return nil
+		}
+		fmt.Fprintln(out)
+		tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+		fmt.Fprintln(tw, "TIME\tCHANGE\tPROTO\tADDRESS\tPROCESS")
+		for _, c := range resp.Changes {
+			change := strings.ToLower(strings.TrimPrefix(c.Type, "LISTENER_CHANGE_TYPE_"))
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Timestamp, change, shortEnum(c.Listener.Protocol), listenAddr(c.Listener), orDash(c.Listener.ProcessName))
+		}
+		_ = tw.Flush()
+	}
+	return nil
+}
+
+// listenAddr renders a listener's bind address, bracketing IPv6:
+// "0.0.0.0:8080", "[::]:22".
+func listenAddr(l listeningPort) string {
+	return net.JoinHostPort(l.Address, strconv.FormatUint(uint64(l.Port), 10))
+}
+
+func pidOrDash(pid int32) string {
+	if pid == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(int64(pid), 10)
+}
+
+func runTrafficWhoTalkedTo(cmd *cobra.Command, args []string) error {
+	dest := args[0]
+	q := url.Values{}
+	q.Set("destination", dest)
+	q.Set("startTime", time.Now().Add(-trafficSince).UTC().Format(time.RFC3339))
+	if trafficDestPort != 0 {
+		q.Set("destPort", strconv.FormatUint(uint64(trafficDestPort), 10))
+	}
+
+	var resp queryByDestinationResp
+	if err := trafficGet(cmd.Context(), "/v1/traffic/destinations", q, &resp); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	if len(resp.Containers) == 0 {
+		fmt.Fprintf(out, "No box connected to %s in the last %s.\n", dest, trafficSince)
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "BOX\tCONNS\tSENT\tRECV\tFIRST SEEN\tLAST SEEN\tADDRESSES")
+	for _, c := range resp.Containers {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", c.ContainerName, c.ConnectionCount,
+			humanBytes(int64(c.BytesSent)), humanBytes(int64(c.BytesReceived)),
+			c.FirstSeen, c.LastSeen, strings.Join(c.DestIPs, ", "))
+	}
+	_ = tw.Flush()
+	fmt.Fprintf(out, "\n%d box(es) connected to %s.\n", len(resp.Containers), dest)
+	return nil
+}
+
+func runTrafficUsage(cmd *cobra.Command, _ []string) error {
+	if trafficMonth != "" {
+		if _, err := time.Parse("2006-01", trafficMonth); err != nil {
+			return fmt.Errorf("invalid --month %q (want YYYY-MM)", trafficMonth)
+		}
+	}
+	q := url.Values{}
+	if trafficMonth != "" {
+		q.Set("month", trafficMonth)
+	}
+	path := "/v1/traffic/usage"
+	if trafficContainer != "" {
+		path = "/v1/containers/" + url.PathEscape(trafficContainer) + "/traffic/usage"
+	}
+
+	var resp dailyUsageResp
+	if err := trafficGet(cmd.Context(), path, q, &resp); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	month := trafficMonth
+	if month == "" {
+		month = "this month"
+	}
+	if len(resp.Days) == 0 {
+		fmt.Fprintf(out, "No traffic usage recorded for %s.\n", month)
+		return nil
+	}
+
+	all := trafficContainer == ""
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	if all {
+		fmt.Fprint(tw, "BOX\t")
+	}
+	fmt.Fprintln(tw, "DAY\tIN\tOUT\tEXT IN\tEXT OUT\tCONNS\tPEAK")
+	row := func(box, day string, u dailyUsage) {
+		if all {
+			fmt.Fprintf(tw, "%s\t", box)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%d\n", day,
+			humanBytes(int64(u.BytesIn)), humanBytes(int64(u.BytesOut)),
+			humanBytes(int64(u.ExternalBytesIn)), humanBytes(int64(u.ExternalBytesOut)),
+			u.ConnectionCount, u.PeakConnections)
+	}
+	for _, d := range resp.Days {
+		row(d.ContainerName, d.Day, d)
+	}
+	if all {
+		for _, t := range resp.Totals {
+			row(t.ContainerName, "TOTAL", t)
+		}
+	} else if resp.Total != nil {
+		row("", "TOTAL", *resp.Total)
+	}
+	_ = tw.Flush()
+	return nil
+}
+
+func runTrafficUsageBackfill(cmd *cobra.Command, _ []string) error {
+	var resp backfillUsageResp
+	if err := trafficDo(cmd.Context(), http.MethodPost, "/v1/traffic/usage/backfill", nil, nil, &resp); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	fmt.Fprintf(out, "✓ Billed %d connection(s); recomputed peaks for %d day(s).\n", resp.ConnectionsBilled, resp.DaysRecomputed)
+	return nil
+}
+
 // --- small display helpers (writeJSON + humanBytes are shared, see runner.go /
 // backup_create.go) ---
 
+// namedHostPort is hostPort followed by the name the box resolved the address
+// from, when DNS logging supplied one: "203.0.113.7:443 (api.example.com)".
+func namedHostPort(ip string, port uint32, hostname string) string {
+	if hostname == "" {
+		return hostPort(ip, port)
+	}
+	return hostPort(ip, port) + " (" + hostname + ")"
+}
+
 // hostPort renders "ip:port", omitting the port when 0 (ICMP / unset).
 func hostPort(ip string, port uint32) string {
 	if ip == "" {
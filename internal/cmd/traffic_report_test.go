@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/credentials"
+	"github.com/spf13/cobra"
+)
+
+func TestParseReportWindow(t *testing.T) {
+	for in, want := range map[string]int{"7d": 7, "30d": 30, "168h": 7} {
+		if got, err := parseReportWindow(in); err != nil || got != want {
+			t.Errorf("parseReportWindow(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0d", "-1d", "week", "36h"} {
+		if _, err := parseReportWindow(in); err == nil {
+			t.Errorf("parseReportWindow(%q): want an error", in)
+		}
+	}
+}
+
+func TestBuildTrafficReport(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	usage := []dailyUsage{
+		{ContainerName: "alice-container", Day: "2026-10-10", BytesIn: 1000, BytesOut: 3000, ConnectionCount: 4, PeakConnections: 2},
+		{ContainerName: "alice-container", Day: "2026-10-16", BytesIn: 500, ConnectionCount: 1, PeakConnections: 5},
+		{ContainerName: "bob-container", Day: "2026-10-12", BytesOut: 10000, ConnectionCount: 2, PeakConnections: 1},
+		{ContainerName: "alice-container", Day: "2026-10-05", BytesIn: 2000, ConnectionCount: 8},
+		{ContainerName: "alice-container", Day: "2026-09-01", BytesIn: 1 << 30}, // before both windows
+	}
+	aggs := []trafficAggregate{
+		{DestIP: "203.0.113.7", Direction: "TRAFFIC_DIRECTION_EGRESS", BytesSent: 100, BytesReceived: 900, ConnectionCount: 3},
+		{DestIP: "203.0.113.7", DestHostname: "api.example.com", Direction: "TRAFFIC_DIRECTION_EGRESS", BytesSent: 1000, ConnectionCount: 1},
+		{DestIP: "198.51.100.2", Direction: "TRAFFIC_DIRECTION_EGRESS", BytesSent: 50, ConnectionCount: 1},
+		{DestIP: "10.0.3.20", Direction: "TRAFFIC_DIRECTION_INGRESS", BytesSent: 1 << 20, ConnectionCount: 9},
+	}
+	alerts := []alertmanagerAlert{
+		{Labels: map[string]string{"container_name": "alice-container"}, StartsAt: "2026-10-14T08:00:00Z"},
+		{Labels: map[string]string{"container_name": "alice-container"}, StartsAt: "2026-10-01T08:00:00Z"},
+		{Labels: map[string]string{"container_name": "bob-container"}, StartsAt: "2026-10-15T08:00:00Z"},
+	}
+
+	r := buildTrafficReport("", 7, now, usage, aggs, alerts)
+	if r.From != "2026-10-10" || r.To != "2026-10-16" || len(r.Days) != 7 {
+		t.Fatalf("window %s..%s with %d days, want 2026-10-10..2026-10-16 with 7", r.From, r.To, len(r.Days))
+	}
+	if r.Totals.BytesIn != 1500 || r.Totals.BytesOut != 13000 || r.Totals.Connections != 7 || r.Totals.PeakConnections != 5 {
+		t.Errorf("totals = %+v", r.Totals)
+	}
+	if r.Previous.BytesIn != 2000 || r.Previous.Connections != 8 {
+		t.Errorf("previous = %+v", r.Previous)
+	}
+	if r.Days[2].Day != "2026-10-12" || r.Days[2].BytesOut != 10000 || r.Days[1].BytesIn+r.Days[1].BytesOut != 0 {
+		t.Errorf("days = %+v", r.Days)
+	}
+	if len(r.Boxes) != 2 || r.Boxes[0].Container != "bob-container" {
+		t.Errorf("boxes = %+v, want bob first", r.Boxes)
+	}
+	if len(r.TopDestinations) != 2 {
+		t.Fatalf("top destinations = %+v, want the two egress ones", r.TopDestinations)
+	}
+	if d := r.TopDestinations[0]; d.IP != "203.0.113.7" || d.Hostname != "api.example.com" || d.Bytes != 2000 || d.Connections != 4 {
+		t.Errorf("top destination = %+v", d)
+	}
+	if r.Alerts == nil || *r.Alerts != 2 {
+		t.Errorf("alerts = %v, want the 2 started in the window", r.Alerts)
+	}
+
+	one := buildTrafficReport("alice-container", 7, now, usage[:2], nil, alerts)
+	if len(one.Boxes) != 0 || one.Alerts == nil || *one.Alerts != 1 {
+		t.Errorf("single-box report: boxes %v, alerts %v", one.Boxes, one.Alerts)
+	}
+	if unknown := buildTrafficReport("alice-container", 7, now, nil, nil, nil); unknown.Alerts != nil {
+		t.Errorf("alerts = %d without Alertmanager, want unknown", *unknown.Alerts)
+	}
+}
+
+func TestBuildGeoBreakdown(t *testing.T) {
+	aggs := []trafficAggregate{
+		{RemoteCountry: "DE", RemoteASN: 3320, RemoteASOrg: "Deutsche Telekom AG", BytesSent: 5000, BytesReceived: 10, ConnectionCount: 2},
+		{RemoteCountry: "DE", RemoteASN: 3320, BytesSent: 1000, ConnectionCount: 1},
+		{RemoteCountry: "US", RemoteASN: 15169, RemoteASOrg: "Google LLC", BytesSent: 9000, ConnectionCount: 4},
+		{RemoteCountry: "NL", BytesSent: 10},
+		{BytesSent: 1 << 30, ConnectionCount: 99}, // internal or not annotated
+	}
+	countries, networks := buildGeoBreakdown(aggs)
+	if len(countries) != 3 || countries[0].Country != "US" || countries[1].Country != "DE" || countries[1].BytesOut != 6000 || countries[1].Connections != 3 {
+		t.Errorf("countries = %+v", countries)
+	}
+	if len(networks) != 2 || networks[1].label() != "AS3320 Deutsche Telekom AG" || networks[1].BytesOut != 6000 {
+		t.Errorf("networks = %+v", networks)
+	}
+
+	r := trafficReport{Countries: countries, Networks: networks}
+	var text, md bytes.Buffer
+	renderReportText(&text, r)
+	renderReportMarkdown(&md, r)
+	if !strings.Contains(text.String(), "Traffic by country") || !strings.Contains(text.String(), "AS15169 Google LLC") {
+		t.Errorf("text report lacks the breakdown:\n%s", text.String())
+	}
+	if !strings.Contains(md.String(), "| DE | 5.9 KiB |") {
+		t.Errorf("markdown report lacks the country row:\n%s", md.String())
+	}
+
+	if c, n := buildGeoBreakdown(aggs[4:]); c != nil || n != nil {
+		t.Errorf("unannotated traffic broke down into %+v, %+v", c, n)
+	}
+}
+
+func TestReportChange(t *testing.T) {
+	for _, tc := range []struct {
+		cur, prev int64
+		want      string
+	}{{0, 0, "-"}, {5, 0, "new"}, {150, 100, "+50%"}, {50, 100, "-50%"}, {100, 100, "+0%"}} {
+		if got := reportChange(tc.cur, tc.prev); got != tc.want {
+			t.Errorf("reportChange(%d, %d) = %q, want %q", tc.cur, tc.prev, got, tc.want)
+		}
+	}
+}
+
+func TestTrafficReport_EndToEnd(t *testing.T) {
+	home := withTempHome(t)
+
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/traffic/usage") && r.URL.Query().Get("month") == "2026-10":
+			_, _ = w.Write([]byte(`{"days":[{"containerName":"alice-container","day":"2026-10-02","bytesIn":"2048","bytesOut":"1024","connectionCount":"3","peakConnections":2}]}`))
+		case strings.HasSuffix(r.URL.Path, "/traffic/usage"):
+			_, _ = w.Write([]byte(`{"days":[{"containerName":"alice-container","day":"2026-09-24","bytesIn":"1024","connectionCount":"1"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/traffic/aggregates"):
+			_, _ = w.Write([]byte(`{"aggregates":[{"timestamp":"2026-10-02T00:00:00Z","destIp":"203.0.113.7","destHostname":"api.example.com","direction":"TRAFFIC_DIRECTION_EGRESS","bytesSent":"4096","connectionCount":2}]}`))
+		default:
+			http.NotFound(w, r) // no Alertmanager
+		}
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	// The window straddles a month boundary, so both months' usage is read.
+	now := time.Date(2026, 10, 3, 9, 0, 0, 0, time.UTC)
+	trafficServerFlag = ""
+	report, err := fetchTrafficReport(context.Background(), "alice-container", 7, now)
+	if err != nil {
+		t.Fatalf("fetchTrafficReport: %v", err)
+	}
+	for _, want := range []string{
+		"/v1/containers/alice-container/traffic/usage?month=2026-09",
+		"/v1/containers/alice-container/traffic/usage?month=2026-10",
+	} {
+		if !slices.Contains(requests, want) {
+			t.Errorf("requests %v missing %s", requests, want)
+		}
+	}
+	if report.Totals.BytesIn != 2048 || report.Previous.BytesIn != 1024 || report.Alerts != nil {
+		t.Errorf("report = %+v", report)
+	}
+
+	var buf bytes.Buffer
+	if err := renderTrafficReport(&buf, report, "md"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Traffic report for alice-container, 2026-09-27 to 2026-10-03",
+		"| In | 2.0 KiB | 1.0 KiB | +100% |",
+		"| 1 | `203.0.113.7` (api.example.com) | 2 | 4.0 KiB |",
+		"Alerts: unknown",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q; got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunTrafficReport_RejectsBadFlags(t *testing.T) {
+	t.Cleanup(func() {
+		trafficReportWindow, trafficReportFormat, trafficReportEmail, trafficReportSMTP = "7d", "text", "", ""
+	})
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	trafficReportWindow, trafficReportFormat = "7d", "html"
+	if err := runTrafficReport(cmd, nil); err == nil || !strings.Contains(err.Error(), "--format") {
+		t.Errorf("format html: err = %v", err)
+	}
+	trafficReportFormat, trafficReportEmail, trafficReportSMTP = "md", "lead@example.com", ""
+	if err := runTrafficReport(cmd, nil); err == nil || !strings.Contains(err.Error(), "--smtp-url") {
+		t.Errorf("email without smtp: err = %v", err)
+	}
+}
+
+func TestReportEmail(t *testing.T) {
+	date := time.Date(2026, 10, 16, 17, 0, 0, 0, time.UTC)
+	msg := string(reportEmail("reports@example.com", []string{"alice@example.com", "bob@example.com"}, "Traffic report for all boxes", "md", "## Report\nline\n", date))
+	for _, want := range []string{
+		"From: reports@example.com\r\n",
+		"To: alice@example.com, bob@example.com\r\n",
+		"Subject: Traffic report for all boxes\r\n",
+		"Content-Type: text/markdown; charset=utf-8\r\n\r\n## Report\r\nline\r\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q; got:\n%s", want, msg)
+		}
+	}
+
+	if err := sendReportEmail("smtp://mail.example.com", "", []string{"alice@example.com"}, "s", "text", "b"); err == nil || !strings.Contains(err.Error(), "--email-from") {
+		t.Errorf("no sender: err = %v", err)
+	}
+	if err := sendReportEmail("http://mail.example.com", "r@example.com", []string{"alice@example.com"}, "s", "text", "b"); err == nil {
+		t.Error("want an error for a non-SMTP URL")
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/footprintai/containarium/internal/credentials"
 	"github.com/spf13/cobra"
@@ -29,18 +31,31 @@ func TestFlexInt64_UnmarshalsQuotedAndBare(t *testing.T) {
 }
 
 func TestProtocolEnum(t *testing.T) {
-	cases := map[string]string{"": "", "tcp": "PROTOCOL_TCP", "UDP": "PROTOCOL_UDP", "icmp": "PROTOCOL_ICMP"}
+	cases := map[string]string{"": "", "tcp": "PROTOCOL_TCP", "UDP": "PROTOCOL_UDP", "icmp": "PROTOCOL_ICMP", "sctp": "PROTOCOL_SCTP", "ICMPv6": "PROTOCOL_ICMPV6"}
 	for in, want := range cases {
 		got, err := protocolEnum(in)
 		if err != nil || got != want {
 			t.Errorf("protocolEnum(%q) = (%q, %v), want %q", in, got, err, want)
 		}
 	}
-	if _, err := protocolEnum("sctp"); err == nil {
+	if _, err := protocolEnum("esp"); err == nil {
 		t.Error("expected error for unknown protocol")
 	}
 }
 
+func TestStateEnum(t *testing.T) {
+	cases := map[string]string{"": "", "syn_sent": "CONNECTION_STATE_SYN_SENT", "SYN-SENT": "CONNECTION_STATE_SYN_SENT", "time_wait": "CONNECTION_STATE_TIME_WAIT"}
+	for in, want := range cases {
+		got, err := stateEnum(in)
+		if err != nil || got != want {
+			t.Errorf("stateEnum(%q) = (%q, %v), want %q", in, got, err, want)
+		}
+	}
+	if _, err := stateEnum("listening"); err == nil {
+		t.Error("expected error for unknown state")
+	}
+}
+
 func TestShortEnum(t *testing.T) {
 	cases := map[string]string{
 		"PROTOCOL_TCP":                 "tcp",
@@ -56,6 +71,13 @@ func TestShortEnum(t *testing.T) {
 	}
 }
 
+func TestFormatStateCounts(t *testing.T) {
+	got := formatStateCounts(map[string]int32{"TIME_WAIT": 3, "ESTABLISHED": 12, "SYN_SENT": 3})
+	if want := "ESTABLISHED 12, SYN_SENT 3, TIME_WAIT 3"; got != want {
+		t.Errorf("formatStateCounts = %q, want %q", got, want)
+	}
+}
+
 func TestHostPort(t *testing.T) {
 	cases := []struct {
 		ip   string
@@ -99,8 +121,12 @@ func TestTrafficConnections_EndToEnd(t *testing.T) {
 	})
 
 	trafficServerFlag, trafficFormat, trafficProtocol = "", "table", "tcp"
-	trafficDestIP, trafficDestPort, trafficLimit = "", 0, 0
-	t.Cleanup(func() { trafficServerFlag, trafficFormat, trafficProtocol = "", "table", "" })
+	trafficDestIP, trafficDestPort, trafficLimit, trafficMinBytes, trafficMinAge = "", 0, 0, "1MiB", 2*time.Hour
+	trafficFresh = true
+	t.Cleanup(func() {
+		trafficServerFlag, trafficFormat, trafficProtocol, trafficMinBytes, trafficMinAge = "", "table", "", "", 0
+		trafficFresh = false
+	})
 
 	var buf bytes.Buffer
 	cmd := &cobra.Command{}
@@ -116,6 +142,15 @@ func TestTrafficConnections_EndToEnd(t *testing.T) {
 	if !strings.Contains(gotQuery, "protocol=PROTOCOL_TCP") {
 		t.Errorf("query missing protocol filter: %q", gotQuery)
 	}
+	if !strings.Contains(gotQuery, "minBytes=1048576") {
+		t.Errorf("query missing minBytes filter: %q", gotQuery)
+	}
+	if !strings.Contains(gotQuery, "minAgeSeconds=7200") {
+		t.Errorf("query missing minAgeSeconds filter: %q", gotQuery)
+	}
+	if !strings.Contains(gotQuery, "fresh=true") {
+		t.Errorf("query missing fresh: %q", gotQuery)
+	}
 	if gotAuth != "Bearer tok-traffic" {
 		t.Errorf("auth = %q, want Bearer tok-traffic", gotAuth)
 	}
@@ -126,3 +161,414 @@ func TestTrafficConnections_EndToEnd(t *testing.T) {
 		}
 	}
 }
+
+func TestTrafficAggregates_ByDirection(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"aggregates":[` +
+			`{"timestamp":"2026-03-01T10:00:00Z","direction":"TRAFFIC_DIRECTION_EGRESS","bytesSent":"2048","connectionCount":3,"egressBytes":"2048"},` +
+			`{"timestamp":"2026-03-01T10:00:00Z","direction":"TRAFFIC_DIRECTION_INGRESS","bytesReceived":"1024","connectionCount":1,"ingressBytes":"1024"},` +
+			`{"timestamp":"2026-03-01T10:00:00Z","bytesSent":"10","connectionCount":1}]}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficInterval, trafficByDir = "", "table", "1h", true
+	t.Cleanup(func() { trafficInterval, trafficByDir = "1h", false })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficAggregates(cmd, []string{"web-container"}); err != nil {
+		t.Fatalf("runTrafficAggregates: %v", err)
+	}
+
+	if gotPath != "/v1/containers/web-container/traffic/aggregates" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if !strings.Contains(gotQuery, "groupByDirection=true") || !strings.Contains(gotQuery, "interval=1h") {
+		t.Errorf("query = %q", gotQuery)
+	}
+	out := buf.String()
+	for _, want := range []string{"egress", "ingress", "Ingress 1.0 KiB, egress 2.0 KiB"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q; got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTrafficAggregates_Timezone(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		// Tokyo midnight on 2 March, as the server sends it.
+		_, _ = w.Write([]byte(`{"aggregates":[{"timestamp":"2026-03-01T15:00:00Z","bytesSent":"10","connectionCount":1}]}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficInterval, trafficTZ = "", "table", "1d", "Asia/Tokyo"
+	t.Cleanup(func() { trafficInterval, trafficTZ = "1h", "" })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficAggregates(cmd, []string{"alice-container"}); err != nil {
+		t.Fatalf("runTrafficAggregates: %v", err)
+	}
+	if !strings.Contains(gotQuery, "timezone=Asia%2FTokyo") {
+		t.Errorf("query = %q", gotQuery)
+	}
+	if out := buf.String(); !strings.Contains(out, "2026-03-02T00:00:00+09:00") {
+		t.Errorf("bucket not shown on the Tokyo clock:\n%s", out)
+	}
+}
+
+func TestTrafficHistory_ByUsername(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"connections":[],"totalCount":0}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficUsername = "", "table", "alice"
+	t.Cleanup(func() { trafficUsername = "" })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficHistory(cmd, nil); err != nil {
+		t.Fatalf("runTrafficHistory: %v", err)
+	}
+	if gotPath != "/v1/traffic/history" || !strings.Contains(gotQuery, "username=alice") {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	if !strings.Contains(buf.String(), `No history for "alice"`) {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestTrafficHistory_AcrossBoxes(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"connections":[{"containerName":"bob-container","protocol":"PROTOCOL_TCP","sourceIp":"10.100.0.6","destIp":"203.0.113.7","destPort":443,"endedAt":"2026-03-01T10:00:00Z"}],"totalCount":1}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficDestIP = "", "table", "203.0.113.7"
+	t.Cleanup(func() { trafficDestIP = "" })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficHistory(cmd, nil); err != nil {
+		t.Fatalf("runTrafficHistory: %v", err)
+	}
+	if gotPath != "/v1/traffic/history" || !strings.Contains(gotQuery, "destIp=203.0.113.7") || strings.Contains(gotQuery, "username") {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	if out := buf.String(); !strings.Contains(out, "BOX") || !strings.Contains(out, "bob-container") {
+		t.Errorf("fleet search does not name the box:\n%s", out)
+	}
+}
+
+func TestTrafficUsage_AllBoxes(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"days":[` +
+			`{"containerName":"alice-container","day":"2025-05-01","bytesIn":"1024","bytesOut":"4096","externalBytesOut":"2048","connectionCount":"3","peakConnections":2},` +
+			`{"containerName":"alice-container","day":"2025-05-02","bytesOut":"1024","connectionCount":"1","peakConnections":1}],` +
+			`"totals":[{"containerName":"alice-container","bytesIn":"1024","bytesOut":"5120","externalBytesOut":"2048","connectionCount":"4","peakConnections":2}]}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficMonth, trafficContainer = "", "table", "2025-05", ""
+	t.Cleanup(func() { trafficMonth = "" })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficUsage(cmd, nil); err != nil {
+		t.Fatalf("runTrafficUsage: %v", err)
+	}
+	if gotPath != "/v1/traffic/usage" || gotQuery != "month=2025-05" {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	out := buf.String()
+	for _, want := range []string{"BOX", "2025-05-02", "TOTAL", "5.0 KiB", "2.0 KiB"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q; got:\n%s", want, out)
+		}
+	}
+
+	trafficMonth = "May"
+	if err := runTrafficUsage(cmd, nil); err == nil {
+		t.Error("want an error for a malformed --month")
+	}
+}
+
+func TestTrafficDNS_FiltersAndRendersAnswers(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"queries":[` +
+			`{"qname":"api.example.com","qtype":"A","answers":["203.0.113.7","203.0.113.8"],"timestamp":"2026-03-01T10:00:00Z"},` +
+			`{"qname":"missing.example.com","qtype":"AAAA","status":"NXDOMAIN","timestamp":"2026-03-01T09:59:00Z"}]}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficQName, trafficAnswerIP = "", "table", "example.com", "203.0.113.7"
+	t.Cleanup(func() { trafficQName, trafficAnswerIP = "", "" })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficDNS(cmd, []string{"alice-container"}); err != nil {
+		t.Fatalf("runTrafficDNS: %v", err)
+	}
+	if gotPath != "/v1/containers/alice-container/traffic/dns" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if !strings.Contains(gotQuery, "qname=example.com") || !strings.Contains(gotQuery, "answerIp=203.0.113.7") {
+		t.Errorf("query = %q", gotQuery)
+	}
+	out := buf.String()
+	for _, want := range []string{"203.0.113.7, 203.0.113.8", "NXDOMAIN", "2 DNS lookup(s)."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q; got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTrafficListeners_ListsPortsAndChanges(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"scannedAt":"2026-03-01T12:05:00Z","listeners":[` +
+			`{"protocol":"PROTOCOL_TCP","address":"::","port":22,"processName":"sshd","pid":501,"firstSeen":"2026-03-01T09:00:00Z"},` +
+			`{"protocol":"PROTOCOL_TCP","address":"0.0.0.0","port":4444,"firstSeen":"2026-03-01T12:05:00Z"}],` +
+			`"changes":[{"type":"LISTENER_CHANGE_TYPE_OPENED","timestamp":"2026-03-01T12:05:00Z",` +
+			`"listener":{"protocol":"PROTOCOL_TCP","address":"0.0.0.0","port":4444}}]}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficChanges = "", "table", 24*time.Hour
+	t.Cleanup(func() { trafficChanges = 0 })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficListeners(cmd, []string{"alice-container"}); err != nil {
+		t.Fatalf("runTrafficListeners: %v", err)
+	}
+	if gotPath != "/v1/containers/alice-container/traffic/listeners" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if !strings.Contains(gotQuery, "historySince=") {
+		t.Errorf("query = %q, want historySince", gotQuery)
+	}
+	out := buf.String()
+	for _, want := range []string{"[::]:22", "sshd", "0.0.0.0:4444", "2 listening port(s)", "opened"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q; got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTrafficWhoTalkedTo_QueriesAcrossBoxes(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"containers":[` +
+			`{"containerName":"bob-container","connectionCount":"3","bytesSent":"2048","bytesReceived":"1024",` +
+			`"firstSeen":"2026-03-01T10:00:00Z","lastSeen":"2026-03-02T10:00:00Z","destIps":["203.0.113.7","203.0.113.9"]}]}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficSince, trafficDestPort = "", "table", 720*time.Hour, 22
+	t.Cleanup(func() { trafficDestPort = 0 })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficWhoTalkedTo(cmd, []string{"203.0.113.0/24"}); err != nil {
+		t.Fatalf("runTrafficWhoTalkedTo: %v", err)
+	}
+	if gotPath != "/v1/traffic/destinations" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if !strings.Contains(gotQuery, "destination=203.0.113.0%2F24") || !strings.Contains(gotQuery, "destPort=22") {
+		t.Errorf("query = %q", gotQuery)
+	}
+	out := buf.String()
+	for _, want := range []string{"bob-container", "203.0.113.7, 203.0.113.9", "1 box(es) connected to 203.0.113.0/24."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q; got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTrafficDNSLogging_PostsToggle(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotMethod, gotPath, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotMethod, gotPath, gotBody = r.Method, r.URL.Path, string(b)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"DNS logging enabled","dnsLoggingEnabled":true}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat = "", "table"
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficDNSLogging(cmd, []string{"alice", "on"}); err != nil {
+		t.Fatalf("runTrafficDNSLogging: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/v1/containers/alice/dns-logging" || gotBody != `{"enabled":true}` {
+		t.Errorf("request = %s %s %s", gotMethod, gotPath, gotBody)
+	}
+	if !strings.Contains(buf.String(), "DNS logging enabled for alice") {
+		t.Errorf("output = %q", buf.String())
+	}
+	if err := runTrafficDNSLogging(cmd, []string{"alice", "maybe"}); err == nil {
+		t.Error("accepted a state other than on/off")
+	}
+}
+
+func TestContainerCacheStatus_StaleWarning(t *testing.T) {
+	var none *containerCacheStatus
+	if w := none.staleWarning(); w != "" {
+		t.Errorf("no cache status warned: %q", w)
+	}
+	if w := (&containerCacheStatus{}).staleWarning(); w != "" {
+		t.Errorf("current cache warned: %q", w)
+	}
+	w := (&containerCacheStatus{Stale: true, BreakerOpen: true, LastRefreshTime: "2026-05-01T10:00:00Z", LastError: "incus down"}).staleWarning()
+	for _, want := range []string{"circuit breaker open", "as of 2026-05-01", "last error: incus down"} {
+		if !strings.Contains(w, want) {
+			t.Errorf("warning %q lacks %q", w, want)
+		}
+	}
+}
+
+func TestRenderConnections_RateColumns(t *testing.T) {
+	var resp getConnectionsResp
+	if err := json.Unmarshal([]byte(`{"connections":[`+
+		`{"protocol":"PROTOCOL_TCP","sourceIp":"10.100.0.42","sourcePort":51000,"destIp":"192.0.2.10","destPort":443,`+
+		`"bytesSent":"8456","bytesReceived":"0","bytesSentPerSec":2048,"bytesReceivedPerSec":0},`+
+		`{"protocol":"PROTOCOL_TCP","sourceIp":"10.100.0.42","sourcePort":51001,"destIp":"192.0.2.11","destPort":443}],`+
+		`"totalCount":2,"snapshotIntervalSeconds":2.5}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	renderConnections(&buf, "web-container", resp, false)
+	if strings.Contains(buf.String(), "SENT/S") {
+		t.Errorf("rate columns without --watch or --min-rate:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	renderConnections(&buf, "web-container", resp, true)
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.Contains(lines[0], "SENT/S") || !strings.Contains(lines[0], "RECV/S") {
+		t.Fatalf("header = %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "2.0 KiB/s") || !strings.Contains(lines[1], "0 B/s") {
+		t.Errorf("measured row = %q", lines[1])
+	}
+	if f := strings.Fields(lines[2]); f[len(f)-1] != "-" || f[len(f)-2] != "-" {
+		t.Errorf("unmeasured row = %q, want - for both rates", lines[2])
+	}
+}
+
+func TestTrafficSummary_ContainerFlag(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"summary":{"containerName":"web-container","activeConnections":4,` +
+			`"tcpConnections":2,"udpConnections":1,"icmpConnections":1,` +
+			`"totalBytesSent":"8456","totalBytesReceived":"2048","bytesSentPerSec":2048,"bytesReceivedPerSec":512,` +
+			`"topDestinations":[{"destIp":"192.0.2.10","connectionCount":3,"bytesTotal":"10240"}]}}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{srv.URL: {Token: "tok-traffic"}})
+
+	trafficServerFlag, trafficFormat, trafficContainer = "", "table", "web-container"
+	t.Cleanup(func() { trafficContainer = "" })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficSummary(cmd, nil); err != nil {
+		t.Fatalf("runTrafficSummary: %v", err)
+	}
+	if gotPath != "/v1/containers/web-container/connections/summary" {
+		t.Errorf("path = %q", gotPath)
+	}
+	out := buf.String()
+	for _, want := range []string{"4 (tcp 2, udp 1, icmp 1)", "8.3 KiB / 2.0 KiB", "2.0 KiB/s / 512 B/s", "DEST IP", "192.0.2.10", "10.0 KiB"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q; got:\n%s", want, out)
+		}
+	}
+
+	if err := runTrafficSummary(cmd, []string{"other-container"}); err == nil {
+		t.Error("want an error when the argument and --container disagree")
+	}
+	trafficContainer = ""
+	if err := runTrafficSummary(cmd, nil); err == nil {
+		t.Error("want an error without a box")
+	}
+}
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/google/uuid"
@@ -40,6 +41,12 @@ func (s *Subscriber) shouldReceive(event *pb.Event) bool {
 		return s.Filter.IncludeMetrics
 	}
 
+	// Container lifecycle filter; legacy events are typed by the shim.
+	if len(s.Filter.ContainerEventTypes) > 0 && event.ResourceType == pb.ResourceType_RESOURCE_TYPE_CONTAINER &&
+		!slices.Contains(s.Filter.ContainerEventTypes, ContainerEventTypeOf(event)) {
+		return false
+	}
+
 	// If no resource type filter, accept all non-metrics
 	if len(s.Filter.ResourceTypes) == 0 {
 		return true
@@ -103,11 +110,22 @@ func (b *Bus) Publish(event *pb.Event) {
 
 	for _, sub := range b.subscribers {
 		if sub.shouldReceive(event) {
-			// Non-blocking send - drop event if channel is full
+			// Non-blocking send. When a slow subscriber's channel is
+			// full, drop its oldest queued event to make room: a live
+			// dashboard would rather skip stale history than freeze
+			// on it.
 			select {
 			case sub.Events <- event:
 			default:
-				// Channel full, event dropped for this subscriber
+				select {
+				case <-sub.Events:
+				default:
+				}
+				select {
+				case sub.Events <- event:
+				default:
+					// Another publisher refilled the slot; drop this one.
+				}
 			}
 		}
 	}
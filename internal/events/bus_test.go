@@ -0,0 +1,33 @@
+package events
+
+import (
+	"strconv"
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestPublish_SlowSubscriberDropsOldest(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe(nil)
+	defer bus.Unsubscribe(sub.ID)
+
+	total := DefaultChannelBufferSize + 10
+	for i := 0; i < total; i++ {
+		bus.Publish(&pb.Event{Id: strconv.Itoa(i)})
+	}
+
+	if got := len(sub.Events); got != DefaultChannelBufferSize {
+		t.Fatalf("queued = %d, want %d", got, DefaultChannelBufferSize)
+	}
+	if first := <-sub.Events; first.Id != "10" {
+		t.Errorf("oldest queued event = %s, want 10 (first 10 dropped)", first.Id)
+	}
+	var last *pb.Event
+	for len(sub.Events) > 0 {
+		last = <-sub.Events
+	}
+	if want := strconv.Itoa(total - 1); last.Id != want {
+		t.Errorf("newest queued event = %s, want %s", last.Id, want)
+	}
+}
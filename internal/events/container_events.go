@@ -0,0 +1,75 @@
+package events
+
+import (
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// legacyEventTypes maps each typed container event onto the coarse
+// EventType carried in Event.type, which subscribers written before typed
+// events filter on.
+var legacyEventTypes = map[pb.ContainerEventType]pb.EventType{
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATING:       pb.EventType_EVENT_TYPE_CONTAINER_CREATING,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED:        pb.EventType_EVENT_TYPE_CONTAINER_CREATED,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_START:          pb.EventType_EVENT_TYPE_CONTAINER_STARTED,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_STOP:           pb.EventType_EVENT_TYPE_CONTAINER_STOPPED,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_DELETE:         pb.EventType_EVENT_TYPE_CONTAINER_DELETED,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_RESIZE:         pb.EventType_EVENT_TYPE_CONTAINER_UPDATED,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_KEY_ADDED:      pb.EventType_EVENT_TYPE_CONTAINER_UPDATED,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_KEY_REMOVED:    pb.EventType_EVENT_TYPE_CONTAINER_UPDATED,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_SNAPSHOT:       pb.EventType_EVENT_TYPE_CONTAINER_UPDATED,
+	pb.ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH_CHANGED: pb.EventType_EVENT_TYPE_CONTAINER_HEALTH_CHANGED,
+}
+
+// EmitContainerEvent publishes a typed container lifecycle event. actor is
+// the subject that performed the operation (auth.SystemSubject for the
+// daemon itself); details carries operation-specific fields. A nil Emitter is a
+// no-op, so servers built without one (tests, tools) can call it freely.
+func (e *Emitter) EmitContainerEvent(t pb.ContainerEventType, container *pb.Container, actor string, details map[string]string) {
+	if e == nil || container == nil {
+		return
+	}
+	ce := &pb.ContainerEvent{
+		Container: container,
+		Type:      t,
+		Actor:     actor,
+		Details:   details,
+	}
+	switch t {
+	case pb.ContainerEventType_CONTAINER_EVENT_TYPE_START:
+		ce.PreviousState = pb.ContainerState_CONTAINER_STATE_STOPPED
+	case pb.ContainerEventType_CONTAINER_EVENT_TYPE_STOP:
+		ce.PreviousState = pb.ContainerState_CONTAINER_STATE_RUNNING
+	}
+	event := newEvent(legacyEventTypes[t], pb.ResourceType_RESOURCE_TYPE_CONTAINER, container.Name)
+	event.Payload = &pb.Event_ContainerEvent{ContainerEvent: ce}
+	e.bus.Publish(event)
+}
+
+// ContainerEventTypeOf returns the lifecycle type of a container event. It
+// is the compatibility shim for events published without one (the untyped
+// Emit* helpers, or a peer running an older release): those are typed from
+// Event.type, and UNSPECIFIED is returned when that says too little (a
+// bare state change) or the event isn't about a container.
+func ContainerEventTypeOf(event *pb.Event) pb.ContainerEventType {
+	if event.GetResourceType() != pb.ResourceType_RESOURCE_TYPE_CONTAINER {
+		return pb.ContainerEventType_CONTAINER_EVENT_TYPE_UNSPECIFIED
+	}
+	if t := event.GetContainerEvent().GetType(); t != pb.ContainerEventType_CONTAINER_EVENT_TYPE_UNSPECIFIED {
+		return t
+	}
+	switch event.GetType() {
+	case pb.EventType_EVENT_TYPE_CONTAINER_CREATING:
+		return pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATING
+	case pb.EventType_EVENT_TYPE_CONTAINER_CREATED:
+		return pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED
+	case pb.EventType_EVENT_TYPE_CONTAINER_STARTED:
+		return pb.ContainerEventType_CONTAINER_EVENT_TYPE_START
+	case pb.EventType_EVENT_TYPE_CONTAINER_STOPPED:
+		return pb.ContainerEventType_CONTAINER_EVENT_TYPE_STOP
+	case pb.EventType_EVENT_TYPE_CONTAINER_DELETED:
+		return pb.ContainerEventType_CONTAINER_EVENT_TYPE_DELETE
+	case pb.EventType_EVENT_TYPE_CONTAINER_HEALTH_CHANGED:
+		return pb.ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH_CHANGED
+	}
+	return pb.ContainerEventType_CONTAINER_EVENT_TYPE_UNSPECIFIED
+}
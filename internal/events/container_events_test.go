@@ -0,0 +1,66 @@
+package events
+
+import (
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestEmitContainerEvent_CarriesTypeActorAndLegacyType(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe(nil)
+	defer bus.Unsubscribe(sub.ID)
+
+	NewEmitter(bus).EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_KEY_ADDED,
+		&pb.Container{Name: "alice-container"}, "alice", map[string]string{"fingerprint": "SHA256:abc"})
+
+	event := <-sub.Events
+	if event.Type != pb.EventType_EVENT_TYPE_CONTAINER_UPDATED {
+		t.Errorf("legacy type = %v, want CONTAINER_UPDATED", event.Type)
+	}
+	ce := event.GetContainerEvent()
+	if ce.Type != pb.ContainerEventType_CONTAINER_EVENT_TYPE_KEY_ADDED || ce.Actor != "alice" || ce.Details["fingerprint"] != "SHA256:abc" {
+		t.Errorf("payload = %+v", ce)
+	}
+}
+
+func TestContainerEventTypeOf_UntypedLegacyEvent(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe(nil)
+	defer bus.Unsubscribe(sub.ID)
+
+	NewEmitter(bus).EmitContainerStateChanged(&pb.Container{Name: "bob-container"}, pb.ContainerState_CONTAINER_STATE_RUNNING)
+	if got := ContainerEventTypeOf(<-sub.Events); got != pb.ContainerEventType_CONTAINER_EVENT_TYPE_UNSPECIFIED {
+		t.Errorf("bare state change typed as %v", got)
+	}
+
+	legacy := &pb.Event{
+		Type:         pb.EventType_EVENT_TYPE_CONTAINER_STOPPED,
+		ResourceType: pb.ResourceType_RESOURCE_TYPE_CONTAINER,
+		Payload:      &pb.Event_ContainerEvent{ContainerEvent: &pb.ContainerEvent{Container: &pb.Container{Name: "bob-container"}}},
+	}
+	if got := ContainerEventTypeOf(legacy); got != pb.ContainerEventType_CONTAINER_EVENT_TYPE_STOP {
+		t.Errorf("untyped CONTAINER_STOPPED typed as %v, want STOP", got)
+	}
+}
+
+func TestSubscribe_FiltersByContainerEventType(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe(&pb.SubscribeEventsRequest{
+		ContainerEventTypes: []pb.ContainerEventType{pb.ContainerEventType_CONTAINER_EVENT_TYPE_RESIZE},
+	})
+	defer bus.Unsubscribe(sub.ID)
+
+	e := NewEmitter(bus)
+	c := &pb.Container{Name: "carol-container"}
+	e.EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_SNAPSHOT, c, "carol", nil)
+	e.EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_RESIZE, c, "carol", map[string]string{"cpu": "4"})
+	e.EmitContainerStarted(c)
+
+	if got := len(sub.Events); got != 1 {
+		t.Fatalf("delivered %d events, want only the resize", got)
+	}
+	if ce := (<-sub.Events).GetContainerEvent(); ce.Type != pb.ContainerEventType_CONTAINER_EVENT_TYPE_RESIZE {
+		t.Errorf("delivered %v", ce.Type)
+	}
+}
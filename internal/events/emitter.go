@@ -29,62 +29,29 @@ func newEvent(eventType pb.EventType, resourceType pb.ResourceType, resourceID s
 }
 
 // Container Events
+//
+// The helpers below predate typed container events and publish through
+// EmitContainerEvent without an actor; new call sites should use
+// EmitContainerEvent directly.
 
 // EmitContainerCreated emits an event when a container is created
 func (e *Emitter) EmitContainerCreated(container *pb.Container) {
-	event := newEvent(
-		pb.EventType_EVENT_TYPE_CONTAINER_CREATED,
-		pb.ResourceType_RESOURCE_TYPE_CONTAINER,
-		container.Name,
-	)
-	event.Payload = &pb.Event_ContainerEvent{
-		ContainerEvent: &pb.ContainerEvent{
-			Container: container,
-		},
-	}
-	e.bus.Publish(event)
+	e.EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED, container, "", nil)
 }
 
 // EmitContainerDeleted emits an event when a container is deleted
 func (e *Emitter) EmitContainerDeleted(containerName string) {
-	event := newEvent(
-		pb.EventType_EVENT_TYPE_CONTAINER_DELETED,
-		pb.ResourceType_RESOURCE_TYPE_CONTAINER,
-		containerName,
-	)
-	e.bus.Publish(event)
+	e.EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_DELETE, &pb.Container{Name: containerName}, "", nil)
 }
 
 // EmitContainerStarted emits an event when a container is started
 func (e *Emitter) EmitContainerStarted(container *pb.Container) {
-	event := newEvent(
-		pb.EventType_EVENT_TYPE_CONTAINER_STARTED,
-		pb.ResourceType_RESOURCE_TYPE_CONTAINER,
-		container.Name,
-	)
-	event.Payload = &pb.Event_ContainerEvent{
-		ContainerEvent: &pb.ContainerEvent{
-			Container:     container,
-			PreviousState: pb.ContainerState_CONTAINER_STATE_STOPPED,
-		},
-	}
-	e.bus.Publish(event)
+	e.EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_START, container, "", nil)
 }
 
 // EmitContainerStopped emits an event when a container is stopped
 func (e *Emitter) EmitContainerStopped(container *pb.Container) {
-	event := newEvent(
-		pb.EventType_EVENT_TYPE_CONTAINER_STOPPED,
-		pb.ResourceType_RESOURCE_TYPE_CONTAINER,
-		container.Name,
-	)
-	event.Payload = &pb.Event_ContainerEvent{
-		ContainerEvent: &pb.ContainerEvent{
-			Container:     container,
-			PreviousState: pb.ContainerState_CONTAINER_STATE_RUNNING,
-		},
-	}
-	e.bus.Publish(event)
+	e.EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_STOP, container, "", nil)
 }
 
 // EmitContainerStateChanged emits an event when a container's state changes
@@ -240,3 +207,16 @@ func (e *Emitter) EmitTrafficEvent(trafficEvent *pb.TrafficEvent) {
 	}
 	e.bus.Publish(event)
 }
+
+// EmitListenerChange emits a container listener opening or closing
+func (e *Emitter) EmitListenerChange(change *pb.ListenerChange) {
+	event := newEvent(
+		pb.EventType_EVENT_TYPE_LISTENER_CHANGED,
+		pb.ResourceType_RESOURCE_TYPE_TRAFFIC,
+		change.GetListener().GetContainerName(),
+	)
+	event.Payload = &pb.Event_ListenerChange{
+		ListenerChange: change,
+	}
+	e.bus.Publish(event)
+}
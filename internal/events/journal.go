@@ -0,0 +1,199 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+const (
+	// DefaultJournalCapacity is how many events the global journal keeps
+	// for pollers that fall behind.
+	DefaultJournalCapacity = 1000
+
+	// MaxPollEvents caps the events returned by one Poll; the cursor then
+	// points at the rest.
+	MaxPollEvents = 100
+)
+
+// journalResourceTypes are the events the journal records: resource state
+// changes. Metrics are periodic snapshots and traffic events are
+// per-connection (and per-tenant); both would push lifecycle events out of
+// the ring within seconds.
+var journalResourceTypes = []pb.ResourceType{
+	pb.ResourceType_RESOURCE_TYPE_CONTAINER,
+	pb.ResourceType_RESOURCE_TYPE_APP,
+	pb.ResourceType_RESOURCE_TYPE_ROUTE,
+}
+
+// Journal keeps the most recent bus events with sequence numbers, so
+// clients without a streaming transport (MCP over stdio) can long-poll for
+// "everything since my cursor". Cursors are opaque strings of the form
+// "<epoch>.<seq>"; the epoch changes whenever the daemon restarts, which is
+// how a poller learns its cursor is stale.
+type Journal struct {
+	epoch string
+
+	mu      sync.Mutex
+	entries []*pb.Event // ring, oldest at entries[head] once full
+	head    int
+	next    uint64        // sequence number of the next recorded event
+	changed chan struct{} // closed and replaced on every Record
+}
+
+// NewJournal creates a journal keeping the last capacity events.
+func NewJournal(capacity int) *Journal {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return &Journal{
+		epoch:   hex.EncodeToString(b),
+		entries: make([]*pb.Event, 0, max(capacity, 1)),
+		next:    1,
+		changed: make(chan struct{}),
+	}
+}
+
+// Attach records bus events until the returned stop function is called.
+func (j *Journal) Attach(bus *Bus) (stop func()) {
+	sub := bus.Subscribe(&pb.SubscribeEventsRequest{ResourceTypes: journalResourceTypes})
+	go func() {
+		for event := range sub.Events {
+			j.Record(event)
+		}
+	}()
+	return func() { bus.Unsubscribe(sub.ID) }
+}
+
+// Record appends an event, evicting the oldest when full, and wakes
+// waiting pollers.
+func (j *Journal) Record(event *pb.Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.entries) < cap(j.entries) {
+		j.entries = append(j.entries, event)
+	} else {
+		j.entries[j.head] = event
+		j.head = (j.head + 1) % len(j.entries)
+	}
+	j.next++
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// PollResult is one Poll answer.
+type PollResult struct {
+	// Events are the matching events after the cursor, oldest first.
+	Events []*pb.Event
+	// Cursor is what the caller passes to the next Poll.
+	Cursor string
+	// Missed is set when events between the caller's cursor and Events
+	// were lost: the journal evicted them, or the daemon restarted.
+	Missed bool
+}
+
+// Poll returns the events after cursor that pass filter (nil = all
+// journaled types), waiting up to timeout for one to arrive when there
+// are none yet. An empty cursor means "from now". Events the filter
+// rejects still advance the cursor, so they are never re-scanned.
+func (j *Journal) Poll(ctx context.Context, cursor string, timeout time.Duration, filter *pb.SubscribeEventsRequest) (*PollResult, error) {
+	var sub *Subscriber
+	if filter != nil {
+		sub = &Subscriber{Filter: filter}
+	}
+
+	j.mu.Lock()
+	from, missed, err := j.resolve(cursor)
+	j.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		j.mu.Lock()
+		events, next, evicted := j.since(from, sub)
+		changed := j.changed
+		j.mu.Unlock()
+		missed = missed || evicted
+		from = next
+		if len(events) > 0 {
+			return &PollResult{Events: events, Cursor: j.cursor(from), Missed: missed}, nil
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return &PollResult{Cursor: j.cursor(from), Missed: missed}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// resolve turns a cursor into the first sequence number to return.
+// Caller holds j.mu.
+func (j *Journal) resolve(cursor string) (uint64, bool, error) {
+	if cursor == "" {
+		return j.next, false, nil
+	}
+	epoch, seqStr, ok := strings.Cut(cursor, ".")
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if !ok || err != nil || seq == 0 {
+		return 0, false, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	if epoch != j.epoch || seq > j.next {
+		// Issued before a restart: replay what this daemon has.
+		return j.oldest(), true, nil
+	}
+	return seq, false, nil
+}
+
+// oldest is the sequence number of the oldest retained event. Caller
+// holds j.mu.
+func (j *Journal) oldest() uint64 {
+	return j.next - uint64(len(j.entries))
+}
+
+// since collects up to MaxPollEvents events from sequence number from,
+// returning the cursor position after the last one examined and whether
+// events before the oldest retained one were skipped. Caller holds j.mu.
+func (j *Journal) since(from uint64, sub *Subscriber) ([]*pb.Event, uint64, bool) {
+	evicted := false
+	if oldest := j.oldest(); from < oldest {
+		from, evicted = oldest, true
+	}
+	var out []*pb.Event
+	for ; from < j.next && len(out) < MaxPollEvents; from++ {
+		idx := (j.head + int(from-j.oldest())) % len(j.entries)
+		if event := j.entries[idx]; sub == nil || sub.shouldReceive(event) {
+			out = append(out, event)
+		}
+	}
+	return out, from, evicted
+}
+
+func (j *Journal) cursor(seq uint64) string {
+	return j.epoch + "." + strconv.FormatUint(seq, 10)
+}
+
+var (
+	globalJournal *Journal
+	journalOnce   sync.Once
+)
+
+// GetJournal returns the global journal, attached to the global bus on
+// first use.
+func GetJournal() *Journal {
+	journalOnce.Do(func() {
+		globalJournal = NewJournal(DefaultJournalCapacity)
+		globalJournal.Attach(GetBus())
+	})
+	return globalJournal
+}
@@ -0,0 +1,112 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func journalEvent(id string, rt pb.ResourceType) *pb.Event {
+	return &pb.Event{Id: id, ResourceType: rt}
+}
+
+func eventIDs(events []*pb.Event) []string {
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.Id
+	}
+	return ids
+}
+
+func TestJournalPoll_CursorReturnsOnlyNewEvents(t *testing.T) {
+	j := NewJournal(10)
+	first, err := j.Poll(t.Context(), "", 0, nil)
+	if err != nil || len(first.Events) != 0 {
+		t.Fatalf("empty poll = %+v, %v", first, err)
+	}
+
+	j.Record(journalEvent("a", pb.ResourceType_RESOURCE_TYPE_CONTAINER))
+	j.Record(journalEvent("b", pb.ResourceType_RESOURCE_TYPE_APP))
+	res, err := j.Poll(t.Context(), first.Cursor, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := eventIDs(res.Events); len(got) != 2 || got[0] != "a" || got[1] != "b" || res.Missed {
+		t.Fatalf("poll = %v (missed %v), want [a b]", got, res.Missed)
+	}
+
+	j.Record(journalEvent("c", pb.ResourceType_RESOURCE_TYPE_CONTAINER))
+	res, _ = j.Poll(t.Context(), res.Cursor, 0, nil)
+	if got := eventIDs(res.Events); len(got) != 1 || got[0] != "c" {
+		t.Fatalf("second poll = %v, want [c]", got)
+	}
+}
+
+func TestJournalPoll_BlocksUntilEvent(t *testing.T) {
+	j := NewJournal(10)
+	start, _ := j.Poll(t.Context(), "", 0, nil)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		j.Record(journalEvent("a", pb.ResourceType_RESOURCE_TYPE_CONTAINER))
+	}()
+	res, err := j.Poll(t.Context(), start.Cursor, 5*time.Second, nil)
+	if err != nil || len(res.Events) != 1 {
+		t.Fatalf("poll = %+v, %v; want the event recorded while waiting", res, err)
+	}
+
+	// Timeout: no events, same position.
+	empty, err := j.Poll(t.Context(), res.Cursor, 10*time.Millisecond, nil)
+	if err != nil || len(empty.Events) != 0 || empty.Cursor != res.Cursor {
+		t.Fatalf("timed-out poll = %+v, %v", empty, err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := j.Poll(ctx, res.Cursor, time.Minute, nil); err != context.Canceled {
+		t.Fatalf("cancelled poll err = %v", err)
+	}
+}
+
+func TestJournalPoll_FilterSkipsButAdvances(t *testing.T) {
+	j := NewJournal(10)
+	start, _ := j.Poll(t.Context(), "", 0, nil)
+	j.Record(journalEvent("route", pb.ResourceType_RESOURCE_TYPE_ROUTE))
+	j.Record(journalEvent("box", pb.ResourceType_RESOURCE_TYPE_CONTAINER))
+
+	filter := &pb.SubscribeEventsRequest{ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_CONTAINER}}
+	res, _ := j.Poll(t.Context(), start.Cursor, 0, filter)
+	if got := eventIDs(res.Events); len(got) != 1 || got[0] != "box" {
+		t.Fatalf("filtered poll = %v, want [box]", got)
+	}
+	again, _ := j.Poll(t.Context(), res.Cursor, 0, nil)
+	if len(again.Events) != 0 {
+		t.Fatalf("cursor did not advance past the filtered events: %v", eventIDs(again.Events))
+	}
+}
+
+func TestJournalPoll_MissedEvents(t *testing.T) {
+	j := NewJournal(2)
+	start, _ := j.Poll(t.Context(), "", 0, nil)
+	for _, id := range []string{"a", "b", "c"} {
+		j.Record(journalEvent(id, pb.ResourceType_RESOURCE_TYPE_CONTAINER))
+	}
+	res, _ := j.Poll(t.Context(), start.Cursor, 0, nil)
+	if got := eventIDs(res.Events); !res.Missed || len(got) != 2 || got[0] != "b" {
+		t.Fatalf("after eviction = %v (missed %v), want [b c] missed", got, res.Missed)
+	}
+
+	// A cursor from another daemon run replays what this one has.
+	restarted := NewJournal(10)
+	restarted.Record(journalEvent("x", pb.ResourceType_RESOURCE_TYPE_CONTAINER))
+	res, err := restarted.Poll(t.Context(), res.Cursor, 0, nil)
+	if err != nil || !res.Missed || len(res.Events) != 1 {
+		t.Fatalf("stale-epoch poll = %+v, %v", res, err)
+	}
+
+	if _, err := restarted.Poll(t.Context(), "not-a-cursor", 0, nil); err == nil {
+		t.Fatal("malformed cursor accepted")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/internal/audit"
 	"github.com/footprintai/containarium/internal/auth"
 )
@@ -24,13 +25,13 @@ func registerAuditEndpoint(mux *http.ServeMux, store *audit.Store, authMW *auth.
 	mux.HandleFunc("/v1/audit/logs", func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			http.Error(w, `{"error": "unauthorized: Bearer token required in Authorization header", "code": 401}`, http.StatusUnauthorized)
+			apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: Bearer token required in Authorization header")
 			return
 		}
 		token := strings.TrimPrefix(authHeader, "Bearer ")
 		claims, err := authMW.ValidateToken(token)
 		if err != nil {
-			http.Error(w, `{"error": "unauthorized: invalid token", "code": 401}`, http.StatusUnauthorized)
+			apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: invalid token")
 			return
 		}
 		// #621: the audit log is sensitive (who-did-what across tenants). Gate
@@ -38,7 +39,7 @@ func registerAuditEndpoint(mux *http.ServeMux, store *audit.Store, authMW *auth.
 		// tightening — the endpoint previously accepted any valid token. A
 		// non-admin consumer must now carry audit:read.
 		if !auth.HasRole(claims.Roles, auth.RoleAdmin) && !auth.HasExplicitScope(claims.Scopes, auth.ScopeAuditRead) {
-			http.Error(w, `{"error": "forbidden: requires admin role or audit:read scope", "code": 403}`, http.StatusForbidden)
+			apierr.Write(w, r, http.StatusForbidden, "forbidden: requires admin role or audit:read scope")
 			return
 		}
 
@@ -77,7 +78,7 @@ func handleAuditQuery(w http.ResponseWriter, r *http.Request, store *audit.Store
 	if fromStr := q.Get("from"); fromStr != "" {
 		t, err := time.Parse(time.RFC3339, fromStr)
 		if err != nil {
-			http.Error(w, fmt.Sprintf(`{"error": "invalid from date: %s"}`, err.Error()), http.StatusBadRequest)
+			apierr.Write(w, r, http.StatusBadRequest, fmt.Sprintf("invalid from date: %s", err.Error()))
 			return
 		}
 		params.From = t
@@ -86,7 +87,7 @@ func handleAuditQuery(w http.ResponseWriter, r *http.Request, store *audit.Store
 	if toStr := q.Get("to"); toStr != "" {
 		t, err := time.Parse(time.RFC3339, toStr)
 		if err != nil {
-			http.Error(w, fmt.Sprintf(`{"error": "invalid to date: %s"}`, err.Error()), http.StatusBadRequest)
+			apierr.Write(w, r, http.StatusBadRequest, fmt.Sprintf("invalid to date: %s", err.Error()))
 			return
 		}
 		params.To = t
@@ -111,7 +112,7 @@ func handleAuditQuery(w http.ResponseWriter, r *http.Request, store *audit.Store
 
 	entries, totalCount, err := store.Query(r.Context(), params)
 	if err != nil {
-		http.Error(w, fmt.Sprintf(`{"error": "failed to query audit logs: %s"}`, err.Error()), http.StatusInternalServerError)
+		apierr.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("failed to query audit logs: %s", err.Error()))
 		return
 	}
 
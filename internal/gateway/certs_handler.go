@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/footprintai/containarium/internal/apierr"
 )
 
 // CertPair holds a domain's certificate and key in PEM format.
@@ -32,7 +34,7 @@ type CertsResponse struct {
 func ServeCerts(certBaseDir string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if certBaseDir == "" {
-			http.Error(w, `{"error":"cert base dir not configured"}`, http.StatusServiceUnavailable)
+			apierr.Write(w, r, http.StatusServiceUnavailable, "cert base dir not configured")
 			return
 		}
 
@@ -87,7 +89,7 @@ func ServeCerts(certBaseDir string) http.HandlerFunc {
 		})
 		if err != nil {
 			log.Printf("[certs] failed to walk cert dir: %v", err)
-			http.Error(w, `{"error":"failed to read certificates"}`, http.StatusInternalServerError)
+			apierr.Write(w, r, http.StatusInternalServerError, "failed to read certificates")
 			return
 		}
 
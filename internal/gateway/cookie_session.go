@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/internal/auth"
 )
 
@@ -37,7 +38,7 @@ func registerCookieSession(mux *http.ServeMux, authMW *auth.AuthMiddleware) {
 			handleSessionCookieClear(w, r)
 		default:
 			w.Header().Set("Allow", "POST, DELETE")
-			http.Error(w, `{"error": "method not allowed", "code": 405}`, http.StatusMethodNotAllowed)
+			apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
 		}
 	})
 }
@@ -45,13 +46,13 @@ func registerCookieSession(mux *http.ServeMux, authMW *auth.AuthMiddleware) {
 func handleSessionCookieSet(w http.ResponseWriter, r *http.Request, authMW *auth.AuthMiddleware) {
 	authHeader := r.Header.Get("Authorization")
 	if !strings.HasPrefix(authHeader, "Bearer ") {
-		http.Error(w, `{"error": "missing authorization header", "code": 401}`, http.StatusUnauthorized)
+		apierr.Write(w, r, http.StatusUnauthorized, "missing authorization header")
 		return
 	}
 	token := strings.TrimPrefix(authHeader, "Bearer ")
 	claims, err := authMW.ValidateToken(token)
 	if err != nil {
-		http.Error(w, `{"error": "invalid token", "code": 401}`, http.StatusUnauthorized)
+		apierr.Write(w, r, http.StatusUnauthorized, "invalid token")
 		return
 	}
 
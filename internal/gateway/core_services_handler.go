@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/pkg/core/incus"
 )
 
@@ -33,14 +34,14 @@ type CoreServiceInfo struct {
 // HandleGetCoreServices handles GET /v1/system/core-services.
 func (h *CoreServicesHandler) HandleGetCoreServices(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	containers, err := h.client.ListContainers()
 	if err != nil {
 		log.Printf("core-services: failed to list containers: %v", err)
-		http.Error(w, `{"error":"failed to list containers"}`, http.StatusInternalServerError)
+		apierr.Write(w, r, http.StatusInternalServerError, "failed to list containers")
 		return
 	}
 
@@ -117,6 +117,18 @@ func parseEventFilter(r *http.Request) *pb.SubscribeEventsRequest {
 		}
 	}
 
+	// Parse container lifecycle types ("created", "key_added", or the
+	// full enum name)
+	for _, t := range r.URL.Query()["containerEventTypes"] {
+		name := strings.ToUpper(t)
+		if !strings.HasPrefix(name, "CONTAINER_EVENT_TYPE_") {
+			name = "CONTAINER_EVENT_TYPE_" + name
+		}
+		if v, ok := pb.ContainerEventType_value[name]; ok && v != 0 {
+			filter.ContainerEventTypes = append(filter.ContainerEventTypes, pb.ContainerEventType(v))
+		}
+	}
+
 	// Parse include metrics
 	if includeMetrics := r.URL.Query().Get("includeMetrics"); includeMetrics == "true" {
 		filter.IncludeMetrics = true
@@ -149,26 +161,7 @@ func (h *EventHandler) sendEvent(w http.ResponseWriter, flusher http.Flusher, ev
 
 // sendProtoEvent sends a protobuf event as SSE
 func (h *EventHandler) sendProtoEvent(w http.ResponseWriter, flusher http.Flusher, event *pb.Event) {
-	// Convert proto event to JSON-friendly format
-	eventData := map[string]interface{}{
-		"id":           event.Id,
-		"type":         event.Type.String(),
-		"resourceType": event.ResourceType.String(),
-		"resourceId":   event.ResourceId,
-		"timestamp":    event.Timestamp.AsTime().Format("2006-01-02T15:04:05.000Z"),
-	}
-
-	// Add payload based on type
-	switch p := event.Payload.(type) {
-	case *pb.Event_ContainerEvent:
-		eventData["containerEvent"] = containerEventToMap(p.ContainerEvent)
-	case *pb.Event_AppEvent:
-		eventData["appEvent"] = appEventToMap(p.AppEvent)
-	case *pb.Event_RouteEvent:
-		eventData["routeEvent"] = routeEventToMap(p.RouteEvent)
-	case *pb.Event_MetricsEvent:
-		eventData["metricsEvent"] = metricsEventToMap(p.MetricsEvent)
-	}
+	eventData := protoEventToMap(event)
 
 	jsonData, err := json.Marshal(eventData)
 	if err != nil {
@@ -215,6 +208,31 @@ func getEventAllowedOrigins() []string {
 	}
 }
 
+// protoEventToMap converts a proto event to the JSON-friendly shape shared
+// by the SSE and WebSocket endpoints.
+func protoEventToMap(event *pb.Event) map[string]interface{} {
+	eventData := map[string]interface{}{
+		"id":           event.Id,
+		"type":         event.Type.String(),
+		"resourceType": event.ResourceType.String(),
+		"resourceId":   event.ResourceId,
+		"timestamp":    event.Timestamp.AsTime().Format("2006-01-02T15:04:05.000Z"),
+	}
+
+	// Add payload based on type
+	switch p := event.Payload.(type) {
+	case *pb.Event_ContainerEvent:
+		eventData["containerEvent"] = containerEventToMap(p.ContainerEvent)
+	case *pb.Event_AppEvent:
+		eventData["appEvent"] = appEventToMap(p.AppEvent)
+	case *pb.Event_RouteEvent:
+		eventData["routeEvent"] = routeEventToMap(p.RouteEvent)
+	case *pb.Event_MetricsEvent:
+		eventData["metricsEvent"] = metricsEventToMap(p.MetricsEvent)
+	}
+	return eventData
+}
+
 // Helper functions to convert proto messages to maps
 
 func containerEventToMap(e *pb.ContainerEvent) map[string]interface{} {
@@ -228,6 +246,15 @@ func containerEventToMap(e *pb.ContainerEvent) map[string]interface{} {
 	if e.PreviousState != pb.ContainerState_CONTAINER_STATE_UNSPECIFIED {
 		result["previousState"] = e.PreviousState.String()
 	}
+	if e.Type != pb.ContainerEventType_CONTAINER_EVENT_TYPE_UNSPECIFIED {
+		result["type"] = e.Type.String()
+	}
+	if e.Actor != "" {
+		result["actor"] = e.Actor
+	}
+	if len(e.Details) > 0 {
+		result["details"] = e.Details
+	}
 	return result
 }
 
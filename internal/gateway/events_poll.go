@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/footprintai/containarium/internal/apierr"
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/events"
+)
+
+// Long-poll bounds. The default leaves headroom under the ~60s request
+// timeout most MCP clients apply to a tool call.
+const (
+	pollDefaultTimeout = 25 * time.Second
+	pollMaxTimeout     = 55 * time.Second
+)
+
+// PollEventHandler serves GET /v1/events/poll: the events since a cursor,
+// blocking until one arrives or the timeout passes. It is the
+// request/response counterpart of the SSE and WebSocket streams, for
+// clients such as MCP over stdio that cannot hold a stream open.
+type PollEventHandler struct {
+	journal       *events.Journal
+	validateToken func(token string) (*auth.Claims, error)
+}
+
+// NewPollEventHandler creates a poll handler reading from journal.
+// validateToken is normally AuthMiddleware.ValidateToken.
+func NewPollEventHandler(journal *events.Journal, validateToken func(string) (*auth.Claims, error)) *PollEventHandler {
+	return &PollEventHandler{journal: journal, validateToken: validateToken}
+}
+
+// pollEventsResponse is the JSON body of a poll. Events use the same
+// shape as the SSE and WebSocket endpoints.
+type pollEventsResponse struct {
+	Events []map[string]interface{} `json:"events"`
+	Cursor string                   `json:"cursor"`
+	Missed bool                     `json:"missed,omitempty"`
+}
+
+// HandlePoll answers one long-poll. Query parameters: cursor (from the
+// previous response; omit to start from now), timeoutSeconds (default 25,
+// max 55), and the resourceTypes/containerEventTypes filters the streams
+// accept. Like the event stream, it requires containers:read.
+func (h *PollEventHandler) HandlePoll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	token, _ := auth.ExtractBearerForUpgrade(r)
+	if _, ok := authenticateEvents(w, r, h.validateToken, token, auth.ScopeContainersRead); !ok {
+		return
+	}
+
+	timeout := pollDefaultTimeout
+	if v := r.URL.Query().Get("timeoutSeconds"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			apierr.Write(w, r, http.StatusBadRequest, "timeoutSeconds must be a non-negative integer")
+			return
+		}
+		timeout = min(time.Duration(n)*time.Second, pollMaxTimeout)
+	}
+
+	res, err := h.journal.Poll(r.Context(), r.URL.Query().Get("cursor"), timeout, parseEventFilter(r))
+	if err != nil {
+		if r.Context().Err() != nil {
+			return // client went away
+		}
+		apierr.Write(w, r, http.StatusBadRequest, err.Error()+"; omit cursor to start from now")
+		return
+	}
+
+	resp := pollEventsResponse{
+		Events: make([]map[string]interface{}, 0, len(res.Events)),
+		Cursor: res.Cursor,
+		Missed: res.Missed,
+	}
+	for _, event := range res.Events {
+		resp.Events = append(resp.Events, protoEventToMap(event))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Warning: failed to write events poll response: %v", err)
+	}
+}
@@ -17,9 +17,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/internal/audit"
 	"github.com/footprintai/containarium/internal/auth"
 	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/internal/metrics/opmetrics"
 	"github.com/footprintai/containarium/internal/mtls"
 	"github.com/footprintai/containarium/internal/releases"
 	"github.com/footprintai/containarium/internal/security"
@@ -48,6 +50,8 @@ type GatewayServer struct {
 	terminalHandler        *TerminalHandler
 	labelHandler           *LabelHandler
 	eventHandler           *EventHandler
+	wsEventHandler         *WSEventHandler
+	pollEventHandler       *PollEventHandler
 	coreServicesHandler    *CoreServicesHandler
 
 	// Guacamole reverse proxy (browser-based RDP for Windows VMs)
@@ -106,6 +110,16 @@ type GatewayServer struct {
 	alertRelayURL    string // external webhook URL to forward to
 	alertRelaySecret string // HMAC-SHA256 signing secret
 
+	// Per-container traffic counters (set externally). Mounted at
+	// /metrics/traffic behind the JWT auth middleware: unlike /metrics its
+	// series are labeled by container, which names the tenant.
+	trafficMetricsHandler http.Handler
+
+	// Alert action handler (set externally). Alertmanager posts alerts
+	// that ask for an action, such as a bandwidth limit, here; the
+	// handler checks Alertmanager's bearer token itself.
+	alertActionHandler http.Handler
+
 	// Callback to record relay delivery attempts (set by dual_server)
 	recordDeliveryFn func(ctx context.Context, alertName, source, webhookURL string, success bool, httpStatus int, errMsg string, payloadSize, durationMs int)
 }
@@ -132,6 +146,8 @@ func NewGatewayServer(grpcAddress string, httpPort int, authMiddleware *auth.Aut
 
 	// Create event handler with global event bus
 	eventHandler := NewEventHandler(events.GetBus())
+	wsEventHandler := NewWSEventHandler(events.GetBus(), authMiddleware.ValidateToken)
+	pollEventHandler := NewPollEventHandler(events.GetJournal(), authMiddleware.ValidateToken)
 
 	return &GatewayServer{
 		grpcAddress:         grpcAddress,
@@ -143,6 +159,8 @@ func NewGatewayServer(grpcAddress string, httpPort int, authMiddleware *auth.Aut
 		terminalHandler:     terminalHandler,
 		labelHandler:        labelHandler,
 		eventHandler:        eventHandler,
+		wsEventHandler:      wsEventHandler,
+		pollEventHandler:    pollEventHandler,
 		coreServicesHandler: coreServicesHandler,
 	}
 }
@@ -162,6 +180,12 @@ func (gs *GatewayServer) SetGuacamoleBackendURL(backendURL string) {
 	gs.guacamoleBackendURL = backendURL
 }
 
+// SetTrafficMetricsHandler sets the handler for the per-container
+// traffic counters on /metrics/traffic.
+func (gs *GatewayServer) SetTrafficMetricsHandler(h http.Handler) {
+	gs.trafficMetricsHandler = h
+}
+
 // SetSecurityStore sets the security store for the CSV export endpoint
 func (gs *GatewayServer) SetSecurityStore(store *security.Store) {
 	gs.securityStore = store
@@ -248,6 +272,11 @@ func (gs *GatewayServer) SetTerminalPeerProxy(proxy PeerTerminalProxy) {
 	}
 }
 
+// SetAlertActionHandler sets the handler mounted at /internal/alert-action.
+func (gs *GatewayServer) SetAlertActionHandler(handler http.Handler) {
+	gs.alertActionHandler = handler
+}
+
 func (gs *GatewayServer) SetAlertRelayConfig(webhookURL, secret string) {
 	gs.alertRelayMu.Lock()
 	defer gs.alertRelayMu.Unlock()
@@ -260,7 +289,7 @@ func (gs *GatewayServer) SetAlertRelayConfig(webhookURL, secret string) {
 // No JWT auth is required — this endpoint is only reachable from the local container network.
 func (gs *GatewayServer) handleAlertRelay(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
@@ -270,14 +299,14 @@ func (gs *GatewayServer) handleAlertRelay(w http.ResponseWriter, r *http.Request
 	gs.alertRelayMu.RUnlock()
 
 	if webhookURL == "" {
-		http.Error(w, `{"error":"no webhook URL configured"}`, http.StatusServiceUnavailable)
+		apierr.Write(w, r, http.StatusServiceUnavailable, "no webhook URL configured")
 		return
 	}
 
 	// Read the body from Alertmanager
 	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20)) // 1 MB limit
 	if err != nil {
-		http.Error(w, `{"error":"failed to read body"}`, http.StatusBadRequest)
+		apierr.Write(w, r, http.StatusBadRequest, "failed to read body")
 		return
 	}
 
@@ -287,7 +316,7 @@ func (gs *GatewayServer) handleAlertRelay(w http.ResponseWriter, r *http.Request
 	// Create forwarding request
 	fwdReq, err := http.NewRequestWithContext(r.Context(), http.MethodPost, webhookURL, bytes.NewReader(body))
 	if err != nil {
-		http.Error(w, `{"error":"failed to create forwarding request"}`, http.StatusInternalServerError)
+		apierr.Write(w, r, http.StatusInternalServerError, "failed to create forwarding request")
 		return
 	}
 	fwdReq.Header.Set("Content-Type", "application/json")
@@ -308,7 +337,7 @@ func (gs *GatewayServer) handleAlertRelay(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		log.Printf("Alert relay: failed to forward to %s: %v", webhookURL, err)
 		gs.recordRelayDelivery(r.Context(), alertName, webhookURL, false, 0, fmt.Sprintf("failed to forward: %v", err), len(body), durationMs)
-		http.Error(w, fmt.Sprintf(`{"error":"failed to forward: %v"}`, err), http.StatusBadGateway)
+		apierr.Write(w, r, http.StatusBadGateway, fmt.Sprintf("failed to forward: %v", err))
 		return
 	}
 	defer resp.Body.Close()
@@ -454,6 +483,16 @@ func (gs *GatewayServer) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to register kms service gateway: %w", err)
 	}
 
+	// Register AdminService gateway handler (config reload)
+	if err := pb.RegisterAdminServiceHandlerFromEndpoint(ctx, mux, gs.grpcAddress, opts); err != nil {
+		return fmt.Errorf("failed to register admin service gateway: %w", err)
+	}
+
+	// Register JobsService gateway handler (scheduled jobs + history)
+	if err := pb.RegisterJobsServiceHandlerFromEndpoint(ctx, mux, gs.grpcAddress, opts); err != nil {
+		return fmt.Errorf("failed to register jobs service gateway: %w", err)
+	}
+
 	// Register NetworkPolicyService gateway handler (#315)
 	if err := pb.RegisterNetworkPolicyServiceHandlerFromEndpoint(ctx, mux, gs.grpcAddress, opts); err != nil {
 		return fmt.Errorf("failed to register network policy service gateway: %w", err)
@@ -498,7 +537,7 @@ func (gs *GatewayServer) Start(ctx context.Context) error {
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   getAllowedOrigins(),
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "Idempotency-Key"},
 		ExposedHeaders:   []string{"Content-Length"},
 		AllowCredentials: true,
 		MaxAge:           300,
@@ -531,13 +570,13 @@ func (gs *GatewayServer) Start(ctx context.Context) error {
 
 			// SECURITY FIX: Authentication is MANDATORY for terminal access
 			if token == "" {
-				http.Error(w, `{"error": "unauthorized: token required for terminal access", "code": 401}`, http.StatusUnauthorized)
+				apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: token required for terminal access")
 				return
 			}
 
 			claims, err := gs.authMiddleware.ValidateToken(token)
 			if err != nil {
-				http.Error(w, `{"error": "unauthorized: invalid token", "code": 401}`, http.StatusUnauthorized)
+				apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: invalid token")
 				return
 			}
 
@@ -610,6 +649,20 @@ func (gs *GatewayServer) Start(ctx context.Context) error {
 		httpMux.Handle("/v1/", corsHandler)
 	}
 
+	// Prometheus scrape endpoint for the daemon's operational metrics
+	// (internal/metrics/opmetrics). Unauthenticated like most scrape
+	// targets: every series is labeled by RPC method / operation / outcome
+	// only, never by tenant, so there's nothing tenant-identifying to leak.
+	httpMux.Handle("/metrics", opmetrics.Handler())
+
+	// Per-container traffic counters. These name containers, so a scrape
+	// needs an admin token with the traffic:read scope (Prometheus
+	// `authorization: {credentials: ...}`).
+	if gs.trafficMetricsHandler != nil {
+		httpMux.Handle("/metrics/traffic", gs.authMiddleware.HTTPMiddleware(
+			requireAdminFromContext(requireScopeFromContext(auth.ScopeTrafficRead, gs.trafficMetricsHandler))))
+	}
+
 	// Wake-on-HTTP handler (no auth — Caddy forwards user traffic here
 	// while a container is auto-slept, and that traffic carries no JWT).
 	// /wake/* is the explicit smoke-test path; the daemon's path-based
@@ -677,13 +730,13 @@ func (gs *GatewayServer) Start(ctx context.Context) error {
 		}
 
 		if token == "" {
-			http.Error(w, `{"error": "unauthorized: token required for events", "code": 401}`, http.StatusUnauthorized)
+			apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: token required for events")
 			return
 		}
 
 		_, err := gs.authMiddleware.ValidateToken(token)
 		if err != nil {
-			http.Error(w, `{"error": "unauthorized: invalid token", "code": 401}`, http.StatusUnauthorized)
+			apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: invalid token")
 			return
 		}
 
@@ -691,6 +744,16 @@ func (gs *GatewayServer) Start(ctx context.Context) error {
 	}))
 	httpMux.Handle("/v1/events/subscribe", eventsWithCORS)
 
+	// WebSocket event/traffic streams for browser dashboards. No CORS
+	// wrapper: upgrades aren't preflighted, and the handler checks the
+	// Origin and the JWT itself before upgrading.
+	httpMux.HandleFunc("/v1/ws/events", gs.wsEventHandler.HandleEvents)
+	httpMux.HandleFunc("/v1/ws/traffic", gs.wsEventHandler.HandleTraffic)
+
+	// Long-poll events for clients without a streaming transport (MCP
+	// over stdio). Authenticates like the streams.
+	httpMux.HandleFunc("/v1/events/poll", gs.pollEventHandler.HandlePoll)
+
 	// Swagger UI routes. Phase 5.1 (audit A-LOW-1) — both
 	// the UI bundle and the spec discloses the full API
 	// surface (route paths, request shapes, security
@@ -706,6 +769,9 @@ func (gs *GatewayServer) Start(ctx context.Context) error {
 
 	// Alert relay endpoint (no auth — internal network only, called by Alertmanager)
 	httpMux.HandleFunc("/internal/alert-relay", gs.handleAlertRelay)
+	if gs.alertActionHandler != nil {
+		httpMux.Handle("/internal/alert-action", gs.alertActionHandler)
+	}
 
 	// Health check endpoint (no auth required)
 	httpMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -798,21 +864,18 @@ func (gs *GatewayServer) Start(ctx context.Context) error {
 	return http.ListenAndServe(addr, httpMux)
 }
 
-// customErrorHandler provides better error messages
+// customErrorHandler renders gRPC errors as the daemon's REST error
+// envelope (see apierr): the status message rather than the "rpc error:
+// code = ... desc = ..." string, the HTTP status, the gRPC code as the
+// machine-readable reason, and a request ID. Server-side failures are
+// logged under that ID so a client report can be matched to the log.
 func customErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
-	w.Header().Set("Content-Type", "application/json")
-
-	// Map gRPC codes to HTTP status codes
-	httpStatus := runtime.HTTPStatusFromCode(status.Code(err))
-	w.WriteHeader(httpStatus)
-
-	// Format error response
-	errorResp := map[string]interface{}{
-		"error": err.Error(),
-		"code":  httpStatus,
+	st := status.Convert(err)
+	httpStatus := runtime.HTTPStatusFromCode(st.Code())
+	if httpStatus >= http.StatusInternalServerError {
+		log.Printf("Warning: %s %s failed (request %s): %v", r.Method, r.URL.Path, apierr.RequestID(w, r), err)
 	}
-
-	_ = json.NewEncoder(w).Encode(errorResp)
+	apierr.WriteReason(w, r, httpStatus, apierr.ReasonForCode(st.Code()), st.Message())
 }
 
 // annotateContext adds metadata that grpc-gateway forwards into the
@@ -831,7 +894,20 @@ func requireAdminFromContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		roles, _ := auth.RolesFromContext(r.Context())
 		if !auth.HasRole(roles, auth.RoleAdmin) {
-			http.Error(w, `{"error":"admin role required","code":403}`, http.StatusForbidden)
+			apierr.Write(w, r, http.StatusForbidden, "admin role required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireScopeFromContext rejects a request whose token was narrowed to
+// scopes that leave out scope. Must run after HTTPMiddleware.
+func requireScopeFromContext(scope string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scopes, _ := auth.ScopesFromContext(r.Context())
+		if !auth.HasScope(scopes, scope) {
+			apierr.Write(w, r, http.StatusForbidden, "scope required: "+scope)
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -855,6 +931,11 @@ func annotateContext(ctx context.Context, req *http.Request) metadata.MD {
 	if scopes, ok := auth.ScopesFromContext(ctx); ok && len(scopes) > 0 {
 		md.Set(auth.MDKeyScopes, strings.Join(scopes, ","))
 	}
+	// grpc-gateway only forwards Grpc-Metadata-* and permanent headers by
+	// default; CreateContainer reads the client's Idempotency-Key here.
+	if key := req.Header.Get("Idempotency-Key"); key != "" {
+		md.Set("idempotency-key", key)
+	}
 	return md
 }
 
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/pkg/core/container"
 )
 
@@ -61,23 +62,23 @@ type SentinelKeyAuthorizer interface {
 func ServeSentinelKeyWithAuthorizer(a SentinelKeyAuthorizer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		var req SentinelKeyRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+			apierr.Write(w, r, http.StatusBadRequest, "invalid JSON body")
 			return
 		}
 		pubKey := strings.TrimSpace(req.PublicKey)
 		if pubKey == "" {
-			http.Error(w, `{"error":"public_key is required"}`, http.StatusBadRequest)
+			apierr.Write(w, r, http.StatusBadRequest, "public_key is required")
 			return
 		}
 		updated, rotated, err := a.SetSentinelKey(r.Context(), pubKey)
 		if err != nil {
 			log.Printf("[keys] sentinel-key authorize failed: %v", err)
-			http.Error(w, `{"error":"failed to apply sentinel key"}`, http.StatusInternalServerError)
+			apierr.Write(w, r, http.StatusInternalServerError, "failed to apply sentinel key")
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -92,13 +93,13 @@ func ServeSentinelKeyWithAuthorizer(a SentinelKeyAuthorizer) http.HandlerFunc {
 func ServeAuthorizedKeysFromLister(lister ClientKeyLister, sshPort func() int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		m, err := lister.ListClientKeys(r.Context())
 		if err != nil {
 			log.Printf("[keys] lister failed: %v", err)
-			http.Error(w, `{"error":"failed to list client keys"}`, http.StatusInternalServerError)
+			apierr.Write(w, r, http.StatusInternalServerError, "failed to list client keys")
 			return
 		}
 		keys := make([]UserKeys, 0, len(m))
@@ -135,14 +136,14 @@ func ServeAuthorizedKeys(homeRoot string, containerExistsFn func(username string
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 
 		entries, err := os.ReadDir(homeRoot)
 		if err != nil {
 			log.Printf("[keys] failed to read %s: %v", homeRoot, err)
-			http.Error(w, `{"error":"failed to read home directories"}`, http.StatusInternalServerError)
+			apierr.Write(w, r, http.StatusInternalServerError, "failed to read home directories")
 			return
 		}
 
@@ -213,26 +214,26 @@ const sentinelKeyMarker = "# sshpiper sentinel upstream key"
 func ServeSentinelKey() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 
 		var req SentinelKeyRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+			apierr.Write(w, r, http.StatusBadRequest, "invalid JSON body")
 			return
 		}
 
 		pubKey := strings.TrimSpace(req.PublicKey)
 		if pubKey == "" {
-			http.Error(w, `{"error":"public_key is required"}`, http.StatusBadRequest)
+			apierr.Write(w, r, http.StatusBadRequest, "public_key is required")
 			return
 		}
 
 		updated, rotated, err := applySentinelKey("/home", pubKey)
 		if err != nil {
 			log.Printf("[keys] sentinel-key apply failed: %v", err)
-			http.Error(w, `{"error":"failed to apply sentinel key"}`, http.StatusInternalServerError)
+			apierr.Write(w, r, http.StatusInternalServerError, "failed to apply sentinel key")
 			return
 		}
 
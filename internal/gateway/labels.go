@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/pkg/core/container"
 )
 
@@ -41,7 +42,7 @@ func (h *LabelHandler) HandleSetLabels(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/v1/containers/")
 	parts := strings.Split(path, "/")
 	if len(parts) < 2 || parts[1] != "labels" {
-		http.Error(w, `{"error":"invalid path"}`, http.StatusBadRequest)
+		apierr.Write(w, r, http.StatusBadRequest, "invalid path")
 		return
 	}
 	username := parts[0]
@@ -92,7 +93,7 @@ func (h *LabelHandler) HandleRemoveLabel(w http.ResponseWriter, r *http.Request)
 	path := strings.TrimPrefix(r.URL.Path, "/v1/containers/")
 	parts := strings.Split(path, "/")
 	if len(parts) < 3 || parts[1] != "labels" {
-		http.Error(w, `{"error":"invalid path"}`, http.StatusBadRequest)
+		apierr.Write(w, r, http.StatusBadRequest, "invalid path")
 		return
 	}
 	username := parts[0]
@@ -133,7 +134,7 @@ func (h *LabelHandler) HandleGetLabels(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/v1/containers/")
 	parts := strings.Split(path, "/")
 	if len(parts) < 2 || parts[1] != "labels" {
-		http.Error(w, `{"error":"invalid path"}`, http.StatusBadRequest)
+		apierr.Write(w, r, http.StatusBadRequest, "invalid path")
 		return
 	}
 	username := parts[0]
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/internal/auth"
 	"github.com/footprintai/containarium/internal/security"
 )
@@ -23,11 +24,11 @@ func registerSecurityExport(mux *http.ServeMux, store *security.Store, authMW *a
 			}
 		}
 		if token == "" {
-			http.Error(w, `{"error": "unauthorized: token required", "code": 401}`, http.StatusUnauthorized)
+			apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: token required")
 			return
 		}
 		if _, err := authMW.ValidateToken(token); err != nil {
-			http.Error(w, `{"error": "unauthorized: invalid token", "code": 401}`, http.StatusUnauthorized)
+			apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: invalid token")
 			return
 		}
 
@@ -42,13 +43,13 @@ func handleClamavExport(w http.ResponseWriter, r *http.Request, store *security.
 	status := r.URL.Query().Get("status")
 
 	if from == "" || to == "" {
-		http.Error(w, `{"error": "from and to date parameters are required (ISO 8601 format)"}`, http.StatusBadRequest)
+		apierr.Write(w, r, http.StatusBadRequest, "from and to date parameters are required (ISO 8601 format)")
 		return
 	}
 
 	reports, err := store.ListReportsForExport(r.Context(), from, to, containerName, status)
 	if err != nil {
-		http.Error(w, fmt.Sprintf(`{"error": "failed to query reports: %s"}`, err.Error()), http.StatusInternalServerError)
+		apierr.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("failed to query reports: %s", err.Error()))
 		return
 	}
 
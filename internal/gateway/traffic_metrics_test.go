@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/footprintai/containarium/internal/auth"
+)
+
+// /metrics/traffic names tenants' containers, so a token narrowed to other
+// scopes must not read it even when its subject is an admin.
+func TestRequireScopeFromContext(t *testing.T) {
+	stub := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	wrapped := requireScopeFromContext(auth.ScopeTrafficRead, stub)
+
+	for _, tc := range []struct {
+		name   string
+		scopes []string
+		want   int
+	}{
+		{"unscoped token", nil, http.StatusOK},
+		{"traffic scope", []string{auth.ScopeTrafficRead}, http.StatusOK},
+		{"other scope", []string{auth.ScopeKMSAdmin}, http.StatusForbidden},
+	} {
+		req := httptest.NewRequest("GET", "/metrics/traffic", nil)
+		req = req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{
+			Username: "ops",
+			Roles:    []string{auth.RoleAdmin},
+			Scopes:   tc.scopes,
+		}))
+		rec := httptest.NewRecorder()
+		wrapped.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, rec.Code, tc.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/internal/auth"
 	"github.com/footprintai/containarium/internal/releases"
 	"github.com/footprintai/containarium/pkg/version"
@@ -31,7 +32,7 @@ func registerVersionEndpoints(mux *http.ServeMux, rc *releases.Client, authMW *a
 		}
 		rel, cached, err := rc.Latest(r.Context())
 		if err != nil {
-			http.Error(w, `{"error":"failed to fetch latest release","code":502}`, http.StatusBadGateway)
+			apierr.Write(w, r, http.StatusBadGateway, "failed to fetch latest release")
 			return
 		}
 		cur := version.GetVersion()
@@ -69,11 +70,11 @@ type latestReleaseResponse struct {
 func requireBearer(w http.ResponseWriter, r *http.Request, authMW *auth.AuthMiddleware) bool {
 	authHeader := r.Header.Get("Authorization")
 	if !strings.HasPrefix(authHeader, "Bearer ") {
-		http.Error(w, `{"error":"unauthorized: Bearer token required in Authorization header","code":401}`, http.StatusUnauthorized)
+		apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: Bearer token required in Authorization header")
 		return false
 	}
 	if _, err := authMW.ValidateToken(strings.TrimPrefix(authHeader, "Bearer ")); err != nil {
-		http.Error(w, `{"error":"unauthorized: invalid token","code":401}`, http.StatusUnauthorized)
+		apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: invalid token")
 		return false
 	}
 	return true
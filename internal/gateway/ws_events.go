@@ -0,0 +1,395 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/footprintai/containarium/internal/apierr"
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/internal/traffic"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// WebSocket transport for SubscribeEvents / SubscribeTraffic.
+//
+// grpc-gateway can't expose server streams in a way browsers consume
+// well, so dashboards connect here instead:
+//
+//	const ws = new WebSocket("wss://<host>/v1/ws/traffic?containerName=alice-container",
+//	  ["containarium.bearer", token]);
+//
+// The JWT is checked before the upgrade (subprotocol, Authorization
+// header, or the deprecated ?token=). The subscription filter takes the
+// same fields as the gRPC request, either as query params or — when the
+// URL carries none — as the first text message, protojson-encoded
+// (e.g. {"containerName":"alice-container","eventTypes":["TRAFFIC_EVENT_TYPE_NEW"]}).
+// A client that sends nothing is subscribed unfiltered after
+// wsInitialFilterWait.
+//
+// Every frame the server sends is one JSON object with a "type":
+//
+//	{"type":"subscribed","subscriptionId":"...","filter":{...}}
+//	{"type":"event","event":{...}}      // /v1/ws/events, same shape as the SSE data
+//	{"type":"traffic","event":{...}}    // /v1/ws/traffic, protojson TrafficEvent
+//	{"type":"error","error":"..."}      // followed by a close frame
+//
+// Slow clients are not disconnected: the bus drops their oldest queued
+// events instead.
+
+const (
+	// wsWriteWait bounds a single frame write.
+	wsWriteWait = 10 * time.Second
+
+	// wsPongWait is how long the server waits for a pong (or any other
+	// frame) before treating the client as gone.
+	wsPongWait = 60 * time.Second
+
+	// wsPingPeriod must be shorter than wsPongWait.
+	wsPingPeriod = (wsPongWait * 9) / 10
+
+	// wsInitialFilterWait is how long the server waits for a filter
+	// message when the URL carried no filter params.
+	wsInitialFilterWait = 3 * time.Second
+
+	// wsMaxMessageSize caps client messages; only a filter is expected.
+	wsMaxMessageSize = 4096
+)
+
+// wsFrame is the envelope of every server-to-client message.
+type wsFrame struct {
+	Type           string          `json:"type"`
+	SubscriptionID string          `json:"subscriptionId,omitempty"`
+	Filter         json.RawMessage `json:"filter,omitempty"`
+	Event          interface{}     `json:"event,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// WSEventHandler serves /v1/ws/events and /v1/ws/traffic.
+type WSEventHandler struct {
+	bus           *events.Bus
+	validateToken func(token string) (*auth.Claims, error)
+	upgrader      websocket.Upgrader
+}
+
+// NewWSEventHandler creates a WebSocket event handler. validateToken is
+// normally AuthMiddleware.ValidateToken.
+func NewWSEventHandler(bus *events.Bus, validateToken func(string) (*auth.Claims, error)) *WSEventHandler {
+	return &WSEventHandler{
+		bus:           bus,
+		validateToken: validateToken,
+		upgrader: websocket.Upgrader{
+			// Same policy as the SSE endpoint: browsers always send
+			// Origin, so a present-but-unknown one is rejected; non-browser
+			// clients without Origin still need a valid token.
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || isAllowedOrigin(origin)
+			},
+			Subprotocols:    []string{auth.WSSubprotocolBearer},
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+		},
+	}
+}
+
+// HandleEvents streams resource events (containers, apps, routes,
+// metrics). Traffic events are never sent here — they carry per-tenant
+// connection data and go through HandleTraffic's authorization.
+func (h *WSEventHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	ctx, ok := h.authenticate(w, r, auth.ScopeContainersRead)
+	if !ok {
+		return
+	}
+	filter := parseEventFilter(r)
+	fromQuery := hasAnyQueryParam(r, "resourceTypes", "includeMetrics", "metricsInterval")
+
+	ws, err := h.upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer ws.close()
+
+	if !fromQuery {
+		if !ws.initialFilter(filter) {
+			return
+		}
+	}
+
+	sub := h.bus.Subscribe(filter)
+	defer h.bus.Unsubscribe(sub.ID)
+	log.Printf("WebSocket events client connected: %s (user=%s)", sub.ID, usernameOf(ctx))
+
+	if !ws.writeSubscribed(sub.ID, filter) {
+		return
+	}
+	ws.pump(sub, func(event *pb.Event) *wsFrame {
+		if event.ResourceType == pb.ResourceType_RESOURCE_TYPE_TRAFFIC {
+			return nil
+		}
+		return &wsFrame{Type: "event", Event: protoEventToMap(event)}
+	})
+}
+
+// HandleTraffic streams live traffic events with SubscribeTraffic's
+// authorization: traffic:read, plus admin for an all-container stream or
+// ownership of the named container.
+func (h *WSEventHandler) HandleTraffic(w http.ResponseWriter, r *http.Request) {
+	ctx, ok := h.authenticate(w, r, auth.ScopeTrafficRead)
+	if !ok {
+		return
+	}
+	req := parseTrafficFilter(r)
+	fromQuery := hasAnyQueryParam(r, "containerName", "eventTypes", "externalOnly")
+
+	ws, err := h.upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer ws.close()
+
+	if !fromQuery {
+		if !ws.initialFilter(req) {
+			return
+		}
+	}
+
+	var authErr error
+	if req.ContainerName == "" {
+		authErr = auth.RequireRole(ctx, auth.RoleAdmin)
+	} else {
+		authErr = auth.AuthorizeContainerAccess(ctx, req.ContainerName)
+	}
+	if authErr != nil {
+		ws.fail(websocket.ClosePolicyViolation, status.Convert(authErr).Message())
+		return
+	}
+
+	sub := h.bus.Subscribe(&pb.SubscribeEventsRequest{
+		ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_TRAFFIC},
+	})
+	defer h.bus.Unsubscribe(sub.ID)
+	log.Printf("WebSocket traffic client connected: %s (user=%s, container=%q)", sub.ID, usernameOf(ctx), req.ContainerName)
+
+	if !ws.writeSubscribed(sub.ID, req) {
+		return
+	}
+	ws.pump(sub, func(event *pb.Event) *wsFrame {
+		te := event.GetTrafficEvent()
+		if !traffic.MatchesSubscription(req, te) {
+			return nil
+		}
+		data, err := protojson.Marshal(te)
+		if err != nil {
+			log.Printf("Failed to marshal traffic event: %v", err)
+			return nil
+		}
+		return &wsFrame{Type: "traffic", Event: json.RawMessage(data)}
+	})
+}
+
+// authenticate validates the bearer token and the required scope before
+// the upgrade, so failures are plain HTTP errors. The returned context
+// carries the claims for the auth.Authorize* helpers.
+func (h *WSEventHandler) authenticate(w http.ResponseWriter, r *http.Request, scope string) (context.Context, bool) {
+	token, src := auth.ExtractBearerForUpgrade(r)
+	if src == auth.TokenSourceQueryParam {
+		log.Printf("WARNING: WebSocket events client used deprecated ?token= (remote=%s) — switch to Sec-WebSocket-Protocol", r.RemoteAddr)
+	}
+	return authenticateEvents(w, r, h.validateToken, token, scope)
+}
+
+// authenticateEvents checks an events client's bearer token and scope,
+// writing the error response itself when either fails.
+func authenticateEvents(w http.ResponseWriter, r *http.Request, validateToken func(string) (*auth.Claims, error), token, scope string) (context.Context, bool) {
+	if token == "" {
+		apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: token required for events")
+		return nil, false
+	}
+	claims, err := validateToken(token)
+	if err != nil {
+		apierr.Write(w, r, http.StatusUnauthorized, "unauthorized: invalid token")
+		return nil, false
+	}
+	ctx := auth.ContextWithClaims(r.Context(), claims)
+	if err := auth.RequireScope(ctx, scope); err != nil {
+		apierr.Write(w, r, http.StatusForbidden, "forbidden: scope required: "+scope)
+		return nil, false
+	}
+	return ctx, true
+}
+
+func (h *WSEventHandler) upgrade(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written the HTTP error response.
+		log.Printf("WebSocket events upgrade failed: %v", err)
+		return nil, err
+	}
+	ws := &wsConn{
+		conn:     conn,
+		messages: make(chan []byte, 1),
+		closed:   make(chan struct{}),
+	}
+	go ws.readLoop()
+	return ws, nil
+}
+
+// wsConn wraps a connection with a single reader goroutine (keepalive
+// deadlines, the optional filter message) and a single writer — the
+// handler goroutine — as gorilla requires.
+type wsConn struct {
+	conn     *websocket.Conn
+	messages chan []byte
+	closed   chan struct{}
+}
+
+func (c *wsConn) readLoop() {
+	defer close(c.closed)
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	for {
+		typ, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		if typ != websocket.TextMessage {
+			continue
+		}
+		// Only the first message means anything; later ones are dropped.
+		select {
+		case c.messages <- data:
+		default:
+		}
+	}
+}
+
+// initialFilter waits briefly for a protojson filter message and merges
+// it into filter. Returns false if the connection should end.
+func (c *wsConn) initialFilter(filter proto.Message) bool {
+	select {
+	case data := <-c.messages:
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, filter); err != nil {
+			c.fail(websocket.CloseInvalidFramePayloadData, "invalid filter message: "+err.Error())
+			return false
+		}
+		return true
+	case <-time.After(wsInitialFilterWait):
+		return true
+	case <-c.closed:
+		return false
+	}
+}
+
+func (c *wsConn) writeSubscribed(id string, filter proto.Message) bool {
+	data, err := protojson.Marshal(filter)
+	if err != nil {
+		data = nil
+	}
+	return c.writeFrame(&wsFrame{Type: "subscribed", SubscriptionID: id, Filter: data})
+}
+
+// pump forwards bus events until either side goes away. toFrame returns
+// nil for events this client shouldn't see.
+func (c *wsConn) pump(sub *events.Subscriber, toFrame func(*pb.Event) *wsFrame) {
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-sub.Done:
+			return
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			frame := toFrame(event)
+			if frame == nil {
+				continue
+			}
+			if !c.writeFrame(frame) {
+				return
+			}
+		}
+	}
+}
+
+func (c *wsConn) writeFrame(frame *wsFrame) bool {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	if err := c.conn.WriteJSON(frame); err != nil {
+		return false
+	}
+	return true
+}
+
+// fail sends an error frame followed by a close frame with code.
+func (c *wsConn) fail(code int, msg string) {
+	if c.writeFrame(&wsFrame{Type: "error", Error: msg}) {
+		// Control-frame payloads are capped at 125 bytes; the full
+		// message already went out in the error frame.
+		reason := msg
+		if len(reason) > 120 {
+			reason = reason[:120]
+		}
+		_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteWait))
+	}
+}
+
+func (c *wsConn) close() {
+	_ = c.conn.Close()
+}
+
+// parseTrafficFilter parses a SubscribeTrafficRequest from query params:
+// containerName, eventTypes (repeatable; "new", "update", "destroy" or the
+// full enum name) and externalOnly.
+func parseTrafficFilter(r *http.Request) *pb.SubscribeTrafficRequest {
+	q := r.URL.Query()
+	req := &pb.SubscribeTrafficRequest{
+		ContainerName: q.Get("containerName"),
+		ExternalOnly:  q.Get("externalOnly") == "true",
+	}
+	for _, et := range q["eventTypes"] {
+		name := strings.ToUpper(et)
+		if !strings.HasPrefix(name, "TRAFFIC_EVENT_TYPE_") {
+			name = "TRAFFIC_EVENT_TYPE_" + name
+		}
+		if v, ok := pb.TrafficEventType_value[name]; ok && v != 0 {
+			req.EventTypes = append(req.EventTypes, pb.TrafficEventType(v))
+		}
+	}
+	return req
+}
+
+func hasAnyQueryParam(r *http.Request, names ...string) bool {
+	q := r.URL.Query()
+	for _, n := range names {
+		if q.Has(n) {
+			return true
+		}
+	}
+	return false
+}
+
+func usernameOf(ctx context.Context) string {
+	u, _ := auth.UsernameFromContext(ctx)
+	return u
+}
@@ -0,0 +1,244 @@
+package gateway
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/events"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite testdata/*.golden files")
+
+const wsTestSecret = "ws-events-test-secret-at-least-32-bytes-long"
+
+// The golden files pair a browser snippet with the frames the handler
+// actually sent, so the documented client code and the wire format can't
+// drift apart silently.
+const wsEventsJSClient = `// Browser client for /v1/ws/events. The filter goes in the first message
+// (protojson SubscribeEventsRequest) because the URL carries none.
+const ws = new WebSocket(` + "`wss://${host}/v1/ws/events`" + `, ["containarium.bearer", token]);
+ws.onopen = () => ws.send(JSON.stringify({ resourceTypes: ["RESOURCE_TYPE_CONTAINER"] }));
+ws.onmessage = (msg) => {
+  const frame = JSON.parse(msg.data);
+  switch (frame.type) {
+    case "subscribed": console.log("subscribed", frame.subscriptionId, frame.filter); break;
+    case "event":      console.log(frame.event.type, frame.event.containerEvent.container.state); break;
+    case "error":      console.error(frame.error); break;
+  }
+};
+`
+
+const wsTrafficJSClient = `// Browser client for /v1/ws/traffic, filtered via query params.
+const ws = new WebSocket(` + "`wss://${host}/v1/ws/traffic?containerName=alice-container&eventTypes=new`" + `,
+  ["containarium.bearer", token]);
+ws.onmessage = (msg) => {
+  const frame = JSON.parse(msg.data);
+  switch (frame.type) {
+    case "subscribed": console.log("subscribed", frame.subscriptionId, frame.filter); break;
+    case "traffic": {
+      const c = frame.event.connection;
+      console.log(frame.event.type, c.destIp + ":" + c.destPort, c.bytesSent);
+      break;
+    }
+    case "error":      console.error(frame.error); break;
+  }
+};
+`
+
+func newWSTestServer(t *testing.T) (*events.Bus, *httptest.Server, *auth.TokenManager) {
+	t.Helper()
+	tm, err := auth.NewTokenManager(wsTestSecret, "containarium")
+	require.NoError(t, err)
+	bus := events.NewBus()
+	h := NewWSEventHandler(bus, auth.NewAuthMiddleware(tm).ValidateToken)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/ws/events", h.HandleEvents)
+	mux.HandleFunc("/v1/ws/traffic", h.HandleTraffic)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return bus, srv, tm
+}
+
+func wsToken(t *testing.T, tm *auth.TokenManager, username string, roles []string, scopes ...string) string {
+	t.Helper()
+	tok, err := tm.GenerateToken(username, roles, time.Hour, scopes...)
+	require.NoError(t, err)
+	return tok
+}
+
+func dialWS(t *testing.T, srv *httptest.Server, path, token string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	dialer := websocket.Dialer{}
+	if token != "" {
+		dialer.Subprotocols = []string{auth.WSSubprotocolBearer, token}
+	}
+	conn, resp, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+path, nil)
+	if conn != nil {
+		t.Cleanup(func() { _ = conn.Close() })
+	}
+	return conn, resp, err
+}
+
+func readFrame(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+	return strings.TrimSpace(string(data))
+}
+
+// checkGolden compares the JS snippet plus the received frames (with the
+// random subscription ID masked) against testdata/<name>.
+func checkGolden(t *testing.T, name, js, subID string, frames ...string) {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString(js)
+	b.WriteString("\n// Frames received:\n")
+	for _, f := range frames {
+		b.WriteString(strings.ReplaceAll(f, subID, "<subscription-id>"))
+		b.WriteString("\n")
+	}
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(b.String()), 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run with -update to create %s", path)
+	assert.Equal(t, string(want), b.String())
+}
+
+func subscriptionID(t *testing.T, frame string) string {
+	t.Helper()
+	const key = `"subscriptionId":"`
+	i := strings.Index(frame, key)
+	require.GreaterOrEqual(t, i, 0, frame)
+	rest := frame[i+len(key):]
+	return rest[:strings.Index(rest, `"`)]
+}
+
+func TestWSEvents_InitialMessageFilterGolden(t *testing.T) {
+	bus, srv, tm := newWSTestServer(t)
+	conn, _, err := dialWS(t, srv, "/v1/ws/events", wsToken(t, tm, "alice", []string{"user"}, auth.ScopeContainersRead))
+	require.NoError(t, err)
+	assert.Equal(t, auth.WSSubprotocolBearer, conn.Subprotocol())
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"resourceTypes":["RESOURCE_TYPE_CONTAINER"]}`)))
+	subscribed := readFrame(t, conn)
+
+	ts := timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	// Routes are filtered by the bus, traffic by the handler; only the
+	// container event should reach the client.
+	bus.Publish(&pb.Event{Id: "evt-route", Type: pb.EventType_EVENT_TYPE_ROUTE_ADDED, ResourceType: pb.ResourceType_RESOURCE_TYPE_ROUTE, Timestamp: ts})
+	bus.Publish(&pb.Event{Id: "evt-traffic", ResourceType: pb.ResourceType_RESOURCE_TYPE_TRAFFIC, Timestamp: ts})
+	bus.Publish(&pb.Event{
+		Id:           "evt-1",
+		Type:         pb.EventType_EVENT_TYPE_CONTAINER_STARTED,
+		ResourceType: pb.ResourceType_RESOURCE_TYPE_CONTAINER,
+		ResourceId:   "alice-container",
+		Timestamp:    ts,
+		Payload: &pb.Event_ContainerEvent{ContainerEvent: &pb.ContainerEvent{
+			Container:     &pb.Container{Name: "alice-container", Username: "alice", State: pb.ContainerState_CONTAINER_STATE_RUNNING},
+			PreviousState: pb.ContainerState_CONTAINER_STATE_STOPPED,
+		}},
+	})
+	event := readFrame(t, conn)
+
+	checkGolden(t, "ws_events.golden", wsEventsJSClient, subscriptionID(t, subscribed), subscribed, event)
+}
+
+func TestWSTraffic_QueryFilterGolden(t *testing.T) {
+	bus, srv, tm := newWSTestServer(t)
+	conn, _, err := dialWS(t, srv, "/v1/ws/traffic?containerName=alice-container&eventTypes=new",
+		wsToken(t, tm, "alice", []string{"user"}, auth.ScopeTrafficRead))
+	require.NoError(t, err)
+	subscribed := readFrame(t, conn)
+
+	ts := timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	publish := func(container string, typ pb.TrafficEventType) {
+		bus.Publish(&pb.Event{
+			ResourceType: pb.ResourceType_RESOURCE_TYPE_TRAFFIC,
+			Timestamp:    ts,
+			Payload: &pb.Event_TrafficEvent{TrafficEvent: &pb.TrafficEvent{
+				Type:      typ,
+				Timestamp: ts,
+				Connection: &pb.Connection{
+					Id:            "conn-1",
+					ContainerName: container,
+					SourceIp:      "10.0.0.10",
+					SourcePort:    40000,
+					DestIp:        "192.0.2.1",
+					DestPort:      443,
+					BytesSent:     1234,
+				},
+			}},
+		})
+	}
+	publish("bob-container", pb.TrafficEventType_TRAFFIC_EVENT_TYPE_NEW)
+	publish("alice-container", pb.TrafficEventType_TRAFFIC_EVENT_TYPE_DESTROY)
+	publish("alice-container", pb.TrafficEventType_TRAFFIC_EVENT_TYPE_NEW)
+	event := readFrame(t, conn)
+
+	checkGolden(t, "ws_traffic.golden", wsTrafficJSClient, subscriptionID(t, subscribed), subscribed, event)
+}
+
+func TestWSTraffic_OtherTenantRejected(t *testing.T) {
+	_, srv, tm := newWSTestServer(t)
+	conn, _, err := dialWS(t, srv, "/v1/ws/traffic?containerName=bob-container",
+		wsToken(t, tm, "alice", []string{"user"}, auth.ScopeTrafficRead))
+	require.NoError(t, err)
+
+	assert.Contains(t, readFrame(t, conn), `"type":"error"`)
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), "got %v", err)
+}
+
+func TestWSTraffic_AllContainersRequiresAdmin(t *testing.T) {
+	_, srv, tm := newWSTestServer(t)
+
+	conn, _, err := dialWS(t, srv, "/v1/ws/traffic?externalOnly=false", wsToken(t, tm, "alice", []string{"user"}))
+	require.NoError(t, err)
+	assert.Contains(t, readFrame(t, conn), "admin")
+
+	conn, _, err = dialWS(t, srv, "/v1/ws/traffic?externalOnly=false", wsToken(t, tm, "root", []string{auth.RoleAdmin}))
+	require.NoError(t, err)
+	assert.Contains(t, readFrame(t, conn), `"type":"subscribed"`)
+}
+
+func TestWS_AuthenticatedBeforeUpgrade(t *testing.T) {
+	_, srv, tm := newWSTestServer(t)
+
+	_, resp, err := dialWS(t, srv, "/v1/ws/events", "")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	_, resp, err = dialWS(t, srv, "/v1/ws/events", "not-a-jwt.x.y")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	_, resp, err = dialWS(t, srv, "/v1/ws/traffic", wsToken(t, tm, "alice", []string{"user"}, auth.ScopeContainersRead))
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestParseTrafficFilter(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v1/ws/traffic?containerName=alice-container&eventTypes=new&eventTypes=TRAFFIC_EVENT_TYPE_DESTROY&eventTypes=bogus&externalOnly=true", nil)
+	req := parseTrafficFilter(r)
+	assert.Equal(t, "alice-container", req.ContainerName)
+	assert.True(t, req.ExternalOnly)
+	assert.Equal(t, []pb.TrafficEventType{pb.TrafficEventType_TRAFFIC_EVENT_TYPE_NEW, pb.TrafficEventType_TRAFFIC_EVENT_TYPE_DESTROY}, req.EventTypes)
+}
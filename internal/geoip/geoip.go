@@ -0,0 +1,74 @@
+package geoip
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Record is what a database knows about an address. Fields a database
+// doesn't carry are left zero: a Country database has no ASN, an ASN
+// database no country.
+type Record struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country the address
+	// is located in, e.g. "DE".
+	Country string
+	// ASN is the autonomous system announcing the address.
+	ASN uint32
+	// ASOrg is the organization the ASN is registered to.
+	ASOrg string
+}
+
+// Empty reports whether r carries nothing.
+func (r Record) Empty() bool {
+	return r == Record{}
+}
+
+// Merge fills r's empty fields from o, so a Country and an ASN database
+// together give a full record.
+func (r Record) Merge(o Record) Record {
+	if r.Country == "" {
+		r.Country = o.Country
+	}
+	if r.ASN == 0 {
+		r.ASN, r.ASOrg = o.ASN, o.ASOrg
+	}
+	return r
+}
+
+// Lookup returns what the database knows about addr; the zero Record when
+// nothing (private and reserved ranges, or addresses the database
+// doesn't cover). An error means the database is corrupt.
+func (r *Reader) Lookup(addr netip.Addr) (Record, error) {
+	v, err := r.lookup(addr)
+	m, ok := v.(map[string]any)
+	if err != nil || !ok {
+		return Record{}, err
+	}
+
+	// MaxMind: {"country": {"iso_code": "DE"}}, falling back to the
+	// country the network is registered in; IPinfo: {"country_code": "DE"}.
+	var rec Record
+	for _, key := range []string{"country", "registered_country"} {
+		if c, ok := m[key].(map[string]any); ok && rec.Country == "" {
+			rec.Country = stringField(c, "iso_code")
+		}
+	}
+	if rec.Country == "" {
+		rec.Country = stringField(m, "country_code")
+	}
+	rec.Country = strings.ToUpper(rec.Country)
+
+	// MaxMind and DB-IP: autonomous_system_number/_organization; IPinfo:
+	// {"asn": "AS3320", "as_name": "..."}.
+	if n := uintField(m, "autonomous_system_number"); n > 0 && n <= 1<<32-1 {
+		rec.ASN = uint32(n)
+		rec.ASOrg = stringField(m, "autonomous_system_organization")
+	} else if s := stringField(m, "asn"); s != "" {
+		if n, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(s), "AS"), 10, 32); err == nil {
+			rec.ASN = uint32(n)
+			rec.ASOrg = stringField(m, "as_name")
+		}
+	}
+	return rec, nil
+}
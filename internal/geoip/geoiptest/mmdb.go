@@ -0,0 +1,210 @@
+// Package geoiptest builds small MaxMind DB files for tests of code that
+// reads them through the geoip package.
+package geoiptest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Network is one entry of a test database: the network and the record
+// stored for it, in the database's own field layout, e.g.
+// {"country": map[string]any{"iso_code": "DE"}} for a Country database or
+// {"autonomous_system_number": uint32(3320)} for an ASN one. Values may
+// be strings, uint16/uint32/uint64, bools, []any and map[string]any.
+// List a network before any network nested inside it.
+type Network struct {
+	Prefix string
+	Record map[string]any
+}
+
+// DB describes a database to build.
+type DB struct {
+	// Type is the database_type metadata, e.g. "GeoLite2-Country".
+	Type      string
+	BuildTime time.Time
+	Networks  []Network
+}
+
+// Write builds db into dir and returns the file's path. IPv4 networks are
+// stored under ::/96 of an IPv6 tree, as MaxMind's own databases are.
+func Write(t testing.TB, dir, name string, db DB) string {
+	t.Helper()
+	buf, err := Build(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, buf, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// node is a search tree node under construction. A record is a child
+// node index, a data offset, or empty.
+type node struct {
+	kind [2]byte // 0 empty, 1 node, 2 data
+	val  [2]int
+}
+
+// Build encodes db as a MaxMind DB with 24-bit records.
+func Build(db DB) ([]byte, error) {
+	var data []byte
+	nodes := []node{{}}
+	for _, n := range db.Networks {
+		p, err := netip.ParsePrefix(n.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		addr, bits := p.Addr().As16(), p.Bits()
+		if p.Addr().Is4() {
+			// ::a.b.c.d, not As16's IPv4-mapped ::ffff:a.b.c.d.
+			v4 := p.Addr().As4()
+			addr = [16]byte{12: v4[0], 13: v4[1], 14: v4[2], 15: v4[3]}
+			bits += 96
+		}
+		off := len(data)
+		if data, err = encode(data, n.Record); err != nil {
+			return nil, fmt.Errorf("%s: %w", n.Prefix, err)
+		}
+
+		cur := 0
+		for i := range bits {
+			bit := addr[i/8] >> (7 - i%8) & 1
+			if i == bits-1 {
+				nodes[cur].kind[bit], nodes[cur].val[bit] = 2, off
+				break
+			}
+			if nodes[cur].kind[bit] != 1 {
+				// Split an empty or data record: the other half keeps it.
+				child := node{kind: [2]byte{nodes[cur].kind[bit], nodes[cur].kind[bit]}, val: [2]int{nodes[cur].val[bit], nodes[cur].val[bit]}}
+				nodes = append(nodes, child)
+				nodes[cur].kind[bit], nodes[cur].val[bit] = 1, len(nodes)-1
+			}
+			cur = nodes[cur].val[bit]
+		}
+	}
+
+	count := len(nodes)
+	var out []byte
+	for _, n := range nodes {
+		for bit := range 2 {
+			var v int
+			switch n.kind[bit] {
+			case 0:
+				v = count
+			case 1:
+				v = n.val[bit]
+			case 2:
+				v = count + 16 + n.val[bit]
+			}
+			if v >= 1<<24 {
+				return nil, fmt.Errorf("database too large for 24-bit records")
+			}
+			out = append(out, byte(v>>16), byte(v>>8), byte(v))
+		}
+	}
+	out = append(out, make([]byte, 16)...)
+	out = append(out, data...)
+	out = append(out, "\xAB\xCD\xEFMaxMind.com"...)
+	meta := map[string]any{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(db.BuildTime.Unix()), // #nosec G115 -- test build times are after 1970
+		"database_type":               db.Type,
+		"ip_version":                  uint16(6),
+		"node_count":                  uint32(count), // #nosec G115 -- bounded by the 24-bit check above
+		"record_size":                 uint16(24),
+	}
+	return encode(out, meta)
+}
+
+// encode appends v in the data section encoding.
+func encode(b []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return append(control(b, 2, len(v)), v...), nil
+	case uint16:
+		return encodeUint(b, 5, uint64(v)), nil
+	case uint32:
+		return encodeUint(b, 6, uint64(v)), nil
+	case uint64:
+		return encodeUint(b, 9, v), nil
+	case bool:
+		n := 0
+		if v {
+			n = 1
+		}
+		return control(b, 14, n), nil
+	case []any:
+		b = control(b, 11, len(v))
+		for _, e := range v {
+			var err error
+			if b, err = encode(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]any:
+		b = control(b, 7, len(v))
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			var err error
+			b = append(control(b, 2, len(k)), k...)
+			if b, err = encode(b, v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported value %T", v)
+}
+
+func encodeUint(b []byte, typ int, v uint64) []byte {
+	var full [8]byte
+	binary.BigEndian.PutUint64(full[:], v)
+	n := 8
+	for n > 0 && full[8-n] == 0 {
+		n--
+	}
+	return append(control(b, typ, n), full[8-n:]...)
+}
+
+// control appends the control byte(s) for a value of typ and size.
+func control(b []byte, typ, size int) []byte {
+	first := byte(typ) << 5
+	if typ > 7 {
+		first = 0
+	}
+	var ext []byte
+	switch {
+	case size < 29:
+		first |= byte(size)
+	case size < 285:
+		first |= 29
+		ext = []byte{byte(size - 29)}
+	case size < 65821:
+		first |= 30
+		ext = []byte{byte((size - 285) >> 8), byte(size - 285)}
+	default:
+		first |= 31
+		s := size - 65821
+		ext = []byte{byte(s >> 16), byte(s >> 8), byte(s)}
+	}
+	b = append(b, first)
+	if typ > 7 {
+		b = append(b, byte(typ-7))
+	}
+	return append(b, ext...)
+}
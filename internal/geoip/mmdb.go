@@ -0,0 +1,409 @@
+// Package geoip looks remote addresses up in local MaxMind DB (.mmdb)
+// files: the GeoLite2/GeoIP2 Country and ASN databases, and the
+// compatible country/ASN files DB-IP and IPinfo publish. Only what traffic
+// enrichment needs is read: a country code and an autonomous system.
+//
+// The reader is a small implementation of the MaxMind DB format
+// (https://maxmind.github.io/MaxMind-DB/) over the file read into memory;
+// a country or ASN database is a few megabytes.
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"os"
+	"time"
+)
+
+// metadataMarker precedes the metadata map at the end of the file.
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// metadataMaxSize is how far from the end of the file the metadata may
+// start.
+const metadataMaxSize = 128 * 1024
+
+// dataSectionSeparator is the run of zero bytes between the search tree
+// and the data section.
+const dataSectionSeparator = 16
+
+// ErrInvalidDatabase is returned for files that aren't MaxMind DBs, or
+// are truncated or corrupt.
+var ErrInvalidDatabase = errors.New("invalid MaxMind DB")
+
+// Metadata describes a database.
+type Metadata struct {
+	// DatabaseType names the database, e.g. "GeoLite2-Country".
+	DatabaseType string
+	// BuildTime is when the database was built.
+	BuildTime time.Time
+	// IPVersion is 4 for an IPv4-only tree, 6 otherwise.
+	IPVersion int
+	NodeCount uint32
+	// RecordSize is the size of a search tree record in bits: 24, 28 or 32.
+	RecordSize int
+}
+
+// Reader is an open database. It is safe for concurrent use.
+type Reader struct {
+	buf       []byte
+	meta      Metadata
+	treeSize  int
+	data      []byte
+	ipv4Start uint32
+}
+
+// Open reads the database at path.
+func Open(path string) (*Reader, error) {
+	buf, err := os.ReadFile(path) // #nosec G304 -- the operator configures the database path
+	if err != nil {
+		return nil, err
+	}
+	r, err := FromBytes(buf)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+// FromBytes parses a database held in memory. buf is not copied.
+func FromBytes(buf []byte) (*Reader, error) {
+	at := bytes.LastIndex(buf[max(0, len(buf)-metadataMaxSize):], metadataMarker)
+	if at < 0 {
+		return nil, fmt.Errorf("%w: metadata marker not found", ErrInvalidDatabase)
+	}
+	at += max(0, len(buf)-metadataMaxSize) + len(metadataMarker)
+
+	d := decoder{buf: buf[at:]}
+	v, _, err := d.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: metadata: %v", ErrInvalidDatabase, err)
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+	meta := Metadata{
+		DatabaseType: stringField(m, "database_type"),
+		IPVersion:    int(uintField(m, "ip_version")),
+		RecordSize:   int(uintField(m, "record_size")),
+	}
+	if epoch := uintField(m, "build_epoch"); epoch > 0 && epoch <= math.MaxInt64 {
+		meta.BuildTime = time.Unix(int64(epoch), 0).UTC()
+	}
+	nodes := uintField(m, "node_count")
+	if nodes == 0 || nodes > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: node_count %d", ErrInvalidDatabase, nodes)
+	}
+	meta.NodeCount = uint32(nodes)
+	switch meta.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: unsupported record_size %d", ErrInvalidDatabase, meta.RecordSize)
+	}
+	if meta.IPVersion != 4 && meta.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported ip_version %d", ErrInvalidDatabase, meta.IPVersion)
+	}
+
+	treeSize := int(nodes) * meta.RecordSize / 4
+	dataStart := treeSize + dataSectionSeparator
+	metaStart := at - len(metadataMarker)
+	if dataStart > metaStart {
+		return nil, fmt.Errorf("%w: search tree overruns the file", ErrInvalidDatabase)
+	}
+	r := &Reader{buf: buf, meta: meta, treeSize: treeSize, data: buf[dataStart:metaStart]}
+
+	// IPv4 addresses live under ::/96 of an IPv6 tree; find that node once.
+	if meta.IPVersion == 6 {
+		node := uint32(0)
+		for i := 0; i < 96 && node < meta.NodeCount; i++ {
+			node = r.record(node, 0)
+		}
+		r.ipv4Start = node
+	}
+	return r, nil
+}
+
+// Metadata describes the database.
+func (r *Reader) Metadata() Metadata {
+	return r.meta
+}
+
+// record reads the left (bit 0) or right (bit 1) record of node.
+func (r *Reader) record(node uint32, bit byte) uint32 {
+	switch r.meta.RecordSize {
+	case 24:
+		off := int(node)*6 + int(bit)*3
+		b := r.buf[off : off+3]
+		return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+	case 28:
+		b := r.buf[int(node)*7 : int(node)*7+7]
+		if bit == 0 {
+			return uint32(b[3]&0xF0)<<20 | uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+		}
+		return uint32(b[3]&0x0F)<<24 | uint32(b[4])<<16 | uint32(b[5])<<8 | uint32(b[6])
+	default:
+		off := int(node)*8 + int(bit)*4
+		return binary.BigEndian.Uint32(r.buf[off : off+4])
+	}
+}
+
+// lookup returns the decoded record for addr, or nil when the database
+// has none.
+func (r *Reader) lookup(addr netip.Addr) (any, error) {
+	addr = addr.Unmap()
+	var ip []byte
+	node := uint32(0)
+	switch {
+	case addr.Is4() && r.meta.IPVersion == 6:
+		a := addr.As4()
+		ip, node = a[:], r.ipv4Start
+	case addr.Is4():
+		a := addr.As4()
+		ip = a[:]
+	case r.meta.IPVersion == 4:
+		return nil, nil
+	default:
+		a := addr.As16()
+		ip = a[:]
+	}
+
+	for i := 0; i < len(ip)*8 && node < r.meta.NodeCount; i++ {
+		node = r.record(node, ip[i/8]>>(7-i%8)&1)
+	}
+	switch {
+	case node == r.meta.NodeCount:
+		return nil, nil
+	case node < r.meta.NodeCount:
+		return nil, fmt.Errorf("%w: search tree is deeper than the address", ErrInvalidDatabase)
+	}
+	off := int(node-r.meta.NodeCount) - dataSectionSeparator
+	if off < 0 || off >= len(r.data) {
+		return nil, fmt.Errorf("%w: data pointer out of range", ErrInvalidDatabase)
+	}
+	d := decoder{buf: r.data}
+	v, _, err := d.decode(off)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDatabase, err)
+	}
+	return v, nil
+}
+
+// Data section field types.
+const (
+	typePointer   = 1
+	typeString    = 2
+	typeDouble    = 3
+	typeBytes     = 4
+	typeUint16    = 5
+	typeUint32    = 6
+	typeMap       = 7
+	typeInt32     = 8
+	typeUint64    = 9
+	typeUint128   = 10
+	typeArray     = 11
+	typeContainer = 12
+	typeEndMarker = 13
+	typeBool      = 14
+	typeFloat     = 15
+)
+
+// maxDepth bounds map and array nesting, so a corrupt file can't recurse
+// without end.
+const maxDepth = 32
+
+// decoder decodes values from a data section (or the metadata, whose
+// pointers are relative to its own start).
+type decoder struct {
+	buf   []byte
+	depth int
+}
+
+// decode decodes the value at off and returns it with the offset just
+// past it. Maps decode to map[string]any, arrays to []any, strings to
+// string, unsigned integers to uint64, int32 to int64, floats to float64.
+// 128-bit integers and raw bytes decode to []byte.
+func (d *decoder) decode(off int) (any, int, error) {
+	typ, size, off, err := d.control(off)
+	if err != nil {
+		return nil, 0, err
+	}
+	if typ == typePointer {
+		// A pointer never points at another pointer, which also keeps a
+		// corrupt file from looping.
+		ptyp, psize, poff, err := d.control(size)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ptyp == typePointer {
+			return nil, 0, errors.New("pointer to a pointer")
+		}
+		v, _, err := d.value(ptyp, psize, poff)
+		return v, off, err
+	}
+	return d.value(typ, size, off)
+}
+
+// control reads the control byte (and extended type and size bytes) at
+// off. For a pointer, size is the offset pointed to.
+func (d *decoder) control(off int) (typ, size, next int, err error) {
+	if off >= len(d.buf) {
+		return 0, 0, 0, errors.New("unexpected end of data")
+	}
+	c := d.buf[off]
+	off++
+	typ = int(c >> 5)
+	if typ == typePointer {
+		ss, vvv := int(c>>3&3), int(c&7)
+		n := ss + 1
+		if off+n > len(d.buf) {
+			return 0, 0, 0, errors.New("unexpected end of data in pointer")
+		}
+		p := 0
+		if ss < 3 {
+			p = vvv
+		}
+		for _, b := range d.buf[off : off+n] {
+			p = p<<8 | int(b)
+		}
+		p += [...]int{0, 2048, 526336, 0}[ss]
+		return typ, p, off + n, nil
+	}
+	if typ == 0 {
+		if off >= len(d.buf) {
+			return 0, 0, 0, errors.New("unexpected end of data in extended type")
+		}
+		typ = 7 + int(d.buf[off])
+		off++
+	}
+	size = int(c & 0x1f)
+	if size >= 29 {
+		n := size - 28
+		if off+n > len(d.buf) {
+			return 0, 0, 0, errors.New("unexpected end of data in size")
+		}
+		v := 0
+		for _, b := range d.buf[off : off+n] {
+			v = v<<8 | int(b)
+		}
+		size = [...]int{29, 285, 65821}[n-1] + v
+		off += n
+	}
+	return typ, size, off, nil
+}
+
+func (d *decoder) value(typ, size, off int) (any, int, error) {
+	bytesOf := func(n int) ([]byte, error) {
+		if n < 0 || off+n > len(d.buf) {
+			return nil, errors.New("unexpected end of data")
+		}
+		return d.buf[off : off+n], nil
+	}
+	uintOf := func(maxSize int) (any, int, error) {
+		if size > maxSize {
+			return nil, 0, fmt.Errorf("integer of %d bytes for type %d", size, typ)
+		}
+		b, err := bytesOf(size)
+		if err != nil {
+			return nil, 0, err
+		}
+		var v uint64
+		for _, x := range b {
+			v = v<<8 | uint64(x)
+		}
+		return v, off + size, nil
+	}
+
+	switch typ {
+	case typeString:
+		b, err := bytesOf(size)
+		if err != nil {
+			return nil, 0, err
+		}
+		return string(b), off + size, nil
+	case typeBytes, typeUint128:
+		b, err := bytesOf(size)
+		if err != nil {
+			return nil, 0, err
+		}
+		return b, off + size, nil
+	case typeDouble:
+		b, err := bytesOf(8)
+		if err != nil || size != 8 {
+			return nil, 0, errors.New("invalid double")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), off + 8, nil
+	case typeFloat:
+		b, err := bytesOf(4)
+		if err != nil || size != 4 {
+			return nil, 0, errors.New("invalid float")
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), off + 4, nil
+	case typeUint16:
+		return uintOf(2)
+	case typeUint32:
+		return uintOf(4)
+	case typeUint64:
+		return uintOf(8)
+	case typeInt32:
+		v, next, err := uintOf(4)
+		if err != nil {
+			return nil, 0, err
+		}
+		return int64(int32(uint32(v.(uint64)))), next, nil // #nosec G115 -- int32 is stored as its 4-byte two's complement
+	case typeBool:
+		return size != 0, off, nil
+	case typeMap, typeArray:
+		if d.depth >= maxDepth {
+			return nil, 0, errors.New("data nested too deeply")
+		}
+		d.depth++
+		defer func() { d.depth-- }()
+		if typ == typeArray {
+			arr := make([]any, 0, min(size, 64))
+			for range size {
+				v, next, err := d.decode(off)
+				if err != nil {
+					return nil, 0, err
+				}
+				arr = append(arr, v)
+				off = next
+			}
+			return arr, off, nil
+		}
+		m := make(map[string]any, min(size, 64))
+		for range size {
+			k, next, err := d.decode(off)
+			if err != nil {
+				return nil, 0, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, 0, errors.New("map key is not a string")
+			}
+			v, next, err := d.decode(next)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+			off = next
+		}
+		return m, off, nil
+	case typeContainer, typeEndMarker:
+		return nil, off, nil
+	}
+	return nil, 0, fmt.Errorf("unknown data type %d", typ)
+}
+
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+func uintField(m map[string]any, key string) uint64 {
+	u, _ := m[key].(uint64)
+	return u
+}
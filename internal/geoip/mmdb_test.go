@@ -0,0 +1,115 @@
+package geoip_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/geoip"
+	"github.com/footprintai/containarium/internal/geoip/geoiptest"
+)
+
+func TestLookup_CountryAndASN(t *testing.T) {
+	built := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	country := geoiptest.Write(t, dir, "country.mmdb", geoiptest.DB{
+		Type:      "GeoLite2-Country",
+		BuildTime: built,
+		Networks: []geoiptest.Network{
+			{Prefix: "203.0.113.0/24", Record: map[string]any{
+				"country": map[string]any{"iso_code": "DE", "names": map[string]any{"en": "Germany"}},
+			}},
+			{Prefix: "198.51.100.0/24", Record: map[string]any{
+				"registered_country": map[string]any{"iso_code": "NL"},
+			}},
+			{Prefix: "2001:db8::/32", Record: map[string]any{
+				"country": map[string]any{"iso_code": "JP"},
+			}},
+		},
+	})
+	asn := geoiptest.Write(t, dir, "asn.mmdb", geoiptest.DB{
+		Type:      "GeoLite2-ASN",
+		BuildTime: built,
+		Networks: []geoiptest.Network{
+			{Prefix: "203.0.113.0/25", Record: map[string]any{
+				"autonomous_system_number":       uint32(3320),
+				"autonomous_system_organization": "Deutsche Telekom AG",
+			}},
+		},
+	})
+
+	cdb, err := geoip.Open(country)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := cdb.Metadata(); m.DatabaseType != "GeoLite2-Country" || !m.BuildTime.Equal(built) || m.IPVersion != 6 {
+		t.Errorf("metadata = %+v", m)
+	}
+	adb, err := geoip.Open(asn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		addr string
+		want geoip.Record
+	}{
+		{"203.0.113.7", geoip.Record{Country: "DE", ASN: 3320, ASOrg: "Deutsche Telekom AG"}},
+		{"203.0.113.200", geoip.Record{Country: "DE"}},
+		{"::ffff:203.0.113.7", geoip.Record{Country: "DE", ASN: 3320, ASOrg: "Deutsche Telekom AG"}},
+		{"198.51.100.1", geoip.Record{Country: "NL"}},
+		{"2001:db8::1", geoip.Record{Country: "JP"}},
+		{"10.100.0.5", geoip.Record{}},
+	} {
+		c, err := cdb.Lookup(netip.MustParseAddr(tc.addr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, err := adb.Lookup(netip.MustParseAddr(tc.addr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Merge(a); got != tc.want {
+			t.Errorf("%s: got %+v, want %+v", tc.addr, got, tc.want)
+		}
+	}
+}
+
+func TestLookup_IPinfoLayout(t *testing.T) {
+	path := geoiptest.Write(t, t.TempDir(), "ipinfo.mmdb", geoiptest.DB{
+		Type: "ipinfo lite.mmdb",
+		Networks: []geoiptest.Network{
+			{Prefix: "192.0.2.0/24", Record: map[string]any{"country_code": "us", "asn": "AS64500", "as_name": "Example Net"}},
+		},
+	})
+	db, err := geoip.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := db.Lookup(netip.MustParseAddr("192.0.2.9"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (geoip.Record{Country: "US", ASN: 64500, ASOrg: "Example Net"}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFromBytes_Invalid(t *testing.T) {
+	valid, err := geoiptest.Build(geoiptest.DB{Type: "GeoLite2-Country", Networks: []geoiptest.Network{
+		{Prefix: "203.0.113.0/24", Record: map[string]any{"country": map[string]any{"iso_code": "DE"}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, buf := range map[string][]byte{
+		"empty":     nil,
+		"not mmdb":  []byte("<html>404 Not Found</html>"),
+		"truncated": valid[len(valid)/2:],
+	} {
+		if _, err := geoip.FromBytes(buf); !errors.Is(err, geoip.ErrInvalidDatabase) {
+			t.Errorf("%s: err = %v, want ErrInvalidDatabase", name, err)
+		}
+	}
+}
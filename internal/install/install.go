@@ -0,0 +1,186 @@
+// Package install brings a fresh machine up as a Containarium host: Incus
+// storage, bridge and default profile, kernel settings, the core
+// PostgreSQL and Caddy containers, the database schema, the Caddy port
+// forwarding rules, SSHPiper and the daemon's systemd unit.
+//
+// Each step is a Task with a Check that reports whether the host is
+// already in the step's end state and an idempotent Apply that gets it
+// there. Run applies only the steps whose check fails, so an install that
+// stopped half-way resumes by running it again; in check mode it applies
+// nothing and reports what is missing. `containarium install` is the CLI.
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+// Options configure the install.
+type Options struct {
+	// NetworkName is the Incus bridge containers attach to.
+	NetworkName string
+	// NetworkCIDR is the bridge's gateway address and prefix, e.g.
+	// 10.100.0.1/24. An existing bridge keeps its own.
+	NetworkCIDR string
+	// BaseDomain is the domain Caddy serves app subdomains under. The
+	// caddy step fails without it; the schema step saves it for the daemon.
+	BaseDomain string
+}
+
+// Env is what tasks share while an install runs.
+type Env struct {
+	Options
+
+	mu    sync.Mutex
+	incus *incus.Client
+}
+
+// NewEnv returns an Env for opts, filling in the default bridge.
+func NewEnv(opts Options) *Env {
+	def := incus.DefaultNetworkConfig()
+	if opts.NetworkName == "" {
+		opts.NetworkName = def.Name
+	}
+	if opts.NetworkCIDR == "" {
+		opts.NetworkCIDR = def.IPv4Address
+	}
+	return &Env{Options: opts}
+}
+
+// Incus returns the Incus client, connecting on first use.
+func (e *Env) Incus() (*incus.Client, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.incus == nil {
+		c, err := incus.New()
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Incus: %w", err)
+		}
+		e.incus = c
+	}
+	return e.incus, nil
+}
+
+// Task is one ordered install step.
+type Task struct {
+	// Name selects the step in --only and --skip, e.g. "bridge".
+	Name string
+	// Description is the progress line, e.g. "Incus bridge incusbr0".
+	Description string
+	// Check reports whether the step's end state holds, with a short
+	// detail either way ("zfs", "no storage pool default"). It must not
+	// change the host. An error means the state could not be read and
+	// counts as not done.
+	Check func(ctx context.Context, env *Env) (done bool, detail string, err error)
+	// Apply brings the host to the step's end state. It runs only when
+	// Check says the step is not done and must be safe to repeat, since a
+	// resumed install re-runs the step that failed.
+	Apply func(ctx context.Context, env *Env) error
+}
+
+// StepError is returned by Run when a step fails.
+type StepError struct {
+	Step  string
+	Index int // 1-based
+	Total int
+	Err   error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("install step %d/%d (%s) failed: %v", e.Index, e.Total, e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error { return e.Err }
+
+// ErrPending is returned by Run in check mode when some step is not done.
+var ErrPending = errors.New("install steps pending")
+
+// Select narrows tasks to the named ones (all when only is empty) minus
+// skip, keeping their order. Unknown names are an error, so a typo does
+// not silently run everything.
+func Select(tasks []Task, only, skip []string) ([]Task, error) {
+	names := make([]string, len(tasks))
+	for i, t := range tasks {
+		names[i] = t.Name
+	}
+	for _, n := range slices.Concat(only, skip) {
+		if !slices.Contains(names, n) {
+			return nil, fmt.Errorf("unknown install step %q (steps: %s)", n, strings.Join(names, ", "))
+		}
+	}
+	var out []Task
+	for _, t := range tasks {
+		if (len(only) == 0 || slices.Contains(only, t.Name)) && !slices.Contains(skip, t.Name) {
+			out = append(out, t)
+		}
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no install steps selected")
+	}
+	return out, nil
+}
+
+// Run works through tasks in order, writing progress to out. It applies
+// each step whose check fails and checks it again afterwards, stopping at
+// the first failure with a *StepError. With checkOnly it applies nothing
+// and returns ErrPending if any step is not done.
+func Run(ctx context.Context, env *Env, tasks []Task, out io.Writer, checkOnly bool) error {
+	pending := 0
+	for i, t := range tasks {
+		if err := ctx.Err(); err != nil {
+			return &StepError{Step: t.Name, Index: i + 1, Total: len(tasks), Err: err}
+		}
+		fmt.Fprintf(out, "[%d/%d] %-9s %s\n", i+1, len(tasks), t.Name, t.Description)
+
+		done, detail := check(ctx, env, t)
+		if done {
+			fmt.Fprintf(out, "       ✓ %s\n", detail)
+			continue
+		}
+		if checkOnly {
+			pending++
+			fmt.Fprintf(out, "       ✗ %s\n", detail)
+			continue
+		}
+
+		fmt.Fprintf(out, "       → %s; applying\n", detail)
+		err := t.Apply(ctx, env)
+		if err == nil {
+			if done, detail = check(ctx, env, t); !done {
+				err = fmt.Errorf("applied, but the check still fails: %s", detail)
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(out, "       ✗ %v\n", err)
+			return &StepError{Step: t.Name, Index: i + 1, Total: len(tasks), Err: err}
+		}
+		fmt.Fprintf(out, "       ✓ %s\n", detail)
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d of %d", ErrPending, pending, len(tasks))
+	}
+	return nil
+}
+
+// check runs t.Check, folding a failed read into the detail.
+func check(ctx context.Context, env *Env, t Task) (bool, string) {
+	done, detail, err := t.Check(ctx, env)
+	if err != nil {
+		return false, "cannot check: " + err.Error()
+	}
+	if detail == "" {
+		if done {
+			detail = "in place"
+		} else {
+			detail = "missing"
+		}
+	}
+	return done, detail
+}
@@ -0,0 +1,219 @@
+package install
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeStep is a task whose state is a bool the test controls.
+type fakeStep struct {
+	name     string
+	done     bool
+	applyErr error
+	sticky   bool // Apply succeeds but leaves the step not done
+	applied  int
+}
+
+func (f *fakeStep) task() Task {
+	return Task{
+		Name:        f.name,
+		Description: "fake " + f.name,
+		Check: func(context.Context, *Env) (bool, string, error) {
+			return f.done, "", nil
+		},
+		Apply: func(context.Context, *Env) error {
+			f.applied++
+			if f.applyErr != nil {
+				return f.applyErr
+			}
+			f.done = !f.sticky
+			return nil
+		},
+	}
+}
+
+func fakeTasks(steps ...*fakeStep) []Task {
+	tasks := make([]Task, len(steps))
+	for i, s := range steps {
+		tasks[i] = s.task()
+	}
+	return tasks
+}
+
+func taskNames(tasks []Task) string {
+	names := make([]string, len(tasks))
+	for i, t := range tasks {
+		names[i] = t.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSelect(t *testing.T) {
+	tasks := fakeTasks(&fakeStep{name: "a"}, &fakeStep{name: "b"}, &fakeStep{name: "c"})
+	tests := []struct {
+		only, skip []string
+		want       string
+		wantErr    string
+	}{
+		{want: "a,b,c"},
+		{only: []string{"c", "a"}, want: "a,c"},
+		{skip: []string{"b"}, want: "a,c"},
+		{only: []string{"a", "b"}, skip: []string{"a"}, want: "b"},
+		{only: []string{"d"}, wantErr: `unknown install step "d" (steps: a, b, c)`},
+		{skip: []string{"a", "b", "c"}, wantErr: "no install steps selected"},
+	}
+	for _, tt := range tests {
+		got, err := Select(tasks, tt.only, tt.skip)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Select(%v, %v) error = %v, want %q", tt.only, tt.skip, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || taskNames(got) != tt.want {
+			t.Errorf("Select(%v, %v) = %s, %v; want %s", tt.only, tt.skip, taskNames(got), err, tt.want)
+		}
+	}
+}
+
+func TestRun_AppliesOnlyPendingSteps(t *testing.T) {
+	a, b, c := &fakeStep{name: "a", done: true}, &fakeStep{name: "b"}, &fakeStep{name: "c"}
+	var out bytes.Buffer
+	if err := Run(context.Background(), NewEnv(Options{}), fakeTasks(a, b, c), &out, false); err != nil {
+		t.Fatalf("Run: %v\n%s", err, out.String())
+	}
+	if a.applied != 0 || b.applied != 1 || c.applied != 1 {
+		t.Errorf("applied a=%d b=%d c=%d, want 0, 1, 1", a.applied, b.applied, c.applied)
+	}
+	for _, want := range []string{"[1/3] a", "✓ in place", "→ missing; applying", "[3/3] c"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+
+	// A second run is a no-op.
+	if err := Run(context.Background(), NewEnv(Options{}), fakeTasks(a, b, c), &out, false); err != nil {
+		t.Fatal(err)
+	}
+	if b.applied != 1 || c.applied != 1 {
+		t.Errorf("second run re-applied finished steps: b=%d c=%d", b.applied, c.applied)
+	}
+}
+
+func TestRun_CheckOnlyChangesNothing(t *testing.T) {
+	a, b, c := &fakeStep{name: "a", done: true}, &fakeStep{name: "b"}, &fakeStep{name: "c"}
+	var out bytes.Buffer
+	err := Run(context.Background(), NewEnv(Options{}), fakeTasks(a, b, c), &out, true)
+	if !errors.Is(err, ErrPending) || !strings.Contains(err.Error(), "2 of 3") {
+		t.Fatalf("Run error = %v, want ErrPending for 2 of 3", err)
+	}
+	if b.applied+c.applied != 0 {
+		t.Error("check mode applied a step")
+	}
+	if strings.Count(out.String(), "✗ missing") != 2 {
+		t.Errorf("check output:\n%s", out.String())
+	}
+
+	if err := Run(context.Background(), NewEnv(Options{}), fakeTasks(a), &out, true); err != nil {
+		t.Errorf("check of a finished step = %v, want nil", err)
+	}
+}
+
+func TestRun_StopsAtFailedStep(t *testing.T) {
+	boom := errors.New("boom")
+	a, b, c := &fakeStep{name: "a"}, &fakeStep{name: "b", applyErr: boom}, &fakeStep{name: "c"}
+	var out bytes.Buffer
+	err := Run(context.Background(), NewEnv(Options{}), fakeTasks(a, b, c), &out, false)
+
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Step != "b" || stepErr.Index != 2 || stepErr.Total != 3 || !errors.Is(err, boom) {
+		t.Fatalf("Run error = %#v, want StepError for b (2/3) wrapping boom", err)
+	}
+	if c.applied != 0 {
+		t.Error("ran a step after the failure")
+	}
+	if !strings.Contains(out.String(), "✗ boom") {
+		t.Errorf("output lacks the failure:\n%s", out.String())
+	}
+
+	// Resuming skips the finished step and retries the failed one.
+	b.applyErr = nil
+	if err := Run(context.Background(), NewEnv(Options{}), fakeTasks(a, b, c), &out, false); err != nil {
+		t.Fatal(err)
+	}
+	if a.applied != 1 || b.applied != 2 || c.applied != 1 {
+		t.Errorf("applied a=%d b=%d c=%d after resume, want 1, 2, 1", a.applied, b.applied, c.applied)
+	}
+}
+
+func TestRun_FailsWhenApplyDoesNotTakeEffect(t *testing.T) {
+	a := &fakeStep{name: "a", sticky: true}
+	var out bytes.Buffer
+	err := Run(context.Background(), NewEnv(Options{}), fakeTasks(a), &out, false)
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || !strings.Contains(err.Error(), "check still fails") {
+		t.Fatalf("Run error = %v, want a failed re-check", err)
+	}
+}
+
+func TestHostTasks_UniqueNames(t *testing.T) {
+	seen := map[string]bool{}
+	for _, task := range HostTasks() {
+		if seen[task.Name] || task.Check == nil || task.Apply == nil {
+			t.Errorf("task %q is duplicated or incomplete", task.Name)
+		}
+		seen[task.Name] = true
+	}
+}
+
+func TestExtractSSHPiper(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range map[string]string{
+		"sshpiperd":         "daemon",
+		"plugins/yaml":      "yaml plugin",
+		"plugins/failtoban": "failtoban plugin",
+		"README.md":         "readme",
+		"../escape":         "nope",
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+
+	dir := t.TempDir()
+	if err := extractSSHPiper(&buf, dir); err != nil {
+		t.Fatalf("extractSSHPiper: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+		info, _ := e.Info()
+		if info.Mode().Perm() != 0o755 {
+			t.Errorf("%s mode = %v, want 0755", e.Name(), info.Mode().Perm())
+		}
+	}
+	if strings.Join(got, ",") != "failtoban,sshpiperd,yaml" {
+		t.Errorf("extracted %v, want failtoban, sshpiperd, yaml", got)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "escape")); err == nil {
+		t.Error("archive entry escaped the target directory")
+	}
+}
@@ -0,0 +1,175 @@
+package install
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// SSHPiper is installed the way the GCE sentinel startup script installs
+// it: the pinned release's sshpiperd and plugins in /usr/local/bin, an
+// ed25519 host key and upstream key, and an enabled unit on port 22. The
+// unit is not started: sshd must move off port 22 first, and the yaml
+// plugin's config.yaml is written by the sentinel key sync.
+const (
+	sshpiperVersion  = "v1.5.3"
+	sshpiperBinDir   = "/usr/local/bin"
+	sshpiperConfDir  = "/etc/sshpiper"
+	sshpiperUnitPath = "/etc/systemd/system/sshpiper.service"
+)
+
+const sshpiperUnit = `[Unit]
+Description=SSHPiper reverse proxy
+After=network.target
+
+[Service]
+ExecStart=/usr/local/bin/sshpiperd \
+  -i /etc/sshpiper/host_key \
+  -p 22 \
+  --drop-hostkeys-message \
+  yaml \
+  --config /etc/sshpiper/config.yaml \
+  -- \
+  failtoban \
+  --max-failures 20 \
+  --ban-duration 1h
+Restart=always
+RestartSec=5
+
+[Install]
+WantedBy=multi-user.target
+`
+
+var sshpiperKeys = []string{"host_key", "upstream_key"}
+
+func checkSSHPiper(context.Context, *Env) (bool, string, error) {
+	var missing []string
+	paths := []string{filepath.Join(sshpiperBinDir, "sshpiperd"), sshpiperUnitPath}
+	for _, k := range sshpiperKeys {
+		paths = append(paths, filepath.Join(sshpiperConfDir, k))
+	}
+	for _, p := range paths {
+		if _, err := os.Stat(p); err != nil {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		return false, "missing " + strings.Join(missing, ", "), nil
+	}
+	return true, "installed (start it once sshd is off port 22)", nil
+}
+
+func applySSHPiper(ctx context.Context, _ *Env) error {
+	if _, err := os.Stat(filepath.Join(sshpiperBinDir, "sshpiperd")); err != nil {
+		if err := downloadSSHPiper(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(sshpiperConfDir, "users"), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", sshpiperConfDir, err)
+	}
+	for _, k := range sshpiperKeys {
+		p := filepath.Join(sshpiperConfDir, k)
+		if _, err := os.Stat(p); err == nil {
+			continue
+		}
+		if out, err := exec.CommandContext(ctx, "ssh-keygen", "-q", "-t", "ed25519", "-f", p, "-N", "").CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to generate %s: %w, output: %s", p, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	// #nosec G306 -- systemd unit files are world-readable
+	if err := os.WriteFile(sshpiperUnitPath, []byte(sshpiperUnit), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", sshpiperUnitPath, err)
+	}
+	for _, args := range [][]string{{"daemon-reload"}, {"enable", "sshpiper"}} {
+		if out, err := exec.CommandContext(ctx, "systemctl", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("systemctl %s failed: %w, output: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// downloadSSHPiper fetches the release tarball and installs sshpiperd and
+// its plugin binaries into sshpiperBinDir.
+func downloadSSHPiper(ctx context.Context) error {
+	if runtime.GOARCH != "amd64" {
+		return fmt.Errorf("no SSHPiper %s release for %s; install sshpiperd into %s by hand", sshpiperVersion, runtime.GOARCH, sshpiperBinDir)
+	}
+	url := fmt.Sprintf("https://github.com/tg123/sshpiper/releases/download/%s/sshpiperd_with_plugins_linux_x86_64.tar.gz", sshpiperVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download SSHPiper: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download SSHPiper: %s", resp.Status)
+	}
+	return extractSSHPiper(resp.Body, sshpiperBinDir)
+}
+
+// extractSSHPiper writes sshpiperd and plugins/* from the release tarball
+// into dir, by base name only. Everything else in the archive is ignored.
+func extractSSHPiper(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to read SSHPiper archive: %w", err)
+	}
+	defer gz.Close()
+
+	found := false
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read SSHPiper archive: %w", err)
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if hdr.Typeflag != tar.TypeReg || (name != "sshpiperd" && path.Dir(name) != "plugins") {
+			continue
+		}
+		dst := filepath.Join(dir, path.Base(name))
+		// Write beside the target and rename, so a running sshpiperd is
+		// replaced rather than truncated.
+		tmp := dst + ".tmp"
+		// #nosec G302 -- installed binaries are world-executable
+		f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", tmp, err)
+		}
+		// #nosec G110 -- the archive is the pinned upstream release
+		_, err = io.Copy(f, tr)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err == nil {
+			err = os.Rename(tmp, dst)
+		}
+		if err != nil {
+			_ = os.Remove(tmp)
+			return fmt.Errorf("failed to install %s: %w", dst, err)
+		}
+		found = found || name == "sshpiperd"
+	}
+	if !found {
+		return fmt.Errorf("SSHPiper archive has no sshpiperd binary")
+	}
+	return nil
+}
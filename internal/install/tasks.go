@@ -0,0 +1,395 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/footprintai/containarium/internal/app"
+	"github.com/footprintai/containarium/internal/server"
+	"github.com/footprintai/containarium/internal/traffic"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/network"
+)
+
+// storagePool is the Incus pool containers and the default profile use.
+const storagePool = "default"
+
+// HostTasks returns the host install steps in order. The CLI appends the
+// daemon's systemd unit, which it owns.
+func HostTasks() []Task {
+	return []Task{
+		{Name: "preflight", Description: "root and a reachable Incus daemon", Check: checkPreflight, Apply: applyPreflight},
+		{Name: "storage", Description: fmt.Sprintf("Incus storage pool %q", storagePool), Check: checkStorage, Apply: applyStorage},
+		{Name: "bridge", Description: "Incus bridge for containers", Check: checkBridge, Apply: applyBridge},
+		{Name: "profile", Description: "default profile root disk and NIC", Check: checkProfile, Apply: applyProfile},
+		{Name: "sysctls", Description: "IP forwarding and conntrack accounting", Check: checkSysctls, Apply: applySysctls},
+		{Name: "postgres", Description: "core PostgreSQL container", Check: checkPostgres, Apply: applyPostgres},
+		{Name: "schema", Description: "database schema and saved daemon config", Check: checkSchema, Apply: applySchema},
+		{Name: "caddy", Description: "core Caddy container", Check: checkCaddy, Apply: applyCaddy},
+		{Name: "firewall", Description: "ports 80/443 forwarded to Caddy", Check: checkFirewall, Apply: applyFirewall},
+		{Name: "sshpiper", Description: "SSHPiper binary, keys and unit", Check: checkSSHPiper, Apply: applySSHPiper},
+	}
+}
+
+func checkPreflight(_ context.Context, env *Env) (bool, string, error) {
+	if euid := os.Geteuid(); euid != 0 {
+		return false, fmt.Sprintf("running as euid %d, not root", euid), nil
+	}
+	c, err := env.Incus()
+	if err != nil {
+		return false, "", err
+	}
+	info, err := c.GetServerInfo()
+	if err != nil {
+		return false, "", fmt.Errorf("failed to query Incus: %w", err)
+	}
+	return true, "root, Incus " + info.Environment.ServerVersion, nil
+}
+
+// applyPreflight cannot fix anything: installing Incus and becoming root
+// are up to the operator.
+func applyPreflight(context.Context, *Env) error {
+	return fmt.Errorf("run as root on a host with Incus installed and running (https://linuxcontainers.org/incus/docs/main/installing/)")
+}
+
+func checkStorage(_ context.Context, env *Env) (bool, string, error) {
+	c, err := env.Incus()
+	if err != nil {
+		return false, "", err
+	}
+	if driver := c.GetStorageDriver(storagePool); driver != "unknown" {
+		return true, driver, nil
+	}
+	return false, fmt.Sprintf("no storage pool %q", storagePool), nil
+}
+
+func applyStorage(_ context.Context, env *Env) error {
+	c, err := env.Incus()
+	if err != nil {
+		return err
+	}
+	_, err = c.EnsureStorage(storagePool)
+	return err
+}
+
+func checkBridge(_ context.Context, env *Env) (bool, string, error) {
+	c, err := env.Incus()
+	if err != nil {
+		return false, "", err
+	}
+	subnet, err := c.GetNetworkSubnet(env.NetworkName)
+	if err != nil {
+		return false, fmt.Sprintf("no bridge %s", env.NetworkName), nil
+	}
+	detail := env.NetworkName + " " + subnet
+	if subnet != env.NetworkCIDR {
+		// EnsureNetwork never re-addresses an existing bridge, and the
+		// daemon follows the bridge, so this is only worth pointing out.
+		detail += fmt.Sprintf(" (kept; --network-cidr %s not applied)", env.NetworkCIDR)
+	}
+	return true, detail, nil
+}
+
+func applyBridge(_ context.Context, env *Env) error {
+	c, err := env.Incus()
+	if err != nil {
+		return err
+	}
+	_, err = c.EnsureNetwork(incus.NetworkConfig{Name: env.NetworkName, IPv4Address: env.NetworkCIDR, IPv4NAT: true})
+	return err
+}
+
+func checkProfile(_ context.Context, env *Env) (bool, string, error) {
+	c, err := env.Incus()
+	if err != nil {
+		return false, "", err
+	}
+	ok, err := c.DefaultProfileReady()
+	if err != nil || !ok {
+		return false, "default profile lacks a root disk or eth0", err
+	}
+	return true, "", nil
+}
+
+func applyProfile(_ context.Context, env *Env) error {
+	c, err := env.Incus()
+	if err != nil {
+		return err
+	}
+	return c.EnsureDefaultProfile(env.NetworkName, storagePool)
+}
+
+// sysctlDropIn persists the kernel settings below across reboots.
+// route_localnet is left to the firewall step's port forwarder, which
+// keeps its own drop-in.
+const sysctlDropIn = "/etc/sysctl.d/99-containarium.conf"
+
+var sysctls = []struct{ key, value string }{
+	{"net.ipv4.ip_forward", "1"},
+	// Byte counters on conntrack entries, which traffic monitoring reads.
+	{"net.netfilter.nf_conntrack_acct", "1"},
+}
+
+func sysctlDropInBody() string {
+	var b strings.Builder
+	b.WriteString("# containarium: container routing and traffic accounting\n")
+	for _, s := range sysctls {
+		fmt.Fprintf(&b, "%s = %s\n", s.key, s.value)
+	}
+	return b.String()
+}
+
+func checkSysctls(context.Context, *Env) (bool, string, error) {
+	var wrong []string
+	for _, s := range sysctls {
+		raw, err := os.ReadFile(filepath.Join("/proc/sys", strings.ReplaceAll(s.key, ".", "/")))
+		if err != nil || strings.TrimSpace(string(raw)) != s.value {
+			wrong = append(wrong, s.key)
+		}
+	}
+	if raw, err := os.ReadFile(sysctlDropIn); err != nil || string(raw) != sysctlDropInBody() {
+		wrong = append(wrong, sysctlDropIn)
+	}
+	if len(wrong) > 0 {
+		return false, "not set: " + strings.Join(wrong, ", "), nil
+	}
+	return true, "", nil
+}
+
+func applySysctls(context.Context, *Env) error {
+	// #nosec G306 -- sysctl drop-ins must be world-readable to be loaded
+	if err := os.WriteFile(sysctlDropIn, []byte(sysctlDropInBody()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", sysctlDropIn, err)
+	}
+	if out, err := exec.Command("sysctl", "-w", "net.ipv4.ip_forward=1").CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to enable IP forwarding: %w, output: %s", err, strings.TrimSpace(string(out)))
+	}
+	// The conntrack sysctls only exist once the module is loaded.
+	_ = exec.Command("modprobe", "nf_conntrack").Run()
+	return network.EnableConntrackAccounting()
+}
+
+// coreContainer returns the running core container with role, or a
+// detail saying why there is none.
+func coreContainer(env *Env, role incus.Role) (*incus.ContainerInfo, string, error) {
+	c, err := env.Incus()
+	if err != nil {
+		return nil, "", err
+	}
+	info, err := c.FindContainerByRole(role)
+	if err != nil {
+		return nil, fmt.Sprintf("no %s container", role), nil
+	}
+	if info.State != "Running" || info.IPAddress == "" {
+		return nil, fmt.Sprintf("%s is %s", info.Name, strings.ToLower(info.State)), nil
+	}
+	return info, "", nil
+}
+
+// coreServices returns the manager that creates the core containers,
+// configured the way the daemon configures it.
+func coreServices(env *Env) (*server.CoreServices, error) {
+	c, err := env.Incus()
+	if err != nil {
+		return nil, err
+	}
+	password, _, err := server.ResolvePostgresPassword()
+	if err != nil {
+		return nil, err
+	}
+	subnet, err := c.GetNetworkSubnet(env.NetworkName)
+	if err != nil {
+		return nil, err
+	}
+	return server.NewCoreServices(c, server.CoreServicesConfig{
+		PostgresPassword: password,
+		NetworkCIDR:      subnet,
+	}), nil
+}
+
+func checkPostgres(_ context.Context, env *Env) (bool, string, error) {
+	if dsn, source, err := server.ResolvePostgresURL(); err != nil {
+		return false, "", err
+	} else if dsn != "" {
+		return true, "external database (" + source + ")", nil
+	}
+	info, detail, err := coreContainer(env, incus.RolePostgres)
+	if info == nil {
+		return false, detail, err
+	}
+	return true, info.Name + " at " + info.IPAddress, nil
+}
+
+func applyPostgres(ctx context.Context, env *Env) error {
+	cs, err := coreServices(env)
+	if err != nil {
+		return err
+	}
+	_, err = cs.EnsurePostgres(ctx)
+	return err
+}
+
+// postgresDSN resolves the database the daemon will use: a configured
+// DSN, else the core container with the resolved password.
+func postgresDSN(env *Env) (string, error) {
+	dsn, _, err := server.ResolvePostgresURL()
+	if err != nil || dsn != "" {
+		return dsn, err
+	}
+	info, detail, err := coreContainer(env, incus.RolePostgres)
+	if err != nil {
+		return "", err
+	}
+	if info == nil {
+		return "", fmt.Errorf("%s (run the postgres step first)", detail)
+	}
+	password, _, err := server.ResolvePostgresPassword()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		server.DefaultPostgresUser, password, info.IPAddress, server.DefaultPostgresPort, server.DefaultPostgresDB), nil
+}
+
+func checkSchema(ctx context.Context, env *Env) (bool, string, error) {
+	dsn, err := postgresDSN(env)
+	if err != nil {
+		return false, "", err
+	}
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	var version int
+	if err := conn.QueryRow(ctx, `
+		SELECT CASE WHEN to_regclass('schema_migrations') IS NULL THEN 0
+		            ELSE (SELECT COALESCE(MAX(version), 0) FROM schema_migrations) END
+	`).Scan(&version); err != nil {
+		return false, "", fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if latest := traffic.LatestSchemaVersion(); version < latest {
+		return false, fmt.Sprintf("schema at version %d of %d", version, latest), nil
+	}
+	if env.BaseDomain == "" {
+		return true, fmt.Sprintf("version %d", version), nil
+	}
+
+	var saved string
+	if err := conn.QueryRow(ctx, `
+		SELECT CASE WHEN to_regclass('daemon_config') IS NULL THEN ''
+		            ELSE COALESCE((SELECT value FROM daemon_config WHERE key = 'base_domain'), '') END
+	`).Scan(&saved); err != nil {
+		return false, "", fmt.Errorf("failed to read saved base domain: %w", err)
+	}
+	if saved != env.BaseDomain {
+		return false, fmt.Sprintf("saved base domain is %q", saved), nil
+	}
+	return true, fmt.Sprintf("version %d, base domain %s", version, saved), nil
+}
+
+// applySchema creates the traffic and app-hosting schemas the way the
+// daemon does on start, by opening their stores, and saves --base-domain
+// where the daemon loads its config from, so the unit needs no flags. The
+// daemon migrates its other stores itself.
+func applySchema(ctx context.Context, env *Env) error {
+	dsn, err := postgresDSN(env)
+	if err != nil {
+		return err
+	}
+	ts, err := traffic.NewStore(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to migrate traffic schema: %w", err)
+	}
+	ts.Close()
+	as, err := app.NewStore(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to migrate app schema: %w", err)
+	}
+	as.Close()
+	if env.BaseDomain == "" {
+		return nil
+	}
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+	cs, err := app.NewDaemonConfigStore(ctx, pool)
+	if err != nil {
+		return err
+	}
+	if err := cs.Set(ctx, "base_domain", env.BaseDomain); err != nil {
+		return fmt.Errorf("failed to save base domain: %w", err)
+	}
+	return nil
+}
+
+func checkCaddy(_ context.Context, env *Env) (bool, string, error) {
+	info, detail, err := coreContainer(env, incus.RoleCaddy)
+	if info == nil {
+		return false, detail, err
+	}
+	return true, info.Name + " at " + info.IPAddress, nil
+}
+
+func applyCaddy(ctx context.Context, env *Env) error {
+	if env.BaseDomain == "" {
+		return fmt.Errorf("--base-domain is required to set up Caddy")
+	}
+	cs, err := coreServices(env)
+	if err != nil {
+		return err
+	}
+	_, err = cs.EnsureCaddy(ctx, env.BaseDomain)
+	return err
+}
+
+// portForwarder returns the forwarder the daemon would set up for the
+// running Caddy container.
+func portForwarder(env *Env) (*network.PortForwarder, string, error) {
+	if !network.CheckIPTablesAvailable() {
+		return nil, "", fmt.Errorf("iptables is not available")
+	}
+	info, detail, err := coreContainer(env, incus.RoleCaddy)
+	if info == nil {
+		if err == nil {
+			err = fmt.Errorf("%s (run the caddy step first)", detail)
+		}
+		return nil, "", err
+	}
+	c, err := env.Incus()
+	if err != nil {
+		return nil, "", err
+	}
+	subnet, _ := c.GetNetworkSubnet(env.NetworkName)
+	return network.NewPortForwarderWithBackends([]string{info.IPAddress}, subnet, network.BalanceRoundRobin), info.IPAddress, nil
+}
+
+func checkFirewall(_ context.Context, env *Env) (bool, string, error) {
+	pf, caddyIP, err := portForwarder(env)
+	if err != nil {
+		return false, "", err
+	}
+	if !pf.Installed() {
+		return false, "no forwarding rules for " + caddyIP, nil
+	}
+	return true, "80,443 -> " + caddyIP, nil
+}
+
+func applyFirewall(_ context.Context, env *Env) error {
+	pf, _, err := portForwarder(env)
+	if err != nil {
+		return err
+	}
+	return pf.SetupPortForwarding()
+}
@@ -0,0 +1,11 @@
+// Package jobs runs the daemon's named maintenance jobs (traffic cleanup,
+// usage rollup, the conntrack snapshot, orphan garbage collection) on
+// cron-like schedules, and records every run — when it started and ended,
+// how it turned out and how many rows it touched — so operators can see
+// what the daemon did overnight instead of grepping its log.
+//
+// A job never runs twice at once: a run started by hand while the
+// scheduled one is in progress is refused with ErrRunning, and a
+// scheduled run that comes due while a manual one is in progress is
+// recorded as skipped.
+package jobs
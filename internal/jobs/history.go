@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultHistoryLimit is how many runs History returns when asked for
+// none in particular.
+const DefaultHistoryLimit = 20
+
+// HistoryStore records job runs.
+type HistoryStore interface {
+	// Add records a run and returns its ID.
+	Add(ctx context.Context, run Run) (int64, error)
+	// Finish records how a run previously added ended.
+	Finish(ctx context.Context, run Run) error
+	// List returns the job's most recent runs, newest first, at most
+	// limit (DefaultHistoryLimit when limit <= 0).
+	List(ctx context.Context, job string, limit int) ([]Run, error)
+}
+
+// MemoryHistory keeps the most recent runs of each job in memory, for
+// daemons without PostgreSQL. History is lost on restart.
+type MemoryHistory struct {
+	perJob int
+
+	mu     sync.Mutex
+	nextID int64
+	runs   map[string][]Run // oldest first
+}
+
+// NewMemoryHistory keeps up to perJob runs of each job.
+func NewMemoryHistory(perJob int) *MemoryHistory {
+	return &MemoryHistory{perJob: max(perJob, 1), runs: make(map[string][]Run)}
+}
+
+// Add records run, dropping the job's oldest run when it has perJob.
+func (h *MemoryHistory) Add(_ context.Context, run Run) (int64, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nextID++
+	run.ID = h.nextID
+	runs := append(h.runs[run.Job], run)
+	if len(runs) > h.perJob {
+		runs = slices.Delete(runs, 0, len(runs)-h.perJob)
+	}
+	h.runs[run.Job] = runs
+	return run.ID, nil
+}
+
+// Finish replaces the recorded run with run's ID. A run already dropped
+// is ignored.
+func (h *MemoryHistory) Finish(_ context.Context, run Run) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	runs := h.runs[run.Job]
+	if i := slices.IndexFunc(runs, func(r Run) bool { return r.ID == run.ID }); i >= 0 {
+		runs[i] = run
+	}
+	return nil
+}
+
+// List returns the job's most recent runs, newest first.
+func (h *MemoryHistory) List(_ context.Context, job string, limit int) ([]Run, error) {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	runs := h.runs[job]
+	out := make([]Run, 0, min(limit, len(runs)))
+	for i := len(runs) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, runs[i])
+	}
+	return out, nil
+}
+
+// historyRetention is how long PostgresHistory keeps runs.
+const historyRetention = 90 * 24 * time.Hour
+
+// PostgresHistory records runs in the jobs_history table.
+type PostgresHistory struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresHistory creates the jobs_history table if needed, and marks
+// runs left "running" by a daemon that stopped mid-run as failed.
+func NewPostgresHistory(ctx context.Context, pool *pgxpool.Pool) (*PostgresHistory, error) {
+	schema := `
+		CREATE TABLE IF NOT EXISTS jobs_history (
+			id BIGSERIAL PRIMARY KEY,
+			job_name TEXT NOT NULL,
+			trigger TEXT NOT NULL,
+			started_at TIMESTAMPTZ NOT NULL,
+			ended_at TIMESTAMPTZ,
+			result TEXT NOT NULL,
+			rows_affected BIGINT NOT NULL DEFAULT 0,
+			error TEXT NOT NULL DEFAULT ''
+		);
+		CREATE INDEX IF NOT EXISTS idx_jobs_history_job ON jobs_history (job_name, started_at DESC);
+	`
+	if _, err := pool.Exec(ctx, schema); err != nil {
+		return nil, fmt.Errorf("init jobs_history schema: %w", err)
+	}
+	if _, err := pool.Exec(ctx, `
+		UPDATE jobs_history SET result = $1, ended_at = NOW(), error = 'interrupted: the daemon stopped during the run'
+		WHERE result = $2
+	`, ResultFailed, ResultRunning); err != nil {
+		return nil, fmt.Errorf("close interrupted job runs: %w", err)
+	}
+	return &PostgresHistory{pool: pool}, nil
+}
+
+// Add inserts run, and drops the job's runs older than historyRetention.
+func (h *PostgresHistory) Add(ctx context.Context, run Run) (int64, error) {
+	var id int64
+	err := h.pool.QueryRow(ctx, `
+		INSERT INTO jobs_history (job_name, trigger, started_at, ended_at, result, rows_affected, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, run.Job, run.Trigger, run.StartedAt, nullTime(run.EndedAt), run.Result, run.RowsAffected, run.Error).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("insert job run: %w", err)
+	}
+	if _, err := h.pool.Exec(ctx, "DELETE FROM jobs_history WHERE job_name = $1 AND started_at < $2",
+		run.Job, run.StartedAt.Add(-historyRetention)); err != nil {
+		return id, fmt.Errorf("prune job history: %w", err)
+	}
+	return id, nil
+}
+
+// Finish updates the run's end, result, rows affected and error.
+func (h *PostgresHistory) Finish(ctx context.Context, run Run) error {
+	_, err := h.pool.Exec(ctx, `
+		UPDATE jobs_history SET ended_at = $2, result = $3, rows_affected = $4, error = $5
+		WHERE id = $1
+	`, run.ID, nullTime(run.EndedAt), run.Result, run.RowsAffected, run.Error)
+	if err != nil {
+		return fmt.Errorf("update job run %d: %w", run.ID, err)
+	}
+	return nil
+}
+
+// List returns the job's most recent runs, newest first.
+func (h *PostgresHistory) List(ctx context.Context, job string, limit int) ([]Run, error) {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	rows, err := h.pool.Query(ctx, `
+		SELECT id, job_name, trigger, started_at, ended_at, result, rows_affected, error
+		FROM jobs_history WHERE job_name = $1
+		ORDER BY started_at DESC, id DESC LIMIT $2
+	`, job, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query job history: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Run
+	for rows.Next() {
+		var run Run
+		var ended *time.Time
+		if err := rows.Scan(&run.ID, &run.Job, &run.Trigger, &run.StartedAt, &ended, &run.Result, &run.RowsAffected, &run.Error); err != nil {
+			return nil, fmt.Errorf("scan job run: %w", err)
+		}
+		if ended != nil {
+			run.EndedAt = *ended
+		}
+		out = append(out, run)
+	}
+	return out, rows.Err()
+}
+
+// nullTime maps the zero time to NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,183 @@
+package jobs
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule says when a job is next due.
+type Schedule interface {
+	// Next returns the first time after t the job is due; the zero time
+	// when it never is.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a job schedule:
+//
+//   - a five-field cron expression, "minute hour day-of-month month
+//     day-of-week" in the daemon's local time, each field *, a number,
+//     a range a-b, a step */n or a-b/n, or a comma-separated list of
+//     those (day-of-week 0 and 7 are both Sunday);
+//   - @hourly, @daily (or @midnight) or @weekly;
+//   - @every <duration>, e.g. "@every 5m", each period jittered by
+//     ±everyJitter so daemons restarted together drift apart.
+//
+// An empty spec or "off" means the job only runs when triggered by hand;
+// ParseSchedule returns nil for it.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch spec {
+	case "", "off":
+		return nil, nil
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	}
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("invalid schedule %q: interval must be at least 1s", spec)
+		}
+		return every(d), nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		return nil, fmt.Errorf("invalid schedule %q: unknown shorthand (use @hourly, @daily, @weekly or @every <duration>)", spec)
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: want 5 cron fields (minute hour day-of-month month day-of-week), got %d", spec, len(fields))
+	}
+	var c cron
+	for i, f := range []struct {
+		name     string
+		min, max int
+		set      *uint64
+	}{
+		{"minute", 0, 59, &c.minute},
+		{"hour", 0, 23, &c.hour},
+		{"day-of-month", 1, 31, &c.dom},
+		{"month", 1, 12, &c.month},
+		{"day-of-week", 0, 7, &c.dow},
+	} {
+		set, err := parseField(fields[i], f.min, f.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %s: %w", spec, f.name, err)
+		}
+		*f.set = set
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar, c.dowStar = fields[2] == "*", fields[4] == "*"
+	if c.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("invalid schedule %q: never due", spec)
+	}
+	return c, nil
+}
+
+// parseField parses one cron field into a bit set of the values it
+// allows.
+func parseField(field string, minVal, maxVal int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("bad step %q", stepStr)
+			}
+			step = n
+		}
+		lo, hi := minVal, maxVal
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return 0, fmt.Errorf("bad value %q", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return 0, fmt.Errorf("bad value %q", part)
+				}
+			} else if hasStep {
+				hi = maxVal
+			}
+			if lo < minVal || hi > maxVal || lo > hi {
+				return 0, fmt.Errorf("%q out of range %d-%d", part, minVal, maxVal)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// cron is a parsed five-field expression; each field is a bit set of the
+// values it allows.
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record an unrestricted day field: as in cron(8),
+	// when both day fields are restricted a day matching either is due.
+	domStar, dowStar bool
+}
+
+// cronHorizon bounds Next's search; any satisfiable expression is due
+// within it (Feb 29 recurs every 4 years, 8 across a skipped leap year).
+const cronHorizon = 9
+
+// Next returns the first whole minute after t that the expression allows.
+func (c cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronHorizon, 0, 0)
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		switch {
+		case c.month&(1<<uint(m)) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// everyJitter is how far each period of an @every schedule may stray from
+// its interval, as a fraction of it; the mean stays the interval.
+const everyJitter = 0.1
+
+// every is an @every schedule.
+type every time.Duration
+
+// Next returns t plus the interval, jittered.
+func (e every) Next(t time.Time) time.Time {
+	// #nosec G404 -- jitter for load spreading; not security-sensitive.
+	return t.Add(time.Duration(float64(e) * (1 - everyJitter + 2*everyJitter*rand.Float64())))
+}
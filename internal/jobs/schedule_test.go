@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Cron(t *testing.T) {
+	loc := time.UTC
+	from := time.Date(2026, 3, 10, 14, 7, 30, 0, loc) // a Tuesday
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 10, 14, 8, 0, 0, loc)},
+		{"0 3 * * *", time.Date(2026, 3, 11, 3, 0, 0, 0, loc)},
+		{"*/15 * * * *", time.Date(2026, 3, 10, 14, 15, 0, 0, loc)},
+		{"0,30 9-17 * * *", time.Date(2026, 3, 10, 14, 30, 0, 0, loc)},
+		{"@hourly", time.Date(2026, 3, 10, 15, 0, 0, 0, loc)},
+		{"@daily", time.Date(2026, 3, 11, 0, 0, 0, 0, loc)},
+		{"@weekly", time.Date(2026, 3, 15, 0, 0, 0, 0, loc)},
+		{"0 0 * * 7", time.Date(2026, 3, 15, 0, 0, 0, 0, loc)},
+		{"0 0 1 */3 *", time.Date(2026, 4, 1, 0, 0, 0, 0, loc)},
+		// Both day fields restricted: either matches (the 1st, or Friday the 13th).
+		{"0 0 1 * 5", time.Date(2026, 3, 13, 0, 0, 0, 0, loc)},
+		// Only day-of-week restricted: Fridays only.
+		{"0 0 * * 5", time.Date(2026, 3, 13, 0, 0, 0, 0, loc)},
+		{"0 12 29 2 *", time.Date(2028, 2, 29, 12, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		sched, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q): %v", tt.spec, err)
+			continue
+		}
+		if got := sched.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %s, want %s", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseSchedule_Every(t *testing.T) {
+	sched, err := ParseSchedule("@every 10m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	for range 100 {
+		if d := sched.Next(from).Sub(from); d < 9*time.Minute || d > 11*time.Minute {
+			t.Fatalf("Next is %s after, want 10m ±10%%", d)
+		}
+	}
+}
+
+func TestParseSchedule_Off(t *testing.T) {
+	for _, spec := range []string{"", "off", "  off "} {
+		if sched, err := ParseSchedule(spec); sched != nil || err != nil {
+			t.Errorf("ParseSchedule(%q) = %v, %v; want nil, nil", spec, sched, err)
+		}
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"0 0 31 2 *",
+		"@yearly",
+		"@every soon",
+		"@every 10ms",
+	} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) should fail", spec)
+		}
+	}
+}
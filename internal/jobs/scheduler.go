@@ -0,0 +1,326 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Func does one run of a job and returns how many rows (records,
+// connections, resources) it touched.
+type Func func(ctx context.Context) (rowsAffected int64, err error)
+
+// Job is a named piece of periodic daemon work.
+type Job struct {
+	Name        string
+	Description string
+	// Schedule is when the job runs on its own; see ParseSchedule. Empty
+	// or "off" runs it only when triggered by hand.
+	Schedule string
+	Run      Func
+}
+
+// What started a run.
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// How a run turned out.
+const (
+	ResultRunning   = "running"
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+	// ResultSkipped is a scheduled run that came due while the job was
+	// already running.
+	ResultSkipped = "skipped"
+)
+
+// Run is one run of a job, as recorded in its history.
+type Run struct {
+	ID           int64
+	Job          string
+	Trigger      string
+	StartedAt    time.Time
+	EndedAt      time.Time // zero while running
+	Result       string
+	RowsAffected int64
+	Error        string
+}
+
+// Status is a registered job and where it stands.
+type Status struct {
+	Name        string
+	Description string
+	Schedule    string
+	// Next is when the job is next due; zero when it has no schedule or
+	// the scheduler isn't started.
+	Next    time.Time
+	Running bool
+	// Last is the job's most recent run since the daemon started; nil
+	// when it hasn't run.
+	Last *Run
+}
+
+var (
+	// ErrUnknownJob is returned for a job name that isn't registered.
+	ErrUnknownJob = errors.New("unknown job")
+	// ErrRunning is returned when a job is triggered while it is running.
+	ErrRunning = errors.New("job is already running")
+)
+
+// entry is a registered job.
+type entry struct {
+	job      Job
+	schedule Schedule
+
+	// running is held for the length of each run, scheduled or manual,
+	// so the two exclude each other.
+	running sync.Mutex
+
+	mu   sync.Mutex
+	next time.Time
+	last *Run
+	busy bool
+}
+
+// Scheduler runs registered jobs on their schedules and on demand, and
+// records each run in a HistoryStore.
+type Scheduler struct {
+	history HistoryStore
+	now     func() time.Time
+
+	mu      sync.Mutex
+	jobs    map[string]*entry
+	names   []string
+	ctx     context.Context
+	started bool
+}
+
+// NewScheduler creates a scheduler recording runs in history.
+func NewScheduler(history HistoryStore) *Scheduler {
+	return &Scheduler{
+		history: history,
+		now:     time.Now,
+		jobs:    make(map[string]*entry),
+		ctx:     context.Background(),
+	}
+}
+
+// Register adds job. Jobs registered after Start are only run by hand.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return errors.New("job needs a name and a Run func")
+	}
+	sched, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	if sched == nil {
+		job.Schedule = "off"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+	s.jobs[job.Name] = &entry{job: job, schedule: sched}
+	s.names = append(s.names, job.Name)
+	return nil
+}
+
+// Start runs each scheduled job whenever it comes due, until ctx is done.
+// Manual runs started afterwards are canceled with ctx too.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started, s.ctx = true, ctx
+	for _, name := range s.names {
+		if e := s.jobs[name]; e.schedule != nil {
+			go s.loop(ctx, e)
+		}
+	}
+}
+
+// Jobs returns every registered job, sorted by name.
+func (s *Scheduler) Jobs() []Status {
+	s.mu.Lock()
+	names := slices.Sorted(slices.Values(s.names))
+	entries := make([]*entry, len(names))
+	for i, name := range names {
+		entries[i] = s.jobs[name]
+	}
+	s.mu.Unlock()
+
+	out := make([]Status, len(entries))
+	for i, e := range entries {
+		out[i] = e.status()
+	}
+	return out
+}
+
+// Job returns the named job.
+func (s *Scheduler) Job(name string) (Status, error) {
+	e, err := s.lookup(name)
+	if err != nil {
+		return Status{}, err
+	}
+	return e.status(), nil
+}
+
+// History returns the named job's most recent runs, newest first.
+func (s *Scheduler) History(ctx context.Context, name string, limit int) ([]Run, error) {
+	if _, err := s.lookup(name); err != nil {
+		return nil, err
+	}
+	return s.history.List(ctx, name, limit)
+}
+
+// Trigger starts a run of the named job now and returns it as recorded
+// at its start; the run continues in the background. It fails with
+// ErrRunning while the job is running, scheduled or not.
+func (s *Scheduler) Trigger(name string) (Run, error) {
+	e, err := s.lookup(name)
+	if err != nil {
+		return Run{}, err
+	}
+	if !e.running.TryLock() {
+		return Run{}, fmt.Errorf("%s: %w", name, ErrRunning)
+	}
+	s.mu.Lock()
+	ctx := s.ctx
+	s.mu.Unlock()
+
+	run := s.begin(ctx, e, TriggerManual)
+	go func() {
+		defer e.running.Unlock()
+		s.execute(ctx, e, run)
+	}()
+	return run, nil
+}
+
+func (s *Scheduler) lookup(name string) (*entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.jobs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownJob, name)
+	}
+	return e, nil
+}
+
+// loop runs e each time its schedule comes due, until ctx is done.
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	for {
+		next := e.schedule.Next(s.now())
+		e.setNext(next)
+		if next.IsZero() {
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !e.running.TryLock() {
+			now := s.now()
+			s.record(ctx, e, Run{
+				Job:       e.job.Name,
+				Trigger:   TriggerSchedule,
+				StartedAt: now,
+				EndedAt:   now,
+				Result:    ResultSkipped,
+				Error:     "the previous run was still in progress",
+			})
+			continue
+		}
+		s.execute(ctx, e, s.begin(ctx, e, TriggerSchedule))
+		e.running.Unlock()
+	}
+}
+
+// begin records the start of a run of e. The caller holds e.running.
+func (s *Scheduler) begin(ctx context.Context, e *entry, trigger string) Run {
+	run := Run{
+		Job:       e.job.Name,
+		Trigger:   trigger,
+		StartedAt: s.now(),
+		Result:    ResultRunning,
+	}
+	e.mu.Lock()
+	e.busy = true
+	e.mu.Unlock()
+	return s.record(ctx, e, run)
+}
+
+// execute does run and records how it ended.
+func (s *Scheduler) execute(ctx context.Context, e *entry, run Run) {
+	rows, err := e.job.Run(ctx)
+	run.EndedAt, run.RowsAffected = s.now(), rows
+	run.Result = ResultSucceeded
+	if err != nil {
+		run.Result, run.Error = ResultFailed, err.Error()
+		log.Printf("Warning: job %s (%s) failed after %s: %v", run.Job, run.Trigger, run.EndedAt.Sub(run.StartedAt).Round(time.Millisecond), err)
+	}
+
+	e.mu.Lock()
+	e.busy = false
+	e.mu.Unlock()
+	s.record(ctx, e, run)
+}
+
+// record saves run to the history, inserting it when it has no ID yet,
+// and makes it e's last run. A history that can't be written is logged;
+// the job still runs.
+func (s *Scheduler) record(ctx context.Context, e *entry, run Run) Run {
+	// Record even when the run itself was canceled by ctx.
+	ctx = context.WithoutCancel(ctx)
+	if run.ID == 0 {
+		id, err := s.history.Add(ctx, run)
+		if err != nil {
+			log.Printf("Warning: failed to record job %s run: %v", run.Job, err)
+		}
+		run.ID = id
+	} else if err := s.history.Finish(ctx, run); err != nil {
+		log.Printf("Warning: failed to record job %s run %d: %v", run.Job, run.ID, err)
+	}
+
+	e.mu.Lock()
+	e.last = &run
+	e.mu.Unlock()
+	return run
+}
+
+func (e *entry) setNext(t time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.next = t
+}
+
+func (e *entry) status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	st := Status{
+		Name:        e.job.Name,
+		Description: e.job.Description,
+		Schedule:    e.job.Schedule,
+		Next:        e.next,
+		Running:     e.busy,
+	}
+	if e.last != nil {
+		last := *e.last
+		st.Last = &last
+	}
+	return st
+}
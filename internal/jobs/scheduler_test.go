@@ -0,0 +1,181 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// soon is due a few milliseconds after any time.
+type soon struct{}
+
+func (soon) Next(t time.Time) time.Time { return t.Add(5 * time.Millisecond) }
+
+// blockingJob returns a job func that signals each start on started and
+// returns once release is closed.
+func blockingJob(started chan<- struct{}, release <-chan struct{}) Func {
+	return func(ctx context.Context) (int64, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return 7, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// waitFor polls cond until it holds or a second passes.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestScheduler_TriggerRecordsRun(t *testing.T) {
+	history := NewMemoryHistory(10)
+	s := NewScheduler(history)
+	calls := 0
+	if err := s.Register(Job{Name: "cleanup", Run: func(context.Context) (int64, error) {
+		calls++
+		if calls == 2 {
+			return 1, errors.New("database unreachable")
+		}
+		return 42, nil
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		run, err := s.Trigger("cleanup")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if run.ID == 0 || run.Result != ResultRunning || run.Trigger != TriggerManual {
+			t.Fatalf("Trigger = %+v, want a recorded running manual run", run)
+		}
+		waitFor(t, "run to finish", func() bool { st, _ := s.Job("cleanup"); return !st.Running })
+	}
+
+	runs, err := s.History(context.Background(), "cleanup", 0)
+	if err != nil || len(runs) != 2 {
+		t.Fatalf("History = %+v, %v", runs, err)
+	}
+	if runs[0].Result != ResultFailed || runs[0].Error != "database unreachable" || runs[0].RowsAffected != 1 {
+		t.Errorf("newest run = %+v, want the failure", runs[0])
+	}
+	if runs[1].Result != ResultSucceeded || runs[1].RowsAffected != 42 || runs[1].EndedAt.IsZero() {
+		t.Errorf("oldest run = %+v, want success with 42 rows", runs[1])
+	}
+	if st, _ := s.Job("cleanup"); st.Schedule != "off" || st.Last == nil || st.Last.ID != runs[0].ID {
+		t.Errorf("status = %+v", st)
+	}
+}
+
+func TestScheduler_ManualExcludesScheduled(t *testing.T) {
+	history := NewMemoryHistory(100)
+	s := NewScheduler(history)
+	started, release := make(chan struct{}, 10), make(chan struct{})
+	if err := s.Register(Job{Name: "rollup", Schedule: "@hourly", Run: blockingJob(started, release)}); err != nil {
+		t.Fatal(err)
+	}
+	s.jobs["rollup"].schedule = soon{}
+
+	if _, err := s.Trigger("rollup"); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if _, err := s.Trigger("rollup"); !errors.Is(err, ErrRunning) {
+		t.Fatalf("second Trigger err = %v, want ErrRunning", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	// The scheduled runs that come due meanwhile are skipped, not started.
+	waitFor(t, "a skipped scheduled run", func() bool {
+		runs, _ := history.List(ctx, "rollup", 100)
+		return len(runs) > 0 && runs[0].Result == ResultSkipped && runs[0].Trigger == TriggerSchedule
+	})
+	select {
+	case <-started:
+		t.Fatal("a scheduled run started while the manual one was running")
+	default:
+	}
+
+	close(release)
+	waitFor(t, "a scheduled run after the manual one", func() bool {
+		select {
+		case <-started:
+			return true
+		default:
+			return false
+		}
+	})
+	cancel()
+}
+
+func TestScheduler_ScheduledExcludesManual(t *testing.T) {
+	s := NewScheduler(NewMemoryHistory(10))
+	started, release := make(chan struct{}, 10), make(chan struct{})
+	if err := s.Register(Job{Name: "snapshot", Schedule: "@every 1h", Run: blockingJob(started, release)}); err != nil {
+		t.Fatal(err)
+	}
+	s.jobs["snapshot"].schedule = soon{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	<-started
+	if _, err := s.Trigger("snapshot"); !errors.Is(err, ErrRunning) {
+		t.Fatalf("Trigger during a scheduled run: err = %v, want ErrRunning", err)
+	}
+	if st, _ := s.Job("snapshot"); !st.Running || st.Last == nil || st.Last.Trigger != TriggerSchedule {
+		t.Errorf("status = %+v, want the scheduled run in progress", st)
+	}
+	cancel()
+	close(release)
+}
+
+func TestScheduler_RegisterErrors(t *testing.T) {
+	s := NewScheduler(NewMemoryHistory(10))
+	noop := func(context.Context) (int64, error) { return 0, nil }
+	if err := s.Register(Job{Name: "gc", Run: noop}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(Job{Name: "gc", Run: noop}); err == nil {
+		t.Error("duplicate name should fail")
+	}
+	if err := s.Register(Job{Name: "bad", Schedule: "every day", Run: noop}); err == nil {
+		t.Error("bad schedule should fail")
+	}
+	if _, err := s.Trigger("nope"); !errors.Is(err, ErrUnknownJob) {
+		t.Errorf("Trigger unknown: %v", err)
+	}
+	if _, err := s.History(context.Background(), "nope", 1); !errors.Is(err, ErrUnknownJob) {
+		t.Errorf("History unknown: %v", err)
+	}
+}
+
+func TestMemoryHistory_KeepsNewest(t *testing.T) {
+	ctx := context.Background()
+	h := NewMemoryHistory(2)
+	for i := range 3 {
+		if _, err := h.Add(ctx, Run{Job: "gc", RowsAffected: int64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runs, _ := h.List(ctx, "gc", 10)
+	if len(runs) != 2 || runs[0].RowsAffected != 2 || runs[1].RowsAffected != 1 {
+		t.Errorf("List = %+v, want the two newest, newest first", runs)
+	}
+	if runs, _ := h.List(ctx, "gc", 1); len(runs) != 1 || runs[0].RowsAffected != 2 {
+		t.Errorf("List limit 1 = %+v", runs)
+	}
+}
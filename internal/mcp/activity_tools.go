@@ -0,0 +1,196 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// activityTools is the MCP-side catalog for the per-container activity
+// report. The daemon does the joins (GET /v1/containers/{username}/activity);
+// this side only parses the window and renders the result.
+func activityTools() []Tool {
+	return []Tool{
+		{
+			Name: "container_activity_report",
+			Description: "Summarize what has happened on a user's container over a " +
+				"recent window — the answer to \"what's been going on on bob's box " +
+				"this week\". One call returns lifecycle events (created, started, " +
+				"stopped, ...), resource usage trends (average CPU, average/peak " +
+				"memory, disk growth) next to current usage, traffic totals and the " +
+				"busiest remote destinations, the ports it listens on and any opened " +
+				"or closed in the window, snapshots taken, and mutating API calls " +
+				"(who resized/restarted/changed it). Output is a readable summary " +
+				"followed by the same data as JSON. Sections the host can't fill " +
+				"(e.g. traffic persistence disabled) are listed under Notes rather " +
+				"than failing the call.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to report on.",
+					},
+					"window": map[string]interface{}{
+						"type":        "string",
+						"description": "Look-back window, e.g. '24h', '7d' (default), '30d'. Max 30 days.",
+					},
+				},
+				"required": []string{"username"},
+			},
+			Handler: handleContainerActivityReport,
+		},
+	}
+}
+
+func handleContainerActivityReport(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+	window, err := parseActivityWindow(getStringArg(args, "window", ""))
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.GetContainerActivity(username, int64(window/time.Second))
+	if err != nil {
+		return "", fmt.Errorf("failed to get container activity: %w", err)
+	}
+	js, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode activity report: %w", err)
+	}
+	return formatActivityReport(resp) + "\nJSON:\n" + string(js), nil
+}
+
+// parseActivityWindow accepts Go durations plus a whole-day "Nd" form,
+// since "7d" is how people ask. Empty means the daemon default.
+func parseActivityWindow(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid window %q (expected e.g. '24h' or '7d')", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window %q (expected e.g. '24h' or '7d')", s)
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("window %q is shorter than a second", s)
+	}
+	return d, nil
+}
+
+func formatActivityReport(r *ContainerActivityResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Activity for %s (%s) from %s to %s\n", r.GetContainerName(), r.GetUsername(), activityTime(r.GetWindowStart()), activityTime(r.GetWindowEnd()))
+	fmt.Fprintf(&b, "Current state: %s\n", strings.TrimPrefix(r.GetState().String(), "CONTAINER_STATE_"))
+
+	fmt.Fprintf(&b, "\nLifecycle events (%d):\n", len(r.GetLifecycleEvents()))
+	for _, e := range r.GetLifecycleEvents() {
+		fmt.Fprintf(&b, "  %s  %s\n", activityTime(e.GetTimestamp()), strings.TrimPrefix(e.GetType(), "EVENT_TYPE_"))
+	}
+
+	if m := r.GetMetrics(); m != nil {
+		b.WriteString("\nResources:\n")
+		fmt.Fprintf(&b, "  avg CPU %.2f cores, avg memory %s, peak memory %s, disk growth %s\n",
+			m.GetAvgCpuCores(), humanBytes(m.GetAvgMemoryBytes()), humanBytes(m.GetPeakMemoryBytes()), signedHumanBytes(m.GetDiskGrowthBytes()))
+		if c := m.GetCurrent(); c != nil {
+			fmt.Fprintf(&b, "  now: memory %s, disk %s, %d processes\n",
+				humanBytes(c.GetMemoryUsageBytes()), humanBytes(c.GetDiskUsageBytes()), c.GetProcessCount())
+		}
+	}
+
+	if t := r.GetTraffic(); t != nil {
+		b.WriteString("\nTraffic:\n")
+		fmt.Fprintf(&b, "  %d connections, %s sent, %s received\n",
+			t.GetConnectionCount(), humanBytes(t.GetBytesSent()), humanBytes(t.GetBytesReceived()))
+		fmt.Fprintf(&b, "  by direction: %s egress (container-initiated), %s ingress\n",
+			humanBytes(t.GetEgressBytes()), humanBytes(t.GetIngressBytes()))
+		for _, d := range t.GetTopDestinations() {
+			fmt.Fprintf(&b, "  %-40s %s sent, %s received, %d connections\n",
+				d.GetDestIp(), humanBytes(d.GetBytesSent()), humanBytes(d.GetBytesReceived()), d.GetConnectionCount())
+		}
+	}
+
+	if l := r.GetListeningPorts(); l != nil {
+		fmt.Fprintf(&b, "\nListening ports (%d):\n", len(l.GetCurrent()))
+		for _, p := range l.GetCurrent() {
+			fmt.Fprintf(&b, "  %s\n", listeningPortLabel(p))
+		}
+		for _, c := range l.GetChanges() {
+			fmt.Fprintf(&b, "  %s  %-7s %s\n", activityTime(c.GetTimestamp()),
+				strings.ToLower(strings.TrimPrefix(c.GetType().String(), "LISTENER_CHANGE_TYPE_")), listeningPortLabel(c.GetListener()))
+		}
+	}
+
+	if sessions := r.GetSshSessions(); len(sessions) > 0 {
+		fmt.Fprintf(&b, "\nSSH sessions (%d):\n", len(sessions))
+		for _, ss := range sessions {
+			fmt.Fprintf(&b, "  %s  %s from %s %s: %s uploaded, %s downloaded",
+				activityTime(ss.GetStartedAt()), ss.GetBoxUser(), ss.GetClientIp(), activitySSHCredential(ss),
+				humanBytes(ss.GetBytesReceived()), humanBytes(ss.GetBytesSent()))
+			if ss.GetEndedAt() == nil {
+				b.WriteString(", still open")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "\nSnapshots taken (%d):\n", len(r.GetSnapshots()))
+	for _, s := range r.GetSnapshots() {
+		fmt.Fprintf(&b, "  %s  %s\n", snapshotCreatedLabel(s.GetCreatedAt()), s.GetName())
+	}
+
+	fmt.Fprintf(&b, "\nChanges (%d):\n", len(r.GetChanges()))
+	for _, c := range r.GetChanges() {
+		fmt.Fprintf(&b, "  %s  %-12s %s -> %d\n", activityTime(c.GetTimestamp()), c.GetActor(), c.GetRequest(), c.GetStatusCode())
+	}
+
+	if len(r.GetNotes()) > 0 {
+		b.WriteString("\nNotes:\n")
+		for _, n := range r.GetNotes() {
+			fmt.Fprintf(&b, "  - %s\n", n)
+		}
+	}
+	return b.String()
+}
+
+// activityTime renders a report timestamp as RFC 3339 UTC, or "-" when
+// the daemon left it unset.
+func activityTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+// activitySSHCredential says how a session authenticated, e.g. "using
+// key SHA256:abc (ED25519)" or "by password".
+func activitySSHCredential(s *SSHSession) string {
+	if s.GetKeyFingerprint() == "" {
+		return "by " + s.GetAuthMethod()
+	}
+	if s.GetKeyType() == "" {
+		return "using key " + s.GetKeyFingerprint()
+	}
+	return fmt.Sprintf("using key %s (%s)", s.GetKeyFingerprint(), s.GetKeyType())
+}
+
+func signedHumanBytes(n int64) string {
+	if n < 0 {
+		return "-" + humanBytes(-n)
+	}
+	return "+" + humanBytes(n)
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseActivityWindow(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":    0,
+		"24h": 24 * time.Hour,
+		"7d":  7 * 24 * time.Hour,
+		"90m": 90 * time.Minute,
+	}
+	for in, want := range cases {
+		got, err := parseActivityWindow(in)
+		if err != nil || got != want {
+			t.Errorf("parseActivityWindow(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"week", "-1d", "0d", "-5h", "10ms"} {
+		if _, err := parseActivityWindow(bad); err == nil {
+			t.Errorf("parseActivityWindow(%q) should fail", bad)
+		}
+	}
+}
+
+func TestContainerActivityReport(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		_, _ = io.WriteString(w, `{
+			"username":"bob","containerName":"bob-container",
+			"windowStart":"2026-03-01T00:00:00Z","windowEnd":"2026-03-02T00:00:00Z",
+			"state":"CONTAINER_STATE_RUNNING",
+			"lifecycleEvents":[{"timestamp":"2026-03-01T09:00:00Z","type":"EVENT_TYPE_CONTAINER_STARTED"}],
+			"metrics":{"avgCpuCores":0.25,"avgMemoryBytes":"1048576","peakMemoryBytes":"2097152","diskGrowthBytes":"-1024"},
+			"sshSessions":[{"boxUser":"bob","clientIp":"203.0.113.7","authMethod":"publickey","keyType":"ED25519",
+				"keyFingerprint":"SHA256:abc","startedAt":"2026-03-01T11:00:00Z","bytesSent":"1024","bytesReceived":"2469606195"}],
+			"snapshots":[{"name":"pre-upgrade","createdAt":"1772355600"}],
+			"changes":[{"timestamp":"2026-03-01T10:00:00Z","actor":"admin","request":"PUT /v1/containers/bob/resize","statusCode":200}],
+			"notes":["traffic unavailable: traffic persistence is disabled"]
+		}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleContainerActivityReport(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "bob", "window": "1d"})
+	if err != nil {
+		t.Fatalf("handleContainerActivityReport: %v", err)
+	}
+	if gotPath != "/v1/containers/bob/activity" || gotQuery != "window_seconds=86400" {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	for _, want := range []string{
+		"Current state: RUNNING",
+		"CONTAINER_STARTED",
+		"disk growth -1024 B",
+		"pre-upgrade",
+		"PUT /v1/containers/bob/resize -> 200",
+		"bob from 203.0.113.7 using key SHA256:abc (ED25519): 2 GiB uploaded, 1024 B downloaded, still open",
+		"- traffic unavailable: traffic persistence is disabled",
+		"\nJSON:\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\nTraffic:\n") {
+		t.Errorf("traffic section should be absent when the daemon sent none:\n%s", out)
+	}
+}
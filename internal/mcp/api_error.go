@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/footprintai/containarium/internal/apierr"
+)
+
+// APIError is a non-2xx response from the daemon, parsed from its REST
+// error envelope (see internal/apierr). Every Client method returns it —
+// possibly wrapped — for daemon-side failures, so callers can branch on
+// Code with errors.As instead of matching message text.
+type APIError struct {
+	// StatusCode is the HTTP status.
+	StatusCode int
+	// Code is the machine-readable reason, e.g. "NOT_FOUND" or
+	// "RESOURCE_EXHAUSTED". Daemons that predate the envelope don't send
+	// one; it is then derived from StatusCode.
+	Code string
+	// Message is the daemon's human-readable message (the raw body when it
+	// wasn't an envelope).
+	Message string
+	// RequestID is the ID the daemon logged the failure under, if any.
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	s := fmt.Sprintf("API error (status %d, %s): %s", e.StatusCode, e.Code, e.Message)
+	if e.RequestID != "" {
+		s += " [request " + e.RequestID + "]"
+	}
+	return s
+}
+
+// parseAPIError builds an APIError from a failed response.
+func parseAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Message:    strings.TrimSpace(string(body)),
+		RequestID:  header.Get(apierr.RequestIDHeader),
+	}
+	var env struct {
+		Error     string `json:"error"`
+		Message   string `json:"message"` // grpc-gateway's default body
+		Reason    string `json:"reason"`
+		RequestID string `json:"request_id"`
+	}
+	if json.Unmarshal(body, &env) == nil {
+		if msg := cmp.Or(env.Error, env.Message); msg != "" {
+			e.Message = msg
+		}
+		e.Code = env.Reason
+		if env.RequestID != "" {
+			e.RequestID = env.RequestID
+		}
+	}
+	if e.Code == "" {
+		e.Code = apierr.ReasonForStatus(statusCode)
+	}
+	return e
+}
+
+// explainAPIError rewrites a tool failure caused by a daemon error into
+// text that tells the model what to do next ("container 'bob' does not
+// exist — use list_containers ..."). The original error stays wrapped and
+// its message is kept in parentheses. Errors that aren't APIErrors, and
+// codes with no useful advice, pass through unchanged.
+func explainAPIError(err error, args map[string]interface{}) error {
+	var ae *APIError
+	if !errors.As(err, &ae) {
+		return err
+	}
+	username := getStringArg(args, "username", "")
+	var hint string
+	switch ae.Code {
+	case "NOT_FOUND":
+		if username != "" && (ae.Message == "" || strings.Contains(strings.ToLower(ae.Message), "container")) {
+			hint = fmt.Sprintf("container '%s' does not exist — use list_containers to see valid names", username)
+		} else {
+			hint = "not found — check the name or ID with the matching list_* tool"
+		}
+	case "ALREADY_EXISTS":
+		hint = "it already exists — inspect it with get_container (or the matching get/list tool) instead of creating it again"
+	case "RESOURCE_EXHAUSTED":
+		hint = "a quota or the host's capacity was reached — stop or delete unused containers, ask for smaller limits, or try another backend"
+	case "PERMISSION_DENIED":
+		hint = "this MCP server's token is not allowed to do that — ask an admin for a token with the required role or scope"
+	case "UNAUTHENTICATED":
+		hint = "the daemon rejected this MCP server's token (expired or revoked?) — issue a new token and restart the MCP server"
+	case "INVALID_ARGUMENT":
+		hint = "the daemon rejected the arguments — fix them and call the tool again"
+	case "FAILED_PRECONDITION":
+		hint = "the container is not in a state that allows this — check its state with get_container first"
+	case "UNAVAILABLE", "DEADLINE_EXCEEDED", "BAD_GATEWAY":
+		hint = "the daemon or one of its backends is temporarily unavailable — retry shortly"
+	default:
+		return err
+	}
+	return fmt.Errorf("%s (%w)", hint, err)
+}
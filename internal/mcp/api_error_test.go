@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_ReturnsAPIErrorFromEnvelope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error":"container bob-container not found","code":404,"reason":"NOT_FOUND","request_id":"abc123"}`)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, "test-token").GetContainer("bob")
+	var ae *APIError
+	if !errors.As(err, &ae) {
+		t.Fatalf("err = %v (%T); want an *APIError", err, err)
+	}
+	if ae.StatusCode != 404 || ae.Code != "NOT_FOUND" || ae.Message != "container bob-container not found" || ae.RequestID != "abc123" {
+		t.Errorf("APIError = %+v", ae)
+	}
+}
+
+func TestParseAPIError_OlderDaemonBodies(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Request-Id", "from-header")
+
+	// grpc-gateway's default body: no reason, message instead of error.
+	ae := parseAPIError(http.StatusTooManyRequests, h, []byte(`{"code":8,"message":"quota exceeded","details":[]}`))
+	if ae.Code != "RESOURCE_EXHAUSTED" || ae.Message != "quota exceeded" || ae.RequestID != "from-header" {
+		t.Errorf("gateway default body: %+v", ae)
+	}
+
+	// A proxy in front of the daemon answering with plain text.
+	ae = parseAPIError(http.StatusBadGateway, http.Header{}, []byte("upstream connect error\n"))
+	if ae.Code != "BAD_GATEWAY" || ae.Message != "upstream connect error" {
+		t.Errorf("plain-text body: %+v", ae)
+	}
+}
+
+func TestExplainAPIError_ActionableText(t *testing.T) {
+	notFound := &APIError{StatusCode: 404, Code: "NOT_FOUND", Message: "container bob-container not found"}
+	err := explainAPIError(fmt.Errorf("failed to get container: %w", notFound), map[string]interface{}{"username": "bob"})
+	if !strings.HasPrefix(err.Error(), "container 'bob' does not exist — use list_containers to see valid names") {
+		t.Errorf("not found: %v", err)
+	}
+	if !errors.Is(err, notFound) {
+		t.Error("explained error should still wrap the APIError")
+	}
+
+	quota := &APIError{StatusCode: 429, Code: "RESOURCE_EXHAUSTED", Message: "CPU overcommit ceiling reached"}
+	if err := explainAPIError(quota, nil); !strings.Contains(err.Error(), "quota or the host's capacity") ||
+		!strings.Contains(err.Error(), "CPU overcommit ceiling reached") {
+		t.Errorf("resource exhausted: %v", err)
+	}
+
+	plain := errors.New("username is required")
+	if err := explainAPIError(plain, nil); err != plain {
+		t.Errorf("non-API error rewritten: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// captureHeaders serves an empty container list and records the last
+// request's headers.
+func captureHeaders(t *testing.T) (*httptest.Server, *http.Header) {
+	t.Helper()
+	got := new(http.Header)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = r.Header.Clone()
+		_, _ = io.WriteString(w, `{"containers":[]}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func TestClient_JWTModeSendsBearer(t *testing.T) {
+	srv, got := captureHeaders(t)
+	if _, err := NewClient(srv.URL, "test-token").ListContainers(); err != nil {
+		t.Fatalf("ListContainers: %v", err)
+	}
+	if a := got.Get("Authorization"); a != "Bearer test-token" {
+		t.Errorf("Authorization = %q, want the Bearer JWT", a)
+	}
+	if k := got.Get(DefaultAPIKeyHeader); k != "" {
+		t.Errorf("%s sent in JWT mode: %q", DefaultAPIKeyHeader, k)
+	}
+}
+
+func TestClient_APIKeyModeSendsKeyHeader(t *testing.T) {
+	for _, header := range []string{DefaultAPIKeyHeader, "X-Api-Token"} {
+		srv, got := captureHeaders(t)
+		cfg := &Config{ServerURL: srv.URL, JWTToken: "stale-jwt", AuthMode: AuthModeAPIKey, APIKey: "k-123", APIKeyHeader: header}
+		if _, err := newBackend(cfg).ListContainers(); err != nil {
+			t.Fatalf("%s: ListContainers: %v", header, err)
+		}
+		if k := got.Get(header); k != "k-123" {
+			t.Errorf("%s = %q, want the API key", header, k)
+		}
+		if a := got.Get("Authorization"); a != "" {
+			t.Errorf("Authorization sent in API-key mode: %q", a)
+		}
+	}
+}
+
+func TestServer_APIKeyModeDoesNotFilterByJWTScopes(t *testing.T) {
+	tok := makeUnsignedJWT(t, map[string]interface{}{"scopes": []string{"containers:read"}})
+	server, err := NewServer(&Config{ServerURL: "http://localhost:8080", JWTToken: tok, AuthMode: AuthModeAPIKey, APIKey: "k-123", APIKeyHeader: DefaultAPIKeyHeader})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scopes := server.allowedScopes(); scopes != nil {
+		t.Errorf("allowedScopes = %v; a JWT that is never sent must not narrow the catalog", scopes)
+	}
+}
+
+func TestLoadConfig_AuthMode(t *testing.T) {
+	tests := []struct {
+		mode, header   string
+		wantMode       string
+		wantHeaderName string
+	}{
+		{mode: "", wantMode: AuthModeJWT, wantHeaderName: DefaultAPIKeyHeader},
+		{mode: "jwt", wantMode: AuthModeJWT, wantHeaderName: DefaultAPIKeyHeader},
+		{mode: "APIKey", wantMode: AuthModeAPIKey, wantHeaderName: DefaultAPIKeyHeader},
+		{mode: "api-key", header: "X-Api-Token", wantMode: AuthModeAPIKey, wantHeaderName: "X-Api-Token"},
+		{mode: "oauth", wantMode: AuthModeJWT, wantHeaderName: DefaultAPIKeyHeader},
+	}
+	for _, tt := range tests {
+		t.Setenv("CONTAINARIUM_JWT_TOKEN", "test-token")
+		t.Setenv("CONTAINARIUM_AUTH_MODE", tt.mode)
+		t.Setenv("CONTAINARIUM_API_KEY", " k-123 ")
+		t.Setenv("CONTAINARIUM_API_KEY_HEADER", tt.header)
+		cfg := LoadConfig()
+		if cfg.AuthMode != tt.wantMode || cfg.APIKeyHeader != tt.wantHeaderName || cfg.APIKey != "k-123" {
+			t.Errorf("mode %q header %q: got mode %q header %q key %q", tt.mode, tt.header, cfg.AuthMode, cfg.APIKeyHeader, cfg.APIKey)
+		}
+	}
+}
@@ -101,7 +101,7 @@ func TestHandleStartContainer_WaitForReadyPropagates(t *testing.T) {
 	var captured map[string]interface{}
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
-		_, _ = w.Write([]byte(`{"message":"started","container":{"state":"Running"},"readyTimedOut":false}`))
+		_, _ = w.Write([]byte(`{"message":"started","container":{"state":"CONTAINER_STATE_RUNNING"},"readyTimedOut":false}`))
 	}))
 	defer srv.Close()
 	client := NewClient(srv.URL, "tok")
@@ -122,7 +122,7 @@ func TestHandleStartContainer_DefaultWaitForReadyFalse(t *testing.T) {
 	var captured map[string]interface{}
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
-		_, _ = w.Write([]byte(`{"message":"started","container":{"state":"Running"},"readyTimedOut":false}`))
+		_, _ = w.Write([]byte(`{"message":"started","container":{"state":"CONTAINER_STATE_RUNNING"},"readyTimedOut":false}`))
 	}))
 	defer srv.Close()
 	client := NewClient(srv.URL, "tok")
@@ -147,7 +147,7 @@ func TestHandleStartContainer_RequiresUsername(t *testing.T) {
 // that operators recognise.
 func TestHandleStartContainer_ShowsTimeoutWarning(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte(`{"message":"started","container":{"state":"Running"},"readyTimedOut":true}`))
+		_, _ = w.Write([]byte(`{"message":"started","container":{"state":"CONTAINER_STATE_RUNNING"},"readyTimedOut":true}`))
 	}))
 	defer srv.Close()
 	client := NewClient(srv.URL, "tok")
@@ -1,9 +1,12 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/footprintai/containarium/internal/credentials"
 )
@@ -26,7 +29,8 @@ import (
 // assertions below pin both implementations to it.
 type API interface {
 	// Containers + lifecycle.
-	CreateContainer(req CreateContainerRequest) (*CreateContainerResponse, error)
+	CreateContainer(req *CreateContainerRequest, idempotencyKey string) (*CreateContainerResponse, error)
+	ListContainerTemplates() (*ListContainerTemplatesResponse, error)
 	ListContainers() (*ListContainersResponse, error)
 	GetContainer(username string) (*GetContainerResponse, error)
 	DeleteContainer(username string, force bool) (*DeleteContainerResponse, error)
@@ -35,7 +39,19 @@ type API interface {
 	ResizeContainer(username, cpu, memory, disk string) (*ResizeContainerResponse, error)
 	ToggleMonitoring(username string, enabled bool) (*ToggleMonitoringResponse, error)
 	ToggleAutoSleep(username string, enabled bool, idleThresholdMinutes int32) (*ToggleAutoSleepResponse, error)
-	GetMetrics(username string) (*GetMetricsResponse, error)
+	GetMetrics(username, sortBy string, limit int) (*GetMetricsResponse, error)
+	GetContainerActivity(username string, windowSeconds int64) (*ContainerActivityResponse, error)
+	GetContainerProcesses(username string, limit int) (*GetContainerProcessesResponse, error)
+	DiffContainers(usernameA, usernameB string, includePackages bool) (*DiffContainersResponse, error)
+	ReadContainerFile(username, path string, maxBytes int64) (*ReadContainerFileResponse, error)
+	WriteContainerFile(req *WriteContainerFileRequest) (*WriteContainerFileResponse, error)
+	ListContainerDir(username, path string) (*ListContainerDirResponse, error)
+	GetContainerReadiness(username string) (*ContainerReadinessResponse, error)
+	GetContainerNetwork(username string) (*ContainerNetwork, error)
+	GetListeningPorts(username string, historySeconds int64) (*GetListeningPortsResponse, error)
+	TestConnectivity(req *TestConnectivityRequest) (*TestConnectivityResponse, error)
+	QueryTrafficHistory(q TrafficHistoryQuery) (*QueryTrafficHistoryResponse, error)
+	PollEvents(ctx context.Context, cursor string, timeout time.Duration, resourceTypes []string) (*PollEventsResponse, error)
 
 	// Recipes / agents / crews.
 	ListRecipes() (*ListRecipesResponse, error)
@@ -51,20 +67,29 @@ type API interface {
 	ListBackups(username string) (*ListBackupsResponse, error)
 	RestoreBackup(req RestoreBackupRequest) (*RestoreBackupResponse, error)
 
+	// Container snapshots.
+	SnapshotContainer(username, snapshotName string) (*SnapshotContainerResponse, error)
+	ListSnapshots(username string) (*ListSnapshotsResponse, error)
+	RestoreContainer(username, snapshotName string) (*RestoreContainerResponse, error)
+
 	// Secrets / KMS.
 	SetSecret(username, name, value string) (*SecretResponse, error)
 	GetSecret(username, name string) (string, error)
 	ListSecrets(username string) ([]map[string]interface{}, error)
 	DeleteSecret(username, name string) error
 	RefreshSecrets(username string) (*RefreshSecretsResponse, error)
+	SetContainerSecret(req *SetContainerSecretRequest) (*SetContainerSecretResponse, error)
+	ListContainerSecrets(username string) (*ListContainerSecretsResponse, error)
+	RemoveContainerSecret(username, name string) (*RemoveContainerSecretResponse, error)
 	GetKMSStatus() (*KMSStatusResponse, error)
 	GetEnvelopeCoverage() (*EnvelopeCoverageResponse, error)
 	MigrateToEnvelope(req MigrateToEnvelopeBody) (*MigrateToEnvelopeResponse, error)
 
 	// Routes / backends.
-	AddRoute(req AddRouteRequest) (*AddRouteResponse, error)
+	AddRoute(req *AddRouteRequest) (*AddRouteResponse, error)
 	ListRoutes(username string, activeOnly bool) (*ListRoutesResponse, error)
 	DeleteRoute(domain string) error
+	ListPassthroughRoutes() (*ListPassthroughRoutesResponse, error)
 	ListBackends() (*ListBackendsResponse, error)
 	GetBackend(id string) (*Backend, error)
 	ValidateGPU(backendID, pci string) (*ValidateGPUResult, error)
@@ -172,6 +197,14 @@ func newBackend(cfg *Config) API {
 	if cfg.JWTTokenFile != "" {
 		base.SetTokenFile(cfg.JWTTokenFile)
 	}
+	if cfg.AuthMode == AuthModeAPIKey {
+		base.SetAPIKey(cfg.APIKeyHeader, cfg.APIKey)
+	}
+	if cfg.DebugHTTPLog != "" {
+		if err := base.SetHTTPLog(cfg.DebugHTTPLog); err != nil {
+			log.Printf("Warning: HTTP debug log disabled: %v", err)
+		}
+	}
 	tok, _ := base.readToken()
 	if credentials.IsCloudToken(tok) {
 		return cloudClient{base}
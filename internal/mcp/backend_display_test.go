@@ -16,7 +16,7 @@ import (
 
 func TestListContainers_ShowsBackendAndPool(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, _ = w.Write([]byte(`{"containers":[{"name":"box1","username":"cld-1","state":"running","backendId":"tunnel-fts-13700k","pool":"gpu-pool"}],"totalCount":1}`))
+		_, _ = w.Write([]byte(`{"containers":[{"name":"box1","username":"cld-1","state":"CONTAINER_STATE_RUNNING","backendId":"tunnel-fts-13700k","pool":"gpu-pool"}],"totalCount":1}`))
 	}))
 	defer server.Close()
 
@@ -30,7 +30,7 @@ func TestCreateContainer_ShowsBackend_AndFallbackWarning(t *testing.T) {
 	// Server reports the box landed on the default GCP backend, regardless of
 	// the requested backend_id — the silent-fallback case.
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, _ = w.Write([]byte(`{"container":{"name":"box1","username":"cld-1","state":"running","backendId":"containarium-jump-ase1-spot"},"message":"created"}`))
+		_, _ = w.Write([]byte(`{"container":{"name":"box1","username":"cld-1","state":"CONTAINER_STATE_RUNNING","backendId":"containarium-jump-ase1-spot"},"message":"created"}`))
 	}))
 	defer server.Close()
 
@@ -46,7 +46,7 @@ func TestCreateContainer_ShowsBackend_AndFallbackWarning(t *testing.T) {
 
 func TestCreateContainer_NoWarningWhenBackendMatches(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, _ = w.Write([]byte(`{"container":{"name":"box1","username":"cld-1","state":"running","backendId":"tunnel-fts-13700k"},"message":"created"}`))
+		_, _ = w.Write([]byte(`{"container":{"name":"box1","username":"cld-1","state":"CONTAINER_STATE_RUNNING","backendId":"tunnel-fts-13700k"},"message":"created"}`))
 	}))
 	defer server.Close()
 
@@ -63,7 +63,7 @@ func TestDeleteContainer_ShowsBackend(t *testing.T) {
 	// Delete pre-fetches the box (GET) to learn its backend, then deletes.
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
-			_, _ = w.Write([]byte(`{"container":{"name":"box1","username":"cld-1","state":"running","backendId":"tunnel-fts-13700k"}}`))
+			_, _ = w.Write([]byte(`{"container":{"name":"box1","username":"cld-1","state":"CONTAINER_STATE_RUNNING","backendId":"tunnel-fts-13700k"}}`))
 			return
 		}
 		_, _ = w.Write([]byte(`{"message":"container cld-1 deleted","containerName":"cld-1"}`))
@@ -7,9 +7,10 @@ import (
 
 // TestListBackends_DecodesProtoJSONStringInts is the regression test for the
 // list_backends decode bug: grpc-gateway's protojson serializes int64 as a
-// QUOTED STRING, which the prior plain-int64 fields rejected ("cannot
-// unmarshal string into int64"), failing the whole response. flexInt64 must
-// accept the string form (and the number form, for mixed-fleet safety).
+// QUOTED STRING, which the old hand-written plain-int64 fields rejected
+// ("cannot unmarshal string into int64"), failing the whole response. The
+// generated BackendInfo decoded through protojson must accept the string
+// form (and the number form, for mixed-fleet safety).
 func TestListBackends_DecodesProtoJSONStringInts(t *testing.T) {
 	// Exactly the shape a proto-first daemon emits: int64s as strings.
 	wire := `{
@@ -24,8 +25,8 @@ func TestListBackends_DecodesProtoJSONStringInts(t *testing.T) {
 	    }
 	  ]
 	}`
-	var resp ListBackendsResponse
-	if err := json.Unmarshal([]byte(wire), &resp); err != nil {
+	resp := &ListBackendsResponse{}
+	if err := unmarshalProto([]byte(wire), resp); err != nil {
 		t.Fatalf("decode proto-JSON (string int64) failed: %v", err)
 	}
 	if len(resp.Backends) != 1 {
@@ -35,8 +36,8 @@ func TestListBackends_DecodesProtoJSONStringInts(t *testing.T) {
 	if b.UptimeSeconds != 123456 {
 		t.Errorf("UptimeSeconds = %d, want 123456", b.UptimeSeconds)
 	}
-	if len(b.GPUs) != 1 || b.GPUs[0].VRAMBytes != 25769803776 {
-		t.Errorf("VRAMBytes = %d, want 25769803776", b.GPUs[0].VRAMBytes)
+	if len(b.Gpus) != 1 || b.Gpus[0].VramBytes != 25769803776 {
+		t.Errorf("VramBytes = %d, want 25769803776", b.Gpus[0].VramBytes)
 	}
 }
 
@@ -44,15 +45,15 @@ func TestListBackends_DecodesProtoJSONStringInts(t *testing.T) {
 // (a daemon / hand-coded handler that emits bare numbers).
 func TestListBackends_DecodesNumberInts(t *testing.T) {
 	wire := `{"backends":[{"id":"local","type":"local","healthy":true,"uptimeSeconds":42,"gpus":[{"vramBytes":1024}]}]}`
-	var resp ListBackendsResponse
-	if err := json.Unmarshal([]byte(wire), &resp); err != nil {
+	resp := &ListBackendsResponse{}
+	if err := unmarshalProto([]byte(wire), resp); err != nil {
 		t.Fatalf("decode number int64 failed: %v", err)
 	}
 	if resp.Backends[0].UptimeSeconds != 42 {
 		t.Errorf("UptimeSeconds = %d, want 42", resp.Backends[0].UptimeSeconds)
 	}
-	if resp.Backends[0].GPUs[0].VRAMBytes != 1024 {
-		t.Errorf("VRAMBytes = %d, want 1024", resp.Backends[0].GPUs[0].VRAMBytes)
+	if resp.Backends[0].Gpus[0].VramBytes != 1024 {
+		t.Errorf("VramBytes = %d, want 1024", resp.Backends[0].Gpus[0].VramBytes)
 	}
 }
 
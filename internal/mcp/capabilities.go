@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// Optional daemon features. A daemon can lack one — no traffic collector,
+// an older release, the hosted control plane — or gain it while running,
+// as when its traffic store comes up after start. Tools that need one set
+// Tool.Capability and are listed only while the daemon has it.
+const (
+	capabilityTrafficHistory = "traffic_history"
+	capabilityPassthrough    = "passthrough"
+)
+
+// capabilityProbes maps each capability to the cheap GET that reveals it.
+// A bare history query is refused for want of a filter, which still
+// proves the route is served.
+var capabilityProbes = map[string]string{
+	capabilityTrafficHistory: "/v1/traffic/history?limit=1",
+	capabilityPassthrough:    "/v1/network/passthrough",
+}
+
+// probeTimeout bounds one probe request, so an unreachable daemon can't
+// hold up startup for the client's full request timeout.
+const probeTimeout = 5 * time.Second
+
+// probeCapabilities probes every capability. The result holds only
+// definite answers: a capability whose probe got none (daemon unreachable,
+// token rejected) is left out, so the server keeps what it last knew
+// rather than dropping tools on a blip.
+func probeCapabilities(ctx context.Context, c *Client) map[string]bool {
+	caps := make(map[string]bool, len(capabilityProbes))
+	if c == nil {
+		return caps
+	}
+	for name, path := range capabilityProbes {
+		if ok, known := c.probeEndpoint(ctx, path); known {
+			caps[name] = ok
+		}
+	}
+	return caps
+}
+
+// probeEndpoint GETs path and reports whether the daemon serves it, and
+// whether the answer settles that. Unlike doRequest it sends no API-error
+// notifications: a refusal is the expected answer from a daemon without
+// the feature, and the probe repeats.
+func (c *Client) probeEndpoint(ctx context.Context, path string) (ok, known bool) {
+	if c.tlsConfigErr != nil {
+		return false, false
+	}
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return false, false
+	}
+	if err := c.setRequestHeaders(req); err != nil {
+		return false, false
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, false
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	switch code := resp.StatusCode; {
+	case code < 300:
+		return true, true
+	case code == http.StatusForbidden, code == http.StatusNotFound,
+		code == http.StatusMethodNotAllowed, code == http.StatusNotImplemented:
+		return false, true
+	case code == http.StatusUnauthorized, code >= 500:
+		return false, false
+	}
+	// Any other refusal means the route exists; FAILED_PRECONDITION
+	// says the feature behind it is switched off.
+	return parseAPIError(resp.StatusCode, resp.Header, body).Code != "FAILED_PRECONDITION", true
+}
+
+// applyCapabilities merges a probe result into what the server knows and
+// recomputes tools from the registered catalog, reporting whether the
+// visible set changed.
+func (s *Server) applyCapabilities(caps map[string]bool) bool {
+	if s.capabilities == nil {
+		s.capabilities = make(map[string]bool, len(caps))
+	}
+	maps.Copy(s.capabilities, caps)
+
+	tools := make([]Tool, 0, len(s.registered))
+	for _, t := range s.registered {
+		if t.Capability == "" || s.capabilities[t.Capability] {
+			tools = append(tools, t)
+		}
+	}
+	changed := !slices.EqualFunc(tools, s.tools, func(a, b Tool) bool { return a.Name == b.Name })
+	s.tools = tools
+	return changed
+}
+
+// updateCapabilities applies a probe result and, when that changes the
+// tool list, tells the client to fetch it again.
+func (s *Server) updateCapabilities(caps map[string]bool) {
+	if !s.applyCapabilities(caps) {
+		return
+	}
+	frame := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "notifications/tools/list_changed",
+	}
+	if err := s.write(frame); err != nil && !errors.Is(err, errNoOutput) {
+		log.Printf("Failed to encode tools/list_changed notification: %v", err)
+	}
+}
+
+// watchCapabilities re-probes the daemon every interval until ctx ends,
+// delivering each result on the returned channel. serve applies them
+// between requests, so a handler never sees the tool list change under
+// it. The channel is nil — never ready — when re-probing is off.
+func (s *Server) watchCapabilities(ctx context.Context, interval time.Duration) <-chan map[string]bool {
+	c := backendClient(s.client)
+	if interval <= 0 || c == nil {
+		return nil
+	}
+	results := make(chan map[string]bool)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			caps := probeCapabilities(ctx, c)
+			select {
+			case results <- caps:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// unavailableTool returns the registered tool called name when it is
+// hidden only because the daemon lacks its capability.
+func (s *Server) unavailableTool(name string) *Tool {
+	for i := range s.registered {
+		t := &s.registered[i]
+		if t.Name == name && t.Capability != "" && !s.capabilities[t.Capability] {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capabilityDaemon fakes a daemon whose traffic endpoints appear when
+// trafficUp is set, as when its traffic store comes online after start.
+// Passthrough is always served; historyStatus overrides the history
+// route's answer while non-zero.
+type capabilityDaemon struct {
+	*httptest.Server
+	trafficUp     atomic.Bool
+	historyStatus atomic.Int32
+}
+
+func newCapabilityDaemon(t *testing.T) *capabilityDaemon {
+	t.Helper()
+	d := &capabilityDaemon{}
+	d.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/network/passthrough":
+			_, _ = io.WriteString(w, `{"routes":[]}`)
+		case "/v1/traffic/history":
+			if code := d.historyStatus.Load(); code != 0 {
+				w.WriteHeader(int(code))
+				return
+			}
+			if !d.trafficUp.Load() {
+				http.NotFound(w, r)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"container_name, container_name_prefix, username, dest_ip or source_ip is required","reason":"INVALID_ARGUMENT"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(d.Close)
+	return d
+}
+
+func TestCapabilities_GatedToolsFollowProbe(t *testing.T) {
+	d := newCapabilityDaemon(t)
+	s, err := NewServer(&Config{ServerURL: d.URL, JWTToken: "test-token"})
+	require.NoError(t, err)
+	client := backendClient(s.client)
+
+	// Nothing is probed yet: every gated tool is hidden.
+	names := toolNames(s)
+	assert.False(t, names["query_traffic_history"])
+	assert.False(t, names["list_passthrough_routes"])
+
+	assert.True(t, s.applyCapabilities(probeCapabilities(context.Background(), client)))
+	names = toolNames(s)
+	assert.False(t, names["query_traffic_history"])
+	assert.True(t, names["list_passthrough_routes"])
+
+	d.trafficUp.Store(true)
+	assert.True(t, s.applyCapabilities(probeCapabilities(context.Background(), client)))
+	assert.True(t, toolNames(s)["query_traffic_history"])
+	assert.False(t, s.applyCapabilities(probeCapabilities(context.Background(), client)), "an unchanged probe is not a change")
+
+	// A daemon answering 503 mid-restart settles nothing: the tool stays.
+	d.historyStatus.Store(http.StatusServiceUnavailable)
+	assert.False(t, s.applyCapabilities(probeCapabilities(context.Background(), client)))
+	assert.True(t, toolNames(s)["query_traffic_history"])
+
+	// The endpoints going away hides the tool again.
+	d.historyStatus.Store(0)
+	d.trafficUp.Store(false)
+	assert.True(t, s.applyCapabilities(probeCapabilities(context.Background(), client)))
+	assert.False(t, toolNames(s)["query_traffic_history"])
+}
+
+func TestCapabilities_ProbeReadsFailedPrecondition(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":"traffic persistence not available","reason":"FAILED_PRECONDITION"}`)
+	}))
+	defer srv.Close()
+
+	ok, known := NewClient(srv.URL, "test-token").probeEndpoint(context.Background(), "/v1/traffic/history?limit=1")
+	assert.False(t, ok)
+	assert.True(t, known)
+}
+
+func TestCapabilities_HiddenToolCallExplainsWhy(t *testing.T) {
+	s, err := NewServer(&Config{ServerURL: "http://localhost:8080", JWTToken: "test-token"})
+	require.NoError(t, err)
+
+	resp := s.handleRequest(&MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params:  map[string]interface{}{"name": "query_traffic_history", "arguments": map[string]interface{}{}},
+	})
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Message, "does not offer traffic_history")
+}
+
+func TestCapabilities_GatedToolsHaveScopeAndAnnotations(t *testing.T) {
+	s, err := NewServer(&Config{ServerURL: "http://localhost:8080", JWTToken: "test-token"})
+	require.NoError(t, err)
+	gated := 0
+	for _, tool := range s.registered {
+		if tool.Capability == "" {
+			continue
+		}
+		gated++
+		assert.Contains(t, capabilityProbes, tool.Capability, "tool %s", tool.Name)
+		assert.NotEmpty(t, tool.RequiredScope, "tool %s", tool.Name)
+		assert.True(t, tool.Annotations.ReadOnlyHint, "tool %s", tool.Name)
+	}
+	assert.Equal(t, 2, gated)
+}
+
+func TestInitialize_AdvertisesToolListChanged(t *testing.T) {
+	s, err := NewServer(&Config{ServerURL: "http://localhost:8080", JWTToken: "test-token"})
+	require.NoError(t, err)
+	resp := s.handleInitialize(&MCPRequest{JSONRPC: "2.0", ID: 1, Method: "initialize"})
+	caps := resp.Result.(map[string]interface{})["capabilities"].(map[string]interface{})
+	assert.Equal(t, map[string]bool{"listChanged": true}, caps["tools"])
+}
+
+func TestServe_NotifiesWhenDaemonGainsTrafficEndpoints(t *testing.T) {
+	d := newCapabilityDaemon(t)
+	s, err := NewServer(&Config{ServerURL: d.URL, JWTToken: "test-token", CapabilityProbeInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	// The startup probe, as Start runs it: no traffic endpoints yet.
+	s.updateCapabilities(probeCapabilities(context.Background(), backendClient(s.client)))
+	require.False(t, toolNames(s)["query_traffic_history"])
+
+	in, feed := io.Pipe()
+	outR, out := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- s.serve(in, out)
+		_ = out.Close()
+	}()
+	frames := make(chan map[string]interface{}, 16)
+	go func() {
+		defer close(frames)
+		dec := json.NewDecoder(outR)
+		for {
+			var f map[string]interface{}
+			if dec.Decode(&f) != nil {
+				return
+			}
+			frames <- f
+		}
+	}()
+	next := func() map[string]interface{} {
+		t.Helper()
+		select {
+		case f, ok := <-frames:
+			require.True(t, ok, "server closed its output")
+			return f
+		case <-time.After(5 * time.Second):
+			t.Fatal("no frame from the server")
+			return nil
+		}
+	}
+
+	// The daemon gains its traffic endpoints between two probes.
+	d.trafficUp.Store(true)
+	f := next()
+	assert.Equal(t, "notifications/tools/list_changed", f["method"])
+	assert.NotContains(t, f, "id")
+
+	_, _ = io.WriteString(feed, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`+"\n")
+	f = next()
+	assert.EqualValues(t, 1, f["id"])
+	var listed []string
+	for _, tool := range f["result"].(map[string]interface{})["tools"].([]interface{}) {
+		listed = append(listed, tool.(map[string]interface{})["name"].(string))
+	}
+	assert.Contains(t, listed, "query_traffic_history")
+
+	// Later probes find nothing new, so nothing more is announced.
+	time.Sleep(50 * time.Millisecond)
+	_ = feed.Close()
+	select {
+	case err := <-served:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after input closed")
+	}
+	for f := range frames {
+		assert.NotEqual(t, "notifications/tools/list_changed", f["method"])
+	}
+}
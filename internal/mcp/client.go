@@ -2,6 +2,9 @@ package mcp
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +16,10 @@ import (
 	"strings"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 	"github.com/footprintai/containarium/pkg/version"
 )
 
@@ -31,6 +38,11 @@ type Client struct {
 	// content. Empty falls back to jwtToken.
 	jwtTokenFile string
 
+	// apiKeyHeader and apiKey, when set, replace the Bearer JWT: every
+	// request carries the key in that header instead (see SetAPIKey).
+	apiKeyHeader string
+	apiKey       string
+
 	httpClient *http.Client
 
 	// tlsConfigErr is set when buildMCPTLSConfig refuses the
@@ -39,6 +51,13 @@ type Client struct {
 	// to the caller rather than buried in the startup log. Audit
 	// C-HIGH-1.
 	tlsConfigErr error
+
+	// notify, when set, receives API errors and retries so the MCP
+	// server can forward them to the client as log notifications.
+	notify func(level int, logger string, data interface{})
+
+	// httpLog, when set, records every exchange (see SetHTTPLog).
+	httpLog *httpLog
 }
 
 // NewClient creates a new Containarium REST API client.
@@ -86,6 +105,35 @@ func (c *Client) SetTokenFile(path string) {
 	c.jwtTokenFile = path
 }
 
+// SetHTTPLog makes the client append every request/response pair,
+// sanitized, to the JSONL file at path (CONTAINARIUM_DEBUG_HTTP_LOG).
+// Call it after SetAPIKey so the key's header is redacted too.
+func (c *Client) SetHTTPLog(path string) error {
+	l, err := openHTTPLog(path)
+	if err != nil {
+		return err
+	}
+	l.secrets = func() []string { return []string{c.jwtToken, c.apiKey} }
+	if c.apiKeyHeader != "" {
+		l.extraHeaders = []string{c.apiKeyHeader}
+	}
+	c.httpLog = l
+	return nil
+}
+
+// SetAPIKey switches the client to API-key mode for deployments that
+// front Containarium with a static key instead of JWTs: every request
+// sends key in header (e.g. "X-API-Key") and no Authorization header.
+// Any JWT or token file is dropped, so readToken reports none and the
+// scope filter treats the key as unrestricted; the backend still decides
+// what the key may do.
+func (c *Client) SetAPIKey(header, key string) {
+	c.apiKeyHeader = header
+	c.apiKey = key
+	c.jwtToken = ""
+	c.jwtTokenFile = ""
+}
+
 // readToken returns the JWT to use for the next request. When a
 // tokenFile is configured, reads it fresh from disk (whitespace
 // trimmed) on every call so token rotation works without a restart.
@@ -117,14 +165,46 @@ func (c *Client) readToken() (string, error) {
 	return token, nil
 }
 
-// doRequest performs an HTTP request with JWT authentication
+// doRequest performs an authenticated HTTP request (JWT or API key)
 func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
+	return c.doRequestWithHeaders(method, path, body, nil)
+}
+
+// SetNotifier routes API errors and retries to fn (see Server.notify).
+func (c *Client) SetNotifier(fn func(level int, logger string, data interface{})) {
+	c.notify = fn
+}
+
+func (c *Client) emit(level int, data map[string]interface{}) {
+	if c.notify != nil {
+		c.notify(level, "api", data)
+	}
+}
+
+// transportError is a request that never got an HTTP response (connection
+// refused, reset, client timeout). The daemon may or may not have acted on
+// it, so only idempotent calls may retry it.
+type transportError struct{ err error }
+
+func (e *transportError) Error() string { return "request failed: " + e.err.Error() }
+func (e *transportError) Unwrap() error { return e.err }
+
+// doRequestWithHeaders is doRequest with extra request headers (e.g.
+// Idempotency-Key).
+func (c *Client) doRequestWithHeaders(method, path string, body interface{}, headers map[string]string) ([]byte, error) {
+	return c.doRequestContext(context.Background(), method, path, body, headers)
+}
+
+// doRequestContext is doRequestWithHeaders bound to ctx, for calls that
+// block (long-polls) and must stop when the tool call is cancelled.
+func (c *Client) doRequestContext(ctx context.Context, method, path string, body interface{}, headers map[string]string) ([]byte, error) {
 	if c.tlsConfigErr != nil {
 		return nil, fmt.Errorf("MCP client refuses to send request: %w", c.tlsConfigErr)
 	}
 	url := c.baseURL + path
 
 	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
 		// A []byte body is already-encoded JSON — pass it through as-is.
 		// Marshaling it again would wrap the bytes as a base64 JSON
@@ -132,68 +212,171 @@ func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error
 		// proto syntax error. Several callers (ToggleMonitoring,
 		// SetSecret, ResizeContainer, RefreshSecrets) pre-marshal; this
 		// keeps them correct rather than double-encoding. See #370.
-		jsonData, ok := body.([]byte)
-		if !ok {
-			var err error
+		//
+		// Generated request messages go through protojson so their field
+		// names and enum spellings match what the gateway decodes.
+		var err error
+		switch b := body.(type) {
+		case []byte:
+			jsonData = b
+		case proto.Message:
+			jsonData, err = protojson.Marshal(b)
+		default:
 			jsonData, err = json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal request: %w", err)
-			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
 		}
 		reqBody = bytes.NewReader(jsonData)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Add JWT token. readToken() may re-read from disk if a token file
-	// was configured — that's the file-rotation-without-restart path.
-	token, err := c.readToken()
-	if err != nil {
-		return nil, fmt.Errorf("authenticate: %w", err)
+	if err := c.setRequestHeaders(req); err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-	// Advertise the client version so the daemon can log it and, if it
-	// chooses, gate on a minimum-supported client. Both the conventional
-	// User-Agent and the explicit header are set; a server reads whichever
-	// it prefers.
-	req.Header.Set("User-Agent", version.UserAgent())
-	req.Header.Set(version.ClientVersionHeader, version.GetVersion())
 
+	started := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		if c.httpLog != nil {
+			c.httpLog.record(req, jsonData, nil, nil, started, err)
+		}
+		c.emit(logError, map[string]interface{}{"method": method, "path": path, "error": err.Error()})
+		return nil, &transportError{err: err}
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
+	if c.httpLog != nil {
+		c.httpLog.record(req, jsonData, resp, respBody, started, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		c.emit(logError, map[string]interface{}{"method": method, "path": path, "status": resp.StatusCode})
+		return nil, parseAPIError(resp.StatusCode, resp.Header, respBody)
 	}
 
 	return respBody, nil
 }
 
-// CreateContainer creates a new container
-func (c *Client) CreateContainer(req CreateContainerRequest) (*CreateContainerResponse, error) {
-	respBody, err := c.doRequest("POST", "/v1/containers", req)
+// setRequestHeaders adds the credential and the client identification
+// headers every daemon request carries.
+func (c *Client) setRequestHeaders(req *http.Request) error {
+	// Add the API key, or the JWT. readToken() may re-read from disk if
+	// a token file was configured — that's the file-rotation-without-
+	// restart path.
+	if c.apiKey != "" {
+		req.Header.Set(c.apiKeyHeader, c.apiKey)
+	} else {
+		token, err := c.readToken()
+		if err != nil {
+			return fmt.Errorf("authenticate: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Advertise the client version so the daemon can log it and, if it
+	// chooses, gate on a minimum-supported client. Both the conventional
+	// User-Agent and the explicit header are set; a server reads whichever
+	// it prefers.
+	req.Header.Set("User-Agent", version.UserAgent())
+	req.Header.Set(version.ClientVersionHeader, version.GetVersion())
+	return nil
+}
+
+// createAttempts and createRetryBackoff bound CreateContainer's retries.
+// Backoff grows linearly per attempt. Vars so tests can shrink them.
+var (
+	createAttempts     = 3
+	createRetryBackoff = 2 * time.Second
+)
+
+// retryableCreateError reports whether a failed create is worth retrying
+// under the same Idempotency-Key: no response at all, or a gateway-level
+// failure in front of the daemon.
+func retryableCreateError(err error) bool {
+	return daemonUnreachable(err)
+}
+
+// idempotencyKeyHeader is the header the daemon dedupes creates by. A
+// create repeated with the same key within the daemon's
+// --idempotency-key-ttl replays the first one's result.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey returns a random key identifying one logical request.
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// CreateContainer creates a new container. Creates are slow enough that a
+// client timeout is routine, so the call carries an Idempotency-Key and
+// retries transient failures with the same key: the daemon replays the
+// original result rather than provisioning the username twice. Pass
+// idempotencyKey to make the caller's own retries of the same create safe
+// too; "" generates one for this call.
+func (c *Client) CreateContainer(req *CreateContainerRequest, idempotencyKey string) (*CreateContainerResponse, error) {
+	if idempotencyKey == "" {
+		idempotencyKey = newIdempotencyKey()
+	}
+	headers := map[string]string{idempotencyKeyHeader: idempotencyKey}
+
+	var respBody []byte
+	var err error
+	for attempt := 1; ; attempt++ {
+		respBody, err = c.doRequestWithHeaders("POST", "/v1/containers", req, headers)
+		if err == nil || attempt >= createAttempts || !retryableCreateError(err) {
+			break
+		}
+		log.Printf("[mcp-client] create for %s failed (attempt %d/%d), retrying with the same idempotency key: %v", req.Username, attempt, createAttempts, err)
+		c.emit(logWarning, map[string]interface{}{
+			"event":    "retry",
+			"call":     "create_container",
+			"attempt":  attempt,
+			"attempts": createAttempts,
+			"error":    err.Error(),
+		})
+		time.Sleep(time.Duration(attempt) * createRetryBackoff)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	var resp CreateContainerResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &CreateContainerResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
+}
+
+// ListContainerTemplates lists the presets create_container's template
+// argument accepts.
+func (c *Client) ListContainerTemplates() (*ListContainerTemplatesResponse, error) {
+	respBody, err := c.doRequest("GET", "/v1/container-templates", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListContainerTemplatesResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
 }
 
 // ListContainers lists all containers
@@ -203,12 +386,12 @@ func (c *Client) ListContainers() (*ListContainersResponse, error) {
 		return nil, err
 	}
 
-	var resp ListContainersResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &ListContainersResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
 // GetContainer gets a specific container
@@ -218,12 +401,12 @@ func (c *Client) GetContainer(username string) (*GetContainerResponse, error) {
 		return nil, err
 	}
 
-	var resp GetContainerResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &GetContainerResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
 // RecipeSummary is the subset of a recipe the MCP surface displays.
@@ -542,6 +725,394 @@ func (c *Client) RestoreBackup(req RestoreBackupRequest) (*RestoreBackupResponse
 	return &resp, nil
 }
 
+// --- container snapshots (incus instance snapshots) ---
+
+// SnapshotContainer takes a point-in-time snapshot of a user's container.
+func (c *Client) SnapshotContainer(username, snapshotName string) (*SnapshotContainerResponse, error) {
+	body := map[string]string{"snapshot_name": snapshotName}
+	respBody, err := c.doRequest("POST", fmt.Sprintf("/v1/containers/%s/snapshots", username), body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SnapshotContainerResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListSnapshots lists a container's snapshots, oldest first.
+func (c *Client) ListSnapshots(username string) (*ListSnapshotsResponse, error) {
+	respBody, err := c.doRequest("GET", fmt.Sprintf("/v1/containers/%s/snapshots", username), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ListSnapshotsResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RestoreContainer rolls a container back to a named snapshot.
+func (c *Client) RestoreContainer(username, snapshotName string) (*RestoreContainerResponse, error) {
+	path := fmt.Sprintf("/v1/containers/%s/snapshots/%s/restore", username, url.PathEscape(snapshotName))
+	respBody, err := c.doRequest("POST", path, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	resp := &RestoreContainerResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetContainerActivity fetches the activity summary for a user's container
+// over the last windowSeconds (0 = the daemon's default of 7 days).
+func (c *Client) GetContainerActivity(username string, windowSeconds int64) (*ContainerActivityResponse, error) {
+	path := fmt.Sprintf("/v1/containers/%s/activity", username)
+	if windowSeconds > 0 {
+		path += fmt.Sprintf("?window_seconds=%d", windowSeconds)
+	}
+	respBody, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ContainerActivityResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetContainerProcesses lists the busiest processes in a user's container
+// (limit 0 = the daemon's default).
+func (c *Client) GetContainerProcesses(username string, limit int) (*GetContainerProcessesResponse, error) {
+	path := fmt.Sprintf("/v1/containers/%s/processes", username)
+	if limit > 0 {
+		path += fmt.Sprintf("?limit=%d", limit)
+	}
+	respBody, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &GetContainerProcessesResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReadContainerFile reads a file in a user's container through the
+// daemon's Incus file API endpoint (maxBytes 0 = the daemon's default).
+func (c *Client) ReadContainerFile(username, path string, maxBytes int64) (*ReadContainerFileResponse, error) {
+	q := url.Values{"path": {path}}
+	if maxBytes > 0 {
+		q.Set("max_bytes", strconv.FormatInt(maxBytes, 10))
+	}
+	respBody, err := c.doRequest("GET", fmt.Sprintf("/v1/containers/%s/files?%s", url.PathEscape(username), q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ReadContainerFileResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// WriteContainerFile writes a file in a user's container, replacing any
+// existing one. The daemon audits every write.
+func (c *Client) WriteContainerFile(req *WriteContainerFileRequest) (*WriteContainerFileResponse, error) {
+	respBody, err := c.doRequest("PUT", fmt.Sprintf("/v1/containers/%s/files", url.PathEscape(req.GetUsername())), req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &WriteContainerFileResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListContainerDir lists a directory in a user's container.
+func (c *Client) ListContainerDir(username, path string) (*ListContainerDirResponse, error) {
+	q := url.Values{"path": {path}}
+	respBody, err := c.doRequest("GET", fmt.Sprintf("/v1/containers/%s/dirs?%s", url.PathEscape(username), q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ListContainerDirResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DiffContainers compares two users' containers; the daemon collects both
+// sides and returns only what differs.
+func (c *Client) DiffContainers(usernameA, usernameB string, includePackages bool) (*DiffContainersResponse, error) {
+	path := fmt.Sprintf("/v1/containers/%s/diff/%s", usernameA, usernameB)
+	if includePackages {
+		path += "?include_packages=true"
+	}
+	respBody, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &DiffContainersResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TestConnectivity probes a target from inside a user's container; the
+// daemon checks it against the egress policy before dialing.
+func (c *Client) TestConnectivity(req *TestConnectivityRequest) (*TestConnectivityResponse, error) {
+	respBody, err := c.doRequest("POST", fmt.Sprintf("/v1/containers/%s/connectivity-test", url.PathEscape(req.GetUsername())), req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &TestConnectivityResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetListeningPorts lists the ports a user's container listens on, as of
+// the daemon's last scan. With historySeconds > 0 the response also
+// carries the listeners opened and closed over that window. The traffic
+// endpoint is keyed by container name, so the user's container is looked
+// up first.
+func (c *Client) GetListeningPorts(username string, historySeconds int64) (*GetListeningPortsResponse, error) {
+	got, err := c.GetContainer(username)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/v1/containers/%s/traffic/listeners", url.PathEscape(got.GetContainer().GetName()))
+	if historySeconds > 0 {
+		since := time.Now().Add(-time.Duration(historySeconds) * time.Second).UTC()
+		path += "?history_since=" + url.QueryEscape(since.Format(time.RFC3339))
+	}
+	respBody, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &GetListeningPortsResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ContainerNetwork is where a container is reached and what the host
+// forwards to it, assembled from the container, the proxy routes and the
+// passthrough routes.
+type ContainerNetwork struct {
+	Container *Container
+	// Routes are the HTTPS proxy routes whose upstream is the container.
+	Routes []*ProxyRoute
+	// Passthrough are the host's TCP/UDP port forwards to the container.
+	Passthrough []*PassthroughRoute
+	// RoutesError and PassthroughError say why a list could not be read
+	// (listing passthrough routes is admin-only); the rest of the view is
+	// still valid.
+	RoutesError      string
+	PassthroughError string
+}
+
+// GetContainerNetwork returns a user's container addressing and the routes
+// and port forwards that reach it. Only failing to read the container is
+// an error.
+func (c *Client) GetContainerNetwork(username string) (*ContainerNetwork, error) {
+	got, err := c.GetContainer(username)
+	if err != nil {
+		return nil, err
+	}
+	ctr := got.GetContainer()
+	out := &ContainerNetwork{Container: ctr}
+	ip := ctr.GetNetwork().GetIpAddress()
+
+	if routes, err := c.ListRoutes(username, false); err != nil {
+		out.RoutesError = err.Error()
+	} else {
+		for _, r := range routes.GetRoutes() {
+			if r.GetContainerIp() == ip && ip != "" || r.GetContainerName() == ctr.GetName() {
+				out.Routes = append(out.Routes, r)
+			}
+		}
+	}
+
+	passthrough, err := c.ListPassthroughRoutes()
+	if err != nil {
+		out.PassthroughError = err.Error()
+	}
+	for _, r := range passthrough.GetRoutes() {
+		if r.GetTargetIp() == ip && ip != "" || r.GetContainerName() == ctr.GetName() {
+			out.Passthrough = append(out.Passthrough, r)
+		}
+	}
+	return out, nil
+}
+
+// ListPassthroughRoutes lists the host's TCP/UDP port forwards. Admin-only
+// on the daemon.
+func (c *Client) ListPassthroughRoutes() (*ListPassthroughRoutesResponse, error) {
+	respBody, err := c.doRequest("GET", "/v1/network/passthrough", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ListPassthroughRoutesResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TrafficHistoryQuery selects recorded connections. At least one of
+// Username, ContainerName, DestIP or SourceIP must be set; without
+// Username or ContainerName the daemon requires an admin token.
+type TrafficHistoryQuery struct {
+	Username      string
+	ContainerName string
+	DestIP        string
+	SourceIP      string
+	DestPort      int
+	// Since bounds the query to connections started this long ago.
+	Since time.Duration
+	Limit int
+}
+
+// QueryTrafficHistory reads closed connections from the daemon's traffic
+// store (GET /v1/traffic/history).
+func (c *Client) QueryTrafficHistory(q TrafficHistoryQuery) (*QueryTrafficHistoryResponse, error) {
+	v := url.Values{}
+	for _, kv := range [][2]string{
+		{"username", q.Username},
+		{"container_name", q.ContainerName},
+		{"dest_ip", q.DestIP},
+		{"source_ip", q.SourceIP},
+	} {
+		if kv[1] != "" {
+			v.Set(kv[0], kv[1])
+		}
+	}
+	if q.DestPort > 0 {
+		v.Set("dest_port", strconv.Itoa(q.DestPort))
+	}
+	if q.Since > 0 {
+		v.Set("start_time", time.Now().Add(-q.Since).UTC().Format(time.RFC3339))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	respBody, err := c.doRequest("GET", "/v1/traffic/history?"+v.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &QueryTrafficHistoryResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetContainerReadiness fetches a container's provisioning progress and
+// readiness checks.
+func (c *Client) GetContainerReadiness(username string) (*ContainerReadinessResponse, error) {
+	respBody, err := c.doRequest("GET", fmt.Sprintf("/v1/containers/%s/readiness", username), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ContainerReadinessResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PollEventsResponse is the /v1/events/poll response.
+type PollEventsResponse struct {
+	Events []PolledEvent `json:"events"`
+	// Cursor is passed to the next PollEvents.
+	Cursor string `json:"cursor"`
+	// Missed is set when events since the previous cursor were dropped
+	// (the daemon's journal overflowed or it restarted).
+	Missed bool `json:"missed,omitempty"`
+}
+
+// PolledEvent is one event in the SSE/WebSocket/poll JSON shape. Exactly
+// one of the payloads is set, matching ResourceType.
+type PolledEvent struct {
+	ID             string                `json:"id"`
+	Type           string                `json:"type"`
+	ResourceType   string                `json:"resourceType"`
+	ResourceID     string                `json:"resourceId"`
+	Timestamp      string                `json:"timestamp"`
+	ContainerEvent *PolledContainerEvent `json:"containerEvent,omitempty"`
+	AppEvent       *PolledAppEvent       `json:"appEvent,omitempty"`
+	RouteEvent     *PolledRouteEvent     `json:"routeEvent,omitempty"`
+}
+
+type PolledContainerEvent struct {
+	Container *struct {
+		Name      string `json:"name"`
+		Username  string `json:"username"`
+		State     string `json:"state"`
+		IPAddress string `json:"ipAddress"`
+	} `json:"container,omitempty"`
+	PreviousState string            `json:"previousState,omitempty"`
+	Type          string            `json:"type,omitempty"`
+	Actor         string            `json:"actor,omitempty"`
+	Details       map[string]string `json:"details,omitempty"`
+}
+
+type PolledAppEvent struct {
+	App *struct {
+		Name       string `json:"name"`
+		Username   string `json:"username"`
+		FullDomain string `json:"fullDomain"`
+		State      string `json:"state"`
+	} `json:"app,omitempty"`
+	PreviousState string `json:"previousState,omitempty"`
+}
+
+type PolledRouteEvent struct {
+	Route *struct {
+		FullDomain  string `json:"fullDomain"`
+		ContainerIP string `json:"containerIp"`
+		Port        int32  `json:"port"`
+		Active      bool   `json:"active"`
+	} `json:"route,omitempty"`
+}
+
+// PollEvents long-polls for container, app and route events after cursor
+// ("" = from now), blocking up to timeout when there are none. It returns
+// early with ctx's error if ctx is cancelled. resourceTypes ("container",
+// "app", "route") narrows the result; empty means all.
+func (c *Client) PollEvents(ctx context.Context, cursor string, timeout time.Duration, resourceTypes []string) (*PollEventsResponse, error) {
+	q := url.Values{}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+	q.Set("timeoutSeconds", strconv.Itoa(int(timeout/time.Second)))
+	for _, rt := range resourceTypes {
+		q.Add("resourceTypes", rt)
+	}
+	respBody, err := c.doRequestContext(ctx, "GET", "/v1/events/poll?"+q.Encode(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp PollEventsResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &resp, nil
+}
+
 // --- KMS admin (KmsService) ---
 
 // KMSStatusResponse is the /v1/kms/status response.
@@ -675,11 +1246,11 @@ func (c *Client) ResizeContainer(username, cpu, memory, disk string) (*ResizeCon
 	if err != nil {
 		return nil, err
 	}
-	var resp ResizeContainerResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &ResizeContainerResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
-	return &resp, nil
+	return resp, nil
 }
 
 // SetSecret creates or updates a tenant secret. Idempotent —
@@ -782,6 +1353,49 @@ func (c *Client) RefreshSecrets(username string) (*RefreshSecretsResponse, error
 	return &resp, nil
 }
 
+// SetContainerSecret stores a tenant secret and stamps it into the
+// tenant's container. scope is "user-env" (or empty) or an absolute
+// file path.
+func (c *Client) SetContainerSecret(req *SetContainerSecretRequest) (*SetContainerSecretResponse, error) {
+	respBody, err := c.doRequest("POST", fmt.Sprintf("/v1/containers/%s/secrets", url.PathEscape(req.GetUsername())), req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SetContainerSecretResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListContainerSecrets lists a tenant's secrets and where each is
+// materialized inside the container. Never returns values.
+func (c *Client) ListContainerSecrets(username string) (*ListContainerSecretsResponse, error) {
+	respBody, err := c.doRequest("GET", fmt.Sprintf("/v1/containers/%s/secrets", url.PathEscape(username)), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ListContainerSecretsResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RemoveContainerSecret deletes a tenant secret and removes it from the
+// container.
+func (c *Client) RemoveContainerSecret(username, name string) (*RemoveContainerSecretResponse, error) {
+	respBody, err := c.doRequest("DELETE", fmt.Sprintf("/v1/containers/%s/secrets/%s", url.PathEscape(username), url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &RemoveContainerSecretResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteContainer deletes a container
 func (c *Client) DeleteContainer(username string, force bool) (*DeleteContainerResponse, error) {
 	path := fmt.Sprintf("/v1/containers/%s?force=%v", username, force)
@@ -790,12 +1404,12 @@ func (c *Client) DeleteContainer(username string, force bool) (*DeleteContainerR
 		return nil, err
 	}
 
-	var resp DeleteContainerResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &DeleteContainerResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
 // StartContainer starts a stopped container. When waitForReady is
@@ -809,12 +1423,12 @@ func (c *Client) StartContainer(username string, waitForReady bool) (*StartConta
 		return nil, err
 	}
 
-	var resp StartContainerResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &StartContainerResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
 // ToggleAutoSleep writes the per-container auto-sleep opt-in flag.
@@ -846,32 +1460,43 @@ func (c *Client) StopContainer(username string, force bool) (*StopContainerRespo
 		return nil, err
 	}
 
-	var resp StopContainerResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &StopContainerResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
-// GetMetrics gets container metrics
-func (c *Client) GetMetrics(username string) (*GetMetricsResponse, error) {
+// GetMetrics gets container metrics. sortBy ("cpu" or "memory") and limit
+// are optional; the daemon ranks and trims the list before returning it.
+func (c *Client) GetMetrics(username, sortBy string, limit int) (*GetMetricsResponse, error) {
 	path := "/v1/metrics"
 	if username != "" {
 		path = "/v1/metrics/" + username
 	}
+	q := url.Values{}
+	if sortBy != "" {
+		q.Set("sort_by", sortBy)
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
 
 	respBody, err := c.doRequest("GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var resp GetMetricsResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &GetMetricsResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
 // GetSystemInfo gets system information
@@ -881,20 +1506,19 @@ func (c *Client) GetSystemInfo() (*GetSystemInfoResponse, error) {
 		return nil, err
 	}
 
-	var resp GetSystemInfoResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &GetSystemInfoResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
 // metricsExportProviderWireName maps the CLI-facing lowercase provider
 // name ("gcp") to the full CloudMetricsProvider enum name the wire
 // protocol (protojson) expects. Mirrors internal/cmd/monitoring_export.go's
-// parseMetricsExportProvider without importing pkg/pb — this package
-// deliberately keeps its own plain-struct wire types rather than the
-// generated proto types (see the ListBackendsResponse comment above).
+// parseMetricsExportProvider; the metrics-export calls still send
+// plain-struct bodies, so the enum is spelled out by name here.
 func metricsExportProviderWireName(provider string) (string, error) {
 	switch strings.ToLower(strings.TrimSpace(provider)) {
 	case "gcp":
@@ -1107,18 +1731,18 @@ func (c *Client) GetUpgradeStatus(upgradeID string) (*UpgradeStatusResponse, err
 // AddRoute creates a domain → container:port mapping in the sentinel
 // reverse proxy. Used by the expose_port tool to make a container
 // reachable on the public internet under a chosen hostname.
-func (c *Client) AddRoute(req AddRouteRequest) (*AddRouteResponse, error) {
+func (c *Client) AddRoute(req *AddRouteRequest) (*AddRouteResponse, error) {
 	respBody, err := c.doRequest("POST", "/v1/network/routes", req)
 	if err != nil {
 		return nil, err
 	}
 
-	var resp AddRouteResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &AddRouteResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
 // DeleteRoute removes a proxy route by its domain — the unexpose counterpart
@@ -1153,11 +1777,11 @@ func (c *Client) ListRoutes(username string, activeOnly bool) (*ListRoutesRespon
 	if err != nil {
 		return nil, err
 	}
-	var resp ListRoutesResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &ListRoutesResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
-	return &resp, nil
+	return resp, nil
 }
 
 // ListBackends returns the cluster topology — the local daemon plus any
@@ -1170,12 +1794,12 @@ func (c *Client) ListBackends() (*ListBackendsResponse, error) {
 		return nil, err
 	}
 
-	var resp ListBackendsResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	resp := &ListBackendsResponse{}
+	if err := unmarshalProto(respBody, resp); err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
 // GetBackend returns a single backend by ID. The daemon doesn't have a
@@ -1189,9 +1813,9 @@ func (c *Client) GetBackend(id string) (*Backend, error) {
 	if err != nil {
 		return nil, err
 	}
-	for i := range resp.Backends {
-		if resp.Backends[i].ID == id {
-			return &resp.Backends[i], nil
+	for _, b := range resp.Backends {
+		if b.Id == id {
+			return b, nil
 		}
 	}
 	return nil, fmt.Errorf("backend %q not found", id)
@@ -1478,62 +2102,99 @@ func (c *Client) InstallZap() (*InstallZapResponse, error) {
 }
 
 // API Request/Response types
+//
+// The container, route and backend shapes below are the generated proto
+// messages rather than hand-written mirrors, decoded with protojson exactly
+// as grpc-gateway encodes them: a field renamed or retyped in proto/ breaks
+// this package's build instead of silently decoding as a zero value (the
+// way ssh_keys once did). `make proto` regenerates them together with the
+// OpenAPI spec (api/swagger/containarium.swagger.json) that documents the
+// same REST shapes. Endpoints served by hand-coded handlers keep their own
+// structs further down.
+type (
+	CreateContainerRequest  = pb.CreateContainerRequest
+	CreateContainerResponse = pb.CreateContainerResponse
+	ListContainersResponse  = pb.ListContainersResponse
+	GetContainerResponse    = pb.GetContainerResponse
+	DeleteContainerResponse = pb.DeleteContainerResponse
+	StartContainerResponse  = pb.StartContainerResponse
+	StopContainerResponse   = pb.StopContainerResponse
+	ResizeContainerResponse = pb.ResizeContainerResponse
+	GetMetricsResponse      = pb.GetMetricsResponse
+	GetSystemInfoResponse   = pb.GetSystemInfoResponse
+
+	GetContainerProcessesResponse = pb.GetContainerProcessesResponse
+	ContainerProcess              = pb.ContainerProcess
+
+	DiffContainersResponse = pb.DiffContainersResponse
+	ContainerDifference    = pb.ContainerDifference
+
+	ReadContainerFileResponse  = pb.ReadContainerFileResponse
+	WriteContainerFileRequest  = pb.WriteContainerFileRequest
+	WriteContainerFileResponse = pb.WriteContainerFileResponse
+	ListContainerDirResponse   = pb.ListContainerDirResponse
+
+	TestConnectivityRequest  = pb.TestConnectivityRequest
+	TestConnectivityResponse = pb.TestConnectivityResponse
+
+	GetListeningPortsResponse = pb.GetListeningPortsResponse
+	ListeningPort             = pb.ListeningPort
+	ListenerChange            = pb.ListenerChange
+
+	QueryTrafficHistoryResponse = pb.QueryTrafficHistoryResponse
+	HistoricalConnection        = pb.HistoricalConnection
+
+	ListContainerTemplatesResponse = pb.ListContainerTemplatesResponse
+	ContainerTemplate              = pb.ContainerTemplate
+
+	ContainerSnapshot         = pb.ContainerSnapshot
+	SnapshotContainerResponse = pb.CreateSnapshotResponse
+	ListSnapshotsResponse     = pb.ListSnapshotsResponse
+	RestoreContainerResponse  = pb.RestoreSnapshotResponse
+
+	ContainerActivityResponse = pb.GetContainerActivityResponse
+	ContainerActivityEvent    = pb.ContainerActivityEvent
+	ContainerActivityChange   = pb.ContainerActivityChange
+	SSHSession                = pb.SSHSession
+
+	ContainerSecret               = pb.ContainerSecret
+	SetContainerSecretRequest     = pb.SetContainerSecretRequest
+	SetContainerSecretResponse    = pb.SetContainerSecretResponse
+	ListContainerSecretsResponse  = pb.ListContainerSecretsResponse
+	RemoveContainerSecretResponse = pb.RemoveContainerSecretResponse
+
+	ContainerReadinessResponse = pb.GetContainerReadinessResponse
+	ProvisionStep              = pb.ProvisionStep
+	ReadinessCheck             = pb.ReadinessCheck
+
+	Container        = pb.Container
+	ResourceLimits   = pb.ResourceLimits
+	NetworkInfo      = pb.NetworkInfo
+	ContainerMetrics = pb.ContainerMetrics
+	SystemInfo       = pb.SystemInfo
+
+	AddRouteRequest  = pb.AddRouteRequest
+	AddRouteResponse = pb.AddRouteResponse
+	// ListRoutesResponse is GET /v1/network/routes (NetworkService.GetRoutes).
+	ListRoutesResponse = pb.GetRoutesResponse
+	ProxyRoute         = pb.ProxyRoute
+
+	ListPassthroughRoutesResponse = pb.ListPassthroughRoutesResponse
+	PassthroughRoute              = pb.PassthroughRoute
+
+	ListBackendsResponse = pb.ListBackendsResponse
+	Backend              = pb.BackendInfo
+	BackendGPU           = pb.BackendGPU
+)
 
-type CreateContainerRequest struct {
-	Username     string            `json:"username"`
-	Resources    *ResourceLimits   `json:"resources,omitempty"`
-	SSHKeys      []string          `json:"sshKeys,omitempty"`
-	Labels       map[string]string `json:"labels,omitempty"`
-	Image        string            `json:"image,omitempty"`
-	EnablePodman bool              `json:"enablePodman,omitempty"`
-
-	// GPU is the legacy single-GPU field. Prefer GPUs. When GPUs is set it
-	// supersedes this on the daemon side.
-	GPU string `json:"gpu,omitempty"`
-
-	// GPUs lists the GPU devices to attach (index "0"/"1" or PCI address per
-	// entry) for multi-GPU passthrough. Empty = no GPU.
-	GPUs []string `json:"gpus,omitempty"`
-
-	// Monitoring opts the container into application-emitted
-	// OpenTelemetry. When true, the daemon stamps the LXC with
-	// OTEL_EXPORTER_OTLP_ENDPOINT etc., pointing at the core
-	// collector. Default false (opt-in). See
-	// docs/OTEL-COLLECTOR-DESIGN.md for the full design.
-	Monitoring bool `json:"monitoring,omitempty"`
-
-	// Pool selects placement by pool tag. When set with an empty
-	// BackendID, the daemon picks any healthy backend in the pool.
-	// When set with BackendID, the daemon validates that BackendID
-	// belongs to this pool.
-	Pool string `json:"pool,omitempty"`
-
-	// BackendID pins the container to a specific peer. Use Pool
-	// when any backend in a group will do; use BackendID for an
-	// exact placement.
-	BackendID string `json:"backendId,omitempty"`
-}
-
-type ResourceLimits struct {
-	CPU    string `json:"cpu,omitempty"`
-	Memory string `json:"memory,omitempty"`
-	Disk   string `json:"disk,omitempty"`
-}
-
-type CreateContainerResponse struct {
-	Container  Container `json:"container"`
-	Message    string    `json:"message"`
-	SSHCommand string    `json:"sshCommand,omitempty"`
-}
-
-type ListContainersResponse struct {
-	Containers []Container `json:"containers"`
-	TotalCount int         `json:"totalCount"`
-}
-
-type GetContainerResponse struct {
-	Container Container         `json:"container"`
-	Metrics   *ContainerMetrics `json:"metrics,omitempty"`
+// unmarshalProto decodes a grpc-gateway response into its generated
+// message. Unknown fields are skipped so a newer daemon that grew a field
+// still decodes.
+func unmarshalProto(body []byte, m proto.Message) error {
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, m); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
 }
 
 type DebugContainerResponse struct {
@@ -1552,21 +2213,11 @@ type DebugContainerResponse struct {
 	SSHIngressHost string `json:"sshIngressHost,omitempty"`
 }
 
-type DeleteContainerResponse struct {
-	Message       string `json:"message"`
-	ContainerName string `json:"containerName"`
-}
-
 type ToggleMonitoringResponse struct {
 	Message           string `json:"message"`
 	MonitoringEnabled bool   `json:"monitoring_enabled"`
 }
 
-type ResizeContainerResponse struct {
-	Message   string    `json:"message"`
-	Container Container `json:"container"`
-}
-
 type SecretResponse struct {
 	Message string                 `json:"message"`
 	Secret  map[string]interface{} `json:"secret"`
@@ -1577,31 +2228,12 @@ type RefreshSecretsResponse struct {
 	Stamped int32  `json:"stamped"`
 }
 
-type StartContainerResponse struct {
-	Message       string    `json:"message"`
-	Container     Container `json:"container"`
-	ReadyTimedOut bool      `json:"readyTimedOut"`
-}
-
 type ToggleAutoSleepResponse struct {
 	Message              string `json:"message"`
 	AutoSleepEnabled     bool   `json:"autoSleepEnabled"`
 	IdleThresholdMinutes int32  `json:"idleThresholdMinutes"`
 }
 
-type StopContainerResponse struct {
-	Message   string    `json:"message"`
-	Container Container `json:"container"`
-}
-
-type GetMetricsResponse struct {
-	Metrics []ContainerMetrics `json:"metrics"`
-}
-
-type GetSystemInfoResponse struct {
-	Info SystemInfo `json:"info"`
-}
-
 // SetMetricsExportResponse mirrors the wire response of
 // ContainerService.SetMetricsExport (#1069).
 type SetMetricsExportResponse struct {
@@ -1626,40 +2258,6 @@ type GetMetricsExportResponse struct {
 	ExportFailures  flexInt64 `json:"exportFailures,omitempty"`
 }
 
-// ListBackendsResponse mirrors the /v1/backends wire shape — the proto-first
-// ContainerService.ListBackends RPC (BackendInfo), which replaced the former
-// hand-coded handler (#354). The MCP client keeps its own struct rather than
-// importing the generated type.
-//
-// 64-bit int fields use flexInt64: grpc-gateway's protojson serializes int64
-// as a QUOTED STRING ("12345"), not a number. The original plain-int64 fields
-// here therefore failed to decode every response from a proto-first daemon
-// ("cannot unmarshal string into int64"), silently breaking list_backends.
-// flexInt64 accepts both the string and number forms, so it works across
-// mixed daemon versions.
-type ListBackendsResponse struct {
-	Backends []Backend `json:"backends"`
-}
-
-type Backend struct {
-	ID             string       `json:"id"`
-	Type           string       `json:"type"` // "local" or "tunnel"
-	Healthy        bool         `json:"healthy"`
-	Version        string       `json:"version,omitempty"`
-	Hostname       string       `json:"hostname,omitempty"`
-	UptimeSeconds  flexInt64    `json:"uptimeSeconds,omitempty"`
-	LastSeenAt     string       `json:"lastSeenAt,omitempty"`
-	OS             string       `json:"os,omitempty"`
-	ContainerCount int32        `json:"containerCount"`
-	GPUs           []BackendGPU `json:"gpus,omitempty"`
-}
-
-type BackendGPU struct {
-	Vendor    string    `json:"vendor,omitempty"`
-	ModelName string    `json:"modelName,omitempty"`
-	VRAMBytes flexInt64 `json:"vramBytes,omitempty"`
-}
-
 // flexInt64 decodes an int64 that may arrive as a JSON number OR, as
 // grpc-gateway's protojson encodes 64-bit ints, a quoted string. encoding/json
 // would reject the string form into a plain int64; this accepts both.
@@ -1678,115 +2276,3 @@ func (n *flexInt64) UnmarshalJSON(b []byte) error {
 	*n = flexInt64(v)
 	return nil
 }
-
-// AddRouteRequest mirrors network.proto's AddRouteRequest. JSON field
-// names match the grpc-gateway HTTP shape (camelCase) so requests
-// serialized with encoding/json land at /v1/network/routes correctly.
-type AddRouteRequest struct {
-	Domain        string `json:"domain"`
-	TargetIP      string `json:"targetIp"`
-	TargetPort    int32  `json:"targetPort"`
-	ContainerName string `json:"containerName,omitempty"`
-	Description   string `json:"description,omitempty"`
-	// Protocol is "ROUTE_PROTOCOL_HTTP" by default on the server side;
-	// expose_port doesn't surface it because the demo path is HTTP.
-}
-
-type AddRouteResponse struct {
-	Route   ProxyRoute `json:"route"`
-	Message string     `json:"message,omitempty"`
-}
-
-type ProxyRoute struct {
-	// Domain (the one expose_port sets) and the proto's richer fields
-	// (subdomain, fullDomain, active, appId, appName) — accept both shapes
-	// because AddRoute echoes one set and GetRoutes returns the other.
-	Domain        string `json:"domain,omitempty"`
-	Subdomain     string `json:"subdomain,omitempty"`
-	FullDomain    string `json:"fullDomain,omitempty"`
-	ContainerIP   string `json:"containerIp"`
-	Port          int32  `json:"port"`
-	Active        bool   `json:"active,omitempty"`
-	ContainerName string `json:"containerName,omitempty"`
-	Description   string `json:"description,omitempty"`
-	AppID         string `json:"appId,omitempty"`
-	AppName       string `json:"appName,omitempty"`
-}
-
-// ListRoutesResponse mirrors the daemon's GetRoutesResponse.
-type ListRoutesResponse struct {
-	Routes     []ProxyRoute `json:"routes"`
-	TotalCount int          `json:"totalCount"`
-}
-
-type Container struct {
-	Name      string          `json:"name"`
-	Username  string          `json:"username"`
-	State     string          `json:"state"`
-	Resources *ResourceLimits `json:"resources,omitempty"`
-	SSHKeys   []string        `json:"sshKeys,omitempty"`
-	Network   *NetworkInfo    `json:"network,omitempty"`
-	// CreatedAt / UpdatedAt come back as JSON strings from the platform's
-	// grpc-gateway HTTP layer — int64 protobuf scalars are emitted as
-	// strings to avoid JavaScript number-precision loss. The `,string`
-	// tag makes encoding/json round-trip them correctly on both sides.
-	CreatedAt     int64             `json:"createdAt,string"`
-	UpdatedAt     int64             `json:"updatedAt,string,omitempty"`
-	Labels        map[string]string `json:"labels,omitempty"`
-	Image         string            `json:"image,omitempty"`
-	PodmanEnabled bool              `json:"podmanEnabled"`
-	BackendID     string            `json:"backendId,omitempty"`
-	Pool          string            `json:"pool,omitempty"`
-	// SSHHost is the public SSH endpoint (the sentinel) clients dial to reach
-	// this container: `ssh <username>@<SSHHost>`. The daemon stamps it per
-	// container from its --ssh-host, so it is the source of truth for which
-	// sentinel a container belongs to. Empty in direct / no-sentinel
-	// deployments, where clients fall back to the container IP.
-	SSHHost string `json:"sshHost,omitempty"`
-}
-
-type NetworkInfo struct {
-	IPAddress  string `json:"ipAddress,omitempty"`
-	MacAddress string `json:"macAddress,omitempty"`
-	Interface  string `json:"interface,omitempty"`
-	Bridge     string `json:"bridge,omitempty"`
-}
-
-type ContainerMetrics struct {
-	Name string `json:"name"`
-	// All int64 fields below come back as JSON strings from grpc-gateway
-	// (see Container.CreatedAt for rationale). int32 fields like
-	// ProcessCount stay numeric.
-	CPUUsageSeconds  int64 `json:"cpuUsageSeconds,string"`
-	MemoryUsageBytes int64 `json:"memoryUsageBytes,string"`
-	MemoryPeakBytes  int64 `json:"memoryPeakBytes,string"`
-	DiskUsageBytes   int64 `json:"diskUsageBytes,string"`
-	NetworkRxBytes   int64 `json:"networkRxBytes,string"`
-	NetworkTxBytes   int64 `json:"networkTxBytes,string"`
-	ProcessCount     int32 `json:"processCount"`
-}
-
-type SystemInfo struct {
-	IncusVersion      string `json:"incusVersion"`
-	OS                string `json:"os"`
-	KernelVersion     string `json:"kernelVersion"`
-	ContainersRunning int    `json:"containersRunning"`
-	ContainersStopped int    `json:"containersStopped"`
-	ContainersTotal   int    `json:"containersTotal"`
-	Hostname          string `json:"hostname"`
-	// DaemonVersion is the Containarium daemon version on this backend
-	// (e.g. "0.21.0") — surfaced so fleet version drift is visible without
-	// SSHing each host. #354.
-	DaemonVersion string `json:"daemonVersion"`
-	// OTelCollectorEndpoint is where monitoring=true containers ship
-	// OTLP telemetry (e.g. "http://10.0.3.5:4318"). Empty when the
-	// daemon has no OTel collector. Surfaced so an agent can configure
-	// docker-in-LXC apps that don't inherit the env-stamped value. #370.
-	OTelCollectorEndpoint string `json:"otelCollectorEndpoint"`
-	// SSHIngressHost is the advertised public SSH entrypoint (sentinel host)
-	// clients dial to reach this backend's containers, or empty for
-	// direct/in-network mode (no external SSH entrypoint). Lets an agent tell
-	// whether an external SSH/deploy entrypoint exists and what host it is,
-	// instead of assuming a sentinel that may not exist. See #1011.
-	SSHIngressHost string `json:"sshIngressHost,omitempty"`
-}
@@ -11,8 +11,21 @@ import (
 	"github.com/footprintai/containarium/pkg/version"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
 
+// writeGatewayJSON encodes m the way the daemon's grpc-gateway does:
+// camelCase names, enums by name, int64 as strings, zero values included.
+func writeGatewayJSON(t *testing.T, w http.ResponseWriter, m proto.Message) {
+	t.Helper()
+	b, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+	require.NoError(t, err)
+	_, _ = w.Write(b)
+}
+
 // TestNewClient tests client creation
 func TestNewClient(t *testing.T) {
 	client := NewClient("http://localhost:8080", "test-token")
@@ -96,18 +109,16 @@ func TestClientListContainers(t *testing.T) {
 		assert.Equal(t, "GET", r.Method)
 		assert.Equal(t, "/v1/containers", r.URL.Path)
 
-		resp := ListContainersResponse{
-			Containers: []Container{
+		writeGatewayJSON(t, w, &ListContainersResponse{
+			Containers: []*Container{
 				{
 					Name:     "alice-container",
 					Username: "alice",
-					State:    "Running",
+					State:    pb.ContainerState_CONTAINER_STATE_RUNNING,
 				},
 			},
 			TotalCount: 1,
-		}
-
-		_ = json.NewEncoder(w).Encode(resp)
+		})
 	}))
 	defer server.Close()
 
@@ -117,9 +128,10 @@ func TestClientListContainers(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.NotNil(t, resp)
-	assert.Equal(t, 1, resp.TotalCount)
+	assert.Equal(t, int32(1), resp.TotalCount)
 	assert.Len(t, resp.Containers, 1)
 	assert.Equal(t, "alice-container", resp.Containers[0].Name)
+	assert.Equal(t, pb.ContainerState_CONTAINER_STATE_RUNNING, resp.Containers[0].State)
 }
 
 // TestClientListRoutes verifies the new list_routes tool's wire path:
@@ -131,12 +143,12 @@ func TestClientListRoutes(t *testing.T) {
 			assert.Equal(t, "GET", r.Method)
 			assert.Equal(t, "/v1/network/routes", r.URL.Path)
 			assert.Empty(t, r.URL.RawQuery, "no filters → no query string")
-			_ = json.NewEncoder(w).Encode(ListRoutesResponse{
-				Routes: []ProxyRoute{
+			writeGatewayJSON(t, w, &ListRoutesResponse{
+				Routes: []*ProxyRoute{
 					{
 						Subdomain:   "alice-web",
 						FullDomain:  "alice-web.example.com",
-						ContainerIP: "10.0.3.42",
+						ContainerIp: "10.0.3.42",
 						Port:        80,
 						Active:      true,
 						AppName:     "alice-container",
@@ -159,7 +171,7 @@ func TestClientListRoutes(t *testing.T) {
 		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			assert.Equal(t, "alice", r.URL.Query().Get("username"))
 			assert.Equal(t, "true", r.URL.Query().Get("activeOnly"))
-			_ = json.NewEncoder(w).Encode(ListRoutesResponse{Routes: nil, TotalCount: 0})
+			writeGatewayJSON(t, w, &ListRoutesResponse{})
 		}))
 		defer srv.Close()
 
@@ -175,15 +187,13 @@ func TestClientGetContainer(t *testing.T) {
 		assert.Equal(t, "GET", r.Method)
 		assert.Equal(t, "/v1/containers/alice", r.URL.Path)
 
-		resp := GetContainerResponse{
-			Container: Container{
+		writeGatewayJSON(t, w, &GetContainerResponse{
+			Container: &Container{
 				Name:     "alice-container",
 				Username: "alice",
-				State:    "Running",
+				State:    pb.ContainerState_CONTAINER_STATE_RUNNING,
 			},
-		}
-
-		_ = json.NewEncoder(w).Encode(resp)
+		})
 	}))
 	defer server.Close()
 
@@ -196,45 +206,118 @@ func TestClientGetContainer(t *testing.T) {
 	assert.Equal(t, "alice", resp.Container.Username)
 }
 
+// TestClientCreateContainer_RetriesWithSameIdempotencyKey: a create that
+// hits a gateway error is retried, and every attempt carries the one key
+// so the daemon can replay instead of provisioning twice.
+func TestClientCreateContainer_RetriesWithSameIdempotencyKey(t *testing.T) {
+	oldBackoff := createRetryBackoff
+	createRetryBackoff = 0
+	defer func() { createRetryBackoff = oldBackoff }()
+
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		writeGatewayJSON(t, w, &CreateContainerResponse{Container: &Container{Name: "alice-container"}})
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(server.URL, "test-token").CreateContainer(&CreateContainerRequest{Username: "alice"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "alice-container", resp.Container.Name)
+	require.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+
+	// A fresh logical create gets a fresh key; a 4xx is not retried.
+	keys = nil
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	_, err = NewClient(server.URL, "test-token").CreateContainer(&CreateContainerRequest{Username: "alice"}, "")
+	require.Error(t, err)
+	require.Len(t, keys, 1)
+}
+
+// TestCreateContainer_CallerIdempotencyKey: a caller-supplied key is sent
+// as-is, and create_container repeated under it sends the same request,
+// ephemeral SSH key included, so the daemon can replay the first create.
+func TestCreateContainer_CallerIdempotencyKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var keys, bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		bodies = append(bodies, string(body))
+		writeGatewayJSON(t, w, &CreateContainerResponse{Container: &Container{Name: "alice-container", Username: "alice"}})
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "test-token")
+
+	args := map[string]interface{}{"username": "alice", "idempotency_key": "create-alice-1"}
+	_, err := handleCreateContainer(client, args)
+	require.NoError(t, err)
+	_, err = handleCreateContainer(client, args)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, []string{"create-alice-1", "create-alice-1"}, keys)
+	assert.Equal(t, bodies[0], bodies[1], "a repeated create must send the same request")
+
+	// Without a key each call is its own create with its own keypair.
+	keys, bodies = nil, nil
+	delete(args, "idempotency_key")
+	_, err = handleCreateContainer(client, args)
+	require.NoError(t, err)
+	_, err = handleCreateContainer(client, args)
+	require.NoError(t, err)
+	assert.NotEqual(t, keys[0], keys[1])
+	assert.NotEqual(t, bodies[0], bodies[1])
+}
+
 // TestClientCreateContainer tests create container API call
 func TestClientCreateContainer(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "POST", r.Method)
 		assert.Equal(t, "/v1/containers", r.URL.Path)
 
-		// Decode request body
-		var req CreateContainerRequest
-		err := json.NewDecoder(r.Body).Decode(&req)
+		// The body must decode as the gateway would decode it.
+		body, err := io.ReadAll(r.Body)
 		require.NoError(t, err)
+		req := &CreateContainerRequest{}
+		require.NoError(t, protojson.Unmarshal(body, req))
 
 		assert.Equal(t, "alice", req.Username)
-		assert.Equal(t, "4", req.Resources.CPU)
+		assert.Equal(t, "4", req.Resources.Cpu)
 		assert.Equal(t, "8GB", req.Resources.Memory)
+		assert.Equal(t, []string{"ssh-ed25519 AAAA alice@example.com"}, req.SshKeys)
 
-		resp := CreateContainerResponse{
-			Container: Container{
+		writeGatewayJSON(t, w, &CreateContainerResponse{
+			Container: &Container{
 				Name:     "alice-container",
 				Username: "alice",
-				State:    "Running",
+				State:    pb.ContainerState_CONTAINER_STATE_RUNNING,
 			},
 			Message: "Container created",
-		}
-
-		_ = json.NewEncoder(w).Encode(resp)
+		})
 	}))
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	req := CreateContainerRequest{
+	req := &CreateContainerRequest{
 		Username: "alice",
 		Resources: &ResourceLimits{
-			CPU:    "4",
+			Cpu:    "4",
 			Memory: "8GB",
 			Disk:   "50GB",
 		},
+		SshKeys: []string{"ssh-ed25519 AAAA alice@example.com"},
 	}
 
-	resp, err := client.CreateContainer(req)
+	resp, err := client.CreateContainer(req, "")
 
 	require.NoError(t, err)
 	assert.NotNil(t, resp)
@@ -266,12 +349,10 @@ func TestClientDeleteContainer(t *testing.T) {
 					assert.Equal(t, "false", force)
 				}
 
-				resp := DeleteContainerResponse{
+				writeGatewayJSON(t, w, &DeleteContainerResponse{
 					Message:       "Container deleted",
 					ContainerName: "alice-container",
-				}
-
-				_ = json.NewEncoder(w).Encode(resp)
+				})
 			}))
 			defer server.Close()
 
@@ -285,15 +366,34 @@ func TestClientDeleteContainer(t *testing.T) {
 	}
 }
 
+// TestClientRemoveContainerSecret_EscapesName — the secret name is one
+// path segment, whatever it holds.
+func TestClientRemoveContainerSecret_EscapesName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/v1/containers/alice/secrets/A%2FB", r.URL.EscapedPath())
+		writeGatewayJSON(t, w, &RemoveContainerSecretResponse{Message: "removed A/B"})
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(server.URL, "test-token").RemoveContainerSecret("alice", "A/B")
+	require.NoError(t, err)
+	assert.Equal(t, "removed A/B", resp.GetMessage())
+}
+
 // TestClientGetMetrics tests get metrics API call
 func TestClientGetMetrics(t *testing.T) {
 	tests := []struct {
 		name     string
 		username string
+		sortBy   string
+		limit    int
 		path     string
+		query    string
 	}{
-		{"all containers", "", "/v1/metrics"},
-		{"specific container", "alice", "/v1/metrics/alice"},
+		{"all containers", "", "", 0, "/v1/metrics", ""},
+		{"specific container", "alice", "", 0, "/v1/metrics/alice", ""},
+		{"top by memory", "", "memory", 10, "/v1/metrics", "limit=10&sort_by=memory"},
 	}
 
 	for _, tt := range tests {
@@ -301,23 +401,22 @@ func TestClientGetMetrics(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, "GET", r.Method)
 				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, tt.query, r.URL.RawQuery)
 
-				resp := GetMetricsResponse{
-					Metrics: []ContainerMetrics{
+				writeGatewayJSON(t, w, &GetMetricsResponse{
+					Metrics: []*ContainerMetrics{
 						{
 							Name:             "alice-container",
-							CPUUsageSeconds:  100,
+							CpuUsageSeconds:  100,
 							MemoryUsageBytes: 1024 * 1024 * 100,
 						},
 					},
-				}
-
-				_ = json.NewEncoder(w).Encode(resp)
+				})
 			}))
 			defer server.Close()
 
 			client := NewClient(server.URL, "test-token")
-			resp, err := client.GetMetrics(tt.username)
+			resp, err := client.GetMetrics(tt.username, tt.sortBy, tt.limit)
 
 			require.NoError(t, err)
 			assert.NotNil(t, resp)
@@ -333,18 +432,16 @@ func TestClientGetSystemInfo(t *testing.T) {
 		assert.Equal(t, "GET", r.Method)
 		assert.Equal(t, "/v1/system/info", r.URL.Path)
 
-		resp := GetSystemInfoResponse{
-			Info: SystemInfo{
+		writeGatewayJSON(t, w, &GetSystemInfoResponse{
+			Info: &SystemInfo{
 				IncusVersion:          "0.6.0",
-				OS:                    "Ubuntu 24.04",
+				Os:                    "Ubuntu 24.04",
 				ContainersRunning:     5,
 				ContainersTotal:       10,
-				OTelCollectorEndpoint: "http://10.0.3.5:4318",
+				OtelCollectorEndpoint: "http://10.0.3.5:4318",
 				DaemonVersion:         "0.21.0",
 			},
-		}
-
-		_ = json.NewEncoder(w).Encode(resp)
+		})
 	}))
 	defer server.Close()
 
@@ -354,10 +451,10 @@ func TestClientGetSystemInfo(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, "0.6.0", resp.Info.IncusVersion)
-	assert.Equal(t, 5, resp.Info.ContainersRunning)
+	assert.Equal(t, int32(5), resp.Info.ContainersRunning)
 	// #370: the collector endpoint must round-trip so an agent can
 	// discover where to point docker-in-LXC apps.
-	assert.Equal(t, "http://10.0.3.5:4318", resp.Info.OTelCollectorEndpoint)
+	assert.Equal(t, "http://10.0.3.5:4318", resp.Info.OtelCollectorEndpoint)
 	// #354: the daemon version must round-trip so fleet drift is visible.
 	assert.Equal(t, "0.21.0", resp.Info.DaemonVersion)
 }
@@ -521,10 +618,13 @@ func TestAddRoute(t *testing.T) {
 		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
 		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
 
-		var req AddRouteRequest
-		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"targetIp":"10.0.3.42"`)
+		req := &AddRouteRequest{}
+		require.NoError(t, protojson.Unmarshal(body, req))
 		assert.Equal(t, "blog.example.com", req.Domain)
-		assert.Equal(t, "10.0.3.42", req.TargetIP)
+		assert.Equal(t, "10.0.3.42", req.TargetIp)
 		assert.Equal(t, int32(8080), req.TargetPort)
 		assert.Equal(t, "alice-container", req.ContainerName)
 
@@ -532,7 +632,7 @@ func TestAddRoute(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte(`{
 			"route": {
-				"domain": "blog.example.com",
+				"fullDomain": "blog.example.com",
 				"containerIp": "10.0.3.42",
 				"port": 8080,
 				"containerName": "alice-container"
@@ -543,15 +643,16 @@ func TestAddRoute(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	resp, err := client.AddRoute(AddRouteRequest{
+	resp, err := client.AddRoute(&AddRouteRequest{
 		Domain:        "blog.example.com",
-		TargetIP:      "10.0.3.42",
+		TargetIp:      "10.0.3.42",
 		TargetPort:    8080,
 		ContainerName: "alice-container",
 	})
 	require.NoError(t, err)
-	assert.Equal(t, "blog.example.com", resp.Route.Domain)
-	assert.Equal(t, "10.0.3.42", resp.Route.ContainerIP)
+	assert.Equal(t, "blog.example.com", resp.Route.FullDomain)
+	assert.Equal(t, "alice-container", resp.Route.ContainerName)
+	assert.Equal(t, "10.0.3.42", resp.Route.ContainerIp)
 	assert.Equal(t, int32(8080), resp.Route.Port)
 	assert.Equal(t, "route added", resp.Message)
 }
@@ -564,7 +665,7 @@ func TestAddRoute_ServerError(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	_, err := client.AddRoute(AddRouteRequest{Domain: "x", TargetIP: "y", TargetPort: 1})
+	_, err := client.AddRoute(&AddRouteRequest{Domain: "x", TargetIp: "y", TargetPort: 1})
 	require.Error(t, err)
 }
 
@@ -636,7 +737,7 @@ func TestStartContainer_WirePayload_WaitForReady(t *testing.T) {
 				assert.Equal(t, "POST", r.Method)
 				assert.Equal(t, "/v1/containers/alice/start", r.URL.Path)
 				require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
-				_, _ = w.Write([]byte(`{"message":"started","container":{"name":"alice-container","state":"Running"},"readyTimedOut":false}`))
+				_, _ = w.Write([]byte(`{"message":"started","container":{"name":"alice-container","state":"CONTAINER_STATE_RUNNING"},"readyTimedOut":false}`))
 			}))
 			defer srv.Close()
 
@@ -654,7 +755,7 @@ func TestStartContainer_WirePayload_WaitForReady(t *testing.T) {
 // through so the MCP handler can show the warning emoji.
 func TestStartContainer_DecodesReadyTimedOut(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte(`{"message":"timed out","container":{"name":"alice-container","state":"Running"},"readyTimedOut":true}`))
+		_, _ = w.Write([]byte(`{"message":"timed out","container":{"name":"alice-container","state":"CONTAINER_STATE_RUNNING"},"readyTimedOut":true}`))
 	}))
 	defer srv.Close()
 	c := NewClient(srv.URL, "tok")
@@ -4,11 +4,25 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/footprintai/containarium/internal/config"
 	"github.com/footprintai/containarium/internal/credentials"
 )
 
+// Authentication modes for Config.AuthMode.
+const (
+	// AuthModeJWT sends "Authorization: Bearer <jwt>" (the default).
+	AuthModeJWT = "jwt"
+	// AuthModeAPIKey sends a static key in Config.APIKeyHeader, for
+	// deployments whose front proxy only understands API keys.
+	AuthModeAPIKey = "apikey"
+
+	// DefaultAPIKeyHeader is the header used when APIKeyHeader is empty.
+	DefaultAPIKeyHeader = "X-API-Key"
+)
+
 // Config holds configuration for the MCP server
 type Config struct {
 	// ServerURL is the base URL of the Containarium REST API
@@ -29,10 +43,66 @@ type Config struct {
 	// Whitespace around the token in the file is trimmed.
 	JWTTokenFile string
 
+	// AuthMode selects how requests authenticate: AuthModeJWT (default,
+	// also when empty) or AuthModeAPIKey. Set via CONTAINARIUM_AUTH_MODE.
+	AuthMode string
+
+	// APIKey is the key sent in AuthModeAPIKey (CONTAINARIUM_API_KEY).
+	APIKey string
+
+	// APIKeyHeader is the header carrying APIKey
+	// (CONTAINARIUM_API_KEY_HEADER, default X-API-Key).
+	APIKeyHeader string
+
 	// Debug enables debug logging
 	Debug bool
+
+	// ToolsPageSize caps how many tools one tools/list response
+	// carries; the rest are reachable via nextCursor. 0 (the default)
+	// returns the whole catalog in one page. Set via
+	// CONTAINARIUM_MCP_TOOLS_PAGE_SIZE for clients that choke on
+	// large listings.
+	ToolsPageSize int
+
+	// EnabledTools, when non-empty, is the only set of tools the server
+	// exposes (CONTAINARIUM_ENABLED_TOOLS, comma-separated).
+	EnabledTools []string
+
+	// DisabledTools are hidden even when EnabledTools names them
+	// (CONTAINARIUM_DISABLED_TOOLS, comma-separated).
+	DisabledTools []string
+
+	// ReadOnly exposes only tools annotated read-only and not
+	// destructive (CONTAINARIUM_READ_ONLY=true). It is applied on top of
+	// the two lists; see toolEnabled.
+	ReadOnly bool
+
+	// DebugHTTPLog, when set, is a file the client appends every API
+	// request/response pair to as JSONL, with credentials redacted
+	// (CONTAINARIUM_DEBUG_HTTP_LOG). It rotates to "<path>.1" at 10 MiB.
+	DebugHTTPLog string
+
+	// CapabilityProbeInterval is how often the server re-probes the
+	// daemon for optional features while serving, announcing tool list
+	// changes (CONTAINARIUM_MCP_PROBE_INTERVAL, default 5m). 0 probes
+	// only at startup.
+	CapabilityProbeInterval time.Duration
+
+	// StaleCache keeps the last successful list_containers,
+	// get_container and get_system_info results, and answers with them
+	// — labeled STALE — while the daemon is unreachable instead of
+	// failing (CONTAINARIUM_MCP_STALE_CACHE=true). Tools that change
+	// anything still fail fast.
+	StaleCache bool
+
+	// StaleCacheTTL is how old a cached result may be and still be
+	// served (CONTAINARIUM_MCP_STALE_CACHE_TTL, default 15m).
+	StaleCacheTTL time.Duration
 }
 
+// DefaultCapabilityProbeInterval is LoadConfig's CapabilityProbeInterval.
+const DefaultCapabilityProbeInterval = 5 * time.Minute
+
 // LoadConfig loads configuration from environment variables, with a
 // final fallback to ~/.containarium/credentials.json (the file
 // `containarium login` writes — see internal/credentials). The
@@ -44,6 +114,9 @@ type Config struct {
 //     ServerURL is empty). Static for the life of the process —
 //     prefer (2) when you need long-running token rotation.
 //
+// In API-key mode only ServerURL falls back to credentials.json, when
+// CONTAINARIUM_SERVER_URL is unset.
+//
 // Fallback (3) is best-effort: any error reading credentials.json is
 // logged + ignored, leaving JWTToken empty so the existing
 // "missing token" message in cmd/mcp-server fires with full guidance.
@@ -55,15 +128,95 @@ func LoadConfig() *Config {
 		debug, _ = strconv.ParseBool(debugStr)
 	}
 
+	pageSize := 0
+	if v := os.Getenv("CONTAINARIUM_MCP_TOOLS_PAGE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Printf("Warning: ignoring invalid CONTAINARIUM_MCP_TOOLS_PAGE_SIZE=%q", v)
+		} else {
+			pageSize = n
+		}
+	}
+
+	readOnly := false
+	if v := os.Getenv("CONTAINARIUM_READ_ONLY"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Printf("Warning: ignoring invalid CONTAINARIUM_READ_ONLY=%q", v)
+		} else {
+			readOnly = b
+		}
+	}
+
+	probeInterval := DefaultCapabilityProbeInterval
+	if v := os.Getenv("CONTAINARIUM_MCP_PROBE_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			log.Printf("Warning: ignoring invalid CONTAINARIUM_MCP_PROBE_INTERVAL=%q", v)
+		} else {
+			probeInterval = d
+		}
+	}
+
+	staleCache := false
+	if v := os.Getenv("CONTAINARIUM_MCP_STALE_CACHE"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Printf("Warning: ignoring invalid CONTAINARIUM_MCP_STALE_CACHE=%q", v)
+		} else {
+			staleCache = b
+		}
+	}
+
+	staleCacheTTL := DefaultStaleCacheTTL
+	if v := os.Getenv("CONTAINARIUM_MCP_STALE_CACHE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Printf("Warning: ignoring invalid CONTAINARIUM_MCP_STALE_CACHE_TTL=%q", v)
+		} else {
+			staleCacheTTL = d
+		}
+	}
+
+	authMode := AuthModeJWT
+	switch v := strings.ToLower(strings.TrimSpace(os.Getenv("CONTAINARIUM_AUTH_MODE"))); v {
+	case "", AuthModeJWT:
+	case AuthModeAPIKey, "api-key", "api_key":
+		authMode = AuthModeAPIKey
+	default:
+		log.Printf("Warning: ignoring invalid CONTAINARIUM_AUTH_MODE=%q (want %q or %q)", v, AuthModeJWT, AuthModeAPIKey)
+	}
+	apiKeyHeader := strings.TrimSpace(os.Getenv("CONTAINARIUM_API_KEY_HEADER"))
+	if apiKeyHeader == "" {
+		apiKeyHeader = DefaultAPIKeyHeader
+	}
+
 	jwt := config.LoadJWT()
 	cfg := &Config{
-		ServerURL:    os.Getenv("CONTAINARIUM_SERVER_URL"),
-		JWTToken:     jwt.Token,
-		JWTTokenFile: jwt.TokenFile,
-		Debug:        debug,
+		ServerURL:     os.Getenv("CONTAINARIUM_SERVER_URL"),
+		JWTToken:      jwt.Token,
+		JWTTokenFile:  jwt.TokenFile,
+		AuthMode:      authMode,
+		APIKey:        strings.TrimSpace(os.Getenv("CONTAINARIUM_API_KEY")),
+		APIKeyHeader:  apiKeyHeader,
+		Debug:         debug,
+		ToolsPageSize: pageSize,
+		EnabledTools:  parseToolList(os.Getenv("CONTAINARIUM_ENABLED_TOOLS")),
+		DisabledTools: parseToolList(os.Getenv("CONTAINARIUM_DISABLED_TOOLS")),
+		ReadOnly:      readOnly,
+		DebugHTTPLog:  strings.TrimSpace(os.Getenv("CONTAINARIUM_DEBUG_HTTP_LOG")),
+
+		CapabilityProbeInterval: probeInterval,
+		StaleCache:              staleCache,
+		StaleCacheTTL:           staleCacheTTL,
 	}
 
-	if cfg.JWTToken == "" && cfg.JWTTokenFile == "" {
+	switch {
+	case authMode == AuthModeJWT && cfg.JWTToken == "" && cfg.JWTTokenFile == "":
+		applyCredentialsFileFallback(cfg)
+	case authMode == AuthModeAPIKey && cfg.ServerURL == "":
+		// The key comes from the environment; credentials.json only
+		// names the server.
 		applyCredentialsFileFallback(cfg)
 	}
 
@@ -71,8 +224,9 @@ func LoadConfig() *Config {
 }
 
 // applyCredentialsFileFallback populates cfg.JWTToken (and ServerURL,
-// when unset) from the user's ~/.containarium/credentials.json. A
-// missing file or missing entry is silent — the existing env-var
+// when unset) from the user's ~/.containarium/credentials.json. In
+// API-key mode only ServerURL is taken: the stored token is a JWT the
+// key replaces. A missing file or missing entry is silent — the existing env-var
 // error message in cmd/mcp-server then surfaces with full guidance.
 func applyCredentialsFileFallback(cfg *Config) {
 	path, err := credentials.DefaultPath()
@@ -94,7 +248,9 @@ func applyCredentialsFileFallback(cfg *Config) {
 	if !ok {
 		return
 	}
-	cfg.JWTToken = creds.Token
+	if cfg.AuthMode == AuthModeJWT {
+		cfg.JWTToken = creds.Token
+	}
 	if cfg.ServerURL == "" {
 		// User didn't pin a server; credentials.json's DefaultServer
 		// answered the lookup, so adopt it as ServerURL too. Required
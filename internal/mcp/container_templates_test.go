@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateContainer_TemplateLeavesDefaultsToDaemon(t *testing.T) {
+	t.Setenv("CONTAINARIUM_KEYS_DIR", t.TempDir())
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(raw, &body))
+		_, _ = w.Write([]byte(`{"container":{"name":"alice-container","username":"alice"},"message":"created"}`))
+	}))
+	defer server.Close()
+
+	_, err := handleCreateContainer(NewClient(server.URL, "t"), map[string]interface{}{
+		"username": "alice",
+		"template": "small",
+		"memory":   "8GB",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "small", body["template"])
+	assert.Empty(t, body["image"], "the template's image must not be shadowed by the default")
+	assert.NotContains(t, body, "enablePodman")
+	res, _ := body["resources"].(map[string]interface{})
+	assert.Equal(t, "8GB", res["memory"], "an explicit argument still overrides the template")
+	assert.Empty(t, res["cpu"])
+	assert.Empty(t, res["disk"])
+}
+
+func TestListContainerTemplates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/container-templates", r.URL.Path)
+		_, _ = w.Write([]byte(`{"templates":[{"name":"web","description":"Small web box","resources":{"cpu":"2","memory":"2GB"},"stack":"nodejs","routes":[{"subdomain":"app","containerPort":3000}]}]}`))
+	}))
+	defer server.Close()
+
+	out, err := handleListContainerTemplates(NewClient(server.URL, "t"), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "web — Small web box")
+	assert.Contains(t, out, "cpu=2 memory=2GB disk=-")
+	assert.Contains(t, out, "Stack: nodejs")
+	assert.Contains(t, out, "<username>-app -> port 3000")
+}
+
+func TestListContainerTemplates_Empty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	out, err := handleListContainerTemplates(NewClient(server.URL, "t"), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "No container templates defined.", out)
+}
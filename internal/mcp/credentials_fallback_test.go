@@ -126,3 +126,23 @@ func TestLoadConfig_CredentialsFallback_MissingServerEntryIsSilent(t *testing.T)
 		t.Errorf("JWTToken = %q, want empty (no entry for the requested ServerURL)", c.JWTToken)
 	}
 }
+
+func TestLoadConfig_CredentialsFallback_APIKeyModeTakesServerOnly(t *testing.T) {
+	t.Setenv("CONTAINARIUM_JWT_TOKEN", "")
+	t.Setenv("CONTAINARIUM_JWT_TOKEN_FILE", "")
+	t.Setenv("CONTAINARIUM_SERVER_URL", "")
+	t.Setenv("CONTAINARIUM_AUTH_MODE", "apikey")
+	t.Setenv("CONTAINARIUM_API_KEY", "k-123")
+	home := t.TempDir()
+	writeCredsFile(t, home, "https://default.example.com", map[string]credentials.ServerCreds{
+		"https://default.example.com": {Token: "default-tok"},
+	})
+
+	c := LoadConfig()
+	if c.ServerURL != "https://default.example.com" {
+		t.Errorf("ServerURL = %q, want default_server propagated to %q", c.ServerURL, "https://default.example.com")
+	}
+	if c.JWTToken != "" {
+		t.Errorf("JWTToken = %q, want none in API-key mode", c.JWTToken)
+	}
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// diffTools is the MCP-side catalog for comparing two boxes. The daemon
+// collects both sides and diffs them
+// (GET /v1/containers/{username_a}/diff/{username_b}), so only the
+// differences cross the wire; this side renders them.
+func diffTools() []Tool {
+	return []Tool{
+		{
+			Name: "diff_containers",
+			Description: "Compare two users' containers and list only what differs — " +
+				"the answer to \"why does it work on alice's box but not bob's\". " +
+				"Covers Incus config and limits, devices (disk sizes, GPUs, ...), OS " +
+				"release, kernel, Docker version, listening ports and, with " +
+				"include_packages, installed dpkg package versions. Secrets in the " +
+				"config (environment, cloud-init) are shown as set or unset, never " +
+				"by value. If a container is stopped the diff is partial and Notes " +
+				"say what was left out. Output is a table per section followed by " +
+				"the same data as JSON.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username_a": map[string]interface{}{
+						"type":        "string",
+						"description": "Username of the first container, e.g. the one that works.",
+					},
+					"username_b": map[string]interface{}{
+						"type":        "string",
+						"description": "Username of the second container.",
+					},
+					"include_packages": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also compare installed dpkg packages (default false; slower, and at most 200 package differences are listed).",
+					},
+				},
+				"required": []string{"username_a", "username_b"},
+			},
+			Handler: handleDiffContainers,
+		},
+	}
+}
+
+func handleDiffContainers(client API, args map[string]interface{}) (string, error) {
+	a := getStringArg(args, "username_a", "")
+	b := getStringArg(args, "username_b", "")
+	if a == "" || b == "" {
+		return "", fmt.Errorf("username_a and username_b are required")
+	}
+
+	resp, err := client.DiffContainers(a, b, getBoolArg(args, "include_packages", false))
+	if err != nil {
+		return "", fmt.Errorf("failed to diff containers: %w", err)
+	}
+	js, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode container diff: %w", err)
+	}
+	return formatContainerDiff(resp) + "\nJSON:\n" + string(js), nil
+}
+
+func formatContainerDiff(r *DiffContainersResponse) string {
+	var b strings.Builder
+	a, o := r.GetUsernameA(), r.GetUsernameB()
+	fmt.Fprintf(&b, "%s's container (%s) vs %s's container (%s)\n", a, r.GetStateA(), o, r.GetStateB())
+	if len(r.GetDifferences()) == 0 {
+		b.WriteString("\nNo differences found.\n")
+	}
+	section := ""
+	for _, d := range r.GetDifferences() {
+		if d.GetSection() != section {
+			section = d.GetSection()
+			fmt.Fprintf(&b, "\n%s:\n", section)
+		}
+		fmt.Fprintf(&b, "  %s\n    %s: %s\n    %s: %s\n", d.GetKey(), a, diffValue(d.GetValueA()), o, diffValue(d.GetValueB()))
+	}
+	if len(r.GetNotes()) > 0 {
+		b.WriteString("\nNotes:\n")
+		for _, n := range r.GetNotes() {
+			fmt.Fprintf(&b, "  - %s\n", n)
+		}
+	}
+	return b.String()
+}
+
+func diffValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
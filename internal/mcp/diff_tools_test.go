@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDiffContainers(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		_, _ = io.WriteString(w, `{
+			"usernameA":"alice","usernameB":"bob","stateA":"Running","stateB":"Stopped",
+			"differences":[
+				{"section":"config","key":"limits.memory","valueA":"4GiB","valueB":"2GiB"},
+				{"section":"device","key":"gpu0.type","valueA":"gpu"}
+			],
+			"notes":["bob-container is stopped: OS, kernel, Docker and packages not read"]
+		}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleDiffContainers(NewClient(srv.URL, "test-token"), map[string]interface{}{
+		"username_a": "alice", "username_b": "bob", "include_packages": true,
+	})
+	if err != nil {
+		t.Fatalf("handleDiffContainers: %v", err)
+	}
+	if gotPath != "/v1/containers/alice/diff/bob" || gotQuery != "include_packages=true" {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	for _, want := range []string{
+		"alice's container (Running) vs bob's container (Stopped)",
+		"config:\n  limits.memory\n    alice: 4GiB\n    bob: 2GiB",
+		"device:\n  gpu0.type\n    alice: gpu\n    bob: (none)",
+		"Notes:\n  - bob-container is stopped",
+		"JSON:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := handleDiffContainers(NewClient(srv.URL, "test-token"), map[string]interface{}{"username_a": "alice"}); err == nil {
+		t.Error("missing username_b: want an error")
+	}
+}
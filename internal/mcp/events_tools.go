@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Long-poll bounds, mirroring the daemon's /v1/events/poll: the default
+// stays well under the ~60s request timeout most MCP clients apply.
+const (
+	pollEventsDefaultTimeout = 25 * time.Second
+	pollEventsMaxTimeout     = 55 * time.Second
+)
+
+// eventsTools is the MCP-side catalog for watching daemon events. MCP over
+// stdio is request/response, so instead of a stream the agent loops on
+// poll_events, handing back the cursor each call returns.
+func eventsTools() []Tool {
+	return []Tool{
+		{
+			Name: "poll_events",
+			Description: "Wait for container, app and route events (created, started, " +
+				"stopped, crashed, deleted, resized, readiness changed, ...) and return " +
+				"them with a cursor. Omit `cursor` on the first call to start from now; " +
+				"pass the returned cursor on every later call to get exactly the events " +
+				"since the previous one. Blocks until at least one event arrives or " +
+				"`timeout_seconds` passes, so loop on it to react to state changes " +
+				"without a streaming connection. Says so when events were missed " +
+				"(daemon restart or a very long gap) — re-check state with " +
+				"list_containers then.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"cursor": map[string]interface{}{
+						"type":        "string",
+						"description": "Cursor returned by the previous poll_events call. Omit to start from now.",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "How long to wait for an event (default 25, max 55). Keep it under your MCP client's request timeout.",
+					},
+					"resource_types": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string", "enum": []string{"container", "app", "route"}},
+						"description": "Only return events for these resource types. Default: all.",
+					},
+				},
+			},
+			Handler: func(client API, args map[string]interface{}) (string, error) {
+				return handlePollEvents(context.Background(), client, args)
+			},
+			ContextHandler: handlePollEvents,
+		},
+	}
+}
+
+func handlePollEvents(ctx context.Context, client API, args map[string]interface{}) (string, error) {
+	timeout := pollEventsDefaultTimeout
+	if n, ok := getIntArg(args, "timeout_seconds"); ok {
+		if n < 0 {
+			return "", fmt.Errorf("timeout_seconds must not be negative")
+		}
+		timeout = min(time.Duration(n)*time.Second, pollEventsMaxTimeout)
+	}
+	var resourceTypes []string
+	if raw, ok := args["resource_types"].([]interface{}); ok {
+		for _, v := range raw {
+			rt, _ := v.(string)
+			switch strings.ToLower(rt) {
+			case "container", "app", "route":
+				resourceTypes = append(resourceTypes, strings.ToLower(rt))
+			default:
+				return "", fmt.Errorf("unknown resource type %q (want container, app or route)", rt)
+			}
+		}
+	}
+
+	resp, err := client.PollEvents(ctx, getStringArg(args, "cursor", ""), timeout, resourceTypes)
+	if err != nil {
+		return "", fmt.Errorf("failed to poll events: %w", err)
+	}
+	return formatPolledEvents(resp, timeout), nil
+}
+
+func formatPolledEvents(resp *PollEventsResponse, timeout time.Duration) string {
+	var b strings.Builder
+	if resp.Missed {
+		b.WriteString("⚠️ Some events were missed (the daemon restarted or the gap was too long) — " +
+			"re-check current state with list_containers.\n\n")
+	}
+	if len(resp.Events) == 0 {
+		fmt.Fprintf(&b, "No new events in %s.\n", timeout)
+	} else {
+		fmt.Fprintf(&b, "%d event(s):\n", len(resp.Events))
+		for _, e := range resp.Events {
+			fmt.Fprintf(&b, "  %s  %s\n", e.Timestamp, describePolledEvent(e))
+		}
+	}
+	fmt.Fprintf(&b, "\nCursor: %s\n(pass it to the next poll_events call)\n", resp.Cursor)
+	return b.String()
+}
+
+// describePolledEvent renders one event as a single line, e.g.
+// "container alice-container: stop — RUNNING → STOPPED (by admin)".
+func describePolledEvent(e PolledEvent) string {
+	what := strings.ToLower(strings.TrimPrefix(e.Type, "EVENT_TYPE_"))
+	switch {
+	case e.ContainerEvent != nil:
+		ce := e.ContainerEvent
+		name, state := e.ResourceID, ""
+		if ce.Container != nil {
+			name, state = ce.Container.Name, ce.Container.State
+		}
+		if ce.Type != "" {
+			what = strings.ToLower(strings.TrimPrefix(ce.Type, "CONTAINER_EVENT_TYPE_"))
+		}
+		line := fmt.Sprintf("container %s: %s", name, what)
+		if state != "" {
+			state = strings.TrimPrefix(state, "CONTAINER_STATE_")
+			if ce.PreviousState != "" {
+				state = strings.TrimPrefix(ce.PreviousState, "CONTAINER_STATE_") + " → " + state
+			}
+			line += " — " + state
+		}
+		if ce.Actor != "" {
+			line += " (by " + ce.Actor + ")"
+		}
+		return line
+	case e.AppEvent != nil && e.AppEvent.App != nil:
+		app := e.AppEvent.App
+		return fmt.Sprintf("app %s (%s): %s — %s", app.Name, app.Username, what,
+			strings.TrimPrefix(app.State, "APP_STATE_"))
+	case e.RouteEvent != nil && e.RouteEvent.Route != nil:
+		r := e.RouteEvent.Route
+		return fmt.Sprintf("route %s → %s:%d: %s", r.FullDomain, r.ContainerIP, r.Port, what)
+	}
+	return fmt.Sprintf("%s %s: %s", strings.ToLower(strings.TrimPrefix(e.ResourceType, "RESOURCE_TYPE_")), e.ResourceID, what)
+}
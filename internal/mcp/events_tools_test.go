@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const polledEventsBody = `{
+	"events":[
+		{"id":"e1","type":"EVENT_TYPE_CONTAINER_STOPPED","resourceType":"RESOURCE_TYPE_CONTAINER","resourceId":"alice-container",
+		 "timestamp":"2026-03-01T09:00:00.000Z",
+		 "containerEvent":{"container":{"name":"alice-container","username":"alice","state":"CONTAINER_STATE_STOPPED"},
+		   "previousState":"CONTAINER_STATE_RUNNING","type":"CONTAINER_EVENT_TYPE_STOP","actor":"admin"}},
+		{"id":"e2","type":"EVENT_TYPE_ROUTE_ADDED","resourceType":"RESOURCE_TYPE_ROUTE","resourceId":"app.example.com",
+		 "timestamp":"2026-03-01T09:00:01.000Z",
+		 "routeEvent":{"route":{"fullDomain":"app.example.com","containerIp":"10.0.3.20","port":8080,"active":true}}}
+	],
+	"cursor":"ab12cd34.43",
+	"missed":true
+}`
+
+func TestPollEvents_SendsCursorAndRendersEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/events/poll", r.URL.Path)
+		assert.Equal(t, "ab12cd34.41", r.URL.Query().Get("cursor"))
+		assert.Equal(t, "10", r.URL.Query().Get("timeoutSeconds"))
+		assert.Equal(t, []string{"container", "route"}, r.URL.Query()["resourceTypes"])
+		_, _ = io.WriteString(w, polledEventsBody)
+	}))
+	defer srv.Close()
+
+	out, err := handlePollEvents(t.Context(), NewClient(srv.URL, "test-token"), map[string]interface{}{
+		"cursor":          "ab12cd34.41",
+		"timeout_seconds": float64(10),
+		"resource_types":  []interface{}{"container", "Route"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Some events were missed")
+	assert.Contains(t, out, "2 event(s)")
+	assert.Contains(t, out, "container alice-container: stop — RUNNING → STOPPED (by admin)")
+	assert.Contains(t, out, "route app.example.com → 10.0.3.20:8080: route_added")
+	assert.Contains(t, out, "Cursor: ab12cd34.43")
+}
+
+func TestPollEvents_RejectsUnknownResourceType(t *testing.T) {
+	_, err := handlePollEvents(t.Context(), NewClient("http://localhost:8080", "test-token"),
+		map[string]interface{}{"resource_types": []interface{}{"metrics"}})
+	assert.ErrorContains(t, err, `unknown resource type "metrics"`)
+}
+
+func TestPollEvents_CancelledByNotification(t *testing.T) {
+	arrived := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(arrived)
+		<-r.Context().Done() // hold the poll open until the client gives up
+	}))
+	defer srv.Close()
+
+	s, err := NewServer(&Config{ServerURL: srv.URL, JWTToken: "test-token"})
+	require.NoError(t, err)
+
+	in, feed := io.Pipe()
+	var out bytes.Buffer
+	served := make(chan error, 1)
+	go func() { served <- s.serve(in, &out) }()
+
+	_, _ = io.WriteString(feed, `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"poll_events","arguments":{}}}`+"\n")
+	select {
+	case <-arrived:
+	case <-time.After(5 * time.Second):
+		t.Fatal("poll never reached the daemon")
+	}
+	_, _ = io.WriteString(feed, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user interrupt"}}`+"\n")
+	_, _ = io.WriteString(feed, `{"jsonrpc":"2.0","id":8,"method":"logging/setLevel","params":{"level":"info"}}`+"\n")
+	_ = feed.Close()
+
+	select {
+	case err := <-served:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelled poll kept the server blocked")
+	}
+	var ids []float64
+	dec := json.NewDecoder(strings.NewReader(out.String()))
+	for dec.More() {
+		var f map[string]interface{}
+		require.NoError(t, dec.Decode(&f))
+		if id, ok := f["id"].(float64); ok {
+			ids = append(ids, id)
+		}
+	}
+	assert.Equal(t, []float64{8}, ids, "the cancelled call must not be answered")
+}
@@ -0,0 +1,233 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// filesTools is the MCP-side catalog for moving single files in and out of
+// a box. The daemon goes through the Incus file API only — no exec, so no
+// shell quoting — normalizes the path, keeps it inside the container's own
+// filesystem and audits every write (GET/PUT
+// /v1/containers/{username}/files, GET /v1/containers/{username}/dirs).
+func filesTools() []Tool {
+	return []Tool{
+		{
+			Name: "read_container_file",
+			Description: "Read a file inside a user's container, e.g. a config file or a log, " +
+				"without a shell. Text comes back as is; binary content (NUL bytes or " +
+				"invalid UTF-8) comes back base64-encoded. Files longer than max_bytes are " +
+				"truncated, and a symlink is reported with its target rather than followed. " +
+				"The path must be absolute and outside /proc, /sys and /dev. Works on " +
+				"stopped containers too.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to read from.",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Absolute path of the file inside the container, e.g. /etc/nginx/nginx.conf.",
+					},
+					"max_bytes": map[string]interface{}{
+						"type":        "integer",
+						"description": "Most bytes to return (default 64 KiB, max 1 MiB).",
+					},
+				},
+				"required": []string{"username", "path"},
+			},
+			Handler: handleReadContainerFile,
+		},
+		{
+			Name: "write_container_file",
+			Description: "Write a file inside a user's container, replacing any existing file — " +
+				"the safe way to drop in a config file, since nothing passes through a " +
+				"shell. The parent directory must exist. Content is capped at 1 MiB; pass " +
+				"encoding \"base64\" for binary content. Set mode (default 0644) and owner " +
+				"as numeric uid or uid:gid inside the container (default 0:0, root) — a " +
+				"file in a user's home usually wants that user's uid, often 1000. The path " +
+				"must be absolute and outside /proc, /sys and /dev. Every write is audited.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to write into.",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Absolute path of the file inside the container.",
+					},
+					"content": map[string]interface{}{
+						"type":        "string",
+						"description": "The file's full content (at most 1 MiB once decoded).",
+					},
+					"encoding": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"text", "base64"},
+						"description": "How content is encoded (default text).",
+					},
+					"mode": map[string]interface{}{
+						"type":        "string",
+						"description": "Permission bits in octal, up to \"0777\", e.g. \"0600\" (default \"0644\").",
+					},
+					"owner": map[string]interface{}{
+						"type":        "string",
+						"description": "Numeric owner inside the container, \"uid\" or \"uid:gid\" (default \"0:0\").",
+					},
+				},
+				"required": []string{"username", "path", "content"},
+			},
+			Handler: handleWriteContainerFile,
+		},
+		{
+			Name: "list_container_dir",
+			Description: "List a directory inside a user's container: its entries by name, " +
+				"with the directory's mode and owner. Use it to find a file before " +
+				"read_container_file. The path must be absolute and outside /proc, /sys " +
+				"and /dev. Works on stopped containers too.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to look in.",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Absolute path of the directory (default /).",
+					},
+				},
+				"required": []string{"username"},
+			},
+			Handler: handleListContainerDir,
+		},
+	}
+}
+
+func handleReadContainerFile(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	path := getStringArg(args, "path", "")
+	if username == "" || path == "" {
+		return "", fmt.Errorf("username and path are required")
+	}
+	maxBytes, _ := getIntArg(args, "max_bytes")
+	if maxBytes < 0 {
+		return "", fmt.Errorf("max_bytes must not be negative")
+	}
+
+	resp, err := client.ReadContainerFile(username, path, int64(maxBytes))
+	if err != nil {
+		if msg, ok := fileErrorMessage(err, username, path); ok {
+			return msg, nil
+		}
+		return "", fmt.Errorf("failed to read container file: %w", err)
+	}
+	return formatContainerFile(username, resp), nil
+}
+
+func formatContainerFile(username string, r *ReadContainerFileResponse) string {
+	var b strings.Builder
+	if r.GetType() == "symlink" {
+		fmt.Fprintf(&b, "%s in %s's container is a symlink to %s (not followed)\n", r.GetPath(), username, r.GetContent())
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s in %s's container (mode %s, owner %d:%d)", r.GetPath(), username, r.GetMode(), r.GetUid(), r.GetGid())
+	if r.GetTruncated() {
+		fmt.Fprintf(&b, ", first %d bytes only — the file is longer", len(r.GetContent()))
+	} else {
+		fmt.Fprintf(&b, ", %d bytes", len(r.GetContent()))
+	}
+	if r.GetBinary() {
+		b.WriteString("\nBinary content, base64-encoded:\n")
+		b.WriteString(base64.StdEncoding.EncodeToString(r.GetContent()))
+		b.WriteString("\n")
+		return b.String()
+	}
+	b.WriteString("\n\n")
+	b.Write(r.GetContent())
+	return b.String()
+}
+
+func handleWriteContainerFile(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	path := getStringArg(args, "path", "")
+	if username == "" || path == "" {
+		return "", fmt.Errorf("username and path are required")
+	}
+	text, ok := args["content"].(string)
+	if !ok {
+		return "", fmt.Errorf("content is required (an empty string writes an empty file)")
+	}
+	content := []byte(text)
+	switch enc := getStringArg(args, "encoding", "text"); enc {
+	case "text":
+	case "base64":
+		var err error
+		if content, err = base64.StdEncoding.DecodeString(text); err != nil {
+			return "", fmt.Errorf("content is not valid base64: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("encoding must be text or base64, got %q", enc)
+	}
+
+	resp, err := client.WriteContainerFile(&WriteContainerFileRequest{
+		Username: username,
+		Path:     path,
+		Content:  content,
+		Mode:     getStringArg(args, "mode", ""),
+		Owner:    getStringArg(args, "owner", ""),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to write container file: %w", err)
+	}
+	return fmt.Sprintf("Wrote %d bytes to %s in %s's container (mode %s, owner %d:%d). The write is recorded in the audit log.\n",
+		resp.GetSize(), resp.GetPath(), username, resp.GetMode(), resp.GetUid(), resp.GetGid()), nil
+}
+
+func handleListContainerDir(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+	path := getStringArg(args, "path", "/")
+
+	resp, err := client.ListContainerDir(username, path)
+	if err != nil {
+		if msg, ok := fileErrorMessage(err, username, path); ok {
+			return msg, nil
+		}
+		return "", fmt.Errorf("failed to list container directory: %w", err)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s in %s's container (mode %s, owner %d:%d), %d entries\n",
+		resp.GetPath(), username, resp.GetMode(), resp.GetUid(), resp.GetGid(), len(resp.GetEntries()))
+	for _, e := range resp.GetEntries() {
+		fmt.Fprintf(&b, "  %s\n", e)
+	}
+	return b.String(), nil
+}
+
+// fileErrorMessage turns the daemon's answers for a missing path and for
+// a file where a directory was expected (or the reverse) into a plain
+// message the model can act on.
+func fileErrorMessage(err error, username, path string) (string, bool) {
+	ae := (*APIError)(nil)
+	if !errors.As(err, &ae) {
+		return "", false
+	}
+	switch ae.Code {
+	case "NOT_FOUND":
+		return fmt.Sprintf("%s does not exist in %s's container. Use list_container_dir to look around.", path, username), true
+	case "FAILED_PRECONDITION":
+		if strings.Contains(ae.Message, "is a directory") {
+			return fmt.Sprintf("%s is a directory. Use list_container_dir to see what's in it.", path), true
+		}
+		return fmt.Sprintf("%s is not a directory. Use read_container_file to read it.", path), true
+	}
+	return "", false
+}
@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadContainerFile(t *testing.T) {
+	var gotPath, gotQuery string
+	body := `{"path":"/etc/app.conf","type":"file","mode":"0644","uid":"0","gid":"0","content":"cG9ydCA9IDgwODAK"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		_, _ = io.WriteString(w, body)
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, "test-token")
+
+	out, err := handleReadContainerFile(client, map[string]interface{}{"username": "bob", "path": "/etc/app.conf", "max_bytes": float64(100)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/v1/containers/bob/files" || gotQuery != "max_bytes=100&path=%2Fetc%2Fapp.conf" {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	if !strings.Contains(out, "(mode 0644, owner 0:0), 12 bytes") || !strings.HasSuffix(out, "\n\nport = 8080\n") {
+		t.Errorf("text output:\n%s", out)
+	}
+
+	body = `{"path":"/usr/bin/tool","type":"file","mode":"0755","content":"f0VMRgA=","truncated":true,"binary":true}`
+	out, err = handleReadContainerFile(client, map[string]interface{}{"username": "bob", "path": "/usr/bin/tool"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "first 5 bytes only") || !strings.Contains(out, "base64-encoded:\nf0VMRgA=\n") {
+		t.Errorf("binary output:\n%s", out)
+	}
+}
+
+func TestReadContainerFile_Errors(t *testing.T) {
+	reason, msg := "NOT_FOUND", "/etc/nope: no such file or directory"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": msg, "reason": reason})
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, "test-token")
+
+	out, err := handleReadContainerFile(client, map[string]interface{}{"username": "bob", "path": "/etc/nope"})
+	if err != nil || !strings.Contains(out, "does not exist") {
+		t.Errorf("missing file = %q, %v", out, err)
+	}
+	reason, msg = "FAILED_PRECONDITION", "/etc is a directory; list it instead"
+	out, err = handleReadContainerFile(client, map[string]interface{}{"username": "bob", "path": "/etc"})
+	if err != nil || !strings.Contains(out, "list_container_dir") {
+		t.Errorf("directory = %q, %v", out, err)
+	}
+	reason, msg = "INVALID_ARGUMENT", "invalid container path"
+	if _, err := handleReadContainerFile(client, map[string]interface{}{"username": "bob", "path": "/proc/1/environ"}); err == nil {
+		t.Error("refused path: want an error")
+	}
+}
+
+func TestWriteContainerFile(t *testing.T) {
+	var got map[string]interface{}
+	var gotMethod, gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = io.WriteString(w, `{"path":"/home/bob/.env","size":"4","mode":"0600","uid":"1000","gid":"1000"}`)
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, "test-token")
+
+	out, err := handleWriteContainerFile(client, map[string]interface{}{
+		"username": "bob", "path": "/home/bob/.env", "content": "QT0xCg==", "encoding": "base64", "mode": "0600", "owner": "1000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotMethod != "PUT" || gotPath != "/v1/containers/bob/files" {
+		t.Errorf("request = %s %s", gotMethod, gotPath)
+	}
+	// "A=1\n", base64-encoded again as a proto bytes field.
+	if got["content"] != "QT0xCg==" || got["mode"] != "0600" || got["owner"] != "1000" {
+		t.Errorf("body = %v", got)
+	}
+	if !strings.Contains(out, "Wrote 4 bytes to /home/bob/.env") || !strings.Contains(out, "owner 1000:1000") {
+		t.Errorf("output = %q", out)
+	}
+
+	if _, err := handleWriteContainerFile(client, map[string]interface{}{"username": "bob", "path": "/tmp/x"}); err == nil {
+		t.Error("missing content: want an error")
+	}
+	if _, err := handleWriteContainerFile(client, map[string]interface{}{"username": "bob", "path": "/tmp/x", "content": "%%", "encoding": "base64"}); err == nil {
+		t.Error("bad base64: want an error")
+	}
+}
+
+func TestListContainerDir(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		_, _ = io.WriteString(w, `{"path":"/","mode":"0755","uid":"0","gid":"0","entries":["bin","etc","home"]}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleListContainerDir(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotQuery != "path=%2F" || !strings.Contains(out, "3 entries\n  bin\n  etc\n  home\n") {
+		t.Errorf("query %q, output:\n%s", gotQuery, out)
+	}
+}
@@ -0,0 +1,248 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// httpLogMaxSize is the size at which the debug log rotates to
+	// "<path>.1", replacing the previous backup.
+	httpLogMaxSize = 10 << 20
+	// httpLogBodyCap is how much of each (redacted) body is kept.
+	httpLogBodyCap = 16 << 10
+
+	// redacted stands in for a credential or secret value, here and in
+	// the Debug frames and tool errors redact.go scrubs.
+	redacted = "[REDACTED]"
+)
+
+// httpLogEntry is one request/response pair in the debug log.
+type httpLogEntry struct {
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody     string            `json:"requestBody,omitempty"`
+	Status          int               `json:"status,omitempty"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody    string            `json:"responseBody,omitempty"`
+	DurationMS      int64             `json:"durationMs"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// httpLog appends sanitized request/response pairs to a JSONL file
+// (CONTAINARIUM_DEBUG_HTTP_LOG) so a failed tool call can be diagnosed
+// from the exact exchange instead of a reproduction. Credentials never
+// reach the file: auth headers are replaced, JSON fields that carry
+// tokens, passwords, secret values or SSH keys are blanked, and anything
+// that still looks like a JWT, PEM block or SSH key is scrubbed from the
+// text.
+type httpLog struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	f       *os.File
+	size    int64
+
+	// secrets are literal values scrubbed wherever they appear, e.g. the
+	// client's own token or API key.
+	secrets func() []string
+	// extraHeaders are redacted on top of the standard auth headers (the
+	// API-key header name).
+	extraHeaders []string
+}
+
+func openHTTPLog(path string) (*httpLog, error) {
+	l := &httpLog{path: path, maxSize: httpLogMaxSize}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *httpLog) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open HTTP debug log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat HTTP debug log: %w", err)
+	}
+	l.f, l.size = f, info.Size()
+	return nil
+}
+
+// rotate moves the current file to "<path>.1" and starts a new one.
+func (l *httpLog) rotate() error {
+	l.f.Close()
+	if err := os.Rename(l.path, l.path+".1"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate HTTP debug log: %w", err)
+	}
+	return l.open()
+}
+
+// record redacts and appends one exchange. reqBody and respBody are the
+// raw bodies; resp is nil when no response arrived.
+func (l *httpLog) record(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, started time.Time, reqErr error) {
+	secretPath := strings.Contains(req.URL.Path, "/secrets")
+	e := httpLogEntry{
+		Time:           started.UTC(),
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: l.redactHeaders(req.Header),
+		RequestBody:    l.redactBody(reqBody, secretPath),
+		DurationMS:     time.Since(started).Milliseconds(),
+	}
+	if resp != nil {
+		e.Status = resp.StatusCode
+		e.ResponseHeaders = l.redactHeaders(resp.Header)
+		e.ResponseBody = l.redactBody(respBody, secretPath)
+	}
+	if reqErr != nil {
+		e.Error = l.scrub(reqErr.Error())
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	if l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			l.f = nil
+			return
+		}
+	}
+	n, _ := l.f.Write(line)
+	l.size += int64(n)
+}
+
+// sensitiveHeaders are replaced wholesale in both directions.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+func (l *httpLog) redactHeaders(h http.Header) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		out[k] = l.scrub(strings.Join(v, ", "))
+	}
+	for _, k := range append(sensitiveHeaders, l.extraHeaders...) {
+		if _, ok := out[http.CanonicalHeaderKey(k)]; ok {
+			out[http.CanonicalHeaderKey(k)] = redacted
+		}
+	}
+	return out
+}
+
+// redactURL blanks query parameters whose name marks them as credentials.
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if len(q) == 0 {
+		return u.String()
+	}
+	for k := range q {
+		if sensitiveKey(k, false) {
+			q[k] = []string{redacted}
+		}
+	}
+	c := *u
+	c.RawQuery = q.Encode()
+	return c.String()
+}
+
+// redactBody blanks sensitive JSON fields, scrubs what is left, and caps
+// the result at httpLogBodyCap. Under /secrets every "value" is a secret.
+func (l *httpLog) redactBody(body []byte, secretPath bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	s := string(body)
+	if dec.Decode(&v) == nil {
+		if b, err := json.Marshal(redactJSON(v, secretPath)); err == nil {
+			s = string(b)
+		}
+	}
+	s = l.scrub(s)
+	if len(s) > httpLogBodyCap {
+		s = fmt.Sprintf("%s... (truncated, %d bytes)", s[:httpLogBodyCap], len(s))
+	}
+	return s
+}
+
+func redactJSON(v interface{}, secretPath bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if sensitiveKey(k, secretPath) {
+				t[k] = redacted
+			} else {
+				t[k] = redactJSON(child, secretPath)
+			}
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = redactJSON(child, secretPath)
+		}
+	}
+	return v
+}
+
+// sensitiveKeyParts match JSON field and query parameter names after
+// lower-casing and dropping '_' and '-': "sshKeys", "ssh_keys",
+// "gitCredential", "access_token" and so on.
+var sensitiveKeyParts = []string{"token", "jwt", "password", "passphrase", "secret", "credential", "apikey", "authorization", "privatekey", "sshkey"}
+
+func sensitiveKey(k string, secretPath bool) bool {
+	n := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(k))
+	if secretPath && n == "value" {
+		return true
+	}
+	for _, p := range sensitiveKeyParts {
+		if strings.Contains(n, p) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	pemPattern    = regexp.MustCompile(`-----BEGIN [A-Z ]+-----[\s\S]*?-----END [A-Z ]+-----`)
+	sshKeyPattern = regexp.MustCompile(`(ssh-(rsa|ed25519|dss)|ecdsa-sha2-nistp\d+|sk-ssh-ed25519@openssh\.com) [A-Za-z0-9+/=]+`)
+)
+
+// scrub removes credentials that survived field redaction: the client's
+// own secrets, JWTs, PEM blocks (private keys) and SSH public keys.
+func (l *httpLog) scrub(s string) string {
+	if l.secrets != nil {
+		for _, secret := range l.secrets() {
+			if secret != "" {
+				s = strings.ReplaceAll(s, secret, redacted)
+			}
+		}
+	}
+	s = pemPattern.ReplaceAllString(s, redacted)
+	s = jwtPattern.ReplaceAllString(s, redacted)
+	return sshKeyPattern.ReplaceAllString(s, redacted)
+}
@@ -31,15 +31,15 @@ func TestMCPClient_K3_CreateContainerAcceptsAPIToken(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		sawHeader = r.Header.Get("Authorization")
 		w.WriteHeader(http.StatusOK)
-		_, _ = io.WriteString(w, `{"username":"alice","status":"created"}`)
+		_, _ = io.WriteString(w, `{"container":{"name":"alice","username":"alice"},"message":"created"}`)
 	}))
 	defer server.Close()
 
 	c := NewClient(server.URL, apiToken)
-	resp, err := c.CreateContainer(CreateContainerRequest{
+	resp, err := c.CreateContainer(&CreateContainerRequest{
 		Username: "alice",
 		Image:    "ubuntu:22.04",
-	})
+	}, "")
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 
@@ -59,14 +59,14 @@ func TestMCPClient_K4_ExposePortAcceptsAPIToken(t *testing.T) {
 		sawPath = r.URL.Path
 		sawMethod = r.Method
 		w.WriteHeader(http.StatusOK)
-		_, _ = io.WriteString(w, `{"route":{"domain":"alice-myapp.example.dev"},"message":"ok"}`)
+		_, _ = io.WriteString(w, `{"route":{"fullDomain":"alice-myapp.example.dev"},"message":"ok"}`)
 	}))
 	defer server.Close()
 
 	c := NewClient(server.URL, apiToken)
-	resp, err := c.AddRoute(AddRouteRequest{
+	resp, err := c.AddRoute(&AddRouteRequest{
 		Domain:        "alice-myapp.example.dev",
-		TargetIP:      "10.0.0.1",
+		TargetIp:      "10.0.0.1",
 		TargetPort:    8080,
 		ContainerName: "alice",
 	})
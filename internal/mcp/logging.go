@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/footprintai/containarium/internal/safecast"
+)
+
+// MCP log levels, lowest to highest severity (RFC 5424 names, as used by
+// the MCP `logging` capability).
+const (
+	logDebug = iota
+	logInfo
+	logNotice
+	logWarning
+	logError
+	logCritical
+	logAlert
+	logEmergency
+)
+
+// errNoOutput is returned by write before serve has set up the encoder,
+// e.g. when handlers are driven directly in tests.
+var errNoOutput = errors.New("output not initialized")
+
+var logLevelNames = []string{"debug", "info", "notice", "warning", "error", "critical", "alert", "emergency"}
+
+func parseLogLevel(name string) (int, bool) {
+	for i, n := range logLevelNames {
+		if n == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// defaultLogLevel is the notification threshold before the client sends
+// logging/setLevel: everything in Debug mode, info and up otherwise.
+func defaultLogLevel(cfg *Config) int {
+	if cfg != nil && cfg.Debug {
+		return logDebug
+	}
+	return logInfo
+}
+
+// write encodes one JSON-RPC frame to the client. Responses and
+// notifications share the encoder and the lock, so a notification emitted
+// while a tool runs can never land inside a response.
+func (s *Server) write(v interface{}) error {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	if s.out == nil {
+		return errNoOutput
+	}
+	return s.out.Encode(v)
+}
+
+// notify sends a notifications/message frame when level meets the
+// client's threshold. In Debug mode the event is mirrored to stderr.
+func (s *Server) notify(level int, logger string, data interface{}) {
+	if s.config != nil && s.config.Debug {
+		d, _ := json.Marshal(data)
+		log.Printf("[%s] %s: %s", logLevelNames[level], logger, d)
+	}
+	if safecast.I32(level) < s.logLevel.Load() {
+		return
+	}
+	frame := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "notifications/message",
+		"params": map[string]interface{}{
+			"level":  logLevelNames[level],
+			"logger": logger,
+			"data":   data,
+		},
+	}
+	if err := s.write(frame); err != nil && !errors.Is(err, errNoOutput) {
+		log.Printf("Failed to encode log notification: %v", err)
+	}
+}
+
+// handleSetLevel handles logging/setLevel.
+func (s *Server) handleSetLevel(req *MCPRequest) *MCPResponse {
+	var params struct {
+		Level string `json:"level"`
+	}
+	paramsJSON, err := json.Marshal(req.Params)
+	if err != nil {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+	}
+	if err := json.Unmarshal(paramsJSON, &params); err != nil {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+	}
+	level, ok := parseLogLevel(params.Level)
+	if !ok {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", fmt.Sprintf("unknown log level %q", params.Level))
+	}
+	s.logLevel.Store(safecast.I32(level))
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  map[string]interface{}{},
+	}
+}
+
+// backendClient returns the REST client under an API backend so the server
+// can hook its notifier in; nil for backends that aren't REST-based.
+func backendClient(api API) *Client {
+	switch c := api.(type) {
+	case *Client:
+		return c
+	case cloudClient:
+		return c.Client
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveLines runs the stdio loop over the given request lines and returns
+// every frame written, in order.
+func serveLines(t *testing.T, s *Server, lines ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, s.serve(strings.NewReader(strings.Join(lines, "\n")+"\n"), &out))
+
+	var frames []map[string]interface{}
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var f map[string]interface{}
+		require.NoError(t, dec.Decode(&f))
+		frames = append(frames, f)
+	}
+	return frames
+}
+
+func newLoggingTestServer(t *testing.T, debug bool) *Server {
+	t.Helper()
+	s, err := NewServer(&Config{ServerURL: "http://localhost:8080", JWTToken: "test-token", Debug: debug})
+	require.NoError(t, err)
+	for i := range s.tools {
+		if s.tools[i].Name == "list_containers" {
+			s.tools[i].Handler = func(_ API, _ map[string]interface{}) (string, error) { return "ok", nil }
+		}
+	}
+	return s
+}
+
+const listContainersCall = `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_containers","arguments":{}}}`
+
+func TestLogging_ToolEventsPrecedeResponse(t *testing.T) {
+	s := newLoggingTestServer(t, false)
+	frames := serveLines(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"logging/setLevel","params":{"level":"debug"}}`,
+		listContainersCall,
+	)
+
+	require.Len(t, frames, 4)
+	assert.EqualValues(t, 1, frames[0]["id"])
+	assert.Nil(t, frames[0]["error"])
+
+	for i, event := range []string{"started", "finished"} {
+		f := frames[i+1]
+		assert.Equal(t, "notifications/message", f["method"])
+		assert.NotContains(t, f, "id")
+		params := f["params"].(map[string]interface{})
+		assert.Equal(t, "tools", params["logger"])
+		data := params["data"].(map[string]interface{})
+		assert.Equal(t, event, data["event"])
+		assert.Equal(t, "list_containers", data["tool"])
+	}
+	finished := frames[2]["params"].(map[string]interface{})
+	assert.Equal(t, "info", finished["level"])
+	assert.Contains(t, finished["data"], "duration_ms")
+
+	assert.EqualValues(t, 2, frames[3]["id"])
+	assert.Contains(t, frames[3], "result")
+}
+
+func TestLogging_DefaultThresholdFollowsDebug(t *testing.T) {
+	// Without Debug the "started" debug event is filtered; "finished" is not.
+	frames := serveLines(t, newLoggingTestServer(t, false), listContainersCall)
+	require.Len(t, frames, 2)
+	assert.Equal(t, "info", frames[0]["params"].(map[string]interface{})["level"])
+
+	frames = serveLines(t, newLoggingTestServer(t, true), listContainersCall)
+	require.Len(t, frames, 3)
+	assert.Equal(t, "debug", frames[0]["params"].(map[string]interface{})["level"])
+}
+
+func TestLogging_SetLevel(t *testing.T) {
+	s := newLoggingTestServer(t, true)
+	frames := serveLines(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"logging/setLevel","params":{"level":"error"}}`,
+		listContainersCall,
+		`{"jsonrpc":"2.0","id":3,"method":"logging/setLevel","params":{"level":"verbose"}}`,
+	)
+	require.Len(t, frames, 3)
+	assert.EqualValues(t, 2, frames[1]["id"])
+	errFrame := frames[2]["error"].(map[string]interface{})
+	assert.EqualValues(t, -32602, errFrame["code"])
+}
+
+func TestLogging_ClientReportsStatusAndRetries(t *testing.T) {
+	oldBackoff := createRetryBackoff
+	createRetryBackoff = 0
+	defer func() { createRetryBackoff = oldBackoff }()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	type event struct {
+		level  int
+		logger string
+		data   map[string]interface{}
+	}
+	var events []event
+	c := NewClient(srv.URL, "test-token")
+	c.SetNotifier(func(level int, logger string, data interface{}) {
+		events = append(events, event{level, logger, data.(map[string]interface{})})
+	})
+
+	_, err := c.CreateContainer(&CreateContainerRequest{Username: "alice"}, "")
+	require.Error(t, err)
+
+	// error, retry, error, retry, error
+	require.Len(t, events, 2*createAttempts-1)
+	assert.Equal(t, logError, events[0].level)
+	assert.Equal(t, "api", events[0].logger)
+	assert.Equal(t, http.StatusServiceUnavailable, events[0].data["status"])
+	assert.Equal(t, "/v1/containers", events[0].data["path"])
+	assert.Equal(t, logWarning, events[1].level)
+	assert.Equal(t, "retry", events[1].data["event"])
+	assert.Equal(t, 1, events[1].data["attempt"])
+}
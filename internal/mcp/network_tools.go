@@ -0,0 +1,318 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/footprintai/containarium/internal/safecast"
+)
+
+// networkTools is the MCP-side catalog for a box's addressing. There is no
+// single daemon endpoint for it: Client.GetContainerNetwork joins the
+// container with the proxy and passthrough route lists.
+func networkTools() []Tool {
+	return []Tool{
+		{
+			Name: "get_container_network",
+			Description: "Get a user's container network details: its IP address, MAC, " +
+				"interface and bridge, plus everything that already reaches it — HTTPS " +
+				"proxy routes (from expose_port) and TCP/UDP passthrough port forwards " +
+				"on the host. Use it after create_container to learn the box's IP " +
+				"before exposing a port. The passthrough list needs an admin token; " +
+				"without one it is reported as unavailable rather than failing the call.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to describe.",
+					},
+				},
+				"required": []string{"username"},
+			},
+			Handler: handleGetContainerNetwork,
+		},
+		{
+			Name: "get_listening_ports",
+			Description: "List the TCP and UDP ports a user's container is listening on, " +
+				"with the owning process and when each was first seen — the answer to " +
+				"\"what is bob's box serving\" before exposing a port, or \"what opened " +
+				"that port\" after a new-listener alert. The daemon scans only when its " +
+				"listener interval is set, so a port opened since the last scan may not " +
+				"show yet. Pass history to also list the ports opened and closed over " +
+				"that window.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to inspect.",
+					},
+					"history": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional look-back window for opened/closed ports, e.g. '1h', '7d'.",
+					},
+				},
+				"required": []string{"username"},
+			},
+			Handler: handleGetListeningPorts,
+		},
+		{
+			Name: "list_passthrough_routes",
+			Description: "List every TCP/UDP port forward on the host: external port, the " +
+				"container IP and port it reaches, and whether it is active. Use " +
+				"get_container_network instead to see one user's forwards. Needs an " +
+				"admin token.",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+			Handler:    handleListPassthroughRoutes,
+			Capability: capabilityPassthrough,
+		},
+		{
+			Name: "test_connectivity",
+			Description: "Test whether a user's container can reach a target — \"can my box " +
+				"reach the artifact registry?\". The daemon resolves the target inside " +
+				"the container, checks the address against the egress network policy, " +
+				"then dials it with a bounded probe (tcp connect, an http(s) request via " +
+				"curl, or ping). Reports the resolved IPs, latency, the HTTP status, and " +
+				"on failure the stage it failed at: policy, dns, connect, tls or http. A " +
+				"target the enforced policy drops is not dialed; the answer names the " +
+				"rule that blocks it.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to test from.",
+					},
+					"target": map[string]interface{}{
+						"type":        "string",
+						"description": "host:port, a bare host (dns, ping) or an http(s) URL, e.g. 'registry.corp.example:443' or 'https://pypi.org/simple/'.",
+					},
+					"method": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"tcp", "http", "dns", "ping"},
+						"description": "Probe to run. Default: http for a URL, tcp for host:port, dns for a bare host.",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout of each probe step (default 5, at most 30).",
+					},
+				},
+				"required": []string{"username", "target"},
+			},
+			Handler: handleTestConnectivity,
+		},
+	}
+}
+
+func handleTestConnectivity(client API, args map[string]interface{}) (string, error) {
+	req := &TestConnectivityRequest{
+		Username: getStringArg(args, "username", ""),
+		Target:   getStringArg(args, "target", ""),
+		Method:   getStringArg(args, "method", ""),
+	}
+	if req.Username == "" || req.Target == "" {
+		return "", fmt.Errorf("username and target are required")
+	}
+	if n, ok := getIntArg(args, "timeout_seconds"); ok {
+		req.TimeoutSeconds = safecast.I32(n)
+	}
+	resp, err := client.TestConnectivity(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to test connectivity: %w", err)
+	}
+	return formatConnectivity(resp), nil
+}
+
+func formatConnectivity(r *TestConnectivityResponse) string {
+	var b strings.Builder
+	stage := strings.ToLower(strings.TrimPrefix(r.GetFailureStage().String(), "CONNECTIVITY_STAGE_"))
+	if r.GetReachable() {
+		fmt.Fprintf(&b, "%s's container CAN reach %s (%s, %.1f ms)\n", r.GetUsername(), r.GetTarget(), r.GetMethod(), r.GetLatencyMs())
+	} else {
+		fmt.Fprintf(&b, "%s's container CANNOT reach %s (%s): failed at %s\n", r.GetUsername(), r.GetTarget(), r.GetMethod(), stage)
+		if r.GetError() != "" {
+			fmt.Fprintf(&b, "  Error: %s\n", r.GetError())
+		}
+	}
+	if len(r.GetResolvedIps()) > 0 {
+		fmt.Fprintf(&b, "  Resolves to: %s\n", strings.Join(r.GetResolvedIps(), ", "))
+	}
+	if r.GetRemoteIp() != "" {
+		fmt.Fprintf(&b, "  Dialed: %s\n", r.GetRemoteIp())
+	}
+	if r.GetHttpStatus() != 0 {
+		fmt.Fprintf(&b, "  HTTP status: %d\n", r.GetHttpStatus())
+	}
+
+	p := r.GetPolicy()
+	switch {
+	case p == nil:
+	case !p.GetEvaluated():
+		fmt.Fprintf(&b, "  Network policy: not checked (%s)\n", p.GetNote())
+	case p.GetAllowed():
+		fmt.Fprintf(&b, "  Network policy: %s allowed by %s\n", p.GetAddress(), p.GetRule())
+	case p.GetEnforced():
+		fmt.Fprintf(&b, "  Network policy: %s BLOCKED by %s's policy — %s\n", p.GetAddress(), p.GetTenant(), p.GetRule())
+	default:
+		fmt.Fprintf(&b, "  Network policy: %s would be blocked (%s), but the policy only logs\n", p.GetAddress(), p.GetRule())
+	}
+	if p.GetDefaultPolicy() {
+		fmt.Fprintf(&b, "  (%s has no network policy; the log-only default applies)\n", p.GetTenant())
+	}
+	return b.String()
+}
+
+func handleListPassthroughRoutes(client API, _ map[string]interface{}) (string, error) {
+	resp, err := client.ListPassthroughRoutes()
+	if err != nil {
+		return "", fmt.Errorf("failed to list passthrough routes: %w", err)
+	}
+	if len(resp.GetRoutes()) == 0 {
+		return "No passthrough port forwards on this host.", nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Passthrough port forwards (%d):\n", len(resp.GetRoutes()))
+	for _, r := range resp.GetRoutes() {
+		state := "active"
+		if !r.GetActive() {
+			state = "disabled"
+		}
+		protocol := strings.ToLower(strings.TrimPrefix(r.GetProtocol().String(), "ROUTE_PROTOCOL_"))
+		fmt.Fprintf(&b, "  host :%d → %s:%d/%s (%s)", r.GetExternalPort(), r.GetTargetIp(), r.GetTargetPort(), protocol, state)
+		if r.GetContainerName() != "" {
+			fmt.Fprintf(&b, " %s", r.GetContainerName())
+		}
+		if r.GetDescription() != "" {
+			fmt.Fprintf(&b, " — %s", r.GetDescription())
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+func handleGetContainerNetwork(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+	n, err := client.GetContainerNetwork(username)
+	if err != nil {
+		return "", fmt.Errorf("failed to get container network: %w", err)
+	}
+	return formatContainerNetwork(n), nil
+}
+
+func formatContainerNetwork(n *ContainerNetwork) string {
+	c := n.Container
+	nw := c.GetNetwork()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Network for %s (%s)\n", c.GetName(), strings.TrimPrefix(c.GetState().String(), "CONTAINER_STATE_"))
+	ip := nw.GetIpAddress()
+	if ip == "" {
+		ip = "(none assigned — is the container running?)"
+	}
+	fmt.Fprintf(&b, "  IP address: %s\n", ip)
+	for _, kv := range [][2]string{
+		{"MAC address", nw.GetMacAddress()},
+		{"Interface", nw.GetInterface()},
+		{"Bridge", nw.GetBridge()},
+	} {
+		if kv[1] != "" {
+			fmt.Fprintf(&b, "  %s: %s\n", kv[0], kv[1])
+		}
+	}
+
+	b.WriteString("\nHTTPS routes:\n")
+	switch {
+	case n.RoutesError != "":
+		fmt.Fprintf(&b, "  unavailable (%s)\n", n.RoutesError)
+	case len(n.Routes) == 0:
+		b.WriteString("  none (use expose_port to add one)\n")
+	}
+	for _, r := range n.Routes {
+		state := "active"
+		if !r.GetActive() {
+			state = "disabled"
+		}
+		fmt.Fprintf(&b, "  https://%s → :%d (%s)\n", r.GetFullDomain(), r.GetPort(), state)
+	}
+
+	b.WriteString("\nPassthrough port forwards:\n")
+	switch {
+	case n.PassthroughError != "":
+		fmt.Fprintf(&b, "  unavailable (%s)\n", n.PassthroughError)
+	case len(n.Passthrough) == 0:
+		b.WriteString("  none\n")
+	}
+	for _, r := range n.Passthrough {
+		state := "active"
+		if !r.GetActive() {
+			state = "disabled"
+		}
+		protocol := strings.ToLower(strings.TrimPrefix(r.GetProtocol().String(), "ROUTE_PROTOCOL_"))
+		fmt.Fprintf(&b, "  host :%d → %s:%d/%s (%s)", r.GetExternalPort(), r.GetTargetIp(), r.GetTargetPort(), protocol, state)
+		if r.GetDescription() != "" {
+			fmt.Fprintf(&b, " — %s", r.GetDescription())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func handleGetListeningPorts(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+	history, err := parseActivityWindow(getStringArg(args, "history", ""))
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.GetListeningPorts(username, int64(history/time.Second))
+	if err != nil {
+		if ae := (*APIError)(nil); errors.As(err, &ae) && ae.Code == "FAILED_PRECONDITION" {
+			return fmt.Sprintf("Listening ports are not available on this host: %s", ae.Message), nil
+		}
+		return "", fmt.Errorf("failed to get listening ports: %w", err)
+	}
+	return formatListeningPorts(username, resp, history > 0), nil
+}
+
+func formatListeningPorts(username string, r *GetListeningPortsResponse, withHistory bool) string {
+	var b strings.Builder
+	if r.GetScannedAt() == nil {
+		fmt.Fprintf(&b, "%s's container has not been scanned yet (it may be stopped or just started).\n", username)
+	} else {
+		fmt.Fprintf(&b, "Listening ports in %s's container (scanned %s)\n", username, r.GetScannedAt().AsTime().Format(time.RFC3339))
+		if len(r.GetListeners()) == 0 {
+			b.WriteString("  none\n")
+		}
+	}
+	for _, l := range r.GetListeners() {
+		fmt.Fprintf(&b, "  %s, first seen %s\n", listeningPortLabel(l), l.GetFirstSeen().AsTime().Format(time.RFC3339))
+	}
+	if withHistory {
+		fmt.Fprintf(&b, "\nOpened/closed (%d):\n", len(r.GetChanges()))
+		for _, c := range r.GetChanges() {
+			fmt.Fprintf(&b, "  %s  %-7s %s\n", c.GetTimestamp().AsTime().Format(time.RFC3339),
+				strings.ToLower(strings.TrimPrefix(c.GetType().String(), "LISTENER_CHANGE_TYPE_")), listeningPortLabel(c.GetListener()))
+		}
+	}
+	return b.String()
+}
+
+func listeningPortLabel(l *ListeningPort) string {
+	label := fmt.Sprintf("%s %s", strings.ToLower(strings.TrimPrefix(l.GetProtocol().String(), "PROTOCOL_")), net.JoinHostPort(l.GetAddress(), strconv.FormatUint(uint64(l.GetPort()), 10)))
+	if l.GetProcessName() != "" {
+		label += fmt.Sprintf(" (%s, pid %d)", l.GetProcessName(), l.GetPid())
+	}
+	return label
+}
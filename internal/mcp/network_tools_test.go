@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetContainerNetwork(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/containers/alice":
+			_, _ = io.WriteString(w, `{"container":{"name":"alice-container","username":"alice","state":"CONTAINER_STATE_RUNNING",
+				"network":{"ipAddress":"10.100.0.12","macAddress":"00:16:3e:aa:bb:cc","interface":"eth0","bridge":"incusbr0"}}}`)
+		case "/v1/network/routes":
+			_, _ = io.WriteString(w, `{"routes":[
+				{"fullDomain":"alice-web.example.com","containerIp":"10.100.0.12","port":8080,"active":true},
+				{"fullDomain":"bob-web.example.com","containerIp":"10.100.0.13","port":8080,"active":true}]}`)
+		case "/v1/network/passthrough":
+			_, _ = io.WriteString(w, `{"routes":[
+				{"externalPort":5432,"targetIp":"10.100.0.12","targetPort":5432,"protocol":"ROUTE_PROTOCOL_TCP","active":true,"description":"postgres"},
+				{"externalPort":2222,"targetIp":"10.100.0.13","targetPort":22,"protocol":"ROUTE_PROTOCOL_TCP","active":true}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	out, err := handleGetContainerNetwork(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "alice"})
+	if err != nil {
+		t.Fatalf("handleGetContainerNetwork: %v", err)
+	}
+	for _, want := range []string{
+		"Network for alice-container (RUNNING)",
+		"IP address: 10.100.0.12",
+		"Bridge: incusbr0",
+		"https://alice-web.example.com → :8080 (active)",
+		"host :5432 → 10.100.0.12:5432/tcp (active) — postgres",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, other := range []string{"bob-web", ":2222"} {
+		if strings.Contains(out, other) {
+			t.Errorf("output lists another box's %q:\n%s", other, out)
+		}
+	}
+}
+
+func TestGetContainerNetwork_PassthroughNeedsAdmin(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/containers/alice":
+			_, _ = io.WriteString(w, `{"container":{"name":"alice-container","network":{"ipAddress":"10.100.0.12"}}}`)
+		case "/v1/network/routes":
+			_, _ = io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"error":"role required: admin","reason":"PERMISSION_DENIED"}`)
+		}
+	}))
+	defer srv.Close()
+
+	out, err := handleGetContainerNetwork(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "alice"})
+	if err != nil {
+		t.Fatalf("a forbidden passthrough list should not fail the call: %v", err)
+	}
+	if !strings.Contains(out, "IP address: 10.100.0.12") || !strings.Contains(out, "unavailable") || !strings.Contains(out, "role required: admin") {
+		t.Errorf("output:\n%s", out)
+	}
+}
+
+func TestGetListeningPorts(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/containers/alice":
+			_, _ = io.WriteString(w, `{"container":{"name":"alice-container","username":"alice"}}`)
+		case "/v1/containers/alice-container/traffic/listeners":
+			gotQuery = r.URL.RawQuery
+			_, _ = io.WriteString(w, `{"scannedAt":"2026-03-01T12:05:00Z","listeners":[
+				{"containerName":"alice-container","protocol":"PROTOCOL_TCP","address":"0.0.0.0","port":8080,"processName":"node","pid":88,"firstSeen":"2026-03-01T12:00:00Z"},
+				{"containerName":"alice-container","protocol":"PROTOCOL_UDP","address":"::","port":53}],
+				"changes":[{"type":"LISTENER_CHANGE_TYPE_OPENED","timestamp":"2026-03-01T12:00:00Z",
+				"listener":{"protocol":"PROTOCOL_TCP","address":"0.0.0.0","port":8080,"processName":"node","pid":88}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	out, err := handleGetListeningPorts(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "alice", "history": "1h"})
+	if err != nil {
+		t.Fatalf("handleGetListeningPorts: %v", err)
+	}
+	if !strings.HasPrefix(gotQuery, "history_since=") {
+		t.Errorf("query = %q, want history_since", gotQuery)
+	}
+	for _, want := range []string{
+		"Listening ports in alice's container (scanned 2026-03-01T12:05:00Z)",
+		"tcp 0.0.0.0:8080 (node, pid 88), first seen 2026-03-01T12:00:00Z",
+		"udp [::]:53",
+		"Opened/closed (1):",
+		"2026-03-01T12:00:00Z  opened  tcp 0.0.0.0:8080 (node, pid 88)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGetListeningPorts_Disabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/containers/alice" {
+			_, _ = io.WriteString(w, `{"container":{"name":"alice-container"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":"listener scanning is disabled (daemon --traffic-listener-interval)","reason":"FAILED_PRECONDITION"}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleGetListeningPorts(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "alice"})
+	if err != nil {
+		t.Fatalf("a disabled scan should not fail the call: %v", err)
+	}
+	if !strings.Contains(out, "--traffic-listener-interval") {
+		t.Errorf("output:\n%s", out)
+	}
+}
+
+func TestTestConnectivity(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotPath, gotBody = r.Method, r.URL.Path, string(body)
+		_, _ = io.WriteString(w, `{"username":"alice","target":"registry.corp.example:5000","method":"tcp",
+			"failureStage":"CONNECTIVITY_STAGE_POLICY","error":"blocked by alice's network policy: no egress CIDR or domain covers 203.0.113.10",
+			"resolvedIps":["203.0.113.10"],"remoteIp":"203.0.113.10",
+			"policy":{"evaluated":true,"tenant":"alice","address":"203.0.113.10","enforced":true,"rule":"no egress CIDR or domain covers 203.0.113.10"}}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleTestConnectivity(NewClient(srv.URL, "test-token"), map[string]interface{}{
+		"username": "alice", "target": "registry.corp.example:5000", "timeout_seconds": float64(3),
+	})
+	if err != nil {
+		t.Fatalf("handleTestConnectivity: %v", err)
+	}
+	if gotMethod != "POST" || gotPath != "/v1/containers/alice/connectivity-test" || !strings.Contains(gotBody, `"timeoutSeconds":3`) {
+		t.Errorf("request = %s %s %s", gotMethod, gotPath, gotBody)
+	}
+	for _, want := range []string{
+		"alice's container CANNOT reach registry.corp.example:5000 (tcp): failed at policy",
+		"Resolves to: 203.0.113.10",
+		"Network policy: 203.0.113.10 BLOCKED by alice's policy — no egress CIDR or domain covers 203.0.113.10",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := handleTestConnectivity(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "alice"}); err == nil {
+		t.Error("missing target: want an error")
+	}
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// processesTools is the MCP-side catalog for looking inside a busy box. The
+// daemon runs ps through the Incus exec API and parses it
+// (GET /v1/containers/{username}/processes); this side renders the rows.
+func processesTools() []Tool {
+	return []Tool{
+		{
+			Name: "get_container_processes",
+			Description: "List the processes running inside a user's container, busiest " +
+				"CPU first, with the container's overall CPU and memory usage for " +
+				"context — the answer to \"what is pegging bob's box\". Each row has " +
+				"PID, user, %CPU, %memory, elapsed time and command. Output is a table " +
+				"followed by the same data as JSON. Fails with a clear message when the " +
+				"container is not running.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to inspect.",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "How many processes to return, busiest first (default 20, max 200).",
+					},
+				},
+				"required": []string{"username"},
+			},
+			Handler: handleGetContainerProcesses,
+		},
+	}
+}
+
+func handleGetContainerProcesses(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+	limit, _ := getIntArg(args, "limit")
+	if limit < 0 {
+		return "", fmt.Errorf("limit must not be negative")
+	}
+
+	resp, err := client.GetContainerProcesses(username, limit)
+	if err != nil {
+		if ae := (*APIError)(nil); errors.As(err, &ae) && ae.Code == "FAILED_PRECONDITION" {
+			return fmt.Sprintf("%s's container is not running, so it has no processes to list. Start it with start_container first.", username), nil
+		}
+		return "", fmt.Errorf("failed to list container processes: %w", err)
+	}
+	js, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode process list: %w", err)
+	}
+	return formatContainerProcesses(username, resp) + "\nJSON:\n" + string(js), nil
+}
+
+func formatContainerProcesses(username string, r *GetContainerProcessesResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Processes in %s's container (top %d of %d by CPU)\n", username, len(r.GetProcesses()), r.GetTotalProcesses())
+	if m := r.GetMetrics(); m != nil {
+		fmt.Fprintf(&b, "Container: %d CPU seconds used, memory %s\n", m.GetCpuUsageSeconds(), humanBytes(m.GetMemoryUsageBytes()))
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "%7s  %-12s %6s %6s %10s  %s\n", "PID", "USER", "%CPU", "%MEM", "ELAPSED", "COMMAND")
+	for _, p := range r.GetProcesses() {
+		fmt.Fprintf(&b, "%7d  %-12s %6.1f %6.1f %10s  %s\n",
+			p.GetPid(), p.GetUser(), p.GetCpuPercent(), p.GetMemoryPercent(), formatElapsed(p.GetElapsedSeconds()), p.GetCommand())
+	}
+	return b.String()
+}
+
+// formatElapsed renders seconds the way ps's etime does: [[dd-]hh:]mm:ss.
+func formatElapsed(secs int64) string {
+	d, h, m, s := secs/86400, secs%86400/3600, secs%3600/60, secs%60
+	switch {
+	case d > 0:
+		return fmt.Sprintf("%d-%02d:%02d:%02d", d, h, m, s)
+	case h > 0:
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetContainerProcesses(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		_, _ = io.WriteString(w, `{
+			"processes":[
+				{"pid":812,"user":"bob","cpuPercent":97.3,"memoryPercent":4.1,"elapsedSeconds":"3605","command":"python3"},
+				{"pid":1,"user":"root","cpuPercent":0.1,"memoryPercent":0.2,"elapsedSeconds":"90061","command":"systemd"}
+			],
+			"totalProcesses":14,
+			"metrics":{"name":"bob-container","cpuUsageSeconds":"5400","memoryUsageBytes":"536870912"}
+		}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleGetContainerProcesses(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "bob", "limit": float64(2)})
+	if err != nil {
+		t.Fatalf("handleGetContainerProcesses: %v", err)
+	}
+	if gotPath != "/v1/containers/bob/processes" || gotQuery != "limit=2" {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	for _, want := range []string{
+		"top 2 of 14 by CPU",
+		"memory 512 MiB",
+		"812  bob",
+		"97.3",
+		"01:00:05  python3",
+		"1-01:01:01  systemd",
+		"JSON:",
+		`"totalProcesses"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGetContainerProcesses_NotRunning(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":"bob-container: container is not running (state: Stopped)","reason":"FAILED_PRECONDITION"}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleGetContainerProcesses(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "bob"})
+	if err != nil {
+		t.Fatalf("not running should be a clean message, got error %v", err)
+	}
+	if !strings.Contains(out, "not running") {
+		t.Errorf("output = %q", out)
+	}
+}
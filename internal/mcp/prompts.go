@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Prompt is a curated, parameterized starting point an MCP client can offer
+// its user ("diagnose a box that won't start"). Expanding one yields
+// messages that walk the model through the right tool sequence, so the
+// server documents how its tools fit together instead of leaving the model
+// to rediscover it from 60+ descriptions.
+type Prompt struct {
+	Name        string
+	Description string
+	Arguments   []PromptArgument
+	// Tools are the tools the expanded messages direct the model to call.
+	// A prompt is hidden when the token can't call all of them, the same
+	// way tools/list hides out-of-scope tools.
+	Tools []string
+	// Render expands the prompt with its (validated) arguments.
+	Render func(args map[string]string) string
+}
+
+// PromptArgument describes one template argument.
+type PromptArgument struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+func operationalPrompts() []Prompt {
+	usernameArg := PromptArgument{Name: "username", Description: "Username whose container to look at", Required: true}
+
+	return []Prompt{
+		{
+			Name:        "diagnose_container_start",
+			Description: "Diagnose a container that won't start (or keeps stopping) and get it running again",
+			Arguments:   []PromptArgument{usernameArg},
+			Tools:       []string{"get_container", "debug_container", "get_metrics", "start_container", "resize_container", "list_snapshots"},
+			Render: func(args map[string]string) string {
+				u := args["username"]
+				return fmt.Sprintf(`%[1]s's container won't start. Diagnose it step by step:
+
+1. Call get_container with username=%[1]q to see its current state and resources.
+2. Call debug_container with username=%[1]q. Read the Incus state, recent console/log output and any error it reports; this usually names the cause (full disk, bad config, missing image, OOM).
+3. If the container has run before, call get_metrics with username=%[1]q and compare disk and memory usage against its limits.
+4. Act on the cause you found:
+   - Disk or memory exhausted: propose resize_container with a concrete new limit and ask before applying it.
+   - Transient or unclear failure: try start_container with username=%[1]q and waitForReady=true, then re-check with get_container.
+   - Broken by a recent change: call list_snapshots with username=%[1]q and suggest the newest snapshot that predates the problem. Do NOT call restore_container without explicit confirmation — it discards everything written since.
+5. Finish with a short summary: root cause, what you changed, and the container's final state.`, u)
+			},
+		},
+		{
+			Name:        "investigate_suspicious_traffic",
+			Description: "Investigate unexpected or suspicious network traffic from a user's container",
+			Arguments: []PromptArgument{
+				usernameArg,
+				{Name: "window", Description: "How far back to look, e.g. 24h or 7d (default 24h)"},
+			},
+			Tools: []string{"container_activity_report", "get_container", "list_routes", "security_scan", "security_findings"},
+			Render: func(args map[string]string) string {
+				u := args["username"]
+				w := args["window"]
+				if w == "" {
+					w = "24h"
+				}
+				return fmt.Sprintf(`Investigate possibly suspicious network traffic from %[1]s's container over the last %[2]s:
+
+1. Call container_activity_report with username=%[1]q and window=%[2]q. Look at traffic totals and the top destinations: unfamiliar addresses, unusually high bytes sent (possible exfiltration), or many connections (scanning, mining pools, C2 beacons).
+2. Correlate with the same report's lifecycle events and changes: did the traffic start right after a restart, a resize, or an API call by someone unexpected?
+3. Call get_container with username=%[1]q and list_routes with username=%[1]q to see what the container is supposed to expose; inbound traffic to exposed routes is expected.
+4. Call security_scan with username=%[1]q, then security_findings with username=%[1]q, to check for malware or known-bad packages.
+5. Report: which destinations look suspicious and why, the evidence for each, and a recommended action. Recommend stopping the container or tightening its network policy only with the evidence spelled out; do not stop or delete anything yourself.`, u, w)
+			},
+		},
+		{
+			Name:        "container_standup_report",
+			Description: "Write a standup-style summary of what happened on a user's container recently",
+			Arguments: []PromptArgument{
+				usernameArg,
+				{Name: "window", Description: "Period to cover, e.g. 24h or 7d (default 7d)"},
+			},
+			Tools: []string{"container_activity_report"},
+			Render: func(args map[string]string) string {
+				u := args["username"]
+				w := args["window"]
+				if w == "" {
+					w = "7d"
+				}
+				return fmt.Sprintf(`Call container_activity_report with username=%[1]q and window=%[2]q, then write a short standup-style summary of %[1]s's container for that period:
+
+- Lifecycle: restarts, stops, creations, and roughly how long it was up.
+- Resources: average and peak usage; flag anything close to its limits or growing fast (disk growth especially).
+- Traffic: total volume and the main destinations, in one line.
+- Changes and snapshots: who changed what, and which snapshots exist to roll back to.
+- Anything the report's notes say is missing, so the reader knows what isn't covered.
+
+Keep it to a few bullet points a human can skim.`, u, w)
+			},
+		},
+	}
+}
+
+// promptVisible reports whether every tool the prompt relies on is
+// registered and callable with the granted scopes.
+func (s *Server) promptVisible(granted []string, p *Prompt) bool {
+	for _, name := range p.Tools {
+		tool := s.findTool(name)
+		if tool == nil || !toolAllowed(granted, tool) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) findTool(name string) *Tool {
+	for i := range s.tools {
+		if s.tools[i].Name == name {
+			return &s.tools[i]
+		}
+	}
+	return nil
+}
+
+// handlePromptsList handles the prompts/list request.
+func (s *Server) handlePromptsList(req *MCPRequest) *MCPResponse {
+	granted := s.allowedScopes()
+	prompts := []map[string]interface{}{}
+	for i := range s.prompts {
+		p := &s.prompts[i]
+		if !s.promptVisible(granted, p) {
+			continue
+		}
+		args := make([]map[string]interface{}, 0, len(p.Arguments))
+		for _, a := range p.Arguments {
+			args = append(args, map[string]interface{}{
+				"name":        a.Name,
+				"description": a.Description,
+				"required":    a.Required,
+			})
+		}
+		prompts = append(prompts, map[string]interface{}{
+			"name":        p.Name,
+			"description": p.Description,
+			"arguments":   args,
+		})
+	}
+
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: map[string]interface{}{
+			"prompts": prompts,
+		},
+	}
+}
+
+// handlePromptsGet handles the prompts/get request.
+func (s *Server) handlePromptsGet(req *MCPRequest) *MCPResponse {
+	var params struct {
+		Name      string            `json:"name"`
+		Arguments map[string]string `json:"arguments"`
+	}
+	paramsJSON, err := json.Marshal(req.Params)
+	if err != nil {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+	}
+	if err := json.Unmarshal(paramsJSON, &params); err != nil {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+	}
+
+	var prompt *Prompt
+	for i := range s.prompts {
+		if s.prompts[i].Name == params.Name {
+			prompt = &s.prompts[i]
+			break
+		}
+	}
+	// A prompt hidden from this token is reported exactly like an unknown
+	// one; its text would only point at tools the token can't call.
+	if prompt == nil || !s.promptVisible(s.allowedScopes(), prompt) {
+		return s.createErrorResponse(req.ID, -32602, "Prompt not found", fmt.Sprintf("Prompt '%s' not found", params.Name))
+	}
+
+	args := make(map[string]string, len(prompt.Arguments))
+	for _, a := range prompt.Arguments {
+		v := strings.TrimSpace(params.Arguments[a.Name])
+		if v == "" && a.Required {
+			return s.createErrorResponse(req.ID, -32602, "Invalid params", fmt.Sprintf("argument %q is required", a.Name))
+		}
+		args[a.Name] = v
+	}
+
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: map[string]interface{}{
+			"description": prompt.Description,
+			"messages": []map[string]interface{}{
+				{
+					"role": "user",
+					"content": map[string]interface{}{
+						"type": "text",
+						"text": prompt.Render(args),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPromptsTestServer(token string) *Server {
+	srv := &Server{
+		config:  &Config{},
+		client:  NewClient("http://localhost:8080", token),
+		prompts: operationalPrompts(),
+	}
+	srv.registerTools()
+	return srv
+}
+
+// TestOperationalPromptsReferenceRealTools — a prompt that names a tool
+// that was renamed or removed would silently disappear from prompts/list.
+func TestOperationalPromptsReferenceRealTools(t *testing.T) {
+	srv := newPromptsTestServer("test-token")
+	for _, p := range srv.prompts {
+		for _, name := range p.Tools {
+			assert.NotNil(t, srv.findTool(name), "prompt %q references unknown tool %q", p.Name, name)
+		}
+	}
+}
+
+func TestHandlePromptsList(t *testing.T) {
+	srv := newPromptsTestServer("test-token")
+
+	resp := srv.handleRequest(&MCPRequest{JSONRPC: "2.0", ID: 1, Method: "prompts/list"})
+	require.Nil(t, resp.Error)
+	prompts := resp.Result.(map[string]interface{})["prompts"].([]map[string]interface{})
+	require.Len(t, prompts, len(operationalPrompts()))
+
+	names := map[string]bool{}
+	for _, p := range prompts {
+		names[p["name"].(string)] = true
+		args := p["arguments"].([]map[string]interface{})
+		require.NotEmpty(t, args)
+		assert.Equal(t, "username", args[0]["name"])
+		assert.Equal(t, true, args[0]["required"])
+	}
+	assert.True(t, names["diagnose_container_start"])
+	assert.True(t, names["investigate_suspicious_traffic"])
+}
+
+// TestHandlePromptsList_ScopeFiltered — a read-only token can't call
+// start_container or security_scan, so the prompts that lead there are
+// hidden; the report-only prompt stays.
+func TestHandlePromptsList_ScopeFiltered(t *testing.T) {
+	tok := makeUnsignedJWT(t, map[string]interface{}{"scopes": []string{"containers:read"}})
+	srv := newPromptsTestServer(tok)
+
+	resp := srv.handleRequest(&MCPRequest{JSONRPC: "2.0", ID: 1, Method: "prompts/list"})
+	require.Nil(t, resp.Error)
+	prompts := resp.Result.(map[string]interface{})["prompts"].([]map[string]interface{})
+	require.Len(t, prompts, 1)
+	assert.Equal(t, "container_standup_report", prompts[0]["name"])
+
+	resp = srv.handleRequest(&MCPRequest{
+		JSONRPC: "2.0", ID: 2, Method: "prompts/get",
+		Params: map[string]interface{}{"name": "diagnose_container_start", "arguments": map[string]string{"username": "bob"}},
+	})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "Prompt not found", resp.Error.Message)
+}
+
+func TestHandlePromptsGet(t *testing.T) {
+	srv := newPromptsTestServer("test-token")
+	get := func(name string, args map[string]string) *MCPResponse {
+		return srv.handleRequest(&MCPRequest{
+			JSONRPC: "2.0", ID: 1, Method: "prompts/get",
+			Params: map[string]interface{}{"name": name, "arguments": args},
+		})
+	}
+
+	resp := get("investigate_suspicious_traffic", map[string]string{"username": "bob"})
+	require.Nil(t, resp.Error)
+	messages := resp.Result.(map[string]interface{})["messages"].([]map[string]interface{})
+	require.Len(t, messages, 1)
+	assert.Equal(t, "user", messages[0]["role"])
+	text := messages[0]["content"].(map[string]interface{})["text"].(string)
+	assert.Contains(t, text, `container_activity_report with username="bob" and window="24h"`)
+	assert.False(t, strings.Contains(text, "%!"), "unexpanded format verb in:\n%s", text)
+
+	resp = get("container_standup_report", map[string]string{"username": "bob", "window": "3d"})
+	require.Nil(t, resp.Error)
+	text = resp.Result.(map[string]interface{})["messages"].([]map[string]interface{})[0]["content"].(map[string]interface{})["text"].(string)
+	assert.Contains(t, text, `window="3d"`)
+
+	resp = get("diagnose_container_start", map[string]string{})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32602, resp.Error.Code)
+	assert.Contains(t, resp.Error.Data, "username")
+
+	resp = get("no_such_prompt", map[string]string{"username": "bob"})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "Prompt not found", resp.Error.Message)
+}
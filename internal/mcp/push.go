@@ -120,8 +120,8 @@ func pickSentinel(client API, args map[string]interface{}) string {
 	}
 	if client != nil {
 		if u := getStringArg(args, "username", ""); u != "" {
-			if resp, err := client.GetContainer(u); err == nil && resp.Container.SSHHost != "" {
-				return resp.Container.SSHHost
+			if resp, err := client.GetContainer(u); err == nil && resp.GetContainer().GetSshHost() != "" {
+				return resp.GetContainer().GetSshHost()
 			}
 		}
 	}
@@ -0,0 +1,167 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Readiness wait bounds. The default stays under the typical MCP client
+// request timeout (~60s, see connectReadyTimeout); callers that know their
+// client allows longer can ask for up to readinessMaxTimeout. vars (not
+// consts) so tests can shrink them.
+var (
+	readinessDefaultTimeout = 45 * time.Second
+	readinessMaxTimeout     = 15 * time.Minute
+	readinessPollInterval   = 3 * time.Second
+)
+
+// readinessTools is the MCP-side catalog for waiting on a freshly created
+// box. The daemon tracks the provisioning steps and runs the live checks
+// (GET /v1/containers/{username}/readiness); this side only polls and
+// renders.
+func readinessTools() []Tool {
+	return []Tool{
+		{
+			Name: "wait_for_container_ready",
+			Description: "Wait until a user's container is ready to use: running, IP " +
+				"assigned, sshd (RDP for Windows) accepting connections, and every " +
+				"provisioning step (packages, user, SSH keys, git source, ...) done. " +
+				"Call this after create_container and before connect/exec — the create " +
+				"returns long before provisioning finishes. Returns a step-by-step " +
+				"progress report once ready. Stops early if a step failed, and on " +
+				"timeout reports the step or check still holding the box back; calling " +
+				"again simply keeps waiting.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to wait for.",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "How long to wait (default 45, max 900). Keep it under your MCP client's request timeout.",
+					},
+				},
+				"required": []string{"username"},
+			},
+			Handler: handleWaitForContainerReady,
+		},
+	}
+}
+
+func handleWaitForContainerReady(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+	timeout := readinessDefaultTimeout
+	if n, ok := getIntArg(args, "timeout_seconds"); ok {
+		if n <= 0 {
+			return "", fmt.Errorf("timeout_seconds must be positive")
+		}
+		timeout = min(time.Duration(n)*time.Second, readinessMaxTimeout)
+	}
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	for {
+		resp, err := client.GetContainerReadiness(username)
+		if err != nil {
+			return "", fmt.Errorf("failed to get container readiness: %w", err)
+		}
+		waited := time.Since(start).Round(time.Second)
+		switch {
+		case resp.GetReady():
+			return fmt.Sprintf("✅ %s is ready (waited %s).\n\n", resp.GetContainerName(), waited) + formatReadiness(resp), nil
+		case failedProvisionStep(resp) != nil:
+			st := failedProvisionStep(resp)
+			return fmt.Sprintf("❌ Provisioning of %s failed at step %q: %s\n"+
+				"Waiting longer will not help; inspect the error, then delete and re-create the box.\n\n",
+				resp.GetContainerName(), st.GetName(), st.GetError()) + formatReadiness(resp), nil
+		case time.Now().After(deadline):
+			return fmt.Sprintf("⏳ %s is not ready after %s. Still waiting on: %s\n"+
+				"This is not an error by itself — call wait_for_container_ready again to keep waiting.\n\n",
+				resp.GetContainerName(), waited, readinessBlocker(resp)) + formatReadiness(resp), nil
+		}
+		time.Sleep(readinessPollInterval)
+	}
+}
+
+func failedProvisionStep(r *ContainerReadinessResponse) *ProvisionStep {
+	for _, st := range r.GetSteps() {
+		if st.GetState() == pb.ProvisionStepState_PROVISION_STEP_STATE_FAILED {
+			return st
+		}
+	}
+	return nil
+}
+
+// readinessBlocker names the first thing keeping the box from ready: an
+// unfinished provisioning step, else the first failing check.
+func readinessBlocker(r *ContainerReadinessResponse) string {
+	for _, st := range r.GetSteps() {
+		if st.GetState() != pb.ProvisionStepState_PROVISION_STEP_STATE_DONE {
+			return fmt.Sprintf("step %q (%s)", st.GetName(), provisionStepLabel(st.GetState()))
+		}
+	}
+	for _, c := range r.GetChecks() {
+		if !c.GetOk() {
+			return fmt.Sprintf("check %q (%s)", c.GetName(), c.GetDetail())
+		}
+	}
+	return "container state " + strings.TrimPrefix(r.GetState().String(), "CONTAINER_STATE_")
+}
+
+func formatReadiness(r *ContainerReadinessResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Container: %s (%s), state %s\n", r.GetContainerName(), r.GetUsername(), strings.TrimPrefix(r.GetState().String(), "CONTAINER_STATE_"))
+
+	if len(r.GetSteps()) == 0 {
+		b.WriteString("\nProvisioning steps: none recorded (created before step tracking, or by another runtime)\n")
+	} else {
+		b.WriteString("\nProvisioning steps:\n")
+		for _, st := range r.GetSteps() {
+			mark := "…"
+			switch st.GetState() {
+			case pb.ProvisionStepState_PROVISION_STEP_STATE_DONE:
+				mark = "✓"
+			case pb.ProvisionStepState_PROVISION_STEP_STATE_FAILED:
+				mark = "✗"
+			}
+			fmt.Fprintf(&b, "  %s %-13s %s", mark, st.GetName(), provisionStepLabel(st.GetState()))
+			if d, ok := provisionStepDuration(st); ok {
+				fmt.Fprintf(&b, " (%s)", d)
+			}
+			if st.GetError() != "" {
+				fmt.Fprintf(&b, ": %s", st.GetError())
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\nChecks:\n")
+	for _, c := range r.GetChecks() {
+		mark := "✗"
+		if c.GetOk() {
+			mark = "✓"
+		}
+		fmt.Fprintf(&b, "  %s %-13s %s\n", mark, c.GetName(), c.GetDetail())
+	}
+	return b.String()
+}
+
+func provisionStepLabel(state pb.ProvisionStepState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "PROVISION_STEP_STATE_"))
+}
+
+// provisionStepDuration is how long a finished step took.
+func provisionStepDuration(st *ProvisionStep) (time.Duration, bool) {
+	if st.GetStartedAt() == nil || st.GetFinishedAt() == nil {
+		return 0, false
+	}
+	return st.GetFinishedAt().AsTime().Sub(st.GetStartedAt().AsTime()).Round(time.Second), true
+}
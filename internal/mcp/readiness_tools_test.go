@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	readinessProvisioning = `{
+		"username":"bob","containerName":"bob-container","state":"CONTAINER_STATE_RUNNING",
+		"steps":[
+			{"name":"create","state":"PROVISION_STEP_STATE_DONE","startedAt":"2026-03-01T09:00:00Z","finishedAt":"2026-03-01T09:00:04Z"},
+			{"name":"packages","state":"PROVISION_STEP_STATE_RUNNING","startedAt":"2026-03-01T09:00:04Z"}
+		],
+		"checks":[
+			{"name":"running","ok":true,"detail":"Running"},
+			{"name":"ip-assigned","ok":true,"detail":"10.0.3.20"},
+			{"name":"sshd","ok":false,"detail":"connection refused"}
+		]
+	}`
+	readinessReady = `{
+		"username":"bob","containerName":"bob-container","state":"CONTAINER_STATE_RUNNING","ready":true,
+		"steps":[
+			{"name":"create","state":"PROVISION_STEP_STATE_DONE","startedAt":"2026-03-01T09:00:00Z","finishedAt":"2026-03-01T09:00:04Z"},
+			{"name":"packages","state":"PROVISION_STEP_STATE_DONE","startedAt":"2026-03-01T09:00:04Z","finishedAt":"2026-03-01T09:02:04Z"}
+		],
+		"checks":[
+			{"name":"running","ok":true,"detail":"Running"},
+			{"name":"ip-assigned","ok":true,"detail":"10.0.3.20"},
+			{"name":"sshd","ok":true,"detail":"10.0.3.20:22 accepting connections"}
+		]
+	}`
+)
+
+func shrinkReadinessPolling(t *testing.T) {
+	t.Helper()
+	oldI, oldD := readinessPollInterval, readinessDefaultTimeout
+	readinessPollInterval, readinessDefaultTimeout = 5*time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() { readinessPollInterval, readinessDefaultTimeout = oldI, oldD })
+}
+
+func TestWaitForContainerReady_PollsUntilReady(t *testing.T) {
+	shrinkReadinessPolling(t)
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/containers/bob/readiness" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		calls++
+		if calls < 3 {
+			_, _ = io.WriteString(w, readinessProvisioning)
+			return
+		}
+		_, _ = io.WriteString(w, readinessReady)
+	}))
+	defer srv.Close()
+
+	out, err := handleWaitForContainerReady(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "bob", "timeout_seconds": float64(5)})
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("polled %d times, want 3", calls)
+	}
+	for _, want := range []string{"✅ bob-container is ready", "✓ packages", "done (2m0s)", "✓ sshd"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWaitForContainerReady_TimeoutNamesBlocker(t *testing.T) {
+	shrinkReadinessPolling(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, readinessProvisioning)
+	}))
+	defer srv.Close()
+
+	out, err := handleWaitForContainerReady(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "bob"})
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if !strings.Contains(out, `Still waiting on: step "packages" (running)`) {
+		t.Errorf("timeout output should name the running step:\n%s", out)
+	}
+}
+
+func TestWaitForContainerReady_StopsOnFailedStep(t *testing.T) {
+	shrinkReadinessPolling(t)
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.WriteString(w, `{"username":"bob","containerName":"bob-container","state":"CONTAINER_STATE_ERROR",
+			"steps":[{"name":"network","state":"PROVISION_STEP_STATE_FAILED","error":"failed to get container IP: timeout"}],
+			"checks":[{"name":"running","detail":"instance does not exist"}]}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleWaitForContainerReady(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "bob", "timeout_seconds": float64(60)})
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("polled %d times after a failed step, want 1", calls)
+	}
+	if !strings.Contains(out, `failed at step "network": failed to get container IP: timeout`) {
+		t.Errorf("output should report the failed step:\n%s", out)
+	}
+}
+
+func TestWaitForContainerReady_RejectsBadTimeout(t *testing.T) {
+	if _, err := handleWaitForContainerReady(nil, map[string]interface{}{"username": "bob", "timeout_seconds": float64(0)}); err == nil {
+		t.Error("timeout_seconds=0 accepted")
+	}
+	if _, err := handleWaitForContainerReady(nil, map[string]interface{}{}); err == nil {
+		t.Error("missing username accepted")
+	}
+}
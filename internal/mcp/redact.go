@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// tool returns the registered tool called name, or nil.
+func (s *Server) tool(name string) *Tool {
+	for i := range s.tools {
+		if s.tools[i].Name == name {
+			return &s.tools[i]
+		}
+	}
+	return nil
+}
+
+// calledTool returns the tool a tools/call request invokes, or nil for
+// any other request.
+func (s *Server) calledTool(req *MCPRequest) *Tool {
+	if req.Method != "tools/call" {
+		return nil
+	}
+	params, _ := req.Params.(map[string]interface{})
+	name, _ := params["name"].(string)
+	return s.tool(name)
+}
+
+// debugRequest renders a received frame for the Debug log with the
+// called tool's SensitiveArgs blanked.
+func (s *Server) debugRequest(line []byte, req *MCPRequest) string {
+	t := s.calledTool(req)
+	if t == nil || len(t.SensitiveArgs) == 0 {
+		return string(line)
+	}
+	var frame map[string]interface{}
+	if err := json.Unmarshal(line, &frame); err != nil {
+		return redacted
+	}
+	params, _ := frame["params"].(map[string]interface{})
+	args, _ := params["arguments"].(map[string]interface{})
+	for _, name := range t.SensitiveArgs {
+		if _, ok := args[name]; ok {
+			args[name] = redacted
+		}
+	}
+	out, err := json.Marshal(frame)
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+// debugResponse renders a response frame for the Debug log, leaving out
+// the result of a SensitiveResult tool.
+func (s *Server) debugResponse(req *MCPRequest, resp *MCPResponse) string {
+	if t := s.calledTool(req); t != nil && t.SensitiveResult && resp.Result != nil {
+		scrubbed := *resp
+		scrubbed.Result = redacted
+		resp = &scrubbed
+	}
+	out, _ := json.Marshal(resp)
+	return string(out)
+}
+
+// scrubError blanks t's sensitive argument values in err's text, for
+// daemons or proxies that quote a request body back in an error. err
+// is returned as is when there is nothing to scrub.
+func (t *Tool) scrubError(args map[string]interface{}, err error) error {
+	msg := err.Error()
+	scrubbed := msg
+	for _, name := range t.SensitiveArgs {
+		if v, _ := args[name].(string); v != "" {
+			scrubbed = strings.ReplaceAll(scrubbed, v, redacted)
+		}
+	}
+	if scrubbed == msg {
+		return err
+	}
+	return errors.New(scrubbed)
+}
@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretValue = "hunter2-secret-value"
+
+func TestRedact_SecretsStayOutOfDebugLogsAndErrors(t *testing.T) {
+	var logs bytes.Buffer
+	prev := log.Writer()
+	log.SetOutput(&logs)
+	defer log.SetOutput(prev)
+
+	s := newLoggingTestServer(t, true)
+	s.tool("set_container_secret").Handler = func(_ API, args map[string]interface{}) (string, error) {
+		// A daemon that quotes the request body back in its error.
+		return "", errors.New(`bad request: {"value":"` + args["value"].(string) + `"}`)
+	}
+	s.tool("get_secret").Handler = func(_ API, _ map[string]interface{}) (string, error) {
+		return testSecretValue, nil
+	}
+
+	frames := serveLines(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"set_container_secret","arguments":{"username":"alice","name":"DB_PASSWORD","value":"`+testSecretValue+`"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_secret","arguments":{"username":"alice","name":"DB_PASSWORD"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"set_secret","arguments":{"username":"alice","name":"X", "value":"`+testSecretValue+`"`,
+	)
+
+	assert.NotContains(t, logs.String(), testSecretValue)
+	assert.Contains(t, logs.String(), `"username":"alice"`, "non-sensitive arguments are still logged")
+	assert.Contains(t, logs.String(), "unparseable frame")
+
+	var setErr, getResult string
+	for _, f := range frames {
+		switch {
+		case f["id"] == float64(1) && f["error"] != nil:
+			setErr = f["error"].(map[string]interface{})["message"].(string)
+		case f["id"] == float64(2):
+			getResult = f["result"].(map[string]interface{})["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+		default:
+			if params, ok := f["params"].(map[string]interface{}); ok {
+				data, _ := params["data"].(map[string]interface{})
+				errText, _ := data["error"].(string)
+				assert.NotContains(t, errText, testSecretValue, "tool event leaks the value")
+			}
+		}
+	}
+	require.NotEmpty(t, setErr)
+	assert.NotContains(t, setErr, testSecretValue)
+	assert.Contains(t, setErr, redacted)
+	// get_secret hands the value to the client by design; only the
+	// Debug log leaves it out.
+	assert.Equal(t, testSecretValue, getResult)
+}
+
+func TestRedact_DebugRequestLeavesOtherToolsAlone(t *testing.T) {
+	s := newLoggingTestServer(t, true)
+	line := []byte(listContainersCall)
+	req := &MCPRequest{Method: "tools/call", Params: map[string]interface{}{"name": "list_containers"}}
+	assert.Equal(t, string(line), s.debugRequest(line, req))
+}
+
+func TestScrubError(t *testing.T) {
+	tool := &Tool{SensitiveArgs: []string{"value"}}
+	err := errors.New("plain failure")
+	assert.Same(t, err, tool.scrubError(map[string]interface{}{"value": testSecretValue}, err))
+
+	got := tool.scrubError(map[string]interface{}{"value": testSecretValue}, errors.New("echo "+testSecretValue))
+	assert.Equal(t, "echo "+redacted, got.Error())
+	assert.False(t, strings.Contains(got.Error(), testSecretValue))
+}
@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MCP resources expose read-only container state an agent can pull into
+// its context without a tool call: the inventory at
+// containarium://containers and one entry per container at
+// containarium://containers/{username}. Both are served from the same
+// client calls as list_containers / get_container.
+
+const (
+	resourceScheme        = "containarium://"
+	containersResourceURI = resourceScheme + "containers"
+	resourceMIMEType      = "application/json"
+)
+
+// Error code for resources/read of a URI we don't serve, as the MCP spec
+// recommends.
+const errCodeResourceNotFound = -32002
+
+func containerResourceURI(username string) string {
+	return containersResourceURI + "/" + url.PathEscape(username)
+}
+
+// handleResourcesList handles the resources/list request. The container
+// entries come from a live ListContainers, so the listing is only as
+// fresh as the call; a token without containers:read sees no resources,
+// the same way tools/list hides list_containers from it.
+func (s *Server) handleResourcesList(req *MCPRequest) *MCPResponse {
+	resources := []map[string]interface{}{}
+	if !auth.HasScope(s.allowedScopes(), auth.ScopeContainersRead) {
+		return &MCPResponse{JSONRPC: "2.0", ID: req.ID, Result: map[string]interface{}{"resources": resources}}
+	}
+
+	resources = append(resources, map[string]interface{}{
+		"uri":         containersResourceURI,
+		"name":        "Container inventory",
+		"description": "All containers with their state, resources and network info",
+		"mimeType":    resourceMIMEType,
+	})
+
+	list, err := s.client.ListContainers()
+	if err != nil {
+		return s.createErrorResponse(req.ID, -32603, fmt.Sprintf("Failed to list containers: %v", err), err.Error())
+	}
+	for _, c := range list.Containers {
+		resources = append(resources, map[string]interface{}{
+			"uri":         containerResourceURI(c.Username),
+			"name":        c.Name,
+			"description": fmt.Sprintf("Status of %s's container (%s)", c.Username, c.State),
+			"mimeType":    resourceMIMEType,
+		})
+	}
+
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: map[string]interface{}{
+			"resources": resources,
+		},
+	}
+}
+
+// handleResourcesRead handles the resources/read request.
+func (s *Server) handleResourcesRead(req *MCPRequest) *MCPResponse {
+	var params struct {
+		URI string `json:"uri"`
+	}
+	paramsJSON, err := json.Marshal(req.Params)
+	if err != nil {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+	}
+	if err := json.Unmarshal(paramsJSON, &params); err != nil {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+	}
+	if params.URI == "" {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", "uri is required")
+	}
+
+	username, ok := parseContainerResourceURI(params.URI)
+	if !ok {
+		return s.createErrorResponse(req.ID, errCodeResourceNotFound, "Resource not found", params.URI)
+	}
+	if !auth.HasScope(s.allowedScopes(), auth.ScopeContainersRead) {
+		return s.createErrorResponse(req.ID, -32603,
+			fmt.Sprintf("Resource %q requires scope %q which the current token does not grant", params.URI, auth.ScopeContainersRead),
+			"insufficient scope")
+	}
+
+	var body proto.Message
+	if username == "" {
+		body, err = s.client.ListContainers()
+	} else {
+		body, err = s.client.GetContainer(username)
+	}
+	if err != nil {
+		return s.createErrorResponse(req.ID, -32603, fmt.Sprintf("Failed to read %s: %v", params.URI, err), err.Error())
+	}
+	// Same JSON the daemon's REST API serves, so a resource reads like the
+	// response it came from.
+	text, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(body)
+	if err != nil {
+		return s.createErrorResponse(req.ID, -32603, "Failed to encode resource", err.Error())
+	}
+
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: map[string]interface{}{
+			"contents": []map[string]interface{}{
+				{
+					"uri":      params.URI,
+					"mimeType": resourceMIMEType,
+					"text":     string(text),
+				},
+			},
+		},
+	}
+}
+
+// parseContainerResourceURI maps a resource URI to the container it
+// names: ("", true) for the inventory, (username, true) for one
+// container, ok=false for anything else.
+func parseContainerResourceURI(uri string) (username string, ok bool) {
+	if uri == containersResourceURI {
+		return "", true
+	}
+	rest, found := strings.CutPrefix(uri, containersResourceURI+"/")
+	if !found || rest == "" || strings.Contains(rest, "/") {
+		return "", false
+	}
+	username, err := url.PathUnescape(rest)
+	if err != nil || username == "" {
+		return "", false
+	}
+	return username, true
+}
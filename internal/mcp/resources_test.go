@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func newResourcesTestServer(t *testing.T, token string) *Server {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/containers":
+			_, _ = io.WriteString(w, `{"containers":[{"name":"alice-container","username":"alice","state":"CONTAINER_STATE_RUNNING","createdAt":"1771122760"},{"name":"bob-container","username":"bob","state":"CONTAINER_STATE_STOPPED","createdAt":"1771122800"}],"totalCount":2}`)
+		case "/v1/containers/alice":
+			_, _ = io.WriteString(w, `{"container":{"name":"alice-container","username":"alice","state":"CONTAINER_STATE_RUNNING","createdAt":"1771122760"}}`)
+		default:
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(api.Close)
+	return &Server{config: &Config{}, client: NewClient(api.URL, token)}
+}
+
+func TestHandleResourcesList(t *testing.T) {
+	server := newResourcesTestServer(t, "test-token")
+
+	resp := server.handleRequest(&MCPRequest{JSONRPC: "2.0", ID: 1, Method: "resources/list"})
+	require.Nil(t, resp.Error)
+
+	resources := resp.Result.(map[string]interface{})["resources"].([]map[string]interface{})
+	require.Len(t, resources, 3)
+	assert.Equal(t, "containarium://containers", resources[0]["uri"])
+	assert.Equal(t, "containarium://containers/alice", resources[1]["uri"])
+	assert.Equal(t, "containarium://containers/bob", resources[2]["uri"])
+	for _, r := range resources {
+		assert.Equal(t, "application/json", r["mimeType"])
+	}
+}
+
+func TestHandleResourcesList_HiddenWithoutContainersRead(t *testing.T) {
+	tok := makeUnsignedJWT(t, map[string]interface{}{"scopes": []string{"secrets:read"}})
+	server := newResourcesTestServer(t, tok)
+
+	resp := server.handleRequest(&MCPRequest{JSONRPC: "2.0", ID: 1, Method: "resources/list"})
+	require.Nil(t, resp.Error)
+	assert.Empty(t, resp.Result.(map[string]interface{})["resources"])
+}
+
+func TestHandleResourcesRead(t *testing.T) {
+	server := newResourcesTestServer(t, "test-token")
+
+	read := func(uri string) *MCPResponse {
+		return server.handleRequest(&MCPRequest{
+			JSONRPC: "2.0", ID: 1, Method: "resources/read",
+			Params: map[string]interface{}{"uri": uri},
+		})
+	}
+
+	resp := read("containarium://containers/alice")
+	require.Nil(t, resp.Error)
+	contents := resp.Result.(map[string]interface{})["contents"].([]map[string]interface{})
+	require.Len(t, contents, 1)
+	assert.Equal(t, "containarium://containers/alice", contents[0]["uri"])
+	got := &GetContainerResponse{}
+	require.NoError(t, unmarshalProto([]byte(contents[0]["text"].(string)), got))
+	assert.Equal(t, pb.ContainerState_CONTAINER_STATE_RUNNING, got.Container.State)
+
+	resp = read("containarium://containers")
+	require.Nil(t, resp.Error)
+	contents = resp.Result.(map[string]interface{})["contents"].([]map[string]interface{})
+	list := &ListContainersResponse{}
+	require.NoError(t, unmarshalProto([]byte(contents[0]["text"].(string)), list))
+	assert.Equal(t, int32(2), list.TotalCount)
+
+	for _, uri := range []string{"containarium://routes", "containarium://containers/a/b", "file:///etc/passwd"} {
+		resp = read(uri)
+		require.NotNil(t, resp.Error, uri)
+		assert.Equal(t, errCodeResourceNotFound, resp.Error.Code, uri)
+	}
+
+	resp = read("containarium://containers/carol")
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32603, resp.Error.Code)
+}
+
+func TestHandleResourcesRead_RequiresContainersRead(t *testing.T) {
+	tok := makeUnsignedJWT(t, map[string]interface{}{"scopes": []string{"secrets:read"}})
+	server := newResourcesTestServer(t, tok)
+
+	resp := server.handleRequest(&MCPRequest{
+		JSONRPC: "2.0", ID: 1, Method: "resources/read",
+		Params: map[string]interface{}{"uri": "containarium://containers"},
+	})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "insufficient scope", resp.Error.Data)
+}
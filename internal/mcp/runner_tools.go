@@ -158,7 +158,7 @@ func (a *mcpDaemonAPI) ListContainers() ([]incus.ContainerInfo, error) {
 	}
 	out := make([]incus.ContainerInfo, 0, len(resp.Containers))
 	for _, c := range resp.Containers {
-		out = append(out, incus.ContainerInfo{Name: c.Name, State: c.State})
+		out = append(out, incus.ContainerInfo{Name: c.Name, State: c.State.String()})
 	}
 	return out, nil
 }
@@ -168,7 +168,7 @@ func (a *mcpDaemonAPI) GetContainer(username string) (*incus.ContainerInfo, erro
 	if err != nil {
 		return nil, err
 	}
-	return &incus.ContainerInfo{Name: resp.Container.Name, State: resp.Container.State}, nil
+	return &incus.ContainerInfo{Name: resp.GetContainer().GetName(), State: resp.GetContainer().GetState().String()}, nil
 }
 
 func (a *mcpDaemonAPI) DeleteContainer(username string, force bool) error {
@@ -187,24 +187,24 @@ func (a *mcpDaemonAPI) DeleteContainer(username string, force bool) error {
 func buildMCPRunnerDeps(client API, sentinel, sshKeyPath string, withSSH bool) (runner.Deps, string, error) {
 	api := &mcpDaemonAPI{c: client}
 	creator := func(_ context.Context, name, sshPubKey string) (string, string, error) {
-		req := CreateContainerRequest{
+		req := &CreateContainerRequest{
 			Username: name,
 			Resources: &ResourceLimits{
-				CPU:    "4",
+				Cpu:    "4",
 				Memory: "4GB",
 				Disk:   "50GB",
 			},
 			Image:        "images:ubuntu/24.04",
 			EnablePodman: true,
-			SSHKeys:      splitMCPKey(sshPubKey),
+			SshKeys:      splitMCPKey(sshPubKey),
 		}
-		resp, err := client.CreateContainer(req)
+		resp, err := client.CreateContainer(req, "")
 		if err != nil {
 			return "", "", err
 		}
 		// Return the daemon-assigned username (≠ requested name when a
 		// control plane mints one) so the install step SSHes as it. (#482)
-		return resp.Container.Name, resp.Container.Username, nil
+		return resp.GetContainer().GetName(), resp.GetContainer().GetUsername(), nil
 	}
 	boxes := runner.NewDaemonBoxManager(api, creator)
 
@@ -236,7 +236,7 @@ func buildMCPRunnerDeps(client API, sentinel, sshKeyPath string, withSSH bool) (
 				sh := sentinel
 				if sh == "" {
 					if resp, gcErr := client.GetContainer(boxName); gcErr == nil {
-						sh = resp.Container.SSHHost
+						sh = resp.GetContainer().GetSshHost()
 					}
 				}
 				if sh == "" {
@@ -2,19 +2,64 @@ package mcp
 
 import (
 	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/footprintai/containarium/internal/safecast"
 	"github.com/footprintai/containarium/pkg/version"
 )
 
 // Server implements the MCP (Model Context Protocol) server
 type Server struct {
-	config *Config
-	client API
-	tools  []Tool
+	config  *Config
+	client  API
+	tools   []Tool
+	prompts []Prompt
+
+	// disabledTools names the tools the configuration removed from
+	// tools (see applyToolFilter).
+	disabledTools map[string]bool
+
+	// registered is the catalog the configuration enables; tools is the
+	// part of it the daemon's capabilities allow (see capabilities.go).
+	// Once serving, both change only on the serve goroutine.
+	registered   []Tool
+	capabilities map[string]bool
+
+	// outMu guards out, the encoder every response and notification
+	// goes through.
+	outMu sync.Mutex
+	out   *json.Encoder
+
+	// logLevel is the minimum level sent as notifications/message;
+	// logging/setLevel changes it.
+	logLevel atomic.Int32
+
+	// callMu guards inflight, the tools/call being executed, so the
+	// reader can cancel it on notifications/cancelled.
+	callMu   sync.Mutex
+	inflight *inflightCall
+
+	// staleCache answers read-only calls while the daemon is
+	// unreachable; nil unless Config.StaleCache.
+	staleCache *staleCache
+}
+
+// inflightCall is the tools/call currently running.
+type inflightCall struct {
+	id     interface{}
+	cancel context.CancelFunc
 }
 
 // NewServer creates a new MCP server. The backend is selected by newBackend
@@ -23,52 +68,147 @@ type Server struct {
 // gets the OSS daemon backend.
 func NewServer(config *Config) (*Server, error) {
 	server := &Server{
-		config: config,
-		client: newBackend(config),
-		tools:  []Tool{},
+		config:  config,
+		client:  newBackend(config),
+		tools:   []Tool{},
+		prompts: operationalPrompts(),
+	}
+	server.logLevel.Store(safecast.I32(defaultLogLevel(config)))
+	if config.StaleCache {
+		server.staleCache = newStaleCache(cmp.Or(config.StaleCacheTTL, DefaultStaleCacheTTL))
+	}
+	if c := backendClient(server.client); c != nil {
+		c.SetNotifier(server.notify)
 	}
 
 	// Register all tools
 	server.registerTools()
+	server.applyToolFilter()
+	server.registered = server.tools
+	server.applyCapabilities(nil)
 
 	return server, nil
 }
 
 // Start starts the MCP server (reads from stdin, writes to stdout)
 func (s *Server) Start() error {
-	scanner := bufio.NewScanner(os.Stdin)
-	encoder := json.NewEncoder(os.Stdout)
+	s.updateCapabilities(probeCapabilities(context.Background(), backendClient(s.client)))
+	return s.serve(os.Stdin, os.Stdout)
+}
 
-	for scanner.Scan() {
-		line := scanner.Bytes()
+func (s *Server) serve(in io.Reader, out io.Writer) error {
+	s.outMu.Lock()
+	s.out = json.NewEncoder(out)
+	s.outMu.Unlock()
+
+	// Requests run one at a time, but input is read on its own goroutine
+	// so a notifications/cancelled can reach a call that is still
+	// blocked (poll_events).
+	lines := make(chan []byte)
+	var scanErr error
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			line := bytes.Clone(scanner.Bytes())
+			if id, ok := cancelledRequestID(line); ok {
+				s.cancelCall(id)
+				continue
+			}
+			lines <- line
+		}
+		scanErr = scanner.Err()
+	}()
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	probes := s.watchCapabilities(ctx, s.config.CapabilityProbeInterval)
+
+	for {
+		select {
+		case caps := <-probes:
+			s.updateCapabilities(caps)
+		case line, ok := <-lines:
+			if !ok {
+				if scanErr != nil {
+					return fmt.Errorf("scanner error: %w", scanErr)
+				}
+				return nil
+			}
+			s.handleLine(line)
+		}
+	}
+}
 
+// handleLine answers one JSON-RPC frame from the client.
+func (s *Server) handleLine(line []byte) {
+	var request MCPRequest
+	if err := json.Unmarshal(line, &request); err != nil {
+		// An unparseable frame can't be redacted, so only its size
+		// is logged.
 		if s.config.Debug {
-			log.Printf("Received: %s", string(line))
+			log.Printf("Received: unparseable frame (%d bytes)", len(line))
 		}
+		s.sendError(nil, -32700, "Parse error", err.Error())
+		return
+	}
+	if s.config.Debug {
+		log.Printf("Received: %s", s.debugRequest(line, &request))
+	}
 
-		var request MCPRequest
-		if err := json.Unmarshal(line, &request); err != nil {
-			s.sendError(encoder, nil, -32700, "Parse error", err.Error())
-			continue
-		}
+	response := s.handleRequest(&request)
+	if response == nil {
+		return // cancelled: the client no longer wants a reply
+	}
+	if err := s.write(response); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+		return
+	}
 
-		response := s.handleRequest(&request)
-		if err := encoder.Encode(response); err != nil {
-			log.Printf("Failed to encode response: %v", err)
-			continue
-		}
+	if s.config.Debug {
+		log.Printf("Sent: %s", s.debugResponse(&request, response))
+	}
+}
 
-		if s.config.Debug {
-			respJSON, _ := json.Marshal(response)
-			log.Printf("Sent: %s", string(respJSON))
-		}
+// cancelledRequestID reports whether line is a notifications/cancelled
+// and, if so, which request it cancels.
+func cancelledRequestID(line []byte) (interface{}, bool) {
+	var n struct {
+		Method string `json:"method"`
+		Params struct {
+			RequestID interface{} `json:"requestId"`
+		} `json:"params"`
+	}
+	if json.Unmarshal(line, &n) != nil || n.Method != "notifications/cancelled" {
+		return nil, false
 	}
+	return n.Params.RequestID, true
+}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("scanner error: %w", err)
+// beginCall registers the tools/call id as in flight and returns the
+// context its handler runs under.
+func (s *Server) beginCall(id interface{}) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.callMu.Lock()
+	s.inflight = &inflightCall{id: id, cancel: cancel}
+	s.callMu.Unlock()
+	return ctx, func() {
+		s.callMu.Lock()
+		s.inflight = nil
+		s.callMu.Unlock()
+		cancel()
 	}
+}
 
-	return nil
+// cancelCall cancels the in-flight tools/call if its id matches. IDs are
+// compared by their JSON text, since both sides decode numbers as
+// float64 but a client may send a string.
+func (s *Server) cancelCall(id interface{}) {
+	s.callMu.Lock()
+	defer s.callMu.Unlock()
+	if s.inflight != nil && fmt.Sprint(s.inflight.id) == fmt.Sprint(id) {
+		s.inflight.cancel()
+	}
 }
 
 // handleRequest handles an MCP request
@@ -80,6 +220,16 @@ func (s *Server) handleRequest(req *MCPRequest) *MCPResponse {
 		return s.handleToolsList(req)
 	case "tools/call":
 		return s.handleToolsCall(req)
+	case "resources/list":
+		return s.handleResourcesList(req)
+	case "resources/read":
+		return s.handleResourcesRead(req)
+	case "prompts/list":
+		return s.handlePromptsList(req)
+	case "prompts/get":
+		return s.handlePromptsGet(req)
+	case "logging/setLevel":
+		return s.handleSetLevel(req)
 	default:
 		return s.createErrorResponse(req.ID, -32601, "Method not found", fmt.Sprintf("Unknown method: %s", req.Method))
 	}
@@ -93,7 +243,12 @@ func (s *Server) handleInitialize(req *MCPRequest) *MCPResponse {
 		Result: map[string]interface{}{
 			"protocolVersion": "2024-11-05",
 			"capabilities": map[string]interface{}{
-				"tools": map[string]bool{},
+				// The tool list follows the daemon's capabilities, so
+				// it can change mid-session (see capabilities.go).
+				"tools":     map[string]bool{"listChanged": true},
+				"resources": map[string]bool{},
+				"prompts":   map[string]bool{},
+				"logging":   map[string]bool{},
 			},
 			"serverInfo": map[string]interface{}{
 				"name":    "containarium-mcp-server",
@@ -109,27 +264,87 @@ func (s *Server) handleInitialize(req *MCPRequest) *MCPResponse {
 // satisfy. A nil/missing scopes claim is treated as "no
 // restriction" (backwards compat for pre-1.7 tokens), in
 // which case every registered tool is returned.
+//
+// Pagination follows the MCP cursor convention: an opaque
+// `cursor` param in, `nextCursor` out while more remain. The
+// page size comes from Config.ToolsPageSize; 0 keeps the whole
+// (filtered) catalog on one page, which is what clients that
+// predate pagination expect. Paging runs AFTER scope
+// filtering, so a cursor indexes the caller's own view.
 func (s *Server) handleToolsList(req *MCPRequest) *MCPResponse {
+	var params struct {
+		Cursor string `json:"cursor"`
+	}
+	if req.Params != nil {
+		paramsJSON, err := json.Marshal(req.Params)
+		if err != nil {
+			return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+		}
+		if err := json.Unmarshal(paramsJSON, &params); err != nil {
+			return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+		}
+	}
+
 	granted := s.allowedScopes()
-	tools := make([]map[string]interface{}, 0, len(s.tools))
+	visible := make([]*Tool, 0, len(s.tools))
 	for i := range s.tools {
-		if !toolAllowed(granted, &s.tools[i]) {
-			continue
+		if toolAllowed(granted, &s.tools[i]) {
+			visible = append(visible, &s.tools[i])
 		}
+	}
+
+	start, err := decodeToolsCursor(params.Cursor, len(visible))
+	if err != nil {
+		return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
+	}
+	end := len(visible)
+	if s.config != nil && s.config.ToolsPageSize > 0 && start+s.config.ToolsPageSize < end {
+		end = start + s.config.ToolsPageSize
+	}
+
+	tools := make([]map[string]interface{}, 0, end-start)
+	for _, tool := range visible[start:end] {
 		tools = append(tools, map[string]interface{}{
-			"name":        s.tools[i].Name,
-			"description": s.tools[i].Description,
-			"inputSchema": s.tools[i].InputSchema,
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": tool.InputSchema,
+			"annotations": tool.Annotations.toMap(),
 		})
 	}
 
+	result := map[string]interface{}{
+		"tools": tools,
+	}
+	if end < len(visible) {
+		result["nextCursor"] = encodeToolsCursor(end)
+	}
 	return &MCPResponse{
 		JSONRPC: "2.0",
 		ID:      req.ID,
-		Result: map[string]interface{}{
-			"tools": tools,
-		},
+		Result:  result,
+	}
+}
+
+// encodeToolsCursor / decodeToolsCursor wrap a tools/list offset.
+// Opaque to clients per the MCP spec; base64 just keeps them from
+// being mistaken for something meaningful.
+func encodeToolsCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeToolsCursor(cursor string, total int) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 || offset > total {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
 	}
+	return offset, nil
 }
 
 // handleToolsCall handles the tools/call request
@@ -149,15 +364,17 @@ func (s *Server) handleToolsCall(req *MCPRequest) *MCPResponse {
 		return s.createErrorResponse(req.ID, -32602, "Invalid params", err.Error())
 	}
 
-	// Find tool
-	var tool *Tool
-	for i := range s.tools {
-		if s.tools[i].Name == params.Name {
-			tool = &s.tools[i]
-			break
-		}
+	tool := s.tool(params.Name)
+	if tool == nil && s.disabledTools[params.Name] {
+		return s.createErrorResponse(req.ID, -32602,
+			fmt.Sprintf("Tool '%s' is disabled by server configuration", params.Name),
+			"tool disabled")
+	}
+	if t := s.unavailableTool(params.Name); tool == nil && t != nil {
+		return s.createErrorResponse(req.ID, -32602,
+			fmt.Sprintf("Tool '%s' is not available: the daemon does not offer %s", params.Name, t.Capability),
+			"capability unavailable")
 	}
-
 	if tool == nil {
 		return s.createErrorResponse(req.ID, -32602, "Tool not found", fmt.Sprintf("Tool '%s' not found", params.Name))
 	}
@@ -173,7 +390,46 @@ func (s *Server) handleToolsCall(req *MCPRequest) *MCPResponse {
 	}
 
 	// Execute tool
-	result, err := tool.Handler(s.client, params.Arguments)
+	s.notify(logDebug, "tools", map[string]interface{}{"event": "started", "tool": tool.Name})
+	start := time.Now()
+	var result string
+	if tool.ContextHandler != nil {
+		ctx, done := s.beginCall(req.ID)
+		result, err = tool.ContextHandler(ctx, s.client, params.Arguments)
+		cancelled := ctx.Err() != nil
+		done()
+		if cancelled {
+			s.notify(logInfo, "tools", map[string]interface{}{"event": "cancelled", "tool": tool.Name})
+			return nil
+		}
+	} else {
+		result, err = tool.Handler(s.client, params.Arguments)
+	}
+	finished := map[string]interface{}{
+		"event":       "finished",
+		"tool":        tool.Name,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	stale := false
+	if s.staleCache != nil {
+		s.staleCache.record(tool.Name, params.Arguments, result, err)
+		if cached, ok := s.staleCache.serve(tool.Name, params.Arguments, err); ok {
+			finished["stale"] = true
+			finished["error"] = err.Error()
+			result, err, stale = cached, nil, true
+		}
+	}
+	if err != nil {
+		err = tool.scrubError(params.Arguments, explainAPIError(err, params.Arguments))
+	}
+	if err != nil {
+		finished["error"] = err.Error()
+		s.notify(logWarning, "tools", finished)
+	} else if stale {
+		s.notify(logWarning, "tools", finished)
+	} else {
+		s.notify(logInfo, "tools", finished)
+	}
 	if err != nil {
 		// Surface the actual error message in `message` so it reaches MCP
 		// clients that only render the top-level `message` field (most do,
@@ -214,9 +470,9 @@ func (s *Server) createErrorResponse(id interface{}, code int, message, data str
 }
 
 // sendError sends an error response
-func (s *Server) sendError(encoder *json.Encoder, id interface{}, code int, message, data string) {
+func (s *Server) sendError(id interface{}, code int, message, data string) {
 	response := s.createErrorResponse(id, code, message, data)
-	if err := encoder.Encode(response); err != nil {
+	if err := s.write(response); err != nil {
 		log.Printf("Failed to encode error response: %v", err)
 	}
 }
@@ -22,8 +22,8 @@ func TestServerCreation(t *testing.T) {
 	assert.NotNil(t, server)
 	assert.Equal(t, config, server.config)
 	assert.NotNil(t, server.client)
-	// 30 base (+check_for_updates +upgrade_backend +get_upgrade_status, #354) + 3 runner-provision + 4 compose-autostart (#325) + 2 recipes + 3 backups + connect (#453) + 2 agent-skills (#562) + call_agent (#570) + 2 crews (#584) + delete_route + install_zap (#960) + set_metrics_export + get_metrics_export (#1069).
-	assert.Len(t, server.tools, 58, "Should have 58 tools registered")
+	// 36 base (+check_for_updates +upgrade_backend +get_upgrade_status, #354) + 3 runner-provision + 4 compose-autostart (#325) + 2 recipes + 3 backups + connect (#453) + 2 agent-skills (#562) + call_agent (#570) + 2 crews (#584) + delete_route + install_zap (#960) + set_metrics_export + get_metrics_export (#1069) + 3 snapshots + wait_for_container_ready + poll_events + list_container_templates + get_listening_ports + get_container_network + test_connectivity + get_container_processes + container_activity_report + diff_containers + 3 container secrets + 3 container files.
+	assert.Len(t, server.tools, 76, "Should have 76 tools registered")
 }
 
 // TestServerTools tests tool registration
@@ -88,7 +88,11 @@ func TestHandleInitialize(t *testing.T) {
 
 	assert.Equal(t, "2024-11-05", result["protocolVersion"])
 	assert.NotNil(t, result["serverInfo"])
-	assert.NotNil(t, result["capabilities"])
+	capabilities := result["capabilities"].(map[string]interface{})
+	assert.Contains(t, capabilities, "tools")
+	assert.Contains(t, capabilities, "resources")
+	assert.Contains(t, capabilities, "prompts")
+	assert.Contains(t, capabilities, "logging")
 
 	// Check server info — version comes from pkg/version (settable
 	// via ldflags). Don't assert an exact value (would have to be
@@ -127,8 +131,8 @@ func TestHandleToolsList(t *testing.T) {
 
 	tools, ok := result["tools"].([]map[string]interface{})
 	require.True(t, ok)
-	// 30 base (+check_for_updates +upgrade_backend +get_upgrade_status, #354) + 3 runner-provision + 4 compose-autostart (#325) + 2 recipes + 3 backups + connect (#453) + 2 agent-skills (#562) + call_agent (#570) + 2 crews (#584) + delete_route + install_zap (#960) + set_metrics_export + get_metrics_export (#1069).
-	assert.Len(t, tools, 58)
+	// 36 base (+check_for_updates +upgrade_backend +get_upgrade_status, #354) + 3 runner-provision + 4 compose-autostart (#325) + 2 recipes + 3 backups + connect (#453) + 2 agent-skills (#562) + call_agent (#570) + 2 crews (#584) + delete_route + install_zap (#960) + set_metrics_export + get_metrics_export (#1069) + 3 snapshots + wait_for_container_ready + poll_events + list_container_templates + get_listening_ports + get_container_network + test_connectivity + get_container_processes + container_activity_report + diff_containers + 3 container secrets + 3 container files.
+	assert.Len(t, tools, 76)
 
 	// Check first tool structure
 	firstTool := tools[0]
@@ -486,3 +490,49 @@ func TestConfigValidation(t *testing.T) {
 		})
 	}
 }
+
+// TestHandleToolsList_Pagination walks the catalog with a small page size
+// and checks the pages stitch back into the unpaginated listing.
+func TestHandleToolsList_Pagination(t *testing.T) {
+	server, err := NewServer(&Config{
+		ServerURL:     "http://localhost:8080",
+		JWTToken:      "test-token",
+		ToolsPageSize: 25,
+	})
+	require.NoError(t, err)
+
+	var names []string
+	cursor := ""
+	for page := 0; ; page++ {
+		require.Less(t, page, 10, "pagination did not terminate")
+		params := map[string]interface{}{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		resp := server.handleRequest(&MCPRequest{JSONRPC: "2.0", ID: page, Method: "tools/list", Params: params})
+		require.Nil(t, resp.Error)
+		result := resp.Result.(map[string]interface{})
+		tools := result["tools"].([]map[string]interface{})
+		assert.LessOrEqual(t, len(tools), 25)
+		for _, tool := range tools {
+			names = append(names, tool["name"].(string))
+			annotations, ok := tool["annotations"].(map[string]interface{})
+			require.True(t, ok, "tool %v has no annotations", tool["name"])
+			assert.NotEmpty(t, annotations["category"])
+		}
+		next, more := result["nextCursor"].(string)
+		if !more {
+			break
+		}
+		cursor = next
+	}
+
+	require.Len(t, names, len(server.tools))
+	for i := range server.tools {
+		assert.Equal(t, server.tools[i].Name, names[i])
+	}
+
+	resp := server.handleRequest(&MCPRequest{JSONRPC: "2.0", ID: 99, Method: "tools/list", Params: map[string]interface{}{"cursor": "not-a-cursor"}})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32602, resp.Error.Code)
+}
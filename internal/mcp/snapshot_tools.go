@@ -0,0 +1,163 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// snapshotTools is the MCP-side catalog for incus container snapshots.
+// Same shape as backupTools(): thin wrappers over the ContainerService
+// snapshot endpoints under /v1/containers/{username}/snapshots.
+//
+// Snapshots cover the whole container filesystem (unlike create_backup,
+// which dumps one database), so they're the undo button for an agent
+// about to do something risky inside a box.
+func snapshotTools() []Tool {
+	return []Tool{
+		{
+			Name: "snapshot_container",
+			Description: "Take a point-in-time snapshot of a user's container " +
+				"filesystem. Cheap on copy-on-write storage pools, so take one " +
+				"before risky changes (package upgrades, migrations, config " +
+				"rewrites) and roll back with restore_container if they go wrong. " +
+				"Returns the snapshot name, creation time, and size when the " +
+				"storage pool reports it.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to snapshot.",
+					},
+					"snapshot_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Snapshot name: letters, digits, '.', '_' or '-' (max 63 chars), e.g. 'pre-upgrade'.",
+					},
+				},
+				"required": []string{"username", "snapshot_name"},
+			},
+			Handler: handleSnapshotContainer,
+		},
+		{
+			Name: "list_snapshots",
+			Description: "List a user's container snapshots (oldest first) with " +
+				"their creation time and size. Use this to discover snapshot " +
+				"names for restore_container.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container snapshots to list.",
+					},
+				},
+				"required": []string{"username"},
+			},
+			Handler: handleListSnapshots,
+		},
+		{
+			Name: "restore_container",
+			Description: "Roll a user's container back to a snapshot. DESTRUCTIVE: " +
+				"every filesystem change made after the snapshot is discarded, " +
+				"and a running container is restarted. Discover snapshot names " +
+				"with list_snapshots.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username whose container to restore.",
+					},
+					"snapshot_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Snapshot to restore (see list_snapshots).",
+					},
+				},
+				"required": []string{"username", "snapshot_name"},
+			},
+			Handler: handleRestoreContainer,
+		},
+	}
+}
+
+func handleSnapshotContainer(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	snapshotName := getStringArg(args, "snapshot_name", "")
+	if username == "" || snapshotName == "" {
+		return "", fmt.Errorf("username and snapshot_name are required")
+	}
+
+	resp, err := client.SnapshotContainer(username, snapshotName)
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot container: %w", err)
+	}
+	out := fmt.Sprintf("✅ %s\n", resp.Message)
+	if s := resp.Snapshot; s != nil {
+		out += formatSnapshotDetail(s)
+	}
+	return out, nil
+}
+
+func handleListSnapshots(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+
+	resp, err := client.ListSnapshots(username)
+	if err != nil {
+		return "", fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	if len(resp.Snapshots) == 0 {
+		return fmt.Sprintf("No snapshots found for %s.", username), nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-32s %-22s %-10s %s\n", "NAME", "CREATED", "SIZE", "STATEFUL")
+	for _, s := range resp.Snapshots {
+		fmt.Fprintf(&b, "%-32s %-22s %-10s %t\n", s.Name, snapshotCreatedLabel(s.CreatedAt), snapshotSizeLabel(s.SizeBytes), s.Stateful)
+	}
+	return b.String(), nil
+}
+
+func handleRestoreContainer(client API, args map[string]interface{}) (string, error) {
+	username := getStringArg(args, "username", "")
+	snapshotName := getStringArg(args, "snapshot_name", "")
+	if username == "" || snapshotName == "" {
+		return "", fmt.Errorf("username and snapshot_name are required")
+	}
+
+	resp, err := client.RestoreContainer(username, snapshotName)
+	if err != nil {
+		return "", fmt.Errorf("failed to restore container: %w", err)
+	}
+	out := fmt.Sprintf("✅ %s\n", resp.Message)
+	if s := resp.Snapshot; s != nil {
+		out += formatSnapshotDetail(s)
+	}
+	return out, nil
+}
+
+func formatSnapshotDetail(s *ContainerSnapshot) string {
+	out := fmt.Sprintf("Name:     %s\n", s.Name)
+	out += fmt.Sprintf("Created:  %s\n", snapshotCreatedLabel(s.CreatedAt))
+	out += fmt.Sprintf("Size:     %s\n", snapshotSizeLabel(s.SizeBytes))
+	return out
+}
+
+// snapshotCreatedLabel renders unix seconds as UTC.
+func snapshotCreatedLabel(unix int64) string {
+	if unix <= 0 {
+		return "-"
+	}
+	return time.Unix(unix, 0).UTC().Format("2006-01-02 15:04:05Z")
+}
+
+// snapshotSizeLabel renders a snapshot size. Zero means the storage pool
+// didn't report one (dir pools, for instance), not an empty snapshot.
+func snapshotSizeLabel(n int64) string {
+	if n <= 0 {
+		return "unknown"
+	}
+	return humanBytes(n)
+}
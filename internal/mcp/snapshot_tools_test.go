@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// The snapshot tools hit the ContainerService gateway paths and decode its
+// string-encoded int64s; a mismatch on either side only shows up against a
+// real daemon, so pin both here.
+func TestSnapshotTools_WireFormat(t *testing.T) {
+	t.Setenv("CONTAINARIUM_MCP_ALLOW_INSECURE", "true")
+	var gotMethod, gotPath, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = io.WriteString(w, `{"snapshots":[{"name":"pre-upgrade","createdAt":"1771122760","sizeBytes":"2147483648"},{"name":"dirpool","createdAt":"1771122800"}]}`)
+		default:
+			_, _ = io.WriteString(w, `{"message":"ok","snapshot":{"name":"pre-upgrade","createdAt":"1771122760","sizeBytes":"1048576"}}`)
+		}
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, "test-token")
+
+	out, err := handleSnapshotContainer(client, map[string]interface{}{"username": "alice", "snapshot_name": "pre-upgrade"})
+	if err != nil {
+		t.Fatalf("snapshot_container: %v", err)
+	}
+	if gotMethod != "POST" || gotPath != "/v1/containers/alice/snapshots" {
+		t.Errorf("snapshot_container hit %s %s", gotMethod, gotPath)
+	}
+	var body map[string]string
+	if err := json.Unmarshal([]byte(gotBody), &body); err != nil || body["snapshot_name"] != "pre-upgrade" {
+		t.Errorf("snapshot_container body = %q", gotBody)
+	}
+	if !strings.Contains(out, "2026-02-15") || !strings.Contains(out, "1 MiB") {
+		t.Errorf("snapshot_container output missing metadata:\n%s", out)
+	}
+
+	out, err = handleListSnapshots(client, map[string]interface{}{"username": "alice"})
+	if err != nil {
+		t.Fatalf("list_snapshots: %v", err)
+	}
+	if !strings.Contains(out, "2 GiB") || !strings.Contains(out, "unknown") {
+		t.Errorf("list_snapshots output should show the reported size and 'unknown' for a missing one:\n%s", out)
+	}
+
+	if _, err := handleRestoreContainer(client, map[string]interface{}{"username": "alice", "snapshot_name": "pre-upgrade"}); err != nil {
+		t.Fatalf("restore_container: %v", err)
+	}
+	if gotMethod != "POST" || gotPath != "/v1/containers/alice/snapshots/pre-upgrade/restore" {
+		t.Errorf("restore_container hit %s %s", gotMethod, gotPath)
+	}
+}
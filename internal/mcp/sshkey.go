@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/pem"
 	"fmt"
+	"sync"
 
 	"golang.org/x/crypto/ssh"
 )
@@ -45,3 +46,39 @@ func generateEphemeralSSHKey(label string) (publicKeyAuthorizedKeys string, priv
 
 	return publicKeyAuthorizedKeys, privateKeyPEM, nil
 }
+
+// idempotentSSHKeys remembers the keypair create_container generated for
+// each (username, idempotency_key). Repeating a create under the same key
+// must send the same request, since the daemon rejects a key reused for a
+// different one, and the caller needs the private half that went in
+// first. Kept for the life of the MCP server process.
+var idempotentSSHKeys = struct {
+	sync.Mutex
+	keys map[string]ephemeralSSHKey
+}{keys: make(map[string]ephemeralSSHKey)}
+
+type ephemeralSSHKey struct {
+	public  string
+	private []byte
+}
+
+// ephemeralSSHKeyFor is generateEphemeralSSHKey for a create under
+// idempotencyKey: a repeated call gets the keypair of the first. An empty
+// key always generates a fresh one.
+func ephemeralSSHKeyFor(username, idempotencyKey, label string) (string, []byte, error) {
+	if idempotencyKey == "" {
+		return generateEphemeralSSHKey(label)
+	}
+	idempotentSSHKeys.Lock()
+	defer idempotentSSHKeys.Unlock()
+	id := username + "\x00" + idempotencyKey
+	if k, ok := idempotentSSHKeys.keys[id]; ok {
+		return k.public, k.private, nil
+	}
+	pub, priv, err := generateEphemeralSSHKey(label)
+	if err != nil {
+		return "", nil, err
+	}
+	idempotentSSHKeys.keys[id] = ephemeralSSHKey{public: pub, private: priv}
+	return pub, priv, nil
+}
@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultStaleCacheTTL is LoadConfig's StaleCacheTTL.
+const DefaultStaleCacheTTL = 15 * time.Minute
+
+// staleCacheTools are the read-only tools whose last good answer may stand
+// in for the daemon while it is unreachable. Anything that changes state
+// is left out on purpose: it has to fail fast, not pretend to succeed.
+var staleCacheTools = map[string]bool{
+	"list_containers": true,
+	"get_container":   true,
+	"get_system_info": true,
+}
+
+// daemonUnreachable reports whether err means the daemon didn't answer at
+// all: no HTTP response, or a gateway in front of it reporting it down.
+// A daemon that answered with an error is reachable, and its answer
+// stands.
+func daemonUnreachable(err error) bool {
+	var te *transportError
+	if errors.As(err, &te) {
+		return true
+	}
+	var ae *APIError
+	if errors.As(err, &ae) {
+		switch ae.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+// staleEntry is one tool call's last successful result.
+type staleEntry struct {
+	result string
+	at     time.Time
+}
+
+// staleCache keeps the last successful result of each staleCacheTools
+// call, keyed by tool and arguments, so a daemon restart doesn't turn
+// every read into an error mid-conversation (see Config.StaleCache).
+type staleCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]staleEntry
+	// downSince is when the daemon was first found unreachable since
+	// the last call that reached it; zero while it is reachable.
+	downSince time.Time
+}
+
+func newStaleCache(ttl time.Duration) *staleCache {
+	return &staleCache{ttl: ttl, now: time.Now, entries: map[string]staleEntry{}}
+}
+
+// staleCacheKey identifies a call. encoding/json sorts map keys, so equal
+// arguments give equal keys.
+func staleCacheKey(tool string, args map[string]interface{}) string {
+	b, _ := json.Marshal(args)
+	return tool + " " + string(b)
+}
+
+// record notes how a tool call went: a success (err == nil) proves the
+// daemon is back and, for a cacheable tool, replaces its entry.
+func (c *staleCache) record(tool string, args map[string]interface{}, result string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case err == nil:
+		c.downSince = time.Time{}
+		if staleCacheTools[tool] {
+			c.entries[staleCacheKey(tool, args)] = staleEntry{result: result, at: c.now()}
+		}
+	case daemonUnreachable(err):
+		if c.downSince.IsZero() {
+			c.downSince = c.now()
+		}
+	}
+}
+
+// serve returns the cached result of a call that failed because the daemon
+// is unreachable, labeled as stale. ok is false when err is any other
+// failure, the tool isn't cacheable, or there is no entry younger than the
+// TTL.
+func (c *staleCache) serve(tool string, args map[string]interface{}, err error) (string, bool) {
+	if !staleCacheTools[tool] || !daemonUnreachable(err) {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[staleCacheKey(tool, args)]
+	if !ok {
+		return "", false
+	}
+	if c.now().Sub(e.at) > c.ttl {
+		delete(c.entries, staleCacheKey(tool, args))
+		return "", false
+	}
+	return fmt.Sprintf("STALE — daemon unreachable since %s, data as of %s (%v). "+
+		"Do not act on this as current state; retry once the daemon is back.\n\n%s",
+		c.downSince.UTC().Format(time.RFC3339), e.at.UTC().Format(time.RFC3339), err, e.result), true
+}
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// callTool runs one tools/call through s and returns its text or error
+// message.
+func callTool(t *testing.T, s *Server, name string, args map[string]interface{}) (string, string) {
+	t.Helper()
+	resp := s.handleRequest(&MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params:  map[string]interface{}{"name": name, "arguments": args},
+	})
+	if resp.Error != nil {
+		return "", resp.Error.Message
+	}
+	content := resp.Result.(map[string]interface{})["content"].([]map[string]interface{})
+	return content[0]["text"].(string), ""
+}
+
+func newStaleCacheServer(t *testing.T, url string, ttl time.Duration) *Server {
+	t.Helper()
+	s, err := NewServer(&Config{ServerURL: url, JWTToken: "test-token", StaleCache: true, StaleCacheTTL: ttl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestStaleCache_ServesLastResultWhileDaemonDown(t *testing.T) {
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/containers":
+			_, _ = io.WriteString(w, `{"containers":[{"name":"alice-container","username":"alice","state":"CONTAINER_STATE_RUNNING"}],"totalCount":1}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	s := newStaleCacheServer(t, daemon.URL, time.Hour)
+
+	fresh, errMsg := callTool(t, s, "list_containers", nil)
+	if errMsg != "" || !strings.Contains(fresh, "alice-container") {
+		t.Fatalf("list_containers with the daemon up = %q, %q", fresh, errMsg)
+	}
+
+	daemon.Close()
+
+	got, errMsg := callTool(t, s, "list_containers", nil)
+	if errMsg != "" {
+		t.Fatalf("list_containers with the daemon down failed: %s", errMsg)
+	}
+	if !strings.HasPrefix(got, "STALE — daemon unreachable since ") || !strings.Contains(got, ", data as of ") {
+		t.Errorf("stale result should be labeled:\n%s", got)
+	}
+	if !strings.HasSuffix(got, fresh) {
+		t.Errorf("stale result should carry the cached answer:\n%s", got)
+	}
+
+	// Nothing cached for this call, and mutating tools never use the cache.
+	if _, errMsg := callTool(t, s, "get_container", map[string]interface{}{"username": "alice"}); errMsg == "" {
+		t.Error("get_container without a cached result should fail")
+	}
+	if _, errMsg := callTool(t, s, "stop_container", map[string]interface{}{"username": "alice"}); errMsg == "" {
+		t.Error("stop_container should fail fast while the daemon is down")
+	}
+}
+
+func TestStaleCache_ExpiredEntryIsNotServed(t *testing.T) {
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"containers":[],"totalCount":0}`)
+	}))
+	s := newStaleCacheServer(t, daemon.URL, time.Minute)
+	now := time.Now()
+	s.staleCache.now = func() time.Time { return now }
+
+	if _, errMsg := callTool(t, s, "list_containers", nil); errMsg != "" {
+		t.Fatal(errMsg)
+	}
+	daemon.Close()
+	now = now.Add(2 * time.Minute)
+
+	if _, errMsg := callTool(t, s, "list_containers", nil); errMsg == "" {
+		t.Error("a result older than the TTL should not be served")
+	}
+}
+
+func TestStaleCache_DisabledByDefault(t *testing.T) {
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"containers":[],"totalCount":0}`)
+	}))
+	s, err := NewServer(&Config{ServerURL: daemon.URL, JWTToken: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errMsg := callTool(t, s, "list_containers", nil); errMsg != "" {
+		t.Fatal(errMsg)
+	}
+	daemon.Close()
+	if _, errMsg := callTool(t, s, "list_containers", nil); errMsg == "" {
+		t.Error("without StaleCache a daemon failure should surface")
+	}
+}
+
+func TestStaleCache_DaemonErrorsAreNotMasked(t *testing.T) {
+	c := newStaleCache(time.Hour)
+	args := map[string]interface{}{"username": "alice"}
+	c.record("get_container", args, "cached", nil)
+
+	if _, ok := c.serve("get_container", args, &APIError{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}); ok {
+		t.Error("a daemon that answered NOT_FOUND is reachable; its answer must stand")
+	}
+	if _, ok := c.serve("get_container", args, &APIError{StatusCode: http.StatusBadGateway}); !ok {
+		t.Error("a gateway reporting the daemon down should get the cached result")
+	}
+	if _, ok := c.serve("get_container", map[string]interface{}{"username": "bob"}, &transportError{err: io.EOF}); ok {
+		t.Error("another container's call must not get alice's result")
+	}
+}
@@ -71,13 +71,13 @@ func fetchContainersForSSHConfig(client API) ([]incus.ContainerInfo, error) {
 	for _, c := range resp.Containers {
 		info := incus.ContainerInfo{
 			Name:  c.Name,
-			State: c.State,
+			State: c.State.String(),
 		}
 		if c.Network != nil {
-			info.IPAddress = c.Network.IPAddress
+			info.IPAddress = c.Network.IpAddress
 		}
 		if c.Resources != nil {
-			info.CPU = c.Resources.CPU
+			info.CPU = c.Resources.Cpu
 			info.Memory = c.Resources.Memory
 			info.Disk = c.Resources.Disk
 		}
@@ -0,0 +1,159 @@
+package mcp
+
+// ToolAnnotations are the optional hints emitted alongside each tool in
+// tools/list. ReadOnlyHint / DestructiveHint are the MCP-spec annotation
+// names — clients use DestructiveHint to ask for confirmation before a
+// call. Category is our own grouping hint: with 60+ tools, models pick
+// noticeably better when related tools share a label.
+type ToolAnnotations struct {
+	Category        string
+	ReadOnlyHint    bool
+	DestructiveHint bool
+}
+
+// Tool categories. Kept few and coarse — they are a grouping hint for
+// the model, not a taxonomy.
+const (
+	CategoryLifecycle     = "lifecycle"
+	CategoryNetworking    = "networking"
+	CategoryObservability = "observability"
+	CategorySecrets       = "secrets"
+	CategoryBackups       = "backups"
+	CategorySecurity      = "security"
+	CategoryAgents        = "agents"
+	CategoryDeveloper     = "developer"
+	CategoryAdmin         = "admin"
+)
+
+// toMap renders the annotations in their tools/list wire shape.
+func (a ToolAnnotations) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"category":        a.Category,
+		"readOnlyHint":    a.ReadOnlyHint,
+		"destructiveHint": a.DestructiveHint,
+	}
+}
+
+// toolAnnotationAssignments is the canonical annotations-per-tool table,
+// applied as a post-pass in registerTools exactly like
+// toolScopeAssignments. TestEveryToolHasAnnotations enforces that a new
+// tool gains an entry here.
+//
+// DestructiveHint marks calls that discard state the caller can't get
+// back from the tool surface: deletes, restores over live data, mirror
+// syncs that remove files, and irrevocable token revocation.
+func toolAnnotationAssignments() map[string]ToolAnnotations {
+	ro := func(category string) ToolAnnotations {
+		return ToolAnnotations{Category: category, ReadOnlyHint: true}
+	}
+	rw := func(category string) ToolAnnotations {
+		return ToolAnnotations{Category: category}
+	}
+	destructive := func(category string) ToolAnnotations {
+		return ToolAnnotations{Category: category, DestructiveHint: true}
+	}
+
+	return map[string]ToolAnnotations{
+		// container lifecycle
+		"create_container":  rw(CategoryLifecycle),
+		"delete_container":  destructive(CategoryLifecycle),
+		"start_container":   rw(CategoryLifecycle),
+		"stop_container":    rw(CategoryLifecycle),
+		"resize_container":  rw(CategoryLifecycle),
+		"move_container":    rw(CategoryLifecycle),
+		"toggle_monitoring": rw(CategoryLifecycle),
+		"toggle_auto_sleep": rw(CategoryLifecycle),
+		"list_containers":   ro(CategoryLifecycle),
+		"get_container":     ro(CategoryLifecycle),
+		// create_container's presets
+		"list_container_templates": ro(CategoryLifecycle),
+		// snapshots
+		"snapshot_container": rw(CategoryLifecycle),
+		"list_snapshots":     ro(CategoryLifecycle),
+		"restore_container":  destructive(CategoryLifecycle),
+		// recipes / compose / runners
+		"list_recipes":      ro(CategoryLifecycle),
+		"deploy_recipe":     rw(CategoryLifecycle),
+		"compose_discover":  ro(CategoryLifecycle),
+		"compose_status":    ro(CategoryLifecycle),
+		"compose_enable":    rw(CategoryLifecycle),
+		"compose_disable":   rw(CategoryLifecycle),
+		"provision_runners": rw(CategoryLifecycle),
+		"list_runners":      ro(CategoryLifecycle),
+		"remove_runner":     destructive(CategoryLifecycle),
+
+		// observability
+		"get_metrics":               ro(CategoryObservability),
+		"get_system_info":           ro(CategoryObservability),
+		"debug_container":           ro(CategoryObservability),
+		"get_metrics_export":        ro(CategoryObservability),
+		"set_metrics_export":        rw(CategoryObservability),
+		"list_backends":             ro(CategoryObservability),
+		"get_backend":               ro(CategoryObservability),
+		"check_for_updates":         ro(CategoryObservability),
+		"get_upgrade_status":        ro(CategoryObservability),
+		"container_activity_report": ro(CategoryObservability),
+		"get_container_processes":   ro(CategoryObservability),
+		"diff_containers":           ro(CategoryObservability),
+		"wait_for_container_ready":  ro(CategoryObservability),
+		"poll_events":               ro(CategoryObservability),
+
+		// networking
+		"list_routes":             ro(CategoryNetworking),
+		"get_container_network":   ro(CategoryNetworking),
+		"get_listening_ports":     ro(CategoryNetworking),
+		"list_passthrough_routes": ro(CategoryNetworking),
+		"test_connectivity":       ro(CategoryNetworking),
+		"query_traffic_history":   ro(CategoryNetworking),
+		"expose_port":             rw(CategoryNetworking),
+		"delete_route":            destructive(CategoryNetworking),
+		"sync_ssh_config":         rw(CategoryNetworking),
+		"connect":                 rw(CategoryNetworking),
+
+		// secrets
+		"set_secret":      rw(CategorySecrets),
+		"get_secret":      ro(CategorySecrets),
+		"list_secrets":    ro(CategorySecrets),
+		"delete_secret":   destructive(CategorySecrets),
+		"refresh_secrets": rw(CategorySecrets),
+
+		"set_container_secret":    rw(CategorySecrets),
+		"list_container_secrets":  ro(CategorySecrets),
+		"remove_container_secret": destructive(CategorySecrets),
+
+		// database backups
+		"create_backup":  rw(CategoryBackups),
+		"list_backups":   ro(CategoryBackups),
+		"restore_backup": destructive(CategoryBackups),
+
+		// security
+		"security_scan":      rw(CategorySecurity),
+		"security_findings":  ro(CategorySecurity),
+		"security_remediate": rw(CategorySecurity),
+		"install_zap":        rw(CategorySecurity),
+
+		// agents / crews
+		"list_agent_skills": ro(CategoryAgents),
+		"run_agent_skill":   rw(CategoryAgents),
+		"call_agent":        rw(CategoryAgents),
+		"list_crews":        ro(CategoryAgents),
+		"run_crew":          rw(CategoryAgents),
+
+		// developer loop — sync with delete=true removes remote files
+		// that no longer exist locally, and write_container_file
+		// replaces an existing file.
+		"push":                 rw(CategoryDeveloper),
+		"sync":                 destructive(CategoryDeveloper),
+		"read_container_file":  ro(CategoryDeveloper),
+		"list_container_dir":   ro(CategoryDeveloper),
+		"write_container_file": destructive(CategoryDeveloper),
+
+		// host / platform administration
+		"upgrade_backend":         rw(CategoryAdmin),
+		"backend_validate_gpu":    rw(CategoryAdmin),
+		"revoke_token":            destructive(CategoryAdmin),
+		"kms_status":              ro(CategoryAdmin),
+		"kms_envelope_coverage":   ro(CategoryAdmin),
+		"kms_migrate_to_envelope": rw(CategoryAdmin),
+	}
+}
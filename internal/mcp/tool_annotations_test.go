@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEveryToolHasAnnotations is the annotations counterpart of
+// TestEveryToolHasScope: a tool registered without a table entry would
+// ship with an empty category and no destructive hint.
+func TestEveryToolHasAnnotations(t *testing.T) {
+	assignments := toolAnnotationAssignments()
+	srv := &Server{}
+	srv.registerTools()
+	require.NotEmpty(t, srv.tools)
+
+	for _, tool := range srv.tools {
+		a, ok := assignments[tool.Name]
+		if !ok {
+			t.Errorf("tool %q has no entry in toolAnnotationAssignments() — add one before merging", tool.Name)
+			continue
+		}
+		assert.NotEmpty(t, a.Category, "tool %q has no category", tool.Name)
+		assert.False(t, a.ReadOnlyHint && a.DestructiveHint, "tool %q can't be both read-only and destructive", tool.Name)
+		assert.Equal(t, a, tool.Annotations, "tool %q annotations not applied", tool.Name)
+	}
+	for name := range assignments {
+		found := false
+		for _, tool := range srv.tools {
+			if tool.Name == name {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "toolAnnotationAssignments() has an entry for unregistered tool %q", name)
+	}
+}
+
+// Clients gate confirmation prompts on destructiveHint; these must never
+// silently lose it.
+func TestDestructiveToolsAreMarked(t *testing.T) {
+	assignments := toolAnnotationAssignments()
+	for _, name := range []string{"delete_container", "restore_container", "restore_backup", "delete_secret", "delete_route", "remove_runner"} {
+		assert.True(t, assignments[name].DestructiveHint, "%s must set destructiveHint", name)
+	}
+}
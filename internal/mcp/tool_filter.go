@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"log"
+	"slices"
+	"strings"
+)
+
+// toolEnabled reports whether the server configuration exposes t. A tool
+// is enabled when all of these hold:
+//
+//   - EnabledTools is empty or names it;
+//   - DisabledTools does not name it;
+//   - ReadOnly is off, or t is annotated read-only and not destructive.
+//
+// Each rule only removes tools, so a deny always wins: naming a tool in
+// EnabledTools cannot bring back one that DisabledTools or ReadOnly drops.
+func toolEnabled(cfg *Config, t *Tool) bool {
+	if cfg == nil {
+		return true
+	}
+	if len(cfg.EnabledTools) > 0 && !slices.Contains(cfg.EnabledTools, t.Name) {
+		return false
+	}
+	if slices.Contains(cfg.DisabledTools, t.Name) {
+		return false
+	}
+	if cfg.ReadOnly && (!t.Annotations.ReadOnlyHint || t.Annotations.DestructiveHint) {
+		return false
+	}
+	return true
+}
+
+// applyToolFilter drops the tools the configuration disables from the
+// catalog, remembering their names so tools/call can tell a disabled tool
+// from an unknown one. Names in the allow/deny lists that match no tool are
+// logged: they are most likely typos.
+func (s *Server) applyToolFilter() {
+	known := make(map[string]bool, len(s.tools))
+	for _, t := range s.tools {
+		known[t.Name] = true
+	}
+	for _, list := range []struct {
+		env   string
+		names []string
+	}{
+		{"CONTAINARIUM_ENABLED_TOOLS", s.config.EnabledTools},
+		{"CONTAINARIUM_DISABLED_TOOLS", s.config.DisabledTools},
+	} {
+		for _, name := range list.names {
+			if !known[name] {
+				log.Printf("Warning: %s names unknown tool %q", list.env, name)
+			}
+		}
+	}
+
+	s.disabledTools = make(map[string]bool)
+	s.tools = slices.DeleteFunc(s.tools, func(t Tool) bool {
+		if toolEnabled(s.config, &t) {
+			return false
+		}
+		s.disabledTools[t.Name] = true
+		return true
+	})
+}
+
+// parseToolList splits a comma-separated tool list, dropping blanks.
+func parseToolList(v string) []string {
+	var names []string
+	for name := range strings.SplitSeq(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
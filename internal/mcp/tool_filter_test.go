@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func filteredServer(t *testing.T, mutate func(*Config)) *Server {
+	t.Helper()
+	cfg := &Config{ServerURL: "http://localhost:8080", JWTToken: "test-token"}
+	mutate(cfg)
+	server, err := NewServer(cfg)
+	require.NoError(t, err)
+	return server
+}
+
+func toolNames(s *Server) map[string]bool {
+	names := make(map[string]bool, len(s.tools))
+	for _, t := range s.tools {
+		names[t.Name] = true
+	}
+	return names
+}
+
+func TestToolFilter_Shapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		present []string
+		absent  []string
+	}{
+		{
+			name:    "default exposes everything",
+			mutate:  func(*Config) {},
+			present: []string{"list_containers", "create_container", "delete_container"},
+		},
+		{
+			name:    "allowlist",
+			mutate:  func(c *Config) { c.EnabledTools = []string{"list_containers", "delete_container"} },
+			present: []string{"list_containers", "delete_container"},
+			absent:  []string{"create_container", "get_container"},
+		},
+		{
+			name:    "denylist",
+			mutate:  func(c *Config) { c.DisabledTools = []string{"delete_container"} },
+			present: []string{"list_containers", "create_container"},
+			absent:  []string{"delete_container"},
+		},
+		{
+			name:    "read-only",
+			mutate:  func(c *Config) { c.ReadOnly = true },
+			present: []string{"list_containers", "get_container", "get_container_network"},
+			absent:  []string{"create_container", "delete_container", "stop_container"},
+		},
+		{
+			name: "denylist beats allowlist",
+			mutate: func(c *Config) {
+				c.EnabledTools = []string{"list_containers", "get_container"}
+				c.DisabledTools = []string{"get_container"}
+			},
+			present: []string{"list_containers"},
+			absent:  []string{"get_container", "create_container"},
+		},
+		{
+			name: "read-only beats allowlist",
+			mutate: func(c *Config) {
+				c.EnabledTools = []string{"list_containers", "delete_container"}
+				c.ReadOnly = true
+			},
+			present: []string{"list_containers"},
+			absent:  []string{"delete_container"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := toolNames(filteredServer(t, tt.mutate))
+			for _, n := range tt.present {
+				assert.True(t, names[n], "%s should be listed", n)
+			}
+			for _, n := range tt.absent {
+				assert.False(t, names[n], "%s should not be listed", n)
+			}
+		})
+	}
+}
+
+func TestToolFilter_ReadOnlyKeepsOnlyReadOnlyTools(t *testing.T) {
+	server := filteredServer(t, func(c *Config) { c.ReadOnly = true })
+	require.NotEmpty(t, server.tools)
+	for _, tool := range server.tools {
+		assert.True(t, tool.Annotations.ReadOnlyHint, "%s is not read-only", tool.Name)
+		assert.False(t, tool.Annotations.DestructiveHint, "%s is destructive", tool.Name)
+	}
+}
+
+func TestToolFilter_CallDisabledTool(t *testing.T) {
+	server := filteredServer(t, func(c *Config) { c.DisabledTools = []string{"delete_container"} })
+
+	call := func(name string) *MCPResponse {
+		return server.handleRequest(&MCPRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "tools/call",
+			Params:  map[string]interface{}{"name": name, "arguments": map[string]interface{}{}},
+		})
+	}
+
+	resp := call("delete_container")
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32602, resp.Error.Code)
+	assert.Contains(t, resp.Error.Message, "disabled by server configuration")
+
+	// An unknown name still reports "not found".
+	resp = call("nonexistent_tool")
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Data, "not found")
+}
+
+func TestParseToolList(t *testing.T) {
+	assert.Nil(t, parseToolList(""))
+	assert.Equal(t, []string{"list_containers", "get_container"}, parseToolList(" list_containers, ,get_container ,"))
+}
+
+func TestLoadConfig_ToolFilterEnv(t *testing.T) {
+	t.Setenv("CONTAINARIUM_JWT_TOKEN", "test-token")
+	t.Setenv("CONTAINARIUM_ENABLED_TOOLS", "list_containers,get_container")
+	t.Setenv("CONTAINARIUM_DISABLED_TOOLS", "get_container")
+	t.Setenv("CONTAINARIUM_READ_ONLY", "true")
+
+	cfg := LoadConfig()
+	assert.Equal(t, []string{"list_containers", "get_container"}, cfg.EnabledTools)
+	assert.Equal(t, []string{"get_container"}, cfg.DisabledTools)
+	assert.True(t, cfg.ReadOnly)
+
+	t.Setenv("CONTAINARIUM_READ_ONLY", "sometimes")
+	assert.False(t, LoadConfig().ReadOnly, "invalid value should be ignored")
+}
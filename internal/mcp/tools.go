@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"github.com/footprintai/containarium/internal/auth"
 	"github.com/footprintai/containarium/internal/safecast"
 	"github.com/footprintai/containarium/pkg/core/expose"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // ephemeralKeyDir returns the directory the MCP server writes ephemeral
@@ -95,11 +97,32 @@ type Tool struct {
 	InputSchema   map[string]interface{}
 	Handler       ToolHandler
 	RequiredScope string
+	Annotations   ToolAnnotations
+
+	// ContextHandler, when set, is called instead of Handler with a
+	// context the server cancels on notifications/cancelled. Tools that
+	// block (poll_events) set both; Handler then runs uncancellable.
+	ContextHandler ContextToolHandler
+
+	// SensitiveArgs names arguments that carry secret values. They are
+	// blanked in Debug logs and scrubbed from error text (see redact.go).
+	SensitiveArgs []string
+	// SensitiveResult marks a tool whose result is itself a secret
+	// (get_secret); Debug logs omit it.
+	SensitiveResult bool
+
+	// Capability, when set, names the daemon feature the tool needs
+	// (see capabilities.go). The tool is listed only while the daemon's
+	// last probe found it.
+	Capability string
 }
 
 // ToolHandler is a function that handles a tool call
 type ToolHandler func(client API, args map[string]interface{}) (string, error)
 
+// ContextToolHandler is a ToolHandler that can be cancelled mid-call.
+type ContextToolHandler func(ctx context.Context, client API, args map[string]interface{}) (string, error)
+
 // registerTools registers all available MCP tools
 func (s *Server) registerTools() {
 	s.tools = []Tool{
@@ -204,11 +227,31 @@ func (s *Server) registerTools() {
 						"type":        "string",
 						"description": "Place the container on a specific backend by ID (e.g., 'tunnel-node-a-gpu'). Look up valid IDs via list_backends. Use pool instead when any backend in a pool will do.",
 					},
+					"template": map[string]interface{}{
+						"type":        "string",
+						"description": "Start from this operator-defined preset (see list_container_templates). The template supplies resources, image, stack, cloud-init, labels and exposed routes; only arguments you pass explicitly override it, and the defaults above don't apply.",
+					},
+					"idempotency_key": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional key (at most 128 characters, e.g. a UUID) identifying this create. If the call times out or its result is lost, repeat it with the same key and the same arguments: the daemon returns the first attempt's result instead of creating a second box. Without one, only this call's own retries are deduplicated.",
+					},
 				},
 				"required": []string{"username"},
 			},
 			Handler: handleCreateContainer,
 		},
+		{
+			Name: "list_container_templates",
+			Description: "List the container templates the operator has defined. A template " +
+				"is a named preset (e.g. 'small', 'gpu-dev', 'web') bundling resources, " +
+				"image, stack, cloud-init, labels and routes to expose. Pass its name as " +
+				"create_container's `template` argument instead of spelling each out.",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+			Handler: handleListContainerTemplates,
+		},
 		{
 			Name: "list_containers",
 			Description: "List all containers with name, username, state, IP, and resources. " +
@@ -351,7 +394,8 @@ func (s *Server) registerTools() {
 				},
 				"required": []string{"username", "name", "value"},
 			},
-			Handler: handleSetSecret,
+			Handler:       handleSetSecret,
+			SensitiveArgs: []string{"value"},
 		},
 		{
 			Name:        "get_secret",
@@ -364,7 +408,8 @@ func (s *Server) registerTools() {
 				},
 				"required": []string{"username", "name"},
 			},
-			Handler: handleGetSecret,
+			Handler:         handleGetSecret,
+			SensitiveResult: true,
 		},
 		{
 			Name:        "list_secrets",
@@ -403,6 +448,55 @@ func (s *Server) registerTools() {
 			},
 			Handler: handleRefreshSecrets,
 		},
+		{
+			Name: "set_container_secret",
+			Description: "Store a tenant secret and place it inside the tenant's container right away. " +
+				"scope \"user-env\" (default) stamps it as environment.<NAME>, seen by new processes; " +
+				"an absolute path writes it to that file, mode 0600 and owned by the tenant (allowed under " +
+				"/home/<username>, /run, /srv or /opt; files outside /run persist across restarts). " +
+				"Setting an existing name replaces the value and moves it if the scope changed. " +
+				"The value is encrypted at rest, audit-logged only as metadata, and never echoed back.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{"type": "string", "description": "Tenant username; the secret lands in <username>-container"},
+					"name":     map[string]interface{}{"type": "string", "description": "Env-var-style name (uppercase + digits + underscore, e.g. DATABASE_URL)"},
+					"value":    map[string]interface{}{"type": "string", "description": "Plaintext value; never logged or returned"},
+					"scope": map[string]interface{}{
+						"type":        "string",
+						"description": "\"user-env\" (default) or an absolute file path inside the container, e.g. /home/alice/.config/app/token",
+					},
+				},
+				"required": []string{"username", "name", "value"},
+			},
+			Handler:       handleSetContainerSecret,
+			SensitiveArgs: []string{"value"},
+		},
+		{
+			Name:        "list_container_secrets",
+			Description: "List a tenant's secret names with where each is materialized inside the container (environment key or file path), scope, version and last update. Never returns values.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{"type": "string", "description": "Tenant username"},
+				},
+				"required": []string{"username"},
+			},
+			Handler: handleListContainerSecrets,
+		},
+		{
+			Name:        "remove_container_secret",
+			Description: "Delete a tenant secret and take it out of the container: the environment key is unset or the file deleted. Audit-logged. Running processes keep an environment value they already inherited until restarted.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{"type": "string", "description": "Tenant username"},
+					"name":     map[string]interface{}{"type": "string", "description": "Secret name"},
+				},
+				"required": []string{"username", "name"},
+			},
+			Handler: handleRemoveContainerSecret,
+		},
 		{
 			Name:        "toggle_monitoring",
 			Description: "Enable or disable application-emitted OpenTelemetry on an existing container without recreating it. When enabling, the daemon stamps OTEL_EXPORTER_OTLP_ENDPOINT + related env vars and restarts the container so the app picks them up. Use this to retrofit monitoring onto containers created before --monitoring was wired in. Requires the daemon to have an OTel collector endpoint configured.",
@@ -537,8 +631,10 @@ func (s *Server) registerTools() {
 			Handler: handleStopContainer,
 		},
 		{
-			Name:        "get_metrics",
-			Description: "Get runtime metrics (CPU, memory, disk, network) for containers",
+			Name: "get_metrics",
+			Description: "Get runtime metrics (CPU, memory, disk, network) for containers. " +
+				"Use sort_by and limit for a fleet-wide \"what's hot right now\" view, " +
+				"e.g. the top 10 boxes by memory.",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -546,6 +642,15 @@ func (s *Server) registerTools() {
 						"type":        "string",
 						"description": "Username of specific container (optional, empty for all containers)",
 					},
+					"sort_by": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"cpu", "memory"},
+						"description": "Rank containers highest first by cumulative CPU seconds or current memory usage (optional)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Return at most this many containers, after sorting (optional, default all)",
+					},
 				},
 			},
 			Handler: handleGetMetrics,
@@ -1259,10 +1364,46 @@ func (s *Server) registerTools() {
 	// gateway that `containarium backup` also calls.
 	s.tools = append(s.tools, backupTools()...)
 
+	// Container snapshot tools (snapshot_tools.go) — wrappers over the
+	// ContainerService snapshot endpoints.
+	s.tools = append(s.tools, snapshotTools()...)
+
+	// Activity report (activity_tools.go) — one call over the daemon's
+	// joined /v1/containers/{username}/activity endpoint.
+	s.tools = append(s.tools, activityTools()...)
+
+	// Process list (processes_tools.go) — a bounded ps inside the box via
+	// /v1/containers/{username}/processes.
+	s.tools = append(s.tools, processesTools()...)
+
+	// Container diff (diff_tools.go) — the daemon compares two boxes via
+	// /v1/containers/{username_a}/diff/{username_b}.
+	s.tools = append(s.tools, diffTools()...)
+
+	// Container files (files_tools.go) — single files in and out of the box
+	// through the Incus file API via /v1/containers/{username}/files.
+	s.tools = append(s.tools, filesTools()...)
+
+	// Container network (network_tools.go) — the box's IP plus the proxy
+	// and passthrough routes that reach it, and the ports it listens on.
+	s.tools = append(s.tools, networkTools()...)
+
+	// Readiness wait (readiness_tools.go) — polls the daemon's
+	// /v1/containers/{username}/readiness until the box is usable.
+	s.tools = append(s.tools, readinessTools()...)
+
+	// Event long-poll (events_tools.go) — GET /v1/events/poll, so an
+	// agent can watch lifecycle changes without a streaming transport.
+	s.tools = append(s.tools, eventsTools()...)
+
 	// KMS admin tools (kms_tools.go) — thin wrappers over the
 	// KmsService gateway that `containarium kms` also calls.
 	s.tools = append(s.tools, kmsTools()...)
 
+	// Traffic history (traffic_tools.go) — only listed once a probe
+	// finds the daemon's traffic endpoints.
+	s.tools = append(s.tools, trafficTools()...)
+
 	// Phase 1.7 — assign required scope per tool. Done as a
 	// post-pass so the slice literals above stay short and
 	// the security policy lives in one auditable spot. New
@@ -1271,8 +1412,10 @@ func (s *Server) registerTools() {
 	// to any token) — see TestEveryToolHasScope which
 	// enforces this in CI.
 	scopeByTool := toolScopeAssignments()
+	annotationsByTool := toolAnnotationAssignments()
 	for i := range s.tools {
 		s.tools[i].RequiredScope = scopeByTool[s.tools[i].Name]
+		s.tools[i].Annotations = annotationsByTool[s.tools[i].Name]
 	}
 }
 
@@ -1306,6 +1449,8 @@ func toolScopeAssignments() map[string]string {
 		// a new scope for one feature.
 		"set_metrics_export": auth.ScopeContainersWrite,
 		"get_metrics_export": auth.ScopeContainersRead,
+		// create_container's presets
+		"list_container_templates": auth.ScopeContainersRead,
 		// validate-gpu creates+deletes a throwaway container (a write op);
 		// the daemon additionally enforces admin role.
 		"backend_validate_gpu": auth.ScopeContainersWrite,
@@ -1315,10 +1460,22 @@ func toolScopeAssignments() map[string]string {
 		"refresh_secrets": auth.ScopeSecretsWrite,
 		"get_secret":      auth.ScopeSecretsRead,
 		"list_secrets":    auth.ScopeSecretsRead,
+
+		"set_container_secret":    auth.ScopeSecretsWrite,
+		"remove_container_secret": auth.ScopeSecretsWrite,
+		"list_container_secrets":  auth.ScopeSecretsRead,
 		// routes / network exposure
 		"list_routes":  auth.ScopeRoutesRead,
 		"expose_port":  auth.ScopeRoutesWrite,
 		"delete_route": auth.ScopeRoutesWrite,
+		// get_container_network's core is the container's address; the
+		// route lists it joins in degrade to "unavailable" without
+		// routes:read.
+		"get_container_network":   auth.ScopeContainersRead,
+		"get_listening_ports":     auth.ScopeTrafficRead,
+		"query_traffic_history":   auth.ScopeTrafficRead,
+		"list_passthrough_routes": auth.ScopeRoutesRead,
+		"test_connectivity":       auth.ScopeContainersRead,
 		// recipes — declarative GPU/app deploys
 		"list_recipes":  auth.ScopeContainersRead,
 		"deploy_recipe": auth.ScopeContainersWrite,
@@ -1332,6 +1489,19 @@ func toolScopeAssignments() map[string]string {
 		"create_backup":  auth.ScopeBackupsWrite,
 		"restore_backup": auth.ScopeBackupsWrite,
 		"list_backups":   auth.ScopeBackupsRead,
+		// container snapshots — whole-box filesystem state, so they ride
+		// the containers:* scopes rather than backups:*.
+		"snapshot_container":        auth.ScopeContainersWrite,
+		"restore_container":         auth.ScopeContainersWrite,
+		"list_snapshots":            auth.ScopeContainersRead,
+		"container_activity_report": auth.ScopeContainersRead,
+		"get_container_processes":   auth.ScopeContainersRead,
+		"diff_containers":           auth.ScopeContainersRead,
+		"read_container_file":       auth.ScopeContainersRead,
+		"list_container_dir":        auth.ScopeContainersRead,
+		"write_container_file":      auth.ScopeContainersWrite,
+		"wait_for_container_ready":  auth.ScopeContainersRead,
+		"poll_events":               auth.ScopeContainersRead,
 		// KMS envelope-encryption administration (admin-only)
 		"kms_status":              auth.ScopeKMSAdmin,
 		"kms_envelope_coverage":   auth.ScopeKMSAdmin,
@@ -1376,20 +1546,30 @@ func handleCreateContainer(client API, args map[string]interface{}) (string, err
 		return "", fmt.Errorf("username is required")
 	}
 
-	req := CreateContainerRequest{
+	// With a template the daemon fills in whatever is left empty, so the
+	// defaults must not shadow the template's values.
+	template := getStringArg(args, "template", "")
+	idempotencyKey := getStringArg(args, "idempotency_key", "")
+	cpu, memory, disk, image, podman := "4", "4GB", "50GB", "images:ubuntu/24.04", true
+	if template != "" {
+		cpu, memory, disk, image, podman = "", "", "", "", false
+	}
+
+	req := &CreateContainerRequest{
 		Username: username,
 		Resources: &ResourceLimits{
-			CPU:    getStringArg(args, "cpu", "4"),
-			Memory: getStringArg(args, "memory", "4GB"),
-			Disk:   getStringArg(args, "disk", "50GB"),
+			Cpu:    getStringArg(args, "cpu", cpu),
+			Memory: getStringArg(args, "memory", memory),
+			Disk:   getStringArg(args, "disk", disk),
 		},
-		Image:        getStringArg(args, "image", "images:ubuntu/24.04"),
-		EnablePodman: getBoolArg(args, "enable_podman", true),
-		GPU:          getStringArg(args, "gpu", ""),
-		GPUs:         getStringSliceArg(args, "gpus"),
+		Image:        getStringArg(args, "image", image),
+		EnablePodman: getBoolArg(args, "enable_podman", podman),
+		Template:     template,
+		Gpu:          getStringArg(args, "gpu", ""),
+		Gpus:         getStringSliceArg(args, "gpus"),
 		Monitoring:   getBoolArg(args, "monitoring", false),
 		Pool:         getStringArg(args, "pool", ""),
-		BackendID:    getStringArg(args, "backend_id", ""),
+		BackendId:    getStringArg(args, "backend_id", ""),
 	}
 
 	// Handle SSH keys. If the caller passes ssh_keys explicitly we use
@@ -1402,50 +1582,53 @@ func handleCreateContainer(client API, args map[string]interface{}) (string, err
 	if sshKeys, ok := args["ssh_keys"].([]interface{}); ok && len(sshKeys) > 0 {
 		for _, key := range sshKeys {
 			if keyStr, ok := key.(string); ok {
-				req.SSHKeys = append(req.SSHKeys, keyStr)
+				req.SshKeys = append(req.SshKeys, keyStr)
 			}
 		}
 	} else {
-		pubKey, privKey, err := generateEphemeralSSHKey(
+		// Under an idempotency_key a repeated call reuses the first
+		// call's keypair, so the daemon sees the same request.
+		pubKey, privKey, err := ephemeralSSHKeyFor(username, idempotencyKey,
 			fmt.Sprintf("containarium-%s ephemeral key", username),
 		)
 		if err != nil {
 			return "", fmt.Errorf("generate ephemeral ssh key: %w", err)
 		}
-		req.SSHKeys = []string{pubKey}
+		req.SshKeys = []string{pubKey}
 		ephemeralPrivKey = privKey
 	}
 
-	resp, err := client.CreateContainer(req)
+	resp, err := client.CreateContainer(req, idempotencyKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to create container: %w", err)
 	}
 
+	box := resp.GetContainer()
 	result := "✅ Container created successfully!\n\n"
-	result += fmt.Sprintf("Name: %s\n", resp.Container.Name)
-	result += fmt.Sprintf("Username: %s\n", resp.Container.Username)
-	result += fmt.Sprintf("State: %s\n", resp.Container.State)
+	result += fmt.Sprintf("Name: %s\n", box.GetName())
+	result += fmt.Sprintf("Username: %s\n", box.GetUsername())
+	result += fmt.Sprintf("State: %s\n", box.GetState())
 	// Surface the RESOLVED backend/pool the box actually landed on. If a
 	// requested backend_id didn't match any host, placement silently falls back
 	// to the default (cloud #686) — showing where it really landed makes that
 	// immediately visible instead of discovering it later via an egress test.
-	if resp.Container.BackendID != "" {
-		result += fmt.Sprintf("Backend: %s\n", resp.Container.BackendID)
+	if box.GetBackendId() != "" {
+		result += fmt.Sprintf("Backend: %s\n", box.GetBackendId())
 	}
-	if resp.Container.Pool != "" {
-		result += fmt.Sprintf("Pool: %s\n", resp.Container.Pool)
+	if box.GetPool() != "" {
+		result += fmt.Sprintf("Pool: %s\n", box.GetPool())
 	}
-	if reqBackend := getStringArg(args, "backend_id", ""); reqBackend != "" && resp.Container.BackendID != "" && resp.Container.BackendID != reqBackend {
+	if reqBackend := getStringArg(args, "backend_id", ""); reqBackend != "" && box.GetBackendId() != "" && box.GetBackendId() != reqBackend {
 		result += fmt.Sprintf("⚠️  Requested backend_id %q but the box landed on %q (the requested id matched no host; placement used the default).\n",
-			reqBackend, resp.Container.BackendID)
+			reqBackend, box.GetBackendId())
 	}
-	if resp.Container.Network != nil && resp.Container.Network.IPAddress != "" {
-		result += fmt.Sprintf("IP Address: %s\n", resp.Container.Network.IPAddress)
+	if box.GetNetwork().GetIpAddress() != "" {
+		result += fmt.Sprintf("IP Address: %s\n", box.GetNetwork().GetIpAddress())
 	}
-	if resp.Container.Resources != nil {
-		result += fmt.Sprintf("CPU: %s\n", resp.Container.Resources.CPU)
-		result += fmt.Sprintf("Memory: %s\n", resp.Container.Resources.Memory)
-		result += fmt.Sprintf("Disk: %s\n", resp.Container.Resources.Disk)
+	if res := box.GetResources(); res != nil {
+		result += fmt.Sprintf("CPU: %s\n", res.Cpu)
+		result += fmt.Sprintf("Memory: %s\n", res.Memory)
+		result += fmt.Sprintf("Disk: %s\n", res.Disk)
 	}
 	result += fmt.Sprintf("\n%s", resp.Message)
 
@@ -1458,18 +1641,18 @@ func handleCreateContainer(client API, args map[string]interface{}) (string, err
 	// hunting for the env var and give up (see issue #658).
 	result += "\n\n--- CONNECTING ---\n"
 	result += "⏳ PROVISIONING TAKES MINUTES: the create is async — the box installs\n"
-	result += "packages and SSH keys AFTER this response returns. Poll get_container\n"
-	result += "until state is RUNNING before attempting SSH; an early SSH failure is\n"
+	result += "packages and SSH keys AFTER this response returns. Call\n"
+	result += "wait_for_container_ready before attempting SSH; an early SSH failure is\n"
 	result += "NOT an error, and deleting the box because of one aborts a create that\n"
 	result += "was still in progress.\n\n"
-	if resp.Container.SSHHost != "" {
+	if box.GetSshHost() != "" {
 		result += fmt.Sprintf("SSH target: %s@%s  (resolved from ssh_host — no env/config needed)\n",
-			resp.Container.Username, resp.Container.SSHHost)
+			box.GetUsername(), box.GetSshHost())
 	} else {
 		result += "SSH target: direct / no-sentinel deployment — the daemon reported no\n"
 		result += "ssh_host. Reach the container at its IP"
-		if resp.Container.Network != nil && resp.Container.Network.IPAddress != "" {
-			result += fmt.Sprintf(" (%s)", resp.Container.Network.IPAddress)
+		if box.GetNetwork().GetIpAddress() != "" {
+			result += fmt.Sprintf(" (%s)", box.GetNetwork().GetIpAddress())
 		}
 		result += ", or call sync_ssh_config for an alias.\n"
 	}
@@ -1487,7 +1670,7 @@ func handleCreateContainer(client API, args map[string]interface{}) (string, err
 		// never stores a copy. Doing the write here makes "container
 		// created" and "key on disk" a single atomic-from-the-caller's-
 		// perspective operation.
-		savedPath, saveErr := saveEphemeralPrivateKey(resp.Container.Username, ephemeralPrivKey)
+		savedPath, saveErr := saveEphemeralPrivateKey(box.GetUsername(), ephemeralPrivKey)
 
 		result += "\n\n--- EPHEMERAL SSH PRIVATE KEY ---\n"
 		result += "Caller did not provide ssh_keys, so an ed25519 keypair was\n"
@@ -1498,26 +1681,26 @@ func handleCreateContainer(client API, args map[string]interface{}) (string, err
 			result += fmt.Sprintf("⚠️  Could not auto-save the private key: %v\n", saveErr)
 			result += "    Save the key text below to a file with mode 0600 yourself.\n\n"
 			result += "Suggested save path:\n"
-			result += fmt.Sprintf("  ~/.containarium/keys/%s\n\n", resp.Container.Username)
+			result += fmt.Sprintf("  ~/.containarium/keys/%s\n\n", box.GetUsername())
 		}
 		result += "To SSH in:\n"
 		// The daemon stamps ssh_host with the sentinel this container belongs
 		// to (from its --ssh-host), so the agent gets a reachable host straight
 		// from create_container — no env, no config. Empty means a direct /
 		// no-sentinel deployment, where the container IP is the way in.
-		sentinelHost := resp.Container.SSHHost
+		sentinelHost := box.GetSshHost()
 		if sentinelHost == "" {
 			sentinelHost = "<sentinel-host>"
 		}
 		result += fmt.Sprintf("  ssh -i ~/.containarium/keys/%s \\\n"+
 			"      -o IdentitiesOnly=yes -o PreferredAuthentications=publickey \\\n"+
 			"      %s@%s\n\n",
-			resp.Container.Username, resp.Container.Username, sentinelHost)
+			box.GetUsername(), box.GetUsername(), sentinelHost)
 		result += "IdentitiesOnly=yes is REQUIRED — without it ssh offers every key in\n"
 		result += "~/.ssh/, and sshpiper's failtoban counts each rejected offer toward\n"
 		result += "the ban quota. Workstations with several keys can get banned on a\n"
 		result += "single ssh attempt.\n\n"
-		if resp.Container.SSHHost == "" {
+		if box.GetSshHost() == "" {
 			result += "(The daemon reported no ssh_host for this container — a direct /\n"
 			result += "no-sentinel deployment. SSH to the container's IP directly, or call\n"
 			result += "sync_ssh_config for an alias-based setup.)\n\n"
@@ -1549,18 +1732,18 @@ func handleListContainers(client API, args map[string]interface{}) (string, erro
 		// Show WHERE the box actually runs. A wrong/typo'd backend_id silently
 		// falls back to the default pool, so surfacing the resolved backend is
 		// the fastest way to catch a misplacement (see cloud #686).
-		if container.BackendID != "" {
-			result += fmt.Sprintf("   Backend: %s\n", container.BackendID)
+		if container.BackendId != "" {
+			result += fmt.Sprintf("   Backend: %s\n", container.BackendId)
 		}
 		if container.Pool != "" {
 			result += fmt.Sprintf("   Pool: %s\n", container.Pool)
 		}
-		if container.Network != nil && container.Network.IPAddress != "" {
-			result += fmt.Sprintf("   IP: %s\n", container.Network.IPAddress)
+		if container.GetNetwork().GetIpAddress() != "" {
+			result += fmt.Sprintf("   IP: %s\n", container.GetNetwork().GetIpAddress())
 		}
 		if container.Resources != nil {
 			result += fmt.Sprintf("   Resources: CPU=%s, Memory=%s, Disk=%s\n",
-				container.Resources.CPU, container.Resources.Memory, container.Resources.Disk)
+				container.Resources.Cpu, container.Resources.Memory, container.Resources.Disk)
 		}
 		result += "\n"
 	}
@@ -1691,6 +1874,55 @@ func handleRefreshSecrets(client API, args map[string]interface{}) (string, erro
 	return fmt.Sprintf("✅ %s", resp.Message), nil
 }
 
+func handleSetContainerSecret(client API, args map[string]interface{}) (string, error) {
+	username, _ := args["username"].(string)
+	name, _ := args["name"].(string)
+	value, _ := args["value"].(string)
+	scope, _ := args["scope"].(string)
+	if username == "" || name == "" {
+		return "", fmt.Errorf("username and name are required")
+	}
+	resp, err := client.SetContainerSecret(&SetContainerSecretRequest{Username: username, Name: name, Value: value, Scope: scope})
+	if err != nil {
+		return "", fmt.Errorf("failed to set container secret: %w", err)
+	}
+	return fmt.Sprintf("✅ %s", resp.GetMessage()), nil
+}
+
+func handleListContainerSecrets(client API, args map[string]interface{}) (string, error) {
+	username, _ := args["username"].(string)
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+	resp, err := client.ListContainerSecrets(username)
+	if err != nil {
+		return "", fmt.Errorf("failed to list container secrets: %w", err)
+	}
+	list := resp.GetSecrets()
+	if len(list) == 0 {
+		return fmt.Sprintf("(no secrets for %s)", username), nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Secrets in %s-container:\n", username)
+	for _, s := range list {
+		fmt.Fprintf(&b, "  %s  %s  %s  (v%d, updated %s)\n", s.GetName(), s.GetScope(), s.GetLocation(), s.GetVersion(), s.GetUpdatedAt())
+	}
+	return b.String(), nil
+}
+
+func handleRemoveContainerSecret(client API, args map[string]interface{}) (string, error) {
+	username, _ := args["username"].(string)
+	name, _ := args["name"].(string)
+	if username == "" || name == "" {
+		return "", fmt.Errorf("username and name are required")
+	}
+	resp, err := client.RemoveContainerSecret(username, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to remove container secret: %w", err)
+	}
+	return fmt.Sprintf("✅ %s", resp.GetMessage()), nil
+}
+
 func handleResizeContainer(client API, args map[string]interface{}) (string, error) {
 	username, ok := args["username"].(string)
 	if !ok || username == "" {
@@ -1802,7 +2034,7 @@ func handleDeleteContainer(client API, args map[string]interface{}) (string, err
 	// delete still proceeds.
 	var backend, pool string
 	if info, gerr := client.GetContainer(username); gerr == nil && info != nil {
-		backend, pool = info.Container.BackendID, info.Container.Pool
+		backend, pool = info.GetContainer().GetBackendId(), info.GetContainer().GetPool()
 	}
 
 	resp, err := client.DeleteContainer(username, force)
@@ -1833,9 +2065,9 @@ func handleStartContainer(client API, args map[string]interface{}) (string, erro
 	}
 
 	if resp.ReadyTimedOut {
-		return fmt.Sprintf("⚠ %s (readiness probe timed out)\nContainer state: %s", resp.Message, resp.Container.State), nil
+		return fmt.Sprintf("⚠ %s (readiness probe timed out)\nContainer state: %s", resp.Message, resp.GetContainer().GetState()), nil
 	}
-	return fmt.Sprintf("✅ %s\nContainer state: %s", resp.Message, resp.Container.State), nil
+	return fmt.Sprintf("✅ %s\nContainer state: %s", resp.Message, resp.GetContainer().GetState()), nil
 }
 
 func handleToggleAutoSleep(client API, args map[string]interface{}) (string, error) {
@@ -1873,13 +2105,18 @@ func handleStopContainer(client API, args map[string]interface{}) (string, error
 		return "", fmt.Errorf("failed to stop container: %w", err)
 	}
 
-	return fmt.Sprintf("✅ %s\nContainer state: %s", resp.Message, resp.Container.State), nil
+	return fmt.Sprintf("✅ %s\nContainer state: %s", resp.Message, resp.GetContainer().GetState()), nil
 }
 
 func handleGetMetrics(client API, args map[string]interface{}) (string, error) {
 	username := getStringArg(args, "username", "")
+	sortBy := getStringArg(args, "sort_by", "")
+	limit, _ := getIntArg(args, "limit")
+	if limit < 0 {
+		return "", fmt.Errorf("limit must not be negative")
+	}
 
-	resp, err := client.GetMetrics(username)
+	resp, err := client.GetMetrics(username, sortBy, limit)
 	if err != nil {
 		return "", fmt.Errorf("failed to get metrics: %w", err)
 	}
@@ -1889,14 +2126,21 @@ func handleGetMetrics(client API, args map[string]interface{}) (string, error) {
 	}
 
 	result := fmt.Sprintf("Container Metrics (%d container(s)):\n\n", len(resp.Metrics))
+	if sortBy != "" {
+		result = fmt.Sprintf("Container Metrics (top %d by %s):\n\n", len(resp.Metrics), sortBy)
+	}
 	for _, m := range resp.Metrics {
 		result += fmt.Sprintf("📊 %s\n", m.Name)
-		result += fmt.Sprintf("   CPU Usage: %d seconds\n", m.CPUUsageSeconds)
+		result += fmt.Sprintf("   CPU Usage: %d seconds\n", m.CpuUsageSeconds)
 		result += fmt.Sprintf("   Memory: %d MB / %d MB peak\n",
 			m.MemoryUsageBytes/1024/1024, m.MemoryPeakBytes/1024/1024)
 		result += fmt.Sprintf("   Disk: %d MB\n", m.DiskUsageBytes/1024/1024)
 		result += fmt.Sprintf("   Network: ↓%d MB ↑%d MB\n",
 			m.NetworkRxBytes/1024/1024, m.NetworkTxBytes/1024/1024)
+		if m.NetworkBytesSent > 0 || m.NetworkBytesReceived > 0 {
+			result += fmt.Sprintf("   Traffic since start: %s sent, %s received\n",
+				humanBytes(m.NetworkBytesSent), humanBytes(m.NetworkBytesReceived))
+		}
 		result += fmt.Sprintf("   Processes: %d\n", m.ProcessCount)
 		result += "\n"
 	}
@@ -1910,24 +2154,25 @@ func handleGetSystemInfo(client API, args map[string]interface{}) (string, error
 		return "", fmt.Errorf("failed to get system info: %w", err)
 	}
 
+	info := resp.GetInfo()
 	result := "🖥️  System Information:\n\n"
-	result += fmt.Sprintf("Hostname: %s\n", resp.Info.Hostname)
-	result += fmt.Sprintf("OS: %s\n", resp.Info.OS)
-	result += fmt.Sprintf("Kernel: %s\n", resp.Info.KernelVersion)
-	result += fmt.Sprintf("Incus Version: %s\n", resp.Info.IncusVersion)
-	if resp.Info.DaemonVersion != "" {
-		result += fmt.Sprintf("Daemon Version: %s\n", resp.Info.DaemonVersion)
+	result += fmt.Sprintf("Hostname: %s\n", info.GetHostname())
+	result += fmt.Sprintf("OS: %s\n", info.GetOs())
+	result += fmt.Sprintf("Kernel: %s\n", info.GetKernelVersion())
+	result += fmt.Sprintf("Incus Version: %s\n", info.GetIncusVersion())
+	if info.GetDaemonVersion() != "" {
+		result += fmt.Sprintf("Daemon Version: %s\n", info.GetDaemonVersion())
 	}
 	result += "\nContainers:\n"
-	result += fmt.Sprintf("  Running: %d\n", resp.Info.ContainersRunning)
-	result += fmt.Sprintf("  Stopped: %d\n", resp.Info.ContainersStopped)
-	result += fmt.Sprintf("  Total: %d\n", resp.Info.ContainersTotal)
+	result += fmt.Sprintf("  Running: %d\n", info.GetContainersRunning())
+	result += fmt.Sprintf("  Stopped: %d\n", info.GetContainersStopped())
+	result += fmt.Sprintf("  Total: %d\n", info.GetContainersTotal())
 
 	// OTLP endpoint: where monitoring=true containers ship telemetry.
 	// Point docker-in-LXC apps here when they can't inherit the
 	// env-stamped OTEL_EXPORTER_OTLP_ENDPOINT. See #370.
-	if resp.Info.OTelCollectorEndpoint != "" {
-		result += fmt.Sprintf("\nOTel collector (OTLP/HTTP): %s\n", resp.Info.OTelCollectorEndpoint)
+	if info.GetOtelCollectorEndpoint() != "" {
+		result += fmt.Sprintf("\nOTel collector (OTLP/HTTP): %s\n", info.GetOtelCollectorEndpoint())
 	} else {
 		result += "\nOTel collector: not configured (app monitoring unavailable)\n"
 	}
@@ -2031,8 +2276,8 @@ func handleListBackends(client API, args map[string]interface{}) (string, error)
 
 	var b strings.Builder
 	fmt.Fprintf(&b, "Found %d backend(s):\n\n", len(resp.Backends))
-	for i := range resp.Backends {
-		writeBackendDetail(&b, &resp.Backends[i])
+	for _, bk := range resp.Backends {
+		writeBackendDetail(&b, bk)
 		b.WriteString("\n")
 	}
 	return b.String(), nil
@@ -2059,29 +2304,29 @@ func writeBackendDetail(b *strings.Builder, bk *Backend) {
 	if !bk.Healthy {
 		health = "✗ unhealthy"
 	}
-	fmt.Fprintf(b, "🖥️  %s  (%s, %s)\n", bk.ID, bk.Type, health)
+	fmt.Fprintf(b, "🖥️  %s  (%s, %s)\n", bk.Id, bk.Type, health)
 	if bk.Hostname != "" {
 		fmt.Fprintf(b, "   Hostname:   %s\n", bk.Hostname)
 	}
-	if bk.OS != "" {
-		fmt.Fprintf(b, "   OS:         %s\n", bk.OS)
+	if bk.Os != "" {
+		fmt.Fprintf(b, "   OS:         %s\n", bk.Os)
 	}
 	if bk.Version != "" {
 		fmt.Fprintf(b, "   Version:    %s\n", bk.Version)
 	}
 	fmt.Fprintf(b, "   Containers: %d running\n", bk.ContainerCount)
 	if bk.UptimeSeconds > 0 {
-		fmt.Fprintf(b, "   Uptime:     %s\n", formatUptime(int64(bk.UptimeSeconds)))
+		fmt.Fprintf(b, "   Uptime:     %s\n", formatUptime(bk.UptimeSeconds))
 	}
 	if bk.LastSeenAt != "" && bk.Type != "local" {
 		fmt.Fprintf(b, "   Last seen:  %s\n", bk.LastSeenAt)
 	}
-	if len(bk.GPUs) > 0 {
+	if len(bk.Gpus) > 0 {
 		fmt.Fprintf(b, "   GPUs:\n")
-		for _, g := range bk.GPUs {
+		for _, g := range bk.Gpus {
 			vram := ""
-			if g.VRAMBytes > 0 {
-				vram = fmt.Sprintf(" — %s VRAM", humanBytes(int64(g.VRAMBytes)))
+			if g.VramBytes > 0 {
+				vram = fmt.Sprintf(" — %s VRAM", humanBytes(g.VramBytes))
 			}
 			fmt.Fprintf(b, "     - %s %s%s\n", g.Vendor, g.ModelName, vram)
 		}
@@ -2129,7 +2374,7 @@ func handleListRoutes(client API, args map[string]interface{}) (string, error) {
 		return "", fmt.Errorf("failed to list routes: %w", err)
 	}
 
-	out, err := json.MarshalIndent(resp, "", "  ")
+	out, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(resp)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal response: %w", err)
 	}
@@ -2175,6 +2420,37 @@ func handleExposePort(client API, args map[string]interface{}) (string, error) {
 	return out, nil
 }
 
+func handleListContainerTemplates(client API, _ map[string]interface{}) (string, error) {
+	resp, err := client.ListContainerTemplates()
+	if err != nil {
+		return "", fmt.Errorf("failed to list container templates: %w", err)
+	}
+	if len(resp.GetTemplates()) == 0 {
+		return "No container templates defined.", nil
+	}
+	var b strings.Builder
+	for _, t := range resp.GetTemplates() {
+		fmt.Fprintf(&b, "%s", t.GetName())
+		if t.GetDescription() != "" {
+			fmt.Fprintf(&b, " — %s", t.GetDescription())
+		}
+		b.WriteString("\n")
+		if r := t.GetResources(); r != nil {
+			fmt.Fprintf(&b, "  Resources: cpu=%s memory=%s disk=%s\n", cmp.Or(r.GetCpu(), "-"), cmp.Or(r.GetMemory(), "-"), cmp.Or(r.GetDisk(), "-"))
+		}
+		if t.GetImage() != "" {
+			fmt.Fprintf(&b, "  Image: %s\n", t.GetImage())
+		}
+		if t.GetStack() != "" {
+			fmt.Fprintf(&b, "  Stack: %s\n", t.GetStack())
+		}
+		for _, r := range t.GetRoutes() {
+			fmt.Fprintf(&b, "  Route: <username>-%s -> port %d\n", r.GetSubdomain(), r.GetContainerPort())
+		}
+	}
+	return b.String(), nil
+}
+
 func handleListRecipes(client API, _ map[string]interface{}) (string, error) {
 	resp, err := client.ListRecipes()
 	if err != nil {
@@ -2325,17 +2601,14 @@ func (a *mcpExposeAdapter) LookupContainer(_ context.Context, username string) (
 	if err != nil {
 		return "", "", "", err
 	}
-	ip := ""
-	if got.Container.Network != nil {
-		ip = got.Container.Network.IPAddress
-	}
-	return got.Container.Name, ip, got.Container.State, nil
+	c := got.GetContainer()
+	return c.GetName(), c.GetNetwork().GetIpAddress(), c.GetState().String(), nil
 }
 
 func (a *mcpExposeAdapter) CreateRoute(_ context.Context, p expose.AddRouteParams) (*expose.RouteResult, error) {
-	resp, err := a.c.AddRoute(AddRouteRequest{
+	resp, err := a.c.AddRoute(&AddRouteRequest{
 		Domain:        p.Domain,
-		TargetIP:      p.TargetIP,
+		TargetIp:      p.TargetIP,
 		TargetPort:    p.TargetPort,
 		ContainerName: p.ContainerName,
 		Description:   p.Description,
@@ -2343,19 +2616,20 @@ func (a *mcpExposeAdapter) CreateRoute(_ context.Context, p expose.AddRouteParam
 	if err != nil {
 		return nil, err
 	}
-	domain := resp.Route.Domain
+	route := resp.GetRoute()
+	domain := route.GetFullDomain()
 	if domain == "" {
 		domain = p.Domain
 	}
-	containerName := resp.Route.ContainerName
+	containerName := route.GetContainerName()
 	if containerName == "" {
 		containerName = p.ContainerName
 	}
 	return &expose.RouteResult{
 		Domain:        domain,
 		ContainerName: containerName,
-		ContainerIP:   resp.Route.ContainerIP,
-		Port:          resp.Route.Port,
+		ContainerIP:   route.GetContainerIp(),
+		Port:          route.GetPort(),
 		Message:       resp.Message,
 	}, nil
 }
@@ -2,12 +2,14 @@ package mcp
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // TestGetStringArg tests the getStringArg helper
@@ -502,22 +504,24 @@ func TestExposePort_HappyPath(t *testing.T) {
 				"container": {
 					"name": "alice-container",
 					"username": "alice",
-					"state": "Running",
+					"state": "CONTAINER_STATE_RUNNING",
 					"network": {"ipAddress": "10.0.3.42"}
 				}
 			}`))
 		case r.Method == "POST" && r.URL.Path == "/v1/network/routes":
 			addRouteCalls++
-			var req AddRouteRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			req := &AddRouteRequest{}
+			require.NoError(t, protojson.Unmarshal(body, req))
 			// The handler must resolve the IP itself, not trust caller-supplied state.
-			assert.Equal(t, "10.0.3.42", req.TargetIP)
+			assert.Equal(t, "10.0.3.42", req.TargetIp)
 			assert.Equal(t, int32(8080), req.TargetPort)
 			assert.Equal(t, "blog.example.com", req.Domain)
 			assert.Equal(t, "alice-container", req.ContainerName)
 			_, _ = w.Write([]byte(`{
 				"route": {
-					"domain": "blog.example.com",
+					"fullDomain": "blog.example.com",
 					"containerIp": "10.0.3.42",
 					"port": 8080,
 					"containerName": "alice-container"
@@ -568,7 +572,7 @@ func TestExposePort_RejectsContainerWithoutIP(t *testing.T) {
 			"container": {
 				"name": "alice-container",
 				"username": "alice",
-				"state": "Stopped",
+				"state": "CONTAINER_STATE_STOPPED",
 				"network": {"ipAddress": ""}
 			}
 		}`))
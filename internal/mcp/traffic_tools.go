@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultHistoryLimit is how many connections query_traffic_history
+// returns when the caller doesn't say; the daemon caps it at 1000.
+const defaultHistoryLimit = 50
+
+// trafficTools is the MCP-side catalog for recorded traffic. The history
+// endpoints only exist on a daemon running the traffic collector, so the
+// tool is gated on capabilityTrafficHistory.
+func trafficTools() []Tool {
+	return []Tool{
+		{
+			Name: "query_traffic_history",
+			Description: "Search the connections a container made or received, from the " +
+				"daemon's traffic history: \"who did alice's box talk to in the last hour\", " +
+				"\"which containers reached 203.0.113.7\". Filter by username or container " +
+				"name, and optionally by destination/source IP and destination port. An " +
+				"admin token may leave out username and container to search every " +
+				"container by IP. Closed connections only.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Owning username, e.g. 'alice'.",
+					},
+					"container_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Container name, e.g. 'alice-container'.",
+					},
+					"dest_ip": map[string]interface{}{
+						"type":        "string",
+						"description": "Only connections to this IP.",
+					},
+					"source_ip": map[string]interface{}{
+						"type":        "string",
+						"description": "Only connections from this IP.",
+					},
+					"dest_port": map[string]interface{}{
+						"type":        "integer",
+						"description": "Only connections to this port.",
+					},
+					"since": map[string]interface{}{
+						"type":        "string",
+						"description": "Look-back window, e.g. '1h', '7d'. Default: the daemon's retention.",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Max connections to return (default %d, max 1000).", defaultHistoryLimit),
+					},
+				},
+			},
+			Handler:    handleQueryTrafficHistory,
+			Capability: capabilityTrafficHistory,
+		},
+	}
+}
+
+func handleQueryTrafficHistory(client API, args map[string]interface{}) (string, error) {
+	q := TrafficHistoryQuery{
+		Username:      getStringArg(args, "username", ""),
+		ContainerName: getStringArg(args, "container_name", ""),
+		DestIP:        getStringArg(args, "dest_ip", ""),
+		SourceIP:      getStringArg(args, "source_ip", ""),
+		Limit:         defaultHistoryLimit,
+	}
+	if q.Username == "" && q.ContainerName == "" && q.DestIP == "" && q.SourceIP == "" {
+		return "", fmt.Errorf("username, container_name, dest_ip or source_ip is required")
+	}
+	if port, ok := getIntArg(args, "dest_port"); ok {
+		if port < 1 || port > 65535 {
+			return "", fmt.Errorf("dest_port must be between 1 and 65535")
+		}
+		q.DestPort = port
+	}
+	if n, ok := getIntArg(args, "limit"); ok {
+		if n < 1 || n > 1000 {
+			return "", fmt.Errorf("limit must be between 1 and 1000")
+		}
+		q.Limit = n
+	}
+	since, err := parseActivityWindow(getStringArg(args, "since", ""))
+	if err != nil {
+		return "", err
+	}
+	q.Since = since
+
+	resp, err := client.QueryTrafficHistory(q)
+	if err != nil {
+		if ae := (*APIError)(nil); errors.As(err, &ae) && ae.Code == "FAILED_PRECONDITION" {
+			return fmt.Sprintf("Traffic history is not available on this host: %s", ae.Message), nil
+		}
+		return "", fmt.Errorf("failed to query traffic history: %w", err)
+	}
+	return formatTrafficHistory(resp), nil
+}
+
+func formatTrafficHistory(r *QueryTrafficHistoryResponse) string {
+	conns := r.GetConnections()
+	if len(conns) == 0 {
+		return "No recorded connections match."
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Connections (%d of %d):\n", len(conns), r.GetTotalCount())
+	for _, c := range conns {
+		protocol := strings.ToLower(strings.TrimPrefix(c.GetProtocol().String(), "PROTOCOL_"))
+		fmt.Fprintf(&b, "  %s  %s  %s %s → %s, %s sent / %s received",
+			c.GetStartedAt().AsTime().Format(time.RFC3339),
+			c.GetContainerName(),
+			protocol,
+			net.JoinHostPort(c.GetSourceIp(), strconv.FormatUint(uint64(c.GetSourcePort()), 10)),
+			net.JoinHostPort(c.GetDestIp(), strconv.FormatUint(uint64(c.GetDestPort()), 10)),
+			humanBytes(c.GetBytesSent()),
+			humanBytes(c.GetBytesReceived()),
+		)
+		if d := c.GetDurationSeconds(); d > 0 {
+			fmt.Fprintf(&b, ", %s", time.Duration(d)*time.Second)
+		}
+		b.WriteString("\n")
+	}
+	if int(r.GetTotalCount()) > len(conns) {
+		b.WriteString("\nNarrow the filters or raise limit to see more.\n")
+	}
+	return b.String()
+}
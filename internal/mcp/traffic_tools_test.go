@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryTrafficHistory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traffic/history" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("username") != "alice" || q.Get("dest_port") != "443" || q.Get("limit") != "2" {
+			t.Errorf("query = %v", q)
+		}
+		start, err := time.Parse(time.RFC3339, q.Get("start_time"))
+		if err != nil || time.Since(start) < 59*time.Minute || time.Since(start) > 61*time.Minute {
+			t.Errorf("start_time = %q, want an hour ago", q.Get("start_time"))
+		}
+		_, _ = io.WriteString(w, `{"totalCount":5,"connections":[
+			{"containerName":"alice-container","protocol":"PROTOCOL_TCP","sourceIp":"10.100.0.12","sourcePort":41234,
+			 "destIp":"203.0.113.7","destPort":443,"bytesSent":"2048","bytesReceived":"1048576",
+			 "startedAt":"2026-10-16T09:00:00Z","durationSeconds":"12"}]}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleQueryTrafficHistory(NewClient(srv.URL, "test-token"), map[string]interface{}{
+		"username": "alice", "dest_port": float64(443), "since": "1h", "limit": float64(2),
+	})
+	if err != nil {
+		t.Fatalf("handleQueryTrafficHistory: %v", err)
+	}
+	for _, want := range []string{
+		"Connections (1 of 5)",
+		"alice-container  tcp 10.100.0.12:41234 → 203.0.113.7:443",
+		"12s",
+		"Narrow the filters",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestQueryTrafficHistory_Validation(t *testing.T) {
+	client := NewClient("http://127.0.0.1:1", "test-token")
+	for _, args := range []map[string]interface{}{
+		{},
+		{"username": "alice", "dest_port": float64(70000)},
+		{"username": "alice", "limit": float64(0)},
+		{"username": "alice", "since": "soon"},
+	} {
+		if _, err := handleQueryTrafficHistory(client, args); err == nil {
+			t.Errorf("args %v: want an error", args)
+		}
+	}
+}
+
+func TestQueryTrafficHistory_NoPersistence(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":"traffic persistence not available","reason":"FAILED_PRECONDITION"}`)
+	}))
+	defer srv.Close()
+
+	out, err := handleQueryTrafficHistory(NewClient(srv.URL, "test-token"), map[string]interface{}{"username": "alice"})
+	if err != nil {
+		t.Fatalf("handleQueryTrafficHistory: %v", err)
+	}
+	if !strings.Contains(out, "not available on this host") {
+		t.Errorf("output = %q", out)
+	}
+}
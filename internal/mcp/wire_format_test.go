@@ -1,13 +1,15 @@
 package mcp
 
 import (
-	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
 
 // Wire-format regression tests.
@@ -20,10 +22,12 @@ import (
 // tag scheme; only fixtures that mirror the real wire shape catch
 // divergence between client and server.
 //
-// Background: a missing `,string` tag on Container.CreatedAt and the
-// ContainerMetrics int64 fields shipped to main and broke every
-// list_containers / get_container / get_metrics call against a real
-// deployment. These tests hold the line so it can't happen again.
+// Background: a missing `,string` tag on the old hand-written
+// Container.CreatedAt and the ContainerMetrics int64 fields shipped to
+// main and broke every list_containers / get_container / get_metrics call
+// against a real deployment. The client now decodes into the generated
+// messages with protojson, the gateway's own codec; these tests hold the
+// line so the decode path can't drift from the wire again.
 //
 // To refresh a fixture, capture a real response with curl, scrub
 // hostnames / IPs / customer container names down to demo values
@@ -38,24 +42,30 @@ func loadFixture(t *testing.T, name string) []byte {
 	return data
 }
 
+// decodeFixture decodes a fixture the way the client decodes a response.
+func decodeFixture(t *testing.T, name string, m proto.Message) {
+	t.Helper()
+	require.NoError(t, unmarshalProto(loadFixture(t, name), m), "decode fixture %s", name)
+}
+
 // TestWireFormat_ListContainers is the regression test for the bug
 // fixed in PR #116. The fixture has int64 fields encoded as strings
-// (the real grpc-gateway shape); without `,string` tags on the client
-// struct, this test panics on json.Unmarshal.
+// (the real grpc-gateway shape), which encoding/json into a plain int64
+// rejects.
 func TestWireFormat_ListContainers(t *testing.T) {
-	var resp ListContainersResponse
-	require.NoError(t, json.Unmarshal(loadFixture(t, "list_containers_response.json"), &resp))
+	resp := &ListContainersResponse{}
+	decodeFixture(t, "list_containers_response.json", resp)
 
-	assert.Equal(t, 3, resp.TotalCount)
+	assert.Equal(t, int32(3), resp.TotalCount)
 	require.Len(t, resp.Containers, 3)
 
 	// alice — running, has a real createdAt timestamp
 	alice := resp.Containers[0]
 	assert.Equal(t, "alice-container", alice.Name)
 	assert.Equal(t, "alice", alice.Username)
-	assert.Equal(t, "CONTAINER_STATE_RUNNING", alice.State)
+	assert.Equal(t, pb.ContainerState_CONTAINER_STATE_RUNNING, alice.State)
 	assert.NotNil(t, alice.Network)
-	assert.Equal(t, "10.0.0.10", alice.Network.IPAddress)
+	assert.Equal(t, "10.0.0.10", alice.Network.IpAddress)
 	// THE bug-prevention assertion: createdAt must round-trip from
 	// string-encoded JSON into a positive int64.
 	assert.Equal(t, int64(1771122760), alice.CreatedAt)
@@ -66,13 +76,13 @@ func TestWireFormat_ListContainers(t *testing.T) {
 	// though it's nonsense.
 	ws := resp.Containers[2]
 	assert.Equal(t, "ws2022", ws.Name)
-	assert.Equal(t, "CONTAINER_STATE_STOPPED", ws.State)
+	assert.Equal(t, pb.ContainerState_CONTAINER_STATE_STOPPED, ws.State)
 	assert.Equal(t, int64(-62135596800), ws.CreatedAt)
 }
 
 func TestWireFormat_GetContainer(t *testing.T) {
-	var resp GetContainerResponse
-	require.NoError(t, json.Unmarshal(loadFixture(t, "get_container_response.json"), &resp))
+	resp := &GetContainerResponse{}
+	decodeFixture(t, "get_container_response.json", resp)
 
 	assert.Equal(t, "alice-container", resp.Container.Name)
 	assert.Equal(t, int64(1771122760), resp.Container.CreatedAt)
@@ -81,14 +91,14 @@ func TestWireFormat_GetContainer(t *testing.T) {
 	// Embedded metrics must also decode the int64-as-string fields.
 	require.NotNil(t, resp.Metrics)
 	assert.Equal(t, "alice-container", resp.Metrics.Name)
-	assert.Equal(t, int64(1234), resp.Metrics.CPUUsageSeconds)
+	assert.Equal(t, int64(1234), resp.Metrics.CpuUsageSeconds)
 	assert.Equal(t, int64(536870912), resp.Metrics.MemoryUsageBytes)
 	assert.Equal(t, int32(42), resp.Metrics.ProcessCount) // int32 stays numeric
 }
 
 func TestWireFormat_GetMetrics(t *testing.T) {
-	var resp GetMetricsResponse
-	require.NoError(t, json.Unmarshal(loadFixture(t, "get_metrics_response.json"), &resp))
+	resp := &GetMetricsResponse{}
+	decodeFixture(t, "get_metrics_response.json", resp)
 
 	require.Len(t, resp.Metrics, 2)
 	// Spot-check the GPU container's larger numbers — well above 2^32
@@ -98,46 +108,60 @@ func TestWireFormat_GetMetrics(t *testing.T) {
 	assert.Equal(t, "bob-gpu-container", bob.Name)
 	assert.Equal(t, int64(34359738368), bob.MemoryUsageBytes) // 32 GiB
 	assert.Equal(t, int64(214748364800), bob.DiskUsageBytes)  // 200 GiB
+	assert.Equal(t, int64(6442450944), bob.NetworkBytesSent)
+	assert.Equal(t, int64(12884901888), bob.NetworkBytesReceived)
+	// Older daemons omit the traffic totals entirely.
+	assert.Zero(t, resp.Metrics[0].NetworkBytesSent)
 }
 
 func TestWireFormat_GetSystemInfo(t *testing.T) {
-	var resp GetSystemInfoResponse
-	require.NoError(t, json.Unmarshal(loadFixture(t, "get_system_info_response.json"), &resp))
+	resp := &GetSystemInfoResponse{}
+	decodeFixture(t, "get_system_info_response.json", resp)
 
 	assert.Equal(t, "6.23.0", resp.Info.IncusVersion)
-	assert.Equal(t, "Ubuntu 24.04.1 LTS", resp.Info.OS)
-	// container counts are int (not int64), no string-encoding
-	assert.Equal(t, 22, resp.Info.ContainersRunning)
-	assert.Equal(t, 24, resp.Info.ContainersTotal)
+	assert.Equal(t, "Ubuntu 24.04.1 LTS", resp.Info.Os)
+	// container counts are int32, no string-encoding
+	assert.Equal(t, int32(22), resp.Info.ContainersRunning)
+	assert.Equal(t, int32(24), resp.Info.ContainersTotal)
 }
 
 func TestWireFormat_CreateContainer(t *testing.T) {
-	var resp CreateContainerResponse
-	require.NoError(t, json.Unmarshal(loadFixture(t, "create_container_response.json"), &resp))
+	resp := &CreateContainerResponse{}
+	decodeFixture(t, "create_container_response.json", resp)
 
 	assert.Equal(t, "alice-container", resp.Container.Name)
 	assert.Equal(t, "Container created successfully", resp.Message)
-	assert.Equal(t, "ssh alice@10.0.0.10", resp.SSHCommand)
+	assert.Equal(t, "ssh alice@10.0.0.10", resp.SshCommand)
 	// createdAt must decode even on a freshly-created container
 	assert.Equal(t, int64(1771209160), resp.Container.CreatedAt)
 }
 
 // TestWireFormat_ContainerSSHHost guards a regression: the daemon stamps the
 // per-container sentinel onto ssh_host (from --ssh-host), but the MCP client's
-// Container struct lacked the field, so json.Unmarshal silently dropped the
-// reachable host and create_container fell back to the (often unset)
-// CONTAINARIUM_SENTINEL_HOST env — surfacing a "<sentinel-host>" placeholder
-// even though the daemon knew the real host all along.
+// old hand-written Container struct lacked the field, so json.Unmarshal
+// silently dropped the reachable host and create_container fell back to the
+// (often unset) CONTAINARIUM_SENTINEL_HOST env — surfacing a
+// "<sentinel-host>" placeholder even though the daemon knew the real host.
 func TestWireFormat_ContainerSSHHost(t *testing.T) {
-	var c Container
-	require.NoError(t, json.Unmarshal(
-		[]byte(`{"username":"cld-30dc0e41","sshHost":"asia-east1.example.com"}`), &c))
-	assert.Equal(t, "asia-east1.example.com", c.SSHHost)
+	c := &Container{}
+	require.NoError(t, unmarshalProto(
+		[]byte(`{"username":"alice","sshHost":"sentinel.example.com"}`), c))
+	assert.Equal(t, "sentinel.example.com", c.SshHost)
+}
+
+// TestWireFormat_UnknownFieldsIgnored: a daemon newer than the client may
+// emit fields this build's generated types don't know; the decode must not
+// fail on them.
+func TestWireFormat_UnknownFieldsIgnored(t *testing.T) {
+	resp := &GetContainerResponse{}
+	require.NoError(t, unmarshalProto(
+		[]byte(`{"container":{"name":"alice-container","addedLater":{"x":1}},"alsoNew":true}`), resp))
+	assert.Equal(t, "alice-container", resp.Container.Name)
 }
 
 func TestWireFormat_ListBackends(t *testing.T) {
-	var resp ListBackendsResponse
-	require.NoError(t, json.Unmarshal(loadFixture(t, "list_backends_response.json"), &resp))
+	resp := &ListBackendsResponse{}
+	decodeFixture(t, "list_backends_response.json", resp)
 
 	require.Len(t, resp.Backends, 3)
 
@@ -148,20 +172,18 @@ func TestWireFormat_ListBackends(t *testing.T) {
 	assert.Equal(t, "0.16.4", local.Version)
 	assert.Equal(t, int32(16), local.ContainerCount)
 	// uptimeSeconds is int64, which the proto-first /v1/backends RPC
-	// (grpc-gateway protojson, #354) string-encodes ("345600"). flexInt64
-	// decodes that string form; EqualValues compares the numeric value
-	// regardless of the flexInt64 vs int64 type.
-	assert.EqualValues(t, 345600, local.UptimeSeconds)
+	// (grpc-gateway protojson, #354) string-encodes ("345600").
+	assert.Equal(t, int64(345600), local.UptimeSeconds)
 
 	// Second backend is a tunnel peer with a GPU. VRAMBytes is likewise an
 	// int64 string-encoded by protojson.
 	gpuPeer := resp.Backends[1]
 	assert.Equal(t, "tunnel", gpuPeer.Type)
 	assert.True(t, gpuPeer.Healthy)
-	require.Len(t, gpuPeer.GPUs, 1)
-	assert.Equal(t, "NVIDIA", gpuPeer.GPUs[0].Vendor)
-	assert.Equal(t, "GeForce RTX 3090", gpuPeer.GPUs[0].ModelName)
-	assert.EqualValues(t, 25769803776, gpuPeer.GPUs[0].VRAMBytes) // 24 GiB
+	require.Len(t, gpuPeer.Gpus, 1)
+	assert.Equal(t, "NVIDIA", gpuPeer.Gpus[0].Vendor)
+	assert.Equal(t, "GeForce RTX 3090", gpuPeer.Gpus[0].ModelName)
+	assert.Equal(t, int64(25769803776), gpuPeer.Gpus[0].VramBytes) // 24 GiB
 
 	// Third backend is unhealthy — health flag must round-trip false
 	// (no "omitempty" gotcha eating the field).
@@ -170,25 +192,18 @@ func TestWireFormat_ListBackends(t *testing.T) {
 	assert.Equal(t, int32(0), dead.ContainerCount)
 }
 
-// TestWireFormat_AddRoute notes a known mismatch and documents it: our
-// AddRouteResponse.Route declares fields named Domain / ContainerName,
-// but the wire shape from grpc-gateway uses fullDomain / username
-// (the proto's actual field names — see ProxyRoute in
-// proto/containarium/v1/network.proto). The current handler in
-// expose_port falls back to caller-supplied values when these decode
-// as empty; this test pins that behavior so a future refactor that
-// "fixes" the field names without updating the fallback breaks loudly.
+// TestWireFormat_AddRoute: the old hand-written route struct declared
+// Domain / ContainerName where the wire carries fullDomain / username, so
+// both always decoded empty and expose_port silently fell back to the
+// caller's values. With the generated ProxyRoute they populate.
 func TestWireFormat_AddRoute(t *testing.T) {
-	var resp AddRouteResponse
-	require.NoError(t, json.Unmarshal(loadFixture(t, "add_route_response.json"), &resp))
-
-	// These DON'T populate from the wire shape — different field names —
-	// so the handler must fall back. Document the gap.
-	assert.Empty(t, resp.Route.Domain, "Domain field doesn't exist in proto; fullDomain does. Handler must fall back.")
-	assert.Empty(t, resp.Route.ContainerName, "ContainerName field doesn't exist in proto; username does. Handler must fall back.")
+	resp := &AddRouteResponse{}
+	decodeFixture(t, "add_route_response.json", resp)
 
-	// These DO populate — common ground between client struct and proto.
-	assert.Equal(t, "10.0.0.10", resp.Route.ContainerIP)
+	assert.Equal(t, "blog.example.com", resp.Route.FullDomain)
+	assert.Equal(t, "alice", resp.Route.Username)
+	assert.Equal(t, "10.0.0.10", resp.Route.ContainerIp)
 	assert.Equal(t, int32(8080), resp.Route.Port)
+	assert.Equal(t, pb.RouteProtocol_ROUTE_PROTOCOL_HTTP, resp.Route.Protocol)
 	assert.Equal(t, "route added", resp.Message)
 }
@@ -0,0 +1,39 @@
+package opmetrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor records a request count and latency for every
+// completed unary RPC. REST calls through grpc-gateway land here too, so
+// one interceptor covers both transports. Purely observational — the
+// response and error pass through untouched.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart. Latency is the
+// lifetime of the stream, so long-lived subscriptions land in the top
+// bucket; the count is what matters for those.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeRPC(method string, start time.Time, err error) {
+	rpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
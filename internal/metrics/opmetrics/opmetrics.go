@@ -0,0 +1,180 @@
+// Package opmetrics is the daemon's Prometheus registry for operational
+// metrics: RPC counts and latencies, container provisioning durations,
+// Incus API latencies, SSH key sync outcomes and passthrough reconcile
+// outcomes. Every component records into the one shared Registry instead
+// of inventing its own collector, and Handler serves it on /metrics.
+//
+// Unlike the OTel export path (internal/metrics), this is a pull endpoint
+// for an operator's own Prometheus. Labels are kept to a fixed, small
+// cardinality — method / operation / outcome, never a username or
+// container name — so a scrape stays cheap however many tenants a host
+// carries.
+//
+// Like platformstats, the package deliberately imports nothing from the
+// daemon: pkg/core/incus and pkg/core/network record into it, so it must
+// never pull either back in.
+package opmetrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+)
+
+const namespace = "containarium"
+
+// Registry is the shared registry every metric in this package is
+// registered on. Exported so a component with a bespoke collector can
+// register it alongside the rest rather than standing up its own
+// endpoint.
+var Registry = prometheus.NewRegistry()
+
+var (
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_requests_total",
+		Help:      "gRPC requests handled, by full method and final status code.",
+	}, []string{"method", "code"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "gRPC request latency, by full method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	containerOpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "container_operation_duration_seconds",
+		Help:      "Container provisioning operation latency, by operation and result.",
+		// Creates pull images and wait for cloud-init, so the tail runs
+		// into minutes; DefBuckets tops out at 10s.
+		Buckets: []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"operation", "result"})
+
+	containerOpFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "container_operation_failures_total",
+		Help:      "Failed container provisioning operations, by operation.",
+	}, []string{"operation"})
+
+	incusCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "incus_api_call_duration_seconds",
+		Help:      "Incus API call latency, by call and result.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"call", "result"})
+
+	sshKeySyncs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ssh_key_sync_total",
+		Help:      "SSH authorized-key sync operations, by result.",
+	}, []string{"result"})
+
+	passthroughReconciles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "passthrough_reconcile_total",
+		Help:      "Passthrough reconcile passes, by result.",
+	}, []string{"result"})
+
+	passthroughRouteChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "passthrough_reconcile_routes_total",
+		Help:      "Per-route passthrough reconcile outcomes (added, updated, removed, failed).",
+	}, []string{"outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcRequests,
+		rpcDuration,
+		containerOpDuration,
+		containerOpFailures,
+		incusCallDuration,
+		sshKeySyncs,
+		passthroughReconciles,
+		passthroughRouteChanges,
+	)
+}
+
+// Handler serves the shared registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// WriteText appends the shared registry in the text exposition format to
+// w. For processes whose /metrics is hand-rendered (the sentinel) and that
+// still want these series on the same scrape.
+func WriteText(w io.Writer) error {
+	families, err := Registry.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+	enc := expfmt.NewEncoder(w, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, mf := range families {
+		if err := enc.Encode(mf); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", mf.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// Result labels shared by the *_duration_seconds histograms.
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+func resultOf(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultOK
+}
+
+// ObserveContainerOp records one container provisioning operation
+// ("create", "delete", ...) that started at start.
+func ObserveContainerOp(operation string, start time.Time, err error) {
+	containerOpDuration.WithLabelValues(operation, resultOf(err)).Observe(time.Since(start).Seconds())
+	if err != nil {
+		containerOpFailures.WithLabelValues(operation).Inc()
+	}
+}
+
+// ObserveIncusCall records one Incus API call. errp points at the
+// caller's named error result so it can be deferred at the top of the
+// call:
+//
+//	defer opmetrics.ObserveIncusCall("start_container", time.Now(), &err)
+func ObserveIncusCall(call string, start time.Time, errp *error) {
+	var err error
+	if errp != nil {
+		err = *errp
+	}
+	incusCallDuration.WithLabelValues(call, resultOf(err)).Observe(time.Since(start).Seconds())
+}
+
+// RecordSSHKeySync records one authorized-key sync attempt.
+func RecordSSHKeySync(err error) {
+	sshKeySyncs.WithLabelValues(resultOf(err)).Inc()
+}
+
+// RecordPassthroughReconcile records one passthrough reconcile pass and
+// its per-route outcomes. A pass that errored before diffing (DB or
+// iptables unreadable) is recorded with err set and zero counts.
+func RecordPassthroughReconcile(added, updated, removed, failed int, err error) {
+	passthroughReconciles.WithLabelValues(resultOf(err)).Inc()
+	for outcome, n := range map[string]int{"added": added, "updated": updated, "removed": removed, "failed": failed} {
+		if n > 0 {
+			passthroughRouteChanges.WithLabelValues(outcome).Add(float64(n))
+		}
+	}
+}
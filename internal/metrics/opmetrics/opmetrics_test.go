@@ -0,0 +1,69 @@
+package opmetrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exercise one fake request through each recording path, then scrape the
+// handler the daemon mounts on /metrics: dashboards and alerts key off
+// these names, so a rename must fail here first.
+func TestHandler_ExposesKeyMetricsAfterFakeRequest(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/containarium.v1.ContainerService/GetContainer"}
+	_, err := interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "no such container")
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("interceptor changed the error: %v", err)
+	}
+
+	start := time.Now()
+	ObserveContainerOp("create", start, errors.New("image pull failed"))
+	var incusErr error
+	ObserveIncusCall("start_container", start, &incusErr)
+	RecordSSHKeySync(nil)
+	RecordPassthroughReconcile(1, 0, 0, 1, nil)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	out := string(body)
+
+	for _, want := range []string{
+		`containarium_rpc_requests_total{code="NotFound",method="/containarium.v1.ContainerService/GetContainer"} 1`,
+		`containarium_rpc_request_duration_seconds_count{method="/containarium.v1.ContainerService/GetContainer"} 1`,
+		`containarium_container_operation_duration_seconds_count{operation="create",result="error"} 1`,
+		`containarium_container_operation_failures_total{operation="create"} 1`,
+		`containarium_incus_api_call_duration_seconds_count{call="start_container",result="ok"} 1`,
+		`containarium_ssh_key_sync_total{result="ok"} 1`,
+		`containarium_passthrough_reconcile_total{result="ok"} 1`,
+		`containarium_passthrough_reconcile_routes_total{outcome="added"} 1`,
+		`containarium_passthrough_reconcile_routes_total{outcome="failed"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("/metrics missing %q", want)
+		}
+	}
+}
+
+func TestWriteText_MatchesHandlerFamilies(t *testing.T) {
+	RecordSSHKeySync(errors.New("401"))
+	var b strings.Builder
+	if err := WriteText(&b); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	if !strings.Contains(b.String(), `containarium_ssh_key_sync_total{result="error"}`) {
+		t.Errorf("WriteText output missing the keysync series:\n%s", b.String())
+	}
+}
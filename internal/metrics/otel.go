@@ -71,6 +71,24 @@ type EgressFanoutFetcher interface {
 	EgressFanout() []EgressFanoutStat
 }
 
+// ListenerStat is one container's listening sockets for a collection tick.
+// Listening is how many the latest scan found; Opened is how many have
+// appeared since the daemon started, so increase() over it catches a new
+// service even when another one closed in the same interval. Mirrors
+// traffic.ListenerStat at the metrics boundary.
+type ListenerStat struct {
+	ContainerName string
+	ContainerID   string
+	Listening     int
+	Opened        int64
+}
+
+// ListenerFetcher reports per-container listener counts. Implemented by an
+// adapter over the traffic collector's listener scan.
+type ListenerFetcher interface {
+	ListenerStats() []ListenerStat
+}
+
 // PeerMetricsFetcher fetches container and system metrics from peer backends.
 type PeerMetricsFetcher interface {
 	// FetchPeerMetrics returns container metrics from all healthy peers.
@@ -133,6 +151,10 @@ type Collector struct {
 	containerEgressDistinctDest otelmetric.Int64Gauge
 	containerEgressConnections  otelmetric.Int64Gauge
 
+	// Listener instruments (new-service detection).
+	containerListeningPorts  otelmetric.Int64Gauge
+	containerListenersOpened otelmetric.Int64Gauge
+
 	// Aggregate instruments
 	containersRunning otelmetric.Int64Gauge
 	containersStopped otelmetric.Int64Gauge
@@ -140,10 +162,11 @@ type Collector struct {
 	// Backend health instruments
 	backendHealthy otelmetric.Int64Gauge
 
-	ctx           context.Context
-	cancel        context.CancelFunc
-	peerFetcher   PeerMetricsFetcher
-	egressFetcher EgressFanoutFetcher
+	ctx             context.Context
+	cancel          context.CancelFunc
+	peerFetcher     PeerMetricsFetcher
+	egressFetcher   EgressFanoutFetcher
+	listenerFetcher ListenerFetcher
 }
 
 // NewCollector creates a new OTel metrics collector
@@ -333,6 +356,20 @@ func (c *Collector) initInstruments() error {
 		return err
 	}
 
+	// Listening ports, from the traffic collector's listener scan. Opened is
+	// cumulative since daemon start, so rules alert on its increase().
+	c.containerListeningPorts, err = meter.Int64Gauge("container.listening_ports",
+		otelmetric.WithDescription("Sockets the container is listening on at the latest listener scan"))
+	if err != nil {
+		return err
+	}
+
+	c.containerListenersOpened, err = meter.Int64Gauge("container.listeners.opened",
+		otelmetric.WithDescription("Listeners that appeared in the container since the daemon started"))
+	if err != nil {
+		return err
+	}
+
 	// Aggregate metrics
 	c.containersRunning, err = meter.Int64Gauge("containarium.containers.running",
 		otelmetric.WithDescription("Number of running containers"))
@@ -499,6 +536,9 @@ func (c *Collector) collect() {
 	if c.egressFetcher != nil {
 		c.RecordEgressFanout(c.egressFetcher.EgressFanout())
 	}
+	if c.listenerFetcher != nil {
+		c.RecordListeners(c.listenerFetcher.ListenerStats())
+	}
 
 	// Collect metrics from peer backends
 	if c.peerFetcher != nil {
@@ -576,6 +616,30 @@ func (c *Collector) RecordEgressFanout(stats []EgressFanoutStat) {
 	}
 }
 
+// SetListenerFetcher sets the listener-count fetcher. When set, each
+// collection tick records the container.listening_ports and
+// container.listeners.opened gauges from it.
+func (c *Collector) SetListenerFetcher(fetcher ListenerFetcher) {
+	c.listenerFetcher = fetcher
+}
+
+// RecordListeners records per-container listener counts for one tick,
+// labelled like RecordEgressFanout.
+func (c *Collector) RecordListeners(stats []ListenerStat) {
+	for _, s := range stats {
+		attrSet := []attribute.KeyValue{
+			attribute.String("container.name", s.ContainerName),
+			attribute.String("backend.id", c.config.LocalBackendID),
+		}
+		if s.ContainerID != "" {
+			attrSet = append(attrSet, attribute.String("container.id", s.ContainerID))
+		}
+		attrs := otelmetric.WithAttributes(attrSet...)
+		c.containerListeningPorts.Record(c.ctx, int64(s.Listening), attrs)
+		c.containerListenersOpened.Record(c.ctx, s.Opened, attrs)
+	}
+}
+
 // Stop shuts down the collector
 func (c *Collector) Stop() {
 	c.cancel()
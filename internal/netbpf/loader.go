@@ -200,22 +200,37 @@ func (l *Loader) SetVethPolicy(ifindex int, cfg PolicyConfig) error {
 	return nil
 }
 
-// AddEgress installs one egress allow-list LPM entry.
+// AddEgress installs (or updates) one egress allow-list LPM entry: the key
+// (tenant + CIDR) mapping to the entry's port/proto scope. An object built
+// before allow rules were port-scoped has a one-byte value that allows every
+// port; it takes any-port entries only, and a scoped one errors rather than
+// being widened.
 func (l *Loader) AddEgress(e EgressEntry) error {
-	key := egressKeyBytes(e)
-	one := uint8(1)
-	if err := l.coll.Maps[mapEgressCIDR].Update(key[:], &one, ebpf.UpdateAny); err != nil {
+	m := l.coll.Maps[mapEgressCIDR]
+	key := egressKeyBytes(e.Key())
+	var err error
+	if m.ValueSize() == 1 {
+		if e.Port != 0 || e.Proto != 0 {
+			return fmt.Errorf("netbpf: egress_cidr map can't scope an allow rule to a port/proto (rebuild netpolicy.bpf.o?)")
+		}
+		one := uint8(1)
+		err = m.Update(key[:], &one, ebpf.UpdateAny)
+	} else {
+		val := scopeValueBytes(e.Port, e.Proto)
+		err = m.Update(key[:], val[:], ebpf.UpdateAny)
+	}
+	if err != nil {
 		return fmt.Errorf("netbpf: update egress_cidr: %w", err)
 	}
 	return nil
 }
 
-// DeleteEgress removes an egress allow-list LPM entry. Used by the reconcile
-// loop to converge the map when a CIDR is removed from a policy — a stale allow
-// entry is a security hole once a tenant is in enforce mode. A missing key is
-// not an error (the desired state is already reached).
-func (l *Loader) DeleteEgress(e EgressEntry) error {
-	key := egressKeyBytes(e)
+// DeleteEgress removes an egress allow-list LPM entry by its key. Used by the
+// reconcile loop to converge the map when a CIDR is removed from a policy — a
+// stale allow entry is a security hole once a tenant is in enforce mode. A
+// missing key is not an error (the desired state is already reached).
+func (l *Loader) DeleteEgress(k EgressKey) error {
+	key := egressKeyBytes(k)
 	if err := l.coll.Maps[mapEgressCIDR].Delete(key[:]); err != nil {
 		if errors.Is(err, ebpf.ErrKeyNotExist) {
 			return nil
@@ -424,16 +439,16 @@ func vethPolicyValue(cfg PolicyConfig) [8]byte {
 	return b
 }
 
-// egressKeyBytes serializes an EgressEntry into the 12-byte `struct egress_key`
+// egressKeyBytes serializes an EgressKey into the 12-byte `struct egress_key`
 // layout: u32 prefixlen, u32 tenant_id, u32 addr. prefixlen and tenant_id are
 // native byte order to match the program's struct loads; addr stays in network
 // byte order (the 4 IPv4 bytes as-is) so the LPM trie prefix-matches CIDRs
 // most-significant-byte first.
-func egressKeyBytes(e EgressEntry) [12]byte {
+func egressKeyBytes(k EgressKey) [12]byte {
 	var b [12]byte
-	binary.NativeEndian.PutUint32(b[0:4], e.PrefixLen)
-	binary.NativeEndian.PutUint32(b[4:8], e.TenantID)
-	copy(b[8:12], e.Addr[:])
+	binary.NativeEndian.PutUint32(b[0:4], k.PrefixLen)
+	binary.NativeEndian.PutUint32(b[4:8], k.TenantID)
+	copy(b[8:12], k.Addr[:])
 	return b
 }
 
@@ -449,11 +464,18 @@ func denyKeyBytes(k DenyKey) [12]byte {
 }
 
 // denyValueBytes serializes a DenyEntry's scope into the 4-byte `struct deny_val`
-// layout: u16 port (host byte order — the program compares it against the ntoh'd
-// dport), u8 proto, u8 flags (reserved 0).
+// layout (see scopeValueBytes).
 func denyValueBytes(e DenyEntry) [4]byte {
+	return scopeValueBytes(e.Port, e.Proto)
+}
+
+// scopeValueBytes serializes a rule's port/proto scope into the 4-byte
+// `struct deny_val` layout deny_cidr and egress_cidr share: u16 port (host
+// byte order — the program compares it against the ntoh'd dport), u8 proto,
+// u8 flags (reserved 0).
+func scopeValueBytes(port uint16, proto uint8) [4]byte {
 	var b [4]byte
-	binary.NativeEndian.PutUint16(b[0:2], e.Port)
-	b[2] = e.Proto
+	binary.NativeEndian.PutUint16(b[0:2], port)
+	b[2] = proto
 	return b
 }
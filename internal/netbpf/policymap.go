@@ -2,6 +2,7 @@ package netbpf
 
 import (
 	"fmt"
+	"net/netip"
 
 	"github.com/footprintai/containarium/internal/netpolicy"
 	"github.com/footprintai/containarium/internal/safecast"
@@ -27,13 +28,30 @@ type PolicyConfig struct {
 
 // EgressEntry is one allowed-egress LPM-trie entry the loader writes into the
 // BPF egress_cidr map. It is the tenant-scoped destination prefix the sender's
-// policy permits. Field layout mirrors `struct egress_key` in netpolicy.bpf.c
+// policy permits. The key fields mirror `struct egress_key` in netpolicy.bpf.c
 // (prefixlen counts the 32-bit exact tenant match plus the IPv4 prefix bits;
-// Addr holds the masked network address in network byte order).
+// Addr holds the masked network address in network byte order); Port/Proto
+// (0 = any) are the value, scoping an allow rule to one service, as a
+// DenyEntry's do.
 type EgressEntry struct {
 	PrefixLen uint32
 	TenantID  uint32
 	Addr      [4]byte
+	Port      uint16
+	Proto     uint8
+}
+
+// EgressKey is the kernel-map key portion of an EgressEntry. Two entries with
+// the same EgressKey address the same map slot.
+type EgressKey struct {
+	PrefixLen uint32
+	TenantID  uint32
+	Addr      [4]byte
+}
+
+// Key returns the entry's kernel-map key.
+func (e EgressEntry) Key() EgressKey {
+	return EgressKey{PrefixLen: e.PrefixLen, TenantID: e.TenantID, Addr: e.Addr}
 }
 
 // tenantPrefixBits is the LPM prefix length contributed by the tenant_id field:
@@ -112,24 +130,50 @@ func CompileConfig(tenantID uint32, c netpolicy.CompiledPolicy) PolicyConfig {
 }
 
 // CompileEgress renders the tenant's egress allow-list (the already-parsed,
-// masked, deduped EgressCIDRs of a CompiledPolicy) into LPM-trie entries.
+// masked, deduped EgressCIDRs and port/proto-scoped AllowRules of a
+// CompiledPolicy) into LPM-trie entries, one per key: where a CIDR and an
+// allow rule share a prefix, the CIDR's any-port entry wins.
 //
 // Phase A is IPv4-only (the BPF program parses IPv4 only); any IPv6 CIDR is
 // rejected with an error rather than silently dropped, so a v6 allow-rule can't
 // masquerade as effective. EgressDomains are not handled here — the daemon's
 // resolver (Phase C) folds resolved domain IPs into the same map.
+//
+// A default-allow policy compiles to a single 0.0.0.0/0 entry: the program
+// checks deny rules and the metadata IP before the allow-list, so those still
+// block, and the explicit CIDRs would be redundant under it.
 func CompileEgress(tenantID uint32, c netpolicy.CompiledPolicy) ([]EgressEntry, error) {
-	out := make([]EgressEntry, 0, len(c.EgressCIDRs))
-	for _, p := range c.EgressCIDRs {
+	if c.DefaultAllow {
+		return []EgressEntry{{PrefixLen: tenantPrefixBits, TenantID: tenantID}}, nil
+	}
+	out := make([]EgressEntry, 0, len(c.EgressCIDRs)+len(c.AllowRules))
+	seen := make(map[EgressKey]bool, cap(out))
+	add := func(p netip.Prefix, port uint16, proto uint8) error {
 		if !p.Addr().Is4() {
-			return nil, fmt.Errorf("netbpf: egress CIDR %s is not IPv4 (Phase A is IPv4-only)", p)
+			return fmt.Errorf("netbpf: egress CIDR %s is not IPv4 (Phase A is IPv4-only)", p)
 		}
 		entry := EgressEntry{
 			PrefixLen: tenantPrefixBits + safecast.U32(p.Bits()),
 			TenantID:  tenantID,
 			Addr:      p.Addr().As4(), // masked network address, network byte order
+			Port:      port,
+			Proto:     proto,
+		}
+		if !seen[entry.Key()] {
+			seen[entry.Key()] = true
+			out = append(out, entry)
+		}
+		return nil
+	}
+	for _, p := range c.EgressCIDRs {
+		if err := add(p, 0, 0); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range c.AllowRules {
+		if err := add(a.CIDR, a.Port, a.Proto); err != nil {
+			return nil, err
 		}
-		out = append(out, entry)
 	}
 	return out, nil
 }
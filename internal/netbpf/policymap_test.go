@@ -79,6 +79,43 @@ func TestCompileEgress(t *testing.T) {
 	}
 }
 
+func TestCompileEgress_AllowRules(t *testing.T) {
+	c := mustCompile(t, &pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressCidrs: []string{"10.0.0.0/8"},
+		AllowRules: []*pb.NetworkPolicyAllowRule{
+			{Cidr: "1.2.3.4", Port: 443, Proto: "tcp"},
+			{Cidr: "10.1.2.3/32", Port: 22, Proto: "tcp"}, // covered by 10.0.0.0/8: dropped
+		},
+	})
+	entries, err := CompileEgress(7, c)
+	if err != nil {
+		t.Fatalf("CompileEgress: %v", err)
+	}
+	want := []EgressEntry{
+		{PrefixLen: 32 + 8, TenantID: 7, Addr: [4]byte{10, 0, 0, 0}},
+		{PrefixLen: 32 + 32, TenantID: 7, Addr: [4]byte{1, 2, 3, 4}, Port: 443, Proto: 6},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		if entries[i] != w {
+			t.Errorf("entry[%d] = %+v, want %+v", i, entries[i], w)
+		}
+	}
+
+	// A domain-resolved /32 on an allow rule's key wins with any-port scope.
+	c.EgressCIDRs = append(c.EgressCIDRs, netip.MustParsePrefix("1.2.3.4/32"))
+	entries, err = CompileEgress(7, c)
+	if err != nil {
+		t.Fatalf("CompileEgress: %v", err)
+	}
+	if len(entries) != 2 || entries[1] != (EgressEntry{PrefixLen: 64, TenantID: 7, Addr: [4]byte{1, 2, 3, 4}}) {
+		t.Errorf("entries = %+v, want the any-port /32 to replace the port-scoped rule", entries)
+	}
+}
+
 func TestCompileEgress_RejectsIPv6(t *testing.T) {
 	c := netpolicy.CompiledPolicy{
 		Tenant:      "alice",
@@ -0,0 +1,102 @@
+package netpolicy
+
+import (
+	"net/netip"
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestCompile_AllowRules(t *testing.T) {
+	got, err := Compile(&pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressCidrs: []string{"10.0.0.0/8"},
+		AllowRules: []*pb.NetworkPolicyAllowRule{
+			{Cidr: "1.2.3.4", Port: 80, Proto: "tcp"},
+			{Cidr: "1.2.3.4/32", Port: 443, Proto: "TCP"}, // same CIDR → dedup (last wins)
+			{Cidr: "10.9.8.7", Port: 22},                  // inside an egress CIDR → dropped
+			{Cidr: "192.0.2.0/24", Proto: "udp"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	want := []AllowRule{
+		{CIDR: netip.MustParsePrefix("1.2.3.4/32"), Port: 443, Proto: 6},
+		{CIDR: netip.MustParsePrefix("192.0.2.0/24"), Proto: 17},
+	}
+	if len(got.AllowRules) != len(want) {
+		t.Fatalf("allow rules = %+v, want %+v", got.AllowRules, want)
+	}
+	for i, w := range want {
+		if got.AllowRules[i] != w {
+			t.Errorf("rule %d = %+v, want %+v", i, got.AllowRules[i], w)
+		}
+	}
+
+	// ToProto round-trips the kept rules.
+	back, err := Compile(got.ToProto())
+	if err != nil {
+		t.Fatalf("Compile(ToProto): %v", err)
+	}
+	if len(back.AllowRules) != len(want) || back.AllowRules[0] != want[0] || back.AllowRules[1] != want[1] {
+		t.Errorf("round-tripped allow rules = %+v, want %+v", back.AllowRules, want)
+	}
+}
+
+func TestCompile_AllowRules_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		rule *pb.NetworkPolicyAllowRule
+		want string
+	}{
+		{"empty cidr", &pb.NetworkPolicyAllowRule{Cidr: " "}, "cidr is required"},
+		{"bad cidr", &pb.NetworkPolicyAllowRule{Cidr: "nope"}, "invalid allow address"},
+		{"port range", &pb.NetworkPolicyAllowRule{Cidr: "1.1.1.1", Port: 70000}, "out of range"},
+		{"bad proto", &pb.NetworkPolicyAllowRule{Cidr: "1.1.1.1", Proto: "sctp"}, "unknown allow proto"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Compile(&pb.NetworkPolicy{Tenant: "t", AllowRules: []*pb.NetworkPolicyAllowRule{tc.rule}})
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tc.want)
+			}
+			if !contains(err.Error(), tc.want) {
+				t.Errorf("error %q does not contain %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckEgress_AllowRules(t *testing.T) {
+	c, err := Compile(&pb.NetworkPolicy{
+		Tenant: "alice",
+		AllowRules: []*pb.NetworkPolicyAllowRule{
+			{Cidr: "203.0.113.0/24", Port: 443, Proto: "tcp"},
+			{Cidr: "203.0.113.7", Port: 22, Proto: "tcp"}, // longer prefix: shadows the /24 rule
+		},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	cases := []struct {
+		name    string
+		e       Egress
+		allowed bool
+		rule    string
+	}{
+		{"scoped port", Egress{Dst: netip.MustParseAddr("203.0.113.5"), Port: 443, Proto: 6}, true, "allow rule 203.0.113.0/24 (tcp/443)"},
+		{"other port", Egress{Dst: netip.MustParseAddr("203.0.113.5"), Port: 80, Proto: 6}, false, "no egress CIDR or domain covers 203.0.113.5"},
+		{"other proto", Egress{Dst: netip.MustParseAddr("203.0.113.5"), Port: 443, Proto: 17}, false, "no egress CIDR or domain covers 203.0.113.5"},
+		{"longest rule wins", Egress{Dst: netip.MustParseAddr("203.0.113.7"), Port: 443, Proto: 6}, false, "no egress CIDR or domain covers 203.0.113.7"},
+		{"longest rule port", Egress{Dst: netip.MustParseAddr("203.0.113.7"), Port: 22, Proto: 6}, true, "allow rule 203.0.113.7/32 (tcp/22)"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := c.CheckEgress(tc.e, nil)
+			if got.Allowed != tc.allowed || got.Rule != tc.rule {
+				t.Errorf("CheckEgress = %+v, want allowed=%v rule=%q", got, tc.allowed, tc.rule)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package netpolicy
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// MetadataAddr is the cloud metadata service, denied unless AllowMetadata.
+var MetadataAddr = netip.AddrFrom4([4]byte{169, 254, 169, 254})
+
+// Egress is one outbound packet to check against a policy.
+type Egress struct {
+	Dst   netip.Addr
+	Port  uint16
+	Proto uint8 // IP protocol number: 6 = tcp, 17 = udp, 1 = icmp
+	// DstTenant is the tenant owning Dst when it is a managed container on
+	// this backend; "" for an external destination.
+	DstTenant string
+}
+
+// EgressDecision is what the policy does with an Egress.
+type EgressDecision struct {
+	Allowed bool
+	// Rule is the part of the policy that decided, e.g. "egress CIDR
+	// 10.0.0.0/8" or "deny rule 203.0.113.7/32 (CVE-2026-1234)".
+	Rule string
+	// VirtualPatch is set when an explicit deny rule blocked the packet,
+	// rather than the allow-list not covering it.
+	VirtualPatch bool
+}
+
+// CheckEgress decides an Egress the way the TC program does: deny rules
+// first (only the longest matching prefix counts, as in the kernel's LPM
+// map), then the metadata guard, then the intra-tenant gate for managed
+// destinations, then the egress allow-list: CIDRs, the longest matching
+// port/proto-scoped allow rule, and domains. domainIPs returns what an
+// egress domain currently resolves to (the daemon's resolver cache); nil
+// ignores EgressDomains. Callers drop expired deny rules first.
+func (c CompiledPolicy) CheckEgress(e Egress, domainIPs func(domain string) []netip.Addr) EgressDecision {
+	if d, ok := c.longestDeny(e.Dst); ok &&
+		(d.Port == 0 || d.Port == e.Port) &&
+		(d.Proto == 0 || d.Proto == e.Proto) {
+		rule := "deny rule " + d.CIDR.String()
+		if d.Note != "" {
+			rule += " (" + d.Note + ")"
+		}
+		return EgressDecision{Rule: rule, VirtualPatch: true}
+	}
+
+	if e.Dst == MetadataAddr {
+		if c.AllowMetadata {
+			return EgressDecision{Allowed: true, Rule: "allow_metadata"}
+		}
+		return EgressDecision{Rule: "metadata service is denied unless allow_metadata is set"}
+	}
+
+	if e.DstTenant != "" {
+		switch {
+		case e.DstTenant != c.Tenant:
+			return EgressDecision{Rule: fmt.Sprintf("destination is tenant %s's container; cross-tenant traffic is denied", e.DstTenant)}
+		case !c.AllowIntraTenant:
+			return EgressDecision{Rule: "destination is another of the tenant's containers and allow_intra_tenant is off"}
+		}
+		return EgressDecision{Allowed: true, Rule: "allow_intra_tenant"}
+	}
+
+	if c.DefaultAllow {
+		return EgressDecision{Allowed: true, Rule: "default_egress ALLOW"}
+	}
+	for _, p := range c.EgressCIDRs {
+		if p.Contains(e.Dst) {
+			return EgressDecision{Allowed: true, Rule: "egress CIDR " + p.String()}
+		}
+	}
+	if a, ok := c.longestAllow(e.Dst); ok &&
+		(a.Port == 0 || a.Port == e.Port) &&
+		(a.Proto == 0 || a.Proto == e.Proto) {
+		return EgressDecision{Allowed: true, Rule: "allow rule " + a.CIDR.String() + ruleScope(a.Port, a.Proto)}
+	}
+	if domainIPs != nil {
+		for _, dom := range c.EgressDomains {
+			for _, ip := range domainIPs(dom) {
+				if ip == e.Dst {
+					return EgressDecision{Allowed: true, Rule: "egress domain " + dom}
+				}
+			}
+		}
+	}
+	return EgressDecision{Rule: "no egress CIDR or domain covers " + e.Dst.String()}
+}
+
+// longestAllow returns the allow rule with the longest prefix containing
+// dst; as with deny rules, only that one counts.
+func (c CompiledPolicy) longestAllow(dst netip.Addr) (AllowRule, bool) {
+	var best AllowRule
+	found := false
+	for _, a := range c.AllowRules {
+		if a.CIDR.Contains(dst) && (!found || a.CIDR.Bits() > best.CIDR.Bits()) {
+			best, found = a, true
+		}
+	}
+	return best, found
+}
+
+// ruleScope renders a rule's port/proto scope, e.g. " (tcp/443)"; empty
+// for a rule that allows every port and protocol.
+func ruleScope(port uint16, proto uint8) string {
+	switch {
+	case port == 0 && proto == 0:
+		return ""
+	case port == 0:
+		return " (" + protoName(proto) + ")"
+	case proto == 0:
+		return fmt.Sprintf(" (port %d)", port)
+	}
+	return fmt.Sprintf(" (%s/%d)", protoName(proto), port)
+}
+
+// longestDeny returns the deny rule with the longest prefix containing dst.
+func (c CompiledPolicy) longestDeny(dst netip.Addr) (DenyRule, bool) {
+	var best DenyRule
+	found := false
+	for _, d := range c.DenyRules {
+		if d.CIDR.Contains(dst) && (!found || d.CIDR.Bits() > best.CIDR.Bits()) {
+			best, found = d, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,64 @@
+package netpolicy
+
+import (
+	"net/netip"
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestCheckEgress(t *testing.T) {
+	c, err := Compile(&pb.NetworkPolicy{
+		Tenant:        "alice",
+		EgressCidrs:   []string{"10.20.0.0/16", "203.0.113.0/24"},
+		EgressDomains: []string{"registry.corp.example"},
+		DenyRules: []*pb.NetworkPolicyDenyRule{
+			{Cidr: "203.0.113.0/24", Port: 6379, Proto: "tcp", Note: "CVE-2026-0001"},
+			{Cidr: "203.0.113.7"}, // longer prefix: shadows the port-scoped /24 rule
+		},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	registry := netip.MustParseAddr("198.51.100.9")
+	domainIPs := func(d string) []netip.Addr {
+		if d == "registry.corp.example" {
+			return []netip.Addr{registry}
+		}
+		return nil
+	}
+
+	cases := []struct {
+		name         string
+		e            Egress
+		allowed      bool
+		virtualPatch bool
+		rule         string
+	}{
+		{"allow-list CIDR", Egress{Dst: netip.MustParseAddr("10.20.1.1"), Port: 443, Proto: 6}, true, false, "egress CIDR 10.20.0.0/16"},
+		{"resolved domain", Egress{Dst: registry, Port: 443, Proto: 6}, true, false, "egress domain registry.corp.example"},
+		{"not covered", Egress{Dst: netip.MustParseAddr("192.0.2.1"), Port: 443, Proto: 6}, false, false, "no egress CIDR or domain covers 192.0.2.1"},
+		{"deny rule port", Egress{Dst: netip.MustParseAddr("203.0.113.5"), Port: 6379, Proto: 6}, false, true, "deny rule 203.0.113.0/24 (CVE-2026-0001)"},
+		{"deny rule other port", Egress{Dst: netip.MustParseAddr("203.0.113.5"), Port: 443, Proto: 6}, true, false, "egress CIDR 203.0.113.0/24"},
+		{"longest deny wins", Egress{Dst: netip.MustParseAddr("203.0.113.7"), Port: 443, Proto: 6}, false, true, "deny rule 203.0.113.7/32"},
+		{"metadata", Egress{Dst: MetadataAddr, Port: 80, Proto: 6}, false, false, "metadata service is denied unless allow_metadata is set"},
+		{"cross-tenant", Egress{Dst: netip.MustParseAddr("10.20.0.9"), Port: 22, Proto: 6, DstTenant: "bob"}, false, false, "destination is tenant bob's container; cross-tenant traffic is denied"},
+		{"intra-tenant off", Egress{Dst: netip.MustParseAddr("10.20.0.8"), Port: 22, Proto: 6, DstTenant: "alice"}, false, false, "destination is another of the tenant's containers and allow_intra_tenant is off"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := c.CheckEgress(tc.e, domainIPs)
+			if got.Allowed != tc.allowed || got.VirtualPatch != tc.virtualPatch || got.Rule != tc.rule {
+				t.Errorf("CheckEgress = %+v, want allowed=%v virtualPatch=%v rule=%q", got, tc.allowed, tc.virtualPatch, tc.rule)
+			}
+		})
+	}
+
+	c.DefaultAllow = true
+	if got := c.CheckEgress(Egress{Dst: netip.MustParseAddr("192.0.2.1"), Port: 443, Proto: 6}, nil); !got.Allowed || got.Rule != "default_egress ALLOW" {
+		t.Errorf("default allow: %+v", got)
+	}
+	if got := c.CheckEgress(Egress{Dst: MetadataAddr, Port: 80, Proto: 6}, nil); got.Allowed {
+		t.Errorf("default allow must not open the metadata service: %+v", got)
+	}
+}
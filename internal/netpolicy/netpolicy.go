@@ -34,12 +34,28 @@ type CompiledPolicy struct {
 	// LogOnly is true unless Mode is ENFORCE — i.e. UNSPECIFIED and LOG_ONLY
 	// both observe-only (Phase A default), only ENFORCE drops packets.
 	LogOnly bool
+	// DefaultAllow lets any external egress out (default_egress ALLOW), so only
+	// DenyRules and the metadata guard restrict the tenant. False keeps the
+	// egress CIDRs/domains an allow-list.
+	DefaultAllow bool
 	// DenyRules are virtual-patch block rules (#660): parsed/masked/deduped/sorted
 	// destination prefixes (optionally port/proto-scoped) that are denied BEFORE
 	// the egress allow-list is consulted — deny beats allow. Expiry is preserved
 	// here (not filtered) so the package stays time-pure; the daemon drops expired
 	// rules with DenyRule.Expired(now) before pushing them to the kernel.
 	DenyRules []DenyRule
+	// AllowRules extend the allow-list with port/proto-scoped destinations:
+	// parsed/masked/deduped/sorted, minus any an EgressCIDRs prefix already
+	// covers (it allows every port).
+	AllowRules []AllowRule
+}
+
+// AllowRule is one normalized port/proto-scoped egress allow rule. Port and
+// Proto (0 = any) scope the allow to a single service on the CIDR.
+type AllowRule struct {
+	CIDR  netip.Prefix
+	Port  uint16 // 0 = any port
+	Proto uint8  // IP protocol number (0 = any; 6 = tcp, 17 = udp)
 }
 
 // DenyRule is one normalized virtual-patch block rule (#660). The destination
@@ -89,6 +105,10 @@ func Compile(p *pb.NetworkPolicy) (CompiledPolicy, error) {
 	if err != nil {
 		return CompiledPolicy{}, err
 	}
+	allow, err := compileAllowRules(p.GetAllowRules(), cidrs)
+	if err != nil {
+		return CompiledPolicy{}, err
+	}
 
 	// Unspecified defaults to log-only in Phase A; reject unknown enum values.
 	mode := p.GetMode()
@@ -102,6 +122,18 @@ func Compile(p *pb.NetworkPolicy) (CompiledPolicy, error) {
 		return CompiledPolicy{}, fmt.Errorf("network policy: unknown mode %d", int32(mode))
 	}
 
+	// Unspecified default_egress keeps the allow-list semantics every policy
+	// had before the field existed.
+	var defaultAllow bool
+	switch p.GetDefaultEgress() {
+	case pb.NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_UNSPECIFIED,
+		pb.NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_DENY:
+	case pb.NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_ALLOW:
+		defaultAllow = true
+	default:
+		return CompiledPolicy{}, fmt.Errorf("network policy: unknown default_egress %d", int32(p.GetDefaultEgress()))
+	}
+
 	return CompiledPolicy{
 		Tenant:           tenant,
 		AllowIntraTenant: p.GetAllowIntraTenant(),
@@ -111,6 +143,8 @@ func Compile(p *pb.NetworkPolicy) (CompiledPolicy, error) {
 		Mode:             mode,
 		LogOnly:          mode != pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE,
 		DenyRules:        deny,
+		AllowRules:       allow,
+		DefaultAllow:     defaultAllow,
 	}, nil
 }
 
@@ -139,6 +173,18 @@ func (c CompiledPolicy) ToProto() *pb.NetworkPolicy {
 			}
 		}
 	}
+	var allow []*pb.NetworkPolicyAllowRule
+	for _, a := range c.AllowRules {
+		allow = append(allow, &pb.NetworkPolicyAllowRule{
+			Cidr:  a.CIDR.String(),
+			Port:  uint32(a.Port),
+			Proto: protoName(a.Proto),
+		})
+	}
+	defaultEgress := pb.NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_DENY
+	if c.DefaultAllow {
+		defaultEgress = pb.NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_ALLOW
+	}
 	return &pb.NetworkPolicy{
 		Tenant:           c.Tenant,
 		AllowIntraTenant: c.AllowIntraTenant,
@@ -147,6 +193,8 @@ func (c CompiledPolicy) ToProto() *pb.NetworkPolicy {
 		AllowMetadata:    c.AllowMetadata,
 		Mode:             c.Mode,
 		DenyRules:        deny,
+		DefaultEgress:    defaultEgress,
+		AllowRules:       allow,
 	}
 }
 
@@ -194,28 +242,14 @@ func compileDenyRules(raw []*pb.NetworkPolicyDenyRule) ([]DenyRule, error) {
 		if r == nil {
 			continue
 		}
-		c := strings.TrimSpace(r.GetCidr())
-		if c == "" {
-			return nil, fmt.Errorf("network policy: deny rule cidr is required")
-		}
-		var prefix netip.Prefix
-		if strings.Contains(c, "/") {
-			p, err := netip.ParsePrefix(c)
-			if err != nil {
-				return nil, fmt.Errorf("network policy: invalid deny cidr %q: %w", c, err)
-			}
-			prefix = p.Masked()
-		} else {
-			a, err := netip.ParseAddr(c)
-			if err != nil {
-				return nil, fmt.Errorf("network policy: invalid deny address %q: %w", c, err)
-			}
-			prefix = netip.PrefixFrom(a, a.BitLen())
+		prefix, err := parseRuleCIDR("deny", r.GetCidr())
+		if err != nil {
+			return nil, err
 		}
 		if r.GetPort() > 65535 {
 			return nil, fmt.Errorf("network policy: deny rule port %d out of range (0-65535)", r.GetPort())
 		}
-		proto, err := parseProto(r.GetProto())
+		proto, err := parseProto("deny", r.GetProto())
 		if err != nil {
 			return nil, err
 		}
@@ -243,9 +277,79 @@ func compileDenyRules(raw []*pb.NetworkPolicyDenyRule) ([]DenyRule, error) {
 	return out, nil
 }
 
+// compileAllowRules parses each port/proto-scoped allow rule the way
+// compileDenyRules parses deny rules, deduped by CIDR for the same reason:
+// the egress_cidr LPM map holds one entry per prefix. A rule inside one of
+// cidrs (the any-port egress CIDRs) is dropped: that prefix already allows
+// it, and the rule's longer prefix would otherwise win the kernel's LPM
+// lookup and shut the prefix's other ports.
+func compileAllowRules(raw []*pb.NetworkPolicyAllowRule, cidrs []netip.Prefix) ([]AllowRule, error) {
+	seen := make(map[string]AllowRule, len(raw))
+	for _, r := range raw {
+		if r == nil {
+			continue
+		}
+		prefix, err := parseRuleCIDR("allow", r.GetCidr())
+		if err != nil {
+			return nil, err
+		}
+		if r.GetPort() > 65535 {
+			return nil, fmt.Errorf("network policy: allow rule port %d out of range (0-65535)", r.GetPort())
+		}
+		proto, err := parseProto("allow", r.GetProto())
+		if err != nil {
+			return nil, err
+		}
+		seen[prefix.String()] = AllowRule{
+			CIDR:  prefix,
+			Port:  safecast.U16FromUint(r.GetPort()), // range already checked above
+			Proto: proto,
+		}
+	}
+	out := make([]AllowRule, 0, len(seen))
+	for _, a := range seen {
+		if !coveredBy(a.CIDR, cidrs) {
+			out = append(out, a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CIDR.String() < out[j].CIDR.String() })
+	return out, nil
+}
+
+// coveredBy reports whether p lies within one of prefixes.
+func coveredBy(p netip.Prefix, prefixes []netip.Prefix) bool {
+	for _, q := range prefixes {
+		if q.Bits() <= p.Bits() && q.Contains(p.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRuleCIDR parses a deny or allow rule's destination: a CIDR, masked
+// to its network address, or a bare host IP taken as a /32.
+func parseRuleCIDR(kind, c string) (netip.Prefix, error) {
+	c = strings.TrimSpace(c)
+	if c == "" {
+		return netip.Prefix{}, fmt.Errorf("network policy: %s rule cidr is required", kind)
+	}
+	if strings.Contains(c, "/") {
+		p, err := netip.ParsePrefix(c)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("network policy: invalid %s cidr %q: %w", kind, c, err)
+		}
+		return p.Masked(), nil
+	}
+	a, err := netip.ParseAddr(c)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("network policy: invalid %s address %q: %w", kind, c, err)
+	}
+	return netip.PrefixFrom(a, a.BitLen()), nil
+}
+
 // parseProto maps a friendly protocol name to its IP protocol number. ""/"any"
-// is 0 (match any protocol).
-func parseProto(s string) (uint8, error) {
+// is 0 (match any protocol). kind names the rule type in the error.
+func parseProto(kind, s string) (uint8, error) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "", "any":
 		return 0, nil
@@ -254,7 +358,7 @@ func parseProto(s string) (uint8, error) {
 	case "udp":
 		return 17, nil
 	default:
-		return 0, fmt.Errorf("network policy: unknown deny proto %q (want tcp, udp, or empty)", s)
+		return 0, fmt.Errorf("network policy: unknown %s proto %q (want tcp, udp, or empty)", kind, s)
 	}
 }
 
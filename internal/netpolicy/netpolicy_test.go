@@ -64,6 +64,7 @@ func TestCompile_Errors(t *testing.T) {
 		{"domain with scheme", &pb.NetworkPolicy{Tenant: "t", EgressDomains: []string{"https://x.com"}}, "bare hostname"},
 		{"domain with port", &pb.NetworkPolicy{Tenant: "t", EgressDomains: []string{"x.com:443"}}, "bare hostname"},
 		{"unknown mode", &pb.NetworkPolicy{Tenant: "t", Mode: pb.NetworkPolicyMode(99)}, "unknown mode"},
+		{"unknown default", &pb.NetworkPolicy{Tenant: "t", DefaultEgress: pb.NetworkPolicyDefaultAction(99)}, "unknown default_egress"},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -92,6 +93,27 @@ func TestCompile_EmptyListsAreFine(t *testing.T) {
 	}
 }
 
+func TestCompile_DefaultEgress(t *testing.T) {
+	deny := pb.NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_DENY
+	allow := pb.NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_ALLOW
+	for in, want := range map[pb.NetworkPolicyDefaultAction]pb.NetworkPolicyDefaultAction{
+		pb.NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_UNSPECIFIED: deny,
+		deny:  deny,
+		allow: allow,
+	} {
+		got, err := Compile(&pb.NetworkPolicy{Tenant: "t", DefaultEgress: in})
+		if err != nil {
+			t.Fatalf("Compile(default=%v): %v", in, err)
+		}
+		if got.DefaultAllow != (want == allow) {
+			t.Errorf("default=%v: DefaultAllow = %v", in, got.DefaultAllow)
+		}
+		if echoed := got.ToProto().GetDefaultEgress(); echoed != want {
+			t.Errorf("default=%v: ToProto echoes %v, want %v", in, echoed, want)
+		}
+	}
+}
+
 func contains(s, sub string) bool {
 	return len(sub) == 0 || (len(s) >= len(sub) && indexOf(s, sub) >= 0)
 }
@@ -77,3 +77,32 @@ func TestValidateValueForDelivery(t *testing.T) {
 		t.Error("compose/CR should be rejected")
 	}
 }
+
+func TestValidatePath(t *testing.T) {
+	ok := []string{
+		"/home/alice/.config/app/token",
+		"/run/app/db_password",
+		"/srv/api/.env",
+		"/opt/app/credentials.json",
+	}
+	for _, p := range ok {
+		if err := ValidatePath("alice", p); err != nil {
+			t.Errorf("ValidatePath(%q): unexpected error %v", p, err)
+		}
+	}
+	bad := []string{
+		"",
+		"relative/token",
+		"/etc/shadow",
+		"/home/bob/.token",          // another user's home
+		"/home/alice",               // the directory itself
+		"/home/alice/../../etc/pwd", // not clean
+		"/run/",
+		"/runaway/token",
+	}
+	for _, p := range bad {
+		if err := ValidatePath("alice", p); err == nil {
+			t.Errorf("ValidatePath(%q): got nil, want error", p)
+		}
+	}
+}
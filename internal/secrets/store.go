@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
@@ -29,6 +30,11 @@ type SecretMetadata struct {
 	// Phase A lands the field; Phase B switches the stamping
 	// path to honor it. See docs/security/SECRETS-ENV-VAR-RISK.md.
 	Delivery string
+
+	// Path is where a "file" secret is written inside the container
+	// when the tenant chose one (SetAtPath). Empty means the default
+	// /run/secrets/<NAME>.
+	Path string
 }
 
 // Delivery-mode constants. The DB column stores these
@@ -57,6 +63,22 @@ func ValidateDelivery(mode string) error {
 		DeliveryEnv, DeliveryFile, DeliveryCompose, mode)
 }
 
+// ValidatePath checks a file-secret path chosen for username's
+// container. It must be absolute and clean, and sit under the tenant's
+// home directory, /run, /srv or /opt — somewhere an app expects
+// credentials, never system files the daemon would overwrite as root.
+func ValidatePath(username, p string) error {
+	if !strings.HasPrefix(p, "/") || path.Clean(p) != p {
+		return fmt.Errorf("secrets: path must be absolute and clean; got %q", p)
+	}
+	for _, dir := range []string{"/home/" + username, "/run", "/srv", "/opt"} {
+		if strings.HasPrefix(p, dir+"/") {
+			return nil
+		}
+	}
+	return fmt.Errorf("secrets: path must be under /home/%s, /run, /srv or /opt; got %q", username, p)
+}
+
 // ValidateValueForDelivery rejects a value that the chosen delivery mode
 // can't represent. compose renders into a dotenv file (KEY=value lines),
 // so a value with a newline would corrupt the file / be mangled by the
@@ -201,6 +223,10 @@ func (s *Store) initSchema(ctx context.Context) error {
 		-- code to honor this value.
 		ALTER TABLE secrets ADD COLUMN IF NOT EXISTS delivery TEXT NOT NULL DEFAULT 'env';
 
+		-- Tenant-chosen file location for delivery = 'file'; ''
+		-- keeps the default /run/secrets/<NAME>.
+		ALTER TABLE secrets ADD COLUMN IF NOT EXISTS path TEXT NOT NULL DEFAULT '';
+
 		CREATE INDEX IF NOT EXISTS idx_secrets_username
 			ON secrets(username);
 	`
@@ -223,6 +249,20 @@ func (s *Store) initSchema(ctx context.Context) error {
 // "env", "file". Validated at the API boundary; invalid values
 // reject before any DB work.
 func (s *Store) Set(ctx context.Context, username, name, value, delivery string) (*SecretMetadata, error) {
+	return s.set(ctx, username, name, value, delivery, "")
+}
+
+// SetAtPath is Set for a "file" secret written to p inside the
+// container instead of /run/secrets/<NAME>. p is checked with
+// ValidatePath.
+func (s *Store) SetAtPath(ctx context.Context, username, name, value, p string) (*SecretMetadata, error) {
+	if err := ValidatePath(username, p); err != nil {
+		return nil, err
+	}
+	return s.set(ctx, username, name, value, DeliveryFile, p)
+}
+
+func (s *Store) set(ctx context.Context, username, name, value, delivery, p string) (*SecretMetadata, error) {
 	if username == "" {
 		return nil, fmt.Errorf("username is required")
 	}
@@ -257,8 +297,8 @@ func (s *Store) Set(ctx context.Context, username, name, value, delivery string)
 	// rotation; the row's created_at stays as the original
 	// (set-once-ever timestamp), updated_at moves to NOW().
 	const q = `
-		INSERT INTO secrets (username, name, nonce, ciphertext, wrapped_dek, kek_id, delivery, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, 1)
+		INSERT INTO secrets (username, name, nonce, ciphertext, wrapped_dek, kek_id, delivery, path, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1)
 		ON CONFLICT (username, name)
 		DO UPDATE SET
 			nonce       = EXCLUDED.nonce,
@@ -266,13 +306,14 @@ func (s *Store) Set(ctx context.Context, username, name, value, delivery string)
 			wrapped_dek = EXCLUDED.wrapped_dek,
 			kek_id      = EXCLUDED.kek_id,
 			delivery    = EXCLUDED.delivery,
+			path        = EXCLUDED.path,
 			version     = secrets.version + 1,
 			updated_at  = NOW()
 		RETURNING version, created_at, updated_at;
 	`
 	var version int32
 	var createdAt, updatedAt time.Time
-	if err := s.pool.QueryRow(ctx, q, username, name, nonce, ct, wrappedDEK, kekID, delivery).Scan(&version, &createdAt, &updatedAt); err != nil {
+	if err := s.pool.QueryRow(ctx, q, username, name, nonce, ct, wrappedDEK, kekID, delivery, p).Scan(&version, &createdAt, &updatedAt); err != nil {
 		return nil, fmt.Errorf("upsert secret: %w", err)
 	}
 	return &SecretMetadata{
@@ -282,6 +323,7 @@ func (s *Store) Set(ctx context.Context, username, name, value, delivery string)
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
 		Delivery:  delivery,
+		Path:      p,
 	}, nil
 }
 
@@ -381,16 +423,16 @@ func (s *Store) Get(ctx context.Context, username, name string) (meta *SecretMet
 	}
 
 	const q = `
-		SELECT nonce, ciphertext, wrapped_dek, kek_id, delivery, version, created_at, updated_at
+		SELECT nonce, ciphertext, wrapped_dek, kek_id, delivery, path, version, created_at, updated_at
 		FROM secrets
 		WHERE username = $1 AND name = $2
 	`
 	var nonce, ct, wrappedDEK []byte
 	var kekID *string // nullable
-	var delivery string
+	var delivery, p string
 	var version int32
 	var createdAt, updatedAt time.Time
-	if err := s.pool.QueryRow(ctx, q, username, name).Scan(&nonce, &ct, &wrappedDEK, &kekID, &delivery, &version, &createdAt, &updatedAt); err != nil {
+	if err := s.pool.QueryRow(ctx, q, username, name).Scan(&nonce, &ct, &wrappedDEK, &kekID, &delivery, &p, &version, &createdAt, &updatedAt); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, "", ErrNotFound
 		}
@@ -412,6 +454,7 @@ func (s *Store) Get(ctx context.Context, username, name string) (meta *SecretMet
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
 		Delivery:  delivery,
+		Path:      p,
 	}, string(plaintext), nil
 }
 
@@ -423,7 +466,7 @@ func (s *Store) List(ctx context.Context, username string) ([]SecretMetadata, er
 		return nil, fmt.Errorf("username is required")
 	}
 	const q = `
-		SELECT username, name, version, created_at, updated_at, delivery
+		SELECT username, name, version, created_at, updated_at, delivery, path
 		FROM secrets
 		WHERE username = $1
 		ORDER BY name
@@ -437,7 +480,7 @@ func (s *Store) List(ctx context.Context, username string) ([]SecretMetadata, er
 	var out []SecretMetadata
 	for rows.Next() {
 		var m SecretMetadata
-		if err := rows.Scan(&m.Username, &m.Name, &m.Version, &m.CreatedAt, &m.UpdatedAt, &m.Delivery); err != nil {
+		if err := rows.Scan(&m.Username, &m.Name, &m.Version, &m.CreatedAt, &m.UpdatedAt, &m.Delivery, &m.Path); err != nil {
 			return nil, fmt.Errorf("scan secret row: %w", err)
 		}
 		out = append(out, m)
@@ -509,6 +552,7 @@ func (s *Store) UsernamesWithFileDelivery(ctx context.Context) ([]string, error)
 type SecretValue struct {
 	Value    string
 	Delivery string
+	Path     string // SecretMetadata.Path
 }
 
 // LoadAllForUser returns the decrypted plaintext values for every
@@ -553,7 +597,7 @@ func (s *Store) LoadAllForUserWithDelivery(ctx context.Context, username string)
 		return nil, fmt.Errorf("username is required")
 	}
 	const q = `
-		SELECT name, nonce, ciphertext, wrapped_dek, kek_id, delivery
+		SELECT name, nonce, ciphertext, wrapped_dek, kek_id, delivery, path
 		FROM secrets
 		WHERE username = $1
 	`
@@ -565,10 +609,10 @@ func (s *Store) LoadAllForUserWithDelivery(ctx context.Context, username string)
 
 	out := make(map[string]SecretValue)
 	for rows.Next() {
-		var name, delivery string
+		var name, delivery, p string
 		var nonce, ct, wrappedDEK []byte
 		var kekID *string
-		if err := rows.Scan(&name, &nonce, &ct, &wrappedDEK, &kekID, &delivery); err != nil {
+		if err := rows.Scan(&name, &nonce, &ct, &wrappedDEK, &kekID, &delivery, &p); err != nil {
 			return nil, fmt.Errorf("scan secret row: %w", err)
 		}
 		kID := ""
@@ -582,7 +626,7 @@ func (s *Store) LoadAllForUserWithDelivery(ctx context.Context, username string)
 		if delivery == "" {
 			delivery = DeliveryEnv
 		}
-		out[name] = SecretValue{Value: string(pt), Delivery: delivery}
+		out[name] = SecretValue{Value: string(pt), Delivery: delivery, Path: p}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate secret rows: %w", err)
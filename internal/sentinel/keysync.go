@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/footprintai/containarium/internal/gateway"
+	"github.com/footprintai/containarium/internal/metrics/opmetrics"
 )
 
 const (
@@ -306,7 +307,9 @@ func (ks *KeyStore) RunSyncLoopLegacy(ctx context.Context, backendIP string, htt
 }
 
 func (ks *KeyStore) syncAndApply(backendID, backendIP string, httpPort int) {
-	if err := ks.Sync(backendID, backendIP, httpPort); err != nil {
+	err := ks.Sync(backendID, backendIP, httpPort)
+	opmetrics.RecordSSHKeySync(err)
+	if err != nil {
 		log.Printf("[keysync] sync failed for %s: %v", backendID, err)
 		if strings.Contains(err.Error(), "unexpected status 401") {
 			log.Printf("[keysync] backend %s rejected the signed /authorized-keys request with 401 — "+
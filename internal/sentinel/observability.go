@@ -8,6 +8,8 @@ import (
 	"log"
 	"net/http"
 	"time"
+
+	"github.com/footprintai/containarium/internal/metrics/opmetrics"
 )
 
 // Spot-preemption observability (#514 follow-up).
@@ -110,6 +112,10 @@ func (m *Manager) MetricsHandler() http.HandlerFunc {
 		}
 		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
 		fmt.Fprint(w, m.renderMetrics(state, outageSecs))
+		// Shared operational series (keysync outcomes, process stats).
+		if err := opmetrics.WriteText(w); err != nil {
+			log.Printf("[sentinel] metrics: %v", err)
+		}
 	}
 }
 
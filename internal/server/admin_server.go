@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/footprintai/containarium/internal/auth"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConfigReloader re-reads the daemon's config file and applies it (see
+// DualServer.Reload). The daemon command owns the file and its flags, so
+// it supplies the reloader.
+type ConfigReloader func(ctx context.Context) (ConfigReload, error)
+
+// AdminServer implements the gRPC AdminService — operations on the daemon
+// process itself.
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+
+	mu        sync.RWMutex
+	reloader  ConfigReloader
+	templates templateRegistry
+}
+
+// templateRegistry holds the API-managed container templates; the
+// ContainerServer in production, which reads them on create.
+type templateRegistry interface {
+	saveContainerTemplate(ctx context.Context, t *pb.ContainerTemplate) (*pb.ContainerTemplate, error)
+	deleteContainerTemplate(ctx context.Context, name string) error
+}
+
+// NewAdminServer creates an AdminServer. ReloadConfig fails until a
+// reloader is set.
+func NewAdminServer() *AdminServer {
+	return &AdminServer{}
+}
+
+// SetConfigReloader installs the function ReloadConfig runs. nil means
+// the daemon has no config file to reload.
+func (s *AdminServer) SetConfigReloader(fn ConfigReloader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloader = fn
+}
+
+// SetTemplateRegistry wires where SetContainerTemplate and
+// DeleteContainerTemplate apply. Without one they fail.
+func (s *AdminServer) SetTemplateRegistry(r templateRegistry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templates = r
+}
+
+// requireDaemonAdmin gates every AdminService RPC: the daemon:admin scope
+// AND the admin role.
+func requireDaemonAdmin(ctx context.Context) error {
+	if err := auth.RequireScope(ctx, auth.ScopeDaemonAdmin); err != nil {
+		return err
+	}
+	_, roles, ok := auth.SubjectFromGRPCContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "no authenticated subject in request context")
+	}
+	if !auth.HasRole(roles, auth.RoleAdmin) {
+		return status.Error(codes.PermissionDenied, "admin role required for daemon administration")
+	}
+	return nil
+}
+
+// ReloadConfig re-reads the config file and applies what can change
+// without a restart, the same as SIGHUP.
+func (s *AdminServer) ReloadConfig(ctx context.Context, _ *pb.ReloadConfigRequest) (*pb.ReloadConfigResponse, error) {
+	if err := requireDaemonAdmin(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	reload := s.reloader
+	s.mu.RUnlock()
+	if reload == nil {
+		return nil, status.Error(codes.FailedPrecondition, "daemon was started without --config; there is no config file to reload")
+	}
+
+	result, err := reload(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to reload config: %v", err)
+	}
+	return &pb.ReloadConfigResponse{
+		ConfigFile: result.ConfigFile,
+		Applied:    result.Applied,
+		Rejected:   result.Rejected,
+	}, nil
+}
+
+// templateStore returns the wired registry, or FailedPrecondition.
+func (s *AdminServer) templateStore() (templateRegistry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.templates == nil {
+		return nil, status.Error(codes.FailedPrecondition, "container templates are not available on this daemon")
+	}
+	return s.templates, nil
+}
+
+// SetContainerTemplate creates or replaces an API-managed container
+// template. A template decides what every box created from it gets,
+// cloud-init included, so only a daemon admin manages them.
+func (s *AdminServer) SetContainerTemplate(ctx context.Context, req *pb.SetContainerTemplateRequest) (*pb.SetContainerTemplateResponse, error) {
+	if err := requireDaemonAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template is required")
+	}
+	templates, err := s.templateStore()
+	if err != nil {
+		return nil, err
+	}
+	t, err := templates.saveContainerTemplate(ctx, req.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetContainerTemplateResponse{Template: t}, nil
+}
+
+// DeleteContainerTemplate removes an API-managed container template.
+func (s *AdminServer) DeleteContainerTemplate(ctx context.Context, req *pb.DeleteContainerTemplateRequest) (*pb.DeleteContainerTemplateResponse, error) {
+	if err := requireDaemonAdmin(ctx); err != nil {
+		return nil, err
+	}
+	templates, err := s.templateStore()
+	if err != nil {
+		return nil, err
+	}
+	if err := templates.deleteContainerTemplate(ctx, req.Name); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteContainerTemplateResponse{}, nil
+}
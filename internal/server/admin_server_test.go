@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/footprintai/containarium/internal/auth"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// daemonAdminCtx builds a context carrying the daemon:admin scope + admin role.
+func daemonAdminCtx() context.Context {
+	return auth.ContextWithTestSubjectScopes(context.Background(),
+		"ops", []string{auth.RoleAdmin}, []string{auth.ScopeDaemonAdmin})
+}
+
+func TestReloadConfig_RequiresDaemonAdmin(t *testing.T) {
+	srv := NewAdminServer()
+	srv.SetConfigReloader(func(context.Context) (ConfigReload, error) {
+		t.Fatal("reloader ran for an unauthorized caller")
+		return ConfigReload{}, nil
+	})
+
+	for name, ctx := range map[string]context.Context{
+		"wrong scope": auth.ContextWithTestSubjectScopes(context.Background(),
+			"ops", []string{auth.RoleAdmin}, []string{auth.ScopeKMSAdmin}),
+		"not admin": auth.ContextWithTestSubjectScopes(context.Background(),
+			"alice", []string{"user"}, []string{auth.ScopeDaemonAdmin}),
+	} {
+		if _, err := srv.ReloadConfig(ctx, &pb.ReloadConfigRequest{}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s: got %v, want PermissionDenied", name, err)
+		}
+	}
+}
+
+func TestReloadConfig(t *testing.T) {
+	srv := NewAdminServer()
+	if _, err := srv.ReloadConfig(daemonAdminCtx(), &pb.ReloadConfigRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("without a reloader: got %v, want FailedPrecondition", err)
+	}
+
+	srv.SetConfigReloader(func(context.Context) (ConfigReload, error) {
+		return ConfigReload{
+			ConfigFile: "/etc/containarium/daemon.yaml",
+			Applied:    []string{"--traffic-retention-days: 7 → 30"},
+			Rejected:   []string{"--port can only be changed at startup; restart the daemon to apply it"},
+		}, nil
+	})
+	resp, err := srv.ReloadConfig(daemonAdminCtx(), &pb.ReloadConfigRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ConfigFile != "/etc/containarium/daemon.yaml" ||
+		!slices.Equal(resp.Applied, []string{"--traffic-retention-days: 7 → 30"}) || len(resp.Rejected) != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	srv.SetConfigReloader(func(context.Context) (ConfigReload, error) {
+		return ConfigReload{}, errors.New("unknown setting \"traffic-retention\" in config file")
+	})
+	if _, err := srv.ReloadConfig(daemonAdminCtx(), &pb.ReloadConfigRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("failed reload: got %v, want InvalidArgument", err)
+	}
+}
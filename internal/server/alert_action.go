@@ -0,0 +1,166 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/footprintai/containarium/internal/apierr"
+	"github.com/footprintai/containarium/pkg/core/network"
+)
+
+// Alert labels that turn a firing alert into a temporary bandwidth limit.
+// A rule opts in with action=limit_bandwidth; its expression must keep the
+// container_name label so the limit knows where to go:
+//
+//	labels:
+//	  action: limit_bandwidth
+//	  limit_egress: 10mbit
+//	  limit_for: 30m
+const (
+	alertActionLabel       = "action"
+	alertActionLimitBW     = "limit_bandwidth"
+	alertContainerLabel    = "container_name"
+	alertLimitIngressLabel = "limit_ingress"
+	alertLimitEgressLabel  = "limit_egress"
+	alertLimitForLabel     = "limit_for"
+)
+
+// defaultAlertLimitFor is how long an alert's limit lasts without
+// limit_for. Alertmanager re-sends a firing alert every repeat_interval,
+// which renews the limit, so it outlives the alert by at most this long.
+const defaultAlertLimitFor = 30 * time.Minute
+
+// bandwidthAction is one limit a firing alert asks for.
+type bandwidthAction struct {
+	alertName     string
+	containerName string
+	ingressMbps   int
+	egressMbps    int
+	duration      time.Duration
+}
+
+// parseBandwidthActions extracts the bandwidth limits requested by the
+// firing alerts of an Alertmanager webhook payload. Alerts without the
+// action label, and resolved ones, are skipped — resolving does not lift a
+// limit early, its limit_for does. A malformed action is reported and
+// skipped so it can't hold back the rest of the batch.
+func parseBandwidthActions(body []byte) ([]bandwidthAction, []error) {
+	var payload struct {
+		Alerts []struct {
+			Status string            `json:"status"`
+			Labels map[string]string `json:"labels"`
+		} `json:"alerts"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, []error{fmt.Errorf("invalid alertmanager payload: %w", err)}
+	}
+
+	var actions []bandwidthAction
+	var errs []error
+	for _, a := range payload.Alerts {
+		if a.Status != "firing" || a.Labels[alertActionLabel] != alertActionLimitBW {
+			continue
+		}
+		act, err := bandwidthActionFromLabels(a.Labels)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("alert %s: %w", a.Labels["alertname"], err))
+			continue
+		}
+		actions = append(actions, act)
+	}
+	return actions, errs
+}
+
+func bandwidthActionFromLabels(labels map[string]string) (bandwidthAction, error) {
+	act := bandwidthAction{
+		alertName:     labels["alertname"],
+		containerName: passthroughContainerName(labels[alertContainerLabel]),
+		duration:      defaultAlertLimitFor,
+	}
+	if act.containerName == "" {
+		return act, fmt.Errorf("no %s label", alertContainerLabel)
+	}
+	var err error
+	if v := labels[alertLimitIngressLabel]; v != "" {
+		if act.ingressMbps, err = network.ParseMbps(v); err != nil {
+			return act, fmt.Errorf("%s: %w", alertLimitIngressLabel, err)
+		}
+	}
+	if v := labels[alertLimitEgressLabel]; v != "" {
+		if act.egressMbps, err = network.ParseMbps(v); err != nil {
+			return act, fmt.Errorf("%s: %w", alertLimitEgressLabel, err)
+		}
+	}
+	if err := network.ValidateBandwidth(act.ingressMbps, act.egressMbps); err != nil {
+		return act, err
+	}
+	if v := labels[alertLimitForLabel]; v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return act, fmt.Errorf("%s: invalid duration %q", alertLimitForLabel, v)
+		}
+		act.duration = d
+	}
+	return act, nil
+}
+
+// alertActionHandler serves /internal/alert-action. Alertmanager routes
+// alerts labelled action=limit_bandwidth here (see
+// generateAlertmanagerConfig), authenticating with a bearer token minted
+// at daemon start — unlike the alert relay, this endpoint changes state,
+// so being on the container network is not enough.
+type alertActionHandler struct {
+	shaper *network.TrafficShaper
+	token  string
+}
+
+func (h *alertActionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierr.Write(w, r, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(h.token)) != 1 {
+		apierr.Write(w, r, http.StatusUnauthorized, "invalid alert action token")
+		return
+	}
+
+	if h.shaper == nil {
+		apierr.Write(w, r, http.StatusServiceUnavailable, "bandwidth limits are not enabled")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20)) // 1 MB limit
+	if err != nil {
+		apierr.Write(w, r, http.StatusBadRequest, "failed to read body")
+		return
+	}
+	actions, errs := parseBandwidthActions(body)
+	for _, err := range errs {
+		log.Printf("Warning: alert action skipped: %v", err)
+	}
+	for _, a := range actions {
+		// An operator's limit outranks an alert's: leave it alone.
+		if cur, err := h.shaper.GetLimit(r.Context(), a.containerName); err == nil && !strings.HasPrefix(cur.Reason, "alert:") {
+			continue
+		}
+		_, err := h.shaper.SetLimitWithOptions(r.Context(), a.containerName, a.ingressMbps, a.egressMbps, network.LimitOptions{
+			Reason:    "alert:" + a.alertName,
+			CreatedBy: "alertmanager",
+			Duration:  a.duration,
+		})
+		if err != nil {
+			log.Printf("Warning: alert %s: failed to limit %s: %v", a.alertName, a.containerName, err)
+			continue
+		}
+		log.Printf("Alert %s limited %s to %s down / %s up for %v", a.alertName, a.containerName,
+			network.FormatMbps(a.ingressMbps), network.FormatMbps(a.egressMbps), a.duration)
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -109,6 +109,15 @@ const DefaultAlertRules = `groups:
           summary: "No running containers"
           description: "There are no running user containers for more than 5 minutes."
 
+      - alert: ContainerNewListener
+        expr: increase(container_listeners_opened{container_name!~"containarium-core-.*"}[10m]) > 0
+        labels:
+          severity: info
+          source: default
+        annotations:
+          summary: "Container started listening on a new port"
+          description: "Container {{ $labels.container_name }} opened {{ $value | printf \"%.0f\" }} new listening port(s) in the last 10 minutes. Expected after a deploy; otherwise check what is serving with GetListeningPorts (containarium traffic listeners)."
+
   - name: pentest_alerts
     interval: 60s
     rules:
@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -433,33 +434,9 @@ func (s *ContainerServer) UpdateAlertingConfig(ctx context.Context, req *pb.Upda
 	// Update webhook URL if provided (or explicitly clearing it)
 	webhookURL := req.WebhookUrl
 	if webhookURL != "" || !req.GenerateWebhookSecret {
-		// Route through relay if a signing secret is configured
-		relayURL := webhookURL
-		if s.alertWebhookSecret != "" && webhookURL != "" && s.hostRelayURL != "" {
-			relayURL = s.hostRelayURL
-		}
-
-		// Update alertmanager config in the container
-		if err := s.coreServices.UpdateAlertmanagerWebhook(ctx, relayURL); err != nil {
+		if err := s.setAlertWebhookURL(ctx, webhookURL); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to update webhook: %v", err)
 		}
-
-		// Update in-memory webhook URL
-		s.alertWebhookURL = webhookURL
-
-		// Update gateway relay config if available
-		if s.alertRelayConfigFn != nil {
-			s.alertRelayConfigFn(s.alertWebhookURL, s.alertWebhookSecret)
-		}
-
-		// Persist to database if config store is available
-		if s.daemonConfigStore != nil {
-			if err := s.daemonConfigStore.Set(ctx, "alert_webhook_url", webhookURL); err != nil {
-				log.Printf("Warning: failed to persist webhook URL to database: %v", err)
-			} else {
-				log.Printf("Webhook URL persisted to database")
-			}
-		}
 	}
 
 	resp := &pb.UpdateAlertingConfigResponse{
@@ -474,6 +451,44 @@ func (s *ContainerServer) UpdateAlertingConfig(ctx context.Context, req *pb.Upda
 	return resp, nil
 }
 
+// setAlertWebhookURL points Alertmanager at webhookURL, through the
+// signing relay when a webhook secret is set, and persists it. An empty
+// URL clears the webhook. UpdateAlertingConfig and config reloads share it.
+func (s *ContainerServer) setAlertWebhookURL(ctx context.Context, webhookURL string) error {
+	if s.coreServices == nil {
+		return errors.New("core services not available for webhook update")
+	}
+
+	// Route through relay if a signing secret is configured
+	relayURL := webhookURL
+	if s.alertWebhookSecret != "" && webhookURL != "" && s.hostRelayURL != "" {
+		relayURL = s.hostRelayURL
+	}
+
+	// Update alertmanager config in the container
+	if err := s.coreServices.UpdateAlertmanagerWebhook(ctx, relayURL); err != nil {
+		return err
+	}
+
+	// Update in-memory webhook URL
+	s.alertWebhookURL = webhookURL
+
+	// Update gateway relay config if available
+	if s.alertRelayConfigFn != nil {
+		s.alertRelayConfigFn(s.alertWebhookURL, s.alertWebhookSecret)
+	}
+
+	// Persist to database if config store is available
+	if s.daemonConfigStore != nil {
+		if err := s.daemonConfigStore.Set(ctx, "alert_webhook_url", webhookURL); err != nil {
+			log.Printf("Warning: failed to persist webhook URL to database: %v", err)
+		} else {
+			log.Printf("Webhook URL persisted to database")
+		}
+	}
+	return nil
+}
+
 // TestWebhook sends a test notification to the configured webhook
 // with current system status. Admin-only — exposes the webhook URL
 // in error messages (and validates it works).
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Egress allowlist auto-block: when the traffic collector sees a tenant's
+// container reach a destination outside --traffic-egress-allowlist, add a
+// deny rule for that one address to the tenant's network policy, so the
+// next attempt is dropped. Opt-in (--traffic-egress-allowlist-block), and,
+// as with auto-quarantine, only as strong as the network-policy BPF
+// enforcer: without it armed the rule is stored but nothing is dropped.
+// The connection that tripped it has already happened; this stops repeats.
+
+// allowlistBlockNote marks deny rules added by the auto-block, so an
+// operator can tell them from their own and they're refreshed, not
+// duplicated.
+const allowlistBlockNote = "auto-block: egress allowlist violation"
+
+// defaultAllowlistBlockTTL is how long an auto-block rule lasts unless
+// the destination is tried again. A destination wrongly missing from the
+// allowlist shouldn't stay blocked forever once it's been added.
+const defaultAllowlistBlockTTL = 24 * time.Hour
+
+// AllowlistAutoBlock turns egress allowlist violations into deny rules.
+type AllowlistAutoBlock struct {
+	store denyRuleMutator
+	ttl   time.Duration
+	now   func() time.Time // injectable for tests
+
+	// blocked remembers when each tenant/destination rule was last
+	// written, so a burst of connections to one address writes it once.
+	mu      sync.Mutex
+	blocked map[string]time.Time
+}
+
+// NewAllowlistAutoBlock builds the hook over a deny-rule store.
+func NewAllowlistAutoBlock(store denyRuleMutator) *AllowlistAutoBlock {
+	return &AllowlistAutoBlock{store: store, ttl: defaultAllowlistBlockTTL, now: time.Now, blocked: map[string]time.Time{}}
+}
+
+// OnViolation is the traffic collector's policy violation hook. It runs on
+// the collector's event path, so the store is written in the background.
+func (b *AllowlistAutoBlock) OnViolation(conn *pb.Connection) {
+	go b.block(conn)
+}
+
+// block denies conn's destination for conn's tenant.
+func (b *AllowlistAutoBlock) block(conn *pb.Connection) {
+	tenant := strings.TrimSpace(conn.GetUsername())
+	dest, err := netip.ParseAddr(conn.GetDestIp())
+	if tenant == "" || err != nil {
+		return
+	}
+	dest = dest.Unmap()
+	cidr := netip.PrefixFrom(dest, dest.BitLen()).String()
+
+	now := b.now()
+	key := tenant + "|" + cidr
+	b.mu.Lock()
+	if last, ok := b.blocked[key]; ok && now.Sub(last) < b.ttl/2 {
+		b.mu.Unlock()
+		return
+	}
+	b.blocked[key] = now
+	b.mu.Unlock()
+
+	expiry := now.Add(b.ttl)
+	_, err = b.store.MutateDenyRules(context.Background(), tenant, func(existing []*pb.NetworkPolicyDenyRule) ([]*pb.NetworkPolicyDenyRule, error) {
+		return applyAllowlistBlock(existing, cidr, expiry), nil
+	})
+	if err != nil {
+		b.mu.Lock()
+		delete(b.blocked, key)
+		b.mu.Unlock()
+		log.Printf("[allowlist-block] tenant=%q %s: %v", tenant, cidr, err)
+		return
+	}
+	log.Printf("[allowlist-block] BLOCKED %s for tenant %q — %s connected outside the egress allowlist", cidr, tenant, conn.GetContainerName())
+}
+
+// applyAllowlistBlock ensures a deny rule for cidr is present, refreshing
+// the expiry of one the auto-block added. A rule an operator wrote for the
+// same address already blocks it and is left alone.
+func applyAllowlistBlock(rules []*pb.NetworkPolicyDenyRule, cidr string, expiry time.Time) []*pb.NetworkPolicyDenyRule {
+	exp := expiry.UTC().Format(time.RFC3339)
+	for _, r := range rules {
+		if strings.TrimSpace(r.GetCidr()) == cidr {
+			if r.GetNote() == allowlistBlockNote {
+				r.ExpiresAt = exp
+			}
+			return rules
+		}
+	}
+	return append(rules, &pb.NetworkPolicyDenyRule{
+		Cidr:      cidr,
+		Note:      allowlistBlockNote,
+		ExpiresAt: exp,
+	})
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestApplyAllowlistBlock(t *testing.T) {
+	exp := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	got := applyAllowlistBlock(nil, "203.0.113.7/32", exp)
+	if len(got) != 1 || got[0].GetCidr() != "203.0.113.7/32" || got[0].GetNote() != allowlistBlockNote || got[0].GetExpiresAt() == "" {
+		t.Fatalf("block add wrong: %+v", got)
+	}
+
+	exp2 := exp.Add(time.Hour)
+	got = applyAllowlistBlock(got, "203.0.113.7/32", exp2)
+	if len(got) != 1 || got[0].GetExpiresAt() != exp2.Format(time.RFC3339) {
+		t.Fatalf("re-block should refresh expiry without duplicating: %+v", got)
+	}
+
+	op := []*pb.NetworkPolicyDenyRule{{Cidr: "203.0.113.7/32", Note: "operator block"}}
+	if got := applyAllowlistBlock(op, "203.0.113.7/32", exp); len(got) != 1 || got[0].GetNote() != "operator block" || got[0].GetExpiresAt() != "" {
+		t.Fatalf("operator's rule for the address must be left alone: %+v", got)
+	}
+}
+
+func TestAllowlistAutoBlock_Block(t *testing.T) {
+	f := &fakeMutator{}
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	b := NewAllowlistAutoBlock(f)
+	b.ttl = time.Hour
+	b.now = func() time.Time { return now }
+
+	conn := &pb.Connection{ContainerName: "alice-container", Username: "alice", DestIp: "203.0.113.7"}
+	b.block(conn)
+	if got := denyCidrs(f.rules); len(got) != 1 || got[0] != "203.0.113.7/32" {
+		t.Fatalf("violation should deny the destination: %v", got)
+	}
+
+	// A burst of connections to the same address writes once.
+	b.block(conn)
+	if f.calls != 1 {
+		t.Errorf("repeat within the TTL wrote the store again (%d calls)", f.calls)
+	}
+
+	// Past half the TTL the rule is refreshed.
+	now = now.Add(40 * time.Minute)
+	b.block(conn)
+	if f.calls != 2 || len(f.rules) != 1 {
+		t.Errorf("refresh: calls = %d, rules = %v; want 2 calls, one rule", f.calls, denyCidrs(f.rules))
+	}
+
+	// No tenant or no address: nothing to block.
+	b.block(&pb.Connection{DestIp: "198.51.100.1"})
+	b.block(&pb.Connection{Username: "alice", DestIp: "not-an-ip"})
+	if f.calls != 2 {
+		t.Errorf("unattributable violations should not touch the store (calls %d)", f.calls)
+	}
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/netbpf"
+	"github.com/footprintai/containarium/internal/safecast"
+	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetTrafficShaper enables the bandwidth-limit RPCs.
+func (s *NetworkServer) SetTrafficShaper(shaper *network.TrafficShaper) {
+	s.shaper = shaper
+}
+
+// containerVeth resolves a container's host-side veth for the traffic
+// shaper: "" when it is stopped or its veth hasn't come up yet.
+func (s *NetworkServer) containerVeth(containerName string) (string, error) {
+	if s.incusClient == nil {
+		return "", nil
+	}
+	cfg, _, err := s.incusClient.GetRawInstance(containerName)
+	if err != nil {
+		return "", err
+	}
+	veth := netbpf.HostVethFromConfig(cfg)
+	if veth == "" {
+		return "", nil
+	}
+	if _, err := netbpf.VethIndex(veth); err != nil {
+		return "", nil // stale volatile key from the last run
+	}
+	return veth, nil
+}
+
+// SetBandwidthLimit caps a container's throughput. Admin-only — limits
+// are how operators rein in a tenant, so a tenant must not lift its own.
+func (s *NetworkServer) SetBandwidthLimit(ctx context.Context, req *pb.SetBandwidthLimitRequest) (*pb.SetBandwidthLimitResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if s.shaper == nil {
+		return nil, status.Error(codes.Unavailable, "bandwidth limits require PostgreSQL")
+	}
+	containerName := passthroughContainerName(req.ContainerName)
+	if containerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "container_name is required")
+	}
+	if err := network.ValidateBandwidth(int(req.IngressMbps), int(req.EgressMbps)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.DurationSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "duration_seconds must not be negative")
+	}
+	if s.incusClient != nil {
+		if _, _, err := s.incusClient.GetRawInstance(containerName); err != nil {
+			return nil, status.Errorf(codes.NotFound, "container %s not found", containerName)
+		}
+	}
+
+	subject, _, _ := auth.SubjectFromGRPCContext(ctx)
+	limit, err := s.shaper.SetLimitWithOptions(ctx, containerName, int(req.IngressMbps), int(req.EgressMbps), network.LimitOptions{
+		CreatedBy: subject,
+		Duration:  time.Duration(req.DurationSeconds) * time.Second,
+	})
+	if err != nil {
+		if limit == nil {
+			return nil, fmt.Errorf("failed to set bandwidth limit: %w", err)
+		}
+		// Persisted; the next sync retries tc.
+		log.Printf("Warning: bandwidth limit for %s: %v", containerName, err)
+	}
+
+	return &pb.SetBandwidthLimitResponse{Limit: s.toProtoBandwidthLimit(limit)}, nil
+}
+
+// ListBandwidthLimits lists the limits in force. Admin-only; a tenant
+// sees its own limit on GetContainer.
+func (s *NetworkServer) ListBandwidthLimits(ctx context.Context, _ *pb.ListBandwidthLimitsRequest) (*pb.ListBandwidthLimitsResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if s.shaper == nil {
+		return &pb.ListBandwidthLimitsResponse{}, nil
+	}
+	limits, err := s.shaper.ListLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bandwidth limits: %w", err)
+	}
+	resp := &pb.ListBandwidthLimitsResponse{}
+	for _, l := range limits {
+		resp.Limits = append(resp.Limits, s.toProtoBandwidthLimit(l))
+	}
+	return resp, nil
+}
+
+// ClearBandwidthLimit removes a container's limit. Admin-only.
+func (s *NetworkServer) ClearBandwidthLimit(ctx context.Context, req *pb.ClearBandwidthLimitRequest) (*pb.ClearBandwidthLimitResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if s.shaper == nil {
+		return nil, status.Error(codes.Unavailable, "bandwidth limits require PostgreSQL")
+	}
+	containerName := passthroughContainerName(req.ContainerName)
+	if containerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "container_name is required")
+	}
+	if err := s.shaper.ClearLimit(ctx, containerName); err != nil {
+		if errors.Is(err, network.ErrBandwidthLimitNotFound) {
+			return nil, status.Errorf(codes.NotFound, "no bandwidth limit on %s", containerName)
+		}
+		return nil, fmt.Errorf("failed to clear bandwidth limit: %w", err)
+	}
+	return &pb.ClearBandwidthLimitResponse{
+		Message: fmt.Sprintf("Bandwidth limit removed from %s", containerName),
+	}, nil
+}
+
+// toProtoBandwidthLimit converts a stored limit, reporting whether tc
+// enforces it right now.
+func (s *NetworkServer) toProtoBandwidthLimit(l *network.BandwidthLimit) *pb.BandwidthLimit {
+	return bandwidthLimitToProto(l, s.shaper != nil && s.shaper.Applied(l.ContainerName))
+}
+
+func bandwidthLimitToProto(l *network.BandwidthLimit, applied bool) *pb.BandwidthLimit {
+	out := &pb.BandwidthLimit{
+		ContainerName: l.ContainerName,
+		IngressMbps:   safecast.I32(l.IngressMbps),
+		EgressMbps:    safecast.I32(l.EgressMbps),
+		Reason:        l.Reason,
+		CreatedBy:     l.CreatedBy,
+		Applied:       applied,
+	}
+	if !l.ExpiresAt.IsZero() {
+		out.ExpiresAt = timestamppb.New(l.ExpiresAt)
+	}
+	if !l.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(l.UpdatedAt)
+	}
+	return out
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memBandwidthStore is an in-memory network.BandwidthStore.
+type memBandwidthStore map[string]*network.BandwidthLimit
+
+func (m memBandwidthStore) Save(_ context.Context, l *network.BandwidthLimit) error {
+	cp := *l
+	m[l.ContainerName] = &cp
+	return nil
+}
+
+func (m memBandwidthStore) Get(_ context.Context, name string) (*network.BandwidthLimit, error) {
+	l, ok := m[name]
+	if !ok {
+		return nil, network.ErrBandwidthLimitNotFound
+	}
+	cp := *l
+	return &cp, nil
+}
+
+func (m memBandwidthStore) List(_ context.Context) ([]*network.BandwidthLimit, error) {
+	var out []*network.BandwidthLimit
+	for _, l := range m {
+		cp := *l
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func (m memBandwidthStore) Delete(_ context.Context, name string) error {
+	if _, ok := m[name]; !ok {
+		return network.ErrBandwidthLimitNotFound
+	}
+	delete(m, name)
+	return nil
+}
+
+// newTestShaper returns a shaper whose containers are all stopped, so
+// limits are stored but tc never runs.
+func newTestShaper() (*network.TrafficShaper, memBandwidthStore) {
+	store := memBandwidthStore{}
+	return network.NewTrafficShaper(store, func(string) (string, error) { return "", nil }), store
+}
+
+func TestBandwidthLimits_AdminOnly(t *testing.T) {
+	shaper, _ := newTestShaper()
+	srv := &NetworkServer{shaper: shaper}
+	ctx := tenantCtx("alice")
+
+	if _, err := srv.SetBandwidthLimit(ctx, &pb.SetBandwidthLimitRequest{ContainerName: "alice", EgressMbps: 1000}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("tenant SetBandwidthLimit: got %v, want PermissionDenied", err)
+	}
+	if _, err := srv.ListBandwidthLimits(ctx, &pb.ListBandwidthLimitsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("tenant ListBandwidthLimits: got %v, want PermissionDenied", err)
+	}
+	if _, err := srv.ClearBandwidthLimit(ctx, &pb.ClearBandwidthLimitRequest{ContainerName: "alice"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("tenant ClearBandwidthLimit: got %v, want PermissionDenied", err)
+	}
+}
+
+func TestBandwidthLimits_SetListClear(t *testing.T) {
+	shaper, store := newTestShaper()
+	srv := &NetworkServer{shaper: shaper}
+	ctx := adminCtx()
+
+	resp, err := srv.SetBandwidthLimit(ctx, &pb.SetBandwidthLimitRequest{ContainerName: "alice", EgressMbps: 50, DurationSeconds: 3600})
+	if err != nil {
+		t.Fatalf("SetBandwidthLimit: %v", err)
+	}
+	l := resp.Limit
+	if l.ContainerName != "alice-container" || l.EgressMbps != 50 || l.IngressMbps != 0 || l.Applied {
+		t.Errorf("limit = %v", l)
+	}
+	if l.ExpiresAt == nil || l.CreatedBy != "ops" || l.Reason != "manual" {
+		t.Errorf("limit metadata = %v", l)
+	}
+
+	list, err := srv.ListBandwidthLimits(ctx, &pb.ListBandwidthLimitsRequest{})
+	if err != nil || len(list.Limits) != 1 {
+		t.Fatalf("ListBandwidthLimits = %v, %v", list, err)
+	}
+
+	if _, err := srv.ClearBandwidthLimit(ctx, &pb.ClearBandwidthLimitRequest{ContainerName: "alice-container"}); err != nil {
+		t.Fatalf("ClearBandwidthLimit: %v", err)
+	}
+	if len(store) != 0 {
+		t.Errorf("limit still stored: %v", store)
+	}
+	if _, err := srv.ClearBandwidthLimit(ctx, &pb.ClearBandwidthLimitRequest{ContainerName: "alice"}); status.Code(err) != codes.NotFound {
+		t.Errorf("second clear: got %v, want NotFound", err)
+	}
+}
+
+func TestSetBandwidthLimit_Validation(t *testing.T) {
+	shaper, _ := newTestShaper()
+	srv := &NetworkServer{shaper: shaper}
+	for _, req := range []*pb.SetBandwidthLimitRequest{
+		{ContainerName: "alice"},
+		{ContainerName: "alice", EgressMbps: -1},
+		{ContainerName: "", EgressMbps: 10},
+		{ContainerName: "alice", EgressMbps: 10, DurationSeconds: -5},
+	} {
+		if _, err := srv.SetBandwidthLimit(adminCtx(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetBandwidthLimit(%v): got %v, want InvalidArgument", req, err)
+		}
+	}
+
+	srv = &NetworkServer{}
+	if _, err := srv.SetBandwidthLimit(adminCtx(), &pb.SetBandwidthLimitRequest{ContainerName: "alice", EgressMbps: 10}); status.Code(err) != codes.Unavailable {
+		t.Errorf("without a shaper: got %v, want Unavailable", err)
+	}
+}
+
+func TestParseBandwidthActions(t *testing.T) {
+	body := `{"alerts": [
+		{"status": "firing", "labels": {"alertname": "EgressFlood", "container_name": "alice-container",
+			"action": "limit_bandwidth", "limit_egress": "10mbit", "limit_for": "1h"}},
+		{"status": "resolved", "labels": {"alertname": "EgressFlood", "container_name": "bob-container",
+			"action": "limit_bandwidth", "limit_egress": "10mbit"}},
+		{"status": "firing", "labels": {"alertname": "HighCPU", "container_name": "carol-container"}},
+		{"status": "firing", "labels": {"alertname": "EgressFlood", "container_name": "carol-container",
+			"action": "limit_bandwidth", "limit_egress": "fast"}},
+		{"status": "firing", "labels": {"alertname": "EgressFlood", "action": "limit_bandwidth", "limit_egress": "5"}}
+	]}`
+
+	actions, errs := parseBandwidthActions([]byte(body))
+	if len(actions) != 1 {
+		t.Fatalf("actions = %+v, want one", actions)
+	}
+	want := bandwidthAction{alertName: "EgressFlood", containerName: "alice-container", egressMbps: 10, duration: time.Hour}
+	if actions[0] != want {
+		t.Errorf("action = %+v, want %+v", actions[0], want)
+	}
+	if len(errs) != 2 {
+		t.Errorf("errs = %v, want the bad rate and the missing container reported", errs)
+	}
+}
+
+func TestAlertActionHandler(t *testing.T) {
+	shaper, store := newTestShaper()
+	h := &alertActionHandler{shaper: shaper, token: "s3cret"}
+	post := func(token, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/internal/alert-action", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	alert := func(container string) string {
+		return `{"alerts": [{"status": "firing", "labels": {"alertname": "EgressFlood",
+			"container_name": "` + container + `", "action": "limit_bandwidth", "limit_egress": "10mbit"}}]}`
+	}
+
+	if code := post("", alert("alice-container")); code != http.StatusUnauthorized {
+		t.Errorf("no token: got %d", code)
+	}
+	if code := post("wrong", alert("alice-container")); code != http.StatusUnauthorized {
+		t.Errorf("wrong token: got %d", code)
+	}
+	if len(store) != 0 {
+		t.Fatalf("unauthenticated request set a limit: %v", store)
+	}
+
+	if code := post("s3cret", alert("alice-container")); code != http.StatusOK {
+		t.Fatalf("got %d", code)
+	}
+	l := store["alice-container"]
+	if l == nil || l.EgressMbps != 10 || l.Reason != "alert:EgressFlood" || l.ExpiresAt.IsZero() {
+		t.Errorf("limit = %+v", l)
+	}
+
+	// An operator's permanent limit is not replaced by an alert's.
+	if _, err := shaper.SetLimit(context.Background(), "bob-container", 0, 100); err != nil {
+		t.Fatal(err)
+	}
+	post("s3cret", alert("bob-container"))
+	if l := store["bob-container"]; l.EgressMbps != 100 || l.Reason != "manual" {
+		t.Errorf("manual limit overwritten: %+v", l)
+	}
+}
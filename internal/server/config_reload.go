@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/footprintai/containarium/internal/traffic"
+)
+
+// ReloadableConfig is the part of DualServerConfig a running daemon can
+// change on a config reload (see DualServer.Reload). The fields mean what
+// their DualServerConfig namesakes do.
+type ReloadableConfig struct {
+	TrafficRetentionDays    int
+	TrafficSnapshotDebounce time.Duration
+	TrafficListenerInterval time.Duration
+	TrafficUDPIdleTimeout   time.Duration
+	TrafficSampling         traffic.SamplingConfig
+	TrafficHistoryLimits    traffic.HistoryLimits
+	ReservedPorts           []int
+	AlertWebhookURL         string
+}
+
+// ConfigReload reports what a config reload did. Each line names the
+// daemon flag it concerns.
+type ConfigReload struct {
+	// ConfigFile is the file that was read.
+	ConfigFile string
+	// Applied lists the settings changed, old value to new.
+	Applied []string
+	// Rejected lists changed settings left alone, with the reason.
+	Rejected []string
+}
+
+// reloadChanges collects a reload's per-setting outcome.
+type reloadChanges struct {
+	applied, rejected []string
+}
+
+// diff records name as changed when from and to differ, and reports
+// whether they do.
+func (r *reloadChanges) diff(name string, from, to any) bool {
+	oldStr, newStr := fmt.Sprint(from), fmt.Sprint(to)
+	if oldStr == newStr {
+		return false
+	}
+	r.applied = append(r.applied, fmt.Sprintf("--%s: %s → %s", name, oldStr, newStr))
+	return true
+}
+
+// reject moves the lines diff recorded since mark to rejected, with why.
+func (r *reloadChanges) reject(mark int, why string) {
+	for _, line := range r.applied[mark:] {
+		r.rejected = append(r.rejected, line+" not applied: "+why)
+	}
+	r.applied = r.applied[:mark]
+}
+
+// Reload applies cfg to the running daemon: the traffic collector's
+// intervals, retention and sampling, the history query limits, the
+// reserved passthrough ports and the alert webhook. Only settings that
+// differ from the running configuration are touched, and each is logged.
+// A setting that can't be applied is reported as rejected and keeps its
+// running value; the others still apply.
+func (ds *DualServer) Reload(ctx context.Context, cfg ReloadableConfig) ConfigReload {
+	ds.reloadMu.Lock()
+	defer ds.reloadMu.Unlock()
+
+	var r reloadChanges
+	ds.reloadTraffic(cfg, &r)
+
+	if ds.networkServer != nil {
+		from, to := slices.Sorted(slices.Values(ds.config.ReservedPorts)), slices.Sorted(slices.Values(cfg.ReservedPorts))
+		if r.diff("reserved-ports", from, to) {
+			ds.config.ReservedPorts = cfg.ReservedPorts
+			ds.networkServer.setReservedPorts(daemonReservedPorts(ds.config))
+		}
+	}
+
+	mark := len(r.applied)
+	if ds.containerServer != nil && r.diff("alert-webhook-url", maskURL(ds.config.AlertWebhookURL), maskURL(cfg.AlertWebhookURL)) {
+		if err := ds.containerServer.setAlertWebhookURL(ctx, cfg.AlertWebhookURL); err != nil {
+			r.reject(mark, err.Error())
+		} else {
+			ds.config.AlertWebhookURL = cfg.AlertWebhookURL
+		}
+	}
+
+	for _, line := range r.applied {
+		log.Printf("Config reload: %s", line)
+	}
+	for _, line := range r.rejected {
+		log.Printf("Warning: config reload: %s", line)
+	}
+	return ConfigReload{Applied: r.applied, Rejected: r.rejected}
+}
+
+// reloadTraffic applies cfg's traffic settings to the collector and the
+// history store.
+func (ds *DualServer) reloadTraffic(cfg ReloadableConfig, r *reloadChanges) {
+	c := ds.config
+	mark := len(r.applied)
+	retention, changed := c.TrafficRetentionDays, false
+	if r.diff("traffic-retention-days", c.TrafficRetentionDays, cfg.TrafficRetentionDays) {
+		// Compaction reads raw rows the retention cleanup would already
+		// have deleted; the compaction age is fixed at startup.
+		if compact := c.TrafficCompactAfterDays; compact > 0 && cfg.TrafficRetentionDays <= compact {
+			r.reject(mark, fmt.Sprintf("must stay above --traffic-compact-after-days (%d)", compact))
+		} else {
+			retention, changed = cfg.TrafficRetentionDays, true
+		}
+	}
+	changed = r.diff("traffic-snapshot-debounce", c.TrafficSnapshotDebounce, cfg.TrafficSnapshotDebounce) || changed
+	changed = r.diff("traffic-udp-idle-timeout", c.TrafficUDPIdleTimeout, cfg.TrafficUDPIdleTimeout) || changed
+	changed = ds.diffSampling(cfg.TrafficSampling, r) || changed
+
+	listener := c.TrafficListenerInterval
+	listenerMark := len(r.applied)
+	if r.diff("traffic-listener-interval", c.TrafficListenerInterval, cfg.TrafficListenerInterval) {
+		if (listener > 0) != (cfg.TrafficListenerInterval > 0) {
+			r.reject(listenerMark, "listener scanning can only be turned on or off at startup")
+		} else {
+			listener = cfg.TrafficListenerInterval
+			changed = true
+		}
+	}
+
+	if changed {
+		if collector := ds.trafficCollector; collector != nil {
+			settings := collector.Settings()
+			settings.RetentionDays = retention
+			settings.SnapshotDebounce = cfg.TrafficSnapshotDebounce
+			settings.UDPIdleTimeout = cfg.TrafficUDPIdleTimeout
+			settings.Sampling = cfg.TrafficSampling
+			settings.ListenerScanInterval = listener
+			if err := collector.Reconfigure(settings); err != nil {
+				r.reject(mark, err.Error())
+				changed = false
+			}
+		}
+	}
+	if changed {
+		c.TrafficRetentionDays = retention
+		c.TrafficSnapshotDebounce = cfg.TrafficSnapshotDebounce
+		c.TrafficUDPIdleTimeout = cfg.TrafficUDPIdleTimeout
+		c.TrafficSampling = cfg.TrafficSampling
+		c.TrafficListenerInterval = listener
+	}
+
+	from, to := c.TrafficHistoryLimits, cfg.TrafficHistoryLimits
+	limitsChanged := r.diff("traffic-history-max-window", from.MaxUnfilteredWindow, to.MaxUnfilteredWindow)
+	limitsChanged = r.diff("traffic-history-timeout", from.StatementTimeout, to.StatementTimeout) || limitsChanged
+	limitsChanged = r.diff("traffic-history-max-rows", from.MaxEstimatedRows, to.MaxEstimatedRows) || limitsChanged
+	if limitsChanged {
+		c.TrafficHistoryLimits = to
+		if ds.trafficCollector != nil {
+			if store, ok := ds.trafficCollector.GetStore().(*traffic.Store); ok {
+				store.SetHistoryLimits(to)
+			}
+		}
+	}
+}
+
+// diffSampling records the sampling settings that change.
+func (ds *DualServer) diffSampling(to traffic.SamplingConfig, r *reloadChanges) bool {
+	from := ds.config.TrafficSampling
+	changed := r.diff("traffic-sample-rate", from.Rate, to.Rate)
+	changed = r.diff("traffic-sample-min-bytes", from.MinBytes, to.MinBytes) || changed
+	changed = r.diff("traffic-sample-keep-ports", from.KeepPorts, to.KeepPorts) || changed
+	changed = r.diff("traffic-sample-keep-cidrs", from.KeepNets, to.KeepNets) || changed
+	// fmt prints maps sorted by key, so equal overrides print alike.
+	changed = r.diff("traffic-sample-container", from.ContainerRates, to.ContainerRates) || changed
+	return changed
+}
+
+// SetConfigReloader installs the function AdminService.ReloadConfig runs.
+func (ds *DualServer) SetConfigReloader(fn ConfigReloader) {
+	ds.adminServer.SetConfigReloader(fn)
+}
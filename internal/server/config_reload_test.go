@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/traffic"
+)
+
+// newReloadTestServer returns a DualServer running config with a traffic
+// collector and a network server, but no container server.
+func newReloadTestServer(t *testing.T, config *DualServerConfig) (*DualServer, *traffic.Store) {
+	t.Helper()
+	store := &traffic.Store{}
+	store.SetHistoryLimits(config.TrafficHistoryLimits)
+	collector, err := traffic.NewCollector(traffic.CollectorConfig{
+		PersistenceEnabled:   true,
+		RetentionDays:        config.TrafficRetentionDays,
+		SnapshotDebounce:     config.TrafficSnapshotDebounce,
+		ListenerScanInterval: config.TrafficListenerInterval,
+		UDPIdleTimeout:       config.TrafficUDPIdleTimeout,
+	}, nil, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, _ := newPassthroughTestServer()
+	srv.reservedPorts = daemonReservedPorts(config)
+	return &DualServer{config: config, trafficCollector: collector, networkServer: srv}, store
+}
+
+// reloadOf returns the running config as a ReloadableConfig.
+func reloadOf(config *DualServerConfig) ReloadableConfig {
+	return ReloadableConfig{
+		TrafficRetentionDays:    config.TrafficRetentionDays,
+		TrafficSnapshotDebounce: config.TrafficSnapshotDebounce,
+		TrafficListenerInterval: config.TrafficListenerInterval,
+		TrafficUDPIdleTimeout:   config.TrafficUDPIdleTimeout,
+		TrafficSampling:         config.TrafficSampling,
+		TrafficHistoryLimits:    config.TrafficHistoryLimits,
+		ReservedPorts:           config.ReservedPorts,
+		AlertWebhookURL:         config.AlertWebhookURL,
+	}
+}
+
+func TestDualServerReload_AppliesChanges(t *testing.T) {
+	ds, store := newReloadTestServer(t, &DualServerConfig{
+		GRPCPort:                50051,
+		TrafficRetentionDays:    7,
+		TrafficSnapshotDebounce: time.Second,
+		TrafficListenerInterval: time.Minute,
+		TrafficUDPIdleTimeout:   30 * time.Second,
+		TrafficHistoryLimits:    traffic.HistoryLimits{MaxEstimatedRows: 5000},
+		ReservedPorts:           []int{9100},
+	})
+
+	// Nothing changed: nothing to report.
+	if got := ds.Reload(context.Background(), reloadOf(ds.config)); len(got.Applied)+len(got.Rejected) != 0 {
+		t.Fatalf("unchanged reload reported %+v", got)
+	}
+
+	cfg := reloadOf(ds.config)
+	cfg.TrafficRetentionDays = 30
+	cfg.TrafficListenerInterval = 5 * time.Minute
+	cfg.TrafficSampling = traffic.SamplingConfig{Rate: 10, ContainerRates: map[string]int{"alice-container": 1}}
+	cfg.TrafficHistoryLimits.MaxEstimatedRows = 1000
+	cfg.ReservedPorts = []int{9200}
+	got := ds.Reload(context.Background(), cfg)
+
+	want := []string{
+		"--traffic-retention-days: 7 → 30",
+		"--traffic-sample-rate: 0 → 10",
+		"--traffic-sample-container: map[] → map[alice-container:1]",
+		"--traffic-listener-interval: 1m0s → 5m0s",
+		"--traffic-history-max-rows: 5000 → 1000",
+		"--reserved-ports: [9100] → [9200]",
+	}
+	if !slices.Equal(got.Applied, want) || len(got.Rejected) != 0 {
+		t.Fatalf("Applied = %q, Rejected = %q; want Applied %q", got.Applied, got.Rejected, want)
+	}
+
+	settings := ds.trafficCollector.Settings()
+	if settings.RetentionDays != 30 || settings.ListenerScanInterval != 5*time.Minute || settings.Sampling.Rate != 10 {
+		t.Errorf("collector settings = %+v, want the reloaded ones", settings)
+	}
+	if limits := store.HistoryLimits(); limits.MaxEstimatedRows != 1000 {
+		t.Errorf("store limits = %+v, want MaxEstimatedRows 1000", limits)
+	}
+	if err := ds.networkServer.checkReservedPort(9200); err == nil {
+		t.Error("port 9200 not reserved after reload")
+	}
+	if err := ds.networkServer.checkReservedPort(9100); err != nil {
+		t.Errorf("port 9100 still reserved after reload: %v", err)
+	}
+	if err := ds.networkServer.checkReservedPort(50051); err == nil {
+		t.Error("the daemon's own gRPC port lost its reservation")
+	}
+	if ds.config.TrafficRetentionDays != 30 {
+		t.Errorf("running config retention = %d, want 30", ds.config.TrafficRetentionDays)
+	}
+}
+
+func TestDualServerReload_RejectsWhatCannotApply(t *testing.T) {
+	ds, _ := newReloadTestServer(t, &DualServerConfig{
+		TrafficRetentionDays:    7,
+		TrafficListenerInterval: time.Minute,
+	})
+
+	cfg := reloadOf(ds.config)
+	cfg.TrafficListenerInterval = 0
+	cfg.TrafficUDPIdleTimeout = 10 * time.Second
+	got := ds.Reload(context.Background(), cfg)
+
+	if !slices.Equal(got.Applied, []string{"--traffic-udp-idle-timeout: 0s → 10s"}) {
+		t.Errorf("Applied = %q, want only the idle timeout", got.Applied)
+	}
+	if len(got.Rejected) != 1 || !strings.Contains(got.Rejected[0], "--traffic-listener-interval: 1m0s → 0s not applied") {
+		t.Errorf("Rejected = %q, want the listener interval", got.Rejected)
+	}
+	if settings := ds.trafficCollector.Settings(); settings.ListenerScanInterval != time.Minute || settings.UDPIdleTimeout != 10*time.Second {
+		t.Errorf("collector settings = %+v", settings)
+	}
+
+	// The collector refuses invalid sampling; the running config stays.
+	cfg = reloadOf(ds.config)
+	cfg.TrafficSampling.Rate = -1
+	got = ds.Reload(context.Background(), cfg)
+	if len(got.Applied) != 0 || len(got.Rejected) != 1 {
+		t.Errorf("Applied = %q, Rejected = %q; want the sample rate rejected", got.Applied, got.Rejected)
+	}
+	if ds.config.TrafficSampling.Rate != 0 {
+		t.Errorf("running sample rate = %d, want 0", ds.config.TrafficSampling.Rate)
+	}
+}
+
+// TestDualServerReload_RetentionStaysAboveCompaction — lowering retention
+// to the compaction age would delete raw rows before they are rolled up,
+// so that change alone is refused.
+func TestDualServerReload_RetentionStaysAboveCompaction(t *testing.T) {
+	ds, _ := newReloadTestServer(t, &DualServerConfig{
+		TrafficRetentionDays:    30,
+		TrafficCompactAfterDays: 7,
+	})
+
+	cfg := reloadOf(ds.config)
+	cfg.TrafficRetentionDays = 7
+	cfg.TrafficUDPIdleTimeout = 10 * time.Second
+	got := ds.Reload(context.Background(), cfg)
+
+	if !slices.Equal(got.Applied, []string{"--traffic-udp-idle-timeout: 0s → 10s"}) {
+		t.Errorf("Applied = %q, want only the idle timeout", got.Applied)
+	}
+	if len(got.Rejected) != 1 || !strings.Contains(got.Rejected[0], "--traffic-retention-days: 30 → 7 not applied") {
+		t.Errorf("Rejected = %q, want the retention", got.Rejected)
+	}
+	if ds.config.TrafficRetentionDays != 30 || ds.trafficCollector.Settings().RetentionDays != 30 {
+		t.Errorf("retention = %d (collector %d), want 30 kept", ds.config.TrafficRetentionDays, ds.trafficCollector.Settings().RetentionDays)
+	}
+
+	cfg.TrafficRetentionDays = 8
+	if got := ds.Reload(context.Background(), cfg); !slices.Equal(got.Applied, []string{"--traffic-retention-days: 30 → 8"}) {
+		t.Errorf("Applied = %q, want retention above the compaction age applied", got.Applied)
+	}
+}
@@ -0,0 +1,448 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/audit"
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/safecast"
+	"github.com/footprintai/containarium/internal/traffic"
+	"github.com/footprintai/containarium/pkg/core/box"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+const (
+	// defaultActivityWindow is the look-back when the caller passes 0.
+	defaultActivityWindow = 7 * 24 * time.Hour
+	// maxActivityWindow bounds the joins; traffic and audit retention
+	// rarely go further back anyway.
+	maxActivityWindow = 30 * 24 * time.Hour
+
+	// activityTopDestinations is how many remote addresses the traffic
+	// section lists.
+	activityTopDestinations = 5
+	// activityAuditLimit caps the lifecycle events and changes each.
+	activityAuditLimit = 200
+)
+
+// activityChangeActions are the audit actions of mutating API calls.
+var activityChangeActions = []string{"api_post", "api_put", "api_patch", "api_delete"}
+
+// SetTrafficStore wires the traffic store GetContainerActivity reads
+// totals and top destinations from. Nil (persistence disabled) leaves the
+// traffic section empty with a note.
+func (s *ContainerServer) SetTrafficStore(store traffic.ConnectionStore) {
+	s.trafficStore = store
+}
+
+// listenerSource is the part of the traffic collector the activity report
+// reads listening ports from.
+type listenerSource interface {
+	GetListeningPorts(containerName string) ([]*pb.ListeningPort, time.Time, error)
+	QueryListenerHistory(ctx context.Context, params traffic.ListenerQueryParams) ([]*pb.ListenerChange, error)
+}
+
+// SetListenerSource wires the traffic collector whose listener scan fills
+// the report's listening_ports section.
+func (s *ContainerServer) SetListenerSource(c *traffic.Collector) {
+	s.listeners = c
+}
+
+// sshSessionSource is the part of the traffic collector the activity
+// report reads SSH logins from.
+type sshSessionSource interface {
+	QuerySSHSessions(ctx context.Context, params traffic.SSHSessionParams) ([]*pb.SSHSession, error)
+}
+
+// SetSSHSessionSource wires the traffic collector whose SSH auth log fills
+// the report's ssh_sessions section.
+func (s *ContainerServer) SetSSHSessionSource(c *traffic.Collector) {
+	s.sshSessions = c
+}
+
+// GetContainerActivity joins what the daemon already records about one
+// container over a window — lifecycle events, metric trends, traffic,
+// snapshots and audited changes — so a caller gets the whole picture in
+// one round trip. Every section is best-effort: a missing or failing
+// source becomes a note in the response, never an error, since a partial
+// report is still worth having.
+func (s *ContainerServer) GetContainerActivity(ctx context.Context, req *pb.GetContainerActivityRequest) (*pb.GetContainerActivityResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	window, err := activityWindow(req.WindowSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.boxes().Get(ctx, box.BoxRef{Tenant: req.Username})
+	if err != nil || info == nil {
+		resp := &pb.GetContainerActivityResponse{}
+		path := fmt.Sprintf("/v1/containers/%s/activity?window_seconds=%d", req.Username, req.WindowSeconds)
+		if s.forwardContainerRequest(ctx, req.Username, "GET", path, nil, resp) {
+			return resp, nil
+		}
+		if err == nil {
+			return nil, status.Errorf(codes.NotFound, "container for %s not found", req.Username)
+		}
+		return nil, fmt.Errorf("failed to get container: %w", err)
+	}
+
+	end := time.Now()
+	start := end.Add(-window)
+	containerName := info.Ref.Name
+	if containerName == "" {
+		containerName = req.Username + "-container"
+	}
+	resp := &pb.GetContainerActivityResponse{
+		Username:      req.Username,
+		ContainerName: containerName,
+		WindowStart:   timestamppb.New(start),
+		WindowEnd:     timestamppb.New(end),
+		State:         info.State,
+	}
+	note := func(format string, args ...interface{}) {
+		resp.Notes = append(resp.Notes, fmt.Sprintf(format, args...))
+	}
+
+	// Lifecycle events and changes both come from the audit log: the event
+	// subscriber persists bus events keyed by container name, and the HTTP
+	// middleware records API calls keyed by "METHOD path".
+	if s.auditStore == nil {
+		note("lifecycle events and changes unavailable: audit log is not enabled")
+	} else {
+		events, total, err := s.auditStore.Query(ctx, audit.QueryParams{
+			ResourceType: "container",
+			ResourceID:   containerName,
+			From:         start,
+			To:           end,
+			Limit:        activityAuditLimit,
+		})
+		if err != nil {
+			log.Printf("Warning: activity report for %s: %v", req.Username, err)
+			note("lifecycle events unavailable: %v", err)
+		} else {
+			resp.LifecycleEvents = activityEvents(events)
+			if int(total) > len(events) {
+				note("showing the latest %d of %d lifecycle events", len(events), total)
+			}
+		}
+
+		changes, _, err := s.auditStore.Query(ctx, audit.QueryParams{
+			ResourceType:   "api",
+			ResourceIDLike: "% /v1/containers/" + escapeLike(req.Username) + "%",
+			Actions:        activityChangeActions,
+			From:           start,
+			To:             end,
+			Limit:          activityAuditLimit,
+		})
+		if err != nil {
+			log.Printf("Warning: activity report for %s: %v", req.Username, err)
+			note("changes unavailable: %v", err)
+		} else {
+			resp.Changes = activityChanges(changes, req.Username)
+		}
+	}
+
+	resp.Metrics = &pb.ContainerActivityMetrics{}
+	if info.State == pb.ContainerState_CONTAINER_STATE_RUNNING {
+		if m, err := s.manager.GetMetrics(req.Username); err == nil {
+			resp.Metrics.Current = toProtoMetrics(m)
+		}
+	}
+	if s.victoriaMetricsURL == "" {
+		note("metric trends unavailable: no metrics store (VictoriaMetrics) is configured")
+	} else if err := fillActivityTrends(ctx, s.victoriaMetricsURL, containerName, end, window, resp.Metrics); err != nil {
+		log.Printf("Warning: activity report for %s: %v", req.Username, err)
+		note("metric trends unavailable: %v", err)
+	}
+
+	switch {
+	case auth.RequireScope(ctx, auth.ScopeTrafficRead) != nil:
+		note("traffic omitted: token lacks the %s scope", auth.ScopeTrafficRead)
+	case s.trafficStore == nil:
+		note("traffic unavailable: traffic persistence is disabled")
+	default:
+		aggs, err := s.trafficStore.GetAggregates(ctx, traffic.AggregateParams{
+			ContainerNames: []string{containerName},
+			StartTime:      start,
+			EndTime:        end,
+			Interval:       "1h",
+			GroupByDestIP:  true,
+		})
+		if err != nil {
+			log.Printf("Warning: activity report for %s: %v", req.Username, err)
+			note("traffic unavailable: %v", err)
+		} else {
+			resp.Traffic = activityTraffic(aggs, activityTopDestinations)
+		}
+	}
+
+	switch {
+	case auth.RequireScope(ctx, auth.ScopeTrafficRead) != nil:
+		note("listening ports omitted: token lacks the %s scope", auth.ScopeTrafficRead)
+	case s.listeners == nil:
+		note("listening ports unavailable: traffic monitoring is not enabled")
+	default:
+		listeners, notes := activityListeners(ctx, s.listeners, containerName, start, end)
+		resp.ListeningPorts = listeners
+		resp.Notes = append(resp.Notes, notes...)
+	}
+
+	switch {
+	case auth.RequireScope(ctx, auth.ScopeTrafficRead) != nil:
+		note("SSH sessions omitted: token lacks the %s scope", auth.ScopeTrafficRead)
+	case s.sshSessions == nil:
+		note("SSH sessions unavailable: traffic monitoring is not enabled")
+	default:
+		sessions, err := s.sshSessions.QuerySSHSessions(ctx, traffic.SSHSessionParams{
+			ContainerName: containerName,
+			StartTime:     start,
+			EndTime:       end,
+			Limit:         activityAuditLimit,
+		})
+		if err != nil {
+			note("SSH sessions unavailable: %v", err)
+		} else {
+			resp.SshSessions = sessions
+		}
+	}
+
+	snaps, err := s.manager.ListSnapshots(req.Username)
+	if err != nil {
+		note("snapshots unavailable: %v", err)
+	} else {
+		for i := range snaps {
+			if snaps[i].CreatedAt.Before(start) || snaps[i].CreatedAt.After(end) {
+				continue
+			}
+			resp.Snapshots = append(resp.Snapshots, toProtoSnapshot(&snaps[i]))
+		}
+	}
+
+	return resp, nil
+}
+
+// activityWindow resolves the requested look-back.
+func activityWindow(seconds int64) (time.Duration, error) {
+	if seconds < 0 {
+		return 0, fmt.Errorf("window_seconds must not be negative")
+	}
+	if seconds == 0 {
+		return defaultActivityWindow, nil
+	}
+	if seconds > int64(maxActivityWindow/time.Second) {
+		return 0, fmt.Errorf("window_seconds must be at most %d (30 days)", int64(maxActivityWindow/time.Second))
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// escapeLike escapes LIKE wildcards so a username is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// activityEvents converts audit rows (newest first) to lifecycle events,
+// oldest first.
+func activityEvents(entries []audit.AuditEntry) []*pb.ContainerActivityEvent {
+	out := make([]*pb.ContainerActivityEvent, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		out = append(out, &pb.ContainerActivityEvent{
+			Timestamp: timestamppb.New(e.Timestamp),
+			Type:      e.Action,
+			Detail:    e.Detail,
+		})
+	}
+	return out
+}
+
+// activityChanges keeps the audited API calls whose path is the user's
+// container or below it — the LIKE prefix also matches longer usernames
+// ("bob" vs "bobby") — and returns them oldest first.
+func activityChanges(entries []audit.AuditEntry, username string) []*pb.ContainerActivityChange {
+	base := "/v1/containers/" + username
+	var out []*pb.ContainerActivityChange
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		_, path, ok := strings.Cut(e.ResourceID, " ")
+		if !ok || (path != base && !strings.HasPrefix(path, base+"/")) {
+			continue
+		}
+		out = append(out, &pb.ContainerActivityChange{
+			Timestamp:  timestamppb.New(e.Timestamp),
+			Actor:      e.Username,
+			Request:    e.ResourceID,
+			StatusCode: safecast.I32(e.StatusCode),
+		})
+	}
+	return out
+}
+
+// activityTraffic folds hourly per-destination aggregates into window
+// totals and the top destinations by bytes.
+func activityTraffic(aggs []*pb.TrafficAggregate, top int) *pb.ContainerActivityTraffic {
+	t := &pb.ContainerActivityTraffic{}
+	byDest := make(map[string]*pb.ContainerActivityDestination)
+	for _, a := range aggs {
+		t.BytesSent += a.BytesSent
+		t.BytesReceived += a.BytesReceived
+		t.ConnectionCount += int64(a.ConnectionCount)
+		t.IngressBytes += a.IngressBytes
+		t.EgressBytes += a.EgressBytes
+		d, ok := byDest[a.DestIp]
+		if !ok {
+			d = &pb.ContainerActivityDestination{DestIp: a.DestIp}
+			byDest[a.DestIp] = d
+		}
+		d.BytesSent += a.BytesSent
+		d.BytesReceived += a.BytesReceived
+		d.ConnectionCount += a.ConnectionCount
+	}
+	dests := make([]*pb.ContainerActivityDestination, 0, len(byDest))
+	for _, d := range byDest {
+		dests = append(dests, d)
+	}
+	sort.Slice(dests, func(i, j int) bool {
+		bi := dests[i].BytesSent + dests[i].BytesReceived
+		bj := dests[j].BytesSent + dests[j].BytesReceived
+		if bi != bj {
+			return bi > bj
+		}
+		return dests[i].DestIp < dests[j].DestIp
+	})
+	if len(dests) > top {
+		dests = dests[:top]
+	}
+	t.TopDestinations = dests
+	return t
+}
+
+// activityListeners reads the container's current listeners and the
+// changes recorded in [start, end], oldest first. Either half may be
+// missing; notes say why.
+func activityListeners(ctx context.Context, src listenerSource, containerName string, start, end time.Time) (*pb.ContainerActivityListeners, []string) {
+	current, _, err := src.GetListeningPorts(containerName)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("listening ports unavailable: %v", err)}
+	}
+	out := &pb.ContainerActivityListeners{Current: current}
+	changes, err := src.QueryListenerHistory(ctx, traffic.ListenerQueryParams{
+		ContainerName: containerName,
+		StartTime:     start,
+		EndTime:       end,
+		Limit:         activityAuditLimit,
+	})
+	if err != nil {
+		return out, []string{fmt.Sprintf("listener changes unavailable: %v", err)}
+	}
+	slices.Reverse(changes)
+	out.Changes = changes
+	return out, nil
+}
+
+// fillActivityTrends queries VictoriaMetrics for the container's usage
+// over the window ending at `at`. Series that don't exist (a container
+// younger than the window, monitoring just enabled) read as zero.
+func fillActivityTrends(ctx context.Context, vmURL, containerName string, at time.Time, window time.Duration, m *pb.ContainerActivityMetrics) error {
+	sel := fmt.Sprintf(`{container_name=%q}`, containerName)
+	w := fmt.Sprintf("%ds", int64(window/time.Second))
+
+	cpu, err := queryVMScalar(ctx, vmURL, fmt.Sprintf("sum(increase(container_cpu_usage_seconds%s[%s]))", sel, w), at)
+	if err != nil {
+		return err
+	}
+	avgMem, err := queryVMScalar(ctx, vmURL, fmt.Sprintf("sum(avg_over_time(container_memory_usage_bytes%s[%s]))", sel, w), at)
+	if err != nil {
+		return err
+	}
+	peakMem, err := queryVMScalar(ctx, vmURL, fmt.Sprintf("sum(max_over_time(container_memory_usage_bytes%s[%s]))", sel, w), at)
+	if err != nil {
+		return err
+	}
+	disk, err := queryVMScalar(ctx, vmURL, fmt.Sprintf("sum(container_disk_usage_bytes%s) - sum(container_disk_usage_bytes%s offset %s)", sel, sel, w), at)
+	if err != nil {
+		return err
+	}
+
+	m.AvgCpuCores = cpu / window.Seconds()
+	m.AvgMemoryBytes = int64(avgMem)
+	m.PeakMemoryBytes = int64(peakMem)
+	m.DiskGrowthBytes = int64(disk)
+	return nil
+}
+
+// queryVMScalar runs an instant PromQL query against VictoriaMetrics and
+// returns the first sample's value, or 0 when the result is empty.
+func queryVMScalar(ctx context.Context, vmURL, query string, at time.Time) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	q := url.Values{}
+	q.Set("query", query)
+	q.Set("time", strconv.FormatInt(at.Unix(), 10))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(vmURL, "/")+"/api/v1/query?"+q.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build metrics query: %w", err)
+	}
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query metrics store: %w", err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("metrics store returned HTTP %d", httpResp.StatusCode)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			Result []struct {
+				Value [2]interface{} `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("failed to decode metrics response: %w", err)
+	}
+	if body.Status != "success" {
+		return 0, fmt.Errorf("metrics query failed: %s", body.Error)
+	}
+	if len(body.Data.Result) == 0 {
+		return 0, nil
+	}
+	raw, ok := body.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected metrics sample %v", body.Data.Result[0].Value[1])
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected metrics sample %q: %w", raw, err)
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, nil
+	}
+	return v, nil
+}
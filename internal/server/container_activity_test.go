@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/audit"
+	"github.com/footprintai/containarium/internal/traffic"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestActivityWindow(t *testing.T) {
+	if d, err := activityWindow(0); err != nil || d != defaultActivityWindow {
+		t.Errorf("activityWindow(0) = %v, %v; want default", d, err)
+	}
+	if d, err := activityWindow(3600); err != nil || d != time.Hour {
+		t.Errorf("activityWindow(3600) = %v, %v; want 1h", d, err)
+	}
+	if _, err := activityWindow(-1); err == nil {
+		t.Error("negative window should be rejected")
+	}
+	if _, err := activityWindow(31 * 24 * 3600); err == nil {
+		t.Error("window over 30 days should be rejected")
+	}
+}
+
+// TestActivityChanges_ExactUserPaths — the SQL LIKE prefix for "bob"
+// also matches bobby's paths; only bob's container and its subpaths may
+// survive, oldest first.
+func TestActivityChanges_ExactUserPaths(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	// Store.Query returns newest first.
+	entries := []audit.AuditEntry{
+		{Timestamp: t0.Add(3 * time.Minute), Username: "admin", ResourceID: "DELETE /v1/containers/bob", StatusCode: 200},
+		{Timestamp: t0.Add(2 * time.Minute), Username: "bobby", ResourceID: "POST /v1/containers/bobby/start", StatusCode: 200},
+		{Timestamp: t0.Add(time.Minute), Username: "bob", ResourceID: "PUT /v1/containers/bob/resize", StatusCode: 400},
+		{Timestamp: t0, Username: "bob", ResourceID: "malformed", StatusCode: 200},
+	}
+	got := activityChanges(entries, "bob")
+	if len(got) != 2 {
+		t.Fatalf("got %d changes, want 2: %v", len(got), got)
+	}
+	if got[0].Request != "PUT /v1/containers/bob/resize" || got[0].StatusCode != 400 || got[0].Actor != "bob" {
+		t.Errorf("first change = %v", got[0])
+	}
+	if got[1].Request != "DELETE /v1/containers/bob" || got[1].Actor != "admin" {
+		t.Errorf("second change = %v", got[1])
+	}
+}
+
+func TestActivityTraffic_TotalsAndTopDestinations(t *testing.T) {
+	aggs := []*pb.TrafficAggregate{
+		{DestIp: "10.0.0.1", BytesSent: 100, BytesReceived: 50, ConnectionCount: 2, EgressBytes: 150},
+		{DestIp: "10.0.0.2", BytesSent: 1000, BytesReceived: 0, ConnectionCount: 1, IngressBytes: 1000},
+		{DestIp: "10.0.0.1", BytesSent: 900, BytesReceived: 100, ConnectionCount: 3},
+		{DestIp: "10.0.0.3", BytesSent: 1, BytesReceived: 1, ConnectionCount: 1},
+	}
+	got := activityTraffic(aggs, 2)
+	if got.BytesSent != 2001 || got.BytesReceived != 151 || got.ConnectionCount != 7 {
+		t.Errorf("totals = %d/%d/%d", got.BytesSent, got.BytesReceived, got.ConnectionCount)
+	}
+	if got.EgressBytes != 150 || got.IngressBytes != 1000 {
+		t.Errorf("direction split = egress %d / ingress %d", got.EgressBytes, got.IngressBytes)
+	}
+	if len(got.TopDestinations) != 2 {
+		t.Fatalf("got %d destinations, want 2", len(got.TopDestinations))
+	}
+	if d := got.TopDestinations[0]; d.DestIp != "10.0.0.1" || d.BytesSent != 1000 || d.ConnectionCount != 5 {
+		t.Errorf("top destination = %v", d)
+	}
+	if got.TopDestinations[1].DestIp != "10.0.0.2" {
+		t.Errorf("second destination = %v", got.TopDestinations[1])
+	}
+}
+
+type fakeListenerSource struct {
+	current    []*pb.ListeningPort
+	currentErr error
+	history    []*pb.ListenerChange
+	historyErr error
+	gotParams  traffic.ListenerQueryParams
+}
+
+func (f *fakeListenerSource) GetListeningPorts(string) ([]*pb.ListeningPort, time.Time, error) {
+	return f.current, time.Time{}, f.currentErr
+}
+
+func (f *fakeListenerSource) QueryListenerHistory(_ context.Context, params traffic.ListenerQueryParams) ([]*pb.ListenerChange, error) {
+	f.gotParams = params
+	return f.history, f.historyErr
+}
+
+func TestActivityListeners(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	src := &fakeListenerSource{
+		current: []*pb.ListeningPort{{Protocol: pb.Protocol_PROTOCOL_TCP, Port: 22}},
+		// QueryListenerHistory returns newest first.
+		history: []*pb.ListenerChange{
+			{Type: pb.ListenerChangeType_LISTENER_CHANGE_TYPE_CLOSED, Listener: &pb.ListeningPort{Port: 8080}},
+			{Type: pb.ListenerChangeType_LISTENER_CHANGE_TYPE_OPENED, Listener: &pb.ListeningPort{Port: 8080}},
+		},
+	}
+	got, notes := activityListeners(context.Background(), src, "alice-container", t0, t0.Add(time.Hour))
+	if len(notes) != 0 {
+		t.Errorf("notes = %v, want none", notes)
+	}
+	if len(got.Current) != 1 || len(got.Changes) != 2 {
+		t.Fatalf("got %d listeners / %d changes, want 1 / 2", len(got.Current), len(got.Changes))
+	}
+	if got.Changes[0].Type != pb.ListenerChangeType_LISTENER_CHANGE_TYPE_OPENED {
+		t.Errorf("first change = %v, want the opening", got.Changes[0])
+	}
+	if src.gotParams.ContainerName != "alice-container" || !src.gotParams.StartTime.Equal(t0) {
+		t.Errorf("history queried with %+v", src.gotParams)
+	}
+
+	src.historyErr = traffic.ErrListenersUnsupported
+	got, notes = activityListeners(context.Background(), src, "alice-container", t0, t0.Add(time.Hour))
+	if got == nil || len(got.Current) != 1 || len(notes) != 1 {
+		t.Errorf("without history: %v, notes %v; want current listeners and one note", got, notes)
+	}
+
+	src.currentErr = traffic.ErrListenersDisabled
+	if got, notes = activityListeners(context.Background(), src, "alice-container", t0, t0.Add(time.Hour)); got != nil || len(notes) != 1 {
+		t.Errorf("with scanning off: %v, notes %v; want no section and one note", got, notes)
+	}
+}
+
+func TestQueryVMScalar(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("query")
+		switch gotQuery {
+		case "empty":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		case "bad":
+			_, _ = w.Write([]byte(`{"status":"error","error":"parse error"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1771122760,"1.5"]}]}}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	v, err := queryVMScalar(ctx, srv.URL+"/", `sum(x{container_name="bob-container"})`, time.Now())
+	if err != nil || v != 1.5 {
+		t.Errorf("queryVMScalar = %v, %v; want 1.5", v, err)
+	}
+	if gotQuery != `sum(x{container_name="bob-container"})` {
+		t.Errorf("query sent = %q", gotQuery)
+	}
+	if v, err := queryVMScalar(ctx, srv.URL, "empty", time.Now()); err != nil || v != 0 {
+		t.Errorf("empty result = %v, %v; want 0", v, err)
+	}
+	if _, err := queryVMScalar(ctx, srv.URL, "bad", time.Now()); err == nil {
+		t.Error("error status should surface as an error")
+	}
+}
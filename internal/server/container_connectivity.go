@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/netpolicy"
+	"github.com/footprintai/containarium/internal/safecast"
+	"github.com/footprintai/containarium/pkg/core/container"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// egressChecker is the part of the network-policy enforcer a connectivity
+// test asks before it dials.
+type egressChecker interface {
+	CheckEgress(ctx context.Context, containerName string, pkt netpolicy.Egress) (*EgressCheck, error)
+}
+
+// SetEgressChecker wires the network-policy enforcer that connectivity
+// tests check their target against. Nil leaves the policy unchecked, with
+// a note.
+func (s *ContainerServer) SetEgressChecker(c egressChecker) {
+	s.egress = c
+}
+
+// connectivityStages maps the probe's failure stages onto the API enum.
+var connectivityStages = map[string]pb.ConnectivityStage{
+	container.StageDNS:     pb.ConnectivityStage_CONNECTIVITY_STAGE_DNS,
+	container.StageConnect: pb.ConnectivityStage_CONNECTIVITY_STAGE_CONNECT,
+	container.StageTLS:     pb.ConnectivityStage_CONNECTIVITY_STAGE_TLS,
+	container.StageHTTP:    pb.ConnectivityStage_CONNECTIVITY_STAGE_HTTP,
+	container.StageProbe:   pb.ConnectivityStage_CONNECTIVITY_STAGE_PROBE,
+}
+
+// TestConnectivity answers "can my box reach X": it resolves the target
+// inside the container, checks the address against the egress policy and,
+// unless the policy drops it, dials it with a bounded probe. Each step
+// that fails says so in failure_stage rather than as an RPC error.
+func (s *ContainerServer) TestConnectivity(ctx context.Context, req *pb.TestConnectivityRequest) (*pb.TestConnectivityResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	target, err := container.ParseProbeTarget(req.Method, req.Target)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.TimeoutSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "timeout_seconds must not be negative")
+	}
+	timeout := container.DefaultProbeTimeout
+	if req.TimeoutSeconds > 0 {
+		timeout = min(time.Duration(req.TimeoutSeconds)*time.Second, container.MaxProbeTimeout)
+	}
+
+	resp := &pb.TestConnectivityResponse{Username: req.Username, Target: req.Target, Method: target.Method}
+	dns, err := s.manager.ResolveHost(req.Username, target.Host, timeout)
+	switch {
+	case errors.Is(err, container.ErrNotRunning):
+		return nil, status.Errorf(codes.FailedPrecondition, "%s-container: %v", req.Username, err)
+	case err != nil:
+		fwd := &pb.TestConnectivityResponse{}
+		body, _ := protojson.Marshal(req)
+		if s.forwardContainerRequest(ctx, req.Username, "POST", fmt.Sprintf("/v1/containers/%s/connectivity-test", req.Username), body, fwd) {
+			return fwd, nil
+		}
+		return nil, fmt.Errorf("failed to test connectivity: %w", err)
+	}
+	resp.ResolvedIps = dns.ResolvedIPs
+	if dns.FailureStage != "" || target.Method == container.ProbeDNS {
+		fillConnectivityResult(resp, dns)
+		return resp, nil
+	}
+
+	addr := connectivityAddress(dns.ResolvedIPs)
+	resp.Policy = s.connectivityPolicy(ctx, req.Username, target, addr)
+	if p := resp.Policy; p.Evaluated && !p.Allowed && p.Enforced {
+		resp.RemoteIp = addr
+		resp.FailureStage = pb.ConnectivityStage_CONNECTIVITY_STAGE_POLICY
+		resp.Error = fmt.Sprintf("blocked by %s's network policy: %s", p.Tenant, p.Rule)
+		return resp, nil
+	}
+
+	probe, err := s.manager.ProbeConnectivity(req.Username, target, addr, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test connectivity: %w", err)
+	}
+	fillConnectivityResult(resp, probe)
+	return resp, nil
+}
+
+func fillConnectivityResult(resp *pb.TestConnectivityResponse, r *container.ProbeResult) {
+	resp.Reachable = r.Reachable
+	resp.FailureStage = connectivityStages[r.FailureStage]
+	resp.Error = r.Error
+	resp.RemoteIp = r.RemoteIP
+	resp.HttpStatus = safecast.I32(r.HTTPStatus)
+	resp.LatencyMs = float64(r.Latency) / float64(time.Millisecond)
+}
+
+// connectivityAddress picks the address to dial and check: the first IPv4
+// one, since the network policy covers IPv4 only, else the first.
+func connectivityAddress(ips []string) string {
+	for _, ip := range ips {
+		if a, err := netip.ParseAddr(ip); err == nil && a.Is4() {
+			return ip
+		}
+	}
+	return ips[0]
+}
+
+// connectivityPolicy asks the network-policy enforcer what it does with
+// traffic from the user's container to addr.
+func (s *ContainerServer) connectivityPolicy(ctx context.Context, username string, t *container.ProbeTarget, addr string) *pb.ConnectivityPolicyVerdict {
+	v := &pb.ConnectivityPolicyVerdict{Address: addr}
+	if s.egress == nil {
+		v.Note = "network policy enforcement is not running on this backend"
+		return v
+	}
+	ip, err := netip.ParseAddr(addr)
+	if err != nil || !ip.Is4() {
+		v.Note = "network policy covers IPv4 only"
+		return v
+	}
+	pkt := netpolicy.Egress{Dst: ip, Port: safecast.U16FromUint(safecast.U32(t.Port)), Proto: 6}
+	if t.Method == container.ProbePing {
+		pkt.Proto = 1
+	}
+	check, err := s.egress.CheckEgress(ctx, username+"-container", pkt)
+	if err != nil {
+		v.Note = err.Error()
+		return v
+	}
+	v.Evaluated = true
+	v.Tenant = check.Tenant
+	v.Allowed = check.Decision.Allowed
+	v.Enforced = check.Enforced
+	v.Rule = check.Decision.Rule
+	v.VirtualPatch = check.Decision.VirtualPatch
+	v.DefaultPolicy = !check.HasPolicy
+	return v
+}
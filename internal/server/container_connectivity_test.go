@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// newConnectivityTestServer serves alice's running box, where every name
+// resolves to 203.0.113.10 and curl gets a 200, behind an enforcer whose
+// store holds policy. It returns the argv of every exec.
+func newConnectivityTestServer(t *testing.T, policy *pb.NetworkPolicy) (*ContainerServer, *[][]string) {
+	t.Helper()
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Running", IPAddress: "10.100.0.5"}
+	var execs [][]string
+	mock.ExecWithOutputFunc = func(_ string, argv []string) (string, string, error) {
+		execs = append(execs, argv)
+		if argv[0] == "curl" {
+			return "200 0.002 0.008 0.020 203.0.113.10\n", "", nil
+		}
+		return "203.0.113.10 STREAM registry.corp.example\n", "", nil
+	}
+	store := NewMemNetworkPolicyStore()
+	if policy != nil {
+		// Set keeps the stored deny rules; they go in through MutateDenyRules.
+		if err := store.Set(context.Background(), policy); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.MutateDenyRules(context.Background(), policy.Tenant, func([]*pb.NetworkPolicyDenyRule) ([]*pb.NetworkPolicyDenyRule, error) {
+			return policy.DenyRules, nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &ContainerServer{manager: container.NewWithBackend(mock)}
+	s.SetEgressChecker(NewNetworkPolicyEnforcer("", store, NewMemTenantRegistry(), mock, nil, nil, true))
+	return s, &execs
+}
+
+func TestTestConnectivity_HTTP(t *testing.T) {
+	s, execs := newConnectivityTestServer(t, &pb.NetworkPolicy{Tenant: "alice", EgressCidrs: []string{"203.0.113.0/24"}, Mode: pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE})
+
+	resp, err := s.TestConnectivity(adminCtx(), &pb.TestConnectivityRequest{Username: "alice", Target: "https://registry.corp.example/v2/"})
+	if err != nil {
+		t.Fatalf("TestConnectivity: %v", err)
+	}
+	if !resp.Reachable || resp.HttpStatus != 200 || resp.Method != "http" || resp.RemoteIp != "203.0.113.10" {
+		t.Errorf("response = %v", resp)
+	}
+	if p := resp.Policy; !p.Evaluated || !p.Allowed || p.Rule != "egress CIDR 203.0.113.0/24" {
+		t.Errorf("policy = %v", p)
+	}
+	curl := strings.Join((*execs)[len(*execs)-1], " ")
+	if !strings.Contains(curl, "--resolve registry.corp.example:443:203.0.113.10") {
+		t.Errorf("curl is not pinned to the checked address: %s", curl)
+	}
+}
+
+func TestTestConnectivity_BlockedByPolicy(t *testing.T) {
+	s, execs := newConnectivityTestServer(t, &pb.NetworkPolicy{
+		Tenant:    "alice",
+		Mode:      pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE,
+		DenyRules: []*pb.NetworkPolicyDenyRule{{Cidr: "203.0.113.10", Note: "CVE-2026-0001"}},
+	})
+
+	resp, err := s.TestConnectivity(adminCtx(), &pb.TestConnectivityRequest{Username: "alice", Target: "registry.corp.example:5000"})
+	if err != nil {
+		t.Fatalf("TestConnectivity: %v", err)
+	}
+	if resp.Reachable || resp.FailureStage != pb.ConnectivityStage_CONNECTIVITY_STAGE_POLICY {
+		t.Errorf("response = %v, want a policy failure", resp)
+	}
+	if want := "blocked by alice's network policy: deny rule 203.0.113.10/32 (CVE-2026-0001)"; resp.Error != want {
+		t.Errorf("Error = %q, want %q", resp.Error, want)
+	}
+	if len(*execs) != 1 {
+		t.Errorf("a dropped target must not be dialed; execs = %q", *execs)
+	}
+}
+
+func TestTestConnectivity_LogOnlyStillDials(t *testing.T) {
+	s, execs := newConnectivityTestServer(t, nil)
+
+	resp, err := s.TestConnectivity(adminCtx(), &pb.TestConnectivityRequest{Username: "alice", Target: "http://registry.corp.example"})
+	if err != nil {
+		t.Fatalf("TestConnectivity: %v", err)
+	}
+	if p := resp.Policy; !p.Evaluated || p.Allowed || p.Enforced || !p.DefaultPolicy {
+		t.Errorf("policy = %v, want a logged default-policy deny", p)
+	}
+	if !resp.Reachable || len(*execs) != 2 {
+		t.Errorf("response = %v after %d execs, want a dialed, reachable target", resp, len(*execs))
+	}
+}
+
+func TestTestConnectivity_InvalidTarget(t *testing.T) {
+	s, _ := newConnectivityTestServer(t, nil)
+	for _, req := range []*pb.TestConnectivityRequest{
+		{Username: "alice"},
+		{Username: "alice", Target: "example.com", Method: "tcp"},
+		{Username: "alice", Target: "example.com:22", TimeoutSeconds: -1},
+	} {
+		if _, err := s.TestConnectivity(adminCtx(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: err = %v, want InvalidArgument", req, err)
+		}
+	}
+}
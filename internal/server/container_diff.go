@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// maxPackageDifferences caps the package section; two boxes on different
+// releases differ in nearly every package.
+const maxPackageDifferences = 200
+
+// redactedConfigPrefixes are config keys whose values may carry secrets.
+// A diff says that they differ, not what they hold.
+var redactedConfigPrefixes = []string{"environment.", "cloud-init.", "user.user-data", "user.vendor-data"}
+
+// DiffContainers compares two containers on this backend and returns only
+// what differs, so "why does it work on alice's box but not bob's" costs
+// one small response. A stopped container still has its Incus config
+// compared; what needs it running is left out with a note.
+func (s *ContainerServer) DiffContainers(ctx context.Context, req *pb.DiffContainersRequest) (*pb.DiffContainersResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	if req.UsernameA == "" || req.UsernameB == "" {
+		return nil, status.Error(codes.InvalidArgument, "username_a and username_b are required")
+	}
+	if req.UsernameA == req.UsernameB {
+		return nil, status.Error(codes.InvalidArgument, "username_a and username_b name the same container")
+	}
+	for _, username := range []string{req.UsernameA, req.UsernameB} {
+		if err := auth.AuthorizeTenant(ctx, username); err != nil {
+			return nil, err
+		}
+	}
+
+	a, nameA, errA := s.boxFacts(ctx, req.UsernameA, req.IncludePackages)
+	b, nameB, errB := s.boxFacts(ctx, req.UsernameB, req.IncludePackages)
+	if errA != nil && errB != nil {
+		// Neither is here; the peer hosting the first may have both.
+		resp := &pb.DiffContainersResponse{}
+		path := fmt.Sprintf("/v1/containers/%s/diff/%s?include_packages=%t", req.UsernameA, req.UsernameB, req.IncludePackages)
+		if s.forwardContainerRequest(ctx, req.UsernameA, "GET", path, nil, resp) {
+			return resp, nil
+		}
+	}
+	if errA != nil {
+		return nil, status.Errorf(codes.NotFound, "%s's container on this backend: %v", req.UsernameA, errA)
+	}
+	if errB != nil {
+		return nil, status.Errorf(codes.NotFound, "%s's container on this backend: %v", req.UsernameB, errB)
+	}
+
+	resp := &pb.DiffContainersResponse{
+		UsernameA: req.UsernameA,
+		UsernameB: req.UsernameB,
+		StateA:    a.State,
+		StateB:    b.State,
+	}
+	resp.Notes = append(resp.Notes, a.Notes...)
+	resp.Notes = append(resp.Notes, b.Notes...)
+
+	for _, d := range diffFacts("config", a.Config, b.Config, false) {
+		if redactedConfigKey(d.Key) {
+			d.ValueA, d.ValueB = redactedValue(d.ValueA), redactedValue(d.ValueB)
+		}
+		resp.Differences = append(resp.Differences, d)
+	}
+	resp.Differences = append(resp.Differences, diffFacts("device", a.Devices, b.Devices, false)...)
+	if a.System != nil && b.System != nil {
+		resp.Differences = append(resp.Differences, diffFacts("system", a.System, b.System, true)...)
+	}
+
+	portsA, noteA := s.listenerFacts(nameA, a)
+	portsB, noteB := s.listenerFacts(nameB, b)
+	switch {
+	case noteA != "":
+		resp.Notes = append(resp.Notes, "listening ports not compared: "+noteA)
+	case noteB != "":
+		resp.Notes = append(resp.Notes, "listening ports not compared: "+noteB)
+	default:
+		resp.Differences = append(resp.Differences, diffFacts("listening_port", portsA, portsB, false)...)
+	}
+
+	if req.IncludePackages && a.Packages != nil && b.Packages != nil {
+		if a.PackagesTruncated || b.PackagesTruncated {
+			resp.Notes = append(resp.Notes, fmt.Sprintf("packages not compared: more than %d installed", container.MaxFactPackages))
+		} else {
+			pkgs := diffFacts("package", a.Packages, b.Packages, false)
+			if len(pkgs) > maxPackageDifferences {
+				resp.Notes = append(resp.Notes, fmt.Sprintf("%d more package differences not listed", len(pkgs)-maxPackageDifferences))
+				pkgs = pkgs[:maxPackageDifferences]
+			}
+			resp.Differences = append(resp.Differences, pkgs...)
+		}
+	}
+	return resp, nil
+}
+
+// boxFacts resolves username's box through the box backend and collects
+// its diff facts, returning them with the box's name.
+func (s *ContainerServer) boxFacts(ctx context.Context, username string, withPackages bool) (*container.Facts, string, error) {
+	bb := s.boxes()
+	st, err := bb.Get(ctx, box.BoxRef{Tenant: username})
+	if err != nil {
+		return nil, "", err
+	}
+	if st == nil {
+		return nil, "", fmt.Errorf("no container for user %s", username)
+	}
+	ref := st.Ref
+
+	// Expanded config is Incus's; other runtimes compare what's inside.
+	var config map[string]string
+	var devices map[string]map[string]string
+	if bb.Kind() == box.KindLXC {
+		if config, devices, err = s.manager.ExpandedConfig(ref.Name); err != nil {
+			return nil, "", fmt.Errorf("failed to read container config: %w", err)
+		}
+	}
+	var exec func(cmd []string) (string, string, error)
+	if ec, ok := bb.(box.ExecCapable); ok {
+		exec = func(cmd []string) (string, string, error) { return ec.Exec(ctx, ref, cmd) }
+	}
+	return container.CollectFacts(ref.Name, boxStateName(st.State), config, devices, exec, withPackages), ref.Name, nil
+}
+
+// boxStateName spells a box state the way Incus does, e.g. "Running".
+func boxStateName(state pb.ContainerState) string {
+	name := strings.TrimPrefix(state.String(), "CONTAINER_STATE_")
+	return name[:1] + strings.ToLower(name[1:])
+}
+
+// listenerFacts maps a running container's listeners, from the traffic
+// collector's latest scan, to their owning process. A non-empty note says
+// why they can't be compared.
+func (s *ContainerServer) listenerFacts(containerName string, facts *container.Facts) (map[string]string, string) {
+	if facts.State != "Running" {
+		return nil, containerName + " is not running"
+	}
+	if s.listeners == nil {
+		return nil, "traffic monitoring is not enabled"
+	}
+	ports, scannedAt, err := s.listeners.GetListeningPorts(containerName)
+	if err != nil {
+		return nil, err.Error()
+	}
+	if scannedAt.IsZero() {
+		return nil, containerName + " has not been scanned yet"
+	}
+	out := make(map[string]string, len(ports))
+	for _, p := range ports {
+		key := fmt.Sprintf("%s %s:%d", strings.ToLower(strings.TrimPrefix(p.Protocol.String(), "PROTOCOL_")), p.Address, p.Port)
+		out[key] = p.ProcessName
+		if out[key] == "" {
+			out[key] = "listening"
+		}
+	}
+	return out, ""
+}
+
+// diffFacts returns the keys whose values differ, sorted. With onlyShared,
+// a key missing on one side is skipped rather than reported: it could not
+// be read there.
+func diffFacts(section string, a, b map[string]string, onlyShared bool) []*pb.ContainerDifference {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	var out []*pb.ContainerDifference
+	for _, k := range slices.Sorted(maps.Keys(keys)) {
+		va, okA := a[k]
+		vb, okB := b[k]
+		if va == vb && okA == okB || onlyShared && (!okA || !okB) {
+			continue
+		}
+		out = append(out, &pb.ContainerDifference{Section: section, Key: k, ValueA: va, ValueB: vb})
+	}
+	return out
+}
+
+func redactedConfigKey(key string) bool {
+	for _, prefix := range redactedConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactedValue(v string) string {
+	if v == "" {
+		return ""
+	}
+	return "(set)"
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/traffic"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// portsByContainer is a listenerSource with a scan per container.
+type portsByContainer map[string][]*pb.ListeningPort
+
+func (p portsByContainer) GetListeningPorts(name string) ([]*pb.ListeningPort, time.Time, error) {
+	return p[name], time.Now(), nil
+}
+
+func (p portsByContainer) QueryListenerHistory(context.Context, traffic.ListenerQueryParams) ([]*pb.ListenerChange, error) {
+	return nil, nil
+}
+
+// newDiffTestServer serves alice and bob running and carol stopped. Each
+// box's memory limit, kernel and packages carry its name.
+func newDiffTestServer() *ContainerServer {
+	mock := incustest.NewMockBackend()
+	for name, state := range map[string]string{"alice": "Running", "bob": "Running", "carol": "Stopped"} {
+		mock.Containers[name+"-container"] = &incus.ContainerInfo{Name: name + "-container", State: state}
+	}
+	mock.GetExpandedInstanceFunc = func(name string) (map[string]string, map[string]map[string]string, error) {
+		memory := "2GiB"
+		if name == "alice-container" {
+			memory = "4GiB"
+		}
+		config := map[string]string{
+			"limits.cpu":             "2",
+			"limits.memory":          memory,
+			"environment.API_TOKEN":  "token-of-" + name,
+			"volatile.last_state.ip": "10.100.0.9",
+			"security.nesting":       "true",
+		}
+		return config, map[string]map[string]string{"root": {"size": "20GiB"}}, nil
+	}
+	mock.ExecWithOutputFunc = func(name string, argv []string) (string, string, error) {
+		switch {
+		case argv[0] == "dpkg-query" && name == "alice-container":
+			return "bash\t5.2\nlibssl3\t3.0.13\n", "", nil
+		case argv[0] == "dpkg-query":
+			return "bash\t5.2\n", "", nil
+		case argv[0] == "uname":
+			return "6.8.0-" + name + "\n", "", nil
+		}
+		return "Ubuntu 24.04 LTS\n", "", nil
+	}
+	return &ContainerServer{
+		manager: container.NewWithBackend(mock),
+		listeners: portsByContainer{
+			"alice-container": {{Protocol: pb.Protocol_PROTOCOL_TCP, Address: "0.0.0.0", Port: 5432, ProcessName: "postgres"}},
+		},
+	}
+}
+
+func TestDiffContainers(t *testing.T) {
+	s := newDiffTestServer()
+
+	resp, err := s.DiffContainers(adminCtx(), &pb.DiffContainersRequest{UsernameA: "alice", UsernameB: "bob", IncludePackages: true})
+	if err != nil {
+		t.Fatalf("DiffContainers: %v", err)
+	}
+	var got []string
+	for _, d := range resp.Differences {
+		got = append(got, fmt.Sprintf("%s %s: %q → %q", d.Section, d.Key, d.ValueA, d.ValueB))
+	}
+	want := []string{
+		`config environment.API_TOKEN: "(set)" → "(set)"`,
+		`config limits.memory: "4GiB" → "2GiB"`,
+		`system kernel: "6.8.0-alice-container" → "6.8.0-bob-container"`,
+		`listening_port tcp 0.0.0.0:5432: "postgres" → ""`,
+		`package libssl3: "3.0.13" → ""`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("differences:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(resp.Notes) != 0 {
+		t.Errorf("Notes = %q, want none", resp.Notes)
+	}
+}
+
+func TestDiffContainers_StoppedIsPartial(t *testing.T) {
+	s := newDiffTestServer()
+
+	resp, err := s.DiffContainers(adminCtx(), &pb.DiffContainersRequest{UsernameA: "alice", UsernameB: "carol", IncludePackages: true})
+	if err != nil {
+		t.Fatalf("a stopped container should give a partial diff, got %v", err)
+	}
+	if resp.StateB != "Stopped" {
+		t.Errorf("StateB = %q", resp.StateB)
+	}
+	for _, d := range resp.Differences {
+		if d.Section != "config" {
+			t.Errorf("unexpected %s difference %q against a stopped box", d.Section, d.Key)
+		}
+	}
+	notes := strings.Join(resp.Notes, "\n")
+	for _, want := range []string{"carol-container is stopped", "listening ports not compared"} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("notes lack %q:\n%s", want, notes)
+		}
+	}
+}
+
+func TestDiffContainers_Errors(t *testing.T) {
+	s := newDiffTestServer()
+	for name, tc := range map[string]struct {
+		req  *pb.DiffContainersRequest
+		want codes.Code
+	}{
+		"missing username": {&pb.DiffContainersRequest{UsernameA: "alice"}, codes.InvalidArgument},
+		"same container":   {&pb.DiffContainersRequest{UsernameA: "alice", UsernameB: "alice"}, codes.InvalidArgument},
+		"unknown":          {&pb.DiffContainersRequest{UsernameA: "alice", UsernameB: "dave"}, codes.NotFound},
+	} {
+		if _, err := s.DiffContainers(adminCtx(), tc.req); status.Code(err) != tc.want {
+			t.Errorf("%s: got %v, want %v", name, err, tc.want)
+		}
+	}
+}
+
+// TestDiffContainers_K8sRuntime — boxes are resolved through the box
+// backend; a runtime without Incus config or in-box exec gives a diff of
+// notes rather than NotFound.
+func TestDiffContainers_K8sRuntime(t *testing.T) {
+	s := &ContainerServer{
+		manager: newDiffTestServer().manager,
+		boxBackend: k8sBoxStub{boxes: map[string]*box.BoxStatus{
+			"alice": {Ref: box.BoxRef{Tenant: "alice", Name: "alice-sandbox"}, State: pb.ContainerState_CONTAINER_STATE_RUNNING},
+			"bob":   {Ref: box.BoxRef{Tenant: "bob", Name: "bob-sandbox"}, State: pb.ContainerState_CONTAINER_STATE_STOPPED},
+		}},
+	}
+	resp, err := s.DiffContainers(adminCtx(), &pb.DiffContainersRequest{UsernameA: "alice", UsernameB: "bob"})
+	if err != nil {
+		t.Fatalf("DiffContainers: %v", err)
+	}
+	if resp.StateA != "Running" || resp.StateB != "Stopped" || len(resp.Differences) != 0 {
+		t.Errorf("resp = %+v", resp)
+	}
+	notes := strings.Join(resp.Notes, "\n")
+	for _, want := range []string{"alice-sandbox: runtime has no in-box exec", "bob-sandbox is stopped"} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("notes lack %q:\n%s", want, notes)
+		}
+	}
+}
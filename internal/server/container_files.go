@@ -0,0 +1,336 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/footprintai/containarium/internal/audit"
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Size bounds for the container file RPCs. Content travels in a single
+// message, so both directions are capped well under the gRPC limit.
+const (
+	defaultFileReadBytes = 64 << 10
+	maxFileReadBytes     = 1 << 20
+	maxFileWriteBytes    = 1 << 20
+)
+
+// defaultFileMode is the mode WriteContainerFile uses when none is given.
+const defaultFileMode = 0o644
+
+// ReadContainerFile reads a file in a user's container through the Incus
+// file API, so an agent can look at a config file without a shell.
+func (s *ContainerServer) ReadContainerFile(ctx context.Context, req *pb.ReadContainerFileRequest) (*pb.ReadContainerFileResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if req.MaxBytes < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_bytes must not be negative")
+	}
+	p, err := container.CleanPath(req.Path)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	maxBytes := req.MaxBytes
+	if maxBytes == 0 {
+		maxBytes = defaultFileReadBytes
+	}
+	maxBytes = min(maxBytes, maxFileReadBytes)
+
+	containerName, err := s.fileBox(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if containerName == "" {
+		var resp pb.ReadContainerFileResponse
+		q := url.Values{"path": {p}, "max_bytes": {strconv.FormatInt(maxBytes, 10)}}
+		if err := s.forwardContainerFile(ctx, req.Username, "GET", "/files?"+q.Encode(), nil, &resp); err != nil {
+			return nil, err
+		}
+		return &resp, nil
+	}
+	f, err := s.manager.ReadFileAt(containerName, p, maxBytes)
+	if err != nil {
+		return nil, containerFileError(err)
+	}
+	if f.Type == "directory" {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is a directory; list it instead", p)
+	}
+	return &pb.ReadContainerFileResponse{
+		Path:      p,
+		Type:      f.Type,
+		Mode:      fmt.Sprintf("%04o", f.Mode),
+		Uid:       f.UID,
+		Gid:       f.GID,
+		Content:   f.Content,
+		Truncated: f.Truncated,
+		Binary:    isBinaryContent(f.Content, f.Truncated),
+	}, nil
+}
+
+// WriteContainerFile writes a file in a user's container through the
+// Incus file API, replacing any existing file. Every write that reaches
+// Incus is audited with the content's size and digest.
+func (s *ContainerServer) WriteContainerFile(ctx context.Context, req *pb.WriteContainerFileRequest) (*pb.WriteContainerFileResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersWrite); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	p, err := container.CleanPath(req.Path)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(req.Content) > maxFileWriteBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "content is %d bytes; the limit is %d", len(req.Content), maxFileWriteBytes)
+	}
+	mode, err := parseFileMode(req.Mode)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	uid, gid, err := parseFileOwner(req.Owner)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.WriteContainerFileResponse{
+		Path: p,
+		Size: int64(len(req.Content)),
+		Mode: fmt.Sprintf("%04o", mode),
+		Uid:  uid,
+		Gid:  gid,
+	}
+	containerName, err := s.fileBox(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if containerName == "" {
+		body, _ := protojson.Marshal(req)
+		var fwd pb.WriteContainerFileResponse
+		if err := s.forwardContainerFile(ctx, req.Username, "PUT", "/files", body, &fwd); err != nil {
+			return nil, err
+		}
+		return &fwd, nil
+	}
+	if err := s.manager.WriteFileAt(containerName, p, req.Content, mode, uid, gid); err != nil {
+		return nil, containerFileError(err)
+	}
+	s.auditContainerFileWrite(ctx, req.Username, resp, req.Content)
+	return resp, nil
+}
+
+// ListContainerDir lists a directory in a user's container through the
+// Incus file API.
+func (s *ContainerServer) ListContainerDir(ctx context.Context, req *pb.ListContainerDirRequest) (*pb.ListContainerDirResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	p, err := container.CleanPath(req.Path)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	// A directory has no content, so the byte limit doesn't matter; a
+	// file pulled by mistake is cut off at once.
+	containerName, err := s.fileBox(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if containerName == "" {
+		var resp pb.ListContainerDirResponse
+		if err := s.forwardContainerFile(ctx, req.Username, "GET", "/dirs?"+url.Values{"path": {p}}.Encode(), nil, &resp); err != nil {
+			return nil, err
+		}
+		return &resp, nil
+	}
+	f, err := s.manager.ReadFileAt(containerName, p, 0)
+	if err != nil {
+		return nil, containerFileError(err)
+	}
+	if f.Type != "directory" {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is a %s, not a directory", p, f.Type)
+	}
+	entries := slices.Clone(f.Entries)
+	slices.Sort(entries)
+	return &pb.ListContainerDirResponse{
+		Path:    p,
+		Mode:    fmt.Sprintf("%04o", f.Mode),
+		Uid:     f.UID,
+		Gid:     f.GID,
+		Entries: entries,
+	}, nil
+}
+
+// fileBox returns the name of username's container for the file RPCs, or
+// "" when this host doesn't have it and the request belongs to a peer.
+// Incus reports a missing container as an error, so a failed lookup counts
+// as not here. The file RPCs go through the Incus file API, so the
+// Kubernetes runtime has none.
+func (s *ContainerServer) fileBox(ctx context.Context, username string) (string, error) {
+	if _, isK8s := s.k8sBoxes(); isK8s {
+		return "", status.Error(codes.FailedPrecondition, "container files are not supported on the Kubernetes runtime")
+	}
+	st, err := s.boxes().Get(ctx, box.BoxRef{Tenant: username})
+	if err != nil || st == nil {
+		return "", nil
+	}
+	return st.Ref.Name, nil
+}
+
+// containerFileError maps a failed local file operation to its status.
+func containerFileError(err error) error {
+	switch {
+	case errors.Is(err, container.ErrInvalidPath):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, incus.ErrFileNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, incus.ErrFilePermission):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return fmt.Errorf("container file operation failed: %w", err)
+}
+
+// forwardContainerFile asks the peer hosting username's container, which
+// isn't on this host, decoding its answer into out. It is NotFound when no
+// peer has the container. suffix is the request's path under
+// /v1/containers/{username}.
+func (s *ContainerServer) forwardContainerFile(ctx context.Context, username, method, suffix string, body []byte, out proto.Message) error {
+	var peer *PeerClient
+	authToken := extractAuthToken(ctx)
+	if s.peerPool != nil {
+		peer = s.peerPool.FindContainerPeer(username, authToken)
+	}
+	if peer == nil {
+		return status.Errorf(codes.NotFound, "container for user %s not found", username)
+	}
+	respBody, statusCode, err := peer.ForwardRequest(method, "/v1/containers/"+url.PathEscape(username)+suffix, authToken, body)
+	if err != nil {
+		return fmt.Errorf("failed to reach peer %s: %w", peer.ID, err)
+	}
+	if statusCode >= 400 {
+		return fmt.Errorf("peer %s returned status %d: %s", peer.ID, statusCode, string(respBody))
+	}
+	if err := protojson.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response from peer %s: %w", peer.ID, err)
+	}
+	return nil
+}
+
+// isBinaryContent reports whether content isn't text: it has a NUL byte
+// or isn't valid UTF-8. A truncated read may end inside a multi-byte
+// character, so a partial one at the end is forgiven.
+func isBinaryContent(content []byte, truncated bool) bool {
+	if bytes.IndexByte(content, 0) >= 0 {
+		return true
+	}
+	if truncated {
+		for i := len(content) - 1; i >= 0 && i > len(content)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(content[i]) {
+				if !utf8.FullRune(content[i:]) {
+					content = content[:i]
+				}
+				break
+			}
+		}
+	}
+	return !utf8.Valid(content)
+}
+
+// parseFileMode parses octal permission bits ("0644", "600"); empty is
+// defaultFileMode. The setuid, setgid and sticky bits are refused: a
+// tenant's agent has no business planting a setuid binary.
+func parseFileMode(s string) (int, error) {
+	if s == "" {
+		return defaultFileMode, nil
+	}
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "0o"), 8, 32)
+	if err != nil || n > 0o777 {
+		return 0, fmt.Errorf("invalid mode %q: want octal permission bits such as 0644", s)
+	}
+	return int(n), nil
+}
+
+// parseFileOwner parses a numeric owner, "uid" or "uid:gid"; a lone uid
+// is also the gid, and empty is root.
+func parseFileOwner(s string) (uid, gid int64, err error) {
+	if s == "" {
+		return 0, 0, nil
+	}
+	u, g, hasGID := strings.Cut(s, ":")
+	if !hasGID {
+		g = u
+	}
+	uid, err1 := strconv.ParseInt(u, 10, 32)
+	gid, err2 := strconv.ParseInt(g, 10, 32)
+	if err1 != nil || err2 != nil || uid < 0 || gid < 0 {
+		return 0, 0, fmt.Errorf("invalid owner %q: want a numeric uid or uid:gid", s)
+	}
+	return uid, gid, nil
+}
+
+// auditContainerFileWrite records a file written into a tenant's
+// container. The content itself stays out of audit_logs; its size and
+// SHA-256 identify it. Best-effort, like the other audit writers.
+func (s *ContainerServer) auditContainerFileWrite(ctx context.Context, username string, w *pb.WriteContainerFileResponse, content []byte) {
+	if s.auditStore == nil {
+		return
+	}
+	subject, _, _ := auth.SubjectFromGRPCContext(ctx)
+	if subject == "" {
+		subject = "_unknown"
+	}
+	sum := sha256.Sum256(content)
+	payload, _ := json.Marshal(map[string]any{
+		"path":   w.Path,
+		"size":   w.Size,
+		"mode":   w.Mode,
+		"owner":  fmt.Sprintf("%d:%d", w.Uid, w.Gid),
+		"sha256": hex.EncodeToString(sum[:]),
+	})
+	if err := s.auditStore.Log(ctx, &audit.AuditEntry{
+		Username:     subject,
+		Action:       "container.file.write",
+		ResourceType: "container_file",
+		ResourceID:   username + ":" + w.Path,
+		Detail:       string(payload),
+	}); err != nil {
+		log.Printf("[files] audit write %s:%s: %v", username, w.Path, err)
+	}
+}
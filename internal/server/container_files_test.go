@@ -0,0 +1,166 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func fileTestServer() (*ContainerServer, *incustest.MockBackend) {
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Stopped"}
+	files := map[string]*incus.InstanceFile{
+		"/etc/app.conf": {Type: "file", Mode: 0o644, Content: []byte("port = 8080\nname = \"café\"\n")},
+		"/usr/bin/tool": {Type: "file", Mode: 0o755, Content: []byte("\x7fELF\x02\x01\x01\x00")},
+		"/etc":          {Type: "directory", Mode: 0o755, Entries: []string{"passwd", "app.conf", "hosts"}},
+	}
+	mock.PullFileFunc = func(_, path string, maxBytes int64) (*incus.InstanceFile, error) {
+		if strings.HasPrefix(path, "/root/") {
+			return nil, fmt.Errorf("%s: %w", path, incus.ErrFilePermission)
+		}
+		f, ok := files[path]
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", path, incus.ErrFileNotFound)
+		}
+		out := *f
+		if int64(len(out.Content)) > maxBytes {
+			out.Content, out.Truncated = out.Content[:maxBytes], true
+		}
+		return &out, nil
+	}
+	return &ContainerServer{manager: container.NewWithBackend(mock)}, mock
+}
+
+func TestReadContainerFile(t *testing.T) {
+	s, _ := fileTestServer()
+	ctx := adminCtx()
+
+	resp, err := s.ReadContainerFile(ctx, &pb.ReadContainerFileRequest{Username: "alice", Path: "/etc//./app.conf"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Path != "/etc/app.conf" || resp.Mode != "0644" || resp.Binary || resp.Truncated || !strings.Contains(string(resp.Content), "8080") {
+		t.Errorf("text file = %v", resp)
+	}
+	// Cut inside "é": still text.
+	resp, err = s.ReadContainerFile(ctx, &pb.ReadContainerFileRequest{Username: "alice", Path: "/etc/app.conf", MaxBytes: 24})
+	if err != nil || !resp.Truncated || resp.Binary {
+		t.Errorf("truncated text = %v, %v", resp, err)
+	}
+	resp, err = s.ReadContainerFile(ctx, &pb.ReadContainerFileRequest{Username: "alice", Path: "/usr/bin/tool"})
+	if err != nil || !resp.Binary {
+		t.Errorf("binary file = %v, %v", resp, err)
+	}
+
+	for path, want := range map[string]codes.Code{
+		"/etc":                   codes.FailedPrecondition,
+		"/etc/missing":           codes.NotFound,
+		"/root/.ssh/id_ed25519":  codes.PermissionDenied,
+		"etc/app.conf":           codes.InvalidArgument,
+		"/home/../etc/shadow":    codes.InvalidArgument,
+		"/proc/1/root/etc/hosts": codes.InvalidArgument,
+	} {
+		if _, err := s.ReadContainerFile(ctx, &pb.ReadContainerFileRequest{Username: "alice", Path: path}); status.Code(err) != want {
+			t.Errorf("%s: got %v, want %v", path, err, want)
+		}
+	}
+}
+
+func TestWriteContainerFile(t *testing.T) {
+	s, mock := fileTestServer()
+	ctx := adminCtx()
+	var pushed struct {
+		path     string
+		mode     int
+		uid, gid int64
+	}
+	mock.PushFileFunc = func(_, path string, _ []byte, mode int, uid, gid int64) error {
+		pushed.path, pushed.mode, pushed.uid, pushed.gid = path, mode, uid, gid
+		return nil
+	}
+
+	resp, err := s.WriteContainerFile(ctx, &pb.WriteContainerFileRequest{
+		Username: "alice", Path: "/home/alice/.env", Content: []byte("A=1\n"), Mode: "600", Owner: "1000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Size != 4 || resp.Mode != "0600" || resp.Uid != 1000 || resp.Gid != 1000 ||
+		pushed.path != "/home/alice/.env" || pushed.mode != 0o600 || pushed.uid != 1000 || pushed.gid != 1000 {
+		t.Errorf("write = %v, pushed %+v", resp, pushed)
+	}
+
+	for name, req := range map[string]*pb.WriteContainerFileRequest{
+		"too big":     {Username: "alice", Path: "/tmp/big", Content: make([]byte, maxFileWriteBytes+1)},
+		"bad mode":    {Username: "alice", Path: "/tmp/x", Mode: "rw-r--r--"},
+		"setuid mode": {Username: "alice", Path: "/tmp/x", Mode: "4755"},
+		"bad owner":   {Username: "alice", Path: "/tmp/x", Owner: "alice"},
+		"root dir":    {Username: "alice", Path: "/"},
+		"pseudo fs":   {Username: "alice", Path: "/sys/kernel/x"},
+		"relative":    {Username: "alice", Path: "tmp/x"},
+		"dot-dot":     {Username: "alice", Path: "/tmp/../etc/passwd"},
+		"no username": {Path: "/tmp/x"},
+	} {
+		if _, err := s.WriteContainerFile(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", name, err)
+		}
+	}
+}
+
+func TestListContainerDir(t *testing.T) {
+	s, _ := fileTestServer()
+	ctx := adminCtx()
+
+	resp, err := s.ListContainerDir(ctx, &pb.ListContainerDirRequest{Username: "alice", Path: "/etc/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Path != "/etc" || strings.Join(resp.Entries, ",") != "app.conf,hosts,passwd" {
+		t.Errorf("list = %v", resp)
+	}
+	if _, err := s.ListContainerDir(ctx, &pb.ListContainerDirRequest{Username: "alice", Path: "/etc/app.conf"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("list a file: got %v, want FailedPrecondition", err)
+	}
+}
+
+func TestContainerFiles_BoxResolution(t *testing.T) {
+	s, _ := fileTestServer()
+	ctx := adminCtx()
+	if _, err := s.ReadContainerFile(ctx, &pb.ReadContainerFileRequest{Username: "bob", Path: "/etc/app.conf"}); status.Code(err) != codes.NotFound {
+		t.Errorf("read from a missing container: got %v, want NotFound", err)
+	}
+	if _, err := s.WriteContainerFile(ctx, &pb.WriteContainerFileRequest{Username: "bob", Path: "/tmp/x"}); status.Code(err) != codes.NotFound {
+		t.Errorf("write into a missing container: got %v, want NotFound", err)
+	}
+
+	k8s := &ContainerServer{manager: s.manager, boxBackend: k8sBoxStub{}}
+	if _, err := k8s.ListContainerDir(ctx, &pb.ListContainerDirRequest{Username: "alice", Path: "/etc"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("k8s runtime: got %v, want FailedPrecondition", err)
+	}
+}
+
+func TestIsBinaryContent(t *testing.T) {
+	for _, tc := range []struct {
+		content   string
+		truncated bool
+		want      bool
+	}{
+		{"hello\n", false, false},
+		{"caf\xc3", true, false}, // "é" cut by the limit
+		{"caf\xc3", false, true}, // a whole file ending mid-character
+		{"a\x00b", false, true},  // NUL
+		{"\xff\xfe\xfd", true, true},
+	} {
+		if got := isBinaryContent([]byte(tc.content), tc.truncated); got != tc.want {
+			t.Errorf("isBinaryContent(%q, %v) = %v, want %v", tc.content, tc.truncated, got, tc.want)
+		}
+	}
+}
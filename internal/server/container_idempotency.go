@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/footprintai/containarium/pkg/core/box"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// IdempotencyKeyHeader is the HTTP header a client sets on POST
+// /v1/containers to make retries of the same logical create safe. The
+// gateway forwards it as the mdKeyIdempotencyKey gRPC metadata entry.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// mdKeyIdempotencyKey is the gRPC metadata key carrying the header.
+const mdKeyIdempotencyKey = "idempotency-key"
+
+// DefaultIdempotencyKeyTTL is how long a create's key is remembered when
+// the operator doesn't configure --idempotency-key-ttl.
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// maxIdempotencyKeyLen bounds the key so it can't be used to stuff the
+// store; UUIDs and 32-byte hex tokens both fit comfortably.
+const maxIdempotencyKeyLen = 128
+
+// idempotencyPollInterval is how often a replay of a still-running create
+// re-checks the store while waiting for the original to finish.
+var idempotencyPollInterval = time.Second
+
+// daemonInstanceID tags the keys this process claims. An in-flight key
+// carrying another instance's ID was orphaned by a restart mid-create.
+var daemonInstanceID = newDaemonInstanceID()
+
+func newDaemonInstanceID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("pid-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// idempotencyKeyFromContext returns the Idempotency-Key the caller sent,
+// or "" when there is none.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if vals := md.Get(mdKeyIdempotencyKey); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// createRequestFingerprint hashes the request so a key reused for a
+// different create is caught instead of silently replaying the first.
+func createRequestFingerprint(req *pb.CreateContainerRequest) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint create request: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SetIdempotencyStore swaps the store backing Idempotency-Key dedupe.
+// DualServer upgrades the default in-memory store to Postgres once the
+// pool is available.
+func (s *ContainerServer) SetIdempotencyStore(store IdempotencyStore) {
+	s.idempotency = store
+}
+
+// SetIdempotencyKeyTTL sets how long a key is remembered. <= 0 restores
+// DefaultIdempotencyKeyTTL.
+func (s *ContainerServer) SetIdempotencyKeyTTL(ttl time.Duration) {
+	s.idempotencyTTL = ttl
+}
+
+func (s *ContainerServer) idempotencyKeyTTL() time.Duration {
+	if s.idempotencyTTL <= 0 {
+		return DefaultIdempotencyKeyTTL
+	}
+	return s.idempotencyTTL
+}
+
+// beginIdempotentCreate resolves an Idempotency-Key for a create. It
+// returns either a replay (the original create's response — the caller
+// returns it as-is) or a finish func the caller must invoke with the
+// create's outcome. Keys are scoped per username, so tenants can't observe
+// each other's keys.
+//
+// A replay of a create that is still running waits for it to finish. A
+// create orphaned by a daemon restart is taken over: if the container
+// made it into existence, that is reported as the result; otherwise the
+// create runs again under the same key.
+func (s *ContainerServer) beginIdempotentCreate(ctx context.Context, req *pb.CreateContainerRequest, key string) (replay *pb.CreateContainerResponse, finish func(*pb.CreateContainerResponse, error), err error) {
+	if len(key) > maxIdempotencyKeyLen {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLen)
+	}
+	fingerprint, err := createRequestFingerprint(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	finish = func(resp *pb.CreateContainerResponse, createErr error) {
+		// The request ctx may already be cancelled (a client that timed out
+		// is exactly who retries), so record the outcome regardless.
+		bg := context.Background()
+		if createErr != nil || resp == nil {
+			if err := s.idempotency.Release(bg, req.Username, key); err != nil {
+				log.Printf("Warning: failed to release idempotency key for %s: %v", req.Username, err)
+			}
+			return
+		}
+		b, err := protojson.Marshal(resp)
+		if err == nil {
+			err = s.idempotency.Complete(bg, req.Username, key, b)
+		}
+		if err != nil {
+			log.Printf("Warning: failed to record idempotent create for %s: %v", req.Username, err)
+		}
+	}
+
+	for {
+		rec, claimed, err := s.idempotency.Claim(ctx, req.Username, key, fingerprint, daemonInstanceID, s.idempotencyKeyTTL())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check idempotency key: %w", err)
+		}
+		if claimed {
+			return nil, finish, nil
+		}
+		if rec.Fingerprint != fingerprint {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "%s %q was already used for a different create request", IdempotencyKeyHeader, key)
+		}
+		if rec.Response != nil {
+			var resp pb.CreateContainerResponse
+			if err := protojson.Unmarshal(rec.Response, &resp); err != nil {
+				return nil, nil, fmt.Errorf("failed to decode stored create response: %w", err)
+			}
+			return &resp, nil, nil
+		}
+
+		if rec.Owner != daemonInstanceID {
+			took, err := s.idempotency.Takeover(ctx, req.Username, key, rec.Owner, daemonInstanceID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to check idempotency key: %w", err)
+			}
+			if !took {
+				continue // raced another retry; re-read who owns it now
+			}
+			log.Printf("Resuming create for %s orphaned by a daemon restart (idempotency key reused)", req.Username)
+			if info, gerr := s.boxes().Get(ctx, box.BoxRef{Tenant: req.Username}); gerr == nil && info != nil {
+				resp := &pb.CreateContainerResponse{
+					Container: toProtoContainer(info),
+					Message:   fmt.Sprintf("Container %s was created by an earlier attempt with this %s", info.Ref.Name, IdempotencyKeyHeader),
+				}
+				finish(resp, nil)
+				return resp, nil, nil
+			}
+			return nil, finish, nil
+		}
+
+		// Still running in this process: wait for the original.
+		select {
+		case <-ctx.Done():
+			return nil, nil, status.Errorf(codes.Aborted, "create for %s %q is still in progress; retry to get its result", IdempotencyKeyHeader, key)
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestBeginIdempotentCreate_ReplaysCompletedCreate(t *testing.T) {
+	s := &ContainerServer{idempotency: NewMemIdempotencyStore()}
+	req := &pb.CreateContainerRequest{Username: "alice", Image: "images:ubuntu/24.04"}
+
+	replay, finish, err := s.beginIdempotentCreate(context.Background(), req, "k1")
+	if err != nil || replay != nil || finish == nil {
+		t.Fatalf("first call should claim the key: replay=%v finish=%v err=%v", replay, finish != nil, err)
+	}
+	finish(&pb.CreateContainerResponse{Message: "created", Container: &pb.Container{Name: "alice-container"}}, nil)
+
+	replay, finish, err = s.beginIdempotentCreate(context.Background(), req, "k1")
+	if err != nil || finish != nil {
+		t.Fatalf("retry should replay: finish=%v err=%v", finish != nil, err)
+	}
+	if replay.GetMessage() != "created" || replay.GetContainer().GetName() != "alice-container" {
+		t.Errorf("replay = %v, want the original response", replay)
+	}
+
+	// Same key, different request: rejected rather than replayed.
+	other := &pb.CreateContainerRequest{Username: "alice", Image: "images:debian/12"}
+	if _, _, err := s.beginIdempotentCreate(context.Background(), other, "k1"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("reused key with a different request: got %v, want InvalidArgument", err)
+	}
+
+	// Keys are per username.
+	if replay, _, err := s.beginIdempotentCreate(context.Background(), &pb.CreateContainerRequest{Username: "bob"}, "k1"); err != nil || replay != nil {
+		t.Errorf("another tenant's key must not replay: replay=%v err=%v", replay, err)
+	}
+}
+
+func TestBeginIdempotentCreate_FailedCreateReleasesKey(t *testing.T) {
+	s := &ContainerServer{idempotency: NewMemIdempotencyStore()}
+	req := &pb.CreateContainerRequest{Username: "alice"}
+
+	_, finish, err := s.beginIdempotentCreate(context.Background(), req, "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	finish(nil, errors.New("image pull failed"))
+
+	replay, finish, err := s.beginIdempotentCreate(context.Background(), req, "k1")
+	if err != nil || replay != nil || finish == nil {
+		t.Fatalf("retry after a failure should provision afresh: replay=%v err=%v", replay, err)
+	}
+}
+
+func TestBeginIdempotentCreate_InFlightWaitsThenAborts(t *testing.T) {
+	oldPoll := idempotencyPollInterval
+	idempotencyPollInterval = 5 * time.Millisecond
+	defer func() { idempotencyPollInterval = oldPoll }()
+
+	s := &ContainerServer{idempotency: NewMemIdempotencyStore()}
+	req := &pb.CreateContainerRequest{Username: "alice"}
+	if _, _, err := s.beginIdempotentCreate(context.Background(), req, "k1"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if _, _, err := s.beginIdempotentCreate(ctx, req, "k1"); status.Code(err) != codes.Aborted {
+		t.Errorf("replay of a running create: got %v, want Aborted once the caller gives up", err)
+	}
+}
+
+func TestMemIdempotencyStore_ExpiredKeyIsReclaimed(t *testing.T) {
+	st := NewMemIdempotencyStore()
+	now := time.Now()
+	st.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, claimed, _ := st.Claim(ctx, "alice", "k1", "fp", "a", time.Minute); !claimed {
+		t.Fatal("first claim should win")
+	}
+	if _, claimed, _ := st.Claim(ctx, "alice", "k1", "fp", "b", time.Minute); claimed {
+		t.Fatal("second claim inside the TTL should not win")
+	}
+	now = now.Add(2 * time.Minute)
+	if _, claimed, _ := st.Claim(ctx, "alice", "k1", "fp", "b", time.Minute); !claimed {
+		t.Error("claim after expiry should win")
+	}
+	if ok, _ := st.Takeover(ctx, "alice", "k1", "a", "c"); ok {
+		t.Error("takeover from a stale owner should fail")
+	}
+	if ok, _ := st.Takeover(ctx, "alice", "k1", "b", "c"); !ok {
+		t.Error("takeover from the current owner should succeed")
+	}
+}
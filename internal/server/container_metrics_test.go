@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/traffic"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAddTrafficTotals_SumsSinceLastStart(t *testing.T) {
+	started := time.Now().Add(-2 * time.Hour)
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Running", LastStartedAt: started}
+
+	store := traffic.NewMemoryStore(100)
+	ctx := context.Background()
+	for i, c := range []struct {
+		at             time.Time
+		sent, received int64
+	}{
+		{started.Add(-time.Hour), 1 << 30, 1 << 30}, // before the last start
+		{started.Add(10 * time.Minute), 1000, 5000},
+		{started.Add(90 * time.Minute), 24, 120},
+	} {
+		if err := store.SaveConnection(ctx, &pb.Connection{
+			Id: string(rune('a' + i)), ContainerName: "alice-container", Protocol: pb.Protocol_PROTOCOL_TCP,
+			SourceIp: "10.0.3.20", SourcePort: uint32(40000 + i), DestIp: "192.0.2.10", DestPort: 443,
+			FirstSeen: timestamppb.New(c.at), LastSeen: timestamppb.New(c.at),
+			BytesSent: c.sent, BytesReceived: c.received,
+		}); err != nil {
+			t.Fatalf("SaveConnection: %v", err)
+		}
+	}
+
+	s := &ContainerServer{manager: container.NewWithBackend(mock), trafficStore: store}
+	metrics := []*pb.ContainerMetrics{{Name: "alice-container"}}
+	s.addTrafficTotals(adminCtx(), metrics)
+	if metrics[0].NetworkBytesSent != 1024 || metrics[0].NetworkBytesReceived != 5120 {
+		t.Errorf("totals = %d sent / %d received, want 1024 / 5120", metrics[0].NetworkBytesSent, metrics[0].NetworkBytesReceived)
+	}
+
+	// Without persistence the totals stay zero rather than failing.
+	s.trafficStore = nil
+	metrics = []*pb.ContainerMetrics{{Name: "alice-container"}}
+	s.addTrafficTotals(adminCtx(), metrics)
+	if metrics[0].NetworkBytesSent != 0 {
+		t.Errorf("no store: sent = %d, want 0", metrics[0].NetworkBytesSent)
+	}
+}
+
+func TestGetMetrics_RanksFleetAndLimits(t *testing.T) {
+	mock := incustest.NewMockBackend()
+	usage := map[string]int64{"alice-container": 300, "bob-container": 900, "carol-container": 600}
+	for name := range usage {
+		mock.Containers[name] = &incus.ContainerInfo{Name: name, State: "Running"}
+	}
+	mock.GetContainerMetricsFunc = func(name string) (*incus.ContainerMetrics, error) {
+		return &incus.ContainerMetrics{Name: name, MemoryUsageBytes: usage[name] << 20, CPUUsageSeconds: 1000 - usage[name]}, nil
+	}
+	s := &ContainerServer{manager: container.NewWithBackend(mock)}
+
+	resp, err := s.GetMetrics(adminCtx(), &pb.GetMetricsRequest{SortBy: "memory", Limit: 2})
+	if err != nil {
+		t.Fatalf("GetMetrics: %v", err)
+	}
+	if len(resp.Metrics) != 2 || resp.Metrics[0].Name != "bob-container" || resp.Metrics[1].Name != "carol-container" {
+		t.Errorf("top 2 by memory = %v", resp.Metrics)
+	}
+
+	resp, err = s.GetMetrics(adminCtx(), &pb.GetMetricsRequest{SortBy: "cpu", Limit: 1})
+	if err != nil {
+		t.Fatalf("GetMetrics: %v", err)
+	}
+	if len(resp.Metrics) != 1 || resp.Metrics[0].Name != "alice-container" {
+		t.Errorf("top by cpu = %v", resp.Metrics)
+	}
+
+	for _, req := range []*pb.GetMetricsRequest{{SortBy: "disk"}, {Limit: -1}} {
+		if _, err := s.GetMetrics(adminCtx(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetMetrics(%v) err = %v, want InvalidArgument", req, err)
+		}
+	}
+}
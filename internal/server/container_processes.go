@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/safecast"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Page size bounds for GetContainerProcesses.
+const (
+	defaultProcessLimit = 20
+	maxProcessLimit     = 200
+)
+
+// GetContainerProcesses lists the busiest processes in a user's container
+// with its Incus CPU/memory usage, so a caller chasing a pegged box can see
+// what is running without a shell.
+func (s *ContainerServer) GetContainerProcesses(ctx context.Context, req *pb.GetContainerProcessesRequest) (*pb.GetContainerProcessesResponse, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultProcessLimit
+	}
+	limit = min(limit, maxProcessLimit)
+
+	bb := s.boxes()
+	ref := box.BoxRef{Tenant: req.Username}
+	st, err := bb.Get(ctx, ref)
+	if err != nil || st == nil {
+		resp, fwdErr := s.forwardContainerProcesses(ctx, req.Username, limit)
+		if fwdErr != nil {
+			return nil, fwdErr
+		}
+		if resp != nil {
+			return resp, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list processes: %w", err)
+		}
+		return nil, status.Errorf(codes.NotFound, "no container for user %s", req.Username)
+	}
+	ref = st.Ref
+	exec, ok := bb.(box.ExecCapable)
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "listing processes is not supported on the Kubernetes runtime")
+	}
+	if st.State != pb.ContainerState_CONTAINER_STATE_RUNNING {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: %v (state: %s)", ref.Name, container.ErrNotRunning, st.State)
+	}
+
+	procs, total, err := container.ListProcesses(func(cmd []string) (string, string, error) {
+		return exec.Exec(ctx, ref, cmd)
+	}, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	resp := &pb.GetContainerProcessesResponse{TotalProcesses: safecast.I32(total)}
+	for _, p := range procs {
+		resp.Processes = append(resp.Processes, &pb.ContainerProcess{
+			Pid:            p.PID,
+			User:           p.User,
+			CpuPercent:     p.CPUPercent,
+			MemoryPercent:  p.MemoryPercent,
+			ElapsedSeconds: p.ElapsedSeconds,
+			Command:        p.Command,
+		})
+	}
+	if mc, ok := bb.(box.MetricsCapable); ok {
+		if m, err := mc.Metrics(ctx, ref); err != nil {
+			log.Printf("Warning: failed to get metrics for %s: %v", ref.Name, err)
+		} else if m != nil {
+			resp.Metrics = boxMetricsToProto(ref.Name, m)
+		}
+	}
+	return resp, nil
+}
+
+// forwardContainerProcesses asks the peer hosting username's container.
+// It returns nil, nil when no peer has it.
+func (s *ContainerServer) forwardContainerProcesses(ctx context.Context, username string, limit int) (*pb.GetContainerProcessesResponse, error) {
+	if s.peerPool == nil {
+		return nil, nil
+	}
+	authToken := extractAuthToken(ctx)
+	peer := s.peerPool.FindContainerPeer(username, authToken)
+	if peer == nil {
+		return nil, nil
+	}
+	body, statusCode, err := peer.ForwardRequest("GET", fmt.Sprintf("/v1/containers/%s/processes?limit=%d", username, limit), authToken, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes on peer %s: %w", peer.ID, err)
+	}
+	if statusCode >= 400 {
+		return nil, fmt.Errorf("peer %s returned status %d for list processes: %s", peer.ID, statusCode, string(body))
+	}
+	var resp pb.GetContainerProcessesResponse
+	if err := protojson.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode processes from peer %s: %w", peer.ID, err)
+	}
+	return &resp, nil
+}
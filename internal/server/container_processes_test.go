@@ -0,0 +1,62 @@
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestGetContainerProcesses(t *testing.T) {
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Running"}
+	mock.Containers["bob-container"] = &incus.ContainerInfo{Name: "bob-container", State: "Stopped"}
+	mock.ExecWithOutputFunc = func(string, []string) (string, string, error) {
+		return "812 alice 97.3 4.1 3605 python3\n1 root 0.1 0.2 864000 systemd\n", "", nil
+	}
+	mock.GetContainerMetricsFunc = func(name string) (*incus.ContainerMetrics, error) {
+		return &incus.ContainerMetrics{Name: name, CPUUsageSeconds: 5400, MemoryUsageBytes: 512 << 20}, nil
+	}
+	s := &ContainerServer{manager: container.NewWithBackend(mock)}
+
+	resp, err := s.GetContainerProcesses(adminCtx(), &pb.GetContainerProcessesRequest{Username: "alice", Limit: 1})
+	if err != nil {
+		t.Fatalf("GetContainerProcesses: %v", err)
+	}
+	if resp.TotalProcesses != 2 || len(resp.Processes) != 1 || resp.Processes[0].Command != "python3" {
+		t.Errorf("got %v", resp)
+	}
+	if resp.Metrics.GetMemoryUsageBytes() != 512<<20 {
+		t.Errorf("metrics = %v, want the container's Incus usage", resp.Metrics)
+	}
+
+	_, err = s.GetContainerProcesses(adminCtx(), &pb.GetContainerProcessesRequest{Username: "bob"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("stopped container: got %v, want FailedPrecondition", err)
+	}
+	_, err = s.GetContainerProcesses(adminCtx(), &pb.GetContainerProcessesRequest{Username: "ghost"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("missing container: got %v, want NotFound", err)
+	}
+}
+
+// TestGetContainerProcesses_K8sRuntime — the k8s box backend has no in-box
+// exec, so the listing is refused rather than reaching the LXC manager.
+func TestGetContainerProcesses_K8sRuntime(t *testing.T) {
+	s := &ContainerServer{
+		manager: container.NewWithBackend(incus.NewUnavailableBackend()),
+		boxBackend: k8sBoxStub{boxes: map[string]*box.BoxStatus{
+			"alice": {Ref: box.BoxRef{Tenant: "alice", Name: "sandbox"}, State: pb.ContainerState_CONTAINER_STATE_RUNNING},
+		}},
+	}
+	_, err := s.GetContainerProcesses(adminCtx(), &pb.GetContainerProcessesRequest{Username: "alice"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("k8s runtime: got %v, want FailedPrecondition", err)
+	}
+}
@@ -0,0 +1,356 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/ostype"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Persisted provisioning step states (the JSON in incus.ProvisionStepsKey).
+const (
+	stepRunning = "running"
+	stepDone    = "done"
+	stepFailed  = "failed"
+)
+
+// readinessProbeTimeout bounds the sshd/RDP dial in GetContainerReadiness.
+const readinessProbeTimeout = 3 * time.Second
+
+// provisionStep is one recorded provisioning step. It is both the in-memory
+// record and the persisted form.
+type provisionStep struct {
+	Name       string    `json:"name"`
+	State      string    `json:"state"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitzero"`
+}
+
+// provisionProgress records the steps of one creation as the manager reports
+// them. A step runs until the next one begins or the creation finishes.
+type provisionProgress struct {
+	mu    sync.Mutex
+	steps []provisionStep
+}
+
+// begin closes the running step as done and starts name.
+func (p *provisionProgress) begin(name string, now time.Time) []provisionStep {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closeRunning(stepDone, "", now)
+	p.steps = append(p.steps, provisionStep{Name: name, State: stepRunning, StartedAt: now})
+	return slices.Clone(p.steps)
+}
+
+// finish closes the running step: done on success, failed with err's text
+// otherwise.
+func (p *provisionProgress) finish(err error, now time.Time) []provisionStep {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.closeRunning(stepFailed, err.Error(), now)
+	} else {
+		p.closeRunning(stepDone, "", now)
+	}
+	return slices.Clone(p.steps)
+}
+
+func (p *provisionProgress) closeRunning(state, errMsg string, now time.Time) {
+	if n := len(p.steps); n > 0 && p.steps[n-1].State == stepRunning {
+		p.steps[n-1].State = state
+		p.steps[n-1].Error = errMsg
+		p.steps[n-1].FinishedAt = now
+	}
+}
+
+func (p *provisionProgress) snapshot() []provisionStep {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.steps)
+}
+
+// trackProvisioning starts recording a creation for username and returns the
+// callback to hand the backend as BoxSpec.OnStep. Each step transition is
+// also written to the instance's config once the instance exists, so a
+// restarted daemon can still report how far provisioning got.
+func (s *ContainerServer) trackProvisioning(username string) (*provisionProgress, func(string)) {
+	p := &provisionProgress{}
+	s.provisionMu.Lock()
+	if s.provisions == nil {
+		s.provisions = make(map[string]*provisionProgress)
+	}
+	s.provisions[username] = p
+	s.provisionMu.Unlock()
+
+	return p, func(step string) {
+		steps := p.begin(step, time.Now())
+		if step != container.StepCreate {
+			s.persistProvisionSteps(username, p, steps)
+		}
+	}
+}
+
+// finishProvisioning records the outcome of a tracked creation. A failed
+// creation's instance has already been cleaned up, so only success is
+// persisted; the failure stays in memory for GetContainerReadiness.
+func (s *ContainerServer) finishProvisioning(username string, p *provisionProgress, err error) {
+	steps := p.finish(err, time.Now())
+	if err == nil {
+		s.persistProvisionSteps(username, p, steps)
+	}
+}
+
+// forgetProvisioning drops the in-memory record for username (on delete).
+func (s *ContainerServer) forgetProvisioning(username string) {
+	s.provisionMu.Lock()
+	delete(s.provisions, username)
+	delete(s.lastReady, username)
+	s.provisionMu.Unlock()
+}
+
+func (s *ContainerServer) provisionProgressFor(username string) *provisionProgress {
+	s.provisionMu.Lock()
+	defer s.provisionMu.Unlock()
+	return s.provisions[username]
+}
+
+// persistProvisionSteps writes steps to the instance config. Best-effort:
+// the in-memory record is authoritative while this daemon runs. A creation
+// that is no longer tracked (deleted mid-provision) is not written, and
+// neither is anything on the k8s runtime, which has no instance config.
+func (s *ContainerServer) persistProvisionSteps(username string, p *provisionProgress, steps []provisionStep) {
+	if _, isK8s := s.k8sBoxes(); isK8s || s.manager == nil || s.provisionProgressFor(username) != p {
+		return
+	}
+	data, err := json.Marshal(steps)
+	if err != nil {
+		log.Printf("Warning: failed to encode provisioning steps for %s: %v", username, err)
+		return
+	}
+	if err := s.manager.SetConfig(username+"-container", incus.ProvisionStepsKey, string(data)); err != nil {
+		log.Printf("Warning: failed to persist provisioning steps for %s: %v", username, err)
+	}
+}
+
+// persistedStepsFor reads the steps stored on username's instance. Only
+// the LXC runtime persists them; elsewhere there are none.
+func (s *ContainerServer) persistedStepsFor(username string) []provisionStep {
+	if _, isK8s := s.k8sBoxes(); isK8s || s.manager == nil {
+		return nil
+	}
+	info, err := s.manager.Get(username)
+	if err != nil || info == nil {
+		return nil
+	}
+	return persistedProvisionSteps(info.ProvisionSteps)
+}
+
+// persistedProvisionSteps decodes the steps stored on an instance. A step
+// still marked running was cut off by a daemon restart — this daemon isn't
+// tracking the creation, so nothing will ever finish it — and is reported
+// as failed.
+func persistedProvisionSteps(raw string) []provisionStep {
+	if raw == "" {
+		return nil
+	}
+	var steps []provisionStep
+	if err := json.Unmarshal([]byte(raw), &steps); err != nil {
+		log.Printf("Warning: ignoring malformed %s: %v", incus.ProvisionStepsKey, err)
+		return nil
+	}
+	for i := range steps {
+		if steps[i].State == stepRunning {
+			steps[i].State = stepFailed
+			steps[i].Error = "interrupted: the daemon restarted while this step was running"
+		}
+	}
+	return steps
+}
+
+// GetContainerReadiness reports a container's provisioning steps and live
+// readiness checks. The steps come from this daemon's in-memory record when
+// it is (or was) running the creation, and from the instance config
+// otherwise.
+func (s *ContainerServer) GetContainerReadiness(ctx context.Context, req *pb.GetContainerReadinessRequest) (*pb.GetContainerReadinessResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetContainerReadinessResponse{
+		Username:      req.Username,
+		ContainerName: req.Username + "-container",
+	}
+	progress := s.provisionProgressFor(req.Username)
+
+	st, _ := s.boxes().Get(ctx, box.BoxRef{Tenant: req.Username})
+	if st == nil {
+		if progress == nil {
+			if s.forwardContainerRequest(ctx, req.Username, "GET", "/v1/containers/"+req.Username+"/readiness", nil, resp) {
+				return resp, nil
+			}
+			return nil, status.Errorf(codes.NotFound, "container %s not found", resp.ContainerName)
+		}
+		// The creation is either still in its first step or failed and
+		// was cleaned up.
+		steps := progress.snapshot()
+		resp.Steps = protoProvisionSteps(steps)
+		resp.State = pb.ContainerState_CONTAINER_STATE_CREATING
+		if failedStep(steps) != nil {
+			resp.State = pb.ContainerState_CONTAINER_STATE_ERROR
+		}
+		resp.Checks = []*pb.ReadinessCheck{{Name: "running", Detail: "instance does not exist"}}
+		return resp, nil
+	}
+
+	var steps []provisionStep
+	if progress != nil {
+		steps = progress.snapshot()
+	} else {
+		steps = s.persistedStepsFor(req.Username)
+	}
+	resp.Steps = protoProvisionSteps(steps)
+	resp.State = st.State
+	resp.Checks = s.readinessChecks(st)
+
+	resp.Ready = true
+	for _, st := range steps {
+		if st.State != stepDone {
+			resp.Ready = false
+		}
+	}
+	for _, c := range resp.Checks {
+		if !c.Ok {
+			resp.Ready = false
+		}
+	}
+	s.noteReadiness(ctx, resp)
+	return resp, nil
+}
+
+// noteReadiness records a readiness result and emits HEALTH_CHANGED when it
+// differs from the last one seen for that box. The first observation only
+// sets the baseline: with nothing to compare against it isn't a change.
+func (s *ContainerServer) noteReadiness(ctx context.Context, resp *pb.GetContainerReadinessResponse) {
+	s.provisionMu.Lock()
+	prev, seen := s.lastReady[resp.Username]
+	if s.lastReady == nil {
+		s.lastReady = make(map[string]bool)
+	}
+	s.lastReady[resp.Username] = resp.Ready
+	s.provisionMu.Unlock()
+	if !seen || prev == resp.Ready {
+		return
+	}
+
+	details := map[string]string{"ready": strconv.FormatBool(resp.Ready)}
+	for _, c := range resp.Checks {
+		if !c.Ok {
+			details["failing"] = c.Name
+			break
+		}
+	}
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH_CHANGED,
+		&pb.Container{Name: resp.ContainerName, Username: resp.Username, State: resp.State}, details)
+}
+
+// readinessChecks probes the box: running, an IP assigned, and its login
+// service (sshd, or RDP for Windows VMs) accepting connections.
+func (s *ContainerServer) readinessChecks(st *box.BoxStatus) []*pb.ReadinessCheck {
+	running := &pb.ReadinessCheck{
+		Name:   "running",
+		Ok:     st.State == pb.ContainerState_CONTAINER_STATE_RUNNING,
+		Detail: strings.ToLower(strings.TrimPrefix(st.State.String(), "CONTAINER_STATE_")),
+	}
+	ip := &pb.ReadinessCheck{Name: "ip-assigned", Ok: st.IPAddress != "", Detail: st.IPAddress}
+	if !ip.Ok {
+		ip.Detail = "no IPv4 address yet"
+	}
+
+	name, port := "sshd", 22
+	if ostype.IsWindows(ostype.OSTypeFromLabel(st.Labels[ostype.OSTypeLabelKey])) {
+		name, port = "rdp", 3389
+	}
+	login := &pb.ReadinessCheck{Name: name}
+	if !running.Ok || !ip.Ok {
+		login.Detail = "not probed: the instance has no running address"
+	} else {
+		addr := net.JoinHostPort(st.IPAddress, strconv.Itoa(port))
+		probe := s.loginProbe
+		if probe == nil {
+			probe = dialProbe
+		}
+		if err := probe(addr); err != nil {
+			login.Detail = err.Error()
+		} else {
+			login.Ok = true
+			login.Detail = addr + " accepting connections"
+		}
+	}
+	return []*pb.ReadinessCheck{running, ip, login}
+}
+
+// dialProbe is the production loginProbe: a plain TCP connect.
+func dialProbe(addr string) error {
+	conn, err := net.DialTimeout("tcp", addr, readinessProbeTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func failedStep(steps []provisionStep) *provisionStep {
+	for i := range steps {
+		if steps[i].State == stepFailed {
+			return &steps[i]
+		}
+	}
+	return nil
+}
+
+func protoProvisionSteps(steps []provisionStep) []*pb.ProvisionStep {
+	out := make([]*pb.ProvisionStep, 0, len(steps))
+	for _, st := range steps {
+		ps := &pb.ProvisionStep{
+			Name:      st.Name,
+			Error:     st.Error,
+			StartedAt: timestamppb.New(st.StartedAt),
+		}
+		switch st.State {
+		case stepRunning:
+			ps.State = pb.ProvisionStepState_PROVISION_STEP_STATE_RUNNING
+		case stepDone:
+			ps.State = pb.ProvisionStepState_PROVISION_STEP_STATE_DONE
+		case stepFailed:
+			ps.State = pb.ProvisionStepState_PROVISION_STEP_STATE_FAILED
+		}
+		if !st.FinishedAt.IsZero() {
+			ps.FinishedAt = timestamppb.New(st.FinishedAt)
+		}
+		out = append(out, ps)
+	}
+	return out
+}
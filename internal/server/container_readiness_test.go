@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func newReadinessServer(mock *incustest.MockBackend, probeErr error) *ContainerServer {
+	return &ContainerServer{
+		manager:    container.NewWithBackend(mock),
+		loginProbe: func(string) error { return probeErr },
+	}
+}
+
+func TestGetContainerReadiness_TracksAndPersistsSteps(t *testing.T) {
+	mock := incustest.NewMockBackend()
+	persisted := map[string]string{}
+	mock.SetConfigFunc = func(name, key, value string) error {
+		if key == incus.ProvisionStepsKey {
+			persisted[name] = value
+		}
+		return nil
+	}
+	srv := newReadinessServer(mock, nil)
+
+	progress, onStep := srv.trackProvisioning("alice")
+	onStep(container.StepCreate)
+	if len(persisted) != 0 {
+		t.Fatal("steps persisted before the instance exists")
+	}
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Running"}
+	onStep(container.StepStart)
+	onStep(container.StepNetwork)
+
+	resp, err := srv.GetContainerReadiness(tenantCtx("alice"), &pb.GetContainerReadinessRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("GetContainerReadiness: %v", err)
+	}
+	if resp.Ready {
+		t.Error("ready while the network step is still running")
+	}
+	if n := len(resp.Steps); n != 3 || resp.Steps[2].State != pb.ProvisionStepState_PROVISION_STEP_STATE_RUNNING {
+		t.Fatalf("steps = %v, want create, start done and network running", resp.Steps)
+	}
+
+	mock.Containers["alice-container"].IPAddress = "10.0.3.20"
+	srv.finishProvisioning("alice", progress, nil)
+	resp, err = srv.GetContainerReadiness(tenantCtx("alice"), &pb.GetContainerReadinessRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("GetContainerReadiness: %v", err)
+	}
+	if !resp.Ready {
+		t.Errorf("not ready after provisioning finished: steps=%v checks=%v", resp.Steps, resp.Checks)
+	}
+
+	// The persisted record is complete: a daemon that restarts now reads
+	// the same finished steps back.
+	steps := persistedProvisionSteps(persisted["alice-container"])
+	if len(steps) != 3 || steps[2].State != stepDone || steps[2].FinishedAt.IsZero() {
+		t.Errorf("persisted steps = %+v, want three finished steps", steps)
+	}
+}
+
+func TestGetContainerReadiness_InterruptedByRestart(t *testing.T) {
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{
+		Name:      "alice-container",
+		State:     "Running",
+		IPAddress: "10.0.3.20",
+		ProvisionSteps: `[{"name":"start","state":"done","startedAt":"2026-01-01T00:00:00Z","finishedAt":"2026-01-01T00:00:05Z"},` +
+			`{"name":"packages","state":"running","startedAt":"2026-01-01T00:00:05Z"}]`,
+	}
+	// A fresh server: this daemon never saw the creation.
+	srv := newReadinessServer(mock, nil)
+
+	resp, err := srv.GetContainerReadiness(tenantCtx("alice"), &pb.GetContainerReadinessRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("GetContainerReadiness: %v", err)
+	}
+	if resp.Ready {
+		t.Error("ready despite an interrupted packages step")
+	}
+	last := resp.Steps[len(resp.Steps)-1]
+	if last.Name != "packages" || last.State != pb.ProvisionStepState_PROVISION_STEP_STATE_FAILED || !strings.Contains(last.Error, "restarted") {
+		t.Errorf("last step = %v, want packages failed by restart", last)
+	}
+}
+
+func TestGetContainerReadiness_FailedCreationWithoutInstance(t *testing.T) {
+	srv := newReadinessServer(incustest.NewMockBackend(), nil)
+	progress, onStep := srv.trackProvisioning("alice")
+	onStep(container.StepCreate)
+	onStep(container.StepStart)
+	srv.finishProvisioning("alice", progress, errors.New("failed to start container: boom"))
+
+	resp, err := srv.GetContainerReadiness(tenantCtx("alice"), &pb.GetContainerReadinessRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("GetContainerReadiness: %v", err)
+	}
+	if resp.State != pb.ContainerState_CONTAINER_STATE_ERROR || resp.Ready {
+		t.Errorf("state=%v ready=%v, want ERROR and not ready", resp.State, resp.Ready)
+	}
+	if last := resp.Steps[len(resp.Steps)-1]; last.Name != container.StepStart || last.Error == "" {
+		t.Errorf("last step = %v, want start with its error", last)
+	}
+}
+
+func TestGetContainerReadiness_SSHDUnreachable(t *testing.T) {
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Running", IPAddress: "10.0.3.20"}
+	srv := newReadinessServer(mock, errors.New("connection refused"))
+
+	resp, err := srv.GetContainerReadiness(tenantCtx("alice"), &pb.GetContainerReadinessRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("GetContainerReadiness: %v", err)
+	}
+	if resp.Ready {
+		t.Error("ready while sshd refuses connections")
+	}
+	if c := resp.Checks[2]; c.Name != "sshd" || c.Ok || c.Detail != "connection refused" {
+		t.Errorf("sshd check = %v", c)
+	}
+}
+
+func TestGetContainerReadiness_OtherTenantDenied(t *testing.T) {
+	srv := newReadinessServer(incustest.NewMockBackend(), nil)
+	if _, err := srv.GetContainerReadiness(tenantCtx("bob"), &pb.GetContainerReadinessRequest{Username: "alice"}); err == nil {
+		t.Error("bob read alice's readiness")
+	}
+}
+
+// k8sBoxStub is a k8s-runtime box backend holding fixed statuses; the
+// methods tests don't need are left to the nil embedded interface.
+type k8sBoxStub struct {
+	box.BoxBackend
+	boxes map[string]*box.BoxStatus // by tenant
+}
+
+func (k8sBoxStub) Kind() box.BackendKind { return box.KindK8s }
+
+func (b k8sBoxStub) Get(_ context.Context, ref box.BoxRef) (*box.BoxStatus, error) {
+	return b.boxes[ref.Tenant], nil
+}
+
+func TestGetContainerReadiness_K8sRuntime(t *testing.T) {
+	srv := &ContainerServer{
+		manager: container.NewWithBackend(incus.NewUnavailableBackend()),
+		boxBackend: k8sBoxStub{boxes: map[string]*box.BoxStatus{
+			"alice": {Ref: box.BoxRef{Tenant: "alice", Name: "sandbox"}, State: pb.ContainerState_CONTAINER_STATE_RUNNING, IPAddress: "10.244.1.7"},
+		}},
+		loginProbe: func(string) error { return nil },
+	}
+
+	resp, err := srv.GetContainerReadiness(tenantCtx("alice"), &pb.GetContainerReadinessRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("GetContainerReadiness: %v", err)
+	}
+	if !resp.Ready || resp.State != pb.ContainerState_CONTAINER_STATE_RUNNING {
+		t.Errorf("ready=%v state=%v checks=%v, want a ready running box", resp.Ready, resp.State, resp.Checks)
+	}
+	if _, err := srv.GetContainerReadiness(tenantCtx("bob"), &pb.GetContainerReadinessRequest{Username: "bob"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing box: got %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/audit"
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/secrets"
+	"github.com/footprintai/containarium/pkg/core/container"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Container-secret scopes: how a tenant secret is materialized inside
+// <username>-container. They name the store's delivery modes from the
+// container's point of view.
+const (
+	secretScopeUserEnv = "user-env" // environment.<NAME> (DeliveryEnv)
+	secretScopeFile    = "file"     // /run/secrets/<NAME> or a chosen path
+	secretScopeCompose = "compose"  // the shared dotenv file
+)
+
+// SetContainerSecret stores a tenant secret and stamps it into the
+// tenant's container at once, through the same path as RefreshSecrets.
+// The scope is "user-env" or an absolute file path. If the secret
+// moved (say from the environment to a file), the old location is
+// cleaned up.
+func (s *ContainerServer) SetContainerSecret(ctx context.Context, req *pb.SetContainerSecretRequest) (*pb.SetContainerSecretResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeSecretsWrite); err != nil {
+		return nil, err
+	}
+	if s.secretsStore == nil {
+		return nil, status.Error(codes.Unavailable, "secrets store not configured on this daemon")
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	prev, err := s.findSecret(ctx, req.Username, req.Name)
+	if err != nil && !errors.Is(err, secrets.ErrNotFound) {
+		return nil, mapSecretError(err)
+	}
+
+	var meta *secrets.SecretMetadata
+	switch scope := cmp.Or(req.Scope, secretScopeUserEnv); {
+	case scope == secretScopeUserEnv:
+		meta, err = s.secretsStore.Set(ctx, req.Username, req.Name, req.Value, secrets.DeliveryEnv)
+	case strings.HasPrefix(scope, "/"):
+		meta, err = s.secretsStore.SetAtPath(ctx, req.Username, req.Name, req.Value, scope)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "scope must be %q or an absolute file path; got %q", secretScopeUserEnv, req.Scope)
+	}
+	if err != nil {
+		return nil, mapSecretError(err)
+	}
+	cs := toContainerSecret(meta)
+	s.auditContainerSecret(ctx, "secret.set", req.Username, cs)
+
+	containerName := req.Username + "-container"
+	if prev != nil {
+		if old := toContainerSecret(prev); old.Location != cs.Location {
+			if err := s.unstampSecret(ctx, req.Username, prev); err != nil {
+				log.Printf("[secrets] failed to remove %s from its old location %s on %s: %v", req.Name, old.Location, containerName, err)
+			}
+		}
+	}
+	stamped, err := s.stampSecretsOnLXC(ctx, req.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "secret stored but not stamped: %v", err)
+	}
+
+	log.Printf("[secrets] set %s/%s version=%d scope=%s location=%s", req.Username, req.Name, meta.Version, cs.Scope, cs.Location)
+	verb := "created"
+	if meta.Version > 1 {
+		verb = fmt.Sprintf("updated to version %d", meta.Version)
+	}
+	return &pb.SetContainerSecretResponse{
+		Message: fmt.Sprintf("secret %s %s at %s; re-stamped %d secret(s) on %s", req.Name, verb, cs.Location, stamped, containerName),
+		Secret:  cs,
+	}, nil
+}
+
+// ListContainerSecrets lists the tenant's secrets with where each one
+// is materialized. Values never leave the store on this path.
+func (s *ContainerServer) ListContainerSecrets(ctx context.Context, req *pb.ListContainerSecretsRequest) (*pb.ListContainerSecretsResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeSecretsRead); err != nil {
+		return nil, err
+	}
+	if s.secretsStore == nil {
+		return nil, status.Error(codes.Unavailable, "secrets store not configured on this daemon")
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	list, err := s.secretsStore.List(ctx, req.Username)
+	if err != nil {
+		return nil, mapSecretError(err)
+	}
+	out := make([]*pb.ContainerSecret, 0, len(list))
+	for i := range list {
+		out = append(out, toContainerSecret(&list[i]))
+	}
+	return &pb.ListContainerSecretsResponse{Secrets: out}, nil
+}
+
+// RemoveContainerSecret deletes a tenant secret and takes it out of the
+// container. The store delete is what counts: cleanup inside the
+// container is best-effort (a stopped container can't have a file
+// removed) and a failure is reported in the message.
+func (s *ContainerServer) RemoveContainerSecret(ctx context.Context, req *pb.RemoveContainerSecretRequest) (*pb.RemoveContainerSecretResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeSecretsWrite); err != nil {
+		return nil, err
+	}
+	if s.secretsStore == nil {
+		return nil, status.Error(codes.Unavailable, "secrets store not configured on this daemon")
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	meta, err := s.findSecret(ctx, req.Username, req.Name)
+	if err != nil {
+		return nil, mapSecretError(err)
+	}
+	if err := s.secretsStore.Delete(ctx, req.Username, req.Name); err != nil {
+		return nil, mapSecretError(err)
+	}
+	cs := toContainerSecret(meta)
+	s.auditContainerSecret(ctx, "secret.remove", req.Username, cs)
+
+	containerName := req.Username + "-container"
+	msg := fmt.Sprintf("secret %s removed from the store and %s", req.Name, cs.Location)
+	if err := s.unstampSecret(ctx, req.Username, meta); err != nil {
+		log.Printf("[secrets] failed to remove %s from %s: %v", cs.Location, containerName, err)
+		msg = fmt.Sprintf("secret %s removed from the store, but %s could not be cleaned up on %s: %v", req.Name, cs.Location, containerName, err)
+	}
+	log.Printf("[secrets] remove %s/%s location=%s", req.Username, req.Name, cs.Location)
+	return &pb.RemoveContainerSecretResponse{Message: msg}, nil
+}
+
+// findSecret returns the metadata of one secret, or secrets.ErrNotFound.
+func (s *ContainerServer) findSecret(ctx context.Context, username, name string) (*secrets.SecretMetadata, error) {
+	list, err := s.secretsStore.List(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if list[i].Name == name {
+			return &list[i], nil
+		}
+	}
+	return nil, secrets.ErrNotFound
+}
+
+// unstampSecret takes a secret out of the tenant's container: unsets the
+// environment key, deletes the file, or, for compose, re-renders the
+// dotenv file from what is left in the store.
+func (s *ContainerServer) unstampSecret(ctx context.Context, username string, m *secrets.SecretMetadata) error {
+	containerName := username + "-container"
+	switch m.Delivery {
+	case secrets.DeliveryFile:
+		return s.manager.Exec(containerName, []string{"rm", "-f", toContainerSecret(m).Location})
+	case secrets.DeliveryCompose:
+		_, err := s.stampSecretsOnLXC(ctx, username)
+		return err
+	default:
+		return s.manager.UnsetEnv(containerName, m.Name)
+	}
+}
+
+// toContainerSecret is m as seen from inside the container.
+func toContainerSecret(m *secrets.SecretMetadata) *pb.ContainerSecret {
+	cs := &pb.ContainerSecret{
+		Name:      m.Name,
+		Version:   m.Version,
+		UpdatedAt: m.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	switch m.Delivery {
+	case secrets.DeliveryFile:
+		cs.Scope, cs.Location = secretScopeFile, cmp.Or(m.Path, "/run/secrets/"+m.Name)
+	case secrets.DeliveryCompose:
+		cs.Scope, cs.Location = secretScopeCompose, container.SecretsEnvFilePath
+	default:
+		cs.Scope, cs.Location = secretScopeUserEnv, "environment."+m.Name
+	}
+	return cs
+}
+
+// auditContainerSecret records who changed a tenant secret and where it
+// is materialized. Best-effort, like the other audit writers.
+func (s *ContainerServer) auditContainerSecret(ctx context.Context, action, username string, cs *pb.ContainerSecret) {
+	if s.auditStore == nil {
+		return
+	}
+	subject, _, _ := auth.SubjectFromGRPCContext(ctx)
+	if err := s.auditStore.Log(ctx, containerSecretAuditEntry(subject, action, username, cs)); err != nil {
+		log.Printf("[secrets] audit %s %s/%s: %v", action, username, cs.Name, err)
+	}
+}
+
+// containerSecretAuditEntry builds the audit row for a secret change.
+// It is given only metadata, never the value, so a value can't reach
+// audit_logs however the detail is later rendered.
+func containerSecretAuditEntry(subject, action, username string, cs *pb.ContainerSecret) *audit.AuditEntry {
+	payload, _ := json.Marshal(map[string]any{
+		"scope":    cs.Scope,
+		"location": cs.Location,
+		"version":  cs.Version,
+	})
+	return &audit.AuditEntry{
+		Username:     cmp.Or(subject, "_unknown"),
+		Action:       action,
+		ResourceType: "secret",
+		ResourceID:   username + "/" + cs.Name,
+		Detail:       string(payload),
+	}
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/secrets"
+	"github.com/footprintai/containarium/pkg/core/container"
+)
+
+func TestToContainerSecret_Location(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		delivery, path  string
+		scope, location string
+	}{
+		{secrets.DeliveryEnv, "", "user-env", "environment.DB_PASSWORD"},
+		{"", "", "user-env", "environment.DB_PASSWORD"},
+		{secrets.DeliveryFile, "", "file", "/run/secrets/DB_PASSWORD"},
+		{secrets.DeliveryFile, "/home/alice/.config/app/db", "file", "/home/alice/.config/app/db"},
+		{secrets.DeliveryCompose, "", "compose", container.SecretsEnvFilePath},
+	}
+	for _, tt := range tests {
+		cs := toContainerSecret(&secrets.SecretMetadata{
+			Name: "DB_PASSWORD", Version: 2, UpdatedAt: updated, Delivery: tt.delivery, Path: tt.path,
+		})
+		if cs.Scope != tt.scope || cs.Location != tt.location {
+			t.Errorf("delivery %q path %q: scope %q location %q, want %q %q", tt.delivery, tt.path, cs.Scope, cs.Location, tt.scope, tt.location)
+		}
+		if cs.Version != 2 || cs.UpdatedAt != "2026-03-01T10:00:00Z" {
+			t.Errorf("metadata not carried over: %+v", cs)
+		}
+	}
+}
+
+func TestContainerSecretAuditEntry(t *testing.T) {
+	cs := toContainerSecret(&secrets.SecretMetadata{Name: "API_KEY", Version: 3, Delivery: secrets.DeliveryFile, Path: "/srv/api/key"})
+	e := containerSecretAuditEntry("alice", "secret.set", "alice", cs)
+	if e.Username != "alice" || e.Action != "secret.set" || e.ResourceType != "secret" || e.ResourceID != "alice/API_KEY" {
+		t.Errorf("entry = %+v", e)
+	}
+	for _, want := range []string{`"scope":"file"`, `"location":"/srv/api/key"`, `"version":3`} {
+		if !strings.Contains(e.Detail, want) {
+			t.Errorf("detail %s lacks %s", e.Detail, want)
+		}
+	}
+	if strings.Contains(strings.ToLower(e.Detail), "value") {
+		t.Errorf("detail mentions a value: %s", e.Detail)
+	}
+
+	if e := containerSecretAuditEntry("", "secret.remove", "bob", cs); e.Username != "_unknown" {
+		t.Errorf("anonymous subject = %q, want _unknown", e.Username)
+	}
+}
@@ -1,12 +1,16 @@
 package server
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,10 +32,13 @@ import (
 	"github.com/footprintai/containarium/internal/releasecheck"
 	"github.com/footprintai/containarium/internal/safecast"
 	"github.com/footprintai/containarium/internal/secrets"
+	"github.com/footprintai/containarium/internal/sshkey"
+	"github.com/footprintai/containarium/internal/traffic"
 	"github.com/footprintai/containarium/pkg/core/box"
 	boxlxc "github.com/footprintai/containarium/pkg/core/box/lxc"
 	"github.com/footprintai/containarium/pkg/core/container"
 	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/network"
 	"github.com/footprintai/containarium/pkg/core/ostype"
 	"github.com/footprintai/containarium/pkg/core/stacks"
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
@@ -85,6 +92,11 @@ type ContainerServer struct {
 	emitter             *events.Emitter
 	pendingCreations    map[string]*PendingCreation
 	pendingMu           sync.RWMutex
+	// Idempotency-Key dedupe for CreateContainer. In-memory by default;
+	// DualServer swaps in the Postgres store so keys survive a restart.
+	// idempotencyTTL <= 0 means DefaultIdempotencyKeyTTL.
+	idempotency    IdempotencyStore
+	idempotencyTTL time.Duration
 	// Monitoring URLs (set by DualServer after setup)
 	victoriaMetricsURL string
 	grafanaURL         string
@@ -191,6 +203,18 @@ type ContainerServer struct {
 	// owned, so deleting an LXC actually deletes the public hostname too.
 	routeStore   routeLister
 	proxyManager *app.ProxyManager
+	// passthroughStore holds TCP/UDP passthrough routes; DeleteContainer
+	// drops the container's records and GarbageCollect reaps orphans. Nil
+	// on daemons without a Postgres pool.
+	passthroughStore network.PassthroughStore
+	// shaper holds per-container bandwidth limits: re-applied on start,
+	// shown by GetContainer, dropped on delete. Nil without Postgres.
+	shaper *network.TrafficShaper
+	// hostAccountDeleter / hostAccountLister are test seams over the
+	// jump-server account helpers, which shell out to userdel / id. Nil in
+	// production.
+	hostAccountDeleter func(account string) error
+	hostAccountLister  func() ([]string, error)
 
 	// moveRunner shells out to `incus snapshot/copy/stop/start` for the
 	// MoveContainer migration flow. Nil on daemons that don't support
@@ -245,6 +269,34 @@ type ContainerServer struct {
 	// Nil on daemons without a Postgres pool; nil is safe (ops are logged but
 	// not persisted). #354.
 	auditStore *audit.Store
+	// trafficStore backs the traffic section of GetContainerActivity; nil
+	// when traffic persistence is disabled.
+	trafficStore traffic.ConnectionStore
+	// listeners backs the listening_ports section; nil without a traffic
+	// collector.
+	listeners listenerSource
+	// sshSessions backs the ssh_sessions section; nil without a traffic
+	// collector.
+	sshSessions sshSessionSource
+	// egress explains connectivity tests against the network policy; nil
+	// when the policy enforcer isn't running.
+	egress egressChecker
+	// templates are the presets CreateContainer's template field
+	// expands; templateRoutes adds their routes (nil without routing).
+	templates      containerTemplates
+	templateRoutes templateRouteAdder
+
+	// provisions holds the provisioning steps of creations this daemon has
+	// run, by username, for GetContainerReadiness. Entries outlive the
+	// creation so a failure stays visible after its instance is cleaned
+	// up; a delete drops them. loginProbe is the sshd/RDP reachability
+	// check (nil = TCP dial; tests inject a fake). lastReady is the
+	// readiness each user's box last reported, so a flip emits
+	// HEALTH_CHANGED.
+	provisionMu sync.Mutex
+	provisions  map[string]*provisionProgress
+	loginProbe  func(addr string) error
+	lastReady   map[string]bool
 
 	// autoUpdater drives on-demand daemon upgrades (TriggerUpgrade). Nil on
 	// daemons started without an auto-update source (e.g. no sentinel), in
@@ -291,6 +343,7 @@ func NewContainerServer(runtime string) (*ContainerServer, error) {
 		emitter:          events.NewEmitter(events.GetBus()),
 		pendingCreations: make(map[string]*PendingCreation),
 		platformStats:    platformstats.New(),
+		idempotency:      NewMemIdempotencyStore(),
 	}, nil
 }
 
@@ -337,6 +390,12 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
 		return nil, err
 	}
+	// Expand a template first, so everything below checks the settings
+	// the box is actually created with.
+	tmpl, err := s.expandContainerTemplate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 	// Audit B-MED-1 / B-MED-2 / B-LOW-1: cap the unbounded
 	// repeated-string / map fields before any allocation-heavy
 	// work runs. Done after the tenant check (don't enumerate
@@ -362,6 +421,21 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 		return nil, status.Errorf(codes.InvalidArgument, "delete_after_stopped_seconds must be >= 0, got %d", req.DeleteAfterStoppedSeconds)
 	}
 
+	// Idempotency-Key: a retried create (client timeout, agent re-issue)
+	// gets the original result instead of racing a second provisioning of
+	// the same username. Checked after authz so keys stay tenant-scoped,
+	// and before peer routing so forwarded creates dedupe too.
+	if key := idempotencyKeyFromContext(ctx); key != "" && s.idempotency != nil {
+		replay, finish, claimErr := s.beginIdempotentCreate(ctx, req, key)
+		if claimErr != nil {
+			return nil, claimErr
+		}
+		if replay != nil {
+			return replay, nil
+		}
+		defer func() { finish(resp, err) }()
+	}
+
 	// Pool resolution — if a pool is requested, either validate that
 	// the explicit backend_id belongs to that pool, or pick any
 	// healthy backend in the pool when backend_id is empty.
@@ -384,7 +458,7 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 			}
 			// Forward to peer — extract auth token from context
 			authToken := extractAuthToken(ctx)
-			respBody, err := peer.ForwardCreateContainer(authToken, req)
+			respBody, err := peer.ForwardCreateContainer(authToken, templateForwardRequest(req, tmpl))
 			if err != nil {
 				return nil, fmt.Errorf("failed to create container on backend %q: %w", req.BackendId, err)
 			}
@@ -465,6 +539,7 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 		GitRef:        req.GitRef,
 		GitCredential: req.GitCredential,
 		WorkspacePath: req.WorkspacePath,
+		CloudInit:     tmpl.GetCloudInit(),
 	}
 	// Phase 2.5 follow-up — load the OTel bearer for
 	// monitoring=true containers. Best-effort: an error
@@ -581,6 +656,13 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 		}
 		s.pendingMu.Unlock()
 
+		progress, onStep := s.trackProvisioning(req.Username)
+		spec.OnStep = onStep
+		actor := eventActor(ctx)
+		s.emitter.EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATING,
+			&pb.Container{Name: fmt.Sprintf("%s-container", req.Username), Username: req.Username, State: pb.ContainerState_CONTAINER_STATE_CREATING},
+			actor, map[string]string{"image": req.Image, "async": "true"})
+
 		// Set provisioning callback
 		spec.OnProvisioning = func() {
 			s.pendingMu.Lock()
@@ -642,6 +724,7 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 			if err == nil && info != nil && req.DeleteAfterStoppedSeconds > 0 {
 				s.stampBirthDeleteAfterStopped(info.Ref.Name, req.DeleteAfterStoppedSeconds)
 			}
+			s.finishProvisioning(req.Username, progress, err)
 
 			// A delete that landed mid-provisioning owns this outcome, not
 			// the creation (#1035): drop the tracking entry and log the one
@@ -693,7 +776,8 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 			// Emit event on success
 			if err == nil && info != nil {
 				s.refreshContainerIPMap()
-				s.emitter.EmitContainerCreated(toProtoContainer(info))
+				s.addTemplateRoutes(tmpl, req.Username, info.Ref.Name, info.IPAddress)
+				s.emitter.EmitContainerEvent(pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED, toProtoContainer(info), actor, templateDetails(tmpl))
 			}
 		}()
 
@@ -715,7 +799,13 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 	}
 
 	// Sync mode - wait for completion
+	progress, onStep := s.trackProvisioning(req.Username)
+	spec.OnStep = onStep
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATING,
+		&pb.Container{Name: fmt.Sprintf("%s-container", req.Username), Username: req.Username, State: pb.ContainerState_CONTAINER_STATE_CREATING},
+		map[string]string{"image": req.Image})
 	info, err := s.boxes().Create(ctx, spec)
+	s.finishProvisioning(req.Username, progress, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container: %w", err)
 	}
@@ -786,8 +876,10 @@ func (s *ContainerServer) CreateContainer(ctx context.Context, req *pb.CreateCon
 	protoContainer.Pool = s.resolvePool(protoContainer.BackendId)
 	protoContainer.SshHost = s.sshHost
 
-	// Emit container created event
-	s.emitter.EmitContainerCreated(protoContainer)
+	s.addTemplateRoutes(tmpl, req.Username, info.Ref.Name, info.IPAddress)
+
+	// Emit container created event; the audit log records the template.
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED, protoContainer, templateDetails(tmpl))
 
 	resp = &pb.CreateContainerResponse{
 		Container: protoContainer,
@@ -1106,11 +1198,29 @@ func (s *ContainerServer) GetContainer(ctx context.Context, req *pb.GetContainer
 	protoInfo.Pool = s.resolvePool(protoInfo.BackendId)
 	protoInfo.SshHost = s.sshHost
 	return &pb.GetContainerResponse{
-		Container: protoInfo,
+		Container:      protoInfo,
+		BandwidthLimit: s.bandwidthLimit(ctx, protoInfo.Name),
 		// TODO: Add metrics
 	}, nil
 }
 
+// bandwidthLimit returns containerName's bandwidth limit for
+// GetContainer, or nil when it has none. A store error only hides the
+// limit; it never fails the lookup.
+func (s *ContainerServer) bandwidthLimit(ctx context.Context, containerName string) *pb.BandwidthLimit {
+	if s.shaper == nil {
+		return nil
+	}
+	limit, err := s.shaper.GetLimit(ctx, containerName)
+	if err != nil {
+		if !errors.Is(err, network.ErrBandwidthLimitNotFound) {
+			log.Printf("Warning: failed to get bandwidth limit of %s: %v", containerName, err)
+		}
+		return nil
+	}
+	return bandwidthLimitToProto(limit, s.shaper.Applied(containerName))
+}
+
 // DeleteContainer deletes a container
 func (s *ContainerServer) DeleteContainer(ctx context.Context, req *pb.DeleteContainerRequest) (resp *pb.DeleteContainerResponse, err error) {
 	if err := auth.RequireScope(ctx, auth.ScopeContainersWrite); err != nil {
@@ -1125,6 +1235,10 @@ func (s *ContainerServer) DeleteContainer(ctx context.Context, req *pb.DeleteCon
 
 	containerName := fmt.Sprintf("%s-container", req.Username)
 
+	if req.DryRun {
+		return s.planDelete(ctx, req, containerName)
+	}
+
 	// #1083: everything past this point is a genuine attempt to delete a
 	// container — k8s, peer-forwarded, or local-manager. Auth/validation
 	// above is a client-side rejection, not a provisioning attempt, and is
@@ -1143,6 +1257,7 @@ func (s *ContainerServer) DeleteContainer(ctx context.Context, req *pb.DeleteCon
 	// like a genuine create bug. Marking first (not after) matters: the
 	// misleading exec errors are produced by the delete itself.
 	cancelledCreate := s.cancelPendingCreation(req.Username)
+	s.forgetProvisioning(req.Username)
 	if cancelledCreate {
 		log.Printf("Delete for %s arrived while its creation was still provisioning; cancelling the create", req.Username)
 	}
@@ -1154,68 +1269,147 @@ func (s *ContainerServer) DeleteContainer(ctx context.Context, req *pb.DeleteCon
 		// K8s runtime: deleting the Sandbox cascades to its pod + Service via
 		// owner refs; the backend keeps the namespace + data PVC (its
 		// delete-retains-data contract) and removes the gateway Pipe + Secrets.
-		if err := bb.Delete(ctx, box.BoxRef{Tenant: req.Username}, req.Force); err != nil {
-			s.uncancelPendingCreation(req.Username, cancelledCreate)
-			return nil, fmt.Errorf("failed to delete container: %w", err)
+		return s.deleteWithTeardown(ctx, req, containerName, cancelledCreate, func() error {
+			return bb.Delete(ctx, box.BoxRef{Tenant: req.Username}, req.Force)
+		})
+	}
+
+	if _, err = s.manager.Get(req.Username); err == nil {
+		return s.deleteWithTeardown(ctx, req, containerName, cancelledCreate, func() error {
+			return s.manager.Delete(req.Username, req.Force)
+		})
+	}
+
+	// Not found locally — try peers. The owning daemon runs the teardown
+	// against its own registries and reports what it removed.
+	if s.peerPool != nil {
+		authToken := extractAuthToken(ctx)
+		peer := s.peerPool.FindContainerPeer(req.Username, authToken)
+		if peer != nil {
+			body, statusCode, fwdErr := peer.ForwardRequest("DELETE", deleteContainerPath(req), authToken, nil)
+			if fwdErr != nil {
+				s.uncancelPendingCreation(req.Username, cancelledCreate)
+				return nil, fmt.Errorf("failed to delete container on peer %s: %w", peer.ID, fwdErr)
+			}
+			if statusCode >= 400 {
+				s.uncancelPendingCreation(req.Username, cancelledCreate)
+				return nil, fmt.Errorf("peer %s returned status %d for delete", peer.ID, statusCode)
+			}
+			var peerResp pb.DeleteContainerResponse
+			if jsonErr := protojson.Unmarshal(body, &peerResp); jsonErr != nil {
+				log.Printf("Warning: failed to decode delete response from peer %s: %v", peer.ID, jsonErr)
+			}
+			return &pb.DeleteContainerResponse{
+				Message:       fmt.Sprintf("Container for user %s deleted on backend %s", req.Username, peer.ID),
+				ContainerName: containerName,
+				Removed:       peerResp.Removed,
+				LeftBehind:    peerResp.LeftBehind,
+			}, nil
 		}
-		s.cascadeContainerCleanup(ctx, containerName, req.Username)
-		s.emitter.EmitContainerDeleted(containerName)
-		return &pb.DeleteContainerResponse{
-			Message:       fmt.Sprintf("Container for user %s deleted successfully", req.Username),
-			ContainerName: containerName,
-		}, nil
 	}
+	s.uncancelPendingCreation(req.Username, cancelledCreate)
+	return nil, fmt.Errorf("failed to delete container: %w", err)
+}
 
-	err = s.manager.Delete(req.Username, req.Force)
-	if err != nil {
-		// Not found locally — try peers
+// planDelete answers a dry-run DeleteContainer: the same validation and
+// dependent enumeration as a real delete, returned as the response without
+// touching the container, its dependents or any in-flight creation.
+func (s *ContainerServer) planDelete(ctx context.Context, req *pb.DeleteContainerRequest, containerName string) (*pb.DeleteContainerResponse, error) {
+	if bb, isK8s := s.k8sBoxes(); isK8s {
+		st, err := bb.Get(ctx, box.BoxRef{Tenant: req.Username})
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete container: %w", err)
+		}
+		if st == nil {
+			return nil, fmt.Errorf("failed to delete container: container not found")
+		}
+	} else if info, err := s.manager.Get(req.Username); err != nil || info == nil {
+		if err == nil {
+			err = fmt.Errorf("container %s not found", containerName)
+		}
 		if s.peerPool != nil {
 			authToken := extractAuthToken(ctx)
-			peer := s.peerPool.FindContainerPeer(req.Username, authToken)
-			if peer != nil {
-				forceParam := ""
-				if req.Force {
-					forceParam = "?force=true"
-				}
-				_, statusCode, fwdErr := peer.ForwardRequest("DELETE", fmt.Sprintf("/v1/containers/%s%s", req.Username, forceParam), authToken, nil)
+			if peer := s.peerPool.FindContainerPeer(req.Username, authToken); peer != nil {
+				body, statusCode, fwdErr := peer.ForwardRequest("DELETE", deleteContainerPath(req), authToken, nil)
 				if fwdErr != nil {
-					s.uncancelPendingCreation(req.Username, cancelledCreate)
-					return nil, fmt.Errorf("failed to delete container on peer %s: %w", peer.ID, fwdErr)
+					return nil, fmt.Errorf("failed to plan delete on peer %s: %w", peer.ID, fwdErr)
 				}
 				if statusCode >= 400 {
-					s.uncancelPendingCreation(req.Username, cancelledCreate)
 					return nil, fmt.Errorf("peer %s returned status %d for delete", peer.ID, statusCode)
 				}
-				return &pb.DeleteContainerResponse{
-					Message:       fmt.Sprintf("Container for user %s deleted on backend %s", req.Username, peer.ID),
-					ContainerName: containerName,
-				}, nil
+				var peerResp pb.DeleteContainerResponse
+				if jsonErr := protojson.Unmarshal(body, &peerResp); jsonErr != nil {
+					return nil, fmt.Errorf("failed to decode delete plan from peer %s: %w", peer.ID, jsonErr)
+				}
+				peerResp.DryRun = true
+				return &peerResp, nil
 			}
 		}
-		s.uncancelPendingCreation(req.Username, cancelledCreate)
 		return nil, fmt.Errorf("failed to delete container: %w", err)
 	}
 
-	// Cascade-clean the routes + TLS subjects + host user that this
-	// container owned. The LXC is gone above; without these steps the
-	// public hostname returns 502 (Caddy route still points at a
-	// deleted upstream IP) and Caddy keeps trying to ACME-renew an
-	// orphaned cert. All best-effort: any single failure logs a
-	// warning but doesn't block the response — the LXC delete already
-	// succeeded, and partial-cascade is better than telling the caller
-	// "delete failed" when the container is in fact gone.
-	s.cascadeContainerCleanup(ctx, containerName, req.Username)
+	plan, err := s.planTeardown(ctx, containerName, req.Username, req.Force)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete container: %w", err)
+	}
+	s.auditTeardownPlan(ctx, plan, true)
+	items := plan.items()
+	return &pb.DeleteContainerResponse{
+		Message:       fmt.Sprintf("Dry run: deleting the container for user %s would remove %d resource(s)", req.Username, len(items)),
+		ContainerName: containerName,
+		Removed:       items,
+		LeftBehind:    plan.unlisted,
+		DryRun:        true,
+	}, nil
+}
+
+// deleteContainerPath is the REST path a delete is forwarded to a peer on.
+func deleteContainerPath(req *pb.DeleteContainerRequest) string {
+	q := url.Values{}
+	if req.Force {
+		q.Set("force", "true")
+	}
+	if req.DryRun {
+		q.Set("dry_run", "true")
+	}
+	path := fmt.Sprintf("/v1/containers/%s", req.Username)
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	return path
+}
+
+// deleteWithTeardown runs the ordered teardown (see teardownContainer) with
+// destroy as the container step and builds the response. The container's
+// routes, passthrough routes, collaborators and host user go with it;
+// without that the public hostname keeps pointing at a dead upstream IP —
+// or at whichever box gets that IP next.
+func (s *ContainerServer) deleteWithTeardown(ctx context.Context, req *pb.DeleteContainerRequest, containerName string, cancelledCreate bool, destroy func() error) (*pb.DeleteContainerResponse, error) {
+	rep, err := s.teardownContainer(ctx, containerName, req.Username, req.Force, destroy)
+	if err != nil {
+		s.uncancelPendingCreation(req.Username, cancelledCreate)
+		return nil, fmt.Errorf("failed to delete container: %w", err)
+	}
 
 	// Emit container deleted event
-	s.emitter.EmitContainerDeleted(containerName)
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_DELETE,
+		&pb.Container{Name: containerName, Username: req.Username}, map[string]string{"force": strconv.FormatBool(req.Force)})
 
 	// Refresh the collector's IP map so the deleted container's IP
 	// is no longer claimed in source-IP attribution.
-	s.refreshContainerIPMap()
+	if _, isK8s := s.k8sBoxes(); !isK8s {
+		s.refreshContainerIPMap()
+	}
 
+	msg := fmt.Sprintf("Container for user %s deleted successfully", req.Username)
+	if n := len(rep.leftBehind); n > 0 {
+		msg = fmt.Sprintf("Container for user %s deleted; %d dependent resource(s) left behind", req.Username, n)
+	}
 	return &pb.DeleteContainerResponse{
-		Message:       fmt.Sprintf("Container for user %s deleted successfully", req.Username),
+		Message:       msg,
 		ContainerName: containerName,
+		Removed:       rep.removed,
+		LeftBehind:    rep.leftBehind,
 	}, nil
 }
 
@@ -1251,9 +1445,10 @@ func (s *ContainerServer) uncancelPendingCreation(username string, cancelled boo
 	}
 }
 
-// cascadeContainerCleanup removes the resources that DeleteContainer's
-// LXC-delete leaves behind. Documented as #69 / verified live against
-// the demo cluster on 2026-05-14.
+// cascadeContainerCleanup removes the resources an LXC delete leaves
+// behind on the source of a MoveContainer. Documented as #69 / verified
+// live against the demo cluster on 2026-05-14. DeleteContainer uses the
+// fuller, ordered teardownContainer instead.
 //
 // Order is deliberate:
 //  1. Route store first — kills the source of truth so RouteSyncJob
@@ -1375,6 +1570,14 @@ func (s *ContainerServer) StartContainer(ctx context.Context, req *pb.StartConta
 				log.Printf("[secrets] re-stamped %d secret(s) on %s-container at start time", n, req.Username)
 			}
 		}
+
+		// The start gave the container a new veth; put its bandwidth
+		// limit on it now rather than at the shaper's next sync.
+		if s.shaper != nil {
+			if err := s.shaper.Reapply(ctx, req.Username+"-container"); err != nil {
+				log.Printf("[shaper] failed to re-apply bandwidth limit on %s-container: %v (continuing)", req.Username, err)
+			}
+		}
 	}
 
 	info, err := s.boxes().Get(ctx, box.BoxRef{Tenant: req.Username})
@@ -1394,7 +1597,7 @@ func (s *ContainerServer) StartContainer(ctx context.Context, req *pb.StartConta
 		timedOut = s.waitForContainerReady(ctx, req.Username, info.IPAddress, time.Duration(timeoutSec)*time.Second)
 	}
 
-	s.emitter.EmitContainerStarted(toProtoContainer(info))
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_START, toProtoContainer(info), nil)
 
 	// Post-start: point this container's Caddy routes back at the
 	// container's direct IP/port (undo the wake-mode swap that
@@ -1519,7 +1722,7 @@ func (s *ContainerServer) StopContainer(ctx context.Context, req *pb.StopContain
 		}
 	}
 
-	s.emitter.EmitContainerStopped(toProtoContainer(info))
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_STOP, toProtoContainer(info), map[string]string{"force": strconv.FormatBool(req.Force)})
 
 	return &pb.StopContainerResponse{
 		Message:   fmt.Sprintf("Container for user %s stopped successfully", req.Username),
@@ -1613,9 +1816,11 @@ func (s *ContainerServer) ResizeContainer(ctx context.Context, req *pb.ResizeCon
 		if info == nil {
 			return nil, fmt.Errorf("container resized but not found on read-back")
 		}
+		protoContainer := toProtoContainer(info)
+		s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_RESIZE, protoContainer, resizeDetails(req))
 		return &pb.ResizeContainerResponse{
 			Message:   fmt.Sprintf("Container %s resized successfully", containerName),
-			Container: toProtoContainer(info),
+			Container: protoContainer,
 		}, nil
 	}
 
@@ -1659,6 +1864,7 @@ func (s *ContainerServer) ResizeContainer(ctx context.Context, req *pb.ResizeCon
 
 	// Convert to protobuf
 	protoContainer := toProtoContainer(info)
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_RESIZE, protoContainer, resizeDetails(req))
 
 	return &pb.ResizeContainerResponse{
 		Message:   fmt.Sprintf("Container %s resized successfully", containerName),
@@ -2109,6 +2315,51 @@ func (s *ContainerServer) ToggleAutoSleep(ctx context.Context, req *pb.ToggleAut
 	}, nil
 }
 
+// ToggleDNSLogging writes the per-container DNS query logging opt-in.
+// The traffic collector picks the flag up on its next container cache
+// refresh and only records queries from boxes that have it set. Core
+// containers are refused, like ToggleAutoSleep; Kubernetes boxes are
+// refused because their lookups don't go through the bridge resolver
+// whose log the collector follows.
+func (s *ContainerServer) ToggleDNSLogging(ctx context.Context, req *pb.ToggleDNSLoggingRequest) (*pb.ToggleDNSLoggingResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersWrite); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	if _, isK8s := s.k8sBoxes(); isK8s {
+		return nil, status.Error(codes.FailedPrecondition, "DNS logging is not supported on the Kubernetes runtime")
+	}
+
+	info, err := s.boxes().Get(ctx, box.BoxRef{Tenant: req.Username})
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "container for user %s not found: %v", req.Username, err)
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.NotFound, "container for user %s not found", req.Username)
+	}
+	if info.IsCore {
+		return nil, status.Errorf(codes.InvalidArgument, "container %s is a core container; DNS logging is for user containers only", info.Ref.Name)
+	}
+
+	if err := s.manager.SetConfig(info.Ref.Name, incus.DNSLogEnabledKey, strconv.FormatBool(req.Enabled)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set %s: %v", incus.DNSLogEnabledKey, err)
+	}
+
+	msg := "DNS logging disabled"
+	if req.Enabled {
+		msg = "DNS logging enabled"
+	}
+	return &pb.ToggleDNSLoggingResponse{
+		Message:           msg,
+		DnsLoggingEnabled: req.Enabled,
+	}, nil
+}
+
 // AddSSHKey appends an SSH public key to the user's host-side
 // authorized_keys file (/home/<username>/.ssh/authorized_keys).
 //
@@ -2140,6 +2391,8 @@ func (s *ContainerServer) AddSSHKey(ctx context.Context, req *pb.AddSSHKeyReques
 	if err := container.AddAuthorizedKey(req.Username, req.SshPublicKey); err != nil {
 		return nil, fmt.Errorf("add authorized key: %w", err)
 	}
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_KEY_ADDED,
+		&pb.Container{Name: req.Username + "-container", Username: req.Username}, sshKeyDetails(req.SshPublicKey))
 
 	total, err := container.CountAuthorizedKeys(req.Username)
 	if err != nil {
@@ -2174,6 +2427,8 @@ func (s *ContainerServer) RemoveSSHKey(ctx context.Context, req *pb.RemoveSSHKey
 	if err := container.RemoveAuthorizedKey(req.Username, req.SshPublicKey); err != nil {
 		return nil, fmt.Errorf("remove authorized key: %w", err)
 	}
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_KEY_REMOVED,
+		&pb.Container{Name: req.Username + "-container", Username: req.Username}, sshKeyDetails(req.SshPublicKey))
 
 	total, err := container.CountAuthorizedKeys(req.Username)
 	if err != nil {
@@ -2202,6 +2457,9 @@ func (s *ContainerServer) GetMetrics(ctx context.Context, req *pb.GetMetricsRequ
 		}
 		req.Username = subject
 	}
+	if err := validateMetricsRanking(req); err != nil {
+		return nil, err
+	}
 
 	var protoMetrics []*pb.ContainerMetrics
 
@@ -2227,6 +2485,7 @@ func (s *ContainerServer) GetMetrics(ctx context.Context, req *pb.GetMetricsRequ
 			return nil, fmt.Errorf("failed to get metrics: %w", err)
 		}
 		protoMetrics = append(protoMetrics, toProtoMetrics(metrics))
+		s.addTrafficTotals(ctx, protoMetrics)
 	} else {
 		// Get metrics for all containers (local)
 		allMetrics, err := s.manager.GetAllMetrics()
@@ -2236,6 +2495,7 @@ func (s *ContainerServer) GetMetrics(ctx context.Context, req *pb.GetMetricsRequ
 		for _, m := range allMetrics {
 			protoMetrics = append(protoMetrics, toProtoMetrics(m))
 		}
+		s.addTrafficTotals(ctx, protoMetrics)
 
 		// Merge metrics from all healthy peers
 		if s.peerPool != nil {
@@ -2260,10 +2520,87 @@ func (s *ContainerServer) GetMetrics(ctx context.Context, req *pb.GetMetricsRequ
 	}
 
 	return &pb.GetMetricsResponse{
-		Metrics: protoMetrics,
+		Metrics: rankMetrics(protoMetrics, req.SortBy, int(req.Limit)),
 	}, nil
 }
 
+// metricsSortKeys maps GetMetricsRequest.sort_by to the value ranked on.
+var metricsSortKeys = map[string]func(*pb.ContainerMetrics) int64{
+	"cpu":    (*pb.ContainerMetrics).GetCpuUsageSeconds,
+	"memory": (*pb.ContainerMetrics).GetMemoryUsageBytes,
+}
+
+func validateMetricsRanking(req *pb.GetMetricsRequest) error {
+	if _, ok := metricsSortKeys[req.SortBy]; req.SortBy != "" && !ok {
+		return status.Errorf(codes.InvalidArgument, "sort_by must be \"cpu\" or \"memory\", got %q", req.SortBy)
+	}
+	if req.Limit < 0 {
+		return status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	return nil
+}
+
+// rankMetrics orders metrics by sortBy, highest first (ties by name), and
+// keeps the first limit. Ranking happens after the peers' metrics are
+// merged, so the result is the fleet's top N rather than each host's.
+func rankMetrics(metrics []*pb.ContainerMetrics, sortBy string, limit int) []*pb.ContainerMetrics {
+	if key, ok := metricsSortKeys[sortBy]; ok {
+		slices.SortStableFunc(metrics, func(a, b *pb.ContainerMetrics) int {
+			if c := cmp.Compare(key(b), key(a)); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	if limit > 0 && len(metrics) > limit {
+		metrics = metrics[:limit]
+	}
+	return metrics
+}
+
+// addTrafficTotals fills network_bytes_sent/received on local metrics from
+// the traffic store: everything recorded since each container last started
+// (its creation, if it was never started by this daemon). Best-effort — a
+// failed lookup leaves the totals at zero. Peers fill their own.
+func (s *ContainerServer) addTrafficTotals(ctx context.Context, metrics []*pb.ContainerMetrics) {
+	if s.trafficStore == nil || len(metrics) == 0 || auth.RequireScope(ctx, auth.ScopeTrafficRead) != nil {
+		return
+	}
+	boxes, err := s.boxes().List(ctx)
+	if err != nil {
+		log.Printf("Warning: traffic totals: failed to list containers: %v", err)
+		return
+	}
+	since := make(map[string]time.Time, len(boxes))
+	for _, b := range boxes {
+		since[b.Ref.Name] = b.LastStartedAt
+		if b.LastStartedAt.IsZero() {
+			since[b.Ref.Name] = b.CreatedAt
+		}
+	}
+	now := time.Now()
+	for _, m := range metrics {
+		start := since[m.Name]
+		if start.IsZero() {
+			continue
+		}
+		aggs, err := s.trafficStore.GetAggregates(ctx, traffic.AggregateParams{
+			ContainerNames: []string{m.Name},
+			StartTime:      start,
+			EndTime:        now,
+			Interval:       "1d",
+		})
+		if err != nil {
+			log.Printf("Warning: traffic totals for %s: %v", m.Name, err)
+			continue
+		}
+		for _, a := range aggs {
+			m.NetworkBytesSent += a.BytesSent
+			m.NetworkBytesReceived += a.BytesReceived
+		}
+	}
+}
+
 // daemonReleaseChecker caches the latest GitHub release across requests so a
 // busy fleet's status checks don't burn the unauthenticated GitHub rate
 // limit. Package-level (not per-request) for that shared cache. #354.
@@ -3341,6 +3678,56 @@ func toProtoMetrics(m *incus.ContainerMetrics) *pb.ContainerMetrics {
 	}
 }
 
+// boxMetricsToProto converts a box backend's metrics for the named box to
+// protobuf, like toProtoMetrics.
+func boxMetricsToProto(name string, m *box.BoxMetrics) *pb.ContainerMetrics {
+	return &pb.ContainerMetrics{
+		Name:             name,
+		CpuUsageSeconds:  m.CPUUsageSeconds,
+		MemoryUsageBytes: m.MemoryUsageBytes,
+		MemoryPeakBytes:  m.MemoryLimitBytes,
+		DiskUsageBytes:   m.DiskUsageBytes,
+		NetworkRxBytes:   m.NetworkRxBytes,
+		NetworkTxBytes:   m.NetworkTxBytes,
+		ProcessCount:     m.ProcessCount,
+	}
+}
+
+// eventActor is the subject a lifecycle event is attributed to: the
+// caller, or auth.SystemSubject for daemon-internal operations.
+func eventActor(ctx context.Context) string {
+	subject, _, _ := auth.SubjectFromGRPCContext(ctx)
+	return subject
+}
+
+// emitContainerEvent publishes a typed container lifecycle event attributed
+// to the caller in ctx. Operations forwarded to a peer are emitted there,
+// not here.
+func (s *ContainerServer) emitContainerEvent(ctx context.Context, t pb.ContainerEventType, c *pb.Container, details map[string]string) {
+	s.emitter.EmitContainerEvent(t, c, eventActor(ctx), details)
+}
+
+// resizeDetails lists the limits a resize changed.
+func resizeDetails(req *pb.ResizeContainerRequest) map[string]string {
+	details := map[string]string{}
+	for k, v := range map[string]string{"cpu": req.Cpu, "memory": req.Memory, "disk": req.Disk} {
+		if v != "" {
+			details[k] = v
+		}
+	}
+	return details
+}
+
+// sshKeyDetails identifies a key by fingerprint; the key itself stays out
+// of the event stream.
+func sshKeyDetails(key string) map[string]string {
+	fp, err := sshkey.Fingerprint(key)
+	if err != nil {
+		return nil
+	}
+	return map[string]string{"fingerprint": fp}
+}
+
 // toProtoContainer converts internal container info to protobuf
 func toProtoContainer(st *box.BoxStatus) *pb.Container {
 	// Resolve OS type from labels
@@ -3747,6 +4134,17 @@ func (s *ContainerServer) SetRouteCleanupDeps(routeStore *app.RouteStore, proxyM
 	s.proxyManager = proxyManager
 }
 
+// SetPassthroughStore wires the passthrough route store so deletes and
+// garbage collection can remove a container's passthrough routes.
+func (s *ContainerServer) SetPassthroughStore(store network.PassthroughStore) {
+	s.passthroughStore = store
+}
+
+// SetTrafficShaper sets the shaper enforcing per-container bandwidth limits
+func (s *ContainerServer) SetTrafficShaper(shaper *network.TrafficShaper) {
+	s.shaper = shaper
+}
+
 // SetCollaboratorManager sets the collaborator manager for handling collaborator operations
 func (s *ContainerServer) SetCollaboratorManager(cm *container.CollaboratorManager) {
 	s.collaboratorManager = cm
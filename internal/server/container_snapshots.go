@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// CreateSnapshot takes an incus snapshot of a user's container. Like the
+// other per-container operations it tries the local manager first and falls
+// back to the peer that owns the container.
+func (s *ContainerServer) CreateSnapshot(ctx context.Context, req *pb.CreateSnapshotRequest) (*pb.CreateSnapshotResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersWrite); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if req.SnapshotName == "" {
+		return nil, fmt.Errorf("snapshot_name is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	snap, err := s.manager.CreateSnapshot(req.Username, req.SnapshotName)
+	if err != nil {
+		body, _ := protojson.Marshal(req)
+		resp := &pb.CreateSnapshotResponse{}
+		if s.forwardContainerRequest(ctx, req.Username, "POST", snapshotsPath(req.Username), body, resp) {
+			return resp, nil
+		}
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_SNAPSHOT,
+		&pb.Container{Name: req.Username + "-container", Username: req.Username},
+		map[string]string{"snapshot": snap.Name, "action": "create"})
+
+	return &pb.CreateSnapshotResponse{
+		Message:  fmt.Sprintf("Snapshot %s created for %s-container", snap.Name, req.Username),
+		Snapshot: toProtoSnapshot(snap),
+	}, nil
+}
+
+// ListSnapshots lists the snapshots of a user's container.
+func (s *ContainerServer) ListSnapshots(ctx context.Context, req *pb.ListSnapshotsRequest) (*pb.ListSnapshotsResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	snaps, err := s.manager.ListSnapshots(req.Username)
+	if err != nil {
+		resp := &pb.ListSnapshotsResponse{}
+		if s.forwardContainerRequest(ctx, req.Username, "GET", snapshotsPath(req.Username), nil, resp) {
+			return resp, nil
+		}
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	resp := &pb.ListSnapshotsResponse{}
+	for i := range snaps {
+		resp.Snapshots = append(resp.Snapshots, toProtoSnapshot(&snaps[i]))
+	}
+	return resp, nil
+}
+
+// RestoreSnapshot rolls a user's container back to one of its snapshots.
+func (s *ContainerServer) RestoreSnapshot(ctx context.Context, req *pb.RestoreSnapshotRequest) (*pb.RestoreSnapshotResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersWrite); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if req.SnapshotName == "" {
+		return nil, fmt.Errorf("snapshot_name is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	snap, err := s.manager.RestoreSnapshot(req.Username, req.SnapshotName)
+	if err != nil {
+		resp := &pb.RestoreSnapshotResponse{}
+		path := snapshotsPath(req.Username) + "/" + url.PathEscape(req.SnapshotName) + "/restore"
+		if s.forwardContainerRequest(ctx, req.Username, "POST", path, []byte("{}"), resp) {
+			return resp, nil
+		}
+		return nil, fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	s.emitContainerEvent(ctx, pb.ContainerEventType_CONTAINER_EVENT_TYPE_SNAPSHOT,
+		&pb.Container{Name: req.Username + "-container", Username: req.Username},
+		map[string]string{"snapshot": snap.Name, "action": "restore"})
+
+	return &pb.RestoreSnapshotResponse{
+		Message:  fmt.Sprintf("%s-container restored to snapshot %s", req.Username, snap.Name),
+		Snapshot: toProtoSnapshot(snap),
+	}, nil
+}
+
+func snapshotsPath(username string) string {
+	return fmt.Sprintf("/v1/containers/%s/snapshots", username)
+}
+
+// forwardContainerRequest forwards a per-container call (snapshots,
+// activity) to the peer that owns the user's container, decoding the peer's
+// JSON response into out. Returns false when there's no peer pool, no peer
+// owns the container, or the peer call fails — the caller then reports its
+// local error.
+func (s *ContainerServer) forwardContainerRequest(ctx context.Context, username, method, path string, body []byte, out proto.Message) bool {
+	if s.peerPool == nil {
+		return false
+	}
+	authToken := extractAuthToken(ctx)
+	peer := s.peerPool.FindContainerPeer(username, authToken)
+	if peer == nil {
+		return false
+	}
+	respBody, statusCode, err := peer.ForwardRequest(method, path, authToken, body)
+	if err != nil || statusCode >= 400 {
+		return false
+	}
+	return protojson.Unmarshal(respBody, out) == nil
+}
+
+func toProtoSnapshot(snap *incus.SnapshotInfo) *pb.ContainerSnapshot {
+	if snap == nil {
+		return nil
+	}
+	return &pb.ContainerSnapshot{
+		Name:      snap.Name,
+		CreatedAt: snap.CreatedAt.Unix(),
+		SizeBytes: snap.SizeBytes,
+		Stateful:  snap.Stateful,
+	}
+}
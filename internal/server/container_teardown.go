@@ -0,0 +1,493 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/footprintai/containarium/internal/audit"
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Dependent resource kinds, as reported in pb.TeardownItem.Resource.
+const (
+	teardownRoute        = "route"
+	teardownPassthrough  = "passthrough"
+	teardownCollaborator = "collaborator"
+	teardownContainer    = "container"
+	teardownHostAccount  = "host-account"
+	teardownBandwidth    = "bandwidth-limit"
+)
+
+// dependent is one resource that outlives its container unless removed
+// explicitly.
+type dependent struct {
+	resource      string
+	name          string
+	containerName string
+	remove        func(ctx context.Context) error
+}
+
+func (d dependent) item(err error) *pb.TeardownItem {
+	it := &pb.TeardownItem{Resource: d.resource, Name: d.name, ContainerName: d.containerName}
+	if err != nil {
+		it.Error = err.Error()
+	}
+	return it
+}
+
+// teardownReport collects what a teardown removed and what it couldn't.
+type teardownReport struct {
+	removed    []*pb.TeardownItem
+	leftBehind []*pb.TeardownItem
+}
+
+// teardownPlan is what deleting a container would remove, enumerated up
+// front so a dry run can report it and a real run can audit it before
+// anything changes.
+type teardownPlan struct {
+	containerName string
+	username      string
+	// before are removed while the container still exists, after once it
+	// is gone.
+	before []dependent
+	after  []dependent
+	// unlisted are registries that could not be enumerated; only a forced
+	// plan has any.
+	unlisted []*pb.TeardownItem
+}
+
+// items lists the plan in teardown order, the container itself included.
+func (p *teardownPlan) items() []*pb.TeardownItem {
+	out := make([]*pb.TeardownItem, 0, len(p.before)+len(p.after)+1)
+	for _, d := range p.before {
+		out = append(out, d.item(nil))
+	}
+	out = append(out, &pb.TeardownItem{Resource: teardownContainer, Name: p.containerName, ContainerName: p.containerName})
+	for _, d := range p.after {
+		out = append(out, d.item(nil))
+	}
+	return out
+}
+
+// teardownContainer deletes a container together with everything that
+// depends on it, in order:
+//
+//  1. Caddy routes (and their TLS subjects) and passthrough routes, so
+//     nothing forwards to the box's IP once it is gone — or to whichever
+//     box gets that IP next.
+//  2. Collaborators, while the container still exists for their in-box
+//     accounts to be removed.
+//  3. The container itself, via destroy.
+//  4. The host (jump-server) account. sshpiper's keysync on the sentinel
+//     drops the user's directory once the account stops being listed.
+//
+// A dependent that fails in step 1 or 2 aborts the delete before the
+// container is touched, unless force is set, in which case it is recorded
+// as left behind and the teardown moves on. Once destroy has succeeded the
+// delete has happened, so a failure in step 4 never turns it into an
+// error. The plan and every step are logged and audited.
+func (s *ContainerServer) teardownContainer(ctx context.Context, containerName, username string, force bool, destroy func() error) (*teardownReport, error) {
+	plan, err := s.planTeardown(ctx, containerName, username, force)
+	if err != nil {
+		return &teardownReport{}, err
+	}
+	s.auditTeardownPlan(ctx, plan, false)
+	return s.executeTeardown(ctx, plan, force, destroy)
+}
+
+// planTeardown enumerates the dependents of containerName without touching
+// them. A registry that can't be listed fails the plan unless force is set.
+func (s *ContainerServer) planTeardown(ctx context.Context, containerName, username string, force bool) (*teardownPlan, error) {
+	plan := &teardownPlan{containerName: containerName, username: username}
+	var listErrs []*pb.TeardownItem
+	plan.before, listErrs = s.containerDependents(ctx, containerName, username)
+	for _, it := range listErrs {
+		if !force {
+			return nil, fmt.Errorf("teardown could not enumerate %s for %s: %s (the container was not deleted; use --force to continue past it)", it.Resource, containerName, it.Error)
+		}
+		plan.unlisted = append(plan.unlisted, it)
+	}
+	plan.after = []dependent{s.hostAccountDependent(username, containerName)}
+	if s.shaper != nil {
+		switch _, err := s.shaper.GetLimit(ctx, containerName); {
+		case err == nil:
+			plan.after = append(plan.after, s.bandwidthLimitDependent(containerName))
+		case !errors.Is(err, network.ErrBandwidthLimitNotFound):
+			log.Printf("Warning: failed to look up bandwidth limit of %s: %v", containerName, err)
+		}
+	}
+	return plan, nil
+}
+
+// executeTeardown carries out plan with destroy as the container step.
+func (s *ContainerServer) executeTeardown(ctx context.Context, plan *teardownPlan, force bool, destroy func() error) (*teardownReport, error) {
+	rep := &teardownReport{leftBehind: plan.unlisted}
+	for _, d := range plan.before {
+		if err := s.removeDependent(ctx, d, rep); err != nil && !force {
+			return rep, fmt.Errorf("teardown stopped at %s %s: %w (the container was not deleted; use --force to continue past it)", d.resource, d.name, err)
+		}
+	}
+
+	if err := destroy(); err != nil {
+		return rep, err
+	}
+	s.auditTeardown(ctx, dependent{resource: teardownContainer, name: plan.containerName, containerName: plan.containerName}.item(nil))
+
+	// Past this point the container is gone: failures are reported, not
+	// returned.
+	for _, d := range plan.after {
+		_ = s.removeDependent(ctx, d, rep)
+	}
+	return rep, nil
+}
+
+// removeDependent removes d, recording the outcome in rep, the log and the
+// audit trail.
+func (s *ContainerServer) removeDependent(ctx context.Context, d dependent, rep *teardownReport) error {
+	err := d.remove(ctx)
+	it := d.item(err)
+	if err != nil {
+		log.Printf("[delete-cascade] remove %s %s of %s failed: %v", d.resource, d.name, d.containerName, err)
+		rep.leftBehind = append(rep.leftBehind, it)
+	} else {
+		log.Printf("[delete-cascade] removed %s %s of %s", d.resource, d.name, d.containerName)
+		rep.removed = append(rep.removed, it)
+	}
+	s.auditTeardown(ctx, it)
+	return err
+}
+
+// containerDependents enumerates the routes, passthrough routes and
+// collaborators that belong to containerName, in teardown order. A registry
+// that can't be listed comes back as an item in the second return value.
+func (s *ContainerServer) containerDependents(ctx context.Context, containerName, username string) ([]dependent, []*pb.TeardownItem) {
+	var deps []dependent
+	var listErrs []*pb.TeardownItem
+
+	if s.routeStore != nil {
+		routes, err := s.routeStore.ListByContainer(ctx, containerName)
+		if err != nil {
+			listErrs = append(listErrs, &pb.TeardownItem{Resource: teardownRoute, ContainerName: containerName, Error: err.Error()})
+		}
+		for _, r := range routes {
+			deps = append(deps, s.routeDependent(r.FullDomain, containerName))
+		}
+	}
+
+	if s.passthroughStore != nil {
+		records, err := s.passthroughStore.List(ctx, false)
+		if err != nil {
+			listErrs = append(listErrs, &pb.TeardownItem{Resource: teardownPassthrough, ContainerName: containerName, Error: err.Error()})
+		}
+		for _, r := range records {
+			if r.ContainerName == containerName {
+				deps = append(deps, s.passthroughDependent(r.ExternalPort, r.Protocol, containerName))
+			}
+		}
+	}
+
+	if s.collaboratorManager != nil {
+		collabs, err := s.collaboratorManager.ListCollaborators(username)
+		if err != nil {
+			listErrs = append(listErrs, &pb.TeardownItem{Resource: teardownCollaborator, ContainerName: containerName, Error: err.Error()})
+		}
+		for _, c := range collabs {
+			deps = append(deps, s.collaboratorDependent(username, c.CollaboratorUsername, containerName))
+		}
+	}
+
+	return deps, listErrs
+}
+
+// routeDependent drops a route from the store — RouteSyncJob reaps the
+// Caddy entry from it — and stops Caddy renewing its certificate.
+func (s *ContainerServer) routeDependent(fullDomain, containerName string) dependent {
+	return dependent{
+		resource:      teardownRoute,
+		name:          fullDomain,
+		containerName: containerName,
+		remove: func(ctx context.Context) error {
+			if err := s.routeStore.Delete(ctx, fullDomain); err != nil {
+				return err
+			}
+			if s.proxyManager != nil {
+				if err := s.proxyManager.RemoveTLSSubject(fullDomain); err != nil {
+					return fmt.Errorf("route removed but TLS subject was not: %w", err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// passthroughDependent drops a passthrough route from the store; the
+// passthrough sync job removes its iptables rules.
+func (s *ContainerServer) passthroughDependent(port int, protocol, containerName string) dependent {
+	return dependent{
+		resource:      teardownPassthrough,
+		name:          fmt.Sprintf("%d/%s", port, protocol),
+		containerName: containerName,
+		remove: func(ctx context.Context) error {
+			return s.passthroughStore.Delete(ctx, port, protocol)
+		},
+	}
+}
+
+// bandwidthLimitDependent drops a container's bandwidth limit, so a new
+// container that reuses the name starts unshaped.
+func (s *ContainerServer) bandwidthLimitDependent(containerName string) dependent {
+	return dependent{
+		resource:      teardownBandwidth,
+		name:          containerName,
+		containerName: containerName,
+		remove: func(ctx context.Context) error {
+			return s.shaper.ClearLimit(ctx, containerName)
+		},
+	}
+}
+
+func (s *ContainerServer) collaboratorDependent(owner, collaboratorUsername, containerName string) dependent {
+	return dependent{
+		resource:      teardownCollaborator,
+		name:          collaboratorUsername,
+		containerName: containerName,
+		remove: func(ctx context.Context) error {
+			return s.collaboratorManager.RemoveCollaborator(owner, collaboratorUsername)
+		},
+	}
+}
+
+func (s *ContainerServer) hostAccountDependent(account, containerName string) dependent {
+	return dependent{
+		resource:      teardownHostAccount,
+		name:          account,
+		containerName: containerName,
+		remove: func(ctx context.Context) error {
+			return s.deleteHostAccount(account)
+		},
+	}
+}
+
+// deleteHostAccount removes a jump-server account (idempotent: a missing
+// account is not an error).
+func (s *ContainerServer) deleteHostAccount(account string) error {
+	if s.hostAccountDeleter != nil {
+		return s.hostAccountDeleter(account)
+	}
+	return container.DeleteJumpServerAccount(account, false)
+}
+
+// auditTeardown records one teardown step. Best-effort, like the other
+// audit writers.
+func (s *ContainerServer) auditTeardown(ctx context.Context, it *pb.TeardownItem) {
+	if s.auditStore == nil {
+		return
+	}
+	subject, _, _ := auth.SubjectFromGRPCContext(ctx)
+	if subject == "" {
+		subject = "_unknown"
+	}
+	outcome := "removed"
+	if it.Error != "" {
+		outcome = "failed"
+	}
+	payload, _ := json.Marshal(map[string]string{
+		"container": it.ContainerName,
+		"outcome":   outcome,
+		"error":     it.Error,
+	})
+	if err := s.auditStore.Log(ctx, &audit.AuditEntry{
+		Username:     subject,
+		Action:       "container.teardown",
+		ResourceType: it.Resource,
+		ResourceID:   it.Name,
+		Detail:       string(payload),
+	}); err != nil {
+		log.Printf("[delete-cascade] audit %s %s: %v", it.Resource, it.Name, err)
+	}
+}
+
+// auditTeardownPlan records what a delete is about to remove — or, for a
+// dry run, would have — so the audit trail shows the intent next to the
+// per-step outcomes.
+func (s *ContainerServer) auditTeardownPlan(ctx context.Context, plan *teardownPlan, dryRun bool) {
+	if s.auditStore == nil {
+		return
+	}
+	subject, _, _ := auth.SubjectFromGRPCContext(ctx)
+	if subject == "" {
+		subject = "_unknown"
+	}
+	steps := make([]string, 0, len(plan.before)+len(plan.after)+1)
+	for _, it := range plan.items() {
+		steps = append(steps, it.Resource+" "+it.Name)
+	}
+	unlisted := make([]string, 0, len(plan.unlisted))
+	for _, it := range plan.unlisted {
+		unlisted = append(unlisted, it.Resource)
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"dry_run":  dryRun,
+		"steps":    steps,
+		"unlisted": unlisted,
+	})
+	if err := s.auditStore.Log(ctx, &audit.AuditEntry{
+		Username:     subject,
+		Action:       "container.teardown.plan",
+		ResourceType: teardownContainer,
+		ResourceID:   plan.containerName,
+		Detail:       string(payload),
+	}); err != nil {
+		log.Printf("[delete-cascade] audit plan for %s: %v", plan.containerName, err)
+	}
+}
+
+// GarbageCollect finds dependents whose container no longer exists — left
+// behind by deletes that predate the teardown pipeline, or by a forced
+// delete that couldn't remove them — and removes them unless dry_run is set.
+func (s *ContainerServer) GarbageCollect(ctx context.Context, req *pb.GarbageCollectRequest) (*pb.GarbageCollectResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.collectGarbage(ctx, req.DryRun)
+}
+
+// collectGarbage is GarbageCollect without the authorization check, for
+// the daemon's orphan-gc job.
+func (s *ContainerServer) collectGarbage(ctx context.Context, dryRun bool) (*pb.GarbageCollectResponse, error) {
+	existing, err := s.existingContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orphans, err := s.orphanedDependents(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GarbageCollectResponse{DryRun: dryRun}
+	for _, d := range orphans {
+		if dryRun {
+			resp.Orphans = append(resp.Orphans, d.item(nil))
+			continue
+		}
+		rep := &teardownReport{}
+		_ = s.removeDependent(ctx, d, rep)
+		resp.Orphans = append(resp.Orphans, append(rep.removed, rep.leftBehind...)...)
+	}
+	return resp, nil
+}
+
+// existingContainers returns the names of every container this daemon can
+// see: its own plus those on peers. Refuses when a peer is unreachable,
+// since its containers' dependents would otherwise look orphaned.
+func (s *ContainerServer) existingContainers(ctx context.Context) (map[string]bool, error) {
+	bb := s.boxes()
+	statuses, err := bb.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	existing := make(map[string]bool, len(statuses))
+	for _, st := range statuses {
+		existing[st.Ref.Name] = true
+		// On k8s the substrate name is the pod, which routes never
+		// reference; a tenant box's dependents record
+		// "<tenant>-container". Core boxes have no tenant alias.
+		if bb.Kind() == box.KindK8s && !st.IsCore && st.Ref.Tenant != "" {
+			existing[st.Ref.Tenant+"-container"] = true
+		}
+	}
+
+	if s.peerPool != nil {
+		for _, p := range s.peerPool.Peers() {
+			if !p.Healthy {
+				return nil, status.Errorf(codes.FailedPrecondition, "peer %s is unreachable; can't tell which dependents belong to its containers", p.ID)
+			}
+		}
+		for _, c := range s.peerPool.ListContainers(extractAuthToken(ctx)) {
+			existing[c.Name] = true
+		}
+	}
+	return existing, nil
+}
+
+// orphanedDependents cross-references every registry against existing.
+func (s *ContainerServer) orphanedDependents(ctx context.Context, existing map[string]bool) ([]dependent, error) {
+	var orphans []dependent
+
+	if s.routeStore != nil {
+		routes, err := s.routeStore.List(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list routes: %w", err)
+		}
+		for _, r := range routes {
+			if r.ContainerName != "" && !existing[r.ContainerName] {
+				orphans = append(orphans, s.routeDependent(r.FullDomain, r.ContainerName))
+			}
+		}
+	}
+
+	if s.passthroughStore != nil {
+		records, err := s.passthroughStore.List(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list passthrough routes: %w", err)
+		}
+		for _, r := range records {
+			if r.ContainerName != "" && !existing[r.ContainerName] {
+				orphans = append(orphans, s.passthroughDependent(r.ExternalPort, r.Protocol, r.ContainerName))
+			}
+		}
+	}
+
+	if s.collaboratorManager != nil {
+		collabs, err := s.collaboratorManager.GetStore().ListAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list collaborators: %w", err)
+		}
+		for _, c := range collabs {
+			if !existing[c.ContainerName] {
+				orphans = append(orphans, s.collaboratorDependent(c.OwnerUsername, c.CollaboratorUsername, c.ContainerName))
+			}
+		}
+	}
+
+	if _, isK8s := s.k8sBoxes(); !isK8s {
+		accounts, err := s.listHostAccounts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list host accounts: %w", err)
+		}
+		for _, a := range accounts {
+			if name := hostAccountContainer(a); !existing[name] {
+				orphans = append(orphans, s.hostAccountDependent(a, name))
+			}
+		}
+	}
+
+	return orphans, nil
+}
+
+func (s *ContainerServer) listHostAccounts() ([]string, error) {
+	if s.hostAccountLister != nil {
+		return s.hostAccountLister()
+	}
+	return container.ListJumpServerAccounts()
+}
+
+// hostAccountContainer maps a jump-server account to the container it
+// reaches: "alice" → "alice-container", and a collaborator account
+// "alice-container-bob" → "alice-container".
+func hostAccountContainer(account string) string {
+	if i := strings.Index(account, "-container-"); i > 0 {
+		return account[:i] + "-container"
+	}
+	return account + "-container"
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/footprintai/containarium/internal/app"
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/internal/metrics/platformstats"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stuckPassthroughStore refuses to delete, standing in for a registry whose
+// database is unreachable mid-teardown.
+type stuckPassthroughStore struct{ *memPassthroughStore }
+
+func (stuckPassthroughStore) Delete(context.Context, int, string) error {
+	return errors.New("connection refused")
+}
+
+type teardownFixture struct {
+	srv          *ContainerServer
+	mock         *incustest.MockBackend
+	routes       *memoryRouteStore
+	passthrough  *memPassthroughStore
+	hostAccounts []string
+	deleted      []string
+}
+
+func newTeardownFixture(t *testing.T, containers ...string) *teardownFixture {
+	t.Helper()
+	f := &teardownFixture{
+		mock:        incustest.NewMockBackend(),
+		routes:      &memoryRouteStore{},
+		passthrough: newMemPassthroughStore(),
+	}
+	for _, name := range containers {
+		f.mock.Containers[name] = &incus.ContainerInfo{Name: name, State: "Stopped"}
+	}
+	f.srv = &ContainerServer{
+		manager:          container.NewWithBackend(f.mock),
+		routeStore:       f.routes,
+		passthroughStore: f.passthrough,
+		emitter:          events.NewEmitter(events.NewBus()),
+		pendingCreations: make(map[string]*PendingCreation),
+		platformStats:    platformstats.New(),
+		hostAccountDeleter: func(account string) error {
+			f.deleted = append(f.deleted, account)
+			return nil
+		},
+		hostAccountLister: func() ([]string, error) { return f.hostAccounts, nil },
+	}
+	return f
+}
+
+func (f *teardownFixture) seed(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+	f.routes.routes = []*app.RouteRecord{
+		{FullDomain: "alice.example.test", ContainerName: "alice-container"},
+		{FullDomain: "bob.example.test", ContainerName: "bob-container"},
+	}
+	for _, r := range []*network.PassthroughRecord{
+		{ExternalPort: 5432, Protocol: "tcp", ContainerName: "alice-container"},
+		{ExternalPort: 2222, Protocol: "tcp", ContainerName: "bob-container"},
+	} {
+		if err := f.passthrough.Save(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDeleteContainer_TearsDownDependents(t *testing.T) {
+	f := newTeardownFixture(t, "alice-container", "bob-container")
+	f.seed(t)
+
+	resp, err := f.srv.DeleteContainer(tenantCtx("alice"), &pb.DeleteContainerRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("DeleteContainer: %v", err)
+	}
+	if len(resp.LeftBehind) != 0 {
+		t.Errorf("left behind = %v, want none", resp.LeftBehind)
+	}
+	var order []string
+	for _, it := range resp.Removed {
+		order = append(order, it.Resource+":"+it.Name)
+	}
+	want := []string{"route:alice.example.test", "passthrough:5432/tcp", "host-account:alice"}
+	if len(order) != len(want) {
+		t.Fatalf("removed = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("removed = %v, want %v", order, want)
+		}
+	}
+
+	if _, ok := f.mock.Containers["alice-container"]; ok {
+		t.Error("container still exists")
+	}
+	if len(f.routes.routes) != 1 || f.routes.routes[0].ContainerName != "bob-container" {
+		t.Errorf("routes after delete = %v, want only bob's", f.routes.routes)
+	}
+	if _, err := f.passthrough.GetByPortProtocol(context.Background(), 2222, "tcp"); err != nil {
+		t.Errorf("bob's passthrough route was removed: %v", err)
+	}
+}
+
+func TestDeleteContainer_DryRunPlansWithoutRemoving(t *testing.T) {
+	f := newTeardownFixture(t, "alice-container", "bob-container")
+	f.seed(t)
+
+	resp, err := f.srv.DeleteContainer(tenantCtx("alice"), &pb.DeleteContainerRequest{Username: "alice", DryRun: true})
+	if err != nil {
+		t.Fatalf("DeleteContainer: %v", err)
+	}
+	if !resp.DryRun {
+		t.Error("response does not echo dry_run")
+	}
+	var plan []string
+	for _, it := range resp.Removed {
+		plan = append(plan, it.Resource+":"+it.Name)
+	}
+	want := []string{"route:alice.example.test", "passthrough:5432/tcp", "container:alice-container", "host-account:alice"}
+	if len(plan) != len(want) {
+		t.Fatalf("plan = %v, want %v", plan, want)
+	}
+	for i := range want {
+		if plan[i] != want[i] {
+			t.Fatalf("plan = %v, want %v", plan, want)
+		}
+	}
+
+	if _, ok := f.mock.Containers["alice-container"]; !ok {
+		t.Error("dry run deleted the container")
+	}
+	if len(f.routes.routes) != 2 || len(f.deleted) != 0 {
+		t.Errorf("dry run removed dependents: routes=%v host accounts=%v", f.routes.routes, f.deleted)
+	}
+	if _, err := f.passthrough.GetByPortProtocol(context.Background(), 5432, "tcp"); err != nil {
+		t.Errorf("dry run removed alice's passthrough route: %v", err)
+	}
+
+	if _, err := f.srv.DeleteContainer(tenantCtx("carol"), &pb.DeleteContainerRequest{Username: "carol", DryRun: true}); err == nil {
+		t.Error("dry run for a missing container succeeded")
+	}
+}
+
+func TestDeleteContainer_FailedDependentAbortsWithoutForce(t *testing.T) {
+	f := newTeardownFixture(t, "alice-container")
+	f.seed(t)
+	f.srv.passthroughStore = stuckPassthroughStore{f.passthrough}
+
+	if _, err := f.srv.DeleteContainer(tenantCtx("alice"), &pb.DeleteContainerRequest{Username: "alice"}); err == nil {
+		t.Fatal("DeleteContainer succeeded, want the stuck passthrough route to abort it")
+	}
+	if _, ok := f.mock.Containers["alice-container"]; !ok {
+		t.Error("container was deleted despite the aborted teardown")
+	}
+	if len(f.deleted) != 0 {
+		t.Errorf("host accounts deleted = %v, want none", f.deleted)
+	}
+}
+
+func TestDeleteContainer_ForceContinuesAndReportsLeftovers(t *testing.T) {
+	f := newTeardownFixture(t, "alice-container")
+	f.seed(t)
+	f.srv.passthroughStore = stuckPassthroughStore{f.passthrough}
+
+	resp, err := f.srv.DeleteContainer(tenantCtx("alice"), &pb.DeleteContainerRequest{Username: "alice", Force: true})
+	if err != nil {
+		t.Fatalf("DeleteContainer: %v", err)
+	}
+	if _, ok := f.mock.Containers["alice-container"]; ok {
+		t.Error("container still exists")
+	}
+	if len(resp.LeftBehind) != 1 || resp.LeftBehind[0].Resource != teardownPassthrough || resp.LeftBehind[0].Error == "" {
+		t.Errorf("left behind = %v, want the passthrough route with its error", resp.LeftBehind)
+	}
+	if len(f.deleted) != 1 || f.deleted[0] != "alice" {
+		t.Errorf("host accounts deleted = %v, want [alice]", f.deleted)
+	}
+}
+
+func TestGarbageCollect_RequiresAdmin(t *testing.T) {
+	f := newTeardownFixture(t)
+	_, err := f.srv.GarbageCollect(tenantCtx("alice"), &pb.GarbageCollectRequest{DryRun: true})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("GarbageCollect as tenant: got %v, want PermissionDenied", err)
+	}
+}
+
+func TestGarbageCollect_FindsAndRemovesOrphans(t *testing.T) {
+	// bob's container is gone but his route, passthrough route and host
+	// account remain; alice and her collaborator account are live.
+	f := newTeardownFixture(t, "alice-container")
+	f.seed(t)
+	f.hostAccounts = []string{"alice", "alice-container-carol", "bob"}
+
+	dry, err := f.srv.GarbageCollect(adminCtx(), &pb.GarbageCollectRequest{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(dry.Orphans) != 3 {
+		t.Fatalf("dry-run orphans = %v, want bob's route, passthrough route and account", dry.Orphans)
+	}
+	for _, o := range dry.Orphans {
+		if o.ContainerName != "bob-container" {
+			t.Errorf("orphan %s %s belongs to %s, want bob-container", o.Resource, o.Name, o.ContainerName)
+		}
+	}
+	if len(f.routes.routes) != 2 || len(f.deleted) != 0 {
+		t.Fatal("dry run removed something")
+	}
+
+	if _, err := f.srv.GarbageCollect(adminCtx(), &pb.GarbageCollectRequest{}); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(f.routes.routes) != 1 || f.routes.routes[0].ContainerName != "alice-container" {
+		t.Errorf("routes after gc = %v, want only alice's", f.routes.routes)
+	}
+	if len(f.deleted) != 1 || f.deleted[0] != "bob" {
+		t.Errorf("host accounts deleted = %v, want [bob]", f.deleted)
+	}
+}
+
+func TestGarbageCollect_KeepsCoreAndCustomNamedBoxes(t *testing.T) {
+	// A core container and a box not named "<tenant>-container" are live;
+	// their routes must not look orphaned.
+	f := newTeardownFixture(t)
+	f.mock.Containers["containarium-core-caddy"] = &incus.ContainerInfo{Name: "containarium-core-caddy", State: "Running", Role: incus.RoleCaddy}
+	f.mock.Containers["web-app"] = &incus.ContainerInfo{Name: "web-app", Username: "alice", State: "Running"}
+	f.routes.routes = []*app.RouteRecord{
+		{FullDomain: "console.example.test", ContainerName: "containarium-core-caddy"},
+		{FullDomain: "app.example.test", ContainerName: "web-app"},
+		{FullDomain: "bob.example.test", ContainerName: "bob-container"},
+	}
+	if err := f.passthrough.Save(context.Background(), &network.PassthroughRecord{ExternalPort: 8443, Protocol: "tcp", ContainerName: "web-app"}); err != nil {
+		t.Fatal(err)
+	}
+
+	dry, err := f.srv.GarbageCollect(adminCtx(), &pb.GarbageCollectRequest{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(dry.Orphans) != 1 || dry.Orphans[0].ContainerName != "bob-container" {
+		t.Errorf("orphans = %v, want only bob's route", dry.Orphans)
+	}
+}
+
+func TestHostAccountContainer(t *testing.T) {
+	for account, want := range map[string]string{
+		"alice":                 "alice-container",
+		"alice-container-carol": "alice-container",
+		"web-app":               "web-app-container",
+	} {
+		if got := hostAccountContainer(account); got != want {
+			t.Errorf("hostAccountContainer(%q) = %q, want %q", account, got, want)
+		}
+	}
+}
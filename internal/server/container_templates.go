@@ -0,0 +1,450 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/container"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// containerTemplatesKey is the daemon_config key the API-managed
+// templates are persisted under, all of them as one JSON document.
+const containerTemplatesKey = "container_templates"
+
+// maxContainerTemplateRoutes caps the routes one template adds per box.
+const maxContainerTemplateRoutes = 16
+
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// containerTemplates is the template registry behind CreateContainer's
+// template field: read-only presets from the daemon's
+// --container-templates file, plus those managed through the API. The
+// API set is loaded from the daemon config store on first use, the same
+// way the metrics export config is, so a store wired late in startup is
+// still read.
+type containerTemplates struct {
+	mu     sync.Mutex
+	file   map[string]*pb.ContainerTemplate
+	api    map[string]*pb.ContainerTemplate
+	loaded bool
+}
+
+// templateRouteAdder adds the proxy routes a template declares; the
+// NetworkServer in production.
+type templateRouteAdder interface {
+	AddRoute(ctx context.Context, req *pb.AddRouteRequest) (*pb.AddRouteResponse, error)
+}
+
+// SetContainerTemplates installs the templates from the daemon's
+// --container-templates file. They are read-only through the API.
+func (s *ContainerServer) SetContainerTemplates(templates []*pb.ContainerTemplate) {
+	s.templates.mu.Lock()
+	defer s.templates.mu.Unlock()
+	s.templates.file = make(map[string]*pb.ContainerTemplate, len(templates))
+	for _, t := range templates {
+		t = proto.Clone(t).(*pb.ContainerTemplate)
+		t.ReadOnly = true
+		s.templates.file[t.Name] = t
+	}
+}
+
+// SetTemplateRouteAdder wires where template routes are added. Without
+// one, templates with routes create the box and log that the routes
+// were skipped.
+func (s *ContainerServer) SetTemplateRouteAdder(r templateRouteAdder) {
+	s.templateRoutes = r
+}
+
+// loadTemplatesLocked reads the API-managed templates from the config
+// store once it is wired. A store that can't be read or holds a document
+// that doesn't parse is an error and leaves the set unloaded, so the
+// next call retries and a save never overwrites templates it couldn't
+// read. Callers hold s.templates.mu.
+func (s *ContainerServer) loadTemplatesLocked(ctx context.Context) error {
+	if s.templates.loaded {
+		return nil
+	}
+	if s.daemonConfigStore == nil {
+		if s.templates.api == nil {
+			s.templates.api = make(map[string]*pb.ContainerTemplate)
+		}
+		return nil
+	}
+	raw, err := s.daemonConfigStore.Get(ctx, containerTemplatesKey)
+	if err != nil {
+		return fmt.Errorf("failed to read container templates: %w", err)
+	}
+	api := make(map[string]*pb.ContainerTemplate)
+	if raw != "" {
+		var stored pb.ListContainerTemplatesResponse
+		if err := protojson.Unmarshal([]byte(raw), &stored); err != nil {
+			return fmt.Errorf("failed to parse stored container templates: %w", err)
+		}
+		for _, t := range stored.Templates {
+			api[t.Name] = t
+		}
+	}
+	s.templates.api = api
+	s.templates.loaded = true
+	return nil
+}
+
+// templatesLoadError is the status a caller gets when the API-managed
+// templates can't be loaded.
+func templatesLoadError(err error) error {
+	log.Printf("Warning: %v", err)
+	return status.Error(codes.Internal, "failed to load container templates")
+}
+
+// saveTemplatesLocked persists api, the API-managed set after a change.
+func (s *ContainerServer) saveTemplatesLocked(ctx context.Context, api map[string]*pb.ContainerTemplate) error {
+	if s.daemonConfigStore == nil {
+		return nil
+	}
+	doc := &pb.ListContainerTemplatesResponse{}
+	for _, name := range slices.Sorted(maps.Keys(api)) {
+		doc.Templates = append(doc.Templates, api[name])
+	}
+	raw, err := protojson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return s.daemonConfigStore.Set(ctx, containerTemplatesKey, string(raw))
+}
+
+// lookupContainerTemplate returns the named template; file templates
+// shadow API ones.
+func (s *ContainerServer) lookupContainerTemplate(ctx context.Context, name string) (*pb.ContainerTemplate, bool, error) {
+	s.templates.mu.Lock()
+	defer s.templates.mu.Unlock()
+	if err := s.loadTemplatesLocked(ctx); err != nil {
+		return nil, false, templatesLoadError(err)
+	}
+	if t, ok := s.templates.file[name]; ok {
+		return t, true, nil
+	}
+	t, ok := s.templates.api[name]
+	return t, ok, nil
+}
+
+// ListContainerTemplates lists the templates CreateContainer accepts.
+func (s *ContainerServer) ListContainerTemplates(ctx context.Context, req *pb.ListContainerTemplatesRequest) (*pb.ListContainerTemplatesResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	s.templates.mu.Lock()
+	defer s.templates.mu.Unlock()
+	if err := s.loadTemplatesLocked(ctx); err != nil {
+		return nil, templatesLoadError(err)
+	}
+
+	all := maps.Clone(s.templates.api)
+	if all == nil {
+		all = make(map[string]*pb.ContainerTemplate)
+	}
+	maps.Copy(all, s.templates.file)
+	resp := &pb.ListContainerTemplatesResponse{}
+	for _, name := range slices.Sorted(maps.Keys(all)) {
+		resp.Templates = append(resp.Templates, all[name])
+	}
+	return resp, nil
+}
+
+// GetContainerTemplate returns one template.
+func (s *ContainerServer) GetContainerTemplate(ctx context.Context, req *pb.GetContainerTemplateRequest) (*pb.GetContainerTemplateResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeContainersRead); err != nil {
+		return nil, err
+	}
+	t, ok, err := s.lookupContainerTemplate(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "container template %q not found", req.Name)
+	}
+	return &pb.GetContainerTemplateResponse{Template: t}, nil
+}
+
+// saveContainerTemplate creates or replaces an API-managed template.
+// AdminService.SetContainerTemplate calls it once the caller is checked.
+func (s *ContainerServer) saveContainerTemplate(ctx context.Context, t *pb.ContainerTemplate) (*pb.ContainerTemplate, error) {
+	t = proto.Clone(t).(*pb.ContainerTemplate)
+	t.ReadOnly = false
+	if err := validateContainerTemplate(t); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.templates.mu.Lock()
+	defer s.templates.mu.Unlock()
+	if err := s.loadTemplatesLocked(ctx); err != nil {
+		return nil, templatesLoadError(err)
+	}
+	if _, ok := s.templates.file[t.Name]; ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "container template %q comes from the daemon's --container-templates file and is read-only", t.Name)
+	}
+	api := maps.Clone(s.templates.api)
+	api[t.Name] = t
+	if err := s.saveTemplatesLocked(ctx, api); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save container templates: %v", err)
+	}
+	s.templates.api = api
+	log.Printf("Container template %q saved by %s", t.Name, eventActor(ctx))
+	return t, nil
+}
+
+// deleteContainerTemplate removes an API-managed template.
+// AdminService.DeleteContainerTemplate calls it once the caller is
+// checked.
+func (s *ContainerServer) deleteContainerTemplate(ctx context.Context, name string) error {
+	s.templates.mu.Lock()
+	defer s.templates.mu.Unlock()
+	if err := s.loadTemplatesLocked(ctx); err != nil {
+		return templatesLoadError(err)
+	}
+	if _, ok := s.templates.file[name]; ok {
+		return status.Errorf(codes.FailedPrecondition, "container template %q comes from the daemon's --container-templates file and is read-only", name)
+	}
+	if _, ok := s.templates.api[name]; !ok {
+		return status.Errorf(codes.NotFound, "container template %q not found", name)
+	}
+	api := maps.Clone(s.templates.api)
+	delete(api, name)
+	if err := s.saveTemplatesLocked(ctx, api); err != nil {
+		return status.Errorf(codes.Internal, "failed to save container templates: %v", err)
+	}
+	s.templates.api = api
+	log.Printf("Container template %q deleted by %s", name, eventActor(ctx))
+	return nil
+}
+
+// expandContainerTemplate fills the fields req leaves empty from the
+// template it names and returns that template (nil when req names
+// none). The name is cleared from req: what follows sees only the
+// expanded settings (see templateForwardRequest for a forward to a
+// peer).
+func (s *ContainerServer) expandContainerTemplate(ctx context.Context, req *pb.CreateContainerRequest) (*pb.ContainerTemplate, error) {
+	if req.Template == "" {
+		return nil, nil
+	}
+	t, ok, err := s.lookupContainerTemplate(ctx, req.Template)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown container template %q (see ListContainerTemplates)", req.Template)
+	}
+	applyContainerTemplate(req, t)
+	req.Template = ""
+	return t, nil
+}
+
+// applyContainerTemplate sets what req leaves empty from t: request
+// fields take precedence, labels merge with the request's winning, and
+// enable_podman is on if either turns it on (an unset bool can't be told
+// from false). A template's cloud-init has no request field to land in:
+// the create reads it from the template, so only an admin decides what
+// runs on a box's first boot.
+func applyContainerTemplate(req *pb.CreateContainerRequest, t *pb.ContainerTemplate) {
+	if r := t.Resources; r != nil {
+		if req.Resources == nil {
+			req.Resources = &pb.ResourceLimits{}
+		}
+		req.Resources.Cpu = cmp.Or(req.Resources.Cpu, r.Cpu)
+		req.Resources.Memory = cmp.Or(req.Resources.Memory, r.Memory)
+		req.Resources.Disk = cmp.Or(req.Resources.Disk, r.Disk)
+		req.Resources.StorageClass = cmp.Or(req.Resources.StorageClass, r.StorageClass)
+	}
+	req.Image = cmp.Or(req.Image, t.Image)
+	if len(req.SshKeys) == 0 {
+		req.SshKeys = slices.Clone(t.SshKeys)
+	}
+	req.EnablePodman = req.EnablePodman || t.EnablePodman
+	req.Stack = cmp.Or(req.Stack, t.Stack)
+	if len(t.Labels) > 0 {
+		labels := maps.Clone(t.Labels)
+		maps.Copy(labels, req.Labels)
+		req.Labels = labels
+	}
+}
+
+// templateForwardRequest is req as forwarded to the peer creating the
+// box. The expanded settings travel in req, except t's cloud-init, which
+// has no request field; for a template with one the peer is sent its
+// name to expand again (request fields still win, so the expansion
+// already done is unchanged).
+func templateForwardRequest(req *pb.CreateContainerRequest, t *pb.ContainerTemplate) *pb.CreateContainerRequest {
+	if t.GetCloudInit() == "" {
+		return req
+	}
+	fwd := proto.Clone(req).(*pb.CreateContainerRequest)
+	fwd.Template = t.Name
+	return fwd
+}
+
+// templateDetails are the created event's details for a box from t,
+// which the audit log records.
+func templateDetails(t *pb.ContainerTemplate) map[string]string {
+	if t == nil {
+		return nil
+	}
+	return map[string]string{"template": t.Name}
+}
+
+// addTemplateRoutes adds t's routes to the new box. Best-effort, like a
+// recipe's: a route that fails is logged and the box stays.
+func (s *ContainerServer) addTemplateRoutes(t *pb.ContainerTemplate, username, containerName, ip string) {
+	if t == nil || len(t.Routes) == 0 {
+		return
+	}
+	if s.templateRoutes == nil || ip == "" {
+		log.Printf("Warning: template %q routes not added to %s: routing is unavailable or the box has no IP", t.Name, containerName)
+		return
+	}
+	// The template's author (an admin) chose these routes; the tenant
+	// creating the box needn't be allowed to add routes.
+	ctx := auth.ContextWithSystemIdentity(context.Background())
+	for _, r := range t.Routes {
+		_, err := s.templateRoutes.AddRoute(ctx, &pb.AddRouteRequest{
+			Domain:        username + "-" + r.Subdomain,
+			TargetIp:      ip,
+			TargetPort:    r.ContainerPort,
+			ContainerName: containerName,
+			Description:   "template:" + t.Name,
+		})
+		if err != nil {
+			log.Printf("Warning: failed to add template %q route %s-%s -> %s:%d: %v", t.Name, username, r.Subdomain, containerName, r.ContainerPort, err)
+		}
+	}
+}
+
+// validateContainerTemplate checks a template against the create
+// request's bounds, so a box made from it can't fail on them.
+func validateContainerTemplate(t *pb.ContainerTemplate) error {
+	if !templateNamePattern.MatchString(t.Name) {
+		return fmt.Errorf("template name %q must be 1-63 lowercase letters, digits or dashes", t.Name)
+	}
+	if n := len(t.SshKeys); n > MaxSSHKeys {
+		return fmt.Errorf("ssh_keys has %d entries, max is %d", n, MaxSSHKeys)
+	}
+	for i, key := range t.SshKeys {
+		if err := container.ValidateSSHPublicKey(key); err != nil {
+			return fmt.Errorf("ssh_keys[%d]: %w", i, err)
+		}
+	}
+	if n := len(t.CloudInit); n > MaxCloudInitLen {
+		return fmt.Errorf("cloud_init is %d bytes, max is %d", n, MaxCloudInitLen)
+	}
+	if n := len(t.Labels); n > MaxLabels {
+		return fmt.Errorf("labels has %d entries, max is %d", n, MaxLabels)
+	}
+	if n := len(t.Routes); n > maxContainerTemplateRoutes {
+		return fmt.Errorf("routes has %d entries, max is %d", n, maxContainerTemplateRoutes)
+	}
+	for i, r := range t.Routes {
+		if !templateNamePattern.MatchString(r.Subdomain) {
+			return fmt.Errorf("routes[%d]: subdomain %q must be lowercase letters, digits or dashes", i, r.Subdomain)
+		}
+		if r.ContainerPort <= 0 || r.ContainerPort > 65535 {
+			return fmt.Errorf("routes[%d]: container_port must be between 1 and 65535", i)
+		}
+	}
+	if t.Image != "" {
+		if err := validateImageRegistry(t.Image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// containerTemplatesFile is the --container-templates file:
+//
+//	templates:
+//	  - name: small
+//	    description: Small dev box
+//	    cpu: "2"
+//	    memory: 2GB
+//	    enable_podman: true
+//	    cloud_init: |
+//	      #cloud-config
+//	      packages: [htop]
+//	    routes:
+//	      - subdomain: web
+//	        port: 3000
+type containerTemplatesFile struct {
+	Templates []struct {
+		Name         string            `yaml:"name"`
+		Description  string            `yaml:"description"`
+		CPU          string            `yaml:"cpu"`
+		Memory       string            `yaml:"memory"`
+		Disk         string            `yaml:"disk"`
+		StorageClass string            `yaml:"storage_class"`
+		Image        string            `yaml:"image"`
+		SSHKeys      []string          `yaml:"ssh_keys"`
+		EnablePodman bool              `yaml:"enable_podman"`
+		Stack        string            `yaml:"stack"`
+		CloudInit    string            `yaml:"cloud_init"`
+		Labels       map[string]string `yaml:"labels"`
+		Routes       []struct {
+			Subdomain string `yaml:"subdomain"`
+			Port      int32  `yaml:"port"`
+		} `yaml:"routes"`
+	} `yaml:"templates"`
+}
+
+// LoadContainerTemplates reads and validates a --container-templates
+// file.
+func LoadContainerTemplates(path string) ([]*pb.ContainerTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read container templates: %w", err)
+	}
+	var file containerTemplatesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse container templates %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool)
+	templates := make([]*pb.ContainerTemplate, 0, len(file.Templates))
+	for _, e := range file.Templates {
+		t := &pb.ContainerTemplate{
+			Name:         e.Name,
+			Description:  e.Description,
+			Image:        e.Image,
+			SshKeys:      e.SSHKeys,
+			EnablePodman: e.EnablePodman,
+			Stack:        e.Stack,
+			CloudInit:    e.CloudInit,
+			Labels:       e.Labels,
+		}
+		if e.CPU != "" || e.Memory != "" || e.Disk != "" || e.StorageClass != "" {
+			t.Resources = &pb.ResourceLimits{Cpu: e.CPU, Memory: e.Memory, Disk: e.Disk, StorageClass: e.StorageClass}
+		}
+		for _, r := range e.Routes {
+			t.Routes = append(t.Routes, &pb.ContainerTemplateRoute{Subdomain: r.Subdomain, ContainerPort: r.Port})
+		}
+		if err := validateContainerTemplate(t); err != nil {
+			return nil, fmt.Errorf("container template %q in %s: %w", e.Name, path, err)
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("container template %q is defined twice in %s", t.Name, path)
+		}
+		seen[t.Name] = true
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
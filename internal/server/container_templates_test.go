@@ -0,0 +1,245 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestApplyContainerTemplate_RequestFieldsWin(t *testing.T) {
+	tmpl := &pb.ContainerTemplate{
+		Name:         "web",
+		Resources:    &pb.ResourceLimits{Cpu: "2", Memory: "2GB", Disk: "20GB"},
+		Image:        "images:debian/12",
+		SshKeys:      []string{"ssh-ed25519 AAAA template"},
+		EnablePodman: true,
+		Stack:        "nodejs",
+		CloudInit:    "#cloud-config\npackages: [htop]\n",
+		Labels:       map[string]string{"tier": "web", "team": "platform"},
+	}
+	req := &pb.CreateContainerRequest{
+		Username:  "alice",
+		Resources: &pb.ResourceLimits{Memory: "8GB"},
+		SshKeys:   []string{"ssh-ed25519 AAAA alice"},
+		Labels:    map[string]string{"team": "alice"},
+	}
+	applyContainerTemplate(req, tmpl)
+
+	if req.Resources.Cpu != "2" || req.Resources.Memory != "8GB" || req.Resources.Disk != "20GB" {
+		t.Errorf("resources = %v, want cpu/disk from the template and memory from the request", req.Resources)
+	}
+	if req.Image != "images:debian/12" || req.Stack != "nodejs" || !req.EnablePodman {
+		t.Errorf("template fields not applied: %v", req)
+	}
+	if len(req.SshKeys) != 1 || req.SshKeys[0] != "ssh-ed25519 AAAA alice" {
+		t.Errorf("ssh_keys = %v, want only the request's", req.SshKeys)
+	}
+	if req.Labels["tier"] != "web" || req.Labels["team"] != "alice" {
+		t.Errorf("labels = %v, want merged with the request winning", req.Labels)
+	}
+	if tmpl.Labels["team"] != "platform" {
+		t.Error("applying the template modified it")
+	}
+}
+
+func TestExpandContainerTemplate(t *testing.T) {
+	srv := &ContainerServer{}
+	srv.SetContainerTemplates([]*pb.ContainerTemplate{{Name: "small", Resources: &pb.ResourceLimits{Cpu: "1"}}})
+	ctx := auth.ContextWithTestSubject(context.Background(), "alice", "user")
+
+	req := &pb.CreateContainerRequest{Username: "alice", Template: "small"}
+	tmpl, err := srv.expandContainerTemplate(ctx, req)
+	if err != nil {
+		t.Fatalf("expandContainerTemplate: %v", err)
+	}
+	if tmpl.GetName() != "small" || req.Resources.GetCpu() != "1" || req.Template != "" {
+		t.Errorf("template %v, request %v", tmpl, req)
+	}
+
+	_, err = srv.expandContainerTemplate(ctx, &pb.CreateContainerRequest{Username: "alice", Template: "huge"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown template: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestTemplateForwardRequest(t *testing.T) {
+	req := &pb.CreateContainerRequest{Username: "alice", Stack: "nodejs"}
+	if got := templateForwardRequest(req, &pb.ContainerTemplate{Name: "plain"}); got.Template != "" {
+		t.Errorf("template without cloud-init: forwarded template = %q, want none", got.Template)
+	}
+	got := templateForwardRequest(req, &pb.ContainerTemplate{Name: "web", CloudInit: "#cloud-config\n"})
+	if got.Template != "web" || got.Stack != "nodejs" {
+		t.Errorf("template with cloud-init: forwarded %v, want the expanded request naming web", got)
+	}
+	if req.Template != "" {
+		t.Error("forwarding modified the request")
+	}
+}
+
+func TestContainerTemplates_AdminCRUDPersists(t *testing.T) {
+	kv := &fakeDaemonConfigKV{}
+	srv := &ContainerServer{daemonConfigStore: kv}
+	srv.SetContainerTemplates([]*pb.ContainerTemplate{{Name: "small"}})
+	admin := NewAdminServer()
+	admin.SetTemplateRegistry(srv)
+	ops := daemonAdminCtx()
+	user := auth.ContextWithTestSubject(context.Background(), "alice", "user")
+
+	web := &pb.ContainerTemplate{Name: "web", Stack: "nodejs", Routes: []*pb.ContainerTemplateRoute{{Subdomain: "app", ContainerPort: 3000}}}
+	for name, ctx := range map[string]context.Context{
+		"user": user,
+		"containers:write only": auth.ContextWithTestSubjectScopes(context.Background(),
+			"ops", []string{auth.RoleAdmin}, []string{auth.ScopeContainersWrite}),
+	} {
+		if _, err := admin.SetContainerTemplate(ctx, &pb.SetContainerTemplateRequest{Template: web}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s set: err = %v, want PermissionDenied", name, err)
+		}
+	}
+	if _, err := admin.SetContainerTemplate(ops, &pb.SetContainerTemplateRequest{Template: web}); err != nil {
+		t.Fatalf("SetContainerTemplate: %v", err)
+	}
+	if _, err := admin.SetContainerTemplate(ops, &pb.SetContainerTemplateRequest{Template: &pb.ContainerTemplate{Name: "small"}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("overwriting a file template: err = %v, want FailedPrecondition", err)
+	}
+	if _, err := admin.SetContainerTemplate(ops, &pb.SetContainerTemplateRequest{Template: &pb.ContainerTemplate{Name: "Bad Name"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid name: err = %v, want InvalidArgument", err)
+	}
+
+	// A restarted daemon sharing the store still has it.
+	restarted := &ContainerServer{daemonConfigStore: kv}
+	restarted.SetContainerTemplates([]*pb.ContainerTemplate{{Name: "small"}})
+	admin.SetTemplateRegistry(restarted)
+	list, err := restarted.ListContainerTemplates(user, &pb.ListContainerTemplatesRequest{})
+	if err != nil {
+		t.Fatalf("ListContainerTemplates: %v", err)
+	}
+	var names []string
+	for _, tmpl := range list.Templates {
+		names = append(names, tmpl.Name)
+	}
+	if strings.Join(names, ",") != "small,web" {
+		t.Errorf("templates = %v, want small,web", names)
+	}
+	if !list.Templates[0].ReadOnly || list.Templates[1].ReadOnly {
+		t.Errorf("read_only = %v/%v, want only the file template read-only", list.Templates[0].ReadOnly, list.Templates[1].ReadOnly)
+	}
+
+	if _, err := admin.DeleteContainerTemplate(user, &pb.DeleteContainerTemplateRequest{Name: "web"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("non-admin delete: err = %v, want PermissionDenied", err)
+	}
+	if _, err := admin.DeleteContainerTemplate(ops, &pb.DeleteContainerTemplateRequest{Name: "small"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("deleting a file template: err = %v, want FailedPrecondition", err)
+	}
+	if _, err := admin.DeleteContainerTemplate(ops, &pb.DeleteContainerTemplateRequest{Name: "web"}); err != nil {
+		t.Fatalf("DeleteContainerTemplate: %v", err)
+	}
+	if _, err := restarted.GetContainerTemplate(user, &pb.GetContainerTemplateRequest{Name: "web"}); status.Code(err) != codes.NotFound {
+		t.Errorf("deleted template: err = %v, want NotFound", err)
+	}
+}
+
+func TestContainerTemplates_WithoutRegistry(t *testing.T) {
+	admin := NewAdminServer()
+	_, err := admin.SetContainerTemplate(daemonAdminCtx(), &pb.SetContainerTemplateRequest{Template: &pb.ContainerTemplate{Name: "web"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("err = %v, want FailedPrecondition", err)
+	}
+}
+
+// TestContainerTemplates_CorruptStore — a stored document that doesn't
+// parse fails reads and writes rather than being treated as empty, so a
+// save can't overwrite the templates it holds.
+func TestContainerTemplates_CorruptStore(t *testing.T) {
+	kv := &fakeDaemonConfigKV{m: map[string]string{containerTemplatesKey: "{not json"}}
+	srv := &ContainerServer{daemonConfigStore: kv}
+	admin := NewAdminServer()
+	admin.SetTemplateRegistry(srv)
+	user := auth.ContextWithTestSubject(context.Background(), "alice", "user")
+
+	if _, err := srv.ListContainerTemplates(user, &pb.ListContainerTemplatesRequest{}); status.Code(err) != codes.Internal {
+		t.Errorf("list: err = %v, want Internal", err)
+	}
+	if _, err := srv.expandContainerTemplate(user, &pb.CreateContainerRequest{Username: "alice", Template: "web"}); status.Code(err) != codes.Internal {
+		t.Errorf("expand: err = %v, want Internal", err)
+	}
+	if _, err := admin.SetContainerTemplate(daemonAdminCtx(), &pb.SetContainerTemplateRequest{Template: &pb.ContainerTemplate{Name: "web"}}); status.Code(err) != codes.Internal {
+		t.Errorf("set: err = %v, want Internal", err)
+	}
+	if raw, _ := kv.Get(context.Background(), containerTemplatesKey); raw != "{not json" {
+		t.Errorf("stored document = %q, want it left alone", raw)
+	}
+}
+
+// fakeRouteAdder records the routes a template adds.
+type fakeRouteAdder struct {
+	reqs []*pb.AddRouteRequest
+}
+
+func (f *fakeRouteAdder) AddRoute(ctx context.Context, req *pb.AddRouteRequest) (*pb.AddRouteResponse, error) {
+	f.reqs = append(f.reqs, req)
+	return &pb.AddRouteResponse{}, nil
+}
+
+func TestAddTemplateRoutes(t *testing.T) {
+	routes := &fakeRouteAdder{}
+	srv := &ContainerServer{}
+	srv.SetTemplateRouteAdder(routes)
+
+	tmpl := &pb.ContainerTemplate{Name: "web", Routes: []*pb.ContainerTemplateRoute{{Subdomain: "app", ContainerPort: 3000}}}
+	srv.addTemplateRoutes(tmpl, "alice", "alice-container", "10.0.3.20")
+
+	if len(routes.reqs) != 1 {
+		t.Fatalf("added %d routes, want 1", len(routes.reqs))
+	}
+	r := routes.reqs[0]
+	if r.Domain != "alice-app" || r.TargetIp != "10.0.3.20" || r.TargetPort != 3000 || r.ContainerName != "alice-container" {
+		t.Errorf("route = %v", r)
+	}
+}
+
+func TestLoadContainerTemplates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "templates.yaml")
+	yaml := `templates:
+  - name: small
+    description: Small dev box
+    cpu: "2"
+    memory: 2GB
+    enable_podman: true
+    labels:
+      tier: dev
+    routes:
+      - subdomain: web
+        port: 3000
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	templates, err := LoadContainerTemplates(path)
+	if err != nil {
+		t.Fatalf("LoadContainerTemplates: %v", err)
+	}
+	if len(templates) != 1 {
+		t.Fatalf("loaded %d templates, want 1", len(templates))
+	}
+	small := templates[0]
+	if small.Resources.GetCpu() != "2" || small.Resources.GetMemory() != "2GB" || !small.EnablePodman ||
+		small.Labels["tier"] != "dev" || len(small.Routes) != 1 || small.Routes[0].ContainerPort != 3000 {
+		t.Errorf("template = %v", small)
+	}
+
+	dup := yaml + "  - name: small\n"
+	if err := os.WriteFile(path, []byte(dup), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadContainerTemplates(path); err == nil || !strings.Contains(err.Error(), "defined twice") {
+		t.Errorf("duplicate name: err = %v", err)
+	}
+}
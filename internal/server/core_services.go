@@ -125,6 +125,11 @@ type CoreServices struct {
 	caddyIP           string
 	victoriaMetricsIP string
 	otelCollectorIP   string
+
+	// alertActionURL and alertActionToken, when set, add the receiver
+	// that delivers alert actions to the daemon (see SetAlertActionTarget).
+	alertActionURL   string
+	alertActionToken string
 }
 
 // NewCoreServices creates a new core services manager
@@ -1174,46 +1179,84 @@ WantedBy=multi-user.target
 	return nil
 }
 
-// generateAlertmanagerConfig creates the Alertmanager YAML configuration
+// SetAlertActionTarget points Alertmanager's action receiver at the
+// daemon: alerts labelled action=limit_bandwidth are posted to url with
+// token as the bearer credential. Takes effect on the next config write.
+func (cs *CoreServices) SetAlertActionTarget(url, token string) {
+	cs.alertActionURL = url
+	cs.alertActionToken = token
+}
+
+// generateAlertmanagerConfig creates the Alertmanager YAML configuration.
+// Alerts go to webhookURL, or nowhere without one; with an action target
+// set, alerts that ask for an action also go to the daemon.
 func (cs *CoreServices) generateAlertmanagerConfig(webhookURL string) string {
+	receiver := "webhook"
 	if webhookURL == "" {
-		// No webhook configured — use a null receiver
-		return `global:
-  resolve_timeout: 5m
-
-route:
-  receiver: 'null'
-  group_by: ['alertname', 'severity']
-  group_wait: 30s
-  group_interval: 5m
-  repeat_interval: 4h
-
-receivers:
-  - name: 'null'
-`
+		receiver = "null"
 	}
 
-	return fmt.Sprintf(`global:
+	var b strings.Builder
+	fmt.Fprintf(&b, `global:
   resolve_timeout: 5m
 
 route:
-  receiver: 'webhook'
+  receiver: '%s'
   group_by: ['alertname', 'severity']
   group_wait: 30s
   group_interval: 5m
   repeat_interval: 4h
-  routes:
-    - match:
+`, receiver)
+
+	if cs.alertActionURL != "" || webhookURL != "" {
+		b.WriteString("  routes:\n")
+	}
+	if cs.alertActionURL != "" {
+		// continue: the alert still reaches the routes below. The short
+		// repeat interval renews a temporary limit while the alert fires.
+		b.WriteString(`    - match:
+        action: limit_bandwidth
+      group_by: ['alertname', 'container_name']
+      group_wait: 0s
+      repeat_interval: 10m
+      receiver: 'actions'
+      continue: true
+`)
+	}
+	if webhookURL != "" {
+		b.WriteString(`    - match:
         severity: critical
       repeat_interval: 1h
       receiver: 'webhook'
+`)
+		if cs.alertActionURL != "" {
+			// Once a child route matched, the root receiver no longer
+			// applies; route everything else to the webhook explicitly.
+			b.WriteString("    - receiver: 'webhook'\n")
+		}
+	}
 
-receivers:
-  - name: 'webhook'
+	b.WriteString("\nreceivers:\n")
+	if webhookURL == "" {
+		b.WriteString("  - name: 'null'\n")
+	} else {
+		fmt.Fprintf(&b, `  - name: 'webhook'
     webhook_configs:
       - url: '%s'
         send_resolved: true
 `, webhookURL)
+	}
+	if cs.alertActionURL != "" {
+		fmt.Fprintf(&b, `  - name: 'actions'
+    webhook_configs:
+      - url: '%s'
+        send_resolved: false
+        http_config:
+          authorization:
+            credentials: '%s'
+`, cs.alertActionURL, cs.alertActionToken)
+	}
+	return b.String()
 }
 
 // UpdateAlertmanagerWebhook regenerates the Alertmanager config with a new webhook URL
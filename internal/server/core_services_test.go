@@ -1,6 +1,11 @@
 package server
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
 
 // TestCoreStaticIP covers the deterministic stable-IP assignment for core
 // containers (#240): caddy gets a fixed high host in the bridge subnet so a
@@ -88,3 +93,53 @@ func TestCoreStaticIP_Deterministic(t *testing.T) {
 		}
 	}
 }
+
+// TestGenerateAlertmanagerConfig_ActionRoute checks that the action
+// receiver sees limit_bandwidth alerts without taking them from the
+// webhook, and that it carries the bearer token.
+func TestGenerateAlertmanagerConfig_ActionRoute(t *testing.T) {
+	type route struct {
+		Receiver string            `yaml:"receiver"`
+		Match    map[string]string `yaml:"match"`
+		Continue bool              `yaml:"continue"`
+	}
+	var cfg struct {
+		Route struct {
+			Receiver string  `yaml:"receiver"`
+			Routes   []route `yaml:"routes"`
+		} `yaml:"route"`
+		Receivers []struct {
+			Name string `yaml:"name"`
+		} `yaml:"receivers"`
+	}
+
+	cs := &CoreServices{}
+	if err := yaml.Unmarshal([]byte(cs.generateAlertmanagerConfig("")), &cfg); err != nil {
+		t.Fatalf("null config: %v", err)
+	}
+	if cfg.Route.Receiver != "null" || len(cfg.Route.Routes) != 0 {
+		t.Errorf("null config route = %+v", cfg.Route)
+	}
+
+	cs.SetAlertActionTarget("http://10.100.0.1:8080/internal/alert-action", "tok")
+	for _, webhook := range []string{"", "https://hooks.example.com/alerts"} {
+		out := cs.generateAlertmanagerConfig(webhook)
+		cfg.Route.Routes, cfg.Receivers = nil, nil
+		if err := yaml.Unmarshal([]byte(out), &cfg); err != nil {
+			t.Fatalf("config with webhook %q: %v\n%s", webhook, err, out)
+		}
+		first := cfg.Route.Routes[0]
+		if first.Receiver != "actions" || first.Match["action"] != "limit_bandwidth" || !first.Continue {
+			t.Errorf("webhook %q: first route = %+v", webhook, first)
+		}
+		if !strings.Contains(out, "credentials: 'tok'") {
+			t.Errorf("webhook %q: action receiver has no token:\n%s", webhook, out)
+		}
+		if webhook != "" {
+			last := cfg.Route.Routes[len(cfg.Route.Routes)-1]
+			if last.Receiver != "webhook" || len(last.Match) != 0 {
+				t.Errorf("no catch-all webhook route after the action route: %+v", cfg.Route.Routes)
+			}
+		}
+	}
+}
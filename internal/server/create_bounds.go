@@ -48,6 +48,11 @@ const (
 	// component is comfortably within those.
 	MaxLabelKeyLen   = 256
 	MaxLabelValueLen = 256
+
+	// MaxCloudInitLen caps a container template's cloud_init. Incus
+	// stores the user-data in the instance config; 64 KiB is plenty for
+	// a #cloud-config.
+	MaxCloudInitLen = 64 * 1024
 )
 
 // validateCreateContainerBounds enforces the size caps above on
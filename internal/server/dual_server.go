@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -11,11 +12,13 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/footprintai/containarium/internal/alert"
+	"github.com/footprintai/containarium/internal/apierr"
 	"github.com/footprintai/containarium/internal/app"
 	"github.com/footprintai/containarium/internal/audit"
 	"github.com/footprintai/containarium/internal/auth"
@@ -26,7 +29,9 @@ import (
 	"github.com/footprintai/containarium/internal/events"
 	"github.com/footprintai/containarium/internal/gateway"
 	"github.com/footprintai/containarium/internal/guacamole"
+	"github.com/footprintai/containarium/internal/jobs"
 	"github.com/footprintai/containarium/internal/metrics"
+	"github.com/footprintai/containarium/internal/metrics/opmetrics"
 	"github.com/footprintai/containarium/internal/metrics/platformstats"
 	"github.com/footprintai/containarium/internal/modelgateway"
 	"github.com/footprintai/containarium/internal/mtls"
@@ -49,6 +54,7 @@ import (
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -82,6 +88,15 @@ type DualServerConfig struct {
 	// Caddy certificate directory for /certs endpoint (sentinel cert sync)
 	CaddyCertDir string
 
+	// CaddyBackends are extra Caddy IPs that host ports 80/443 forward to
+	// alongside the core Caddy container, spread according to CaddyBalance.
+	CaddyBackends []string
+	CaddyBalance  network.BalanceMode
+
+	// PassthroughGCInterval is how often passthrough rules left behind for
+	// containers that no longer exist are removed (0 disables).
+	PassthroughGCInterval time.Duration
+
 	// VictoriaMetrics URL (auto-detected or provided)
 	VictoriaMetricsURL string
 
@@ -112,6 +127,84 @@ type DualServerConfig struct {
 	// CPUOvercommitEnforce actually rejects over-ceiling creates when the gate
 	// is enabled; false keeps it advisory (log-only).
 	CPUOvercommitEnforce bool
+	// TrafficReplay, when set, makes the traffic collector re-emit stored
+	// history as live events (--traffic-replay; test/staging only).
+	TrafficReplay *traffic.ReplayConfig
+	// TrafficStore selects the traffic history backend: "" or "postgres"
+	// (the app-hosting PostgreSQL, when available) or "memory" (an
+	// in-process ring buffer of TrafficMemoryCapacity connections, for
+	// development without PostgreSQL) or "none" (live monitoring only,
+	// nothing written).
+	TrafficStore          string
+	TrafficMemoryCapacity int
+	// TrafficMigrate is what opening the PostgreSQL traffic store does
+	// about pending schema migrations (--migrate); "" applies them.
+	TrafficMigrate traffic.MigrateMode
+	// TrafficSampling thins out persisted short flows on very busy hosts
+	// (--traffic-sample-*); the zero value keeps every flow.
+	TrafficSampling traffic.SamplingConfig
+	// TrafficRecordStates keeps every connection state change for
+	// GetConnectionTimeline (--traffic-record-states).
+	TrafficRecordStates bool
+	// TrafficDNSLog is the dnsmasq query log to follow for the DNS queries
+	// of boxes opted into DNS logging (--traffic-dns-log); empty disables
+	// DNS logging for every box.
+	TrafficDNSLog string
+	// TrafficConntrackNetNS is the network namespace whose conntrack table
+	// the collector watches (--traffic-conntrack-netns); empty is the host's.
+	TrafficConntrackNetNS string
+	// TrafficSSHLog is the sshd auth log to follow for SSH sessions
+	// (--traffic-ssh-log); empty disables SSH session logging.
+	TrafficSSHLog string
+	// TrafficGeoIPDatabases are the MMDB files closed connections are
+	// annotated from with the remote country and AS (--traffic-geoip-db);
+	// empty disables enrichment.
+	TrafficGeoIPDatabases []string
+	// TrafficEgressAllowlist are the addresses and CIDRs containers may
+	// reach outside the container network; egress anywhere else is
+	// flagged and alerted on (--traffic-egress-allowlist). Empty disables
+	// the check.
+	TrafficEgressAllowlist []string
+	// TrafficEgressAllowlistBlock also denies each violating destination
+	// in the tenant's network policy (--traffic-egress-allowlist-block).
+	TrafficEgressAllowlistBlock bool
+	// JobSchedules overrides DefaultJobSchedules by job name
+	// (--job-schedule).
+	JobSchedules map[string]string
+	// TrafficServicesFile adds port → service names, in /etc/services
+	// format, to the built-in map connections are labelled from
+	// (--traffic-services-file); empty uses the built-in map alone.
+	TrafficServicesFile string
+	// ContainerTemplatesFile is a YAML file of read-only container
+	// templates (--container-templates); empty leaves only the templates
+	// managed through the API.
+	ContainerTemplatesFile string
+	// TrafficListenerInterval is how often each running container's
+	// listening ports are scanned (--traffic-listener-interval); zero
+	// disables the scan.
+	TrafficListenerInterval time.Duration
+	// TrafficUDPIdleTimeout closes UDP and other connectionless flows
+	// after this long without a packet (--traffic-udp-idle-timeout);
+	// zero waits for conntrack to expire them.
+	TrafficUDPIdleTimeout time.Duration
+	// TrafficSnapshotDebounce is how recent a conntrack snapshot live
+	// connection queries reuse (--traffic-snapshot-debounce); zero dumps on
+	// every query.
+	TrafficSnapshotDebounce time.Duration
+	// TrafficHistoryLimits guard history queries against the PostgreSQL
+	// store (--traffic-history-*); the zero value disables them.
+	TrafficHistoryLimits traffic.HistoryLimits
+	// TrafficRetentionDays is how many days of traffic history the daily
+	// cleanup keeps (--traffic-retention-days); zero keeps the collector's
+	// default.
+	TrafficRetentionDays int
+	// TrafficCompactAfterDays is the age at which the traffic-compaction
+	// job folds connections into traffic_aggregates
+	// (--traffic-compact-after-days); 0 disables it.
+	TrafficCompactAfterDays int
+	// IdempotencyKeyTTL is how long a create's Idempotency-Key is remembered
+	// for replay. <= 0 uses DefaultIdempotencyKeyTTL.
+	IdempotencyKeyTTL time.Duration
 	// PlacementCPUAware ranks pool placement by peer CPU commitment (least
 	// committed wins) instead of first-healthy (#1029 direction 2).
 	PlacementCPUAware bool
@@ -123,6 +216,10 @@ type DualServerConfig struct {
 	PublicBaseDomains []string // suffix-match anchors advertised to the sentinel; <anything>.<one-of-these> routes here. List multiple to host workloads under different parent domains on the same backend (see docs/PER-POOL-BASE-DOMAIN.md)
 	PublicPort        int      // TLS port the sentinel forwards to (typically 443)
 
+	// ReservedPorts are extra host ports passthrough routes may not use, on
+	// top of the daemon's own listeners, SSH and the sentinel port.
+	ReservedPorts []int
+
 	// SSHHost is the public host clients dial to SSH into this daemon's
 	// containers — the sentinel's SSH endpoint (e.g. region-a.example.com),
 	// from --ssh-host. Surfaced on each Container.ssh_host so clients build
@@ -175,6 +272,9 @@ type DualServer struct {
 	networkServer         *NetworkServer
 	trafficServer         *TrafficServer
 	trafficCollector      *traffic.Collector
+	adminServer           *AdminServer
+	jobScheduler          *jobs.Scheduler
+	healthReporter        *healthReporter
 	gatewayServer         *gateway.GatewayServer
 	tokenManager          *auth.TokenManager
 	authMiddleware        *auth.AuthMiddleware
@@ -182,6 +282,9 @@ type DualServer struct {
 	routeSyncJob          *app.RouteSyncJob
 	passthroughStore      network.PassthroughStore
 	passthroughSyncJob    *network.PassthroughSyncJob
+	trafficShaper         *network.TrafficShaper
+	passthroughSweeper    *network.OrphanSweeper
+	passthroughGCInterval time.Duration
 	collaboratorStore     *collaborator.Store
 	daemonConfigStore     *app.DaemonConfigStore
 	metricsCollector      *metrics.Collector
@@ -206,6 +309,8 @@ type DualServer struct {
 	networkPolicyEnforcer *NetworkPolicyEnforcer // #315 Phase A — eBPF per-tenant net policy (off unless configured)
 	cloudClient           *cloud.Client          // #354 — cloud-actuation client (nil unless host is enrolled)
 	startTime             time.Time
+
+	reloadMu sync.Mutex // serializes Reload
 }
 
 // bridgeDNSRaw builds the incusbr0 `raw.dnsmasq` value for container DNS.
@@ -261,6 +366,7 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	// default and resume-on-restart silently never fired (caught live
 	// on a GCP backend while validating #1070).
 	containerServer.SetDaemonConfigStore(config.DaemonConfigStore)
+	containerServer.SetIdempotencyKeyTTL(config.IdempotencyKeyTTL)
 	// NOTE: metrics-export resume (StartMetricsExportIfEnabled) is
 	// deliberately NOT called here. The resumed collector snapshots the
 	// daemon's backend_id/region at build time, and neither is populated
@@ -313,19 +419,27 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 			grpc.ChainUnaryInterceptor(
 				auth.RequireMTLSUnaryInterceptor(),
 				platformstats.UnaryInterceptor(containerServer.platformStats),
+				opmetrics.UnaryServerInterceptor(),
+			),
+			grpc.ChainStreamInterceptor(
+				auth.RequireMTLSStreamInterceptor(),
+				opmetrics.StreamServerInterceptor(),
 			),
-			grpc.StreamInterceptor(auth.RequireMTLSStreamInterceptor()),
 		)
 		log.Printf("gRPC server: mTLS enabled (interceptor verifies peer cert on every call)")
 	} else {
 		grpcServer = grpc.NewServer(
 			// Same ordering rationale as the mTLS branch above: auth
-			// outer, platform-stats inner.
+			// outer, platform-stats and opmetrics inner.
 			grpc.ChainUnaryInterceptor(
 				authMiddleware.GRPCUnaryInterceptor(),
 				platformstats.UnaryInterceptor(containerServer.platformStats),
+				opmetrics.UnaryServerInterceptor(),
+			),
+			grpc.ChainStreamInterceptor(
+				authMiddleware.GRPCStreamInterceptor(),
+				opmetrics.StreamServerInterceptor(),
 			),
-			grpc.StreamInterceptor(authMiddleware.GRPCStreamInterceptor()),
 		)
 		log.Printf("WARNING: gRPC server running in INSECURE mode")
 	}
@@ -366,6 +480,8 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 			networkCIDR,
 			"", // Proxy IP determined dynamically
 		)
+		networkServer.reservedPorts = daemonReservedPorts(config)
+		networkServer.caddyCertDir = config.CaddyCertDir
 		pb.RegisterNetworkServiceServer(grpcServer, networkServer)
 		log.Printf("Network service enabled")
 	}
@@ -374,6 +490,18 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	// GPU/app recipes. Pure orchestration over the container + network
 	// servers; networkServer may be nil (expose then degrades to a warning).
 	recipeServer := NewRecipeServer(containerServer, networkServer)
+	if networkServer != nil {
+		containerServer.SetTemplateRouteAdder(networkServer)
+	}
+	if config.ContainerTemplatesFile != "" {
+		templates, err := LoadContainerTemplates(config.ContainerTemplatesFile)
+		if err != nil {
+			log.Printf("Warning: Failed to load container templates, continuing without them: %v", err)
+		} else {
+			containerServer.SetContainerTemplates(templates)
+			log.Printf("Loaded %d container template(s) from %s", len(templates), config.ContainerTemplatesFile)
+		}
+	}
 	pb.RegisterRecipeServiceServer(grpcServer, recipeServer)
 	log.Printf("Recipe service enabled")
 
@@ -453,6 +581,13 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	// the same secrets Store; backend *config* stays in env/systemd.
 	pb.RegisterKmsServiceServer(grpcServer, NewKmsServer(containerServer))
 
+	// Daemon admin service — config reload and container template
+	// management. The daemon command installs the reloader once the
+	// server is built (SetConfigReloader).
+	adminServer := NewAdminServer()
+	adminServer.SetTemplateRegistry(containerServer)
+	pb.RegisterAdminServiceServer(grpcServer, adminServer)
+
 	// Cloud-actuation client (#354) is constructed later, once routeStore is
 	// finalized — the container actuator needs it to expose cloud routes at the
 	// host edge. Declared here so it's in scope for the DualServer assembly.
@@ -461,19 +596,47 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	// Create TrafficServer (always available, but conntrack only works on Linux)
 	var trafficServer *TrafficServer
 	var trafficCollector *traffic.Collector
+	trafficServices, err := traffic.LoadServiceNames(config.TrafficServicesFile)
+	if err != nil {
+		log.Printf("Warning: Failed to load traffic services file, using built-in port names: %v", err)
+		trafficServices = traffic.DefaultServiceNames()
+	}
 	if networkIncusClient != nil {
 		// Traffic collector needs PostgreSQL - will be set up later if app hosting enabled
 		// For now, create without store and update later
 		emitter := events.NewEmitter(events.GetBus())
 		collectorConfig := traffic.DefaultCollectorConfig()
 		collectorConfig.NetworkCIDR = networkCIDR
-
-		// Create collector without store initially
-		trafficCollector, err = traffic.NewCollector(collectorConfig, networkIncusClient, nil, emitter)
+		collectorConfig.PersistenceEnabled = config.TrafficStore != "none"
+		collectorConfig.Replay = config.TrafficReplay
+		collectorConfig.Sampling = config.TrafficSampling
+		collectorConfig.RecordStateChanges = config.TrafficRecordStates
+		collectorConfig.DNSLogPath = config.TrafficDNSLog
+		collectorConfig.ConntrackNetNS = config.TrafficConntrackNetNS
+		collectorConfig.SSHLogPath = config.TrafficSSHLog
+		collectorConfig.GeoIPDatabases = config.TrafficGeoIPDatabases
+		collectorConfig.EgressAllowlist = config.TrafficEgressAllowlist
+		collectorConfig.SnapshotDebounce = config.TrafficSnapshotDebounce
+		collectorConfig.ListenerScanInterval = config.TrafficListenerInterval
+		collectorConfig.UDPIdleTimeout = config.TrafficUDPIdleTimeout
+		collectorConfig.CompactAfterDays = config.TrafficCompactAfterDays
+		if config.TrafficRetentionDays > 0 {
+			collectorConfig.RetentionDays = config.TrafficRetentionDays
+		}
+
+		// Create collector without store initially, unless history is kept
+		// in memory.
+		var memoryStore traffic.ConnectionStore
+		if config.TrafficStore == "memory" {
+			memoryStore = traffic.NewMemoryStore(config.TrafficMemoryCapacity)
+			log.Printf("Traffic history kept in memory (not persisted across restarts)")
+		}
+		trafficCollector, err = traffic.NewCollector(collectorConfig, networkIncusClient, memoryStore, emitter)
 		if err != nil {
 			log.Printf("Warning: Failed to create traffic collector: %v", err)
 		} else {
 			trafficServer = NewTrafficServer(trafficCollector)
+			trafficServer.SetServiceNames(trafficServices)
 			pb.RegisterTrafficServiceServer(grpcServer, trafficServer)
 			if trafficCollector.IsAvailable() {
 				log.Printf("Traffic monitoring service enabled (conntrack available)")
@@ -489,6 +652,9 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	var routeSyncJob *app.RouteSyncJob
 	// coreServices is hoisted so alert setup can reference it later
 	var coreServices *CoreServices
+	// alertActionToken authenticates Alertmanager on /internal/alert-action;
+	// minted per start since SetupAlerting rewrites the config every boot.
+	alertActionToken := generateWebhookSecret()
 	// postgresConnString is hoisted so collaborator init (after skipAppHosting) can use it
 	postgresConnString := config.PostgresConnString
 	if config.EnableAppHosting {
@@ -545,11 +711,13 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 						// it here makes first-install work without requiring a
 						// daemon restart.
 						if network.CheckIPTablesAvailable() {
-							pf := network.NewPortForwarderWithNetwork(caddyIP, networkCIDR)
+							backends := append([]string{caddyIP}, config.CaddyBackends...)
+							pf := network.NewPortForwarderWithBackends(backends, networkCIDR, config.CaddyBalance)
 							if err := pf.SetupPortForwarding(); err != nil {
 								log.Printf("Warning: Failed to setup port forwarding after Caddy bring-up: %v", err)
 								log.Printf("  External HTTPS for %s may not work", config.BaseDomain)
 							}
+							go pf.WatchBackends(context.Background(), network.DefaultBackendCheckInterval)
 						}
 
 						// Resolve *.baseDomain to Caddy internally (hairpin NAT
@@ -613,6 +781,9 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 				if config.AlertWebhookSecret != "" && config.AlertWebhookURL != "" && config.HostIP != "" {
 					alertTargetURL = fmt.Sprintf("http://%s:%d/internal/alert-relay", config.HostIP, config.HTTPPort)
 				}
+				if config.EnableREST && config.HostIP != "" {
+					coreServices.SetAlertActionTarget(fmt.Sprintf("http://%s:%d/internal/alert-action", config.HostIP, config.HTTPPort), alertActionToken)
+				}
 				if err := coreServices.SetupAlerting(context.Background(), alertTargetURL); err != nil {
 					log.Printf("Warning: Failed to setup alerting: %v. Alerting disabled.", err)
 				} else {
@@ -799,16 +970,36 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 				}
 
 				// Update TrafficCollector with store for persistence
-				if trafficCollector != nil && postgresConnString != "" {
-					trafficStore, err := traffic.NewStore(context.Background(), postgresConnString)
+				if trafficCollector != nil && trafficCollector.PersistenceEnabled() && postgresConnString != "" && config.TrafficStore != "memory" {
+					trafficStore, err := traffic.NewStore(context.Background(), postgresConnString,
+						traffic.WithMigrateMode(config.TrafficMigrate))
+					if errors.Is(err, traffic.ErrPendingMigrations) {
+						return nil, err
+					}
 					if err != nil {
 						log.Printf("Warning: Failed to create traffic store: %v. Traffic persistence disabled.", err)
 					} else {
+						trafficStore.SetHistoryLimits(config.TrafficHistoryLimits)
 						// Re-create collector with store
 						emitter := events.NewEmitter(events.GetBus())
 						collectorConfig := traffic.DefaultCollectorConfig()
 						collectorConfig.NetworkCIDR = networkCIDR
 						collectorConfig.PostgresConnString = postgresConnString
+						collectorConfig.Replay = config.TrafficReplay
+						collectorConfig.Sampling = config.TrafficSampling
+						collectorConfig.RecordStateChanges = config.TrafficRecordStates
+						collectorConfig.DNSLogPath = config.TrafficDNSLog
+						collectorConfig.ConntrackNetNS = config.TrafficConntrackNetNS
+						collectorConfig.SSHLogPath = config.TrafficSSHLog
+						collectorConfig.GeoIPDatabases = config.TrafficGeoIPDatabases
+						collectorConfig.EgressAllowlist = config.TrafficEgressAllowlist
+						collectorConfig.SnapshotDebounce = config.TrafficSnapshotDebounce
+						collectorConfig.ListenerScanInterval = config.TrafficListenerInterval
+						collectorConfig.UDPIdleTimeout = config.TrafficUDPIdleTimeout
+						collectorConfig.CompactAfterDays = config.TrafficCompactAfterDays
+						if config.TrafficRetentionDays > 0 {
+							collectorConfig.RetentionDays = config.TrafficRetentionDays
+						}
 
 						newCollector, err := traffic.NewCollector(collectorConfig, incusClient, trafficStore, emitter)
 						if err != nil {
@@ -816,6 +1007,7 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 						} else {
 							trafficCollector = newCollector
 							trafficServer = NewTrafficServer(trafficCollector)
+							trafficServer.SetServiceNames(trafficServices)
 							log.Printf("Traffic monitoring updated with persistence")
 						}
 					}
@@ -1043,11 +1235,42 @@ skipAppHosting:
 				}
 				passthroughSyncJob = network.NewPassthroughSyncJob(passthroughStore, networkServer.passthroughManager, syncInterval)
 				networkServer.passthroughStore = passthroughStore
+				containerServer.SetPassthroughStore(passthroughStore)
 				log.Printf("Passthrough route persistence enabled with %v sync interval", syncInterval)
 			}
 		}
 	}
 
+	// Sweep passthrough rules whose container is gone: crashes and partial
+	// operations leave them behind, and without a registry nothing else
+	// would notice.
+	var passthroughSweeper *network.OrphanSweeper
+	if config.PassthroughGCInterval > 0 && networkServer != nil &&
+		networkServer.passthroughManager != nil && networkServer.incusClient != nil {
+		passthroughSweeper = network.NewOrphanSweeper(networkServer.passthroughManager, networkServer.passthroughLiveContainers)
+	}
+
+	// Setup per-container bandwidth limits: stored in PostgreSQL and
+	// enforced with tc on each container's veth.
+	var trafficShaper *network.TrafficShaper
+	if postgresConnString != "" && networkServer != nil {
+		pool, poolErr := connectToPostgres(postgresConnString, 5, 3*time.Second)
+		if poolErr != nil {
+			log.Printf("Warning: Failed to connect to PostgreSQL for bandwidth limits: %v", poolErr)
+		} else {
+			bandwidthStore, err := network.NewBandwidthStore(context.Background(), pool)
+			if err != nil {
+				log.Printf("Warning: Failed to create bandwidth limit store: %v", err)
+				pool.Close()
+			} else {
+				trafficShaper = network.NewTrafficShaper(bandwidthStore, networkServer.containerVeth)
+				networkServer.SetTrafficShaper(trafficShaper)
+				containerServer.SetTrafficShaper(trafficShaper)
+				log.Printf("Bandwidth limits enabled")
+			}
+		}
+	}
+
 	// Upgrade the NetworkPolicy service from its initial in-memory store to a
 	// Postgres-backed one now that postgresConnString is finalized. Best-effort:
 	// on any failure we keep the in-memory store (policies won't survive a
@@ -1073,8 +1296,29 @@ skipAppHosting:
 		}
 	}
 
+	// Same best-effort upgrade for create Idempotency-Keys: with Postgres, a
+	// retry after a daemon restart mid-create still dedupes.
+	if postgresConnString != "" {
+		pool, poolErr := connectToPostgres(postgresConnString, 5, 3*time.Second)
+		if poolErr != nil {
+			log.Printf("Warning: Failed to connect to PostgreSQL for idempotency key store: %v", poolErr)
+		} else if idemStore, idemErr := NewPostgresIdempotencyStore(context.Background(), pool); idemErr != nil {
+			log.Printf("Warning: Failed to create Postgres idempotency key store: %v", idemErr)
+			pool.Close()
+		} else {
+			containerServer.SetIdempotencyStore(idemStore)
+			log.Printf("Create idempotency keys persisted (Postgres store)")
+		}
+	}
+
 	reflection.Register(grpcServer)
 
+	// Standard grpc_health_v1 service for grpcurl / mesh probes. Registered
+	// here, after the traffic collector is final (the Postgres block above
+	// may replace it with a store-backed one).
+	healthReporter := newHealthReporter(trafficCollector)
+	healthpb.RegisterHealthServer(grpcServer, healthReporter.server)
+
 	// Create OTel metrics collector if VictoriaMetrics URL is available
 	var metricsCollector *metrics.Collector
 	if config.VictoriaMetricsURL != "" {
@@ -1104,6 +1348,32 @@ skipAppHosting:
 		containerServer.SetMonitoringURLs(config.VictoriaMetricsURL, grafanaURL)
 	}
 
+	// The activity report reads traffic totals straight from the store of
+	// whichever collector survived the app-hosting upgrade above.
+	if trafficCollector != nil {
+		containerServer.SetTrafficStore(trafficCollector.GetStore())
+		containerServer.SetListenerSource(trafficCollector)
+		containerServer.SetSSHSessionSource(trafficCollector)
+	}
+
+	// Scheduled maintenance jobs — traffic cleanup, usage rollup, the
+	// conntrack snapshot and orphan GC — with their runs recorded in
+	// jobs_history, or in memory without PostgreSQL. Registered here, once
+	// the traffic collector's store is final; started in Start.
+	var jobHistory jobs.HistoryStore = jobs.NewMemoryHistory(jobHistoryPerJob)
+	if postgresConnString != "" {
+		if jobsPool, poolErr := connectToPostgres(postgresConnString, 5, 3*time.Second); poolErr != nil {
+			log.Printf("Warning: Failed to connect to PostgreSQL for job history: %v; keeping it in memory", poolErr)
+		} else if pgHistory, herr := jobs.NewPostgresHistory(context.Background(), jobsPool); herr != nil {
+			log.Printf("Warning: Failed to create job history store: %v; keeping it in memory", herr)
+			jobsPool.Close()
+		} else {
+			jobHistory = pgHistory
+		}
+	}
+	jobScheduler := newJobScheduler(jobHistory, trafficCollector, containerServer, config.JobSchedules)
+	pb.RegisterJobsServiceServer(grpcServer, NewJobsServer(jobScheduler))
+
 	// Setup ClamAV security scanner
 	var securityScanner *security.Scanner
 	var securityStore *security.Store
@@ -1317,6 +1587,22 @@ skipAppHosting:
 		}
 	}
 
+	// Egress allowlist auto-block: each destination a tenant's container
+	// reaches outside the allowlist becomes a deny rule in the tenant's
+	// policy. Wired here, once npServer's store is final; like
+	// auto-quarantine it only drops packets when the enforcer is armed.
+	if config.TrafficEgressAllowlistBlock {
+		switch {
+		case trafficCollector == nil:
+			log.Printf("Warning: --traffic-egress-allowlist-block ignored: traffic monitoring is unavailable")
+		case len(config.TrafficEgressAllowlist) == 0:
+			log.Printf("Warning: --traffic-egress-allowlist-block ignored: no --traffic-egress-allowlist configured")
+		default:
+			trafficCollector.SetPolicyViolationHook(NewAllowlistAutoBlock(npServer.Store()).OnViolation)
+			log.Printf("Egress allowlist auto-block enabled: destinations outside the allowlist are denied per tenant")
+		}
+	}
+
 	// Setup alert store and manager
 	var alertStore *alert.Store
 	var alertManager *alert.Manager
@@ -1491,6 +1777,11 @@ skipAppHosting:
 			log.Printf("[gateway] /authorized-keys/sentinel authorizes the sentinel at the K8s in-cluster gateway")
 		}
 
+		// Per-container traffic counters for Prometheus
+		if trafficCollector != nil {
+			gatewayServer.SetTrafficMetricsHandler(trafficCollector.MetricsHandler())
+		}
+
 		// Wire security store for CSV export
 		if securityStore != nil {
 			gatewayServer.SetSecurityStore(securityStore)
@@ -1560,6 +1851,10 @@ skipAppHosting:
 		log.Printf("HTTP/REST gateway enabled on port %d", config.HTTPPort)
 	}
 
+	if gatewayServer != nil {
+		gatewayServer.SetAlertActionHandler(&alertActionHandler{shaper: trafficShaper, token: alertActionToken})
+	}
+
 	// Wire the relay URL + callback into the container server so runtime
 	// UpdateAlertingConfig can update the gateway relay config dynamically.
 	if gatewayServer != nil && config.HostIP != "" {
@@ -1620,6 +1915,9 @@ skipAppHosting:
 		networkServer:         networkServer,
 		trafficServer:         trafficServer,
 		trafficCollector:      trafficCollector,
+		adminServer:           adminServer,
+		jobScheduler:          jobScheduler,
+		healthReporter:        healthReporter,
 		gatewayServer:         gatewayServer,
 		tokenManager:          tokenManager,
 		authMiddleware:        authMiddleware,
@@ -1627,6 +1925,9 @@ skipAppHosting:
 		routeSyncJob:          routeSyncJob,
 		passthroughStore:      passthroughStore,
 		passthroughSyncJob:    passthroughSyncJob,
+		trafficShaper:         trafficShaper,
+		passthroughSweeper:    passthroughSweeper,
+		passthroughGCInterval: config.PassthroughGCInterval,
 		collaboratorStore:     collabStore,
 		daemonConfigStore:     config.DaemonConfigStore,
 		metricsCollector:      metricsCollector,
@@ -1671,7 +1972,7 @@ skipAppHosting:
 func (ds *DualServer) backendsHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := auth.RequireRole(r.Context(), auth.RoleAdmin); err != nil {
-			http.Error(w, `{"error":"admin role required","code":403}`, http.StatusForbidden)
+			apierr.Write(w, r, http.StatusForbidden, "admin role required")
 			return
 		}
 		path := strings.TrimPrefix(r.URL.Path, "/v1/backends")
@@ -1775,7 +2076,7 @@ func (ds *DualServer) startIntegrityHeartbeat(ctx context.Context) {
 // For peer backends, it forwards the request to the peer.
 func (ds *DualServer) handleBackendSystemInfo(w http.ResponseWriter, r *http.Request, backendID string) {
 	if ds.peerPool == nil {
-		http.Error(w, `{"error":"no backends configured"}`, http.StatusNotFound)
+		apierr.Write(w, r, http.StatusNotFound, "no backends configured")
 		return
 	}
 
@@ -1794,7 +2095,7 @@ func (ds *DualServer) handleBackendSystemInfo(w http.ResponseWriter, r *http.Req
 		req.Header.Set("Authorization", authToken)
 		resp, err := client.Do(req)
 		if err != nil {
-			http.Error(w, fmt.Sprintf(`{"error":"failed to get local system info: %v"}`, err), http.StatusInternalServerError)
+			apierr.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("failed to get local system info: %v", err))
 			return
 		}
 		defer resp.Body.Close()
@@ -1807,11 +2108,11 @@ func (ds *DualServer) handleBackendSystemInfo(w http.ResponseWriter, r *http.Req
 	// Peer backend — forward to peer
 	peer := ds.peerPool.Get(backendID)
 	if peer == nil {
-		http.Error(w, fmt.Sprintf(`{"error":"backend %q not found"}`, backendID), http.StatusNotFound)
+		apierr.Write(w, r, http.StatusNotFound, fmt.Sprintf("backend %q not found", backendID))
 		return
 	}
 	if !peer.Healthy {
-		http.Error(w, fmt.Sprintf(`{"error":"backend %q is not healthy"}`, backendID), http.StatusServiceUnavailable)
+		apierr.Write(w, r, http.StatusServiceUnavailable, fmt.Sprintf("backend %q is not healthy", backendID))
 		return
 	}
 
@@ -1823,7 +2124,7 @@ func (ds *DualServer) handleBackendSystemInfo(w http.ResponseWriter, r *http.Req
 
 	respBody, statusCode, err := peer.ForwardRequest("GET", "/v1/system/info", authToken, nil)
 	if err != nil {
-		http.Error(w, fmt.Sprintf(`{"error":"failed to get system info from peer: %v"}`, err), http.StatusBadGateway)
+		apierr.Write(w, r, http.StatusBadGateway, fmt.Sprintf("failed to get system info from peer: %v", err))
 		return
 	}
 	w.WriteHeader(statusCode)
@@ -1984,6 +2285,8 @@ func (ds *DualServer) Start(ctx context.Context) error {
 			log.Printf("Warning: Failed to start traffic collector: %v", err)
 		}
 	}
+	ds.jobScheduler.Start(ctx)
+	ds.healthReporter.Start(ctx)
 
 	// Phase 1.2 — prune expired revocation rows hourly. Rows
 	// whose token exp has already passed can no longer
@@ -2003,7 +2306,9 @@ func (ds *DualServer) Start(ctx context.Context) error {
 	} else {
 		var trafficSrc autosleep.TrafficSource
 		if ds.trafficCollector != nil {
-			if store := ds.trafficCollector.GetStore(); store != nil {
+			// The activity probe is raw SQL over traffic_connections, so it
+			// only works on the PostgreSQL backend.
+			if store, ok := ds.trafficCollector.GetStore().(*traffic.Store); ok {
 				if pool := store.Pool(); pool != nil {
 					trafficSrc = autosleep.NewTrafficStoreAdapter(pool)
 				}
@@ -2103,6 +2408,9 @@ func (ds *DualServer) Start(ctx context.Context) error {
 			ds.metricsCollector.SetEgressFetcher(&EgressFanoutFetcherAdapter{
 				Collector: ds.trafficCollector,
 			})
+			ds.metricsCollector.SetListenerFetcher(&ListenerFetcherAdapter{
+				Collector: ds.trafficCollector,
+			})
 		}
 		ds.metricsCollector.Start()
 	}
@@ -2115,7 +2423,8 @@ func (ds *DualServer) Start(ctx context.Context) error {
 		// Subscribe to container creation events to auto-scan new containers
 		go func() {
 			sub := events.GetBus().Subscribe(&pb.SubscribeEventsRequest{
-				ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_CONTAINER},
+				ResourceTypes:       []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_CONTAINER},
+				ContainerEventTypes: []pb.ContainerEventType{pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED},
 			})
 			defer events.GetBus().Unsubscribe(sub.ID)
 			for {
@@ -2126,13 +2435,11 @@ func (ds *DualServer) Start(ctx context.Context) error {
 					if !ok {
 						return
 					}
-					if event.Type == pb.EventType_EVENT_TYPE_CONTAINER_CREATED {
-						if ce := event.GetContainerEvent(); ce != nil && ce.Container != nil {
-							name := ce.Container.Name
-							// Skip core containers
-							if !strings.HasPrefix(name, "containarium-core-") {
-								ds.securityScanner.EnqueueNewContainer(name)
-							}
+					if ce := event.GetContainerEvent(); ce != nil && ce.Container != nil {
+						name := ce.Container.Name
+						// Skip core containers
+						if !strings.HasPrefix(name, "containarium-core-") {
+							ds.securityScanner.EnqueueNewContainer(name)
 						}
 					}
 				}
@@ -2181,6 +2488,39 @@ func (ds *DualServer) Start(ctx context.Context) error {
 		log.Printf("Passthrough sync job started")
 	}
 
+	if ds.passthroughSweeper != nil {
+		go ds.passthroughSweeper.Watch(ctx, ds.passthroughGCInterval)
+		log.Printf("Passthrough orphan sweeper started (every %v)", ds.passthroughGCInterval)
+	}
+
+	// Keep bandwidth limits on each container's current veth; a restart
+	// replaces the veth and drops whatever tc had on the old one.
+	// StartContainer re-applies at once, so this only has to catch
+	// restarts that bypass the API (incus restart, host reboot).
+	if ds.trafficShaper != nil {
+		go ds.trafficShaper.Watch(ctx, 30*time.Second)
+		log.Printf("Bandwidth shaper started")
+	}
+
+	// Keep routes declared by container pointed at the container's IP
+	// across recreates (PostgreSQL -> the sync jobs above).
+	if ds.networkServer != nil && (ds.routeStore != nil || ds.passthroughStore != nil) {
+		retargeter := &routeRetargeter{
+			passthrough: ds.passthroughStore,
+			lookupIP:    ds.networkServer.lookupContainerIP,
+		}
+		if ds.routeStore != nil {
+			retargeter.routes = ds.routeStore
+		}
+		if ds.routeSyncJob != nil {
+			retargeter.syncRoutes = ds.routeSyncJob.SyncNow
+		}
+		if ds.passthroughSyncJob != nil {
+			retargeter.syncPassthrough = ds.passthroughSyncJob.SyncNow
+		}
+		go retargeter.Run(ctx, events.GetBus())
+	}
+
 	// Start the eBPF network-policy enforcer if configured (#315 Phase A). A
 	// load failure (e.g. not on Linux, missing object, verifier reject) is
 	// logged and the daemon continues without enforcement — it must never block
@@ -2197,6 +2537,9 @@ func (ds *DualServer) Start(ctx context.Context) error {
 			ds.networkPolicyEnforcer = nil
 		} else {
 			log.Printf("NetworkPolicy enforcer started")
+			if ds.containerServer != nil {
+				ds.containerServer.SetEgressChecker(ds.networkPolicyEnforcer)
+			}
 		}
 	}
 
@@ -2349,6 +2692,7 @@ func (ds *DualServer) Start(ctx context.Context) error {
 		return err
 	case <-ctx.Done():
 		log.Println("Shutting down servers...")
+		ds.healthReporter.Stop()
 		if ds.routeSyncJob != nil {
 			ds.routeSyncJob.Stop()
 		}
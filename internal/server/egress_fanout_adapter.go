@@ -31,3 +31,29 @@ func (a *EgressFanoutFetcherAdapter) EgressFanout() []metricsPackage.EgressFanou
 	}
 	return out
 }
+
+// ListenerFetcherAdapter adapts the traffic collector's listener scan to the
+// metrics.ListenerFetcher interface, the same way EgressFanoutFetcherAdapter
+// bridges egress fan-out.
+type ListenerFetcherAdapter struct {
+	Collector *traffic.Collector
+}
+
+// ListenerStats returns per-container listener counts, or nil when listener
+// scanning is off.
+func (a *ListenerFetcherAdapter) ListenerStats() []metricsPackage.ListenerStat {
+	if a.Collector == nil {
+		return nil
+	}
+	stats := a.Collector.ListenerStats()
+	out := make([]metricsPackage.ListenerStat, len(stats))
+	for i, s := range stats {
+		out[i] = metricsPackage.ListenerStat{
+			ContainerName: s.ContainerName,
+			ContainerID:   s.ContainerID,
+			Listening:     s.Listening,
+			Opened:        s.Opened,
+		}
+	}
+	return out
+}
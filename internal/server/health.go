@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/footprintai/containarium/internal/traffic"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// healthCheckInterval is how often the traffic dependencies are re-probed.
+// Short enough that a mesh drains traffic within a couple of probe periods
+// of a Postgres outage; the probe itself is a single pool Ping.
+const healthCheckInterval = 10 * time.Second
+
+// healthReporter drives the standard grpc_health_v1 service. The overall
+// ("") status and ContainerService are SERVING once the gRPC listener is
+// up — they have no dependency a probe could usefully check. TrafficService
+// tracks its collector (including its first container cache fill) and,
+// when persistence is configured, its Postgres store: NOT_SERVING until
+// both are up, and again while the store is
+// unreachable and pgxpool is re-dialing.
+type healthReporter struct {
+	server    *health.Server
+	collector *traffic.Collector
+	stopCh    chan struct{}
+
+	// last is only touched from check, which runs on one goroutine.
+	last healthpb.HealthCheckResponse_ServingStatus
+}
+
+func newHealthReporter(collector *traffic.Collector) *healthReporter {
+	hs := health.NewServer()
+	// Everything starts NOT_SERVING; Start flips the unconditional ones.
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(pb.ContainerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(pb.TrafficService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	return &healthReporter{
+		server:    hs,
+		collector: collector,
+		stopCh:    make(chan struct{}),
+		last:      healthpb.HealthCheckResponse_NOT_SERVING,
+	}
+}
+
+// Start marks the daemon SERVING and begins probing the traffic
+// dependencies. Call after the traffic collector has been started.
+func (h *healthReporter) Start(ctx context.Context) {
+	h.server.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	h.server.SetServingStatus(pb.ContainerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	h.check(ctx)
+
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-h.stopCh:
+				return
+			case <-ticker.C:
+				h.check(ctx)
+			}
+		}
+	}()
+}
+
+// Stop flips every service to NOT_SERVING so in-flight health watchers see
+// the shutdown before the listener goes away.
+func (h *healthReporter) Stop() {
+	close(h.stopCh)
+	h.server.Shutdown()
+}
+
+func (h *healthReporter) check(ctx context.Context) {
+	var storeErr error
+	var store traffic.ConnectionStore
+	collectorUp := h.collector != nil && h.collector.IsAvailable() && h.collector.Ready()
+	if h.collector != nil {
+		store = h.collector.GetStore()
+	}
+	if store != nil {
+		pingCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		storeErr = store.HealthCheck(pingCtx)
+		cancel()
+	}
+
+	status := trafficServingStatus(collectorUp, store != nil, storeErr)
+	if status != h.last {
+		if storeErr != nil {
+			log.Printf("Warning: traffic store unreachable, %s now %s: %v", pb.TrafficService_ServiceDesc.ServiceName, status, storeErr)
+		} else {
+			log.Printf("gRPC health: %s now %s", pb.TrafficService_ServiceDesc.ServiceName, status)
+		}
+		h.last = status
+	}
+	h.server.SetServingStatus(pb.TrafficService_ServiceDesc.ServiceName, status)
+}
+
+// trafficServingStatus is the TrafficService health decision. A daemon
+// without persistence configured still serves live connection data, so a
+// missing store doesn't hold the service down; an unreachable one does,
+// because history queries would fail.
+func trafficServingStatus(collectorUp, storeConfigured bool, storeErr error) healthpb.HealthCheckResponse_ServingStatus {
+	if !collectorUp {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	if storeConfigured && storeErr != nil {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestTrafficServingStatus(t *testing.T) {
+	serving := healthpb.HealthCheckResponse_SERVING
+	notServing := healthpb.HealthCheckResponse_NOT_SERVING
+	tests := []struct {
+		name            string
+		collectorUp     bool
+		storeConfigured bool
+		storeErr        error
+		want            healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"collector down", false, true, nil, notServing},
+		{"collector up, no persistence configured", true, false, nil, serving},
+		{"collector and store up", true, true, nil, serving},
+		{"store reconnecting", true, true, errors.New("connection refused"), notServing},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := trafficServingStatus(tc.collectorUp, tc.storeConfigured, tc.storeErr); got != tc.want {
+				t.Errorf("trafficServingStatus() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
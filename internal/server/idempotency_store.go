@@ -0,0 +1,208 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IdempotencyRecord is one (username, Idempotency-Key) entry. Response is
+// nil while the create that claimed the key is still running.
+type IdempotencyRecord struct {
+	// Fingerprint is a hash of the request that claimed the key, so a key
+	// reused for a different request is rejected rather than replayed.
+	Fingerprint string
+	// Owner identifies the daemon process that claimed the key. A record
+	// still in flight under a different owner was orphaned by a restart.
+	Owner string
+	// Response is the protojson-encoded CreateContainerResponse.
+	Response  []byte
+	ExpiresAt time.Time
+}
+
+// IdempotencyStore persists create idempotency keys. Two impls, mirroring
+// NetworkPolicyStore: PostgresIdempotencyStore (survives a daemon restart
+// mid-create) and MemIdempotencyStore (--standalone daemons and tests).
+type IdempotencyStore interface {
+	// Claim records (username, key) as in flight for owner unless an
+	// unexpired record already exists, in which case that record is
+	// returned with claimed=false. An expired record is replaced.
+	Claim(ctx context.Context, username, key, fingerprint, owner string, ttl time.Duration) (existing *IdempotencyRecord, claimed bool, err error)
+	// Takeover moves an in-flight record from fromOwner to toOwner. It
+	// reports false if the record is no longer in flight under fromOwner
+	// (another caller took it over, or it completed).
+	Takeover(ctx context.Context, username, key, fromOwner, toOwner string) (bool, error)
+	// Complete stores the final response for replays.
+	Complete(ctx context.Context, username, key string, response []byte) error
+	// Release forgets the key, so a retry after a failed create provisions
+	// afresh instead of replaying the failure.
+	Release(ctx context.Context, username, key string) error
+}
+
+// --- in-memory ------------------------------------------------------
+
+type idempotencyMapKey struct{ username, key string }
+
+// MemIdempotencyStore is a goroutine-safe in-memory store. Keys do not
+// survive a daemon restart.
+type MemIdempotencyStore struct {
+	mu  sync.Mutex
+	m   map[idempotencyMapKey]*IdempotencyRecord
+	now func() time.Time
+}
+
+func NewMemIdempotencyStore() *MemIdempotencyStore {
+	return &MemIdempotencyStore{m: make(map[idempotencyMapKey]*IdempotencyRecord), now: time.Now}
+}
+
+func (s *MemIdempotencyStore) Claim(_ context.Context, username, key, fingerprint, owner string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	// Sweep expired keys while holding the lock; the map only ever holds
+	// keys from the last TTL window, so this stays small.
+	for k, rec := range s.m {
+		if !rec.ExpiresAt.After(now) {
+			delete(s.m, k)
+		}
+	}
+	k := idempotencyMapKey{username, key}
+	if rec, ok := s.m[k]; ok {
+		cp := *rec
+		return &cp, false, nil
+	}
+	s.m[k] = &IdempotencyRecord{Fingerprint: fingerprint, Owner: owner, ExpiresAt: now.Add(ttl)}
+	return nil, true, nil
+}
+
+func (s *MemIdempotencyStore) Takeover(_ context.Context, username, key, fromOwner, toOwner string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.m[idempotencyMapKey{username, key}]
+	if !ok || rec.Response != nil || rec.Owner != fromOwner {
+		return false, nil
+	}
+	rec.Owner = toOwner
+	return true, nil
+}
+
+func (s *MemIdempotencyStore) Complete(_ context.Context, username, key string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.m[idempotencyMapKey{username, key}]; ok {
+		rec.Response = append([]byte(nil), response...)
+	}
+	return nil
+}
+
+func (s *MemIdempotencyStore) Release(_ context.Context, username, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, idempotencyMapKey{username, key})
+	return nil
+}
+
+// --- postgres -------------------------------------------------------
+
+// PostgresIdempotencyStore persists keys in a container_idempotency_keys
+// table, so a create interrupted by a daemon restart still dedupes.
+type PostgresIdempotencyStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresIdempotencyStore(ctx context.Context, pool *pgxpool.Pool) (*PostgresIdempotencyStore, error) {
+	s := &PostgresIdempotencyStore{pool: pool}
+	schema := `
+		CREATE TABLE IF NOT EXISTS container_idempotency_keys (
+			username TEXT NOT NULL,
+			idem_key TEXT NOT NULL,
+			fingerprint TEXT NOT NULL,
+			owner TEXT NOT NULL,
+			response BYTEA,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			expires_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (username, idem_key)
+		);
+		CREATE INDEX IF NOT EXISTS idx_container_idempotency_keys_expires ON container_idempotency_keys(expires_at);
+	`
+	if _, err := pool.Exec(ctx, schema); err != nil {
+		return nil, fmt.Errorf("init container_idempotency_keys schema: %w", err)
+	}
+	return s, nil
+}
+
+func (s *PostgresIdempotencyStore) Claim(ctx context.Context, username, key, fingerprint, owner string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	// Opportunistic sweep: keys are only looked up by exact match, so
+	// expired rows are dead weight rather than a correctness problem.
+	if _, err := s.pool.Exec(ctx, `DELETE FROM container_idempotency_keys WHERE expires_at < NOW()`); err != nil {
+		return nil, false, fmt.Errorf("sweep idempotency keys: %w", err)
+	}
+
+	// Insert, or take over a row that expired between the sweep and here.
+	// RETURNING yields a row only when this call won the key.
+	const claim = `
+		INSERT INTO container_idempotency_keys (username, idem_key, fingerprint, owner, response, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, NULL, NOW(), NOW() + make_interval(secs => $5))
+		ON CONFLICT (username, idem_key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			owner = EXCLUDED.owner,
+			response = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE container_idempotency_keys.expires_at < NOW()
+		RETURNING username
+	`
+	var got string
+	err := s.pool.QueryRow(ctx, claim, username, key, fingerprint, owner, ttl.Seconds()).Scan(&got)
+	if err == nil {
+		return nil, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, fmt.Errorf("claim idempotency key: %w", err)
+	}
+
+	rec := &IdempotencyRecord{}
+	err = s.pool.QueryRow(ctx,
+		`SELECT fingerprint, owner, response, expires_at FROM container_idempotency_keys WHERE username = $1 AND idem_key = $2`,
+		username, key).Scan(&rec.Fingerprint, &rec.Owner, &rec.Response, &rec.ExpiresAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("load idempotency key: %w", err)
+	}
+	return rec, false, nil
+}
+
+func (s *PostgresIdempotencyStore) Takeover(ctx context.Context, username, key, fromOwner, toOwner string) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE container_idempotency_keys SET owner = $4
+		WHERE username = $1 AND idem_key = $2 AND owner = $3 AND response IS NULL
+	`, username, key, fromOwner, toOwner)
+	if err != nil {
+		return false, fmt.Errorf("take over idempotency key: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *PostgresIdempotencyStore) Complete(ctx context.Context, username, key string, response []byte) error {
+	_, err := s.pool.Exec(ctx,
+		`UPDATE container_idempotency_keys SET response = $3 WHERE username = $1 AND idem_key = $2`,
+		username, key, response)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresIdempotencyStore) Release(ctx context.Context, username, key string) error {
+	_, err := s.pool.Exec(ctx,
+		`DELETE FROM container_idempotency_keys WHERE username = $1 AND idem_key = $2`,
+		username, key)
+	if err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/footprintai/containarium/internal/jobs"
+	"github.com/footprintai/containarium/internal/traffic"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The daemon's scheduled jobs, by the name --job-schedule and the
+// JobsService know them by.
+const (
+	JobTrafficCleanup   = "traffic-cleanup"
+	JobTrafficRollup    = "traffic-rollup"
+	JobTrafficCompact   = "traffic-compaction"
+	JobSnapshotSchedule = "snapshot-schedule"
+	JobOrphanGC         = "orphan-gc"
+)
+
+// DefaultJobSchedules is each job's schedule unless --job-schedule sets
+// it. orphan-gc removes resources, so running it unattended is left to
+// the operator; it can always be run by hand.
+var DefaultJobSchedules = map[string]string{
+	JobTrafficCleanup:   "0 3 * * *",
+	JobTrafficRollup:    "@every 1h",
+	JobTrafficCompact:   "0 4 * * *",
+	JobSnapshotSchedule: "@every 5m",
+	JobOrphanGC:         "off",
+}
+
+// jobHistoryPerJob is how many runs of each job are kept when there is no
+// PostgreSQL to keep them in.
+const jobHistoryPerJob = 200
+
+// maxJobHistory caps GetJobHistory's limit.
+const maxJobHistory = 1000
+
+// ValidateJobSchedules checks that schedules (--job-schedule) names only
+// known jobs and that each schedule parses.
+func ValidateJobSchedules(schedules map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(schedules)) {
+		if _, ok := DefaultJobSchedules[name]; !ok {
+			return fmt.Errorf("unknown job %q (jobs: %v)", name, slices.Sorted(maps.Keys(DefaultJobSchedules)))
+		}
+		if _, err := jobs.ParseSchedule(schedules[name]); err != nil {
+			return fmt.Errorf("job %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// newJobScheduler registers the daemon's jobs that apply to it: the
+// traffic jobs need a collector (cleanup a history store, rollup one that
+// bills usage, compaction one that compacts and an age to compact at,
+// snapshot conntrack), orphan-gc the container server.
+// schedules overrides DefaultJobSchedules.
+func newJobScheduler(history jobs.HistoryStore, collector *traffic.Collector, containers *ContainerServer, schedules map[string]string) *jobs.Scheduler {
+	scheduler := jobs.NewScheduler(history)
+	schedule := func(name string) string {
+		if spec, ok := schedules[name]; ok {
+			return spec
+		}
+		return DefaultJobSchedules[name]
+	}
+
+	var defs []jobs.Job
+	if collector != nil {
+		if collector.GetStore() != nil {
+			defs = append(defs, jobs.Job{
+				Name:        JobTrafficCleanup,
+				Description: "Delete traffic history older than --traffic-retention-days",
+				Run:         collector.Cleanup,
+			})
+		}
+		if _, ok := collector.GetStore().(traffic.UsageRollup); ok {
+			defs = append(defs, jobs.Job{
+				Name:        JobTrafficRollup,
+				Description: "Bill newly recorded traffic into the daily usage rollup",
+				Run: func(ctx context.Context) (int64, error) {
+					res, err := collector.RollupUsage(ctx, false)
+					return res.ConnectionsBilled, err
+				},
+			})
+		}
+		if collector.CompactionEnabled() {
+			defs = append(defs, jobs.Job{
+				Name:        JobTrafficCompact,
+				Description: "Roll connections older than --traffic-compact-after-days up into hourly aggregates and delete the raw rows",
+				Run:         collector.Compact,
+			})
+		}
+		if collector.IsAvailable() {
+			defs = append(defs, jobs.Job{
+				Name:        JobSnapshotSchedule,
+				Description: "Snapshot the conntrack table and checkpoint long-lived connections to traffic history",
+				Run:         collector.Snapshot,
+			})
+		}
+	}
+	if containers != nil {
+		defs = append(defs, jobs.Job{
+			Name:        JobOrphanGC,
+			Description: "Remove routes, passthrough routes, collaborators and accounts left behind by deleted containers",
+			Run: func(ctx context.Context) (int64, error) {
+				resp, err := containers.collectGarbage(ctx, false)
+				if err != nil {
+					return 0, err
+				}
+				var removed, failed int64
+				for _, o := range resp.Orphans {
+					if o.Error != "" {
+						failed++
+					} else {
+						removed++
+					}
+				}
+				if failed > 0 {
+					return removed, fmt.Errorf("%d orphaned resource(s) could not be removed", failed)
+				}
+				return removed, nil
+			},
+		})
+	}
+
+	registered := map[string]bool{}
+	for _, job := range defs {
+		job.Schedule = schedule(job.Name)
+		if err := scheduler.Register(job); err != nil {
+			// ValidateJobSchedules vetted the daemon's flags.
+			log.Printf("Warning: job %s not registered: %v", job.Name, err)
+			continue
+		}
+		registered[job.Name] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(schedules)) {
+		if !registered[name] {
+			log.Printf("Warning: --job-schedule %s ignored: the job doesn't apply to this daemon", name)
+		}
+	}
+	return scheduler
+}
+
+// JobsServer implements the gRPC JobsService over the daemon's job
+// scheduler.
+type JobsServer struct {
+	pb.UnimplementedJobsServiceServer
+	scheduler *jobs.Scheduler
+}
+
+// NewJobsServer creates a JobsServer for scheduler.
+func NewJobsServer(scheduler *jobs.Scheduler) *JobsServer {
+	return &JobsServer{scheduler: scheduler}
+}
+
+// ListJobs returns every job with its schedule and latest run.
+func (s *JobsServer) ListJobs(ctx context.Context, _ *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	if err := requireDaemonAdmin(ctx); err != nil {
+		return nil, err
+	}
+	resp := &pb.ListJobsResponse{}
+	for _, st := range s.scheduler.Jobs() {
+		resp.Jobs = append(resp.Jobs, jobStatusToProto(st))
+	}
+	return resp, nil
+}
+
+// RunJob starts a run of the job now.
+func (s *JobsServer) RunJob(ctx context.Context, req *pb.RunJobRequest) (*pb.RunJobResponse, error) {
+	if err := requireDaemonAdmin(ctx); err != nil {
+		return nil, err
+	}
+	run, err := s.scheduler.Trigger(req.Name)
+	if err != nil {
+		return nil, jobsError(err)
+	}
+	return &pb.RunJobResponse{Run: jobRunToProto(run)}, nil
+}
+
+// GetJobHistory returns the job's most recent runs.
+func (s *JobsServer) GetJobHistory(ctx context.Context, req *pb.GetJobHistoryRequest) (*pb.GetJobHistoryResponse, error) {
+	if err := requireDaemonAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	runs, err := s.scheduler.History(ctx, req.Name, min(int(req.Limit), maxJobHistory))
+	if err != nil {
+		return nil, jobsError(err)
+	}
+	resp := &pb.GetJobHistoryResponse{}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, jobRunToProto(run))
+	}
+	return resp, nil
+}
+
+// jobsError maps a scheduler error to a gRPC status.
+func jobsError(err error) error {
+	switch {
+	case errors.Is(err, jobs.ErrUnknownJob):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, jobs.ErrRunning):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return status.Errorf(codes.Internal, "%v", err)
+}
+
+func jobStatusToProto(st jobs.Status) *pb.Job {
+	job := &pb.Job{
+		Name:        st.Name,
+		Description: st.Description,
+		Schedule:    st.Schedule,
+		NextRun:     optionalTimestamp(st.Next),
+		Running:     st.Running,
+	}
+	if st.Last != nil {
+		job.LastRun = jobRunToProto(*st.Last)
+	}
+	return job
+}
+
+func jobRunToProto(run jobs.Run) *pb.JobRun {
+	return &pb.JobRun{
+		Id:           run.ID,
+		Job:          run.Job,
+		Trigger:      jobTriggers[run.Trigger],
+		StartedAt:    optionalTimestamp(run.StartedAt),
+		EndedAt:      optionalTimestamp(run.EndedAt),
+		Result:       jobResults[run.Result],
+		RowsAffected: run.RowsAffected,
+		Error:        run.Error,
+	}
+}
+
+var jobTriggers = map[string]pb.JobTrigger{
+	jobs.TriggerSchedule: pb.JobTrigger_JOB_TRIGGER_SCHEDULE,
+	jobs.TriggerManual:   pb.JobTrigger_JOB_TRIGGER_MANUAL,
+}
+
+var jobResults = map[string]pb.JobResult{
+	jobs.ResultRunning:   pb.JobResult_JOB_RESULT_RUNNING,
+	jobs.ResultSucceeded: pb.JobResult_JOB_RESULT_SUCCEEDED,
+	jobs.ResultFailed:    pb.JobResult_JOB_RESULT_FAILED,
+	jobs.ResultSkipped:   pb.JobResult_JOB_RESULT_SKIPPED,
+}
+
+// optionalTimestamp leaves the zero time unset.
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/jobs"
+	"github.com/footprintai/containarium/internal/traffic"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateJobSchedules(t *testing.T) {
+	if err := ValidateJobSchedules(map[string]string{
+		JobTrafficCleanup: "0 3 * * *",
+		JobOrphanGC:       "@weekly",
+		JobTrafficRollup:  "off",
+	}); err != nil {
+		t.Errorf("valid schedules: %v", err)
+	}
+	for name, schedules := range map[string]map[string]string{
+		"unknown job":  {"backup-everything": "@daily"},
+		"bad schedule": {JobTrafficCleanup: "nightly"},
+	} {
+		if err := ValidateJobSchedules(schedules); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
+
+func TestJobsServer_RequiresDaemonAdmin(t *testing.T) {
+	srv := NewJobsServer(jobs.NewScheduler(jobs.NewMemoryHistory(10)))
+	for name, ctx := range map[string]context.Context{
+		"wrong scope": auth.ContextWithTestSubjectScopes(context.Background(),
+			"ops", []string{auth.RoleAdmin}, []string{auth.ScopeKMSAdmin}),
+		"not admin": auth.ContextWithTestSubjectScopes(context.Background(),
+			"alice", []string{"user"}, []string{auth.ScopeDaemonAdmin}),
+	} {
+		if _, err := srv.ListJobs(ctx, &pb.ListJobsRequest{}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s: ListJobs got %v, want PermissionDenied", name, err)
+		}
+		if _, err := srv.RunJob(ctx, &pb.RunJobRequest{Name: JobOrphanGC}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s: RunJob got %v, want PermissionDenied", name, err)
+		}
+		if _, err := srv.GetJobHistory(ctx, &pb.GetJobHistoryRequest{Name: JobOrphanGC}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s: GetJobHistory got %v, want PermissionDenied", name, err)
+		}
+	}
+}
+
+func TestJobsServer_RunAndHistory(t *testing.T) {
+	scheduler := jobs.NewScheduler(jobs.NewMemoryHistory(10))
+	release := make(chan struct{})
+	if err := scheduler.Register(jobs.Job{
+		Name:     JobTrafficCleanup,
+		Schedule: "0 3 * * *",
+		Run: func(context.Context) (int64, error) {
+			<-release
+			return 12, nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	srv := NewJobsServer(scheduler)
+	ctx := daemonAdminCtx()
+
+	resp, err := srv.RunJob(ctx, &pb.RunJobRequest{Name: JobTrafficCleanup})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Run.Result != pb.JobResult_JOB_RESULT_RUNNING || resp.Run.Trigger != pb.JobTrigger_JOB_TRIGGER_MANUAL || resp.Run.EndedAt != nil {
+		t.Errorf("RunJob = %v, want a running manual run", resp.Run)
+	}
+	if _, err := srv.RunJob(ctx, &pb.RunJobRequest{Name: JobTrafficCleanup}); status.Code(err) != codes.Aborted {
+		t.Errorf("RunJob while running: got %v, want Aborted", err)
+	}
+	if _, err := srv.RunJob(ctx, &pb.RunJobRequest{Name: "nope"}); status.Code(err) != codes.NotFound {
+		t.Errorf("RunJob unknown: got %v, want NotFound", err)
+	}
+
+	list, err := srv.ListJobs(ctx, &pb.ListJobsRequest{})
+	if err != nil || len(list.Jobs) != 1 || !list.Jobs[0].Running || list.Jobs[0].Schedule != "0 3 * * *" {
+		t.Fatalf("ListJobs = %v, %v", list, err)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		hist, err := srv.GetJobHistory(ctx, &pb.GetJobHistoryRequest{Name: JobTrafficCleanup})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hist.Runs) == 1 && hist.Runs[0].Result == pb.JobResult_JOB_RESULT_SUCCEEDED {
+			if hist.Runs[0].RowsAffected != 12 || hist.Runs[0].EndedAt == nil || hist.Runs[0].Id != resp.Run.Id {
+				t.Errorf("history = %v", hist.Runs[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("run never finished: %v", hist.Runs)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := srv.GetJobHistory(ctx, &pb.GetJobHistoryRequest{Name: JobTrafficCleanup, Limit: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("negative limit: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestNewJobScheduler_RegistersApplicableJobs(t *testing.T) {
+	collector, err := traffic.NewCollector(traffic.CollectorConfig{
+		PersistenceEnabled: true,
+		RetentionDays:      7,
+	}, nil, traffic.NewMemoryStore(10), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduler := newJobScheduler(jobs.NewMemoryHistory(10), collector, nil, map[string]string{
+		JobTrafficCleanup: "@daily",
+		JobOrphanGC:       "@weekly", // no container server: not registered
+	})
+
+	st, err := scheduler.Job(JobTrafficCleanup)
+	if err != nil || st.Schedule != "@daily" {
+		t.Fatalf("traffic-cleanup = %+v, %v; want the overridden schedule", st, err)
+	}
+	if _, err := scheduler.Job(JobOrphanGC); err == nil {
+		t.Error("orphan-gc registered without a container server")
+	}
+	if _, err := scheduler.Job(JobTrafficCompact); err == nil {
+		t.Error("traffic-compaction registered for a store that can't compact")
+	}
+
+	if _, err := scheduler.Trigger(JobTrafficCleanup); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		runs, _ := scheduler.History(context.Background(), JobTrafficCleanup, 1)
+		if len(runs) == 1 && runs[0].Result == jobs.ResultSucceeded {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("cleanup run = %+v, want success", runs)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
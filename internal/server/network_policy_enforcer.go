@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/netip"
@@ -57,6 +58,19 @@ type FlowSink interface {
 	// PersistEBPFFlows writes a batch of closed/idle flows straight to history,
 	// independent of IngestEBPFFlows' disappearance diff — the idle reaper (#632).
 	PersistEBPFFlows(flows []traffic.EBPFFlow)
+	// ReportBlockedEBPFFlow announces a flow an enforce-mode policy just started
+	// dropping (counter + BLOCKED traffic event), once per flow.
+	ReportBlockedEBPFFlow(f traffic.EBPFFlow)
+}
+
+// blockedFlow identifies a flow an enforce-mode policy dropped, by what a deny
+// event carries. The event has no source port, so every attempt from the veth
+// at the same destination service shares one entry.
+type blockedFlow struct {
+	Ifindex uint32
+	Daddr   uint32
+	Dport   uint16
+	Proto   uint8
 }
 
 // defaultDomainRefreshInterval is how often egress_domains are re-resolved to
@@ -111,12 +125,13 @@ type NetworkPolicyEnforcer struct {
 	sigLoaded  string                      // last applied signature set fingerprint (skip redundant map writes)
 
 	mu                sync.Mutex
-	attached          map[int]string                      // ifindex -> container name currently attached
-	idName            map[uint32]string                   // tenant id -> name (for audit/log)
-	enforced          map[uint32]bool                     // tenant ids whose effective mode is ENFORCE (deny == dropped)
-	egressInstalled   map[netbpf.EgressEntry]bool         // egress LPM entries currently in the map
-	denyInstalled     map[netbpf.DenyKey]netbpf.DenyEntry // virtual-patch deny entries currently in the map (#660)
-	ipTenantInstalled map[[4]byte]uint32                  // ip_tenant entries currently in the map (#923: converge deletes)
+	attached          map[int]string                          // ifindex -> container name currently attached
+	idName            map[uint32]string                       // tenant id -> name (for audit/log)
+	enforced          map[uint32]bool                         // tenant ids whose effective mode is ENFORCE (deny == dropped)
+	egressInstalled   map[netbpf.EgressKey]netbpf.EgressEntry // egress LPM entries currently in the map
+	denyInstalled     map[netbpf.DenyKey]netbpf.DenyEntry     // virtual-patch deny entries currently in the map (#660)
+	ipTenantInstalled map[[4]byte]uint32                      // ip_tenant entries currently in the map (#923: converge deletes)
+	blocked           map[blockedFlow]time.Time               // dropped flows -> last drop; tags the flow view, pruned by pollFlows
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -141,9 +156,10 @@ func NewNetworkPolicyEnforcer(objPath string, store NetworkPolicyStore, registry
 		attached:          make(map[int]string),
 		idName:            make(map[uint32]string),
 		enforced:          make(map[uint32]bool),
-		egressInstalled:   make(map[netbpf.EgressEntry]bool),
+		egressInstalled:   make(map[netbpf.EgressKey]netbpf.EgressEntry),
 		denyInstalled:     make(map[netbpf.DenyKey]netbpf.DenyEntry),
 		ipTenantInstalled: make(map[[4]byte]uint32),
+		blocked:           make(map[blockedFlow]time.Time),
 	}
 }
 
@@ -438,6 +454,9 @@ func (e *NetworkPolicyEnforcer) OnDenyEvent(ctx context.Context, ev netbpf.DenyE
 		sigName = e.sigNames[ev.SigID]
 		e.mu.Unlock()
 	}
+	if dropped && !signature {
+		e.noteBlocked(ev)
+	}
 	if signature {
 		log.Printf("[netpolicy] signature: tenant=%q src=%s dst=%s dport=%d sig=%d(%s) %s",
 			tenant, ev.Src(), ev.Dst(), ev.Dport, ev.SigID, sigName, action)
@@ -484,6 +503,35 @@ func (e *NetworkPolicyEnforcer) OnDenyEvent(ctx context.Context, ev netbpf.DenyE
 	}
 }
 
+// noteBlocked remembers an egress flow the policy dropped so pollFlows tags it
+// as blocked, and reports it to the flow sink the first time it is seen. A
+// signature drop is left out: it hits the reply direction of a flow the
+// container accepted, which did connect.
+func (e *NetworkPolicyEnforcer) noteBlocked(ev netbpf.DenyEvent) {
+	key := blockedFlow{Ifindex: ev.Ifindex, Daddr: ev.Daddr, Dport: ev.Dport, Proto: ev.Proto}
+	now := time.Now()
+	e.mu.Lock()
+	_, seen := e.blocked[key]
+	e.blocked[key] = now
+	name := e.attached[int(ev.Ifindex)]
+	e.mu.Unlock()
+	if seen || name == "" || e.flowSink == nil {
+		return
+	}
+	src := ev.Src().String()
+	e.flowSink.ReportBlockedEBPFFlow(traffic.EBPFFlow{
+		ContainerName: name,
+		ContainerIP:   src,
+		Protocol:      protoName(ev.Proto),
+		SrcIP:         src,
+		DstIP:         ev.Dst().String(),
+		DstPort:       ev.Dport,
+		First:         now,
+		Last:          now,
+		Blocked:       true,
+	})
+}
+
 // pollFlows reads the BPF per-flow accounting map (#627), attributes each flow
 // to a container via the veth ifindex (exact — the map key carries it), and
 // hands the batch to the traffic collector. Flows on a veth that is no longer
@@ -497,20 +545,30 @@ func (e *NetworkPolicyEnforcer) pollFlows() {
 		log.Printf("[netpolicy] read flows: %v", err)
 		return
 	}
-	// Snapshot the ifindex -> container attribution under the lock.
+	// Snapshot the ifindex -> container attribution and the blocked flows under
+	// the lock. A blocked entry outlives the idle timeout twice over, so a flow
+	// is still tagged on the poll that reaps it; past that it is forgotten.
+	now := time.Now()
 	e.mu.Lock()
 	attached := make(map[int]string, len(e.attached))
 	for idx, name := range e.attached {
 		attached[idx] = name
 	}
+	blocked := make(map[blockedFlow]bool, len(e.blocked))
+	for key, last := range e.blocked {
+		if now.Sub(last) > 2*e.flowIdleTimeout {
+			delete(e.blocked, key)
+			continue
+		}
+		blocked[key] = true
+	}
 	e.mu.Unlock()
 
-	now := time.Now()
 	active, idle := splitIdleFlows(records, monotonicNowNs(), e.flowIdleTimeout)
 
 	// Live view = currently-active flows only (an idle flow being reaped this
 	// poll shouldn't show as a live connection).
-	e.flowSink.IngestEBPFFlows(flowsToEBPF(active, attached, now))
+	e.flowSink.IngestEBPFFlows(flowsToEBPF(active, attached, blocked, now))
 
 	// Idle reaper (#632): a flow whose last packet is older than the idle
 	// timeout has effectively closed. Persist its final counters to history,
@@ -519,7 +577,7 @@ func (e *NetworkPolicyEnforcer) pollFlows() {
 	// far-from-full 65536-entry map never disappeared, so closedFlows never
 	// fired and history stayed empty.
 	if len(idle) > 0 {
-		e.flowSink.PersistEBPFFlows(flowsToEBPF(idle, attached, now))
+		e.flowSink.PersistEBPFFlows(flowsToEBPF(idle, attached, blocked, now))
 		for _, r := range idle {
 			if err := e.loader.DeleteFlow(r); err != nil {
 				log.Printf("[netpolicy] reap idle flow: %v", err)
@@ -563,8 +621,10 @@ func monotonicNowNs() uint64 {
 // ifindex and renders it as a traffic.EBPFFlow. Records on an unmanaged veth are
 // dropped. Pure (no loader/clock access) so it is unit-testable. Absolute
 // first/last timestamps aren't recoverable from the monotonic ktime stamps, so
-// First is derived as now-duration to preserve the flow's age.
-func flowsToEBPF(records []netbpf.FlowRecord, attached map[int]string, now time.Time) []traffic.EBPFFlow {
+// First is derived as now-duration to preserve the flow's age. A flow matching
+// a blocked entry that never saw a reply is tagged Blocked; one with replies
+// got through before the policy changed.
+func flowsToEBPF(records []netbpf.FlowRecord, attached map[int]string, blocked map[blockedFlow]bool, now time.Time) []traffic.EBPFFlow {
 	out := make([]traffic.EBPFFlow, 0, len(records))
 	for _, r := range records {
 		name := attached[int(r.Ifindex)]
@@ -593,6 +653,7 @@ func flowsToEBPF(records []netbpf.FlowRecord, attached map[int]string, now time.
 			RxPackets:     safecast.I64FromU64(r.RxPackets), // 0 if object predates #631
 			First:         now.Add(-dur),                    // absolute first/last unknown; preserve duration
 			Last:          now,
+			Blocked:       r.RxPackets == 0 && blocked[blockedFlow{Ifindex: r.Ifindex, Daddr: r.Daddr, Dport: r.Dport, Proto: r.Proto}],
 		})
 	}
 	return out
@@ -608,6 +669,16 @@ func protoName(proto uint8) string {
 		return "tcp"
 	case 17:
 		return "udp"
+	case 33:
+		return "dccp"
+	case 47:
+		return "gre"
+	case 58:
+		return "icmpv6"
+	case 132:
+		return "sctp"
+	case 136:
+		return "udplite"
 	default:
 		return ""
 	}
@@ -641,24 +712,10 @@ func (e *NetworkPolicyEnforcer) reconcile(ctx context.Context) error {
 	// since-freed or re-purposed IP as a same-tenant peer until a daemon restart
 	// rebuilt the maps (#923).
 	applyIPTenant(e.ipTenantInstalled, plan.ipTenant, e.loader)
-	// egress allow-list: converge the map (add new, delete stale). Deleting
-	// removed CIDRs is what makes a tightened policy actually take effect in
-	// enforce mode.
-	toAdd, toDel := diffEgress(e.egressInstalled, plan.egress)
-	for _, ee := range toAdd {
-		if err := e.loader.AddEgress(ee); err != nil {
-			log.Printf("[netpolicy] add egress: %v", err)
-			continue
-		}
-		e.egressInstalled[ee] = true
-	}
-	for _, ee := range toDel {
-		if err := e.loader.DeleteEgress(ee); err != nil {
-			log.Printf("[netpolicy] delete egress: %v", err)
-			continue
-		}
-		delete(e.egressInstalled, ee)
-	}
+	// egress allow-list: converge the map (upsert new or re-scoped, delete stale).
+	// Deleting removed CIDRs is what makes a tightened policy actually take effect
+	// in enforce mode.
+	applyEgress(e.egressInstalled, plan.egress, e.loader)
 	// Virtual-patch deny rules (#660): converge the deny_cidr map the same way —
 	// upsert desired entries, delete keys no longer desired (a removed/expired
 	// rule must actually stop blocking). Only when the loaded object carries the
@@ -835,6 +892,58 @@ func (e *NetworkPolicyEnforcer) compiledPolicies(ctx context.Context) (map[strin
 	return out, nil
 }
 
+// EgressCheck explains what this backend's network policy does to one
+// packet from a container: the owning tenant's decision, and whether a deny
+// is actually dropped or only logged.
+type EgressCheck struct {
+	Tenant    string
+	HasPolicy bool
+	Decision  netpolicy.EgressDecision
+	// Enforced is true when a deny drops the packet: the policy is in
+	// ENFORCE mode and the daemon armed enforcement.
+	Enforced bool
+}
+
+// CheckEgress evaluates a packet from containerName against the stored
+// policy, without touching the kernel maps: what a connectivity test asks
+// before it dials. A container with no policy gets the reconcile default
+// (log-only, nothing allowed).
+func (e *NetworkPolicyEnforcer) CheckEgress(ctx context.Context, containerName string, pkt netpolicy.Egress) (*EgressCheck, error) {
+	containers, err := e.insp.ListContainers()
+	if err != nil {
+		return nil, err
+	}
+	var tenant string
+	for _, c := range containers {
+		t := resolveTenant(c.Tenant, c.Labels[cloudOrgIDLabel], c.Name)
+		if c.Name == containerName {
+			tenant = t
+		}
+		if c.Role != incus.RoleControlPlane && c.IPAddress == pkt.Dst.String() {
+			pkt.DstTenant = t
+		}
+	}
+	if tenant == "" {
+		return nil, fmt.Errorf("%s is not managed by network policy", containerName)
+	}
+
+	stored, err := e.store.Get(ctx, tenant)
+	if err != nil && !errors.Is(err, ErrNetworkPolicyNotFound) {
+		return nil, err
+	}
+	check := &EgressCheck{Tenant: tenant, HasPolicy: stored != nil}
+	policy := netpolicy.CompiledPolicy{Tenant: tenant, LogOnly: true}
+	if stored != nil {
+		if policy, err = netpolicy.Compile(stored); err != nil {
+			return nil, err
+		}
+		policy.DenyRules = activeDenyRules(policy.DenyRules, time.Now())
+	}
+	check.Decision = policy.CheckEgress(pkt, e.resolver.IPs)
+	check.Enforced = !check.Decision.Allowed && !policy.LogOnly && e.enforceEnabled
+	return check, nil
+}
+
 // refreshDomains re-resolves every egress_domain across all stored policies into
 // the resolver cache. Best-effort: store/lookup errors are logged, not fatal.
 func (e *NetworkPolicyEnforcer) refreshDomains() {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/footprintai/containarium/internal/netbpf"
+	"github.com/footprintai/containarium/internal/traffic"
 )
 
 func beIPv4(a, b, c, d byte) uint32 {
@@ -40,7 +41,7 @@ func TestFlowsToEBPF_AttributesAndMaps(t *testing.T) {
 	}
 	attached := map[int]string{59: "web-container"}
 
-	out := flowsToEBPF(records, attached, now)
+	out := flowsToEBPF(records, attached, nil, now)
 	if len(out) != 1 {
 		t.Fatalf("flowsToEBPF returned %d flows, want 1 (unmanaged veth dropped)", len(out))
 	}
@@ -71,8 +72,71 @@ func TestFlowsToEBPF_AttributesAndMaps(t *testing.T) {
 	}
 }
 
+// TestFlowsToEBPF_BlockedTagging — a flow matching a blocked entry is tagged
+// only while it has seen no reply; one that got replies connected before the
+// policy changed, and a flow to another port is a different service.
+func TestFlowsToEBPF_BlockedTagging(t *testing.T) {
+	dst := beIPv4(203, 0, 113, 7)
+	blocked := map[blockedFlow]bool{{Ifindex: 59, Daddr: dst, Dport: 443, Proto: 6}: true}
+	records := []netbpf.FlowRecord{
+		{Ifindex: 59, Daddr: dst, Sport: 51000, Dport: 443, Proto: 6, Packets: 3},               // dropped: no reply
+		{Ifindex: 59, Daddr: dst, Sport: 51001, Dport: 443, Proto: 6, Packets: 3, RxPackets: 2}, // replied
+		{Ifindex: 59, Daddr: dst, Sport: 51002, Dport: 80, Proto: 6, Packets: 3},                // other port
+	}
+	out := flowsToEBPF(records, map[int]string{59: "web-container"}, blocked, time.Unix(1_000_000, 0))
+	if len(out) != 3 {
+		t.Fatalf("flowsToEBPF returned %d flows, want 3", len(out))
+	}
+	for i, want := range []bool{true, false, false} {
+		if out[i].Blocked != want {
+			t.Errorf("flow %d (sport %d) Blocked = %v, want %v", i, out[i].SrcPort, out[i].Blocked, want)
+		}
+	}
+}
+
+// recordingFlowSink captures ReportBlockedEBPFFlow calls.
+type recordingFlowSink struct {
+	reported []traffic.EBPFFlow
+}
+
+func (s *recordingFlowSink) IngestEBPFFlows([]traffic.EBPFFlow)  {}
+func (s *recordingFlowSink) PersistEBPFFlows([]traffic.EBPFFlow) {}
+func (s *recordingFlowSink) ReportBlockedEBPFFlow(f traffic.EBPFFlow) {
+	s.reported = append(s.reported, f)
+}
+
+// TestNoteBlocked — a drop is remembered for flow tagging and reported to the
+// sink once per flow; a drop on an unattached veth is remembered but not
+// reported (there is no container to attribute it to).
+func TestNoteBlocked(t *testing.T) {
+	sink := &recordingFlowSink{}
+	e := NewNetworkPolicyEnforcer("", nil, nil, nil, nil, nil, true)
+	e.SetFlowSink(sink)
+	e.attached[59] = "web-container"
+
+	ev := netbpf.DenyEvent{Ifindex: 59, Saddr: beIPv4(10, 100, 0, 42), Daddr: beIPv4(203, 0, 113, 7), Dport: 443, Proto: 6}
+	e.noteBlocked(ev)
+	e.noteBlocked(ev) // a retry of the same flow
+	e.noteBlocked(netbpf.DenyEvent{Ifindex: 77, Daddr: ev.Daddr, Dport: 443, Proto: 6})
+
+	key := blockedFlow{Ifindex: 59, Daddr: ev.Daddr, Dport: 443, Proto: 6}
+	if _, ok := e.blocked[key]; !ok {
+		t.Errorf("blocked = %+v, want %+v remembered", e.blocked, key)
+	}
+	if len(e.blocked) != 2 {
+		t.Errorf("blocked has %d entries, want 2 (the unattached veth's drop is remembered too)", len(e.blocked))
+	}
+	if len(sink.reported) != 1 {
+		t.Fatalf("reported %d flows, want 1 (once per flow, attached veths only): %+v", len(sink.reported), sink.reported)
+	}
+	f := sink.reported[0]
+	if f.ContainerName != "web-container" || f.SrcIP != "10.100.0.42" || f.DstIP != "203.0.113.7" || f.DstPort != 443 || f.Protocol != "tcp" || !f.Blocked {
+		t.Errorf("reported flow = %+v", f)
+	}
+}
+
 func TestProtoName(t *testing.T) {
-	for proto, want := range map[uint8]string{1: "icmp", 6: "tcp", 17: "udp", 47: ""} {
+	for proto, want := range map[uint8]string{1: "icmp", 6: "tcp", 17: "udp", 58: "icmpv6", 132: "sctp", 50: ""} {
 		if got := protoName(proto); got != want {
 			t.Errorf("protoName(%d) = %q, want %q", proto, got, want)
 		}
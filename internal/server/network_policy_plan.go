@@ -122,32 +122,64 @@ func activeDenyRules(rules []netpolicy.DenyRule, now time.Time) []netpolicy.Deny
 	return out
 }
 
-// diffEgress computes the egress LPM entries to add and to delete so the kernel
-// map converges to the desired set: add anything desired but not installed,
-// delete anything installed but no longer desired. Deleting stale entries is
-// what makes a removed allow-CIDR actually stop allowing — load-bearing once a
-// tenant is in enforce mode. EgressEntry is comparable, so it keys the sets
-// directly.
-func diffEgress(installed map[netbpf.EgressEntry]bool, desired []netbpf.EgressEntry) (toAdd, toDel []netbpf.EgressEntry) {
-	desiredSet := make(map[netbpf.EgressEntry]bool, len(desired))
+// diffEgress computes the egress LPM entries to upsert and the keys to delete
+// so the kernel egress_cidr map converges to the desired set. Like a deny entry,
+// an allow entry's kernel key (EgressKey: tenant+CIDR) is narrower than the
+// entry, whose port/proto scope is the map VALUE — so an allow rule whose port
+// changed is an upsert of the same slot. Deleting stale keys is what makes a
+// removed allow-CIDR actually stop allowing — load-bearing once a tenant is in
+// enforce mode.
+func diffEgress(installed map[netbpf.EgressKey]netbpf.EgressEntry, desired []netbpf.EgressEntry) (toUpsert []netbpf.EgressEntry, toDel []netbpf.EgressKey) {
+	desiredKeys := make(map[netbpf.EgressKey]bool, len(desired))
 	for _, e := range desired {
-		desiredSet[e] = true
-		if !installed[e] {
-			toAdd = append(toAdd, e)
+		k := e.Key()
+		desiredKeys[k] = true
+		if cur, ok := installed[k]; !ok || cur != e {
+			toUpsert = append(toUpsert, e)
+		}
+	}
+	for k := range installed {
+		if !desiredKeys[k] {
+			toDel = append(toDel, k)
 		}
 	}
-	for e := range installed {
-		if !desiredSet[e] {
-			toDel = append(toDel, e)
+	return toUpsert, toDel
+}
+
+// egressApplier is the slice of the BPF loader the egress reconcile needs.
+// *netbpf.Loader satisfies it; an interface keeps applyEgress testable without
+// a kernel.
+type egressApplier interface {
+	AddEgress(netbpf.EgressEntry) error
+	DeleteEgress(netbpf.EgressKey) error
+}
+
+// applyEgress converges the kernel egress_cidr map from installed to desired
+// via the applier, updating installed in place — the allow-list twin of
+// applyDeny, with the same upsert-before-delete order and retry-on-failure
+// bookkeeping.
+func applyEgress(installed map[netbpf.EgressKey]netbpf.EgressEntry, desired []netbpf.EgressEntry, a egressApplier) {
+	upsert, del := diffEgress(installed, desired)
+	for _, ee := range upsert {
+		if err := a.AddEgress(ee); err != nil {
+			log.Printf("[netpolicy] add egress: %v", err)
+			continue
+		}
+		installed[ee.Key()] = ee
+	}
+	for _, ek := range del {
+		if err := a.DeleteEgress(ek); err != nil {
+			log.Printf("[netpolicy] delete egress: %v", err)
+			continue
 		}
+		delete(installed, ek)
 	}
-	return toAdd, toDel
 }
 
 // diffDeny computes the virtual-patch deny entries to upsert and the keys to
-// delete so the kernel deny_cidr map converges to the desired set (#660). Unlike
-// egress, a deny entry's kernel key (DenyKey: tenant+CIDR) is narrower than the
-// full entry (which also carries port/proto in the map VALUE) — so a rule whose
+// delete so the kernel deny_cidr map converges to the desired set (#660). A
+// deny entry's kernel key (DenyKey: tenant+CIDR) is narrower than the full
+// entry (which also carries port/proto in the map VALUE) — so a rule whose
 // port changed keeps the same key and is an UPSERT, not a delete+add of two map
 // slots. installed tracks the last-applied entry per key; an entry is upserted
 // when its key is new OR its port/proto differs from what's installed, and a key
@@ -140,20 +140,61 @@ func TestDiffEgress(t *testing.T) {
 	b := netbpf.EgressEntry{PrefixLen: 64, TenantID: 1, Addr: [4]byte{1, 1, 1, 1}}
 	c := netbpf.EgressEntry{PrefixLen: 40, TenantID: 2, Addr: [4]byte{10, 0, 0, 0}}
 
-	installed := map[netbpf.EgressEntry]bool{a: true, b: true}
+	installed := map[netbpf.EgressKey]netbpf.EgressEntry{a.Key(): a, b.Key(): b}
 	desired := []netbpf.EgressEntry{a, c} // keep a, drop b, add c
 
-	toAdd, toDel := diffEgress(installed, desired)
-	if len(toAdd) != 1 || toAdd[0] != c {
-		t.Errorf("toAdd = %+v, want [c]", toAdd)
+	toUpsert, toDel := diffEgress(installed, desired)
+	if len(toUpsert) != 1 || toUpsert[0] != c {
+		t.Errorf("toUpsert = %+v, want [c]", toUpsert)
 	}
-	if len(toDel) != 1 || toDel[0] != b {
-		t.Errorf("toDel = %+v, want [b]", toDel)
+	if len(toDel) != 1 || toDel[0] != b.Key() {
+		t.Errorf("toDel = %+v, want [b.Key()]", toDel)
 	}
 
 	// Converged state → no churn.
-	add2, del2 := diffEgress(map[netbpf.EgressEntry]bool{a: true, c: true}, []netbpf.EgressEntry{a, c})
+	add2, del2 := diffEgress(map[netbpf.EgressKey]netbpf.EgressEntry{a.Key(): a, c.Key(): c}, []netbpf.EgressEntry{a, c})
 	if len(add2) != 0 || len(del2) != 0 {
 		t.Errorf("converged diff should be empty, got add=%v del=%v", add2, del2)
 	}
 }
+
+// fakeEgressApplier records AddEgress/DeleteEgress calls so applyEgress is
+// testable without a kernel.
+type fakeEgressApplier struct {
+	added   []netbpf.EgressEntry
+	deleted []netbpf.EgressKey
+}
+
+func (f *fakeEgressApplier) AddEgress(e netbpf.EgressEntry) error {
+	f.added = append(f.added, e)
+	return nil
+}
+
+func (f *fakeEgressApplier) DeleteEgress(k netbpf.EgressKey) error {
+	f.deleted = append(f.deleted, k)
+	return nil
+}
+
+func TestApplyEgress_PortChangeIsUpsert(t *testing.T) {
+	a := netbpf.EgressEntry{PrefixLen: 64, TenantID: 1, Addr: [4]byte{1, 2, 3, 4}, Port: 443, Proto: 6}
+	a2 := netbpf.EgressEntry{PrefixLen: 64, TenantID: 1, Addr: [4]byte{1, 2, 3, 4}, Port: 8443, Proto: 6} // same key as a
+
+	installed := map[netbpf.EgressKey]netbpf.EgressEntry{a.Key(): a}
+	f := &fakeEgressApplier{}
+	applyEgress(installed, []netbpf.EgressEntry{a2}, f)
+	if len(f.added) != 1 || f.added[0] != a2 {
+		t.Errorf("added=%v, want [a2] (upsert)", f.added)
+	}
+	if len(f.deleted) != 0 {
+		t.Fatalf("deleted=%v, want none — the re-scoped rule shares a's key", f.deleted)
+	}
+	if installed[a.Key()] != a2 {
+		t.Errorf("installed[key] = %+v, want a2", installed[a.Key()])
+	}
+
+	// Removing the rule deletes its key.
+	applyEgress(installed, nil, f)
+	if len(f.deleted) != 1 || f.deleted[0] != a.Key() || len(installed) != 0 {
+		t.Errorf("deleted=%v installed=%v, want a's key deleted", f.deleted, installed)
+	}
+}
@@ -125,9 +125,23 @@ func clonePolicy(p *pb.NetworkPolicy) *pb.NetworkPolicy {
 		Mode:             p.GetMode(),
 		Source:           p.GetSource(),
 		DenyRules:        cloneDenyRules(p.GetDenyRules()),
+		DefaultEgress:    p.GetDefaultEgress(),
+		AllowRules:       cloneAllowRules(p.GetAllowRules()),
 	}
 }
 
+// cloneAllowRules deep-copies an allow-rule slice, like cloneDenyRules.
+func cloneAllowRules(in []*pb.NetworkPolicyAllowRule) []*pb.NetworkPolicyAllowRule {
+	if in == nil {
+		return nil
+	}
+	out := make([]*pb.NetworkPolicyAllowRule, len(in))
+	for i, r := range in {
+		out[i] = &pb.NetworkPolicyAllowRule{Cidr: r.GetCidr(), Port: r.GetPort(), Proto: r.GetProto()}
+	}
+	return out
+}
+
 // cloneDenyRules deep-copies a deny-rule slice so stored state can't be mutated
 // through a returned pointer (and vice versa).
 func cloneDenyRules(in []*pb.NetworkPolicyDenyRule) []*pb.NetworkPolicyDenyRule {
@@ -184,6 +198,40 @@ func decodeDenyRules(b []byte) ([]*pb.NetworkPolicyDenyRule, error) {
 	return out, nil
 }
 
+// allowRuleRow is the JSON shape stored in the network_policies.allow_rules
+// JSONB column.
+type allowRuleRow struct {
+	Cidr  string `json:"cidr"`
+	Port  uint32 `json:"port,omitempty"`
+	Proto string `json:"proto,omitempty"`
+}
+
+func encodeAllowRules(rules []*pb.NetworkPolicyAllowRule) ([]byte, error) {
+	rows := make([]allowRuleRow, 0, len(rules))
+	for _, r := range rules {
+		rows = append(rows, allowRuleRow{r.GetCidr(), r.GetPort(), r.GetProto()})
+	}
+	return json.Marshal(rows)
+}
+
+func decodeAllowRules(b []byte) ([]*pb.NetworkPolicyAllowRule, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	var rows []allowRuleRow
+	if err := json.Unmarshal(b, &rows); err != nil {
+		return nil, fmt.Errorf("decode allow_rules: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	out := make([]*pb.NetworkPolicyAllowRule, len(rows))
+	for i, r := range rows {
+		out[i] = &pb.NetworkPolicyAllowRule{Cidr: r.Cidr, Port: r.Port, Proto: r.Proto}
+	}
+	return out, nil
+}
+
 // --- postgres -------------------------------------------------------
 
 // PostgresNetworkPolicyStore persists policies in a network_policies table.
@@ -204,14 +252,19 @@ func NewPostgresNetworkPolicyStore(ctx context.Context, pool *pgxpool.Pool) (*Po
 			allow_metadata BOOLEAN NOT NULL DEFAULT false,
 			source TEXT NOT NULL DEFAULT '',
 			deny_rules JSONB NOT NULL DEFAULT '[]',
+			default_egress INTEGER NOT NULL DEFAULT 0,
+			allow_rules JSONB NOT NULL DEFAULT '[]',
 			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 		);
 		-- Non-destructive upgrades for tables created before these columns
 		-- (allow_metadata: #315 Phase D; source: #354 convergence;
-		-- deny_rules: #660 virtual patching).
+		-- deny_rules: #660 virtual patching; default_egress: default-allow policies;
+		-- allow_rules: port/proto-scoped allow rules).
 		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS allow_metadata BOOLEAN NOT NULL DEFAULT false;
 		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT '';
 		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS deny_rules JSONB NOT NULL DEFAULT '[]';
+		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS default_egress INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS allow_rules JSONB NOT NULL DEFAULT '[]';
 	`
 	if _, err := pool.Exec(ctx, schema); err != nil {
 		return nil, fmt.Errorf("init network_policies schema: %w", err)
@@ -226,8 +279,8 @@ func (s *PostgresNetworkPolicyStore) Set(ctx context.Context, p *pb.NetworkPolic
 	// existing tenant's deny rules untouched — so `set` never clobbers them and
 	// needs no client round-trip.
 	const q = `
-		INSERT INTO network_policies (tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, '[]'::jsonb, NOW())
+		INSERT INTO network_policies (tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, default_egress, allow_rules, deny_rules, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::jsonb, '[]'::jsonb, NOW())
 		ON CONFLICT (tenant) DO UPDATE SET
 			allow_intra_tenant = EXCLUDED.allow_intra_tenant,
 			egress_cidrs = EXCLUDED.egress_cidrs,
@@ -235,6 +288,8 @@ func (s *PostgresNetworkPolicyStore) Set(ctx context.Context, p *pb.NetworkPolic
 			mode = EXCLUDED.mode,
 			allow_metadata = EXCLUDED.allow_metadata,
 			source = EXCLUDED.source,
+			default_egress = EXCLUDED.default_egress,
+			allow_rules = EXCLUDED.allow_rules,
 			updated_at = NOW()
 	`
 	// egress_cidrs / egress_domains are `TEXT[] NOT NULL DEFAULT '{}'`, but the
@@ -243,10 +298,14 @@ func (s *PostgresNetworkPolicyStore) Set(ctx context.Context, p *pb.NetworkPolic
 	// NOT NULL constraint (SQLSTATE 23502). A policy that allows no domains (or no
 	// CIDRs) arrives with a nil slice, so coerce nil -> empty so the array lands
 	// as '{}' rather than NULL.
-	_, err := s.pool.Exec(ctx, q,
+	allowJSON, err := encodeAllowRules(p.GetAllowRules())
+	if err != nil {
+		return err
+	}
+	_, err = s.pool.Exec(ctx, q,
 		p.GetTenant(), p.GetAllowIntraTenant(),
 		nonNilStrings(p.GetEgressCidrs()), nonNilStrings(p.GetEgressDomains()), int32(p.GetMode()),
-		p.GetAllowMetadata(), p.GetSource())
+		p.GetAllowMetadata(), p.GetSource(), int32(p.GetDefaultEgress()), string(allowJSON))
 	if err != nil {
 		return fmt.Errorf("save network policy: %w", err)
 	}
@@ -263,12 +322,12 @@ func nonNilStrings(s []string) []string {
 }
 
 func (s *PostgresNetworkPolicyStore) Get(ctx context.Context, tenant string) (*pb.NetworkPolicy, error) {
-	const q = `SELECT tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules
+	const q = `SELECT tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, default_egress, deny_rules, allow_rules
 		FROM network_policies WHERE tenant = $1`
 	p := &pb.NetworkPolicy{}
-	var mode int32
-	var denyJSON []byte
-	err := s.pool.QueryRow(ctx, q, tenant).Scan(&p.Tenant, &p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON)
+	var mode, defaultEgress int32
+	var denyJSON, allowJSON []byte
+	err := s.pool.QueryRow(ctx, q, tenant).Scan(&p.Tenant, &p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &defaultEgress, &denyJSON, &allowJSON)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNetworkPolicyNotFound
@@ -276,14 +335,18 @@ func (s *PostgresNetworkPolicyStore) Get(ctx context.Context, tenant string) (*p
 		return nil, fmt.Errorf("get network policy: %w", err)
 	}
 	p.Mode = pb.NetworkPolicyMode(mode)
+	p.DefaultEgress = pb.NetworkPolicyDefaultAction(defaultEgress)
 	if p.DenyRules, err = decodeDenyRules(denyJSON); err != nil {
 		return nil, err
 	}
+	if p.AllowRules, err = decodeAllowRules(allowJSON); err != nil {
+		return nil, err
+	}
 	return p, nil
 }
 
 func (s *PostgresNetworkPolicyStore) List(ctx context.Context) ([]*pb.NetworkPolicy, error) {
-	const q = `SELECT tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules
+	const q = `SELECT tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, default_egress, deny_rules, allow_rules
 		FROM network_policies ORDER BY tenant`
 	rows, err := s.pool.Query(ctx, q)
 	if err != nil {
@@ -293,15 +356,19 @@ func (s *PostgresNetworkPolicyStore) List(ctx context.Context) ([]*pb.NetworkPol
 	var out []*pb.NetworkPolicy
 	for rows.Next() {
 		p := &pb.NetworkPolicy{}
-		var mode int32
-		var denyJSON []byte
-		if err := rows.Scan(&p.Tenant, &p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON); err != nil {
+		var mode, defaultEgress int32
+		var denyJSON, allowJSON []byte
+		if err := rows.Scan(&p.Tenant, &p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &defaultEgress, &denyJSON, &allowJSON); err != nil {
 			return nil, fmt.Errorf("scan network policy: %w", err)
 		}
 		p.Mode = pb.NetworkPolicyMode(mode)
+		p.DefaultEgress = pb.NetworkPolicyDefaultAction(defaultEgress)
 		if p.DenyRules, err = decodeDenyRules(denyJSON); err != nil {
 			return nil, err
 		}
+		if p.AllowRules, err = decodeAllowRules(allowJSON); err != nil {
+			return nil, err
+		}
 		out = append(out, p)
 	}
 	return out, rows.Err()
@@ -325,15 +392,19 @@ func (s *PostgresNetworkPolicyStore) MutateDenyRules(ctx context.Context, tenant
 	}
 
 	p := &pb.NetworkPolicy{Tenant: tenant}
-	var mode int32
-	var denyJSON []byte
-	err = tx.QueryRow(ctx, `SELECT allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules
+	var mode, defaultEgress int32
+	var denyJSON, allowJSON []byte
+	err = tx.QueryRow(ctx, `SELECT allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, default_egress, deny_rules, allow_rules
 		FROM network_policies WHERE tenant = $1 FOR UPDATE`, tenant).
-		Scan(&p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON)
+		Scan(&p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &defaultEgress, &denyJSON, &allowJSON)
 	if err != nil {
 		return nil, fmt.Errorf("lock policy: %w", err)
 	}
 	p.Mode = pb.NetworkPolicyMode(mode)
+	p.DefaultEgress = pb.NetworkPolicyDefaultAction(defaultEgress)
+	if p.AllowRules, err = decodeAllowRules(allowJSON); err != nil {
+		return nil, err
+	}
 	existing, err := decodeDenyRules(denyJSON)
 	if err != nil {
 		return nil, err
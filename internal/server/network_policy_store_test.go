@@ -102,3 +102,40 @@ func TestEncodeDecodeDenyRules(t *testing.T) {
 		}
 	}
 }
+
+// TestEncodeDecodeAllowRules round-trips the allow_rules JSONB shape, and the
+// mem store keeps allow rules across Set/Get.
+func TestEncodeDecodeAllowRules(t *testing.T) {
+	in := []*pb.NetworkPolicyAllowRule{
+		{Cidr: "1.2.3.4/32", Port: 443, Proto: "tcp"},
+		{Cidr: "192.0.2.0/24"},
+	}
+	b, err := encodeAllowRules(in)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	out, err := decodeAllowRules(b)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(out) != 2 || out[0].GetCidr() != "1.2.3.4/32" || out[0].GetPort() != 443 || out[0].GetProto() != "tcp" || out[1].GetCidr() != "192.0.2.0/24" {
+		t.Fatalf("round-trip mismatch: %+v", out)
+	}
+	if got, err := decodeAllowRules([]byte("[]")); err != nil || got != nil {
+		t.Errorf("decodeAllowRules([]) = (%v, %v), want (nil, nil)", got, err)
+	}
+
+	ctx := context.Background()
+	s := NewMemNetworkPolicyStore()
+	if err := s.Set(ctx, &pb.NetworkPolicy{Tenant: "acme", AllowRules: in}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	in[0].Port = 80 // stored state must not alias the caller's rules
+	got, err := s.Get(ctx, "acme")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(got.GetAllowRules()) != 2 || got.GetAllowRules()[0].GetPort() != 443 {
+		t.Errorf("stored allow rules = %+v, want the 443 rule kept", got.GetAllowRules())
+	}
+}
@@ -2,11 +2,15 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/footprintai/containarium/internal/app"
 	"github.com/footprintai/containarium/internal/auth"
@@ -18,6 +22,7 @@ import (
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // NetworkServer implements the NetworkService gRPC service
@@ -34,6 +39,32 @@ type NetworkServer struct {
 	baseDomain         string                   // e.g., "example.com"
 	emitter            *events.Emitter
 	egressMgr          *egressproxy.Manager // egress-via-client relays, keyed by box (#808)
+
+	// containerIPLookup overrides the Incus lookup used to resolve a
+	// passthrough route's container to its current IP (tests).
+	containerIPLookup func(containerName string) (string, error)
+
+	// containerAtLookup overrides the Incus lookup of the container with
+	// a given IP, used when adopting a foreign DNAT rule (tests).
+	containerAtLookup func(ip string) (string, error)
+
+	// portConflictCheck overrides the host probe (listening sockets and
+	// foreign NAT rules) run before a passthrough route is added (tests).
+	portConflictCheck func(port int, protocol string) error
+
+	// reservedPorts are host ports no passthrough route may take, force
+	// or not (see daemonReservedPorts). A config reload replaces them
+	// (setReservedPorts).
+	reservedMu    sync.RWMutex
+	reservedPorts network.ReservedPorts
+
+	// caddyCertDir is Caddy's storage directory, read to report each
+	// route's certificate. Empty leaves certificates unreported.
+	caddyCertDir string
+
+	// shaper enforces per-container bandwidth limits; nil without
+	// PostgreSQL, which disables the bandwidth-limit RPCs.
+	shaper *network.TrafficShaper
 }
 
 // resolveFullDomain determines the full domain from a user-provided domain string.
@@ -132,6 +163,7 @@ func (s *NetworkServer) GetRoutes(ctx context.Context, req *pb.GetRoutesRequest)
 				Protocol:      protocol,
 				AppName:       containerName,
 				ContainerName: containerName,
+				Certificate:   s.routeCertificate(route.FullDomain, protocol),
 			}
 			pbRoutes = append(pbRoutes, pbRoute)
 		}
@@ -202,6 +234,7 @@ func (s *NetworkServer) GetRoutes(ctx context.Context, req *pb.GetRoutesRequest)
 			Protocol:      routeProtocolToProto(route.Protocol),
 			AppName:       containerName, // legacy: container name doubled as app name for display
 			ContainerName: containerName,
+			Certificate:   s.routeCertificate(route.FullDomain, routeProtocolToProto(route.Protocol)),
 		}
 		pbRoutes = append(pbRoutes, pbRoute)
 	}
@@ -212,6 +245,33 @@ func (s *NetworkServer) GetRoutes(ctx context.Context, req *pb.GetRoutesRequest)
 	}, nil
 }
 
+// routeCertificate reports the certificate Caddy holds for a route's
+// domain, or nil when it can't be known: no cert dir configured, or a TLS
+// passthrough route whose container terminates TLS itself.
+func (s *NetworkServer) routeCertificate(domain string, protocol pb.RouteProtocol) *pb.RouteCertificate {
+	if s.caddyCertDir == "" || protocol == pb.RouteProtocol_ROUTE_PROTOCOL_TLS_PASSTHROUGH {
+		return nil
+	}
+	info, ok, err := app.FindCertificate(s.caddyCertDir, domain)
+	if err != nil {
+		log.Printf("Warning: failed to read certificate for %s: %v", domain, err)
+		return nil
+	}
+	if !ok {
+		return &pb.RouteCertificate{Status: pb.CertificateStatus_CERTIFICATE_STATUS_PENDING}
+	}
+	cert := &pb.RouteCertificate{
+		Status:   pb.CertificateStatus_CERTIFICATE_STATUS_ISSUED,
+		Subject:  info.Subject,
+		Issuer:   info.Issuer,
+		NotAfter: timestamppb.New(info.NotAfter),
+	}
+	if time.Now().After(info.NotAfter) {
+		cert.Status = pb.CertificateStatus_CERTIFICATE_STATUS_EXPIRED
+	}
+	return cert
+}
+
 // AddRoute adds a new proxy route (saves to PostgreSQL, sync job updates Caddy).
 // Admin-only — routes can point at any container IP and steal traffic
 // addressed to other tenants.
@@ -226,12 +286,22 @@ func (s *NetworkServer) AddRoute(ctx context.Context, req *pb.AddRouteRequest) (
 	if req.Domain == "" {
 		return nil, fmt.Errorf("domain is required")
 	}
-	if req.TargetIp == "" {
-		return nil, fmt.Errorf("target_ip is required")
+	if req.TargetIp == "" && req.ContainerName == "" {
+		return nil, fmt.Errorf("target_ip or container_name is required")
 	}
 	if req.TargetPort <= 0 {
 		return nil, fmt.Errorf("target_port must be positive")
 	}
+	// A route declared by container alone targets its current IP; the
+	// retargeter keeps it there when the container is recreated.
+	if req.TargetIp == "" {
+		req.ContainerName = passthroughContainerName(req.ContainerName)
+		ip, err := s.resolvePassthroughTarget(req.ContainerName, "")
+		if err != nil {
+			return nil, err
+		}
+		req.TargetIp = ip
+	}
 
 	// Determine full domain
 	subdomain := req.Domain
@@ -514,6 +584,9 @@ func (s *NetworkServer) ListPassthroughRoutes(ctx context.Context, req *pb.ListP
 				Active:        rec.Active,
 				ContainerName: containerName,
 				Description:   rec.Description,
+				InInterface:   rec.InInterface,
+				SnatSource:    rec.SNATSource,
+				AllowSources:  rec.AllowSources,
 			})
 		}
 
@@ -549,6 +622,9 @@ func (s *NetworkServer) ListPassthroughRoutes(ctx context.Context, req *pb.ListP
 			Active:        route.Active,
 			ContainerName: containerName,
 			Description:   route.Description,
+			InInterface:   route.InInterface,
+			SnatSource:    route.SNATSource,
+			AllowSources:  route.AllowSources,
 		})
 	}
 
@@ -559,22 +635,28 @@ func (s *NetworkServer) ListPassthroughRoutes(ctx context.Context, req *pb.ListP
 }
 
 // AddPassthroughRoute adds a new TCP/UDP passthrough route.
-// Admin-only — passthrough binds a host-wide port (iptables/nftables),
-// outside any tenant's scope.
+// Passthrough binds a host-wide port (iptables/nftables), so arbitrary
+// targets are admin-only. Non-admins may only route to their own
+// container, named via container_name; its current IP is resolved here,
+// and a target_ip given alongside it must match.
 func (s *NetworkServer) AddPassthroughRoute(ctx context.Context, req *pb.AddPassthroughRouteRequest) (*pb.AddPassthroughRouteResponse, error) {
-	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+	if err := auth.RequireScope(ctx, auth.ScopeRoutesWrite); err != nil {
 		return nil, err
 	}
+	subject, roles, ok := auth.SubjectFromGRPCContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "no authenticated subject")
+	}
 	// Validate request
 	if req.ExternalPort <= 0 || req.ExternalPort > 65535 {
 		return nil, fmt.Errorf("external_port must be between 1 and 65535")
 	}
-	if req.TargetIp == "" {
-		return nil, fmt.Errorf("target_ip is required")
-	}
 	if req.TargetPort <= 0 || req.TargetPort > 65535 {
 		return nil, fmt.Errorf("target_port must be between 1 and 65535")
 	}
+	if err := s.checkReservedPort(int(req.ExternalPort)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	// Determine protocol
 	protocol := "tcp"
@@ -582,16 +664,46 @@ func (s *NetworkServer) AddPassthroughRoute(ctx context.Context, req *pb.AddPass
 		protocol = "udp"
 	}
 
+	containerName := passthroughContainerName(req.ContainerName)
+	if !auth.HasRole(roles, auth.RoleAdmin) {
+		if err := s.authorizeTenantPassthrough(ctx, int(req.ExternalPort), protocol, containerName); err != nil {
+			return nil, err
+		}
+	}
+	targetIP := req.TargetIp
+	if containerName != "" {
+		ip, err := s.resolvePassthroughTarget(containerName, targetIP)
+		if err != nil {
+			return nil, err
+		}
+		targetIP = ip
+	}
+	if targetIP == "" {
+		return nil, fmt.Errorf("target_ip or container_name is required")
+	}
+	if !req.Force {
+		if err := s.checkPassthroughPort(int(req.ExternalPort), protocol); err != nil {
+			return nil, err
+		}
+	}
+	opts, err := s.resolvePassthroughOptions(req.InInterface, req.SnatSource, req.AllowSources)
+	if err != nil {
+		return nil, err
+	}
+	opts.Container = containerName
+
 	// If PassthroughStore is available, save to PostgreSQL (source of truth)
 	if s.passthroughStore != nil {
 		record := &network.PassthroughRecord{
 			ExternalPort:  int(req.ExternalPort),
-			TargetIP:      req.TargetIp,
+			TargetIP:      targetIP,
 			TargetPort:    int(req.TargetPort),
 			Protocol:      protocol,
-			ContainerName: req.ContainerName,
+			ContainerName: containerName,
 			Description:   req.Description,
 			Active:        true,
+			CreatedBy:     subject,
+			RouteOptions:  opts,
 		}
 
 		if err := s.passthroughStore.Save(ctx, record); err != nil {
@@ -599,27 +711,96 @@ func (s *NetworkServer) AddPassthroughRoute(ctx context.Context, req *pb.AddPass
 		}
 	} else {
 		// Fallback: directly add to iptables (legacy behavior)
-		if err := s.passthroughManager.AddRoute(int(req.ExternalPort), req.TargetIp, int(req.TargetPort), protocol); err != nil {
+		if err := s.passthroughManager.AddRouteWithOptions(int(req.ExternalPort), targetIP, int(req.TargetPort), protocol, opts); err != nil {
 			return nil, fmt.Errorf("failed to add passthrough route: %w", err)
 		}
 	}
 
 	route := &pb.PassthroughRoute{
 		ExternalPort:  req.ExternalPort,
-		TargetIp:      req.TargetIp,
+		TargetIp:      targetIP,
 		TargetPort:    req.TargetPort,
 		Protocol:      req.Protocol,
 		Active:        true,
-		ContainerName: req.ContainerName,
+		ContainerName: containerName,
 		Description:   req.Description,
+		InInterface:   opts.InInterface,
+		SnatSource:    opts.SNATSource,
+		AllowSources:  opts.AllowSources,
 	}
 
 	return &pb.AddPassthroughRouteResponse{
 		Route:   route,
-		Message: fmt.Sprintf("Passthrough route added: %s:%d -> %s:%d (will sync to iptables)", protocol, req.ExternalPort, req.TargetIp, req.TargetPort),
+		Message: fmt.Sprintf("Passthrough route added: %s:%d -> %s:%d (will sync to iptables)", protocol, req.ExternalPort, targetIP, req.TargetPort),
 	}, nil
 }
 
+// resolvePassthroughOptions resolves the requested interface binding, SNAT
+// source ("auto", "host", or literal values) and allowed sources into what
+// gets stored and installed.
+func (s *NetworkServer) resolvePassthroughOptions(inIface, snatSource string, allowSources []string) (network.RouteOptions, error) {
+	if inIface == "" && snatSource == "" && len(allowSources) == 0 {
+		return network.RouteOptions{}, nil
+	}
+	if s.passthroughManager == nil {
+		return network.RouteOptions{}, status.Error(codes.FailedPrecondition, "passthrough routing is not available on this host")
+	}
+	opts, err := s.passthroughManager.ResolveRouteOptions(inIface, snatSource, allowSources)
+	if err != nil {
+		return network.RouteOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return opts, nil
+}
+
+// checkPassthroughPort rejects an external port the host already uses:
+// the DNAT rule would either never see the traffic or hijack it from the
+// service that expects it. A probe that can't read the host state is
+// logged and not treated as a conflict.
+func (s *NetworkServer) checkPassthroughPort(port int, protocol string) error {
+	check := s.portConflictCheck
+	if check == nil {
+		if s.passthroughManager == nil {
+			return nil
+		}
+		check = s.passthroughManager.CheckPortConflict
+	}
+	err := check(port, protocol)
+	var conflict *network.PortConflictError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &conflict):
+		return status.Errorf(codes.FailedPrecondition, "%v (set force to add the route anyway)", conflict)
+	default:
+		log.Printf("Warning: could not check host port %d/%s for conflicts: %v", port, protocol, err)
+		return nil
+	}
+}
+
+// passthroughLiveContainers is what the orphan sweeper checks passthrough
+// rules against: every incus container, plus the targets of active
+// registry routes, whose rules are the sync job's to add and remove.
+func (s *NetworkServer) passthroughLiveContainers() (*network.LiveContainers, error) {
+	containers, err := s.incusClient.ListContainers()
+	if err != nil {
+		return nil, err
+	}
+	live := network.NewLiveContainers()
+	for _, c := range containers {
+		live.Add(c.Name, c.IPAddress)
+	}
+	if s.passthroughStore != nil {
+		records, err := s.passthroughStore.List(context.Background(), true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list passthrough routes: %w", err)
+		}
+		for _, r := range records {
+			live.Add(r.ContainerName, r.TargetIP)
+		}
+	}
+	return live, nil
+}
+
 // DeletePassthroughRoute removes a TCP/UDP passthrough route.
 // Admin-only.
 func (s *NetworkServer) DeletePassthroughRoute(ctx context.Context, req *pb.DeletePassthroughRouteRequest) (*pb.DeletePassthroughRouteResponse, error) {
@@ -637,6 +818,23 @@ func (s *NetworkServer) DeletePassthroughRoute(ctx context.Context, req *pb.Dele
 		protocol = "udp"
 	}
 
+	// Look the route up first: a dry run reports it, a real run echoes what
+	// it removed. Deletes stay idempotent, so a missing route isn't an error.
+	route, lookupErr := s.findPassthroughRoute(ctx, int(req.ExternalPort), protocol)
+	if req.DryRun {
+		if lookupErr != nil {
+			return nil, fmt.Errorf("failed to look up passthrough route: %w", lookupErr)
+		}
+		msg := fmt.Sprintf("Dry run: no passthrough route on %s:%d; nothing would be removed", protocol, req.ExternalPort)
+		if route != nil {
+			msg = fmt.Sprintf("Dry run: would remove passthrough route %s:%d -> %s:%d", protocol, req.ExternalPort, route.TargetIp, route.TargetPort)
+		}
+		return &pb.DeletePassthroughRouteResponse{Message: msg, Route: route, DryRun: true}, nil
+	}
+	if lookupErr != nil {
+		log.Printf("Warning: failed to look up passthrough route %s:%d before removing it: %v", protocol, req.ExternalPort, lookupErr)
+	}
+
 	// If PassthroughStore is available, delete from PostgreSQL (source of truth)
 	if s.passthroughStore != nil {
 		err := s.passthroughStore.Delete(ctx, int(req.ExternalPort), protocol)
@@ -652,15 +850,76 @@ func (s *NetworkServer) DeletePassthroughRoute(ctx context.Context, req *pb.Dele
 
 	return &pb.DeletePassthroughRouteResponse{
 		Message: fmt.Sprintf("Passthrough route removed: %s:%d (will sync to iptables)", protocol, req.ExternalPort),
+		Route:   route,
+	}, nil
+}
+
+// findPassthroughRoute returns the route on port/protocol from the store,
+// or from iptables when there is none; nil if no such route exists.
+func (s *NetworkServer) findPassthroughRoute(ctx context.Context, port int, protocol string) (*pb.PassthroughRoute, error) {
+	var r network.PassthroughRoute
+	if s.passthroughStore != nil {
+		rec, err := s.passthroughStore.GetByPortProtocol(ctx, port, protocol)
+		if errors.Is(err, network.ErrPassthroughNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		r = network.PassthroughRoute{
+			ExternalPort:  rec.ExternalPort,
+			TargetIP:      rec.TargetIP,
+			TargetPort:    rec.TargetPort,
+			Protocol:      rec.Protocol,
+			ContainerName: rec.ContainerName,
+			Description:   rec.Description,
+			Active:        rec.Active,
+			RouteOptions:  rec.RouteOptions,
+		}
+	} else {
+		routes, err := s.passthroughManager.ListRoutes()
+		if err != nil {
+			return nil, err
+		}
+		i := slices.IndexFunc(routes, func(r network.PassthroughRoute) bool {
+			return r.ExternalPort == port && r.Protocol == protocol
+		})
+		if i < 0 {
+			return nil, nil
+		}
+		r = routes[i]
+	}
+
+	pbProtocol := pb.RouteProtocol_ROUTE_PROTOCOL_TCP
+	if r.Protocol == "udp" {
+		pbProtocol = pb.RouteProtocol_ROUTE_PROTOCOL_UDP
+	}
+	return &pb.PassthroughRoute{
+		ExternalPort:  safecast.I32(r.ExternalPort),
+		TargetIp:      r.TargetIP,
+		TargetPort:    safecast.I32(r.TargetPort),
+		Protocol:      pbProtocol,
+		Active:        r.Active,
+		ContainerName: r.ContainerName,
+		Description:   r.Description,
+		InInterface:   r.InInterface,
+		SnatSource:    r.SNATSource,
+		AllowSources:  r.AllowSources,
 	}, nil
 }
 
 // UpdatePassthroughRoute updates an existing TCP/UDP passthrough route.
-// Admin-only.
+// Same rules as AddPassthroughRoute: non-admins may only touch routes to
+// their own container. Setting container_name re-resolves the target IP,
+// which is how a route follows a recreated container.
 func (s *NetworkServer) UpdatePassthroughRoute(ctx context.Context, req *pb.UpdatePassthroughRouteRequest) (*pb.UpdatePassthroughRouteResponse, error) {
-	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+	if err := auth.RequireScope(ctx, auth.ScopeRoutesWrite); err != nil {
 		return nil, err
 	}
+	_, roles, ok := auth.SubjectFromGRPCContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "no authenticated subject")
+	}
 	// Validate request
 	if req.ExternalPort <= 0 || req.ExternalPort > 65535 {
 		return nil, fmt.Errorf("external_port must be between 1 and 65535")
@@ -676,6 +935,13 @@ func (s *NetworkServer) UpdatePassthroughRoute(ctx context.Context, req *pb.Upda
 		protocol = "udp"
 	}
 
+	containerName := passthroughContainerName(req.ContainerName)
+	if !auth.HasRole(roles, auth.RoleAdmin) {
+		if err := s.authorizeTenantPassthrough(ctx, int(req.ExternalPort), protocol, containerName); err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle enable/disable toggle
 	if req.Active != nil {
 		active := *req.Active
@@ -695,7 +961,7 @@ func (s *NetworkServer) UpdatePassthroughRoute(ctx context.Context, req *pb.Upda
 		}
 
 		// If this is a pure toggle (no target info provided), return early
-		if req.TargetIp == "" && req.TargetPort == 0 {
+		if req.TargetIp == "" && req.TargetPort == 0 && req.InInterface == nil && req.SnatSource == nil {
 			action := "enabled"
 			if !active {
 				action = "disabled"
@@ -705,7 +971,7 @@ func (s *NetworkServer) UpdatePassthroughRoute(ctx context.Context, req *pb.Upda
 					ExternalPort:  req.ExternalPort,
 					Protocol:      pbProtocol,
 					Active:        active,
-					ContainerName: req.ContainerName,
+					ContainerName: containerName,
 					Description:   req.Description,
 				},
 				Message: fmt.Sprintf("Passthrough route %s: %s:%d (will sync to iptables)", action, protocol, req.ExternalPort),
@@ -714,22 +980,63 @@ func (s *NetworkServer) UpdatePassthroughRoute(ctx context.Context, req *pb.Upda
 	}
 
 	// For updates with new target info, we need target fields
-	if req.TargetIp == "" {
-		return nil, fmt.Errorf("target_ip is required")
+	targetIP := req.TargetIp
+	if containerName != "" {
+		ip, err := s.resolvePassthroughTarget(containerName, targetIP)
+		if err != nil {
+			return nil, err
+		}
+		targetIP = ip
 	}
-	if req.TargetPort <= 0 || req.TargetPort > 65535 {
+	if targetIP == "" {
+		return nil, fmt.Errorf("target_ip or container_name is required")
+	}
+	// Unset fields mean "no change": start from the stored route.
+	targetPort := req.TargetPort
+	var opts network.RouteOptions
+	if s.passthroughStore != nil {
+		if existing, err := s.passthroughStore.GetByPortProtocol(ctx, int(req.ExternalPort), protocol); err == nil {
+			if targetPort == 0 {
+				targetPort = safecast.I32(existing.TargetPort)
+			}
+			opts = existing.RouteOptions
+		}
+	} else if s.passthroughManager != nil {
+		if routes, err := s.passthroughManager.ListRoutes(); err == nil {
+			for _, r := range routes {
+				if r.ExternalPort == int(req.ExternalPort) && r.Protocol == protocol {
+					opts = r.RouteOptions
+				}
+			}
+		}
+	}
+	if targetPort <= 0 || targetPort > 65535 {
 		return nil, fmt.Errorf("target_port must be between 1 and 65535")
 	}
+	if req.InInterface != nil || req.SnatSource != nil {
+		requested, err := s.resolvePassthroughOptions(req.GetInInterface(), req.GetSnatSource(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if req.InInterface != nil {
+			opts.InInterface = requested.InInterface
+		}
+		if req.SnatSource != nil {
+			opts.SNATSource = requested.SNATSource
+		}
+	}
+	opts.Container = containerName
 
 	if s.passthroughStore != nil {
 		record := &network.PassthroughRecord{
 			ExternalPort:  int(req.ExternalPort),
-			TargetIP:      req.TargetIp,
-			TargetPort:    int(req.TargetPort),
+			TargetIP:      targetIP,
+			TargetPort:    int(targetPort),
 			Protocol:      protocol,
-			ContainerName: req.ContainerName,
+			ContainerName: containerName,
 			Description:   req.Description,
 			Active:        true,
+			RouteOptions:  opts,
 		}
 
 		if err := s.passthroughStore.Save(ctx, record); err != nil {
@@ -740,7 +1047,7 @@ func (s *NetworkServer) UpdatePassthroughRoute(ctx context.Context, req *pb.Upda
 		// Remove existing route first (ignore errors if it doesn't exist)
 		_ = s.passthroughManager.RemoveRoute(int(req.ExternalPort), protocol)
 
-		if err := s.passthroughManager.AddRoute(int(req.ExternalPort), req.TargetIp, int(req.TargetPort), protocol); err != nil {
+		if err := s.passthroughManager.AddRouteWithOptions(int(req.ExternalPort), targetIP, int(targetPort), protocol, opts); err != nil {
 			return nil, fmt.Errorf("failed to update passthrough route: %w", err)
 		}
 	}
@@ -748,14 +1055,111 @@ func (s *NetworkServer) UpdatePassthroughRoute(ctx context.Context, req *pb.Upda
 	return &pb.UpdatePassthroughRouteResponse{
 		Route: &pb.PassthroughRoute{
 			ExternalPort:  req.ExternalPort,
-			TargetIp:      req.TargetIp,
-			TargetPort:    req.TargetPort,
+			TargetIp:      targetIP,
+			TargetPort:    targetPort,
 			Protocol:      pbProtocol,
 			Active:        true,
-			ContainerName: req.ContainerName,
+			ContainerName: containerName,
+			Description:   req.Description,
+			InInterface:   opts.InInterface,
+			SnatSource:    opts.SNATSource,
+			AllowSources:  opts.AllowSources,
+		},
+		Message: fmt.Sprintf("Passthrough route updated: %s:%d -> %s:%d (will sync to iptables)", protocol, req.ExternalPort, targetIP, targetPort),
+	}, nil
+}
+
+// AdoptPassthroughRoute brings an unmanaged DNAT rule on the port under
+// management: the rule is replaced by the tagged one a passthrough route
+// installs and recorded in the registry, and from then on the sync job
+// reconciles it like any other route. The target must be a known
+// container's IP unless the caller allows an external target. Admin-only,
+// like the host rules it takes over.
+func (s *NetworkServer) AdoptPassthroughRoute(ctx context.Context, req *pb.AdoptPassthroughRouteRequest) (*pb.AdoptPassthroughRouteResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	subject, _, _ := auth.SubjectFromGRPCContext(ctx)
+	if req.ExternalPort <= 0 || req.ExternalPort > 65535 {
+		return nil, status.Error(codes.InvalidArgument, "external_port must be between 1 and 65535")
+	}
+	if s.passthroughManager == nil {
+		return nil, status.Error(codes.FailedPrecondition, "passthrough routing is not available on this host")
+	}
+	// An adopted route is tagged as managed; outside the registry nothing
+	// would track it, and the orphan sweeper would take it for a leftover.
+	if s.passthroughStore == nil {
+		return nil, status.Error(codes.FailedPrecondition, "adopting a route needs the passthrough route registry (daemon --postgres)")
+	}
+	port := int(req.ExternalPort)
+	if err := s.checkReservedPort(port); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	protocol := "tcp"
+	pbProtocol := pb.RouteProtocol_ROUTE_PROTOCOL_TCP
+	if req.Protocol == pb.RouteProtocol_ROUTE_PROTOCOL_UDP {
+		protocol, pbProtocol = "udp", req.Protocol
+	}
+
+	if _, err := s.passthroughStore.GetByPortProtocol(ctx, port, protocol); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "passthrough route %s:%d is already in the route registry", protocol, port)
+	} else if !errors.Is(err, network.ErrPassthroughNotFound) {
+		return nil, fmt.Errorf("failed to look up passthrough route: %w", err)
+	}
+
+	foreign, err := s.passthroughManager.FindForeignRoute(port, protocol)
+	switch {
+	case errors.Is(err, network.ErrForeignRouteNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, network.ErrRouteManaged):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	containerName, err := s.adoptionTarget(passthroughContainerName(req.ContainerName), foreign.TargetIP, req.AllowExternalTarget)
+	if err != nil {
+		return nil, err
+	}
+	opts := foreign.RouteOptions
+	opts.Container = containerName
+
+	record := &network.PassthroughRecord{
+		ExternalPort:  port,
+		TargetIP:      foreign.TargetIP,
+		TargetPort:    foreign.TargetPort,
+		Protocol:      protocol,
+		ContainerName: containerName,
+		Description:   req.Description,
+		Active:        true,
+		CreatedBy:     subject,
+		RouteOptions:  opts,
+	}
+	commit := func() error {
+		if err := s.passthroughStore.Save(ctx, record); err != nil {
+			return fmt.Errorf("failed to save passthrough route: %w", err)
+		}
+		return nil
+	}
+	if err := s.passthroughManager.AdoptRoute(foreign, containerName, commit); err != nil {
+		return nil, fmt.Errorf("failed to adopt passthrough route: %w", err)
+	}
+
+	return &pb.AdoptPassthroughRouteResponse{
+		Route: &pb.PassthroughRoute{
+			ExternalPort:  req.ExternalPort,
+			TargetIp:      foreign.TargetIP,
+			TargetPort:    safecast.I32(foreign.TargetPort),
+			Protocol:      pbProtocol,
+			Active:        true,
+			ContainerName: containerName,
 			Description:   req.Description,
+			InInterface:   opts.InInterface,
+			SnatSource:    opts.SNATSource,
+			AllowSources:  opts.AllowSources,
 		},
-		Message: fmt.Sprintf("Passthrough route updated: %s:%d -> %s:%d (will sync to iptables)", protocol, req.ExternalPort, req.TargetIp, req.TargetPort),
+		Message: fmt.Sprintf("Passthrough route adopted: %s:%d -> %s:%d", protocol, port, foreign.TargetIP, foreign.TargetPort),
 	}, nil
 }
 
@@ -1,6 +1,16 @@
 package server
 
-import "testing"
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/events"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
 
 func TestResolveFullDomain(t *testing.T) {
 	tests := []struct {
@@ -102,3 +112,48 @@ func TestBridgeGatewayIP(t *testing.T) {
 		}
 	}
 }
+
+func TestAddRoute_ByContainerResolvesIP(t *testing.T) {
+	srv := &NetworkServer{
+		emitter: events.NewEmitter(events.NewBus()),
+		containerIPLookup: func(name string) (string, error) {
+			switch name {
+			case "alice-container":
+				return "", nil // stopped
+			case "bob-container":
+				return "10.0.3.20", nil
+			}
+			return "", fmt.Errorf("container %s not found", name)
+		},
+	}
+	add := func(container string) error {
+		_, err := srv.AddRoute(adminCtx(), &pb.AddRouteRequest{Domain: "app.example.com", TargetPort: 3000, ContainerName: container})
+		return err
+	}
+	if err := add("carol"); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown container: %v, want NotFound", err)
+	}
+	if err := add("alice"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("stopped container: %v, want FailedPrecondition", err)
+	}
+	// Resolved; fails only because this server has no route persistence.
+	if err := add("bob"); err == nil || !strings.Contains(err.Error(), "route persistence not configured") {
+		t.Errorf("running container: %v, want to reach persistence", err)
+	}
+	if _, err := srv.AddRoute(adminCtx(), &pb.AddRouteRequest{Domain: "app.example.com", TargetPort: 3000}); err == nil {
+		t.Error("route with neither target_ip nor container_name accepted")
+	}
+}
+
+func TestRouteCertificate(t *testing.T) {
+	srv := &NetworkServer{caddyCertDir: t.TempDir()}
+	if got := srv.routeCertificate("app.example.com", pb.RouteProtocol_ROUTE_PROTOCOL_HTTP); got.GetStatus() != pb.CertificateStatus_CERTIFICATE_STATUS_PENDING {
+		t.Errorf("no certificate stored: %v, want PENDING", got)
+	}
+	if got := srv.routeCertificate("app.example.com", pb.RouteProtocol_ROUTE_PROTOCOL_TLS_PASSTHROUGH); got != nil {
+		t.Errorf("TLS passthrough route reported certificate %v", got)
+	}
+	if got := (&NetworkServer{}).routeCertificate("app.example.com", pb.RouteProtocol_ROUTE_PROTOCOL_HTTP); got != nil {
+		t.Errorf("no cert dir configured, got %v", got)
+	}
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/network"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passthroughContainerName maps the container_name a caller passes on a
+// passthrough request to the Incus instance name. The CLI's --container
+// takes a username, so the "-container" suffix is optional.
+func passthroughContainerName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.HasSuffix(name, "-container") {
+		return name
+	}
+	return name + "-container"
+}
+
+// daemonReservedPorts is the set of host ports passthrough routes must
+// leave alone: the daemon's own listeners, host SSH, the port the sentinel
+// forwards to, Caddy's 80/443 forward when app hosting is on, and whatever
+// the operator adds with --reserved-ports. Reserved for TCP and UDP alike.
+func daemonReservedPorts(config *DualServerConfig) network.ReservedPorts {
+	r := network.ReservedPorts{}
+	r.Reserve(config.GRPCPort, "daemon gRPC API")
+	if config.EnableREST {
+		r.Reserve(config.HTTPPort, "daemon REST API and /metrics")
+	}
+	r.Reserve(22, "host SSH")
+	r.Reserve(config.PublicPort, "TLS port the sentinel forwards to")
+	if config.EnableAppHosting {
+		r.Reserve(80, "forwarded to Caddy")
+		r.Reserve(443, "forwarded to Caddy")
+	}
+	for _, port := range config.ReservedPorts {
+		r.Reserve(port, "listed in --reserved-ports")
+	}
+	return r
+}
+
+// checkReservedPort fails for a host port passthrough routes may not use.
+func (s *NetworkServer) checkReservedPort(port int) error {
+	s.reservedMu.RLock()
+	defer s.reservedMu.RUnlock()
+	return s.reservedPorts.Check(port)
+}
+
+// setReservedPorts replaces the reserved host ports; routes already added
+// on a newly reserved port are left in place.
+func (s *NetworkServer) setReservedPorts(r network.ReservedPorts) {
+	s.reservedMu.Lock()
+	defer s.reservedMu.Unlock()
+	s.reservedPorts = r
+}
+
+// resolvePassthroughTarget looks up the container's current IP so routes can
+// be declared by container instead of by an IP that changes on recreate.
+// When the caller also gave a target IP it must agree with the lookup.
+func (s *NetworkServer) resolvePassthroughTarget(containerName, targetIP string) (string, error) {
+	ip, err := s.lookupContainerIP(containerName)
+	if err != nil {
+		return "", status.Errorf(codes.NotFound, "failed to resolve container %s: %v", containerName, err)
+	}
+	if ip == "" {
+		return "", status.Errorf(codes.FailedPrecondition, "container %s has no IP address (is it running?)", containerName)
+	}
+	if targetIP != "" && targetIP != ip {
+		return "", status.Errorf(codes.InvalidArgument,
+			"target_ip %s does not match container %s's current IP %s", targetIP, containerName, ip)
+	}
+	return ip, nil
+}
+
+func (s *NetworkServer) lookupContainerIP(containerName string) (string, error) {
+	if s.containerIPLookup != nil {
+		return s.containerIPLookup(containerName)
+	}
+	if s.incusClient == nil {
+		return "", fmt.Errorf("incus client not available")
+	}
+	info, err := s.incusClient.GetContainer(containerName)
+	if err != nil {
+		return "", err
+	}
+	return info.IPAddress, nil
+}
+
+// adoptionTarget is the container an adopted rule forwards to: the named
+// one, which must currently have targetIP, or else the one that has it.
+// A target that is no known container's IP is refused unless
+// allowExternal, and then adopted as a route to a bare IP.
+func (s *NetworkServer) adoptionTarget(containerName, targetIP string, allowExternal bool) (string, error) {
+	if containerName != "" {
+		if _, err := s.resolvePassthroughTarget(containerName, targetIP); err != nil {
+			return "", err
+		}
+		return containerName, nil
+	}
+	name, err := s.containerAt(targetIP)
+	switch {
+	case name != "":
+		return name, nil
+	case allowExternal:
+		return "", nil
+	case err != nil:
+		return "", status.Errorf(codes.Unavailable, "failed to look up the container at %s: %v", targetIP, err)
+	}
+	return "", status.Errorf(codes.FailedPrecondition,
+		"target %s is not the IP of a known container (set allow_external_target to adopt it anyway)", targetIP)
+}
+
+// containerAt returns the container whose IP is ip, "" if none.
+func (s *NetworkServer) containerAt(ip string) (string, error) {
+	if s.containerAtLookup != nil {
+		return s.containerAtLookup(ip)
+	}
+	if s.incusClient == nil {
+		return "", fmt.Errorf("incus client not available")
+	}
+	containers, err := s.incusClient.ListContainers()
+	if err != nil {
+		return "", err
+	}
+	for _, c := range containers {
+		if c.IPAddress == ip {
+			return c.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// authorizeTenantPassthrough is the non-admin gate for adding or changing a
+// passthrough route: the route must name the caller's own container, and
+// the port must not already forward to someone else's.
+func (s *NetworkServer) authorizeTenantPassthrough(ctx context.Context, externalPort int, protocol, containerName string) error {
+	if containerName == "" {
+		return status.Error(codes.PermissionDenied, "non-admin callers must target their own container via container_name")
+	}
+	if err := auth.AuthorizeContainerAccess(ctx, containerName); err != nil {
+		return err
+	}
+	if s.passthroughStore == nil {
+		return nil
+	}
+	existing, err := s.passthroughStore.GetByPortProtocol(ctx, externalPort, protocol)
+	if errors.Is(err, network.ErrPassthroughNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up passthrough route: %w", err)
+	}
+	if existing.ContainerName != containerName {
+		return status.Errorf(codes.PermissionDenied, "port %s:%d is already routed to another container", protocol, externalPort)
+	}
+	return nil
+}
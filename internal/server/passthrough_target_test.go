@@ -0,0 +1,360 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// memPassthroughStore is a map-backed network.PassthroughStore.
+type memPassthroughStore struct {
+	records map[string]*network.PassthroughRecord
+}
+
+func newMemPassthroughStore() *memPassthroughStore {
+	return &memPassthroughStore{records: map[string]*network.PassthroughRecord{}}
+}
+
+func passthroughKey(port int, protocol string) string { return fmt.Sprintf("%s:%d", protocol, port) }
+
+func (m *memPassthroughStore) Save(_ context.Context, r *network.PassthroughRecord) error {
+	cp := *r
+	m.records[passthroughKey(r.ExternalPort, r.Protocol)] = &cp
+	return nil
+}
+
+func (m *memPassthroughStore) GetByPortProtocol(_ context.Context, port int, protocol string) (*network.PassthroughRecord, error) {
+	r, ok := m.records[passthroughKey(port, protocol)]
+	if !ok {
+		return nil, network.ErrPassthroughNotFound
+	}
+	return r, nil
+}
+
+func (m *memPassthroughStore) List(context.Context, bool) ([]*network.PassthroughRecord, error) {
+	var out []*network.PassthroughRecord
+	for _, r := range m.records {
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (m *memPassthroughStore) Delete(_ context.Context, port int, protocol string) error {
+	delete(m.records, passthroughKey(port, protocol))
+	return nil
+}
+
+func (m *memPassthroughStore) SetActive(_ context.Context, port int, protocol string, active bool) error {
+	r, ok := m.records[passthroughKey(port, protocol)]
+	if !ok {
+		return network.ErrPassthroughNotFound
+	}
+	r.Active = active
+	return nil
+}
+
+func (m *memPassthroughStore) Count(context.Context, bool) (int32, error) {
+	return int32(len(m.records)), nil
+}
+
+func newPassthroughTestServer() (*NetworkServer, *memPassthroughStore) {
+	store := newMemPassthroughStore()
+	ips := map[string]string{
+		"alice-container": "10.0.3.10",
+		"bob-container":   "10.0.3.20",
+	}
+	return &NetworkServer{
+		passthroughStore: store,
+		containerIPLookup: func(name string) (string, error) {
+			ip, ok := ips[name]
+			if !ok {
+				return "", fmt.Errorf("container %s not found", name)
+			}
+			return ip, nil
+		},
+	}, store
+}
+
+func TestAddPassthroughRoute_TenantOwnContainerResolvesIP(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	resp, err := srv.AddPassthroughRoute(nonAdminCtx(), &pb.AddPassthroughRouteRequest{
+		ExternalPort: 50051, TargetPort: 50051, ContainerName: "alice",
+	})
+	if err != nil {
+		t.Fatalf("AddPassthroughRoute: %v", err)
+	}
+	if resp.Route.TargetIp != "10.0.3.10" || resp.Route.ContainerName != "alice-container" {
+		t.Fatalf("got route %+v", resp.Route)
+	}
+	rec := store.records[passthroughKey(50051, "tcp")]
+	if rec == nil || rec.ContainerName != "alice-container" || rec.CreatedBy != "alice" {
+		t.Fatalf("stored record %+v", rec)
+	}
+}
+
+func TestAddPassthroughRoute_TenantOtherContainerDenied(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	_, err := srv.AddPassthroughRoute(nonAdminCtx(), &pb.AddPassthroughRouteRequest{
+		ExternalPort: 50051, TargetPort: 50051, ContainerName: "bob",
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+	if len(store.records) != 0 {
+		t.Fatalf("route was stored despite denial")
+	}
+}
+
+func TestAddPassthroughRoute_TenantCannotTakeOverPort(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	_ = store.Save(context.Background(), &network.PassthroughRecord{
+		ExternalPort: 50051, TargetIP: "10.0.3.20", TargetPort: 50051, Protocol: "tcp", ContainerName: "bob-container",
+	})
+	_, err := srv.AddPassthroughRoute(nonAdminCtx(), &pb.AddPassthroughRouteRequest{
+		ExternalPort: 50051, TargetPort: 50051, ContainerName: "alice",
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+	if got := store.records[passthroughKey(50051, "tcp")].ContainerName; got != "bob-container" {
+		t.Fatalf("route owner changed to %s", got)
+	}
+}
+
+func TestAddPassthroughRoute_TargetIPMustMatchContainer(t *testing.T) {
+	srv, _ := newPassthroughTestServer()
+	admin := auth.ContextWithTestSubject(context.Background(), "root", auth.RoleAdmin)
+	_, err := srv.AddPassthroughRoute(admin, &pb.AddPassthroughRouteRequest{
+		ExternalPort: 50051, TargetIp: "10.0.3.99", TargetPort: 50051, ContainerName: "alice-container",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("got %v want InvalidArgument", err)
+	}
+
+	resp, err := srv.AddPassthroughRoute(admin, &pb.AddPassthroughRouteRequest{
+		ExternalPort: 50051, TargetIp: "10.0.3.10", TargetPort: 50051, ContainerName: "alice-container",
+	})
+	if err != nil {
+		t.Fatalf("matching target_ip: %v", err)
+	}
+	if resp.Route.TargetIp != "10.0.3.10" {
+		t.Fatalf("got target %s", resp.Route.TargetIp)
+	}
+}
+
+func TestAddPassthroughRoute_HostPortConflict(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	srv.portConflictCheck = func(port int, protocol string) error {
+		return &network.PortConflictError{Port: port, Protocol: protocol, Holders: []string{"listening socket on 0.0.0.0:5432"}}
+	}
+	req := &pb.AddPassthroughRouteRequest{ExternalPort: 5432, TargetPort: 5432, ContainerName: "alice"}
+
+	_, err := srv.AddPassthroughRoute(nonAdminCtx(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("got %v want FailedPrecondition", err)
+	}
+	if len(store.records) != 0 {
+		t.Fatalf("route was stored despite the conflict")
+	}
+
+	req.Force = true
+	if _, err := srv.AddPassthroughRoute(nonAdminCtx(), req); err != nil {
+		t.Fatalf("forced add: %v", err)
+	}
+	if store.records[passthroughKey(5432, "tcp")] == nil {
+		t.Fatalf("forced route was not stored")
+	}
+}
+
+func TestAddPassthroughRoute_ReservedPortRejectedEvenForced(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	srv.reservedPorts = daemonReservedPorts(&DualServerConfig{
+		GRPCPort: 50051, HTTPPort: 8080, EnableREST: true, PublicPort: 443, ReservedPorts: []int{9100},
+	})
+
+	for _, port := range []int32{8080, 50051, 22, 443, 9100} {
+		_, err := srv.AddPassthroughRoute(adminCtx(), &pb.AddPassthroughRouteRequest{
+			ExternalPort: port, TargetPort: 80, ContainerName: "alice", Force: true,
+		})
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "reserved") {
+			t.Errorf("port %d: got %v, want InvalidArgument naming the reservation", port, err)
+		}
+	}
+	if len(store.records) != 0 {
+		t.Fatalf("routes stored on reserved ports: %v", store.records)
+	}
+
+	if _, err := srv.AddPassthroughRoute(adminCtx(), &pb.AddPassthroughRouteRequest{
+		ExternalPort: 2222, TargetPort: 22, ContainerName: "alice",
+	}); err != nil {
+		t.Fatalf("unreserved port: %v", err)
+	}
+}
+
+func TestAddPassthroughRoute_ConflictProbeErrorIsNotFatal(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	srv.portConflictCheck = func(int, string) error { return fmt.Errorf("iptables: command not found") }
+	if _, err := srv.AddPassthroughRoute(nonAdminCtx(), &pb.AddPassthroughRouteRequest{
+		ExternalPort: 5432, TargetPort: 5432, ContainerName: "alice",
+	}); err != nil {
+		t.Fatalf("AddPassthroughRoute: %v", err)
+	}
+	if store.records[passthroughKey(5432, "tcp")] == nil {
+		t.Fatalf("route was not stored")
+	}
+}
+
+func TestUpdatePassthroughRoute_ReResolvesContainerIP(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	_ = store.Save(context.Background(), &network.PassthroughRecord{
+		ExternalPort: 50051, TargetIP: "10.0.3.5", TargetPort: 8443, Protocol: "tcp", ContainerName: "alice-container",
+	})
+	resp, err := srv.UpdatePassthroughRoute(nonAdminCtx(), &pb.UpdatePassthroughRouteRequest{
+		ExternalPort: 50051, ContainerName: "alice",
+	})
+	if err != nil {
+		t.Fatalf("UpdatePassthroughRoute: %v", err)
+	}
+	// Target port 0 keeps the stored port; the IP follows the container.
+	if resp.Route.TargetIp != "10.0.3.10" || resp.Route.TargetPort != 8443 {
+		t.Fatalf("got route %+v", resp.Route)
+	}
+}
+
+func TestPassthroughRoute_OptionsPersistAndSurviveUpdate(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	srv.passthroughManager = network.NewPassthroughManager("10.0.3.0/24")
+	resp, err := srv.AddPassthroughRoute(adminCtx(), &pb.AddPassthroughRouteRequest{
+		ExternalPort: 2222, TargetIp: "10.0.3.20", TargetPort: 22, InInterface: "eth0", SnatSource: "10.0.3.1",
+		AllowSources: []string{"198.51.100.7"},
+	})
+	if err != nil {
+		t.Fatalf("AddPassthroughRoute: %v", err)
+	}
+	if resp.Route.InInterface != "eth0" || resp.Route.SnatSource != "10.0.3.1" {
+		t.Fatalf("got route %+v", resp.Route)
+	}
+
+	// Options left unset on update are kept; an explicit "" clears one.
+	cleared := ""
+	if _, err := srv.UpdatePassthroughRoute(adminCtx(), &pb.UpdatePassthroughRouteRequest{
+		ExternalPort: 2222, TargetIp: "10.0.3.30", SnatSource: &cleared,
+	}); err != nil {
+		t.Fatalf("UpdatePassthroughRoute: %v", err)
+	}
+	rec := store.records[passthroughKey(2222, "tcp")]
+	if rec.TargetIP != "10.0.3.30" || rec.InInterface != "eth0" || rec.SNATSource != "" ||
+		len(rec.AllowSources) != 1 || rec.AllowSources[0] != "198.51.100.7/32" {
+		t.Fatalf("stored record %+v", rec)
+	}
+
+	list, err := srv.ListPassthroughRoutes(adminCtx(), &pb.ListPassthroughRoutesRequest{})
+	if err != nil {
+		t.Fatalf("ListPassthroughRoutes: %v", err)
+	}
+	if len(list.Routes) != 1 || list.Routes[0].InInterface != "eth0" {
+		t.Fatalf("listed routes %+v", list.Routes)
+	}
+}
+
+func TestAddPassthroughRoute_RejectsBadOptions(t *testing.T) {
+	srv, _ := newPassthroughTestServer()
+	srv.passthroughManager = network.NewPassthroughManager("10.0.3.0/24")
+	_, err := srv.AddPassthroughRoute(adminCtx(), &pb.AddPassthroughRouteRequest{
+		ExternalPort: 2222, TargetIp: "10.0.3.20", TargetPort: 22, SnatSource: "example.com",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestDeletePassthroughRoute_DryRunKeepsRoute(t *testing.T) {
+	srv, store := newPassthroughTestServer()
+	ctx := context.Background()
+	if err := store.Save(ctx, &network.PassthroughRecord{ExternalPort: 5432, TargetIP: "10.0.3.20", TargetPort: 5432, Protocol: "tcp", ContainerName: "bob-container"}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := srv.DeletePassthroughRoute(adminCtx(), &pb.DeletePassthroughRouteRequest{ExternalPort: 5432, DryRun: true})
+	if err != nil {
+		t.Fatalf("DeletePassthroughRoute: %v", err)
+	}
+	if !resp.DryRun || resp.GetRoute().GetTargetIp() != "10.0.3.20" || resp.GetRoute().GetContainerName() != "bob-container" {
+		t.Errorf("dry-run response = %+v", resp)
+	}
+	if _, err := store.GetByPortProtocol(ctx, 5432, "tcp"); err != nil {
+		t.Errorf("dry run removed the route: %v", err)
+	}
+
+	resp, err = srv.DeletePassthroughRoute(adminCtx(), &pb.DeletePassthroughRouteRequest{ExternalPort: 6000, DryRun: true})
+	if err != nil || resp.Route != nil {
+		t.Errorf("dry run of a missing route = %+v, %v; want no route, no error", resp, err)
+	}
+
+	resp, err = srv.DeletePassthroughRoute(adminCtx(), &pb.DeletePassthroughRouteRequest{ExternalPort: 5432})
+	if err != nil {
+		t.Fatalf("DeletePassthroughRoute: %v", err)
+	}
+	if resp.DryRun || resp.GetRoute().GetExternalPort() != 5432 {
+		t.Errorf("response = %+v, want the removed route echoed", resp)
+	}
+	if _, err := store.GetByPortProtocol(ctx, 5432, "tcp"); !errors.Is(err, network.ErrPassthroughNotFound) {
+		t.Errorf("route still stored after delete: %v", err)
+	}
+}
+
+func TestAdoptPassthroughRoute_Preconditions(t *testing.T) {
+	srv, _ := newPassthroughTestServer()
+	srv.passthroughManager = network.NewPassthroughManager("10.0.3.0/24")
+	srv.reservedPorts = daemonReservedPorts(&DualServerConfig{GRPCPort: 50051, HTTPPort: 8080, EnableREST: true})
+
+	for _, port := range []int32{8080, 50051} {
+		_, err := srv.AdoptPassthroughRoute(adminCtx(), &pb.AdoptPassthroughRouteRequest{ExternalPort: port})
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "reserved") {
+			t.Errorf("port %d: got %v, want InvalidArgument naming the reservation", port, err)
+		}
+	}
+
+	srv.passthroughStore = nil
+	_, err := srv.AdoptPassthroughRoute(adminCtx(), &pb.AdoptPassthroughRouteRequest{ExternalPort: 5432})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("without a registry: got %v, want FailedPrecondition", err)
+	}
+}
+
+func TestAdoptionTarget(t *testing.T) {
+	srv, _ := newPassthroughTestServer()
+	srv.containerAtLookup = func(ip string) (string, error) {
+		if ip == "10.0.3.20" {
+			return "bob-container", nil
+		}
+		return "", nil
+	}
+
+	for _, tc := range []struct {
+		name, container, ip string
+		allowExternal       bool
+		want                string
+		code                codes.Code
+	}{
+		{"named container", "alice-container", "10.0.3.10", false, "alice-container", codes.OK},
+		{"named container elsewhere", "alice-container", "10.0.3.20", false, "", codes.InvalidArgument},
+		{"found by IP", "", "10.0.3.20", false, "bob-container", codes.OK},
+		{"unknown target", "", "192.0.2.7", false, "", codes.FailedPrecondition},
+		{"unknown target allowed", "", "192.0.2.7", true, "", codes.OK},
+	} {
+		got, err := srv.adoptionTarget(tc.container, tc.ip, tc.allowExternal)
+		if status.Code(err) != tc.code || got != tc.want {
+			t.Errorf("%s: got %q, %v; want %q, %v", tc.name, got, err, tc.want, tc.code)
+		}
+	}
+}
@@ -56,6 +56,14 @@ func TestTrafficGetConnectionSummary_RejectsOtherTenant(t *testing.T) {
 	}
 }
 
+func TestTrafficDescribeConnection_RejectsOtherTenant(t *testing.T) {
+	srv := &TrafficServer{}
+	_, err := srv.DescribeConnection(tenantCtx("alice"), &pb.DescribeConnectionRequest{ContainerName: "bob-container", ConnectionId: "c1"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+}
+
 func TestTrafficQueryHistory_RejectsOtherTenant(t *testing.T) {
 	srv := &TrafficServer{}
 	_, err := srv.QueryTrafficHistory(tenantCtx("alice"), &pb.QueryTrafficHistoryRequest{ContainerName: "bob-container"})
@@ -64,6 +72,74 @@ func TestTrafficQueryHistory_RejectsOtherTenant(t *testing.T) {
 	}
 }
 
+func TestTrafficQueryHistory_RejectsOtherTenantByUsername(t *testing.T) {
+	srv := &TrafficServer{}
+	_, err := srv.QueryTrafficHistory(tenantCtx("alice"), &pb.QueryTrafficHistoryRequest{Username: "bob"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+	// A matching username doesn't launder someone else's container_name.
+	_, err = srv.QueryTrafficHistory(tenantCtx("alice"), &pb.QueryTrafficHistoryRequest{Username: "alice", ContainerName: "bob-container"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+}
+
+func TestTrafficQueryHistory_FleetSearchIsAdminOnly(t *testing.T) {
+	srv := &TrafficServer{}
+	_, err := srv.QueryTrafficHistory(tenantCtx("alice"), &pb.QueryTrafficHistoryRequest{DestIp: "203.0.113.7"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("tenant fleet search: got %v want PermissionDenied", err)
+	}
+	_, err = srv.QueryTrafficHistory(adminCtx(), &pb.QueryTrafficHistoryRequest{DestPort: 443})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("fleet search without an address: got %v want InvalidArgument", err)
+	}
+	_, err = srv.QueryTrafficHistory(adminCtx(), &pb.QueryTrafficHistoryRequest{SourceIp: "not-an-ip"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("malformed source_ip: got %v want InvalidArgument", err)
+	}
+}
+
+func TestTrafficStreamHistory_RejectsOtherTenant(t *testing.T) {
+	srv := &TrafficServer{}
+	for _, req := range []*pb.StreamTrafficHistoryRequest{
+		{ContainerName: "bob-container"},
+		{Username: "bob"},
+		{Username: "alice", ContainerName: "bob-container"},
+	} {
+		err := srv.StreamTrafficHistory(req, &historyStream{ctx: tenantCtx("alice")})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("%v: got %v want PermissionDenied", req, err)
+		}
+	}
+}
+
+func TestTrafficGetListeningPorts_RejectsOtherTenant(t *testing.T) {
+	srv := &TrafficServer{}
+	_, err := srv.GetListeningPorts(tenantCtx("alice"), &pb.GetListeningPortsRequest{ContainerName: "bob-container"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+	// Past authz, a daemon without a collector says so.
+	_, err = srv.GetListeningPorts(tenantCtx("alice"), &pb.GetListeningPortsRequest{ContainerName: "alice-container"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("got %v want FailedPrecondition", err)
+	}
+}
+
+func TestTrafficGetSSHSessions_RejectsOtherTenant(t *testing.T) {
+	srv := &TrafficServer{}
+	_, err := srv.GetSSHSessions(tenantCtx("alice"), &pb.GetSSHSessionsRequest{ContainerName: "bob-container"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+	_, err = srv.GetSSHSessions(tenantCtx("alice"), &pb.GetSSHSessionsRequest{ContainerName: "alice-container"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("got %v want FailedPrecondition", err)
+	}
+}
+
 func TestTrafficAggregates_RejectsOtherTenant(t *testing.T) {
 	srv := &TrafficServer{}
 	_, err := srv.GetTrafficAggregates(tenantCtx("alice"), &pb.GetTrafficAggregatesRequest{ContainerName: "bob-container"})
@@ -72,6 +148,42 @@ func TestTrafficAggregates_RejectsOtherTenant(t *testing.T) {
 	}
 }
 
+func TestTrafficDailyUsage_RejectsOtherTenant(t *testing.T) {
+	srv := &TrafficServer{}
+	_, err := srv.GetDailyUsage(tenantCtx("alice"), &pb.GetDailyUsageRequest{ContainerName: "bob-container"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+}
+
+func TestTrafficQueryByDestination_RequiresAdmin(t *testing.T) {
+	srv := &TrafficServer{}
+	req := &pb.QueryByDestinationRequest{Destination: "203.0.113.7"}
+	if _, err := srv.QueryByDestination(tenantCtx("alice"), req); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("tenant: got %v want PermissionDenied", err)
+	}
+	if _, err := srv.QueryByDestination(adminCtx(), &pb.QueryByDestinationRequest{Destination: "example.com"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad destination: got %v want InvalidArgument", err)
+	}
+	if _, err := srv.QueryByDestination(adminCtx(), req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("no collector: got %v want FailedPrecondition", err)
+	}
+}
+
+func TestTrafficAllDailyUsage_RequiresAdmin(t *testing.T) {
+	srv := &TrafficServer{}
+	if _, err := srv.GetAllDailyUsage(tenantCtx("alice"), &pb.GetAllDailyUsageRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("GetAllDailyUsage: got %v want PermissionDenied", err)
+	}
+	if _, err := srv.BackfillDailyUsage(tenantCtx("alice"), &pb.BackfillDailyUsageRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("BackfillDailyUsage: got %v want PermissionDenied", err)
+	}
+	// Admins get past authz; a bad month is then rejected as such.
+	if _, err := srv.GetAllDailyUsage(adminCtx(), &pb.GetAllDailyUsageRequest{Month: "2025-5"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad month: got %v want InvalidArgument", err)
+	}
+}
+
 // --- SecurityServer ClamAV tenant reads ---
 
 func TestListClamavReports_RejectsOtherTenant(t *testing.T) {
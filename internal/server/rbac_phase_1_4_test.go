@@ -203,6 +203,14 @@ func TestUpdatePassthroughRoute_RejectsNonAdmin(t *testing.T) {
 	}
 }
 
+func TestAdoptPassthroughRoute_RejectsNonAdmin(t *testing.T) {
+	srv := &NetworkServer{}
+	_, err := srv.AdoptPassthroughRoute(nonAdminCtx(), &pb.AdoptPassthroughRouteRequest{ExternalPort: 80})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got %v want PermissionDenied", err)
+	}
+}
+
 func TestListDNSRecords_RejectsNonAdmin(t *testing.T) {
 	srv := &NetworkServer{}
 	_, err := srv.ListDNSRecords(nonAdminCtx(), &pb.ListDNSRecordsRequest{})
@@ -11,11 +11,13 @@ import (
 // unit-tested with an in-memory fake (no pgx pool, no postgres).
 //
 // Method set covers exactly the production call sites in this package
-// (cascadeContainerCleanup, MoveContainer cutover, waitForContainerReady):
+// (cascadeContainerCleanup, MoveContainer cutover, waitForContainerReady,
+// GarbageCollect):
 // nothing more. Adding a method here only when a new call site needs it
 // keeps the test surface minimal.
 type routeLister interface {
 	ListByContainer(ctx context.Context, containerName string) ([]*app.RouteRecord, error)
 	Delete(ctx context.Context, fullDomain string) error
 	Save(ctx context.Context, route *app.RouteRecord) error
+	List(ctx context.Context, activeOnly bool) ([]*app.RouteRecord, error)
 }
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/footprintai/containarium/internal/app"
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// routeTargetStore is the part of *app.RouteStore the retargeter uses.
+type routeTargetStore interface {
+	List(ctx context.Context, activeOnly bool) ([]*app.RouteRecord, error)
+	Save(ctx context.Context, route *app.RouteRecord) error
+}
+
+// routeRetargeter keeps routes that name a container pointed at that
+// container's current IP. Proxy and passthrough routes store the IP they
+// forward to, and a recreated container can come back with a different
+// one. The retargeter rewrites the stored target on daemon start and on
+// every container create/start event; the route and passthrough sync jobs
+// then push the change to Caddy and iptables.
+type routeRetargeter struct {
+	routes      routeTargetStore         // nil when app hosting is off
+	passthrough network.PassthroughStore // nil without PostgreSQL
+	lookupIP    func(containerName string) (string, error)
+
+	// syncRoutes and syncPassthrough apply a change right away instead of
+	// on the sync jobs' next tick. Either may be nil.
+	syncRoutes      func(ctx context.Context) error
+	syncPassthrough func(ctx context.Context) error
+}
+
+// Run retargets every container's routes once, then follows container
+// events until ctx is done.
+func (r *routeRetargeter) Run(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(&pb.SubscribeEventsRequest{
+		ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_CONTAINER},
+		ContainerEventTypes: []pb.ContainerEventType{
+			pb.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+			pb.ContainerEventType_CONTAINER_EVENT_TYPE_START,
+		},
+	})
+	defer bus.Unsubscribe(sub.ID)
+
+	if err := r.retargetAll(ctx); err != nil {
+		log.Printf("Warning: failed to retarget routes at startup: %v", err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			c := event.GetContainerEvent().GetContainer()
+			if c == nil {
+				continue
+			}
+			ip := c.GetNetwork().GetIpAddress()
+			if ip == "" {
+				var err error
+				if ip, err = r.lookupIP(c.Name); err != nil || ip == "" {
+					continue
+				}
+			}
+			if _, err := r.retarget(ctx, c.Name, ip); err != nil {
+				log.Printf("Warning: failed to retarget routes for %s: %v", c.Name, err)
+			}
+		}
+	}
+}
+
+// retargetAll retargets the routes of every container some route names.
+// Containers without an IP (stopped) keep their routes as they are.
+func (r *routeRetargeter) retargetAll(ctx context.Context) error {
+	var names []string
+	if r.routes != nil {
+		routes, err := r.routes.List(ctx, false)
+		if err != nil {
+			return fmt.Errorf("failed to list routes: %w", err)
+		}
+		for _, route := range routes {
+			names = append(names, route.ContainerName)
+		}
+	}
+	if r.passthrough != nil {
+		routes, err := r.passthrough.List(ctx, false)
+		if err != nil {
+			return fmt.Errorf("failed to list passthrough routes: %w", err)
+		}
+		for _, route := range routes {
+			names = append(names, route.ContainerName)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range slices.Compact(names) {
+		if name == "" {
+			continue
+		}
+		ip, err := r.lookupIP(name)
+		if err != nil || ip == "" {
+			continue
+		}
+		if _, err := r.retarget(ctx, name, ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retarget points containerName's routes at ip and returns how many
+// changed.
+func (r *routeRetargeter) retarget(ctx context.Context, containerName, ip string) (int, error) {
+	var changedRoutes, changedPassthrough int
+	if r.routes != nil {
+		routes, err := r.routes.List(ctx, false)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list routes: %w", err)
+		}
+		for _, route := range routes {
+			if route.ContainerName != containerName || route.TargetIP == ip {
+				continue
+			}
+			log.Printf("Retargeting route %s: %s -> %s (%s)", route.FullDomain, route.TargetIP, ip, containerName)
+			route.TargetIP = ip
+			if err := r.routes.Save(ctx, route); err != nil {
+				return changedRoutes, fmt.Errorf("failed to update route %s: %w", route.FullDomain, err)
+			}
+			changedRoutes++
+		}
+	}
+	if r.passthrough != nil {
+		routes, err := r.passthrough.List(ctx, false)
+		if err != nil {
+			return changedRoutes, fmt.Errorf("failed to list passthrough routes: %w", err)
+		}
+		for _, route := range routes {
+			if route.ContainerName != containerName || route.TargetIP == ip {
+				continue
+			}
+			log.Printf("Retargeting passthrough %d/%s: %s -> %s (%s)", route.ExternalPort, route.Protocol, route.TargetIP, ip, containerName)
+			route.TargetIP = ip
+			if err := r.passthrough.Save(ctx, route); err != nil {
+				return changedRoutes + changedPassthrough, fmt.Errorf("failed to update passthrough route %d/%s: %w", route.ExternalPort, route.Protocol, err)
+			}
+			changedPassthrough++
+		}
+	}
+
+	if changedRoutes > 0 && r.syncRoutes != nil {
+		if err := r.syncRoutes(ctx); err != nil {
+			log.Printf("Warning: route sync after retarget failed: %v", err)
+		}
+	}
+	if changedPassthrough > 0 && r.syncPassthrough != nil {
+		if err := r.syncPassthrough(ctx); err != nil {
+			log.Printf("Warning: passthrough sync after retarget failed: %v", err)
+		}
+	}
+	return changedRoutes + changedPassthrough, nil
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/app"
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/pkg/core/network"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// memRouteStore is a map-backed routeTargetStore keyed by full domain.
+type memRouteStore struct {
+	mu      sync.Mutex
+	records map[string]*app.RouteRecord
+	saves   int
+}
+
+func (m *memRouteStore) target(domain string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.records[domain].TargetIP
+}
+
+func (m *memRouteStore) List(context.Context, bool) ([]*app.RouteRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*app.RouteRecord
+	for _, r := range m.records {
+		cp := *r
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func (m *memRouteStore) Save(_ context.Context, r *app.RouteRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *r
+	m.records[r.FullDomain] = &cp
+	m.saves++
+	return nil
+}
+
+func newRetargetFixture() (*routeRetargeter, *memRouteStore, *memPassthroughStore, map[string]string, *int) {
+	routes := &memRouteStore{records: map[string]*app.RouteRecord{
+		"app.example.com":  {FullDomain: "app.example.com", TargetIP: "10.0.3.10", TargetPort: 3000, ContainerName: "alice-container"},
+		"api.example.com":  {FullDomain: "api.example.com", TargetIP: "10.0.3.20", TargetPort: 8080, ContainerName: "bob-container"},
+		"ip.example.com":   {FullDomain: "ip.example.com", TargetIP: "10.0.3.99", TargetPort: 80},
+		"docs.example.com": {FullDomain: "docs.example.com", TargetIP: "10.0.3.30", TargetPort: 80, ContainerName: "carol-container"},
+	}}
+	passthrough := newMemPassthroughStore()
+	_ = passthrough.Save(context.Background(), &network.PassthroughRecord{
+		ExternalPort: 50051, Protocol: "tcp", TargetIP: "10.0.3.10", TargetPort: 50051, ContainerName: "alice-container",
+	})
+	ips := map[string]string{
+		"alice-container": "10.0.3.10",
+		"bob-container":   "10.0.3.20",
+		"carol-container": "", // stopped
+	}
+	syncs := new(int)
+	r := &routeRetargeter{
+		routes:      routes,
+		passthrough: passthrough,
+		lookupIP:    func(name string) (string, error) { return ips[name], nil },
+		syncRoutes: func(context.Context) error {
+			*syncs++
+			return nil
+		},
+	}
+	return r, routes, passthrough, ips, syncs
+}
+
+func TestRouteRetargeter_RetargetsContainerRoutes(t *testing.T) {
+	r, routes, passthrough, _, syncs := newRetargetFixture()
+
+	n, err := r.retarget(context.Background(), "alice-container", "10.0.3.11")
+	if err != nil {
+		t.Fatalf("retarget: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("changed %d routes, want 2 (one proxy, one passthrough)", n)
+	}
+	if got := routes.records["app.example.com"].TargetIP; got != "10.0.3.11" {
+		t.Errorf("proxy route target = %s, want 10.0.3.11", got)
+	}
+	if got := passthrough.records[passthroughKey(50051, "tcp")].TargetIP; got != "10.0.3.11" {
+		t.Errorf("passthrough target = %s, want 10.0.3.11", got)
+	}
+	if got := routes.records["api.example.com"].TargetIP; got != "10.0.3.20" {
+		t.Errorf("another container's route moved to %s", got)
+	}
+	if *syncs != 1 {
+		t.Errorf("route sync ran %d times, want 1", *syncs)
+	}
+
+	// Unchanged IP: nothing saved, no sync.
+	saves := routes.saves
+	if n, _ := r.retarget(context.Background(), "alice-container", "10.0.3.11"); n != 0 || routes.saves != saves || *syncs != 1 {
+		t.Errorf("retarget to the same IP changed %d routes, saves %d->%d, syncs %d", n, saves, routes.saves, *syncs)
+	}
+}
+
+func TestRouteRetargeter_RetargetAllAtStartup(t *testing.T) {
+	r, routes, _, ips, _ := newRetargetFixture()
+	ips["bob-container"] = "10.0.3.21" // recreated while the daemon was down
+
+	if err := r.retargetAll(context.Background()); err != nil {
+		t.Fatalf("retargetAll: %v", err)
+	}
+	if got := routes.records["api.example.com"].TargetIP; got != "10.0.3.21" {
+		t.Errorf("bob's route target = %s, want 10.0.3.21", got)
+	}
+	if got := routes.records["docs.example.com"].TargetIP; got != "10.0.3.30" {
+		t.Errorf("stopped container's route changed to %q", got)
+	}
+	if got := routes.records["ip.example.com"].TargetIP; got != "10.0.3.99" {
+		t.Errorf("route without a container changed to %q", got)
+	}
+}
+
+func TestRouteRetargeter_FollowsStartEvents(t *testing.T) {
+	r, routes, _, _, _ := newRetargetFixture()
+	bus := events.NewBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx, bus)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for bus.SubscriberCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	events.NewEmitter(bus).EmitContainerStarted(&pb.Container{
+		Name:    "alice-container",
+		Network: &pb.NetworkInfo{IpAddress: "10.0.3.12"},
+	})
+
+	for time.Now().Before(deadline) {
+		if routes.target("app.example.com") == "10.0.3.12" {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("route not retargeted on START: %s", routes.target("app.example.com"))
+}
@@ -123,6 +123,10 @@ func TestTraffic_RejectsWithoutTrafficRead(t *testing.T) {
 	if status.Code(err) != codes.PermissionDenied {
 		t.Fatalf("GetConnectionSummary without traffic:read: got %v", err)
 	}
+	_, err = srv.DescribeConnection(ctx, &pb.DescribeConnectionRequest{ContainerName: "alice-container", ConnectionId: "c1"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("DescribeConnection without traffic:read: got %v", err)
+	}
 	_, err = srv.QueryTrafficHistory(ctx, &pb.QueryTrafficHistoryRequest{ContainerName: "alice-container"})
 	if status.Code(err) != codes.PermissionDenied {
 		t.Fatalf("QueryTrafficHistory without traffic:read: got %v", err)
@@ -131,6 +135,18 @@ func TestTraffic_RejectsWithoutTrafficRead(t *testing.T) {
 	if status.Code(err) != codes.PermissionDenied {
 		t.Fatalf("GetTrafficAggregates without traffic:read: got %v", err)
 	}
+	_, err = srv.GetListeningPorts(ctx, &pb.GetListeningPortsRequest{ContainerName: "alice-container"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("GetListeningPorts without traffic:read: got %v", err)
+	}
+	_, err = srv.GetSSHSessions(ctx, &pb.GetSSHSessionsRequest{ContainerName: "alice-container"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("GetSSHSessions without traffic:read: got %v", err)
+	}
+	err = srv.StreamTrafficHistory(&pb.StreamTrafficHistoryRequest{ContainerName: "alice-container"}, &historyStream{ctx: ctx})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("StreamTrafficHistory without traffic:read: got %v", err)
+	}
 }
 
 // --- Sanity: pre-1.7 tokens still pass ---
@@ -124,6 +124,10 @@ func TestContainerWrite_RejectsMissingScope(t *testing.T) {
 			_, e := srv.ToggleAutoSleep(ctx, &pb.ToggleAutoSleepRequest{Username: "alice"})
 			return e
 		},
+		"ToggleDNSLogging": func() error {
+			_, e := srv.ToggleDNSLogging(ctx, &pb.ToggleDNSLoggingRequest{Username: "alice"})
+			return e
+		},
 	}
 	for name, call := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -145,6 +149,10 @@ func TestContainerRead_RejectsMissingScope(t *testing.T) {
 	if status.Code(err) != codes.PermissionDenied {
 		t.Fatalf("ListContainers without containers:read: got %v", err)
 	}
+	_, err = srv.GetContainerActivity(ctx, &pb.GetContainerActivityRequest{Username: "alice"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("GetContainerActivity without containers:read: got %v", err)
+	}
 }
 
 // --- SSH key writes (ssh:write scope) ---
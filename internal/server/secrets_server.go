@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"path"
 
 	"github.com/footprintai/containarium/internal/auth"
 	"github.com/footprintai/containarium/internal/safecast"
@@ -196,6 +197,11 @@ func (s *ContainerServer) RefreshSecrets(ctx context.Context, req *pb.RefreshSec
 // start (since the tmpfs file doesn't survive a stop/start),
 // which the daemon already does at CreateContainer +
 // StartContainer + RefreshSecrets call sites.
+//
+// A file secret with a tenant-chosen Path (SetContainerSecret)
+// skips /run/secrets: it is written where asked, mode 0600 and
+// owned by the tenant, by writeSecretFile. Outside /run that
+// file is on disk and outlives a stop.
 func (s *ContainerServer) stampSecretsOnLXC(ctx context.Context, username string) (int, error) {
 	if s.secretsStore == nil {
 		return 0, errors.New("secrets store not configured")
@@ -216,7 +222,7 @@ func (s *ContainerServer) stampSecretsOnLXC(ctx context.Context, username string
 	// not stamped.
 	hasFileMode := false
 	for _, v := range secretMap {
-		if v.Delivery == secrets.DeliveryFile {
+		if v.Delivery == secrets.DeliveryFile && v.Path == "" {
 			hasFileMode = true
 			break
 		}
@@ -265,6 +271,13 @@ func (s *ContainerServer) stampSecretsOnLXC(ctx context.Context, username string
 		case secrets.DeliveryCompose:
 			composeEnv[k] = sv.Value
 		case secrets.DeliveryFile:
+			if sv.Path != "" {
+				if err := s.writeSecretFile(containerName, username, sv.Path, sv.Value); err != nil {
+					log.Printf("[secrets] failed to write %s to %s on %s: %v (continuing)", k, sv.Path, containerName, err)
+					continue
+				}
+				break
+			}
 			if !hasFileMode {
 				continue
 			}
@@ -317,6 +330,23 @@ func (s *ContainerServer) stampSecretsOnLXC(ctx context.Context, username string
 	return stamped, nil
 }
 
+// writeSecretFile writes a file secret to p through the Incus file
+// API, mode 0600, then hands it to the tenant. No shell is involved:
+// p is a tenant-chosen path. chown is best-effort, as for
+// /run/secrets — without the tenant user the file stays root-only.
+func (s *ContainerServer) writeSecretFile(containerName, username, p, value string) error {
+	if err := s.manager.Exec(containerName, []string{"mkdir", "-p", path.Dir(p)}); err != nil {
+		return fmt.Errorf("failed to create %s: %w", path.Dir(p), err)
+	}
+	if err := s.manager.WriteFile(containerName, p, []byte(value), "0600"); err != nil {
+		return err
+	}
+	if err := s.manager.Exec(containerName, []string{"chown", username + ":", p}); err != nil {
+		log.Printf("[secrets] chown %s on %s failed (%v) — file stays root-only 0600", p, containerName, err)
+	}
+	return nil
+}
+
 // mapSecretError maps store errors to gRPC status codes. Centralized
 // so the five RPC methods stay short.
 func mapSecretError(err error) error {
@@ -340,6 +370,7 @@ func isValidationError(msg string) bool {
 		"name must match",
 		"value exceeds",
 		"username is required",
+		"path must be",
 	}
 	for _, kw := range keywords {
 		if containsCI(msg, kw) {
@@ -7,10 +7,12 @@ import (
 	"testing"
 
 	"github.com/footprintai/containarium/internal/app"
+	"github.com/footprintai/containarium/internal/auth"
 	"github.com/footprintai/containarium/internal/events"
 	"github.com/footprintai/containarium/pkg/core/container"
 	"github.com/footprintai/containarium/pkg/core/incus"
 	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
 
 // newStopForAutoSleepTestServer wires the minimum dependencies the
@@ -152,6 +154,9 @@ func (recordingRouteStore) ListByContainer(_ context.Context, _ string) ([]*app.
 }
 func (recordingRouteStore) Delete(context.Context, string) error         { return nil }
 func (recordingRouteStore) Save(context.Context, *app.RouteRecord) error { return nil }
+func (recordingRouteStore) List(context.Context, bool) ([]*app.RouteRecord, error) {
+	return nil, nil
+}
 
 // TestStopForAutoSleep_SwapsBeforeStop pins the #224 fix: the Caddy
 // route swap MUST happen before the container is stopped, otherwise
@@ -195,3 +200,32 @@ func TestStopForAutoSleep_SwapsBeforeStop(t *testing.T) {
 			wakeRouter.swapSeqAt, stopSeqAt)
 	}
 }
+
+// The autosleep stop is attributed to the daemon, not left anonymous, so
+// audit and event consumers can tell it from a user-initiated stop.
+func TestStopForAutoSleep_EmitsTypedStopAsSystem(t *testing.T) {
+	bus := events.NewBus()
+	sub := bus.Subscribe(nil)
+	defer bus.Unsubscribe(sub.ID)
+
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Running"}
+	s := &ContainerServer{
+		manager: container.NewWithBackend(mock),
+		emitter: events.NewEmitter(bus),
+	}
+
+	if err := s.StopForAutoSleep(context.Background(), "alice", "idle 90m", 90); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ce := (<-sub.Events).GetContainerEvent()
+	if ce.GetType() != pb.ContainerEventType_CONTAINER_EVENT_TYPE_STOP {
+		t.Errorf("type = %v, want STOP", ce.GetType())
+	}
+	if ce.GetActor() != auth.SystemSubject {
+		t.Errorf("actor = %q, want %q", ce.GetActor(), auth.SystemSubject)
+	}
+	if ce.GetDetails()["force"] != "false" {
+		t.Errorf("details = %v, want force=false", ce.GetDetails())
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestToggleDNSLogging_WritesFlag — enable and disable each write the
+// per-container key the traffic collector reads.
+func TestToggleDNSLogging_WritesFlag(t *testing.T) {
+	s, calls, _ := newAutoSleepTestServer(t, map[string]*incus.ContainerInfo{
+		"alice-container": {Name: "alice-container", State: "Running"},
+	})
+	for _, enabled := range []bool{true, false} {
+		resp, err := s.ToggleDNSLogging(testCtx(), &pb.ToggleDNSLoggingRequest{Username: "alice", Enabled: enabled})
+		if err != nil {
+			t.Fatalf("ToggleDNSLogging(%v): %v", enabled, err)
+		}
+		if resp.DnsLoggingEnabled != enabled {
+			t.Errorf("DnsLoggingEnabled = %v, want %v", resp.DnsLoggingEnabled, enabled)
+		}
+	}
+	want := []setConfigCall{
+		{name: "alice-container", key: incus.DNSLogEnabledKey, value: "true"},
+		{name: "alice-container", key: incus.DNSLogEnabledKey, value: "false"},
+	}
+	if len(*calls) != len(want) {
+		t.Fatalf("SetConfig calls = %+v, want %+v", *calls, want)
+	}
+	for i, c := range *calls {
+		if c != want[i] {
+			t.Errorf("call %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+}
+
+// TestToggleDNSLogging_Refusals — core containers, missing boxes and the
+// k8s runtime are refused without touching Incus config.
+func TestToggleDNSLogging_Refusals(t *testing.T) {
+	s, calls, _ := newAutoSleepTestServer(t, map[string]*incus.ContainerInfo{
+		"caddy-container": {Name: "caddy-container", State: "Running", Role: incus.RoleCaddy},
+	})
+	k8s := &ContainerServer{manager: s.manager, boxBackend: k8sBoxStub{}}
+
+	cases := []struct {
+		name string
+		srv  *ContainerServer
+		req  *pb.ToggleDNSLoggingRequest
+		want codes.Code
+	}{
+		{"missing username", s, &pb.ToggleDNSLoggingRequest{Enabled: true}, codes.InvalidArgument},
+		{"core container", s, &pb.ToggleDNSLoggingRequest{Username: "caddy", Enabled: true}, codes.InvalidArgument},
+		{"no such container", s, &pb.ToggleDNSLoggingRequest{Username: "ghost", Enabled: true}, codes.NotFound},
+		{"k8s runtime", k8s, &pb.ToggleDNSLoggingRequest{Username: "alice", Enabled: true}, codes.FailedPrecondition},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.srv.ToggleDNSLogging(testCtx(), tc.req); status.Code(err) != tc.want {
+				t.Errorf("err = %v, want %v", err, tc.want)
+			}
+		})
+	}
+	if len(*calls) != 0 {
+		t.Errorf("refusals must not call SetConfig, got %+v", *calls)
+	}
+}
@@ -1,9 +1,20 @@
 package server
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"slices"
 	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/footprintai/containarium/internal/auth"
 	"github.com/footprintai/containarium/internal/events"
@@ -18,6 +29,7 @@ type TrafficServer struct {
 	collector *traffic.Collector
 	eventBus  *events.Bus
 	peerPool  *PeerPool
+	services  *traffic.ServiceNames
 }
 
 // NewTrafficServer creates a new traffic server
@@ -25,9 +37,31 @@ func NewTrafficServer(collector *traffic.Collector) *TrafficServer {
 	return &TrafficServer{
 		collector: collector,
 		eventBus:  events.GetBus(),
+		services:  traffic.DefaultServiceNames(),
 	}
 }
 
+// SetServiceNames replaces the port map connections are labelled from
+// (see --traffic-services-file).
+func (s *TrafficServer) SetServiceNames(services *traffic.ServiceNames) {
+	s.services = services
+}
+
+// labelServices sets ServiceName on conns. They are the collector's own
+// and shared with every other reader, so a connection is copied before it
+// is labelled; one on an unknown port is passed through as is.
+func (s *TrafficServer) labelServices(conns []*pb.Connection) []*pb.Connection {
+	out := make([]*pb.Connection, len(conns))
+	for i, conn := range conns {
+		if name := s.services.Lookup(conn.Protocol, conn.DestPort); name != "" {
+			conn = proto.Clone(conn).(*pb.Connection)
+			conn.ServiceName = name
+		}
+		out[i] = conn
+	}
+	return out
+}
+
 // SetPeerPool sets the peer pool for forwarding traffic queries to peers.
 func (s *TrafficServer) SetPeerPool(pool *PeerPool) {
 	s.peerPool = pool
@@ -48,9 +82,69 @@ func (s *TrafficServer) GetConnections(ctx context.Context, req *pb.GetConnectio
 		return nil, err
 	}
 
-	connections := s.collector.GetConnections(req.ContainerName)
+	if req.MinBytes < 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_bytes must not be negative")
+	}
+	if req.MinAgeSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_age_seconds must not be negative")
+	}
+	if req.MinRateBps < 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_rate_bps must not be negative")
+	}
+	if req.ServiceName != "" && !s.services.Known(req.ServiceName) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown service_name %q (not in the daemon's port map)", req.ServiceName)
+	}
+
+	if req.Fresh {
+		s.collector.RefreshNow()
+	}
+	filtered := filterConnections(s.collector.GetConnections(req.ContainerName), req, s.services, time.Now())
+
+	// Apply limit
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 100
+	}
+	totalCount := len(filtered)
+	if len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+
+	return &pb.GetConnectionsResponse{
+		Connections: s.labelServices(filtered),
+		TotalCount:  safecast.I32(totalCount),
+		Cache:       cacheStatus(ctx, s.collector.CacheStats()),
 
-	// Apply filters
+		SnapshotIntervalSeconds: s.collector.SnapshotInterval().Seconds(),
+	}, nil
+}
+
+// cacheStatus converts the container cache's stats for the response. The
+// Incus error is host detail, so only admins get it.
+func cacheStatus(ctx context.Context, st traffic.CacheStats) *pb.ContainerCacheStatus {
+	out := &pb.ContainerCacheStatus{
+		Stale:               st.Stale,
+		BreakerOpen:         st.BreakerOpen,
+		ConsecutiveFailures: safecast.I32(st.ConsecutiveFailures),
+		Containers:          safecast.I32(st.Containers),
+	}
+	if !st.LastRefresh.IsZero() {
+		out.LastRefreshTime = timestamppb.New(st.LastRefresh)
+	}
+	if st.BreakerOpen {
+		out.RetryTime = timestamppb.New(st.RetryAt)
+	}
+	if auth.RequireRole(ctx, auth.RoleAdmin) == nil {
+		out.LastError = st.LastError
+	}
+	return out
+}
+
+// filterConnections applies GetConnections' filters. With min_bytes set
+// the survivors are ordered by total bytes, heaviest first, so the limit
+// keeps the top talkers. services resolves the service_name filter.
+func filterConnections(connections []*pb.Connection, req *pb.GetConnectionsRequest, services *traffic.ServiceNames, now time.Time) []*pb.Connection {
+	minAge := time.Duration(req.MinAgeSeconds) * time.Second
 	var filtered []*pb.Connection
 	for _, conn := range connections {
 		// Filter by protocol
@@ -68,23 +162,93 @@ func (s *TrafficServer) GetConnections(ctx context.Context, req *pb.GetConnectio
 			continue
 		}
 
+		// Filter by well-known service
+		if req.ServiceName != "" && !services.Is(req.ServiceName, conn.Protocol, conn.DestPort) {
+			continue
+		}
+
+		// Filter out light connections
+		if req.MinBytes > 0 && conn.BytesSent+conn.BytesReceived < req.MinBytes {
+			continue
+		}
+
+		// Filter out young connections
+		if minAge > 0 && (conn.FirstSeen == nil || now.Sub(conn.FirstSeen.AsTime()) < minAge) {
+			continue
+		}
+
+		// Filter out idle connections, and those without a rate yet
+		if req.MinRateBps > 0 && (conn.BytesSentPerSec == nil || connectionRate(conn) < req.MinRateBps) {
+			continue
+		}
+
 		filtered = append(filtered, conn)
 	}
+	switch {
+	case req.MinRateBps > 0:
+		slices.SortFunc(filtered, func(a, b *pb.Connection) int {
+			return cmp.Compare(connectionRate(b), connectionRate(a))
+		})
+	case req.MinBytes > 0:
+		slices.SortFunc(filtered, func(a, b *pb.Connection) int {
+			return cmp.Compare(b.BytesSent+b.BytesReceived, a.BytesSent+a.BytesReceived)
+		})
+	}
+	return filtered
+}
 
-	// Apply limit
-	limit := int(req.Limit)
-	if limit == 0 {
-		limit = 100
+// connectionRate is conn's bytes per second in both directions, 0 when no
+// rate has been measured.
+func connectionRate(conn *pb.Connection) float64 {
+	return conn.GetBytesSentPerSec() + conn.GetBytesReceivedPerSec()
+}
+
+// ListTrafficContainers returns the containers the collector tracks, for
+// pickers. Tenants get only the containers they own.
+func (s *TrafficServer) ListTrafficContainers(ctx context.Context, req *pb.ListTrafficContainersRequest) (*pb.ListTrafficContainersResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
 	}
-	totalCount := len(filtered)
-	if len(filtered) > limit {
-		filtered = filtered[:limit]
+
+	ips := s.collector.TrackedContainerIPs()
+	resp := &pb.ListTrafficContainersResponse{
+		Cache: cacheStatus(ctx, s.collector.CacheStats()),
 	}
+	for _, name := range slices.Sorted(maps.Keys(ips)) {
+		if auth.AuthorizeContainerAccess(ctx, name) != nil {
+			continue
+		}
+		resp.Containers = append(resp.Containers, &pb.TrafficContainer{Name: name, IpAddress: ips[name]})
+	}
+	return resp, nil
+}
 
-	return &pb.GetConnectionsResponse{
-		Connections: filtered,
-		TotalCount:  safecast.I32(totalCount),
-	}, nil
+// GetTrafficStatus reports what the collector is running with, for
+// operators: monitoring, persistence and GeoIP enrichment, with a warning
+// for each part that is degraded. Admin only, since it names host paths.
+func (s *TrafficServer) GetTrafficStatus(ctx context.Context, _ *pb.GetTrafficStatusRequest) (*pb.GetTrafficStatusResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if s.collector == nil {
+		return &pb.GetTrafficStatusResponse{Error: "traffic monitoring not enabled", Geoip: &pb.GeoIPStatus{}}, nil
+	}
+
+	resp := &pb.GetTrafficStatusResponse{
+		Available:          s.collector.IsAvailable(),
+		Error:              s.collector.Error(),
+		PersistenceEnabled: s.collector.PersistenceEnabled(),
+	}
+	if !resp.Available {
+		resp.Warnings = append(resp.Warnings, resp.Error)
+	}
+	if resp.PersistenceEnabled && s.collector.GetStore() == nil {
+		resp.Warnings = append(resp.Warnings, "traffic persistence is enabled but the history store is not connected")
+	}
+	geo, warnings := s.collector.GeoIPStatus()
+	resp.Geoip = geo
+	resp.Warnings = append(resp.Warnings, warnings...)
+	return resp, nil
 }
 
 // GetConnectionSummary returns aggregate connection statistics.
@@ -100,13 +264,218 @@ func (s *TrafficServer) GetConnectionSummary(ctx context.Context, req *pb.GetCon
 		return nil, err
 	}
 
-	summary := s.collector.GetConnectionSummary(req.ContainerName)
+	summary := s.collector.GetConnectionSummary(req.ContainerName, req.SeparateDns)
+	for _, st := range summary.TopServices {
+		st.ServiceName = s.services.Lookup(st.Protocol, st.Port)
+	}
 
 	return &pb.GetConnectionSummaryResponse{
 		Summary: summary,
 	}, nil
 }
 
+// DescribeConnection resolves the process inside the container that owns
+// an active connection. Same authz as GetConnections: the answer is about
+// the caller's own container, so no extra scope beyond traffic:read.
+func (s *TrafficServer) DescribeConnection(ctx context.Context, req *pb.DescribeConnectionRequest) (*pb.DescribeConnectionResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if req.ContainerName == "" {
+		return nil, fmt.Errorf("container_name is required")
+	}
+	if req.ConnectionId == "" {
+		return nil, fmt.Errorf("connection_id is required")
+	}
+	if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.collector.DescribeConnection(req.ContainerName, req.ConnectionId)
+	if errors.Is(err, traffic.ErrConnectionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "connection %s not found in %s", req.ConnectionId, req.ContainerName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe connection: %w", err)
+	}
+
+	conn.ServiceName = s.services.Lookup(conn.Protocol, conn.DestPort)
+	return &pb.DescribeConnectionResponse{
+		Connection: conn,
+	}, nil
+}
+
+// GetConnectionTimeline returns the states one connection went through,
+// oldest first. Recording is opt-in on the daemon, so a collector without
+// it answers FailedPrecondition rather than an empty timeline.
+func (s *TrafficServer) GetConnectionTimeline(ctx context.Context, req *pb.GetConnectionTimelineRequest) (*pb.GetConnectionTimelineResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if req.ContainerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "container_name is required")
+	}
+	if req.ConntrackId == "" {
+		return nil, status.Error(codes.InvalidArgument, "conntrack_id is required")
+	}
+	if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+		return nil, err
+	}
+	if err := s.requireHistory(); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.collector.ConnectionTimeline(ctx, req.ContainerName, req.ConntrackId)
+	if errors.Is(err, traffic.ErrTimelineDisabled) || errors.Is(err, traffic.ErrTimelineUnsupported) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get connection timeline: %v", err)
+	}
+	return &pb.GetConnectionTimelineResponse{Changes: changes}, nil
+}
+
+// QueryDNSHistory returns the DNS queries a container made through the
+// host resolver, newest first. Like GetConnectionTimeline it depends on an
+// opt-in daemon feature and answers FailedPrecondition without it.
+func (s *TrafficServer) QueryDNSHistory(ctx context.Context, req *pb.QueryDNSHistoryRequest) (*pb.QueryDNSHistoryResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if req.ContainerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "container_name is required")
+	}
+	if req.AnswerIp != "" && net.ParseIP(req.AnswerIp) == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "answer_ip %q is not an IP address", req.AnswerIp)
+	}
+	if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+		return nil, err
+	}
+	if err := s.requireHistory(); err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+	start := end.Add(-time.Hour)
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	queries, err := s.collector.QueryDNSHistory(ctx, traffic.DNSQueryParams{
+		ContainerName: req.ContainerName,
+		StartTime:     start,
+		EndTime:       end,
+		QName:         req.Qname,
+		AnswerIP:      req.AnswerIp,
+		Limit:         int(req.Limit),
+	})
+	if errors.Is(err, traffic.ErrDNSDisabled) || errors.Is(err, traffic.ErrDNSUnsupported) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query DNS history: %v", err)
+	}
+	return &pb.QueryDNSHistoryResponse{Queries: queries}, nil
+}
+
+// GetSSHSessions returns the SSH logins to a container, newest first,
+// with the traffic each carried.
+func (s *TrafficServer) GetSSHSessions(ctx context.Context, req *pb.GetSSHSessionsRequest) (*pb.GetSSHSessionsResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if req.ContainerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "container_name is required")
+	}
+	if req.ClientIp != "" && net.ParseIP(req.ClientIp) == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "client_ip %q is not an IP address", req.ClientIp)
+	}
+	if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+		return nil, err
+	}
+	if err := s.requireHistory(); err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+	start := end.Add(-24 * time.Hour)
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	sessions, err := s.collector.QuerySSHSessions(ctx, traffic.SSHSessionParams{
+		ContainerName: req.ContainerName,
+		StartTime:     start,
+		EndTime:       end,
+		ClientIP:      req.ClientIp,
+		Limit:         int(req.Limit),
+	})
+	if errors.Is(err, traffic.ErrSSHDisabled) || errors.Is(err, traffic.ErrSSHUnsupported) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query SSH sessions: %v", err)
+	}
+	return &pb.GetSSHSessionsResponse{Sessions: sessions}, nil
+}
+
+// GetListeningPorts returns the ports a container listens on as of the
+// collector's latest scan, plus recorded changes when history_since is set.
+func (s *TrafficServer) GetListeningPorts(ctx context.Context, req *pb.GetListeningPortsRequest) (*pb.GetListeningPortsResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if req.ContainerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "container_name is required")
+	}
+	if req.HistoryLimit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "history_limit must not be negative")
+	}
+	if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+		return nil, err
+	}
+	if s.collector == nil {
+		return nil, status.Error(codes.FailedPrecondition, "traffic monitoring not enabled")
+	}
+
+	listeners, scannedAt, err := s.collector.GetListeningPorts(req.ContainerName)
+	if errors.Is(err, traffic.ErrListenersDisabled) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get listening ports: %v", err)
+	}
+	resp := &pb.GetListeningPortsResponse{Listeners: listeners}
+	if !scannedAt.IsZero() {
+		resp.ScannedAt = timestamppb.New(scannedAt)
+	}
+	if req.HistorySince == nil {
+		return resp, nil
+	}
+	if err := s.requireHistory(); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.collector.QueryListenerHistory(ctx, traffic.ListenerQueryParams{
+		ContainerName: req.ContainerName,
+		StartTime:     req.HistorySince.AsTime(),
+		EndTime:       time.Now(),
+		Limit:         int(req.HistoryLimit),
+	})
+	if errors.Is(err, traffic.ErrListenersUnsupported) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query listener history: %v", err)
+	}
+	resp.Changes = changes
+	return resp, nil
+}
+
 // SubscribeTraffic opens a streaming connection for real-time traffic events.
 // Phase 1.4 — when ContainerName is set, tenant authz via the
 // owner derivation; when blank, the stream would cover all
@@ -143,36 +512,10 @@ func (s *TrafficServer) SubscribeTraffic(req *pb.SubscribeTrafficRequest, stream
 		case event := <-sub.Events:
 			// Extract traffic event from generic event
 			trafficEvent := event.GetTrafficEvent()
-			if trafficEvent == nil {
+			if !traffic.MatchesSubscription(req, trafficEvent) {
 				continue
 			}
 
-			// Apply container filter
-			if req.ContainerName != "" {
-				if trafficEvent.Connection == nil || trafficEvent.Connection.ContainerName != req.ContainerName {
-					continue
-				}
-			}
-
-			// Apply event type filter
-			if len(req.EventTypes) > 0 {
-				found := false
-				for _, et := range req.EventTypes {
-					if et == trafficEvent.Type {
-						found = true
-						break
-					}
-				}
-				if !found {
-					continue
-				}
-			}
-
-			// Apply external only filter.
-			// TODO: when req.ExternalOnly is set, skip events whose destination
-			// is also a container IP. This requires consulting the IP cache and
-			// is not yet implemented.
-
 			if err := stream.Send(trafficEvent); err != nil {
 				return err
 			}
@@ -186,37 +529,267 @@ func (s *TrafficServer) QueryTrafficHistory(ctx context.Context, req *pb.QueryTr
 	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
 		return nil, err
 	}
-	if req.ContainerName == "" {
-		return nil, fmt.Errorf("container_name is required")
+	params := traffic.QueryParams{
+		ContainerNames:      requestedContainers(req.ContainerName, req.ContainerNames),
+		ContainerNamePrefix: req.ContainerNamePrefix,
+		Username:            req.Username,
+		StartTime:           req.StartTime.AsTime(),
+		EndTime:             req.EndTime.AsTime(),
+		DestIP:              req.DestIp,
+		SourceIP:            req.SourceIp,
+		DestPort:            int(req.DestPort),
+		Offset:              int(req.Offset),
+		Limit:               int(req.Limit),
+		IncludeOpen:         req.IncludeOpen,
+		State:               req.State,
+
+		PolicyViolationsOnly: req.PolicyViolationsOnly,
 	}
-	if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+	omitted, err := authorizeHistoryQuery(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := s.historyStore()
+	if err != nil {
 		return nil, err
 	}
 
+	connections, totalCount, err := store.QueryConnections(ctx, params)
+	if err != nil {
+		return nil, historyQueryError(err)
+	}
+	s.collector.AnnotateSSHSessions(ctx, connections)
+
+	return &pb.QueryTrafficHistoryResponse{
+		Connections:       connections,
+		TotalCount:        totalCount,
+		OmittedContainers: omitted,
+	}, nil
+}
+
+const (
+	defaultHistoryBatch = 500
+	maxHistoryBatch     = 1000
+)
+
+// authorizeHistoryQuery checks that the caller may read the history a
+// query names, narrowing p.ContainerNames to the containers the caller may
+// read and returning the rest. A query with neither a container nor a
+// username searches every container: that is admin only, and it must name
+// a container name prefix or a destination or source address so the store
+// can use an index instead of scanning all history. A prefix can match
+// other tenants' containers, so a tenant must pair it with their own
+// username.
+func authorizeHistoryQuery(ctx context.Context, p *traffic.QueryParams) (omitted []string, err error) {
+	for _, f := range []struct{ name, ip string }{{"dest_ip", p.DestIP}, {"source_ip", p.SourceIP}} {
+		if f.ip != "" && net.ParseIP(f.ip) == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s %q is not an IP address", f.name, f.ip)
+		}
+	}
+	if len(p.ContainerNames) > 0 && p.ContainerNamePrefix != "" {
+		return nil, status.Error(codes.InvalidArgument, "container names and container_name_prefix are mutually exclusive")
+	}
+	if len(p.ContainerNames) == 0 && p.Username == "" {
+		if p.ContainerNamePrefix == "" && p.DestIP == "" && p.SourceIP == "" && !p.PolicyViolationsOnly {
+			return nil, status.Error(codes.InvalidArgument, "container_name, container_name_prefix, username, dest_ip, source_ip or policy_violations_only is required")
+		}
+		return nil, auth.RequireRole(ctx, auth.RoleAdmin)
+	}
+	if len(p.ContainerNames) > 0 {
+		if p.ContainerNames, omitted, err = readableContainers(ctx, p.ContainerNames); err != nil {
+			return nil, err
+		}
+	}
+	if p.Username != "" {
+		if err := auth.AuthorizeTenant(ctx, p.Username); err != nil {
+			return nil, err
+		}
+	}
+	return omitted, nil
+}
+
+// requestedContainers merges a request's container_name and
+// container_names, dropping blanks and repeats.
+func requestedContainers(name string, names []string) []string {
+	var out []string
+	for _, n := range append([]string{name}, names...) {
+		if n != "" && !slices.Contains(out, n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// readableContainers splits names into the containers the caller may read
+// and those it may not, so a multi-container query covers the caller's
+// share instead of failing outright. It fails only when none are readable.
+func readableContainers(ctx context.Context, names []string) (allowed, omitted []string, err error) {
+	var denied error
+	for _, name := range names {
+		err := auth.AuthorizeContainerAccess(ctx, name)
+		switch {
+		case err == nil:
+			allowed = append(allowed, name)
+		case status.Code(err) == codes.PermissionDenied:
+			omitted = append(omitted, name)
+			denied = cmp.Or(denied, err)
+		default:
+			return nil, nil, err
+		}
+	}
+	if len(allowed) == 0 && denied != nil {
+		return nil, nil, denied
+	}
+	return allowed, omitted, nil
+}
+
+// requireHistory answers FailedPrecondition unless the collector keeps
+// history: traffic monitoring is off, or the daemon runs without
+// persistence.
+func (s *TrafficServer) requireHistory() error {
+	if s.collector == nil {
+		return status.Error(codes.FailedPrecondition, "traffic monitoring not enabled")
+	}
+	if !s.collector.PersistenceEnabled() {
+		return status.Error(codes.FailedPrecondition, traffic.ErrPersistenceDisabled.Error())
+	}
+	return nil
+}
+
+// historyStore returns the store history queries read, after
+// requireHistory. It is nil until the database comes up.
+func (s *TrafficServer) historyStore() (traffic.ConnectionStore, error) {
+	if err := s.requireHistory(); err != nil {
+		return nil, err
+	}
 	store := s.collector.GetStore()
 	if store == nil {
-		return nil, fmt.Errorf("traffic persistence not available")
+		return nil, status.Error(codes.FailedPrecondition, "traffic persistence not available")
 	}
+	return store, nil
+}
 
+// historyQueryError maps a store error to a gRPC status. A query the
+// store's HistoryLimits refused is the caller's to narrow.
+func historyQueryError(err error) error {
+	var broad *traffic.QueryTooBroadError
+	if errors.As(err, &broad) {
+		return status.Error(codes.FailedPrecondition, broad.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to query traffic history: %v", err)
+}
+
+// StreamTrafficHistory streams the connections QueryTrafficHistory would
+// return, without its 1000-row cap: the store is paged by keyset and each
+// page is sent as one batch, so memory and message size stay bounded by
+// batch_size however large the range is. Admins' exports are exempt from
+// the store's HistoryLimits; keyset pages keep each statement small.
+func (s *TrafficServer) StreamTrafficHistory(req *pb.StreamTrafficHistoryRequest, stream pb.TrafficService_StreamTrafficHistoryServer) error {
+	ctx := stream.Context()
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return err
+	}
 	params := traffic.QueryParams{
-		ContainerName: req.ContainerName,
-		StartTime:     req.StartTime.AsTime(),
-		EndTime:       req.EndTime.AsTime(),
-		DestIP:        req.DestIp,
-		DestPort:      int(req.DestPort),
-		Offset:        int(req.Offset),
-		Limit:         int(req.Limit),
+		ContainerNames:      requestedContainers(req.ContainerName, req.ContainerNames),
+		ContainerNamePrefix: req.ContainerNamePrefix,
+		Username:            req.Username,
+		StartTime:           req.StartTime.AsTime(),
+		EndTime:             req.EndTime.AsTime(),
+		DestIP:              req.DestIp,
+		SourceIP:            req.SourceIp,
+		DestPort:            int(req.DestPort),
+		Limit:               cmp.Or(int(req.BatchSize), defaultHistoryBatch),
+		IncludeOpen:         req.IncludeOpen,
+		State:               req.State,
+		Unbounded:           auth.RequireRole(ctx, auth.RoleAdmin) == nil,
+
+		PolicyViolationsOnly: req.PolicyViolationsOnly,
+	}
+	omitted, err := authorizeHistoryQuery(ctx, &params)
+	if err != nil {
+		return err
+	}
+	if req.BatchSize < 0 || req.BatchSize > maxHistoryBatch {
+		return status.Errorf(codes.InvalidArgument, "batch_size must be between 0 and %d", maxHistoryBatch)
+	}
+	store, err := s.historyStore()
+	if err != nil {
+		return err
 	}
 
-	connections, totalCount, err := store.QueryConnections(ctx, params)
+	first := true
+	return streamHistoryBatches(ctx, store, params, func(batch *pb.TrafficHistoryBatch) error {
+		if first {
+			batch.OmittedContainers, first = omitted, false
+		}
+		return stream.Send(batch)
+	})
+}
+
+// streamHistoryBatches pages params through store and hands each page to
+// send until a short page marks the end. Only the first batch carries the
+// total count.
+func streamHistoryBatches(ctx context.Context, store traffic.ConnectionStore, params traffic.QueryParams, send func(*pb.TrafficHistoryBatch) error) error {
+	for first := true; ; first = false {
+		conns, total, err := store.QueryConnections(ctx, params)
+		if err != nil {
+			return historyQueryError(err)
+		}
+		if !first && len(conns) == 0 {
+			return nil
+		}
+		if err := send(&pb.TrafficHistoryBatch{Connections: conns, TotalCount: total}); err != nil {
+			return err
+		}
+		if len(conns) < params.Limit {
+			return nil
+		}
+		params.After = traffic.CursorAfter(conns[len(conns)-1])
+	}
+}
+
+// QueryByDestination lists the containers that connected to an address or
+// network. Admin only: it spans tenants.
+func (s *TrafficServer) QueryByDestination(ctx context.Context, req *pb.QueryByDestinationRequest) (*pb.QueryByDestinationResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if req.Destination == "" {
+		return nil, status.Error(codes.InvalidArgument, "destination is required")
+	}
+	dest, err := traffic.ParseDestination(req.Destination)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query traffic history: %w", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := s.historyStore(); err != nil {
+		return nil, err
 	}
 
-	return &pb.QueryTrafficHistoryResponse{
-		Connections: connections,
-		TotalCount:  totalCount,
-	}, nil
+	end := time.Now()
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+	start := end.AddDate(0, 0, -7)
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	contacts, err := s.collector.QueryByDestination(ctx, traffic.DestinationQueryParams{
+		Destination: dest,
+		StartTime:   start,
+		EndTime:     end,
+		DestPort:    int(req.DestPort),
+	})
+	if errors.Is(err, traffic.ErrDestinationQueryUnsupported) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query traffic by destination: %v", err)
+	}
+	return &pb.QueryByDestinationResponse{Containers: contacts}, nil
 }
 
 // GetTrafficAggregates returns time-series traffic aggregates.
@@ -225,25 +798,35 @@ func (s *TrafficServer) GetTrafficAggregates(ctx context.Context, req *pb.GetTra
 	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
 		return nil, err
 	}
-	if req.ContainerName == "" {
-		return nil, fmt.Errorf("container_name is required")
+	names := requestedContainers(req.ContainerName, req.ContainerNames)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("container_name or container_names is required")
 	}
-	if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+	names, omitted, err := readableContainers(ctx, names)
+	if err != nil {
 		return nil, err
 	}
 
-	store := s.collector.GetStore()
-	if store == nil {
-		return nil, fmt.Errorf("traffic persistence not available")
+	if _, err := traffic.AggregateLocation(req.Timezone); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	store, err := s.historyStore()
+	if err != nil {
+		return nil, err
 	}
 
 	params := traffic.AggregateParams{
-		ContainerName:   req.ContainerName,
-		StartTime:       req.StartTime.AsTime(),
-		EndTime:         req.EndTime.AsTime(),
-		Interval:        req.Interval,
-		GroupByDestIP:   req.GroupByDestIp,
-		GroupByDestPort: req.GroupByDestPort,
+		ContainerNames:   names,
+		StartTime:        req.StartTime.AsTime(),
+		EndTime:          req.EndTime.AsTime(),
+		Interval:         req.Interval,
+		GroupByDestIP:    req.GroupByDestIp,
+		GroupByDestPort:  req.GroupByDestPort,
+		GroupByDirection: req.GroupByDirection,
+		GroupByCountry:   req.GroupByCountry,
+		GroupByASN:       req.GroupByAsn,
+		Timezone:         req.Timezone,
 	}
 
 	aggregates, err := store.GetAggregates(ctx, params)
@@ -252,6 +835,92 @@ func (s *TrafficServer) GetTrafficAggregates(ctx context.Context, req *pb.GetTra
 	}
 
 	return &pb.GetTrafficAggregatesResponse{
-		Aggregates: aggregates,
+		Aggregates:        aggregates,
+		OmittedContainers: omitted,
+	}, nil
+}
+
+// usageError maps traffic usage failures onto gRPC status codes.
+func usageError(err error) error {
+	if errors.Is(err, traffic.ErrUsageUnsupported) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to get traffic usage: %v", err)
+}
+
+// GetDailyUsage returns one container's billed traffic per day for a month.
+func (s *TrafficServer) GetDailyUsage(ctx context.Context, req *pb.GetDailyUsageRequest) (*pb.GetDailyUsageResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if req.ContainerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "container_name is required")
+	}
+	if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+		return nil, err
+	}
+	month, err := traffic.ParseUsageMonth(req.Month, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.requireHistory(); err != nil {
+		return nil, err
+	}
+
+	days, err := s.collector.DailyUsage(ctx, req.ContainerName, month)
+	if err != nil {
+		return nil, usageError(err)
+	}
+	total := &pb.DailyUsage{ContainerName: req.ContainerName}
+	if totals := traffic.UsageTotals(days); len(totals) == 1 {
+		total = totals[0]
+	}
+	return &pb.GetDailyUsageResponse{Days: days, Total: total}, nil
+}
+
+// GetAllDailyUsage returns every container's billed traffic per day for a
+// month. Admin only: it spans tenants.
+func (s *TrafficServer) GetAllDailyUsage(ctx context.Context, req *pb.GetAllDailyUsageRequest) (*pb.GetAllDailyUsageResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	month, err := traffic.ParseUsageMonth(req.Month, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.requireHistory(); err != nil {
+		return nil, err
+	}
+
+	days, err := s.collector.DailyUsage(ctx, "", month)
+	if err != nil {
+		return nil, usageError(err)
+	}
+	return &pb.GetAllDailyUsageResponse{Days: days, Totals: traffic.UsageTotals(days)}, nil
+}
+
+// BackfillDailyUsage bills connection history recorded before the daily
+// rollup existed. Admin only.
+func (s *TrafficServer) BackfillDailyUsage(ctx context.Context, _ *pb.BackfillDailyUsageRequest) (*pb.BackfillDailyUsageResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := s.requireHistory(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.collector.RollupUsage(ctx, true)
+	if err != nil {
+		if errors.Is(err, traffic.ErrUsageUnsupported) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to backfill traffic usage: %v", err)
+	}
+	return &pb.BackfillDailyUsageResponse{
+		ConnectionsBilled: res.ConnectionsBilled,
+		DaysRecomputed:    safecast.I32(res.DaysRecomputed),
 	}, nil
 }
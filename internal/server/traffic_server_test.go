@@ -0,0 +1,496 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/traffic"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestFilterConnections_MinBytesTopTalkers(t *testing.T) {
+	conns := []*pb.Connection{
+		{Id: "small", DestPort: 443, BytesSent: 100, BytesReceived: 100},
+		{Id: "mid", DestPort: 443, BytesSent: 1000, BytesReceived: 4000},
+		{Id: "big", DestPort: 443, BytesSent: 9000, BytesReceived: 1000},
+		{Id: "other-port", DestPort: 22, BytesSent: 50000},
+		// Exactly at the threshold counts.
+		{Id: "edge", DestPort: 443, BytesReceived: 1000},
+	}
+
+	got := filterConnections(conns, &pb.GetConnectionsRequest{DestPort: 443, MinBytes: 1000}, nil, time.Now())
+	var ids []string
+	for _, c := range got {
+		ids = append(ids, c.Id)
+	}
+	want := []string{"big", "mid", "edge"}
+	if len(ids) != len(want) {
+		t.Fatalf("got %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("got %v, want %v (heaviest first)", ids, want)
+		}
+	}
+
+	// Without min_bytes the collector's order is kept.
+	got = filterConnections(conns, &pb.GetConnectionsRequest{DestPort: 443}, nil, time.Now())
+	if len(got) != 4 || got[0].Id != "small" {
+		t.Errorf("unfiltered order changed: %v", got)
+	}
+}
+
+func TestFilterConnections_MinAge(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	conns := []*pb.Connection{
+		{Id: "fresh", FirstSeen: timestamppb.New(now.Add(-30 * time.Second))},
+		{Id: "day-old", FirstSeen: timestamppb.New(now.Add(-24 * time.Hour))},
+		{Id: "hour", FirstSeen: timestamppb.New(now.Add(-time.Hour))},
+		// No first observation: its age is unknown, so it never matches.
+		{Id: "unknown"},
+	}
+
+	got := filterConnections(conns, &pb.GetConnectionsRequest{MinAgeSeconds: 3600}, nil, now)
+	if len(got) != 2 || got[0].Id != "day-old" || got[1].Id != "hour" {
+		t.Errorf("got %v, want day-old and hour", got)
+	}
+}
+
+func TestFilterConnections_MinRate(t *testing.T) {
+	rate := func(sent, received float64) (*float64, *float64) { return &sent, &received }
+	conns := []*pb.Connection{
+		{Id: "idle"},
+		{Id: "slow"},
+		{Id: "fast"},
+		{Id: "upload"},
+		// Lots of bytes but no rate measured yet: never matches.
+		{Id: "new", BytesSent: 1 << 30},
+	}
+	conns[0].BytesSentPerSec, conns[0].BytesReceivedPerSec = rate(0, 0)
+	conns[1].BytesSentPerSec, conns[1].BytesReceivedPerSec = rate(100, 900)
+	conns[2].BytesSentPerSec, conns[2].BytesReceivedPerSec = rate(500, 80000)
+	conns[3].BytesSentPerSec, conns[3].BytesReceivedPerSec = rate(20000, 0)
+
+	got := filterConnections(conns, &pb.GetConnectionsRequest{MinRateBps: 1000}, nil, time.Now())
+	var ids []string
+	for _, c := range got {
+		ids = append(ids, c.Id)
+	}
+	if strings.Join(ids, ",") != "fast,upload,slow" {
+		t.Errorf("got %v, want fast, upload, slow (fastest first)", ids)
+	}
+}
+
+func TestFilterConnections_ServiceName(t *testing.T) {
+	conns := []*pb.Connection{
+		{Id: "dns-udp", Protocol: pb.Protocol_PROTOCOL_UDP, DestPort: 53},
+		{Id: "dns-tcp", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 53},
+		{Id: "https", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 443},
+		{Id: "unnamed", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 4444},
+	}
+	services := traffic.DefaultServiceNames()
+	ids := func(got []*pb.Connection) string {
+		var out []string
+		for _, c := range got {
+			out = append(out, c.Id)
+		}
+		return strings.Join(out, ",")
+	}
+
+	got := filterConnections(conns, &pb.GetConnectionsRequest{ServiceName: "DNS"}, services, time.Now())
+	if ids(got) != "dns-udp,dns-tcp" {
+		t.Errorf("service dns: got %s", ids(got))
+	}
+	got = filterConnections(conns, &pb.GetConnectionsRequest{ServiceName: "dns", Protocol: pb.Protocol_PROTOCOL_UDP}, services, time.Now())
+	if ids(got) != "dns-udp" {
+		t.Errorf("service dns over udp: got %s", ids(got))
+	}
+}
+
+func TestGetConnections_UnknownService(t *testing.T) {
+	srv := NewTrafficServer(nil)
+	_, err := srv.GetConnections(tenantCtx("alice"), &pb.GetConnectionsRequest{ContainerName: "alice-container", ServiceName: "gopher"})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "gopher") {
+		t.Fatalf("got %v, want InvalidArgument naming the service", err)
+	}
+}
+
+// historyStream collects what StreamTrafficHistory sends.
+type historyStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	batches []*pb.TrafficHistoryBatch
+}
+
+func (h *historyStream) Context() context.Context { return h.ctx }
+
+func (h *historyStream) Send(b *pb.TrafficHistoryBatch) error {
+	h.batches = append(h.batches, b)
+	return nil
+}
+
+func TestStreamTrafficHistory_PagesThroughStore(t *testing.T) {
+	ctx := context.Background()
+	store := traffic.NewMemoryStore(0)
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i := range 7 {
+		start := base.Add(time.Duration(i) * time.Minute)
+		if err := store.SaveConnection(ctx, &pb.Connection{
+			Id:            fmt.Sprint(i),
+			ContainerName: "alice-container",
+			Protocol:      pb.Protocol_PROTOCOL_TCP,
+			DestIp:        "192.0.2.1",
+			DestPort:      443,
+			FirstSeen:     timestamppb.New(start),
+			LastSeen:      timestamppb.New(start.Add(time.Second)),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	collector, err := traffic.NewCollector(traffic.CollectorConfig{PersistenceEnabled: true}, nil, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewTrafficServer(collector)
+
+	stream := &historyStream{ctx: tenantCtx("alice")}
+	err = srv.StreamTrafficHistory(&pb.StreamTrafficHistoryRequest{
+		ContainerName: "alice-container",
+		StartTime:     timestamppb.New(base.Add(-time.Hour)),
+		EndTime:       timestamppb.New(base.Add(time.Hour)),
+		BatchSize:     3,
+	}, stream)
+	if err != nil {
+		t.Fatalf("StreamTrafficHistory: %v", err)
+	}
+	if len(stream.batches) != 3 {
+		t.Fatalf("got %d batches, want 3 (3+3+1)", len(stream.batches))
+	}
+	if stream.batches[0].TotalCount != 7 || stream.batches[1].TotalCount != 0 {
+		t.Errorf("total counts = %d, %d; want 7 on the first batch only", stream.batches[0].TotalCount, stream.batches[1].TotalCount)
+	}
+	var last time.Time
+	n := 0
+	for _, b := range stream.batches {
+		for _, c := range b.Connections {
+			if n > 0 && !c.StartedAt.AsTime().Before(last) {
+				t.Fatalf("connection %d started at %v, not before %v", n, c.StartedAt.AsTime(), last)
+			}
+			last = c.StartedAt.AsTime()
+			n++
+		}
+	}
+	if n != 7 {
+		t.Errorf("streamed %d connections, want 7", n)
+	}
+
+	// An empty range still answers with one (empty) batch.
+	stream = &historyStream{ctx: tenantCtx("alice")}
+	if err := srv.StreamTrafficHistory(&pb.StreamTrafficHistoryRequest{ContainerName: "alice-container"}, stream); err != nil {
+		t.Fatalf("StreamTrafficHistory: %v", err)
+	}
+	if len(stream.batches) != 1 || len(stream.batches[0].Connections) != 0 {
+		t.Errorf("empty range sent %v", stream.batches)
+	}
+}
+
+func TestGetTrafficAggregates_RejectsUnknownTimezone(t *testing.T) {
+	srv := &TrafficServer{}
+	_, err := srv.GetTrafficAggregates(tenantCtx("alice"), &pb.GetTrafficAggregatesRequest{ContainerName: "alice-container", Timezone: "Nowhere/Special"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("got %v want InvalidArgument", err)
+	}
+}
+
+func TestHistoryRPCs_PersistenceDisabled(t *testing.T) {
+	collector, err := traffic.NewCollector(traffic.CollectorConfig{}, nil, traffic.NewMemoryStore(0), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if collector.GetStore() != nil {
+		t.Fatal("collector kept the store with persistence disabled")
+	}
+	srv := NewTrafficServer(collector)
+	ctx := tenantCtx("alice")
+
+	_, err = srv.QueryTrafficHistory(ctx, &pb.QueryTrafficHistoryRequest{ContainerName: "alice-container"})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "persistence is disabled") {
+		t.Errorf("QueryTrafficHistory: got %v, want FailedPrecondition saying persistence is disabled", err)
+	}
+	_, err = srv.GetTrafficAggregates(ctx, &pb.GetTrafficAggregatesRequest{ContainerName: "alice-container"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetTrafficAggregates: got %v, want FailedPrecondition", err)
+	}
+	_, err = srv.GetDailyUsage(ctx, &pb.GetDailyUsageRequest{ContainerName: "alice-container"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetDailyUsage: got %v, want FailedPrecondition", err)
+	}
+}
+
+func TestGetTrafficStatus_WarnsAboutMissingGeoIP(t *testing.T) {
+	collector, err := traffic.NewCollector(traffic.CollectorConfig{
+		PersistenceEnabled: true,
+		GeoIPDatabases:     []string{t.TempDir() + "/GeoLite2-ASN.mmdb"},
+	}, nil, traffic.NewMemoryStore(0), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewTrafficServer(collector)
+
+	if _, err := srv.GetTrafficStatus(tenantCtx("alice"), &pb.GetTrafficStatusRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("tenant: got %v, want PermissionDenied", err)
+	}
+	resp, err := srv.GetTrafficStatus(adminCtx(), &pb.GetTrafficStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.PersistenceEnabled || !resp.Geoip.Enabled || resp.Geoip.Active || len(resp.Geoip.Databases) != 1 {
+		t.Errorf("status = %v", resp)
+	}
+	if !slices.ContainsFunc(resp.Warnings, func(w string) bool { return strings.Contains(w, "GeoLite2-ASN.mmdb is not used") }) {
+		t.Errorf("warnings = %q, want one for the missing database", resp.Warnings)
+	}
+}
+
+// paramsStore records the QueryParams it is asked for.
+type paramsStore struct {
+	*traffic.MemoryStore
+	params []traffic.QueryParams
+}
+
+func (p *paramsStore) QueryConnections(ctx context.Context, params traffic.QueryParams) ([]*pb.HistoricalConnection, int32, error) {
+	p.params = append(p.params, params)
+	return p.MemoryStore.QueryConnections(ctx, params)
+}
+
+func TestStreamTrafficHistory_UnboundedForAdminsOnly(t *testing.T) {
+	store := &paramsStore{MemoryStore: traffic.NewMemoryStore(0)}
+	collector, err := traffic.NewCollector(traffic.CollectorConfig{PersistenceEnabled: true}, nil, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewTrafficServer(collector)
+	req := &pb.StreamTrafficHistoryRequest{ContainerName: "alice-container"}
+
+	if err := srv.StreamTrafficHistory(req, &historyStream{ctx: tenantCtx("alice")}); err != nil {
+		t.Fatalf("tenant stream: %v", err)
+	}
+	if err := srv.StreamTrafficHistory(req, &historyStream{ctx: adminCtx()}); err != nil {
+		t.Fatalf("admin stream: %v", err)
+	}
+	if len(store.params) != 2 || store.params[0].Unbounded || !store.params[1].Unbounded {
+		t.Errorf("Unbounded = %v, want false for the tenant and true for the admin", store.params)
+	}
+}
+
+func TestHistoryQueryError(t *testing.T) {
+	broad := fmt.Errorf("page 2: %w", &traffic.QueryTooBroadError{Reason: "too long", Suggestions: []string{"dest_port"}})
+	if err := historyQueryError(broad); status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "dest_port") {
+		t.Errorf("too broad: got %v, want FailedPrecondition naming the suggestion", err)
+	}
+	if err := historyQueryError(errors.New("connection reset")); status.Code(err) != codes.Internal {
+		t.Errorf("other failure: got %v, want Internal", err)
+	}
+}
+
+func TestQueryTrafficHistory_SearchesEveryContainer(t *testing.T) {
+	ctx := context.Background()
+	store := traffic.NewMemoryStore(0)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, c := range []struct {
+		container, source, dest string
+		violation               bool
+	}{
+		{"alice-container", "10.100.0.5", "203.0.113.7", false},
+		{"bob-container", "10.100.0.6", "203.0.113.7", false},
+		{"bob-container", "10.100.0.6", "198.51.100.20", true},
+	} {
+		if err := store.SaveConnection(ctx, &pb.Connection{
+			Id:            fmt.Sprint(i),
+			ContainerName: c.container,
+			Protocol:      pb.Protocol_PROTOCOL_TCP,
+			SourceIp:      c.source,
+			DestIp:        c.dest,
+			DestPort:      443,
+			FirstSeen:     timestamppb.New(start.Add(time.Duration(i) * time.Minute)),
+			LastSeen:      timestamppb.New(start.Add(time.Hour)),
+
+			PolicyViolation: c.violation,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	collector, err := traffic.NewCollector(traffic.CollectorConfig{PersistenceEnabled: true}, nil, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewTrafficServer(collector)
+	window := func(req *pb.QueryTrafficHistoryRequest) *pb.QueryTrafficHistoryRequest {
+		req.StartTime = timestamppb.New(start.Add(-time.Hour))
+		req.EndTime = timestamppb.New(start.Add(time.Hour))
+		return req
+	}
+
+	resp, err := srv.QueryTrafficHistory(adminCtx(), window(&pb.QueryTrafficHistoryRequest{DestIp: "203.0.113.7"}))
+	if err != nil {
+		t.Fatalf("QueryTrafficHistory by dest_ip: %v", err)
+	}
+	if resp.TotalCount != 2 || resp.Connections[0].ContainerName != "bob-container" || resp.Connections[1].ContainerName != "alice-container" {
+		t.Errorf("by dest_ip = %v, want bob's then alice's connection", resp.Connections)
+	}
+
+	resp, err = srv.QueryTrafficHistory(adminCtx(), window(&pb.QueryTrafficHistoryRequest{SourceIp: "10.100.0.6"}))
+	if err != nil {
+		t.Fatalf("QueryTrafficHistory by source_ip: %v", err)
+	}
+	if resp.TotalCount != 2 || resp.Connections[0].ContainerName != "bob-container" {
+		t.Errorf("by source_ip = %v, want bob's two connections", resp.Connections)
+	}
+
+	resp, err = srv.QueryTrafficHistory(adminCtx(), window(&pb.QueryTrafficHistoryRequest{PolicyViolationsOnly: true}))
+	if err != nil {
+		t.Fatalf("QueryTrafficHistory by policy_violations_only: %v", err)
+	}
+	if resp.TotalCount != 1 || resp.Connections[0].DestIp != "198.51.100.20" || !resp.Connections[0].PolicyViolation {
+		t.Errorf("violations only = %v, want bob's connection to 198.51.100.20", resp.Connections)
+	}
+}
+
+func TestAuthorizeHistoryQuery_ContainerPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		params traffic.QueryParams
+		want   codes.Code
+	}{
+		{"admin prefix", adminCtx(), traffic.QueryParams{ContainerNamePrefix: "acme-"}, codes.OK},
+		{"tenant prefix alone", tenantCtx("alice"), traffic.QueryParams{ContainerNamePrefix: "alice-"}, codes.PermissionDenied},
+		{"tenant prefix with own username", tenantCtx("alice"), traffic.QueryParams{ContainerNamePrefix: "alice-", Username: "alice"}, codes.OK},
+		{"tenant prefix with other username", tenantCtx("alice"), traffic.QueryParams{ContainerNamePrefix: "bob-", Username: "bob"}, codes.PermissionDenied},
+		{"name and prefix", adminCtx(), traffic.QueryParams{ContainerNames: []string{"acme-web"}, ContainerNamePrefix: "acme-"}, codes.InvalidArgument},
+		{"admin violations fleet-wide", adminCtx(), traffic.QueryParams{PolicyViolationsOnly: true}, codes.OK},
+		{"tenant violations fleet-wide", tenantCtx("alice"), traffic.QueryParams{PolicyViolationsOnly: true}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		if _, err := authorizeHistoryQuery(tt.ctx, &tt.params); status.Code(err) != tt.want {
+			t.Errorf("%s: code = %v, want %v", tt.name, status.Code(err), tt.want)
+		}
+	}
+}
+
+func TestQueryTrafficHistory_SeveralContainers(t *testing.T) {
+	ctx := context.Background()
+	store := traffic.NewMemoryStore(0)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, container := range []string{"alice-container", "bob-container", "carol-container"} {
+		if err := store.SaveConnection(ctx, &pb.Connection{
+			Id:            fmt.Sprint(i),
+			ContainerName: container,
+			Protocol:      pb.Protocol_PROTOCOL_TCP,
+			DestIp:        "203.0.113.7",
+			DestPort:      443,
+			FirstSeen:     timestamppb.New(start),
+			LastSeen:      timestamppb.New(start.Add(time.Minute)),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	collector, err := traffic.NewCollector(traffic.CollectorConfig{PersistenceEnabled: true}, nil, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewTrafficServer(collector)
+	req := &pb.QueryTrafficHistoryRequest{
+		ContainerName:  "alice-container",
+		ContainerNames: []string{"bob-container", "alice-container"},
+		StartTime:      timestamppb.New(start.Add(-time.Hour)),
+		EndTime:        timestamppb.New(start.Add(time.Hour)),
+	}
+
+	resp, err := srv.QueryTrafficHistory(adminCtx(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.TotalCount != 2 || len(resp.OmittedContainers) != 0 {
+		t.Errorf("admin: got %d connections, omitted %v; want 2 and none", resp.TotalCount, resp.OmittedContainers)
+	}
+
+	// alice gets her own box; bob's is skipped, not a failure.
+	resp, err = srv.QueryTrafficHistory(tenantCtx("alice"), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.TotalCount != 1 || strings.Join(resp.OmittedContainers, ",") != "bob-container" {
+		t.Errorf("tenant: got %d connections, omitted %v; want 1 and bob-container", resp.TotalCount, resp.OmittedContainers)
+	}
+
+	aggs, err := srv.GetTrafficAggregates(adminCtx(), &pb.GetTrafficAggregatesRequest{
+		ContainerNames: []string{"alice-container", "carol-container"}, StartTime: req.StartTime, EndTime: req.EndTime, Interval: "1h",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, a := range aggs.Aggregates {
+		names = append(names, a.ContainerName)
+	}
+	slices.Sort(names)
+	if strings.Join(names, ",") != "alice-container,carol-container" {
+		t.Errorf("aggregates labelled %v, want one row per container", names)
+	}
+
+	// Nothing readable is still refused.
+	_, err = srv.QueryTrafficHistory(tenantCtx("alice"), &pb.QueryTrafficHistoryRequest{ContainerNames: []string{"bob-container", "carol-container"}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("only others' boxes: got %v, want PermissionDenied", err)
+	}
+}
+
+func TestCacheStatus_ErrorIsAdminOnly(t *testing.T) {
+	retry := time.Date(2026, 5, 1, 10, 0, 30, 0, time.UTC)
+	st := traffic.CacheStats{Containers: 4, Ready: true, Stale: true, BreakerOpen: true, RetryAt: retry, ConsecutiveFailures: 3, LastError: "incus socket refused"}
+
+	got := cacheStatus(adminCtx(), st)
+	if !got.Stale || !got.BreakerOpen || got.ConsecutiveFailures != 3 || got.Containers != 4 || !got.RetryTime.AsTime().Equal(retry) {
+		t.Errorf("cacheStatus = %+v", got)
+	}
+	if got.LastError != "incus socket refused" || got.LastRefreshTime != nil {
+		t.Errorf("admin status: last_error %q, last_refresh_time %v", got.LastError, got.LastRefreshTime)
+	}
+	if got := cacheStatus(tenantCtx("alice"), st); got.LastError != "" || !got.Stale {
+		t.Errorf("tenant status = %+v, want stale without the Incus error", got)
+	}
+}
+
+func TestLabelServices_CopiesBeforeLabelling(t *testing.T) {
+	https := &pb.Connection{Id: "https", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 443}
+	unknown := &pb.Connection{Id: "unknown", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 41234}
+
+	srv := NewTrafficServer(nil)
+	got := srv.labelServices([]*pb.Connection{https, unknown})
+
+	if got[0].ServiceName != "https" {
+		t.Errorf("tcp/443 ServiceName = %q, want https", got[0].ServiceName)
+	}
+	if https.ServiceName != "" {
+		t.Error("labelServices wrote to the collector's connection")
+	}
+	if got[1] != unknown || got[1].ServiceName != "" {
+		t.Errorf("unknown port: got %v, want the original unlabelled", got[1])
+	}
+
+	srv.SetServiceNames(nil)
+	if got := srv.labelServices([]*pb.Connection{https}); got[0].ServiceName != "" {
+		t.Errorf("nil map labelled %q", got[0].ServiceName)
+	}
+}
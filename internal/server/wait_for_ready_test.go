@@ -24,6 +24,10 @@ func (f *fakeRouteLister) Delete(ctx context.Context, fullDomain string) error {
 
 func (f *fakeRouteLister) Save(ctx context.Context, r *app.RouteRecord) error { return nil }
 
+func (f *fakeRouteLister) List(ctx context.Context, activeOnly bool) ([]*app.RouteRecord, error) {
+	return f.routes, f.err
+}
+
 func listenLocal(t *testing.T) (net.Listener, int) {
 	t.Helper()
 	ln, err := net.Listen("tcp", "127.0.0.1:0")
@@ -74,7 +74,22 @@ func (m *memoryRouteStore) ListByContainer(ctx context.Context, name string) ([]
 	return out, nil
 }
 
-func (m *memoryRouteStore) Delete(ctx context.Context, fullDomain string) error { return nil }
+func (m *memoryRouteStore) Delete(ctx context.Context, fullDomain string) error {
+	for i, r := range m.routes {
+		if r.FullDomain == fullDomain {
+			m.routes = append(m.routes[:i], m.routes[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *memoryRouteStore) List(ctx context.Context, activeOnly bool) ([]*app.RouteRecord, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.routes, nil
+}
 func (m *memoryRouteStore) Save(ctx context.Context, route *app.RouteRecord) error {
 	m.routes = append(m.routes, route)
 	return nil
@@ -0,0 +1,127 @@
+package traffic
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net/netip"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// recentViolationsSize bounds the set of connections already reported as
+// allowlist violations, so a connection seen by every snapshot while it
+// stays open is reported once.
+const recentViolationsSize = 8192
+
+// egressAllowlist is the set of destinations containers may reach besides
+// each other (CollectorConfig.EgressAllowlist).
+type egressAllowlist struct {
+	network netip.Prefix // the container network; invalid when unparseable
+	allowed []netip.Prefix
+}
+
+// newEgressAllowlist parses entries, each an address or CIDR. No entries
+// means no allowlist: nil, which flags nothing.
+func newEgressAllowlist(networkCIDR string, entries []string) (*egressAllowlist, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	a := &egressAllowlist{}
+	if p, err := netip.ParsePrefix(networkCIDR); err == nil {
+		a.network = p.Masked()
+	}
+	for _, e := range entries {
+		p, err := ParseDestination(e)
+		if err != nil {
+			return nil, fmt.Errorf("egress allowlist: %w", err)
+		}
+		a.allowed = append(a.allowed, p)
+	}
+	return a, nil
+}
+
+// LoadEgressAllowlist reads allowlist entries from path, one address or
+// CIDR per line; blank lines and "#" comments are skipped. Each entry is
+// checked, so a typo fails at startup rather than flagging everything.
+func LoadEgressAllowlist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open egress allowlist: %w", err)
+	}
+	defer f.Close()
+	var entries []string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		entry, _, _ := strings.Cut(scanner.Text(), "#")
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		if _, err := ParseDestination(entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// violates reports whether conn is an egress connection to somewhere
+// neither the container network nor the allowlist covers. Loopback,
+// link-local and multicast destinations never leave the host's segment
+// and aren't flagged; other private ranges are, unless allowlisted.
+func (a *egressAllowlist) violates(conn *pb.Connection) bool {
+	if a == nil || conn.Direction != pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS {
+		return false
+	}
+	dest, err := netip.ParseAddr(conn.DestIp)
+	if err != nil {
+		return false
+	}
+	dest = dest.Unmap()
+	if !dest.IsGlobalUnicast() || (a.network.IsValid() && a.network.Contains(dest)) {
+		return false
+	}
+	for _, p := range a.allowed {
+		if p.Contains(dest) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetPolicyViolationHook registers fn to be called for each egress
+// connection that violates the allowlist, once per connection. It is how
+// the daemon auto-blocks violators (--traffic-egress-allowlist-block).
+// fn runs on the collector's event path and must not block; call this
+// before Start.
+func (c *Collector) SetPolicyViolationHook(fn func(conn *pb.Connection)) {
+	c.violationHook = fn
+}
+
+// reportViolation alerts on conn if it violates the allowlist and hasn't
+// been reported yet: a warning in the log, a POLICY_VIOLATION traffic
+// event, and the violation hook. Caller must not hold c.mu.
+func (c *Collector) reportViolation(conn *pb.Connection) {
+	if !conn.PolicyViolation || !c.violations.add(connectionKey(conn)) {
+		return
+	}
+	log.Printf("Warning: egress allowlist violation: %s -> %s %s:%d",
+		conn.ContainerName, strings.ToLower(strings.TrimPrefix(conn.Protocol.String(), "PROTOCOL_")), conn.DestIp, conn.DestPort)
+	if c.emitter != nil {
+		c.emitter.EmitTrafficEvent(&pb.TrafficEvent{
+			Type:       pb.TrafficEventType_TRAFFIC_EVENT_TYPE_POLICY_VIOLATION,
+			Connection: conn,
+			Timestamp:  timestamppb.Now(),
+		})
+	}
+	if c.violationHook != nil {
+		c.violationHook(conn)
+	}
+}
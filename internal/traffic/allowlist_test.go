@@ -0,0 +1,144 @@
+package traffic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/events"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestEgressAllowlist_Violates(t *testing.T) {
+	a, err := newEgressAllowlist("10.100.0.0/24", []string{"203.0.113.0/24", "198.51.100.7", "2001:db8::/32"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	egress := func(dest string) *pb.Connection {
+		return &pb.Connection{Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, DestIp: dest}
+	}
+	for _, tc := range []struct {
+		conn *pb.Connection
+		want bool
+	}{
+		{egress("203.0.113.9"), false},  // allowlisted network
+		{egress("198.51.100.7"), false}, // allowlisted address
+		{egress("198.51.100.8"), true},
+		{egress("10.100.0.9"), false},  // another container
+		{egress("192.168.1.10"), true}, // private, but not allowlisted
+		{egress("127.0.0.1"), false},
+		{egress("169.254.169.254"), false},
+		{egress("2001:db8::1"), false},
+		{egress("2606:4700::1111"), true},
+		{egress("not-an-ip"), false},
+		{&pb.Connection{Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS, SourceIp: "8.8.8.8", DestIp: "10.100.0.5"}, false},
+	} {
+		if got := a.violates(tc.conn); got != tc.want {
+			t.Errorf("violates(%s %s) = %v, want %v", tc.conn.Direction, tc.conn.DestIp, got, tc.want)
+		}
+	}
+
+	var none *egressAllowlist
+	if none.violates(egress("8.8.8.8")) {
+		t.Error("without an allowlist nothing is a violation")
+	}
+	if a, err := newEgressAllowlist("10.100.0.0/24", nil); a != nil || err != nil {
+		t.Errorf("no entries = %v, %v; want no allowlist", a, err)
+	}
+	if _, err := newEgressAllowlist("10.100.0.0/24", []string{"203.0.113.0/33"}); err == nil {
+		t.Error("an invalid CIDR should be rejected")
+	}
+}
+
+func TestLoadEgressAllowlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "allowlist")
+	content := `# approved vendors
+203.0.113.0/24   # payments
+198.51.100.7
+
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadEgressAllowlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"203.0.113.0/24", "198.51.100.7"}; !slices.Equal(got, want) {
+		t.Errorf("entries = %v, want %v", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte("203.0.113.0/24\nexample.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadEgressAllowlist(path); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("err = %v, want one naming line 2", err)
+	}
+}
+
+func TestCollector_ReportsPolicyViolationsOnce(t *testing.T) {
+	bus := events.NewBus()
+	sub := bus.Subscribe(&pb.SubscribeEventsRequest{
+		ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_TRAFFIC},
+	})
+	defer bus.Unsubscribe(sub.ID)
+
+	store := NewMemoryStore(16)
+	c := newStoreTestCollector(t, store)
+	c.emitter = events.NewEmitter(bus)
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	c.allowlist, _ = newEgressAllowlist("10.100.0.0/24", []string{"203.0.113.0/24"})
+	c.violations = newRecentKeys(recentViolationsSize)
+	var hooked []string
+	c.SetPolicyViolationHook(func(conn *pb.Connection) { hooked = append(hooked, conn.DestIp) })
+
+	t0 := time.Now()
+	flow := func(typ ConntrackEventType, id, dest string, at time.Time) *ConntrackEvent {
+		return &ConntrackEvent{Type: typ, ID: id, Protocol: "tcp", SrcIP: "10.100.0.5", SrcPort: 40000,
+			DstIP: dest, DstPort: 443, State: "ESTABLISHED", Timestamp: at}
+	}
+	c.processConntrackEvent(flow(ConntrackEventNew, "1", "198.51.100.7", t0))
+	c.processConntrackEvent(flow(ConntrackEventUpdate, "1", "198.51.100.7", t0.Add(time.Second)))
+	c.processConntrackEvent(flow(ConntrackEventNew, "2", "203.0.113.9", t0))
+	c.processConntrackEvent(flow(ConntrackEventDestroy, "1", "198.51.100.7", t0.Add(2*time.Second)))
+	c.processConntrackEvent(flow(ConntrackEventDestroy, "2", "203.0.113.9", t0.Add(2*time.Second)))
+
+	var violations int
+	for len(sub.Events) > 0 {
+		ev := <-sub.Events
+		if ev.GetTrafficEvent().GetType() != pb.TrafficEventType_TRAFFIC_EVENT_TYPE_POLICY_VIOLATION {
+			continue
+		}
+		violations++
+		if conn := ev.GetTrafficEvent().GetConnection(); conn.GetDestIp() != "198.51.100.7" || !conn.GetPolicyViolation() {
+			t.Errorf("violation event for %v", conn)
+		}
+	}
+	if violations != 1 || !slices.Equal(hooked, []string{"198.51.100.7"}) {
+		t.Errorf("violation events = %d, hook calls = %v; want one, for 198.51.100.7", violations, hooked)
+	}
+
+	// The stored row is flagged and can be queried on its own.
+	var rows []*pb.HistoricalConnection
+	for deadline := time.Now().Add(time.Second); len(rows) < 2 && time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		rows, _, _ = store.QueryConnections(context.Background(), QueryParams{
+			ContainerNames: []string{"alice-container"}, StartTime: t0.Add(-time.Minute), EndTime: t0.Add(time.Minute),
+		})
+	}
+	if len(rows) != 2 {
+		t.Fatalf("stored %d connections, want 2", len(rows))
+	}
+	only, _, err := store.QueryConnections(context.Background(), QueryParams{
+		PolicyViolationsOnly: true, StartTime: t0.Add(-time.Minute), EndTime: t0.Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(only) != 1 || only[0].DestIp != "198.51.100.7" || !only[0].PolicyViolation {
+		t.Errorf("violations only = %v, want the 198.51.100.7 connection", only)
+	}
+}
@@ -2,23 +2,111 @@ package traffic
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/footprintai/containarium/pkg/core/incus"
 )
 
+const (
+	// cachePrimeAttempts and cachePrimeBackoff bound the startup retry of
+	// the first refresh. After that the regular refresh interval takes
+	// over, and readiness waits for it.
+	cachePrimeAttempts = 5
+	cachePrimeBackoff  = time.Second
+
+	// cacheRefreshTimeout caps how long a query waits on a refresh of an
+	// empty cache before answering with what it has.
+	cacheRefreshTimeout = 3 * time.Second
+
+	// cacheListTimeout is how long an Incus listing may take before the
+	// refresh waiting on it counts as failed.
+	cacheListTimeout = 10 * time.Second
+
+	// cacheBreakerThreshold consecutive failed refreshes open the circuit
+	// breaker; while it is open, refreshes fail at once for
+	// cacheBreakerCooldown and lookups answer from the last good listing.
+	// The first refresh after the cooldown goes through to Incus and
+	// reopens the breaker if it fails too.
+	cacheBreakerThreshold = 3
+	cacheBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCacheBreakerOpen is returned by Refresh while the circuit breaker is
+// open.
+var ErrCacheBreakerOpen = errors.New("container cache circuit breaker open")
+
+// errListingHung fails a refresh that would queue behind an Incus listing
+// that already ran past cacheListTimeout.
+var errListingHung = errors.New("previous Incus container listing has not returned")
+
+// CacheStats describe the container cache and its circuit breaker.
+type CacheStats struct {
+	Containers int
+	// Ready is false until the first successful refresh.
+	Ready bool
+	// Stale is set while refreshes are failing: lookups answer from the
+	// listing taken at LastRefresh.
+	Stale bool
+	// BreakerOpen is set while refreshes fail fast, until RetryAt.
+	BreakerOpen         bool
+	RetryAt             time.Time
+	ConsecutiveFailures int
+	LastRefresh         time.Time
+	LastError           string
+}
+
+// listCall is one ListContainers round trip, shared by every refresh that
+// arrives while it is in flight.
+type listCall struct {
+	done chan struct{}
+	err  error
+	// timedOut is set (under breakerMu) once a waiter gave up on the call.
+	timedOut bool
+}
+
+// containerLister is the part of the Incus client the cache reads.
+type containerLister interface {
+	ListContainers() ([]incus.ContainerInfo, error)
+}
+
 // ContainerCache maps IP addresses to container names
 type ContainerCache struct {
-	incusClient *incus.Client
+	incusClient containerLister
 	network     *net.IPNet
 
-	mu       sync.RWMutex
-	ipToName map[string]string
-	nameToIP map[string]string
-	nameToID map[string]string // container name -> cloud_container_id label ("" on non-cloud boxes)
+	// primed is set by the first successful Refresh. Until then every
+	// lookup misses and connections go unattributed.
+	primed atomic.Bool
+
+	// breakerMu guards the circuit breaker and the in-flight listing,
+	// which every concurrent refresh shares.
+	breakerMu   sync.Mutex
+	listing     *listCall
+	failures    int // consecutive failed refreshes
+	openUntil   time.Time
+	lastRefresh time.Time
+	lastErr     error
+	listTimeout time.Duration
+	cooldown    time.Duration
+	now         func() time.Time
+
+	mu         sync.RWMutex
+	ipToName   map[string]string
+	nameToIP   map[string]string
+	nameToID   map[string]string // container name -> cloud_container_id label ("" on non-cloud boxes)
+	nameToUser map[string]string // container name -> owning username ("" for system containers)
+	markToName map[uint32]string // conntrack mark -> container name, for boxes with user.containarium.conntrack_mark
+	sharedMark map[uint32]bool   // marks two or more boxes claimed in the last listing
+	listed     map[string]bool   // every container in the last listing, with or without an IP
+	dnsLogged  map[string]bool   // containers opted into DNS query logging (user.containarium.dns_log)
 }
 
 // NewContainerCache creates a new container cache
@@ -35,6 +123,13 @@ func NewContainerCache(incusClient *incus.Client, networkCIDR string) *Container
 		ipToName:    make(map[string]string),
 		nameToIP:    make(map[string]string),
 		nameToID:    make(map[string]string),
+		nameToUser:  make(map[string]string),
+		markToName:  make(map[uint32]string),
+		listed:      make(map[string]bool),
+		dnsLogged:   make(map[string]bool),
+		listTimeout: cacheListTimeout,
+		cooldown:    cacheBreakerCooldown,
+		now:         time.Now,
 	}
 }
 
@@ -45,6 +140,33 @@ func (c *ContainerCache) LookupIP(ip string) string {
 	return c.ipToName[ip]
 }
 
+// LookupMark returns the container whose flows carry conntrack mark, or ""
+// for unmarked flows and marks no container claims.
+func (c *ContainerCache) LookupMark(mark uint32) string {
+	if mark == 0 {
+		return ""
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.markToName[mark]
+}
+
+// Listed reports whether the last listing had a container named name,
+// running or not.
+func (c *ContainerCache) Listed(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.listed[name]
+}
+
+// DNSLogged reports whether the container named name has opted into DNS
+// query logging, as of the last listing.
+func (c *ContainerCache) DNSLogged(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dnsLogged[name]
+}
+
 // LookupName returns the IP for a container name
 func (c *ContainerCache) LookupName(name string) string {
 	c.mu.RLock()
@@ -61,6 +183,50 @@ func (c *ContainerCache) LookupID(name string) string {
 	return c.nameToID[name]
 }
 
+// LookupUsername returns the username owning a container, or "" for system
+// containers and names the cache hasn't seen.
+func (c *ContainerCache) LookupUsername(name string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.nameToUser[name]
+}
+
+// LookupUserContainer returns the container username owns, or "" when the
+// cache knows none. Of several, "<username>-container" is preferred, then
+// the first by name.
+func (c *ContainerCache) LookupUserContainer(username string) string {
+	if username == "" {
+		return ""
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var found string
+	for name, user := range c.nameToUser {
+		if user != username {
+			continue
+		}
+		if name == username+"-container" {
+			return name
+		}
+		if found == "" || name < found {
+			found = name
+		}
+	}
+	return found
+}
+
+// containerUsername is the owning username for a container: the one Incus
+// reports when set, otherwise derived from the "<user>-container" name.
+func containerUsername(info incus.ContainerInfo) string {
+	if info.Username != "" {
+		return info.Username
+	}
+	if info.Role.IsCoreRole() || !strings.HasSuffix(info.Name, "-container") {
+		return ""
+	}
+	return strings.TrimSuffix(info.Name, "-container")
+}
+
 // IsContainerIP checks if an IP belongs to the container network
 func (c *ContainerCache) IsContainerIP(ip string) bool {
 	if c.network == nil {
@@ -73,6 +239,22 @@ func (c *ContainerCache) IsContainerIP(ip string) bool {
 	return c.network.Contains(parsed)
 }
 
+// GatewayIP returns the container network's bridge gateway (its first
+// host address), the source boxes see for connections the host itself
+// makes to them. Empty without an IPv4 network.
+func (c *ContainerCache) GatewayIP() string {
+	if c.network == nil {
+		return ""
+	}
+	ip := c.network.IP.To4()
+	if ip == nil {
+		return ""
+	}
+	gw := slices.Clone(ip)
+	gw[3]++
+	return gw.String()
+}
+
 // GetAllContainers returns a copy of all container name to IP mappings
 func (c *ContainerCache) GetAllContainers() map[string]string {
 	c.mu.RLock()
@@ -85,13 +267,27 @@ func (c *ContainerCache) GetAllContainers() map[string]string {
 	return result
 }
 
-// Refresh updates the cache from Incus
+// Refresh updates the cache from Incus. While the circuit breaker is open
+// it returns ErrCacheBreakerOpen without calling Incus, and the cache
+// keeps its last good listing.
 func (c *ContainerCache) Refresh() error {
-	containers, err := c.incusClient.ListContainers()
-	if err != nil {
-		return err
+	return c.refresh(true, 0)
+}
+
+// refresh is Refresh, optionally ignoring an open breaker, and giving up
+// waiting after timeout when that is shorter than listTimeout. Failures
+// are recorded either way.
+func (c *ContainerCache) refresh(useBreaker bool, timeout time.Duration) error {
+	if useBreaker {
+		if err := c.breakerAllows(); err != nil {
+			return err
+		}
 	}
+	return c.list(timeout)
+}
 
+// apply replaces the cache's maps with a fresh listing.
+func (c *ContainerCache) apply(containers []incus.ContainerInfo) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -99,8 +295,17 @@ func (c *ContainerCache) Refresh() error {
 	c.ipToName = make(map[string]string)
 	c.nameToIP = make(map[string]string)
 	c.nameToID = make(map[string]string)
+	c.nameToUser = make(map[string]string)
+	c.markToName = make(map[uint32]string)
+	c.listed = make(map[string]bool, len(containers))
+	c.dnsLogged = make(map[string]bool)
+	claimants := make(map[uint32][]string)
 
 	for _, container := range containers {
+		c.listed[container.Name] = true
+		if container.DNSLogEnabled {
+			c.dnsLogged[container.Name] = true
+		}
 		if container.IPAddress != "" {
 			c.ipToName[container.IPAddress] = container.Name
 			c.nameToIP[container.Name] = container.IPAddress
@@ -108,32 +313,209 @@ func (c *ContainerCache) Refresh() error {
 		if id := container.Labels["cloud_container_id"]; id != "" {
 			c.nameToID[container.Name] = id
 		}
+		if user := containerUsername(container); user != "" {
+			c.nameToUser[container.Name] = user
+		}
+		if mark := container.ConntrackMark; mark != 0 {
+			claimants[mark] = append(claimants[mark], container.Name)
+		}
 	}
+	// A mark two boxes claim identifies neither; their flows fall back to
+	// address lookup. Refreshes repeat the listing, so the warning is
+	// logged when a mark becomes shared rather than on every refresh.
+	shared := make(map[uint32]bool)
+	for mark, names := range claimants {
+		if len(names) == 1 {
+			c.markToName[mark] = names[0]
+			continue
+		}
+		shared[mark] = true
+		if !c.sharedMark[mark] {
+			log.Printf("Warning: %s share conntrack mark %#x, attributing their flows by address", strings.Join(names, ", "), mark)
+		}
+	}
+	for mark := range c.sharedMark {
+		if !shared[mark] {
+			log.Printf("Conntrack mark %#x is no longer shared", mark)
+		}
+	}
+	c.sharedMark = shared
 
+	c.primed.Store(true)
 	log.Printf("Container cache refreshed: %d containers", len(c.ipToName))
+}
+
+// list lists containers from Incus and applies the result, joining a call
+// already in flight. Incus calls take no context, so a call that runs past
+// listTimeout is abandoned rather than cancelled (its result is still
+// applied if it arrives); until it returns, further refreshes fail at once
+// instead of stacking up behind it. Each failed call counts once towards
+// the breaker. A caller's shorter timeout (0 = none) only stops it
+// waiting: the call runs on for the others.
+func (c *ContainerCache) list(timeout time.Duration) error {
+	c.breakerMu.Lock()
+	call := c.listing
+	if call != nil && call.timedOut {
+		c.recordFailureLocked(errListingHung)
+		c.breakerMu.Unlock()
+		return errListingHung
+	}
+	if call == nil {
+		call = &listCall{done: make(chan struct{})}
+		c.listing = call
+		go c.runList(call)
+	}
+	listTimeout := c.listTimeout
+	c.breakerMu.Unlock()
+
+	hung := timeout <= 0 || timeout >= listTimeout
+	if hung {
+		timeout = listTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-call.done:
+		return call.err
+	case <-timer.C:
+		if !hung {
+			return fmt.Errorf("container cache refresh timed out after %s", timeout)
+		}
+		err := fmt.Errorf("incus did not list containers within %s", timeout)
+		c.breakerMu.Lock()
+		if !call.timedOut {
+			call.timedOut = true
+			c.recordFailureLocked(err)
+		}
+		c.breakerMu.Unlock()
+		return err
+	}
+}
+
+func (c *ContainerCache) runList(call *listCall) {
+	containers, err := c.incusClient.ListContainers()
+	if err == nil {
+		c.apply(containers)
+	}
+
+	c.breakerMu.Lock()
+	call.err = err
+	c.listing = nil
+	switch {
+	case err == nil:
+		c.recordSuccessLocked()
+	case !call.timedOut:
+		// A timed-out call was counted when its waiter gave up.
+		c.recordFailureLocked(err)
+	}
+	c.breakerMu.Unlock()
+	close(call.done)
+}
+
+// breakerAllows returns ErrCacheBreakerOpen, wrapped with the retry time,
+// while the breaker is open.
+func (c *ContainerCache) breakerAllows() error {
+	c.breakerMu.Lock()
+	defer c.breakerMu.Unlock()
+	if c.now().Before(c.openUntil) {
+		return fmt.Errorf("%w after %d failed refreshes (last: %v); retrying at %s",
+			ErrCacheBreakerOpen, c.failures, c.lastErr, c.openUntil.Format(time.RFC3339))
+	}
 	return nil
 }
 
-// StartRefresh begins periodic cache refresh
-func (c *ContainerCache) StartRefresh(ctx context.Context, interval time.Duration) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+func (c *ContainerCache) recordFailureLocked(err error) {
+	c.failures++
+	c.lastErr = err
+	if c.failures < cacheBreakerThreshold {
+		return
+	}
+	if c.failures == cacheBreakerThreshold {
+		log.Printf("Warning: container cache circuit breaker open after %d failed refreshes, serving the last listing: %v", c.failures, err)
+	}
+	c.openUntil = c.now().Add(c.cooldown)
+}
+
+func (c *ContainerCache) recordSuccessLocked() {
+	if c.failures >= cacheBreakerThreshold {
+		log.Printf("Container cache circuit breaker closed after %d failed refreshes", c.failures)
+	}
+	c.failures = 0
+	c.lastErr = nil
+	c.openUntil = time.Time{}
+	c.lastRefresh = c.now()
+}
 
-	// Initial refresh
-	if err := c.Refresh(); err != nil {
-		log.Printf("Warning: initial container cache refresh failed: %v", err)
+// Stats reports the cache size and circuit breaker state.
+func (c *ContainerCache) Stats() CacheStats {
+	c.breakerMu.Lock()
+	s := CacheStats{
+		Stale:               c.failures > 0,
+		ConsecutiveFailures: c.failures,
+		LastRefresh:         c.lastRefresh,
+	}
+	if c.now().Before(c.openUntil) {
+		s.BreakerOpen = true
+		s.RetryAt = c.openUntil
+	}
+	if c.lastErr != nil {
+		s.LastError = c.lastErr.Error()
 	}
+	c.breakerMu.Unlock()
+	s.Containers = c.Size()
+	s.Ready = c.Ready()
+	return s
+}
+
+// Ready reports whether the cache has been filled from Incus at least once.
+func (c *ContainerCache) Ready() bool {
+	return c.primed.Load()
+}
 
-	for {
+// RefreshWithin runs Refresh but gives up waiting after timeout. Incus
+// calls take no context, so a timed-out refresh finishes in the
+// background; callers arriving meanwhile wait on that one instead of
+// starting another.
+func (c *ContainerCache) RefreshWithin(timeout time.Duration) error {
+	return c.refresh(true, timeout)
+}
+
+// prime retries the first refresh up to attempts times, doubling backoff
+// between tries, so a daemon that starts before Incus answers still
+// becomes ready quickly. It goes past an open breaker: its own backoff
+// already spaces the calls out.
+func (c *ContainerCache) prime(ctx context.Context, attempts int, backoff time.Duration) error {
+	var err error
+	for i := range attempts {
+		if err = c.refresh(false, 0); err == nil {
+			return nil
+		}
+		if i == attempts-1 {
+			break
+		}
+		log.Printf("Warning: container cache refresh failed (attempt %d/%d): %v", i+1, attempts, err)
 		select {
 		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			if err := c.Refresh(); err != nil {
-				log.Printf("Warning: container cache refresh failed: %v", err)
-			}
+			return ctx.Err()
+		case <-time.After(backoff):
 		}
+		backoff *= 2
 	}
+	return err
+}
+
+// StartRefresh begins periodic cache refresh, every interval give or take
+// the collector's tick jitter.
+func (c *ContainerCache) StartRefresh(ctx context.Context, interval time.Duration) {
+	if err := c.prime(ctx, cachePrimeAttempts, cachePrimeBackoff); err != nil {
+		log.Printf("Warning: initial container cache refresh failed, retrying every %s: %v", interval, err)
+	}
+
+	tickJittered(ctx, interval, func() {
+		if err := c.Refresh(); err != nil {
+			log.Printf("Warning: container cache refresh failed: %v", err)
+		}
+	})
 }
 
 // Size returns the number of containers in the cache
@@ -0,0 +1,236 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+func TestContainerUsername(t *testing.T) {
+	cases := []struct {
+		info incus.ContainerInfo
+		want string
+	}{
+		{incus.ContainerInfo{Name: "alice-container"}, "alice"},
+		// The username Incus reports wins over the name convention.
+		{incus.ContainerInfo{Name: "alice-container", Username: "u-7f3a"}, "u-7f3a"},
+		{incus.ContainerInfo{Name: "containarium-core-caddy", Role: incus.RoleCaddy}, ""},
+		{incus.ContainerInfo{Name: "scratch"}, ""},
+	}
+	for _, tc := range cases {
+		if got := containerUsername(tc.info); got != tc.want {
+			t.Errorf("containerUsername(%q) = %q, want %q", tc.info.Name, got, tc.want)
+		}
+	}
+}
+
+func TestEBPFConn_LabelsUsername(t *testing.T) {
+	c := newTestCollector()
+	c.cache.nameToUser["alice-container"] = "alice"
+
+	conn := c.ebpfConn(EBPFFlow{ContainerName: "alice-container", Protocol: "tcp", SrcIP: "10.100.0.5", DstIP: "192.0.2.1", DstPort: 443})
+	if conn.Username != "alice" {
+		t.Errorf("Username = %q, want alice", conn.Username)
+	}
+}
+
+// flakyLister fails its first failures calls, then lists containers. A
+// non-nil block holds every call until it is closed.
+type flakyLister struct {
+	failures   int32
+	calls      atomic.Int32
+	block      chan struct{}
+	containers []incus.ContainerInfo
+}
+
+func (f *flakyLister) ListContainers() ([]incus.ContainerInfo, error) {
+	n := f.calls.Add(1)
+	if f.block != nil {
+		<-f.block
+	}
+	if n <= f.failures {
+		return nil, errors.New("incus not answering yet")
+	}
+	return f.containers, nil
+}
+
+func TestContainerCache_PrimeRetriesUntilReady(t *testing.T) {
+	cache := NewContainerCache(nil, "10.100.0.0/24")
+	fake := &flakyLister{
+		failures:   2,
+		containers: []incus.ContainerInfo{{Name: "alice-container", IPAddress: "10.100.0.5"}},
+	}
+	cache.incusClient = fake
+
+	if cache.Ready() {
+		t.Fatal("cache ready before any refresh")
+	}
+	if err := cache.prime(context.Background(), 5, time.Millisecond); err != nil {
+		t.Fatalf("prime: %v", err)
+	}
+	if got := fake.calls.Load(); got != 3 {
+		t.Errorf("ListContainers called %d times, want 3", got)
+	}
+	if !cache.Ready() {
+		t.Error("cache not ready after a successful refresh")
+	}
+	if got := cache.LookupIP("10.100.0.5"); got != "alice-container" {
+		t.Errorf("LookupIP = %q, want alice-container", got)
+	}
+
+	// Retries are bounded: a client that never answers gives up.
+	down := NewContainerCache(nil, "10.100.0.0/24")
+	down.incusClient = &flakyLister{failures: 100}
+	if err := down.prime(context.Background(), 3, time.Millisecond); err == nil {
+		t.Error("prime succeeded against a failing client")
+	}
+	if down.Ready() {
+		t.Error("cache ready after every refresh failed")
+	}
+}
+
+func TestCollector_GetConnectionsRefreshesEmptyCache(t *testing.T) {
+	c := newTestCollector()
+	c.cache = NewContainerCache(nil, "10.100.0.0/24")
+	c.cache.incusClient = &flakyLister{
+		containers: []incus.ContainerInfo{{Name: "alice-container", IPAddress: "10.100.0.5"}},
+	}
+
+	c.GetConnections("alice-container")
+	if c.cache.Size() != 1 || !c.Ready() {
+		t.Errorf("GetConnections did not fill the empty cache: size %d", c.cache.Size())
+	}
+}
+
+func TestContainerCache_RefreshWithinTimesOut(t *testing.T) {
+	cache := NewContainerCache(nil, "10.100.0.0/24")
+	fake := &flakyLister{block: make(chan struct{})}
+	cache.incusClient = fake
+
+	if err := cache.RefreshWithin(10 * time.Millisecond); err == nil {
+		t.Fatal("RefreshWithin returned before the hung refresh finished")
+	}
+	// A second caller joins the refresh still in flight.
+	errc := make(chan error, 1)
+	go func() { errc <- cache.RefreshWithin(time.Second) }()
+	time.Sleep(10 * time.Millisecond)
+	close(fake.block)
+	if err := <-errc; err != nil {
+		t.Fatalf("RefreshWithin: %v", err)
+	}
+	if got := fake.calls.Load(); got != 1 {
+		t.Errorf("ListContainers called %d times, want 1", got)
+	}
+}
+
+// newBreakerCache returns a cache over lister with a controllable clock.
+func newBreakerCache(lister containerLister) (*ContainerCache, *time.Time) {
+	cache := NewContainerCache(nil, "10.100.0.0/24")
+	cache.incusClient = lister
+	now := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestContainerCache_BreakerOpensAndServesLastListing(t *testing.T) {
+	fake := &flakyLister{containers: []incus.ContainerInfo{{Name: "alice-container", IPAddress: "10.100.0.5"}}}
+	cache, now := newBreakerCache(fake)
+	if err := cache.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Incus goes down: the first failures reach Incus, then the breaker
+	// opens and refreshes fail without calling it.
+	fake.failures = 1 << 20
+	for range cacheBreakerThreshold {
+		if err := cache.Refresh(); err == nil || errors.Is(err, ErrCacheBreakerOpen) {
+			t.Fatalf("Refresh = %v, want the Incus error", err)
+		}
+	}
+	calls := fake.calls.Load()
+	if err := cache.Refresh(); !errors.Is(err, ErrCacheBreakerOpen) {
+		t.Fatalf("Refresh = %v, want ErrCacheBreakerOpen", err)
+	}
+	if fake.calls.Load() != calls {
+		t.Error("open breaker still called Incus")
+	}
+	if got := cache.LookupIP("10.100.0.5"); got != "alice-container" {
+		t.Errorf("LookupIP = %q, want the last listing's alice-container", got)
+	}
+	st := cache.Stats()
+	if !st.Stale || !st.BreakerOpen || st.ConsecutiveFailures != cacheBreakerThreshold || st.Containers != 1 || st.LastError == "" {
+		t.Errorf("Stats = %+v, want stale with the breaker open", st)
+	}
+
+	// After the cooldown one probe goes through; a failure reopens it.
+	*now = now.Add(cacheBreakerCooldown)
+	if err := cache.Refresh(); err == nil || errors.Is(err, ErrCacheBreakerOpen) {
+		t.Fatalf("probe Refresh = %v, want the Incus error", err)
+	}
+	if err := cache.Refresh(); !errors.Is(err, ErrCacheBreakerOpen) {
+		t.Fatalf("Refresh after failed probe = %v, want ErrCacheBreakerOpen", err)
+	}
+
+	// Incus recovers: the next probe closes the breaker.
+	*now = now.Add(cacheBreakerCooldown)
+	fake.failures = 0
+	if err := cache.Refresh(); err != nil {
+		t.Fatalf("Refresh after recovery: %v", err)
+	}
+	if st := cache.Stats(); st.Stale || st.BreakerOpen || st.ConsecutiveFailures != 0 || !st.LastRefresh.Equal(*now) {
+		t.Errorf("Stats = %+v, want a current cache", st)
+	}
+}
+
+func TestContainerCache_HungListingTripsBreaker(t *testing.T) {
+	fake := &flakyLister{
+		block:      make(chan struct{}),
+		containers: []incus.ContainerInfo{{Name: "alice-container", IPAddress: "10.100.0.5"}},
+	}
+	cache, _ := newBreakerCache(fake)
+	cache.listTimeout = 10 * time.Millisecond
+
+	for range cacheBreakerThreshold {
+		if err := cache.Refresh(); err == nil {
+			t.Fatal("Refresh returned while Incus hangs")
+		}
+	}
+	if got := fake.calls.Load(); got != 1 {
+		t.Errorf("ListContainers called %d times, want 1: refreshes must not pile up behind a hung call", got)
+	}
+	if err := cache.Refresh(); !errors.Is(err, ErrCacheBreakerOpen) {
+		t.Errorf("Refresh = %v, want ErrCacheBreakerOpen", err)
+	}
+
+	// The hung call finally answering is applied and closes the breaker.
+	close(fake.block)
+	deadline := time.Now().Add(time.Second)
+	for cache.Stats().BreakerOpen && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if st := cache.Stats(); st.BreakerOpen || st.Stale || st.Containers != 1 {
+		t.Errorf("Stats = %+v after Incus answered, want the late listing applied and the breaker closed", st)
+	}
+}
+
+func TestCollector_ListTrackedContainers(t *testing.T) {
+	c := newTestCollector()
+	c.cache.apply([]incus.ContainerInfo{
+		{Name: "bob-container", IPAddress: "10.100.0.6"},
+		{Name: "alice-container", IPAddress: "10.100.0.5"},
+	})
+
+	got := c.ListTrackedContainers()
+	want := []string{"alice-container", "bob-container"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ListTrackedContainers() = %v, want %v", got, want)
+	}
+	if ip := c.TrackedContainerIPs()["bob-container"]; ip != "10.100.0.6" {
+		t.Errorf("TrackedContainerIPs()[bob-container] = %q, want 10.100.0.6", ip)
+	}
+}
@@ -0,0 +1,230 @@
+package traffic
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestLongLivedConnections(t *testing.T) {
+	now := time.Now()
+	conns := map[string]*pb.Connection{
+		"ssh":    {Id: "ssh", ContainerName: "alice-container", BytesSent: 4096, FirstSeen: timestamppb.New(now)},
+		"fresh":  {Id: "fresh", ContainerName: "alice-container", FirstSeen: timestamppb.New(now)},
+		"orphan": {Id: "orphan", ContainerName: "alice-container"}, // no openSince entry
+	}
+	openSince := map[string]openFlow{
+		"ssh":   {since: now.Add(-7 * 24 * time.Hour)},
+		"fresh": {since: now.Add(-30 * time.Second)},
+	}
+
+	got := longLivedConnections(conns, openSince, now, 15*time.Minute)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 long-lived connection, got %d", len(got))
+	}
+	if got[0].Id != "ssh" || got[0].BytesSent != 4096 {
+		t.Errorf("unexpected connection: %+v", got[0])
+	}
+	if !got[0].FirstSeen.AsTime().Equal(openSince["ssh"].since) {
+		t.Errorf("FirstSeen = %v, want first observation %v", got[0].FirstSeen.AsTime(), openSince["ssh"].since)
+	}
+	// The live view must not be mutated by the checkpoint copy.
+	if !conns["ssh"].FirstSeen.AsTime().Equal(now) {
+		t.Errorf("longLivedConnections mutated the live connection's FirstSeen")
+	}
+}
+
+func TestConnectionKey_DistinguishesRecycledIDs(t *testing.T) {
+	a := &pb.Connection{Id: "42", ContainerName: "alice-container", Protocol: pb.Protocol_PROTOCOL_TCP,
+		SourceIp: "10.100.0.5", SourcePort: 51000, DestIp: "1.1.1.1", DestPort: 443}
+	b := &pb.Connection{Id: "42", ContainerName: "alice-container", Protocol: pb.Protocol_PROTOCOL_TCP,
+		SourceIp: "10.100.0.5", SourcePort: 51001, DestIp: "1.1.1.1", DestPort: 443}
+
+	if connectionKey(a) == connectionKey(b) {
+		t.Errorf("recycled conntrack ID with a different tuple produced the same key %q", connectionKey(a))
+	}
+}
+
+// snapshotMonitor serves a fixed conntrack table from Snapshot and
+// SnapshotFunc and counts the dumps.
+type snapshotMonitor struct {
+	events []*ConntrackEvent
+	dumps  int
+	err    error // returned by SnapshotFunc after streaming events
+}
+
+func (m *snapshotMonitor) Events() <-chan *ConntrackEvent { return nil }
+func (m *snapshotMonitor) Close() error                   { return nil }
+
+func (m *snapshotMonitor) Snapshot() ([]*ConntrackEvent, error) {
+	m.dumps++
+	return m.events, nil
+}
+
+func (m *snapshotMonitor) SnapshotFunc(filter SnapshotFilter, fn func(*ConntrackEvent) error) error {
+	m.dumps++
+	for _, e := range m.events {
+		if !filter.Match(e) {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return m.err
+}
+
+func TestTakeSnapshot_KeepsFirstSeen(t *testing.T) {
+	c := newTestCollector()
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	first := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := &ConntrackEvent{ID: "42", Protocol: "tcp", SrcIP: "10.100.0.5", DstIP: "192.0.2.1", DstPort: 443, Timestamp: first}
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{event}}
+
+	c.takeSnapshot()
+	// The next snapshot sees the same flow later; its age must not reset.
+	event.Timestamp = first.Add(10 * time.Minute)
+	c.takeSnapshot()
+
+	got := c.GetConnections("alice-container")
+	if len(got) != 1 {
+		t.Fatalf("got %d connections, want 1", len(got))
+	}
+	if !got[0].FirstSeen.AsTime().Equal(first) {
+		t.Errorf("FirstSeen = %v, want first observation %v", got[0].FirstSeen.AsTime(), first)
+	}
+}
+
+func TestTakeSnapshot_FailedDumpKeepsPreviousView(t *testing.T) {
+	c := newTestCollector()
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	c.cache.ipToName["10.100.0.6"] = "bob-container"
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{
+		{ID: "1", Protocol: "tcp", SrcIP: "10.100.0.5", DstIP: "192.0.2.1", DstPort: 443, Timestamp: now},
+		{ID: "2", Protocol: "tcp", SrcIP: "10.100.0.6", DstIP: "192.0.2.1", DstPort: 443, Timestamp: now},
+	}}
+	c.takeSnapshot()
+
+	// The next dump breaks off after one flow; the half-built table must
+	// not replace the complete one.
+	c.monitor = &snapshotMonitor{
+		events: []*ConntrackEvent{{ID: "1", Protocol: "tcp", SrcIP: "10.100.0.5", DstIP: "192.0.2.1", DstPort: 443, Timestamp: now}},
+		err:    errors.New("recvfrom: no buffer space available"),
+	}
+	c.takeSnapshot()
+
+	if got := c.GetConnections(""); len(got) != 2 {
+		t.Errorf("got %d connections after a failed dump, want the previous 2", len(got))
+	}
+}
+
+func TestTakeSnapshot_RecycledIDStartsFresh(t *testing.T) {
+	c := newTestCollector()
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	first := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := &ConntrackEvent{ID: "42", Protocol: "tcp", SrcIP: "10.100.0.5", SrcPort: 40000, DstIP: "192.0.2.1", DstPort: 443, Timestamp: first}
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{event}}
+	c.takeSnapshot()
+
+	// The flow closed without a DESTROY we saw, and conntrack handed its ID
+	// to a new one.
+	later := first.Add(10 * time.Minute)
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{{
+		ID: "42", Protocol: "tcp", SrcIP: "10.100.0.5", SrcPort: 40001, DstIP: "198.51.100.7", DstPort: 443, Timestamp: later,
+	}}}
+	c.takeSnapshot()
+
+	got := c.GetConnections("alice-container")
+	if len(got) != 1 {
+		t.Fatalf("got %d connections, want 1", len(got))
+	}
+	if !got[0].FirstSeen.AsTime().Equal(later) {
+		t.Errorf("FirstSeen = %v, want the new flow's %v", got[0].FirstSeen.AsTime(), later)
+	}
+}
+
+func TestGetConnections_DebouncesSnapshots(t *testing.T) {
+	c := newTestCollector()
+	c.config.SnapshotDebounce = time.Hour
+	mon := &snapshotMonitor{}
+	c.monitor = mon
+
+	for range 3 {
+		c.GetConnections("")
+	}
+	c.EgressFanout()
+	if mon.dumps != 1 {
+		t.Errorf("%d conntrack dumps within the debounce window, want 1", mon.dumps)
+	}
+
+	c.RefreshNow()
+	if mon.dumps != 2 {
+		t.Errorf("RefreshNow did not dump: %d dumps, want 2", mon.dumps)
+	}
+	c.GetConnections("")
+	if mon.dumps != 2 {
+		t.Errorf("%d dumps after RefreshNow, want its snapshot reused", mon.dumps)
+	}
+	if _, err := c.DescribeConnection("alice-container", "gone"); !errors.Is(err, ErrConnectionNotFound) {
+		t.Fatalf("DescribeConnection: got %v, want ErrConnectionNotFound", err)
+	}
+	if mon.dumps != 3 {
+		t.Errorf("DescribeConnection did not force a dump: %d dumps, want 3", mon.dumps)
+	}
+
+	c.config.SnapshotDebounce = 0
+	c.GetConnections("")
+	if mon.dumps != 4 {
+		t.Errorf("zero debounce: %d dumps, want 4", mon.dumps)
+	}
+}
+
+func TestTakeSnapshot_RatesFromSuccessiveSamples(t *testing.T) {
+	c := newTestCollector()
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	first := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := &ConntrackEvent{ID: "42", Protocol: "tcp", SrcIP: "10.100.0.5", SrcPort: 40000, DstIP: "192.0.2.1", DstPort: 443,
+		BytesOrig: 1000, BytesReply: 5000, Timestamp: first}
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{event}}
+
+	// One sample: no rate, no window.
+	c.takeSnapshot()
+	if got := c.connections[event.Key()]; got.BytesSentPerSec != nil || got.BytesReceivedPerSec != nil || c.SnapshotInterval() != 0 {
+		t.Fatalf("rate after one snapshot: sent %v, received %v, window %s", got.BytesSentPerSec, got.BytesReceivedPerSec, c.SnapshotInterval())
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	event.BytesOrig, event.BytesReply = 3000, 5000
+	c.takeSnapshot()
+	window := c.SnapshotInterval().Seconds()
+	got := c.connections[event.Key()]
+	if window <= 0 || got.BytesSentPerSec == nil || got.BytesReceivedPerSec == nil {
+		t.Fatalf("no rate after two snapshots (window %v)", window)
+	}
+	if sent := *got.BytesSentPerSec * window; sent < 1999 || sent > 2001 {
+		t.Errorf("sent rate × window = %v, want the 2000 bytes sent between snapshots", sent)
+	}
+	if *got.BytesReceivedPerSec != 0 {
+		t.Errorf("received rate = %v, want 0 for an idle direction", *got.BytesReceivedPerSec)
+	}
+
+	// An update event between snapshots keeps the measured rate.
+	c.processConntrackEvent(&ConntrackEvent{Type: ConntrackEventUpdate, ID: "42", Protocol: "tcp", SrcIP: "10.100.0.5", SrcPort: 40000,
+		DstIP: "192.0.2.1", DstPort: 443, BytesOrig: 3500, Timestamp: first.Add(time.Minute)})
+	if got := c.connections[event.Key()]; got.BytesSentPerSec == nil {
+		t.Error("update event dropped the rate")
+	}
+
+	// A recycled ID is a new flow and starts without a rate.
+	time.Sleep(5 * time.Millisecond)
+	event.SrcPort = 40001
+	c.takeSnapshot()
+	if got := c.connections[event.Key()]; got.BytesSentPerSec != nil {
+		t.Errorf("new flow on a recycled ID has rate %v", *got.BytesSentPerSec)
+	}
+}
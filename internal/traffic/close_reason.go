@@ -0,0 +1,81 @@
+package traffic
+
+import (
+	"strings"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Close reasons recorded alongside final_state in traffic_connections.
+// "peer" is the far end of the connection and "container" the box the row
+// belongs to, so the reason reads the same whichever side initiated.
+const (
+	CloseReasonCompleted            = "completed"
+	CloseReasonIdleTimeout          = "idle_timeout"
+	CloseReasonRefusedByPeer        = "refused_by_peer"
+	CloseReasonRefusedByContainer   = "refused_by_container"
+	CloseReasonNoReplyFromPeer      = "no_reply_from_peer"
+	CloseReasonNoReplyFromContainer = "no_reply_from_container"
+	CloseReasonHandshakeIncomplete  = "handshake_incomplete"
+	CloseReasonBlockedByPolicy      = "blocked_by_policy"
+)
+
+// closeReason infers why a connection ended from the state conntrack
+// reported on destroy and the reply-direction counters. Conntrack doesn't
+// record a cause, so this is a heuristic: a SYN_SENT flow never saw a
+// reply, a flow that went straight to CLOSE after a packet or two in each
+// direction was reset during the handshake (refused), and one destroyed
+// while ESTABLISHED aged out of the table. Returns "" when there's nothing
+// to go on — eBPF-sourced flows carry no state, and their reply counters
+// are zero on loaders that predate reply accounting — unless the flow was
+// dropped by the network policy, which the enforcer knows for certain.
+func closeReason(conn *pb.Connection) string {
+	if conn.Blocked {
+		return CloseReasonBlockedByPolicy
+	}
+	if strings.HasPrefix(conn.Id, "ebpf-") {
+		return ""
+	}
+
+	// Counters are container-relative; the initiator's view is what the
+	// handshake heuristics need. Egress: the container initiated.
+	egress := conn.Direction != pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS
+	origPackets, replyPackets := conn.PacketsSent, conn.PacketsReceived
+	noReply, refused := CloseReasonNoReplyFromPeer, CloseReasonRefusedByPeer
+	if !egress {
+		origPackets, replyPackets = conn.PacketsReceived, conn.PacketsSent
+		noReply, refused = CloseReasonNoReplyFromContainer, CloseReasonRefusedByContainer
+	}
+
+	switch conn.Protocol {
+	case pb.Protocol_PROTOCOL_TCP, pb.Protocol_PROTOCOL_SCTP, pb.Protocol_PROTOCOL_DCCP:
+		// SCTP and DCCP states are mapped onto the TCP ones.
+		switch conn.State {
+		case pb.ConnectionState_CONNECTION_STATE_SYN_SENT:
+			return noReply
+		case pb.ConnectionState_CONNECTION_STATE_SYN_RECV:
+			return CloseReasonHandshakeIncomplete
+		case pb.ConnectionState_CONNECTION_STATE_ESTABLISHED:
+			return CloseReasonIdleTimeout
+		case pb.ConnectionState_CONNECTION_STATE_CLOSED:
+			// SYN (maybe retransmitted) answered by a lone RST.
+			if replyPackets <= 1 && origPackets <= 2 {
+				return refused
+			}
+			return CloseReasonCompleted
+		case pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED:
+			return ""
+		default:
+			return CloseReasonCompleted
+		}
+	case pb.Protocol_PROTOCOL_UDP, pb.Protocol_PROTOCOL_ICMP, pb.Protocol_PROTOCOL_ICMPV6,
+		pb.Protocol_PROTOCOL_UDPLITE, pb.Protocol_PROTOCOL_GRE:
+		// Connectionless: every flow ends by timing out. The useful
+		// distinction is whether anything ever came back.
+		if origPackets > 0 && replyPackets == 0 {
+			return noReply
+		}
+		return CloseReasonIdleTimeout
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package traffic
+
+import (
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestCloseReason(t *testing.T) {
+	tcp := pb.Protocol_PROTOCOL_TCP
+	udp := pb.Protocol_PROTOCOL_UDP
+	sctp := pb.Protocol_PROTOCOL_SCTP
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+	ingress := pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS
+
+	tests := []struct {
+		name string
+		conn *pb.Connection
+		want string
+	}{
+		{"egress SYN never answered", &pb.Connection{Protocol: tcp, Direction: egress, State: pb.ConnectionState_CONNECTION_STATE_SYN_SENT, PacketsSent: 3}, CloseReasonNoReplyFromPeer},
+		{"ingress SYN never answered", &pb.Connection{Protocol: tcp, Direction: ingress, State: pb.ConnectionState_CONNECTION_STATE_SYN_SENT, PacketsReceived: 3}, CloseReasonNoReplyFromContainer},
+		{"egress refused with RST", &pb.Connection{Protocol: tcp, Direction: egress, State: pb.ConnectionState_CONNECTION_STATE_CLOSED, PacketsSent: 1, PacketsReceived: 1}, CloseReasonRefusedByPeer},
+		{"ingress refused with RST", &pb.Connection{Protocol: tcp, Direction: ingress, State: pb.ConnectionState_CONNECTION_STATE_CLOSED, PacketsReceived: 1, PacketsSent: 1}, CloseReasonRefusedByContainer},
+		{"normal close", &pb.Connection{Protocol: tcp, Direction: egress, State: pb.ConnectionState_CONNECTION_STATE_TIME_WAIT, PacketsSent: 20, PacketsReceived: 18}, CloseReasonCompleted},
+		{"reset after transfer", &pb.Connection{Protocol: tcp, Direction: egress, State: pb.ConnectionState_CONNECTION_STATE_CLOSED, PacketsSent: 20, PacketsReceived: 18}, CloseReasonCompleted},
+		{"aged out while established", &pb.Connection{Protocol: tcp, Direction: egress, State: pb.ConnectionState_CONNECTION_STATE_ESTABLISHED}, CloseReasonIdleTimeout},
+		{"udp unanswered", &pb.Connection{Protocol: udp, Direction: egress, PacketsSent: 2}, CloseReasonNoReplyFromPeer},
+		{"udp answered", &pb.Connection{Protocol: udp, Direction: egress, PacketsSent: 2, PacketsReceived: 2}, CloseReasonIdleTimeout},
+		{"sctp INIT never answered", &pb.Connection{Protocol: sctp, Direction: egress, State: stateStringToEnum("SCTP_COOKIE_WAIT"), PacketsSent: 3}, CloseReasonNoReplyFromPeer},
+		{"sctp shut down", &pb.Connection{Protocol: sctp, Direction: ingress, State: stateStringToEnum("SCTP_SHUTDOWN_ACK_SENT"), PacketsSent: 12, PacketsReceived: 14}, CloseReasonCompleted},
+		{"icmpv6 unanswered", &pb.Connection{Protocol: pb.Protocol_PROTOCOL_ICMPV6, Direction: egress, PacketsSent: 4}, CloseReasonNoReplyFromPeer},
+		{"ebpf flow", &pb.Connection{Id: "ebpf-x", Protocol: udp, Direction: egress, PacketsSent: 2}, ""},
+		{"ebpf flow dropped by policy", &pb.Connection{Id: "ebpf-y", Protocol: tcp, Direction: egress, PacketsSent: 3, Blocked: true}, CloseReasonBlockedByPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := closeReason(tt.conn); got != tt.want {
+				t.Errorf("closeReason = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,19 @@
 package traffic
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/footprintai/containarium/internal/events"
@@ -21,26 +28,104 @@ type CollectorConfig struct {
 	// NetworkCIDR is the container network CIDR (e.g., "10.100.0.0/24")
 	NetworkCIDR string
 
-	// SnapshotInterval is how often to take a full conntrack snapshot
-	SnapshotInterval time.Duration
+	// PersistenceEnabled keeps closed connections in the store passed to
+	// NewCollector. When false the store is ignored: live connections,
+	// summaries and the event stream still work, nothing is written, and
+	// history queries fail with ErrPersistenceDisabled.
+	PersistenceEnabled bool
 
-	// CleanupInterval is how often to run database cleanup
-	CleanupInterval time.Duration
+	// SnapshotDebounce is how recent a snapshot GetConnections and
+	// EgressFanout reuse instead of dumping conntrack again, so a dashboard
+	// polling every second doesn't force a dump per request. Zero dumps on
+	// every call; RefreshNow always does.
+	SnapshotDebounce time.Duration
 
 	// RetentionDays is how many days to keep traffic data
 	RetentionDays int
 
+	// CompactAfterDays is the age at which Compact folds closed
+	// connections into hourly traffic_aggregates and deletes the raw rows;
+	// it should be below RetentionDays, or Cleanup deletes them first.
+	// Zero disables compaction.
+	CompactAfterDays int
+
+	// ConntrackNetNS is the network namespace whose conntrack table is
+	// watched (e.g. /run/netns/containers or /proc/<pid>/ns/net), for
+	// topologies where container flows are tracked in their own netns
+	// rather than on the host. Empty watches the host's.
+	ConntrackNetNS string
+
 	// PostgresConnString is the database connection string
 	PostgresConnString string
+
+	// CheckpointAge is how long a connection must have been open before
+	// Snapshot starts checkpointing it to the store (ended_at NULL,
+	// counters updated in place). Zero disables checkpointing, so connections
+	// are only persisted when they close.
+	CheckpointAge time.Duration
+
+	// Replay, when set, re-emits stored history through the events bus
+	// once the collector starts (see ReplayConfig). nil in production.
+	Replay *ReplayConfig
+
+	// Sampling thins out persisted short flows on very busy hosts (see
+	// SamplingConfig). The zero value persists every closed flow.
+	Sampling SamplingConfig
+
+	// RecordStateChanges stores every TCP state a connection passes
+	// through, not just the final one, for GetConnectionTimeline. States
+	// between the NEW and DESTROY events are taken from each snapshot, so
+	// one that lasts less than a snapshot interval can be missed. Off by
+	// default: it writes a row per state change. Ignored by stores that
+	// don't implement StateChangeRecorder.
+	RecordStateChanges bool
+
+	// DNSLogPath is the dnsmasq query log ("log-queries=extra") to follow
+	// for per-container DNS queries, which also name connections'
+	// destinations (dest_hostname). Empty disables DNS logging.
+	DNSLogPath string
+
+	// GeoIPDatabases are MaxMind DB (.mmdb) files, typically a Country
+	// and an ASN database, used to annotate each closed connection with
+	// the remote end's country and autonomous system. They are re-read
+	// when replaced. A missing, unreadable or stale file is skipped with
+	// a warning in GeoIPStatus; connections are stored regardless. Empty
+	// disables enrichment.
+	GeoIPDatabases []string
+
+	// EgressAllowlist lists the addresses and CIDRs containers may reach
+	// outside the container network. An egress connection anywhere else
+	// is flagged (Connection.policy_violation, stored with it) and
+	// reported once as a POLICY_VIOLATION traffic event. Empty disables
+	// the check.
+	EgressAllowlist []string
+
+	// SSHLogPath is the sshd auth log to follow for SSH sessions, which
+	// also annotate port-22 connections in history. Empty disables SSH
+	// session logging.
+	SSHLogPath string
+
+	// ListenerScanInterval is how often each running container's listening
+	// sockets are listed (see GetListeningPorts). Zero disables scanning.
+	ListenerScanInterval time.Duration
+
+	// UDPIdleTimeout closes a UDP (or other connectionless) flow once a
+	// snapshot finds no packet has passed for this long, or conntrack has
+	// already expired it, instead of waiting for its DESTROY: conntrack
+	// only sends that when the entry times out, minutes after a short
+	// DNS or QUIC exchange ended. Zero waits for DESTROY.
+	UDPIdleTimeout time.Duration
 }
 
 // DefaultCollectorConfig returns a default configuration
 func DefaultCollectorConfig() CollectorConfig {
 	return CollectorConfig{
-		NetworkCIDR:      "10.100.0.0/24",
-		SnapshotInterval: 5 * time.Minute,
-		CleanupInterval:  24 * time.Hour,
-		RetentionDays:    7,
+		NetworkCIDR:        "10.100.0.0/24",
+		PersistenceEnabled: true,
+		SnapshotDebounce:   time.Second,
+		RetentionDays:      7,
+		CheckpointAge:      15 * time.Minute,
+		UDPIdleTimeout:     30 * time.Second,
 	}
 }
 
@@ -48,13 +133,13 @@ func DefaultCollectorConfig() CollectorConfig {
 type Collector struct {
 	config      CollectorConfig
 	incusClient *incus.Client
-	store       *Store
+	store       ConnectionStore
 	cache       *ContainerCache
 	monitor     ConntrackMonitor
 	emitter     *events.Emitter
 
 	mu          sync.RWMutex
-	connections map[string]*pb.Connection // conntrack ID -> connection
+	connections map[string]*pb.Connection // ConntrackEvent.Key() -> connection
 	ebpfFlows   map[string]*pb.Connection // eBPF flow ID -> connection (#627)
 	// conntrackSeen is the set of container names the conntrack collector has
 	// successfully attributed at least one flow for. It's the source-arbitration
@@ -66,20 +151,74 @@ type Collector struct {
 	// race. Sticky: once true it stays true (attribution is by container IP,
 	// all-or-nothing per container).
 	conntrackSeen map[string]bool
+	// openSince records when the collector first observed each open conntrack
+	// connection. takeSnapshot rebuilds c.connections from scratch, so this is
+	// what keeps FirstSeen (and so durations, age filters and checkpoints)
+	// from restarting every snapshot. Entries are dropped on DESTROY and when
+	// a connection is missing from a snapshot.
+	openSince map[string]openFlow
+	// idleSplits holds the connectionless flows closed for idleness whose
+	// conntrack entries are still around (see splitIdle).
+	idleSplits map[string]idleSplit
+	// dnsNames maps addresses containers resolved to the name they looked
+	// up, for dest_hostname. Only filled when DNSLogPath is set.
+	dnsNames map[dnsNameKey]dnsName
+	// listeners is each running container's latest listener scan, and
+	// listenersOpened how many listeners have appeared in it since the
+	// daemon started. Only filled when ListenerScanInterval is set.
+	listeners       map[string]*containerListeners
+	listenersOpened map[string]int64
+	// listenerInterval is the current scan period: ListenerScanInterval
+	// until Reconfigure changes it.
+	listenerInterval time.Duration
+	// lastSnapshot is when takeSnapshot last rebuilt connections, and
+	// snapshotInterval how long after the snapshot before it: the window
+	// connection rates cover.
+	lastSnapshot     time.Time
+	snapshotInterval time.Duration
+	// snapshotMu serializes debounced snapshots, so concurrent pollers
+	// wait for one dump and then share it.
+	snapshotMu sync.Mutex
+	// persisted holds the keys of recently saved conntrack connections, so
+	// a repeated DESTROY skips the store (see recentKeys).
+	persisted *recentKeys
+	// counters accumulates ContainerTotals from every flow observation.
+	counters flowCounters
+	// geoip annotates connections with the remote end's country and AS as
+	// they're persisted; nil unless GeoIPDatabases is set.
+	geoip *geoIPEnricher
+
+	// allowlist flags egress outside EgressAllowlist; nil when unset.
+	// violations holds the keys of connections already reported, and
+	// violationHook is called for each new one (SetPolicyViolationHook).
+	allowlist     *egressAllowlist
+	violations    *recentKeys
+	violationHook func(conn *pb.Connection)
+
+	// stateChanges queues connection states for writeStateChanges when
+	// RecordStateChanges is set, nil otherwise; stateChangeDrops counts
+	// those dropped because the queue was full.
+	stateChanges     chan stateChange
+	stateChangeDrops atomic.Int64
 
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
-// NewCollector creates a new traffic collector
-func NewCollector(config CollectorConfig, incusClient *incus.Client, store *Store, emitter *events.Emitter) (*Collector, error) {
+// NewCollector creates a new traffic collector. store may be nil, which
+// disables history persistence.
+func NewCollector(config CollectorConfig, incusClient *incus.Client, store ConnectionStore, emitter *events.Emitter) (*Collector, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Initialize cache
 	cache := NewContainerCache(incusClient, config.NetworkCIDR)
 
 	// Initialize conntrack monitor
-	monitor, err := NewConntrackMonitor()
+	var conntrackOpts []ConntrackOption
+	if config.ConntrackNetNS != "" {
+		conntrackOpts = append(conntrackOpts, WithNetNS(config.ConntrackNetNS))
+	}
+	monitor, err := NewConntrackMonitor(conntrackOpts...)
 	if err != nil {
 		cancel()
 		// Don't fail if conntrack is not available (e.g., on macOS)
@@ -87,18 +226,47 @@ func NewCollector(config CollectorConfig, incusClient *incus.Client, store *Stor
 		monitor = nil
 	}
 
+	if !config.PersistenceEnabled && store != nil {
+		log.Printf("Warning: traffic persistence is disabled; ignoring the traffic store")
+		store = nil
+	}
+
+	allowlist, err := newEgressAllowlist(config.NetworkCIDR, config.EgressAllowlist)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	var geo *geoIPEnricher
+	if len(config.GeoIPDatabases) > 0 {
+		geo = newGeoIPEnricher(config.GeoIPDatabases)
+		geo.reload()
+	}
+
 	return &Collector{
-		config:        config,
-		incusClient:   incusClient,
-		store:         store,
-		cache:         cache,
-		monitor:       monitor,
-		emitter:       emitter,
-		connections:   make(map[string]*pb.Connection),
-		ebpfFlows:     make(map[string]*pb.Connection),
-		conntrackSeen: make(map[string]bool),
-		ctx:           ctx,
-		cancel:        cancel,
+		config:          config,
+		incusClient:     incusClient,
+		store:           store,
+		cache:           cache,
+		monitor:         monitor,
+		emitter:         emitter,
+		connections:     make(map[string]*pb.Connection),
+		ebpfFlows:       make(map[string]*pb.Connection),
+		conntrackSeen:   make(map[string]bool),
+		openSince:       make(map[string]openFlow),
+		idleSplits:      make(map[string]idleSplit),
+		dnsNames:        make(map[dnsNameKey]dnsName),
+		listeners:       make(map[string]*containerListeners),
+		listenersOpened: make(map[string]int64),
+		persisted:       newRecentKeys(recentlyPersistedSize),
+		geoip:           geo,
+		allowlist:       allowlist,
+		violations:      newRecentKeys(recentViolationsSize),
+		ctx:             ctx,
+		cancel:          cancel,
+
+		listenerInterval: config.ListenerScanInterval,
+		stateChanges:     newStateChangeQueue(config, store),
 	}, nil
 }
 
@@ -108,7 +276,11 @@ func (c *Collector) Start() error {
 
 	// Enable conntrack accounting for byte counters (Linux only)
 	if c.monitor != nil {
-		if err := network.EnableConntrackAccounting(); err != nil {
+		enable := network.EnableConntrackAccounting
+		if ns := c.config.ConntrackNetNS; ns != "" {
+			enable = func() error { return EnableConntrackAccountingInNetNS(ns) }
+		}
+		if err := enable(); err != nil {
 			log.Printf("Warning: failed to enable conntrack accounting: %v", err)
 		}
 	}
@@ -121,12 +293,36 @@ func (c *Collector) Start() error {
 		go c.handleConntrackEvents()
 	}
 
-	// Start periodic snapshot
-	go c.periodicSnapshot()
+	// The periodic snapshot, cleanup and usage rollup are the daemon's
+	// scheduled jobs (Snapshot, Cleanup and RollupUsage).
+	if c.store == nil && !c.config.PersistenceEnabled {
+		log.Printf("Traffic persistence disabled: live monitoring only")
+	}
+
+	if c.config.Replay != nil {
+		go c.runReplay()
+	}
+
+	if c.config.DNSLogPath != "" {
+		log.Printf("Following DNS query log %s", c.config.DNSLogPath)
+		go c.followDNSLog()
+	}
+
+	if c.geoip != nil {
+		go tickJittered(c.ctx, geoIPReloadInterval, c.geoip.reload)
+	}
+
+	if c.config.SSHLogPath != "" {
+		log.Printf("Following SSH auth log %s", c.config.SSHLogPath)
+		go c.followSSHLog()
+	}
+
+	if c.config.ListenerScanInterval > 0 {
+		go c.periodicListenerScan()
+	}
 
-	// Start periodic cleanup
-	if c.store != nil {
-		go c.periodicCleanup()
+	if c.stateChanges != nil {
+		go c.writeStateChanges()
 	}
 
 	return nil
@@ -152,21 +348,7 @@ func (c *Collector) handleConntrackEvents() {
 // processConntrackEvent handles a single conntrack event
 func (c *Collector) processConntrackEvent(event *ConntrackEvent) {
 	// Determine which container this connection belongs to
-	containerName := ""
-	containerIP := ""
-	direction := pb.TrafficDirection_TRAFFIC_DIRECTION_UNSPECIFIED
-
-	// Check source IP first (egress from container)
-	if name := c.cache.LookupIP(event.SrcIP); name != "" {
-		containerName = name
-		containerIP = event.SrcIP
-		direction = pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
-	} else if name := c.cache.LookupIP(event.DstIP); name != "" {
-		// Check destination IP (ingress to container)
-		containerName = name
-		containerIP = event.DstIP
-		direction = pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS
-	}
+	containerName, containerIP := c.attributeEvent(event)
 
 	// Skip if not a container connection
 	if containerName == "" {
@@ -174,50 +356,177 @@ func (c *Collector) processConntrackEvent(event *ConntrackEvent) {
 	}
 
 	// Convert to proto connection
-	conn := c.convertToProto(event, containerName, containerIP, direction)
+	conn := c.convertToProto(event, containerName, containerIP)
 
 	// Update local cache
 	c.mu.Lock()
 	c.conntrackSeen[containerName] = true // conntrack owns this container's history (#643)
+	key := event.Key()
+	c.counters.add(key, event.tuple(), conn)
 	if event.Type == ConntrackEventDestroy {
-		delete(c.connections, event.ID)
+		c.counters.forget(key)
+	}
+	if c.splitIdle(key, event, conn) {
+		// Closed for idleness and quiet since: already recorded.
+		if event.Type == ConntrackEventDestroy {
+			delete(c.idleSplits, key)
+		}
+		c.mu.Unlock()
+		return
+	}
+	if conn.Direction == pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS {
+		conn.DestHostname = c.destHostname(containerName, conn.DestIp, event.Timestamp)
+	}
+	prevState := pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED
+	if prev, ok := c.connections[key]; ok {
+		prevState = prev.State
+	}
+	if event.Type == ConntrackEventDestroy {
+		// Carry the first observation into the final row so a connection
+		// that was checkpointed while open keeps its real start time, and
+		// end it at its last packet rather than when conntrack let go.
+		if open, ok := c.openSince[key]; ok && open.tuple == event.tuple() {
+			conn.FirstSeen = timestamppb.New(open.since)
+			conn.LastSeen = timestamppb.New(open.lastPacketAt(event))
+		}
+		delete(c.connections, key)
+		delete(c.openSince, key)
+		delete(c.idleSplits, key)
 	} else {
-		c.connections[event.ID] = conn
+		c.connections[key] = conn
+		c.markOpen(key, event, conn)
 	}
 	c.mu.Unlock()
 
+	c.reportViolation(conn)
 	// Emit traffic event
 	c.emitTrafficEvent(event.Type, conn)
+	c.recordStateChange(conn, prevState, event.Timestamp)
 
 	// Persist to database on connection close
 	if event.Type == ConntrackEventDestroy && c.store != nil {
-		go func() {
-			if err := c.store.SaveConnection(c.ctx, conn); err != nil {
-				log.Printf("Warning: failed to persist connection: %v", err)
-			}
-		}()
+		conn, keep := c.sample(conn)
+		if !keep {
+			return
+		}
+		c.persistConnection(conn)
+	}
+}
+
+// persistConnection saves a closed conntrack connection off the hot path,
+// unless the same flow was saved recently: a repeated DESTROY would only
+// cost a round trip for ON CONFLICT to discard.
+func (c *Collector) persistConnection(conn *pb.Connection) {
+	key := connectionKey(conn)
+	if !c.persisted.add(key) {
+		return
+	}
+	go func() {
+		if err := c.store.SaveConnection(c.ctx, c.enrich(conn)); err != nil {
+			c.persisted.remove(key)
+			log.Printf("Warning: failed to persist connection: %v", err)
+		}
+	}()
+}
+
+// enrich adds the remote end's country and AS to a closed connection
+// about to be persisted, when GeoIP databases are configured.
+func (c *Collector) enrich(conn *pb.Connection) *pb.Connection {
+	if c.geoip == nil {
+		return conn
 	}
+	return c.geoip.annotate(conn)
 }
 
-// convertToProto converts a ConntrackEvent to a pb.Connection
-func (c *Collector) convertToProto(event *ConntrackEvent, containerName, containerIP string, direction pb.TrafficDirection) *pb.Connection {
+// GeoIPStatus describes the configured GeoIP databases, with a warning
+// for each one connections are currently stored without.
+func (c *Collector) GeoIPStatus() (*pb.GeoIPStatus, []string) {
+	if c.geoip == nil {
+		return &pb.GeoIPStatus{}, nil
+	}
+	return c.geoip.status()
+}
+
+// attributeEvent returns the container a conntrack flow belongs to and the
+// address it has in the flow, or empty strings when neither end is a
+// container. A marked flow belongs to the container that owns its mark,
+// whatever its addresses say; the rest are looked up by address, and a
+// flow between two containers is attributed to the one that opened it.
+//
+// A client reaching a container through a passthrough route connects to
+// the host, whose DNAT rewrites the destination; the original tuple then
+// names only the client and the host, and the container shows up as the
+// source of the reply tuple instead.
+func (c *Collector) attributeEvent(event *ConntrackEvent) (containerName, containerIP string) {
+	if name := c.cache.LookupMark(event.Mark); name != "" {
+		return name, markedEnd(event, c.cache.LookupName(name))
+	}
+	if name := c.cache.LookupIP(event.SrcIP); name != "" {
+		return name, event.SrcIP
+	}
+	if name := c.cache.LookupIP(event.DstIP); name != "" {
+		return name, event.DstIP
+	}
+	if dnatted(event) {
+		if name := c.cache.LookupIP(event.ReplySrcIP); name != "" {
+			return name, event.ReplySrcIP
+		}
+	}
+	return "", ""
+}
+
+// dnatted reports whether the flow's destination was rewritten, so the
+// reply comes from somewhere other than the address the client dialled.
+func dnatted(event *ConntrackEvent) bool {
+	return event.ReplySrcIP != "" && event.ReplySrcIP != event.DstIP
+}
+
+// markedEnd is the container's address in a flow attributed by mark. The
+// container is the destination only when that end (or, after a DNAT, the
+// reply's source) carries its address and the source doesn't; otherwise
+// the addresses were rewritten or are shared, and it is the source, since
+// the marking rules match the packets a container sends.
+func markedEnd(event *ConntrackEvent, containerIP string) string {
+	if containerIP != "" && event.SrcIP != containerIP &&
+		(event.DstIP == containerIP || event.ReplySrcIP == containerIP) {
+		return containerIP
+	}
+	return event.SrcIP
+}
+
+// convertToProto converts a ConntrackEvent to a pb.Connection seen from
+// containerIP's side. Conntrack's "original" direction runs from whoever
+// opened the connection, so the container's position in that tuple decides
+// both the direction and which counters it sent: as the source it initiated
+// (egress) and sent the original bytes; as the destination, or the reply
+// source of a DNATed flow, it is serving an external client (ingress) and
+// sent the reply bytes.
+func (c *Collector) convertToProto(event *ConntrackEvent, containerName, containerIP string) *pb.Connection {
+	direction := pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS
+	if containerIP == event.SrcIP {
+		direction = pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+	}
 	conn := &pb.Connection{
 		Id:             event.ID,
 		ContainerName:  containerName,
+		Username:       c.cache.LookupUsername(containerName),
 		ContainerIp:    containerIP,
 		Protocol:       protoStringToEnum(event.Protocol),
 		SourceIp:       event.SrcIP,
 		SourcePort:     uint32(event.SrcPort),
 		DestIp:         event.DstIP,
 		DestPort:       uint32(event.DstPort),
+		IcmpType:       uint32(event.ICMPType),
+		IcmpCode:       uint32(event.ICMPCode),
+		IcmpId:         uint32(event.ICMPID),
 		State:          stateStringToEnum(event.State),
 		Direction:      direction,
 		FirstSeen:      timestamppb.New(event.Timestamp),
 		LastSeen:       timestamppb.New(event.Timestamp),
 		TimeoutSeconds: event.Timeout,
+		Zone:           uint32(event.Zone),
 	}
 
-	// Set bytes based on direction
 	if direction == pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS {
 		conn.BytesSent = event.BytesOrig
 		conn.BytesReceived = event.BytesReply
@@ -229,6 +538,7 @@ func (c *Collector) convertToProto(event *ConntrackEvent, containerName, contain
 		conn.PacketsSent = event.PacketsReply
 		conn.PacketsReceived = event.PacketsOrig
 	}
+	conn.PolicyViolation = c.allowlist.violates(conn)
 
 	return conn
 }
@@ -241,7 +551,7 @@ func (c *Collector) convertToProto(event *ConntrackEvent, containerName, contain
 type EBPFFlow struct {
 	ContainerName string
 	ContainerIP   string
-	Protocol      string // "tcp" | "udp" | "icmp"
+	Protocol      string // "tcp" | "udp" | "icmp" | "sctp" | ...; see protoStringToEnum
 	SrcIP         string
 	SrcPort       uint16
 	DstIP         string
@@ -252,6 +562,9 @@ type EBPFFlow struct {
 	RxPackets     int64
 	First         time.Time
 	Last          time.Time
+	// Blocked marks a flow the container's network policy dropped; nothing
+	// of it got past the veth.
+	Blocked bool
 }
 
 // IngestEBPFFlows replaces the collector's eBPF-sourced flow set with a fresh
@@ -263,14 +576,22 @@ type EBPFFlow struct {
 func (c *Collector) IngestEBPFFlows(flows []EBPFFlow) {
 	next := make(map[string]*pb.Connection, len(flows))
 	for _, f := range flows {
-		conn := ebpfFlowToConn(f)
+		conn := c.ebpfConn(f)
 		next[conn.Id] = conn
 	}
 	c.mu.Lock()
 	prev := c.ebpfFlows
 	c.ebpfFlows = next
+	c.countEBPFFlows(next)
 	c.mu.Unlock()
 
+	// Flows conntrack also sees are reported from there.
+	for _, conn := range next {
+		if conn.PolicyViolation && !conn.Blocked && !c.conntrackOwns(conn.ContainerName) {
+			c.reportViolation(conn)
+		}
+	}
+
 	// Persist flows that disappeared since the last poll to traffic_history
 	// (#632). The enforcer hands us the active set each poll, so a flow in the
 	// previous set but not this one has been evicted from the BPF LRU map, had
@@ -300,7 +621,7 @@ func (c *Collector) IngestEBPFFlows(flows []EBPFFlow) {
 func (c *Collector) PersistEBPFFlows(flows []EBPFFlow) {
 	conns := make([]*pb.Connection, 0, len(flows))
 	for _, f := range flows {
-		conns = append(conns, ebpfFlowToConn(f))
+		conns = append(conns, c.ebpfConn(f))
 	}
 	c.persistClosedFlows(conns)
 }
@@ -319,9 +640,12 @@ func (c *Collector) persistClosedFlows(conns []*pb.Connection) {
 		if c.conntrackOwns(conn.ContainerName) {
 			continue
 		}
-		conn := conn
+		conn, keep := c.sample(conn)
+		if !keep {
+			continue
+		}
 		go func() {
-			if err := c.store.SaveConnection(c.ctx, conn); err != nil {
+			if err := c.store.SaveConnection(c.ctx, c.enrich(conn)); err != nil {
 				log.Printf("Warning: failed to persist closed eBPF flow: %v", err)
 			}
 		}()
@@ -348,7 +672,41 @@ func ebpfFlowToConn(f EBPFFlow) *pb.Connection {
 		PacketsReceived: f.RxPackets, // 0 when the BPF object predates #631
 		FirstSeen:       timestamppb.New(f.First),
 		LastSeen:        timestamppb.New(f.Last),
+		Blocked:         f.Blocked,
+	}
+}
+
+// ReportBlockedEBPFFlow records a connection attempt the container's
+// network policy dropped: it counts toward the container's blocked
+// connections and is published as a BLOCKED traffic event. The enforcer
+// reports each blocked flow once, not per dropped packet.
+func (c *Collector) ReportBlockedEBPFFlow(f EBPFFlow) {
+	f.Blocked = true
+	conn := c.ebpfConn(f)
+	c.mu.Lock()
+	c.counters.block(f.ContainerName)
+	c.mu.Unlock()
+
+	if c.emitter == nil {
+		return
 	}
+	c.emitter.EmitTrafficEvent(&pb.TrafficEvent{
+		Type:       pb.TrafficEventType_TRAFFIC_EVENT_TYPE_BLOCKED,
+		Connection: conn,
+		Timestamp:  timestamppb.Now(),
+	})
+}
+
+// ebpfConn is ebpfFlowToConn plus the owning username from the cache and
+// the destination's name from DNS logging.
+func (c *Collector) ebpfConn(f EBPFFlow) *pb.Connection {
+	conn := ebpfFlowToConn(f)
+	conn.Username = c.cache.LookupUsername(f.ContainerName)
+	conn.PolicyViolation = c.allowlist.violates(conn)
+	c.mu.RLock()
+	conn.DestHostname = c.destHostname(f.ContainerName, f.DstIP, f.Last)
+	c.mu.RUnlock()
+	return conn
 }
 
 // conntrackOwns reports whether the conntrack collector has attributed traffic
@@ -375,6 +733,27 @@ func closedFlows(prev, next map[string]*pb.Connection) []*pb.Connection {
 	return out
 }
 
+// countEBPFFlows adds the eBPF flows of containers conntrack doesn't own
+// to the container totals, and forgets the flows no longer reported.
+// Caller holds c.mu.
+func (c *Collector) countEBPFFlows(flows map[string]*pb.Connection) {
+	for id, conn := range flows {
+		if !c.conntrackSeen[conn.ContainerName] {
+			c.counters.add(id, "", conn)
+		}
+	}
+	c.counters.prune(func(key string) bool {
+		_, ok := flows[key]
+		return ok || !isEBPFFlowID(key)
+	})
+}
+
+// isEBPFFlowID reports whether key is an ebpfFlowID rather than a
+// conntrack key.
+func isEBPFFlowID(key string) bool {
+	return strings.HasPrefix(key, "ebpf-")
+}
+
 // ebpfFlowID is a stable identifier for an eBPF flow, derived from its 5-tuple
 // and owning container so a flow keeps the same ID across polls. The "ebpf-"
 // prefix keeps it from colliding with conntrack connection IDs.
@@ -407,32 +786,129 @@ func (c *Collector) emitTrafficEvent(eventType ConntrackEventType, conn *pb.Conn
 	c.emitter.EmitTrafficEvent(trafficEvent)
 }
 
-// periodicSnapshot takes periodic snapshots of the conntrack table
-func (c *Collector) periodicSnapshot() {
+// Snapshot takes a full conntrack snapshot and checkpoints the connections
+// open longer than CheckpointAge, returning how many it checkpointed. The
+// daemon's snapshot-schedule job runs it.
+func (c *Collector) Snapshot(ctx context.Context) (int64, error) {
 	if c.monitor == nil {
-		return
+		return 0, errors.New("conntrack monitoring is unavailable")
 	}
+	c.takeSnapshot()
+	return c.checkpointOpenConnections(ctx)
+}
 
-	ticker := time.NewTicker(c.config.SnapshotInterval)
-	defer ticker.Stop()
+// checkpointOpenConnections persists connections that have been open longer
+// than CheckpointAge, so their bytes show up in history queries and aggregates
+// without waiting for DESTROY. Piggybacks on Snapshot; the store upserts by
+// connection key, so each pass just refreshes the counters. A connection
+// that fails to save is logged and skipped; the error reports how many did.
+func (c *Collector) checkpointOpenConnections(ctx context.Context) (int64, error) {
+	checkpointer, ok := c.store.(ConnectionCheckpointer)
+	if !ok || c.config.CheckpointAge <= 0 {
+		return 0, nil
+	}
 
-	for {
-		select {
-		case <-c.ctx.Done():
-			return
-		case <-ticker.C:
-			c.takeSnapshot()
+	c.mu.RLock()
+	conns := longLivedConnections(c.connections, c.openSince, time.Now(), c.config.CheckpointAge)
+	c.mu.RUnlock()
+
+	var saved, failed int64
+	var lastErr error
+	for _, conn := range conns {
+		if err := checkpointer.CheckpointConnection(ctx, conn); err != nil {
+			log.Printf("Warning: failed to checkpoint open connection: %v", err)
+			failed, lastErr = failed+1, err
+			continue
 		}
+		saved++
+	}
+	if failed > 0 {
+		return saved, fmt.Errorf("failed to checkpoint %d of %d open connections: %w", failed, len(conns), lastErr)
 	}
+	return saved, nil
+}
+
+// longLivedConnections returns copies of the open connections first seen at
+// least minAge before now, with FirstSeen set to that first observation. Pure,
+// so it's unit-testable without a database.
+func longLivedConnections(conns map[string]*pb.Connection, openSince map[string]openFlow, now time.Time, minAge time.Duration) []*pb.Connection {
+	var out []*pb.Connection
+	for id, conn := range conns {
+		open, ok := openSince[id]
+		if !ok || now.Sub(open.since) < minAge {
+			continue
+		}
+		cp := proto.Clone(conn).(*pb.Connection)
+		cp.FirstSeen = timestamppb.New(open.since)
+		out = append(out, cp)
+	}
+	return out
 }
 
-// takeSnapshot captures the current conntrack state
+// takeSnapshot captures the current conntrack state. Flows are streamed
+// from the monitor into a fresh connection map that replaces the old
+// one when the dump completes, so the full table is never held as one
+// event slice and readers keep the previous view until then.
 func (c *Collector) takeSnapshot() {
 	if c.monitor == nil {
 		return
 	}
 
-	events, err := c.monitor.Snapshot()
+	now := time.Now()
+	next := make(map[string]*pb.Connection)
+	quiet := make(map[string]bool) // idle-closed flows still in the table
+	var closed, violations []*pb.Connection
+	var changed []stateChange
+	defer func() {
+		c.recordIdleClosed(closed)
+		for _, conn := range violations {
+			c.reportViolation(conn)
+		}
+		for _, ch := range changed {
+			c.queueStateChange(ch)
+		}
+	}()
+	err := c.monitor.SnapshotFunc(SnapshotFilter{}, func(event *ConntrackEvent) error {
+		containerName, containerIP := c.attributeEvent(event)
+		if containerName == "" {
+			return nil
+		}
+
+		conn := c.convertToProto(event, containerName, containerIP)
+		if conn.PolicyViolation {
+			violations = append(violations, conn)
+		}
+		key := event.Key()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.conntrackSeen[containerName] = true // conntrack owns this container's history (#643)
+		c.counters.add(key, event.tuple(), conn)
+		if c.splitIdle(key, event, conn) {
+			quiet[key] = true
+			return nil
+		}
+		last := c.openSince[key].lastPacketAt(event)
+		c.markOpen(key, event, conn)
+		if c.idle(key, event, conn) {
+			closed = append(closed, c.closeIdle(key, conn, last))
+			quiet[key] = true
+			return nil
+		}
+		c.sampleRate(key, conn, now)
+		if c.stateChanges != nil {
+			// The event stream carries no UPDATEs, so states between NEW
+			// and DESTROY are seen only here.
+			prev := pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED
+			if old, ok := c.connections[key]; ok {
+				prev = old.State
+			}
+			if conn.State != prev {
+				changed = append(changed, stateChange{containerName: conn.ContainerName, id: conn.Id, state: conn.State, at: event.Timestamp})
+			}
+		}
+		next[key] = conn
+		return nil
+	})
 	if err != nil {
 		log.Printf("Warning: failed to take conntrack snapshot: %v", err)
 		return
@@ -441,69 +917,282 @@ func (c *Collector) takeSnapshot() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Clear old connections and rebuild from snapshot
-	c.connections = make(map[string]*pb.Connection)
-
-	matched := 0
-	// Update connections from snapshot
-	for _, event := range events {
-		containerName := ""
-		containerIP := ""
-		direction := pb.TrafficDirection_TRAFFIC_DIRECTION_UNSPECIFIED
+	c.connections = next
+	if !c.lastSnapshot.IsZero() {
+		c.snapshotInterval = now.Sub(c.lastSnapshot)
+	}
+	c.lastSnapshot = now
 
-		// Check source IP first (egress from container)
-		if name := c.cache.LookupIP(event.SrcIP); name != "" {
-			containerName = name
-			containerIP = event.SrcIP
-			direction = pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
-		} else if name := c.cache.LookupIP(event.DstIP); name != "" {
-			// Check destination IP (ingress to container)
-			containerName = name
-			containerIP = event.DstIP
-			direction = pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS
+	// Forget connections that vanished without us seeing their DESTROY
+	// (dropped netlink event, table flush).
+	for id := range c.openSince {
+		if _, ok := next[id]; !ok {
+			delete(c.openSince, id)
 		}
+	}
+	for id := range c.idleSplits {
+		if _, ok := next[id]; !ok && !quiet[id] {
+			delete(c.idleSplits, id)
+		}
+	}
+	c.counters.prune(func(key string) bool {
+		_, ok := next[key]
+		return ok || quiet[key] || isEBPFFlowID(key)
+	})
+}
 
-		if containerName == "" {
+// idle reports whether a snapshot should close the connectionless flow
+// key: conntrack has expired its entry, or no packet has passed for
+// UDPIdleTimeout. Without packet counters (accounting off) only expiry
+// counts. Caller holds c.mu, after markOpen.
+func (c *Collector) idle(key string, event *ConntrackEvent, conn *pb.Connection) bool {
+	if c.config.UDPIdleTimeout <= 0 || !connectionless(conn.Protocol) {
+		return false
+	}
+	if event.Timeout <= 0 {
+		return true
+	}
+	open := c.openSince[key]
+	return open.packets > 0 && event.Timestamp.Sub(open.lastActive) >= c.config.UDPIdleTimeout
+}
+
+// idleSplit is a connectionless flow closed for idleness whose conntrack
+// entry lives on. Packets on the entry afterwards make a new segment of
+// it, recorded as "<id>.<segments>" with counters relative to the close.
+type idleSplit struct {
+	tuple                        string
+	sent, received               int64
+	packetsSent, packetsReceived int64
+	segments                     int
+}
+
+// closeIdle ends the idle flow key at its last packet and returns conn,
+// now its final row. Caller holds c.mu.
+func (c *Collector) closeIdle(key string, conn *pb.Connection, last time.Time) *pb.Connection {
+	conn.LastSeen = timestamppb.New(last)
+	split := c.idleSplits[key]
+	split.tuple = c.openSince[key].tuple
+	split.sent += conn.BytesSent
+	split.received += conn.BytesReceived
+	split.packetsSent += conn.PacketsSent
+	split.packetsReceived += conn.PacketsReceived
+	split.segments++
+	c.idleSplits[key] = split
+	delete(c.openSince, key)
+	delete(c.connections, key)
+	return conn
+}
+
+// splitIdle rewrites conn, built from an event for a flow closed by
+// closeIdle, into the segment that began after the close, and reports
+// true when nothing has passed since and the event should be dropped. A
+// different tuple means conntrack reused the ID for a new flow. Caller
+// holds c.mu.
+func (c *Collector) splitIdle(key string, event *ConntrackEvent, conn *pb.Connection) bool {
+	split, ok := c.idleSplits[key]
+	if !ok {
+		return false
+	}
+	if split.tuple != event.tuple() {
+		delete(c.idleSplits, key)
+		return false
+	}
+	if conn.PacketsSent+conn.PacketsReceived <= split.packetsSent+split.packetsReceived {
+		return true
+	}
+	conn.Id = fmt.Sprintf("%s.%d", conn.Id, split.segments)
+	conn.BytesSent -= split.sent
+	conn.BytesReceived -= split.received
+	conn.PacketsSent -= split.packetsSent
+	conn.PacketsReceived -= split.packetsReceived
+	return false
+}
+
+// recordIdleClosed emits and persists the flows a snapshot closed for
+// idleness, as a DESTROY would have.
+func (c *Collector) recordIdleClosed(closed []*pb.Connection) {
+	for _, conn := range closed {
+		c.emitTrafficEvent(ConntrackEventDestroy, conn)
+		if c.store == nil {
 			continue
 		}
+		if conn, keep := c.sample(conn); keep {
+			c.persistConnection(conn)
+		}
+	}
+}
 
-		matched++
-		c.conntrackSeen[containerName] = true // conntrack owns this container's history (#643)
-		conn := c.convertToProto(event, containerName, containerIP, direction)
-		c.connections[event.ID] = conn
+// connectionless reports whether p has no teardown of its own, so its
+// conntrack entries end only by timing out.
+func connectionless(p pb.Protocol) bool {
+	switch p {
+	case pb.Protocol_PROTOCOL_UDP, pb.Protocol_PROTOCOL_UDPLITE,
+		pb.Protocol_PROTOCOL_ICMP, pb.Protocol_PROTOCOL_ICMPV6, pb.Protocol_PROTOCOL_GRE:
+		return true
 	}
+	return false
+}
 
+// openFlow is the first observation of an open connection.
+type openFlow struct {
+	since time.Time
+	// tuple is the flow's 5-tuple. Conntrack reuses IDs, so a key seen
+	// again with a different tuple is a new flow whose DESTROY we missed.
+	tuple string
+
+	// sampledAt is the last snapshot that saw the flow, with the byte
+	// counters it had then. rate is derived from the two latest samples
+	// and nil until there are two.
+	sampledAt      time.Time
+	sent, received int64
+	rate           *flowRate
+
+	// lastActive is when the flow's packet count last grew, and packets
+	// that count. expiry is the longest timeout conntrack reported for
+	// it: the entry goes that long after its last packet.
+	lastActive time.Time
+	packets    int64
+	expiry     time.Duration
 }
 
-// periodicCleanup removes old data from the database
-func (c *Collector) periodicCleanup() {
-	ticker := time.NewTicker(c.config.CleanupInterval)
-	defer ticker.Stop()
+// lastPacketAt estimates when event, a later observation of the flow,
+// last saw a packet. Unchanged counters put it at lastActive. Otherwise a
+// connectionless flow's DESTROY comes its timeout after the last packet,
+// which can't be before lastActive; anything else is taken at its word.
+func (o openFlow) lastPacketAt(event *ConntrackEvent) time.Time {
+	packets := event.PacketsOrig + event.PacketsReply
+	switch {
+	case packets == 0 || o.lastActive.IsZero() || o.tuple != event.tuple():
+		return event.Timestamp
+	case packets == o.packets:
+		return o.lastActive
+	case connectionless(protoStringToEnum(event.Protocol)) && o.expiry > 0:
+		return maxTime(o.lastActive, event.Timestamp.Add(-o.expiry))
+	}
+	return event.Timestamp
+}
 
-	for {
-		select {
-		case <-c.ctx.Done():
-			return
-		case <-ticker.C:
-			if err := c.store.Cleanup(c.ctx, c.config.RetentionDays); err != nil {
-				log.Printf("Warning: traffic cleanup failed: %v", err)
-			}
+// flowRate is a connection's bytes per second between two snapshots.
+type flowRate struct {
+	sent, received float64
+}
+
+// sampleRate records conn's counters as the flow's sample at snapshot
+// time at and sets its rates from the previous sample, if the flow was in
+// the previous snapshot. Counters that went backwards mean conntrack
+// reused the entry, so the rate restarts. Caller holds c.mu, after
+// markOpen.
+func (c *Collector) sampleRate(key string, conn *pb.Connection, at time.Time) {
+	open := c.openSince[key]
+	open.rate = nil
+	if !open.sampledAt.IsZero() && at.After(open.sampledAt) && conn.BytesSent >= open.sent && conn.BytesReceived >= open.received {
+		secs := at.Sub(open.sampledAt).Seconds()
+		open.rate = &flowRate{
+			sent:     float64(conn.BytesSent-open.sent) / secs,
+			received: float64(conn.BytesReceived-open.received) / secs,
 		}
 	}
+	open.sampledAt, open.sent, open.received = at, conn.BytesSent, conn.BytesReceived
+	c.openSince[key] = open
+	open.rate.stamp(conn)
 }
 
-// GetConnections returns current active connections for a container
-func (c *Collector) GetConnections(containerName string) []*pb.Connection {
-	// Ensure cache is refreshed before taking snapshot
+// stamp sets conn's rate fields; a nil rate leaves them unset.
+func (r *flowRate) stamp(conn *pb.Connection) {
+	if r == nil {
+		return
+	}
+	conn.BytesSentPerSec = proto.Float64(r.sent)
+	conn.BytesReceivedPerSec = proto.Float64(r.received)
+}
+
+// SnapshotInterval is the time between the last two conntrack snapshots,
+// the window connection rates are measured over. Zero until there have
+// been two.
+func (c *Collector) SnapshotInterval() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshotInterval
+}
+
+// refreshSnapshot takes a snapshot unless one was taken within
+// SnapshotDebounce.
+func (c *Collector) refreshSnapshot() {
+	c.snapshotMu.Lock()
+	defer c.snapshotMu.Unlock()
+
+	c.mu.RLock()
+	fresh := c.config.SnapshotDebounce > 0 && time.Since(c.lastSnapshot) < c.config.SnapshotDebounce
+	c.mu.RUnlock()
+	if !fresh {
+		c.takeSnapshot()
+	}
+}
+
+// RefreshNow takes a conntrack snapshot regardless of SnapshotDebounce, for
+// callers that need the table as it is now rather than up to a debounce
+// window old. No-op when conntrack monitoring is unavailable.
+func (c *Collector) RefreshNow() {
+	if c.monitor == nil {
+		return
+	}
+	c.fillEmptyCache()
+
+	c.snapshotMu.Lock()
+	defer c.snapshotMu.Unlock()
+	c.takeSnapshot()
+}
+
+// fillEmptyCache refreshes the container cache when it is empty: an empty
+// cache attributes nothing, so a snapshot taken before it is filled would
+// answer with no connections.
+func (c *Collector) fillEmptyCache() {
 	if c.cache.Size() == 0 {
-		if err := c.cache.Refresh(); err != nil {
+		if err := c.cache.RefreshWithin(cacheRefreshTimeout); err != nil {
 			log.Printf("Warning: failed to refresh container cache: %v", err)
 		}
 	}
+}
+
+// markOpen records event as the first observation of the open connection
+// key unless an earlier one of the same flow is known, and stamps that on
+// conn.FirstSeen: a snapshot rebuilds every connection, and without this
+// its age would restart at each one. It also notes when the flow's packet
+// count last grew, for lastPacketAt and idle. Caller holds c.mu.
+func (c *Collector) markOpen(key string, event *ConntrackEvent, conn *pb.Connection) {
+	tuple := event.tuple()
+	open, ok := c.openSince[key]
+	if !ok || open.tuple != tuple || event.Timestamp.Before(open.since) {
+		open = openFlow{since: event.Timestamp, tuple: tuple}
+	}
+	if packets := event.PacketsOrig + event.PacketsReply; packets > open.packets || open.lastActive.IsZero() {
+		open.lastActive, open.packets = event.Timestamp, packets
+	}
+	open.expiry = max(open.expiry, time.Duration(event.Timeout)*time.Second)
+	c.openSince[key] = open
+	conn.FirstSeen = timestamppb.New(open.since)
+	// An update event between snapshots replaces the connection; keep
+	// showing the rate the last snapshot measured.
+	open.rate.stamp(conn)
+}
+
+// Cleanup deletes stored history older than RetentionDays and returns how
+// many records it deleted. The daemon's traffic-cleanup job runs it.
+func (c *Collector) Cleanup(ctx context.Context) (int64, error) {
+	if c.store == nil {
+		return 0, ErrPersistenceDisabled
+	}
+	c.mu.RLock()
+	days := c.config.RetentionDays
+	c.mu.RUnlock()
+	return c.store.Cleanup(ctx, days)
+}
 
-	// Take a fresh snapshot from conntrack for most up-to-date data
+// GetConnections returns current active connections for a container
+func (c *Collector) GetConnections(containerName string) []*pb.Connection {
+	c.fillEmptyCache()
 	if c.monitor != nil {
-		c.takeSnapshot()
+		c.refreshSnapshot()
 	}
 
 	c.mu.RLock()
@@ -531,31 +1220,67 @@ func (c *Collector) GetConnections(containerName string) []*pb.Connection {
 	return result
 }
 
-// GetConnectionSummary returns aggregate statistics for a container
-func (c *Collector) GetConnectionSummary(containerName string) *pb.ConnectionSummary {
+// GetConnectionSummary returns aggregate statistics for a container. With
+// separateDNS, DNS lookups are tallied into summary.Dns alone instead of the
+// counts, totals, destinations and services.
+func (c *Collector) GetConnectionSummary(containerName string, separateDNS bool) *pb.ConnectionSummary {
 	connections := c.GetConnections(containerName)
 
 	summary := &pb.ConnectionSummary{
-		ContainerName:     containerName,
-		ActiveConnections: safecast.I32(len(connections)),
+		ContainerName: containerName,
 	}
 
 	destCounts := make(map[string]int)
 	destBytes := make(map[string]int64)
+	services := make(map[serviceKey]*pb.ServiceStats)
+	resolvers := make(map[string]bool)
 
 	for _, conn := range connections {
+		if separateDNS && isDNS(conn) {
+			if summary.Dns == nil {
+				summary.Dns = &pb.DNSStats{}
+			}
+			summary.Dns.ConnectionCount++
+			summary.Dns.BytesTotal += conn.BytesSent + conn.BytesReceived
+			resolvers[conn.DestIp] = true
+			continue
+		}
+		summary.ActiveConnections++
+
 		switch conn.Protocol {
 		case pb.Protocol_PROTOCOL_TCP:
 			summary.TcpConnections++
 		case pb.Protocol_PROTOCOL_UDP:
 			summary.UdpConnections++
+		case pb.Protocol_PROTOCOL_ICMP, pb.Protocol_PROTOCOL_ICMPV6:
+			summary.IcmpConnections++
 		}
 
 		summary.TotalBytesSent += conn.BytesSent
 		summary.TotalBytesReceived += conn.BytesReceived
+		summary.BytesSentPerSec += conn.GetBytesSentPerSec()
+		summary.BytesReceivedPerSec += conn.GetBytesReceivedPerSec()
+
+		if conn.State != pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED {
+			if summary.StateCounts == nil {
+				summary.StateCounts = make(map[string]int32)
+			}
+			summary.StateCounts[StateName(conn.State)]++
+		}
 
 		destCounts[conn.DestIp]++
 		destBytes[conn.DestIp] += conn.BytesSent + conn.BytesReceived
+
+		if conn.DestPort != 0 {
+			key := serviceKey{conn.Protocol, conn.DestPort}
+			st := services[key]
+			if st == nil {
+				st = &pb.ServiceStats{Port: conn.DestPort, Protocol: conn.Protocol}
+				services[key] = st
+			}
+			st.ConnectionCount++
+			st.BytesTotal += conn.BytesSent + conn.BytesReceived
+		}
 	}
 
 	// Top destinations
@@ -567,14 +1292,43 @@ func (c *Collector) GetConnectionSummary(containerName string) *pb.ConnectionSum
 		})
 	}
 
+	// Ports by connection count. Service names are left to the caller,
+	// which labels them at response time.
+	for _, st := range services {
+		summary.TopServices = append(summary.TopServices, st)
+	}
+	slices.SortFunc(summary.TopServices, func(a, b *pb.ServiceStats) int {
+		return cmp.Or(cmp.Compare(b.ConnectionCount, a.ConnectionCount), cmp.Compare(a.Port, b.Port), cmp.Compare(a.Protocol, b.Protocol))
+	})
+
+	if summary.Dns != nil {
+		summary.Dns.Resolvers = slices.Sorted(maps.Keys(resolvers))
+	}
+
 	return summary
 }
 
-// GetStore returns the traffic store
-func (c *Collector) GetStore() *Store {
+// isDNS reports whether conn is a DNS lookup: TCP or UDP to port 53.
+func isDNS(conn *pb.Connection) bool {
+	return conn.DestPort == 53 &&
+		(conn.Protocol == pb.Protocol_PROTOCOL_UDP || conn.Protocol == pb.Protocol_PROTOCOL_TCP)
+}
+
+// GetStore returns the traffic store, or nil when persistence is disabled
+func (c *Collector) GetStore() ConnectionStore {
 	return c.store
 }
 
+// ErrPersistenceDisabled is returned by history queries on a collector
+// configured without persistence.
+var ErrPersistenceDisabled = errors.New("traffic persistence is disabled (daemon --traffic-store none)")
+
+// PersistenceEnabled reports whether the collector was configured to keep
+// history. It may still have no store yet, while the database comes up.
+func (c *Collector) PersistenceEnabled() bool {
+	return c.config.PersistenceEnabled
+}
+
 // Stop stops the collector
 func (c *Collector) Stop() {
 	c.cancel()
@@ -594,38 +1348,80 @@ func protoStringToEnum(proto string) pb.Protocol {
 		return pb.Protocol_PROTOCOL_UDP
 	case "icmp":
 		return pb.Protocol_PROTOCOL_ICMP
+	case "sctp":
+		return pb.Protocol_PROTOCOL_SCTP
+	case "icmpv6":
+		return pb.Protocol_PROTOCOL_ICMPV6
+	case "dccp":
+		return pb.Protocol_PROTOCOL_DCCP
+	case "udplite":
+		return pb.Protocol_PROTOCOL_UDPLITE
+	case "gre":
+		return pb.Protocol_PROTOCOL_GRE
 	default:
 		return pb.Protocol_PROTOCOL_UNSPECIFIED
 	}
 }
 
-// stateStringToEnum converts a TCP state string to enum
+// stateStringToEnum converts a TCP, SCTP or DCCP state string to enum.
+// SCTP and DCCP states map to their TCP counterparts: the association
+// handshake to SYN_SENT/SYN_RECV, shutdown to FIN_WAIT.
 func stateStringToEnum(state string) pb.ConnectionState {
 	switch state {
-	case "SYN_SENT":
+	case "SYN_SENT", "SCTP_COOKIE_WAIT", "DCCP_REQUEST":
 		return pb.ConnectionState_CONNECTION_STATE_SYN_SENT
-	case "SYN_RECV":
+	case "SYN_RECV", "SCTP_COOKIE_ECHOED", "DCCP_RESPOND":
 		return pb.ConnectionState_CONNECTION_STATE_SYN_RECV
-	case "ESTABLISHED":
+	case "ESTABLISHED", "SCTP_ESTABLISHED", "SCTP_HEARTBEAT_SENT", "SCTP_HEARTBEAT_ACKED", "DCCP_PARTOPEN", "DCCP_OPEN":
 		return pb.ConnectionState_CONNECTION_STATE_ESTABLISHED
-	case "FIN_WAIT":
+	case "FIN_WAIT", "SCTP_SHUTDOWN_SENT", "SCTP_SHUTDOWN_RECD", "SCTP_SHUTDOWN_ACK_SENT", "DCCP_CLOSEREQ", "DCCP_CLOSING":
 		return pb.ConnectionState_CONNECTION_STATE_FIN_WAIT
 	case "CLOSE_WAIT":
 		return pb.ConnectionState_CONNECTION_STATE_CLOSE_WAIT
-	case "LAST_ACK", "TIME_WAIT":
+	case "LAST_ACK", "TIME_WAIT", "DCCP_TIMEWAIT":
 		return pb.ConnectionState_CONNECTION_STATE_TIME_WAIT
-	case "CLOSE":
+	case "CLOSE", "SCTP_CLOSED":
 		return pb.ConnectionState_CONNECTION_STATE_CLOSED
 	default:
 		return pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED
 	}
 }
 
+// StateName is st's name without the enum prefix, e.g. "SYN_SENT": the
+// key ConnectionSummary.state_counts uses.
+func StateName(st pb.ConnectionState) string {
+	return strings.TrimPrefix(st.String(), "CONNECTION_STATE_")
+}
+
 // IsAvailable returns true if conntrack monitoring is available
 func (c *Collector) IsAvailable() bool {
 	return c.monitor != nil
 }
 
+// Ready reports whether the container cache has been filled, i.e. whether
+// connections can be attributed to containers yet.
+func (c *Collector) Ready() bool {
+	return c.cache.Ready()
+}
+
+// CacheStats reports the container cache and its circuit breaker, so a
+// caller can tell when connections are attributed from a stale listing.
+func (c *Collector) CacheStats() CacheStats {
+	return c.cache.Stats()
+}
+
+// ListTrackedContainers returns the names of the containers connections
+// are attributed to, sorted, from the cached Incus listing. See CacheStats
+// for how fresh the listing is.
+func (c *Collector) ListTrackedContainers() []string {
+	return slices.Sorted(maps.Keys(c.cache.GetAllContainers()))
+}
+
+// TrackedContainerIPs maps each tracked container to its IP address.
+func (c *Collector) TrackedContainerIPs() map[string]string {
+	return c.cache.GetAllContainers()
+}
+
 // Error returns any collector error message
 func (c *Collector) Error() string {
 	if c.monitor == nil {
@@ -0,0 +1,105 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCompactionUnsupported is returned by Collector.Compact when the
+// configured store can't roll connections up into traffic_aggregates (e.g.
+// --traffic-store memory).
+var ErrCompactionUnsupported = errors.New("history compaction requires the PostgreSQL traffic store")
+
+// compactBatchSize is how many connections one CompactConnections
+// statement folds away, so a first pass over months of history doesn't
+// hold one huge transaction.
+const compactBatchSize = 10000
+
+// compactConnectionsSQL folds up to $2 closed connections that ended
+// before $1 into hourly traffic_aggregates rows (per container,
+// destination and direction) and deletes them, in one statement so no
+// connection is counted twice or lost. Rows whose bytes the usage rollup
+// has yet to bill are left for it, including rows from before the rollup
+// existed (billed_sent NULL): only its backfill bills those, and it
+// can't once they are folded away.
+// Bytes and counts are scaled by sample_weight, as GetAggregates does.
+//
+// $1 cutoff, $2 batch size.
+const compactConnectionsSQL = `
+	WITH gone AS (
+		DELETE FROM traffic_connections
+		WHERE id IN (
+			SELECT id FROM traffic_connections
+			WHERE started_at < $1 AND ended_at < $1
+			  AND billed_at IS NOT NULL AND billed_sent IS NOT NULL
+			  AND bytes_sent <= billed_sent AND bytes_received <= billed_received
+			LIMIT $2
+		)
+		RETURNING container_name, dest_ip, dest_port, direction,
+		          date_trunc('hour', started_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS hour,
+		          bytes_sent * sample_weight AS bytes_sent,
+		          bytes_received * sample_weight AS bytes_received,
+		          sample_weight
+	), rolled AS (
+		INSERT INTO traffic_aggregates AS a (
+			container_name, dest_ip, dest_port, direction, interval_start, interval_end,
+			bytes_sent, bytes_received, connection_count
+		)
+		SELECT container_name, dest_ip, dest_port, direction, hour, hour + interval '1 hour',
+		       SUM(bytes_sent), SUM(bytes_received), SUM(sample_weight)
+		FROM gone
+		GROUP BY container_name, dest_ip, dest_port, direction, hour
+		ON CONFLICT (container_name, dest_ip, dest_port, direction, interval_start) DO UPDATE SET
+			bytes_sent = a.bytes_sent + EXCLUDED.bytes_sent,
+			bytes_received = a.bytes_received + EXCLUDED.bytes_received,
+			connection_count = a.connection_count + EXCLUDED.connection_count
+	)
+	SELECT COUNT(*) FROM gone
+`
+
+// CompactConnections rolls closed connections that ended before cutoff up
+// into traffic_aggregates and deletes them, returning how many it
+// compacted. Unlike Cleanup, which drops history outright, this keeps
+// hourly totals per container and destination for GetAggregates, so
+// long-term trends outlive the raw rows. Still-open (checkpointed)
+// connections and bytes not yet billed by the usage rollup are left alone.
+func (s *Store) CompactConnections(ctx context.Context, cutoff time.Time) (int64, error) {
+	var total int64
+	for {
+		var n int64
+		if err := s.pool.QueryRow(ctx, compactConnectionsSQL, cutoff, compactBatchSize).Scan(&n); err != nil {
+			return total, fmt.Errorf("failed to compact traffic connections: %w", err)
+		}
+		total += n
+		if n < compactBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// Compact rolls connections older than CompactAfterDays up into
+// traffic_aggregates and deletes them, returning how many it compacted.
+// The daemon's traffic-compaction job runs it.
+func (c *Collector) Compact(ctx context.Context) (int64, error) {
+	if c.store == nil {
+		return 0, ErrPersistenceDisabled
+	}
+	compactor, ok := c.store.(ConnectionCompactor)
+	if !ok {
+		return 0, ErrCompactionUnsupported
+	}
+	days := c.config.CompactAfterDays
+	if days <= 0 {
+		return 0, errors.New("history compaction is off (CompactAfterDays is 0)")
+	}
+	return compactor.CompactConnections(ctx, time.Now().AddDate(0, 0, -days))
+}
+
+// CompactionEnabled reports whether Compact has anything to do: a store
+// that supports it and a CompactAfterDays set.
+func (c *Collector) CompactionEnabled() bool {
+	_, ok := c.store.(ConnectionCompactor)
+	return ok && c.config.CompactAfterDays > 0
+}
@@ -0,0 +1,70 @@
+package traffic
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ConnectionStore is the persistence backend for traffic history. The
+// collector and the traffic/activity APIs depend only on this interface;
+// *Store (PostgreSQL via pgx) is the production implementation, and other
+// backends (SQLite for single-host installs, a TSDB, in-memory fakes in
+// tests) plug in by satisfying it.
+type ConnectionStore interface {
+	// SaveConnection persists a closed connection.
+	SaveConnection(ctx context.Context, conn *pb.Connection) error
+	// QueryConnections returns historical connections matching params and
+	// the total match count, for pagination.
+	QueryConnections(ctx context.Context, params QueryParams) ([]*pb.HistoricalConnection, int32, error)
+	// GetAggregates returns time-bucketed traffic totals.
+	GetAggregates(ctx context.Context, params AggregateParams) ([]*pb.TrafficAggregate, error)
+	// Cleanup deletes history older than retentionDays and returns how
+	// many records it deleted.
+	Cleanup(ctx context.Context, retentionDays int) (int64, error)
+	// HealthCheck reports whether the backend is reachable; the gRPC health
+	// reporter polls it.
+	HealthCheck(ctx context.Context) error
+}
+
+// ConnectionCheckpointer is implemented by backends that can persist a
+// still-open connection and refresh it in place on later passes. The
+// collector skips checkpointing for backends without it, so long-lived
+// connections only reach history when they close.
+type ConnectionCheckpointer interface {
+	CheckpointConnection(ctx context.Context, conn *pb.Connection) error
+}
+
+// HistoryStreamer is implemented by backends that can stream stored
+// connections in start order; Collector.Replay requires it.
+type HistoryStreamer interface {
+	StreamHistory(ctx context.Context, params QueryParams) <-chan *pb.Connection
+}
+
+// UsageRollup is implemented by backends that maintain the traffic_daily
+// billing rollup; the collector's rollup job and the usage RPCs require it.
+type UsageRollup interface {
+	RollupDailyUsage(ctx context.Context, opts RollupOptions) (RollupResult, error)
+	GetDailyUsage(ctx context.Context, containerName string, month time.Time) ([]*pb.DailyUsage, error)
+}
+
+// ConnectionCompactor is implemented by backends that can fold old
+// connections into time-bucketed aggregates, which GetAggregates still
+// reports; the collector's compaction job requires it.
+type ConnectionCompactor interface {
+	CompactConnections(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+var (
+	_ ConnectionStore        = (*Store)(nil)
+	_ ConnectionCheckpointer = (*Store)(nil)
+	_ HistoryStreamer        = (*Store)(nil)
+	_ UsageRollup            = (*Store)(nil)
+	_ ConnectionCompactor    = (*Store)(nil)
+	_ StateChangeRecorder    = (*Store)(nil)
+	_ DNSRecorder            = (*Store)(nil)
+	_ DestinationQuerier     = (*Store)(nil)
+	_ ListenerRecorder       = (*Store)(nil)
+	_ SSHSessionRecorder     = (*Store)(nil)
+)
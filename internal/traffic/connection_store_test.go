@@ -0,0 +1,91 @@
+package traffic
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// fakeConnectionStore is a minimal in-memory ConnectionStore. It does not
+// implement ConnectionCheckpointer or HistoryStreamer.
+type fakeConnectionStore struct {
+	mu    sync.Mutex
+	saved []*pb.Connection
+	done  chan struct{}
+}
+
+func newFakeConnectionStore() *fakeConnectionStore {
+	return &fakeConnectionStore{done: make(chan struct{}, 16)}
+}
+
+func (f *fakeConnectionStore) SaveConnection(_ context.Context, conn *pb.Connection) error {
+	f.mu.Lock()
+	f.saved = append(f.saved, conn)
+	f.mu.Unlock()
+	f.done <- struct{}{}
+	return nil
+}
+
+func (f *fakeConnectionStore) QueryConnections(context.Context, QueryParams) ([]*pb.HistoricalConnection, int32, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeConnectionStore) GetAggregates(context.Context, AggregateParams) ([]*pb.TrafficAggregate, error) {
+	return nil, nil
+}
+
+func (f *fakeConnectionStore) Cleanup(context.Context, int) (int64, error) { return 0, nil }
+
+func (f *fakeConnectionStore) HealthCheck(context.Context) error { return nil }
+
+func newStoreTestCollector(t *testing.T, store ConnectionStore) *Collector {
+	t.Helper()
+	c := newTestCollector()
+	c.config.CheckpointAge = time.Minute
+	c.store = store
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	t.Cleanup(c.cancel)
+	return c
+}
+
+func TestCollector_PersistsThroughConnectionStore(t *testing.T) {
+	store := newFakeConnectionStore()
+	c := newStoreTestCollector(t, store)
+	c.conntrackSeen["bob-container"] = true // conntrack owns bob; eBPF copy is skipped
+
+	c.persistClosedFlows([]*pb.Connection{
+		{Id: "a", ContainerName: "alice-container"},
+		{Id: "b", ContainerName: "bob-container"},
+	})
+
+	select {
+	case <-store.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SaveConnection was not called")
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.saved) != 1 || store.saved[0].Id != "a" {
+		t.Fatalf("saved = %v, want only alice's flow", store.saved)
+	}
+}
+
+func TestCollector_OptionalStoreCapabilities(t *testing.T) {
+	c := newStoreTestCollector(t, newFakeConnectionStore())
+	c.connections["k"] = &pb.Connection{Id: "k", FirstSeen: timestamppb.Now()}
+	c.openSince["k"] = openFlow{since: time.Now().Add(-time.Hour)}
+
+	// No ConnectionCheckpointer: the pass is a no-op rather than a panic.
+	if n, err := c.checkpointOpenConnections(context.Background()); n != 0 || err != nil {
+		t.Errorf("checkpoint without a ConnectionCheckpointer = %d, %v", n, err)
+	}
+
+	if _, err := c.Replay(context.Background(), ReplayConfig{Speed: 1}); err == nil {
+		t.Error("Replay should fail on a store without HistoryStreamer")
+	}
+}
@@ -2,6 +2,8 @@ package traffic
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 )
 
@@ -42,7 +44,8 @@ type ConntrackEvent struct {
 	// Type indicates the event type (new, update, destroy)
 	Type ConntrackEventType
 
-	// Protocol is the connection protocol (tcp, udp, icmp)
+	// Protocol is the connection protocol (tcp, udp, icmp, sctp, icmpv6,
+	// dccp, udplite, gre)
 	Protocol string
 
 	// SrcIP is the source IP address
@@ -57,6 +60,19 @@ type ConntrackEvent struct {
 	// DstPort is the destination port (0 for ICMP)
 	DstPort uint16
 
+	// ReplySrcIP is the source address of the reply tuple: the host that
+	// actually answers. It differs from DstIP when the destination was
+	// rewritten, e.g. by a passthrough route's DNAT to a container. Empty
+	// when the event didn't carry a reply tuple.
+	ReplySrcIP string
+
+	// ICMPType, ICMPCode and ICMPID identify an ICMP or ICMPv6 flow in
+	// place of ports: the type and code of its first packet and the echo
+	// identifier.
+	ICMPType uint8
+	ICMPCode uint8
+	ICMPID   uint16
+
 	// State is the TCP connection state (empty for UDP/ICMP)
 	State string
 
@@ -77,6 +93,84 @@ type ConntrackEvent struct {
 
 	// Timestamp is when the event was received
 	Timestamp time.Time
+
+	// Zone is the conntrack zone the flow belongs to (0 = default). Flows
+	// in different zones may share an identical tuple.
+	Zone uint16
+
+	// Mark is the flow's conntrack mark (0 = unmarked). Host rules can
+	// mark each container's flows so they are attributed by mark rather
+	// than by address; see docs/TRAFFIC-CONNTRACK-MARKS.md.
+	Mark uint32
+}
+
+// Key identifies the connection across events. Conntrack IDs are only
+// meaningful within a zone, so flows outside the default zone are
+// qualified with it; default-zone keys stay the bare ID.
+func (e *ConntrackEvent) Key() string {
+	if e.Zone == 0 {
+		return e.ID
+	}
+	return fmt.Sprintf("z%d/%s", e.Zone, e.ID)
+}
+
+// tuple is the flow's protocol and original-direction addresses, which
+// stay fixed for its lifetime. ICMP flows have no ports; two pings
+// between the same hosts differ in their identifier.
+func (e *ConntrackEvent) tuple() string {
+	if isICMP(e.Protocol) {
+		return fmt.Sprintf("%s %s>%s type %d code %d id %d", e.Protocol, e.SrcIP, e.DstIP, e.ICMPType, e.ICMPCode, e.ICMPID)
+	}
+	return fmt.Sprintf("%s %s:%d>%s:%d", e.Protocol, e.SrcIP, e.SrcPort, e.DstIP, e.DstPort)
+}
+
+// isICMP reports whether protocol, as in ConntrackEvent.Protocol, is ICMP
+// or ICMPv6.
+func isICMP(protocol string) bool {
+	return protocol == "icmp" || protocol == "icmpv6"
+}
+
+// SnapshotFilter narrows a SnapshotFunc dump. The zero value passes
+// every flow.
+type SnapshotFilter struct {
+	// Zones, when non-empty, passes only flows in these conntrack zones.
+	Zones []uint16
+
+	// Protocols, when non-empty, passes only these protocols ("tcp", "udp").
+	Protocols []string
+}
+
+// Match reports whether e passes the filter.
+func (f SnapshotFilter) Match(e *ConntrackEvent) bool {
+	if len(f.Zones) > 0 && !slices.Contains(f.Zones, e.Zone) {
+		return false
+	}
+	return len(f.Protocols) == 0 || slices.Contains(f.Protocols, e.Protocol)
+}
+
+// ConntrackOption configures NewConntrackMonitor.
+type ConntrackOption func(*conntrackOptions)
+
+type conntrackOptions struct {
+	netnsPath string
+	netnsFD   int
+	hasFD     bool
+}
+
+// WithNetNS monitors the conntrack table of the network namespace at path
+// (/run/netns/<name>, /proc/<pid>/ns/net, ...) instead of the host's, for
+// topologies where containers have their own netns and conntrack table,
+// whose flows the host table never sees. Entering a namespace needs
+// CAP_SYS_ADMIN.
+func WithNetNS(path string) ConntrackOption {
+	return func(o *conntrackOptions) { o.netnsPath = path }
+}
+
+// WithNetNSFD is WithNetNS for an open namespace file. The monitor keeps
+// a duplicate, so the caller may close fd once NewConntrackMonitor
+// returns.
+func WithNetNSFD(fd int) ConntrackOption {
+	return func(o *conntrackOptions) { o.netnsFD, o.hasFD = fd, true }
 }
 
 // ConntrackMonitor defines the interface for connection tracking
@@ -84,9 +178,15 @@ type ConntrackMonitor interface {
 	// Events returns a channel of conntrack events
 	Events() <-chan *ConntrackEvent
 
-	// Snapshot returns all current connections
+	// Snapshot returns all current connections. It holds the whole table
+	// in memory at once (tens of MB on a busy host); prefer SnapshotFunc.
 	Snapshot() ([]*ConntrackEvent, error)
 
+	// SnapshotFunc streams the current connections matching filter to fn,
+	// one at a time, so memory stays flat however large the table is. A
+	// non-nil error from fn stops the dump and is returned.
+	SnapshotFunc(filter SnapshotFilter, fn func(*ConntrackEvent) error) error
+
 	// Close stops monitoring and releases resources
 	Close() error
 }
@@ -4,16 +4,21 @@ package traffic
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"log"
+	"os"
+	"runtime"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/footprintai/containarium/internal/safecast"
 
+	"github.com/mdlayher/netlink"
 	"github.com/ti-mo/conntrack"
 	"github.com/ti-mo/netfilter"
+	"golang.org/x/sys/unix"
 )
 
 // LinuxConntrackMonitor implements ConntrackMonitor using Linux netlink
@@ -24,22 +29,47 @@ type LinuxConntrackMonitor struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	lastDropWarn time.Time // Rate-limit drop warnings
+	netns        int       // Network namespace fd, -1 for the daemon's own
+	netnsName    string    // For error messages
 }
 
-// NewConntrackMonitor creates a new Linux conntrack monitor
-func NewConntrackMonitor() (ConntrackMonitor, error) {
-	conn, err := conntrack.Dial(nil)
+// NewConntrackMonitor creates a new Linux conntrack monitor. By default it
+// watches the daemon's (host) network namespace; WithNetNS or WithNetNSFD
+// point it at another one.
+func NewConntrackMonitor(opts ...ConntrackOption) (ConntrackMonitor, error) {
+	var o conntrackOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	netns, name, err := openNetNS(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg *netlink.Config
+	if netns >= 0 {
+		// netlink enters the namespace on a locked thread for the dial;
+		// the socket stays bound to it afterwards.
+		cfg = &netlink.Config{NetNS: netns}
+	}
+	conn, err := conntrack.Dial(cfg)
 	if err != nil {
+		if netns >= 0 {
+			_ = unix.Close(netns)
+			return nil, fmt.Errorf("failed to open conntrack in netns %s: %w", name, err)
+		}
 		return nil, fmt.Errorf("failed to open conntrack: %w", err)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
 	m := &LinuxConntrackMonitor{
-		conn:   conn,
-		events: make(chan *ConntrackEvent, 8192),
-		ctx:    ctx,
-		cancel: cancel,
+		conn:      conn,
+		events:    make(chan *ConntrackEvent, 8192),
+		ctx:       ctx,
+		cancel:    cancel,
+		netns:     netns,
+		netnsName: name,
 	}
 
 	// Start listening for events
@@ -48,6 +78,79 @@ func NewConntrackMonitor() (ConntrackMonitor, error) {
 	return m, nil
 }
 
+// openNetNS opens the namespace o names, returning -1 for the daemon's
+// own.
+func openNetNS(o conntrackOptions) (int, string, error) {
+	switch {
+	case o.hasFD:
+		fd, err := unix.FcntlInt(uintptr(o.netnsFD), unix.F_DUPFD_CLOEXEC, 0)
+		if err != nil {
+			return -1, "", fmt.Errorf("failed to duplicate netns fd %d: %w", o.netnsFD, err)
+		}
+		return fd, fmt.Sprintf("fd %d", o.netnsFD), nil
+	case o.netnsPath != "":
+		fd, err := unix.Open(o.netnsPath, unix.O_RDONLY|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return -1, "", fmt.Errorf("failed to open netns %s: %w", o.netnsPath, err)
+		}
+		return fd, o.netnsPath, nil
+	}
+	return -1, "", nil
+}
+
+// inNetNS runs fn on a thread in the network namespace nsFD; sockets fn
+// opens belong to that namespace for life. The switch happens on a
+// dedicated, locked goroutine: if its thread can't be moved back, the
+// goroutine exits still locked, and the runtime terminates the thread
+// rather than reuse it in the wrong namespace.
+func inNetNS(nsFD int, fn func() error) error {
+	if nsFD < 0 {
+		return fn()
+	}
+	errc := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+		orig, err := unix.Open("/proc/thread-self/ns/net", unix.O_RDONLY|unix.O_CLOEXEC, 0)
+		if err != nil {
+			runtime.UnlockOSThread()
+			errc <- fmt.Errorf("failed to open current netns: %w", err)
+			return
+		}
+		defer func() { _ = unix.Close(orig) }()
+		if err := unix.Setns(nsFD, unix.CLONE_NEWNET); err != nil {
+			runtime.UnlockOSThread()
+			errc <- fmt.Errorf("failed to enter netns: %w", err)
+			return
+		}
+		err = fn()
+		if serr := unix.Setns(orig, unix.CLONE_NEWNET); serr != nil {
+			log.Printf("Warning: failed to leave netns, retiring thread: %v", serr)
+		} else {
+			runtime.UnlockOSThread()
+		}
+		errc <- err
+	}()
+	return <-errc
+}
+
+// EnableConntrackAccountingInNetNS turns on conntrack byte/packet
+// accounting (nf_conntrack_acct) in the network namespace at path; the
+// setting is per namespace, so the host's sysctl doesn't cover it.
+func EnableConntrackAccountingInNetNS(path string) error {
+	ns, err := unix.Open(path, unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %w", path, err)
+	}
+	defer func() { _ = unix.Close(ns) }()
+	return inNetNS(ns, func() error {
+		// /proc/sys/net resolves against the opening thread's netns.
+		if err := os.WriteFile("/proc/sys/net/netfilter/nf_conntrack_acct", []byte("1"), 0); err != nil {
+			return fmt.Errorf("failed to enable conntrack accounting in netns %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
 // listen subscribes to conntrack events via netlink.
 //
 // We deliberately skip GroupCTUpdate. The kernel emits UPDATE events
@@ -63,7 +166,9 @@ func NewConntrackMonitor() (ConntrackMonitor, error) {
 // (to capture final byte counts for accounting). Interim byte counts
 // for active connections are available via Snapshot() on demand.
 // Skipping UPDATE drops the event volume by ~100x in typical
-// workloads without losing observability.
+// workloads without losing observability. The state-change timeline
+// (RecordStateChanges) takes intermediate TCP states from the periodic
+// snapshot for the same reason.
 func (m *LinuxConntrackMonitor) listen() {
 	evCh := make(chan conntrack.Event, 8192)
 
@@ -100,13 +205,20 @@ func (m *LinuxConntrackMonitor) processEvent(ev conntrack.Event) {
 	}
 
 	event := &ConntrackEvent{
-		ID:        fmt.Sprintf("%d", flow.ID),
-		Protocol:  protoToString(flow.TupleOrig.Proto.Protocol),
-		SrcIP:     flow.TupleOrig.IP.SourceAddress.String(),
-		SrcPort:   flow.TupleOrig.Proto.SourcePort,
-		DstIP:     flow.TupleOrig.IP.DestinationAddress.String(),
-		DstPort:   flow.TupleOrig.Proto.DestinationPort,
-		Timestamp: time.Now(),
+		ID:         fmt.Sprintf("%d", flow.ID),
+		Protocol:   protoToString(flow.TupleOrig.Proto.Protocol),
+		SrcIP:      flow.TupleOrig.IP.SourceAddress.String(),
+		SrcPort:    flow.TupleOrig.Proto.SourcePort,
+		DstIP:      flow.TupleOrig.IP.DestinationAddress.String(),
+		DstPort:    flow.TupleOrig.Proto.DestinationPort,
+		ICMPType:   flow.TupleOrig.Proto.ICMPType,
+		ICMPCode:   flow.TupleOrig.Proto.ICMPCode,
+		ICMPID:     flow.TupleOrig.Proto.ICMPID,
+		Timeout:    safecast.I32FromU32(flow.Timeout),
+		Timestamp:  time.Now(),
+		Zone:       flow.Zone,
+		Mark:       flow.Mark,
+		ReplySrcIP: replySource(flow),
 	}
 
 	// Set event type
@@ -129,10 +241,7 @@ func (m *LinuxConntrackMonitor) processEvent(ev conntrack.Event) {
 		event.PacketsReply = safecast.I64FromU64(flow.CountersReply.Packets)
 	}
 
-	// Get TCP state
-	if flow.ProtoInfo.TCP != nil {
-		event.State = tcpStateToString(flow.ProtoInfo.TCP.State)
-	}
+	event.State = flowState(flow.ProtoInfo)
 
 	// Get timeout
 	if flow.Timeout > 0 {
@@ -157,59 +266,192 @@ func (m *LinuxConntrackMonitor) Events() <-chan *ConntrackEvent {
 	return m.events
 }
 
-// Snapshot returns all current connections from the conntrack table
+// Snapshot returns all current connections from the conntrack table.
+// The whole table is materialized as one slice, which on a busy host
+// runs to hundreds of thousands of events; the collector uses
+// SnapshotFunc instead, and this stays for callers that want a slice.
 func (m *LinuxConntrackMonitor) Snapshot() ([]*ConntrackEvent, error) {
-	// Create a separate connection for querying (can't use event listener conn for queries)
+	var result []*ConntrackEvent
+	err := m.SnapshotFunc(SnapshotFilter{}, func(event *ConntrackEvent) error {
+		result = append(result, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// snapshotRecvBuffer holds one netlink datagram of a dump. The kernel
+// fills dump replies up to roughly 32KB, so this never truncates.
+const snapshotRecvBuffer = 64 * 1024
+
+// SnapshotFunc streams the conntrack table to fn one flow at a time.
+//
+// conntrack.Conn.Dump (and the netlink library beneath it) reads the
+// entire multi-part reply before returning, so this talks to a netlink
+// socket of its own and decodes each datagram as it arrives. Memory is
+// bounded by one receive buffer plus whatever fn keeps.
+func (m *LinuxConntrackMonitor) SnapshotFunc(filter SnapshotFilter, fn func(*ConntrackEvent) error) error {
+	// Queries need their own socket; the event listener's conn is
+	// subscribed to multicast groups.
 	m.queryMu.Lock()
 	defer m.queryMu.Unlock()
 
-	queryConn, err := conntrack.Dial(nil)
+	// A netlink socket queries the namespace it was created in.
+	fd := -1
+	err := inNetNS(m.netns, func() (err error) {
+		fd, err = unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_NETFILTER)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to open conntrack for query: %w", err)
+		if m.netns >= 0 {
+			return fmt.Errorf("failed to open conntrack for query in netns %s: %w", m.netnsName, err)
+		}
+		return fmt.Errorf("failed to open conntrack for query: %w", err)
 	}
-	defer func() { _ = queryConn.Close() }()
+	defer func() { _ = unix.Close(fd) }()
 
-	flows, err := queryConn.Dump(nil)
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return fmt.Errorf("failed to bind conntrack query socket: %w", err)
+	}
+	req, err := dumpRequest()
 	if err != nil {
-		return nil, fmt.Errorf("failed to dump conntrack: %w", err)
+		return fmt.Errorf("failed to build conntrack dump request: %w", err)
+	}
+	if err := unix.Sendto(fd, req, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return fmt.Errorf("failed to dump conntrack: %w", err)
 	}
 
-	var result []*ConntrackEvent
-	for _, flow := range flows {
-		if !flow.TupleOrig.IP.SourceAddress.IsValid() {
+	buf := make([]byte, snapshotRecvBuffer)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err == unix.EINTR {
 			continue
 		}
-
-		event := &ConntrackEvent{
-			ID:           fmt.Sprintf("%d", flow.ID),
-			Type:         ConntrackEventUpdate,
-			Protocol:     protoToString(flow.TupleOrig.Proto.Protocol),
-			SrcIP:        flow.TupleOrig.IP.SourceAddress.String(),
-			SrcPort:      flow.TupleOrig.Proto.SourcePort,
-			DstIP:        flow.TupleOrig.IP.DestinationAddress.String(),
-			DstPort:      flow.TupleOrig.Proto.DestinationPort,
-			BytesOrig:    safecast.I64FromU64(flow.CountersOrig.Bytes),
-			BytesReply:   safecast.I64FromU64(flow.CountersReply.Bytes),
-			PacketsOrig:  safecast.I64FromU64(flow.CountersOrig.Packets),
-			PacketsReply: safecast.I64FromU64(flow.CountersReply.Packets),
-			Timeout:      safecast.I32FromU32(flow.Timeout),
-			Timestamp:    time.Now(),
+		if err != nil {
+			return fmt.Errorf("failed to dump conntrack: %w", err)
 		}
-
-		if flow.ProtoInfo.TCP != nil {
-			event.State = tcpStateToString(flow.ProtoInfo.TCP.State)
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return fmt.Errorf("failed to parse conntrack dump: %w", err)
+		}
+		for _, msg := range msgs {
+			switch msg.Header.Type {
+			case unix.NLMSG_DONE:
+				return nil
+			case unix.NLMSG_ERROR:
+				if errno := dumpErrno(msg.Data); errno != 0 {
+					return fmt.Errorf("failed to dump conntrack: %w", errno)
+				}
+				continue
+			}
+
+			event, ok := dumpEvent(msg)
+			if !ok || !filter.Match(event) {
+				continue
+			}
+			if err := fn(event); err != nil {
+				return err
+			}
 		}
+	}
+}
 
-		result = append(result, event)
+// dumpRequest is the netlink request for a conntrack table dump of both
+// address families.
+func dumpRequest() ([]byte, error) {
+	const ctGet = 1 // IPCTNL_MSG_CT_GET
+	req, err := netfilter.MarshalNetlink(
+		netfilter.Header{
+			SubsystemID: netfilter.NFSubsysCTNetlink,
+			MessageType: netfilter.MessageType(ctGet),
+			Family:      netfilter.ProtoUnspec,
+			Flags:       netlink.Request | netlink.Dump,
+		},
+		nil)
+	if err != nil {
+		return nil, err
 	}
+	req.Header.Length = uint32(unix.NLMSG_HDRLEN + len(req.Data))
+	req.Header.Sequence = 1
+	return req.MarshalBinary()
+}
 
-	return result, nil
+// dumpErrno is the errno carried by an NLMSG_ERROR message; zero is an
+// acknowledgement.
+func dumpErrno(data []byte) syscall.Errno {
+	if len(data) < 4 {
+		return syscall.EINVAL
+	}
+	code := int32(binary.NativeEndian.Uint32(data[:4]))
+	return syscall.Errno(-code)
+}
+
+// dumpEvent decodes one dump reply into a ConntrackEvent. ok is false for
+// messages that aren't flows or have no usable source address.
+func dumpEvent(msg syscall.NetlinkMessage) (*ConntrackEvent, bool) {
+	var ev conntrack.Event
+	err := ev.Unmarshal(netlink.Message{
+		Header: netlink.Header{
+			Length:   msg.Header.Len,
+			Type:     netlink.HeaderType(msg.Header.Type),
+			Flags:    netlink.HeaderFlags(msg.Header.Flags),
+			Sequence: msg.Header.Seq,
+			PID:      msg.Header.Pid,
+		},
+		Data: msg.Data,
+	})
+	if err != nil || ev.Flow == nil || !ev.Flow.TupleOrig.IP.SourceAddress.IsValid() {
+		return nil, false
+	}
+	return flowEvent(ev.Flow), true
+}
+
+// flowEvent converts a dumped flow into an update event stamped now.
+func flowEvent(flow *conntrack.Flow) *ConntrackEvent {
+	event := &ConntrackEvent{
+		ID:           fmt.Sprintf("%d", flow.ID),
+		Type:         ConntrackEventUpdate,
+		Protocol:     protoToString(flow.TupleOrig.Proto.Protocol),
+		SrcIP:        flow.TupleOrig.IP.SourceAddress.String(),
+		SrcPort:      flow.TupleOrig.Proto.SourcePort,
+		DstIP:        flow.TupleOrig.IP.DestinationAddress.String(),
+		DstPort:      flow.TupleOrig.Proto.DestinationPort,
+		ICMPType:     flow.TupleOrig.Proto.ICMPType,
+		ICMPCode:     flow.TupleOrig.Proto.ICMPCode,
+		ICMPID:       flow.TupleOrig.Proto.ICMPID,
+		BytesOrig:    safecast.I64FromU64(flow.CountersOrig.Bytes),
+		BytesReply:   safecast.I64FromU64(flow.CountersReply.Bytes),
+		PacketsOrig:  safecast.I64FromU64(flow.CountersOrig.Packets),
+		PacketsReply: safecast.I64FromU64(flow.CountersReply.Packets),
+		Timeout:      safecast.I32FromU32(flow.Timeout),
+		Timestamp:    time.Now(),
+		Zone:         flow.Zone,
+		Mark:         flow.Mark,
+		ReplySrcIP:   replySource(flow),
+	}
+
+	event.State = flowState(flow.ProtoInfo)
+	return event
+}
+
+// replySource is the source address of the flow's reply tuple, or "" when
+// the event doesn't carry one.
+func replySource(flow *conntrack.Flow) string {
+	if !flow.TupleReply.IP.SourceAddress.IsValid() {
+		return ""
+	}
+	return flow.TupleReply.IP.SourceAddress.String()
 }
 
 // Close stops monitoring and closes the connection
 func (m *LinuxConntrackMonitor) Close() error {
 	m.cancel()
 	close(m.events)
+	if m.netns >= 0 {
+		_ = unix.Close(m.netns)
+	}
 	return m.conn.Close()
 }
 
@@ -222,11 +464,35 @@ func protoToString(proto uint8) string {
 		return "udp"
 	case syscall.IPPROTO_ICMP:
 		return "icmp"
+	case syscall.IPPROTO_SCTP:
+		return "sctp"
+	case syscall.IPPROTO_ICMPV6:
+		return "icmpv6"
+	case syscall.IPPROTO_DCCP:
+		return "dccp"
+	case syscall.IPPROTO_UDPLITE:
+		return "udplite"
+	case syscall.IPPROTO_GRE:
+		return "gre"
 	default:
 		return fmt.Sprintf("%d", proto)
 	}
 }
 
+// flowState is the state of a connection-oriented flow (TCP, SCTP or
+// DCCP), "" for the rest.
+func flowState(info conntrack.ProtoInfo) string {
+	switch {
+	case info.TCP != nil:
+		return tcpStateToString(info.TCP.State)
+	case info.SCTP != nil:
+		return sctpStateToString(info.SCTP.State)
+	case info.DCCP != nil:
+		return dccpStateToString(info.DCCP.State)
+	}
+	return ""
+}
+
 // tcpStateToString converts a TCP state number to string
 func tcpStateToString(state uint8) string {
 	states := map[uint8]string{
@@ -246,3 +512,39 @@ func tcpStateToString(state uint8) string {
 	}
 	return "UNKNOWN"
 }
+
+// sctpStateToString converts an SCTP conntrack state number to string.
+func sctpStateToString(state uint8) string {
+	states := map[uint8]string{
+		1: "SCTP_CLOSED",
+		2: "SCTP_COOKIE_WAIT",
+		3: "SCTP_COOKIE_ECHOED",
+		4: "SCTP_ESTABLISHED",
+		5: "SCTP_SHUTDOWN_SENT",
+		6: "SCTP_SHUTDOWN_RECD",
+		7: "SCTP_SHUTDOWN_ACK_SENT",
+		8: "SCTP_HEARTBEAT_SENT",
+		9: "SCTP_HEARTBEAT_ACKED",
+	}
+	if s, ok := states[state]; ok {
+		return s
+	}
+	return "UNKNOWN"
+}
+
+// dccpStateToString converts a DCCP conntrack state number to string.
+func dccpStateToString(state uint8) string {
+	states := map[uint8]string{
+		1: "DCCP_REQUEST",
+		2: "DCCP_RESPOND",
+		3: "DCCP_PARTOPEN",
+		4: "DCCP_OPEN",
+		5: "DCCP_CLOSEREQ",
+		6: "DCCP_CLOSING",
+		7: "DCCP_TIMEWAIT",
+	}
+	if s, ok := states[state]; ok {
+		return s
+	}
+	return "UNKNOWN"
+}
@@ -0,0 +1,84 @@
+//go:build linux
+
+package traffic
+
+import (
+	"errors"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/ti-mo/conntrack"
+	"golang.org/x/sys/unix"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestProtoToString_RoundTripsToEnum(t *testing.T) {
+	for _, tc := range []struct {
+		proto uint8
+		want  pb.Protocol
+	}{
+		{syscall.IPPROTO_TCP, pb.Protocol_PROTOCOL_TCP},
+		{syscall.IPPROTO_UDP, pb.Protocol_PROTOCOL_UDP},
+		{syscall.IPPROTO_ICMP, pb.Protocol_PROTOCOL_ICMP},
+		{syscall.IPPROTO_SCTP, pb.Protocol_PROTOCOL_SCTP},
+		{syscall.IPPROTO_ICMPV6, pb.Protocol_PROTOCOL_ICMPV6},
+		{syscall.IPPROTO_DCCP, pb.Protocol_PROTOCOL_DCCP},
+		{syscall.IPPROTO_UDPLITE, pb.Protocol_PROTOCOL_UDPLITE},
+		{syscall.IPPROTO_GRE, pb.Protocol_PROTOCOL_GRE},
+		{syscall.IPPROTO_ESP, pb.Protocol_PROTOCOL_UNSPECIFIED},
+	} {
+		if got := protoStringToEnum(protoToString(tc.proto)); got != tc.want {
+			t.Errorf("protocol %d = %v, want %v", tc.proto, got, tc.want)
+		}
+	}
+}
+
+func TestFlowState(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		info conntrack.ProtoInfo
+		want pb.ConnectionState
+	}{
+		{"tcp", conntrack.ProtoInfo{TCP: &conntrack.ProtoInfoTCP{State: 3}}, pb.ConnectionState_CONNECTION_STATE_ESTABLISHED},
+		{"sctp cookie wait", conntrack.ProtoInfo{SCTP: &conntrack.ProtoInfoSCTP{State: 2}}, pb.ConnectionState_CONNECTION_STATE_SYN_SENT},
+		{"sctp established", conntrack.ProtoInfo{SCTP: &conntrack.ProtoInfoSCTP{State: 4}}, pb.ConnectionState_CONNECTION_STATE_ESTABLISHED},
+		{"sctp shutdown", conntrack.ProtoInfo{SCTP: &conntrack.ProtoInfoSCTP{State: 6}}, pb.ConnectionState_CONNECTION_STATE_FIN_WAIT},
+		{"sctp closed", conntrack.ProtoInfo{SCTP: &conntrack.ProtoInfoSCTP{State: 1}}, pb.ConnectionState_CONNECTION_STATE_CLOSED},
+		{"dccp open", conntrack.ProtoInfo{DCCP: &conntrack.ProtoInfoDCCP{State: 4}}, pb.ConnectionState_CONNECTION_STATE_ESTABLISHED},
+		{"connectionless", conntrack.ProtoInfo{}, pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED},
+	} {
+		if got := stateStringToEnum(flowState(tc.info)); got != tc.want {
+			t.Errorf("%s: state = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestNewConntrackMonitor_MissingNetNS(t *testing.T) {
+	_, err := NewConntrackMonitor(WithNetNS("/run/netns/no-such-ns"))
+	if err == nil || !strings.Contains(err.Error(), "/run/netns/no-such-ns") {
+		t.Fatalf("err = %v, want one naming the netns", err)
+	}
+}
+
+func TestInNetNS_OwnNamespace(t *testing.T) {
+	ns, err := unix.Open("/proc/self/ns/net", unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		t.Skipf("no netns file: %v", err)
+	}
+	defer func() { _ = unix.Close(ns) }()
+
+	ran := false
+	err = inNetNS(ns, func() error { ran = true; return nil })
+	if errors.Is(err, unix.EPERM) {
+		t.Skip("entering a netns needs CAP_SYS_ADMIN")
+	}
+	if err != nil || !ran {
+		t.Fatalf("inNetNS = %v, ran %v", err, ran)
+	}
+	want := errors.New("boom")
+	if err := inNetNS(ns, func() error { return want }); !errors.Is(err, want) {
+		t.Errorf("inNetNS err = %v, want fn's error", err)
+	}
+}
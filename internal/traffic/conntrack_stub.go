@@ -8,10 +8,16 @@ type stubConntrackMonitor struct {
 }
 
 // NewConntrackMonitor returns an error on non-Linux platforms
-func NewConntrackMonitor() (ConntrackMonitor, error) {
+func NewConntrackMonitor(...ConntrackOption) (ConntrackMonitor, error) {
 	return nil, ErrNotSupported
 }
 
+// EnableConntrackAccountingInNetNS returns an error on non-Linux
+// platforms.
+func EnableConntrackAccountingInNetNS(string) error {
+	return ErrNotSupported
+}
+
 // Events returns an empty channel (stub)
 func (m *stubConntrackMonitor) Events() <-chan *ConntrackEvent {
 	return m.events
@@ -22,6 +28,11 @@ func (m *stubConntrackMonitor) Snapshot() ([]*ConntrackEvent, error) {
 	return nil, ErrNotSupported
 }
 
+// SnapshotFunc returns ErrNotSupported (stub)
+func (m *stubConntrackMonitor) SnapshotFunc(SnapshotFilter, func(*ConntrackEvent) error) error {
+	return ErrNotSupported
+}
+
 // Close does nothing (stub)
 func (m *stubConntrackMonitor) Close() error {
 	return nil
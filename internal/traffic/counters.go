@@ -0,0 +1,120 @@
+package traffic
+
+import (
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ContainerTotals is a container's traffic since the daemon started,
+// from its side: sent is what the container put on the wire. Every field
+// only grows, so the totals can back Prometheus counters.
+type ContainerTotals struct {
+	BytesSent       int64
+	BytesReceived   int64
+	PacketsSent     int64
+	PacketsReceived int64
+	// Connections counts the flows seen opening.
+	Connections int64
+	// BlockedConnections counts attempts the network policy dropped.
+	BlockedConnections int64
+}
+
+// flowCounters accumulates ContainerTotals from per-flow observations.
+// Each flow's counters are cumulative, so only the growth since the
+// flow's previous observation is added. Its zero value is ready to use;
+// the Collector guards it with c.mu.
+type flowCounters struct {
+	flows  map[string]countedFlow
+	totals map[string]*ContainerTotals
+}
+
+// countedFlow is a flow's counters as last added to its container's
+// totals.
+type countedFlow struct {
+	container, tuple             string
+	sent, received               int64
+	packetsSent, packetsReceived int64
+}
+
+// add adds conn's growth since the flow key was last seen. tuple tells a
+// new flow from a conntrack ID reused for another one; counters that went
+// backwards also start a new flow.
+func (f *flowCounters) add(key, tuple string, conn *pb.Connection) {
+	if f.flows == nil {
+		f.flows = make(map[string]countedFlow)
+	}
+	t := f.total(conn.ContainerName)
+	prev, ok := f.flows[key]
+	if !ok || prev.tuple != tuple || prev.container != conn.ContainerName ||
+		conn.BytesSent < prev.sent || conn.BytesReceived < prev.received ||
+		conn.PacketsSent < prev.packetsSent || conn.PacketsReceived < prev.packetsReceived {
+		prev = countedFlow{container: conn.ContainerName, tuple: tuple}
+		t.Connections++
+	}
+	t.BytesSent += conn.BytesSent - prev.sent
+	t.BytesReceived += conn.BytesReceived - prev.received
+	t.PacketsSent += conn.PacketsSent - prev.packetsSent
+	t.PacketsReceived += conn.PacketsReceived - prev.packetsReceived
+	prev.sent, prev.received = conn.BytesSent, conn.BytesReceived
+	prev.packetsSent, prev.packetsReceived = conn.PacketsSent, conn.PacketsReceived
+	f.flows[key] = prev
+}
+
+// block counts a connection attempt the network policy dropped.
+func (f *flowCounters) block(container string) {
+	f.total(container).BlockedConnections++
+}
+
+// total returns container's totals, creating them on first use.
+func (f *flowCounters) total(container string) *ContainerTotals {
+	if f.totals == nil {
+		f.totals = make(map[string]*ContainerTotals)
+	}
+	t := f.totals[container]
+	if t == nil {
+		t = &ContainerTotals{}
+		f.totals[container] = t
+	}
+	return t
+}
+
+// forget drops the flow key once it is closed; its counters stay in the
+// totals.
+func (f *flowCounters) forget(key string) {
+	delete(f.flows, key)
+}
+
+// prune forgets the flows keep rejects: those gone from the source that
+// reported them without a close being seen.
+func (f *flowCounters) prune(keep func(key string) bool) {
+	for key := range f.flows {
+		if !keep(key) {
+			delete(f.flows, key)
+		}
+	}
+}
+
+// ContainerTotals returns each container's traffic since the daemon
+// started. A container the host no longer lists and with no open flow is
+// dropped first, so the result holds at most the host's containers plus
+// those with flows still draining.
+func (c *Collector) ContainerTotals() map[string]ContainerTotals {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cache.Ready() {
+		open := make(map[string]bool)
+		for _, flow := range c.counters.flows {
+			open[flow.container] = true
+		}
+		for name := range c.counters.totals {
+			if !open[name] && !c.cache.Listed(name) {
+				delete(c.counters.totals, name)
+			}
+		}
+	}
+	out := make(map[string]ContainerTotals, len(c.counters.totals))
+	for name, t := range c.counters.totals {
+		out[name] = *t
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+package traffic
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestContainerTotals_CountsFlowGrowth(t *testing.T) {
+	c := newStoreTestCollector(t, newFakeConnectionStore())
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	c.processConntrackEvent(dnsQuery(ConntrackEventNew, t0, 2, 30))
+	c.processConntrackEvent(dnsQuery(ConntrackEventUpdate, t0.Add(time.Second), 4, 120))
+	c.processConntrackEvent(dnsQuery(ConntrackEventDestroy, t0.Add(121*time.Second), 4, 0))
+
+	want := ContainerTotals{BytesSent: 120, BytesReceived: 240, PacketsSent: 2, PacketsReceived: 2, Connections: 1}
+	if got := c.ContainerTotals()["alice-container"]; got != want {
+		t.Fatalf("totals = %+v, want %+v", got, want)
+	}
+
+	// Conntrack hands ID 42 to the next lookup, then to a flow from
+	// another port without a DESTROY in between: both are new connections.
+	c.processConntrackEvent(dnsQuery(ConntrackEventNew, t0.Add(200*time.Second), 2, 30))
+	other := dnsQuery(ConntrackEventNew, t0.Add(201*time.Second), 2, 30)
+	other.SrcPort = 53001
+	c.processConntrackEvent(other)
+
+	want = ContainerTotals{BytesSent: 240, BytesReceived: 480, PacketsSent: 4, PacketsReceived: 4, Connections: 3}
+	if got := c.ContainerTotals()["alice-container"]; got != want {
+		t.Errorf("after ID reuse totals = %+v, want %+v", got, want)
+	}
+}
+
+func TestContainerTotals_IdleSplitCountsOnce(t *testing.T) {
+	c := newStoreTestCollector(t, newFakeConnectionStore())
+	c.config.UDPIdleTimeout = 30 * time.Second
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	event := dnsQuery(ConntrackEventNew, t0, 2, 110)
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{event}}
+	c.takeSnapshot()
+	event.Timestamp, event.Timeout = t0.Add(40*time.Second), 70
+	c.takeSnapshot()
+	c.processConntrackEvent(dnsQuery(ConntrackEventDestroy, t0.Add(110*time.Second), 2, 0))
+
+	want := ContainerTotals{BytesSent: 60, BytesReceived: 120, PacketsSent: 1, PacketsReceived: 1, Connections: 1}
+	if got := c.ContainerTotals()["alice-container"]; got != want {
+		t.Errorf("totals = %+v, want %+v", got, want)
+	}
+}
+
+func TestContainerTotals_DropsUnlistedContainers(t *testing.T) {
+	c := newStoreTestCollector(t, newFakeConnectionStore())
+	c.cache.apply([]incus.ContainerInfo{
+		{Name: "alice-container", IPAddress: "10.100.0.5"},
+		{Name: "bob-container", IPAddress: "10.100.0.6"},
+		{Name: "carol-container", IPAddress: "10.100.0.7"},
+	})
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, ip := range []string{"10.100.0.5", "10.100.0.6", "10.100.0.7"} {
+		event := dnsQuery(ConntrackEventNew, t0, 2, 30)
+		event.ID, event.SrcIP = string(rune('a'+i)), ip
+		c.processConntrackEvent(event)
+	}
+	closing := dnsQuery(ConntrackEventDestroy, t0.Add(time.Second), 2, 0)
+	closing.ID, closing.SrcIP = "b", "10.100.0.6"
+	c.processConntrackEvent(closing)
+
+	// bob and carol are deleted; carol's flow is still open.
+	c.cache.apply([]incus.ContainerInfo{{Name: "alice-container", IPAddress: "10.100.0.5"}})
+	got := c.ContainerTotals()
+	if _, ok := got["bob-container"]; ok {
+		t.Errorf("bob-container still exported: %+v", got)
+	}
+	if _, ok := got["alice-container"]; !ok {
+		t.Errorf("alice-container missing: %+v", got)
+	}
+	if _, ok := got["carol-container"]; !ok {
+		t.Errorf("carol-container dropped while its flow is open: %+v", got)
+	}
+}
+
+func TestMetricsHandler_ExportsCounters(t *testing.T) {
+	c := newStoreTestCollector(t, newFakeConnectionStore())
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	c.processConntrackEvent(dnsQuery(ConntrackEventNew, time.Now(), 4, 30))
+
+	rec := httptest.NewRecorder()
+	c.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics/traffic", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, want := range []string{
+		"# TYPE containarium_container_bytes_sent_total counter",
+		`containarium_container_bytes_sent_total{container="alice-container"} 120`,
+		`containarium_container_packets_received_total{container="alice-container"} 2`,
+		`containarium_container_connections_total{container="alice-container"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output lacks %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestReportBlockedEBPFFlow(t *testing.T) {
+	bus := events.NewBus()
+	sub := bus.Subscribe(&pb.SubscribeEventsRequest{
+		ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_TRAFFIC},
+	})
+	defer bus.Unsubscribe(sub.ID)
+	c := newStoreTestCollector(t, newFakeConnectionStore())
+	c.emitter = events.NewEmitter(bus)
+
+	now := time.Now()
+	c.ReportBlockedEBPFFlow(EBPFFlow{
+		ContainerName: "alice-container", ContainerIP: "10.100.0.5", Protocol: "tcp",
+		SrcIP: "10.100.0.5", DstIP: "203.0.113.7", DstPort: 6379, First: now, Last: now,
+	})
+
+	select {
+	case ev := <-sub.Events:
+		conn := ev.GetTrafficEvent().GetConnection()
+		if ev.GetTrafficEvent().GetType() != pb.TrafficEventType_TRAFFIC_EVENT_TYPE_BLOCKED || ev.ResourceId != "alice-container" {
+			t.Errorf("event = %v for %q, want BLOCKED for alice-container", ev.GetTrafficEvent().GetType(), ev.ResourceId)
+		}
+		if !conn.GetBlocked() || conn.GetDestIp() != "203.0.113.7" || conn.GetDestPort() != 6379 {
+			t.Errorf("connection = %v, want a blocked flow to 203.0.113.7:6379", conn)
+		}
+	default:
+		t.Fatal("no BLOCKED event emitted")
+	}
+	if got := c.ContainerTotals()["alice-container"].BlockedConnections; got != 1 {
+		t.Errorf("BlockedConnections = %d, want 1", got)
+	}
+
+	rec := httptest.NewRecorder()
+	c.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics/traffic", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if want := `containarium_container_blocked_connections_total{container="alice-container"} 1`; !strings.Contains(string(body), want) {
+		t.Errorf("metrics output lacks %q:\n%s", want, body)
+	}
+}
@@ -0,0 +1,161 @@
+package traffic
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/footprintai/containarium/internal/safecast"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ErrConnectionNotFound is returned by DescribeConnection when the ID does
+// not match any active connection of the container (it may have closed
+// since it was listed).
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// DescribeConnection attributes one active connection to the process that
+// owns its socket inside the container. Conntrack only sees addresses, so
+// this execs `ss -nap` in the container and matches the container-side
+// port (and the peer, when it is visible unNATed). It costs an exec round
+// trip per call, which is why it is on-demand rather than done per flow.
+//
+// The returned connection is a copy; the collector's live table is never
+// annotated. ProcessName/Pid stay empty if no socket matched — the
+// connection may have closed, or the process runs as another user in a
+// way ss cannot see. The lookup forces a snapshot rather than trust a
+// debounced one, so a connection that closed since it was listed is
+// reported as not found instead of matched against a stale socket.
+func (c *Collector) DescribeConnection(containerName, connectionID string) (*pb.Connection, error) {
+	c.RefreshNow()
+	var found *pb.Connection
+	for _, conn := range c.GetConnections(containerName) {
+		if conn.Id == connectionID {
+			found = conn
+			break
+		}
+	}
+	if found == nil {
+		return nil, ErrConnectionNotFound
+	}
+
+	var protoFlag string
+	switch found.Protocol {
+	case pb.Protocol_PROTOCOL_TCP:
+		protoFlag = "-t"
+	case pb.Protocol_PROTOCOL_UDP:
+		protoFlag = "-u"
+	default:
+		return nil, fmt.Errorf("process attribution is not supported for %s connections", found.Protocol)
+	}
+	if c.incusClient == nil {
+		return nil, fmt.Errorf("incus client not available")
+	}
+
+	stdout, stderr, err := c.incusClient.ExecWithOutput(containerName, []string{"ss", "-H", "-n", "-a", "-p", protoFlag})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sockets in %s: %w (stderr: %s)", containerName, err, strings.TrimSpace(stderr))
+	}
+
+	localPort, peerIP, peerPort := containerSideEndpoints(found)
+	described := proto.Clone(found).(*pb.Connection)
+	if name, pid, ok := parseSSProcess(stdout, localPort, peerIP, peerPort); ok {
+		described.ProcessName = name
+		described.Pid = pid
+	}
+	return described, nil
+}
+
+// containerSideEndpoints returns the container's own port and the remote
+// peer for conn, as they appear from inside the container. For egress the
+// container is the source; for ingress it is the destination.
+func containerSideEndpoints(conn *pb.Connection) (localPort uint32, peerIP string, peerPort uint32) {
+	if conn.Direction == pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS {
+		return conn.DestPort, conn.SourceIp, conn.SourcePort
+	}
+	return conn.SourcePort, conn.DestIp, conn.DestPort
+}
+
+// ssUsersRe pulls the first ("name",pid=N,...) tuple out of an ss
+// `users:((...),(...))` column.
+var ssUsersRe = regexp.MustCompile(`\("([^"]*)",pid=(\d+)`)
+
+// parseSSProcess finds the process owning the socket bound to localPort in
+// `ss -H -n -a -p` output. A socket whose peer also matches wins; failing
+// that, the first socket on the local port does (ingress through an incus
+// proxy device shows the proxy, not the real client, as the peer — and a
+// listener on that port belongs to the same process anyway).
+func parseSSProcess(output string, localPort uint32, peerIP string, peerPort uint32) (name string, pid int32, ok bool) {
+	var fallbackName string
+	var fallbackPID int32
+	var haveFallback bool
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		users := -1
+		for i, f := range fields {
+			if strings.HasPrefix(f, "users:") {
+				users = i
+				break
+			}
+		}
+		// Local and peer address are the two columns before users:. Lines
+		// without a users column (header, sockets of invisible processes)
+		// carry nothing to attribute.
+		if users < 2 {
+			continue
+		}
+		_, lport, lok := splitSSAddr(fields[users-2])
+		if !lok || lport != localPort {
+			continue
+		}
+		m := ssUsersRe.FindStringSubmatch(fields[users])
+		if m == nil {
+			continue
+		}
+		p, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		rip, rport, rok := splitSSAddr(fields[users-1])
+		if rok && rport == peerPort && rip == peerIP {
+			return m[1], safecast.I32(p), true
+		}
+		if !haveFallback {
+			fallbackName, fallbackPID, haveFallback = m[1], safecast.I32(p), true
+		}
+	}
+	return fallbackName, fallbackPID, haveFallback
+}
+
+// splitSSAddr splits an ss address column such as "10.100.0.5:443",
+// "[::1]:22", "[::ffff:10.0.0.1]:22" or "10.0.0.5%eth0:68". A wildcard
+// port ("*") reports ok=false.
+func splitSSAddr(addr string) (ip string, port uint32, ok bool) {
+	i := strings.LastIndex(addr, ":")
+	if i < 0 {
+		return "", 0, false
+	}
+	host, portStr := addr[:i], addr[i+1:]
+	p, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", 0, false
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if j := strings.Index(host, "%"); j >= 0 {
+		host = host[:j]
+	}
+	// ss shows IPv4 peers of dual-stack sockets as v4-mapped v6.
+	if parsed := net.ParseIP(host); parsed != nil {
+		if v4 := parsed.To4(); v4 != nil {
+			host = v4.String()
+		}
+	}
+	return host, safecast.U32FromUint(p), true
+}
@@ -0,0 +1,53 @@
+package traffic
+
+import (
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestParseSSProcess(t *testing.T) {
+	out := `LISTEN 0      4096   0.0.0.0:8080        0.0.0.0:*     users:(("node",pid=88,fd=20))
+ESTAB  0      0      10.100.0.5:8080     10.100.0.1:50122 users:(("node",pid=88,fd=24))
+ESTAB  0      0      10.100.0.5:51000    1.1.1.1:443      users:(("curl",pid=1234,fd=3),("curl",pid=1235,fd=3))
+ESTAB  0      0      10.100.0.5:51001    1.1.1.1:443
+ESTAB  0      0      [::ffff:10.100.0.5]:22 [::ffff:203.0.113.9]:61000 users:(("sshd",pid=501,fd=4))
+`
+	tests := []struct {
+		name      string
+		localPort uint32
+		peerIP    string
+		peerPort  uint32
+		wantName  string
+		wantPID   int32
+		wantOK    bool
+	}{
+		{"egress exact match", 51000, "1.1.1.1", 443, "curl", 1234, true},
+		{"ingress behind proxy falls back to local port", 8080, "198.51.100.7", 40000, "node", 88, true},
+		{"v4-mapped peer", 22, "203.0.113.9", 61000, "sshd", 501, true},
+		{"socket without users column", 51001, "1.1.1.1", 443, "", 0, false},
+		{"no socket on port", 9999, "1.1.1.1", 443, "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, pid, ok := parseSSProcess(out, tt.localPort, tt.peerIP, tt.peerPort)
+			if name != tt.wantName || pid != tt.wantPID || ok != tt.wantOK {
+				t.Errorf("parseSSProcess = (%q, %d, %v), want (%q, %d, %v)", name, pid, ok, tt.wantName, tt.wantPID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestContainerSideEndpoints(t *testing.T) {
+	conn := &pb.Connection{SourceIp: "10.100.0.5", SourcePort: 51000, DestIp: "1.1.1.1", DestPort: 443}
+
+	conn.Direction = pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+	if lp, ip, pp := containerSideEndpoints(conn); lp != 51000 || ip != "1.1.1.1" || pp != 443 {
+		t.Errorf("egress: got (%d, %s, %d)", lp, ip, pp)
+	}
+
+	conn.Direction = pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS
+	if lp, ip, pp := containerSideEndpoints(conn); lp != 443 || ip != "10.100.0.5" || pp != 51000 {
+		t.Errorf("ingress: got (%d, %s, %d)", lp, ip, pp)
+	}
+}
@@ -0,0 +1,85 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ErrDestinationQueryUnsupported is returned by QueryByDestination when the
+// traffic store cannot search across containers.
+var ErrDestinationQueryUnsupported = errors.New("the traffic store does not support queries by destination")
+
+// destContactMaxIPs bounds the addresses listed per container, so a wide
+// CIDR doesn't return every host in it.
+const destContactMaxIPs = 64
+
+// DestinationQueryParams filters QueryByDestination.
+type DestinationQueryParams struct {
+	// Destination is the address or network connections went to; a single
+	// address is a /32 (or /128) prefix.
+	Destination netip.Prefix
+	StartTime   time.Time
+	EndTime     time.Time
+	// DestPort filters by destination port (0 = any).
+	DestPort int
+}
+
+// ParseDestination parses an address or CIDR into the prefix
+// QueryByDestination matches. The prefix is masked, so "203.0.113.7/24"
+// means 203.0.113.0/24.
+func ParseDestination(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid destination %q: %w", s, err)
+		}
+		return p.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid destination %q: want an IP address or CIDR", s)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// DestinationQuerier is implemented by backends that can search every
+// container's history by destination, for questions like "which
+// containers talked to 203.0.113.7 last month?".
+type DestinationQuerier interface {
+	// QueryByDestination returns one DestinationContact per container with
+	// connections into params.Destination, most recently seen first.
+	QueryByDestination(ctx context.Context, params DestinationQueryParams) ([]*pb.DestinationContact, error)
+}
+
+// sortContacts orders contacts most recently seen first, then by name, and
+// sorts each one's addresses.
+func sortContacts(contacts []*pb.DestinationContact) {
+	for _, c := range contacts {
+		slices.Sort(c.DestIps)
+	}
+	slices.SortFunc(contacts, func(a, b *pb.DestinationContact) int {
+		if c := b.LastSeen.AsTime().Compare(a.LastSeen.AsTime()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ContainerName, b.ContainerName)
+	})
+}
+
+// QueryByDestination returns the containers that connected to
+// params.Destination.
+func (c *Collector) QueryByDestination(ctx context.Context, params DestinationQueryParams) ([]*pb.DestinationContact, error) {
+	q, ok := c.store.(DestinationQuerier)
+	if !ok {
+		return nil, ErrDestinationQueryUnsupported
+	}
+	return q.QueryByDestination(ctx, params)
+}
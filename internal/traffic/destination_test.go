@@ -0,0 +1,84 @@
+package traffic
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestParseDestination(t *testing.T) {
+	for in, want := range map[string]string{
+		"203.0.113.7":      "203.0.113.7/32",
+		"203.0.113.7/24":   "203.0.113.0/24",
+		" 198.51.100.0/24": "198.51.100.0/24",
+		"2001:db8::1":      "2001:db8::1/128",
+	} {
+		got, err := ParseDestination(in)
+		if err != nil || got.String() != want {
+			t.Errorf("ParseDestination(%q) = %v, %v; want %s", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "example.com", "203.0.113.0/33"} {
+		if _, err := ParseDestination(bad); err == nil {
+			t.Errorf("ParseDestination(%q): want an error", bad)
+		}
+	}
+}
+
+func TestMemoryStore_QueryByDestination(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(0)
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+
+	for _, c := range []*pb.Connection{
+		memConn("1", "alice-container", "203.0.113.7", 443, egress, base, false),
+		memConn("2", "alice-container", "203.0.113.9", 22, egress, base.Add(time.Hour), false),
+		memConn("3", "bob-container", "203.0.113.7", 443, egress, base.Add(2*time.Hour), false),
+		memConn("4", "carol-container", "198.51.100.1", 443, egress, base, false),
+	} {
+		if err := s.SaveConnection(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	window := DestinationQueryParams{StartTime: base.Add(-time.Hour), EndTime: base.Add(3 * time.Hour)}
+
+	p := window
+	p.Destination, _ = ParseDestination("203.0.113.0/24")
+	got, err := s.QueryByDestination(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ContainerName != "bob-container" || got[1].ContainerName != "alice-container" {
+		t.Fatalf("want bob then alice (most recent first), got %v", got)
+	}
+	alice := got[1]
+	if alice.Username != "alice" || alice.ConnectionCount != 2 || alice.BytesSent != 200 || alice.BytesReceived != 100 {
+		t.Errorf("alice totals = %v", alice)
+	}
+	if !alice.FirstSeen.AsTime().Equal(base) || !alice.LastSeen.AsTime().Equal(base.Add(time.Hour+time.Minute)) {
+		t.Errorf("alice first/last = %v / %v", alice.FirstSeen.AsTime(), alice.LastSeen.AsTime())
+	}
+	if !slices.Equal(alice.DestIps, []string{"203.0.113.7", "203.0.113.9"}) {
+		t.Errorf("alice dest IPs = %v", alice.DestIps)
+	}
+
+	p.DestPort = 22
+	if got, _ := s.QueryByDestination(ctx, p); len(got) != 1 || got[0].ContainerName != "alice-container" {
+		t.Errorf("port filter: %v", got)
+	}
+
+	p = window
+	p.Destination, _ = ParseDestination("203.0.113.7")
+	p.EndTime = base.Add(time.Minute)
+	if got, _ := s.QueryByDestination(ctx, p); len(got) != 1 || got[0].ContainerName != "alice-container" {
+		t.Errorf("single address in a narrow window: %v", got)
+	}
+
+	if _, err := s.QueryByDestination(ctx, window); err == nil {
+		t.Error("want an error without a destination")
+	}
+}
@@ -0,0 +1,109 @@
+package traffic
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestProcessConntrackEvent_ByteDirection(t *testing.T) {
+	tests := []struct {
+		name          string
+		event         *ConntrackEvent
+		wantContainer string
+		wantDirection pb.TrafficDirection
+		wantSent      int64
+		wantReceived  int64
+	}{
+		{
+			// alice's box fetches a page: it sends the small request (orig)
+			// and receives the large response (reply).
+			name: "container-initiated",
+			event: &ConntrackEvent{
+				ID: "1", Protocol: "tcp",
+				SrcIP: "10.100.0.5", SrcPort: 51000, DstIP: "203.0.113.9", DstPort: 443,
+				BytesOrig: 500, PacketsOrig: 5, BytesReply: 90000, PacketsReply: 70,
+			},
+			wantContainer: "alice-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS,
+			wantSent:      500,
+			wantReceived:  90000,
+		},
+		{
+			// An external client downloads from alice's box: the client's
+			// side is orig, so the container sent the reply bytes.
+			name: "externally-initiated",
+			event: &ConntrackEvent{
+				ID: "2", Protocol: "tcp",
+				SrcIP: "203.0.113.9", SrcPort: 40000, DstIP: "10.100.0.5", DstPort: 8080,
+				BytesOrig: 500, PacketsOrig: 5, BytesReply: 90000, PacketsReply: 70,
+			},
+			wantContainer: "alice-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS,
+			wantSent:      90000,
+			wantReceived:  500,
+		},
+		{
+			// Box to box: recorded once, from the side that opened it.
+			name: "container-to-container",
+			event: &ConntrackEvent{
+				ID: "3", Protocol: "tcp",
+				SrcIP: "10.100.0.6", SrcPort: 52000, DstIP: "10.100.0.5", DstPort: 5432,
+				BytesOrig: 500, PacketsOrig: 5, BytesReply: 90000, PacketsReply: 70,
+			},
+			wantContainer: "bob-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS,
+			wantSent:      500,
+			wantReceived:  90000,
+		},
+		{
+			// A client reaches alice's database through a passthrough
+			// route: it dialled the host, whose DNAT sent the flow on
+			// to the box, so only the reply tuple names the container.
+			name: "passthrough-dnat",
+			event: &ConntrackEvent{
+				ID: "4", Protocol: "tcp",
+				SrcIP: "198.51.100.20", SrcPort: 40000, DstIP: "192.0.2.10", DstPort: 5432,
+				ReplySrcIP: "10.100.0.5",
+				BytesOrig:  500, PacketsOrig: 5, BytesReply: 90000, PacketsReply: 70,
+			},
+			wantContainer: "alice-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS,
+			wantSent:      90000,
+			wantReceived:  500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, path := range []string{"event", "snapshot"} {
+				c := newTestCollector()
+				c.cache.ipToName["10.100.0.5"] = "alice-container"
+				c.cache.ipToName["10.100.0.6"] = "bob-container"
+				event := *tt.event
+				event.Type = ConntrackEventNew
+				event.Timestamp = time.Now()
+				if path == "event" {
+					c.processConntrackEvent(&event)
+				} else {
+					c.monitor = &snapshotMonitor{events: []*ConntrackEvent{&event}}
+					c.takeSnapshot()
+				}
+
+				conn, ok := c.connections[event.Key()]
+				if !ok {
+					t.Fatalf("%s: connection not recorded", path)
+				}
+				if conn.ContainerName != tt.wantContainer || conn.Direction != tt.wantDirection {
+					t.Errorf("%s: attributed to %s/%v, want %s/%v", path, conn.ContainerName, conn.Direction, tt.wantContainer, tt.wantDirection)
+				}
+				if conn.BytesSent != tt.wantSent || conn.BytesReceived != tt.wantReceived {
+					t.Errorf("%s: sent/received = %d/%d, want %d/%d", path, conn.BytesSent, conn.BytesReceived, tt.wantSent, tt.wantReceived)
+				}
+				if conn.PacketsSent+conn.PacketsReceived != 75 || (conn.BytesSent == 500) != (conn.PacketsSent == 5) {
+					t.Errorf("%s: packets %d/%d not oriented with bytes", path, conn.PacketsSent, conn.PacketsReceived)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// DNS visibility: destination IPs alone rarely say what a box talks to (a
+// CDN address serves thousands of sites), so the collector can follow the
+// query log of the dnsmasq Incus runs on the bridge and record each
+// container's lookups. Enable it on the network and point the daemon at
+// the file:
+//
+//	incus network set incusbr0 raw.dnsmasq "log-queries=extra
+//	log-facility=/var/log/containarium/dnsmasq.log"
+//	containarium daemon --traffic-dns-log /var/log/containarium/dnsmasq.log
+//
+// "extra" makes dnsmasq prefix every line with a per-query serial and the
+// client address, which is what ties a reply to the query that caused it.
+//
+// The log covers every box on the bridge, but only boxes opted in with
+// user.containarium.dns_log=true (`containarium traffic dns-logging`) have
+// their queries recorded; the rest are dropped as they are parsed.
+
+var (
+	// ErrDNSDisabled is returned by QueryDNSHistory when the collector is
+	// not following a resolver log (DNSLogPath unset).
+	ErrDNSDisabled = errors.New("DNS query logging is disabled (daemon --traffic-dns-log)")
+
+	// ErrDNSUnsupported is returned when the traffic store cannot keep DNS
+	// queries.
+	ErrDNSUnsupported = errors.New("the traffic store does not record DNS queries")
+)
+
+const (
+	// dnsQueryLinger is how long a query waits for further reply lines
+	// before it is recorded. dnsmasq logs a query's answers right after
+	// the upstream reply, so anything slower is a timeout.
+	dnsQueryLinger = 2 * time.Second
+
+	// dnsNameTTL is how long a resolved address is used to name a
+	// container's connections. Longer than typical record TTLs, because
+	// clients keep connections (and their own caches) well past them.
+	dnsNameTTL = time.Hour
+)
+
+// DNSQueryParams filters QueryDNSHistory.
+type DNSQueryParams struct {
+	ContainerName string
+	StartTime     time.Time
+	EndTime       time.Time
+	// QName matches queries whose name contains it, case-insensitively.
+	QName string
+	// AnswerIP matches queries that resolved to this address.
+	AnswerIP string
+	Limit    int
+}
+
+// dnsQueryLimit applies QueryDNSHistory's default and maximum page size.
+func dnsQueryLimit(limit int) int {
+	if limit <= 0 {
+		return 100
+	}
+	return min(limit, 1000)
+}
+
+// DNSRecorder is implemented by backends that can keep DNS queries. The
+// collector only uses it when CollectorConfig.DNSLogPath is set.
+type DNSRecorder interface {
+	// SaveDNSQuery records one completed query.
+	SaveDNSQuery(ctx context.Context, q *pb.DNSQuery) error
+	// QueryDNSHistory returns matching queries, newest first.
+	QueryDNSHistory(ctx context.Context, params DNSQueryParams) ([]*pb.DNSQuery, error)
+}
+
+// dnsmasqParser assembles dnsmasq "log-queries=extra" lines into queries.
+// A query line opens an entry keyed by its serial; reply, cached and
+// config lines add answers to it. dnsmasq writes no end marker, so an
+// entry is complete once it has been quiet for dnsQueryLinger.
+type dnsmasqParser struct {
+	pending map[string]*pendingDNSQuery
+}
+
+type pendingDNSQuery struct {
+	q    *pb.DNSQuery
+	last time.Time
+}
+
+func newDNSMasqParser() *dnsmasqParser {
+	return &dnsmasqParser{pending: make(map[string]*pendingDNSQuery)}
+}
+
+// Line consumes one log line read at now. Lines that are not part of a
+// query (DHCP, startup, queries logged without "extra") are ignored.
+func (p *dnsmasqParser) Line(line string, now time.Time) {
+	// Strip the syslog-style "Mar  1 12:00:00 dnsmasq[123]: " prefix.
+	if _, rest, ok := strings.Cut(line, "]: "); ok {
+		line = rest
+	}
+	fields := strings.Fields(line)
+	// <serial> <client>/<port> <verb> <name> <preposition> <value>
+	if len(fields) < 6 {
+		return
+	}
+	serial, client, verb, name, value := fields[0], fields[1], fields[2], fields[3], fields[5]
+	clientIP, _, ok := strings.Cut(client, "/")
+	if !ok || net.ParseIP(clientIP) == nil {
+		return
+	}
+	key := serial + " " + client
+
+	if qtype, ok := strings.CutPrefix(verb, "query["); ok {
+		p.pending[key] = &pendingDNSQuery{
+			q: &pb.DNSQuery{
+				ClientIp:  clientIP,
+				Qname:     strings.ToLower(strings.TrimSuffix(name, ".")),
+				Qtype:     strings.TrimSuffix(qtype, "]"),
+				Timestamp: timestamppb.New(now),
+			},
+			last: now,
+		}
+		return
+	}
+	pq, ok := p.pending[key]
+	if !ok {
+		return
+	}
+	switch verb {
+	case "reply", "cached", "config", "/etc/hosts":
+	default:
+		return // "forwarded", "validation", ...
+	}
+	pq.last = now
+	if net.ParseIP(value) != nil {
+		pq.q.Answers = append(pq.q.Answers, value)
+		return
+	}
+	// "<CNAME>" just continues the chain; NXDOMAIN, NODATA, NODATA-IPv6,
+	// SERVFAIL and REFUSED are outcomes. Anything else (MX or TXT data)
+	// is neither.
+	if !strings.HasPrefix(value, "<") && strings.ToUpper(value) == value && pq.q.Status == "" {
+		pq.q.Status = value
+	}
+}
+
+// Flush returns the queries that have been quiet since before cutoff,
+// oldest first.
+func (p *dnsmasqParser) Flush(cutoff time.Time) []*pb.DNSQuery {
+	var done []*pb.DNSQuery
+	for key, pq := range p.pending {
+		if pq.last.Before(cutoff) {
+			done = append(done, pq.q)
+			delete(p.pending, key)
+		}
+	}
+	slices.SortFunc(done, func(a, b *pb.DNSQuery) int {
+		return a.Timestamp.AsTime().Compare(b.Timestamp.AsTime())
+	})
+	return done
+}
+
+// dnsName is a resolved address remembered for naming connections.
+type dnsName struct {
+	name string
+	at   time.Time
+}
+
+// dnsNameKey scopes a resolved address to the container that looked it
+// up: two boxes may resolve different names to one shared address.
+type dnsNameKey struct{ container, ip string }
+
+// followDNSLog tails the resolver log until the collector stops, recording
+// each completed query.
+func (c *Collector) followDNSLog() {
+	parser := newDNSMasqParser()
+	c.followLog(c.config.DNSLogPath, "DNS query log", parser.Line, func(now time.Time) {
+		for _, q := range parser.Flush(now.Add(-dnsQueryLinger)) {
+			c.recordDNSQuery(q)
+		}
+		c.pruneDNSNames(now)
+	})
+}
+
+// recordDNSQuery attributes a completed query to the container that sent
+// it, remembers its answers for naming connections, and persists it.
+// Queries from addresses that aren't containers (the host itself) or from
+// containers that haven't opted into DNS logging are dropped.
+func (c *Collector) recordDNSQuery(q *pb.DNSQuery) {
+	q.ContainerName = c.cache.LookupIP(q.ClientIp)
+	if q.ContainerName == "" || !c.cache.DNSLogged(q.ContainerName) {
+		return
+	}
+	at := q.Timestamp.AsTime()
+	if len(q.Answers) > 0 {
+		c.mu.Lock()
+		for _, ip := range q.Answers {
+			c.dnsNames[dnsNameKey{q.ContainerName, ip}] = dnsName{name: q.Qname, at: at}
+		}
+		c.mu.Unlock()
+	}
+	rec, ok := c.store.(DNSRecorder)
+	if !ok {
+		return
+	}
+	go func() {
+		if err := rec.SaveDNSQuery(c.ctx, q); err != nil {
+			log.Printf("Warning: failed to record DNS query: %v", err)
+		}
+	}()
+}
+
+// destHostname returns the name container last resolved ip from, if it
+// did so within dnsNameTTL of at. Caller holds c.mu.
+func (c *Collector) destHostname(container, ip string, at time.Time) string {
+	n, ok := c.dnsNames[dnsNameKey{container, ip}]
+	if !ok || at.Sub(n.at) > dnsNameTTL {
+		return ""
+	}
+	return n.name
+}
+
+// pruneDNSNames forgets resolved addresses older than dnsNameTTL.
+func (c *Collector) pruneDNSNames(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, n := range c.dnsNames {
+		if now.Sub(n.at) > dnsNameTTL {
+			delete(c.dnsNames, k)
+		}
+	}
+}
+
+// QueryDNSHistory returns the DNS queries recorded for a container.
+func (c *Collector) QueryDNSHistory(ctx context.Context, params DNSQueryParams) ([]*pb.DNSQuery, error) {
+	if c.config.DNSLogPath == "" {
+		return nil, ErrDNSDisabled
+	}
+	rec, ok := c.store.(DNSRecorder)
+	if !ok {
+		return nil, ErrDNSUnsupported
+	}
+	return rec.QueryDNSHistory(ctx, params)
+}
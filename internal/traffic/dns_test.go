@@ -0,0 +1,173 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestDNSMasqParser_AssemblesQueries(t *testing.T) {
+	p := newDNSMasqParser()
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, line := range []string{
+		"Mar  1 12:00:00 dnsmasq[812]: 17 10.100.0.5/41000 query[A] WWW.Example.com from 10.100.0.5",
+		"Mar  1 12:00:00 dnsmasq[812]: 17 10.100.0.5/41000 forwarded www.example.com to 192.0.2.53",
+		"Mar  1 12:00:00 dnsmasq[812]: 18 10.100.0.6/52000 query[AAAA] missing.example.com from 10.100.0.6",
+		"Mar  1 12:00:00 dnsmasq[812]: 17 10.100.0.5/41000 reply www.example.com is <CNAME>",
+		"Mar  1 12:00:00 dnsmasq[812]: 17 10.100.0.5/41000 reply cdn.example.net is 203.0.113.7",
+		"Mar  1 12:00:00 dnsmasq[812]: 17 10.100.0.5/41000 reply cdn.example.net is 203.0.113.8",
+		"Mar  1 12:00:00 dnsmasq[812]: 18 10.100.0.6/52000 reply missing.example.com is NXDOMAIN",
+		// Logged without log-queries=extra, and DHCP chatter: ignored.
+		"Mar  1 12:00:00 dnsmasq[812]: query[A] other.example.com from 10.100.0.5",
+		"Mar  1 12:00:00 dnsmasq-dhcp[812]: DHCPACK(incusbr0) 10.100.0.5 00:16:3e:00:00:01 alice-container",
+	} {
+		p.Line(line, t0)
+	}
+
+	if got := p.Flush(t0); len(got) != 0 {
+		t.Fatalf("flushed %d queries before they went quiet", len(got))
+	}
+	got := p.Flush(t0.Add(dnsQueryLinger + time.Second))
+	if len(got) != 2 {
+		t.Fatalf("got %d queries, want 2: %v", len(got), got)
+	}
+	slices.SortFunc(got, func(a, b *pb.DNSQuery) int { return len(a.Answers) - len(b.Answers) })
+
+	nx, www := got[0], got[1]
+	if nx.Qname != "missing.example.com" || nx.Qtype != "AAAA" || nx.Status != "NXDOMAIN" || len(nx.Answers) != 0 {
+		t.Errorf("NXDOMAIN query = %v", nx)
+	}
+	if www.Qname != "www.example.com" || www.Qtype != "A" || www.ClientIp != "10.100.0.5" || www.Status != "" {
+		t.Errorf("CNAME query = %v", www)
+	}
+	if !slices.Equal(www.Answers, []string{"203.0.113.7", "203.0.113.8"}) {
+		t.Errorf("answers = %v, want both addresses behind the CNAME", www.Answers)
+	}
+	if len(p.pending) != 0 {
+		t.Errorf("%d queries left pending after flush", len(p.pending))
+	}
+}
+
+func TestCollector_DNSNamesConnections(t *testing.T) {
+	c := newTestCollector()
+	c.ctx = context.Background()
+	c.config.DNSLogPath = "/dev/null"
+	c.store = NewMemoryStore(10)
+	c.cache.apply([]incus.ContainerInfo{
+		{Name: "alice-container", IPAddress: "10.100.0.5", DNSLogEnabled: true},
+		{Name: "bob-container", IPAddress: "10.100.0.6"},
+	})
+
+	now := time.Now()
+	c.recordDNSQuery(&pb.DNSQuery{ClientIp: "10.100.0.5", Qname: "api.example.com", Qtype: "A",
+		Answers: []string{"203.0.113.7"}, Timestamp: timestamppb.New(now)})
+	// bob hasn't opted in: his lookups are neither kept nor used.
+	c.recordDNSQuery(&pb.DNSQuery{ClientIp: "10.100.0.6", Qname: "api.example.com", Qtype: "A",
+		Answers: []string{"203.0.113.7"}, Timestamp: timestamppb.New(now)})
+	// The host's own lookups are not a container's.
+	c.recordDNSQuery(&pb.DNSQuery{ClientIp: "10.100.0.1", Qname: "host.example.com", Qtype: "A",
+		Answers: []string{"203.0.113.9"}, Timestamp: timestamppb.New(now)})
+
+	c.processConntrackEvent(&ConntrackEvent{ID: "1", Type: ConntrackEventNew, Protocol: "tcp",
+		SrcIP: "10.100.0.5", SrcPort: 51000, DstIP: "203.0.113.7", DstPort: 443, Timestamp: now})
+	conns := c.GetConnections("alice-container")
+	if len(conns) != 1 || conns[0].DestHostname != "api.example.com" {
+		t.Fatalf("connections = %v, want dest_hostname api.example.com", conns)
+	}
+
+	// An hour later the answer is too old to vouch for the address.
+	c.processConntrackEvent(&ConntrackEvent{ID: "2", Type: ConntrackEventNew, Protocol: "tcp",
+		SrcIP: "10.100.0.5", SrcPort: 51001, DstIP: "203.0.113.7", DstPort: 443, Timestamp: now.Add(dnsNameTTL + time.Minute)})
+	for _, conn := range c.GetConnections("alice-container") {
+		if conn.Id == "2" && conn.DestHostname != "" {
+			t.Errorf("stale DNS answer named connection: %q", conn.DestHostname)
+		}
+	}
+
+	var got []*pb.DNSQuery
+	deadline := time.Now().Add(2 * time.Second)
+	for len(got) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		var err error
+		got, err = c.QueryDNSHistory(context.Background(), DNSQueryParams{
+			ContainerName: "alice-container", StartTime: now.Add(-time.Minute), EndTime: now.Add(time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("QueryDNSHistory: %v", err)
+		}
+	}
+	if len(got) != 1 || got[0].ContainerName != "alice-container" || got[0].Qname != "api.example.com" {
+		t.Errorf("history = %v", got)
+	}
+
+	c.processConntrackEvent(&ConntrackEvent{ID: "3", Type: ConntrackEventNew, Protocol: "tcp",
+		SrcIP: "10.100.0.6", SrcPort: 52000, DstIP: "203.0.113.7", DstPort: 443, Timestamp: now})
+	for _, conn := range c.GetConnections("bob-container") {
+		if conn.DestHostname != "" {
+			t.Errorf("bob's connection named from an unlogged lookup: %q", conn.DestHostname)
+		}
+	}
+	bob, err := c.QueryDNSHistory(context.Background(), DNSQueryParams{
+		ContainerName: "bob-container", StartTime: now.Add(-time.Minute), EndTime: now.Add(time.Minute),
+	})
+	if err != nil || len(bob) != 0 {
+		t.Errorf("bob's history = %v, %v; want none", bob, err)
+	}
+}
+
+func TestCollector_QueryDNSHistoryDisabled(t *testing.T) {
+	c := newTestCollector()
+	c.store = NewMemoryStore(10)
+	if _, err := c.QueryDNSHistory(context.Background(), DNSQueryParams{ContainerName: "alice-container"}); !errors.Is(err, ErrDNSDisabled) {
+		t.Errorf("err = %v, want ErrDNSDisabled", err)
+	}
+}
+
+func TestMemoryStore_QueryDNSHistoryFilters(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore(10)
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, q := range []*pb.DNSQuery{
+		{ContainerName: "alice-container", Qname: "api.example.com", Answers: []string{"203.0.113.7"}},
+		{ContainerName: "alice-container", Qname: "cdn.example.net", Answers: []string{"203.0.113.8"}},
+		{ContainerName: "bob-container", Qname: "api.example.com", Answers: []string{"203.0.113.7"}},
+		{ContainerName: "alice-container", Qname: "missing.example.com", Status: "NXDOMAIN"},
+	} {
+		q.Timestamp = timestamppb.New(t0.Add(time.Duration(i) * time.Minute))
+		if err := m.SaveDNSQuery(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	query := func(p DNSQueryParams) []string {
+		p.ContainerName, p.StartTime, p.EndTime = "alice-container", t0, t0.Add(time.Hour)
+		got, err := m.QueryDNSHistory(ctx, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, q := range got {
+			names = append(names, q.Qname)
+		}
+		return names
+	}
+
+	if got := query(DNSQueryParams{}); !slices.Equal(got, []string{"missing.example.com", "cdn.example.net", "api.example.com"}) {
+		t.Errorf("all (newest first) = %v", got)
+	}
+	if got := query(DNSQueryParams{QName: "EXAMPLE.COM"}); !slices.Equal(got, []string{"missing.example.com", "api.example.com"}) {
+		t.Errorf("by name = %v", got)
+	}
+	if got := query(DNSQueryParams{AnswerIP: "203.0.113.8"}); !slices.Equal(got, []string{"cdn.example.net"}) {
+		t.Errorf("by answer = %v", got)
+	}
+	if got := query(DNSQueryParams{Limit: 1}); len(got) != 1 {
+		t.Errorf("limit 1 returned %v", got)
+	}
+}
@@ -18,6 +18,10 @@ func newTestCollector() *Collector {
 		connections:   make(map[string]*pb.Connection),
 		ebpfFlows:     make(map[string]*pb.Connection),
 		conntrackSeen: make(map[string]bool),
+		openSince:     make(map[string]openFlow),
+		idleSplits:    make(map[string]idleSplit),
+		dnsNames:      make(map[dnsNameKey]dnsName),
+		persisted:     newRecentKeys(recentlyPersistedSize),
 	}
 }
 
@@ -73,14 +73,14 @@ func aggregateEgress(conns []egressConn, idForName func(string) string) []Egress
 
 // EgressFanout returns per-container egress fan-out stats from the current
 // connection snapshot — the crawler-detection signal. It refreshes the snapshot
-// first (when conntrack is available) so the counts reflect live state, then
+// first (at most once per SnapshotDebounce) so the counts reflect live state, then
 // aggregates only EGRESS-direction connections. Returns nil when conntrack
 // monitoring is unavailable (e.g. macOS).
 func (c *Collector) EgressFanout() []EgressStat {
 	if c.monitor == nil {
 		return nil
 	}
-	c.takeSnapshot()
+	c.refreshSnapshot()
 
 	c.mu.RLock()
 	conns := make([]egressConn, 0, len(c.connections))
@@ -0,0 +1,184 @@
+package traffic
+
+import (
+	"fmt"
+	"log"
+	"net/netip"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/geoip"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// geoIPReloadInterval is how often the GeoIP databases are checked for a
+// new file (geoipupdate replaces them in place), or one that appeared or
+// went missing.
+const geoIPReloadInterval = 10 * time.Minute
+
+// geoIPMaxAge is how old a GeoIP database may be before it is no longer
+// used. Addresses move between networks and countries, and a wrong label
+// in a report on where data went is worse than none; the free databases
+// are rebuilt weekly or more often, so this only trips when updates
+// stopped.
+const geoIPMaxAge = 90 * 24 * time.Hour
+
+// geoIPDatabase is one configured MMDB file and what became of loading it.
+type geoIPDatabase struct {
+	path    string
+	modTime time.Time
+	reader  *geoip.Reader // parsed file; nil when missing or unreadable
+	stale   bool
+	err     string
+	loaded  time.Time
+}
+
+// usable reports whether lookups use the database.
+func (d *geoIPDatabase) usable() bool {
+	return d.reader != nil && !d.stale
+}
+
+// geoIPEnricher annotates closed connections with the remote end's
+// country and autonomous system. A database that is missing, corrupt or
+// stale is skipped, with a warning in status; connections are then stored
+// without what it would have added, never held back.
+type geoIPEnricher struct {
+	mu  sync.RWMutex
+	dbs []*geoIPDatabase
+	now func() time.Time
+}
+
+func newGeoIPEnricher(paths []string) *geoIPEnricher {
+	g := &geoIPEnricher{now: time.Now}
+	for _, p := range paths {
+		g.dbs = append(g.dbs, &geoIPDatabase{path: p})
+	}
+	return g
+}
+
+// reload re-reads each database whose file changed since it was last
+// read, and re-checks every database's age.
+func (g *geoIPEnricher) reload() {
+	g.mu.RLock()
+	current := make([]geoIPDatabase, len(g.dbs))
+	for i, d := range g.dbs {
+		current[i] = *d
+	}
+	g.mu.RUnlock()
+
+	now := g.now()
+	for i := range current {
+		d := &current[i]
+		before, prevErr := d.usable(), d.err
+		fi, err := os.Stat(d.path)
+		switch {
+		case err != nil:
+			d.reader, d.modTime, d.err = nil, time.Time{}, err.Error()
+		case d.reader == nil || !fi.ModTime().Equal(d.modTime):
+			d.modTime = fi.ModTime()
+			if d.reader, err = geoip.Open(d.path); err != nil {
+				d.err = err.Error()
+			} else {
+				d.err, d.loaded = "", now
+			}
+		}
+		d.stale = false
+		if d.reader != nil {
+			if built := d.reader.Metadata().BuildTime; !built.IsZero() && now.Sub(built) > geoIPMaxAge {
+				d.stale = true
+				d.err = fmt.Sprintf("built %s, more than %d days ago", built.Format(time.DateOnly), int(geoIPMaxAge.Hours()/24))
+			}
+		}
+		switch after := d.usable(); {
+		case after && !before:
+			log.Printf("Loaded GeoIP database %s (%s)", d.path, d.reader.Metadata().DatabaseType)
+		case !after && (before || d.err != prevErr):
+			log.Printf("Warning: GeoIP database %s is not used: %s; connections are stored without it", d.path, d.err)
+		}
+	}
+
+	g.mu.Lock()
+	for i := range current {
+		*g.dbs[i] = current[i]
+	}
+	g.mu.Unlock()
+}
+
+// lookup merges what the usable databases know about addr. Lookup errors
+// (a corrupt record) count as not knowing.
+func (g *geoIPEnricher) lookup(addr netip.Addr) geoip.Record {
+	var rec geoip.Record
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return rec
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, d := range g.dbs {
+		if !d.usable() {
+			continue
+		}
+		if r, err := d.reader.Lookup(addr); err == nil {
+			rec = rec.Merge(r)
+		}
+	}
+	return rec
+}
+
+// remoteAddr is the far end of conn: the source of an ingress connection,
+// the destination otherwise.
+func remoteAddr(conn *pb.Connection) netip.Addr {
+	ip := conn.DestIp
+	if conn.Direction == pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS {
+		ip = conn.SourceIp
+	}
+	addr, _ := netip.ParseAddr(ip)
+	return addr
+}
+
+// annotate returns conn with the remote end's country and AS set. conn is
+// shared with the live view, so it is copied rather than changed; when
+// nothing is known it is returned as is.
+func (g *geoIPEnricher) annotate(conn *pb.Connection) *pb.Connection {
+	rec := g.lookup(remoteAddr(conn))
+	if rec.Empty() {
+		return conn
+	}
+	conn = proto.Clone(conn).(*pb.Connection)
+	conn.RemoteCountry = rec.Country
+	conn.RemoteAsn = rec.ASN
+	conn.RemoteAsOrg = rec.ASOrg
+	return conn
+}
+
+// status describes each configured database, and a warning for each one
+// connections are stored without.
+func (g *geoIPEnricher) status() (*pb.GeoIPStatus, []string) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	st := &pb.GeoIPStatus{Enabled: len(g.dbs) > 0}
+	var warnings []string
+	for _, d := range g.dbs {
+		db := &pb.GeoIPDatabase{Path: d.path, Loaded: d.usable(), Stale: d.stale, Error: d.err}
+		if d.reader != nil {
+			m := d.reader.Metadata()
+			db.DatabaseType = m.DatabaseType
+			if !m.BuildTime.IsZero() {
+				db.BuildTime = timestamppb.New(m.BuildTime)
+			}
+		}
+		if !d.loaded.IsZero() {
+			db.LoadedTime = timestamppb.New(d.loaded)
+		}
+		if db.Loaded {
+			st.Active = true
+		} else {
+			warnings = append(warnings, fmt.Sprintf("GeoIP database %s is not used (%s): connections are stored without its country/ASN", d.path, d.err))
+		}
+		st.Databases = append(st.Databases, db)
+	}
+	return st, warnings
+}
@@ -0,0 +1,150 @@
+package traffic
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/geoip/geoiptest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// writeGeoDBs writes a Country and an ASN database covering 203.0.113.0/24
+// (DE, AS3320) and 198.51.100.0/24 (US, no AS), built at built.
+func writeGeoDBs(t *testing.T, built time.Time) (country, asn string) {
+	t.Helper()
+	dir := t.TempDir()
+	country = geoiptest.Write(t, dir, "country.mmdb", geoiptest.DB{Type: "GeoLite2-Country", BuildTime: built, Networks: []geoiptest.Network{
+		{Prefix: "203.0.113.0/24", Record: map[string]any{"country": map[string]any{"iso_code": "DE"}}},
+		{Prefix: "198.51.100.0/24", Record: map[string]any{"country": map[string]any{"iso_code": "US"}}},
+	}})
+	asn = geoiptest.Write(t, dir, "asn.mmdb", geoiptest.DB{Type: "GeoLite2-ASN", BuildTime: built, Networks: []geoiptest.Network{
+		{Prefix: "203.0.113.0/24", Record: map[string]any{"autonomous_system_number": uint32(3320), "autonomous_system_organization": "Deutsche Telekom AG"}},
+	}})
+	return country, asn
+}
+
+func TestGeoIPEnricher_AnnotatesRemoteEnd(t *testing.T) {
+	country, asn := writeGeoDBs(t, time.Now().Add(-48*time.Hour))
+	g := newGeoIPEnricher([]string{country, asn})
+	g.reload()
+
+	egress := &pb.Connection{SourceIp: "10.100.0.5", DestIp: "203.0.113.7", Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS}
+	got := g.annotate(egress)
+	if got.RemoteCountry != "DE" || got.RemoteAsn != 3320 || got.RemoteAsOrg != "Deutsche Telekom AG" {
+		t.Errorf("egress = %v", got)
+	}
+	if egress.RemoteCountry != "" {
+		t.Error("annotate changed the live connection")
+	}
+
+	ingress := &pb.Connection{SourceIp: "198.51.100.9", DestIp: "10.100.0.5", Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS}
+	if got := g.annotate(ingress); got.RemoteCountry != "US" || got.RemoteAsn != 0 {
+		t.Errorf("ingress = %v, want the source's country", got)
+	}
+
+	internal := &pb.Connection{SourceIp: "10.100.0.5", DestIp: "10.100.0.6"}
+	if got := g.annotate(internal); got != internal {
+		t.Errorf("internal connection was copied: %v", got)
+	}
+
+	st, warnings := g.status()
+	if !st.Enabled || !st.Active || len(st.Databases) != 2 || st.Databases[1].DatabaseType != "GeoLite2-ASN" || len(warnings) != 0 {
+		t.Errorf("status = %v, warnings %q", st, warnings)
+	}
+}
+
+func TestGeoIPEnricher_MissingAndStaleDegrade(t *testing.T) {
+	country, asn := writeGeoDBs(t, time.Now().Add(-200*24*time.Hour))
+	missing := country + ".missing"
+	g := newGeoIPEnricher([]string{missing, asn})
+	g.reload()
+
+	conn := &pb.Connection{DestIp: "203.0.113.7", Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS}
+	if got := g.annotate(conn); got != conn {
+		t.Errorf("annotated from unusable databases: %v", got)
+	}
+	st, warnings := g.status()
+	if st.Active || st.Databases[0].Loaded || !st.Databases[1].Stale || len(warnings) != 2 {
+		t.Fatalf("status = %v, warnings %q", st, warnings)
+	}
+	if !strings.Contains(warnings[1], "more than 90 days ago") {
+		t.Errorf("stale warning = %q", warnings[1])
+	}
+
+	// geoipupdate drops a fresh database in place: the next reload uses it.
+	fresh, _ := writeGeoDBs(t, time.Now())
+	buf, err := os.ReadFile(fresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(missing, buf, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	g.reload()
+	if got := g.annotate(conn); got.RemoteCountry != "DE" || got.RemoteAsn != 0 {
+		t.Errorf("after the update = %v, want the country only", got)
+	}
+}
+
+func TestCollector_PersistsWithoutGeoIPDatabase(t *testing.T) {
+	store := NewMemoryStore(10)
+	cfg := DefaultCollectorConfig()
+	cfg.GeoIPDatabases = []string{t.TempDir() + "/GeoLite2-Country.mmdb"}
+	c, err := NewCollector(cfg, nil, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.cancel()
+
+	now := time.Now()
+	c.persistConnection(&pb.Connection{Id: "1", ContainerName: "alice-container", DestIp: "203.0.113.7",
+		FirstSeen: timestamppb.New(now.Add(-time.Minute)), LastSeen: timestamppb.New(now)})
+	deadline := time.Now().Add(time.Second)
+	for store.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	rows, _, err := store.QueryConnections(context.Background(), QueryParams{ContainerNames: []string{"alice-container"}, StartTime: now.Add(-time.Hour), EndTime: now})
+	if err != nil || len(rows) != 1 || rows[0].RemoteCountry != "" {
+		t.Fatalf("rows = %v, %v; want the connection, unannotated", rows, err)
+	}
+	if _, warnings := c.GeoIPStatus(); len(warnings) != 1 {
+		t.Errorf("warnings = %q, want one for the missing database", warnings)
+	}
+}
+
+func TestMemoryStore_GroupByCountryAndASN(t *testing.T) {
+	store := NewMemoryStore(10)
+	now := time.Now()
+	for i, c := range []*pb.Connection{
+		{RemoteCountry: "DE", RemoteAsn: 3320, RemoteAsOrg: "Deutsche Telekom AG", BytesSent: 100},
+		{RemoteCountry: "DE", RemoteAsn: 3320, BytesSent: 50},
+		{RemoteCountry: "US", BytesSent: 7},
+		{BytesSent: 1},
+	} {
+		c.Id = string(rune('a' + i))
+		c.ContainerName = "alice-container"
+		c.FirstSeen = timestamppb.New(now)
+		if err := store.SaveConnection(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggs, err := store.GetAggregates(context.Background(), AggregateParams{
+		ContainerNames: []string{"alice-container"}, StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour),
+		Interval: "1d", GroupByCountry: true, GroupByASN: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int64)
+	for _, a := range aggs {
+		got[a.RemoteCountry+"/"+a.RemoteAsOrg] += a.BytesSent
+	}
+	if len(got) != 3 || got["DE/Deutsche Telekom AG"] != 150 || got["US/"] != 7 || got["/"] != 1 {
+		t.Errorf("groups = %v", got)
+	}
+}
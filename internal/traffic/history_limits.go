@@ -0,0 +1,210 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// HistoryLimits keep a broad QueryConnections call from monopolizing the
+// PostgreSQL pool the collector writes through. A query narrowed only by
+// container or username (no IP, dest port or state) over a long range
+// walks every row the container recorded in that range; these limits
+// refuse it up front, with a QueryTooBroadError that says how to narrow
+// it. Each field's zero value disables that check.
+//
+// Queries with QueryParams.Unbounded set skip the limits; the admin export
+// path (StreamTrafficHistory) uses it, paging by keyset so no single
+// statement is large.
+type HistoryLimits struct {
+	// MaxUnfilteredWindow is the longest time range an unfiltered query
+	// may cover.
+	MaxUnfilteredWindow time.Duration
+
+	// StatementTimeout bounds each history statement on the server
+	// (SET LOCAL statement_timeout), filtered or not.
+	StatementTimeout time.Duration
+
+	// MaxEstimatedRows refuses an unfiltered query when the traffic_daily
+	// rollup says its range holds more connections than this.
+	MaxEstimatedRows int64
+}
+
+// DefaultHistoryLimits returns the limits NewStore starts with.
+func DefaultHistoryLimits() HistoryLimits {
+	return HistoryLimits{
+		MaxUnfilteredWindow: 7 * 24 * time.Hour,
+		StatementTimeout:    30 * time.Second,
+		MaxEstimatedRows:    5_000_000,
+	}
+}
+
+// QueryTooBroadError is returned by QueryConnections when a query trips
+// one of the HistoryLimits. Suggestions lists ways to narrow it.
+type QueryTooBroadError struct {
+	Reason      string
+	Suggestions []string
+}
+
+func (e *QueryTooBroadError) Error() string {
+	msg := "traffic history query too broad: " + e.Reason
+	if len(e.Suggestions) > 0 {
+		msg += "; narrow it with " + strings.Join(e.Suggestions, ", ")
+	}
+	return msg
+}
+
+// filtered reports whether params narrow the query beyond container,
+// username and time, i.e. whether an index other than the per-container
+// time index can serve it.
+func (p QueryParams) filtered() bool {
+	return p.DestIP != "" || p.SourceIP != "" || p.DestPort > 0 || p.State != pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+// tooBroad builds the error for params, suggesting the filters they lack.
+func (l HistoryLimits) tooBroad(params QueryParams, reason string) *QueryTooBroadError {
+	var s []string
+	if l.MaxUnfilteredWindow > 0 {
+		s = append(s, "a time range of at most "+formatWindow(l.MaxUnfilteredWindow))
+	}
+	if len(params.ContainerNames) == 0 && params.ContainerNamePrefix == "" {
+		s = append(s, "container_name")
+	}
+	if params.DestIP == "" {
+		s = append(s, "dest_ip")
+	}
+	if params.SourceIP == "" {
+		s = append(s, "source_ip")
+	}
+	if params.DestPort <= 0 {
+		s = append(s, "dest_port")
+	}
+	if params.State == pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED {
+		s = append(s, "state")
+	}
+	return &QueryTooBroadError{Reason: reason, Suggestions: s}
+}
+
+// checkWindow refuses an unfiltered query over more than
+// MaxUnfilteredWindow.
+func (l HistoryLimits) checkWindow(params QueryParams) error {
+	if params.Unbounded || params.filtered() || l.MaxUnfilteredWindow <= 0 {
+		return nil
+	}
+	if window := params.EndTime.Sub(params.StartTime); window > l.MaxUnfilteredWindow {
+		return l.tooBroad(params, fmt.Sprintf("a %s range without dest_ip, source_ip, dest_port or state filters exceeds the %s limit",
+			formatWindow(window), formatWindow(l.MaxUnfilteredWindow)))
+	}
+	return nil
+}
+
+// needsEstimate reports whether params are subject to MaxEstimatedRows.
+func (l HistoryLimits) needsEstimate(params QueryParams) bool {
+	return !params.Unbounded && !params.filtered() && l.MaxEstimatedRows > 0
+}
+
+// checkEstimate refuses an unfiltered query whose estimated row count is
+// over MaxEstimatedRows.
+func (l HistoryLimits) checkEstimate(params QueryParams, estimated int64) error {
+	if !l.needsEstimate(params) || estimated <= l.MaxEstimatedRows {
+		return nil
+	}
+	return l.tooBroad(params, fmt.Sprintf("the range holds about %d connections, over the %d limit", estimated, l.MaxEstimatedRows))
+}
+
+// formatWindow prints whole days as days and anything else as a duration.
+func formatWindow(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d >= day && d%day == 0 {
+		if d == day {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", d/day)
+	}
+	return d.Round(time.Second).String()
+}
+
+// SetHistoryLimits replaces the store's HistoryLimits. Queries already
+// running finish under the limits they started with.
+func (s *Store) SetHistoryLimits(limits HistoryLimits) {
+	s.limitsMu.Lock()
+	defer s.limitsMu.Unlock()
+	s.limits = limits
+}
+
+// HistoryLimits returns the limits the store currently applies.
+func (s *Store) HistoryLimits() HistoryLimits {
+	s.limitsMu.RLock()
+	defer s.limitsMu.RUnlock()
+	return s.limits
+}
+
+// estimateConnections sums traffic_daily's connection counts over params'
+// range. It is an upper bound on the rows an unfiltered query reads:
+// sampled rows are counted at their weight, and days the rollup has not
+// reached yet count as zero.
+func (s *Store) estimateConnections(ctx context.Context, q pgQuerier, params QueryParams) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(connection_count), 0)::BIGINT FROM traffic_daily
+		WHERE day >= ($1::timestamptz AT TIME ZONE 'UTC')::date
+		  AND day <= ($2::timestamptz AT TIME ZONE 'UTC')::date
+	`
+	args := []interface{}{params.StartTime, params.EndTime}
+	if cond, arg := params.containerFilter(len(args) + 1); cond != "" {
+		args = append(args, arg)
+		query += " AND " + cond
+	}
+	if params.Username != "" {
+		args = append(args, params.Username)
+		query += " AND " + usernameFilter(len(args))
+	}
+	var n int64
+	if err := q.QueryRow(ctx, query, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to estimate connections: %w", err)
+	}
+	return n, nil
+}
+
+// pgQuerier is what QueryConnections needs from a pool or transaction.
+type pgQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// historyTx starts the read-only transaction a guarded history query runs
+// in, with the statement timeout applied to it alone. The caller must
+// roll it back.
+func (s *Store) historyTx(ctx context.Context) (pgx.Tx, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin history query: %w", err)
+	}
+	ms := s.HistoryLimits().StatementTimeout.Milliseconds()
+	if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", max(ms, 1))); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, fmt.Errorf("failed to set statement timeout: %w", err)
+	}
+	return tx, nil
+}
+
+// pgQueryCanceled is SQLSTATE query_canceled, raised by statement_timeout.
+const pgQueryCanceled = "57014"
+
+// timeoutError turns a statement timeout into a QueryTooBroadError and
+// passes any other error through. A cancelled request context also shows
+// up as query_canceled, so ctx is checked first.
+func (s *Store) timeoutError(ctx context.Context, params QueryParams, err error) error {
+	var pgErr *pgconn.PgError
+	if ctx.Err() != nil || !errors.As(err, &pgErr) || pgErr.Code != pgQueryCanceled {
+		return err
+	}
+	limits := s.HistoryLimits()
+	return limits.tooBroad(params, fmt.Sprintf("it ran past the %s statement timeout", limits.StatementTimeout))
+}
@@ -0,0 +1,74 @@
+package traffic
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestHistoryLimits_Window(t *testing.T) {
+	limits := DefaultHistoryLimits()
+	end := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	broad := QueryParams{ContainerNames: []string{"alice-container"}, StartTime: end.AddDate(0, 0, -90), EndTime: end}
+
+	err := limits.checkWindow(broad)
+	var tooBroad *QueryTooBroadError
+	if !errors.As(err, &tooBroad) {
+		t.Fatalf("90-day unfiltered query: err = %v, want QueryTooBroadError", err)
+	}
+	if !strings.Contains(err.Error(), "90 days") || !strings.Contains(err.Error(), "7 days") {
+		t.Errorf("error %q does not name the range and the limit", err)
+	}
+	if !slices.Contains(tooBroad.Suggestions, "dest_port") || slices.Contains(tooBroad.Suggestions, "container_name") {
+		t.Errorf("suggestions = %v, want dest_port and not the container filter already given", tooBroad.Suggestions)
+	}
+
+	for name, p := range map[string]QueryParams{
+		"a week":    {ContainerNames: []string{"alice-container"}, StartTime: end.AddDate(0, 0, -7), EndTime: end},
+		"dest_port": {ContainerNames: []string{"alice-container"}, StartTime: broad.StartTime, EndTime: end, DestPort: 5432},
+		"state":     {ContainerNames: []string{"alice-container"}, StartTime: broad.StartTime, EndTime: end, State: pb.ConnectionState_CONNECTION_STATE_TIME_WAIT},
+		"source_ip": {StartTime: broad.StartTime, EndTime: end, SourceIP: "10.100.0.5"},
+		"unbounded": {ContainerNames: []string{"alice-container"}, StartTime: broad.StartTime, EndTime: end, Unbounded: true},
+	} {
+		if err := limits.checkWindow(p); err != nil {
+			t.Errorf("%s: checkWindow = %v, want nil", name, err)
+		}
+	}
+	if err := (HistoryLimits{}).checkWindow(broad); err != nil {
+		t.Errorf("zero limits: checkWindow = %v, want nil", err)
+	}
+}
+
+func TestHistoryLimits_Estimate(t *testing.T) {
+	limits := HistoryLimits{MaxEstimatedRows: 1000}
+	p := QueryParams{Username: "bob"}
+	if err := limits.checkEstimate(p, 1000); err != nil {
+		t.Errorf("at the limit: %v", err)
+	}
+	err := limits.checkEstimate(p, 1001)
+	var tooBroad *QueryTooBroadError
+	if !errors.As(err, &tooBroad) || !slices.Contains(tooBroad.Suggestions, "container_name") {
+		t.Errorf("over the limit: err = %v, want QueryTooBroadError suggesting container_name", err)
+	}
+	p.DestIP = "192.0.2.10"
+	if limits.needsEstimate(p) || limits.checkEstimate(p, 1e9) != nil {
+		t.Error("a dest_ip filter should skip the estimate")
+	}
+}
+
+func TestFormatWindow(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		24 * time.Hour:      "1 day",
+		90 * 24 * time.Hour: "90 days",
+		36 * time.Hour:      "36h0m0s",
+		90 * time.Minute:    "1h30m0s",
+	} {
+		if got := formatWindow(d); got != want {
+			t.Errorf("formatWindow(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
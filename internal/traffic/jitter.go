@@ -0,0 +1,43 @@
+package traffic
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// tickJitter is how far each period of the collector's periodic work may
+// stray from its configured interval, as a fraction of it. Collectors
+// started together (one per daemon restarted by the same rollout) drift
+// apart instead of hitting conntrack, Postgres and Incus in lockstep.
+const tickJitter = 0.1
+
+// jitteredInterval spreads d uniformly by ±tickJitter; the mean stays d.
+func jitteredInterval(d time.Duration) time.Duration {
+	// #nosec G404 -- jitter for load spreading; not security-sensitive.
+	return time.Duration(float64(d) * (1 - tickJitter + 2*tickJitter*rand.Float64()))
+}
+
+// tickJittered calls fn every interval, each period jittered, until ctx is
+// done. Periods are measured from when each tick was due rather than from
+// when fn returned, so the average rate matches a time.Ticker's; like a
+// Ticker, a tick that fn overran is dropped rather than queued.
+func tickJittered(ctx context.Context, interval time.Duration, fn func()) {
+	next := time.Now().Add(jitteredInterval(interval))
+	timer := time.NewTimer(time.Until(next))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			fn()
+			next = next.Add(jitteredInterval(interval))
+			if now := time.Now(); next.Before(now) {
+				next = now.Add(jitteredInterval(interval))
+			}
+			timer.Reset(time.Until(next))
+		}
+	}
+}
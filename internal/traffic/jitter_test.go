@@ -0,0 +1,51 @@
+package traffic
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestJitteredInterval(t *testing.T) {
+	d := time.Minute
+	lo, hi := time.Duration(float64(d)*(1-tickJitter)), time.Duration(float64(d)*(1+tickJitter))
+	var sum time.Duration
+	const n = 2000
+	for range n {
+		got := jitteredInterval(d)
+		if got < lo || got > hi {
+			t.Fatalf("jitteredInterval(%s) = %s, outside [%s, %s]", d, got, lo, hi)
+		}
+		sum += got
+	}
+	// The mean stays the configured interval (within 1%).
+	if mean := sum / n; mean < d*99/100 || mean > d*101/100 {
+		t.Errorf("mean interval = %s, want about %s", mean, d)
+	}
+}
+
+func TestTickJittered_KeepsRateAndStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time, 100)
+	done := make(chan struct{})
+	go func() {
+		tickJittered(ctx, 10*time.Millisecond, func() { ticks <- time.Now() })
+		close(done)
+	}()
+
+	start := time.Now()
+	for range 10 {
+		<-ticks
+	}
+	// Ten ticks at 10ms ±10% take 90-110ms of schedule; allow for a slow
+	// scheduler on the high side only.
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("10 ticks in %s, want about 100ms", elapsed)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("tickJittered did not return after cancel")
+	}
+}
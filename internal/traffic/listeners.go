@@ -0,0 +1,451 @@
+package traffic
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/safecast"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Listener scanning: flows say what connected, not what a box is offering.
+// Every ListenerScanInterval the collector lists each running container's
+// listening sockets (ss inside the container, /proc/net when the image has
+// no ss), keeps the result in memory for GetListeningPorts, and diffs it
+// against the previous scan. A listener that appears or goes away becomes
+// a ListenerChange: recorded by stores that implement ListenerRecorder,
+// published on the events bus, and counted in ListenerStats for the
+// container.listeners.opened metric alert rules watch.
+//
+// A container's first scan after the daemon starts (or after the
+// container comes back up) is a baseline: whatever is already open is not
+// reported as a change.
+
+var (
+	// ErrListenersDisabled is returned by GetListeningPorts when listener
+	// scanning is off (ListenerScanInterval zero).
+	ErrListenersDisabled = errors.New("listener scanning is disabled (daemon --traffic-listener-interval)")
+
+	// ErrListenersUnsupported is returned by QueryListenerHistory when the
+	// traffic store cannot keep listener changes.
+	ErrListenersUnsupported = errors.New("the traffic store does not record listener changes")
+)
+
+// ListenerQueryParams filters QueryListenerHistory.
+type ListenerQueryParams struct {
+	ContainerName string
+	StartTime     time.Time
+	EndTime       time.Time
+	Limit         int
+}
+
+// ListenerRecorder is implemented by backends that can keep listener
+// changes.
+type ListenerRecorder interface {
+	// SaveListenerChange records one change.
+	SaveListenerChange(ctx context.Context, change *pb.ListenerChange) error
+	// QueryListenerHistory returns matching changes, newest first.
+	QueryListenerHistory(ctx context.Context, params ListenerQueryParams) ([]*pb.ListenerChange, error)
+}
+
+// ListenerStat is one container's listener counts for the metrics plane.
+// Opened counts the listeners that appeared since the daemon started
+// (baselines excluded), so an increase() over it fires on new services.
+type ListenerStat struct {
+	ContainerName string
+	ContainerID   string
+	Listening     int
+	Opened        int64
+}
+
+// listenerKey identifies a listener across scans. The owning process is
+// deliberately not part of it: a service restarting under a new PID is
+// the same listener.
+type listenerKey struct {
+	protocol pb.Protocol
+	address  string
+	port     uint32
+}
+
+// containerListeners is the latest scan of one container.
+type containerListeners struct {
+	ports     map[listenerKey]*pb.ListeningPort
+	scannedAt time.Time
+	// failing is set while scans of the container fail, so the failure is
+	// logged once rather than every interval.
+	failing bool
+}
+
+// ssListenCommand lists listening TCP and UDP sockets with their owners.
+var ssListenCommand = []string{"ss", "-H", "-n", "-l", "-p", "-t", "-u"}
+
+// procNetListenCommand dumps the kernel socket tables for images without
+// ss. Each table is preceded by a "# <name>" marker, since tcp and udp
+// rows look alike; a missing table (IPv6 disabled) is skipped.
+var procNetListenCommand = []string{"sh", "-c",
+	`for f in tcp tcp6 udp udp6; do echo "# $f"; cat /proc/net/$f 2>/dev/null; done; true`}
+
+// listSockets returns a container's listening sockets. The process
+// columns stay empty on the /proc/net fallback.
+func (c *Collector) listSockets(containerName string) ([]*pb.ListeningPort, error) {
+	if c.incusClient == nil {
+		return nil, fmt.Errorf("incus client not available")
+	}
+	stdout, _, ssErr := c.incusClient.ExecWithOutput(containerName, ssListenCommand)
+	if ssErr == nil {
+		return parseSSListeners(stdout), nil
+	}
+	stdout, stderr, err := c.incusClient.ExecWithOutput(containerName, procNetListenCommand)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sockets in %s: %w (stderr: %s)", containerName, err, strings.TrimSpace(stderr))
+	}
+	return parseProcNetListeners(stdout), nil
+}
+
+// parseSSListeners parses `ss -H -n -l -p -t -u` output, whose rows are
+//
+//	tcp LISTEN 0 4096 0.0.0.0:8080 0.0.0.0:* users:(("node",pid=88,fd=20))
+//
+// A socket shared by several processes reports the first of them.
+func parseSSListeners(output string) []*pb.ListeningPort {
+	var out []*pb.ListeningPort
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		protocol := protoStringToEnum(fields[0])
+		if protocol != pb.Protocol_PROTOCOL_TCP && protocol != pb.Protocol_PROTOCOL_UDP {
+			continue
+		}
+		address, port, ok := splitSSAddr(fields[4])
+		if !ok {
+			continue
+		}
+		l := &pb.ListeningPort{Protocol: protocol, Address: address, Port: port}
+		if len(fields) > 6 {
+			if m := ssUsersRe.FindStringSubmatch(fields[6]); m != nil {
+				if pid, err := strconv.ParseInt(m[2], 10, 64); err == nil {
+					l.ProcessName, l.Pid = m[1], safecast.I32(pid)
+				}
+			}
+		}
+		out = append(out, l)
+	}
+	return out
+}
+
+// tcpListenState is TCP_LISTEN in /proc/net/tcp's st column.
+const tcpListenState = "0A"
+
+// parseProcNetListeners parses the procNetListenCommand dump. TCP rows
+// count when they are in LISTEN; UDP rows when they have no remote end
+// (a connected UDP socket is a client, not a service).
+func parseProcNetListeners(output string) []*pb.ListeningPort {
+	var out []*pb.ListeningPort
+	var table string
+	for line := range strings.SplitSeq(output, "\n") {
+		if name, ok := strings.CutPrefix(line, "# "); ok {
+			table = strings.TrimSpace(name)
+			continue
+		}
+		// sl local_address rem_address st ...
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[0] == "sl" {
+			continue
+		}
+		var protocol pb.Protocol
+		switch table {
+		case "tcp", "tcp6":
+			if fields[3] != tcpListenState {
+				continue
+			}
+			protocol = pb.Protocol_PROTOCOL_TCP
+		case "udp", "udp6":
+			if _, remotePort, ok := parseProcNetAddr(fields[2]); !ok || remotePort != 0 {
+				continue
+			}
+			protocol = pb.Protocol_PROTOCOL_UDP
+		default:
+			continue
+		}
+		address, port, ok := parseProcNetAddr(fields[1])
+		if !ok {
+			continue
+		}
+		out = append(out, &pb.ListeningPort{Protocol: protocol, Address: address, Port: port})
+	}
+	return out
+}
+
+// parseProcNetAddr decodes a /proc/net address such as "0100007F:1F90":
+// the IP in hex, one host-order 32-bit word at a time (8 digits for IPv4,
+// 32 for IPv6), and the port in hex.
+func parseProcNetAddr(s string) (ip string, port uint32, ok bool) {
+	hexIP, hexPort, found := strings.Cut(s, ":")
+	if !found || (len(hexIP) != 8 && len(hexIP) != 32) {
+		return "", 0, false
+	}
+	p, err := strconv.ParseUint(hexPort, 16, 16)
+	if err != nil {
+		return "", 0, false
+	}
+	addr := make(net.IP, len(hexIP)/2)
+	for w := 0; w < len(hexIP); w += 8 {
+		word, err := strconv.ParseUint(hexIP[w:w+8], 16, 32)
+		if err != nil {
+			return "", 0, false
+		}
+		// The kernel prints each word in host (little-endian) order.
+		for i := range 4 {
+			addr[w/2+i] = byte(word >> (8 * i))
+		}
+	}
+	if v4 := addr.To4(); v4 != nil {
+		addr = v4
+	}
+	return addr.String(), safecast.U32FromUint(p), true
+}
+
+// sortListeners orders listeners by protocol, port, then address.
+func sortListeners(ls []*pb.ListeningPort) {
+	slices.SortFunc(ls, func(a, b *pb.ListeningPort) int {
+		return cmp.Or(
+			cmp.Compare(a.Protocol, b.Protocol),
+			cmp.Compare(a.Port, b.Port),
+			strings.Compare(a.Address, b.Address),
+		)
+	})
+}
+
+// periodicListenerScan scans every running container each
+// ListenerScanInterval until the collector stops.
+func (c *Collector) periodicListenerScan() {
+	interval := c.config.ListenerScanInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.scanListeners(time.Now())
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// Pick up a period Reconfigure changed since the last tick.
+		c.mu.RLock()
+		next := c.listenerInterval
+		c.mu.RUnlock()
+		if next != interval {
+			interval = next
+			ticker.Reset(interval)
+		}
+	}
+}
+
+// scanListeners scans the containers the cache knows (running ones with
+// an address) and forgets the state of containers that went away, so
+// their next scan is a fresh baseline.
+func (c *Collector) scanListeners(now time.Time) {
+	running := c.cache.GetAllContainers()
+	c.mu.Lock()
+	for name := range c.listeners {
+		if _, ok := running[name]; !ok {
+			delete(c.listeners, name)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, name := range slices.Sorted(maps.Keys(running)) {
+		if c.ctx.Err() != nil {
+			return
+		}
+		ports, err := c.listSockets(name)
+		c.applyListenerScan(name, ports, err, now)
+	}
+}
+
+// applyListenerScan replaces a container's listeners with one scan's
+// result and reports what changed. A failed scan keeps the previous
+// listeners.
+func (c *Collector) applyListenerScan(containerName string, ports []*pb.ListeningPort, scanErr error, now time.Time) {
+	c.mu.Lock()
+	prev, seen := c.listeners[containerName]
+	if scanErr != nil {
+		if !seen {
+			prev = &containerListeners{}
+			c.listeners[containerName] = prev
+		}
+		if !prev.failing {
+			prev.failing = true
+			log.Printf("Warning: listener scan of %s failed: %v", containerName, scanErr)
+		}
+		c.mu.Unlock()
+		return
+	}
+	baseline := !seen || prev.scannedAt.IsZero()
+
+	cur := make(map[listenerKey]*pb.ListeningPort, len(ports))
+	var changes []*pb.ListenerChange
+	for _, l := range ports {
+		key := listenerKey{l.Protocol, l.Address, l.Port}
+		if _, dup := cur[key]; dup {
+			continue
+		}
+		l.ContainerName = containerName
+		l.FirstSeen = timestamppb.New(now)
+		if seen {
+			if old, ok := prev.ports[key]; ok {
+				l.FirstSeen = old.FirstSeen
+			}
+		}
+		cur[key] = l
+		if !baseline {
+			if _, ok := prev.ports[key]; !ok {
+				changes = append(changes, &pb.ListenerChange{
+					Type:      pb.ListenerChangeType_LISTENER_CHANGE_TYPE_OPENED,
+					Listener:  proto.Clone(l).(*pb.ListeningPort),
+					Timestamp: timestamppb.New(now),
+				})
+			}
+		}
+	}
+	if !baseline {
+		for key, old := range prev.ports {
+			if _, ok := cur[key]; !ok {
+				changes = append(changes, &pb.ListenerChange{
+					Type:      pb.ListenerChangeType_LISTENER_CHANGE_TYPE_CLOSED,
+					Listener:  proto.Clone(old).(*pb.ListeningPort),
+					Timestamp: timestamppb.New(now),
+				})
+			}
+		}
+	}
+	if seen && prev.failing {
+		log.Printf("Listener scan of %s recovered", containerName)
+	}
+	c.listeners[containerName] = &containerListeners{ports: cur, scannedAt: now}
+	for _, ch := range changes {
+		if ch.Type == pb.ListenerChangeType_LISTENER_CHANGE_TYPE_OPENED {
+			c.listenersOpened[containerName]++
+		}
+	}
+	c.mu.Unlock()
+
+	slices.SortFunc(changes, func(a, b *pb.ListenerChange) int {
+		return cmp.Or(
+			cmp.Compare(a.Type, b.Type),
+			cmp.Compare(a.Listener.Protocol, b.Listener.Protocol),
+			cmp.Compare(a.Listener.Port, b.Listener.Port),
+			strings.Compare(a.Listener.Address, b.Listener.Address),
+		)
+	})
+	for _, ch := range changes {
+		c.recordListenerChange(ch)
+	}
+}
+
+// recordListenerChange publishes a change and persists it when the store
+// keeps listener history.
+func (c *Collector) recordListenerChange(change *pb.ListenerChange) {
+	l := change.Listener
+	verb := "opened"
+	if change.Type == pb.ListenerChangeType_LISTENER_CHANGE_TYPE_CLOSED {
+		verb = "closed"
+	}
+	log.Printf("Listener %s in %s: %s %s:%d (%s)", verb, l.ContainerName,
+		strings.ToLower(strings.TrimPrefix(l.Protocol.String(), "PROTOCOL_")), l.Address, l.Port, l.ProcessName)
+
+	if c.emitter != nil {
+		c.emitter.EmitListenerChange(change)
+	}
+	rec, ok := c.store.(ListenerRecorder)
+	if !ok {
+		return
+	}
+	go func() {
+		if err := rec.SaveListenerChange(c.ctx, change); err != nil {
+			log.Printf("Warning: failed to record listener change: %v", err)
+		}
+	}()
+}
+
+// GetListeningPorts returns a container's listeners as of its latest scan
+// and when that scan ran (zero when it has not been scanned yet).
+func (c *Collector) GetListeningPorts(containerName string) ([]*pb.ListeningPort, time.Time, error) {
+	if c.config.ListenerScanInterval <= 0 {
+		return nil, time.Time{}, ErrListenersDisabled
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	state, ok := c.listeners[containerName]
+	if !ok {
+		return nil, time.Time{}, nil
+	}
+	out := make([]*pb.ListeningPort, 0, len(state.ports))
+	for _, l := range state.ports {
+		out = append(out, proto.Clone(l).(*pb.ListeningPort))
+	}
+	sortListeners(out)
+	return out, state.scannedAt, nil
+}
+
+// QueryListenerHistory returns the listener changes recorded for a
+// container.
+func (c *Collector) QueryListenerHistory(ctx context.Context, params ListenerQueryParams) ([]*pb.ListenerChange, error) {
+	if c.config.ListenerScanInterval <= 0 {
+		return nil, ErrListenersDisabled
+	}
+	rec, ok := c.store.(ListenerRecorder)
+	if !ok {
+		return nil, ErrListenersUnsupported
+	}
+	return rec.QueryListenerHistory(ctx, params)
+}
+
+// ListenerStats returns per-container listener counts for the metrics
+// plane, sorted by container name. Nil when scanning is off.
+func (c *Collector) ListenerStats() []ListenerStat {
+	if c.config.ListenerScanInterval <= 0 {
+		return nil
+	}
+	c.mu.RLock()
+	out := make([]ListenerStat, 0, len(c.listeners))
+	for name, state := range c.listeners {
+		if state.scannedAt.IsZero() {
+			continue
+		}
+		out = append(out, ListenerStat{
+			ContainerName: name,
+			Listening:     len(state.ports),
+			Opened:        c.listenersOpened[name],
+		})
+	}
+	c.mu.RUnlock()
+	for i := range out {
+		out[i].ContainerID = c.cache.LookupID(out[i].ContainerName)
+	}
+	slices.SortFunc(out, func(a, b ListenerStat) int { return strings.Compare(a.ContainerName, b.ContainerName) })
+	return out
+}
+
+// listenerQueryLimit applies QueryListenerHistory's default and maximum
+// page size.
+func listenerQueryLimit(limit int) int {
+	if limit <= 0 {
+		return 100
+	}
+	return min(limit, 1000)
+}
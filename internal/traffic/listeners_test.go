@@ -0,0 +1,231 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/events"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestParseSSListeners(t *testing.T) {
+	out := `tcp   LISTEN 0      4096   0.0.0.0:8080        0.0.0.0:*     users:(("node",pid=88,fd=20))
+tcp   LISTEN 0      128    [::]:22             [::]:*        users:(("sshd",pid=501,fd=4))
+tcp   LISTEN 0      511    127.0.0.1:6379      0.0.0.0:*
+udp   UNCONN 0      0      127.0.0.53%lo:53    0.0.0.0:*     users:(("systemd-resolve",pid=77,fd=13))
+tcp   LISTEN 0      128    *:9090              *:*           users:(("prometheus",pid=300,fd=7))
+`
+	got := parseSSListeners(out)
+	want := []*pb.ListeningPort{
+		{Protocol: pb.Protocol_PROTOCOL_TCP, Address: "0.0.0.0", Port: 8080, ProcessName: "node", Pid: 88},
+		{Protocol: pb.Protocol_PROTOCOL_TCP, Address: "::", Port: 22, ProcessName: "sshd", Pid: 501},
+		{Protocol: pb.Protocol_PROTOCOL_TCP, Address: "127.0.0.1", Port: 6379},
+		{Protocol: pb.Protocol_PROTOCOL_UDP, Address: "127.0.0.53", Port: 53, ProcessName: "systemd-resolve", Pid: 77},
+		{Protocol: pb.Protocol_PROTOCOL_TCP, Address: "*", Port: 9090, ProcessName: "prometheus", Pid: 300},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d listeners, want %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Protocol != w.Protocol || g.Address != w.Address || g.Port != w.Port || g.ProcessName != w.ProcessName || g.Pid != w.Pid {
+			t.Errorf("listener %d = %v, want %v", i, g, w)
+		}
+	}
+}
+
+func TestParseProcNetListeners(t *testing.T) {
+	out := `# tcp
+  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1001 1
+   1: 0100007F:18EB 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1002 1
+   2: 0500640A:C350 0100640A:01BB 01 00000000:00000000 00:00000000 00000000     0        0 1003 1
+# tcp6
+  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000000000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1004 1
+# udp
+  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  10: 3500007F:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 1005 2 0000000000000000 0
+  11: 0500640A:D431 08080808:0035 01 00000000:00000000 00:00000000 00000000     0        0 1006 2 0000000000000000 0
+# udp6
+`
+	got := parseProcNetListeners(out)
+	want := []struct {
+		protocol pb.Protocol
+		address  string
+		port     uint32
+	}{
+		{pb.Protocol_PROTOCOL_TCP, "0.0.0.0", 8080},
+		{pb.Protocol_PROTOCOL_TCP, "127.0.0.1", 6379},
+		{pb.Protocol_PROTOCOL_TCP, "::", 22},
+		{pb.Protocol_PROTOCOL_UDP, "127.0.0.53", 53},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d listeners, want %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Protocol != w.protocol || got[i].Address != w.address || got[i].Port != w.port {
+			t.Errorf("listener %d = %v, want %v %s:%d", i, got[i], w.protocol, w.address, w.port)
+		}
+	}
+}
+
+func TestParseProcNetAddr_IPv6(t *testing.T) {
+	// ::1 is printed as four host-order words, the last one 01000000.
+	ip, port, ok := parseProcNetAddr("00000000000000000000000001000000:1F90")
+	if !ok || ip != "::1" || port != 8080 {
+		t.Errorf("parseProcNetAddr = (%q, %d, %v), want (::1, 8080, true)", ip, port, ok)
+	}
+	if _, _, ok := parseProcNetAddr("0100007F"); ok {
+		t.Error("address without a port parsed")
+	}
+}
+
+func newListenerTestCollector(store ConnectionStore, bus *events.Bus) *Collector {
+	c := newTestCollector()
+	c.config.ListenerScanInterval = time.Minute
+	c.store = store
+	c.ctx = context.Background()
+	c.listeners = make(map[string]*containerListeners)
+	c.listenersOpened = make(map[string]int64)
+	if bus != nil {
+		c.emitter = events.NewEmitter(bus)
+	}
+	return c
+}
+
+func tcpListener(port uint32, process string) *pb.ListeningPort {
+	return &pb.ListeningPort{Protocol: pb.Protocol_PROTOCOL_TCP, Address: "0.0.0.0", Port: port, ProcessName: process}
+}
+
+func TestApplyListenerScan_ReportsChangesAfterBaseline(t *testing.T) {
+	bus := events.NewBus()
+	sub := bus.Subscribe(&pb.SubscribeEventsRequest{
+		ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_TRAFFIC},
+	})
+	defer bus.Unsubscribe(sub.ID)
+	store := NewMemoryStore(10)
+	c := newListenerTestCollector(store, bus)
+
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c.applyListenerScan("alice-container", []*pb.ListeningPort{tcpListener(22, "sshd"), tcpListener(8080, "node")}, nil, t0)
+	select {
+	case ev := <-sub.Events:
+		t.Fatalf("baseline scan emitted %v", ev)
+	default:
+	}
+
+	t1 := t0.Add(time.Minute)
+	c.applyListenerScan("alice-container", []*pb.ListeningPort{tcpListener(22, "sshd"), tcpListener(4444, "nc")}, nil, t1)
+
+	var got []*pb.ListenerChange
+	for range 2 {
+		select {
+		case ev := <-sub.Events:
+			if ev.Type != pb.EventType_EVENT_TYPE_LISTENER_CHANGED || ev.ResourceId != "alice-container" {
+				t.Errorf("event = %v %q, want LISTENER_CHANGED for alice-container", ev.Type, ev.ResourceId)
+			}
+			got = append(got, ev.GetListenerChange())
+		case <-time.After(time.Second):
+			t.Fatalf("got %d listener events, want 2", len(got))
+		}
+	}
+	if got[0].Type != pb.ListenerChangeType_LISTENER_CHANGE_TYPE_OPENED || got[0].Listener.Port != 4444 || got[0].Listener.ProcessName != "nc" {
+		t.Errorf("first change = %v, want 4444 opened by nc", got[0])
+	}
+	if got[1].Type != pb.ListenerChangeType_LISTENER_CHANGE_TYPE_CLOSED || got[1].Listener.Port != 8080 {
+		t.Errorf("second change = %v, want 8080 closed", got[1])
+	}
+
+	ports, scannedAt, err := c.GetListeningPorts("alice-container")
+	if err != nil {
+		t.Fatalf("GetListeningPorts: %v", err)
+	}
+	if !scannedAt.Equal(t1) || len(ports) != 2 || ports[0].Port != 22 || ports[1].Port != 4444 {
+		t.Fatalf("GetListeningPorts = %v at %v", ports, scannedAt)
+	}
+	if !ports[0].FirstSeen.AsTime().Equal(t0) || !ports[1].FirstSeen.AsTime().Equal(t1) {
+		t.Errorf("first_seen = %v, %v; want the scan each listener appeared in", ports[0].FirstSeen.AsTime(), ports[1].FirstSeen.AsTime())
+	}
+
+	stats := c.ListenerStats()
+	if len(stats) != 1 || stats[0].Listening != 2 || stats[0].Opened != 1 {
+		t.Errorf("ListenerStats = %+v, want 2 listening, 1 opened", stats)
+	}
+
+	// Persistence runs in the background.
+	deadline := time.Now().Add(time.Second)
+	for {
+		history, err := c.QueryListenerHistory(context.Background(), ListenerQueryParams{
+			ContainerName: "alice-container",
+			StartTime:     t0,
+			EndTime:       t1,
+		})
+		if err != nil {
+			t.Fatalf("QueryListenerHistory: %v", err)
+		}
+		if len(history) == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("history has %d changes, want 2", len(history))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestApplyListenerScan_FailureKeepsListeners(t *testing.T) {
+	c := newListenerTestCollector(nil, nil)
+	t0 := time.Now()
+	c.applyListenerScan("bob-container", []*pb.ListeningPort{tcpListener(80, "nginx")}, nil, t0)
+	c.applyListenerScan("bob-container", nil, errors.New("exec failed"), t0.Add(time.Minute))
+
+	ports, scannedAt, err := c.GetListeningPorts("bob-container")
+	if err != nil {
+		t.Fatalf("GetListeningPorts: %v", err)
+	}
+	if len(ports) != 1 || !scannedAt.Equal(t0) {
+		t.Errorf("after a failed scan: %v at %v, want the previous scan", ports, scannedAt)
+	}
+
+	// Recovering from a failure is not a change.
+	c.applyListenerScan("bob-container", []*pb.ListeningPort{tcpListener(80, "nginx")}, nil, t0.Add(2*time.Minute))
+	if stats := c.ListenerStats(); len(stats) != 1 || stats[0].Opened != 0 {
+		t.Errorf("ListenerStats = %+v, want nothing opened", stats)
+	}
+}
+
+func TestScanListeners_ForgetsStoppedContainers(t *testing.T) {
+	c := newListenerTestCollector(nil, nil)
+	c.ctx = context.Background()
+	c.applyListenerScan("carol-container", []*pb.ListeningPort{tcpListener(80, "nginx")}, nil, time.Now())
+
+	// carol-container is not in the cache (stopped); the scan drops it
+	// and the only cached container fails to scan without Incus.
+	c.scanListeners(time.Now())
+	ports, scannedAt, err := c.GetListeningPorts("carol-container")
+	if err != nil || len(ports) != 0 || !scannedAt.IsZero() {
+		t.Errorf("GetListeningPorts = (%v, %v, %v), want nothing for a stopped container", ports, scannedAt, err)
+	}
+}
+
+func TestListeners_Disabled(t *testing.T) {
+	c := newListenerTestCollector(NewMemoryStore(10), nil)
+	c.config.ListenerScanInterval = 0
+	if _, _, err := c.GetListeningPorts("alice-container"); !errors.Is(err, ErrListenersDisabled) {
+		t.Errorf("GetListeningPorts err = %v, want ErrListenersDisabled", err)
+	}
+	if _, err := c.QueryListenerHistory(context.Background(), ListenerQueryParams{ContainerName: "alice-container"}); !errors.Is(err, ErrListenersDisabled) {
+		t.Errorf("QueryListenerHistory err = %v, want ErrListenersDisabled", err)
+	}
+	if c.ListenerStats() != nil {
+		t.Error("ListenerStats reported counts with scanning off")
+	}
+
+	c = newListenerTestCollector(nil, nil)
+	if _, err := c.QueryListenerHistory(context.Background(), ListenerQueryParams{ContainerName: "alice-container"}); !errors.Is(err, ErrListenersUnsupported) {
+		t.Errorf("QueryListenerHistory without a store err = %v, want ErrListenersUnsupported", err)
+	}
+}
@@ -0,0 +1,89 @@
+package traffic
+
+import (
+	"bufio"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// logPollInterval is how often a followed log file is checked for new
+// lines and rotation.
+const logPollInterval = 500 * time.Millisecond
+
+// followLog tails the log at path until the collector stops, handing each
+// complete line to line with the time it was read, then calling tick once
+// per poll. what names the log in warnings.
+//
+// The first open starts at the end of the file: old lines carry no usable
+// timestamp. After logrotate replaces or truncates the file it is reopened
+// from the start.
+func (c *Collector) followLog(path, what string, line func(string, time.Time), tick func(time.Time)) {
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+
+	var (
+		f       *os.File
+		reader  *bufio.Reader
+		offset  int64
+		partial string
+		warned  bool
+	)
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if st, err := os.Stat(path); err != nil {
+			if !warned {
+				log.Printf("Warning: %s %s unavailable: %v", what, path, err)
+				warned = true
+			}
+			continue
+		} else if f == nil || st.Size() < offset || !sameFile(f, st) {
+			rotated := f != nil
+			if rotated {
+				_ = f.Close()
+			}
+			nf, err := os.Open(path)
+			if err != nil {
+				log.Printf("Warning: failed to open %s: %v", what, err)
+				f = nil
+				continue
+			}
+			offset = 0
+			if !rotated {
+				offset, _ = nf.Seek(0, io.SeekEnd)
+			}
+			f, reader, partial, warned = nf, bufio.NewReader(nf), "", false
+		}
+
+		for {
+			chunk, err := reader.ReadString('\n')
+			offset += int64(len(chunk))
+			partial += chunk
+			if err != nil {
+				break // keep a partial line until the rest is written
+			}
+			line(strings.TrimRight(partial, "\r\n"), time.Now())
+			partial = ""
+		}
+		tick(time.Now())
+	}
+}
+
+// sameFile reports whether f is still the file at the path st describes.
+func sameFile(f *os.File, st os.FileInfo) bool {
+	cur, err := f.Stat()
+	return err == nil && os.SameFile(cur, st)
+}
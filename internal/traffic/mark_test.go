@@ -0,0 +1,148 @@
+package traffic
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestContainerCache_MarksClaimedOnce(t *testing.T) {
+	cache := NewContainerCache(nil, "10.100.0.0/24")
+	cache.apply([]incus.ContainerInfo{
+		{Name: "alice-container", IPAddress: "10.100.0.5", ConntrackMark: 0x2a},
+		{Name: "bob-container", IPAddress: "10.100.0.6", ConntrackMark: 0x2b},
+		{Name: "carol-container", IPAddress: "10.100.0.7", ConntrackMark: 0x2b},
+		{Name: "dave-container", IPAddress: "10.100.0.8", ConntrackMark: 0x2b},
+		{Name: "erin-container", IPAddress: "10.100.0.9"},
+	})
+
+	if got := cache.LookupMark(0x2a); got != "alice-container" {
+		t.Errorf("LookupMark(0x2a) = %q, want alice-container", got)
+	}
+	if got := cache.LookupMark(0x2b); got != "" {
+		t.Errorf("LookupMark(0x2b) = %q, want none for a mark three boxes share", got)
+	}
+	if got := cache.LookupMark(0); got != "" {
+		t.Errorf("LookupMark(0) = %q, want none for unmarked flows", got)
+	}
+}
+
+// TestContainerCache_SharedMarkWarnsOnChange — a shared mark is logged when
+// it becomes shared, not again on every refresh of the same listing, and
+// again if it is shared anew after being resolved.
+func TestContainerCache_SharedMarkWarnsOnChange(t *testing.T) {
+	var buf bytes.Buffer
+	prev := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(prev) })
+
+	shared := []incus.ContainerInfo{
+		{Name: "bob-container", IPAddress: "10.100.0.6", ConntrackMark: 0x2b},
+		{Name: "carol-container", IPAddress: "10.100.0.7", ConntrackMark: 0x2b},
+	}
+	resolved := []incus.ContainerInfo{
+		{Name: "bob-container", IPAddress: "10.100.0.6", ConntrackMark: 0x2b},
+		{Name: "carol-container", IPAddress: "10.100.0.7", ConntrackMark: 0x2c},
+	}
+	cache := NewContainerCache(nil, "10.100.0.0/24")
+	warnings := func() int { return strings.Count(buf.String(), "share conntrack mark 0x2b") }
+
+	cache.apply(shared)
+	cache.apply(shared)
+	if got := warnings(); got != 1 {
+		t.Fatalf("warned %d times over two identical refreshes, want 1:\n%s", got, buf.String())
+	}
+	cache.apply(resolved)
+	if got := cache.LookupMark(0x2b); got != "bob-container" {
+		t.Errorf("LookupMark(0x2b) = %q after the clash resolved, want bob-container", got)
+	}
+	cache.apply(shared)
+	if got := warnings(); got != 2 {
+		t.Errorf("warned %d times, want 2 once the mark is shared again:\n%s", got, buf.String())
+	}
+}
+
+func TestProcessConntrackEvent_AttributesByMark(t *testing.T) {
+	tests := []struct {
+		name          string
+		event         *ConntrackEvent
+		wantContainer string
+		wantDirection pb.TrafficDirection
+	}{
+		{
+			// alice's box reuses an address bob holds on the bridge: the
+			// mark says whose flow it is.
+			name: "shared address",
+			event: &ConntrackEvent{ID: "1", Protocol: "tcp", Mark: 0x2a,
+				SrcIP: "10.100.0.6", SrcPort: 51000, DstIP: "203.0.113.9", DstPort: 443},
+			wantContainer: "alice-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS,
+		},
+		{
+			// The flow reached the host already rewritten to an address
+			// no container has.
+			name: "rewritten source",
+			event: &ConntrackEvent{ID: "2", Protocol: "udp", Mark: 0x2a,
+				SrcIP: "198.51.100.4", SrcPort: 40000, DstIP: "192.0.2.53", DstPort: 53},
+			wantContainer: "alice-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS,
+		},
+		{
+			name: "marked ingress",
+			event: &ConntrackEvent{ID: "3", Protocol: "tcp", Mark: 0x2a,
+				SrcIP: "203.0.113.9", SrcPort: 40000, DstIP: "10.100.0.5", DstPort: 8080},
+			wantContainer: "alice-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS,
+		},
+		{
+			// Through a passthrough route's DNAT: the client dialled
+			// the host, and the reply comes from alice's box.
+			name: "marked passthrough ingress",
+			event: &ConntrackEvent{ID: "5", Protocol: "tcp", Mark: 0x2a,
+				SrcIP: "198.51.100.20", SrcPort: 40000, DstIP: "192.0.2.10", DstPort: 5432, ReplySrcIP: "10.100.0.5"},
+			wantContainer: "alice-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS,
+		},
+		{
+			// Marks the cache doesn't know fall back to the address.
+			name: "foreign mark",
+			event: &ConntrackEvent{ID: "4", Protocol: "tcp", Mark: 0x4000,
+				SrcIP: "10.100.0.6", SrcPort: 52000, DstIP: "203.0.113.9", DstPort: 443},
+			wantContainer: "bob-container",
+			wantDirection: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, path := range []string{"event", "snapshot"} {
+				c := newTestCollector()
+				c.cache.ipToName["10.100.0.5"] = "alice-container"
+				c.cache.nameToIP["alice-container"] = "10.100.0.5"
+				c.cache.ipToName["10.100.0.6"] = "bob-container"
+				c.cache.markToName[0x2a] = "alice-container"
+				event := *tt.event
+				event.Type = ConntrackEventNew
+				event.Timestamp = time.Now()
+				if path == "event" {
+					c.processConntrackEvent(&event)
+				} else {
+					c.monitor = &snapshotMonitor{events: []*ConntrackEvent{&event}}
+					c.takeSnapshot()
+				}
+
+				conn, ok := c.connections[event.Key()]
+				if !ok {
+					t.Fatalf("%s: connection not recorded", path)
+				}
+				if conn.ContainerName != tt.wantContainer || conn.Direction != tt.wantDirection {
+					t.Errorf("%s: attributed to %s/%v, want %s/%v", path, conn.ContainerName, conn.Direction, tt.wantContainer, tt.wantDirection)
+				}
+			}
+		})
+	}
+}
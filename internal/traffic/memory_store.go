@@ -0,0 +1,735 @@
+package traffic
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/safecast"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// DefaultMemoryStoreCapacity is the number of connections a MemoryStore
+// keeps when no capacity is given.
+const DefaultMemoryStoreCapacity = 100000
+
+// MemoryStore is an in-process ConnectionStore for tests and laptop
+// development (daemon --traffic-store memory). Connections live in a ring
+// buffer: once it is full, the oldest saved connection is evicted. It
+// mirrors the PostgreSQL store's semantics — upsert by connection key,
+// checkpointing of open connections, the QueryParams filters, and hourly
+// aggregation buckets — so results are comparable between the two.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	buf    []*memRow
+	start  int // index of the oldest row
+	n      int // rows in use
+	byKey  map[string]*memRow
+	nextID int64
+	now    func() time.Time
+
+	// timeline holds recorded state changes per container + conntrack ID
+	// (see RecordStateChange), capped at memTimelineCap entries each.
+	timeline map[timelineKey][]*pb.ConnectionStateChange
+
+	// dns holds recorded DNS queries oldest first (see SaveDNSQuery),
+	// capped at memDNSCap.
+	dns []*pb.DNSQuery
+
+	// listenerChanges holds recorded listener changes oldest first (see
+	// SaveListenerChange), capped at memListenerCap.
+	listenerChanges []*pb.ListenerChange
+
+	// sshSessions holds recorded SSH sessions oldest first (see
+	// SaveSSHSession), capped at memSSHSessionCap.
+	sshSessions []*pb.SSHSession
+}
+
+// timelineKey identifies one connection's state changes.
+type timelineKey struct{ container, conntrackID string }
+
+// memTimelineCap bounds the states kept per connection, so one flapping
+// connection can't grow the store without limit; the oldest are dropped.
+const memTimelineCap = 256
+
+// memDNSCap bounds the DNS queries kept; the oldest are dropped.
+const memDNSCap = 10000
+
+// memListenerCap bounds the listener changes kept; the oldest are dropped.
+const memListenerCap = 10000
+
+// memSSHSessionCap bounds the SSH sessions kept; the oldest are dropped.
+const memSSHSessionCap = 10000
+
+// memRow is one stored connection, shaped like a traffic_connections row.
+type memRow struct {
+	id         int64
+	key        string
+	conn       *pb.Connection // FirstSeen = started_at, LastSeen = ended_at
+	finalState pb.ConnectionState
+	reason     string
+	createdAt  time.Time
+	updatedAt  time.Time // last checkpoint or save
+}
+
+// agedFrom is when the row's retention starts: when the connection closed,
+// or while it is open, when it was last checkpointed.
+func (r *memRow) agedFrom() time.Time {
+	if r.conn.LastSeen != nil {
+		return r.conn.LastSeen.AsTime()
+	}
+	return r.updatedAt
+}
+
+// NewMemoryStore creates an in-memory store holding up to capacity
+// connections (DefaultMemoryStoreCapacity if capacity <= 0).
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = DefaultMemoryStoreCapacity
+	}
+	return &MemoryStore{
+		buf:      make([]*memRow, capacity),
+		byKey:    make(map[string]*memRow),
+		now:      time.Now,
+		timeline: make(map[timelineKey][]*pb.ConnectionStateChange),
+	}
+}
+
+// SaveConnection stores a closed connection, finalizing a checkpointed row
+// in place the way the PostgreSQL store does.
+func (m *MemoryStore) SaveConnection(_ context.Context, conn *pb.Connection) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := connectionKey(conn)
+	row, ok := m.byKey[key]
+	if !ok {
+		m.insert(key, conn)
+		row = m.byKey[key]
+	} else {
+		mergeCounters(row.conn, conn)
+		if conn.FirstSeen.AsTime().Before(row.conn.FirstSeen.AsTime()) {
+			row.conn.FirstSeen = conn.FirstSeen
+		}
+		row.conn.LastSeen = conn.LastSeen
+		row.conn.State = conn.State
+		if conn.DestHostname != "" {
+			row.conn.DestHostname = conn.DestHostname
+		}
+		if conn.RemoteCountry != "" {
+			row.conn.RemoteCountry = conn.RemoteCountry
+		}
+		if conn.RemoteAsn != 0 {
+			row.conn.RemoteAsn, row.conn.RemoteAsOrg = conn.RemoteAsn, conn.RemoteAsOrg
+		}
+		row.conn.PolicyViolation = row.conn.PolicyViolation || conn.PolicyViolation
+		row.updatedAt = m.now()
+	}
+
+	// Only a closed connection has a final state.
+	row.finalState, row.reason = pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED, ""
+	if row.conn.LastSeen != nil {
+		row.finalState = conn.State
+		row.reason = closeReason(conn)
+	}
+	return nil
+}
+
+// CheckpointConnection upserts a still-open connection. A row that has
+// already been finalized is left untouched.
+func (m *MemoryStore) CheckpointConnection(_ context.Context, conn *pb.Connection) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := connectionKey(conn)
+	row, ok := m.byKey[key]
+	if !ok {
+		m.insert(key, conn)
+		m.byKey[key].conn.LastSeen = nil
+		return nil
+	}
+	if row.conn.LastSeen == nil {
+		mergeCounters(row.conn, conn)
+		row.updatedAt = m.now()
+	}
+	return nil
+}
+
+// insert appends a copy of conn, evicting the oldest row when full.
+// Caller holds m.mu.
+func (m *MemoryStore) insert(key string, conn *pb.Connection) {
+	m.nextID++
+	row := &memRow{
+		id:        m.nextID,
+		key:       key,
+		conn:      proto.Clone(conn).(*pb.Connection),
+		createdAt: m.now(),
+	}
+	row.updatedAt = row.createdAt
+	if row.conn.FirstSeen == nil {
+		row.conn.FirstSeen = timestamppb.New(row.createdAt)
+	}
+
+	if m.n < len(m.buf) {
+		m.buf[(m.start+m.n)%len(m.buf)] = row
+		m.n++
+	} else {
+		evicted := m.buf[m.start]
+		if m.byKey[evicted.key] == evicted {
+			delete(m.byKey, evicted.key)
+		}
+		delete(m.timeline, timelineKey{evicted.conn.ContainerName, evicted.conn.Id})
+		m.buf[m.start] = row
+		m.start = (m.start + 1) % len(m.buf)
+	}
+	m.byKey[key] = row
+}
+
+func mergeCounters(dst, src *pb.Connection) {
+	dst.BytesSent = max(dst.BytesSent, src.BytesSent)
+	dst.BytesReceived = max(dst.BytesReceived, src.BytesReceived)
+	dst.PacketsSent = max(dst.PacketsSent, src.PacketsSent)
+	dst.PacketsReceived = max(dst.PacketsReceived, src.PacketsReceived)
+}
+
+// rows returns the stored rows oldest-first. Caller holds m.mu.
+func (m *MemoryStore) rows() []*memRow {
+	out := make([]*memRow, 0, m.n)
+	for i := 0; i < m.n; i++ {
+		out = append(out, m.buf[(m.start+i)%len(m.buf)])
+	}
+	return out
+}
+
+// matches applies the QueryParams filters. No container, prefix or
+// Username matches every container (StreamHistory semantics);
+// QueryConnections only allows that with an address filter, as the
+// PostgreSQL store does.
+func (r *memRow) matches(params QueryParams) bool {
+	started := r.conn.FirstSeen.AsTime()
+	switch {
+	case !params.matchesContainer(r.conn.ContainerName):
+		return false
+	case params.Username != "" && r.conn.Username != params.Username:
+		return false
+	case started.Before(params.StartTime) || started.After(params.EndTime):
+		return false
+	case params.DestIP != "" && r.conn.DestIp != params.DestIP:
+		return false
+	case params.SourceIP != "" && r.conn.SourceIp != params.SourceIP:
+		return false
+	case params.DestPort > 0 && int(r.conn.DestPort) != params.DestPort:
+		return false
+	case params.State != pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED && r.finalState != params.State:
+		return false
+	case params.PolicyViolationsOnly && !r.conn.PolicyViolation:
+		return false
+	case !params.IncludeOpen && r.conn.LastSeen == nil:
+		return false
+	}
+	return true
+}
+
+// QueryConnections returns matching connections newest first, paginated
+// like the PostgreSQL store (default limit 100, max 1000).
+func (m *MemoryStore) QueryConnections(_ context.Context, params QueryParams) ([]*pb.HistoricalConnection, int32, error) {
+	if !params.scoped() {
+		return nil, 0, errUnscopedQuery
+	}
+
+	m.mu.RLock()
+	var matched []*memRow
+	for _, r := range m.rows() {
+		if r.matches(params) {
+			matched = append(matched, r)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].before(matched[j].conn.FirstSeen.AsTime(), matched[j].id)
+	})
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	total := len(matched)
+	from := min(max(params.Offset, 0), total)
+	if c := params.After; c != nil {
+		// Like the PostgreSQL store, keyset pages carry no total.
+		from = sort.Search(total, func(i int) bool {
+			return !matched[i].before(c.StartedAt, c.ID)
+		})
+		if from < total && matched[from].conn.FirstSeen.AsTime().Equal(c.StartedAt) && matched[from].id == c.ID {
+			from++
+		}
+		total = 0
+	}
+	to := min(from+limit, len(matched))
+
+	out := make([]*pb.HistoricalConnection, 0, to-from)
+	for _, r := range matched[from:to] {
+		out = append(out, r.historical())
+	}
+	return out, safecast.I32(total), nil
+}
+
+// before reports whether r comes before (startedAt, id) in QueryConnections
+// order: newest start first, then descending ID.
+func (r *memRow) before(startedAt time.Time, id int64) bool {
+	t := r.conn.FirstSeen.AsTime()
+	if !t.Equal(startedAt) {
+		return t.After(startedAt)
+	}
+	return r.id > id
+}
+
+func (r *memRow) historical() *pb.HistoricalConnection {
+	c := r.conn
+	h := &pb.HistoricalConnection{
+		Id:            r.id,
+		ContainerName: c.ContainerName,
+		Protocol:      c.Protocol,
+		SourceIp:      c.SourceIp,
+		SourcePort:    c.SourcePort,
+		DestIp:        c.DestIp,
+		DestPort:      c.DestPort,
+		Direction:     c.Direction,
+		BytesSent:     c.BytesSent,
+		BytesReceived: c.BytesReceived,
+		StartedAt:     c.FirstSeen,
+		FinalState:    r.finalState,
+		CloseReason:   r.reason,
+		Zone:          c.Zone,
+		Username:      c.Username,
+		SampleWeight:  safecast.U32(rowWeight(c)),
+		DestHostname:  c.DestHostname,
+		RemoteCountry: c.RemoteCountry,
+		RemoteAsn:     c.RemoteAsn,
+		RemoteAsOrg:   c.RemoteAsOrg,
+
+		PolicyViolation: c.PolicyViolation,
+	}
+	if c.LastSeen != nil {
+		h.EndedAt = c.LastSeen
+		h.DurationSeconds = int64(c.LastSeen.AsTime().Sub(c.FirstSeen.AsTime()).Seconds())
+	}
+	return h
+}
+
+// StreamHistory streams matching connections oldest-first, in the same
+// shape as the PostgreSQL store (IDs "replay-<row id>").
+func (m *MemoryStore) StreamHistory(ctx context.Context, params QueryParams) <-chan *pb.Connection {
+	m.mu.RLock()
+	var matched []*pb.Connection
+	for _, r := range m.rows() {
+		if !r.matches(params) {
+			continue
+		}
+		c := proto.Clone(r.conn).(*pb.Connection)
+		c.Id = fmt.Sprintf("replay-%d", r.id)
+		matched = append(matched, c)
+	}
+	m.mu.RUnlock()
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].FirstSeen.AsTime().Before(matched[j].FirstSeen.AsTime())
+	})
+	if params.Limit > 0 && len(matched) > params.Limit {
+		matched = matched[:params.Limit]
+	}
+
+	out := make(chan *pb.Connection, 64)
+	go func() {
+		defer close(out)
+		for _, c := range matched {
+			select {
+			case out <- c:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// GetAggregates buckets matching connections by hour (then re-aggregates
+// to the requested interval), with the same grouping options and
+// direction sums as the PostgreSQL store. Buckets are in UTC.
+func (m *MemoryStore) GetAggregates(_ context.Context, params AggregateParams) ([]*pb.TrafficAggregate, error) {
+	intervalDuration, err := parseInterval(params.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval: %w", err)
+	}
+	loc, err := AggregateLocation(params.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	type groupKey struct {
+		bucket    int64
+		container string
+		destIP    string
+		destPort  uint32
+		direction pb.TrafficDirection
+		country   string
+		asn       uint32
+	}
+	groups := make(map[groupKey]*pb.TrafficAggregate)
+
+	m.mu.RLock()
+	for _, r := range m.rows() {
+		c := r.conn
+		started := c.FirstSeen.AsTime()
+		if !slices.Contains(params.ContainerNames, c.ContainerName) || started.Before(params.StartTime) || started.After(params.EndTime) {
+			continue
+		}
+		key := groupKey{bucket: truncateIn(started, time.Hour, loc).Unix()}
+		if params.byContainer() {
+			key.container = c.ContainerName
+		}
+		if params.GroupByDestIP {
+			key.destIP = c.DestIp
+		}
+		if params.GroupByDestPort {
+			key.destPort = c.DestPort
+		}
+		if params.GroupByDirection {
+			key.direction = c.Direction
+		}
+		if params.GroupByCountry {
+			key.country = c.RemoteCountry
+		}
+		if params.GroupByASN {
+			key.asn = c.RemoteAsn
+		}
+		agg, ok := groups[key]
+		if !ok {
+			agg = &pb.TrafficAggregate{
+				Timestamp:     timestamppb.New(time.Unix(key.bucket, 0).UTC()),
+				ContainerName: key.container,
+				DestIp:        key.destIP,
+				DestPort:      key.destPort,
+				Direction:     key.direction,
+				RemoteCountry: key.country,
+				RemoteAsn:     key.asn,
+			}
+			groups[key] = agg
+		}
+		w := int64(rowWeight(c)) // sampled rows stand for w flows
+		agg.BytesSent += c.BytesSent * w
+		agg.BytesReceived += c.BytesReceived * w
+		agg.ConnectionCount += rowWeight(c)
+		switch c.Direction {
+		case pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS:
+			agg.IngressBytes += (c.BytesSent + c.BytesReceived) * w
+		case pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS:
+			agg.EgressBytes += (c.BytesSent + c.BytesReceived) * w
+		}
+		if params.GroupByDestIP {
+			agg.DestHostname = cmp.Or(agg.DestHostname, c.DestHostname)
+		}
+		if params.GroupByASN {
+			agg.RemoteAsOrg = cmp.Or(agg.RemoteAsOrg, c.RemoteAsOrg)
+		}
+	}
+	m.mu.RUnlock()
+
+	aggregates := make([]*pb.TrafficAggregate, 0, len(groups))
+	for _, agg := range groups {
+		aggregates = append(aggregates, agg)
+	}
+	if intervalDuration > time.Hour {
+		aggregates = reAggregate(aggregates, intervalDuration, loc)
+	}
+	sort.SliceStable(aggregates, func(i, j int) bool {
+		return aggregates[i].Timestamp.AsTime().After(aggregates[j].Timestamp.AsTime())
+	})
+	return aggregates, nil
+}
+
+// Cleanup drops connections that closed, or while open were last
+// checkpointed, more than retentionDays ago, and the state changes, DNS
+// queries, listener changes and SSH sessions from before then, and returns
+// how many it dropped.
+func (m *MemoryStore) Cleanup(_ context.Context, retentionDays int) (int64, error) {
+	cutoff := m.now().AddDate(0, 0, -retentionDays)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := make([]*memRow, 0, m.n)
+	for _, r := range m.rows() {
+		if r.agedFrom().Before(cutoff) {
+			delete(m.byKey, r.key)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	dropped := m.n - len(kept)
+	clear(m.buf)
+	copy(m.buf, kept)
+	m.start, m.n = 0, len(kept)
+
+	for k, changes := range m.timeline {
+		if changes[len(changes)-1].Timestamp.AsTime().Before(cutoff) {
+			dropped += len(changes)
+			delete(m.timeline, k)
+		}
+	}
+	n := len(m.dns) + len(m.listenerChanges) + len(m.sshSessions)
+	m.dns = slices.DeleteFunc(m.dns, func(q *pb.DNSQuery) bool {
+		return q.Timestamp.AsTime().Before(cutoff)
+	})
+	m.listenerChanges = slices.DeleteFunc(m.listenerChanges, func(c *pb.ListenerChange) bool {
+		return c.Timestamp.AsTime().Before(cutoff)
+	})
+	m.sshSessions = slices.DeleteFunc(m.sshSessions, func(sess *pb.SSHSession) bool {
+		return cmp.Or(sess.EndedAt, sess.StartedAt).AsTime().Before(cutoff)
+	})
+	dropped += n - len(m.dns) - len(m.listenerChanges) - len(m.sshSessions)
+	return int64(dropped), nil
+}
+
+// RecordStateChange appends one observed state of a connection.
+func (m *MemoryStore) RecordStateChange(_ context.Context, containerName, conntrackID string, state pb.ConnectionState, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := timelineKey{containerName, conntrackID}
+	changes := append(m.timeline[k], &pb.ConnectionStateChange{State: state, Timestamp: timestamppb.New(at)})
+	if len(changes) > memTimelineCap {
+		changes = changes[len(changes)-memTimelineCap:]
+	}
+	m.timeline[k] = changes
+	return nil
+}
+
+// GetConnectionTimeline returns a connection's recorded states, oldest
+// first.
+func (m *MemoryStore) GetConnectionTimeline(_ context.Context, containerName, conntrackID string) ([]*pb.ConnectionStateChange, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	changes := slices.Clone(m.timeline[timelineKey{containerName, conntrackID}])
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Timestamp.AsTime().Before(changes[j].Timestamp.AsTime())
+	})
+	return changes, nil
+}
+
+// SaveDNSQuery records one DNS query, evicting the oldest beyond
+// memDNSCap.
+func (m *MemoryStore) SaveDNSQuery(_ context.Context, q *pb.DNSQuery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dns = append(m.dns, proto.Clone(q).(*pb.DNSQuery))
+	if len(m.dns) > memDNSCap {
+		m.dns = slices.Delete(m.dns, 0, len(m.dns)-memDNSCap)
+	}
+	return nil
+}
+
+// QueryDNSHistory returns a container's DNS queries matching params,
+// newest first.
+func (m *MemoryStore) QueryDNSHistory(_ context.Context, params DNSQueryParams) ([]*pb.DNSQuery, error) {
+	if params.ContainerName == "" {
+		return nil, fmt.Errorf("container name is required")
+	}
+	qname := strings.ToLower(params.QName)
+	limit := dnsQueryLimit(params.Limit)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []*pb.DNSQuery
+	for i := len(m.dns) - 1; i >= 0 && len(out) < limit; i-- {
+		q := m.dns[i]
+		at := q.Timestamp.AsTime()
+		switch {
+		case q.ContainerName != params.ContainerName,
+			at.Before(params.StartTime), at.After(params.EndTime),
+			qname != "" && !strings.Contains(q.Qname, qname),
+			params.AnswerIP != "" && !slices.Contains(q.Answers, params.AnswerIP):
+			continue
+		}
+		out = append(out, proto.Clone(q).(*pb.DNSQuery))
+	}
+	return out, nil
+}
+
+// SaveListenerChange records one listener change, evicting the oldest
+// beyond memListenerCap.
+func (m *MemoryStore) SaveListenerChange(_ context.Context, change *pb.ListenerChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listenerChanges = append(m.listenerChanges, proto.Clone(change).(*pb.ListenerChange))
+	if len(m.listenerChanges) > memListenerCap {
+		m.listenerChanges = slices.Delete(m.listenerChanges, 0, len(m.listenerChanges)-memListenerCap)
+	}
+	return nil
+}
+
+// QueryListenerHistory returns a container's listener changes matching
+// params, newest first.
+func (m *MemoryStore) QueryListenerHistory(_ context.Context, params ListenerQueryParams) ([]*pb.ListenerChange, error) {
+	if params.ContainerName == "" {
+		return nil, fmt.Errorf("container name is required")
+	}
+	limit := listenerQueryLimit(params.Limit)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []*pb.ListenerChange
+	for i := len(m.listenerChanges) - 1; i >= 0 && len(out) < limit; i-- {
+		c := m.listenerChanges[i]
+		at := c.Timestamp.AsTime()
+		if c.Listener.GetContainerName() != params.ContainerName || at.Before(params.StartTime) || at.After(params.EndTime) {
+			continue
+		}
+		out = append(out, proto.Clone(c).(*pb.ListenerChange))
+	}
+	return out, nil
+}
+
+// SaveSSHSession records a session, or sets the end of one recorded at
+// login, evicting the oldest beyond memSSHSessionCap.
+func (m *MemoryStore) SaveSSHSession(_ context.Context, sess *pb.SSHSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, have := range m.sshSessions {
+		if have.ContainerName == sess.ContainerName && have.ClientIp == sess.ClientIp &&
+			have.ClientPort == sess.ClientPort && have.StartedAt.AsTime().Equal(sess.StartedAt.AsTime()) {
+			if sess.EndedAt != nil {
+				have.EndedAt = timestamppb.New(sess.EndedAt.AsTime())
+			}
+			return nil
+		}
+	}
+	m.sshSessions = append(m.sshSessions, proto.Clone(sess).(*pb.SSHSession))
+	if len(m.sshSessions) > memSSHSessionCap {
+		m.sshSessions = slices.Delete(m.sshSessions, 0, len(m.sshSessions)-memSSHSessionCap)
+	}
+	return nil
+}
+
+// QuerySSHSessions returns a container's SSH sessions active within
+// params' range, newest first.
+func (m *MemoryStore) QuerySSHSessions(_ context.Context, params SSHSessionParams) ([]*pb.SSHSession, error) {
+	if params.ContainerName == "" {
+		return nil, fmt.Errorf("container name is required")
+	}
+
+	m.mu.RLock()
+	var out []*pb.SSHSession
+	for _, sess := range m.sshSessions {
+		switch {
+		case sess.ContainerName != params.ContainerName,
+			params.ClientIP != "" && sess.ClientIp != params.ClientIP,
+			sess.StartedAt.AsTime().After(params.EndTime),
+			sess.EndedAt != nil && sess.EndedAt.AsTime().Before(params.StartTime):
+			continue
+		}
+		out = append(out, proto.Clone(sess).(*pb.SSHSession))
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(out, compareSSHSessions)
+	if limit := sshSessionLimit(params.Limit); len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// QueryByDestination groups the connections into params.Destination by
+// container, like the PostgreSQL store.
+func (m *MemoryStore) QueryByDestination(_ context.Context, params DestinationQueryParams) ([]*pb.DestinationContact, error) {
+	if !params.Destination.IsValid() {
+		return nil, fmt.Errorf("destination is required")
+	}
+
+	byContainer := make(map[string]*pb.DestinationContact)
+	m.mu.RLock()
+	for _, r := range m.rows() {
+		c := r.conn
+		started := c.FirstSeen.AsTime()
+		addr, err := netip.ParseAddr(c.DestIp)
+		switch {
+		case err != nil, !params.Destination.Contains(addr.Unmap()),
+			started.Before(params.StartTime), started.After(params.EndTime),
+			params.DestPort > 0 && int(c.DestPort) != params.DestPort:
+			continue
+		}
+		last := started
+		if c.LastSeen != nil {
+			last = c.LastSeen.AsTime()
+		}
+		contact, ok := byContainer[c.ContainerName]
+		if !ok {
+			contact = &pb.DestinationContact{
+				ContainerName: c.ContainerName,
+				FirstSeen:     timestamppb.New(started),
+				LastSeen:      timestamppb.New(last),
+			}
+			byContainer[c.ContainerName] = contact
+		}
+		w := int64(rowWeight(c))
+		contact.Username = max(contact.Username, c.Username)
+		contact.ConnectionCount += w
+		contact.BytesSent += c.BytesSent * w
+		contact.BytesReceived += c.BytesReceived * w
+		if started.Before(contact.FirstSeen.AsTime()) {
+			contact.FirstSeen = timestamppb.New(started)
+		}
+		if last.After(contact.LastSeen.AsTime()) {
+			contact.LastSeen = timestamppb.New(last)
+		}
+		if !slices.Contains(contact.DestIps, c.DestIp) {
+			contact.DestIps = append(contact.DestIps, c.DestIp)
+		}
+	}
+	m.mu.RUnlock()
+
+	contacts := make([]*pb.DestinationContact, 0, len(byContainer))
+	for _, c := range byContainer {
+		contacts = append(contacts, c)
+	}
+	sortContacts(contacts)
+	for _, c := range contacts {
+		c.DestIps = c.DestIps[:min(len(c.DestIps), destContactMaxIPs)]
+	}
+	return contacts, nil
+}
+
+// HealthCheck always succeeds; there is nothing to reach.
+func (m *MemoryStore) HealthCheck(context.Context) error {
+	return nil
+}
+
+// Len returns the number of stored connections.
+func (m *MemoryStore) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.n
+}
+
+var (
+	_ ConnectionStore        = (*MemoryStore)(nil)
+	_ ConnectionCheckpointer = (*MemoryStore)(nil)
+	_ HistoryStreamer        = (*MemoryStore)(nil)
+	_ StateChangeRecorder    = (*MemoryStore)(nil)
+	_ DNSRecorder            = (*MemoryStore)(nil)
+	_ DestinationQuerier     = (*MemoryStore)(nil)
+	_ ListenerRecorder       = (*MemoryStore)(nil)
+	_ SSHSessionRecorder     = (*MemoryStore)(nil)
+)
@@ -0,0 +1,329 @@
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func memConn(id, container, destIP string, destPort uint32, dir pb.TrafficDirection, start time.Time, open bool) *pb.Connection {
+	c := &pb.Connection{
+		Id:            id,
+		ContainerName: container,
+		Username:      strings.TrimSuffix(container, "-container"),
+		Protocol:      pb.Protocol_PROTOCOL_TCP,
+		SourceIp:      "10.0.3.10",
+		SourcePort:    40000,
+		DestIp:        destIP,
+		DestPort:      destPort,
+		Direction:     dir,
+		State:         pb.ConnectionState_CONNECTION_STATE_TIME_WAIT,
+		BytesSent:     100,
+		BytesReceived: 50,
+		FirstSeen:     timestamppb.New(start),
+	}
+	if !open {
+		c.LastSeen = timestamppb.New(start.Add(time.Minute))
+	}
+	return c
+}
+
+func TestMemoryStore_QueryFilters(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(0)
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+
+	for _, c := range []*pb.Connection{
+		memConn("1", "alice-container", "192.0.2.1", 443, egress, base, false),
+		memConn("2", "alice-container", "192.0.2.2", 80, egress, base.Add(time.Minute), false),
+		memConn("3", "bob-container", "192.0.2.1", 443, egress, base, false),
+	} {
+		if err := s.SaveConnection(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.CheckpointConnection(ctx, memConn("4", "alice-container", "192.0.2.1", 443, egress, base.Add(2*time.Minute), true)); err != nil {
+		t.Fatal(err)
+	}
+
+	window := QueryParams{ContainerNames: []string{"alice-container"}, StartTime: base.Add(-time.Hour), EndTime: base.Add(time.Hour)}
+
+	got, total, err := s.QueryConnections(ctx, window)
+	if err != nil || total != 2 || len(got) != 2 {
+		t.Fatalf("closed only: got %d/%d, %v", len(got), total, err)
+	}
+	if got[0].DestIp != "192.0.2.2" {
+		t.Errorf("want newest first, got %s", got[0].DestIp)
+	}
+
+	p := window
+	p.IncludeOpen = true
+	if _, total, _ := s.QueryConnections(ctx, p); total != 3 {
+		t.Errorf("include open: total %d, want 3", total)
+	}
+
+	p = window
+	p.DestIP, p.DestPort = "192.0.2.1", 443
+	if got, _, _ := s.QueryConnections(ctx, p); len(got) != 1 || got[0].ContainerName != "alice-container" {
+		t.Errorf("dest filter: %v", got)
+	}
+
+	p = window
+	p.State = pb.ConnectionState_CONNECTION_STATE_SYN_SENT
+	if _, total, _ := s.QueryConnections(ctx, p); total != 0 {
+		t.Errorf("state filter: total %d, want 0", total)
+	}
+
+	p = QueryParams{Username: "bob", StartTime: window.StartTime, EndTime: window.EndTime}
+	if got, _, _ := s.QueryConnections(ctx, p); len(got) != 1 || got[0].ContainerName != "bob-container" || got[0].Username != "bob" {
+		t.Errorf("username filter: %v", got)
+	}
+
+	p = window
+	p.ContainerNames = []string{"alice-container", "bob-container"}
+	if _, total, _ := s.QueryConnections(ctx, p); total != 3 {
+		t.Errorf("several containers: total %d, want 3", total)
+	}
+
+	p = QueryParams{ContainerNamePrefix: "ali", StartTime: window.StartTime, EndTime: window.EndTime}
+	if _, total, _ := s.QueryConnections(ctx, p); total != 2 {
+		t.Errorf("prefix filter: total %d, want 2", total)
+	}
+	p.ContainerNames = []string{"bob-container"}
+	if got, _, _ := s.QueryConnections(ctx, p); len(got) != 1 || got[0].ContainerName != "bob-container" {
+		t.Errorf("container name should override prefix: %v", got)
+	}
+
+	p = QueryParams{StartTime: window.StartTime, EndTime: window.EndTime}
+	if _, _, err := s.QueryConnections(ctx, p); err == nil {
+		t.Error("want an error without container name or username")
+	}
+
+	p = window
+	p.Limit, p.Offset = 1, 1
+	if got, total, _ := s.QueryConnections(ctx, p); total != 2 || len(got) != 1 || got[0].DestIp != "192.0.2.1" {
+		t.Errorf("pagination: %v (total %d)", got, total)
+	}
+}
+
+func TestMemoryStore_KeysetPaging(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(0)
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+	// Five connections, three sharing a start time so the ID breaks ties.
+	for i, start := range []time.Time{base, base, base, base.Add(time.Minute), base.Add(2 * time.Minute)} {
+		if err := s.SaveConnection(ctx, memConn(fmt.Sprint(i), "alice-container", "192.0.2.1", 443, egress, start, false)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := QueryParams{ContainerNames: []string{"alice-container"}, StartTime: base.Add(-time.Hour), EndTime: base.Add(time.Hour), Limit: 2}
+	var ids []int64
+	for page := 0; ; page++ {
+		got, total, err := s.QueryConnections(ctx, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page == 0 && total != 5 {
+			t.Errorf("first page total = %d, want 5", total)
+		}
+		if page > 0 && total != 0 {
+			t.Errorf("keyset page %d total = %d, want 0", page, total)
+		}
+		for _, c := range got {
+			ids = append(ids, c.Id)
+		}
+		if len(got) < p.Limit {
+			break
+		}
+		p.After = CursorAfter(got[len(got)-1])
+	}
+
+	all, _, _ := s.QueryConnections(ctx, QueryParams{ContainerNames: []string{"alice-container"}, StartTime: p.StartTime, EndTime: p.EndTime})
+	if len(ids) != len(all) {
+		t.Fatalf("paged %d rows, want %d", len(ids), len(all))
+	}
+	for i, c := range all {
+		if ids[i] != c.Id {
+			t.Fatalf("paged IDs %v differ from the single query's order at %d", ids, i)
+		}
+	}
+}
+
+func TestMemoryStore_AggregatesInTimezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	ctx := context.Background()
+	s := NewMemoryStore(0)
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+	// 14:00 and 16:00 UTC on 1 March straddle midnight in Tokyo (UTC+9).
+	for i, start := range []time.Time{
+		time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 16, 0, 0, 0, time.UTC),
+	} {
+		if err := s.SaveConnection(ctx, memConn(fmt.Sprint(i), "alice-container", "192.0.2.1", 443, egress, start, false)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := AggregateParams{
+		ContainerNames: []string{"alice-container"},
+		StartTime:      time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:        time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		Interval:       "1d",
+	}
+	if got, _ := s.GetAggregates(ctx, p); len(got) != 1 {
+		t.Errorf("UTC: %d daily buckets, want 1", len(got))
+	}
+
+	p.Timezone = "Asia/Tokyo"
+	got, err := s.GetAggregates(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Asia/Tokyo: %d daily buckets, want 2", len(got))
+	}
+	// Newest first, each starting at Tokyo midnight.
+	for i, want := range []time.Time{time.Date(2026, 3, 2, 0, 0, 0, 0, tokyo), time.Date(2026, 3, 1, 0, 0, 0, 0, tokyo)} {
+		if !got[i].Timestamp.AsTime().Equal(want) {
+			t.Errorf("bucket %d starts %v, want %v", i, got[i].Timestamp.AsTime().In(tokyo), want)
+		}
+	}
+
+	p.Timezone = "Nowhere/Special"
+	if _, err := s.GetAggregates(ctx, p); err == nil {
+		t.Error("unknown timezone accepted")
+	}
+}
+
+func TestMemoryStore_CheckpointThenFinalize(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(0)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	open := memConn("7", "alice-container", "192.0.2.1", 22, pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, start, true)
+	open.BytesSent = 4096
+	_ = s.CheckpointConnection(ctx, open)
+
+	closed := memConn("7", "alice-container", "192.0.2.1", 22, pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, start.Add(time.Hour), false)
+	closed.BytesSent = 10 // a restarted counter must not shrink the row
+	_ = s.SaveConnection(ctx, closed)
+
+	if s.Len() != 1 {
+		t.Fatalf("Len = %d, want the checkpoint finalized in place", s.Len())
+	}
+	got, _, _ := s.QueryConnections(ctx, QueryParams{ContainerNames: []string{"alice-container"}, StartTime: start.Add(-time.Hour), EndTime: start.Add(2 * time.Hour)})
+	if len(got) != 1 || got[0].BytesSent != 4096 || !got[0].StartedAt.AsTime().Equal(start) || got[0].EndedAt == nil {
+		t.Fatalf("finalized row = %v", got)
+	}
+
+	// A checkpoint arriving after the close leaves the row alone.
+	late := memConn("7", "alice-container", "192.0.2.1", 22, pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, start, true)
+	late.BytesSent = 99999
+	_ = s.CheckpointConnection(ctx, late)
+	got, _, _ = s.QueryConnections(ctx, QueryParams{ContainerNames: []string{"alice-container"}, StartTime: start.Add(-time.Hour), EndTime: start.Add(2 * time.Hour)})
+	if got[0].BytesSent != 4096 {
+		t.Errorf("late checkpoint changed a finalized row: %d", got[0].BytesSent)
+	}
+}
+
+func TestMemoryStore_RingEvictsOldest(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(2)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, id := range []string{"a", "b", "c"} {
+		_ = s.SaveConnection(ctx, memConn(id, "alice-container", "192.0.2.1", uint32(1000+i), pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, start.Add(time.Duration(i)*time.Minute), false))
+	}
+	if s.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", s.Len())
+	}
+	got, _, _ := s.QueryConnections(ctx, QueryParams{ContainerNames: []string{"alice-container"}, StartTime: start, EndTime: start.Add(time.Hour)})
+	if len(got) != 2 || got[0].DestPort != 1002 || got[1].DestPort != 1001 {
+		t.Errorf("after eviction: %v", got)
+	}
+}
+
+func TestMemoryStore_AggregatesAndCleanup(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(0)
+	hour := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	_ = s.SaveConnection(ctx, memConn("1", "alice-container", "192.0.2.1", 443, pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, hour.Add(5*time.Minute), false))
+	_ = s.SaveConnection(ctx, memConn("2", "alice-container", "192.0.2.1", 443, pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, hour.Add(50*time.Minute), false))
+	_ = s.SaveConnection(ctx, memConn("3", "alice-container", "192.0.2.9", 22, pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS, hour.Add(70*time.Minute), false))
+
+	params := AggregateParams{ContainerNames: []string{"alice-container"}, StartTime: hour.Add(-time.Hour), EndTime: hour.Add(3 * time.Hour), Interval: "1h"}
+	aggs, err := s.GetAggregates(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aggs) != 2 {
+		t.Fatalf("got %d hourly buckets, want 2", len(aggs))
+	}
+	first, second := aggs[1], aggs[0] // newest first
+	if !first.Timestamp.AsTime().Equal(hour) || first.ConnectionCount != 2 || first.BytesSent != 200 || first.EgressBytes != 300 {
+		t.Errorf("10:00 bucket = %+v", first)
+	}
+	if second.IngressBytes != 150 || second.EgressBytes != 0 {
+		t.Errorf("11:00 bucket = %+v", second)
+	}
+
+	params.Interval, params.GroupByDirection = "1d", true
+	aggs, _ = s.GetAggregates(ctx, params)
+	if len(aggs) != 2 {
+		t.Errorf("daily by direction: got %d rows, want 2", len(aggs))
+	}
+
+	// Several containers: one row per container and bucket, labelled.
+	_ = s.SaveConnection(ctx, memConn("4", "bob-container", "192.0.2.1", 443, pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, hour.Add(10*time.Minute), false))
+	params = AggregateParams{ContainerNames: []string{"alice-container", "bob-container"}, StartTime: hour.Add(-time.Hour), EndTime: hour.Add(3 * time.Hour), Interval: "1d"}
+	aggs, _ = s.GetAggregates(ctx, params)
+	byContainer := make(map[string]int32)
+	for _, a := range aggs {
+		byContainer[a.ContainerName] += a.ConnectionCount
+	}
+	if len(aggs) != 2 || byContainer["alice-container"] != 3 || byContainer["bob-container"] != 1 {
+		t.Errorf("per container: %v", aggs)
+	}
+
+	s.now = func() time.Time { return time.Now().AddDate(0, 0, 31) }
+	before := int64(s.Len())
+	if n, err := s.Cleanup(ctx, 30); err != nil || s.Len() != 0 || n != before {
+		t.Errorf("Cleanup = %d, %v: Len %d, want %d dropped", n, err, s.Len(), before)
+	}
+}
+
+// TestMemoryStore_CleanupKeepsCheckpointedConnections — an open connection
+// ages from its last checkpoint, not from when it was first stored.
+func TestMemoryStore_CleanupKeepsCheckpointedConnections(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(0)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+
+	_ = s.CheckpointConnection(ctx, memConn("open", "alice-container", "192.0.2.1", 22, egress, now, true))
+	_ = s.CheckpointConnection(ctx, memConn("stale", "alice-container", "192.0.2.2", 22, egress, now, true))
+	_ = s.SaveConnection(ctx, memConn("closed", "alice-container", "192.0.2.3", 443, egress, now, false))
+
+	now = now.AddDate(0, 0, 29)
+	_ = s.CheckpointConnection(ctx, memConn("open", "alice-container", "192.0.2.1", 22, egress, now, true))
+
+	now = now.AddDate(0, 0, 2)
+	if n, err := s.Cleanup(ctx, 30); err != nil || n != 2 {
+		t.Fatalf("Cleanup = %d, %v; want the stale checkpoint and the closed row dropped", n, err)
+	}
+	got, _, _ := s.QueryConnections(ctx, QueryParams{ContainerNames: []string{"alice-container"}, IncludeOpen: true, StartTime: now.AddDate(0, 0, -60), EndTime: now})
+	if len(got) != 1 || got[0].DestIp != "192.0.2.1" {
+		t.Errorf("kept %v, want only the connection checkpointed within retention", got)
+	}
+}
@@ -0,0 +1,717 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// migration is one step of the traffic schema. Steps run in version order,
+// each in its own transaction, and are recorded in schema_migrations so
+// every step runs exactly once per database.
+//
+// To change the schema, append a step with the next version; never edit
+// one that has shipped, since deployments that already applied it will
+// not run it again. down reverts the step for `containarium db migrate
+// --to`; a step without one can't be reverted. tables lists what the step
+// touches, for the row estimates `containarium db status` prints.
+type migration struct {
+	version int
+	name    string
+	sql     string
+	down    string
+	tables  []string
+}
+
+// migrations is the traffic schema history, oldest first.
+var migrations = []migration{
+	{version: 1, name: "initial schema", sql: schemaV1, tables: []string{
+		"traffic_connections", "traffic_connection_events", "dns_queries", "traffic_daily", "traffic_aggregates",
+	}},
+	{version: 2, name: "dest_ip prefix index", sql: `
+		-- QueryByDestination matches dest_ip <<= '<cidr>', which the
+		-- btree idx_traffic_dest_ip can't serve.
+		CREATE INDEX IF NOT EXISTS idx_traffic_dest_ip_gist
+			ON traffic_connections USING GIST (dest_ip inet_ops);
+	`, down: `
+		DROP INDEX IF EXISTS idx_traffic_dest_ip_gist;
+	`, tables: []string{"traffic_connections"}},
+	{version: 3, name: "listening ports", sql: `
+		-- Listeners that opened or closed between two scans of a
+		-- container (see ListenerScanInterval). Current listeners are
+		-- kept in memory only. Subject to Cleanup.
+		CREATE TABLE IF NOT EXISTS listening_ports (
+			id BIGSERIAL PRIMARY KEY,
+			container_name TEXT NOT NULL,
+			change SMALLINT NOT NULL,
+			protocol SMALLINT NOT NULL,
+			address TEXT NOT NULL,
+			port INTEGER NOT NULL,
+			process_name TEXT NOT NULL DEFAULT '',
+			pid INTEGER NOT NULL DEFAULT 0,
+			first_seen TIMESTAMP WITH TIME ZONE NOT NULL,
+			changed_at TIMESTAMP WITH TIME ZONE NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_listening_ports_container_time
+			ON listening_ports(container_name, changed_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_listening_ports_time
+			ON listening_ports(changed_at);
+	`, down: `
+		DROP TABLE IF EXISTS listening_ports;
+	`, tables: []string{"listening_ports"}},
+	{version: 4, name: "closed history indexes", sql: `
+		-- QueryConnections reads closed connections newest first, paging
+		-- by (started_at, id). Partial indexes over closed rows serve
+		-- that order directly, per container and per username, so an
+		-- unfiltered page stops after LIMIT rows instead of sorting the
+		-- range.
+		CREATE INDEX IF NOT EXISTS idx_traffic_closed_container_time
+			ON traffic_connections(container_name, started_at DESC, id DESC)
+			WHERE ended_at IS NOT NULL;
+		CREATE INDEX IF NOT EXISTS idx_traffic_closed_username_time
+			ON traffic_connections(username, started_at DESC, id DESC)
+			WHERE ended_at IS NOT NULL;
+	`, down: `
+		DROP INDEX IF EXISTS idx_traffic_closed_container_time;
+		DROP INDEX IF EXISTS idx_traffic_closed_username_time;
+	`, tables: []string{"traffic_connections"}},
+	{version: 5, name: "source_ip index", sql: `
+		-- QueryConnections without a container or username searches
+		-- every container by dest_ip or source_ip; both need an index.
+		CREATE INDEX IF NOT EXISTS idx_traffic_source_ip
+			ON traffic_connections(source_ip);
+	`, down: `
+		DROP INDEX IF EXISTS idx_traffic_source_ip;
+	`, tables: []string{"traffic_connections"}},
+	{version: 6, name: "container name prefix index", sql: `
+		-- QueryParams.ContainerNamePrefix matches container_name LIKE
+		-- 'prefix%'. A btree only serves LIKE under the C collation or
+		-- with text_pattern_ops, so idx_traffic_container_time can't.
+		CREATE INDEX IF NOT EXISTS idx_traffic_container_prefix
+			ON traffic_connections(container_name text_pattern_ops, started_at DESC);
+	`, down: `
+		DROP INDEX IF EXISTS idx_traffic_container_prefix;
+	`, tables: []string{"traffic_connections"}},
+	{version: 7, name: "ssh sessions", sql: `
+		-- SSH logins from the sshd auth log, written when the collector
+		-- follows it (SSHLogPath): once at login and again at logout,
+		-- when ended_at is set. Matched to port-22 connections when
+		-- read. Subject to Cleanup.
+		CREATE TABLE IF NOT EXISTS ssh_sessions (
+			id BIGSERIAL PRIMARY KEY,
+			container_name TEXT NOT NULL,
+			box_user TEXT NOT NULL,
+			client_ip INET NOT NULL,
+			client_port INTEGER NOT NULL,
+			auth_method TEXT NOT NULL DEFAULT '',
+			key_type TEXT NOT NULL DEFAULT '',
+			key_fingerprint TEXT NOT NULL DEFAULT '',
+			started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			ended_at TIMESTAMP WITH TIME ZONE,
+			UNIQUE (container_name, client_ip, client_port, started_at)
+		);
+		CREATE INDEX IF NOT EXISTS idx_ssh_sessions_container_time
+			ON ssh_sessions(container_name, started_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_ssh_sessions_time
+			ON ssh_sessions(started_at);
+	`, down: `
+		DROP TABLE IF EXISTS ssh_sessions;
+	`, tables: []string{"ssh_sessions"}},
+	{version: 8, name: "remote geoip", sql: `
+		-- Country and autonomous system of the remote end, looked up in
+		-- the daemon's GeoIP databases (--traffic-geoip-db) when the
+		-- connection closes. NULL when no database knew the address or
+		-- none was loaded; rows are never back-filled.
+		ALTER TABLE traffic_connections
+			ADD COLUMN IF NOT EXISTS remote_country TEXT,
+			ADD COLUMN IF NOT EXISTS remote_asn BIGINT,
+			ADD COLUMN IF NOT EXISTS remote_as_org TEXT;
+	`, down: `
+		ALTER TABLE traffic_connections
+			DROP COLUMN IF EXISTS remote_country,
+			DROP COLUMN IF EXISTS remote_asn,
+			DROP COLUMN IF EXISTS remote_as_org;
+	`, tables: []string{"traffic_connections"}},
+	{version: 9, name: "egress policy violations", sql: `
+		-- Egress outside the daemon's allowlist
+		-- (--traffic-egress-allowlist), flagged when the connection is
+		-- first seen. The partial index keeps "violations only" queries
+		-- cheap, since they should be rare.
+		ALTER TABLE traffic_connections
+			ADD COLUMN IF NOT EXISTS policy_violation BOOLEAN NOT NULL DEFAULT FALSE;
+		CREATE INDEX IF NOT EXISTS idx_traffic_policy_violations
+			ON traffic_connections(started_at DESC) WHERE policy_violation;
+	`, down: `
+		DROP INDEX IF EXISTS idx_traffic_policy_violations;
+		ALTER TABLE traffic_connections
+			DROP COLUMN IF EXISTS policy_violation;
+	`, tables: []string{"traffic_connections"}},
+	{version: 10, name: "connection updated_at", sql: `
+		-- When a connection row was last written: each checkpoint of a
+		-- still-open connection and its final save. Cleanup ages a row
+		-- from ended_at, or from this while it has none, so a long-lived
+		-- connection checkpointed since before the retention cutoff is
+		-- kept. Rows from before this step stay NULL and fall back to
+		-- created_at.
+		ALTER TABLE traffic_connections
+			ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+		ALTER TABLE traffic_connections
+			ALTER COLUMN updated_at SET DEFAULT NOW();
+	`, down: `
+		ALTER TABLE traffic_connections
+			DROP COLUMN IF EXISTS updated_at;
+	`, tables: []string{"traffic_connections"}},
+}
+
+// LatestSchemaVersion is the version a database is at once every
+// migration this build knows has been applied.
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// migrationLockID keys the advisory lock that serializes migrations when
+// several daemons share one database.
+const migrationLockID int64 = 0x74726166666963 // "traffic"
+
+// MigrateMode is what NewStore does about migrations the database hasn't
+// applied yet (the daemon's --migrate flag).
+type MigrateMode string
+
+const (
+	// MigrateAuto applies pending migrations when the store opens.
+	MigrateAuto MigrateMode = "auto"
+	// MigrateCheck refuses to open a database with pending migrations, so
+	// the operator applies them with `containarium db migrate` instead.
+	MigrateCheck MigrateMode = "check"
+	// MigrateOff opens the database without looking at its schema.
+	MigrateOff MigrateMode = "off"
+)
+
+// ParseMigrateMode validates a --migrate value. Empty means MigrateAuto.
+func ParseMigrateMode(s string) (MigrateMode, error) {
+	switch m := MigrateMode(s); m {
+	case "":
+		return MigrateAuto, nil
+	case MigrateAuto, MigrateCheck, MigrateOff:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid migrate mode %q: must be auto, check or off", s)
+	}
+}
+
+// ErrPendingMigrations is returned by NewStore in MigrateCheck mode when the
+// database is behind this build's schema.
+var ErrPendingMigrations = errors.New("traffic schema has pending migrations")
+
+// MigrationInfo describes one migration of the traffic schema.
+type MigrationInfo struct {
+	Version int
+	Name    string
+	// Tables the migration touches.
+	Tables []string
+	// Reversible is whether `db migrate --to` can revert it.
+	Reversible bool
+	// AppliedAt is when the migration ran, zero while it is pending.
+	AppliedAt time.Time
+}
+
+// SchemaStatus is where a database stands against this build's migrations.
+type SchemaStatus struct {
+	// Version is the highest applied migration, 0 for none.
+	Version int
+	// Latest is the highest migration this build knows.
+	Latest  int
+	Applied []MigrationInfo
+	Pending []MigrationInfo
+	// TableRows estimates the rows in each existing table a pending
+	// migration touches, from the planner statistics (pg_class.reltuples),
+	// so an operator can judge how long a migration will hold its locks.
+	// -1 for a table that has never been analyzed.
+	TableRows map[string]int64
+	// Unbaselined is set when the traffic tables exist but no migration is
+	// recorded: an install from before migrations were versioned, which
+	// `containarium db baseline` marks as current without re-running them.
+	Unbaselined bool
+}
+
+// MigrationStep is one migration MigrateTo runs, or would run.
+type MigrationStep struct {
+	Version int
+	Name    string
+	// Down is set when the step reverts the migration.
+	Down bool
+	SQL  string
+}
+
+// migrate brings the database up to the latest schema version, or in
+// MigrateCheck mode only verifies it is there. A database migrated by a
+// newer daemon is left alone: steps only ever add, so an older daemon can
+// keep using the tables it knows.
+func (s *Store) migrate(ctx context.Context, mode MigrateMode) error {
+	switch mode {
+	case MigrateOff:
+		return nil
+	case MigrateCheck:
+		status, err := s.SchemaStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if n := len(status.Pending); n > 0 {
+			return fmt.Errorf("%w: %d to apply (schema at version %d, this daemon expects %d); run `containarium db migrate` or start with --migrate=auto",
+				ErrPendingMigrations, n, status.Version, status.Latest)
+		}
+		return nil
+	}
+
+	if err := s.ensureMigrationsTable(ctx); err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if err := s.applyMigration(ctx, m); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+	}
+
+	version, err := s.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if latest := LatestSchemaVersion(); version > latest {
+		log.Printf("Warning: traffic schema is at version %d, newer than this daemon's %d", version, latest)
+	}
+	return nil
+}
+
+// ensureMigrationsTable creates schema_migrations if it doesn't exist.
+func (s *Store) ensureMigrationsTable(ctx context.Context) error {
+	if _, err := s.pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// applyMigration runs m unless it has already been applied. The advisory
+// lock is held for the transaction, so a concurrent daemon waits and then
+// sees the step as applied.
+func (s *Store) applyMigration(ctx context.Context, m migration) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+			return fmt.Errorf("failed to lock: %w", err)
+		}
+		var applied bool
+		if err := tx.QueryRow(ctx,
+			"SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", m.version,
+		).Scan(&applied); err != nil {
+			return fmt.Errorf("failed to check: %w", err)
+		}
+		if applied {
+			return nil
+		}
+		if _, err := tx.Exec(ctx, m.sql); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx,
+			"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.version, m.name,
+		); err != nil {
+			return fmt.Errorf("failed to record: %w", err)
+		}
+		log.Printf("Applied traffic schema migration %d (%s)", m.version, m.name)
+		return nil
+	})
+}
+
+// SchemaVersion returns the highest applied migration version, 0 for a
+// database that has none.
+func (s *Store) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	if err := s.pool.QueryRow(ctx,
+		"SELECT COALESCE(MAX(version), 0) FROM schema_migrations",
+	).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// SchemaStatus reports the applied and pending migrations without changing
+// anything, not even creating schema_migrations.
+func (s *Store) SchemaStatus(ctx context.Context) (*SchemaStatus, error) {
+	applied, err := s.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := &SchemaStatus{Latest: LatestSchemaVersion(), TableRows: make(map[string]int64)}
+	for _, m := range migrations {
+		info := MigrationInfo{Version: m.version, Name: m.name, Tables: m.tables, Reversible: m.down != ""}
+		at, ok := applied[m.version]
+		if !ok {
+			status.Pending = append(status.Pending, info)
+			continue
+		}
+		info.AppliedAt = at
+		status.Applied = append(status.Applied, info)
+	}
+	for v := range applied {
+		status.Version = max(status.Version, v)
+	}
+
+	var tables []string
+	for _, m := range status.Pending {
+		tables = append(tables, m.Tables...)
+	}
+	slices.Sort(tables)
+	rows, err := s.pool.Query(ctx, `
+		SELECT c.relname, c.reltuples::BIGINT
+		FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema() AND c.relkind = 'r' AND c.relname = ANY($1)
+	`, slices.Compact(tables))
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate table sizes: %w", err)
+	}
+	for rows.Next() {
+		var name string
+		var n int64
+		if err := rows.Scan(&name, &n); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to estimate table sizes: %w", err)
+		}
+		status.TableRows[name] = n
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to estimate table sizes: %w", err)
+	}
+
+	if status.Version == 0 {
+		if err := s.pool.QueryRow(ctx,
+			"SELECT to_regclass('traffic_connections') IS NOT NULL",
+		).Scan(&status.Unbaselined); err != nil {
+			return nil, fmt.Errorf("failed to look for traffic tables: %w", err)
+		}
+	}
+	return status, nil
+}
+
+// appliedMigrations maps each recorded migration version to when it ran.
+// A database without schema_migrations has none.
+func (s *Store) appliedMigrations(ctx context.Context) (map[int]time.Time, error) {
+	applied := make(map[int]time.Time)
+	var exists bool
+	if err := s.pool.QueryRow(ctx,
+		"SELECT to_regclass('schema_migrations') IS NOT NULL",
+	).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to look for schema_migrations: %w", err)
+	}
+	if !exists {
+		return applied, nil
+	}
+	rows, err := s.pool.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v int
+		var at time.Time
+		if err := rows.Scan(&v, &at); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[v] = at
+	}
+	return applied, rows.Err()
+}
+
+// planMigration lists the steps that take a database with the applied
+// versions to target: pending migrations up to target in order, then — if
+// target is below what's applied — reverts newest first. It fails on a
+// revert of a migration without down SQL.
+func planMigration(applied map[int]bool, target int) ([]MigrationStep, error) {
+	if latest := LatestSchemaVersion(); target < 0 || target > latest {
+		return nil, fmt.Errorf("invalid target version %d: must be between 0 and %d", target, latest)
+	}
+	var steps []MigrationStep
+	for _, m := range migrations {
+		if m.version <= target && !applied[m.version] {
+			steps = append(steps, MigrationStep{Version: m.version, Name: m.name, SQL: m.sql})
+		}
+	}
+	for _, m := range slices.Backward(migrations) {
+		if m.version <= target || !applied[m.version] {
+			continue
+		}
+		if m.down == "" {
+			return nil, fmt.Errorf("migration %d (%s) cannot be reverted", m.version, m.name)
+		}
+		steps = append(steps, MigrationStep{Version: m.version, Name: m.name, Down: true, SQL: m.down})
+	}
+	return steps, nil
+}
+
+// MigrateTo applies or reverts migrations until the database is at target,
+// each step in its own transaction. With dryRun nothing is executed; either
+// way it returns the steps, with their SQL, in the order they run.
+func (s *Store) MigrateTo(ctx context.Context, target int, dryRun bool) ([]MigrationStep, error) {
+	applied, err := s.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[int]bool, len(applied))
+	for v := range applied {
+		done[v] = true
+	}
+	steps, err := planMigration(done, target)
+	if err != nil || dryRun {
+		return steps, err
+	}
+
+	if err := s.ensureMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]migration, len(migrations))
+	for _, m := range migrations {
+		byVersion[m.version] = m
+	}
+	for i, step := range steps {
+		m := byVersion[step.Version]
+		if step.Down {
+			err = s.revertMigration(ctx, m)
+		} else {
+			err = s.applyMigration(ctx, m)
+		}
+		if err != nil {
+			return steps[:i], fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+	}
+	return steps, nil
+}
+
+// revertMigration runs m's down SQL and forgets it was applied, under the
+// same advisory lock as applyMigration.
+func (s *Store) revertMigration(ctx context.Context, m migration) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+			return fmt.Errorf("failed to lock: %w", err)
+		}
+		tag, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", m.version)
+		if err != nil {
+			return fmt.Errorf("failed to unrecord: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+		if _, err := tx.Exec(ctx, m.down); err != nil {
+			return err
+		}
+		log.Printf("Reverted traffic schema migration %d (%s)", m.version, m.name)
+		return nil
+	})
+}
+
+// Baseline records migrations 1 through version as applied without running
+// them, for a database whose schema already matches: one created before
+// migrations were versioned, or migrated by hand.
+func (s *Store) Baseline(ctx context.Context, version int) error {
+	if latest := LatestSchemaVersion(); version < 1 || version > latest {
+		return fmt.Errorf("invalid baseline version %d: must be between 1 and %d", version, latest)
+	}
+	if err := s.ensureMigrationsTable(ctx); err != nil {
+		return err
+	}
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+			return fmt.Errorf("failed to lock: %w", err)
+		}
+		for _, m := range migrations[:version] {
+			if _, err := tx.Exec(ctx,
+				"INSERT INTO schema_migrations (version, name) VALUES ($1, $2) ON CONFLICT (version) DO NOTHING",
+				m.version, m.name,
+			); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", m.version, err)
+			}
+		}
+		return nil
+	})
+}
+
+// schemaV1 is the schema as it stood when migrations were introduced. It
+// is written to be idempotent, so deployments created before then (which
+// already have these tables) record it as applied without changes.
+const schemaV1 = `
+	-- Connection history table for long-term storage
+	CREATE TABLE IF NOT EXISTS traffic_connections (
+		id BIGSERIAL PRIMARY KEY,
+		container_name TEXT NOT NULL,
+		protocol SMALLINT NOT NULL,
+		source_ip INET NOT NULL,
+		source_port INTEGER,
+		dest_ip INET NOT NULL,
+		dest_port INTEGER,
+		direction SMALLINT NOT NULL,
+		bytes_sent BIGINT NOT NULL DEFAULT 0,
+		bytes_received BIGINT NOT NULL DEFAULT 0,
+		packets_sent BIGINT NOT NULL DEFAULT 0,
+		packets_received BIGINT NOT NULL DEFAULT 0,
+		started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+		ended_at TIMESTAMP WITH TIME ZONE,
+		duration_seconds INTEGER,
+		conntrack_id TEXT,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+	);
+
+	-- Indexes for common query patterns
+	CREATE INDEX IF NOT EXISTS idx_traffic_container_time
+		ON traffic_connections(container_name, started_at DESC);
+	CREATE INDEX IF NOT EXISTS idx_traffic_dest_ip
+		ON traffic_connections(dest_ip);
+	CREATE INDEX IF NOT EXISTS idx_traffic_dest_port
+		ON traffic_connections(dest_port);
+	CREATE INDEX IF NOT EXISTS idx_traffic_started_at
+		ON traffic_connections(started_at DESC);
+	CREATE INDEX IF NOT EXISTS idx_traffic_conntrack_id
+		ON traffic_connections(conntrack_id);
+
+	-- Stable per-connection key so a long-lived connection can be
+	-- checkpointed while open and finalized in place on close. Rows
+	-- written before the column existed keep a NULL key, which the
+	-- unique index treats as distinct.
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS conn_key TEXT;
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_traffic_conn_key
+		ON traffic_connections(conn_key);
+	CREATE INDEX IF NOT EXISTS idx_traffic_open
+		ON traffic_connections(container_name) WHERE ended_at IS NULL;
+
+	-- TCP state at close and the inferred close reason, so refused or
+	-- unanswered connections can be told apart from completed ones.
+	-- NULL for rows written before the columns existed and for
+	-- still-open checkpoints.
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS final_state SMALLINT;
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS close_reason TEXT;
+	CREATE INDEX IF NOT EXISTS idx_traffic_final_state
+		ON traffic_connections(container_name, final_state);
+
+	-- Conntrack zone of the flow. Boxes isolated in separate zones can
+	-- reuse the same private addresses, so identical tuples in different
+	-- zones are different connections. Existing rows are default-zone.
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS zone INTEGER NOT NULL DEFAULT 0;
+
+	-- Owning username, so history can be queried by "alice" rather than
+	-- the derived container name. Rows written before the column existed
+	-- keep '' and are matched through their container name instead (see
+	-- usernameFilter).
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS username TEXT NOT NULL DEFAULT '';
+	CREATE INDEX IF NOT EXISTS idx_traffic_username_time
+		ON traffic_connections(username, started_at DESC);
+
+	-- Billing watermark: how much of each connection's bytes has already
+	-- been added to traffic_daily. Rows from before the rollup existed keep
+	-- NULL and are only billed by the one-shot backfill; the defaults are
+	-- set after the columns exist so they apply to new rows only.
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS billed_sent BIGINT;
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS billed_received BIGINT;
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS billed_at TIMESTAMP WITH TIME ZONE;
+	ALTER TABLE traffic_connections ALTER COLUMN billed_sent SET DEFAULT 0;
+	ALTER TABLE traffic_connections ALTER COLUMN billed_received SET DEFAULT 0;
+	CREATE INDEX IF NOT EXISTS idx_traffic_unbilled
+		ON traffic_connections(id)
+		WHERE billed_at IS NULL OR bytes_sent > billed_sent OR bytes_received > billed_received;
+
+	-- How many flows a row stands for when the collector samples short
+	-- flows 1-in-N (see SamplingConfig). Aggregates and billing multiply
+	-- by it; every row written before sampling existed is a single flow.
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS sample_weight INTEGER NOT NULL DEFAULT 1;
+
+	-- Every TCP state a connection passed through, written only when
+	-- the collector records state changes (RecordStateChanges). Keyed
+	-- like traffic_connections.conntrack_id; subject to Cleanup.
+	CREATE TABLE IF NOT EXISTS traffic_connection_events (
+		id BIGSERIAL PRIMARY KEY,
+		container_name TEXT NOT NULL,
+		conntrack_id TEXT NOT NULL,
+		state SMALLINT NOT NULL,
+		observed_at TIMESTAMP WITH TIME ZONE NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_traffic_conn_events_conn
+		ON traffic_connection_events(container_name, conntrack_id, observed_at);
+	CREATE INDEX IF NOT EXISTS idx_traffic_conn_events_time
+		ON traffic_connection_events(observed_at);
+
+	-- Name the container resolved dest_ip from, when DNS logging is on.
+	ALTER TABLE traffic_connections ADD COLUMN IF NOT EXISTS dest_hostname TEXT NOT NULL DEFAULT '';
+
+	-- DNS queries containers made through the bridge resolver, written
+	-- only when the collector follows its log (DNSLogPath). Join to
+	-- traffic_connections on container_name, dest_ip = ANY(answers)
+	-- and queried_at shortly before started_at. Subject to Cleanup.
+	CREATE TABLE IF NOT EXISTS dns_queries (
+		id BIGSERIAL PRIMARY KEY,
+		container_name TEXT NOT NULL,
+		client_ip INET NOT NULL,
+		qname TEXT NOT NULL,
+		qtype TEXT NOT NULL,
+		answers INET[] NOT NULL DEFAULT '{}',
+		status TEXT NOT NULL DEFAULT '',
+		queried_at TIMESTAMP WITH TIME ZONE NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_dns_queries_container_time
+		ON dns_queries(container_name, queried_at DESC);
+	CREATE INDEX IF NOT EXISTS idx_dns_queries_answers
+		ON dns_queries USING GIN (answers);
+	CREATE INDEX IF NOT EXISTS idx_dns_queries_time
+		ON dns_queries(queried_at);
+
+	-- Daily per-container billing rollup (see RollupDailyUsage). Not
+	-- subject to Cleanup: it outlives the connection history it was
+	-- built from.
+	CREATE TABLE IF NOT EXISTS traffic_daily (
+		container_name TEXT NOT NULL,
+		day DATE NOT NULL,
+		username TEXT NOT NULL DEFAULT '',
+		bytes_in BIGINT NOT NULL DEFAULT 0,
+		bytes_out BIGINT NOT NULL DEFAULT 0,
+		external_bytes_in BIGINT NOT NULL DEFAULT 0,
+		external_bytes_out BIGINT NOT NULL DEFAULT 0,
+		connection_count BIGINT NOT NULL DEFAULT 0,
+		peak_connections INTEGER NOT NULL DEFAULT 0,
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (container_name, day)
+	);
+	CREATE INDEX IF NOT EXISTS idx_traffic_daily_day
+		ON traffic_daily(day);
+
+	-- Aggregated traffic stats table (for faster time-series queries)
+	CREATE TABLE IF NOT EXISTS traffic_aggregates (
+		id BIGSERIAL PRIMARY KEY,
+		container_name TEXT NOT NULL,
+		dest_ip INET,
+		dest_port INTEGER,
+		interval_start TIMESTAMP WITH TIME ZONE NOT NULL,
+		interval_end TIMESTAMP WITH TIME ZONE NOT NULL,
+		bytes_sent BIGINT NOT NULL DEFAULT 0,
+		bytes_received BIGINT NOT NULL DEFAULT 0,
+		connection_count INTEGER NOT NULL DEFAULT 0,
+		UNIQUE(container_name, dest_ip, dest_port, interval_start)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_traffic_agg_container_time
+		ON traffic_aggregates(container_name, interval_start DESC);
+
+	-- Direction becomes part of the aggregate key so ingress and egress
+	-- are summed separately. Rows written before the column existed had
+	-- no direction and stay UNSPECIFIED (0). The dropped constraint is
+	-- the table's original UNIQUE, under PostgreSQL's generated name.
+	ALTER TABLE traffic_aggregates ADD COLUMN IF NOT EXISTS direction SMALLINT NOT NULL DEFAULT 0;
+	ALTER TABLE traffic_aggregates
+		DROP CONSTRAINT IF EXISTS traffic_aggregates_container_name_dest_ip_dest_port_interva_key;
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_traffic_agg_key
+		ON traffic_aggregates(container_name, dest_ip, dest_port, direction, interval_start);
+`
@@ -0,0 +1,94 @@
+package traffic
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Versions are what schema_migrations records, so a gap or reordering
+// would skip a step on some databases or run one twice on others.
+func TestMigrations_ContiguousFromOne(t *testing.T) {
+	if len(migrations) == 0 {
+		t.Fatal("no migrations")
+	}
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("migrations[%d].version = %d, want %d", i, m.version, i+1)
+		}
+		if m.name == "" || m.sql == "" {
+			t.Errorf("migration %d has an empty name or body", m.version)
+		}
+	}
+}
+
+func TestPlanMigration(t *testing.T) {
+	latest := LatestSchemaVersion()
+	upTo := func(n int) map[int]bool {
+		applied := make(map[int]bool)
+		for v := 1; v <= n; v++ {
+			applied[v] = true
+		}
+		return applied
+	}
+	versions := func(steps []MigrationStep) (out []int) {
+		for _, s := range steps {
+			if s.Down {
+				out = append(out, -s.Version)
+			} else {
+				out = append(out, s.Version)
+			}
+		}
+		return out
+	}
+
+	steps, err := planMigration(upTo(latest-2), latest)
+	if err != nil || !slices.Equal(versions(steps), []int{latest - 1, latest}) {
+		t.Errorf("up to latest = %v, %v; want %d and %d applied", versions(steps), err, latest-1, latest)
+	}
+	steps, err = planMigration(upTo(latest), 2)
+	want := []int{}
+	for v := latest; v > 2; v-- {
+		want = append(want, -v)
+	}
+	if err != nil || !slices.Equal(versions(steps), want) {
+		t.Errorf("down to 2 = %v, %v; want %v", versions(steps), err, want)
+	}
+	if steps, err = planMigration(upTo(latest), latest); err != nil || len(steps) != 0 {
+		t.Errorf("at latest = %v, %v; want nothing to do", steps, err)
+	}
+	// A gap (applied by hand out of order) is filled in.
+	applied := upTo(latest)
+	delete(applied, 2)
+	if steps, err = planMigration(applied, latest); err != nil || !slices.Equal(versions(steps), []int{2}) {
+		t.Errorf("gap = %v, %v; want 2 applied", versions(steps), err)
+	}
+	if _, err := planMigration(upTo(latest), 0); err == nil || !strings.Contains(err.Error(), "cannot be reverted") {
+		t.Errorf("down to 0 = %v; want the initial schema to be irreversible", err)
+	}
+	for _, bad := range []int{-1, latest + 1} {
+		if _, err := planMigration(upTo(1), bad); err == nil {
+			t.Errorf("target %d accepted", bad)
+		}
+	}
+}
+
+// Every migration but the initial schema can be reverted with db migrate --to.
+func TestMigrations_Reversible(t *testing.T) {
+	for _, m := range migrations[1:] {
+		if m.down == "" || len(m.tables) == 0 {
+			t.Errorf("migration %d has no down SQL or no tables", m.version)
+		}
+	}
+}
+
+func TestParseMigrateMode(t *testing.T) {
+	for in, want := range map[string]MigrateMode{"": MigrateAuto, "auto": MigrateAuto, "check": MigrateCheck, "off": MigrateOff} {
+		if got, err := ParseMigrateMode(in); err != nil || got != want {
+			t.Errorf("ParseMigrateMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseMigrateMode("yes"); err == nil {
+		t.Error(`ParseMigrateMode("yes") accepted`)
+	}
+}
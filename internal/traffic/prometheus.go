@@ -0,0 +1,80 @@
+package traffic
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Per-container traffic series. They carry the container name, so they are
+// served apart from the daemon's operational metrics (opmetrics), whose
+// labels never name a tenant.
+var (
+	containerBytesSentDesc = prometheus.NewDesc(
+		"containarium_container_bytes_sent_total",
+		"Bytes the container sent since the daemon started.",
+		[]string{"container"}, nil)
+	containerBytesReceivedDesc = prometheus.NewDesc(
+		"containarium_container_bytes_received_total",
+		"Bytes the container received since the daemon started.",
+		[]string{"container"}, nil)
+	containerPacketsSentDesc = prometheus.NewDesc(
+		"containarium_container_packets_sent_total",
+		"Packets the container sent since the daemon started.",
+		[]string{"container"}, nil)
+	containerPacketsReceivedDesc = prometheus.NewDesc(
+		"containarium_container_packets_received_total",
+		"Packets the container received since the daemon started.",
+		[]string{"container"}, nil)
+	containerConnectionsDesc = prometheus.NewDesc(
+		"containarium_container_connections_total",
+		"Connections the container took part in since the daemon started.",
+		[]string{"container"}, nil)
+	containerBlockedConnectionsDesc = prometheus.NewDesc(
+		"containarium_container_blocked_connections_total",
+		"Connection attempts the container's network policy dropped since the daemon started.",
+		[]string{"container"}, nil)
+)
+
+// containerMetrics exports ContainerTotals as counters, read at scrape.
+type containerMetrics struct {
+	collector *Collector
+}
+
+// Describe implements prometheus.Collector.
+func (m containerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- containerBytesSentDesc
+	ch <- containerBytesReceivedDesc
+	ch <- containerPacketsSentDesc
+	ch <- containerPacketsReceivedDesc
+	ch <- containerConnectionsDesc
+	ch <- containerBlockedConnectionsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (m containerMetrics) Collect(ch chan<- prometheus.Metric) {
+	for name, t := range m.collector.ContainerTotals() {
+		for desc, v := range map[*prometheus.Desc]int64{
+			containerBytesSentDesc:          t.BytesSent,
+			containerBytesReceivedDesc:      t.BytesReceived,
+			containerPacketsSentDesc:        t.PacketsSent,
+			containerPacketsReceivedDesc:    t.PacketsReceived,
+			containerConnectionsDesc:        t.Connections,
+			containerBlockedConnectionsDesc: t.BlockedConnections,
+		} {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(v), name)
+		}
+	}
+}
+
+// MetricsHandler serves the per-container traffic counters in the
+// Prometheus exposition format. The counters restart from zero with the
+// daemon, which rate() and increase() treat as a counter reset. One series
+// per container per counter: a container the host no longer lists drops
+// out once its last flow closes.
+func (c *Collector) MetricsHandler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(containerMetrics{collector: c})
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
@@ -0,0 +1,68 @@
+package traffic
+
+import (
+	"container/list"
+	"sync"
+)
+
+// recentlyPersistedSize bounds the recently-persisted set. A flapping flow
+// repeats its DESTROY within moments, so a few thousand closes of history
+// is plenty on even a busy host.
+const recentlyPersistedSize = 8192
+
+// recentKeys is a bounded LRU set of connection keys the collector has
+// already written. Conntrack can report a flow's DESTROY more than once
+// (a flow that flaps, or an event replayed after a netlink overrun); the
+// store would absorb the repeat with ON CONFLICT, but only after a round
+// trip. Keys are connectionKey values rather than bare conntrack IDs, which
+// the kernel recycles. A nil *recentKeys remembers nothing.
+type recentKeys struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List // front = most recent; values are keys
+	index map[string]*list.Element
+}
+
+func newRecentKeys(max int) *recentKeys {
+	return &recentKeys{
+		max:   max,
+		order: list.New(),
+		index: make(map[string]*list.Element, max),
+	}
+}
+
+// add records key and reports whether it was new. A key already present
+// is refreshed to most recent.
+func (r *recentKeys) add(key string) bool {
+	if r == nil {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.index[key]; ok {
+		r.order.MoveToFront(el)
+		return false
+	}
+	r.index[key] = r.order.PushFront(key)
+	if r.order.Len() > r.max {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.index, oldest.Value.(string))
+	}
+	return true
+}
+
+// remove forgets key, so a write that failed can be retried.
+func (r *recentKeys) remove(key string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.index[key]; ok {
+		r.order.Remove(el)
+		delete(r.index, key)
+	}
+}
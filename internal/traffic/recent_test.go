@@ -0,0 +1,104 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestRecentKeys_EvictsLeastRecent(t *testing.T) {
+	r := newRecentKeys(2)
+	if !r.add("a") || !r.add("b") {
+		t.Fatal("fresh keys reported as seen")
+	}
+	if r.add("a") {
+		t.Error("repeated key reported as new")
+	}
+	r.add("c") // evicts b, the least recently used
+	if !r.add("b") {
+		t.Error("evicted key still remembered")
+	}
+	if r.add("c") {
+		t.Error("recent key forgotten")
+	}
+
+	r.remove("c")
+	if !r.add("c") {
+		t.Error("removed key still remembered")
+	}
+
+	var none *recentKeys
+	if !none.add("a") || !none.add("a") {
+		t.Error("nil set deduplicated")
+	}
+}
+
+// failingConnectionStore fails every save after signalling it.
+type failingConnectionStore struct{ *fakeConnectionStore }
+
+func (f failingConnectionStore) SaveConnection(context.Context, *pb.Connection) error {
+	f.done <- struct{}{}
+	return errors.New("connection refused")
+}
+
+func TestProcessConntrackEvent_RepeatedDestroySavedOnce(t *testing.T) {
+	store := newFakeConnectionStore()
+	c := newStoreTestCollector(t, store)
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+
+	destroy := &ConntrackEvent{ID: "42", Type: ConntrackEventDestroy, Protocol: "tcp",
+		SrcIP: "10.100.0.5", SrcPort: 51000, DstIP: "1.1.1.1", DstPort: 443, Timestamp: time.Now()}
+	for range 3 {
+		c.processConntrackEvent(destroy)
+	}
+
+	select {
+	case <-store.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SaveConnection was not called")
+	}
+	select {
+	case <-store.done:
+		t.Fatal("a repeated DESTROY was saved again")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestProcessConntrackEvent_FailedSaveIsRetried(t *testing.T) {
+	store := failingConnectionStore{newFakeConnectionStore()}
+	c := newStoreTestCollector(t, store)
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+
+	destroy := &ConntrackEvent{ID: "42", Type: ConntrackEventDestroy, Protocol: "tcp",
+		SrcIP: "10.100.0.5", SrcPort: 51000, DstIP: "1.1.1.1", DstPort: 443, Timestamp: time.Now()}
+	for range 2 {
+		c.processConntrackEvent(destroy)
+		select {
+		case <-store.done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the DESTROY after a failed save was not retried")
+		}
+		waitForgotten(t, c.persisted)
+	}
+}
+
+// waitForgotten waits for a failed write's goroutine to drop its key.
+func waitForgotten(t *testing.T, r *recentKeys) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		n := len(r.index)
+		r.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("failed save left its key in the recently-persisted set")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
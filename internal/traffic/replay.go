@@ -0,0 +1,173 @@
+package traffic
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ReplayConfig configures re-emitting stored connections as live traffic
+// events, for exercising dashboards and alert rules without waiting for
+// real traffic. Test and staging use only: replayed events are
+// indistinguishable from live ones to subscribers apart from their
+// "replay-" connection IDs, so the daemon only enables this behind the
+// explicit --traffic-replay flag.
+type ReplayConfig struct {
+	// Params selects the rows to replay (ContainerName empty = all).
+	Params QueryParams
+
+	// Speed scales the pacing: 1 replays in real time, 60 plays an hour in
+	// a minute, and <= 0 emits everything back to back without waiting.
+	Speed float64
+}
+
+// Replay streams the connections selected by cfg from the store and
+// re-emits them through the events bus: a NEW event at each connection's
+// start and a DESTROY at its end, in timestamp order and paced by
+// cfg.Speed. Timestamps are shifted so the replayed history appears to
+// start now. Returns the number of events emitted; blocks until done or
+// ctx is cancelled.
+func (c *Collector) Replay(ctx context.Context, cfg ReplayConfig) (int, error) {
+	streamer, ok := c.store.(HistoryStreamer)
+	if !ok {
+		return 0, fmt.Errorf("traffic replay needs a traffic store that can stream history")
+	}
+	r := &replayer{
+		speed: cfg.Speed,
+		now:   time.Now,
+		wait:  waitCtx,
+		emit:  c.emitTrafficEvent,
+	}
+	n := r.run(ctx, streamer.StreamHistory(ctx, cfg.Params))
+	return n, ctx.Err()
+}
+
+// runReplay is the Start hook for CollectorConfig.Replay.
+func (c *Collector) runReplay() {
+	cfg := *c.config.Replay
+	log.Printf("WARNING: traffic replay enabled — re-emitting stored connections from %s to %s at %.1fx as live events",
+		cfg.Params.StartTime.Format(time.RFC3339), cfg.Params.EndTime.Format(time.RFC3339), cfg.Speed)
+	n, err := c.Replay(c.ctx, cfg)
+	if err != nil {
+		log.Printf("Warning: traffic replay stopped after %d events: %v", n, err)
+		return
+	}
+	log.Printf("Traffic replay finished: %d events emitted", n)
+}
+
+func waitCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// replayer merges the start-ordered connection stream with the pending
+// ends into one time-ordered event sequence. Clock, wait and emit are
+// fields so tests can drive it without sleeping.
+type replayer struct {
+	speed float64
+	now   func() time.Time
+	wait  func(ctx context.Context, d time.Duration) bool
+	emit  func(ConntrackEventType, *pb.Connection)
+
+	origin time.Time // first replayed timestamp
+	wall0  time.Time // wall clock when origin was emitted
+	ends   endHeap
+}
+
+func (r *replayer) run(ctx context.Context, in <-chan *pb.Connection) int {
+	emitted := 0
+	for conn := range in {
+		start := conn.GetFirstSeen().AsTime()
+		if r.wall0.IsZero() {
+			r.origin, r.wall0 = start, r.now()
+		}
+		// Ends that happened before this start go out first.
+		for len(r.ends) > 0 && !r.ends[0].GetLastSeen().AsTime().After(start) {
+			if !r.emitAt(ctx, ConntrackEventDestroy, heap.Pop(&r.ends).(*pb.Connection)) {
+				return emitted
+			}
+			emitted++
+		}
+		if !r.emitAt(ctx, ConntrackEventNew, conn) {
+			return emitted
+		}
+		emitted++
+		if conn.LastSeen != nil {
+			heap.Push(&r.ends, conn)
+		}
+	}
+	for len(r.ends) > 0 {
+		if !r.emitAt(ctx, ConntrackEventDestroy, heap.Pop(&r.ends).(*pb.Connection)) {
+			return emitted
+		}
+		emitted++
+	}
+	return emitted
+}
+
+// emitAt waits until the event's shifted time, then emits a copy of conn
+// with its timestamps shifted. A NEW event's LastSeen equals its FirstSeen,
+// as it does when conntrack reports a new flow live.
+func (r *replayer) emitAt(ctx context.Context, eventType ConntrackEventType, conn *pb.Connection) bool {
+	at := conn.GetFirstSeen().AsTime()
+	if eventType == ConntrackEventDestroy {
+		at = conn.GetLastSeen().AsTime()
+	}
+	if d := r.shift(at).Sub(r.now()); r.speed > 0 && d > 0 {
+		if !r.wait(ctx, d) {
+			return false
+		}
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+
+	out := proto.Clone(conn).(*pb.Connection)
+	out.FirstSeen = timestamppb.New(r.shift(conn.GetFirstSeen().AsTime()))
+	if eventType == ConntrackEventDestroy {
+		out.LastSeen = timestamppb.New(r.shift(at))
+	} else {
+		out.LastSeen = out.FirstSeen
+	}
+	r.emit(eventType, out)
+	return true
+}
+
+// shift maps an original timestamp onto the replay's wall clock.
+func (r *replayer) shift(t time.Time) time.Time {
+	offset := t.Sub(r.origin)
+	if r.speed > 0 {
+		offset = time.Duration(float64(offset) / r.speed)
+	}
+	return r.wall0.Add(offset)
+}
+
+// endHeap orders pending connections by their end time.
+type endHeap []*pb.Connection
+
+func (h endHeap) Len() int { return len(h) }
+func (h endHeap) Less(i, j int) bool {
+	return h[i].GetLastSeen().AsTime().Before(h[j].GetLastSeen().AsTime())
+}
+func (h endHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *endHeap) Push(x interface{}) { *h = append(*h, x.(*pb.Connection)) }
+func (h *endHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
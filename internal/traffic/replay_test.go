@@ -0,0 +1,95 @@
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// fakeClock advances only when the replayer waits, so pacing is observable
+// without sleeping.
+type fakeClock struct{ t time.Time }
+
+func (f *fakeClock) now() time.Time { return f.t }
+func (f *fakeClock) wait(_ context.Context, d time.Duration) bool {
+	f.t = f.t.Add(d)
+	return true
+}
+
+type emitted struct {
+	typ   ConntrackEventType
+	id    string
+	at    time.Time
+	first time.Time
+}
+
+func replayConns(t0 time.Time) []*pb.Connection {
+	at := func(sec int) *timestamppb.Timestamp { return timestamppb.New(t0.Add(time.Duration(sec) * time.Second)) }
+	return []*pb.Connection{
+		{Id: "a", FirstSeen: at(0), LastSeen: at(30)},
+		{Id: "b", FirstSeen: at(10), LastSeen: at(20)},
+		{Id: "c", FirstSeen: at(25)}, // still open when stored
+	}
+}
+
+func runReplayer(t *testing.T, speed float64, conns []*pb.Connection) ([]emitted, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var got []emitted
+	r := &replayer{
+		speed: speed,
+		now:   clock.now,
+		wait:  clock.wait,
+		emit: func(typ ConntrackEventType, c *pb.Connection) {
+			got = append(got, emitted{typ, c.Id, clock.now(), c.FirstSeen.AsTime()})
+		},
+	}
+	in := make(chan *pb.Connection, len(conns))
+	for _, c := range conns {
+		in <- c
+	}
+	close(in)
+	if n := r.run(context.Background(), in); n != len(got) {
+		t.Fatalf("run returned %d, emitted %d", n, len(got))
+	}
+	return got, clock
+}
+
+func TestReplayer_OrdersStartsAndEnds(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	got, _ := runReplayer(t, 0, replayConns(t0))
+
+	var seq []string
+	for _, e := range got {
+		kind := "new"
+		if e.typ == ConntrackEventDestroy {
+			kind = "end"
+		}
+		seq = append(seq, kind+":"+e.id)
+	}
+	want := "[new:a new:b end:b new:c end:a]"
+	if fmt.Sprint(seq) != want {
+		t.Errorf("event order = %v, want %s", seq, want)
+	}
+}
+
+func TestReplayer_PacesAndShiftsTimestamps(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	got, clock := runReplayer(t, 10, replayConns(t0))
+	start := got[0].at
+
+	// 30s of history at 10x takes 3s of wall time.
+	if elapsed := clock.now().Sub(start); elapsed != 3*time.Second {
+		t.Errorf("replay took %v of wall time, want 3s", elapsed)
+	}
+	// b starts 10s in, so 1s into the replay — and its FirstSeen is shifted
+	// onto the replay clock, not left in the past.
+	if got[1].id != "b" || got[1].at.Sub(start) != time.Second || !got[1].first.Equal(got[1].at) {
+		t.Errorf("b emitted at +%v with FirstSeen %v", got[1].at.Sub(start), got[1].first)
+	}
+}
@@ -0,0 +1,134 @@
+package traffic
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/netip"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/footprintai/containarium/internal/safecast"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// SamplingConfig thins out what the collector persists on hosts where
+// short flows (DNS lookups, health checks, crawlers) outnumber everything
+// else. Only one in Rate short flows is written, with sample_weight set to
+// Rate so aggregates and billing scale it back up; flows that matter on
+// their own are always kept at weight 1. The zero value keeps every flow.
+type SamplingConfig struct {
+	// Rate persists one in Rate short flows. 0 or 1 disables sampling.
+	Rate int
+
+	// MinBytes always keeps a flow whose total bytes reach it. 0 means no
+	// size exemption.
+	MinBytes int64
+
+	// KeepPorts always keeps flows to these destination ports (e.g. 22, or
+	// the ports alert rules watch), so rare-but-interesting flows are never
+	// sampled away.
+	KeepPorts []uint32
+
+	// KeepNets always keeps flows whose peer address falls in one of these
+	// prefixes.
+	KeepNets []netip.Prefix
+
+	// ContainerRates overrides Rate per container name; 1 exempts the
+	// container from sampling.
+	ContainerRates map[string]int
+}
+
+// Validate rejects a negative rate or size threshold and per-container
+// rates below 1.
+func (s SamplingConfig) Validate() error {
+	if s.Rate < 0 {
+		return fmt.Errorf("sample rate must not be negative, got %d", s.Rate)
+	}
+	if s.MinBytes < 0 {
+		return fmt.Errorf("sample min bytes must not be negative, got %d", s.MinBytes)
+	}
+	for name, rate := range s.ContainerRates {
+		if rate < 1 {
+			return fmt.Errorf("sample rate for container %s must be at least 1, got %d", name, rate)
+		}
+	}
+	return nil
+}
+
+// rateFor is the sampling rate that applies to a container's flows.
+func (s SamplingConfig) rateFor(containerName string) int {
+	if rate, ok := s.ContainerRates[containerName]; ok {
+		return max(rate, 1)
+	}
+	return max(s.Rate, 1)
+}
+
+// exempt reports whether conn is kept regardless of the rate: large flows,
+// flows matching a keep rule, and flows open for at least longLived (those
+// were already checkpointed in full, so dropping the final row would leave
+// history inconsistent).
+func (s SamplingConfig) exempt(conn *pb.Connection, longLived time.Duration) bool {
+	if s.MinBytes > 0 && conn.GetBytesSent()+conn.GetBytesReceived() >= s.MinBytes {
+		return true
+	}
+	if slices.Contains(s.KeepPorts, conn.GetDestPort()) {
+		return true
+	}
+	if len(s.KeepNets) > 0 {
+		peer := conn.GetDestIp()
+		if conn.GetDirection() == pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS {
+			peer = conn.GetSourceIp()
+		}
+		if addr, err := netip.ParseAddr(peer); err == nil {
+			for _, p := range s.KeepNets {
+				if p.Contains(addr.Unmap()) {
+					return true
+				}
+			}
+		}
+	}
+	if longLived > 0 && conn.GetFirstSeen() != nil && conn.GetLastSeen() != nil &&
+		conn.GetLastSeen().AsTime().Sub(conn.GetFirstSeen().AsTime()) >= longLived {
+		return true
+	}
+	return false
+}
+
+// sampleWeight decides whether a closed flow is persisted: 0 drops it, 1
+// keeps it as itself, and N keeps it standing in for N flows. The choice
+// hashes the flow ID, so a flow seen by both the eviction diff and the idle
+// reaper gets the same answer each time.
+func (s SamplingConfig) sampleWeight(conn *pb.Connection, longLived time.Duration) uint32 {
+	rate := s.rateFor(conn.GetContainerName())
+	if rate <= 1 || s.exempt(conn, longLived) {
+		return 1
+	}
+	weight := safecast.U32(rate)
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(conn.GetId()))
+	if h.Sum32()%weight != 0 {
+		return 0
+	}
+	return weight
+}
+
+// sample applies the sampling decision to a flow about to be persisted. It
+// returns the connection to save (a copy carrying the weight, since the
+// original is shared with event subscribers) or false to drop it.
+func (c *Collector) sample(conn *pb.Connection) (*pb.Connection, bool) {
+	c.mu.RLock()
+	sampling := c.config.Sampling
+	c.mu.RUnlock()
+	switch w := sampling.sampleWeight(conn, c.config.CheckpointAge); w {
+	case 0:
+		return nil, false
+	case 1:
+		return conn, true
+	default:
+		weighted := proto.Clone(conn).(*pb.Connection)
+		weighted.SampleWeight = w
+		return weighted, true
+	}
+}
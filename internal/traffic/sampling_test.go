@@ -0,0 +1,137 @@
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestSampleWeight_KeepsOneInNShortFlows(t *testing.T) {
+	s := SamplingConfig{Rate: 10}
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	kept := 0
+	for i := range 1000 {
+		c := memConn(fmt.Sprint(i), "alice-container", "192.0.2.1", 53, egress, base, false)
+		w := s.sampleWeight(c, 15*time.Minute)
+		switch w {
+		case 0:
+		case 10:
+			kept++
+		default:
+			t.Fatalf("flow %d: weight %d, want 0 or 10", i, w)
+		}
+		if again := s.sampleWeight(c, 15*time.Minute); again != w {
+			t.Fatalf("flow %d: weight %d then %d, want a stable decision", i, w, again)
+		}
+	}
+	if kept < 50 || kept > 150 {
+		t.Errorf("kept %d of 1000 flows at rate 10, want about 100", kept)
+	}
+}
+
+func TestSampleWeight_Exemptions(t *testing.T) {
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+	ingress := pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	s := SamplingConfig{
+		Rate:           1 << 30, // effectively drops every non-exempt flow
+		MinBytes:       1 << 20,
+		KeepPorts:      []uint32{22},
+		KeepNets:       []netip.Prefix{netip.MustParsePrefix("198.51.100.0/24")},
+		ContainerRates: map[string]int{"bob-container": 1},
+	}
+
+	large := memConn("large", "alice-container", "192.0.2.1", 443, egress, base, false)
+	large.BytesReceived = 2 << 20
+	ssh := memConn("ssh", "alice-container", "192.0.2.1", 22, egress, base, false)
+	watched := memConn("watched", "alice-container", "198.51.100.7", 443, egress, base, false)
+	inbound := memConn("inbound", "alice-container", "10.0.3.10", 8080, ingress, base, false)
+	inbound.SourceIp = "198.51.100.9"
+	long := memConn("long", "alice-container", "192.0.2.1", 443, egress, base, false)
+	long.LastSeen = timestamppb.New(base.Add(time.Hour))
+	exempt := memConn("exempt", "bob-container", "192.0.2.1", 443, egress, base, false)
+
+	for _, c := range []*pb.Connection{large, ssh, watched, inbound, long, exempt} {
+		if w := s.sampleWeight(c, 15*time.Minute); w != 1 {
+			t.Errorf("%s: weight %d, want 1 (always kept)", c.Id, w)
+		}
+	}
+
+	short := memConn("short", "alice-container", "192.0.2.1", 443, egress, base, false)
+	if w := s.sampleWeight(short, 15*time.Minute); w == 1 {
+		t.Errorf("short flow kept unweighted despite sampling")
+	}
+	if w := (SamplingConfig{}).sampleWeight(short, 0); w != 1 {
+		t.Errorf("zero config: weight %d, want 1", w)
+	}
+}
+
+func TestSamplingConfig_Validate(t *testing.T) {
+	for _, bad := range []SamplingConfig{
+		{Rate: -1},
+		{MinBytes: -1},
+		{ContainerRates: map[string]int{"alice-container": 0}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("%+v accepted", bad)
+		}
+	}
+	if err := (SamplingConfig{Rate: 100, ContainerRates: map[string]int{"alice-container": 1}}).Validate(); err != nil {
+		t.Errorf("valid config rejected: %v", err)
+	}
+}
+
+func TestMemoryStore_AggregatesScaleBySampleWeight(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(0)
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	egress := pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+
+	sampled := memConn("1", "alice-container", "192.0.2.1", 53, egress, base, false)
+	sampled.SampleWeight = 10
+	for _, c := range []*pb.Connection{
+		sampled,
+		memConn("2", "alice-container", "192.0.2.2", 443, egress, base, false),
+	} {
+		if err := s.SaveConnection(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	aggs, err := s.GetAggregates(ctx, AggregateParams{
+		ContainerNames: []string{"alice-container"},
+		StartTime:      base.Add(-time.Hour),
+		EndTime:        base.Add(time.Hour),
+		Interval:       "1h",
+	})
+	if err != nil || len(aggs) != 1 {
+		t.Fatalf("aggregates: %v, %v", aggs, err)
+	}
+	a := aggs[0]
+	if a.ConnectionCount != 11 || a.BytesSent != 1100 || a.BytesReceived != 550 || a.EgressBytes != 1650 {
+		t.Errorf("aggregate = %d conns, %d sent, %d received, %d egress; want 11, 1100, 550, 1650",
+			a.ConnectionCount, a.BytesSent, a.BytesReceived, a.EgressBytes)
+	}
+
+	hist, _, err := s.QueryConnections(ctx, QueryParams{ContainerNames: []string{"alice-container"}, StartTime: base.Add(-time.Hour), EndTime: base.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range hist {
+		want := uint32(1)
+		if h.DestPort == 53 {
+			want = 10
+		}
+		if h.SampleWeight != want {
+			t.Errorf("history row to port %d: sample weight %d, want %d", h.DestPort, h.SampleWeight, want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package traffic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// wellKnownServices is the built-in port map, in the /etc/services format
+// LoadServiceNames reads. Names follow IANA's registry except where the
+// registered name would puzzle a reader (53 is "dns", not "domain").
+const wellKnownServices = `
+ftp            21/tcp
+ssh            22/tcp
+telnet         23/tcp
+smtp           25/tcp
+dns            53/tcp
+dns            53/udp
+dhcp           67/udp
+dhcp-client    68/udp
+http           80/tcp
+pop3           110/tcp
+ntp            123/udp
+imap           143/tcp
+snmp           161/udp
+ldap           389/tcp
+https          443/tcp
+quic           443/udp
+isakmp         500/udp
+smtps          465/tcp
+syslog         514/udp
+submission     587/tcp
+ldaps          636/tcp
+imaps          993/tcp
+pop3s          995/tcp
+openvpn        1194/udp
+mssql          1433/tcp
+oracle         1521/tcp
+nfs            2049/tcp
+docker         2375/tcp
+docker-tls     2376/tcp
+etcd           2379/tcp
+mysql          3306/tcp
+rdp            3389/tcp
+diameter       3868/sctp
+ipsec-nat-t    4500/udp
+mdns           5353/udp
+postgresql     5432/tcp
+amqp           5672/tcp
+vnc            5900/tcp
+redis          6379/tcp
+kube-apiserver 6443/tcp
+http-alt       8080/tcp
+https-alt      8443/tcp
+kafka          9092/tcp
+elasticsearch  9200/tcp
+memcache       11211/tcp
+mongodb        27017/tcp
+wireguard      51820/udp
+`
+
+type serviceKey struct {
+	protocol pb.Protocol
+	port     uint32
+}
+
+// ServiceNames maps a protocol and port to the service usually found
+// there, so a connection to 3306 can be shown as "mysql". The name is a
+// guess from the port alone and purely cosmetic: it is looked up when a
+// response is built and never stored, so editing the map relabels old
+// connections too. A nil *ServiceNames knows no services.
+type ServiceNames struct {
+	names map[serviceKey]string
+}
+
+// DefaultServiceNames returns the built-in port map.
+func DefaultServiceNames() *ServiceNames {
+	names, err := parseServices(strings.NewReader(wellKnownServices))
+	if err != nil {
+		panic(fmt.Sprintf("built-in service map: %v", err))
+	}
+	return &ServiceNames{names: names}
+}
+
+// LoadServiceNames returns the built-in port map extended by path, a file
+// in the /etc/services format ("grafana 3000/tcp"; '#' starts a comment,
+// trailing aliases are ignored). An entry for a port the built-in map
+// already names replaces it. An empty path returns the built-in map.
+func LoadServiceNames(path string) (*ServiceNames, error) {
+	s := DefaultServiceNames()
+	if path == "" {
+		return s, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open services file: %w", err)
+	}
+	defer f.Close()
+	custom, err := parseServices(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	maps.Copy(s.names, custom)
+	return s, nil
+}
+
+// Lookup returns the service name for protocol and port, or "" when the
+// map has none.
+func (s *ServiceNames) Lookup(protocol pb.Protocol, port uint32) string {
+	if s == nil || port == 0 {
+		return ""
+	}
+	return s.names[serviceKey{protocol, port}]
+}
+
+// Known reports whether the map names a service name on any port or
+// protocol, ignoring case.
+func (s *ServiceNames) Known(name string) bool {
+	if s == nil || name == "" {
+		return false
+	}
+	for _, n := range s.names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Is reports whether protocol and port are labelled name, ignoring case.
+func (s *ServiceNames) Is(name string, protocol pb.Protocol, port uint32) bool {
+	return name != "" && strings.EqualFold(s.Lookup(protocol, port), name)
+}
+
+func parseServices(r io.Reader) (map[serviceKey]string, error) {
+	names := make(map[serviceKey]string)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: want \"name port/protocol\"", n)
+		}
+		portStr, protoStr, ok := strings.Cut(fields[1], "/")
+		if !ok {
+			return nil, fmt.Errorf("line %d: %q: want port/protocol", n, fields[1])
+		}
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil || port == 0 {
+			return nil, fmt.Errorf("line %d: invalid port %q", n, portStr)
+		}
+		var protocol pb.Protocol
+		switch strings.ToLower(protoStr) {
+		case "tcp":
+			protocol = pb.Protocol_PROTOCOL_TCP
+		case "udp":
+			protocol = pb.Protocol_PROTOCOL_UDP
+		case "sctp":
+			protocol = pb.Protocol_PROTOCOL_SCTP
+		case "dccp":
+			protocol = pb.Protocol_PROTOCOL_DCCP
+		default:
+			return nil, fmt.Errorf("line %d: unsupported protocol %q (want tcp, udp, sctp or dccp)", n, protoStr)
+		}
+		names[serviceKey{protocol, uint32(port)}] = fields[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
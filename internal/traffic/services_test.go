@@ -0,0 +1,258 @@
+package traffic
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestServiceNames_Defaults(t *testing.T) {
+	s := DefaultServiceNames()
+	for _, tc := range []struct {
+		protocol pb.Protocol
+		port     uint32
+		want     string
+	}{
+		{pb.Protocol_PROTOCOL_TCP, 443, "https"},
+		{pb.Protocol_PROTOCOL_UDP, 443, "quic"},
+		{pb.Protocol_PROTOCOL_UDP, 53, "dns"},
+		{pb.Protocol_PROTOCOL_TCP, 3306, "mysql"},
+		{pb.Protocol_PROTOCOL_UDP, 3306, ""},
+		{pb.Protocol_PROTOCOL_SCTP, 3868, "diameter"},
+		{pb.Protocol_PROTOCOL_TCP, 41234, ""},
+		{pb.Protocol_PROTOCOL_TCP, 0, ""},
+	} {
+		if got := s.Lookup(tc.protocol, tc.port); got != tc.want {
+			t.Errorf("Lookup(%v, %d) = %q, want %q", tc.protocol, tc.port, got, tc.want)
+		}
+	}
+
+	var none *ServiceNames
+	if got := none.Lookup(pb.Protocol_PROTOCOL_TCP, 443); got != "" {
+		t.Errorf("nil Lookup = %q, want empty", got)
+	}
+}
+
+func TestServiceNames_KnownAndIs(t *testing.T) {
+	s := DefaultServiceNames()
+	if !s.Known("HTTPS") || !s.Known("dns") {
+		t.Error("Known should find built-in names, ignoring case")
+	}
+	if s.Known("gopher") || s.Known("") {
+		t.Error("Known should reject names the map lacks")
+	}
+	if !s.Is("dns", pb.Protocol_PROTOCOL_TCP, 53) || s.Is("dns", pb.Protocol_PROTOCOL_TCP, 443) {
+		t.Error("Is should match only the named ports")
+	}
+
+	var none *ServiceNames
+	if none.Known("https") || none.Is("https", pb.Protocol_PROTOCOL_TCP, 443) {
+		t.Error("nil map should know no services")
+	}
+}
+
+func TestLoadServiceNames_OverridesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "services")
+	content := `# site-local services
+grafana   3000/tcp   dashboards   # alias ignored
+app       8080/TCP
+
+statsd    8125/udp
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := LoadServiceNames(path)
+	if err != nil {
+		t.Fatalf("LoadServiceNames: %v", err)
+	}
+	for port, want := range map[uint32]string{3000: "grafana", 8080: "app", 443: "https"} {
+		if got := s.Lookup(pb.Protocol_PROTOCOL_TCP, port); got != want {
+			t.Errorf("tcp/%d = %q, want %q", port, got, want)
+		}
+	}
+	if got := s.Lookup(pb.Protocol_PROTOCOL_UDP, 8125); got != "statsd" {
+		t.Errorf("udp/8125 = %q, want statsd", got)
+	}
+
+	// The override must not leak into the built-in map.
+	if got := DefaultServiceNames().Lookup(pb.Protocol_PROTOCOL_TCP, 8080); got != "http-alt" {
+		t.Errorf("default tcp/8080 = %q, want http-alt", got)
+	}
+}
+
+func TestLoadServiceNames_EmptyPath(t *testing.T) {
+	s, err := LoadServiceNames("")
+	if err != nil {
+		t.Fatalf("LoadServiceNames: %v", err)
+	}
+	if got := s.Lookup(pb.Protocol_PROTOCOL_TCP, 22); got != "ssh" {
+		t.Errorf("tcp/22 = %q, want ssh", got)
+	}
+}
+
+func TestLoadServiceNames_Errors(t *testing.T) {
+	if _, err := LoadServiceNames(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("missing file: want an error")
+	}
+
+	for _, tc := range []struct {
+		content string
+		want    string
+	}{
+		{"grafana\n", "line 1"},
+		{"ok 22/tcp\ngrafana 3000\n", "line 2"},
+		{"grafana 70000/tcp\n", "invalid port"},
+		{"grafana 0/tcp\n", "invalid port"},
+		{"grafana 3000/icmp\n", "unsupported protocol"},
+	} {
+		path := filepath.Join(t.TempDir(), "services")
+		if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadServiceNames(path)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%q: err = %v, want it to mention %q", tc.content, err, tc.want)
+		}
+	}
+}
+
+func TestGetConnectionSummary_TopServices(t *testing.T) {
+	c := newTestCollector()
+	add := func(id string, protocol pb.Protocol, port uint32, bytes int64) {
+		c.connections[id] = &pb.Connection{
+			Id:            id,
+			ContainerName: "web-container",
+			Protocol:      protocol,
+			DestIp:        "203.0.113.7",
+			DestPort:      port,
+			BytesSent:     bytes,
+		}
+	}
+	add("a", pb.Protocol_PROTOCOL_TCP, 443, 100)
+	add("b", pb.Protocol_PROTOCOL_TCP, 443, 200)
+	add("c", pb.Protocol_PROTOCOL_UDP, 443, 10)
+	add("d", pb.Protocol_PROTOCOL_UDP, 53, 5)
+	add("e", pb.Protocol_PROTOCOL_ICMP, 0, 1)
+
+	got := c.GetConnectionSummary("web-container", false).GetTopServices()
+	if len(got) != 3 {
+		t.Fatalf("TopServices = %v, want 3 entries", got)
+	}
+	if got[0].Port != 443 || got[0].Protocol != pb.Protocol_PROTOCOL_TCP || got[0].ConnectionCount != 2 || got[0].BytesTotal != 300 {
+		t.Errorf("TopServices[0] = %v, want tcp/443 with 2 connections and 300 bytes", got[0])
+	}
+	// Ties break by port, so udp/53 precedes udp/443.
+	if got[1].Port != 53 || got[2].Port != 443 || got[2].Protocol != pb.Protocol_PROTOCOL_UDP {
+		t.Errorf("TopServices order = %v", got)
+	}
+	for _, st := range got {
+		if st.ServiceName != "" {
+			t.Errorf("collector named %v; names are added at response time", st)
+		}
+	}
+}
+
+func TestGetConnectionSummary_SeparateDNS(t *testing.T) {
+	c := newTestCollector()
+	add := func(id string, protocol pb.Protocol, dest string, port uint32, bytes int64) {
+		c.connections[id] = &pb.Connection{
+			Id:            id,
+			ContainerName: "web-container",
+			Protocol:      protocol,
+			DestIp:        dest,
+			DestPort:      port,
+			BytesSent:     bytes,
+		}
+	}
+	add("a", pb.Protocol_PROTOCOL_TCP, "203.0.113.7", 443, 100)
+	add("b", pb.Protocol_PROTOCOL_UDP, "10.100.0.1", 53, 5)
+	add("c", pb.Protocol_PROTOCOL_UDP, "10.100.0.1", 53, 6)
+	add("d", pb.Protocol_PROTOCOL_TCP, "1.1.1.1", 53, 40)
+
+	all := c.GetConnectionSummary("web-container", false)
+	if all.ActiveConnections != 4 || all.Dns != nil {
+		t.Errorf("without separate_dns: active = %d, dns = %v; want 4 and no DNS line", all.ActiveConnections, all.Dns)
+	}
+
+	got := c.GetConnectionSummary("web-container", true)
+	if got.ActiveConnections != 1 || got.TcpConnections != 1 || got.UdpConnections != 0 || got.TotalBytesSent != 100 {
+		t.Errorf("summary = %v, want only the tcp/443 connection counted", got)
+	}
+	if len(got.TopDestinations) != 1 || got.TopDestinations[0].DestIp != "203.0.113.7" {
+		t.Errorf("TopDestinations = %v, want only 203.0.113.7", got.TopDestinations)
+	}
+	if len(got.TopServices) != 1 || got.TopServices[0].Port != 443 {
+		t.Errorf("TopServices = %v, want only 443", got.TopServices)
+	}
+	dns := got.GetDns()
+	if dns.GetConnectionCount() != 3 || dns.GetBytesTotal() != 51 || !slices.Equal(dns.GetResolvers(), []string{"1.1.1.1", "10.100.0.1"}) {
+		t.Errorf("Dns = %v, want 3 connections, 51 bytes to 1.1.1.1 and 10.100.0.1", dns)
+	}
+}
+
+func TestGetConnectionSummary_StateCounts(t *testing.T) {
+	c := newTestCollector()
+	add := func(id string, protocol pb.Protocol, state pb.ConnectionState) {
+		c.connections[id] = &pb.Connection{
+			Id:            id,
+			ContainerName: "web-container",
+			Protocol:      protocol,
+			State:         state,
+			DestIp:        "203.0.113.7",
+			DestPort:      443,
+		}
+	}
+	add("a", pb.Protocol_PROTOCOL_TCP, pb.ConnectionState_CONNECTION_STATE_ESTABLISHED)
+	add("b", pb.Protocol_PROTOCOL_TCP, pb.ConnectionState_CONNECTION_STATE_SYN_SENT)
+	add("c", pb.Protocol_PROTOCOL_TCP, pb.ConnectionState_CONNECTION_STATE_SYN_SENT)
+	add("d", pb.Protocol_PROTOCOL_UDP, pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED)
+
+	got := c.GetConnectionSummary("web-container", false).GetStateCounts()
+	want := map[string]int32{"ESTABLISHED": 1, "SYN_SENT": 2}
+	if len(got) != len(want) {
+		t.Fatalf("StateCounts = %v, want %v", got, want)
+	}
+	for st, n := range want {
+		if got[st] != n {
+			t.Errorf("StateCounts[%s] = %d, want %d", st, got[st], n)
+		}
+	}
+
+	if empty := c.GetConnectionSummary("other", false); empty.StateCounts != nil {
+		t.Errorf("StateCounts for a box without connections = %v, want none", empty.StateCounts)
+	}
+}
+
+func TestGetConnectionSummary_ICMPAndRates(t *testing.T) {
+	c := newTestCollector()
+	add := func(id string, protocol pb.Protocol, sentPerSec *float64) {
+		c.connections[id] = &pb.Connection{
+			Id:                  id,
+			ContainerName:       "web-container",
+			Protocol:            protocol,
+			DestIp:              "203.0.113.7",
+			BytesSentPerSec:     sentPerSec,
+			BytesReceivedPerSec: sentPerSec,
+		}
+	}
+	add("a", pb.Protocol_PROTOCOL_TCP, proto.Float64(1000))
+	add("b", pb.Protocol_PROTOCOL_TCP, nil) // seen in one snapshot only
+	add("c", pb.Protocol_PROTOCOL_ICMP, proto.Float64(64))
+	add("d", pb.Protocol_PROTOCOL_ICMPV6, nil)
+
+	got := c.GetConnectionSummary("web-container", false)
+	if got.TcpConnections != 2 || got.UdpConnections != 0 || got.IcmpConnections != 2 {
+		t.Errorf("tcp/udp/icmp = %d/%d/%d, want 2/0/2", got.TcpConnections, got.UdpConnections, got.IcmpConnections)
+	}
+	if got.BytesSentPerSec != 1064 || got.BytesReceivedPerSec != 1064 {
+		t.Errorf("rates = %v sent, %v received; want 1064 each", got.BytesSentPerSec, got.BytesReceivedPerSec)
+	}
+}
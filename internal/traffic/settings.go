@@ -0,0 +1,57 @@
+package traffic
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CollectorSettings are the parts of CollectorConfig a running collector
+// can change without a restart (see Reconfigure).
+type CollectorSettings struct {
+	RetentionDays        int
+	SnapshotDebounce     time.Duration
+	ListenerScanInterval time.Duration
+	UDPIdleTimeout       time.Duration
+	Sampling             SamplingConfig
+}
+
+// Settings returns the collector's current CollectorSettings.
+func (c *Collector) Settings() CollectorSettings {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return CollectorSettings{
+		RetentionDays:        c.config.RetentionDays,
+		SnapshotDebounce:     c.config.SnapshotDebounce,
+		ListenerScanInterval: c.listenerInterval,
+		UDPIdleTimeout:       c.config.UDPIdleTimeout,
+		Sampling:             c.config.Sampling,
+	}
+}
+
+// Reconfigure applies s to the running collector. The next cleanup uses
+// the new retention, the next snapshot the new debounce and idle timeout,
+// and the next persisted flow the new sampling. A new listener scan
+// period starts after the scan already scheduled. Listener scanning can't
+// be turned on or off this way: its goroutine only starts with the
+// collector.
+func (c *Collector) Reconfigure(s CollectorSettings) error {
+	if s.RetentionDays < 1 {
+		return fmt.Errorf("retention must be at least 1 day, got %d", s.RetentionDays)
+	}
+	if err := s.Sampling.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if (s.ListenerScanInterval > 0) != (c.listenerInterval > 0) {
+		return errors.New("listener scanning can only be turned on or off at startup")
+	}
+	c.config.RetentionDays = s.RetentionDays
+	c.config.SnapshotDebounce = s.SnapshotDebounce
+	c.config.UDPIdleTimeout = s.UDPIdleTimeout
+	c.config.Sampling = s.Sampling
+	c.listenerInterval = s.ListenerScanInterval
+	return nil
+}
@@ -0,0 +1,407 @@
+package traffic
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// SSH sessions: a connection to port 22 says a box was reached, not who
+// reached it. sshd logs every login with the account, client address and,
+// for public keys, the key's fingerprint, so the collector can follow that
+// log and record each session:
+//
+//	containarium daemon --traffic-ssh-log /var/log/auth.log
+//
+// Sessions are attributed to a container by box user, and matched to the
+// ingress port-22 connections they rode on by container, client address
+// and time. A jump host on the daemon's host (ProxyJump through the
+// sentinel) re-dials the box from the bridge gateway, so the box never
+// sees the client's address; those connections are matched by box user
+// and time alone.
+
+var (
+	// ErrSSHDisabled is returned by QuerySSHSessions when the collector is
+	// not following an SSH auth log (SSHLogPath unset).
+	ErrSSHDisabled = errors.New("SSH session logging is disabled (daemon --traffic-ssh-log)")
+
+	// ErrSSHUnsupported is returned when the traffic store cannot keep SSH
+	// sessions.
+	ErrSSHUnsupported = errors.New("the traffic store does not record SSH sessions")
+)
+
+const (
+	// sshPort is the port whose ingress connections are matched to
+	// sessions.
+	sshPort = 22
+
+	// sshHandshakeSlack is how long before a logged login its connection
+	// may have opened: the TCP handshake, key exchange and authentication
+	// all precede the "Accepted" line.
+	sshHandshakeSlack = time.Minute
+
+	// sshOpenSessionTTL is how long a session whose end was never logged
+	// is tracked before the parser forgets it.
+	sshOpenSessionTTL = 7 * 24 * time.Hour
+)
+
+// SSHSessionParams filters QuerySSHSessions.
+type SSHSessionParams struct {
+	ContainerName string
+	// StartTime and EndTime select sessions active at any point between
+	// them.
+	StartTime time.Time
+	EndTime   time.Time
+	ClientIP  string
+	Limit     int
+}
+
+// sshSessionLimit applies QuerySSHSessions' default and maximum page size.
+func sshSessionLimit(limit int) int {
+	if limit <= 0 {
+		return 100
+	}
+	return min(limit, 1000)
+}
+
+// SSHSessionRecorder is implemented by backends that can keep SSH
+// sessions. The collector only uses it when CollectorConfig.SSHLogPath is
+// set.
+type SSHSessionRecorder interface {
+	// SaveSSHSession records a session when it starts and again when it
+	// ends; a session is identified by container, client address and port,
+	// and start time.
+	SaveSSHSession(ctx context.Context, s *pb.SSHSession) error
+	// QuerySSHSessions returns matching sessions, newest first.
+	QuerySSHSessions(ctx context.Context, params SSHSessionParams) ([]*pb.SSHSession, error)
+}
+
+// sshdEndPrefixes start the sshd lines that end a session; each names the
+// client's address and port.
+var sshdEndPrefixes = []string{
+	"Disconnected from ",
+	"Received disconnect from ",
+	"Connection closed by ",
+	"Connection reset by ",
+	"Timeout, client not responding ",
+}
+
+// sshdParser turns OpenSSH auth log lines into sessions. An "Accepted"
+// line opens one keyed by client address and port; the disconnect lines
+// for that address close it.
+type sshdParser struct {
+	open map[string]*pb.SSHSession
+}
+
+func newSSHDParser() *sshdParser {
+	return &sshdParser{open: make(map[string]*pb.SSHSession)}
+}
+
+// Line consumes one log line read at now and returns the session it
+// opened or closed, or nil. Lines from other programs and sshd lines that
+// are not logins or disconnects are ignored.
+func (p *sshdParser) Line(line string, now time.Time) *pb.SSHSession {
+	// Strip the syslog-style "Mar  1 12:00:00 host sshd[123]: " prefix,
+	// keeping only sshd's own lines (sshd-session since OpenSSH 9.8).
+	if prefix, rest, ok := strings.Cut(line, "]: "); ok {
+		prog, _, _ := strings.Cut(prefix[strings.LastIndexByte(prefix, ' ')+1:], "[")
+		if prog != "sshd" && prog != "sshd-session" {
+			return nil
+		}
+		line = rest
+	}
+	fields := strings.Fields(line)
+
+	// Accepted <method> for <user> from <ip> port <port> ssh2[: <type> <fingerprint>]
+	if len(fields) >= 8 && fields[0] == "Accepted" && fields[2] == "for" && fields[4] == "from" && fields[6] == "port" {
+		ip, port, ok := sshClientAddr(fields[5], fields[7])
+		if !ok {
+			return nil
+		}
+		s := &pb.SSHSession{
+			BoxUser:    fields[3],
+			ClientIp:   ip,
+			ClientPort: port,
+			AuthMethod: fields[1],
+			StartedAt:  timestamppb.New(now),
+		}
+		if len(fields) >= 11 && fields[8] == "ssh2:" {
+			s.KeyType, s.KeyFingerprint = fields[9], fields[10]
+		}
+		p.open[sshSessionKey(ip, port)] = s
+		return s
+	}
+
+	for _, prefix := range sshdEndPrefixes {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		for i := 1; i+1 < len(fields); i++ {
+			if fields[i] != "port" {
+				continue
+			}
+			ip, port, ok := sshClientAddr(fields[i-1], fields[i+1])
+			if !ok {
+				continue
+			}
+			key := sshSessionKey(ip, port)
+			if s, ok := p.open[key]; ok {
+				delete(p.open, key)
+				s.EndedAt = timestamppb.New(now)
+				return s
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// Prune forgets open sessions that started before cutoff; their end was
+// lost (a rotated-away line, sshd killed).
+func (p *sshdParser) Prune(cutoff time.Time) {
+	for key, s := range p.open {
+		if s.StartedAt.AsTime().Before(cutoff) {
+			delete(p.open, key)
+		}
+	}
+}
+
+// sshClientAddr parses the address and port sshd logs as "<ip> port
+// <port>", where the port may carry a trailing ":<reason code>:".
+func sshClientAddr(ipField, portField string) (string, uint32, bool) {
+	if net.ParseIP(ipField) == nil {
+		return "", 0, false
+	}
+	portStr, _, _ := strings.Cut(portField, ":")
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", 0, false
+	}
+	return ipField, uint32(port), true
+}
+
+func sshSessionKey(ip string, port uint32) string {
+	return net.JoinHostPort(ip, strconv.FormatUint(uint64(port), 10))
+}
+
+// followSSHLog tails the sshd auth log until the collector stops,
+// recording each session as it starts and ends.
+func (c *Collector) followSSHLog() {
+	parser := newSSHDParser()
+	c.followLog(c.config.SSHLogPath, "SSH auth log", func(line string, now time.Time) {
+		if s := parser.Line(line, now); s != nil {
+			c.recordSSHSession(s)
+		}
+	}, func(now time.Time) {
+		parser.Prune(now.Add(-sshOpenSessionTTL))
+	})
+}
+
+// recordSSHSession attributes a session to the container its box user
+// owns and persists it. Logins to accounts no container belongs to (the
+// host's own users) are dropped.
+func (c *Collector) recordSSHSession(s *pb.SSHSession) {
+	if s.ContainerName == "" {
+		s.ContainerName = c.cache.LookupUserContainer(s.BoxUser)
+	}
+	if s.ContainerName == "" {
+		return
+	}
+	rec, ok := c.store.(SSHSessionRecorder)
+	if !ok {
+		return
+	}
+	// The parser still holds s and will set its end; save a copy.
+	saved := proto.Clone(s).(*pb.SSHSession)
+	go func() {
+		if err := rec.SaveSSHSession(c.ctx, saved); err != nil {
+			log.Printf("Warning: failed to record SSH session: %v", err)
+		}
+	}()
+}
+
+// sshRecorder returns the store as an SSHSessionRecorder, or the error
+// QuerySSHSessions reports when there is none.
+func (c *Collector) sshRecorder() (SSHSessionRecorder, error) {
+	if c.config.SSHLogPath == "" {
+		return nil, ErrSSHDisabled
+	}
+	rec, ok := c.store.(SSHSessionRecorder)
+	if !ok {
+		return nil, ErrSSHUnsupported
+	}
+	return rec, nil
+}
+
+// QuerySSHSessions returns the SSH sessions recorded for a container, each
+// with the traffic of the port-22 connections it carried.
+func (c *Collector) QuerySSHSessions(ctx context.Context, params SSHSessionParams) ([]*pb.SSHSession, error) {
+	rec, err := c.sshRecorder()
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := rec.QuerySSHSessions(ctx, params)
+	if err != nil || len(sessions) == 0 {
+		return sessions, err
+	}
+
+	now, gateway := time.Now(), c.cache.GatewayIP()
+	start, end := now, time.Time{}
+	for _, s := range sessions {
+		start = minTime(start, s.StartedAt.AsTime())
+		end = maxTime(end, sessionEnd(s, now))
+	}
+	// Not filtered by ClientIP: connections through a jump host come from
+	// the gateway, and matchSSHSession checks the address.
+	conns, _, err := c.store.QueryConnections(ctx, QueryParams{
+		ContainerNames: []string{params.ContainerName},
+		StartTime:      start.Add(-sshHandshakeSlack),
+		EndTime:        end,
+		DestPort:       sshPort,
+		IncludeOpen:    true,
+		Limit:          1000,
+	})
+	if err != nil {
+		// The sessions are still worth returning without their totals.
+		log.Printf("Warning: failed to read SSH session traffic for %s: %v", params.ContainerName, err)
+		return sessions, nil
+	}
+	for _, conn := range conns {
+		if s := matchSSHSession(sessions, conn, gateway, now); s != nil {
+			s.BytesSent += conn.BytesSent
+			s.BytesReceived += conn.BytesReceived
+			s.ConnectionCount++
+		}
+	}
+	return sessions, nil
+}
+
+// AnnotateSSHSessions sets SshSession on the ingress port-22 connections
+// in conns that a recorded session matches. It does nothing when SSH
+// session logging is off; a failed lookup leaves conns as they were.
+func (c *Collector) AnnotateSSHSessions(ctx context.Context, conns []*pb.HistoricalConnection) {
+	rec, err := c.sshRecorder()
+	if err != nil {
+		return
+	}
+	now, gateway := time.Now(), c.cache.GatewayIP()
+	type window struct{ start, end time.Time }
+	windows := make(map[string]*window)
+	for _, conn := range conns {
+		if !isSSHIngress(conn) {
+			continue
+		}
+		cs, ce := conn.StartedAt.AsTime(), connEnd(conn, now)
+		if w := windows[conn.ContainerName]; w != nil {
+			w.start, w.end = minTime(w.start, cs), maxTime(w.end, ce)
+		} else {
+			windows[conn.ContainerName] = &window{cs, ce}
+		}
+	}
+	for container, w := range windows {
+		sessions, err := rec.QuerySSHSessions(ctx, SSHSessionParams{
+			ContainerName: container,
+			StartTime:     w.start,
+			EndTime:       w.end.Add(sshHandshakeSlack),
+			Limit:         1000,
+		})
+		if err != nil {
+			log.Printf("Warning: failed to read SSH sessions for %s: %v", container, err)
+			continue
+		}
+		for _, conn := range conns {
+			if conn.ContainerName == container {
+				conn.SshSession = matchSSHSession(sessions, conn, gateway, now)
+			}
+		}
+	}
+}
+
+// matchSSHSession returns the session conn carried, or nil. A candidate
+// shares conn's container and client address and overlaps it in time; of
+// several (parallel logins from one address), the one on conn's source
+// port wins, then the one that started nearest conn. A connection from
+// gatewayIP came through a jump host on the daemon's host, which logged
+// the client's address rather than its own, so any session of the box's
+// user (its container's) overlapping it is a candidate.
+func matchSSHSession(sessions []*pb.SSHSession, conn *pb.HistoricalConnection, gatewayIP string, now time.Time) *pb.SSHSession {
+	if !isSSHIngress(conn) {
+		return nil
+	}
+	jumped := gatewayIP != "" && conn.SourceIp == gatewayIP
+	cs, ce := conn.StartedAt.AsTime(), connEnd(conn, now)
+	var best *pb.SSHSession
+	var bestGap time.Duration
+	for _, s := range sessions {
+		ss := s.StartedAt.AsTime()
+		if s.ContainerName != conn.ContainerName || (!jumped && s.ClientIp != conn.SourceIp) ||
+			cs.After(sessionEnd(s, now)) || ce.Before(ss.Add(-sshHandshakeSlack)) {
+			continue
+		}
+		if !jumped && s.ClientPort != 0 && s.ClientPort == conn.SourcePort {
+			return s
+		}
+		gap := ss.Sub(cs).Abs()
+		if best == nil || gap < bestGap {
+			best, bestGap = s, gap
+		}
+	}
+	return best
+}
+
+// isSSHIngress reports whether conn is a client connecting to the box's
+// sshd. Rows recorded before direction was tracked count too.
+func isSSHIngress(conn *pb.HistoricalConnection) bool {
+	return conn.DestPort == sshPort && conn.Protocol == pb.Protocol_PROTOCOL_TCP &&
+		conn.Direction != pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+}
+
+// sessionEnd is when s ended, or now while it is open.
+func sessionEnd(s *pb.SSHSession, now time.Time) time.Time {
+	if s.EndedAt == nil {
+		return now
+	}
+	return s.EndedAt.AsTime()
+}
+
+// connEnd is when conn ended, or now while it is open.
+func connEnd(conn *pb.HistoricalConnection, now time.Time) time.Time {
+	if conn.EndedAt == nil {
+		return now
+	}
+	return conn.EndedAt.AsTime()
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// compareSSHSessions orders sessions newest first, as QuerySSHSessions
+// returns them.
+func compareSSHSessions(a, b *pb.SSHSession) int {
+	return cmp.Or(
+		b.StartedAt.AsTime().Compare(a.StartedAt.AsTime()),
+		cmp.Compare(a.ClientIp, b.ClientIp),
+		cmp.Compare(a.ClientPort, b.ClientPort),
+	)
+}
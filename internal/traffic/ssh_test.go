@@ -0,0 +1,285 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestSSHDParser_SessionLifecycle(t *testing.T) {
+	p := newSSHDParser()
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	s := p.Line("Mar  1 12:00:00 jump sshd[4242]: Accepted publickey for alice from 203.0.113.7 port 51234 ssh2: ED25519 SHA256:abc", t0)
+	if s == nil {
+		t.Fatal("Accepted line opened no session")
+	}
+	if s.BoxUser != "alice" || s.ClientIp != "203.0.113.7" || s.ClientPort != 51234 ||
+		s.AuthMethod != "publickey" || s.KeyType != "ED25519" || s.KeyFingerprint != "SHA256:abc" {
+		t.Errorf("session = %v", s)
+	}
+	if s.EndedAt != nil {
+		t.Error("a new session has no end")
+	}
+
+	// Other programs, other clients and unrelated sshd lines leave it open.
+	for _, line := range []string{
+		"Mar  1 12:00:01 jump sudo[99]: Connection closed by 203.0.113.7 port 51234",
+		"Mar  1 12:00:01 jump sshd[4242]: pam_unix(sshd:session): session opened for user alice(uid=1000)",
+		"Mar  1 12:00:01 jump sshd[4243]: Connection closed by 203.0.113.7 port 40000 [preauth]",
+		"Mar  1 12:00:01 jump sshd[4243]: Accepted publickey for bob from not-an-ip port 1 ssh2",
+	} {
+		if got := p.Line(line, t0.Add(time.Second)); got != nil {
+			t.Errorf("%q returned %v", line, got)
+		}
+	}
+
+	end := p.Line("Mar  1 12:30:00 jump sshd[4242]: Received disconnect from 203.0.113.7 port 51234:11: disconnected by user", t0.Add(30*time.Minute))
+	if end != s || !end.EndedAt.AsTime().Equal(t0.Add(30*time.Minute)) {
+		t.Fatalf("disconnect returned %v, want the session ended at 12:30", end)
+	}
+	// sshd logs the end twice; the second line finds nothing open.
+	if got := p.Line("Mar  1 12:30:00 jump sshd[4242]: Disconnected from user alice 203.0.113.7 port 51234", t0.Add(30*time.Minute)); got != nil {
+		t.Errorf("second disconnect returned %v", got)
+	}
+}
+
+func TestSSHDParser_PasswordAndNewerSSHD(t *testing.T) {
+	p := newSSHDParser()
+	now := time.Now()
+	s := p.Line("2026-03-01T12:00:00+00:00 jump sshd-session[77]: Accepted password for bob from 2001:db8::7 port 50022 ssh2", now)
+	if s == nil || s.AuthMethod != "password" || s.KeyFingerprint != "" || s.ClientIp != "2001:db8::7" {
+		t.Fatalf("session = %v", s)
+	}
+	if got := p.Line("Timeout, client not responding from user bob 2001:db8::7 port 50022", now); got != s {
+		t.Errorf("timeout line returned %v", got)
+	}
+}
+
+func TestSSHDParser_PruneForgetsLostSessions(t *testing.T) {
+	p := newSSHDParser()
+	t0 := time.Now()
+	p.Line("sshd[1]: Accepted publickey for alice from 203.0.113.7 port 51234 ssh2: ED25519 SHA256:abc", t0)
+	p.Prune(t0.Add(time.Second))
+	if got := p.Line("sshd[1]: Disconnected from user alice 203.0.113.7 port 51234", t0.Add(time.Minute)); got != nil {
+		t.Errorf("pruned session still closed: %v", got)
+	}
+}
+
+// testGatewayIP is the bridge gateway of the test container network.
+const testGatewayIP = "10.100.0.1"
+
+func TestMatchSSHSession(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	session := func(port uint32, start, end time.Duration) *pb.SSHSession {
+		return &pb.SSHSession{ContainerName: "alice-container", ClientIp: "203.0.113.7", ClientPort: port,
+			StartedAt: timestamppb.New(t0.Add(start)), EndedAt: timestamppb.New(t0.Add(end))}
+	}
+	first := session(51234, 0, time.Hour)
+	second := session(51300, 10*time.Minute, 20*time.Minute)
+	sessions := []*pb.SSHSession{second, first}
+
+	conn := func(port uint32, start, end time.Duration) *pb.HistoricalConnection {
+		return &pb.HistoricalConnection{ContainerName: "alice-container", Protocol: pb.Protocol_PROTOCOL_TCP,
+			Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS, SourceIp: "203.0.113.7", SourcePort: port,
+			DestPort: 22, StartedAt: timestamppb.New(t0.Add(start)), EndedAt: timestamppb.New(t0.Add(end))}
+	}
+	now := t0.Add(2 * time.Hour)
+
+	// The source port settles which of two overlapping sessions it was.
+	if got := matchSSHSession(sessions, conn(51234, -2*time.Second, time.Hour), testGatewayIP, now); got != first {
+		t.Errorf("port 51234 matched %v", got)
+	}
+	// A client address the box saw through NAT has another port: the
+	// session that started nearest wins.
+	if got := matchSSHSession(sessions, conn(40000, 10*time.Minute-time.Second, 20*time.Minute), testGatewayIP, now); got != second {
+		t.Errorf("nearest start matched %v", got)
+	}
+
+	// with is a connection that would match first, changed by mod.
+	with := func(mod func(c *pb.HistoricalConnection)) *pb.HistoricalConnection {
+		c := conn(51234, 0, time.Hour)
+		mod(c)
+		return c
+	}
+	for name, c := range map[string]*pb.HistoricalConnection{
+		"after both ended":    conn(51234, 90*time.Minute, 100*time.Minute),
+		"long before a login": conn(51234, -time.Hour, -30*time.Minute),
+		"other container":     with(func(c *pb.HistoricalConnection) { c.ContainerName = "bob-container" }),
+		"other address":       with(func(c *pb.HistoricalConnection) { c.SourceIp = "198.51.100.1" }),
+		"not the ssh port":    with(func(c *pb.HistoricalConnection) { c.DestPort = 443 }),
+		"udp to port 22":      with(func(c *pb.HistoricalConnection) { c.Protocol = pb.Protocol_PROTOCOL_UDP }),
+		"egress to port 22": with(func(c *pb.HistoricalConnection) {
+			c.Direction = pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS
+		}),
+	} {
+		if got := matchSSHSession(sessions, c, testGatewayIP, now); got != nil {
+			t.Errorf("%s: matched %v", name, got)
+		}
+	}
+
+	// Through a jump host on the daemon's host the box sees the gateway:
+	// the session of the box's user nearest in time matches, whatever
+	// the source port.
+	jumped := with(func(c *pb.HistoricalConnection) { c.SourceIp, c.SourcePort = testGatewayIP, 51300 })
+	jumped.StartedAt = timestamppb.New(t0.Add(-time.Second))
+	if got := matchSSHSession(sessions, jumped, testGatewayIP, now); got != first {
+		t.Errorf("jump host connection matched %v, want the session nearest in time", got)
+	}
+	jumped.ContainerName = "bob-container"
+	if got := matchSSHSession(sessions, jumped, testGatewayIP, now); got != nil {
+		t.Errorf("jump host connection to another box matched %v", got)
+	}
+	if got := matchSSHSession(sessions, with(func(c *pb.HistoricalConnection) { c.SourceIp = testGatewayIP }), "", now); got != nil {
+		t.Errorf("gateway address without a known gateway matched %v", got)
+	}
+}
+
+func TestContainerCache_LookupUserContainer(t *testing.T) {
+	cache := NewContainerCache(nil, "10.100.0.0/24")
+	cache.nameToUser["alice-dev"] = "alice"
+	cache.nameToUser["alice-container"] = "alice"
+	cache.nameToUser["bob-b"] = "bob"
+	cache.nameToUser["bob-a"] = "bob"
+
+	for user, want := range map[string]string{"alice": "alice-container", "bob": "bob-a", "carol": "", "": ""} {
+		if got := cache.LookupUserContainer(user); got != want {
+			t.Errorf("LookupUserContainer(%q) = %q, want %q", user, got, want)
+		}
+	}
+}
+
+func TestContainerCache_GatewayIP(t *testing.T) {
+	for cidr, want := range map[string]string{"10.100.0.0/24": testGatewayIP, "10.0.3.1/24": "10.0.3.1", "fd42::/64": "", "bogus": ""} {
+		if got := NewContainerCache(nil, cidr).GatewayIP(); got != want {
+			t.Errorf("GatewayIP() for %s = %q, want %q", cidr, got, want)
+		}
+	}
+}
+
+func TestCollector_SSHSessionsCarryTheirTraffic(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCollector()
+	c.ctx = ctx
+	c.config.SSHLogPath = "/dev/null"
+	store := NewMemoryStore(10)
+	c.store = store
+	c.cache.nameToUser["alice-container"] = "alice"
+
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Second)
+	p := newSSHDParser()
+	c.recordSSHSession(p.Line("sshd[1]: Accepted publickey for alice from 203.0.113.7 port 51234 ssh2: ED25519 SHA256:abc", t0))
+	// The host's own accounts are not a container's.
+	c.recordSSHSession(p.Line("sshd[2]: Accepted publickey for root from 203.0.113.9 port 50000 ssh2: ED25519 SHA256:def", t0))
+	c.recordSSHSession(p.Line("sshd[1]: Disconnected from user alice 203.0.113.7 port 51234", t0.Add(30*time.Minute)))
+
+	for _, conn := range []*pb.Connection{
+		{Id: "ssh", ContainerName: "alice-container", Protocol: pb.Protocol_PROTOCOL_TCP,
+			Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS, SourceIp: "203.0.113.7", SourcePort: 51234,
+			DestIp: "10.100.0.5", DestPort: 22, BytesReceived: 2 << 30, BytesSent: 4096},
+		{Id: "web", ContainerName: "alice-container", Protocol: pb.Protocol_PROTOCOL_TCP,
+			Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS, SourceIp: "203.0.113.7", SourcePort: 51240,
+			DestIp: "10.100.0.5", DestPort: 443, BytesReceived: 100},
+	} {
+		conn.FirstSeen = timestamppb.New(t0.Add(-2 * time.Second))
+		conn.LastSeen = timestamppb.New(t0.Add(30 * time.Minute))
+		if err := store.SaveConnection(ctx, conn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var sessions []*pb.SSHSession
+	deadline := time.Now().Add(2 * time.Second)
+	for (len(sessions) == 0 || sessions[0].EndedAt == nil) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		var err error
+		sessions, err = c.QuerySSHSessions(ctx, SSHSessionParams{
+			ContainerName: "alice-container", StartTime: t0.Add(-time.Minute), EndTime: time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("QuerySSHSessions: %v", err)
+		}
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("sessions = %v, want alice's one", sessions)
+	}
+	s := sessions[0]
+	if s.KeyFingerprint != "SHA256:abc" || s.EndedAt == nil || s.BytesReceived != 2<<30 || s.BytesSent != 4096 || s.ConnectionCount != 1 {
+		t.Errorf("session = %v, want the ended session with the port-22 connection's bytes", s)
+	}
+
+	history, _, err := store.QueryConnections(ctx, QueryParams{ContainerNames: []string{"alice-container"}, StartTime: t0.Add(-time.Hour), EndTime: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.AnnotateSSHSessions(ctx, history)
+	for _, conn := range history {
+		switch {
+		case conn.DestPort == 22 && conn.SshSession.GetBoxUser() != "alice":
+			t.Errorf("ssh connection session = %v", conn.SshSession)
+		case conn.DestPort != 22 && conn.SshSession != nil:
+			t.Errorf("port %d connection annotated with %v", conn.DestPort, conn.SshSession)
+		}
+	}
+}
+
+func TestCollector_QuerySSHSessionsDisabled(t *testing.T) {
+	c := newTestCollector()
+	c.store = NewMemoryStore(10)
+	if _, err := c.QuerySSHSessions(context.Background(), SSHSessionParams{ContainerName: "alice-container"}); !errors.Is(err, ErrSSHDisabled) {
+		t.Errorf("err = %v, want ErrSSHDisabled", err)
+	}
+	c.config.SSHLogPath = "/dev/null"
+	c.store = nil
+	if _, err := c.QuerySSHSessions(context.Background(), SSHSessionParams{ContainerName: "alice-container"}); !errors.Is(err, ErrSSHUnsupported) {
+		t.Errorf("err = %v, want ErrSSHUnsupported", err)
+	}
+}
+
+func TestMemoryStore_SSHSessions(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore(10)
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	open := &pb.SSHSession{ContainerName: "alice-container", BoxUser: "alice", ClientIp: "203.0.113.7", ClientPort: 51234,
+		StartedAt: timestamppb.New(t0)}
+	for _, s := range []*pb.SSHSession{
+		open,
+		{ContainerName: "alice-container", BoxUser: "alice", ClientIp: "198.51.100.4", ClientPort: 40000,
+			StartedAt: timestamppb.New(t0.Add(-2 * time.Hour)), EndedAt: timestamppb.New(t0.Add(-time.Hour))},
+		{ContainerName: "bob-container", BoxUser: "bob", ClientIp: "203.0.113.7", ClientPort: 51300,
+			StartedAt: timestamppb.New(t0)},
+	} {
+		if err := m.SaveSSHSession(ctx, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Logout updates the session recorded at login.
+	ended := &pb.SSHSession{ContainerName: "alice-container", ClientIp: "203.0.113.7", ClientPort: 51234,
+		StartedAt: timestamppb.New(t0), EndedAt: timestamppb.New(t0.Add(time.Hour))}
+	if err := m.SaveSSHSession(ctx, ended); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := m.QuerySSHSessions(ctx, SSHSessionParams{ContainerName: "alice-container", StartTime: t0.Add(-3 * time.Hour), EndTime: t0.Add(2 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ClientPort != 51234 || got[0].EndedAt == nil || got[1].ClientPort != 40000 {
+		t.Errorf("sessions = %v, want alice's two, newest first, the first ended", got)
+	}
+
+	// Only sessions active in the range, from the given address.
+	got, _ = m.QuerySSHSessions(ctx, SSHSessionParams{ContainerName: "alice-container", StartTime: t0.Add(-30 * time.Minute), EndTime: t0.Add(2 * time.Hour)})
+	if len(got) != 1 || got[0].ClientPort != 51234 {
+		t.Errorf("in range: %v", got)
+	}
+	got, _ = m.QuerySSHSessions(ctx, SSHSessionParams{ContainerName: "alice-container", ClientIP: "198.51.100.4", StartTime: t0.Add(-3 * time.Hour), EndTime: t0})
+	if len(got) != 1 || got[0].ClientPort != 40000 {
+		t.Errorf("by client: %v", got)
+	}
+}
@@ -0,0 +1,95 @@
+package traffic
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestReAggregate_KeepsDirectionGroups(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) *timestamppb.Timestamp { return timestamppb.New(day.Add(time.Duration(h) * time.Hour)) }
+	in := []*pb.TrafficAggregate{
+		{Timestamp: at(1), Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, BytesSent: 10, EgressBytes: 10, ConnectionCount: 1},
+		{Timestamp: at(2), Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, BytesSent: 5, EgressBytes: 5, ConnectionCount: 1},
+		{Timestamp: at(1), Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS, BytesReceived: 7, IngressBytes: 7, ConnectionCount: 1},
+		// A row from before direction was recorded: never guessed into a side.
+		{Timestamp: at(3), BytesSent: 3, ConnectionCount: 1},
+	}
+
+	got := map[pb.TrafficDirection]*pb.TrafficAggregate{}
+	for _, a := range reAggregate(in, 24*time.Hour, time.UTC) {
+		if _, dup := got[a.Direction]; dup {
+			t.Fatalf("direction %v appears twice", a.Direction)
+		}
+		got[a.Direction] = a
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d groups, want 3 (egress, ingress, unspecified)", len(got))
+	}
+	if e := got[pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS]; e.BytesSent != 15 || e.EgressBytes != 15 || e.ConnectionCount != 2 {
+		t.Errorf("egress = %+v", e)
+	}
+	if i := got[pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS]; i.IngressBytes != 7 {
+		t.Errorf("ingress = %+v", i)
+	}
+	if u := got[pb.TrafficDirection_TRAFFIC_DIRECTION_UNSPECIFIED]; u.BytesSent != 3 || u.IngressBytes != 0 || u.EgressBytes != 0 {
+		t.Errorf("unspecified = %+v", u)
+	}
+}
+
+func TestAggregateLocation(t *testing.T) {
+	if loc, err := AggregateLocation(""); err != nil || loc != time.UTC {
+		t.Errorf(`AggregateLocation("") = %v, %v; want UTC`, loc, err)
+	}
+	if loc, err := AggregateLocation("America/New_York"); err != nil || loc.String() != "America/New_York" {
+		t.Errorf("AggregateLocation(America/New_York) = %v, %v", loc, err)
+	}
+	for _, bad := range []string{"Local", "Mars/Olympus_Mons", "+02:00"} {
+		if _, err := AggregateLocation(bad); err == nil {
+			t.Errorf("AggregateLocation(%q) accepted", bad)
+		}
+	}
+}
+
+func TestTruncateIn_LocalMidnight(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	// 03:30 UTC on 10 March is 23:30 on 9 March in New York (UTC-4,
+	// daylight time).
+	got := truncateIn(time.Date(2026, 3, 10, 3, 30, 0, 0, time.UTC), 24*time.Hour, ny)
+	if want := time.Date(2026, 3, 9, 0, 0, 0, 0, ny); !got.Equal(want) {
+		t.Errorf("truncateIn = %v, want %v", got, want)
+	}
+	// In UTC it is plain truncation.
+	ts := time.Date(2026, 3, 10, 13, 45, 0, 0, time.UTC)
+	if got := truncateIn(ts, 6*time.Hour, time.UTC); !got.Equal(ts.Truncate(6 * time.Hour)) {
+		t.Errorf("truncateIn UTC = %v", got)
+	}
+}
+
+func TestContainerFilter(t *testing.T) {
+	tests := []struct {
+		params   QueryParams
+		wantCond string
+		wantArg  any
+	}{
+		{QueryParams{}, "", nil},
+		{QueryParams{ContainerNames: []string{"alice-container"}, ContainerNamePrefix: "bob"}, "container_name = $3", "alice-container"},
+		{QueryParams{ContainerNames: []string{"alice-container", "bob-container"}}, "container_name = ANY($3)", []string{"alice-container", "bob-container"}},
+		{QueryParams{ContainerNamePrefix: "acme-"}, "container_name LIKE $3", "acme-%"},
+		{QueryParams{ContainerNamePrefix: `a_b%c\`}, "container_name LIKE $3", `a\_b\%c\\%`},
+	}
+	for _, tt := range tests {
+		cond, arg := tt.params.containerFilter(3)
+		if cond != tt.wantCond || !reflect.DeepEqual(arg, tt.wantArg) {
+			t.Errorf("containerFilter(%+v) = %q, %v; want %q, %v", tt.params, cond, arg, tt.wantCond, tt.wantArg)
+		}
+	}
+}
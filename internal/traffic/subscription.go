@@ -0,0 +1,35 @@
+package traffic
+
+import (
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// MatchesSubscription reports whether a traffic event passes the filters of
+// a SubscribeTrafficRequest. Shared by the gRPC stream and the gateway's
+// WebSocket endpoint so both transports filter identically.
+func MatchesSubscription(req *pb.SubscribeTrafficRequest, ev *pb.TrafficEvent) bool {
+	if ev == nil {
+		return false
+	}
+	if req.GetContainerName() != "" {
+		if ev.Connection == nil || ev.Connection.ContainerName != req.GetContainerName() {
+			return false
+		}
+	}
+	if len(req.GetEventTypes()) > 0 {
+		found := false
+		for _, et := range req.GetEventTypes() {
+			if et == ev.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	// TODO: when req.ExternalOnly is set, skip events whose destination
+	// is also a container IP. This requires consulting the IP cache and
+	// is not yet implemented.
+	return true
+}
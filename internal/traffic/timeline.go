@@ -0,0 +1,109 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+var (
+	// ErrTimelineDisabled is returned by ConnectionTimeline when the
+	// collector is not recording state changes (RecordStateChanges off).
+	ErrTimelineDisabled = errors.New("connection state recording is disabled (daemon --traffic-record-states)")
+
+	// ErrTimelineUnsupported is returned when the traffic store cannot
+	// keep state changes.
+	ErrTimelineUnsupported = errors.New("the traffic store does not record connection state changes")
+)
+
+// StateChangeRecorder is implemented by backends that can keep every state
+// a connection goes through, not just its final one. The collector only
+// uses it when CollectorConfig.RecordStateChanges is set.
+type StateChangeRecorder interface {
+	// RecordStateChange appends one observed state of a connection.
+	RecordStateChange(ctx context.Context, containerName, conntrackID string, state pb.ConnectionState, at time.Time) error
+	// GetConnectionTimeline returns a connection's recorded states, oldest
+	// first.
+	GetConnectionTimeline(ctx context.Context, containerName, conntrackID string) ([]*pb.ConnectionStateChange, error)
+}
+
+// stateChangeQueueSize bounds the state changes waiting for the store. A
+// conntrack burst beyond it drops changes rather than piling up writes.
+const stateChangeQueueSize = 4096
+
+// stateChange is one observed state of a connection, queued for the store.
+type stateChange struct {
+	containerName, id string
+	state             pb.ConnectionState
+	at                time.Time
+}
+
+// newStateChangeQueue returns the queue writeStateChanges drains, or nil
+// when state changes aren't recorded or store can't keep them.
+func newStateChangeQueue(config CollectorConfig, store ConnectionStore) chan stateChange {
+	if !config.RecordStateChanges {
+		return nil
+	}
+	if _, ok := store.(StateChangeRecorder); !ok {
+		return nil
+	}
+	return make(chan stateChange, stateChangeQueueSize)
+}
+
+// recordStateChange queues conn's state when it differs from prev, the
+// state last seen for the same connection (UNSPECIFIED for a new one).
+// Repeated UPDATEs that only move counters are not recorded.
+func (c *Collector) recordStateChange(conn *pb.Connection, prev pb.ConnectionState, at time.Time) {
+	if conn.State == prev {
+		return
+	}
+	c.queueStateChange(stateChange{containerName: conn.ContainerName, id: conn.Id, state: conn.State, at: at})
+}
+
+// queueStateChange hands ch to writeStateChanges without blocking,
+// dropping it when the queue is full.
+func (c *Collector) queueStateChange(ch stateChange) {
+	if c.stateChanges == nil {
+		return
+	}
+	select {
+	case c.stateChanges <- ch:
+	default:
+		c.stateChangeDrops.Add(1)
+	}
+}
+
+// writeStateChanges stores queued state changes one at a time until the
+// collector stops, reporting any dropped since the last write.
+func (c *Collector) writeStateChanges() {
+	rec := c.store.(StateChangeRecorder)
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case ch := <-c.stateChanges:
+			if err := rec.RecordStateChange(c.ctx, ch.containerName, ch.id, ch.state, ch.at); err != nil {
+				log.Printf("Warning: failed to record connection state change: %v", err)
+			}
+			if n := c.stateChangeDrops.Swap(0); n > 0 {
+				log.Printf("Warning: connection state queue full, dropped %d state changes", n)
+			}
+		}
+	}
+}
+
+// ConnectionTimeline returns the recorded states of one of containerName's
+// connections, oldest first.
+func (c *Collector) ConnectionTimeline(ctx context.Context, containerName, conntrackID string) ([]*pb.ConnectionStateChange, error) {
+	if !c.config.RecordStateChanges {
+		return nil, ErrTimelineDisabled
+	}
+	rec, ok := c.store.(StateChangeRecorder)
+	if !ok {
+		return nil, ErrTimelineUnsupported
+	}
+	return rec.GetConnectionTimeline(ctx, containerName, conntrackID)
+}
@@ -0,0 +1,168 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestCollector_RecordsOnlyStateChanges(t *testing.T) {
+	c := newTimelineCollector(t)
+	conn := &pb.Connection{Id: "42", ContainerName: "alice-container"}
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		prev, state pb.ConnectionState
+	}{
+		{pb.ConnectionState_CONNECTION_STATE_UNSPECIFIED, pb.ConnectionState_CONNECTION_STATE_SYN_SENT},
+		{pb.ConnectionState_CONNECTION_STATE_SYN_SENT, pb.ConnectionState_CONNECTION_STATE_ESTABLISHED},
+		// A counters-only UPDATE: same state, nothing recorded.
+		{pb.ConnectionState_CONNECTION_STATE_ESTABLISHED, pb.ConnectionState_CONNECTION_STATE_ESTABLISHED},
+		{pb.ConnectionState_CONNECTION_STATE_ESTABLISHED, pb.ConnectionState_CONNECTION_STATE_TIME_WAIT},
+	}
+	for i, s := range steps {
+		conn.State = s.state
+		c.recordStateChange(conn, s.prev, base.Add(time.Duration(i)*time.Second))
+	}
+
+	got := waitForTimeline(t, c, "42", 3)
+	want := []pb.ConnectionState{
+		pb.ConnectionState_CONNECTION_STATE_SYN_SENT,
+		pb.ConnectionState_CONNECTION_STATE_ESTABLISHED,
+		pb.ConnectionState_CONNECTION_STATE_TIME_WAIT,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d state changes, want %d: %v", len(got), len(want), got)
+	}
+	for i, ch := range got {
+		if ch.State != want[i] {
+			t.Errorf("change %d = %v, want %v", i, ch.State, want[i])
+		}
+	}
+	if got[0].Timestamp.AsTime().After(got[2].Timestamp.AsTime()) {
+		t.Errorf("timeline not oldest first: %v", got)
+	}
+}
+
+// newTimelineCollector returns a test collector recording state changes
+// to a memory store, with its writer running.
+func newTimelineCollector(t *testing.T) *Collector {
+	t.Helper()
+	c := newTestCollector()
+	c.config.RecordStateChanges = true
+	c.store = NewMemoryStore(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	c.ctx = ctx
+	c.stateChanges = newStateChangeQueue(c.config, c.store)
+	go c.writeStateChanges()
+	return c
+}
+
+// waitForTimeline polls alice-container's connection id until it has n
+// recorded states or two seconds pass.
+func waitForTimeline(t *testing.T, c *Collector, id string, n int) []*pb.ConnectionStateChange {
+	t.Helper()
+	var got []*pb.ConnectionStateChange
+	deadline := time.Now().Add(2 * time.Second)
+	for len(got) < n && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		var err error
+		if got, err = c.ConnectionTimeline(context.Background(), "alice-container", id); err != nil {
+			t.Fatalf("ConnectionTimeline: %v", err)
+		}
+	}
+	return got
+}
+
+// TestTakeSnapshot_RecordsIntermediateStates — the event stream has no
+// UPDATEs, so a state reached between NEW and DESTROY is recorded from
+// the snapshot that first sees it, once.
+func TestTakeSnapshot_RecordsIntermediateStates(t *testing.T) {
+	c := newTimelineCollector(t)
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := &ConntrackEvent{ID: "42", Protocol: "tcp", SrcIP: "10.100.0.5", DstIP: "192.0.2.1", DstPort: 443, State: "SYN_SENT", Timestamp: base}
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{event}}
+
+	for i, state := range []string{"SYN_SENT", "ESTABLISHED", "ESTABLISHED", "TIME_WAIT"} {
+		event.State = state
+		event.Timestamp = base.Add(time.Duration(i) * time.Second)
+		c.takeSnapshot()
+	}
+
+	got := waitForTimeline(t, c, "42", 3)
+	want := []pb.ConnectionState{
+		pb.ConnectionState_CONNECTION_STATE_SYN_SENT,
+		pb.ConnectionState_CONNECTION_STATE_ESTABLISHED,
+		pb.ConnectionState_CONNECTION_STATE_TIME_WAIT,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d state changes, want %d: %v", len(got), len(want), got)
+	}
+	for i, ch := range got {
+		if ch.State != want[i] {
+			t.Errorf("change %d = %v, want %v", i, ch.State, want[i])
+		}
+	}
+}
+
+// TestQueueStateChange_DropsWhenFull — a burst beyond the queue is
+// counted and dropped rather than blocking the caller.
+func TestQueueStateChange_DropsWhenFull(t *testing.T) {
+	c := &Collector{stateChanges: make(chan stateChange, 1)}
+	for range 3 {
+		c.queueStateChange(stateChange{containerName: "alice-container", id: "42"})
+	}
+	if n := c.stateChangeDrops.Load(); n != 2 {
+		t.Errorf("dropped %d state changes, want 2", n)
+	}
+}
+
+func TestCollector_ConnectionTimelineErrors(t *testing.T) {
+	off := &Collector{store: NewMemoryStore(10), ctx: context.Background()}
+	if _, err := off.ConnectionTimeline(context.Background(), "alice-container", "42"); !errors.Is(err, ErrTimelineDisabled) {
+		t.Errorf("recording off: err = %v, want ErrTimelineDisabled", err)
+	}
+
+	// A store without StateChangeRecorder.
+	unsupported := &Collector{
+		config: CollectorConfig{RecordStateChanges: true},
+		store:  struct{ ConnectionStore }{NewMemoryStore(10)},
+		ctx:    context.Background(),
+	}
+	if _, err := unsupported.ConnectionTimeline(context.Background(), "alice-container", "42"); !errors.Is(err, ErrTimelineUnsupported) {
+		t.Errorf("unsupported store: err = %v, want ErrTimelineUnsupported", err)
+	}
+}
+
+func TestMemoryStore_TimelineCleanupAndEviction(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore(1)
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	old := now.Add(-10 * 24 * time.Hour)
+	_ = m.RecordStateChange(ctx, "alice-container", "1", pb.ConnectionState_CONNECTION_STATE_ESTABLISHED, old)
+	_ = m.RecordStateChange(ctx, "alice-container", "2", pb.ConnectionState_CONNECTION_STATE_ESTABLISHED, now)
+
+	if n, err := m.Cleanup(ctx, 7); err != nil || n != 1 {
+		t.Fatalf("Cleanup = %d, %v; want the one old state change", n, err)
+	}
+	if got, _ := m.GetConnectionTimeline(ctx, "alice-container", "1"); len(got) != 0 {
+		t.Errorf("timeline older than retention kept: %v", got)
+	}
+	if got, _ := m.GetConnectionTimeline(ctx, "alice-container", "2"); len(got) != 1 {
+		t.Errorf("recent timeline dropped: %v", got)
+	}
+
+	for i := range memTimelineCap + 5 {
+		_ = m.RecordStateChange(ctx, "bob-container", "3", pb.ConnectionState_CONNECTION_STATE_ESTABLISHED, now.Add(time.Duration(i)*time.Second))
+	}
+	if got, _ := m.GetConnectionTimeline(ctx, "bob-container", "3"); len(got) != memTimelineCap {
+		t.Errorf("per-connection timeline = %d entries, want cap %d", len(got), memTimelineCap)
+	}
+}
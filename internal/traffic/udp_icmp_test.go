@@ -0,0 +1,211 @@
+package traffic
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// dnsQuery is a DNS lookup from alice's container as conntrack reports it:
+// one query and one answer.
+func dnsQuery(typ ConntrackEventType, at time.Time, packets int64, timeout int32) *ConntrackEvent {
+	return &ConntrackEvent{
+		Type: typ, ID: "42", Protocol: "udp",
+		SrcIP: "10.100.0.5", SrcPort: 53000, DstIP: "192.0.2.53", DstPort: 53,
+		PacketsOrig: packets / 2, PacketsReply: packets - packets/2,
+		BytesOrig: 60 * (packets / 2), BytesReply: 120 * (packets - packets/2),
+		Timeout: timeout, Timestamp: at,
+	}
+}
+
+// waitSaved returns the next connection the store saves.
+func waitSaved(t *testing.T, store *fakeConnectionStore) *pb.Connection {
+	t.Helper()
+	select {
+	case <-store.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SaveConnection was not called")
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.saved[len(store.saved)-1]
+}
+
+// expectNoSave fails if the store saves anything shortly.
+func expectNoSave(t *testing.T, store *fakeConnectionStore) {
+	t.Helper()
+	select {
+	case <-store.done:
+		t.Errorf("unexpected save: %v", store.saved[len(store.saved)-1])
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestDestroy_UDPEndsAtLastPacket(t *testing.T) {
+	store := newFakeConnectionStore()
+	c := newStoreTestCollector(t, store)
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	c.processConntrackEvent(dnsQuery(ConntrackEventNew, t0, 1, 30))
+	c.processConntrackEvent(dnsQuery(ConntrackEventUpdate, t0.Add(time.Second), 2, 120))
+	// Conntrack lets go once the 120s timeout runs out.
+	c.processConntrackEvent(dnsQuery(ConntrackEventDestroy, t0.Add(121*time.Second), 2, 0))
+
+	got := waitSaved(t, store)
+	if !got.FirstSeen.AsTime().Equal(t0) || !got.LastSeen.AsTime().Equal(t0.Add(time.Second)) {
+		t.Errorf("seen %v..%v, want %v..%v", got.FirstSeen.AsTime(), got.LastSeen.AsTime(), t0, t0.Add(time.Second))
+	}
+}
+
+func TestDestroy_UDPPacketsAfterLastEventEndAtTimeout(t *testing.T) {
+	store := newFakeConnectionStore()
+	c := newStoreTestCollector(t, store)
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	c.processConntrackEvent(dnsQuery(ConntrackEventNew, t0, 1, 30))
+	c.processConntrackEvent(dnsQuery(ConntrackEventUpdate, t0.Add(time.Second), 2, 120))
+	// More packets went by without an event; the last one was a timeout
+	// before the DESTROY.
+	c.processConntrackEvent(dnsQuery(ConntrackEventDestroy, t0.Add(200*time.Second), 6, 0))
+
+	got := waitSaved(t, store)
+	if want := t0.Add(80 * time.Second); !got.LastSeen.AsTime().Equal(want) {
+		t.Errorf("LastSeen = %v, want %v", got.LastSeen.AsTime(), want)
+	}
+}
+
+func TestTakeSnapshot_ClosesIdleUDPFlowOnce(t *testing.T) {
+	store := newFakeConnectionStore()
+	c := newStoreTestCollector(t, store)
+	c.config.UDPIdleTimeout = 30 * time.Second
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	event := dnsQuery(ConntrackEventNew, t0, 2, 110)
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{event}}
+	c.takeSnapshot()
+	expectNoSave(t, store)
+
+	event.Timestamp, event.Timeout = t0.Add(40*time.Second), 70
+	c.takeSnapshot()
+	got := waitSaved(t, store)
+	if got.Id != "42" || !got.LastSeen.AsTime().Equal(t0) || got.PacketsSent+got.PacketsReceived != 2 {
+		t.Errorf("closed row = %v, want id 42 ending at %v with 2 packets", got, t0)
+	}
+	if conns := c.GetConnections("alice-container"); len(conns) != 0 {
+		t.Errorf("idle flow still listed: %v", conns)
+	}
+
+	// Conntrack's own DESTROY comes much later and adds nothing.
+	c.processConntrackEvent(dnsQuery(ConntrackEventDestroy, t0.Add(110*time.Second), 2, 0))
+	expectNoSave(t, store)
+	if len(c.idleSplits) != 0 {
+		t.Errorf("idleSplits = %v after DESTROY, want empty", c.idleSplits)
+	}
+}
+
+func TestTakeSnapshot_ResumedUDPFlowIsNewSegment(t *testing.T) {
+	store := newFakeConnectionStore()
+	c := newStoreTestCollector(t, store)
+	c.config.UDPIdleTimeout = 30 * time.Second
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	event := dnsQuery(ConntrackEventNew, t0, 2, 110)
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{event}}
+	c.takeSnapshot()
+	event.Timestamp = t0.Add(40 * time.Second)
+	c.takeSnapshot()
+	waitSaved(t, store)
+
+	// The same socket sends another query on the still-live entry.
+	resumed := t0.Add(50 * time.Second)
+	c.processConntrackEvent(dnsQuery(ConntrackEventUpdate, resumed, 4, 120))
+	c.processConntrackEvent(dnsQuery(ConntrackEventDestroy, resumed.Add(120*time.Second), 4, 0))
+
+	got := waitSaved(t, store)
+	if got.Id != "42.1" {
+		t.Errorf("Id = %q, want 42.1", got.Id)
+	}
+	if got.PacketsSent != 1 || got.PacketsReceived != 1 || got.BytesSent != 60 || got.BytesReceived != 120 {
+		t.Errorf("counters %d/%d packets %d/%d bytes, want only the second exchange",
+			got.PacketsSent, got.PacketsReceived, got.BytesSent, got.BytesReceived)
+	}
+	if !got.FirstSeen.AsTime().Equal(resumed) || !got.LastSeen.AsTime().Equal(resumed) {
+		t.Errorf("seen %v..%v, want both at %v", got.FirstSeen.AsTime(), got.LastSeen.AsTime(), resumed)
+	}
+}
+
+func TestTakeSnapshot_ClosesExpiredUDPFlow(t *testing.T) {
+	store := newFakeConnectionStore()
+	c := newStoreTestCollector(t, store)
+	c.config.UDPIdleTimeout = 30 * time.Second
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	c.processConntrackEvent(dnsQuery(ConntrackEventNew, t0, 2, 30))
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{dnsQuery(ConntrackEventNew, t0.Add(10*time.Second), 2, 0)}}
+	c.takeSnapshot()
+
+	got := waitSaved(t, store)
+	if !got.LastSeen.AsTime().Equal(t0) {
+		t.Errorf("LastSeen = %v, want %v", got.LastSeen.AsTime(), t0)
+	}
+}
+
+func TestTakeSnapshot_TCPNeverIdleClosed(t *testing.T) {
+	store := newFakeConnectionStore()
+	c := newStoreTestCollector(t, store)
+	c.config.UDPIdleTimeout = 30 * time.Second
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	event := &ConntrackEvent{ID: "7", Protocol: "tcp", SrcIP: "10.100.0.5", DstIP: "192.0.2.1", DstPort: 22,
+		State: "ESTABLISHED", PacketsOrig: 3, PacketsReply: 3, Timeout: 432000, Timestamp: t0}
+	c.monitor = &snapshotMonitor{events: []*ConntrackEvent{event}}
+	c.takeSnapshot()
+	event.Timestamp = t0.Add(time.Hour)
+	c.takeSnapshot()
+
+	expectNoSave(t, store)
+	if conns := c.GetConnections("alice-container"); len(conns) != 1 {
+		t.Errorf("got %d connections, want the quiet ssh session", len(conns))
+	}
+}
+
+func TestICMPFlowsKeyedByIdentifier(t *testing.T) {
+	c := newTestCollector()
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ping := func(id uint16) *ConntrackEvent {
+		return &ConntrackEvent{ID: "9", Protocol: "icmp", SrcIP: "10.100.0.5", DstIP: "192.0.2.1",
+			ICMPType: 8, ICMPID: id, Timestamp: t0}
+	}
+
+	a, b := ping(100), ping(200)
+	if a.tuple() == b.tuple() {
+		t.Errorf("pings with different identifiers share tuple %q", a.tuple())
+	}
+	connA := c.convertToProto(a, "alice-container", "10.100.0.5")
+	if connA.IcmpType != 8 || connA.IcmpId != 100 {
+		t.Errorf("icmp type/id = %d/%d, want 8/100", connA.IcmpType, connA.IcmpId)
+	}
+	// Stored rows keep the key rows checkpointed by earlier daemons have.
+	if got, want := connectionKey(connA), "alice-container|9|3|10.100.0.5:0|192.0.2.1:0"; got != want {
+		t.Errorf("icmp key = %q, want the port form %q", got, want)
+	}
+
+	// A second ping reusing the ID is a new flow, not a continuation.
+	c.processConntrackEvent(a)
+	b.Timestamp = t0.Add(time.Minute)
+	c.processConntrackEvent(b)
+	c.mu.RLock()
+	since := c.openSince["9"].since
+	c.mu.RUnlock()
+	if !since.Equal(b.Timestamp) {
+		t.Errorf("first seen %v, want the second ping's %v", since, b.Timestamp)
+	}
+}
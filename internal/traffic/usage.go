@@ -0,0 +1,280 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/footprintai/containarium/internal/safecast"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ErrUsageUnsupported is returned by the usage methods when the configured
+// store doesn't maintain the daily rollup (e.g. --traffic-store memory).
+var ErrUsageUnsupported = errors.New("daily usage requires the PostgreSQL traffic store")
+
+// RollupOptions controls one RollupDailyUsage pass.
+type RollupOptions struct {
+	// Now is the pass time: bytes of still-open connections are billed to
+	// Now's UTC day, and peaks are recomputed for that day and the one
+	// before.
+	Now time.Time
+
+	// NetworkCIDR is the container network; peers outside it count as
+	// external.
+	NetworkCIDR string
+
+	// Backfill also bills connections recorded before the rollup existed
+	// and recomputes peaks for every day still in the connection history.
+	Backfill bool
+}
+
+// RollupResult reports what a RollupDailyUsage pass did.
+type RollupResult struct {
+	ConnectionsBilled int64
+	DaysRecomputed    int
+}
+
+// billUsageSQL moves each connection's unbilled bytes (its counters minus
+// the billed_* watermark) into traffic_daily and advances the watermark in
+// the same statement. Counters are cumulative and only ever grow (see
+// SaveConnection), so a long-lived connection checkpointed on many passes
+// is billed piecewise, each byte once, on the day the pass saw it; a closed
+// connection's remainder goes to its close day. A sampled row stands for
+// sample_weight flows, so its bytes and connection count are scaled by it.
+//
+// $1 pass time, $2 container network, $3 INGRESS direction, $4 include
+// pre-rollup rows (billed_sent NULL).
+const billUsageSQL = `
+	WITH due AS (
+		SELECT id, container_name, username,
+		       (COALESCE(ended_at, $1) AT TIME ZONE 'UTC')::date AS day,
+		       GREATEST(bytes_received - COALESCE(billed_received, 0), 0) * sample_weight AS d_in,
+		       GREATEST(bytes_sent - COALESCE(billed_sent, 0), 0) * sample_weight AS d_out,
+		       sample_weight,
+		       billed_at IS NULL AS first_billing,
+		       NOT ((CASE WHEN direction = $3 THEN source_ip ELSE dest_ip END) <<= $2::inet) AS external
+		FROM traffic_connections
+		WHERE (billed_at IS NULL OR bytes_sent > billed_sent OR bytes_received > billed_received)
+		  AND ($4 OR billed_sent IS NOT NULL)
+		FOR UPDATE
+	), marked AS (
+		UPDATE traffic_connections t
+		SET billed_sent = t.bytes_sent, billed_received = t.bytes_received, billed_at = $1
+		FROM due WHERE t.id = due.id
+		RETURNING t.id
+	), rolled AS (
+		INSERT INTO traffic_daily AS d (
+			container_name, day, username, bytes_in, bytes_out,
+			external_bytes_in, external_bytes_out, connection_count, updated_at
+		)
+		SELECT container_name, day, MAX(username), SUM(d_in), SUM(d_out),
+		       COALESCE(SUM(d_in) FILTER (WHERE external), 0),
+		       COALESCE(SUM(d_out) FILTER (WHERE external), 0),
+		       COALESCE(SUM(sample_weight) FILTER (WHERE first_billing), 0), $1
+		FROM due
+		GROUP BY container_name, day
+		ON CONFLICT (container_name, day) DO UPDATE SET
+			username = CASE WHEN EXCLUDED.username <> '' THEN EXCLUDED.username ELSE d.username END,
+			bytes_in = d.bytes_in + EXCLUDED.bytes_in,
+			bytes_out = d.bytes_out + EXCLUDED.bytes_out,
+			external_bytes_in = d.external_bytes_in + EXCLUDED.external_bytes_in,
+			external_bytes_out = d.external_bytes_out + EXCLUDED.external_bytes_out,
+			connection_count = d.connection_count + EXCLUDED.connection_count,
+			updated_at = EXCLUDED.updated_at
+	)
+	SELECT COUNT(*) FROM marked
+`
+
+// peakUsageSQL sweeps the open/close events of one UTC day [$1, $2) and
+// records each container's highest concurrent-connection count. Rows
+// already open at midnight enter at $1. Peaks only ever rise, so a day
+// whose history has partly aged out of traffic_connections keeps the
+// value computed while it was complete. A sampled row opens and closes
+// sample_weight connections at once.
+const peakUsageSQL = `
+	WITH ev AS (
+		SELECT container_name, username, GREATEST(started_at, $1) AS t, sample_weight AS delta
+		FROM traffic_connections
+		WHERE started_at < $2 AND (ended_at IS NULL OR ended_at >= $1)
+		UNION ALL
+		SELECT container_name, username, ended_at, -sample_weight
+		FROM traffic_connections
+		WHERE started_at < $2 AND ended_at >= $1 AND ended_at < $2
+	), running AS (
+		SELECT container_name, username,
+		       SUM(delta) OVER (PARTITION BY container_name ORDER BY t, delta ROWS UNBOUNDED PRECEDING) AS open
+		FROM ev
+	)
+	INSERT INTO traffic_daily AS d (container_name, day, username, peak_connections, updated_at)
+	SELECT container_name, $3::date, MAX(username), MAX(open), $4
+	FROM running
+	GROUP BY container_name
+	ON CONFLICT (container_name, day) DO UPDATE SET
+		peak_connections = GREATEST(d.peak_connections, EXCLUDED.peak_connections),
+		updated_at = EXCLUDED.updated_at
+`
+
+// RollupDailyUsage folds newly recorded traffic into traffic_daily: it
+// bills every connection's bytes not yet billed and recomputes peak
+// concurrency for the affected days. Safe to run repeatedly and
+// concurrently with collection.
+func (s *Store) RollupDailyUsage(ctx context.Context, opts RollupOptions) (RollupResult, error) {
+	var res RollupResult
+	if _, _, err := net.ParseCIDR(opts.NetworkCIDR); err != nil {
+		return res, fmt.Errorf("invalid container network %q: %w", opts.NetworkCIDR, err)
+	}
+	now := opts.Now.UTC()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed to begin usage rollup: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := tx.QueryRow(ctx, billUsageSQL, now, opts.NetworkCIDR,
+		safecast.I16(pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS), opts.Backfill,
+	).Scan(&res.ConnectionsBilled); err != nil {
+		return res, fmt.Errorf("failed to bill traffic usage: %w", err)
+	}
+
+	today := now.Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -1)
+	if opts.Backfill {
+		var earliest *time.Time
+		if err := tx.QueryRow(ctx, "SELECT MIN(started_at) FROM traffic_connections").Scan(&earliest); err != nil {
+			return res, fmt.Errorf("failed to find earliest connection: %w", err)
+		}
+		if earliest != nil && earliest.Before(from) {
+			from = earliest.UTC().Truncate(24 * time.Hour)
+		}
+	}
+	for day := from; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if _, err := tx.Exec(ctx, peakUsageSQL, day, day.AddDate(0, 0, 1), day.Format(time.DateOnly), now); err != nil {
+			return res, fmt.Errorf("failed to compute peak connections for %s: %w", day.Format(time.DateOnly), err)
+		}
+		res.DaysRecomputed++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return res, fmt.Errorf("failed to commit usage rollup: %w", err)
+	}
+	return res, nil
+}
+
+// GetDailyUsage returns the rollup rows for the UTC month starting at
+// month, ordered by container then day. An empty containerName returns
+// every container.
+func (s *Store) GetDailyUsage(ctx context.Context, containerName string, month time.Time) ([]*pb.DailyUsage, error) {
+	query := `
+		SELECT container_name, username, day, bytes_in, bytes_out,
+		       external_bytes_in, external_bytes_out, connection_count, peak_connections
+		FROM traffic_daily
+		WHERE day >= $1 AND day < $2
+	`
+	args := []interface{}{month.Format(time.DateOnly), month.AddDate(0, 1, 0).Format(time.DateOnly)}
+	if containerName != "" {
+		query += " AND container_name = $3"
+		args = append(args, containerName)
+	}
+	query += " ORDER BY container_name, day"
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily usage: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*pb.DailyUsage
+	for rows.Next() {
+		u := &pb.DailyUsage{}
+		var day time.Time
+		if err := rows.Scan(&u.ContainerName, &u.Username, &day, &u.BytesIn, &u.BytesOut,
+			&u.ExternalBytesIn, &u.ExternalBytesOut, &u.ConnectionCount, &u.PeakConnections); err != nil {
+			return nil, fmt.Errorf("failed to scan daily usage: %w", err)
+		}
+		u.Day = day.Format(time.DateOnly)
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating daily usage: %w", err)
+	}
+	return out, nil
+}
+
+// ParseUsageMonth parses a YYYY-MM month into the first instant of that
+// UTC month. Empty means the month containing now.
+func ParseUsageMonth(month string, now time.Time) (time.Time, error) {
+	if month == "" {
+		now = now.UTC()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month %q (want YYYY-MM)", month)
+	}
+	return t, nil
+}
+
+// UsageTotals sums daily rows into one monthly total per container, sorted
+// by container name. Peak is the highest daily peak.
+func UsageTotals(days []*pb.DailyUsage) []*pb.DailyUsage {
+	byName := make(map[string]*pb.DailyUsage)
+	for _, d := range days {
+		t, ok := byName[d.ContainerName]
+		if !ok {
+			t = &pb.DailyUsage{ContainerName: d.ContainerName}
+			byName[d.ContainerName] = t
+		}
+		if d.Username != "" {
+			t.Username = d.Username
+		}
+		t.BytesIn += d.BytesIn
+		t.BytesOut += d.BytesOut
+		t.ExternalBytesIn += d.ExternalBytesIn
+		t.ExternalBytesOut += d.ExternalBytesOut
+		t.ConnectionCount += d.ConnectionCount
+		t.PeakConnections = max(t.PeakConnections, d.PeakConnections)
+	}
+	out := make([]*pb.DailyUsage, 0, len(byName))
+	for _, t := range byName {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ContainerName < out[j].ContainerName })
+	return out
+}
+
+func (c *Collector) usageStore() (UsageRollup, error) {
+	u, ok := c.store.(UsageRollup)
+	if !ok {
+		return nil, ErrUsageUnsupported
+	}
+	return u, nil
+}
+
+// RollupUsage runs one billing rollup pass now. With backfill it also
+// bills history recorded before the rollup existed.
+func (c *Collector) RollupUsage(ctx context.Context, backfill bool) (RollupResult, error) {
+	u, err := c.usageStore()
+	if err != nil {
+		return RollupResult{}, err
+	}
+	return u.RollupDailyUsage(ctx, RollupOptions{
+		Now:         time.Now(),
+		NetworkCIDR: c.config.NetworkCIDR,
+		Backfill:    backfill,
+	})
+}
+
+// DailyUsage returns the rollup rows for one container (or all, when
+// containerName is empty) in the given month.
+func (c *Collector) DailyUsage(ctx context.Context, containerName string, month time.Time) ([]*pb.DailyUsage, error) {
+	u, err := c.usageStore()
+	if err != nil {
+		return nil, err
+	}
+	return u.GetDailyUsage(ctx, containerName, month)
+}
@@ -0,0 +1,57 @@
+package traffic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestParseUsageMonth(t *testing.T) {
+	now := time.Date(2026, 3, 17, 23, 0, 0, 0, time.FixedZone("X", -5*3600)) // already Mar 18 in UTC
+	got, err := ParseUsageMonth("", now)
+	if err != nil || !got.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("default month = %v, %v", got, err)
+	}
+	got, err = ParseUsageMonth("2025-05", now)
+	if err != nil || !got.Equal(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("2025-05 = %v, %v", got, err)
+	}
+	for _, bad := range []string{"2025-5", "2025-13", "May 2025"} {
+		if _, err := ParseUsageMonth(bad, now); err == nil {
+			t.Errorf("ParseUsageMonth(%q) = nil error", bad)
+		}
+	}
+}
+
+func TestUsageTotals(t *testing.T) {
+	days := []*pb.DailyUsage{
+		{ContainerName: "bob-container", Username: "bob", Day: "2025-05-01", BytesOut: 5, PeakConnections: 2},
+		{ContainerName: "alice-container", Username: "alice", Day: "2025-05-01", BytesIn: 10, BytesOut: 20, ExternalBytesOut: 15, ConnectionCount: 3, PeakConnections: 4},
+		{ContainerName: "alice-container", Day: "2025-05-02", BytesIn: 1, BytesOut: 2, ExternalBytesOut: 2, ConnectionCount: 1, PeakConnections: 7},
+	}
+	totals := UsageTotals(days)
+	if len(totals) != 2 || totals[0].ContainerName != "alice-container" || totals[1].ContainerName != "bob-container" {
+		t.Fatalf("totals = %v", totals)
+	}
+	a := totals[0]
+	if a.Username != "alice" || a.Day != "" || a.BytesIn != 11 || a.BytesOut != 22 || a.ExternalBytesOut != 17 || a.ConnectionCount != 4 {
+		t.Errorf("alice total = %+v", a)
+	}
+	if a.PeakConnections != 7 {
+		t.Errorf("peak = %d, want the highest daily peak 7", a.PeakConnections)
+	}
+}
+
+func TestCollectorUsage_RequiresRollupStore(t *testing.T) {
+	c := newTestCollector()
+	c.store = NewMemoryStore(0)
+	if _, err := c.DailyUsage(context.Background(), "alice-container", time.Now()); !errors.Is(err, ErrUsageUnsupported) {
+		t.Errorf("DailyUsage on memory store: %v", err)
+	}
+	if _, err := c.RollupUsage(context.Background(), true); !errors.Is(err, ErrUsageUnsupported) {
+		t.Errorf("RollupUsage on memory store: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package traffic
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestProcessConntrackEvent_ZonesKeepIdenticalTuplesApart(t *testing.T) {
+	c := newTestCollector()
+	c.cache.ipToName["10.100.0.5"] = "alice-container"
+
+	now := time.Now()
+	flow := func(zone uint16, bytes int64) *ConntrackEvent {
+		return &ConntrackEvent{
+			ID:        "42",
+			Type:      ConntrackEventNew,
+			Protocol:  "tcp",
+			SrcIP:     "10.100.0.5",
+			SrcPort:   51000,
+			DstIP:     "1.1.1.1",
+			DstPort:   443,
+			BytesOrig: bytes,
+			Timestamp: now,
+			Zone:      zone,
+		}
+	}
+	c.processConntrackEvent(flow(1, 100))
+	c.processConntrackEvent(flow(2, 200))
+
+	if len(c.connections) != 2 {
+		t.Fatalf("identical tuples in two zones produced %d connections, want 2", len(c.connections))
+	}
+	byZone := map[uint32]*pb.Connection{}
+	for _, conn := range c.connections {
+		byZone[conn.Zone] = conn
+	}
+	if byZone[1].GetBytesSent() != 100 || byZone[2].GetBytesSent() != 200 {
+		t.Errorf("zone counters merged: zone1=%d zone2=%d", byZone[1].GetBytesSent(), byZone[2].GetBytesSent())
+	}
+	if connectionKey(byZone[1]) == connectionKey(byZone[2]) {
+		t.Errorf("stored rows for different zones share key %q", connectionKey(byZone[1]))
+	}
+
+	// Closing one zone's flow must leave the other open.
+	destroy := flow(1, 150)
+	destroy.Type = ConntrackEventDestroy
+	c.processConntrackEvent(destroy)
+	if len(c.connections) != 1 {
+		t.Fatalf("after DESTROY in zone 1: %d connections, want 1", len(c.connections))
+	}
+	if _, ok := c.connections[flow(2, 0).Key()]; !ok {
+		t.Errorf("zone 2 connection was removed by zone 1's DESTROY")
+	}
+}
+
+func TestConnectionKey_DefaultZoneUnchanged(t *testing.T) {
+	conn := &pb.Connection{Id: "42", ContainerName: "alice-container", Protocol: pb.Protocol_PROTOCOL_TCP,
+		SourceIp: "10.100.0.5", SourcePort: 51000, DestIp: "1.1.1.1", DestPort: 443}
+	// Rows checkpointed before zones were recorded must still match on close.
+	if got, want := connectionKey(conn), "alice-container|42|1|10.100.0.5:51000|1.1.1.1:443"; got != want {
+		t.Errorf("default-zone key = %q, want %q", got, want)
+	}
+	if got := (&ConntrackEvent{ID: "42"}).Key(); got != "42" {
+		t.Errorf("default-zone event key = %q, want bare ID", got)
+	}
+}
+
+func TestSnapshotFilter_Match(t *testing.T) {
+	tcp := &ConntrackEvent{Protocol: "tcp", Zone: 7}
+	udp := &ConntrackEvent{Protocol: "udp"}
+	tests := []struct {
+		name     string
+		filter   SnapshotFilter
+		tcp, udp bool
+	}{
+		{"zero value", SnapshotFilter{}, true, true},
+		{"zone", SnapshotFilter{Zones: []uint16{7}}, true, false},
+		{"default zone", SnapshotFilter{Zones: []uint16{0}}, false, true},
+		{"protocol", SnapshotFilter{Protocols: []string{"udp"}}, false, true},
+		{"zone and protocol", SnapshotFilter{Zones: []uint16{7}, Protocols: []string{"udp"}}, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Match(tcp); got != tt.tcp {
+			t.Errorf("%s: Match(tcp) = %v, want %v", tt.name, got, tt.tcp)
+		}
+		if got := tt.filter.Match(udp); got != tt.udp {
+			t.Errorf("%s: Match(udp) = %v, want %v", tt.name, got, tt.udp)
+		}
+	}
+}
@@ -90,6 +90,10 @@ type BoxSpec struct {
 	GitCredential string
 	WorkspacePath string
 
+	// CloudInit is cloud-init user-data for the first boot; empty = none.
+	// Ignored on K8s v1.
+	CloudInit string
+
 	// Monitoring wiring, only meaningful when Monitoring is true. These are
 	// daemon-runtime values the server supplies per-create (collector
 	// endpoint, the originating backend's id for OTel resource attributes,
@@ -105,6 +109,12 @@ type BoxSpec struct {
 	// installing packages/stack — the daemon uses it to flip async-create
 	// state to "provisioning". A K8s backend may never call it.
 	OnProvisioning func()
+
+	// OnStep, if set, is called as each provisioning step begins, with the
+	// backend's step name (LXC: container.Step*). The daemon records these
+	// so a caller can watch a create progress. A K8s backend may never call
+	// it.
+	OnStep func(step string)
 }
 
 // BoxEndpoint describes how an agent reaches a box over SSH. The server turns
@@ -153,6 +163,7 @@ type BoxStatus struct {
 	IdleThresholdMinutes      int32
 	TTLExpiresAt              time.Time
 	StoppedAt                 time.Time
+	LastStartedAt             time.Time // zero if never stamped; callers fall back to CreatedAt
 	DeleteAfterStoppedSeconds int64
 	DeletePolicy              string // "protected" or "" (unprotected)
 
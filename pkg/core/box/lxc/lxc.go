@@ -189,8 +189,10 @@ func specToCreateOptions(spec box.BoxSpec) container.CreateOptions {
 		GitRef:                 spec.GitRef,
 		GitCredential:          spec.GitCredential,
 		WorkspacePath:          spec.WorkspacePath,
+		CloudInit:              spec.CloudInit,
 		AutoStart:              spec.AutoStart,
 		OnProvisioning:         spec.OnProvisioning,
+		OnStep:                 spec.OnStep,
 	}
 }
 
@@ -212,6 +214,7 @@ func StatusFromInfo(info *incus.ContainerInfo) box.BoxStatus {
 		IdleThresholdMinutes:      info.IdleThresholdMinutes,
 		TTLExpiresAt:              info.TTLExpiresAt,
 		StoppedAt:                 info.StoppedAt,
+		LastStartedAt:             info.LastStartedAt,
 		DeleteAfterStoppedSeconds: info.DeleteAfterStoppedSeconds,
 		DeletePolicy:              info.DeletePolicy,
 		Image:                     info.Image,
@@ -0,0 +1,384 @@
+package container
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Connectivity test methods.
+const (
+	ProbeTCP  = "tcp"
+	ProbeHTTP = "http"
+	ProbeDNS  = "dns"
+	ProbePing = "ping"
+)
+
+// Stages a connectivity test can fail at, in the order a connection
+// passes them. StageProbe means the probe itself couldn't run, e.g. the
+// image lacks the tool.
+const (
+	StageDNS     = "dns"
+	StageConnect = "connect"
+	StageTLS     = "tls"
+	StageHTTP    = "http"
+	StageProbe   = "probe"
+)
+
+// Bounds on how long one probe step may take inside the container.
+const (
+	DefaultProbeTimeout = 5 * time.Second
+	MaxProbeTimeout     = 30 * time.Second
+)
+
+// hostnamePattern is what a probe accepts as a hostname. Hosts end up in
+// argv, so a leading "-" is refused: the tool would take it for a flag.
+var hostnamePattern = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_.-]{0,252})$`)
+
+// ProbeTarget is a validated connectivity test target.
+type ProbeTarget struct {
+	Method string
+	// Host is a hostname or an IP literal, without brackets.
+	Host string
+	// Port is 0 for dns and ping.
+	Port int
+	// URL is set for http.
+	URL string
+}
+
+// ParseProbeTarget validates target, a host, host:port or http(s) URL,
+// for method. An empty method is picked from the target: http for a URL,
+// tcp for host:port, dns for a bare host.
+func ParseProbeTarget(method, target string) (*ProbeTarget, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return nil, fmt.Errorf("target is required")
+	}
+	t := &ProbeTarget{Method: strings.ToLower(strings.TrimSpace(method))}
+
+	var scheme string
+	switch {
+	case strings.Contains(target, "://"):
+		u, err := url.Parse(target)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target URL: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("target URL scheme must be http or https, not %q", u.Scheme)
+		}
+		scheme, t.Host = u.Scheme, u.Hostname()
+		t.Port = 80
+		if scheme == "https" {
+			t.Port = 443
+		}
+		if p := u.Port(); p != "" {
+			if t.Port, err = parseProbePort(p); err != nil {
+				return nil, err
+			}
+		}
+		t.URL = u.String()
+		if t.Method == "" {
+			t.Method = ProbeHTTP
+		}
+	default:
+		if host, port, err := net.SplitHostPort(target); err == nil {
+			if t.Port, err = parseProbePort(port); err != nil {
+				return nil, err
+			}
+			t.Host = host
+		} else {
+			t.Host = strings.TrimSuffix(strings.TrimPrefix(target, "["), "]")
+		}
+		if t.Method == "" {
+			t.Method = ProbeDNS
+			if t.Port != 0 {
+				t.Method = ProbeTCP
+			}
+		}
+	}
+	if net.ParseIP(t.Host) == nil && !hostnamePattern.MatchString(t.Host) {
+		return nil, fmt.Errorf("invalid target host %q", t.Host)
+	}
+
+	switch t.Method {
+	case ProbeTCP:
+		if t.Port == 0 {
+			return nil, fmt.Errorf("tcp needs a port: use host:port or a URL")
+		}
+	case ProbeHTTP:
+		if t.URL == "" {
+			if t.Port == 0 {
+				t.Port = 80
+			}
+			scheme = "http"
+			if t.Port == 443 {
+				scheme = "https"
+			}
+			t.URL = (&url.URL{Scheme: scheme, Host: net.JoinHostPort(t.Host, strconv.Itoa(t.Port)), Path: "/"}).String()
+		}
+	case ProbeDNS, ProbePing:
+		t.Port, t.URL = 0, ""
+	default:
+		return nil, fmt.Errorf("unknown method %q (want tcp, http, dns or ping)", method)
+	}
+	return t, nil
+}
+
+func parseProbePort(s string) (int, error) {
+	p, err := strconv.Atoi(s)
+	if err != nil || p < 1 || p > 65535 {
+		return 0, fmt.Errorf("invalid target port %q", s)
+	}
+	return p, nil
+}
+
+// ProbeResult is the outcome of one step of a connectivity test. A target
+// that can't be reached is a result with FailureStage set, not an error.
+type ProbeResult struct {
+	// ResolvedIPs are the target's addresses as the container resolves
+	// them, in resolver order.
+	ResolvedIPs []string
+	// RemoteIP is the address the probe connected to.
+	RemoteIP   string
+	Reachable  bool
+	HTTPStatus int
+	// FailureStage is one of the Stage constants; empty on success.
+	FailureStage string
+	Error        string
+	// Latency is the time to connect (tcp), to complete the request
+	// (http), the echo round trip (ping) or to resolve (dns; this one
+	// includes the exec round trip).
+	Latency time.Duration
+}
+
+// runningContainerName returns a user's container name, or ErrNotRunning.
+func (m *Manager) runningContainerName(username string) (string, error) {
+	containerName := username + "-container"
+	info, err := m.incus.GetContainer(containerName)
+	if err != nil {
+		return "", fmt.Errorf("container not found: %w", err)
+	}
+	if info == nil {
+		return "", fmt.Errorf("container not found: %s", containerName)
+	}
+	if info.State != "Running" {
+		return "", fmt.Errorf("%w (state: %s)", ErrNotRunning, info.State)
+	}
+	return containerName, nil
+}
+
+// ResolveHost resolves host inside a user's container, through the
+// container's own resolver configuration. An IP literal is returned as is.
+func (m *Manager) ResolveHost(username, host string, timeout time.Duration) (*ProbeResult, error) {
+	containerName, err := m.runningContainerName(username)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) != nil {
+		return &ProbeResult{ResolvedIPs: []string{host}, Reachable: true}, nil
+	}
+
+	start := time.Now()
+	stdout, stderr, err := m.incus.ExecWithOutput(containerName, []string{"timeout", probeSeconds(timeout), "getent", "ahosts", host})
+	res := &ProbeResult{Latency: time.Since(start), ResolvedIPs: parseGetentHosts(stdout)}
+	switch {
+	case len(res.ResolvedIPs) > 0:
+		res.Reachable = true
+	case execExitCode(err) == 2:
+		res.FailureStage, res.Error = StageDNS, fmt.Sprintf("%s does not resolve", host)
+	case execExitCode(err) == 124:
+		res.FailureStage, res.Error = StageDNS, fmt.Sprintf("resolving %s timed out after %s", host, timeout)
+	default:
+		res.FailureStage, res.Error = probeFailure(StageDNS, "getent", err, stderr)
+	}
+	return res, nil
+}
+
+// ProbeConnectivity dials t from inside a user's container at addr, one of
+// the addresses ResolveHost returned, so the probe reaches the address the
+// caller checked rather than whatever a second lookup returns.
+func (m *Manager) ProbeConnectivity(username string, t *ProbeTarget, addr string, timeout time.Duration) (*ProbeResult, error) {
+	containerName, err := m.runningContainerName(username)
+	if err != nil {
+		return nil, err
+	}
+	secs := probeSeconds(timeout)
+
+	switch t.Method {
+	case ProbeTCP:
+		stdout, stderr, err := m.incus.ExecWithOutput(containerName, []string{"timeout", secs, "bash", "-c", tcpProbeScript, "probe", addr, strconv.Itoa(t.Port)})
+		return parseTCPProbe(addr, stdout, stderr, err, timeout), nil
+	case ProbeHTTP:
+		argv := []string{
+			"curl", "-sS", "-o", "/dev/null",
+			"--connect-timeout", secs, "--max-time", secs,
+			"--resolve", net.JoinHostPort(t.Host, strconv.Itoa(t.Port)) + ":" + bracketIPv6(addr),
+			"-w", curlWriteOut, t.URL,
+		}
+		stdout, stderr, err := m.incus.ExecWithOutput(containerName, argv)
+		return parseCurlProbe(stdout, stderr, err, strings.HasPrefix(t.URL, "https:")), nil
+	case ProbePing:
+		stdout, stderr, err := m.incus.ExecWithOutput(containerName, []string{"timeout", secs, "ping", "-c", "1", "-W", secs, addr})
+		return parsePingProbe(addr, stdout, stderr, err, timeout), nil
+	}
+	return nil, fmt.Errorf("method %q has no connect step", t.Method)
+}
+
+// tcpProbeScript connects with bash's /dev/tcp and prints the clock before
+// and after in microseconds. Host and port arrive as $1 and $2, never
+// spliced into the script. EPOCHREALTIME needs bash 5.
+const tcpProbeScript = `s=$EPOCHREALTIME; exec 3<>"/dev/tcp/$1/$2" || exit 7; e=$EPOCHREALTIME; echo "${s//[.,]/} ${e//[.,]/}"`
+
+// curlWriteOut is what curl prints after the transfer, failed or not.
+// remote_ip goes last: it is empty when curl never connected.
+const curlWriteOut = `%{http_code} %{time_connect} %{time_appconnect} %{time_total} %{remote_ip}\n`
+
+func probeSeconds(d time.Duration) string {
+	return strconv.Itoa(max(1, int(d.Round(time.Second)/time.Second)))
+}
+
+func bracketIPv6(addr string) string {
+	if strings.Contains(addr, ":") {
+		return "[" + addr + "]"
+	}
+	return addr
+}
+
+// execExitCode recovers a command's exit status from an ExecWithOutput
+// error; 0 without an error, -1 when the error doesn't carry one.
+func execExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var code int
+	msg := err.Error()
+	if i := strings.LastIndex(msg, "exited with code "); i >= 0 {
+		if _, scanErr := fmt.Sscanf(msg[i:], "exited with code %d", &code); scanErr == nil {
+			return code
+		}
+	}
+	return -1
+}
+
+// probeFailure names the stage and message for a probe command that
+// failed without output, telling a missing tool apart from a failed step.
+func probeFailure(stage, tool string, err error, stderr string) (string, string) {
+	if execExitCode(err) == 127 {
+		return StageProbe, tool + " is not installed in the container"
+	}
+	msg := strings.TrimSpace(stderr)
+	if msg == "" && err != nil {
+		msg = err.Error()
+	}
+	return stage, msg
+}
+
+// parseGetentHosts reads `getent ahosts` output, one address per socket
+// type, into its distinct addresses.
+func parseGetentHosts(out string) []string {
+	var ips []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || net.ParseIP(fields[0]) == nil || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		ips = append(ips, fields[0])
+	}
+	return ips
+}
+
+func parseTCPProbe(addr, stdout, stderr string, err error, timeout time.Duration) *ProbeResult {
+	res := &ProbeResult{RemoteIP: addr}
+	var start, end int64
+	if _, scanErr := fmt.Sscanf(stdout, "%d %d", &start, &end); scanErr == nil && err == nil {
+		res.Reachable = true
+		res.Latency = time.Duration(end-start) * time.Microsecond
+		return res
+	}
+	switch execExitCode(err) {
+	case 124:
+		res.FailureStage, res.Error = StageConnect, fmt.Sprintf("connect timed out after %s", timeout)
+	case 7:
+		// bash reports "bash: connect: Connection refused" and the like.
+		msg := strings.TrimSpace(stderr)
+		if i := strings.LastIndex(msg, "connect: "); i >= 0 {
+			msg = msg[i+len("connect: "):]
+		}
+		if j := strings.IndexByte(msg, '\n'); j >= 0 {
+			msg = msg[:j]
+		}
+		res.FailureStage, res.Error = StageConnect, msg
+	default:
+		res.FailureStage, res.Error = probeFailure(StageProbe, "bash", err, stderr)
+	}
+	return res
+}
+
+// curlError matches the message `curl -sS` prints on failure.
+var curlError = regexp.MustCompile(`curl: \((\d+)\) (.*)`)
+
+// curlTLSErrors are the curl exit codes of a failed TLS handshake or
+// certificate check.
+var curlTLSErrors = map[int]bool{35: true, 51: true, 53: true, 54: true, 58: true, 59: true, 60: true, 64: true, 66: true, 77: true, 80: true, 83: true, 90: true, 91: true}
+
+func parseCurlProbe(stdout, stderr string, err error, https bool) *ProbeResult {
+	res := &ProbeResult{}
+	var connect, appconnect, total float64
+	n, _ := fmt.Sscanf(stdout, "%d %g %g %g", &res.HTTPStatus, &connect, &appconnect, &total)
+	if n < 4 {
+		res.FailureStage, res.Error = probeFailure(StageProbe, "curl", err, stderr)
+		return res
+	}
+	if fields := strings.Fields(stdout); len(fields) > 4 {
+		res.RemoteIP = fields[4]
+	}
+	res.Latency = time.Duration(total * float64(time.Second))
+	m := curlError.FindStringSubmatch(stderr)
+	if err == nil || m == nil {
+		res.Reachable = err == nil
+		if !res.Reachable {
+			res.FailureStage, res.Error = probeFailure(StageHTTP, "curl", err, stderr)
+		}
+		return res
+	}
+
+	code, _ := strconv.Atoi(m[1])
+	res.Error = strings.TrimSpace(m[2])
+	switch {
+	case code == 6:
+		res.FailureStage = StageDNS
+	case code == 7 || connect == 0:
+		res.FailureStage = StageConnect
+	case curlTLSErrors[code] || https && appconnect == 0:
+		res.FailureStage = StageTLS
+	default:
+		res.FailureStage = StageHTTP
+	}
+	return res
+}
+
+// pingRTT matches the round trip in ping's reply line.
+var pingRTT = regexp.MustCompile(`time[=<]([0-9.]+) ?ms`)
+
+func parsePingProbe(addr, stdout, stderr string, err error, timeout time.Duration) *ProbeResult {
+	res := &ProbeResult{RemoteIP: addr}
+	if m := pingRTT.FindStringSubmatch(stdout); m != nil {
+		ms, _ := strconv.ParseFloat(m[1], 64)
+		res.Reachable = true
+		res.Latency = time.Duration(ms * float64(time.Millisecond))
+		return res
+	}
+	switch execExitCode(err) {
+	case 1, 124:
+		res.FailureStage, res.Error = StageConnect, fmt.Sprintf("no echo reply within %s", timeout)
+	default:
+		res.FailureStage, res.Error = probeFailure(StageConnect, "ping", err, stderr)
+	}
+	return res
+}
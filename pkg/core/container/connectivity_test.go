@@ -0,0 +1,129 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+)
+
+func TestParseProbeTarget(t *testing.T) {
+	cases := []struct {
+		method, target string
+		want           ProbeTarget
+	}{
+		{"", "https://registry.corp.example/v2/", ProbeTarget{Method: ProbeHTTP, Host: "registry.corp.example", Port: 443, URL: "https://registry.corp.example/v2/"}},
+		{"", "db.internal:5432", ProbeTarget{Method: ProbeTCP, Host: "db.internal", Port: 5432}},
+		{"", "pypi.org", ProbeTarget{Method: ProbeDNS, Host: "pypi.org"}},
+		{"http", "10.0.0.5:443", ProbeTarget{Method: ProbeHTTP, Host: "10.0.0.5", Port: 443, URL: "https://10.0.0.5:443/"}},
+		{"tcp", "http://example.com:8080/x", ProbeTarget{Method: ProbeTCP, Host: "example.com", Port: 8080, URL: "http://example.com:8080/x"}},
+		{"PING", "[2001:db8::1]:22", ProbeTarget{Method: ProbePing, Host: "2001:db8::1"}},
+	}
+	for _, tc := range cases {
+		got, err := ParseProbeTarget(tc.method, tc.target)
+		if err != nil {
+			t.Errorf("ParseProbeTarget(%q, %q): %v", tc.method, tc.target, err)
+			continue
+		}
+		if *got != tc.want {
+			t.Errorf("ParseProbeTarget(%q, %q) = %+v, want %+v", tc.method, tc.target, *got, tc.want)
+		}
+	}
+
+	for _, bad := range [][2]string{
+		{"", ""},
+		{"tcp", "example.com"},
+		{"", "ftp://example.com"},
+		{"", "-oProxyCommand=x:22"},
+		{"", "example.com:70000"},
+		{"smtp", "example.com:25"},
+		{"dns", "exa mple.com"},
+	} {
+		if _, err := ParseProbeTarget(bad[0], bad[1]); err == nil {
+			t.Errorf("ParseProbeTarget(%q, %q): want an error", bad[0], bad[1])
+		}
+	}
+}
+
+func TestParseGetentHosts(t *testing.T) {
+	out := "93.184.216.34   STREAM example.com\n93.184.216.34   DGRAM  \n2606:2800:220:1::   STREAM \n"
+	if got, want := parseGetentHosts(out), []string{"93.184.216.34", "2606:2800:220:1::"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGetentHosts = %q, want %q", got, want)
+	}
+}
+
+func TestParseCurlProbe(t *testing.T) {
+	exit := func(code int) error { return fmt.Errorf("command execution failed: command exited with code %d", code) }
+	cases := []struct {
+		name           string
+		stdout, stderr string
+		err            error
+		https          bool
+		want           ProbeResult
+	}{
+		{"ok", "404 0.002 0.010 0.031 10.0.0.5\n", "", nil, true,
+			ProbeResult{Reachable: true, HTTPStatus: 404, RemoteIP: "10.0.0.5", Latency: 31 * time.Millisecond}},
+		{"refused", "000 0.000 0.000 0.001 \n", "curl: (7) Failed to connect to 10.0.0.5 port 443: Connection refused\n", exit(7), true,
+			ProbeResult{FailureStage: StageConnect, Error: "Failed to connect to 10.0.0.5 port 443: Connection refused", Latency: time.Millisecond}},
+		{"bad cert", "000 0.002 0.000 0.012 10.0.0.5\n", "curl: (60) SSL certificate problem: self-signed certificate\n", exit(60), true,
+			ProbeResult{FailureStage: StageTLS, RemoteIP: "10.0.0.5", Error: "SSL certificate problem: self-signed certificate", Latency: 12 * time.Millisecond}},
+		{"slow server", "000 0.002 0.009 5.001 10.0.0.5\n", "curl: (28) Operation timed out after 5001 milliseconds with 0 bytes received\n", exit(28), true,
+			ProbeResult{FailureStage: StageHTTP, RemoteIP: "10.0.0.5", Error: "Operation timed out after 5001 milliseconds with 0 bytes received", Latency: 5001 * time.Millisecond}},
+		{"no curl", "", "", exit(127), false,
+			ProbeResult{FailureStage: StageProbe, Error: "curl is not installed in the container"}},
+	}
+	for _, tc := range cases {
+		if got := parseCurlProbe(tc.stdout, tc.stderr, tc.err, tc.https); !reflect.DeepEqual(*got, tc.want) {
+			t.Errorf("%s: parseCurlProbe = %+v, want %+v", tc.name, *got, tc.want)
+		}
+	}
+}
+
+func TestResolveHostAndProbe(t *testing.T) {
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Running"}
+	mock.Containers["bob-container"] = &incus.ContainerInfo{Name: "bob-container", State: "Stopped"}
+	var calls [][]string
+	mock.ExecWithOutputFunc = func(_ string, command []string) (string, string, error) {
+		calls = append(calls, command)
+		switch command[2] {
+		case "getent":
+			if command[4] == "nowhere.example" {
+				return "", "", errors.New("command exited with code 2")
+			}
+			return "10.0.0.5 STREAM registry\n", "", nil
+		case "bash":
+			return "", "probe: connect: Connection refused\nprobe: line 1: /dev/tcp/10.0.0.5/5000: Connection refused\n", errors.New("command exited with code 7")
+		}
+		return "", "", nil
+	}
+	m := NewWithBackend(mock)
+
+	res, err := m.ResolveHost("alice", "registry", time.Second)
+	if err != nil || !reflect.DeepEqual(res.ResolvedIPs, []string{"10.0.0.5"}) {
+		t.Fatalf("ResolveHost = %+v, %v", res, err)
+	}
+	if res, _ := m.ResolveHost("alice", "nowhere.example", time.Second); res.FailureStage != StageDNS {
+		t.Errorf("unresolvable host: %+v", res)
+	}
+
+	target := &ProbeTarget{Method: ProbeTCP, Host: "registry", Port: 5000}
+	res, err = m.ProbeConnectivity("alice", target, "10.0.0.5", 2*time.Second)
+	if err != nil {
+		t.Fatalf("ProbeConnectivity: %v", err)
+	}
+	if res.Reachable || res.FailureStage != StageConnect || res.Error != "Connection refused" {
+		t.Errorf("refused port: %+v", res)
+	}
+	if got := calls[len(calls)-1]; got[0] != "timeout" || got[1] != "2" || got[len(got)-2] != "10.0.0.5" || got[len(got)-1] != "5000" {
+		t.Errorf("tcp probe argv = %q", got)
+	}
+
+	if _, err := m.ResolveHost("bob", "registry", time.Second); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("stopped container: err = %v, want ErrNotRunning", err)
+	}
+}
@@ -0,0 +1,162 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+// MaxFactPackages bounds the dpkg list CollectFacts reads. A box with more
+// packages than this reports PackagesTruncated.
+const MaxFactPackages = 5000
+
+// Facts is what a container diff compares about one container.
+type Facts struct {
+	State string
+
+	// Config is the expanded Incus config, profiles applied, without the
+	// keys every container has a value of its own for (volatile state,
+	// tenant, lifecycle timestamps).
+	Config map[string]string
+
+	// Devices maps "<device>.<key>" to the value, e.g. "root.size".
+	// Addresses and MACs are left out for the same reason.
+	Devices map[string]string
+
+	// System holds "os", "kernel" and "docker" as read inside the
+	// container. A fact that couldn't be read is missing, with a note.
+	// Nil when the container isn't running.
+	System map[string]string
+
+	// Packages maps installed dpkg packages to their versions. Nil when
+	// not asked for, when the container isn't running or has no dpkg.
+	Packages map[string]string
+
+	// PackagesTruncated is set when the box has more than MaxFactPackages.
+	PackagesTruncated bool
+
+	// Notes says what couldn't be collected.
+	Notes []string
+}
+
+// perContainerConfigKeys hold a value of the container's own, so they
+// differ between any two boxes and say nothing about why one works.
+var perContainerConfigKeys = map[string]bool{
+	incus.TenantLabelKey:    true,
+	incus.ConntrackMarkKey:  true,
+	incus.LastStartedAtKey:  true,
+	incus.StoppedAtKey:      true,
+	incus.TTLExpiresAtKey:   true,
+	incus.ProvisionStepsKey: true,
+}
+
+// perContainerDeviceKeys are the NIC settings that are unique per box.
+var perContainerDeviceKeys = map[string]bool{
+	"hwaddr":       true,
+	"ipv4.address": true,
+	"ipv6.address": true,
+	"host_name":    true,
+}
+
+// systemFactCommands read the system facts. Each prints one line; the os
+// command falls back to os-release for images without lsb_release, and
+// the docker one prints nothing when Docker isn't installed.
+var systemFactCommands = []struct {
+	key  string
+	argv []string
+}{
+	{"os", []string{"sh", "-c", `lsb_release -ds 2>/dev/null || (. /etc/os-release && echo "$PRETTY_NAME")`}},
+	{"kernel", []string{"uname", "-r"}},
+	{"docker", []string{"sh", "-c", `command -v docker >/dev/null 2>&1 || exit 0; docker version --format '{{.Server.Version}}' 2>/dev/null || docker --version`}},
+}
+
+// dpkgCommand lists installed packages as "name<TAB>version" lines.
+var dpkgCommand = []string{"dpkg-query", "-W", "-f=${Package}\t${Version}\n"}
+
+// ExpandedConfig returns a container's Incus config and devices with its
+// profiles applied.
+func (m *Manager) ExpandedConfig(containerName string) (map[string]string, map[string]map[string]string, error) {
+	return m.incus.GetExpandedInstance(containerName)
+}
+
+// CollectFacts reads what a container diff compares about the box name,
+// which is in state ("Running", "Stopped", ...). config and devices are its
+// expanded Incus config, nil on a runtime without one; exec runs a command
+// inside it, nil on a runtime without in-box exec. Facts that need a
+// running box are skipped when it is stopped, and a command that fails
+// inside it becomes a note.
+func CollectFacts(name, state string, config map[string]string, devices map[string]map[string]string, exec func(cmd []string) (stdout, stderr string, err error), withPackages bool) *Facts {
+	facts := &Facts{
+		State:   state,
+		Config:  make(map[string]string),
+		Devices: make(map[string]string),
+	}
+	if config == nil && devices == nil {
+		facts.Notes = append(facts.Notes, fmt.Sprintf("%s: runtime has no Incus config to compare", name))
+	}
+	for key, value := range config {
+		if !strings.HasPrefix(key, "volatile.") && !perContainerConfigKeys[key] {
+			facts.Config[key] = value
+		}
+	}
+	for device, settings := range devices {
+		for key, value := range settings {
+			if !perContainerDeviceKeys[key] {
+				facts.Devices[device+"."+key] = value
+			}
+		}
+	}
+
+	if state != "Running" {
+		facts.Notes = append(facts.Notes, fmt.Sprintf("%s is %s: OS, kernel, Docker and packages not read", name, strings.ToLower(state)))
+		return facts
+	}
+	if exec == nil {
+		facts.Notes = append(facts.Notes, fmt.Sprintf("%s: runtime has no in-box exec: OS, kernel, Docker and packages not read", name))
+		return facts
+	}
+	facts.System = make(map[string]string)
+	for _, cmd := range systemFactCommands {
+		stdout, stderr, err := exec(cmd.argv)
+		value := strings.Trim(strings.TrimSpace(stdout), `"`)
+		switch {
+		case err != nil:
+			facts.Notes = append(facts.Notes, fmt.Sprintf("%s: failed to read %s: %v: %s", name, cmd.key, err, strings.TrimSpace(stderr)))
+			continue
+		case value == "" && cmd.key == "docker":
+			value = "not installed"
+		case value == "":
+			facts.Notes = append(facts.Notes, fmt.Sprintf("%s: %s unknown", name, cmd.key))
+			continue
+		}
+		facts.System[cmd.key] = value
+	}
+
+	if withPackages {
+		stdout, stderr, err := exec(dpkgCommand)
+		if err != nil {
+			facts.Notes = append(facts.Notes, fmt.Sprintf("%s: failed to list packages (no dpkg?): %v: %s", name, err, strings.TrimSpace(stderr)))
+		} else {
+			facts.Packages, facts.PackagesTruncated = parseDpkgList(stdout)
+		}
+	}
+	return facts
+}
+
+// parseDpkgList parses dpkgCommand output, keeping at most
+// MaxFactPackages packages and reporting whether any were dropped.
+func parseDpkgList(out string) (map[string]string, bool) {
+	pkgs := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		name, version, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok || name == "" {
+			continue
+		}
+		if len(pkgs) == MaxFactPackages {
+			return pkgs, true
+		}
+		pkgs[name] = version
+	}
+	return pkgs, false
+}
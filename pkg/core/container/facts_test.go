@@ -0,0 +1,79 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+func TestParseDpkgList(t *testing.T) {
+	pkgs, truncated := parseDpkgList("bash\t5.2.21-2ubuntu4\nlibssl3\t3.0.13-0ubuntu3\n\ngarbage\n")
+	want := map[string]string{"bash": "5.2.21-2ubuntu4", "libssl3": "3.0.13-0ubuntu3"}
+	if !reflect.DeepEqual(pkgs, want) || truncated {
+		t.Errorf("parseDpkgList = %v, %v; want %v, false", pkgs, truncated, want)
+	}
+
+	var b strings.Builder
+	for i := range MaxFactPackages + 1 {
+		fmt.Fprintf(&b, "pkg%d\t1\n", i)
+	}
+	if pkgs, truncated := parseDpkgList(b.String()); len(pkgs) != MaxFactPackages || !truncated {
+		t.Errorf("oversized list: got %d packages, truncated %v", len(pkgs), truncated)
+	}
+}
+
+func TestCollectFacts(t *testing.T) {
+	config := map[string]string{
+		"limits.memory":        "4GiB",
+		"volatile.eth0.hwaddr": "00:16:3e:00:00:01",
+		incus.TenantLabelKey:   "alice",
+	}
+	devices := map[string]map[string]string{
+		"root": {"type": "disk", "size": "20GiB"},
+		"eth0": {"type": "nic", "ipv4.address": "10.100.0.5"},
+	}
+	exec := func(argv []string) (string, string, error) {
+		switch {
+		case reflect.DeepEqual(argv, dpkgCommand):
+			return "bash\t5.2.21\n", "", nil
+		case argv[0] == "uname":
+			return "6.8.0-45-generic\n", "", nil
+		case strings.Contains(argv[2], "docker"):
+			return "", "", nil
+		}
+		return "", "sh: 1: .: cannot open /etc/os-release", errors.New("exit status 2")
+	}
+
+	facts := CollectFacts("alice-container", "Running", config, devices, exec, true)
+	if !reflect.DeepEqual(facts.Config, map[string]string{"limits.memory": "4GiB"}) {
+		t.Errorf("Config = %v, want only limits.memory", facts.Config)
+	}
+	if !reflect.DeepEqual(facts.Devices, map[string]string{"root.type": "disk", "root.size": "20GiB", "eth0.type": "nic"}) {
+		t.Errorf("Devices = %v", facts.Devices)
+	}
+	if !reflect.DeepEqual(facts.System, map[string]string{"kernel": "6.8.0-45-generic", "docker": "not installed"}) {
+		t.Errorf("System = %v", facts.System)
+	}
+	if len(facts.Notes) != 1 || !strings.Contains(facts.Notes[0], "failed to read os") {
+		t.Errorf("Notes = %q, want the unreadable OS", facts.Notes)
+	}
+	if facts.Packages["bash"] != "5.2.21" {
+		t.Errorf("Packages = %v", facts.Packages)
+	}
+
+	// A stopped box still has its config read, and nothing from inside.
+	facts = CollectFacts("bob-container", "Stopped", config, devices, exec, true)
+	if facts.State != "Stopped" || facts.Config["limits.memory"] != "4GiB" || facts.System != nil || facts.Packages != nil {
+		t.Errorf("stopped container facts = %+v", facts)
+	}
+
+	// A runtime without Incus config or in-box exec says so.
+	facts = CollectFacts("sandbox", "Running", nil, nil, nil, true)
+	if len(facts.Config) != 0 || facts.System != nil || len(facts.Notes) != 2 {
+		t.Errorf("runtime without config or exec: facts = %+v", facts)
+	}
+}
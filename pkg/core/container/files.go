@@ -0,0 +1,69 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+// ErrInvalidPath is returned for a container path ReadFileAt, ListDir and
+// WriteFileAt refuse.
+var ErrInvalidPath = errors.New("invalid container path")
+
+// pseudoFSRoots are the kernel filesystems mounted into every container.
+// Their entries describe the kernel and, through the likes of
+// /proc/<pid>/root, lead back out to the host, so the file tools stay out
+// of them.
+var pseudoFSRoots = []string{"/proc", "/sys", "/dev"}
+
+// CleanPath normalizes a path inside a container: it must be absolute,
+// with no ".." element, and is returned cleaned. Paths under /proc, /sys
+// and /dev are refused.
+func CleanPath(p string) (string, error) {
+	if !strings.HasPrefix(p, "/") {
+		return "", fmt.Errorf("%w: %q is not absolute", ErrInvalidPath, p)
+	}
+	if strings.ContainsRune(p, 0) {
+		return "", fmt.Errorf("%w: %q contains a NUL byte", ErrInvalidPath, p)
+	}
+	for _, elem := range strings.Split(p, "/") {
+		if elem == ".." {
+			return "", fmt.Errorf("%w: %q contains \"..\"", ErrInvalidPath, p)
+		}
+	}
+	clean := path.Clean(p)
+	for _, root := range pseudoFSRoots {
+		if clean == root || strings.HasPrefix(clean, root+"/") {
+			return "", fmt.Errorf("%w: %s is on the kernel's %s filesystem", ErrInvalidPath, clean, root)
+		}
+	}
+	return clean, nil
+}
+
+// ReadFileAt reads a file, directory or symlink in a container through the
+// Incus file API, keeping at most maxBytes of a file. The container need
+// not be running.
+func (m *Manager) ReadFileAt(containerName, p string, maxBytes int64) (*incus.InstanceFile, error) {
+	clean, err := CleanPath(p)
+	if err != nil {
+		return nil, err
+	}
+	return m.incus.PullFile(containerName, clean, maxBytes)
+}
+
+// WriteFileAt writes content to a file in a container through the Incus
+// file API, replacing any existing file, with mode and the numeric owner
+// uid:gid. The container need not be running.
+func (m *Manager) WriteFileAt(containerName, p string, content []byte, mode int, uid, gid int64) error {
+	clean, err := CleanPath(p)
+	if err != nil {
+		return err
+	}
+	if clean == "/" {
+		return fmt.Errorf("%w: / is a directory", ErrInvalidPath)
+	}
+	return m.incus.PushFile(containerName, clean, content, mode, uid, gid)
+}
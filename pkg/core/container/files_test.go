@@ -0,0 +1,51 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+)
+
+func TestCleanPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"/etc/app.conf":         "/etc/app.conf",
+		"/home/alice//notes/./": "/home/alice/notes",
+		"/":                     "/",
+		"/process/file":         "/process/file",
+		"/devel":                "/devel",
+	} {
+		if got, err := CleanPath(in); err != nil || got != want {
+			t.Errorf("CleanPath(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "etc/passwd", "/home/alice/../../etc/shadow", "/proc/1/root/etc/shadow", "/sys", "/dev/sda", "/etc/a\x00b"} {
+		if _, err := CleanPath(bad); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("CleanPath(%q): err = %v, want ErrInvalidPath", bad, err)
+		}
+	}
+}
+
+func TestWriteFileAt(t *testing.T) {
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Stopped"}
+	var gotPath string
+	var gotMode int
+	var gotUID, gotGID int64
+	mock.PushFileFunc = func(_, path string, _ []byte, mode int, uid, gid int64) error {
+		gotPath, gotMode, gotUID, gotGID = path, mode, uid, gid
+		return nil
+	}
+	m := NewWithBackend(mock)
+
+	if err := m.WriteFileAt("alice-container", "/home/alice/./app.env", []byte("A=1\n"), 0o600, 1000, 1000); err != nil {
+		t.Fatalf("WriteFileAt: %v", err)
+	}
+	if gotPath != "/home/alice/app.env" || gotMode != 0o600 || gotUID != 1000 || gotGID != 1000 {
+		t.Errorf("pushed %s %o %d:%d", gotPath, gotMode, gotUID, gotGID)
+	}
+	if err := m.WriteFileAt("alice-container", "/", nil, 0o644, 0, 0); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("write to /: err = %v, want ErrInvalidPath", err)
+	}
+}
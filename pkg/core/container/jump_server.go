@@ -136,6 +136,44 @@ func DeleteJumpServerAccount(username string, verbose bool) error {
 	return nil
 }
 
+// ListJumpServerAccounts returns the jump-server accounts Containarium
+// created on this host. Every account gets a /etc/sudoers.d/containarium-<user>
+// entry, so that directory is the registry; entries whose user no longer
+// exists are skipped.
+func ListJumpServerAccounts() ([]string, error) {
+	entries, err := os.ReadDir("/etc/sudoers.d")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read sudoers.d: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	var accounts []string
+	for _, a := range sudoersAccounts(names) {
+		if userExists(a) {
+			accounts = append(accounts, a)
+		}
+	}
+	return accounts, nil
+}
+
+// sudoersAccounts extracts account names from sudoers.d file names, keeping
+// only valid usernames behind the containarium- prefix.
+func sudoersAccounts(fileNames []string) []string {
+	var out []string
+	for _, n := range fileNames {
+		a, ok := strings.CutPrefix(n, "containarium-")
+		if ok && isValidUsername(a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
 // runUserdel deletes the account, tolerating the one failure mode that is
 // routine rather than exceptional: `userdel: user X is currently used by
 // process N` (exit 8), which happens whenever the caller still has an SSH
@@ -154,6 +154,21 @@ func TestUserExists(t *testing.T) {
 	})
 }
 
+func TestSudoersAccounts(t *testing.T) {
+	got := sudoersAccounts([]string{
+		"containarium-alice",
+		"containarium-alice-container-bob",
+		"README",
+		"90-cloud-init-users",
+		"containarium-",
+		"containarium-1bad",
+	})
+	want := []string{"alice", "alice-container-bob"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("sudoersAccounts = %v, want %v", got, want)
+	}
+}
+
 func TestParseSSHKeyFromAuthorizedKeys(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/footprintai/containarium/internal/metrics/opmetrics"
 	"github.com/footprintai/containarium/pkg/core/incus"
 	"github.com/footprintai/containarium/pkg/core/ospkg"
 	"github.com/footprintai/containarium/pkg/core/ostype"
@@ -45,6 +47,7 @@ type CreateOptions struct {
 	StackParameters        map[string]string // Stack parameters — passed to install scripts as CONTAINARIUM_STACK_<name> env vars
 	OSType                 pb.OSType         // Operating system type for the container
 	OnProvisioning         func()            // Called when container is running but still provisioning (installing packages/stack)
+	OnStep                 func(step string) // Called as each provisioning step (Step* constants) begins
 	RDPPassword            string            // Generated RDP password for Windows VMs (output, set by Create)
 
 	// Monitoring toggles application-emitted OpenTelemetry. When
@@ -83,6 +86,33 @@ type CreateOptions struct {
 	GitRef        string // SHA / branch / tag / "refs/pull/N/merge"; empty = default branch
 	GitCredential string // bearer token for private repos; daemon-side only, never persisted in the box
 	WorkspacePath string // where to place source; empty defaults to "/workspace"
+
+	// CloudInit is cloud-init user-data applied on first boot (Incus
+	// cloud-init.user-data); empty = none.
+	CloudInit string
+}
+
+// Provisioning steps reported through CreateOptions.OnStep, in the order
+// Create runs them. A step is complete when the next one begins or Create
+// returns; optional steps that don't apply (no SSH keys, no git source) are
+// not reported.
+const (
+	StepCreate      = "create"
+	StepStart       = "start"
+	StepHostAccount = "host-account"
+	StepNetwork     = "network"
+	StepPackages    = "packages"
+	StepUser        = "user"
+	StepSSHKeys     = "ssh-keys"
+	StepGitSource   = "git-source"
+	StepRDPPassword = "rdp-password" // Windows VMs, after StepNetwork
+)
+
+// step reports the start of a provisioning step to OnStep, if set.
+func (o *CreateOptions) step(name string) {
+	if o.OnStep != nil {
+		o.OnStep(name)
+	}
 }
 
 // New creates a new container manager backed by a real Incus client.
@@ -125,6 +155,13 @@ func resolveGPUDevices(b incus.Backend, inputs []string) ([]incus.GPUDevice, err
 
 // Create creates a new container with full setup
 func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
+	start := time.Now()
+	info, err := m.create(opts)
+	opmetrics.ObserveContainerOp("create", start, err)
+	return info, err
+}
+
+func (m *Manager) create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	containerName := opts.Username + "-container"
 
 	if opts.Verbose {
@@ -132,6 +169,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	}
 
 	// Step 1: Create container
+	opts.step(StepCreate)
 	if opts.Verbose {
 		fmt.Println("  [1/6] Creating container...")
 	}
@@ -173,6 +211,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 		EnablePodmanPrivileged: opts.EnablePodmanPrivileged,
 		AutoStart:              opts.AutoStart,
 		Env:                    otelEnvVars(opts, containerName),
+		CloudInit:              opts.CloudInit,
 	}
 
 	// Windows VMs: set instance type and enforce minimum resources
@@ -233,6 +272,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	}
 
 	// Step 2: Start container
+	opts.step(StepStart)
 	if opts.Verbose {
 		fmt.Println("  [2/6] Starting container...")
 	}
@@ -269,6 +309,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	}
 
 	if len(opts.SSHKeys) > 0 {
+		opts.step(StepHostAccount)
 		// Seed the jump-server account with the first key.
 		if err := CreateJumpServerAccount(opts.Username, opts.SSHKeys[0], opts.Verbose); err != nil {
 			_ = m.cleanup(containerName)
@@ -296,6 +337,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	}
 
 	// Step 4: Wait for network
+	opts.step(StepNetwork)
 	if opts.Verbose {
 		fmt.Println("  [4/7] Waiting for network...")
 	}
@@ -327,6 +369,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	}
 
 	// Step 5: Install packages
+	opts.step(StepPackages)
 	if opts.Verbose {
 		if opts.Stack != "" {
 			fmt.Printf("  [5/7] Installing Podman, SSH, tools, and %s stack...\n", opts.Stack)
@@ -358,6 +401,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	}
 
 	// Step 6: Create user
+	opts.step(StepUser)
 	if opts.Verbose {
 		fmt.Printf("  [6/7] Creating user: %s...\n", opts.Username)
 	}
@@ -387,6 +431,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	allKeys = append(allKeys, opts.SSHKeys...)
 
 	if len(allKeys) > 0 {
+		opts.step(StepSSHKeys)
 		if opts.Verbose {
 			fmt.Printf("       Adding %d SSH key(s)...\n", len(allKeys))
 		}
@@ -406,6 +451,7 @@ func (m *Manager) Create(opts CreateOptions) (*incus.ContainerInfo, error) {
 	// A failed fetch fails the create: a box without its source is
 	// useless, and a half-populated workspace is worse than none.
 	if opts.GitSource != "" {
+		opts.step(StepGitSource)
 		if opts.Verbose {
 			fmt.Printf("  [8/8] Fetching git source %s into %s...\n", opts.GitSource, gitWorkspacePath(opts.WorkspacePath))
 		}
@@ -436,6 +482,7 @@ func generatePassword(byteLen int) (string, error) {
 // provisionWindowsVM handles the Windows-specific provisioning after the VM
 // has been created, started, and labelled.
 func (m *Manager) provisionWindowsVM(vmName string, opts *CreateOptions) (*incus.ContainerInfo, error) {
+	opts.step(StepNetwork)
 	if opts.Verbose {
 		fmt.Println("  [3/4] Waiting for Windows VM network (this may take 1-2 minutes)...")
 	}
@@ -457,6 +504,7 @@ func (m *Manager) provisionWindowsVM(vmName string, opts *CreateOptions) (*incus
 	}
 
 	// Generate and set Administrator password
+	opts.step(StepRDPPassword)
 	if opts.Verbose {
 		fmt.Println("  [4/4] Setting Administrator password and verifying RDP...")
 	}
@@ -905,12 +953,8 @@ func (m *Manager) Exec(containerName string, command []string) error {
 // ExecWithOutput runs a command inside the container and returns its
 // stdout/stderr. Used by the backup server to surface pg_dump /
 // pg_restore diagnostics (which go to stderr) when the command fails.
-// Type-asserts to the concrete client.
 func (m *Manager) ExecWithOutput(containerName string, command []string) (string, string, error) {
-	if real, ok := m.incus.(*incus.Client); ok {
-		return real.ExecWithOutput(containerName, command)
-	}
-	return "", "", fmt.Errorf("ExecWithOutput not supported on this incus backend (mock?)")
+	return m.incus.ExecWithOutput(containerName, command)
 }
 
 // ReadFile pulls a file from inside the container into host memory.
@@ -961,6 +1005,13 @@ func (m *Manager) Start(username string) error {
 
 // Delete deletes a container
 func (m *Manager) Delete(username string, force bool) error {
+	start := time.Now()
+	err := m.delete(username, force)
+	opmetrics.ObserveContainerOp("delete", start, err)
+	return err
+}
+
+func (m *Manager) delete(username string, force bool) error {
 	containerName := username + "-container"
 
 	// Get container state
@@ -1130,6 +1181,51 @@ func (m *Manager) CleanupDisk(username string) (string, int64, error) {
 	return m.incus.CleanupDisk(containerName)
 }
 
+// validSnapshotName matches incus-safe snapshot names: no path separators
+// (incus addresses snapshots as "<instance>/<snapshot>") and no whitespace.
+var validSnapshotName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,62}$`)
+
+// CreateSnapshot takes a snapshot of a user's container
+func (m *Manager) CreateSnapshot(username, snapshotName string) (*incus.SnapshotInfo, error) {
+	if !validSnapshotName.MatchString(snapshotName) {
+		return nil, fmt.Errorf("invalid snapshot name %q: use letters, digits, '.', '_' or '-'", snapshotName)
+	}
+	containerName := username + "-container"
+	if _, err := m.incus.GetContainer(containerName); err != nil {
+		return nil, fmt.Errorf("container not found: %w", err)
+	}
+	return m.incus.CreateSnapshot(containerName, snapshotName)
+}
+
+// ListSnapshots lists the snapshots of a user's container
+func (m *Manager) ListSnapshots(username string) ([]incus.SnapshotInfo, error) {
+	containerName := username + "-container"
+	if _, err := m.incus.GetContainer(containerName); err != nil {
+		return nil, fmt.Errorf("container not found: %w", err)
+	}
+	return m.incus.ListSnapshots(containerName)
+}
+
+// RestoreSnapshot rolls a user's container back to an existing snapshot and
+// returns the snapshot's metadata.
+func (m *Manager) RestoreSnapshot(username, snapshotName string) (*incus.SnapshotInfo, error) {
+	containerName := username + "-container"
+	snaps, err := m.ListSnapshots(username)
+	if err != nil {
+		return nil, err
+	}
+	for i := range snaps {
+		if snaps[i].Name != snapshotName {
+			continue
+		}
+		if err := m.incus.RestoreSnapshot(containerName, snapshotName); err != nil {
+			return nil, err
+		}
+		return &snaps[i], nil
+	}
+	return nil, fmt.Errorf("snapshot %q not found for %s", snapshotName, containerName)
+}
+
 // InstallStack installs a stack or base script on a running container
 func (m *Manager) InstallStack(username, stackID string) error {
 	containerName := username + "-container"
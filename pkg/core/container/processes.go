@@ -0,0 +1,77 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/footprintai/containarium/internal/safecast"
+)
+
+// ErrNotRunning is returned for operations that need a running container.
+var ErrNotRunning = errors.New("container is not running")
+
+// psCommand lists every process busiest first, one per line without a
+// header. etimes is elapsed time in seconds (etime's [[dd-]hh:]mm:ss needs
+// parsing), user:32 keeps long names from being cut to a "+", and comm
+// comes last because it may contain spaces.
+var psCommand = []string{"ps", "-eo", "pid=,user:32=,pcpu=,pmem=,etimes=,comm=", "--sort=-pcpu"}
+
+// Process is one row of ps output.
+type Process struct {
+	PID            int32
+	User           string
+	CPUPercent     float64
+	MemoryPercent  float64
+	ElapsedSeconds int64
+	Command        string
+}
+
+// ListProcesses returns up to limit of the processes running in a
+// container, busiest first, and how many there are in total. It runs ps
+// through exec, the box's in-box exec, so the image must ship procps
+// (busybox ps lacks --sort).
+func ListProcesses(exec func(cmd []string) (stdout, stderr string, err error), limit int) ([]Process, int, error) {
+	stdout, stderr, err := exec(psCommand)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to run ps: %w: %s", err, strings.TrimSpace(stderr))
+	}
+	procs := parsePS(stdout)
+	total := len(procs)
+	if limit > 0 && len(procs) > limit {
+		procs = procs[:limit]
+	}
+	return procs, total, nil
+}
+
+// parsePS parses psCommand output. Lines that don't have the expected
+// columns are skipped.
+func parsePS(out string) []Process {
+	var procs []Process
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+		pid, err := strconv.ParseInt(fields[0], 10, 32)
+		if err != nil {
+			continue
+		}
+		cpu, err1 := strconv.ParseFloat(fields[2], 64)
+		mem, err2 := strconv.ParseFloat(fields[3], 64)
+		elapsed, err3 := strconv.ParseInt(fields[4], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		procs = append(procs, Process{
+			PID:            safecast.I32(pid),
+			User:           fields[1],
+			CPUPercent:     cpu,
+			MemoryPercent:  mem,
+			ElapsedSeconds: elapsed,
+			Command:        strings.Join(fields[5:], " "),
+		})
+	}
+	return procs
+}
@@ -0,0 +1,50 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const psOutput = `    812 alice                            97.3  4.1   3605 python3
+      1 root                              0.1  0.2 864000 systemd
+    455 www-data                          0.0  1.0  86400 tmux: server
+garbage line
+`
+
+func TestParsePS(t *testing.T) {
+	got := parsePS(psOutput)
+	want := []Process{
+		{PID: 812, User: "alice", CPUPercent: 97.3, MemoryPercent: 4.1, ElapsedSeconds: 3605, Command: "python3"},
+		{PID: 1, User: "root", CPUPercent: 0.1, MemoryPercent: 0.2, ElapsedSeconds: 864000, Command: "systemd"},
+		{PID: 455, User: "www-data", CPUPercent: 0, MemoryPercent: 1.0, ElapsedSeconds: 86400, Command: "tmux: server"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePS:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestListProcesses(t *testing.T) {
+	var argv []string
+	exec := func(command []string) (string, string, error) {
+		argv = command
+		return psOutput, "", nil
+	}
+
+	procs, total, err := ListProcesses(exec, 2)
+	if err != nil {
+		t.Fatalf("ListProcesses: %v", err)
+	}
+	if total != 3 || len(procs) != 2 || procs[0].PID != 812 {
+		t.Errorf("got %d of %d processes (%+v), want the 2 busiest of 3", len(procs), total, procs)
+	}
+	if !reflect.DeepEqual(argv, psCommand) {
+		t.Errorf("exec argv = %q, want %q", argv, psCommand)
+	}
+
+	failing := func([]string) (string, string, error) { return "", "ps: not found", errors.New("exit status 127") }
+	if _, _, err := ListProcesses(failing, 2); err == nil || !strings.Contains(err.Error(), "ps: not found") {
+		t.Errorf("failed exec: err = %v, want it to carry ps's stderr", err)
+	}
+}
@@ -2,10 +2,12 @@ package incus
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"log"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -14,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/footprintai/containarium/internal/metrics/opmetrics"
 	"github.com/footprintai/containarium/internal/safecast"
 	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/shared/api"
@@ -47,6 +50,8 @@ type Backend interface {
 	ExecWithOutput(containerName string, command []string) (string, string, error)
 	WriteFile(containerName, path string, content []byte, mode string) error
 	ReadFile(containerName, path string) ([]byte, error)
+	PullFile(containerName, path string, maxBytes int64) (*InstanceFile, error)
+	PushFile(containerName, path string, content []byte, mode int, uid, gid int64) error
 
 	// Config & devices
 	SetConfig(containerName, key, value string) error
@@ -55,9 +60,15 @@ type Backend interface {
 	SetDeviceSize(containerName, deviceName, size string) error
 	UpdateContainerConfig(name, key, value string) error
 	GetRawInstance(name string) (map[string]string, string, error)
+	GetExpandedInstance(name string) (map[string]string, map[string]map[string]string, error)
 	ResolveGPUInputToPCI(input string) (string, error)
 	CleanupDisk(containerName string) (string, int64, error)
 
+	// Snapshots
+	CreateSnapshot(containerName, snapshotName string) (*SnapshotInfo, error)
+	ListSnapshots(containerName string) ([]SnapshotInfo, error)
+	RestoreSnapshot(containerName, snapshotName string) error
+
 	// Labels
 	AddLabel(containerName, key, value string) error
 	RemoveLabel(containerName, key string) error
@@ -290,6 +301,10 @@ type ContainerConfig struct {
 	// app-monitoring opt-in (OTEL_EXPORTER_OTLP_ENDPOINT etc.) and
 	// can host other platform-injected env vars going forward.
 	Env map[string]string
+
+	// CloudInit is cloud-init user-data (`cloud-init.user-data`), run on
+	// the first boot by images that ship cloud-init.
+	CloudInit string
 }
 
 // LabelPrefix is the prefix used for storing labels in Incus container config
@@ -405,17 +420,42 @@ type ContainerInfo struct {
 	// single-box delete can remove it. Empty = unprotected (today's default).
 	DeletePolicy string
 
+	// ProvisionSteps mirrors user.containarium.provision_steps: the JSON
+	// progress record the daemon writes while provisioning the container.
+	// Empty for containers created before step tracking existed.
+	ProvisionSteps string
+
 	// Image is a human-readable description of the image the container was
 	// launched from (Incus's auto-populated image.description config key,
 	// falling back to the volatile.base_image fingerprint). Empty if Incus
 	// never recorded either — e.g. a container created by a very old client.
 	Image string
+
+	// ConntrackMark is the conntrack mark the host's marking rules put on
+	// this container's flows (user.containarium.conntrack_mark); 0 when
+	// none is configured. The traffic collector attributes marked flows
+	// by it before falling back to their addresses.
+	ConntrackMark uint32
+
+	// DNSLogEnabled mirrors user.containarium.dns_log — the per-container
+	// opt-in for recording the box's DNS queries. The traffic collector
+	// drops queries from boxes without it.
+	DNSLogEnabled bool
 }
 
 // AutoSleepEnabledKey is the Incus config key storing the per-container
 // auto-sleep opt-in flag (Phase 1 of the serverless feature).
 const AutoSleepEnabledKey = "user.containarium.auto_sleep_enabled"
 
+// ConntrackMarkKey is the Incus config key holding the conntrack mark
+// (decimal or 0x-prefixed hex) that identifies a container's flows. See
+// docs/TRAFFIC-CONNTRACK-MARKS.md for the matching iptables rules.
+const ConntrackMarkKey = "user.containarium.conntrack_mark"
+
+// DNSLogEnabledKey is the Incus config key storing the per-container DNS
+// query logging opt-in ("true" to record).
+const DNSLogEnabledKey = "user.containarium.dns_log"
+
 // IdleThresholdMinutesKey is the Incus config key storing the per-container
 // idle threshold in minutes consumed by the Phase 2 auto-sleep ticker.
 const IdleThresholdMinutesKey = "user.containarium.idle_threshold_minutes"
@@ -458,6 +498,13 @@ const DeleteAfterStoppedSecondsKey = "user.containarium.delete_after_stopped_sec
 // Absent/empty = unprotected (today's default: eligible for prune + reap).
 const DeletePolicyKey = "user.containarium.delete_policy"
 
+// ProvisionStepsKey is the Incus config key holding a JSON record of the
+// container's provisioning steps (name, state, error, timestamps), rewritten
+// by the daemon as each step starts and finishes. It lives on the instance so
+// a daemon restarted mid-provision can still tell a caller which step was
+// running when it went down.
+const ProvisionStepsKey = "user.containarium.provision_steps"
+
 // DeletePolicyProtected marks a box that must never be auto-reaped or
 // bulk-deleted (e.g. a persistent GitHub Actions runner). Deleting it takes a
 // deliberate single-box delete, not a sweep. The value is a stable string
@@ -513,6 +560,22 @@ func parseIdleThresholdMinutes(cfg map[string]string) int32 {
 	return safecast.I32(n)
 }
 
+// parseConntrackMark reads the container's conntrack mark from an Incus
+// config map. Missing, zero or unparseable values yield 0: the container's
+// flows are attributed by address.
+func parseConntrackMark(cfg map[string]string) uint32 {
+	raw := strings.TrimSpace(cfg[ConntrackMarkKey])
+	if raw == "" {
+		return 0
+	}
+	n, err := strconv.ParseUint(raw, 0, 32)
+	if err != nil {
+		log.Printf("Warning: ignoring %s=%q: %v", ConntrackMarkKey, raw, err)
+		return 0
+	}
+	return uint32(n)
+}
+
 // parseLastStartedAt reads the last-started timestamp from an Incus config
 // map. Missing or unparseable values yield the zero time — callers treat
 // that as "unknown" rather than a real moment in epoch history.
@@ -629,7 +692,9 @@ func parseImageSource(image string) api.InstanceSource {
 }
 
 // CreateContainer creates a new container with the specified configuration
-func (c *Client) CreateContainer(config ContainerConfig) error {
+func (c *Client) CreateContainer(config ContainerConfig) (err error) {
+	defer opmetrics.ObserveIncusCall("create_container", time.Now(), &err)
+
 	// Debug: Log the image being used
 	fmt.Printf("[DEBUG] CreateContainer - Image: '%s'\n", config.Image)
 
@@ -711,6 +776,9 @@ func (c *Client) CreateContainer(config ContainerConfig) error {
 	for k, v := range config.Env {
 		req.Config["environment."+k] = v
 	}
+	if config.CloudInit != "" {
+		req.Config["cloud-init.user-data"] = config.CloudInit
+	}
 
 	// Device configuration
 	req.Devices = make(map[string]map[string]string)
@@ -756,7 +824,9 @@ func (c *Client) CreateContainer(config ContainerConfig) error {
 }
 
 // StartContainer starts a container
-func (c *Client) StartContainer(name string) error {
+func (c *Client) StartContainer(name string) (err error) {
+	defer opmetrics.ObserveIncusCall("start_container", time.Now(), &err)
+
 	reqState := api.InstanceStatePut{
 		Action:  "start",
 		Timeout: 30,
@@ -775,7 +845,9 @@ func (c *Client) StartContainer(name string) error {
 }
 
 // StopContainer stops a container
-func (c *Client) StopContainer(name string, force bool) error {
+func (c *Client) StopContainer(name string, force bool) (err error) {
+	defer opmetrics.ObserveIncusCall("stop_container", time.Now(), &err)
+
 	reqState := api.InstanceStatePut{
 		Action:  "stop",
 		Timeout: 30,
@@ -795,7 +867,9 @@ func (c *Client) StopContainer(name string, force bool) error {
 }
 
 // DeleteContainer deletes a container
-func (c *Client) DeleteContainer(name string) error {
+func (c *Client) DeleteContainer(name string) (err error) {
+	defer opmetrics.ObserveIncusCall("delete_container", time.Now(), &err)
+
 	op, err := c.server.DeleteInstance(name)
 	if err != nil {
 		return fmt.Errorf("failed to delete container: %w", err)
@@ -809,7 +883,9 @@ func (c *Client) DeleteContainer(name string) error {
 }
 
 // ListContainers lists all containers
-func (c *Client) ListContainers() ([]ContainerInfo, error) {
+func (c *Client) ListContainers() (_ []ContainerInfo, err error) {
+	defer opmetrics.ObserveIncusCall("list_containers", time.Now(), &err)
+
 	// Get list of instance names (both containers and VMs)
 	names, err := c.server.GetInstanceNames(api.InstanceTypeAny)
 	if err != nil {
@@ -840,7 +916,10 @@ func (c *Client) ListContainers() ([]ContainerInfo, error) {
 			StoppedAt:                 parseStoppedAt(inst.Config),
 			DeleteAfterStoppedSeconds: parseDeleteAfterStoppedSeconds(inst.Config),
 			DeletePolicy:              inst.Config[DeletePolicyKey],
+			ProvisionSteps:            inst.Config[ProvisionStepsKey],
 			Image:                     imageDescriptionFromConfig(inst.Config),
+			ConntrackMark:             parseConntrackMark(inst.Config),
+			DNSLogEnabled:             inst.Config[DNSLogEnabledKey] == "true",
 		}
 
 		// Get CPU and memory limits from config
@@ -947,7 +1026,9 @@ func (c *Client) ListContainers() ([]ContainerInfo, error) {
 }
 
 // GetContainer gets information about a specific container
-func (c *Client) GetContainer(name string) (*ContainerInfo, error) {
+func (c *Client) GetContainer(name string) (_ *ContainerInfo, err error) {
+	defer opmetrics.ObserveIncusCall("get_container", time.Now(), &err)
+
 	inst, _, err := c.server.GetInstance(name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container: %w", err)
@@ -966,7 +1047,10 @@ func (c *Client) GetContainer(name string) (*ContainerInfo, error) {
 		LastStartedAt:        parseLastStartedAt(inst.Config),
 		TTLExpiresAt:         parseTTLExpiresAt(inst.Config),
 		DeletePolicy:         inst.Config[DeletePolicyKey],
+		ProvisionSteps:       inst.Config[ProvisionStepsKey],
 		Image:                imageDescriptionFromConfig(inst.Config),
+		ConntrackMark:        parseConntrackMark(inst.Config),
+		DNSLogEnabled:        inst.Config[DNSLogEnabledKey] == "true",
 	}
 
 	// Get resource limits
@@ -1163,7 +1247,9 @@ func stateWithRetry(what string, attempt func() (*api.InstanceState, string, err
 }
 
 // Exec executes a command inside a container
-func (c *Client) Exec(containerName string, command []string) error {
+func (c *Client) Exec(containerName string, command []string) (err error) {
+	defer opmetrics.ObserveIncusCall("exec", time.Now(), &err)
+
 	req := api.InstanceExecPost{
 		Command:     command,
 		WaitForWS:   true,
@@ -1303,6 +1389,16 @@ func (c *Client) GetRawInstance(name string) (map[string]string, string, error)
 	return inst.Config, etag, nil
 }
 
+// GetExpandedInstance returns a container's config and devices with its
+// profiles applied, as Incus runs it.
+func (c *Client) GetExpandedInstance(name string) (map[string]string, map[string]map[string]string, error) {
+	inst, _, err := c.server.GetInstance(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get instance %s: %w", name, err)
+	}
+	return inst.ExpandedConfig, inst.ExpandedDevices, nil
+}
+
 // GetServerInfo gets information about the Incus server
 func (c *Client) GetServerInfo() (*api.Server, error) {
 	server, _, err := c.server.GetServer()
@@ -1979,6 +2075,18 @@ func (c *Client) EnsureDefaultProfile(networkName, storageName string) error {
 	return nil
 }
 
+// DefaultProfileReady reports whether the default profile has the root
+// disk and eth0 devices EnsureDefaultProfile adds.
+func (c *Client) DefaultProfileReady() (bool, error) {
+	profile, _, err := c.server.GetProfile("default")
+	if err != nil {
+		return false, fmt.Errorf("failed to get default profile: %w", err)
+	}
+	_, root := profile.Devices["root"]
+	_, eth0 := profile.Devices["eth0"]
+	return root && eth0, nil
+}
+
 // InitializeInfrastructure ensures network, storage, and default profile are configured
 // This should be called once during daemon startup
 func (c *Client) InitializeInfrastructure(networkConfig NetworkConfig) error {
@@ -2058,8 +2166,87 @@ func (c *Client) ReadFile(containerName, path string) ([]byte, error) {
 	return content, nil
 }
 
+// ErrFileNotFound is returned by PullFile when the path doesn't exist in
+// the container.
+var ErrFileNotFound = errors.New("no such file or directory")
+
+// ErrFilePermission is returned by PullFile and PushFile when the
+// container refuses access to the path.
+var ErrFilePermission = errors.New("permission denied")
+
+// InstanceFile is a path pulled from inside a container with PullFile.
+type InstanceFile struct {
+	// Type is "file", "directory" or "symlink".
+	Type string
+	Mode int
+	UID  int64
+	GID  int64
+	// Content is a file's content, at most PullFile's maxBytes of it, or
+	// a symlink's target.
+	Content []byte
+	// Truncated is set when the file is longer than maxBytes.
+	Truncated bool
+	// Entries lists a directory's entries.
+	Entries []string
+}
+
+// PullFile reads a file, directory or symlink inside a container through
+// the Incus file API, keeping at most maxBytes of a file's content. The
+// path is resolved inside the container's root, and a symlink is
+// returned as its target rather than followed.
+func (c *Client) PullFile(containerName, path string, maxBytes int64) (*InstanceFile, error) {
+	reader, resp, err := c.server.GetInstanceFile(containerName, path)
+	if api.StatusErrorCheck(err, http.StatusNotFound) {
+		return nil, fmt.Errorf("%s: %w", path, ErrFileNotFound)
+	}
+	if api.StatusErrorCheck(err, http.StatusForbidden) {
+		return nil, fmt.Errorf("%s: %w", path, ErrFilePermission)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	f := &InstanceFile{Type: resp.Type, Mode: resp.Mode, UID: resp.UID, GID: resp.GID, Entries: resp.Entries}
+	if f.Type == "directory" {
+		return f, nil
+	}
+	content, err := io.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if int64(len(content)) > maxBytes {
+		content, f.Truncated = content[:maxBytes], true
+	}
+	f.Content = content
+	return f, nil
+}
+
+// PushFile writes content to a file inside a container through the Incus
+// file API, replacing any existing file, with the given mode and owner
+// (numeric IDs inside the container).
+func (c *Client) PushFile(containerName, path string, content []byte, mode int, uid, gid int64) error {
+	err := c.server.CreateInstanceFile(containerName, path, incus.InstanceFileArgs{
+		Content:   bytes.NewReader(content),
+		UID:       uid,
+		GID:       gid,
+		Mode:      mode,
+		Type:      "file",
+		WriteMode: "overwrite",
+	})
+	if api.StatusErrorCheck(err, http.StatusForbidden) {
+		return fmt.Errorf("%s: %w", path, ErrFilePermission)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
 // ExecWithOutput executes a command inside a container and returns stdout/stderr
-func (c *Client) ExecWithOutput(containerName string, command []string) (string, string, error) {
+func (c *Client) ExecWithOutput(containerName string, command []string) (_, _ string, err error) {
+	defer opmetrics.ObserveIncusCall("exec", time.Now(), &err)
+
 	var stdout, stderr bytes.Buffer
 
 	req := api.InstanceExecPost{
@@ -2070,7 +2257,7 @@ func (c *Client) ExecWithOutput(containerName string, command []string) (string,
 		// We capture output via the args.Stdout/Stderr instead
 	}
 
-	err := execWithRetry("exec "+containerName, func() error {
+	err = execWithRetry("exec "+containerName, func() error {
 		// Reset the capture buffers each attempt so a retried command's
 		// output replaces (not appends to) a failed attempt's partial output.
 		stdout.Reset()
@@ -2129,6 +2316,88 @@ journalctl --vacuum-size=50M 2>/dev/null
 	return summary, freedBytes, nil
 }
 
+// SnapshotInfo describes an incus snapshot of a container
+type SnapshotInfo struct {
+	Name      string
+	CreatedAt time.Time
+	SizeBytes int64 // 0 when the storage driver doesn't report usage
+	Stateful  bool
+}
+
+func snapshotInfoFromAPI(snap api.InstanceSnapshot) SnapshotInfo {
+	// The API returns the snapshot name bare, but be tolerant of the
+	// "<instance>/<snapshot>" form some endpoints use.
+	name := snap.Name
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return SnapshotInfo{
+		Name:      name,
+		CreatedAt: snap.CreatedAt,
+		SizeBytes: snap.Size,
+		Stateful:  snap.Stateful,
+	}
+}
+
+// CreateSnapshot takes a (stateless) snapshot of a container and returns
+// its metadata as recorded by incus.
+func (c *Client) CreateSnapshot(containerName, snapshotName string) (*SnapshotInfo, error) {
+	op, err := c.server.CreateInstanceSnapshot(containerName, api.InstanceSnapshotsPost{
+		Name: snapshotName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	if err := op.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot (operation failed): %w", err)
+	}
+
+	snap, _, err := c.server.GetInstanceSnapshot(containerName, snapshotName)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot created but failed to read it back: %w", err)
+	}
+	info := snapshotInfoFromAPI(*snap)
+	return &info, nil
+}
+
+// ListSnapshots returns a container's snapshots, oldest first.
+func (c *Client) ListSnapshots(containerName string) ([]SnapshotInfo, error) {
+	snaps, err := c.server.GetInstanceSnapshots(containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	infos := make([]SnapshotInfo, 0, len(snaps))
+	for _, snap := range snaps {
+		infos = append(infos, snapshotInfoFromAPI(snap))
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CreatedAt.Before(infos[j].CreatedAt)
+	})
+	return infos, nil
+}
+
+// RestoreSnapshot rolls a container's filesystem back to the named snapshot.
+// Incus handles stopping and restarting a running container around the
+// restore.
+func (c *Client) RestoreSnapshot(containerName, snapshotName string) error {
+	inst, etag, err := c.server.GetInstance(containerName)
+	if err != nil {
+		return fmt.Errorf("failed to get container %s: %w", containerName, err)
+	}
+
+	put := inst.Writable()
+	put.Restore = snapshotName
+	op, err := c.server.UpdateInstance(containerName, put, etag)
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	if err := op.Wait(); err != nil {
+		return fmt.Errorf("failed to restore snapshot (operation failed): %w", err)
+	}
+	return nil
+}
+
 // parseDfUsedBytes parses "df -B1 /" output and returns the "Used" column in bytes.
 func parseDfUsedBytes(dfOutput string) int64 {
 	lines := strings.Split(strings.TrimSpace(dfOutput), "\n")
@@ -34,14 +34,20 @@ type MockBackend struct {
 	ExecWithOutputFunc        func(containerName string, command []string) (string, string, error)
 	WriteFileFunc             func(containerName, path string, content []byte, mode string) error
 	ReadFileFunc              func(containerName, path string) ([]byte, error)
+	PullFileFunc              func(containerName, path string, maxBytes int64) (*incus.InstanceFile, error)
+	PushFileFunc              func(containerName, path string, content []byte, mode int, uid, gid int64) error
 	SetConfigFunc             func(containerName, key, value string) error
 	SetCPULimitFunc           func(containerName, cpu string) error
 	UnsetConfigFunc           func(containerName, key string) error
 	SetDeviceSizeFunc         func(containerName, deviceName, size string) error
 	ResolveGPUInputToPCIFunc  func(input string) (string, error)
 	CleanupDiskFunc           func(containerName string) (string, int64, error)
+	CreateSnapshotFunc        func(containerName, snapshotName string) (*incus.SnapshotInfo, error)
+	ListSnapshotsFunc         func(containerName string) ([]incus.SnapshotInfo, error)
+	RestoreSnapshotFunc       func(containerName, snapshotName string) error
 	UpdateContainerConfigFunc func(name, key, value string) error
 	GetRawInstanceFunc        func(name string) (map[string]string, string, error)
+	GetExpandedInstanceFunc   func(name string) (map[string]string, map[string]map[string]string, error)
 	AddLabelFunc              func(containerName, key, value string) error
 	RemoveLabelFunc           func(containerName, key string) error
 	GetLabelsFunc             func(containerName string) (map[string]string, error)
@@ -170,6 +176,20 @@ func (m *MockBackend) ReadFile(containerName, path string) ([]byte, error) {
 	return nil, nil
 }
 
+func (m *MockBackend) PullFile(containerName, path string, maxBytes int64) (*incus.InstanceFile, error) {
+	if m.PullFileFunc != nil {
+		return m.PullFileFunc(containerName, path, maxBytes)
+	}
+	return &incus.InstanceFile{Type: "file"}, nil
+}
+
+func (m *MockBackend) PushFile(containerName, path string, content []byte, mode int, uid, gid int64) error {
+	if m.PushFileFunc != nil {
+		return m.PushFileFunc(containerName, path, content, mode, uid, gid)
+	}
+	return nil
+}
+
 func (m *MockBackend) SetConfig(containerName, key, value string) error {
 	if m.SetConfigFunc != nil {
 		return m.SetConfigFunc(containerName, key, value)
@@ -212,6 +232,27 @@ func (m *MockBackend) CleanupDisk(containerName string) (string, int64, error) {
 	return "", 0, nil
 }
 
+func (m *MockBackend) CreateSnapshot(containerName, snapshotName string) (*incus.SnapshotInfo, error) {
+	if m.CreateSnapshotFunc != nil {
+		return m.CreateSnapshotFunc(containerName, snapshotName)
+	}
+	return &incus.SnapshotInfo{Name: snapshotName, CreatedAt: time.Now()}, nil
+}
+
+func (m *MockBackend) ListSnapshots(containerName string) ([]incus.SnapshotInfo, error) {
+	if m.ListSnapshotsFunc != nil {
+		return m.ListSnapshotsFunc(containerName)
+	}
+	return nil, nil
+}
+
+func (m *MockBackend) RestoreSnapshot(containerName, snapshotName string) error {
+	if m.RestoreSnapshotFunc != nil {
+		return m.RestoreSnapshotFunc(containerName, snapshotName)
+	}
+	return nil
+}
+
 func (m *MockBackend) AddLabel(containerName, key, value string) error {
 	if m.AddLabelFunc != nil {
 		return m.AddLabelFunc(containerName, key, value)
@@ -289,5 +330,12 @@ func (m *MockBackend) GetRawInstance(name string) (map[string]string, string, er
 	return nil, "", nil
 }
 
+func (m *MockBackend) GetExpandedInstance(name string) (map[string]string, map[string]map[string]string, error) {
+	if m.GetExpandedInstanceFunc != nil {
+		return m.GetExpandedInstanceFunc(name)
+	}
+	return nil, nil, nil
+}
+
 // Compile-time assertion that *MockBackend satisfies incus.Backend.
 var _ incus.Backend = (*MockBackend)(nil)
@@ -239,3 +239,20 @@ func BenchmarkMatchLabels(b *testing.B) {
 		_ = MatchLabels(containerLabels, filter)
 	}
 }
+
+func TestParseConntrackMark(t *testing.T) {
+	for raw, want := range map[string]uint32{
+		"":            0,
+		"42":          42,
+		"0x2a":        42,
+		" 0x10000 ":   0x10000,
+		"0xffffffff":  0xffffffff,
+		"0x100000000": 0, // wider than a mark
+		"-1":          0,
+		"mark":        0,
+	} {
+		if got := parseConntrackMark(map[string]string{ConntrackMarkKey: raw}); got != want {
+			t.Errorf("parseConntrackMark(%q) = %#x, want %#x", raw, got, want)
+		}
+	}
+}
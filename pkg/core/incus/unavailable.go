@@ -41,6 +41,12 @@ func (*UnavailableBackend) Exec(string, []string) error { return ErrUnavailable
 func (*UnavailableBackend) ExecWithOutput(string, []string) (string, string, error) {
 	return "", "", ErrUnavailable
 }
+func (*UnavailableBackend) PullFile(string, string, int64) (*InstanceFile, error) {
+	return nil, ErrUnavailable
+}
+func (*UnavailableBackend) PushFile(string, string, []byte, int, int64, int64) error {
+	return ErrUnavailable
+}
 func (*UnavailableBackend) WriteFile(string, string, []byte, string) error { return ErrUnavailable }
 func (*UnavailableBackend) ReadFile(string, string) ([]byte, error)        { return nil, ErrUnavailable }
 func (*UnavailableBackend) SetConfig(string, string, string) error         { return ErrUnavailable }
@@ -53,10 +59,22 @@ func (*UnavailableBackend) UpdateContainerConfig(string, string, string) error {
 func (*UnavailableBackend) GetRawInstance(string) (map[string]string, string, error) {
 	return nil, "", ErrUnavailable
 }
+func (*UnavailableBackend) GetExpandedInstance(string) (map[string]string, map[string]map[string]string, error) {
+	return nil, nil, ErrUnavailable
+}
 func (*UnavailableBackend) ResolveGPUInputToPCI(string) (string, error) { return "", ErrUnavailable }
 func (*UnavailableBackend) CleanupDisk(string) (string, int64, error) {
 	return "", 0, ErrUnavailable
 }
+func (*UnavailableBackend) CreateSnapshot(string, string) (*SnapshotInfo, error) {
+	return nil, ErrUnavailable
+}
+func (*UnavailableBackend) ListSnapshots(string) ([]SnapshotInfo, error) {
+	return nil, ErrUnavailable
+}
+func (*UnavailableBackend) RestoreSnapshot(string, string) error {
+	return ErrUnavailable
+}
 func (*UnavailableBackend) AddLabel(string, string, string) error { return ErrUnavailable }
 func (*UnavailableBackend) RemoveLabel(string, string) error      { return ErrUnavailable }
 func (*UnavailableBackend) GetLabels(string) (map[string]string, error) {
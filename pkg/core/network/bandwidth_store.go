@@ -0,0 +1,210 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrBandwidthLimitNotFound is returned when a container has no bandwidth limit
+var ErrBandwidthLimitNotFound = errors.New("bandwidth limit not found")
+
+// BandwidthLimit caps a container's network throughput. Ingress is traffic
+// toward the container (downloads), egress traffic leaving it (uploads).
+// Zero leaves that direction unlimited.
+type BandwidthLimit struct {
+	ContainerName string
+	IngressMbps   int
+	EgressMbps    int
+
+	// Reason says why the limit exists: "manual" for an operator's limit,
+	// "alert:<name>" for one an alert rule applied.
+	Reason    string
+	CreatedBy string
+
+	// ExpiresAt ends a temporary limit; the zero value never expires.
+	ExpiresAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Expired reports whether a temporary limit has run out at now.
+func (l *BandwidthLimit) Expired(now time.Time) bool {
+	return !l.ExpiresAt.IsZero() && !now.Before(l.ExpiresAt)
+}
+
+// BandwidthStore persists bandwidth limits, one per container. The
+// production implementation is *postgresBandwidthStore; TrafficShaper
+// re-applies what it holds whenever a container's veth changes.
+type BandwidthStore interface {
+	Save(ctx context.Context, limit *BandwidthLimit) error
+	Get(ctx context.Context, containerName string) (*BandwidthLimit, error)
+	List(ctx context.Context) ([]*BandwidthLimit, error)
+	Delete(ctx context.Context, containerName string) error
+}
+
+// postgresBandwidthStore is the PostgreSQL-backed implementation of
+// BandwidthStore.
+type postgresBandwidthStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewBandwidthStore creates a new PostgreSQL-backed bandwidth limit store
+// and initializes its schema.
+func NewBandwidthStore(ctx context.Context, pool *pgxpool.Pool) (BandwidthStore, error) {
+	store := &postgresBandwidthStore{pool: pool}
+
+	if err := store.initSchema(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize bandwidth limit schema: %w", err)
+	}
+
+	return store, nil
+}
+
+// initSchema creates the bandwidth_limits table if it doesn't exist
+func (s *postgresBandwidthStore) initSchema(ctx context.Context) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS bandwidth_limits (
+			container_name TEXT PRIMARY KEY,
+			ingress_mbps INTEGER NOT NULL DEFAULT 0,
+			egress_mbps INTEGER NOT NULL DEFAULT 0,
+			reason TEXT NOT NULL DEFAULT '',
+			created_by TEXT NOT NULL DEFAULT '',
+			expires_at TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+	`
+
+	_, err := s.pool.Exec(ctx, schema)
+	return err
+}
+
+// Save saves or replaces a container's bandwidth limit
+func (s *postgresBandwidthStore) Save(ctx context.Context, limit *BandwidthLimit) error {
+	query := `
+		INSERT INTO bandwidth_limits (container_name, ingress_mbps, egress_mbps,
+			reason, created_by, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (container_name) DO UPDATE SET
+			ingress_mbps = EXCLUDED.ingress_mbps,
+			egress_mbps = EXCLUDED.egress_mbps,
+			reason = EXCLUDED.reason,
+			created_by = EXCLUDED.created_by,
+			expires_at = EXCLUDED.expires_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	now := time.Now()
+	if limit.CreatedAt.IsZero() {
+		limit.CreatedAt = now
+	}
+	limit.UpdatedAt = now
+
+	var expiresAt *time.Time
+	if !limit.ExpiresAt.IsZero() {
+		expiresAt = &limit.ExpiresAt
+	}
+
+	err := s.pool.QueryRow(ctx, query,
+		limit.ContainerName,
+		limit.IngressMbps,
+		limit.EgressMbps,
+		limit.Reason,
+		limit.CreatedBy,
+		expiresAt,
+		limit.CreatedAt,
+		limit.UpdatedAt,
+	).Scan(&limit.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save bandwidth limit: %w", err)
+	}
+
+	return nil
+}
+
+const bandwidthLimitColumns = `container_name, ingress_mbps, egress_mbps, reason,
+	created_by, expires_at, created_at, updated_at`
+
+// scanBandwidthLimit reads one row selected with bandwidthLimitColumns.
+func scanBandwidthLimit(row pgx.Row) (*BandwidthLimit, error) {
+	limit := &BandwidthLimit{}
+	var expiresAt *time.Time
+	if err := row.Scan(
+		&limit.ContainerName,
+		&limit.IngressMbps,
+		&limit.EgressMbps,
+		&limit.Reason,
+		&limit.CreatedBy,
+		&expiresAt,
+		&limit.CreatedAt,
+		&limit.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if expiresAt != nil {
+		limit.ExpiresAt = *expiresAt
+	}
+	return limit, nil
+}
+
+// Get retrieves a container's bandwidth limit
+func (s *postgresBandwidthStore) Get(ctx context.Context, containerName string) (*BandwidthLimit, error) {
+	query := "SELECT " + bandwidthLimitColumns + " FROM bandwidth_limits WHERE container_name = $1"
+
+	limit, err := scanBandwidthLimit(s.pool.QueryRow(ctx, query, containerName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBandwidthLimitNotFound
+		}
+		return nil, fmt.Errorf("failed to get bandwidth limit: %w", err)
+	}
+
+	return limit, nil
+}
+
+// List retrieves every bandwidth limit, expired ones included
+func (s *postgresBandwidthStore) List(ctx context.Context) ([]*BandwidthLimit, error) {
+	query := "SELECT " + bandwidthLimitColumns + " FROM bandwidth_limits ORDER BY container_name ASC"
+
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bandwidth limits: %w", err)
+	}
+	defer rows.Close()
+
+	var limits []*BandwidthLimit
+	for rows.Next() {
+		limit, err := scanBandwidthLimit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bandwidth limit: %w", err)
+		}
+		limits = append(limits, limit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bandwidth limits: %w", err)
+	}
+
+	return limits, nil
+}
+
+// Delete removes a container's bandwidth limit
+func (s *postgresBandwidthStore) Delete(ctx context.Context, containerName string) error {
+	result, err := s.pool.Exec(ctx, "DELETE FROM bandwidth_limits WHERE container_name = $1", containerName)
+	if err != nil {
+		return fmt.Errorf("failed to delete bandwidth limit: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrBandwidthLimitNotFound
+	}
+
+	return nil
+}
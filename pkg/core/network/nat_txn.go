@@ -0,0 +1,87 @@
+package network
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+)
+
+// command runs an external command (iptables, sysctl) and returns its
+// combined output. PassthroughManager.run overrides it in tests.
+func (pm *PassthroughManager) command(name string, args ...string) ([]byte, error) {
+	if pm.run != nil {
+		return pm.run(name, args...)
+	}
+	// #nosec G204 -- name is a fixed binary; args are built from validated
+	// ports, protocols and container IPs.
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+// natChange is one nat-table rule change: op is "-A" (append) or "-D"
+// (delete), spec the chain and match/target arguments.
+type natChange struct {
+	op   string
+	spec []string
+	desc string // e.g. "DNAT rule tcp/5432 -> 10.0.3.20:5432"
+}
+
+// inverse is the change that undoes c.
+func (c natChange) inverse() natChange {
+	op := "-D"
+	if c.op == "-D" {
+		op = "-A"
+	}
+	return natChange{op: op, spec: c.spec, desc: c.desc}
+}
+
+// natTxn applies a sequence of nat-table changes and remembers the ones that
+// took effect, so a multi-rule operation that fails part-way can put the
+// table back the way it found it.
+type natTxn struct {
+	pm      *PassthroughManager
+	applied []natChange
+}
+
+// apply runs c; on success it is recorded for rollback.
+func (t *natTxn) apply(c natChange) error {
+	args := append([]string{"-t", "nat", c.op}, c.spec...)
+	if output, err := t.pm.command("iptables", args...); err != nil {
+		verb := "add"
+		if c.op == "-D" {
+			verb = "remove"
+		}
+		return fmt.Errorf("failed to %s %s: %w, output: %s", verb, c.desc, err, strings.TrimSpace(string(output)))
+	}
+	t.applied = append(t.applied, c)
+	return nil
+}
+
+// abort undoes the applied changes, newest first, and returns cause
+// extended with what was rolled back — or, if an undo itself failed, which
+// rules now need fixing by hand.
+func (t *natTxn) abort(cause error) error {
+	if len(t.applied) == 0 {
+		return cause
+	}
+	var undone, stuck []string
+	for i := len(t.applied) - 1; i >= 0; i-- {
+		undo := t.applied[i].inverse()
+		args := append([]string{"-t", "nat", undo.op}, undo.spec...)
+		if output, err := t.pm.command("iptables", args...); err != nil {
+			log.Printf("  Warning: rollback of %s failed: %v, output: %s", undo.desc, err, strings.TrimSpace(string(output)))
+			stuck = append(stuck, undo.desc)
+			continue
+		}
+		undone = append(undone, undo.desc)
+	}
+	t.applied = nil
+	msg := ""
+	if len(undone) > 0 {
+		msg += "; rolled back: " + strings.Join(undone, ", ")
+	}
+	if len(stuck) > 0 {
+		msg += "; rollback failed, fix by hand: " + strings.Join(stuck, ", ")
+	}
+	return fmt.Errorf("%w%s", cause, msg)
+}
@@ -0,0 +1,248 @@
+package network
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeIPTables records iptables calls and fails those whose joined
+// arguments contain any of failOn. `-C` checks fail unless listed in
+// present; `-S` returns listing.
+type fakeIPTables struct {
+	calls   []string
+	failOn  []string
+	present []string
+	listing string
+}
+
+func (f *fakeIPTables) run(name string, args ...string) ([]byte, error) {
+	line := name + " " + strings.Join(args, " ")
+	f.calls = append(f.calls, line)
+	for _, s := range f.failOn {
+		if strings.Contains(line, s) {
+			return []byte("iptables: simulated failure"), errors.New("exit status 1")
+		}
+	}
+	if strings.Contains(line, " -C ") {
+		for _, p := range f.present {
+			if strings.Contains(line, p) {
+				return nil, nil
+			}
+		}
+		return nil, errors.New("exit status 1")
+	}
+	if strings.HasSuffix(line, " -S") {
+		return []byte(f.listing), nil
+	}
+	return nil, nil
+}
+
+// mutations returns the -A/-D calls in order.
+func (f *fakeIPTables) mutations() []string {
+	var out []string
+	for _, c := range f.calls {
+		if strings.Contains(c, " -A ") || strings.Contains(c, " -D ") {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func TestAddRoute_RollsBackDNATWhenMasqueradeFails(t *testing.T) {
+	f := &fakeIPTables{failOn: []string{"-A POSTROUTING"}}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	err := pm.AddRoute(5432, "10.0.3.20", 5432, "tcp")
+	if err == nil {
+		t.Fatal("AddRoute succeeded, want the MASQUERADE failure")
+	}
+	if !strings.Contains(err.Error(), "failed to add MASQUERADE rule") || !strings.Contains(err.Error(), "rolled back: DNAT rule tcp/5432 -> 10.0.3.20:5432") {
+		t.Errorf("error = %v, want the failure and what was rolled back", err)
+	}
+
+	got := f.mutations()
+	if len(got) != 3 || !strings.Contains(got[0], "-A PREROUTING") || !strings.Contains(got[2], "-D PREROUTING") {
+		t.Fatalf("mutations = %v, want DNAT add, MASQUERADE add, DNAT delete", got)
+	}
+	if strings.TrimPrefix(got[2], "iptables -t nat -D") != strings.TrimPrefix(got[0], "iptables -t nat -A") {
+		t.Errorf("rollback %q doesn't match the added rule %q", got[2], got[0])
+	}
+}
+
+func TestAddRoute_SharedMasqueradeNotDuplicated(t *testing.T) {
+	f := &fakeIPTables{present: []string{"POSTROUTING"}}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	if err := pm.AddRoute(5433, "10.0.3.20", 5432, "tcp"); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	if got := f.mutations(); len(got) != 1 || !strings.Contains(got[0], "-A PREROUTING") {
+		t.Errorf("mutations = %v, want only the DNAT add", got)
+	}
+}
+
+const passthroughListing = `-P PREROUTING ACCEPT
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 5432 -j DNAT --to-destination 10.0.3.20:5432
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 5433 -j DNAT --to-destination 10.0.3.20:5432
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 2222 -j DNAT --to-destination 10.0.3.30:22
+-A POSTROUTING -d 10.0.3.20/32 -p tcp -m tcp --dport 5432 -j MASQUERADE
+-A POSTROUTING -d 10.0.3.30/32 -p tcp -m tcp --dport 22 -j MASQUERADE`
+
+func TestRemoveRoute_RestoresDNATWhenMasqueradeRemovalFails(t *testing.T) {
+	f := &fakeIPTables{listing: passthroughListing, present: []string{"POSTROUTING"}, failOn: []string{"-D POSTROUTING"}}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	err := pm.RemoveRoute(2222, "tcp")
+	if err == nil || !strings.Contains(err.Error(), "rolled back: DNAT rule tcp/2222 -> 10.0.3.30:22") {
+		t.Fatalf("RemoveRoute error = %v, want the DNAT removal rolled back", err)
+	}
+	got := f.mutations()
+	if len(got) != 3 || !strings.Contains(got[2], "-A PREROUTING") || !strings.Contains(got[2], "--dport 2222") {
+		t.Errorf("mutations = %v, want DNAT delete, MASQUERADE delete, DNAT re-add", got)
+	}
+}
+
+func TestRemoveRoute_KeepsSharedMasquerade(t *testing.T) {
+	f := &fakeIPTables{listing: passthroughListing, present: []string{"POSTROUTING"}}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	if err := pm.RemoveRoute(5433, "tcp"); err != nil {
+		t.Fatalf("RemoveRoute: %v", err)
+	}
+	if got := f.mutations(); len(got) != 1 || !strings.Contains(got[0], "-D PREROUTING") {
+		t.Errorf("mutations = %v, want only the DNAT delete (5432 still uses the MASQUERADE rule)", got)
+	}
+}
+
+func TestNATTxnAbort_ReportsStuckRules(t *testing.T) {
+	f := &fakeIPTables{failOn: []string{"-D PREROUTING"}}
+	txn := &natTxn{pm: &PassthroughManager{run: f.run}}
+	if err := txn.apply(natChange{op: "-A", spec: []string{"PREROUTING", "-p", "tcp"}, desc: "DNAT rule tcp/80"}); err != nil {
+		t.Fatal(err)
+	}
+	err := txn.abort(errors.New("boom"))
+	if err == nil || !strings.Contains(err.Error(), "boom; rollback failed, fix by hand: DNAT rule tcp/80") {
+		t.Errorf("abort = %v", err)
+	}
+}
+
+func TestAddRouteWithOptions_InterfaceAndSNAT(t *testing.T) {
+	f := &fakeIPTables{}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	err := pm.AddRouteWithOptions(5353, "10.0.3.20", 53, "udp", RouteOptions{InInterface: "eth0", SNATSource: "10.0.3.1"})
+	if err != nil {
+		t.Fatalf("AddRouteWithOptions: %v", err)
+	}
+	got := f.mutations()
+	if len(got) != 2 {
+		t.Fatalf("mutations = %v", got)
+	}
+	if !strings.Contains(got[0], "-A PREROUTING -i eth0 -p udp") {
+		t.Errorf("DNAT rule %q not bound to eth0", got[0])
+	}
+	if !strings.HasSuffix(got[1], "-j SNAT --to-source 10.0.3.1") {
+		t.Errorf("POSTROUTING rule %q, want SNAT to 10.0.3.1", got[1])
+	}
+}
+
+func TestParsePassthroughRules_RoundTripsOptions(t *testing.T) {
+	save := `-A PREROUTING -i eth0 ! -s 10.0.3.0/24 -p udp -m udp --dport 5353 -j DNAT --to-destination 10.0.3.20:53
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 80 -j DNAT --to-destination 10.0.3.50:80
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 2222 -j DNAT --to-destination 10.0.3.30:22
+-A POSTROUTING -d 10.0.3.20/32 -p udp -m udp --dport 53 -j SNAT --to-source 10.0.3.1
+-A POSTROUTING -d 10.0.3.30/32 -p tcp -m tcp --dport 22 -j MASQUERADE`
+
+	got := parsePassthroughRules(save)
+	want := []PassthroughRoute{
+		{ExternalPort: 5353, TargetIP: "10.0.3.20", TargetPort: 53, Protocol: "udp", Active: true,
+			RouteOptions: RouteOptions{InInterface: "eth0", SNATSource: "10.0.3.1"}},
+		{ExternalPort: 2222, TargetIP: "10.0.3.30", TargetPort: 22, Protocol: "tcp", Active: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("routes = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("route %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDefaultRouteInterface(t *testing.T) {
+	table := `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+incusbr0	0003000A	00000000	0001	0	0	0	00FFFFFF	0	0	0
+ens4	00000000	0100800A	0003	0	0	100	00000000	0	0	0`
+	if got := defaultRouteInterface(table); got != "ens4" {
+		t.Errorf("defaultRouteInterface = %q, want ens4", got)
+	}
+	if got := defaultRouteInterface("Iface\tDestination\n"); got != "" {
+		t.Errorf("no default route: got %q", got)
+	}
+}
+
+func TestResolveRouteOptions(t *testing.T) {
+	pm := NewPassthroughManager("10.0.3.0/24")
+	opts, err := pm.ResolveRouteOptions("eth1", "192.0.2.10", nil)
+	if err != nil || !opts.Equal(RouteOptions{InInterface: "eth1", SNATSource: "192.0.2.10"}) {
+		t.Errorf("explicit options = %+v, %v", opts, err)
+	}
+	for _, bad := range [][2]string{{"eth0; rm -rf /", ""}, {"", "not-an-ip"}, {"", "2001:db8::1"}} {
+		if _, err := pm.ResolveRouteOptions(bad[0], bad[1], nil); err == nil {
+			t.Errorf("ResolveRouteOptions(%q, %q) accepted", bad[0], bad[1])
+		}
+	}
+}
+
+func TestResolveRouteOptions_AllowSources(t *testing.T) {
+	pm := NewPassthroughManager("10.0.3.0/24")
+	opts, err := pm.ResolveRouteOptions("", "", []string{"198.51.100.7/24", "203.0.113.7", "198.51.100.0/24"})
+	if err != nil {
+		t.Fatalf("ResolveRouteOptions: %v", err)
+	}
+	// Written the way `iptables -S` prints them, duplicates dropped.
+	if want := []string{"198.51.100.0/24", "203.0.113.7/32"}; !reflect.DeepEqual(opts.AllowSources, want) {
+		t.Errorf("AllowSources = %v, want %v", opts.AllowSources, want)
+	}
+	for _, bad := range []string{"partner.example.com", "2001:db8::/32", "198.51.100.0/33"} {
+		if _, err := pm.ResolveRouteOptions("", "", []string{bad}); err == nil {
+			t.Errorf("allowed source %q accepted", bad)
+		}
+	}
+}
+
+func TestAddRouteWithOptions_AllowSourcesRollsBackPartialRules(t *testing.T) {
+	f := &fakeIPTables{failOn: []string{"-A PREROUTING -p tcp -s 203.0.113.7/32"}}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	err := pm.AddRouteWithOptions(50051, "10.0.3.20", 50051, "tcp", RouteOptions{AllowSources: []string{"198.51.100.0/24", "203.0.113.7/32"}})
+	if err == nil || !strings.Contains(err.Error(), "rolled back: DNAT rule tcp/50051 -> 10.0.3.20:50051 from 198.51.100.0/24") {
+		t.Fatalf("error = %v, want the first source's rule rolled back", err)
+	}
+	got := f.mutations()
+	if len(got) != 3 || strings.Contains(got[0], "! -s") || !strings.Contains(got[0], "-s 198.51.100.0/24") {
+		t.Fatalf("mutations = %v, want per-source DNAT adds without the open rule, then the undo", got)
+	}
+}
+
+func TestRemoveRoute_RemovesEveryAllowedSourceRule(t *testing.T) {
+	listing := `-A PREROUTING -s 198.51.100.0/24 -p tcp -m tcp --dport 50051 -j DNAT --to-destination 10.0.3.20:50051
+-A PREROUTING -s 203.0.113.7/32 -p tcp -m tcp --dport 50051 -j DNAT --to-destination 10.0.3.20:50051
+-A POSTROUTING -d 10.0.3.20/32 -p tcp -m tcp --dport 50051 -j MASQUERADE`
+	f := &fakeIPTables{listing: listing, present: []string{"POSTROUTING"}}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	routes, err := pm.ListRoutes()
+	if err != nil || len(routes) != 1 || !reflect.DeepEqual(routes[0].AllowSources, []string{"198.51.100.0/24", "203.0.113.7/32"}) {
+		t.Fatalf("ListRoutes = %+v, %v; want one route with both sources", routes, err)
+	}
+	if err := pm.RemoveRoute(50051, "tcp"); err != nil {
+		t.Fatalf("RemoveRoute: %v", err)
+	}
+	got := f.mutations()
+	if len(got) != 3 || !strings.Contains(got[0], "-s 198.51.100.0/24") || !strings.Contains(got[1], "-s 203.0.113.7/32") || !strings.Contains(got[2], "-D POSTROUTING") {
+		t.Errorf("mutations = %v, want both DNAT rules then MASQUERADE deleted", got)
+	}
+}
@@ -0,0 +1,247 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrForeignRouteNotFound is returned when no unmanaged DNAT rule
+	// forwards the port.
+	ErrForeignRouteNotFound = errors.New("no unmanaged DNAT rule for the port")
+	// ErrRouteManaged is returned when adopting a port whose DNAT rules
+	// already carry the passthrough tag.
+	ErrRouteManaged = errors.New("port is already a managed passthrough route")
+)
+
+// ForeignRoute is a passthrough route written by hand (or by another
+// tool): PREROUTING DNAT rules on one port without the passthrough comment
+// tag, which AdoptRoute can bring under management.
+type ForeignRoute struct {
+	PassthroughRoute
+
+	rules [][]string // the DNAT rules as listed, without "-A"
+	// postrouting is set when a POSTROUTING MASQUERADE or SNAT rule
+	// covers the target, i.e. the forward rewrites the client's address.
+	postrouting bool
+}
+
+// FindForeignRoute returns the unmanaged DNAT rule(s) forwarding
+// externalPort/protocol as a route. It fails with ErrForeignRouteNotFound
+// when there are none, ErrRouteManaged when the port's rules are already
+// ours, and a descriptive error when the rules do something a passthrough
+// route can't express (a destination match, a port range, a negated
+// source other than the container network), since adopting them would
+// change what they forward.
+func (pm *PassthroughManager) FindForeignRoute(externalPort int, protocol string) (*ForeignRoute, error) {
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	output, err := pm.command("iptables", "-t", "nat", "-S")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list iptables rules: %w", err)
+	}
+	return parseForeignRoute(string(output), externalPort, strings.ToLower(protocol), pm.networkCIDR)
+}
+
+// parseForeignRoute is FindForeignRoute over `iptables -t nat -S` output.
+func parseForeignRoute(saveOutput string, externalPort int, protocol, networkCIDR string) (*ForeignRoute, error) {
+	port := strconv.Itoa(externalPort)
+	var route *ForeignRoute
+	open := 0
+	for _, line := range strings.Split(saveOutput, "\n") {
+		r, ok := parseNATRule(line)
+		if !ok || r.chain != "PREROUTING" || r.target != "DNAT" || r.proto != protocol || r.dport != port {
+			continue
+		}
+		if _, ours := parseRuleComment(r.comment); ours {
+			return nil, fmt.Errorf("%w: %s/%d", ErrRouteManaged, protocol, externalPort)
+		}
+		fields := splitRule(line)
+		if err := checkAdoptable(fields, networkCIDR); err != nil {
+			return nil, fmt.Errorf("cannot adopt `%s`: %w", strings.TrimSpace(line), err)
+		}
+
+		targetIP, targetPort := r.toDest, externalPort
+		if host, p, ok := strings.Cut(r.toDest, ":"); ok {
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 1 || n > 65535 {
+				return nil, fmt.Errorf("cannot adopt `%s`: unsupported --to-destination %q", strings.TrimSpace(line), r.toDest)
+			}
+			targetIP, targetPort = host, n
+		}
+		if ip := net.ParseIP(targetIP); ip == nil || ip.To4() == nil {
+			return nil, fmt.Errorf("cannot adopt `%s`: unsupported --to-destination %q", strings.TrimSpace(line), r.toDest)
+		}
+		if r.source == "" {
+			open++
+		}
+
+		if route == nil {
+			route = &ForeignRoute{PassthroughRoute: PassthroughRoute{
+				ExternalPort: externalPort,
+				TargetIP:     targetIP,
+				TargetPort:   targetPort,
+				Protocol:     protocol,
+				Active:       true,
+				RouteOptions: RouteOptions{InInterface: r.inIface},
+			}}
+		} else if route.TargetIP != targetIP || route.TargetPort != targetPort || route.InInterface != r.inIface {
+			return nil, fmt.Errorf("cannot adopt %s/%d: its DNAT rules disagree on the target or interface", protocol, externalPort)
+		}
+		if r.source != "" {
+			route.AllowSources = append(route.AllowSources, r.source)
+		}
+		route.rules = append(route.rules, fields[1:])
+	}
+	if route == nil {
+		return nil, fmt.Errorf("%w: %s/%d", ErrForeignRouteNotFound, protocol, externalPort)
+	}
+	if open > 0 && len(route.rules) > 1 {
+		// An open rule next to source-restricted ones: the restrictions
+		// never applied, and neither shape is the route's.
+		return nil, fmt.Errorf("cannot adopt %s/%d: it has both open and source-restricted DNAT rules", protocol, externalPort)
+	}
+
+	// Whether the target's traffic is source-NATed, and the SNAT source as
+	// ListRoutes recovers it.
+	targetPort := strconv.Itoa(route.TargetPort)
+	for _, line := range strings.Split(saveOutput, "\n") {
+		post, ok := parseNATRule(line)
+		if !ok || post.chain != "POSTROUTING" || post.proto != protocol ||
+			post.dest != route.TargetIP || post.dport != targetPort {
+			continue
+		}
+		switch post.target {
+		case "SNAT":
+			route.postrouting = true
+			if route.SNATSource == "" {
+				route.SNATSource, _, _ = strings.Cut(post.toSource, ":")
+			}
+		case "MASQUERADE":
+			route.postrouting = true
+		}
+	}
+	return route, nil
+}
+
+// checkAdoptable fails for a DNAT rule (split `iptables -S` fields) with
+// matches or options the managed rules wouldn't carry over.
+func checkAdoptable(fields []string, networkCIDR string) error {
+	for i := 2; i < len(fields); i++ {
+		switch f := fields[i]; f {
+		case "-m":
+			if i+1 < len(fields) {
+				switch fields[i+1] {
+				case "tcp", "udp", "comment":
+					i++
+					continue
+				}
+			}
+			return fmt.Errorf("unsupported match module %q", strings.Join(fields[i:min(i+2, len(fields))], " "))
+		case "!":
+			if i+2 < len(fields) && fields[i+1] == "-s" && fields[i+2] == networkCIDR {
+				i += 2
+				continue
+			}
+			return fmt.Errorf("unsupported negated match %q", strings.Join(fields[i:min(i+3, len(fields))], " "))
+		case "-p", "-i", "-s", "--dport", "--comment", "-j", "--to-destination":
+			if i+1 >= len(fields) {
+				return fmt.Errorf("%s has no value", f)
+			}
+			if f == "--dport" && strings.ContainsAny(fields[i+1], ":,") {
+				return fmt.Errorf("port range %s", fields[i+1])
+			}
+			if f == "--to-destination" && strings.Contains(fields[i+1], "-") {
+				return fmt.Errorf("address range %s", fields[i+1])
+			}
+			i++
+		default:
+			return fmt.Errorf("unsupported option %q", f)
+		}
+	}
+	return nil
+}
+
+// splitRule splits an `iptables -S` line into fields, honouring the
+// double quotes iptables puts around comments with spaces; the quotes are
+// dropped, as exec arguments don't need them.
+func splitRule(line string) []string {
+	var fields []string
+	var cur strings.Builder
+	inField, quoted := false, false
+	for _, c := range strings.TrimSpace(line) {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			inField = true
+		case c == ' ' && !quoted:
+			if inField {
+				fields = append(fields, cur.String())
+				cur.Reset()
+				inField = false
+			}
+		default:
+			cur.WriteRune(c)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, cur.String())
+	}
+	return fields
+}
+
+// AdoptRoute brings a foreign route under management, forwarding to
+// container ("" for a bare IP). Its DNAT rule(s) are replaced by the
+// tagged rules AddRouteWithOptions would install — an open rule also
+// gains the container-network exclusion. A tagged POSTROUTING rule is
+// added only when the foreign rules had one for the target, so a forward
+// that kept the client's address still does; a foreign POSTROUTING rule
+// is left alone, as it may cover more than this route. The managed rules
+// go in before the foreign ones come out, so forwarding never stops.
+//
+// commit runs once the rules are in place, to record the route; if it
+// fails, the original rules are put back.
+func (pm *PassthroughManager) AdoptRoute(route *ForeignRoute, container string, commit func() error) error {
+	opts := route.RouteOptions
+	opts.Container = container
+	protocol, port := route.Protocol, route.ExternalPort
+	targetIP, targetPort := route.TargetIP, route.TargetPort
+
+	log.Printf("Adopting passthrough route: %s:%d -> %s:%d", protocol, port, targetIP, targetPort)
+
+	txn := &natTxn{pm: pm}
+	for _, c := range pm.dnatChanges("-A", port, targetIP, targetPort, protocol, opts, opts.tag(false)) {
+		if err := txn.apply(c); err != nil {
+			return txn.abort(err)
+		}
+	}
+	for _, spec := range route.rules {
+		if err := txn.apply(natChange{op: "-D", spec: spec,
+			desc: fmt.Sprintf("foreign DNAT rule %s/%d -> %s:%d", protocol, port, targetIP, targetPort)}); err != nil {
+			return txn.abort(err)
+		}
+	}
+	if route.postrouting {
+		post := postroutingSpec(targetIP, targetPort, protocol, opts.SNATSource, opts.tag(false))
+		if _, err := pm.command("iptables", append([]string{"-t", "nat", "-C"}, post...)...); err != nil {
+			if err := txn.apply(natChange{op: "-A", spec: post,
+				desc: postroutingDesc(targetIP, targetPort, protocol, opts.SNATSource)}); err != nil {
+				return txn.abort(err)
+			}
+		}
+	}
+	if commit != nil {
+		if err := commit(); err != nil {
+			return txn.abort(err)
+		}
+	}
+
+	log.Printf("  Passthrough route adopted successfully")
+	return nil
+}
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// foreignListing is `iptables -t nat -S` from a host with hand-written
+// forwards next to a managed route and Caddy's.
+const foreignListing = `-P PREROUTING ACCEPT
+-P INPUT ACCEPT
+-P OUTPUT ACCEPT
+-P POSTROUTING ACCEPT
+-A PREROUTING -p tcp -m tcp --dport 80 -j DNAT --to-destination 10.0.3.2:80
+-A PREROUTING -i eth0 -p tcp -m tcp --dport 5432 -m comment --comment "postgres for billing" -j DNAT --to-destination 10.0.3.20:5432
+-A PREROUTING -s 203.0.113.0/24 -p udp -m udp --dport 5353 -j DNAT --to-destination 10.0.3.30
+-A PREROUTING -s 198.51.100.7/32 -p udp -m udp --dport 5353 -j DNAT --to-destination 10.0.3.30
+-A PREROUTING -d 192.0.2.10/32 -p tcp -m tcp --dport 8443 -j DNAT --to-destination 10.0.3.40:443
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 2222 -m comment --comment "containarium:alice-container" -j DNAT --to-destination 10.0.3.10:22
+-A PREROUTING -p tcp -m tcp --dport 9000 -j DNAT --to-destination 10.0.3.50:9000
+-A PREROUTING -s 203.0.113.0/24 -p tcp -m tcp --dport 9000 -j DNAT --to-destination 10.0.3.50:9000
+-A POSTROUTING -d 10.0.3.30/32 -p udp -m udp --dport 5353 -j SNAT --to-source 10.0.3.1
+-A POSTROUTING -s 10.0.3.0/24 ! -d 10.0.3.0/24 -j MASQUERADE`
+
+func TestFindForeignRoute(t *testing.T) {
+	f := &fakeIPTables{listing: foreignListing}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	r, err := pm.FindForeignRoute(5432, "tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TargetIP != "10.0.3.20" || r.TargetPort != 5432 || r.InInterface != "eth0" || r.AllowSources != nil || r.SNATSource != "" || r.postrouting {
+		t.Errorf("5432 = %+v", r.PassthroughRoute)
+	}
+
+	// Two source-restricted rules, no port in --to-destination, an SNAT rule.
+	r, err = pm.FindForeignRoute(5353, "udp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TargetIP != "10.0.3.30" || r.TargetPort != 5353 || r.SNATSource != "10.0.3.1" ||
+		!slices.Equal(r.AllowSources, []string{"203.0.113.0/24", "198.51.100.7/32"}) || len(r.rules) != 2 || !r.postrouting {
+		t.Errorf("5353 = %+v", r.PassthroughRoute)
+	}
+
+	for _, tc := range []struct {
+		port    int
+		wantErr string
+		is      error
+	}{
+		{2222, "already a managed", ErrRouteManaged},
+		{6000, "no unmanaged DNAT rule", ErrForeignRouteNotFound},
+		{8443, "unsupported option \"-d\"", nil},
+		{9000, "both open and source-restricted", nil},
+	} {
+		_, err := pm.FindForeignRoute(tc.port, "tcp")
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) || (tc.is != nil && !errors.Is(err, tc.is)) {
+			t.Errorf("port %d: err = %v, want %q", tc.port, err, tc.wantErr)
+		}
+	}
+}
+
+func TestAdoptRoute_ReplacesForeignRule(t *testing.T) {
+	f := &fakeIPTables{listing: foreignListing}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+	r, err := pm.FindForeignRoute(5432, "tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	committed := false
+	if err := pm.AdoptRoute(r, "bob-container", func() error { committed = true; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if !committed {
+		t.Error("commit not called")
+	}
+	// The foreign rule had no SNAT, so none is added: postgres keeps
+	// seeing client addresses.
+	want := []string{
+		"iptables -t nat -A PREROUTING -i eth0 -p tcp ! -s 10.0.3.0/24 --dport 5432 -m comment --comment containarium:bob-container -j DNAT --to-destination 10.0.3.20:5432",
+		"iptables -t nat -D PREROUTING -i eth0 -p tcp -m tcp --dport 5432 -m comment --comment postgres for billing -j DNAT --to-destination 10.0.3.20:5432",
+	}
+	if got := f.mutations(); !slices.Equal(got, want) {
+		t.Errorf("mutations:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// The managed rules read back as the route with the container tagged.
+	routes := parsePassthroughRules(`-A PREROUTING -i eth0 ! -s 10.0.3.0/24 -p tcp -m tcp --dport 5432 -m comment --comment "containarium:bob-container" -j DNAT --to-destination 10.0.3.20:5432`)
+	if len(routes) != 1 || routes[0].Container != "bob-container" || !routes[0].RouteOptions.Equal(RouteOptions{InInterface: "eth0", Container: "bob-container"}) {
+		t.Errorf("read back = %+v", routes)
+	}
+}
+
+func TestAdoptRoute_CommitFailureRestoresForeignRules(t *testing.T) {
+	f := &fakeIPTables{listing: foreignListing, present: []string{"POSTROUTING"}}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+	r, err := pm.FindForeignRoute(5353, "udp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = pm.AdoptRoute(r, "", func() error { return errors.New("registry unavailable") })
+	if err == nil || !strings.Contains(err.Error(), "registry unavailable") || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("err = %v, want the commit failure and a rollback", err)
+	}
+	got := f.mutations()
+	// Two managed adds, two foreign deletes, then the inverse, newest first.
+	if len(got) != 8 {
+		t.Fatalf("mutations = %v", got)
+	}
+	swap := strings.NewReplacer(" -A ", " -D ", " -D ", " -A ")
+	for i := range 4 {
+		if want := swap.Replace(got[i]); got[7-i] != want {
+			t.Errorf("rollback %q, want %q", got[7-i], want)
+		}
+	}
+}
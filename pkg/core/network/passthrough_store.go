@@ -34,6 +34,18 @@ type PassthroughRecord struct {
 	CreatedBy     string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	// Interface binding and SNAT source; zero values are the defaults
+	// (any interface, MASQUERADE).
+	RouteOptions
+}
+
+// routeOptions are the options the record's rules are installed with,
+// tagged with its container.
+func (r *PassthroughRecord) routeOptions() RouteOptions {
+	opts := r.RouteOptions
+	opts.Container = r.ContainerName
+	return opts
 }
 
 // PassthroughStore abstracts persistence of passthrough routes. The production
@@ -85,6 +97,10 @@ func (s *postgresPassthroughStore) initSchema(ctx context.Context) error {
 			UNIQUE (external_port, protocol)
 		);
 
+		ALTER TABLE passthrough_routes ADD COLUMN IF NOT EXISTS in_interface TEXT NOT NULL DEFAULT '';
+		ALTER TABLE passthrough_routes ADD COLUMN IF NOT EXISTS snat_source TEXT NOT NULL DEFAULT '';
+		ALTER TABLE passthrough_routes ADD COLUMN IF NOT EXISTS allow_sources TEXT[] NOT NULL DEFAULT '{}';
+
 		CREATE INDEX IF NOT EXISTS idx_passthrough_routes_active ON passthrough_routes(active);
 		CREATE INDEX IF NOT EXISTS idx_passthrough_routes_port_proto ON passthrough_routes(external_port, protocol);
 	`
@@ -97,14 +113,18 @@ func (s *postgresPassthroughStore) initSchema(ctx context.Context) error {
 func (s *postgresPassthroughStore) Save(ctx context.Context, route *PassthroughRecord) error {
 	query := `
 		INSERT INTO passthrough_routes (external_port, target_ip, target_port, protocol,
-			container_name, description, active, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			container_name, description, active, in_interface, snat_source,
+			allow_sources, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		ON CONFLICT (external_port, protocol) DO UPDATE SET
 			target_ip = EXCLUDED.target_ip,
 			target_port = EXCLUDED.target_port,
 			container_name = EXCLUDED.container_name,
 			description = EXCLUDED.description,
 			active = EXCLUDED.active,
+			in_interface = EXCLUDED.in_interface,
+			snat_source = EXCLUDED.snat_source,
+			allow_sources = EXCLUDED.allow_sources,
 			updated_at = EXCLUDED.updated_at
 		RETURNING id
 	`
@@ -118,6 +138,10 @@ func (s *postgresPassthroughStore) Save(ctx context.Context, route *PassthroughR
 	if route.Protocol == "" {
 		route.Protocol = "tcp"
 	}
+	allowSources := route.AllowSources
+	if allowSources == nil {
+		allowSources = []string{} // pgx sends a nil slice as NULL
+	}
 
 	err := s.pool.QueryRow(ctx, query,
 		route.ExternalPort,
@@ -127,6 +151,9 @@ func (s *postgresPassthroughStore) Save(ctx context.Context, route *PassthroughR
 		route.ContainerName,
 		route.Description,
 		route.Active,
+		route.InInterface,
+		route.SNATSource,
+		allowSources,
 		route.CreatedBy,
 		route.CreatedAt,
 		route.UpdatedAt,
@@ -144,7 +171,7 @@ func (s *postgresPassthroughStore) GetByPortProtocol(ctx context.Context, extern
 	query := `
 		SELECT id, external_port, target_ip, target_port, protocol,
 			COALESCE(container_name, ''), COALESCE(description, ''), active,
-			COALESCE(created_by, ''), created_at, updated_at
+			in_interface, snat_source, allow_sources, COALESCE(created_by, ''), created_at, updated_at
 		FROM passthrough_routes
 		WHERE external_port = $1 AND protocol = $2
 	`
@@ -159,6 +186,9 @@ func (s *postgresPassthroughStore) GetByPortProtocol(ctx context.Context, extern
 		&route.ContainerName,
 		&route.Description,
 		&route.Active,
+		&route.InInterface,
+		&route.SNATSource,
+		&route.AllowSources,
 		&route.CreatedBy,
 		&route.CreatedAt,
 		&route.UpdatedAt,
@@ -182,7 +212,7 @@ func (s *postgresPassthroughStore) List(ctx context.Context, activeOnly bool) ([
 		query = `
 			SELECT id, external_port, target_ip, target_port, protocol,
 				COALESCE(container_name, ''), COALESCE(description, ''), active,
-				COALESCE(created_by, ''), created_at, updated_at
+				in_interface, snat_source, allow_sources, COALESCE(created_by, ''), created_at, updated_at
 			FROM passthrough_routes
 			WHERE active = true
 			ORDER BY external_port ASC
@@ -191,7 +221,7 @@ func (s *postgresPassthroughStore) List(ctx context.Context, activeOnly bool) ([
 		query = `
 			SELECT id, external_port, target_ip, target_port, protocol,
 				COALESCE(container_name, ''), COALESCE(description, ''), active,
-				COALESCE(created_by, ''), created_at, updated_at
+				in_interface, snat_source, allow_sources, COALESCE(created_by, ''), created_at, updated_at
 			FROM passthrough_routes
 			ORDER BY external_port ASC
 		`
@@ -215,6 +245,9 @@ func (s *postgresPassthroughStore) List(ctx context.Context, activeOnly bool) ([
 			&route.ContainerName,
 			&route.Description,
 			&route.Active,
+			&route.InInterface,
+			&route.SNATSource,
+			&route.AllowSources,
 			&route.CreatedBy,
 			&route.CreatedAt,
 			&route.UpdatedAt,
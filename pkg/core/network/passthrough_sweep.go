@@ -0,0 +1,220 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// passthroughTag is the iptables comment on every nat rule a passthrough
+// route installs: "containarium", or "containarium:<container>" when the
+// route names its container. Only tagged rules are ever swept, so rules an
+// operator wrote by hand are left alone.
+const passthroughTag = "containarium"
+
+// ruleComment is the comment tag for a route to container.
+func ruleComment(container string) string {
+	if container == "" {
+		return passthroughTag
+	}
+	return passthroughTag + ":" + container
+}
+
+// parseRuleComment is the inverse of ruleComment; ok is false for a comment
+// that isn't ours.
+func parseRuleComment(comment string) (container string, ok bool) {
+	if comment == passthroughTag {
+		return "", true
+	}
+	container, ok = strings.CutPrefix(comment, passthroughTag+":")
+	return container, ok && container != ""
+}
+
+// unquoteComment strips the quotes `iptables -S` puts around a comment
+// with characters outside [A-Za-z0-9_-], such as the ':' in ours.
+func unquoteComment(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// TaggedRule is a nat rule carrying the passthrough comment tag.
+type TaggedRule struct {
+	Chain    string // PREROUTING (DNAT) or POSTROUTING (MASQUERADE/SNAT)
+	Protocol string
+	// Port is the external port of a DNAT rule, the target port of a
+	// POSTROUTING rule.
+	Port      int
+	TargetIP  string
+	Container string // from the tag; "" for a route to a bare IP
+
+	spec []string // the rule as listed, without "-A"
+}
+
+func (r TaggedRule) String() string {
+	s := fmt.Sprintf("%s %s/%d -> %s", r.Chain, r.Protocol, r.Port, r.TargetIP)
+	if r.Container != "" {
+		s += " (" + r.Container + ")"
+	}
+	return s
+}
+
+// parseTaggedRules extracts the tagged passthrough rules from `iptables -t
+// nat -S` output.
+func parseTaggedRules(saveOutput string) []TaggedRule {
+	var rules []TaggedRule
+	for _, line := range strings.Split(saveOutput, "\n") {
+		r, ok := parseNATRule(line)
+		if !ok {
+			continue
+		}
+		container, ours := parseRuleComment(r.comment)
+		if !ours {
+			continue
+		}
+		tr := TaggedRule{Chain: r.chain, Protocol: r.proto, Container: container}
+		switch {
+		case r.chain == "PREROUTING" && r.target == "DNAT":
+			tr.TargetIP, _, _ = strings.Cut(r.toDest, ":")
+		case r.chain == "POSTROUTING" && (r.target == "MASQUERADE" || r.target == "SNAT"):
+			tr.TargetIP = r.dest
+		default:
+			continue
+		}
+		port, err := strconv.Atoi(r.dport)
+		if err != nil || tr.TargetIP == "" {
+			continue
+		}
+		tr.Port = port
+		// Re-issued as exec arguments, so the comment loses its quotes.
+		fields := strings.Fields(strings.TrimSpace(line))[1:]
+		for i, f := range fields {
+			if i > 0 && fields[i-1] == "--comment" {
+				fields[i] = unquoteComment(f)
+			}
+		}
+		tr.spec = fields
+		rules = append(rules, tr)
+	}
+	return rules
+}
+
+// LiveContainers is the set of containers sweeping checks rules against.
+type LiveContainers struct {
+	names       map[string]bool
+	ips         map[string]bool
+	unaddressed int
+}
+
+// NewLiveContainers returns an empty set; Add each existing container.
+func NewLiveContainers() *LiveContainers {
+	return &LiveContainers{names: make(map[string]bool), ips: make(map[string]bool)}
+}
+
+// Add records an existing container, running or not, and its address (""
+// when it has none, e.g. stopped).
+func (l *LiveContainers) Add(name, ip string) {
+	l.names[name] = true
+	if ip == "" {
+		l.unaddressed++
+		return
+	}
+	l.ips[ip] = true
+}
+
+// orphaned reports whether r points at a box that no longer exists. A rule
+// naming its container is orphaned once that container is gone, whatever
+// its state. A rule to a bare IP is only considered inside the container
+// network — outside it the target is a LAN host or VM no container listing
+// knows about — and is orphaned when no container has that address, which
+// can only be told while every container has one: a stopped container
+// might own it.
+func (l *LiveContainers) orphaned(r TaggedRule, containerNet *net.IPNet) bool {
+	if r.Container != "" {
+		return !l.names[r.Container]
+	}
+	ip := net.ParseIP(r.TargetIP)
+	if containerNet == nil || ip == nil || !containerNet.Contains(ip) {
+		return false
+	}
+	return l.unaddressed == 0 && !l.ips[r.TargetIP]
+}
+
+// SweepOrphans removes the tagged passthrough rules whose container no
+// longer exists, logging each, and returns them. With dryRun nothing is
+// removed. A rule that fails to delete is logged and skipped; the error
+// reports how many did. Bare-IP rules are left alone unless the manager's
+// network CIDR parses and contains their target.
+func (pm *PassthroughManager) SweepOrphans(live *LiveContainers, dryRun bool) ([]TaggedRule, error) {
+	_, containerNet, _ := net.ParseCIDR(pm.networkCIDR)
+	output, err := pm.command("iptables", "-t", "nat", "-S")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list iptables rules: %w", err)
+	}
+
+	var swept []TaggedRule
+	failed := 0
+	for _, r := range parseTaggedRules(string(output)) {
+		if !live.orphaned(r, containerNet) {
+			continue
+		}
+		if !dryRun {
+			args := append([]string{"-t", "nat", "-D"}, r.spec...)
+			if out, err := pm.command("iptables", args...); err != nil {
+				log.Printf("  Warning: failed to remove orphaned passthrough rule %s: %v, output: %s", r, err, strings.TrimSpace(string(out)))
+				failed++
+				continue
+			}
+			log.Printf("Removed orphaned passthrough rule %s", r)
+		}
+		swept = append(swept, r)
+	}
+	if failed > 0 {
+		return swept, fmt.Errorf("failed to remove %d orphaned passthrough rule(s)", failed)
+	}
+	return swept, nil
+}
+
+// OrphanSweeper periodically removes passthrough rules left behind by
+// crashes and partial operations for boxes that are gone.
+type OrphanSweeper struct {
+	manager *PassthroughManager
+	live    func() (*LiveContainers, error)
+}
+
+// NewOrphanSweeper returns a sweeper checking manager's rules against the
+// containers live lists on each pass.
+func NewOrphanSweeper(manager *PassthroughManager, live func() (*LiveContainers, error)) *OrphanSweeper {
+	return &OrphanSweeper{manager: manager, live: live}
+}
+
+// Sweep runs one pass.
+func (s *OrphanSweeper) Sweep() ([]TaggedRule, error) {
+	live, err := s.live()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	return s.manager.SweepOrphans(live, false)
+}
+
+// Watch runs Sweep every interval until ctx is done.
+func (s *OrphanSweeper) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sweep(); err != nil {
+			log.Printf("[passthrough-sweeper] sweep: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
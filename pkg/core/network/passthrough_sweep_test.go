@@ -0,0 +1,161 @@
+package network
+
+import (
+	"strings"
+	"testing"
+)
+
+// sweepListing is a nat table with rules for a live box (alice), a deleted
+// one (bob), a bare-IP route from before container tags, and a rule added
+// by hand.
+const sweepListing = `-P PREROUTING ACCEPT
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 2222 -m comment --comment "containarium:alice-container" -j DNAT --to-destination 10.0.3.20:22
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 2223 -m comment --comment "containarium:bob-container" -j DNAT --to-destination 10.0.3.30:22
+-A PREROUTING ! -s 10.0.3.0/24 -p udp -m udp --dport 5353 -m comment --comment containarium -j DNAT --to-destination 10.0.3.40:53
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 8080 -j DNAT --to-destination 10.0.3.99:80
+-A POSTROUTING -d 10.0.3.20/32 -p tcp -m tcp --dport 22 -m comment --comment "containarium:alice-container" -j MASQUERADE
+-A POSTROUTING -d 10.0.3.30/32 -p tcp -m tcp --dport 22 -m comment --comment "containarium:bob-container" -j MASQUERADE
+-A POSTROUTING -d 10.0.3.40/32 -p udp -m udp --dport 53 -m comment --comment containarium -j SNAT --to-source 10.0.3.1`
+
+func TestParseTaggedRules(t *testing.T) {
+	got := parseTaggedRules(sweepListing)
+	want := []string{
+		"PREROUTING tcp/2222 -> 10.0.3.20 (alice-container)",
+		"PREROUTING tcp/2223 -> 10.0.3.30 (bob-container)",
+		"PREROUTING udp/5353 -> 10.0.3.40",
+		"POSTROUTING tcp/22 -> 10.0.3.20 (alice-container)",
+		"POSTROUTING tcp/22 -> 10.0.3.30 (bob-container)",
+		"POSTROUTING udp/53 -> 10.0.3.40",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseTaggedRules = %v, want %d rules", got, len(want))
+	}
+	for i, r := range got {
+		if r.String() != want[i] {
+			t.Errorf("rule %d = %q, want %q", i, r, want[i])
+		}
+	}
+	// Deleting must pass the comment unquoted, as it was added.
+	if spec := strings.Join(got[0].spec, " "); !strings.Contains(spec, "--comment containarium:alice-container -j DNAT") {
+		t.Errorf("spec = %q", spec)
+	}
+}
+
+func TestSweepOrphans_RemovesRulesForMissingContainers(t *testing.T) {
+	f := &fakeIPTables{listing: sweepListing}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	live := NewLiveContainers()
+	live.Add("alice-container", "10.0.3.20")
+	live.Add("carol-container", "10.0.3.50")
+
+	swept, err := pm.SweepOrphans(live, false)
+	if err != nil {
+		t.Fatalf("SweepOrphans: %v", err)
+	}
+	got := f.mutations()
+	want := []string{
+		"iptables -t nat -D PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 2223 -m comment --comment containarium:bob-container -j DNAT --to-destination 10.0.3.30:22",
+		"iptables -t nat -D PREROUTING ! -s 10.0.3.0/24 -p udp -m udp --dport 5353 -m comment --comment containarium -j DNAT --to-destination 10.0.3.40:53",
+		"iptables -t nat -D POSTROUTING -d 10.0.3.30/32 -p tcp -m tcp --dport 22 -m comment --comment containarium:bob-container -j MASQUERADE",
+		"iptables -t nat -D POSTROUTING -d 10.0.3.40/32 -p udp -m udp --dport 53 -m comment --comment containarium -j SNAT --to-source 10.0.3.1",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("mutations:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(swept) != len(want) {
+		t.Errorf("swept %d rules, want %d", len(swept), len(want))
+	}
+}
+
+func TestSweepOrphans_StoppedContainerKeepsRules(t *testing.T) {
+	f := &fakeIPTables{listing: sweepListing}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	// bob is stopped: no address, but its rules name it. The untagged
+	// 10.0.3.40 route might be a stopped box's, so it stays too.
+	live := NewLiveContainers()
+	live.Add("alice-container", "10.0.3.20")
+	live.Add("bob-container", "")
+
+	swept, err := pm.SweepOrphans(live, false)
+	if err != nil {
+		t.Fatalf("SweepOrphans: %v", err)
+	}
+	if len(swept) != 0 || len(f.mutations()) != 0 {
+		t.Errorf("swept %v, mutations %v; want none", swept, f.mutations())
+	}
+}
+
+func TestSweepOrphans_LeavesTargetsOutsideContainerNetwork(t *testing.T) {
+	// Admin routes to a LAN host and a VM: no container will ever own
+	// those addresses.
+	f := &fakeIPTables{listing: `-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 9000 -m comment --comment containarium -j DNAT --to-destination 192.168.1.50:9000
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 9001 -m comment --comment containarium -j DNAT --to-destination 172.16.0.8:22
+-A POSTROUTING -d 192.168.1.50/32 -p tcp -m tcp --dport 9000 -m comment --comment containarium -j MASQUERADE`}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	live := NewLiveContainers()
+	live.Add("alice-container", "10.0.3.20")
+
+	swept, err := pm.SweepOrphans(live, false)
+	if err != nil {
+		t.Fatalf("SweepOrphans: %v", err)
+	}
+	if len(swept) != 0 || len(f.mutations()) != 0 {
+		t.Errorf("swept %v, mutations %v; want none", swept, f.mutations())
+	}
+}
+
+func TestSweepOrphans_DryRunChangesNothing(t *testing.T) {
+	f := &fakeIPTables{listing: sweepListing}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	swept, err := pm.SweepOrphans(NewLiveContainers(), true)
+	if err != nil {
+		t.Fatalf("SweepOrphans: %v", err)
+	}
+	if len(swept) != 6 {
+		t.Errorf("dry run reported %d rules, want 6", len(swept))
+	}
+	if m := f.mutations(); len(m) != 0 {
+		t.Errorf("dry run changed rules: %v", m)
+	}
+}
+
+func TestRemoveRoute_RemovesTaggedAndLegacyRules(t *testing.T) {
+	f := &fakeIPTables{listing: sweepListing}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	if err := pm.RemoveRoute(2222, "tcp"); err != nil {
+		t.Fatalf("RemoveRoute tagged: %v", err)
+	}
+	if err := pm.RemoveRoute(8080, "tcp"); err != nil {
+		t.Fatalf("RemoveRoute legacy: %v", err)
+	}
+	got := f.mutations()
+	if len(got) < 2 {
+		t.Fatalf("mutations = %v", got)
+	}
+	if !strings.Contains(got[0], "-D PREROUTING") || !strings.Contains(got[0], "--comment containarium:alice-container -j DNAT") {
+		t.Errorf("tagged DNAT removal = %q", got[0])
+	}
+	last := got[len(got)-1]
+	if strings.Contains(last, "--comment") || !strings.Contains(last, "10.0.3.99") {
+		t.Errorf("legacy removal = %q, want the untagged spec", last)
+	}
+}
+
+func TestAddRouteWithOptions_TagsRulesWithContainer(t *testing.T) {
+	f := &fakeIPTables{}
+	pm := &PassthroughManager{networkCIDR: "10.0.3.0/24", run: f.run}
+
+	if err := pm.AddRouteWithOptions(2222, "10.0.3.20", 22, "tcp", RouteOptions{Container: "alice-container"}); err != nil {
+		t.Fatalf("AddRouteWithOptions: %v", err)
+	}
+	for _, m := range f.mutations() {
+		if !strings.Contains(m, "-m comment --comment containarium:alice-container -j ") {
+			t.Errorf("rule %q not tagged with its container", m)
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"sync"
 	"time"
+
+	"github.com/footprintai/containarium/internal/metrics/opmetrics"
 )
 
 // PassthroughSyncJob synchronizes passthrough routes from PostgreSQL (source of truth) to iptables (runtime)
@@ -105,13 +107,17 @@ func (j *PassthroughSyncJob) sync(ctx context.Context) error {
 	// Get routes from PostgreSQL (source of truth)
 	dbRoutes, err := j.store.List(ctx, true) // activeOnly = true
 	if err != nil {
-		return fmt.Errorf("failed to list passthrough routes from DB: %w", err)
+		err = fmt.Errorf("failed to list passthrough routes from DB: %w", err)
+		opmetrics.RecordPassthroughReconcile(0, 0, 0, 0, err)
+		return err
 	}
 
 	// Get current routes from iptables
 	iptablesRoutes, err := j.manager.ListRoutes()
 	if err != nil {
-		return fmt.Errorf("failed to list passthrough routes from iptables: %w", err)
+		err = fmt.Errorf("failed to list passthrough routes from iptables: %w", err)
+		opmetrics.RecordPassthroughReconcile(0, 0, 0, 0, err)
+		return err
 	}
 
 	// Build maps for efficient diffing
@@ -128,7 +134,7 @@ func (j *PassthroughSyncJob) sync(ctx context.Context) error {
 		iptablesRouteMap[key] = r
 	}
 
-	var added, removed, updated int
+	var added, removed, updated, failed int
 
 	// Find routes to add or update (in DB but not in iptables, or different)
 	for key, dbRoute := range dbRouteMap {
@@ -136,8 +142,9 @@ func (j *PassthroughSyncJob) sync(ctx context.Context) error {
 
 		if !exists {
 			// Route in DB but not in iptables - add it
-			if err := j.manager.AddRoute(dbRoute.ExternalPort, dbRoute.TargetIP, dbRoute.TargetPort, dbRoute.Protocol); err != nil {
+			if err := j.manager.AddRouteWithOptions(dbRoute.ExternalPort, dbRoute.TargetIP, dbRoute.TargetPort, dbRoute.Protocol, dbRoute.routeOptions()); err != nil {
 				log.Printf("[PassthroughSyncJob] Failed to add route %s: %v", key, err)
+				failed++
 				continue
 			}
 			added++
@@ -147,10 +154,12 @@ func (j *PassthroughSyncJob) sync(ctx context.Context) error {
 				// Remove old rule and add new one
 				if err := j.manager.RemoveRoute(dbRoute.ExternalPort, dbRoute.Protocol); err != nil {
 					log.Printf("[PassthroughSyncJob] Failed to remove old route %s for update: %v", key, err)
+					failed++
 					continue
 				}
-				if err := j.manager.AddRoute(dbRoute.ExternalPort, dbRoute.TargetIP, dbRoute.TargetPort, dbRoute.Protocol); err != nil {
+				if err := j.manager.AddRouteWithOptions(dbRoute.ExternalPort, dbRoute.TargetIP, dbRoute.TargetPort, dbRoute.Protocol, dbRoute.routeOptions()); err != nil {
 					log.Printf("[PassthroughSyncJob] Failed to add updated route %s: %v", key, err)
+					failed++
 					continue
 				}
 				updated++
@@ -163,6 +172,7 @@ func (j *PassthroughSyncJob) sync(ctx context.Context) error {
 		if _, exists := dbRouteMap[key]; !exists {
 			if err := j.manager.RemoveRoute(iptablesRoute.ExternalPort, iptablesRoute.Protocol); err != nil {
 				log.Printf("[PassthroughSyncJob] Failed to remove route %s: %v", key, err)
+				failed++
 				continue
 			}
 			removed++
@@ -173,6 +183,7 @@ func (j *PassthroughSyncJob) sync(ctx context.Context) error {
 	if added > 0 || removed > 0 || updated > 0 {
 		log.Printf("[PassthroughSyncJob] Synced passthrough routes: +%d added, -%d removed, ~%d updated", added, removed, updated)
 	}
+	opmetrics.RecordPassthroughReconcile(added, updated, removed, failed, nil)
 
 	return nil
 }
@@ -185,5 +196,5 @@ func (j *PassthroughSyncJob) needsUpdate(dbRoute *PassthroughRecord, iptablesRou
 	if dbRoute.TargetPort != iptablesRoute.TargetPort {
 		return true
 	}
-	return false
+	return !dbRoute.routeOptions().Equal(iptablesRoute.RouteOptions)
 }
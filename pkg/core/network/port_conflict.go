@@ -0,0 +1,179 @@
+package network
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// PortConflictError reports that a host port is already taken by something
+// other than a Containarium passthrough route: a process listening on it, or
+// a NAT rule (Docker's, a hand-written DNAT, Caddy's 80/443 forward) that
+// would shadow or be shadowed by the new rule.
+type PortConflictError struct {
+	Port     int
+	Protocol string
+	Holders  []string
+}
+
+func (e *PortConflictError) Error() string {
+	return fmt.Sprintf("port %d/%s is already in use on the host: %s", e.Port, e.Protocol, strings.Join(e.Holders, "; "))
+}
+
+// CheckPortConflict verifies externalPort is free for a passthrough route:
+// nothing on the host listens on it (loopback-only listeners don't count —
+// external traffic never reaches them) and no NAT rule other than
+// Containarium's own passthrough DNATs matches it. Returns a
+// *PortConflictError naming what holds the port, or a plain error if the
+// host state couldn't be read.
+func (pm *PassthroughManager) CheckPortConflict(externalPort int, protocol string) error {
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	protocol = strings.ToLower(protocol)
+
+	var holders []string
+	for _, suffix := range []string{"", "6"} {
+		path := "/proc/net/" + protocol + suffix
+		data, err := os.ReadFile(path) // #nosec G304 -- fixed /proc path
+		if err != nil {
+			if os.IsNotExist(err) && suffix == "6" {
+				continue // IPv6 disabled
+			}
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		for _, addr := range procNetListeners(string(data), protocol == "udp", externalPort) {
+			holders = append(holders, "listening socket on "+addr)
+		}
+	}
+
+	save, err := exec.Command("iptables", "-t", "nat", "-S").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to list iptables nat rules: %w, output: %s", err, string(save))
+	}
+	for _, rule := range conflictingNATRules(string(save), externalPort, protocol, pm.networkCIDR) {
+		holders = append(holders, "iptables rule `"+rule+"`")
+	}
+
+	if len(holders) > 0 {
+		return &PortConflictError{Port: externalPort, Protocol: protocol, Holders: holders}
+	}
+	return nil
+}
+
+// procNetListeners returns the non-loopback local addresses in a
+// /proc/net/{tcp,tcp6,udp,udp6} table bound to port. For TCP only LISTEN
+// sockets count; for UDP, unconnected (bound) sockets.
+func procNetListeners(table string, udp bool, port int) []string {
+	state := "0A" // TCP_LISTEN
+	if udp {
+		state = "07" // TCP_CLOSE: a bound, unconnected UDP socket
+	}
+	var out []string
+	for _, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[3] != state {
+			continue
+		}
+		ip, p, ok := parseProcNetAddr(fields[1])
+		if !ok || p != port || ip.IsLoopback() {
+			continue
+		}
+		out = append(out, net.JoinHostPort(ip.String(), strconv.Itoa(p)))
+	}
+	return out
+}
+
+// parseProcNetAddr decodes a /proc/net local_address ("0100007F:1F90"). The
+// address is hex in host (little-endian) order, one 32-bit word at a time.
+func parseProcNetAddr(s string) (net.IP, int, bool) {
+	hostHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, 0, false
+	}
+	raw, err := hex.DecodeString(hostHex)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil, 0, false
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return nil, 0, false
+	}
+	ip := make(net.IP, len(raw))
+	for w := 0; w < len(raw); w += 4 {
+		ip[w], ip[w+1], ip[w+2], ip[w+3] = raw[w+3], raw[w+2], raw[w+1], raw[w]
+	}
+	return ip, int(port), true
+}
+
+// conflictingNATRules returns the `iptables -t nat -S` rules that redirect
+// port/protocol and aren't Containarium passthrough DNATs (PREROUTING,
+// `! -s networkCIDR`, outside the Caddy ports 80/443).
+func conflictingNATRules(saveOutput string, port int, protocol, networkCIDR string) []string {
+	var out []string
+	for _, raw := range strings.Split(saveOutput, "\n") {
+		line := strings.TrimSpace(raw)
+		if !strings.HasPrefix(line, "-A ") {
+			continue
+		}
+		fields := strings.Fields(line)
+		var proto, target string
+		var ports []string
+		excludesNetwork := false
+		for i := 0; i+1 < len(fields); i++ {
+			negated := i > 0 && fields[i-1] == "!"
+			switch fields[i] {
+			case "-p":
+				proto = fields[i+1]
+			case "-j":
+				target = fields[i+1]
+			case "--dport", "--dports":
+				if !negated {
+					ports = strings.Split(fields[i+1], ",")
+				}
+			case "-s":
+				excludesNetwork = negated && fields[i+1] == networkCIDR
+			}
+		}
+		if target != "DNAT" && target != "REDIRECT" {
+			continue
+		}
+		if proto != "" && proto != protocol && proto != "all" {
+			continue
+		}
+		if !portListMatches(ports, port) {
+			continue
+		}
+		ours := fields[1] == "PREROUTING" && target == "DNAT" && excludesNetwork && port != 80 && port != 443
+		if !ours {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+// portListMatches reports whether port is in an iptables port list
+// ("80", "8000:8100", or the split form of "80,443").
+func portListMatches(ports []string, port int) bool {
+	for _, p := range ports {
+		lo, hi, isRange := strings.Cut(p, ":")
+		from, err := strconv.Atoi(lo)
+		if err != nil {
+			continue
+		}
+		to := from
+		if isRange {
+			if to, err = strconv.Atoi(hi); err != nil {
+				continue
+			}
+		}
+		if port >= from && port <= to {
+			return true
+		}
+	}
+	return false
+}
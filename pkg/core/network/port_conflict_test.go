@@ -0,0 +1,82 @@
+package network
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProcNetListeners(t *testing.T) {
+	// Trimmed /proc/net/tcp: sshd on 0.0.0.0:22, postgres on
+	// 127.0.0.1:5432 (loopback-only), an app on 192.0.2.10:5432, and an
+	// established connection from local port 5432.
+	tcp := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1 1 0 100 0 0 10 0
+   1: 0100007F:1538 00000000:0000 0A 00000000:00000000 00:00000000 00000000   106        0 2 1 0 100 0 0 10 0
+   2: 0A0200C0:1538 00000000:0000 0A 00000000:00000000 00:00000000 00000000   106        0 3 1 0 100 0 0 10 0
+   3: 0A0200C0:1538 140200C0:D431 01 00000000:00000000 00:00000000 00000000   106        0 4 1 0 100 0 0 10 0`
+
+	if got := procNetListeners(tcp, false, 22); !reflect.DeepEqual(got, []string{"0.0.0.0:22"}) {
+		t.Errorf("port 22: got %v", got)
+	}
+	if got := procNetListeners(tcp, false, 5432); !reflect.DeepEqual(got, []string{"192.0.2.10:5432"}) {
+		t.Errorf("port 5432: got %v, want only the non-loopback listener", got)
+	}
+	if got := procNetListeners(tcp, false, 8080); got != nil {
+		t.Errorf("port 8080: got %v, want none", got)
+	}
+
+	// tcp6: [::]:443 listening, [::1]:8443 loopback-only.
+	tcp6 := `  sl  local_address                         remote_address                        st
+   0: 00000000000000000000000000000000:01BB 00000000000000000000000000000000:0000 0A
+   1: 00000000000000000000000001000000:20FB 00000000000000000000000000000000:0000 0A`
+	if got := procNetListeners(tcp6, false, 443); !reflect.DeepEqual(got, []string{"[::]:443"}) {
+		t.Errorf("tcp6 port 443: got %v", got)
+	}
+	if got := procNetListeners(tcp6, false, 8443); got != nil {
+		t.Errorf("tcp6 port 8443: got %v, want loopback skipped", got)
+	}
+
+	// UDP: a bound socket on 0.0.0.0:53 has state 07.
+	udp := `   sl  local_address rem_address   st
+  0: 00000000:0035 00000000:0000 07`
+	if got := procNetListeners(udp, true, 53); !reflect.DeepEqual(got, []string{"0.0.0.0:53"}) {
+		t.Errorf("udp port 53: got %v", got)
+	}
+	if got := procNetListeners(udp, false, 53); got != nil {
+		t.Errorf("udp table read as tcp: got %v, want none", got)
+	}
+}
+
+func TestConflictingNATRules(t *testing.T) {
+	save := `-P PREROUTING ACCEPT
+-A PREROUTING -p tcp -m tcp ! -s 10.0.3.0/24 --dport 80 -j DNAT --to-destination 10.0.3.50:80
+-A PREROUTING ! -s 10.0.3.0/24 -p tcp -m tcp --dport 50051 -j DNAT --to-destination 10.0.3.150:50051
+-A DOCKER ! -i docker0 -p tcp -m tcp --dport 8080 -j DNAT --to-destination 172.17.0.2:80
+-A PREROUTING -p udp -m multiport --dports 5000:5100,6000 -j REDIRECT --to-ports 7000
+-A PREROUTING -p tcp -m tcp ! --dport 9000 -j DNAT --to-destination 192.0.2.5:9000
+-A POSTROUTING -p tcp -d 10.0.3.150/32 --dport 50051 -j MASQUERADE`
+
+	tests := []struct {
+		name     string
+		port     int
+		protocol string
+		want     []string
+	}{
+		{"own passthrough", 50051, "tcp", nil},
+		{"caddy forward", 80, "tcp", []string{"-A PREROUTING -p tcp -m tcp ! -s 10.0.3.0/24 --dport 80 -j DNAT --to-destination 10.0.3.50:80"}},
+		{"docker publish", 8080, "tcp", []string{"-A DOCKER ! -i docker0 -p tcp -m tcp --dport 8080 -j DNAT --to-destination 172.17.0.2:80"}},
+		{"protocol mismatch", 8080, "udp", nil},
+		{"multiport range", 5050, "udp", []string{"-A PREROUTING -p udp -m multiport --dports 5000:5100,6000 -j REDIRECT --to-ports 7000"}},
+		{"multiport single", 6000, "udp", []string{"-A PREROUTING -p udp -m multiport --dports 5000:5100,6000 -j REDIRECT --to-ports 7000"}},
+		{"negated dport", 9000, "tcp", nil},
+		{"free", 2222, "tcp", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := conflictingNATRules(save, tt.port, tt.protocol, "10.0.3.0/24")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("conflictingNATRules(%d/%s) = %v, want %v", tt.port, tt.protocol, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,77 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+// BalanceMode selects how PortForwarder spreads ports 80/443 over several
+// Caddy backends.
+type BalanceMode string
+
+const (
+	// BalanceRoundRobin spreads new connections evenly over every healthy
+	// backend using the iptables statistic match.
+	BalanceRoundRobin BalanceMode = "round-robin"
+
+	// BalanceFailover sends everything to the first healthy backend in the
+	// configured order; the others are standbys.
+	BalanceFailover BalanceMode = "failover"
+)
+
+// ParseBalanceMode validates a balance mode flag value. Empty means
+// round-robin.
+func ParseBalanceMode(s string) (BalanceMode, error) {
+	switch BalanceMode(strings.ToLower(strings.TrimSpace(s))) {
+	case "", BalanceRoundRobin:
+		return BalanceRoundRobin, nil
+	case BalanceFailover:
+		return BalanceFailover, nil
+	}
+	return "", fmt.Errorf("unknown balance mode %q (want %s or %s)", s, BalanceRoundRobin, BalanceFailover)
+}
+
+// DefaultBackendCheckInterval is how often WatchBackends probes the Caddy
+// backends.
+const DefaultBackendCheckInterval = 10 * time.Second
+
+// backendDialTimeout bounds one health probe. A Caddy that can't accept a
+// TCP connection within this is treated as down.
+const backendDialTimeout = 2 * time.Second
+
+// caddyDNATGroups lists the chain/port pairs that carry Caddy DNAT rules,
+// in the order SetupPortForwarding installs them.
+var caddyDNATGroups = []struct {
+	chain string
+	port  int
+}{
+	{"PREROUTING", 80},
+	{"PREROUTING", 443},
+	{"OUTPUT", 80},
+	{"OUTPUT", 443},
+}
+
 // PortForwarder manages iptables port forwarding rules for Caddy
 type PortForwarder struct {
-	caddyIP     string
+	backends    []string // Caddy IPs in priority order; the first is the primary
+	mode        BalanceMode
 	networkCIDR string // Container network CIDR to exclude from forwarding (e.g., "10.0.3.0/24")
+
+	// dial opens the health-probe connection; replaced in tests.
+	dial func(network, address string, timeout time.Duration) (net.Conn, error)
+
+	mu      sync.Mutex
+	healthy map[string]bool
+	applied []string // backends the installed DNAT rules currently point at
 }
 
 // NewPortForwarder creates a new port forwarder for the given Caddy IP
@@ -23,16 +83,53 @@ func NewPortForwarder(caddyIP string) *PortForwarder {
 
 // NewPortForwarderWithNetwork creates a new port forwarder with explicit network CIDR
 func NewPortForwarderWithNetwork(caddyIP, networkCIDR string) *PortForwarder {
+	return NewPortForwarderWithBackends([]string{caddyIP}, networkCIDR, BalanceRoundRobin)
+}
+
+// NewPortForwarderWithBackends creates a port forwarder that spreads 80/443
+// over several Caddy instances. backends is in priority order: in failover
+// mode the first healthy one takes all traffic. Empty and duplicate entries
+// are dropped. If networkCIDR is empty it is derived from the first backend.
+func NewPortForwarderWithBackends(backends []string, networkCIDR string, mode BalanceMode) *PortForwarder {
+	var ips []string
+	for _, ip := range backends {
+		ip = strings.TrimSpace(ip)
+		if ip != "" && !slices.Contains(ips, ip) {
+			ips = append(ips, ip)
+		}
+	}
 	// If no network CIDR provided, derive from Caddy IP (assume /24)
-	if networkCIDR == "" {
-		networkCIDR = deriveNetworkCIDR(caddyIP)
+	if networkCIDR == "" && len(ips) > 0 {
+		networkCIDR = deriveNetworkCIDR(ips[0])
+	}
+	if mode == "" {
+		mode = BalanceRoundRobin
+	}
+	healthy := make(map[string]bool, len(ips))
+	for _, ip := range ips {
+		healthy[ip] = true // assume up until the first probe says otherwise
 	}
 	return &PortForwarder{
-		caddyIP:     caddyIP,
+		backends:    ips,
+		mode:        mode,
 		networkCIDR: networkCIDR,
+		dial:        net.DialTimeout,
+		healthy:     healthy,
 	}
 }
 
+// Backends returns the configured Caddy IPs in priority order.
+func (pf *PortForwarder) Backends() []string {
+	return slices.Clone(pf.backends)
+}
+
+// ActiveBackends returns the Caddy IPs the DNAT rules currently point at.
+func (pf *PortForwarder) ActiveBackends() []string {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	return slices.Clone(pf.applied)
+}
+
 // deriveNetworkCIDR derives a /24 network CIDR from an IP address
 // e.g., "10.0.3.111" -> "10.0.3.0/24"
 func deriveNetworkCIDR(ip string) string {
@@ -57,6 +154,9 @@ func deriveNetworkCIDR(ip string) string {
 //     packets, so OUTPUT is needed.
 //  3. POSTROUTING MASQUERADE — for return traffic.
 //
+// With several backends each DNAT path becomes an ordered run of rules, one
+// per active backend (see dnatRuleSpecs).
+//
 // Plus one sysctl: net.ipv4.conf.all.route_localnet=1. By default the
 // kernel refuses to route 127.0.0.0/8 packets out a non-loopback
 // interface even after DNAT, so the tunneled-primary path silently
@@ -64,7 +164,14 @@ func deriveNetworkCIDR(ip string) string {
 // page calls out that this is the right knob for "DNAT 127/8 to a
 // non-local address" use case.
 func (pf *PortForwarder) SetupPortForwarding() error {
-	log.Printf("Setting up port forwarding to Caddy (%s)...", pf.caddyIP)
+	if len(pf.backends) == 0 {
+		return fmt.Errorf("no Caddy backend configured")
+	}
+	if len(pf.backends) > 1 {
+		log.Printf("Setting up port forwarding to Caddy (%s, %s)...", strings.Join(pf.backends, ", "), pf.mode)
+	} else {
+		log.Printf("Setting up port forwarding to Caddy (%s)...", pf.backends[0])
+	}
 	log.Printf("  Excluding container network: %s", pf.networkCIDR)
 
 	// Enable IP forwarding
@@ -78,57 +185,229 @@ func (pf *PortForwarder) SetupPortForwarding() error {
 		log.Printf("  Warning: failed to set route_localnet=1: %v (tunneled-primary loopback path may not work)", err)
 	}
 
-	// Clear Caddy DNAT/MASQUERADE rules that point at a *different* IP than the
-	// current one. When core-caddy is recreated it comes back with a new
-	// container IP; the per-rule existence checks below would then ADD rules for
-	// the new IP while leaving the old ones in place. The stale rule sorts first
-	// in the chain and matches, so all :443 traffic is DNAT'd to a container
-	// that no longer exists and the TLS handshake returns nothing (#400).
+	// Clear Caddy DNAT/MASQUERADE rules that point at an IP that is not one
+	// of the current backends. When core-caddy is recreated it comes back
+	// with a new container IP; the existence checks below would then ADD
+	// rules for the new IP while leaving the old ones in place. The stale
+	// rule sorts first in the chain and matches, so all :443 traffic is
+	// DNAT'd to a container that no longer exists and the TLS handshake
+	// returns nothing (#400).
 	pf.reconcileStaleRules()
 
-	// Each rule is added independently with its own existence check.
-	// An older deploy may have PREROUTING but not OUTPUT — we want to
-	// add the missing one without duplicating what's already there.
-	if err := pf.ensurePreRoutingRule(80); err != nil {
-		return fmt.Errorf("failed to add port 80 PREROUTING: %w", err)
+	pf.mu.Lock()
+	active := pf.activeBackends()
+	err := pf.applyDNATRules(active)
+	pf.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Masquerade return traffic for every backend, active or not, so a
+	// failover only has to rewrite the DNAT rules.
+	for _, ip := range pf.backends {
+		if err := pf.addMasqueradeRule(ip); err != nil {
+			return fmt.Errorf("failed to add masquerade rule for %s: %w", ip, err)
+		}
+	}
+
+	log.Printf("  Port forwarding configured: 80,443 -> %s (PREROUTING + OUTPUT)", strings.Join(active, ", "))
+	return nil
+}
+
+// Installed reports whether every DNAT and masquerade rule
+// SetupPortForwarding would install is in place.
+func (pf *PortForwarder) Installed() bool {
+	pf.mu.Lock()
+	active := pf.activeBackends()
+	pf.mu.Unlock()
+	for _, g := range caddyDNATGroups {
+		if !rulesExist(pf.dnatRuleSpecs(g.chain, g.port, active)) {
+			return false
+		}
+	}
+	for _, ip := range pf.backends {
+		if !rulesExist([][]string{{"POSTROUTING", "-d", ip, "-j", "MASQUERADE"}}) {
+			return false
+		}
 	}
-	if err := pf.ensurePreRoutingRule(443); err != nil {
-		return fmt.Errorf("failed to add port 443 PREROUTING: %w", err)
+	return true
+}
+
+// activeBackends returns the backends the DNAT rules should point at: every
+// healthy one for round-robin, the first healthy one for failover. If none
+// is healthy all of them are used, since forwarding to a Caddy that might
+// recover beats dropping every connection. Caller holds pf.mu.
+func (pf *PortForwarder) activeBackends() []string {
+	var up []string
+	for _, ip := range pf.backends {
+		if pf.healthy[ip] {
+			up = append(up, ip)
+		}
 	}
-	if err := pf.ensureOutputRule(80); err != nil {
-		return fmt.Errorf("failed to add port 80 OUTPUT: %w", err)
+	if len(up) == 0 {
+		up = slices.Clone(pf.backends)
 	}
-	if err := pf.ensureOutputRule(443); err != nil {
-		return fmt.Errorf("failed to add port 443 OUTPUT: %w", err)
+	if pf.mode == BalanceFailover && len(up) > 1 {
+		up = up[:1]
+	}
+	return up
+}
+
+// dnatRuleSpecs returns the ordered DNAT rules (chain first, ready for
+// `iptables -t nat -A`) that spread chain/port over active. Each rule sees
+// only the packets the rules before it let through, so taking every
+// (n-i)th of those gives each of the n backends an equal share; the last
+// rule takes the remainder unconditionally. The nat table only sees a
+// connection's first packet, so this balances connections, not packets.
+// A single backend yields the plain rule older releases installed.
+func (pf *PortForwarder) dnatRuleSpecs(chain string, port int, active []string) [][]string {
+	dport := strconv.Itoa(port)
+	var match []string
+	switch chain {
+	case "PREROUTING":
+		// Excludes traffic from the container network so containers can
+		// reach external HTTPS services (e.g., Docker registry, Let's Encrypt).
+		match = []string{"-p", "tcp", "!", "-s", pf.networkCIDR, "--dport", dport}
+	case "OUTPUT":
+		// Locally-generated packets to 127.0.0.0/8:port — the path used by
+		// tunneled-primary tunnel clients (slice 6), which dial
+		// 127.0.0.1:port to forward inbound bytes.
+		match = []string{"-p", "tcp", "-d", "127.0.0.0/8", "--dport", dport}
+	}
+
+	n := len(active)
+	specs := make([][]string, 0, n)
+	for i, ip := range active {
+		spec := append([]string{chain}, match...)
+		if i < n-1 {
+			spec = append(spec, "-m", "statistic", "--mode", "nth", "--every", strconv.Itoa(n-i), "--packet", "0")
+		}
+		spec = append(spec, "-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", ip, port))
+		specs = append(specs, spec)
 	}
-	if err := pf.addMasqueradeRule(); err != nil {
-		return fmt.Errorf("failed to add masquerade rule: %w", err)
+	return specs
+}
+
+// applyDNATRules makes each Caddy chain/port run of DNAT rules match
+// dnatRuleSpecs(active). The statistic matches only balance correctly in
+// order, so a run that differs in any way is deleted and re-appended
+// whole; a run that already matches is left alone, and a missing run (an
+// older deploy may have PREROUTING but not OUTPUT) is simply added. Caller
+// holds pf.mu.
+func (pf *PortForwarder) applyDNATRules(active []string) error {
+	save, err := exec.Command("iptables", "-t", "nat", "-S").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to list nat rules: %w, output: %s", err, string(save))
 	}
+	existing := caddyNATRules(string(save))
+
+	for _, g := range caddyDNATGroups {
+		want := pf.dnatRuleSpecs(g.chain, g.port, active)
+		var have []caddyNATRule
+		for _, r := range existing {
+			if r.chain == g.chain && r.dport == strconv.Itoa(g.port) {
+				have = append(have, r)
+			}
+		}
+		if len(have) == len(want) && rulesExist(want) {
+			continue
+		}
 
-	log.Printf("  Port forwarding configured: 80,443 -> %s (PREROUTING + OUTPUT)", pf.caddyIP)
+		for _, r := range have {
+			// #nosec G204 -- rule specs read back from this host's own
+			// `iptables -t nat -S` output, not external input.
+			if e := exec.Command("iptables", r.deleteArgs()...).Run(); e != nil {
+				log.Printf("  Warning: failed to delete Caddy rule (%v): %v", r.fields, e)
+			}
+		}
+		for _, spec := range want {
+			args := append([]string{"-t", "nat", "-A"}, spec...)
+			// #nosec G204 -- args are built from the configured backend IPs
+			// and network CIDR.
+			if output, err := exec.Command("iptables", args...).CombinedOutput(); err != nil {
+				return fmt.Errorf("failed to add port %d %s: iptables failed: %w, output: %s", g.port, g.chain, err, string(output))
+			}
+		}
+	}
+	pf.applied = active
 	return nil
 }
 
-// ensurePreRoutingRule adds a PREROUTING DNAT rule if it's not already present.
-func (pf *PortForwarder) ensurePreRoutingRule(port int) error {
-	check := exec.Command("iptables", "-t", "nat", "-C", "PREROUTING",
-		"-p", "tcp", "!", "-s", pf.networkCIDR, "--dport", fmt.Sprintf("%d", port),
-		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", pf.caddyIP, port))
-	if check.Run() == nil {
-		return nil
+// rulesExist reports whether every nat rule spec is installed.
+func rulesExist(specs [][]string) bool {
+	for _, spec := range specs {
+		args := append([]string{"-t", "nat", "-C"}, spec...)
+		// #nosec G204 -- see applyDNATRules.
+		if exec.Command("iptables", args...).Run() != nil {
+			return false
+		}
 	}
-	return pf.addPreRoutingRule(port)
+	return true
 }
 
-// ensureOutputRule adds an OUTPUT DNAT rule if it's not already present.
-func (pf *PortForwarder) ensureOutputRule(port int) error {
-	check := exec.Command("iptables", "-t", "nat", "-C", "OUTPUT",
-		"-p", "tcp", "-d", "127.0.0.0/8", "--dport", fmt.Sprintf("%d", port),
-		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", pf.caddyIP, port))
-	if check.Run() == nil {
+// CheckBackends probes every backend's :443 once and, if that changes which
+// backends should take traffic, rewrites the DNAT rules. A dead backend
+// drops out of rotation (or hands primary to the next one, in failover
+// mode) and rejoins once it accepts connections again.
+func (pf *PortForwarder) CheckBackends() error {
+	health := pf.probeBackends()
+
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	for _, ip := range pf.backends {
+		if health[ip] != pf.healthy[ip] {
+			state := "down"
+			if health[ip] {
+				state = "up"
+			}
+			log.Printf("Caddy backend %s is %s", ip, state)
+		}
+	}
+	pf.healthy = health
+
+	active := pf.activeBackends()
+	if slices.Equal(active, pf.applied) {
 		return nil
 	}
-	return pf.addOutputRule(port)
+	log.Printf("Re-pointing port forwarding 80,443 -> %s", strings.Join(active, ", "))
+	return pf.applyDNATRules(active)
+}
+
+// probeBackends dials each backend's :443 and reports which answered.
+func (pf *PortForwarder) probeBackends() map[string]bool {
+	health := make(map[string]bool, len(pf.backends))
+	for _, ip := range pf.backends {
+		conn, err := pf.dial("tcp", net.JoinHostPort(ip, "443"), backendDialTimeout)
+		if err == nil {
+			_ = conn.Close()
+		}
+		health[ip] = err == nil
+	}
+	return health
+}
+
+// WatchBackends runs CheckBackends every interval until ctx is done. With a
+// single backend there is nothing to fail over to, so it returns at once.
+func (pf *PortForwarder) WatchBackends(ctx context.Context, interval time.Duration) {
+	if len(pf.backends) < 2 {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultBackendCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := pf.CheckBackends(); err != nil {
+				log.Printf("Warning: failed to update Caddy port forwarding: %v", err)
+			}
+		}
+	}
 }
 
 // enableRouteLocalnet sets net.ipv4.conf.all.route_localnet=1, which is
@@ -148,21 +427,6 @@ func (pf *PortForwarder) enableRouteLocalnet() error {
 	return nil
 }
 
-// addOutputRule mirrors addPreRoutingRule but on the OUTPUT chain, so
-// locally-generated packets to 127.0.0.0/8:port get DNAT'd to the Caddy
-// container. This is the path used by tunneled-primary tunnel clients
-// (slice 6) which dial 127.0.0.1:port to forward inbound bytes.
-func (pf *PortForwarder) addOutputRule(port int) error {
-	cmd := exec.Command("iptables", "-t", "nat", "-A", "OUTPUT",
-		"-p", "tcp", "-d", "127.0.0.0/8", "--dport", fmt.Sprintf("%d", port),
-		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", pf.caddyIP, port))
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("iptables OUTPUT failed: %w, output: %s", err, string(output))
-	}
-	return nil
-}
-
 // enableIPForwarding enables IP forwarding in the kernel
 func (pf *PortForwarder) enableIPForwarding() error {
 	cmd := exec.Command("sysctl", "-w", "net.ipv4.ip_forward=1")
@@ -186,31 +450,17 @@ func EnableConntrackAccounting() error {
 	return nil
 }
 
-// addPreRoutingRule adds a PREROUTING DNAT rule for the specified port
-// The rule excludes traffic from the container network to allow containers
-// to access external HTTPS services (e.g., Docker registry, Let's Encrypt)
-func (pf *PortForwarder) addPreRoutingRule(port int) error {
-	cmd := exec.Command("iptables", "-t", "nat", "-A", "PREROUTING",
-		"-p", "tcp", "!", "-s", pf.networkCIDR, "--dport", fmt.Sprintf("%d", port),
-		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", pf.caddyIP, port))
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("iptables failed: %w, output: %s", err, string(output))
-	}
-	return nil
-}
-
 // addMasqueradeRule adds a POSTROUTING MASQUERADE rule for return traffic
-func (pf *PortForwarder) addMasqueradeRule() error {
+func (pf *PortForwarder) addMasqueradeRule(caddyIP string) error {
 	// Check if rule already exists
 	checkCmd := exec.Command("iptables", "-t", "nat", "-C", "POSTROUTING",
-		"-d", pf.caddyIP, "-j", "MASQUERADE")
+		"-d", caddyIP, "-j", "MASQUERADE")
 	if checkCmd.Run() == nil {
 		return nil // Rule already exists
 	}
 
 	cmd := exec.Command("iptables", "-t", "nat", "-A", "POSTROUTING",
-		"-d", pf.caddyIP, "-j", "MASQUERADE")
+		"-d", caddyIP, "-j", "MASQUERADE")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("iptables failed: %w, output: %s", err, string(output))
@@ -218,58 +468,54 @@ func (pf *PortForwarder) addMasqueradeRule() error {
 	return nil
 }
 
-// RemovePortForwarding removes the port forwarding rules
+// RemovePortForwarding removes the port forwarding rules. Every Caddy DNAT
+// rule targeting one of the backends is removed, whatever balancing match
+// it carries, so rules installed with a different backend count or mode
+// are cleaned up too.
 func (pf *PortForwarder) RemovePortForwarding() error {
-	log.Printf("Removing port forwarding rules for Caddy (%s)...", pf.caddyIP)
-
-	// Remove PREROUTING rules
-	pf.removePreRoutingRule(80)
-	pf.removePreRoutingRule(443)
-
-	// Remove OUTPUT rules
-	pf.removeOutputRule(80)
-	pf.removeOutputRule(443)
-
-	// Remove MASQUERADE rule
-	pf.removeMasqueradeRule()
+	log.Printf("Removing port forwarding rules for Caddy (%s)...", strings.Join(pf.backends, ", "))
 
-	return nil
-}
-
-// removePreRoutingRule removes a PREROUTING DNAT rule
-func (pf *PortForwarder) removePreRoutingRule(port int) {
-	cmd := exec.Command("iptables", "-t", "nat", "-D", "PREROUTING",
-		"-p", "tcp", "!", "-s", pf.networkCIDR, "--dport", fmt.Sprintf("%d", port),
-		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", pf.caddyIP, port))
-	if err := cmd.Run(); err != nil {
-		log.Printf("  removePreRoutingRule: rule may not exist (ignored): %v", err)
+	save, err := exec.Command("iptables", "-t", "nat", "-S").CombinedOutput()
+	if err != nil {
+		// Can't see what's installed; fall back to the single-backend rules.
+		log.Printf("  Warning: could not enumerate nat rules: %v", err)
+		for _, ip := range pf.backends {
+			for _, g := range caddyDNATGroups {
+				pf.removeRule(pf.dnatRuleSpecs(g.chain, g.port, []string{ip})[0])
+			}
+		}
+	} else {
+		for _, r := range caddyNATRules(string(save)) {
+			if r.dport != "" && slices.Contains(pf.backends, r.target) {
+				pf.removeRule(r.fields)
+			}
+		}
 	}
-}
 
-// removeOutputRule removes the OUTPUT-chain DNAT rule for tunneled-primary
-// loopback traffic.
-func (pf *PortForwarder) removeOutputRule(port int) {
-	cmd := exec.Command("iptables", "-t", "nat", "-D", "OUTPUT",
-		"-p", "tcp", "-d", "127.0.0.0/8", "--dport", fmt.Sprintf("%d", port),
-		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", pf.caddyIP, port))
-	if err := cmd.Run(); err != nil {
-		log.Printf("  removeOutputRule: rule may not exist (ignored): %v", err)
+	// Remove MASQUERADE rules
+	for _, ip := range pf.backends {
+		pf.removeRule([]string{"POSTROUTING", "-d", ip, "-j", "MASQUERADE"})
 	}
+
+	pf.mu.Lock()
+	pf.applied = nil
+	pf.mu.Unlock()
+	return nil
 }
 
-// removeMasqueradeRule removes the POSTROUTING MASQUERADE rule
-func (pf *PortForwarder) removeMasqueradeRule() {
-	cmd := exec.Command("iptables", "-t", "nat", "-D", "POSTROUTING",
-		"-d", pf.caddyIP, "-j", "MASQUERADE")
-	if err := cmd.Run(); err != nil {
-		log.Printf("  removeMasqueradeRule: rule may not exist (ignored): %v", err)
+// removeRule deletes one nat rule spec, ignoring a rule that isn't there.
+func (pf *PortForwarder) removeRule(spec []string) {
+	args := append([]string{"-t", "nat", "-D"}, spec...)
+	// #nosec G204 -- see applyDNATRules.
+	if err := exec.Command("iptables", args...).Run(); err != nil {
+		log.Printf("  removeRule %v: rule may not exist (ignored): %v", spec, err)
 	}
 }
 
 // reconcileStaleRules deletes Caddy port-forward DNAT/MASQUERADE rules whose
-// target IP is not pf.caddyIP. Best-effort: enumerates the nat table with
-// `iptables -t nat -S`, computes the stale deletions, and runs each. Failures
-// are logged, not fatal — the subsequent ensure*Rule calls still install the
+// target IP is not one of pf.backends. Best-effort: enumerates the nat table
+// with `iptables -t nat -S`, computes the stale deletions, and runs each.
+// Failures are logged, not fatal — applyDNATRules still installs the
 // current rules. See SetupPortForwarding / issue #400.
 func (pf *PortForwarder) reconcileStaleRules() {
 	out, err := exec.Command("iptables", "-t", "nat", "-S").CombinedOutput()
@@ -277,31 +523,44 @@ func (pf *PortForwarder) reconcileStaleRules() {
 		log.Printf("  Warning: could not enumerate nat rules to clear stale Caddy targets: %v", err)
 		return
 	}
-	for _, args := range staleCaddyNATRules(string(out), pf.caddyIP) {
+	for _, args := range staleCaddyNATRules(string(out), pf.backends...) {
 		// #nosec G204 -- args are iptables rule specs read back from this host's
 		// own `iptables -t nat -S` output and re-issued verbatim as -D deletes;
 		// not external/user input.
 		if e := exec.Command("iptables", args...).Run(); e != nil {
 			log.Printf("  Warning: failed to delete stale Caddy rule (%v): %v", args, e)
 		} else {
-			log.Printf("  Cleared stale Caddy port-forward rule pointing away from %s: %v", pf.caddyIP, args)
+			log.Printf("  Cleared stale Caddy port-forward rule pointing away from %s: %v", strings.Join(pf.backends, ", "), args)
 		}
 	}
 }
 
-// staleCaddyNATRules scans `iptables -t nat -S` output and returns the argument
-// lists (ready to pass to `iptables`, with the leading `-A` turned into `-D`)
-// for Caddy port-forward rules that point at an IP other than currentIP:
+// caddyNATRule is one Caddy port-forward rule read back from
+// `iptables -t nat -S`.
+type caddyNATRule struct {
+	chain  string
+	dport  string   // "80" or "443" for DNAT rules; "" for the MASQUERADE rule
+	target string   // DNAT destination or MASQUERADE -d address, without port or prefix
+	fields []string // the rule spec after "-A", starting with the chain
+}
+
+// deleteArgs returns the arguments that delete r.
+func (r caddyNATRule) deleteArgs() []string {
+	return append([]string{"-t", "nat", "-D"}, r.fields...)
+}
+
+// caddyNATRules scans `iptables -t nat -S` output for Caddy port-forward
+// rules:
 //
 //   - PREROUTING/OUTPUT DNAT rules whose --dport is 80 or 443 (the dport gate
 //     keeps passthrough-route DNATs, which use other ports, untouched), and
-//   - the POSTROUTING MASQUERADE rule of the form `-d <ip> -j MASQUERADE`
+//   - POSTROUTING MASQUERADE rules of the form `-d <ip> -j MASQUERADE`
 //     with no -p/--dport (passthrough MASQUERADE rules carry -p/--dport, so
 //     they're left alone).
 //
 // Pure/string-only so it can be unit-tested without touching the host firewall.
-func staleCaddyNATRules(saveOutput, currentIP string) [][]string {
-	var dels [][]string
+func caddyNATRules(saveOutput string) []caddyNATRule {
+	var rules []caddyNATRule
 	for _, raw := range strings.Split(saveOutput, "\n") {
 		line := strings.TrimSpace(raw)
 		if !strings.HasPrefix(line, "-A ") {
@@ -334,20 +593,25 @@ func staleCaddyNATRules(saveOutput, currentIP string) [][]string {
 			}
 		}
 
-		stale := false
 		switch {
 		case (chain == "PREROUTING" || chain == "OUTPUT") && toIP != "" && (dport == "80" || dport == "443"):
-			stale = toIP != currentIP
-		case chain == "POSTROUTING" && isMasq && dport == "":
-			stale = dstIP != "" && dstIP != currentIP
-		}
-		if !stale {
-			continue
+			rules = append(rules, caddyNATRule{chain: chain, dport: dport, target: toIP, fields: fields[1:]})
+		case chain == "POSTROUTING" && isMasq && dport == "" && dstIP != "":
+			rules = append(rules, caddyNATRule{chain: chain, target: dstIP, fields: fields[1:]})
 		}
+	}
+	return rules
+}
 
-		// Re-issue the exact rule spec as a delete: -t nat -D <chain> <rest>.
-		del := append([]string{"-t", "nat", "-D"}, fields[1:]...)
-		dels = append(dels, del)
+// staleCaddyNATRules returns the argument lists (ready to pass to
+// `iptables`, with the leading `-A` turned into `-D`) for the Caddy
+// port-forward rules in saveOutput that point at an IP outside current.
+func staleCaddyNATRules(saveOutput string, current ...string) [][]string {
+	var dels [][]string
+	for _, r := range caddyNATRules(saveOutput) {
+		if !slices.Contains(current, r.target) {
+			dels = append(dels, r.deleteArgs())
+		}
 	}
 	return dels
 }
@@ -393,11 +657,58 @@ type PassthroughRoute struct {
 	ContainerName string
 	Description   string
 	Active        bool
+
+	RouteOptions
+}
+
+// RouteOptions are the optional knobs of a passthrough route's rules.
+type RouteOptions struct {
+	// InInterface restricts the DNAT rule to traffic arriving on this host
+	// interface (e.g. "eth0"), so a multi-homed host doesn't forward its
+	// management network too. Empty matches any interface.
+	InInterface string
+	// SNATSource rewrites forwarded traffic's source to this address with
+	// an SNAT rule instead of MASQUERADE, for targets that need a stable
+	// source (some UDP protocols reply to where packets came from). Empty
+	// uses MASQUERADE.
+	SNATSource string
+	// AllowSources restricts the route to clients in these CIDRs: one DNAT
+	// rule per CIDR (`-s <cidr>`) instead of one open rule, so other
+	// sources never reach the target. Empty leaves the route open.
+	AllowSources []string
+	// Container is the box the route forwards to, recorded in the rules'
+	// comment tag so the orphan sweeper can tell when the box is gone.
+	// Empty for routes to a bare IP.
+	Container string
+
+	// tagged is set on routes read back from iptables whose rules carry the
+	// comment tag. Rules installed before tagging are removed by their
+	// untagged spec.
+	tagged bool
+}
+
+// Equal reports whether o and p install the same rules.
+func (o RouteOptions) Equal(p RouteOptions) bool {
+	return o.InInterface == p.InInterface && o.SNATSource == p.SNATSource &&
+		slices.Equal(o.AllowSources, p.AllowSources) && o.Container == p.Container
+}
+
+// tag is the comment match on the route's rules: the installed one for a
+// route read back from iptables (none if it predates tagging), the current
+// one otherwise.
+func (o RouteOptions) tag(installed bool) []string {
+	if installed && !o.tagged {
+		return nil
+	}
+	return []string{"-m", "comment", "--comment", ruleComment(o.Container)}
 }
 
 // PassthroughManager manages TCP/UDP passthrough routes via iptables
 type PassthroughManager struct {
 	networkCIDR string // Container network CIDR (e.g., "10.0.3.0/24")
+	// run replaces exec for iptables/sysctl calls in tests; nil runs the
+	// real binaries.
+	run func(name string, args ...string) ([]byte, error)
 }
 
 // NewPassthroughManager creates a new passthrough manager
@@ -407,91 +718,136 @@ func NewPassthroughManager(networkCIDR string) *PassthroughManager {
 	}
 }
 
-// ListRoutes returns all passthrough routes from iptables PREROUTING chain
+// ListRoutes returns all passthrough routes installed in iptables: the
+// PREROUTING DNAT rules outside the Caddy ports (source-restricted routes
+// have one per allowed CIDR), each joined with the POSTROUTING rule for its
+// target to recover the SNAT source.
 func (pm *PassthroughManager) ListRoutes() ([]PassthroughRoute, error) {
-	var routes []PassthroughRoute
-
-	// List NAT PREROUTING rules
-	cmd := exec.Command("iptables", "-t", "nat", "-L", "PREROUTING", "-n", "--line-numbers")
-	output, err := cmd.CombinedOutput()
+	output, err := pm.command("iptables", "-t", "nat", "-S")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list iptables rules: %w", err)
 	}
-
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		route := pm.parsePassthroughRule(line)
-		if route != nil {
-			routes = append(routes, *route)
-		}
-	}
-
-	return routes, nil
+	return parsePassthroughRules(string(output)), nil
 }
 
-// parsePassthroughRule parses an iptables rule line to extract passthrough route info
-// Example line: "1    DNAT       tcp  --  0.0.0.0/0            0.0.0.0/0            tcp dpt:50051 to:10.0.3.150:50051"
-func (pm *PassthroughManager) parsePassthroughRule(line string) *PassthroughRoute {
-	// Skip header lines and empty lines
-	if !strings.Contains(line, "DNAT") || !strings.Contains(line, "dpt:") {
-		return nil
-	}
-
-	// Skip Caddy port forwarding rules (ports 80 and 443)
-	if strings.Contains(line, "dpt:80 ") || strings.Contains(line, "dpt:443 ") {
-		return nil
-	}
+// natRuleFields is the subset of an `iptables -S` rule passthrough cares
+// about.
+type natRuleFields struct {
+	chain, proto, inIface, source, dest, dport, target, toDest, toSource, comment string
+}
 
-	fields := strings.Fields(line)
-	if len(fields) < 7 {
-		return nil
+func parseNATRule(line string) (natRuleFields, bool) {
+	fields := strings.Fields(strings.TrimSpace(line))
+	if len(fields) < 2 || fields[0] != "-A" {
+		return natRuleFields{}, false
 	}
-
-	route := &PassthroughRoute{
-		Active: true,
+	r := natRuleFields{chain: fields[1]}
+	for i := 2; i+1 < len(fields); i++ {
+		if fields[i-1] == "!" {
+			continue // negated matches (`! -s <network>`) aren't route fields
+		}
+		switch fields[i] {
+		case "-p":
+			r.proto = fields[i+1]
+		case "-i":
+			r.inIface = fields[i+1]
+		case "-s":
+			r.source = fields[i+1]
+		case "-d":
+			r.dest = stripCIDR(fields[i+1])
+		case "--dport":
+			r.dport = fields[i+1]
+		case "-j":
+			r.target = fields[i+1]
+		case "--to-destination":
+			r.toDest = fields[i+1]
+		case "--to-source":
+			r.toSource = fields[i+1]
+		case "--comment":
+			r.comment = unquoteComment(fields[i+1])
+		}
 	}
+	return r, true
+}
 
-	// Parse protocol
-	for _, field := range fields {
-		if field == "tcp" || field == "udp" {
-			route.Protocol = field
-			break
+// parsePassthroughRules extracts passthrough routes from `iptables -t nat
+// -S` output.
+func parsePassthroughRules(saveOutput string) []PassthroughRoute {
+	var rules []natRuleFields
+	for _, line := range strings.Split(saveOutput, "\n") {
+		if r, ok := parseNATRule(line); ok {
+			rules = append(rules, r)
 		}
 	}
 
-	// Parse external port (dpt:PORT)
-	for _, field := range fields {
-		if strings.HasPrefix(field, "dpt:") {
-			port := strings.TrimPrefix(field, "dpt:")
-			if _, err := fmt.Sscanf(port, "%d", &route.ExternalPort); err != nil {
-				log.Printf("  failed to parse external port %q: %v", port, err)
+	var routes []PassthroughRoute
+	byPort := map[string]int{} // "proto/dport" -> index in routes
+	for _, r := range rules {
+		if r.chain != "PREROUTING" || r.target != "DNAT" || (r.proto != "tcp" && r.proto != "udp") {
+			continue
+		}
+		// Skip Caddy port forwarding rules (ports 80 and 443)
+		if r.dport == "80" || r.dport == "443" {
+			continue
+		}
+		externalPort, err := strconv.Atoi(r.dport)
+		if err != nil {
+			continue
+		}
+		targetIP, portStr, ok := strings.Cut(r.toDest, ":")
+		if !ok {
+			continue
+		}
+		key := r.proto + "/" + r.dport
+		if i, seen := byPort[key]; seen {
+			// Another allowed source of a restricted route.
+			if r.source != "" {
+				routes[i].AllowSources = append(routes[i].AllowSources, r.source)
 			}
+			continue
 		}
-	}
-
-	// Parse target (to:IP:PORT)
-	for _, field := range fields {
-		if strings.HasPrefix(field, "to:") {
-			target := strings.TrimPrefix(field, "to:")
-			parts := strings.Split(target, ":")
-			if len(parts) == 2 {
-				route.TargetIP = parts[0]
-				if _, err := fmt.Sscanf(parts[1], "%d", &route.TargetPort); err != nil {
-					log.Printf("  failed to parse target port %q: %v", parts[1], err)
-				}
+		targetPort, err := strconv.Atoi(portStr)
+		if err != nil {
+			log.Printf("  failed to parse target port %q: %v", portStr, err)
+			continue
+		}
+		container, tagged := parseRuleComment(r.comment)
+		route := PassthroughRoute{
+			ExternalPort: externalPort,
+			TargetIP:     targetIP,
+			TargetPort:   targetPort,
+			Protocol:     r.proto,
+			Active:       true,
+			RouteOptions: RouteOptions{InInterface: r.inIface, Container: container, tagged: tagged},
+		}
+		if r.source != "" {
+			route.AllowSources = []string{r.source}
+		}
+		for _, post := range rules {
+			if post.chain == "POSTROUTING" && post.target == "SNAT" && post.proto == r.proto &&
+				post.dest == targetIP && post.dport == portStr {
+				route.SNATSource, _, _ = strings.Cut(post.toSource, ":")
+				break
 			}
 		}
+		byPort[key] = len(routes)
+		routes = append(routes, route)
 	}
-
-	if route.ExternalPort == 0 || route.TargetIP == "" {
-		return nil
-	}
-
-	return route
+	return routes
 }
 
-// AddRoute adds a new passthrough route via iptables
+// AddRoute adds a new passthrough route with default options: DNAT on any
+// interface, MASQUERADE for return traffic.
 func (pm *PassthroughManager) AddRoute(externalPort int, targetIP string, targetPort int, protocol string) error {
+	return pm.AddRouteWithOptions(externalPort, targetIP, targetPort, protocol, RouteOptions{})
+}
+
+// AddRouteWithOptions adds a new passthrough route via iptables: its
+// PREROUTING DNAT rule(s) and, unless another route to the same target already
+// installed one, a POSTROUTING MASQUERADE (or SNAT) rule for return
+// traffic. The rules are applied as a unit — if one fails the ones before it
+// are removed again, and the error says what was rolled back.
+func (pm *PassthroughManager) AddRouteWithOptions(externalPort int, targetIP string, targetPort int, protocol string, opts RouteOptions) error {
 	if protocol == "" {
 		protocol = "tcp"
 	}
@@ -505,34 +861,26 @@ func (pm *PassthroughManager) AddRoute(externalPort int, targetIP string, target
 	}
 
 	// Enable IP forwarding
-	cmd := exec.Command("sysctl", "-w", "net.ipv4.ip_forward=1")
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := pm.command("sysctl", "-w", "net.ipv4.ip_forward=1"); err != nil {
 		return fmt.Errorf("failed to enable IP forwarding: %w, output: %s", err, string(output))
 	}
 
-	// Add PREROUTING DNAT rule
-	// Exclude traffic from container network to allow containers to use the same port externally
-	cmd = exec.Command("iptables", "-t", "nat", "-A", "PREROUTING",
-		"-p", protocol,
-		"!", "-s", pm.networkCIDR,
-		"--dport", fmt.Sprintf("%d", externalPort),
-		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", targetIP, targetPort))
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to add DNAT rule: %w, output: %s", err, string(output))
+	txn := &natTxn{pm: pm}
+
+	// Add PREROUTING DNAT rule(s)
+	for _, c := range pm.dnatChanges("-A", externalPort, targetIP, targetPort, protocol, opts, opts.tag(false)) {
+		if err := txn.apply(c); err != nil {
+			return txn.abort(err)
+		}
 	}
 
-	// Add POSTROUTING MASQUERADE rule for return traffic
-	// Check if rule already exists
-	checkCmd := exec.Command("iptables", "-t", "nat", "-C", "POSTROUTING",
-		"-p", protocol, "-d", targetIP, "--dport", fmt.Sprintf("%d", targetPort),
-		"-j", "MASQUERADE")
-	if checkCmd.Run() != nil {
-		// Rule doesn't exist, add it
-		cmd = exec.Command("iptables", "-t", "nat", "-A", "POSTROUTING",
-			"-p", protocol, "-d", targetIP, "--dport", fmt.Sprintf("%d", targetPort),
-			"-j", "MASQUERADE")
-		if output, err := cmd.CombinedOutput(); err != nil {
-			return fmt.Errorf("failed to add MASQUERADE rule: %w, output: %s", err, string(output))
+	// Add POSTROUTING rule for return traffic, unless it's already there
+	// (shared with another route to the same target).
+	post := postroutingSpec(targetIP, targetPort, protocol, opts.SNATSource, opts.tag(false))
+	if _, err := pm.command("iptables", append([]string{"-t", "nat", "-C"}, post...)...); err != nil {
+		if err := txn.apply(natChange{op: "-A", spec: post,
+			desc: postroutingDesc(targetIP, targetPort, protocol, opts.SNATSource)}); err != nil {
+			return txn.abort(err)
 		}
 	}
 
@@ -540,17 +888,76 @@ func (pm *PassthroughManager) AddRoute(externalPort int, targetIP string, target
 	return nil
 }
 
-// routeExists checks if a passthrough route already exists
+// dnatChanges are the PREROUTING rule changes (op "-A" or "-D") for a
+// route's DNAT rules: one per allowed source CIDR, or a single rule open to
+// everything outside the container network. Each carries tag.
+func (pm *PassthroughManager) dnatChanges(op string, externalPort int, targetIP string, targetPort int, protocol string, opts RouteOptions, tag []string) []natChange {
+	desc := fmt.Sprintf("DNAT rule %s/%d -> %s:%d", protocol, externalPort, targetIP, targetPort)
+	if len(opts.AllowSources) == 0 {
+		// Exclude traffic from container network to allow containers to use the same port externally
+		return []natChange{{op: op, desc: desc,
+			spec: pm.dnatSpec(externalPort, targetIP, targetPort, protocol, opts.InInterface, tag, "!", "-s", pm.networkCIDR)}}
+	}
+	changes := make([]natChange, 0, len(opts.AllowSources))
+	for _, cidr := range opts.AllowSources {
+		changes = append(changes, natChange{op: op, desc: desc + " from " + cidr,
+			spec: pm.dnatSpec(externalPort, targetIP, targetPort, protocol, opts.InInterface, tag, "-s", cidr)})
+	}
+	return changes
+}
+
+// dnatSpec is a PREROUTING rule forwarding externalPort to the target for
+// traffic matching source, optionally only when arriving on inIface.
+func (pm *PassthroughManager) dnatSpec(externalPort int, targetIP string, targetPort int, protocol, inIface string, tag []string, source ...string) []string {
+	spec := []string{"PREROUTING"}
+	if inIface != "" {
+		spec = append(spec, "-i", inIface)
+	}
+	spec = append(spec, "-p", protocol)
+	spec = append(spec, source...)
+	spec = append(spec, "--dport", strconv.Itoa(externalPort))
+	spec = append(spec, tag...)
+	return append(spec,
+		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", targetIP, targetPort))
+}
+
+// postroutingSpec is the POSTROUTING rule rewriting the source of forwarded
+// traffic to the target: SNAT to snatSource when set, else MASQUERADE.
+func postroutingSpec(targetIP string, targetPort int, protocol, snatSource string, tag []string) []string {
+	spec := []string{"POSTROUTING",
+		"-p", protocol, "-d", targetIP, "--dport", strconv.Itoa(targetPort)}
+	spec = append(spec, tag...)
+	if snatSource != "" {
+		spec = append(spec, "-j", "SNAT", "--to-source", snatSource)
+	} else {
+		spec = append(spec, "-j", "MASQUERADE")
+	}
+	return spec
+}
+
+func postroutingDesc(targetIP string, targetPort int, protocol, snatSource string) string {
+	if snatSource != "" {
+		return fmt.Sprintf("SNAT rule %s -> %s:%d (source %s)", protocol, targetIP, targetPort, snatSource)
+	}
+	return fmt.Sprintf("MASQUERADE rule %s -> %s:%d", protocol, targetIP, targetPort)
+}
+
+// routeExists checks if a passthrough route already exists, whatever its
+// options.
 func (pm *PassthroughManager) routeExists(externalPort int, protocol string) bool {
-	cmd := exec.Command("iptables", "-t", "nat", "-C", "PREROUTING",
-		"-p", protocol,
-		"!", "-s", pm.networkCIDR,
-		"--dport", fmt.Sprintf("%d", externalPort),
-		"-j", "DNAT")
-	return cmd.Run() == nil
+	routes, err := pm.ListRoutes()
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(routes, func(r PassthroughRoute) bool {
+		return r.ExternalPort == externalPort && r.Protocol == protocol
+	})
 }
 
-// RemoveRoute removes a passthrough route
+// RemoveRoute removes a passthrough route: its DNAT rule(s), then its
+// MASQUERADE rule unless another route still forwards to the same target.
+// If a later removal fails the earlier rules are put back, so the route is
+// either fully removed or left working.
 func (pm *PassthroughManager) RemoveRoute(externalPort int, protocol string) error {
 	if protocol == "" {
 		protocol = "tcp"
@@ -565,36 +972,47 @@ func (pm *PassthroughManager) RemoveRoute(externalPort int, protocol string) err
 		return err
 	}
 
-	var targetIP string
-	var targetPort int
-	for _, route := range routes {
-		if route.ExternalPort == externalPort && route.Protocol == protocol {
-			targetIP = route.TargetIP
-			targetPort = route.TargetPort
+	var found *PassthroughRoute
+	for i := range routes {
+		if routes[i].ExternalPort == externalPort && routes[i].Protocol == protocol {
+			found = &routes[i]
 			break
 		}
 	}
 
-	if targetIP == "" {
+	if found == nil {
 		return fmt.Errorf("passthrough route for port %d/%s not found", externalPort, protocol)
 	}
+	targetIP, targetPort := found.TargetIP, found.TargetPort
+	postShared := false
+	for _, route := range routes {
+		if route.ExternalPort != externalPort && route.Protocol == protocol &&
+			route.TargetIP == targetIP && route.TargetPort == targetPort && route.SNATSource == found.SNATSource &&
+			route.Container == found.Container && route.tagged == found.tagged {
+			postShared = true
+		}
+	}
 
-	// Remove PREROUTING DNAT rule
-	cmd := exec.Command("iptables", "-t", "nat", "-D", "PREROUTING",
-		"-p", protocol,
-		"!", "-s", pm.networkCIDR,
-		"--dport", fmt.Sprintf("%d", externalPort),
-		"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", targetIP, targetPort))
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to remove DNAT rule: %w, output: %s", err, string(output))
+	txn := &natTxn{pm: pm}
+
+	// Remove PREROUTING DNAT rule(s)
+	tag := found.tag(true)
+	for _, c := range pm.dnatChanges("-D", externalPort, targetIP, targetPort, protocol, found.RouteOptions, tag) {
+		if err := txn.apply(c); err != nil {
+			return txn.abort(err)
+		}
 	}
 
-	// Remove POSTROUTING MASQUERADE rule
-	cmd = exec.Command("iptables", "-t", "nat", "-D", "POSTROUTING",
-		"-p", protocol, "-d", targetIP, "--dport", fmt.Sprintf("%d", targetPort),
-		"-j", "MASQUERADE")
-	if err := cmd.Run(); err != nil {
-		log.Printf("  Passthrough MASQUERADE rule may not exist or be shared (ignored): %v", err)
+	// Remove the POSTROUTING rule, if present and not still needed by
+	// another route to the same target.
+	post := postroutingSpec(targetIP, targetPort, protocol, found.SNATSource, tag)
+	if postShared {
+		log.Printf("  Passthrough POSTROUTING rule for %s:%d kept: shared with another route", targetIP, targetPort)
+	} else if _, err := pm.command("iptables", append([]string{"-t", "nat", "-C"}, post...)...); err != nil {
+		log.Printf("  Passthrough POSTROUTING rule for %s:%d not present (ignored)", targetIP, targetPort)
+	} else if err := txn.apply(natChange{op: "-D", spec: post,
+		desc: postroutingDesc(targetIP, targetPort, protocol, found.SNATSource)}); err != nil {
+		return txn.abort(err)
 	}
 
 	log.Printf("  Passthrough route removed successfully")
@@ -1,8 +1,11 @@
 package network
 
 import (
+	"errors"
+	"net"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestStaleCaddyNATRules(t *testing.T) {
@@ -60,3 +63,124 @@ func TestStaleCaddyNATRules_LeavesPassthroughAlone(t *testing.T) {
 		t.Errorf("expected passthrough rules to be left alone, got %v", got)
 	}
 }
+
+func TestStaleCaddyNATRules_KeepsEveryBackend(t *testing.T) {
+	// Two HA backends (10.0.3.50, 10.0.3.51) balanced with the statistic
+	// match; only the rules for the retired 10.0.3.111 are stale.
+	save := `-A PREROUTING -p tcp -m tcp ! -s 10.0.3.0/24 --dport 443 -m statistic --mode nth --every 2 --packet 0 -j DNAT --to-destination 10.0.3.50:443
+-A PREROUTING -p tcp -m tcp ! -s 10.0.3.0/24 --dport 443 -j DNAT --to-destination 10.0.3.51:443
+-A PREROUTING -p tcp -m tcp ! -s 10.0.3.0/24 --dport 443 -j DNAT --to-destination 10.0.3.111:443
+-A POSTROUTING -d 10.0.3.50/32 -j MASQUERADE
+-A POSTROUTING -d 10.0.3.51/32 -j MASQUERADE
+-A POSTROUTING -d 10.0.3.111/32 -j MASQUERADE`
+
+	got := staleCaddyNATRules(save, "10.0.3.50", "10.0.3.51")
+	want := [][]string{
+		{"-t", "nat", "-D", "PREROUTING", "-p", "tcp", "-m", "tcp", "!", "-s", "10.0.3.0/24", "--dport", "443", "-j", "DNAT", "--to-destination", "10.0.3.111:443"},
+		{"-t", "nat", "-D", "POSTROUTING", "-d", "10.0.3.111/32", "-j", "MASQUERADE"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("staleCaddyNATRules mismatch.\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestDNATRuleSpecs_RoundRobin(t *testing.T) {
+	pf := NewPortForwarderWithBackends([]string{"10.0.3.50", "10.0.3.51", "10.0.3.52"}, "", BalanceRoundRobin)
+
+	got := pf.dnatRuleSpecs("PREROUTING", 443, pf.Backends())
+	match := []string{"PREROUTING", "-p", "tcp", "!", "-s", "10.0.3.0/24", "--dport", "443"}
+	want := [][]string{
+		append(append([]string{}, match...), "-m", "statistic", "--mode", "nth", "--every", "3", "--packet", "0", "-j", "DNAT", "--to-destination", "10.0.3.50:443"),
+		append(append([]string{}, match...), "-m", "statistic", "--mode", "nth", "--every", "2", "--packet", "0", "-j", "DNAT", "--to-destination", "10.0.3.51:443"),
+		append(append([]string{}, match...), "-j", "DNAT", "--to-destination", "10.0.3.52:443"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dnatRuleSpecs mismatch.\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestDNATRuleSpecs_SingleBackendUnchanged(t *testing.T) {
+	// One backend must produce exactly the rule older releases installed,
+	// so upgrading doesn't churn the chain.
+	pf := NewPortForwarder("10.0.3.50")
+
+	got := pf.dnatRuleSpecs("OUTPUT", 80, pf.Backends())
+	want := [][]string{{"OUTPUT", "-p", "tcp", "-d", "127.0.0.0/8", "--dport", "80", "-j", "DNAT", "--to-destination", "10.0.3.50:80"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dnatRuleSpecs mismatch.\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestActiveBackends(t *testing.T) {
+	backends := []string{"10.0.3.50", "10.0.3.51", "10.0.3.52"}
+	tests := []struct {
+		name    string
+		mode    BalanceMode
+		healthy map[string]bool
+		want    []string
+	}{
+		{"round-robin all up", BalanceRoundRobin, map[string]bool{"10.0.3.50": true, "10.0.3.51": true, "10.0.3.52": true}, backends},
+		{"round-robin drops dead backend", BalanceRoundRobin, map[string]bool{"10.0.3.50": true, "10.0.3.52": true}, []string{"10.0.3.50", "10.0.3.52"}},
+		{"failover uses primary", BalanceFailover, map[string]bool{"10.0.3.50": true, "10.0.3.51": true}, []string{"10.0.3.50"}},
+		{"failover promotes next healthy", BalanceFailover, map[string]bool{"10.0.3.51": true, "10.0.3.52": true}, []string{"10.0.3.51"}},
+		{"all down keeps every backend", BalanceRoundRobin, map[string]bool{}, backends},
+		{"failover all down keeps primary", BalanceFailover, map[string]bool{}, []string{"10.0.3.50"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf := NewPortForwarderWithBackends(backends, "", tt.mode)
+			pf.healthy = tt.healthy
+			if got := pf.activeBackends(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("activeBackends() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeBackends(t *testing.T) {
+	pf := NewPortForwarderWithBackends([]string{"10.0.3.50", "10.0.3.51"}, "", BalanceRoundRobin)
+	var dialed []string
+	pf.dial = func(network, address string, timeout time.Duration) (net.Conn, error) {
+		dialed = append(dialed, address)
+		if address == "10.0.3.51:443" {
+			return nil, errors.New("connection refused")
+		}
+		client, server := net.Pipe()
+		_ = server.Close()
+		return client, nil
+	}
+
+	got := pf.probeBackends()
+	want := map[string]bool{"10.0.3.50": true, "10.0.3.51": false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("probeBackends() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(dialed, []string{"10.0.3.50:443", "10.0.3.51:443"}) {
+		t.Errorf("dialed %v", dialed)
+	}
+}
+
+func TestNewPortForwarderWithBackends_Dedupes(t *testing.T) {
+	pf := NewPortForwarderWithBackends([]string{" 10.0.3.50", "", "10.0.3.51", "10.0.3.50"}, "", "")
+	if got := pf.Backends(); !reflect.DeepEqual(got, []string{"10.0.3.50", "10.0.3.51"}) {
+		t.Errorf("Backends() = %v", got)
+	}
+	if pf.networkCIDR != "10.0.3.0/24" {
+		t.Errorf("networkCIDR = %q, want derived from the first backend", pf.networkCIDR)
+	}
+	if pf.mode != BalanceRoundRobin {
+		t.Errorf("mode = %q, want round-robin default", pf.mode)
+	}
+}
+
+func TestParseBalanceMode(t *testing.T) {
+	for in, want := range map[string]BalanceMode{"": BalanceRoundRobin, "round-robin": BalanceRoundRobin, "Failover": BalanceFailover} {
+		got, err := ParseBalanceMode(in)
+		if err != nil || got != want {
+			t.Errorf("ParseBalanceMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseBalanceMode("random"); err == nil {
+		t.Error("ParseBalanceMode(\"random\") should fail")
+	}
+}
@@ -0,0 +1,41 @@
+package network
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ReservedPorts are host ports a passthrough route may not claim, each with
+// the reasons it is reserved. Forwarding one of them into a container cuts
+// the daemon off from its own clients (including the one making the
+// change), so unlike a PortConflictError no force flag overrides it.
+type ReservedPorts map[int][]string
+
+// Reserve marks port as reserved for reason. Ports outside 1-65535 (an
+// unset listener) are ignored.
+func (r ReservedPorts) Reserve(port int, reason string) {
+	if port <= 0 || port > 65535 || slices.Contains(r[port], reason) {
+		return
+	}
+	r[port] = append(r[port], reason)
+}
+
+// Check returns a *ReservedPortError when port is reserved. A nil set
+// reserves nothing.
+func (r ReservedPorts) Check(port int) error {
+	if reasons := r[port]; len(reasons) > 0 {
+		return &ReservedPortError{Port: port, Reasons: reasons}
+	}
+	return nil
+}
+
+// ReservedPortError reports a passthrough route on a reserved port.
+type ReservedPortError struct {
+	Port    int
+	Reasons []string
+}
+
+func (e *ReservedPortError) Error() string {
+	return fmt.Sprintf("port %d is reserved by the daemon: %s", e.Port, strings.Join(e.Reasons, "; "))
+}
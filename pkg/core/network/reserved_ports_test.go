@@ -0,0 +1,34 @@
+package network
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReservedPorts(t *testing.T) {
+	r := ReservedPorts{}
+	r.Reserve(22, "host SSH")
+	r.Reserve(22, "--reserved-ports")
+	r.Reserve(22, "host SSH") // duplicate reason
+	r.Reserve(0, "unset listener")
+
+	var reserved *ReservedPortError
+	if err := r.Check(22); !errors.As(err, &reserved) {
+		t.Fatalf("Check(22) = %v, want *ReservedPortError", err)
+	}
+	if want := []string{"host SSH", "--reserved-ports"}; !reflect.DeepEqual(reserved.Reasons, want) {
+		t.Errorf("reasons = %v, want %v", reserved.Reasons, want)
+	}
+	if err := r.Check(2222); err != nil {
+		t.Errorf("Check(2222) = %v, want nil", err)
+	}
+	if len(r) != 1 {
+		t.Errorf("out-of-range port was reserved: %v", r)
+	}
+
+	var none ReservedPorts
+	if err := none.Check(22); err != nil {
+		t.Errorf("nil set: Check(22) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,130 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Special RouteOptions request values resolved by ResolveRouteOptions.
+const (
+	// InInterfaceAuto binds the DNAT rule to the default-route interface.
+	InInterfaceAuto = "auto"
+	// SNATSourceHost uses this host's address on the container network.
+	SNATSourceHost = "host"
+)
+
+// ifaceNameRe matches a Linux interface name (IFNAMSIZ-1 = 15 chars).
+var ifaceNameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,15}$`)
+
+// ResolveRouteOptions turns requested route options into the concrete values
+// stored and installed: "auto" becomes the default-route interface, "host"
+// this host's address on the container network, and allowed sources are
+// written the way `iptables -S` prints them (a bare IP as /32). Resolving up
+// front keeps the registry and iptables in exact agreement, so ListRoutes
+// round-trips what was stored.
+func (pm *PassthroughManager) ResolveRouteOptions(inIface, snatSource string, allowSources []string) (RouteOptions, error) {
+	var opts RouteOptions
+	switch inIface {
+	case "":
+	case InInterfaceAuto:
+		iface, err := DefaultRouteInterface()
+		if err != nil {
+			return opts, err
+		}
+		opts.InInterface = iface
+	default:
+		if !ifaceNameRe.MatchString(inIface) {
+			return opts, fmt.Errorf("invalid interface name %q", inIface)
+		}
+		opts.InInterface = inIface
+	}
+
+	switch snatSource {
+	case "":
+	case SNATSourceHost:
+		ip, err := hostAddressIn(pm.networkCIDR)
+		if err != nil {
+			return opts, err
+		}
+		opts.SNATSource = ip
+	default:
+		if ip := net.ParseIP(snatSource); ip == nil || ip.To4() == nil {
+			return opts, fmt.Errorf("invalid SNAT source %q: want an IPv4 address or %q", snatSource, SNATSourceHost)
+		}
+		opts.SNATSource = snatSource
+	}
+
+	for _, src := range allowSources {
+		cidr, err := canonicalSourceCIDR(src)
+		if err != nil {
+			return opts, err
+		}
+		if !slices.Contains(opts.AllowSources, cidr) {
+			opts.AllowSources = append(opts.AllowSources, cidr)
+		}
+	}
+	return opts, nil
+}
+
+// canonicalSourceCIDR parses an IPv4 address or CIDR into network/prefix
+// form with the host bits cleared, as iptables stores it.
+func canonicalSourceCIDR(s string) (string, error) {
+	cidr := strings.TrimSpace(s)
+	if !strings.Contains(cidr, "/") {
+		cidr += "/32"
+	}
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return "", fmt.Errorf("invalid allowed source %q: want an IPv4 address or CIDR", s)
+	}
+	return network.String(), nil
+}
+
+// DefaultRouteInterface returns the interface of the host's IPv4 default
+// route, from /proc/net/route.
+func DefaultRouteInterface() (string, error) {
+	data, err := os.ReadFile("/proc/net/route")
+	if err != nil {
+		return "", fmt.Errorf("failed to read routing table: %w", err)
+	}
+	iface := defaultRouteInterface(string(data))
+	if iface == "" {
+		return "", fmt.Errorf("no IPv4 default route found")
+	}
+	return iface, nil
+}
+
+// defaultRouteInterface returns the interface of the first default route
+// (destination and mask 00000000) in a /proc/net/route table.
+func defaultRouteInterface(table string) string {
+	for _, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 8 && fields[1] == "00000000" && fields[7] == "00000000" {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// hostAddressIn returns this host's IPv4 address inside cidr — on a
+// container bridge, the gateway address containers see.
+func hostAddressIn(cidr string) (string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid container network %q: %w", cidr, err)
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "", fmt.Errorf("failed to list host addresses: %w", err)
+	}
+	for _, a := range addrs {
+		if ipNet, ok := a.(*net.IPNet); ok && ipNet.IP.To4() != nil && network.Contains(ipNet.IP) {
+			return ipNet.IP.String(), nil
+		}
+	}
+	return "", fmt.Errorf("host has no address in container network %s", cidr)
+}
@@ -0,0 +1,387 @@
+package network
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxBandwidthMbps bounds a single direction's limit (100 Gbit/s); anything
+// above is a typo, not a cap.
+const MaxBandwidthMbps = 100_000
+
+// LimitOptions annotates a bandwidth limit; the zero value is a permanent
+// manual limit.
+type LimitOptions struct {
+	// Reason is recorded on the limit; empty means "manual".
+	Reason    string
+	CreatedBy string
+
+	// Duration, when positive, makes the limit temporary: the shaper
+	// lifts it that long after it is set.
+	Duration time.Duration
+}
+
+// VethResolver returns the host-side veth of a container's primary NIC, or
+// "" when the container isn't running and so has none.
+type VethResolver func(containerName string) (string, error)
+
+// TrafficShaper enforces bandwidth limits with tc on each container's
+// host-side veth. The veth's egress is the container's ingress, so
+// downloads are shaped by an HTB class at the root; the container's
+// uploads arrive on the veth's ingress, where tc can only police, so they
+// are capped by a drop policer.
+//
+// The store is the source of truth. A veth lives only as long as the
+// container runs and is renamed on every start, so Sync re-applies limits
+// whenever the resolved veth differs from the one last shaped.
+type TrafficShaper struct {
+	store BandwidthStore
+	veth  VethResolver
+	// run replaces exec for tc calls in tests; nil runs the real binary.
+	run func(name string, args ...string) ([]byte, error)
+	now func() time.Time
+
+	mu      sync.Mutex
+	applied map[string]shapedVeth // container name -> what tc carries now
+}
+
+// shapedVeth is the limit tc currently enforces on a container's veth.
+type shapedVeth struct {
+	veth            string
+	ingress, egress int
+}
+
+// NewTrafficShaper creates a shaper backed by store, resolving veths with veth.
+func NewTrafficShaper(store BandwidthStore, veth VethResolver) *TrafficShaper {
+	return &TrafficShaper{
+		store:   store,
+		veth:    veth,
+		now:     time.Now,
+		applied: make(map[string]shapedVeth),
+	}
+}
+
+// SetLimit caps containerName at ingressMbps down and egressMbps up; zero
+// leaves a direction unlimited. A stopped container keeps the limit in the
+// store and gets it when it next starts.
+func (t *TrafficShaper) SetLimit(ctx context.Context, containerName string, ingressMbps, egressMbps int) (*BandwidthLimit, error) {
+	return t.SetLimitWithOptions(ctx, containerName, ingressMbps, egressMbps, LimitOptions{})
+}
+
+// SetLimitWithOptions is SetLimit with a reason, creator and optional expiry.
+func (t *TrafficShaper) SetLimitWithOptions(ctx context.Context, containerName string, ingressMbps, egressMbps int, opts LimitOptions) (*BandwidthLimit, error) {
+	if containerName == "" {
+		return nil, fmt.Errorf("container name is required")
+	}
+	if err := ValidateBandwidth(ingressMbps, egressMbps); err != nil {
+		return nil, err
+	}
+
+	limit := &BandwidthLimit{
+		ContainerName: containerName,
+		IngressMbps:   ingressMbps,
+		EgressMbps:    egressMbps,
+		Reason:        cmp.Or(opts.Reason, "manual"),
+		CreatedBy:     opts.CreatedBy,
+	}
+	if opts.Duration > 0 {
+		limit.ExpiresAt = t.now().Add(opts.Duration)
+	}
+	if err := t.store.Save(ctx, limit); err != nil {
+		return nil, err
+	}
+
+	if err := t.shape(limit); err != nil {
+		return limit, fmt.Errorf("limit saved but not applied: %w", err)
+	}
+	return limit, nil
+}
+
+// ValidateBandwidth checks a pair of per-direction caps: each within
+// 0..MaxBandwidthMbps and at least one of them set.
+func ValidateBandwidth(ingressMbps, egressMbps int) error {
+	for _, v := range []int{ingressMbps, egressMbps} {
+		if v < 0 || v > MaxBandwidthMbps {
+			return fmt.Errorf("bandwidth must be between 0 and %d Mbps, got %d", MaxBandwidthMbps, v)
+		}
+	}
+	if ingressMbps == 0 && egressMbps == 0 {
+		return fmt.Errorf("at least one of ingress or egress must be limited")
+	}
+	return nil
+}
+
+// ClearLimit removes containerName's limit from the store and its veth.
+// Returns ErrBandwidthLimitNotFound when there was none.
+func (t *TrafficShaper) ClearLimit(ctx context.Context, containerName string) error {
+	if err := t.store.Delete(ctx, containerName); err != nil {
+		return err
+	}
+	return t.unshape(containerName)
+}
+
+// GetLimit returns containerName's limit, or ErrBandwidthLimitNotFound
+// when it has none or its temporary limit has run out.
+func (t *TrafficShaper) GetLimit(ctx context.Context, containerName string) (*BandwidthLimit, error) {
+	limit, err := t.store.Get(ctx, containerName)
+	if err != nil {
+		return nil, err
+	}
+	if limit.Expired(t.now()) {
+		return nil, ErrBandwidthLimitNotFound
+	}
+	return limit, nil
+}
+
+// ListLimits returns the limits in force, leaving out expired ones the
+// next Sync will lift.
+func (t *TrafficShaper) ListLimits(ctx context.Context) ([]*BandwidthLimit, error) {
+	limits, err := t.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := t.now()
+	out := limits[:0]
+	for _, l := range limits {
+		if !l.Expired(now) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// Applied reports whether tc currently enforces containerName's limit, as
+// opposed to it waiting in the store for the container to start.
+func (t *TrafficShaper) Applied(containerName string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.applied[containerName]
+	return ok
+}
+
+// Reapply shapes containerName's new veth after a start without waiting
+// for the next Sync. No-op when the container has no limit.
+func (t *TrafficShaper) Reapply(ctx context.Context, containerName string) error {
+	limit, err := t.GetLimit(ctx, containerName)
+	if errors.Is(err, ErrBandwidthLimitNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	delete(t.applied, containerName)
+	t.mu.Unlock()
+	return t.shape(limit)
+}
+
+// Sync converges tc with the store: lifts expired limits, shapes veths
+// that are new or carry a stale limit, and unshapes containers whose
+// limit was removed behind the shaper's back.
+func (t *TrafficShaper) Sync(ctx context.Context) error {
+	limits, err := t.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := t.now()
+	want := make(map[string]bool, len(limits))
+	var failed int
+	for _, l := range limits {
+		if l.Expired(now) {
+			switch err := t.ClearLimit(ctx, l.ContainerName); {
+			case err == nil:
+				log.Printf("[shaper] lifted expired %s limit on %s", l.Reason, l.ContainerName)
+			case !errors.Is(err, ErrBandwidthLimitNotFound):
+				log.Printf("[shaper] failed to lift expired limit on %s: %v", l.ContainerName, err)
+				failed++
+			}
+			continue
+		}
+		want[l.ContainerName] = true
+		if err := t.shape(l); err != nil {
+			log.Printf("[shaper] failed to shape %s: %v", l.ContainerName, err)
+			failed++
+		}
+	}
+
+	t.mu.Lock()
+	var stale []string
+	for name := range t.applied {
+		if !want[name] {
+			stale = append(stale, name)
+		}
+	}
+	t.mu.Unlock()
+	for _, name := range stale {
+		if err := t.unshape(name); err != nil {
+			log.Printf("[shaper] failed to unshape %s: %v", name, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d bandwidth limit(s) failed to sync", failed)
+	}
+	return nil
+}
+
+// Watch runs Sync every interval until ctx is done.
+func (t *TrafficShaper) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Sync(ctx); err != nil {
+			log.Printf("[shaper] sync: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// shape puts limit on its container's current veth, unless that veth
+// already carries it. A container that isn't running is left for Sync.
+func (t *TrafficShaper) shape(limit *BandwidthLimit) error {
+	veth, err := t.veth(limit.ContainerName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve veth: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if veth == "" {
+		delete(t.applied, limit.ContainerName) // stopped; its veth is gone
+		return nil
+	}
+	want := shapedVeth{veth: veth, ingress: limit.IngressMbps, egress: limit.EgressMbps}
+	if t.applied[limit.ContainerName] == want {
+		return nil
+	}
+
+	t.clearVeth(veth)
+	for _, args := range tcShapeCommands(veth, limit.IngressMbps, limit.EgressMbps) {
+		if output, err := t.command("tc", args...); err != nil {
+			t.clearVeth(veth)
+			delete(t.applied, limit.ContainerName)
+			return fmt.Errorf("failed to run tc %s: %w, output: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+		}
+	}
+	t.applied[limit.ContainerName] = want
+	log.Printf("[shaper] %s (%s): ingress %s, egress %s", limit.ContainerName, veth, FormatMbps(limit.IngressMbps), FormatMbps(limit.EgressMbps))
+	return nil
+}
+
+// unshape removes the qdiscs shape installed for containerName, if any.
+func (t *TrafficShaper) unshape(containerName string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	shaped, ok := t.applied[containerName]
+	if !ok {
+		return nil
+	}
+	delete(t.applied, containerName)
+	veth, err := t.veth(containerName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve veth: %w", err)
+	}
+	if veth == shaped.veth {
+		t.clearVeth(veth)
+	}
+	return nil
+}
+
+// clearVeth deletes the root and ingress qdiscs. Either may be absent,
+// so errors are ignored.
+func (t *TrafficShaper) clearVeth(veth string) {
+	_, _ = t.command("tc", "qdisc", "del", "dev", veth, "root")
+	_, _ = t.command("tc", "qdisc", "del", "dev", veth, "ingress")
+}
+
+// command runs tc and returns its combined output.
+func (t *TrafficShaper) command(name string, args ...string) ([]byte, error) {
+	if t.run != nil {
+		return t.run(name, args...)
+	}
+	// #nosec G204 -- name is a fixed binary; args are a resolved veth name
+	// and validated rates.
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+// tcShapeCommands are the tc invocations that cap veth: an HTB class for
+// the container's ingress (the veth's egress) and a policer on the veth's
+// ingress for the container's egress.
+func tcShapeCommands(veth string, ingressMbps, egressMbps int) [][]string {
+	var cmds [][]string
+	if ingressMbps > 0 {
+		rate := strconv.Itoa(ingressMbps) + "mbit"
+		cmds = append(cmds,
+			[]string{"qdisc", "add", "dev", veth, "root", "handle", "1:", "htb", "default", "10"},
+			[]string{"class", "add", "dev", veth, "parent", "1:", "classid", "1:10", "htb", "rate", rate, "ceil", rate},
+		)
+	}
+	if egressMbps > 0 {
+		cmds = append(cmds,
+			[]string{"qdisc", "add", "dev", veth, "handle", "ffff:", "ingress"},
+			[]string{"filter", "add", "dev", veth, "parent", "ffff:", "protocol", "all", "prio", "1",
+				"u32", "match", "u32", "0", "0",
+				"police", "rate", strconv.Itoa(egressMbps) + "mbit", "burst", strconv.Itoa(policerBurst(egressMbps)), "drop"},
+		)
+	}
+	return cmds
+}
+
+// policerBurst is the policer's bucket in bytes: 100ms at the rate, and
+// never less than ten full-size frames so a slow limit still passes them.
+func policerBurst(mbps int) int {
+	return max(mbps*1_000_000/8/10, 10*1514)
+}
+
+// ParseMbps parses a bandwidth such as "50mbit", "50Mbps", "1gbit" or a
+// bare number of Mbit/s. "0" means unlimited.
+func ParseMbps(s string) (int, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	mult := 1
+	for _, u := range []struct {
+		suffix string
+		mult   int
+	}{{"gbit", 1000}, {"gbps", 1000}, {"g", 1000}, {"mbit", 1}, {"mbps", 1}, {"m", 1}} {
+		if strings.HasSuffix(v, u.suffix) {
+			v, mult = strings.TrimSuffix(v, u.suffix), u.mult
+			break
+		}
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid bandwidth %q (want e.g. 50mbit or 1gbit)", s)
+	}
+	if n > MaxBandwidthMbps || n*mult > MaxBandwidthMbps {
+		return 0, fmt.Errorf("bandwidth %q exceeds %d Mbps", s, MaxBandwidthMbps)
+	}
+	return n * mult, nil
+}
+
+// FormatMbps renders a limit for display; zero is "unlimited".
+func FormatMbps(mbps int) string {
+	switch {
+	case mbps == 0:
+		return "unlimited"
+	case mbps%1000 == 0:
+		return fmt.Sprintf("%dgbit", mbps/1000)
+	default:
+		return fmt.Sprintf("%dmbit", mbps)
+	}
+}
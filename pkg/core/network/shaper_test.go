@@ -0,0 +1,215 @@
+package network
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memBandwidthStore is an in-memory BandwidthStore.
+type memBandwidthStore map[string]*BandwidthLimit
+
+func (m memBandwidthStore) Save(_ context.Context, l *BandwidthLimit) error {
+	cp := *l
+	m[l.ContainerName] = &cp
+	return nil
+}
+
+func (m memBandwidthStore) Get(_ context.Context, name string) (*BandwidthLimit, error) {
+	l, ok := m[name]
+	if !ok {
+		return nil, ErrBandwidthLimitNotFound
+	}
+	cp := *l
+	return &cp, nil
+}
+
+func (m memBandwidthStore) List(_ context.Context) ([]*BandwidthLimit, error) {
+	var out []*BandwidthLimit
+	for _, l := range m {
+		cp := *l
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func (m memBandwidthStore) Delete(_ context.Context, name string) error {
+	if _, ok := m[name]; !ok {
+		return ErrBandwidthLimitNotFound
+	}
+	delete(m, name)
+	return nil
+}
+
+// fakeTC records tc calls, joined as command lines.
+type fakeTC struct{ calls []string }
+
+func (f *fakeTC) run(name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, name+" "+strings.Join(args, " "))
+	return nil, nil
+}
+
+// adds returns the calls that install something.
+func (f *fakeTC) adds() []string {
+	var out []string
+	for _, c := range f.calls {
+		if strings.Contains(c, " add ") {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func newTestShaper(veths map[string]string) (*TrafficShaper, memBandwidthStore, *fakeTC) {
+	store := memBandwidthStore{}
+	tc := &fakeTC{}
+	t := NewTrafficShaper(store, func(name string) (string, error) { return veths[name], nil })
+	t.run = tc.run
+	return t, store, tc
+}
+
+func TestSetLimit_ShapesBothDirections(t *testing.T) {
+	shaper, _, tc := newTestShaper(map[string]string{"alice-container": "veth1234"})
+
+	if _, err := shaper.SetLimit(context.Background(), "alice-container", 100, 50); err != nil {
+		t.Fatalf("SetLimit: %v", err)
+	}
+
+	want := []string{
+		"tc qdisc add dev veth1234 root handle 1: htb default 10",
+		"tc class add dev veth1234 parent 1: classid 1:10 htb rate 100mbit ceil 100mbit",
+		"tc qdisc add dev veth1234 handle ffff: ingress",
+		"tc filter add dev veth1234 parent ffff: protocol all prio 1 u32 match u32 0 0 police rate 50mbit burst 625000 drop",
+	}
+	if got := tc.adds(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("tc adds:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !shaper.Applied("alice-container") {
+		t.Error("limit not reported as applied")
+	}
+}
+
+func TestSetLimit_StoppedContainerWaitsForStart(t *testing.T) {
+	veths := map[string]string{}
+	shaper, store, tc := newTestShaper(veths)
+	ctx := context.Background()
+
+	if _, err := shaper.SetLimit(ctx, "bob-container", 0, 20); err != nil {
+		t.Fatalf("SetLimit: %v", err)
+	}
+	if len(tc.calls) != 0 || shaper.Applied("bob-container") {
+		t.Fatalf("stopped container shaped: %v", tc.calls)
+	}
+	if _, ok := store["bob-container"]; !ok {
+		t.Fatal("limit not persisted")
+	}
+
+	veths["bob-container"] = "vethaaaa"
+	if err := shaper.Reapply(ctx, "bob-container"); err != nil {
+		t.Fatalf("Reapply: %v", err)
+	}
+	if adds := tc.adds(); len(adds) != 2 || !strings.Contains(adds[1], "police rate 20mbit") {
+		t.Errorf("tc adds after start = %v", adds)
+	}
+}
+
+func TestSync_FollowsVethAcrossRestarts(t *testing.T) {
+	veths := map[string]string{"alice-container": "veth1111"}
+	shaper, _, tc := newTestShaper(veths)
+	ctx := context.Background()
+	if _, err := shaper.SetLimit(ctx, "alice-container", 10, 10); err != nil {
+		t.Fatalf("SetLimit: %v", err)
+	}
+
+	tc.calls = nil
+	if err := shaper.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(tc.calls) != 0 {
+		t.Errorf("Sync re-ran tc on an unchanged veth: %v", tc.calls)
+	}
+
+	veths["alice-container"] = "veth2222"
+	if err := shaper.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	for _, c := range tc.adds() {
+		if !strings.Contains(c, "dev veth2222 ") {
+			t.Errorf("shaped the old veth after a restart: %s", c)
+		}
+	}
+	if len(tc.adds()) != 4 {
+		t.Errorf("got %d tc adds on the new veth, want 4", len(tc.adds()))
+	}
+}
+
+func TestSync_LiftsExpiredLimit(t *testing.T) {
+	shaper, store, tc := newTestShaper(map[string]string{"alice-container": "veth1234"})
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	shaper.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	limit, err := shaper.SetLimitWithOptions(ctx, "alice-container", 0, 5, LimitOptions{Reason: "alert:EgressFlood", Duration: 30 * time.Minute})
+	if err != nil {
+		t.Fatalf("SetLimitWithOptions: %v", err)
+	}
+	if !limit.ExpiresAt.Equal(now.Add(30 * time.Minute)) {
+		t.Errorf("ExpiresAt = %v", limit.ExpiresAt)
+	}
+
+	now = now.Add(31 * time.Minute)
+	if got, _ := shaper.ListLimits(ctx); len(got) != 0 {
+		t.Errorf("ListLimits shows an expired limit: %v", got)
+	}
+	tc.calls = nil
+	if err := shaper.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if _, ok := store["alice-container"]; ok {
+		t.Error("expired limit still stored")
+	}
+	if shaper.Applied("alice-container") {
+		t.Error("expired limit still applied")
+	}
+	if len(tc.calls) == 0 || !strings.Contains(tc.calls[0], "qdisc del dev veth1234") {
+		t.Errorf("tc calls = %v, want the qdiscs deleted", tc.calls)
+	}
+}
+
+func TestSetLimit_Validation(t *testing.T) {
+	shaper, _, _ := newTestShaper(nil)
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name            string
+		ingress, egress int
+	}{
+		{"alice-container", 0, 0},
+		{"alice-container", -1, 10},
+		{"alice-container", MaxBandwidthMbps + 1, 0},
+		{"", 10, 10},
+	} {
+		if _, err := shaper.SetLimit(ctx, tc.name, tc.ingress, tc.egress); err == nil {
+			t.Errorf("SetLimit(%q, %d, %d) succeeded", tc.name, tc.ingress, tc.egress)
+		}
+	}
+}
+
+func TestParseMbps(t *testing.T) {
+	for in, want := range map[string]int{
+		"50": 50, "50mbit": 50, "50Mbps": 50, "50M": 50, "1gbit": 1000, "2G": 2000, "0": 0,
+	} {
+		got, err := ParseMbps(in)
+		if err != nil || got != want {
+			t.Errorf("ParseMbps(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "fast", "-5mbit", "500kbit", "200gbit", "99999999999999999999"} {
+		if _, err := ParseMbps(in); err == nil {
+			t.Errorf("ParseMbps(%q) succeeded", in)
+		}
+	}
+	if FormatMbps(0) != "unlimited" || FormatMbps(2000) != "2gbit" || FormatMbps(50) != "50mbit" {
+		t.Errorf("FormatMbps = %q %q %q", FormatMbps(0), FormatMbps(2000), FormatMbps(50))
+	}
+}
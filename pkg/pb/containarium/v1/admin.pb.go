@@ -0,0 +1,388 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: containarium/v1/admin.proto
+
+package containariumv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReloadConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
+	mi := &file_containarium_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigRequest) ProtoMessage() {}
+
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type ReloadConfigResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the config file that was read.
+	ConfigFile string `protobuf:"bytes,1,opt,name=config_file,json=configFile,proto3" json:"config_file,omitempty"`
+	// One line per setting changed, e.g.
+	// "--traffic-retention-days: 7 → 30".
+	Applied []string `protobuf:"bytes,2,rep,name=applied,proto3" json:"applied,omitempty"`
+	// One line per changed setting that was not applied, with the
+	// reason (needs a restart, set on the command line, ...).
+	Rejected      []string `protobuf:"bytes,3,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigResponse) Reset() {
+	*x = ReloadConfigResponse{}
+	mi := &file_containarium_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigResponse) ProtoMessage() {}
+
+func (x *ReloadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigResponse.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReloadConfigResponse) GetConfigFile() string {
+	if x != nil {
+		return x.ConfigFile
+	}
+	return ""
+}
+
+func (x *ReloadConfigResponse) GetApplied() []string {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+func (x *ReloadConfigResponse) GetRejected() []string {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+// SetContainerTemplateRequest creates a template or replaces the one with
+// the same name.
+type SetContainerTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *ContainerTemplate     `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetContainerTemplateRequest) Reset() {
+	*x = SetContainerTemplateRequest{}
+	mi := &file_containarium_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetContainerTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerTemplateRequest) ProtoMessage() {}
+
+func (x *SetContainerTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerTemplateRequest.ProtoReflect.Descriptor instead.
+func (*SetContainerTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetContainerTemplateRequest) GetTemplate() *ContainerTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+// SetContainerTemplateResponse holds the template as stored.
+type SetContainerTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *ContainerTemplate     `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetContainerTemplateResponse) Reset() {
+	*x = SetContainerTemplateResponse{}
+	mi := &file_containarium_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetContainerTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerTemplateResponse) ProtoMessage() {}
+
+func (x *SetContainerTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerTemplateResponse.ProtoReflect.Descriptor instead.
+func (*SetContainerTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetContainerTemplateResponse) GetTemplate() *ContainerTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+// DeleteContainerTemplateRequest names the template to remove.
+type DeleteContainerTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteContainerTemplateRequest) Reset() {
+	*x = DeleteContainerTemplateRequest{}
+	mi := &file_containarium_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContainerTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContainerTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteContainerTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteContainerTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteContainerTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteContainerTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// DeleteContainerTemplateResponse is empty; boxes created from the
+// template are unaffected.
+type DeleteContainerTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteContainerTemplateResponse) Reset() {
+	*x = DeleteContainerTemplateResponse{}
+	mi := &file_containarium_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContainerTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContainerTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteContainerTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteContainerTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteContainerTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+var File_containarium_v1_admin_proto protoreflect.FileDescriptor
+
+const file_containarium_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x1bcontainarium/v1/admin.proto\x12\x0fcontainarium.v1\x1a\x1fcontainarium/v1/container.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x15\n" +
+	"\x13ReloadConfigRequest\"m\n" +
+	"\x14ReloadConfigResponse\x12\x1f\n" +
+	"\vconfig_file\x18\x01 \x01(\tR\n" +
+	"configFile\x12\x18\n" +
+	"\aapplied\x18\x02 \x03(\tR\aapplied\x12\x1a\n" +
+	"\brejected\x18\x03 \x03(\tR\brejected\"]\n" +
+	"\x1bSetContainerTemplateRequest\x12>\n" +
+	"\btemplate\x18\x01 \x01(\v2\".containarium.v1.ContainerTemplateR\btemplate\"^\n" +
+	"\x1cSetContainerTemplateResponse\x12>\n" +
+	"\btemplate\x18\x01 \x01(\v2\".containarium.v1.ContainerTemplateR\btemplate\"4\n" +
+	"\x1eDeleteContainerTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"!\n" +
+	"\x1fDeleteContainerTemplateResponse2\xca\t\n" +
+	"\fAdminService\x12\xd0\x03\n" +
+	"\fReloadConfig\x12$.containarium.v1.ReloadConfigRequest\x1a%.containarium.v1.ReloadConfigResponse\"\xf2\x02\x92A\xcc\x02\n" +
+	"\x05Admin\x12\x1dReload the daemon config file\x1a\xa3\x02Re-reads --config and applies the runtime-changeable subset (traffic collector intervals, retention, sampling and history limits, the alert webhook, reserved ports) without a restart. Lists each applied change, and each change rejected because it needs a restart. Admin + daemon:admin scope.\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/admin/reload-config\x12\xfd\x02\n" +
+	"\x14SetContainerTemplate\x12,.containarium.v1.SetContainerTemplateRequest\x1a-.containarium.v1.SetContainerTemplateResponse\"\x87\x02\x92A\xdb\x01\n" +
+	"\x05Admin\x12&Create or replace a container template\x1a\xa9\x01Stores the template in the daemon's config store (in memory without PostgreSQL). Templates from the --container-templates file are read-only. Admin + daemon:admin scope.\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/admin/container-templates\x12\xe6\x02\n" +
+	"\x17DeleteContainerTemplate\x12/.containarium.v1.DeleteContainerTemplateRequest\x1a0.containarium.v1.DeleteContainerTemplateResponse\"\xe7\x01\x92A\xb7\x01\n" +
+	"\x05Admin\x12\x1bDelete a container template\x1a\x90\x01Boxes already created from the template are unaffected. Templates from the --container-templates file are read-only. Admin + daemon:admin scope.\x82\xd3\xe4\x93\x02&*$/v1/admin/container-templates/{name}BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
+
+var (
+	file_containarium_v1_admin_proto_rawDescOnce sync.Once
+	file_containarium_v1_admin_proto_rawDescData []byte
+)
+
+func file_containarium_v1_admin_proto_rawDescGZIP() []byte {
+	file_containarium_v1_admin_proto_rawDescOnce.Do(func() {
+		file_containarium_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_containarium_v1_admin_proto_rawDesc), len(file_containarium_v1_admin_proto_rawDesc)))
+	})
+	return file_containarium_v1_admin_proto_rawDescData
+}
+
+var file_containarium_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_containarium_v1_admin_proto_goTypes = []any{
+	(*ReloadConfigRequest)(nil),             // 0: containarium.v1.ReloadConfigRequest
+	(*ReloadConfigResponse)(nil),            // 1: containarium.v1.ReloadConfigResponse
+	(*SetContainerTemplateRequest)(nil),     // 2: containarium.v1.SetContainerTemplateRequest
+	(*SetContainerTemplateResponse)(nil),    // 3: containarium.v1.SetContainerTemplateResponse
+	(*DeleteContainerTemplateRequest)(nil),  // 4: containarium.v1.DeleteContainerTemplateRequest
+	(*DeleteContainerTemplateResponse)(nil), // 5: containarium.v1.DeleteContainerTemplateResponse
+	(*ContainerTemplate)(nil),               // 6: containarium.v1.ContainerTemplate
+}
+var file_containarium_v1_admin_proto_depIdxs = []int32{
+	6, // 0: containarium.v1.SetContainerTemplateRequest.template:type_name -> containarium.v1.ContainerTemplate
+	6, // 1: containarium.v1.SetContainerTemplateResponse.template:type_name -> containarium.v1.ContainerTemplate
+	0, // 2: containarium.v1.AdminService.ReloadConfig:input_type -> containarium.v1.ReloadConfigRequest
+	2, // 3: containarium.v1.AdminService.SetContainerTemplate:input_type -> containarium.v1.SetContainerTemplateRequest
+	4, // 4: containarium.v1.AdminService.DeleteContainerTemplate:input_type -> containarium.v1.DeleteContainerTemplateRequest
+	1, // 5: containarium.v1.AdminService.ReloadConfig:output_type -> containarium.v1.ReloadConfigResponse
+	3, // 6: containarium.v1.AdminService.SetContainerTemplate:output_type -> containarium.v1.SetContainerTemplateResponse
+	5, // 7: containarium.v1.AdminService.DeleteContainerTemplate:output_type -> containarium.v1.DeleteContainerTemplateResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_containarium_v1_admin_proto_init() }
+func file_containarium_v1_admin_proto_init() {
+	if File_containarium_v1_admin_proto != nil {
+		return
+	}
+	file_containarium_v1_container_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_admin_proto_rawDesc), len(file_containarium_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_containarium_v1_admin_proto_goTypes,
+		DependencyIndexes: file_containarium_v1_admin_proto_depIdxs,
+		MessageInfos:      file_containarium_v1_admin_proto_msgTypes,
+	}.Build()
+	File_containarium_v1_admin_proto = out.File
+	file_containarium_v1_admin_proto_goTypes = nil
+	file_containarium_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,227 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: containarium/v1/admin.proto
+
+package containariumv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AdminService_ReloadConfig_FullMethodName            = "/containarium.v1.AdminService/ReloadConfig"
+	AdminService_SetContainerTemplate_FullMethodName    = "/containarium.v1.AdminService/SetContainerTemplate"
+	AdminService_DeleteContainerTemplate_FullMethodName = "/containarium.v1.AdminService/DeleteContainerTemplate"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService holds daemon-wide operations that act on the daemon
+// process or its daemon-wide settings rather than on any tenant's
+// resources. Every RPC requires the `daemon:admin` scope AND the admin
+// role.
+type AdminServiceClient interface {
+	// ReloadConfig re-reads the daemon's --config file and applies the
+	// settings that can change while it runs, exactly as SIGHUP does.
+	// Settings that only take effect at startup (listen addresses, the
+	// PostgreSQL DSN, the traffic store, ...) are reported as rejected
+	// and left alone. Fails with FailedPrecondition when the daemon was
+	// started without --config.
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	// SetContainerTemplate creates or replaces a container template
+	// (see ContainerService.ListContainerTemplates). A template decides
+	// what every box created from it gets, cloud-init included.
+	SetContainerTemplate(ctx context.Context, in *SetContainerTemplateRequest, opts ...grpc.CallOption) (*SetContainerTemplateResponse, error)
+	// DeleteContainerTemplate removes a container template.
+	DeleteContainerTemplate(ctx context.Context, in *DeleteContainerTemplateRequest, opts ...grpc.CallOption) (*DeleteContainerTemplateResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, AdminService_ReloadConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetContainerTemplate(ctx context.Context, in *SetContainerTemplateRequest, opts ...grpc.CallOption) (*SetContainerTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetContainerTemplateResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetContainerTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteContainerTemplate(ctx context.Context, in *DeleteContainerTemplateRequest, opts ...grpc.CallOption) (*DeleteContainerTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteContainerTemplateResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteContainerTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService holds daemon-wide operations that act on the daemon
+// process or its daemon-wide settings rather than on any tenant's
+// resources. Every RPC requires the `daemon:admin` scope AND the admin
+// role.
+type AdminServiceServer interface {
+	// ReloadConfig re-reads the daemon's --config file and applies the
+	// settings that can change while it runs, exactly as SIGHUP does.
+	// Settings that only take effect at startup (listen addresses, the
+	// PostgreSQL DSN, the traffic store, ...) are reported as rejected
+	// and left alone. Fails with FailedPrecondition when the daemon was
+	// started without --config.
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	// SetContainerTemplate creates or replaces a container template
+	// (see ContainerService.ListContainerTemplates). A template decides
+	// what every box created from it gets, cloud-init included.
+	SetContainerTemplate(context.Context, *SetContainerTemplateRequest) (*SetContainerTemplateResponse, error)
+	// DeleteContainerTemplate removes a container template.
+	DeleteContainerTemplate(context.Context, *DeleteContainerTemplateRequest) (*DeleteContainerTemplateResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedAdminServiceServer) SetContainerTemplate(context.Context, *SetContainerTemplateRequest) (*SetContainerTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetContainerTemplate not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteContainerTemplate(context.Context, *DeleteContainerTemplateRequest) (*DeleteContainerTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteContainerTemplate not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetContainerTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContainerTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetContainerTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetContainerTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetContainerTemplate(ctx, req.(*SetContainerTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteContainerTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteContainerTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteContainerTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteContainerTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteContainerTemplate(ctx, req.(*DeleteContainerTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "containarium.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _AdminService_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "SetContainerTemplate",
+			Handler:    _AdminService_SetContainerTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteContainerTemplate",
+			Handler:    _AdminService_DeleteContainerTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "containarium/v1/admin.proto",
+}
@@ -246,6 +246,57 @@ func (NetworkPolicyMode) EnumDescriptor() ([]byte, []int) {
 	return file_containarium_v1_config_proto_rawDescGZIP(), []int{2}
 }
 
+// NetworkPolicyDefaultAction is what a tenant's policy does with external
+// egress that no rule matches.
+type NetworkPolicyDefaultAction int32
+
+const (
+	NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_UNSPECIFIED NetworkPolicyDefaultAction = 0 // treated as DENY: egress_cidrs/egress_domains are an allow-list
+	NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_DENY        NetworkPolicyDefaultAction = 1
+	NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_ALLOW       NetworkPolicyDefaultAction = 2 // all external egress allowed; only deny_rules (and the metadata IP) block
+)
+
+// Enum value maps for NetworkPolicyDefaultAction.
+var (
+	NetworkPolicyDefaultAction_name = map[int32]string{
+		0: "NETWORK_POLICY_DEFAULT_ACTION_UNSPECIFIED",
+		1: "NETWORK_POLICY_DEFAULT_ACTION_DENY",
+		2: "NETWORK_POLICY_DEFAULT_ACTION_ALLOW",
+	}
+	NetworkPolicyDefaultAction_value = map[string]int32{
+		"NETWORK_POLICY_DEFAULT_ACTION_UNSPECIFIED": 0,
+		"NETWORK_POLICY_DEFAULT_ACTION_DENY":        1,
+		"NETWORK_POLICY_DEFAULT_ACTION_ALLOW":       2,
+	}
+)
+
+func (x NetworkPolicyDefaultAction) Enum() *NetworkPolicyDefaultAction {
+	p := new(NetworkPolicyDefaultAction)
+	*p = x
+	return p
+}
+
+func (x NetworkPolicyDefaultAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NetworkPolicyDefaultAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_config_proto_enumTypes[3].Descriptor()
+}
+
+func (NetworkPolicyDefaultAction) Type() protoreflect.EnumType {
+	return &file_containarium_v1_config_proto_enumTypes[3]
+}
+
+func (x NetworkPolicyDefaultAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NetworkPolicyDefaultAction.Descriptor instead.
+func (NetworkPolicyDefaultAction) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{3}
+}
+
 // BackendType represents the type of backend instance
 type BackendType int32
 
@@ -280,11 +331,11 @@ func (x BackendType) String() string {
 }
 
 func (BackendType) Descriptor() protoreflect.EnumDescriptor {
-	return file_containarium_v1_config_proto_enumTypes[3].Descriptor()
+	return file_containarium_v1_config_proto_enumTypes[4].Descriptor()
 }
 
 func (BackendType) Type() protoreflect.EnumType {
-	return &file_containarium_v1_config_proto_enumTypes[3]
+	return &file_containarium_v1_config_proto_enumTypes[4]
 }
 
 func (x BackendType) Number() protoreflect.EnumNumber {
@@ -293,7 +344,7 @@ func (x BackendType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BackendType.Descriptor instead.
 func (BackendType) EnumDescriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{3}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{4}
 }
 
 type ValidateGPUResponse_GPUStatus int32
@@ -332,11 +383,11 @@ func (x ValidateGPUResponse_GPUStatus) String() string {
 }
 
 func (ValidateGPUResponse_GPUStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_containarium_v1_config_proto_enumTypes[4].Descriptor()
+	return file_containarium_v1_config_proto_enumTypes[5].Descriptor()
 }
 
 func (ValidateGPUResponse_GPUStatus) Type() protoreflect.EnumType {
-	return &file_containarium_v1_config_proto_enumTypes[4]
+	return &file_containarium_v1_config_proto_enumTypes[5]
 }
 
 func (x ValidateGPUResponse_GPUStatus) Number() protoreflect.EnumNumber {
@@ -1958,7 +2009,16 @@ type NetworkPolicy struct {
 	// real upstream fix ships — instant, in-kernel, zero downtime. A rule whose
 	// expires_at is in the past is dropped at compile time, so the patch
 	// self-removes once the fix lands.
-	DenyRules     []*NetworkPolicyDenyRule `protobuf:"bytes,8,rep,name=deny_rules,json=denyRules,proto3" json:"deny_rules,omitempty"`
+	DenyRules []*NetworkPolicyDenyRule `protobuf:"bytes,8,rep,name=deny_rules,json=denyRules,proto3" json:"deny_rules,omitempty"`
+	// What happens to external egress no rule matches. DENY (the default)
+	// makes egress_cidrs/egress_domains an allow-list; ALLOW lets everything
+	// out, so the tenant is restricted only by deny_rules and the metadata
+	// guard. Intra-backend traffic is governed by allow_intra_tenant either way.
+	DefaultEgress NetworkPolicyDefaultAction `protobuf:"varint,9,opt,name=default_egress,json=defaultEgress,proto3,enum=containarium.v1.NetworkPolicyDefaultAction" json:"default_egress,omitempty"`
+	// Allowed egress destinations scoped to a port and/or protocol (e.g. only
+	// tcp/443 to a registry), where egress_cidrs allows every port. Part of the
+	// allow-list, so they only matter with default_egress DENY.
+	AllowRules    []*NetworkPolicyAllowRule `protobuf:"bytes,10,rep,name=allow_rules,json=allowRules,proto3" json:"allow_rules,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2049,6 +2109,91 @@ func (x *NetworkPolicy) GetDenyRules() []*NetworkPolicyDenyRule {
 	return nil
 }
 
+func (x *NetworkPolicy) GetDefaultEgress() NetworkPolicyDefaultAction {
+	if x != nil {
+		return x.DefaultEgress
+	}
+	return NetworkPolicyDefaultAction_NETWORK_POLICY_DEFAULT_ACTION_UNSPECIFIED
+}
+
+func (x *NetworkPolicy) GetAllowRules() []*NetworkPolicyAllowRule {
+	if x != nil {
+		return x.AllowRules
+	}
+	return nil
+}
+
+// NetworkPolicyAllowRule allows a tenant's egress to a destination CIDR,
+// optionally only to one port/proto. Like deny rules they are keyed by CIDR
+// (one rule per destination prefix, the kernel map holds one entry per
+// prefix), and the longest matching prefix decides: a narrower rule inside
+// a broader one replaces it for the addresses it covers. A rule inside an
+// egress_cidrs prefix is dropped, since that prefix already allows every
+// port.
+type NetworkPolicyAllowRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Destination CIDR to allow (e.g. "203.0.113.0/24"); a host IP is a /32.
+	// Required. IPv4 only.
+	Cidr string `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// Destination port to allow (0 = any port).
+	Port uint32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	// IP protocol to allow: "tcp" | "udp" | "" (any).
+	Proto         string `protobuf:"bytes,3,opt,name=proto,proto3" json:"proto,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkPolicyAllowRule) Reset() {
+	*x = NetworkPolicyAllowRule{}
+	mi := &file_containarium_v1_config_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkPolicyAllowRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkPolicyAllowRule) ProtoMessage() {}
+
+func (x *NetworkPolicyAllowRule) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkPolicyAllowRule.ProtoReflect.Descriptor instead.
+func (*NetworkPolicyAllowRule) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *NetworkPolicyAllowRule) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *NetworkPolicyAllowRule) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *NetworkPolicyAllowRule) GetProto() string {
+	if x != nil {
+		return x.Proto
+	}
+	return ""
+}
+
 // NetworkPolicyDenyRule is one virtual-patch block rule (#660). Traffic from a
 // tenant's container to the destination is denied — dropped in ENFORCE mode,
 // audited (action network_policy.virtual_patch) in every mode. Deny beats the
@@ -2077,7 +2222,7 @@ type NetworkPolicyDenyRule struct {
 
 func (x *NetworkPolicyDenyRule) Reset() {
 	*x = NetworkPolicyDenyRule{}
-	mi := &file_containarium_v1_config_proto_msgTypes[22]
+	mi := &file_containarium_v1_config_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2089,7 +2234,7 @@ func (x *NetworkPolicyDenyRule) String() string {
 func (*NetworkPolicyDenyRule) ProtoMessage() {}
 
 func (x *NetworkPolicyDenyRule) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[22]
+	mi := &file_containarium_v1_config_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2102,7 +2247,7 @@ func (x *NetworkPolicyDenyRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkPolicyDenyRule.ProtoReflect.Descriptor instead.
 func (*NetworkPolicyDenyRule) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{22}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *NetworkPolicyDenyRule) GetCidr() string {
@@ -2149,7 +2294,7 @@ type SetNetworkPolicyRequest struct {
 
 func (x *SetNetworkPolicyRequest) Reset() {
 	*x = SetNetworkPolicyRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[23]
+	mi := &file_containarium_v1_config_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2161,7 +2306,7 @@ func (x *SetNetworkPolicyRequest) String() string {
 func (*SetNetworkPolicyRequest) ProtoMessage() {}
 
 func (x *SetNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[23]
+	mi := &file_containarium_v1_config_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2174,7 +2319,7 @@ func (x *SetNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetNetworkPolicyRequest.ProtoReflect.Descriptor instead.
 func (*SetNetworkPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{23}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *SetNetworkPolicyRequest) GetPolicy() *NetworkPolicy {
@@ -2194,7 +2339,7 @@ type SetNetworkPolicyResponse struct {
 
 func (x *SetNetworkPolicyResponse) Reset() {
 	*x = SetNetworkPolicyResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[24]
+	mi := &file_containarium_v1_config_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2206,7 +2351,7 @@ func (x *SetNetworkPolicyResponse) String() string {
 func (*SetNetworkPolicyResponse) ProtoMessage() {}
 
 func (x *SetNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[24]
+	mi := &file_containarium_v1_config_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2219,7 +2364,7 @@ func (x *SetNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetNetworkPolicyResponse.ProtoReflect.Descriptor instead.
 func (*SetNetworkPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{24}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SetNetworkPolicyResponse) GetPolicy() *NetworkPolicy {
@@ -2238,7 +2383,7 @@ type GetNetworkPolicyRequest struct {
 
 func (x *GetNetworkPolicyRequest) Reset() {
 	*x = GetNetworkPolicyRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[25]
+	mi := &file_containarium_v1_config_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2250,7 +2395,7 @@ func (x *GetNetworkPolicyRequest) String() string {
 func (*GetNetworkPolicyRequest) ProtoMessage() {}
 
 func (x *GetNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[25]
+	mi := &file_containarium_v1_config_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2263,7 +2408,7 @@ func (x *GetNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworkPolicyRequest.ProtoReflect.Descriptor instead.
 func (*GetNetworkPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{25}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetNetworkPolicyRequest) GetTenant() string {
@@ -2282,7 +2427,7 @@ type GetNetworkPolicyResponse struct {
 
 func (x *GetNetworkPolicyResponse) Reset() {
 	*x = GetNetworkPolicyResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[26]
+	mi := &file_containarium_v1_config_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2294,7 +2439,7 @@ func (x *GetNetworkPolicyResponse) String() string {
 func (*GetNetworkPolicyResponse) ProtoMessage() {}
 
 func (x *GetNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[26]
+	mi := &file_containarium_v1_config_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2307,7 +2452,7 @@ func (x *GetNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworkPolicyResponse.ProtoReflect.Descriptor instead.
 func (*GetNetworkPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{26}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetNetworkPolicyResponse) GetPolicy() *NetworkPolicy {
@@ -2325,7 +2470,7 @@ type ListNetworkPoliciesRequest struct {
 
 func (x *ListNetworkPoliciesRequest) Reset() {
 	*x = ListNetworkPoliciesRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[27]
+	mi := &file_containarium_v1_config_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2337,7 +2482,7 @@ func (x *ListNetworkPoliciesRequest) String() string {
 func (*ListNetworkPoliciesRequest) ProtoMessage() {}
 
 func (x *ListNetworkPoliciesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[27]
+	mi := &file_containarium_v1_config_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2350,7 +2495,7 @@ func (x *ListNetworkPoliciesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNetworkPoliciesRequest.ProtoReflect.Descriptor instead.
 func (*ListNetworkPoliciesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{27}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{28}
 }
 
 type ListNetworkPoliciesResponse struct {
@@ -2362,7 +2507,7 @@ type ListNetworkPoliciesResponse struct {
 
 func (x *ListNetworkPoliciesResponse) Reset() {
 	*x = ListNetworkPoliciesResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[28]
+	mi := &file_containarium_v1_config_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2374,7 +2519,7 @@ func (x *ListNetworkPoliciesResponse) String() string {
 func (*ListNetworkPoliciesResponse) ProtoMessage() {}
 
 func (x *ListNetworkPoliciesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[28]
+	mi := &file_containarium_v1_config_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2387,7 +2532,7 @@ func (x *ListNetworkPoliciesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNetworkPoliciesResponse.ProtoReflect.Descriptor instead.
 func (*ListNetworkPoliciesResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{28}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ListNetworkPoliciesResponse) GetPolicies() []*NetworkPolicy {
@@ -2406,7 +2551,7 @@ type DeleteNetworkPolicyRequest struct {
 
 func (x *DeleteNetworkPolicyRequest) Reset() {
 	*x = DeleteNetworkPolicyRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[29]
+	mi := &file_containarium_v1_config_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2418,7 +2563,7 @@ func (x *DeleteNetworkPolicyRequest) String() string {
 func (*DeleteNetworkPolicyRequest) ProtoMessage() {}
 
 func (x *DeleteNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[29]
+	mi := &file_containarium_v1_config_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2431,7 +2576,7 @@ func (x *DeleteNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNetworkPolicyRequest.ProtoReflect.Descriptor instead.
 func (*DeleteNetworkPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{29}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *DeleteNetworkPolicyRequest) GetTenant() string {
@@ -2449,7 +2594,7 @@ type DeleteNetworkPolicyResponse struct {
 
 func (x *DeleteNetworkPolicyResponse) Reset() {
 	*x = DeleteNetworkPolicyResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[30]
+	mi := &file_containarium_v1_config_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2461,7 +2606,7 @@ func (x *DeleteNetworkPolicyResponse) String() string {
 func (*DeleteNetworkPolicyResponse) ProtoMessage() {}
 
 func (x *DeleteNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[30]
+	mi := &file_containarium_v1_config_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2474,7 +2619,7 @@ func (x *DeleteNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNetworkPolicyResponse.ProtoReflect.Descriptor instead.
 func (*DeleteNetworkPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{30}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{31}
 }
 
 // PatchNetworkPolicyDenyRulesRequest atomically mutates a tenant's virtual-patch
@@ -2495,7 +2640,7 @@ type PatchNetworkPolicyDenyRulesRequest struct {
 
 func (x *PatchNetworkPolicyDenyRulesRequest) Reset() {
 	*x = PatchNetworkPolicyDenyRulesRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[31]
+	mi := &file_containarium_v1_config_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2507,7 +2652,7 @@ func (x *PatchNetworkPolicyDenyRulesRequest) String() string {
 func (*PatchNetworkPolicyDenyRulesRequest) ProtoMessage() {}
 
 func (x *PatchNetworkPolicyDenyRulesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[31]
+	mi := &file_containarium_v1_config_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2520,7 +2665,7 @@ func (x *PatchNetworkPolicyDenyRulesRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use PatchNetworkPolicyDenyRulesRequest.ProtoReflect.Descriptor instead.
 func (*PatchNetworkPolicyDenyRulesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{31}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *PatchNetworkPolicyDenyRulesRequest) GetTenant() string {
@@ -2571,7 +2716,7 @@ type NetworkPolicySignature struct {
 
 func (x *NetworkPolicySignature) Reset() {
 	*x = NetworkPolicySignature{}
-	mi := &file_containarium_v1_config_proto_msgTypes[32]
+	mi := &file_containarium_v1_config_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2583,7 +2728,7 @@ func (x *NetworkPolicySignature) String() string {
 func (*NetworkPolicySignature) ProtoMessage() {}
 
 func (x *NetworkPolicySignature) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[32]
+	mi := &file_containarium_v1_config_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2596,7 +2741,7 @@ func (x *NetworkPolicySignature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkPolicySignature.ProtoReflect.Descriptor instead.
 func (*NetworkPolicySignature) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{32}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *NetworkPolicySignature) GetName() string {
@@ -2643,7 +2788,7 @@ type SetNetworkPolicySignatureRequest struct {
 
 func (x *SetNetworkPolicySignatureRequest) Reset() {
 	*x = SetNetworkPolicySignatureRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[33]
+	mi := &file_containarium_v1_config_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2655,7 +2800,7 @@ func (x *SetNetworkPolicySignatureRequest) String() string {
 func (*SetNetworkPolicySignatureRequest) ProtoMessage() {}
 
 func (x *SetNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[33]
+	mi := &file_containarium_v1_config_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2668,7 +2813,7 @@ func (x *SetNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetNetworkPolicySignatureRequest.ProtoReflect.Descriptor instead.
 func (*SetNetworkPolicySignatureRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{33}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *SetNetworkPolicySignatureRequest) GetSignature() *NetworkPolicySignature {
@@ -2687,7 +2832,7 @@ type SetNetworkPolicySignatureResponse struct {
 
 func (x *SetNetworkPolicySignatureResponse) Reset() {
 	*x = SetNetworkPolicySignatureResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[34]
+	mi := &file_containarium_v1_config_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2699,7 +2844,7 @@ func (x *SetNetworkPolicySignatureResponse) String() string {
 func (*SetNetworkPolicySignatureResponse) ProtoMessage() {}
 
 func (x *SetNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[34]
+	mi := &file_containarium_v1_config_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2712,7 +2857,7 @@ func (x *SetNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SetNetworkPolicySignatureResponse.ProtoReflect.Descriptor instead.
 func (*SetNetworkPolicySignatureResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{34}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *SetNetworkPolicySignatureResponse) GetSignature() *NetworkPolicySignature {
@@ -2730,7 +2875,7 @@ type ListNetworkPolicySignaturesRequest struct {
 
 func (x *ListNetworkPolicySignaturesRequest) Reset() {
 	*x = ListNetworkPolicySignaturesRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[35]
+	mi := &file_containarium_v1_config_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2742,7 +2887,7 @@ func (x *ListNetworkPolicySignaturesRequest) String() string {
 func (*ListNetworkPolicySignaturesRequest) ProtoMessage() {}
 
 func (x *ListNetworkPolicySignaturesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[35]
+	mi := &file_containarium_v1_config_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2755,7 +2900,7 @@ func (x *ListNetworkPolicySignaturesRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ListNetworkPolicySignaturesRequest.ProtoReflect.Descriptor instead.
 func (*ListNetworkPolicySignaturesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{35}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{36}
 }
 
 type ListNetworkPolicySignaturesResponse struct {
@@ -2767,7 +2912,7 @@ type ListNetworkPolicySignaturesResponse struct {
 
 func (x *ListNetworkPolicySignaturesResponse) Reset() {
 	*x = ListNetworkPolicySignaturesResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[36]
+	mi := &file_containarium_v1_config_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2779,7 +2924,7 @@ func (x *ListNetworkPolicySignaturesResponse) String() string {
 func (*ListNetworkPolicySignaturesResponse) ProtoMessage() {}
 
 func (x *ListNetworkPolicySignaturesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[36]
+	mi := &file_containarium_v1_config_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2792,7 +2937,7 @@ func (x *ListNetworkPolicySignaturesResponse) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use ListNetworkPolicySignaturesResponse.ProtoReflect.Descriptor instead.
 func (*ListNetworkPolicySignaturesResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{36}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ListNetworkPolicySignaturesResponse) GetSignatures() []*NetworkPolicySignature {
@@ -2811,7 +2956,7 @@ type DeleteNetworkPolicySignatureRequest struct {
 
 func (x *DeleteNetworkPolicySignatureRequest) Reset() {
 	*x = DeleteNetworkPolicySignatureRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[37]
+	mi := &file_containarium_v1_config_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2823,7 +2968,7 @@ func (x *DeleteNetworkPolicySignatureRequest) String() string {
 func (*DeleteNetworkPolicySignatureRequest) ProtoMessage() {}
 
 func (x *DeleteNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[37]
+	mi := &file_containarium_v1_config_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2836,7 +2981,7 @@ func (x *DeleteNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use DeleteNetworkPolicySignatureRequest.ProtoReflect.Descriptor instead.
 func (*DeleteNetworkPolicySignatureRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{37}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *DeleteNetworkPolicySignatureRequest) GetName() string {
@@ -2854,7 +2999,7 @@ type DeleteNetworkPolicySignatureResponse struct {
 
 func (x *DeleteNetworkPolicySignatureResponse) Reset() {
 	*x = DeleteNetworkPolicySignatureResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[38]
+	mi := &file_containarium_v1_config_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2866,7 +3011,7 @@ func (x *DeleteNetworkPolicySignatureResponse) String() string {
 func (*DeleteNetworkPolicySignatureResponse) ProtoMessage() {}
 
 func (x *DeleteNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[38]
+	mi := &file_containarium_v1_config_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2879,7 +3024,7 @@ func (x *DeleteNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use DeleteNetworkPolicySignatureResponse.ProtoReflect.Descriptor instead.
 func (*DeleteNetworkPolicySignatureResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{38}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{39}
 }
 
 // BackendInfo describes one backend in the fleet — the local daemon
@@ -2925,7 +3070,7 @@ type BackendInfo struct {
 
 func (x *BackendInfo) Reset() {
 	*x = BackendInfo{}
-	mi := &file_containarium_v1_config_proto_msgTypes[39]
+	mi := &file_containarium_v1_config_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2937,7 +3082,7 @@ func (x *BackendInfo) String() string {
 func (*BackendInfo) ProtoMessage() {}
 
 func (x *BackendInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[39]
+	mi := &file_containarium_v1_config_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2950,7 +3095,7 @@ func (x *BackendInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BackendInfo.ProtoReflect.Descriptor instead.
 func (*BackendInfo) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{39}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *BackendInfo) GetId() string {
@@ -3086,7 +3231,7 @@ type CapabilityProfile struct {
 
 func (x *CapabilityProfile) Reset() {
 	*x = CapabilityProfile{}
-	mi := &file_containarium_v1_config_proto_msgTypes[40]
+	mi := &file_containarium_v1_config_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3098,7 +3243,7 @@ func (x *CapabilityProfile) String() string {
 func (*CapabilityProfile) ProtoMessage() {}
 
 func (x *CapabilityProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[40]
+	mi := &file_containarium_v1_config_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3111,7 +3256,7 @@ func (x *CapabilityProfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapabilityProfile.ProtoReflect.Descriptor instead.
 func (*CapabilityProfile) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{40}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *CapabilityProfile) GetCpuCores() int32 {
@@ -3226,7 +3371,7 @@ type CapabilityBenchmark struct {
 
 func (x *CapabilityBenchmark) Reset() {
 	*x = CapabilityBenchmark{}
-	mi := &file_containarium_v1_config_proto_msgTypes[41]
+	mi := &file_containarium_v1_config_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3238,7 +3383,7 @@ func (x *CapabilityBenchmark) String() string {
 func (*CapabilityBenchmark) ProtoMessage() {}
 
 func (x *CapabilityBenchmark) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[41]
+	mi := &file_containarium_v1_config_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3251,7 +3396,7 @@ func (x *CapabilityBenchmark) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapabilityBenchmark.ProtoReflect.Descriptor instead.
 func (*CapabilityBenchmark) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{41}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *CapabilityBenchmark) GetCpuOpsPerSec() int64 {
@@ -3309,7 +3454,7 @@ type CapacityHeadroom struct {
 
 func (x *CapacityHeadroom) Reset() {
 	*x = CapacityHeadroom{}
-	mi := &file_containarium_v1_config_proto_msgTypes[42]
+	mi := &file_containarium_v1_config_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3321,7 +3466,7 @@ func (x *CapacityHeadroom) String() string {
 func (*CapacityHeadroom) ProtoMessage() {}
 
 func (x *CapacityHeadroom) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[42]
+	mi := &file_containarium_v1_config_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3334,7 +3479,7 @@ func (x *CapacityHeadroom) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapacityHeadroom.ProtoReflect.Descriptor instead.
 func (*CapacityHeadroom) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{42}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *CapacityHeadroom) GetAdvertised() bool {
@@ -3413,7 +3558,7 @@ type CapacityPolicy struct {
 
 func (x *CapacityPolicy) Reset() {
 	*x = CapacityPolicy{}
-	mi := &file_containarium_v1_config_proto_msgTypes[43]
+	mi := &file_containarium_v1_config_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3425,7 +3570,7 @@ func (x *CapacityPolicy) String() string {
 func (*CapacityPolicy) ProtoMessage() {}
 
 func (x *CapacityPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[43]
+	mi := &file_containarium_v1_config_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3438,7 +3583,7 @@ func (x *CapacityPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapacityPolicy.ProtoReflect.Descriptor instead.
 func (*CapacityPolicy) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{43}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *CapacityPolicy) GetWindowStartHour() int32 {
@@ -3484,7 +3629,7 @@ type BackendGPU struct {
 
 func (x *BackendGPU) Reset() {
 	*x = BackendGPU{}
-	mi := &file_containarium_v1_config_proto_msgTypes[44]
+	mi := &file_containarium_v1_config_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3496,7 +3641,7 @@ func (x *BackendGPU) String() string {
 func (*BackendGPU) ProtoMessage() {}
 
 func (x *BackendGPU) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[44]
+	mi := &file_containarium_v1_config_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3509,7 +3654,7 @@ func (x *BackendGPU) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BackendGPU.ProtoReflect.Descriptor instead.
 func (*BackendGPU) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{44}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *BackendGPU) GetVendor() string {
@@ -3542,7 +3687,7 @@ type ListBackendsRequest struct {
 
 func (x *ListBackendsRequest) Reset() {
 	*x = ListBackendsRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[45]
+	mi := &file_containarium_v1_config_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3554,7 +3699,7 @@ func (x *ListBackendsRequest) String() string {
 func (*ListBackendsRequest) ProtoMessage() {}
 
 func (x *ListBackendsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[45]
+	mi := &file_containarium_v1_config_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3567,7 +3712,7 @@ func (x *ListBackendsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackendsRequest.ProtoReflect.Descriptor instead.
 func (*ListBackendsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{45}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{46}
 }
 
 // ListBackendsResponse is the response from listing backends
@@ -3581,7 +3726,7 @@ type ListBackendsResponse struct {
 
 func (x *ListBackendsResponse) Reset() {
 	*x = ListBackendsResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[46]
+	mi := &file_containarium_v1_config_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3593,7 +3738,7 @@ func (x *ListBackendsResponse) String() string {
 func (*ListBackendsResponse) ProtoMessage() {}
 
 func (x *ListBackendsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[46]
+	mi := &file_containarium_v1_config_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3606,7 +3751,7 @@ func (x *ListBackendsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackendsResponse.ProtoReflect.Descriptor instead.
 func (*ListBackendsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{46}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *ListBackendsResponse) GetBackends() []*BackendInfo {
@@ -3631,7 +3776,7 @@ type AdvertiseCapacityRequest struct {
 
 func (x *AdvertiseCapacityRequest) Reset() {
 	*x = AdvertiseCapacityRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[47]
+	mi := &file_containarium_v1_config_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3643,7 +3788,7 @@ func (x *AdvertiseCapacityRequest) String() string {
 func (*AdvertiseCapacityRequest) ProtoMessage() {}
 
 func (x *AdvertiseCapacityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[47]
+	mi := &file_containarium_v1_config_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3656,7 +3801,7 @@ func (x *AdvertiseCapacityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdvertiseCapacityRequest.ProtoReflect.Descriptor instead.
 func (*AdvertiseCapacityRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{47}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *AdvertiseCapacityRequest) GetPolicy() *CapacityPolicy {
@@ -3678,7 +3823,7 @@ type AdvertiseCapacityResponse struct {
 
 func (x *AdvertiseCapacityResponse) Reset() {
 	*x = AdvertiseCapacityResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[48]
+	mi := &file_containarium_v1_config_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3690,7 +3835,7 @@ func (x *AdvertiseCapacityResponse) String() string {
 func (*AdvertiseCapacityResponse) ProtoMessage() {}
 
 func (x *AdvertiseCapacityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[48]
+	mi := &file_containarium_v1_config_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3703,7 +3848,7 @@ func (x *AdvertiseCapacityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdvertiseCapacityResponse.ProtoReflect.Descriptor instead.
 func (*AdvertiseCapacityResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{48}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *AdvertiseCapacityResponse) GetHeadroom() *CapacityHeadroom {
@@ -3735,7 +3880,7 @@ type WithdrawCapacityRequest struct {
 
 func (x *WithdrawCapacityRequest) Reset() {
 	*x = WithdrawCapacityRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[49]
+	mi := &file_containarium_v1_config_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3747,7 +3892,7 @@ func (x *WithdrawCapacityRequest) String() string {
 func (*WithdrawCapacityRequest) ProtoMessage() {}
 
 func (x *WithdrawCapacityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[49]
+	mi := &file_containarium_v1_config_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3760,7 +3905,7 @@ func (x *WithdrawCapacityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithdrawCapacityRequest.ProtoReflect.Descriptor instead.
 func (*WithdrawCapacityRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{49}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *WithdrawCapacityRequest) GetDrain() bool {
@@ -3801,7 +3946,7 @@ type WithdrawCapacityResponse struct {
 
 func (x *WithdrawCapacityResponse) Reset() {
 	*x = WithdrawCapacityResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[50]
+	mi := &file_containarium_v1_config_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3813,7 +3958,7 @@ func (x *WithdrawCapacityResponse) String() string {
 func (*WithdrawCapacityResponse) ProtoMessage() {}
 
 func (x *WithdrawCapacityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[50]
+	mi := &file_containarium_v1_config_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3826,7 +3971,7 @@ func (x *WithdrawCapacityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithdrawCapacityResponse.ProtoReflect.Descriptor instead.
 func (*WithdrawCapacityResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{50}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *WithdrawCapacityResponse) GetHeadroom() *CapacityHeadroom {
@@ -3874,7 +4019,7 @@ type GetCapacityHeadroomRequest struct {
 
 func (x *GetCapacityHeadroomRequest) Reset() {
 	*x = GetCapacityHeadroomRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[51]
+	mi := &file_containarium_v1_config_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3886,7 +4031,7 @@ func (x *GetCapacityHeadroomRequest) String() string {
 func (*GetCapacityHeadroomRequest) ProtoMessage() {}
 
 func (x *GetCapacityHeadroomRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[51]
+	mi := &file_containarium_v1_config_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3899,7 +4044,7 @@ func (x *GetCapacityHeadroomRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapacityHeadroomRequest.ProtoReflect.Descriptor instead.
 func (*GetCapacityHeadroomRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{51}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{52}
 }
 
 // GetCapacityHeadroomResponse returns the current headroom snapshot.
@@ -3912,7 +4057,7 @@ type GetCapacityHeadroomResponse struct {
 
 func (x *GetCapacityHeadroomResponse) Reset() {
 	*x = GetCapacityHeadroomResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[52]
+	mi := &file_containarium_v1_config_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3924,7 +4069,7 @@ func (x *GetCapacityHeadroomResponse) String() string {
 func (*GetCapacityHeadroomResponse) ProtoMessage() {}
 
 func (x *GetCapacityHeadroomResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[52]
+	mi := &file_containarium_v1_config_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3937,7 +4082,7 @@ func (x *GetCapacityHeadroomResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapacityHeadroomResponse.ProtoReflect.Descriptor instead.
 func (*GetCapacityHeadroomResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{52}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *GetCapacityHeadroomResponse) GetHeadroom() *CapacityHeadroom {
@@ -3966,7 +4111,7 @@ type ProfileBackendRequest struct {
 
 func (x *ProfileBackendRequest) Reset() {
 	*x = ProfileBackendRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[53]
+	mi := &file_containarium_v1_config_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3978,7 +4123,7 @@ func (x *ProfileBackendRequest) String() string {
 func (*ProfileBackendRequest) ProtoMessage() {}
 
 func (x *ProfileBackendRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[53]
+	mi := &file_containarium_v1_config_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3991,7 +4136,7 @@ func (x *ProfileBackendRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileBackendRequest.ProtoReflect.Descriptor instead.
 func (*ProfileBackendRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{53}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *ProfileBackendRequest) GetBackendId() string {
@@ -4020,7 +4165,7 @@ type ProfileBackendResponse struct {
 
 func (x *ProfileBackendResponse) Reset() {
 	*x = ProfileBackendResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[54]
+	mi := &file_containarium_v1_config_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4032,7 +4177,7 @@ func (x *ProfileBackendResponse) String() string {
 func (*ProfileBackendResponse) ProtoMessage() {}
 
 func (x *ProfileBackendResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[54]
+	mi := &file_containarium_v1_config_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4045,7 +4190,7 @@ func (x *ProfileBackendResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileBackendResponse.ProtoReflect.Descriptor instead.
 func (*ProfileBackendResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{54}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *ProfileBackendResponse) GetProfile() *CapabilityProfile {
@@ -4074,7 +4219,7 @@ type GetCapabilityProfileRequest struct {
 
 func (x *GetCapabilityProfileRequest) Reset() {
 	*x = GetCapabilityProfileRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[55]
+	mi := &file_containarium_v1_config_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4086,7 +4231,7 @@ func (x *GetCapabilityProfileRequest) String() string {
 func (*GetCapabilityProfileRequest) ProtoMessage() {}
 
 func (x *GetCapabilityProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[55]
+	mi := &file_containarium_v1_config_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4099,7 +4244,7 @@ func (x *GetCapabilityProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapabilityProfileRequest.ProtoReflect.Descriptor instead.
 func (*GetCapabilityProfileRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{55}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *GetCapabilityProfileRequest) GetBackendId() string {
@@ -4122,7 +4267,7 @@ type GetCapabilityProfileResponse struct {
 
 func (x *GetCapabilityProfileResponse) Reset() {
 	*x = GetCapabilityProfileResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[56]
+	mi := &file_containarium_v1_config_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4134,7 +4279,7 @@ func (x *GetCapabilityProfileResponse) String() string {
 func (*GetCapabilityProfileResponse) ProtoMessage() {}
 
 func (x *GetCapabilityProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[56]
+	mi := &file_containarium_v1_config_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4147,7 +4292,7 @@ func (x *GetCapabilityProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapabilityProfileResponse.ProtoReflect.Descriptor instead.
 func (*GetCapabilityProfileResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{56}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *GetCapabilityProfileResponse) GetProfile() *CapabilityProfile {
@@ -4216,7 +4361,7 @@ type SelfMeasurement struct {
 
 func (x *SelfMeasurement) Reset() {
 	*x = SelfMeasurement{}
-	mi := &file_containarium_v1_config_proto_msgTypes[57]
+	mi := &file_containarium_v1_config_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4228,7 +4373,7 @@ func (x *SelfMeasurement) String() string {
 func (*SelfMeasurement) ProtoMessage() {}
 
 func (x *SelfMeasurement) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[57]
+	mi := &file_containarium_v1_config_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4241,7 +4386,7 @@ func (x *SelfMeasurement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SelfMeasurement.ProtoReflect.Descriptor instead.
 func (*SelfMeasurement) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{57}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *SelfMeasurement) GetHashAlgorithm() string {
@@ -4342,7 +4487,7 @@ type ProgramDigest struct {
 
 func (x *ProgramDigest) Reset() {
 	*x = ProgramDigest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[58]
+	mi := &file_containarium_v1_config_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4354,7 +4499,7 @@ func (x *ProgramDigest) String() string {
 func (*ProgramDigest) ProtoMessage() {}
 
 func (x *ProgramDigest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[58]
+	mi := &file_containarium_v1_config_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4367,7 +4512,7 @@ func (x *ProgramDigest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProgramDigest.ProtoReflect.Descriptor instead.
 func (*ProgramDigest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{58}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *ProgramDigest) GetName() string {
@@ -4397,7 +4542,7 @@ type GetSelfMeasurementRequest struct {
 
 func (x *GetSelfMeasurementRequest) Reset() {
 	*x = GetSelfMeasurementRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[59]
+	mi := &file_containarium_v1_config_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4409,7 +4554,7 @@ func (x *GetSelfMeasurementRequest) String() string {
 func (*GetSelfMeasurementRequest) ProtoMessage() {}
 
 func (x *GetSelfMeasurementRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[59]
+	mi := &file_containarium_v1_config_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4422,7 +4567,7 @@ func (x *GetSelfMeasurementRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSelfMeasurementRequest.ProtoReflect.Descriptor instead.
 func (*GetSelfMeasurementRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{59}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *GetSelfMeasurementRequest) GetBackendId() string {
@@ -4444,7 +4589,7 @@ type GetSelfMeasurementResponse struct {
 
 func (x *GetSelfMeasurementResponse) Reset() {
 	*x = GetSelfMeasurementResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[60]
+	mi := &file_containarium_v1_config_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4456,7 +4601,7 @@ func (x *GetSelfMeasurementResponse) String() string {
 func (*GetSelfMeasurementResponse) ProtoMessage() {}
 
 func (x *GetSelfMeasurementResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[60]
+	mi := &file_containarium_v1_config_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4469,7 +4614,7 @@ func (x *GetSelfMeasurementResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSelfMeasurementResponse.ProtoReflect.Descriptor instead.
 func (*GetSelfMeasurementResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{60}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *GetSelfMeasurementResponse) GetMeasurement() *SelfMeasurement {
@@ -4620,7 +4765,7 @@ const file_containarium_v1_config_proto_rawDesc = "" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12'\n" +
 	"\x0fcurrent_version\x18\x02 \x01(\tR\x0ecurrentVersion\x12\x14\n" +
 	"\x05error\x18\x03 \x01(\tR\x05error\x12!\n" +
-	"\fcompleted_at\x18\x04 \x01(\tR\vcompletedAt\"\xdd\x02\n" +
+	"\fcompleted_at\x18\x04 \x01(\tR\vcompletedAt\"\xfb\x03\n" +
 	"\rNetworkPolicy\x12\x16\n" +
 	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12,\n" +
 	"\x12allow_intra_tenant\x18\x02 \x01(\bR\x10allowIntraTenant\x12!\n" +
@@ -4630,7 +4775,15 @@ const file_containarium_v1_config_proto_rawDesc = "" +
 	"\x0eallow_metadata\x18\x06 \x01(\bR\rallowMetadata\x12\x16\n" +
 	"\x06source\x18\a \x01(\tR\x06source\x12E\n" +
 	"\n" +
-	"deny_rules\x18\b \x03(\v2&.containarium.v1.NetworkPolicyDenyRuleR\tdenyRules\"\x88\x01\n" +
+	"deny_rules\x18\b \x03(\v2&.containarium.v1.NetworkPolicyDenyRuleR\tdenyRules\x12R\n" +
+	"\x0edefault_egress\x18\t \x01(\x0e2+.containarium.v1.NetworkPolicyDefaultActionR\rdefaultEgress\x12H\n" +
+	"\vallow_rules\x18\n" +
+	" \x03(\v2'.containarium.v1.NetworkPolicyAllowRuleR\n" +
+	"allowRules\"V\n" +
+	"\x16NetworkPolicyAllowRule\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\rR\x04port\x12\x14\n" +
+	"\x05proto\x18\x03 \x01(\tR\x05proto\"\x88\x01\n" +
 	"\x15NetworkPolicyDenyRule\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x12\n" +
 	"\x04port\x18\x02 \x01(\rR\x04port\x12\x14\n" +
@@ -4830,7 +4983,11 @@ const file_containarium_v1_config_proto_rawDesc = "" +
 	"\x11NetworkPolicyMode\x12#\n" +
 	"\x1fNETWORK_POLICY_MODE_UNSPECIFIED\x10\x00\x12 \n" +
 	"\x1cNETWORK_POLICY_MODE_LOG_ONLY\x10\x01\x12\x1f\n" +
-	"\x1bNETWORK_POLICY_MODE_ENFORCE\x10\x02*Z\n" +
+	"\x1bNETWORK_POLICY_MODE_ENFORCE\x10\x02*\x9c\x01\n" +
+	"\x1aNetworkPolicyDefaultAction\x12-\n" +
+	")NETWORK_POLICY_DEFAULT_ACTION_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"NETWORK_POLICY_DEFAULT_ACTION_DENY\x10\x01\x12'\n" +
+	"#NETWORK_POLICY_DEFAULT_ACTION_ALLOW\x10\x02*Z\n" +
 	"\vBackendType\x12\x1c\n" +
 	"\x18BACKEND_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10BACKEND_TYPE_GCP\x10\x01\x12\x17\n" +
@@ -4848,125 +5005,129 @@ func file_containarium_v1_config_proto_rawDescGZIP() []byte {
 	return file_containarium_v1_config_proto_rawDescData
 }
 
-var file_containarium_v1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_containarium_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
+var file_containarium_v1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_containarium_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_containarium_v1_config_proto_goTypes = []any{
 	(GPUVendor)(0),                               // 0: containarium.v1.GPUVendor
 	(GPUModel)(0),                                // 1: containarium.v1.GPUModel
 	(NetworkPolicyMode)(0),                       // 2: containarium.v1.NetworkPolicyMode
-	(BackendType)(0),                             // 3: containarium.v1.BackendType
-	(ValidateGPUResponse_GPUStatus)(0),           // 4: containarium.v1.ValidateGPUResponse.GPUStatus
-	(*Config)(nil),                               // 5: containarium.v1.Config
-	(*IncusConfig)(nil),                          // 6: containarium.v1.IncusConfig
-	(*NetworkConfig)(nil),                        // 7: containarium.v1.NetworkConfig
-	(*StorageConfig)(nil),                        // 8: containarium.v1.StorageConfig
-	(*SecurityConfig)(nil),                       // 9: containarium.v1.SecurityConfig
-	(*GetConfigRequest)(nil),                     // 10: containarium.v1.GetConfigRequest
-	(*GetConfigResponse)(nil),                    // 11: containarium.v1.GetConfigResponse
-	(*UpdateConfigRequest)(nil),                  // 12: containarium.v1.UpdateConfigRequest
-	(*UpdateConfigResponse)(nil),                 // 13: containarium.v1.UpdateConfigResponse
-	(*SystemInfo)(nil),                           // 14: containarium.v1.SystemInfo
-	(*GPUInfo)(nil),                              // 15: containarium.v1.GPUInfo
-	(*GetSystemInfoRequest)(nil),                 // 16: containarium.v1.GetSystemInfoRequest
-	(*GetSystemInfoResponse)(nil),                // 17: containarium.v1.GetSystemInfoResponse
-	(*GetLatestReleaseRequest)(nil),              // 18: containarium.v1.GetLatestReleaseRequest
-	(*GetLatestReleaseResponse)(nil),             // 19: containarium.v1.GetLatestReleaseResponse
-	(*ValidateGPURequest)(nil),                   // 20: containarium.v1.ValidateGPURequest
-	(*ValidateGPUResponse)(nil),                  // 21: containarium.v1.ValidateGPUResponse
-	(*TriggerUpgradeRequest)(nil),                // 22: containarium.v1.TriggerUpgradeRequest
-	(*TriggerUpgradeResponse)(nil),               // 23: containarium.v1.TriggerUpgradeResponse
-	(*GetUpgradeStatusRequest)(nil),              // 24: containarium.v1.GetUpgradeStatusRequest
-	(*GetUpgradeStatusResponse)(nil),             // 25: containarium.v1.GetUpgradeStatusResponse
-	(*NetworkPolicy)(nil),                        // 26: containarium.v1.NetworkPolicy
-	(*NetworkPolicyDenyRule)(nil),                // 27: containarium.v1.NetworkPolicyDenyRule
-	(*SetNetworkPolicyRequest)(nil),              // 28: containarium.v1.SetNetworkPolicyRequest
-	(*SetNetworkPolicyResponse)(nil),             // 29: containarium.v1.SetNetworkPolicyResponse
-	(*GetNetworkPolicyRequest)(nil),              // 30: containarium.v1.GetNetworkPolicyRequest
-	(*GetNetworkPolicyResponse)(nil),             // 31: containarium.v1.GetNetworkPolicyResponse
-	(*ListNetworkPoliciesRequest)(nil),           // 32: containarium.v1.ListNetworkPoliciesRequest
-	(*ListNetworkPoliciesResponse)(nil),          // 33: containarium.v1.ListNetworkPoliciesResponse
-	(*DeleteNetworkPolicyRequest)(nil),           // 34: containarium.v1.DeleteNetworkPolicyRequest
-	(*DeleteNetworkPolicyResponse)(nil),          // 35: containarium.v1.DeleteNetworkPolicyResponse
-	(*PatchNetworkPolicyDenyRulesRequest)(nil),   // 36: containarium.v1.PatchNetworkPolicyDenyRulesRequest
-	(*NetworkPolicySignature)(nil),               // 37: containarium.v1.NetworkPolicySignature
-	(*SetNetworkPolicySignatureRequest)(nil),     // 38: containarium.v1.SetNetworkPolicySignatureRequest
-	(*SetNetworkPolicySignatureResponse)(nil),    // 39: containarium.v1.SetNetworkPolicySignatureResponse
-	(*ListNetworkPolicySignaturesRequest)(nil),   // 40: containarium.v1.ListNetworkPolicySignaturesRequest
-	(*ListNetworkPolicySignaturesResponse)(nil),  // 41: containarium.v1.ListNetworkPolicySignaturesResponse
-	(*DeleteNetworkPolicySignatureRequest)(nil),  // 42: containarium.v1.DeleteNetworkPolicySignatureRequest
-	(*DeleteNetworkPolicySignatureResponse)(nil), // 43: containarium.v1.DeleteNetworkPolicySignatureResponse
-	(*BackendInfo)(nil),                          // 44: containarium.v1.BackendInfo
-	(*CapabilityProfile)(nil),                    // 45: containarium.v1.CapabilityProfile
-	(*CapabilityBenchmark)(nil),                  // 46: containarium.v1.CapabilityBenchmark
-	(*CapacityHeadroom)(nil),                     // 47: containarium.v1.CapacityHeadroom
-	(*CapacityPolicy)(nil),                       // 48: containarium.v1.CapacityPolicy
-	(*BackendGPU)(nil),                           // 49: containarium.v1.BackendGPU
-	(*ListBackendsRequest)(nil),                  // 50: containarium.v1.ListBackendsRequest
-	(*ListBackendsResponse)(nil),                 // 51: containarium.v1.ListBackendsResponse
-	(*AdvertiseCapacityRequest)(nil),             // 52: containarium.v1.AdvertiseCapacityRequest
-	(*AdvertiseCapacityResponse)(nil),            // 53: containarium.v1.AdvertiseCapacityResponse
-	(*WithdrawCapacityRequest)(nil),              // 54: containarium.v1.WithdrawCapacityRequest
-	(*WithdrawCapacityResponse)(nil),             // 55: containarium.v1.WithdrawCapacityResponse
-	(*GetCapacityHeadroomRequest)(nil),           // 56: containarium.v1.GetCapacityHeadroomRequest
-	(*GetCapacityHeadroomResponse)(nil),          // 57: containarium.v1.GetCapacityHeadroomResponse
-	(*ProfileBackendRequest)(nil),                // 58: containarium.v1.ProfileBackendRequest
-	(*ProfileBackendResponse)(nil),               // 59: containarium.v1.ProfileBackendResponse
-	(*GetCapabilityProfileRequest)(nil),          // 60: containarium.v1.GetCapabilityProfileRequest
-	(*GetCapabilityProfileResponse)(nil),         // 61: containarium.v1.GetCapabilityProfileResponse
-	(*SelfMeasurement)(nil),                      // 62: containarium.v1.SelfMeasurement
-	(*ProgramDigest)(nil),                        // 63: containarium.v1.ProgramDigest
-	(*GetSelfMeasurementRequest)(nil),            // 64: containarium.v1.GetSelfMeasurementRequest
-	(*GetSelfMeasurementResponse)(nil),           // 65: containarium.v1.GetSelfMeasurementResponse
-	nil,                                          // 66: containarium.v1.WithdrawCapacityResponse.FailedEntry
-	(*ResourceLimits)(nil),                       // 67: containarium.v1.ResourceLimits
-	(OSType)(0),                                  // 68: containarium.v1.OSType
+	(NetworkPolicyDefaultAction)(0),              // 3: containarium.v1.NetworkPolicyDefaultAction
+	(BackendType)(0),                             // 4: containarium.v1.BackendType
+	(ValidateGPUResponse_GPUStatus)(0),           // 5: containarium.v1.ValidateGPUResponse.GPUStatus
+	(*Config)(nil),                               // 6: containarium.v1.Config
+	(*IncusConfig)(nil),                          // 7: containarium.v1.IncusConfig
+	(*NetworkConfig)(nil),                        // 8: containarium.v1.NetworkConfig
+	(*StorageConfig)(nil),                        // 9: containarium.v1.StorageConfig
+	(*SecurityConfig)(nil),                       // 10: containarium.v1.SecurityConfig
+	(*GetConfigRequest)(nil),                     // 11: containarium.v1.GetConfigRequest
+	(*GetConfigResponse)(nil),                    // 12: containarium.v1.GetConfigResponse
+	(*UpdateConfigRequest)(nil),                  // 13: containarium.v1.UpdateConfigRequest
+	(*UpdateConfigResponse)(nil),                 // 14: containarium.v1.UpdateConfigResponse
+	(*SystemInfo)(nil),                           // 15: containarium.v1.SystemInfo
+	(*GPUInfo)(nil),                              // 16: containarium.v1.GPUInfo
+	(*GetSystemInfoRequest)(nil),                 // 17: containarium.v1.GetSystemInfoRequest
+	(*GetSystemInfoResponse)(nil),                // 18: containarium.v1.GetSystemInfoResponse
+	(*GetLatestReleaseRequest)(nil),              // 19: containarium.v1.GetLatestReleaseRequest
+	(*GetLatestReleaseResponse)(nil),             // 20: containarium.v1.GetLatestReleaseResponse
+	(*ValidateGPURequest)(nil),                   // 21: containarium.v1.ValidateGPURequest
+	(*ValidateGPUResponse)(nil),                  // 22: containarium.v1.ValidateGPUResponse
+	(*TriggerUpgradeRequest)(nil),                // 23: containarium.v1.TriggerUpgradeRequest
+	(*TriggerUpgradeResponse)(nil),               // 24: containarium.v1.TriggerUpgradeResponse
+	(*GetUpgradeStatusRequest)(nil),              // 25: containarium.v1.GetUpgradeStatusRequest
+	(*GetUpgradeStatusResponse)(nil),             // 26: containarium.v1.GetUpgradeStatusResponse
+	(*NetworkPolicy)(nil),                        // 27: containarium.v1.NetworkPolicy
+	(*NetworkPolicyAllowRule)(nil),               // 28: containarium.v1.NetworkPolicyAllowRule
+	(*NetworkPolicyDenyRule)(nil),                // 29: containarium.v1.NetworkPolicyDenyRule
+	(*SetNetworkPolicyRequest)(nil),              // 30: containarium.v1.SetNetworkPolicyRequest
+	(*SetNetworkPolicyResponse)(nil),             // 31: containarium.v1.SetNetworkPolicyResponse
+	(*GetNetworkPolicyRequest)(nil),              // 32: containarium.v1.GetNetworkPolicyRequest
+	(*GetNetworkPolicyResponse)(nil),             // 33: containarium.v1.GetNetworkPolicyResponse
+	(*ListNetworkPoliciesRequest)(nil),           // 34: containarium.v1.ListNetworkPoliciesRequest
+	(*ListNetworkPoliciesResponse)(nil),          // 35: containarium.v1.ListNetworkPoliciesResponse
+	(*DeleteNetworkPolicyRequest)(nil),           // 36: containarium.v1.DeleteNetworkPolicyRequest
+	(*DeleteNetworkPolicyResponse)(nil),          // 37: containarium.v1.DeleteNetworkPolicyResponse
+	(*PatchNetworkPolicyDenyRulesRequest)(nil),   // 38: containarium.v1.PatchNetworkPolicyDenyRulesRequest
+	(*NetworkPolicySignature)(nil),               // 39: containarium.v1.NetworkPolicySignature
+	(*SetNetworkPolicySignatureRequest)(nil),     // 40: containarium.v1.SetNetworkPolicySignatureRequest
+	(*SetNetworkPolicySignatureResponse)(nil),    // 41: containarium.v1.SetNetworkPolicySignatureResponse
+	(*ListNetworkPolicySignaturesRequest)(nil),   // 42: containarium.v1.ListNetworkPolicySignaturesRequest
+	(*ListNetworkPolicySignaturesResponse)(nil),  // 43: containarium.v1.ListNetworkPolicySignaturesResponse
+	(*DeleteNetworkPolicySignatureRequest)(nil),  // 44: containarium.v1.DeleteNetworkPolicySignatureRequest
+	(*DeleteNetworkPolicySignatureResponse)(nil), // 45: containarium.v1.DeleteNetworkPolicySignatureResponse
+	(*BackendInfo)(nil),                          // 46: containarium.v1.BackendInfo
+	(*CapabilityProfile)(nil),                    // 47: containarium.v1.CapabilityProfile
+	(*CapabilityBenchmark)(nil),                  // 48: containarium.v1.CapabilityBenchmark
+	(*CapacityHeadroom)(nil),                     // 49: containarium.v1.CapacityHeadroom
+	(*CapacityPolicy)(nil),                       // 50: containarium.v1.CapacityPolicy
+	(*BackendGPU)(nil),                           // 51: containarium.v1.BackendGPU
+	(*ListBackendsRequest)(nil),                  // 52: containarium.v1.ListBackendsRequest
+	(*ListBackendsResponse)(nil),                 // 53: containarium.v1.ListBackendsResponse
+	(*AdvertiseCapacityRequest)(nil),             // 54: containarium.v1.AdvertiseCapacityRequest
+	(*AdvertiseCapacityResponse)(nil),            // 55: containarium.v1.AdvertiseCapacityResponse
+	(*WithdrawCapacityRequest)(nil),              // 56: containarium.v1.WithdrawCapacityRequest
+	(*WithdrawCapacityResponse)(nil),             // 57: containarium.v1.WithdrawCapacityResponse
+	(*GetCapacityHeadroomRequest)(nil),           // 58: containarium.v1.GetCapacityHeadroomRequest
+	(*GetCapacityHeadroomResponse)(nil),          // 59: containarium.v1.GetCapacityHeadroomResponse
+	(*ProfileBackendRequest)(nil),                // 60: containarium.v1.ProfileBackendRequest
+	(*ProfileBackendResponse)(nil),               // 61: containarium.v1.ProfileBackendResponse
+	(*GetCapabilityProfileRequest)(nil),          // 62: containarium.v1.GetCapabilityProfileRequest
+	(*GetCapabilityProfileResponse)(nil),         // 63: containarium.v1.GetCapabilityProfileResponse
+	(*SelfMeasurement)(nil),                      // 64: containarium.v1.SelfMeasurement
+	(*ProgramDigest)(nil),                        // 65: containarium.v1.ProgramDigest
+	(*GetSelfMeasurementRequest)(nil),            // 66: containarium.v1.GetSelfMeasurementRequest
+	(*GetSelfMeasurementResponse)(nil),           // 67: containarium.v1.GetSelfMeasurementResponse
+	nil,                                          // 68: containarium.v1.WithdrawCapacityResponse.FailedEntry
+	(*ResourceLimits)(nil),                       // 69: containarium.v1.ResourceLimits
+	(OSType)(0),                                  // 70: containarium.v1.OSType
 }
 var file_containarium_v1_config_proto_depIdxs = []int32{
-	6,  // 0: containarium.v1.Config.incus:type_name -> containarium.v1.IncusConfig
-	67, // 1: containarium.v1.Config.default_resources:type_name -> containarium.v1.ResourceLimits
-	7,  // 2: containarium.v1.Config.network:type_name -> containarium.v1.NetworkConfig
-	8,  // 3: containarium.v1.Config.storage:type_name -> containarium.v1.StorageConfig
-	9,  // 4: containarium.v1.Config.security:type_name -> containarium.v1.SecurityConfig
-	68, // 5: containarium.v1.Config.default_os_type:type_name -> containarium.v1.OSType
-	5,  // 6: containarium.v1.GetConfigResponse.config:type_name -> containarium.v1.Config
-	5,  // 7: containarium.v1.UpdateConfigRequest.config:type_name -> containarium.v1.Config
-	5,  // 8: containarium.v1.UpdateConfigResponse.config:type_name -> containarium.v1.Config
-	15, // 9: containarium.v1.SystemInfo.gpus:type_name -> containarium.v1.GPUInfo
+	7,  // 0: containarium.v1.Config.incus:type_name -> containarium.v1.IncusConfig
+	69, // 1: containarium.v1.Config.default_resources:type_name -> containarium.v1.ResourceLimits
+	8,  // 2: containarium.v1.Config.network:type_name -> containarium.v1.NetworkConfig
+	9,  // 3: containarium.v1.Config.storage:type_name -> containarium.v1.StorageConfig
+	10, // 4: containarium.v1.Config.security:type_name -> containarium.v1.SecurityConfig
+	70, // 5: containarium.v1.Config.default_os_type:type_name -> containarium.v1.OSType
+	6,  // 6: containarium.v1.GetConfigResponse.config:type_name -> containarium.v1.Config
+	6,  // 7: containarium.v1.UpdateConfigRequest.config:type_name -> containarium.v1.Config
+	6,  // 8: containarium.v1.UpdateConfigResponse.config:type_name -> containarium.v1.Config
+	16, // 9: containarium.v1.SystemInfo.gpus:type_name -> containarium.v1.GPUInfo
 	0,  // 10: containarium.v1.GPUInfo.vendor:type_name -> containarium.v1.GPUVendor
 	1,  // 11: containarium.v1.GPUInfo.model:type_name -> containarium.v1.GPUModel
-	14, // 12: containarium.v1.GetSystemInfoResponse.info:type_name -> containarium.v1.SystemInfo
-	14, // 13: containarium.v1.GetSystemInfoResponse.peers:type_name -> containarium.v1.SystemInfo
-	4,  // 14: containarium.v1.ValidateGPUResponse.status:type_name -> containarium.v1.ValidateGPUResponse.GPUStatus
+	15, // 12: containarium.v1.GetSystemInfoResponse.info:type_name -> containarium.v1.SystemInfo
+	15, // 13: containarium.v1.GetSystemInfoResponse.peers:type_name -> containarium.v1.SystemInfo
+	5,  // 14: containarium.v1.ValidateGPUResponse.status:type_name -> containarium.v1.ValidateGPUResponse.GPUStatus
 	2,  // 15: containarium.v1.NetworkPolicy.mode:type_name -> containarium.v1.NetworkPolicyMode
-	27, // 16: containarium.v1.NetworkPolicy.deny_rules:type_name -> containarium.v1.NetworkPolicyDenyRule
-	26, // 17: containarium.v1.SetNetworkPolicyRequest.policy:type_name -> containarium.v1.NetworkPolicy
-	26, // 18: containarium.v1.SetNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
-	26, // 19: containarium.v1.GetNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
-	26, // 20: containarium.v1.ListNetworkPoliciesResponse.policies:type_name -> containarium.v1.NetworkPolicy
-	27, // 21: containarium.v1.PatchNetworkPolicyDenyRulesRequest.add:type_name -> containarium.v1.NetworkPolicyDenyRule
-	37, // 22: containarium.v1.SetNetworkPolicySignatureRequest.signature:type_name -> containarium.v1.NetworkPolicySignature
-	37, // 23: containarium.v1.SetNetworkPolicySignatureResponse.signature:type_name -> containarium.v1.NetworkPolicySignature
-	37, // 24: containarium.v1.ListNetworkPolicySignaturesResponse.signatures:type_name -> containarium.v1.NetworkPolicySignature
-	49, // 25: containarium.v1.BackendInfo.gpus:type_name -> containarium.v1.BackendGPU
-	47, // 26: containarium.v1.BackendInfo.headroom:type_name -> containarium.v1.CapacityHeadroom
-	45, // 27: containarium.v1.BackendInfo.capability_profile:type_name -> containarium.v1.CapabilityProfile
-	46, // 28: containarium.v1.CapabilityProfile.benchmark:type_name -> containarium.v1.CapabilityBenchmark
-	48, // 29: containarium.v1.CapacityHeadroom.policy:type_name -> containarium.v1.CapacityPolicy
-	44, // 30: containarium.v1.ListBackendsResponse.backends:type_name -> containarium.v1.BackendInfo
-	48, // 31: containarium.v1.AdvertiseCapacityRequest.policy:type_name -> containarium.v1.CapacityPolicy
-	47, // 32: containarium.v1.AdvertiseCapacityResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
-	47, // 33: containarium.v1.WithdrawCapacityResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
-	66, // 34: containarium.v1.WithdrawCapacityResponse.failed:type_name -> containarium.v1.WithdrawCapacityResponse.FailedEntry
-	47, // 35: containarium.v1.GetCapacityHeadroomResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
-	45, // 36: containarium.v1.ProfileBackendResponse.profile:type_name -> containarium.v1.CapabilityProfile
-	45, // 37: containarium.v1.GetCapabilityProfileResponse.profile:type_name -> containarium.v1.CapabilityProfile
-	63, // 38: containarium.v1.SelfMeasurement.program_digests:type_name -> containarium.v1.ProgramDigest
-	62, // 39: containarium.v1.GetSelfMeasurementResponse.measurement:type_name -> containarium.v1.SelfMeasurement
-	40, // [40:40] is the sub-list for method output_type
-	40, // [40:40] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	29, // 16: containarium.v1.NetworkPolicy.deny_rules:type_name -> containarium.v1.NetworkPolicyDenyRule
+	3,  // 17: containarium.v1.NetworkPolicy.default_egress:type_name -> containarium.v1.NetworkPolicyDefaultAction
+	28, // 18: containarium.v1.NetworkPolicy.allow_rules:type_name -> containarium.v1.NetworkPolicyAllowRule
+	27, // 19: containarium.v1.SetNetworkPolicyRequest.policy:type_name -> containarium.v1.NetworkPolicy
+	27, // 20: containarium.v1.SetNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
+	27, // 21: containarium.v1.GetNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
+	27, // 22: containarium.v1.ListNetworkPoliciesResponse.policies:type_name -> containarium.v1.NetworkPolicy
+	29, // 23: containarium.v1.PatchNetworkPolicyDenyRulesRequest.add:type_name -> containarium.v1.NetworkPolicyDenyRule
+	39, // 24: containarium.v1.SetNetworkPolicySignatureRequest.signature:type_name -> containarium.v1.NetworkPolicySignature
+	39, // 25: containarium.v1.SetNetworkPolicySignatureResponse.signature:type_name -> containarium.v1.NetworkPolicySignature
+	39, // 26: containarium.v1.ListNetworkPolicySignaturesResponse.signatures:type_name -> containarium.v1.NetworkPolicySignature
+	51, // 27: containarium.v1.BackendInfo.gpus:type_name -> containarium.v1.BackendGPU
+	49, // 28: containarium.v1.BackendInfo.headroom:type_name -> containarium.v1.CapacityHeadroom
+	47, // 29: containarium.v1.BackendInfo.capability_profile:type_name -> containarium.v1.CapabilityProfile
+	48, // 30: containarium.v1.CapabilityProfile.benchmark:type_name -> containarium.v1.CapabilityBenchmark
+	50, // 31: containarium.v1.CapacityHeadroom.policy:type_name -> containarium.v1.CapacityPolicy
+	46, // 32: containarium.v1.ListBackendsResponse.backends:type_name -> containarium.v1.BackendInfo
+	50, // 33: containarium.v1.AdvertiseCapacityRequest.policy:type_name -> containarium.v1.CapacityPolicy
+	49, // 34: containarium.v1.AdvertiseCapacityResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
+	49, // 35: containarium.v1.WithdrawCapacityResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
+	68, // 36: containarium.v1.WithdrawCapacityResponse.failed:type_name -> containarium.v1.WithdrawCapacityResponse.FailedEntry
+	49, // 37: containarium.v1.GetCapacityHeadroomResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
+	47, // 38: containarium.v1.ProfileBackendResponse.profile:type_name -> containarium.v1.CapabilityProfile
+	47, // 39: containarium.v1.GetCapabilityProfileResponse.profile:type_name -> containarium.v1.CapabilityProfile
+	65, // 40: containarium.v1.SelfMeasurement.program_digests:type_name -> containarium.v1.ProgramDigest
+	64, // 41: containarium.v1.GetSelfMeasurementResponse.measurement:type_name -> containarium.v1.SelfMeasurement
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_config_proto_init() }
@@ -4980,8 +5141,8 @@ func file_containarium_v1_config_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_config_proto_rawDesc), len(file_containarium_v1_config_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   62,
+			NumEnums:      6,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -259,6 +259,124 @@ func (DeletePolicy) EnumDescriptor() ([]byte, []int) {
 	return file_containarium_v1_container_proto_rawDescGZIP(), []int{3}
 }
 
+// ConnectivityStage is where a connectivity test stopped
+type ConnectivityStage int32
+
+const (
+	// The target answered
+	ConnectivityStage_CONNECTIVITY_STAGE_UNSPECIFIED ConnectivityStage = 0
+	// The egress network policy drops traffic to the target; not dialed
+	ConnectivityStage_CONNECTIVITY_STAGE_POLICY  ConnectivityStage = 1
+	ConnectivityStage_CONNECTIVITY_STAGE_DNS     ConnectivityStage = 2
+	ConnectivityStage_CONNECTIVITY_STAGE_CONNECT ConnectivityStage = 3
+	ConnectivityStage_CONNECTIVITY_STAGE_TLS     ConnectivityStage = 4
+	ConnectivityStage_CONNECTIVITY_STAGE_HTTP    ConnectivityStage = 5
+	// The probe itself couldn't run, e.g. the image lacks curl
+	ConnectivityStage_CONNECTIVITY_STAGE_PROBE ConnectivityStage = 6
+)
+
+// Enum value maps for ConnectivityStage.
+var (
+	ConnectivityStage_name = map[int32]string{
+		0: "CONNECTIVITY_STAGE_UNSPECIFIED",
+		1: "CONNECTIVITY_STAGE_POLICY",
+		2: "CONNECTIVITY_STAGE_DNS",
+		3: "CONNECTIVITY_STAGE_CONNECT",
+		4: "CONNECTIVITY_STAGE_TLS",
+		5: "CONNECTIVITY_STAGE_HTTP",
+		6: "CONNECTIVITY_STAGE_PROBE",
+	}
+	ConnectivityStage_value = map[string]int32{
+		"CONNECTIVITY_STAGE_UNSPECIFIED": 0,
+		"CONNECTIVITY_STAGE_POLICY":      1,
+		"CONNECTIVITY_STAGE_DNS":         2,
+		"CONNECTIVITY_STAGE_CONNECT":     3,
+		"CONNECTIVITY_STAGE_TLS":         4,
+		"CONNECTIVITY_STAGE_HTTP":        5,
+		"CONNECTIVITY_STAGE_PROBE":       6,
+	}
+)
+
+func (x ConnectivityStage) Enum() *ConnectivityStage {
+	p := new(ConnectivityStage)
+	*p = x
+	return p
+}
+
+func (x ConnectivityStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectivityStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_container_proto_enumTypes[4].Descriptor()
+}
+
+func (ConnectivityStage) Type() protoreflect.EnumType {
+	return &file_containarium_v1_container_proto_enumTypes[4]
+}
+
+func (x ConnectivityStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectivityStage.Descriptor instead.
+func (ConnectivityStage) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{4}
+}
+
+// ProvisionStepState is the progress of one provisioning step
+type ProvisionStepState int32
+
+const (
+	ProvisionStepState_PROVISION_STEP_STATE_UNSPECIFIED ProvisionStepState = 0
+	ProvisionStepState_PROVISION_STEP_STATE_RUNNING     ProvisionStepState = 1
+	ProvisionStepState_PROVISION_STEP_STATE_DONE        ProvisionStepState = 2
+	ProvisionStepState_PROVISION_STEP_STATE_FAILED      ProvisionStepState = 3
+)
+
+// Enum value maps for ProvisionStepState.
+var (
+	ProvisionStepState_name = map[int32]string{
+		0: "PROVISION_STEP_STATE_UNSPECIFIED",
+		1: "PROVISION_STEP_STATE_RUNNING",
+		2: "PROVISION_STEP_STATE_DONE",
+		3: "PROVISION_STEP_STATE_FAILED",
+	}
+	ProvisionStepState_value = map[string]int32{
+		"PROVISION_STEP_STATE_UNSPECIFIED": 0,
+		"PROVISION_STEP_STATE_RUNNING":     1,
+		"PROVISION_STEP_STATE_DONE":        2,
+		"PROVISION_STEP_STATE_FAILED":      3,
+	}
+)
+
+func (x ProvisionStepState) Enum() *ProvisionStepState {
+	p := new(ProvisionStepState)
+	*p = x
+	return p
+}
+
+func (x ProvisionStepState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProvisionStepState) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_container_proto_enumTypes[5].Descriptor()
+}
+
+func (ProvisionStepState) Type() protoreflect.EnumType {
+	return &file_containarium_v1_container_proto_enumTypes[5]
+}
+
+func (x ProvisionStepState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProvisionStepState.Descriptor instead.
+func (ProvisionStepState) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{5}
+}
+
 // CloudMetricsProvider identifies which host cloud's native monitoring
 // receives the opt-in export (#1069). Typed so the toggle can never be a
 // magic string. AWS is reserved for a future Sink implementation;
@@ -303,11 +421,11 @@ func (x CloudMetricsProvider) String() string {
 }
 
 func (CloudMetricsProvider) Descriptor() protoreflect.EnumDescriptor {
-	return file_containarium_v1_container_proto_enumTypes[4].Descriptor()
+	return file_containarium_v1_container_proto_enumTypes[6].Descriptor()
 }
 
 func (CloudMetricsProvider) Type() protoreflect.EnumType {
-	return &file_containarium_v1_container_proto_enumTypes[4]
+	return &file_containarium_v1_container_proto_enumTypes[6]
 }
 
 func (x CloudMetricsProvider) Number() protoreflect.EnumNumber {
@@ -316,7 +434,7 @@ func (x CloudMetricsProvider) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CloudMetricsProvider.Descriptor instead.
 func (CloudMetricsProvider) EnumDescriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{4}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{6}
 }
 
 // CloudMetricsGroup names an independently enableable set of exported
@@ -375,11 +493,11 @@ func (x CloudMetricsGroup) String() string {
 }
 
 func (CloudMetricsGroup) Descriptor() protoreflect.EnumDescriptor {
-	return file_containarium_v1_container_proto_enumTypes[5].Descriptor()
+	return file_containarium_v1_container_proto_enumTypes[7].Descriptor()
 }
 
 func (CloudMetricsGroup) Type() protoreflect.EnumType {
-	return &file_containarium_v1_container_proto_enumTypes[5]
+	return &file_containarium_v1_container_proto_enumTypes[7]
 }
 
 func (x CloudMetricsGroup) Number() protoreflect.EnumNumber {
@@ -388,7 +506,7 @@ func (x CloudMetricsGroup) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CloudMetricsGroup.Descriptor instead.
 func (CloudMetricsGroup) EnumDescriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{5}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{7}
 }
 
 // ResourceLimits defines resource constraints for a container
@@ -910,9 +1028,16 @@ type ContainerMetrics struct {
 	// Network bytes transmitted (all interfaces except loopback)
 	NetworkTxBytes int64 `protobuf:"varint,7,opt,name=network_tx_bytes,json=networkTxBytes,proto3" json:"network_tx_bytes,omitempty"`
 	// Number of running processes in container
-	ProcessCount  int32 `protobuf:"varint,8,opt,name=process_count,json=processCount,proto3" json:"process_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ProcessCount int32 `protobuf:"varint,8,opt,name=process_count,json=processCount,proto3" json:"process_count,omitempty"`
+	// Bytes the container sent since it last started, summed from the
+	// traffic store's connection history. 0 when traffic persistence is off
+	// or the caller lacks the traffic:read scope.
+	NetworkBytesSent int64 `protobuf:"varint,9,opt,name=network_bytes_sent,json=networkBytesSent,proto3" json:"network_bytes_sent,omitempty"`
+	// Bytes the container received since it last started (see
+	// network_bytes_sent).
+	NetworkBytesReceived int64 `protobuf:"varint,10,opt,name=network_bytes_received,json=networkBytesReceived,proto3" json:"network_bytes_received,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *ContainerMetrics) Reset() {
@@ -1001,6 +1126,20 @@ func (x *ContainerMetrics) GetProcessCount() int32 {
 	return 0
 }
 
+func (x *ContainerMetrics) GetNetworkBytesSent() int64 {
+	if x != nil {
+		return x.NetworkBytesSent
+	}
+	return 0
+}
+
+func (x *ContainerMetrics) GetNetworkBytesReceived() int64 {
+	if x != nil {
+		return x.NetworkBytesReceived
+	}
+	return 0
+}
+
 // CreateContainerRequest is the request to create a new container
 type CreateContainerRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1108,7 +1247,14 @@ type CreateContainerRequest struct {
 	// singular `gpu` field: when non-empty `gpu` is ignored; when empty a
 	// non-empty `gpu` is treated as a single-element list. Each device is
 	// resolved to a stable PCI address at create time.
-	Gpus          []string `protobuf:"bytes,23,rep,name=gpus,proto3" json:"gpus,omitempty"`
+	Gpus []string `protobuf:"bytes,23,rep,name=gpus,proto3" json:"gpus,omitempty"`
+	// Name of a container template (see ListContainerTemplates) to start
+	// from. The template fills in whatever the request leaves empty:
+	// request fields always take precedence, labels merge key by key, and
+	// a template's enable_podman can't be turned off by the request. The
+	// template's cloud-init runs on first boot and its routes are added
+	// once the box is up. Recorded in the audit log.
+	Template      string `protobuf:"bytes,24,opt,name=template,proto3" json:"template,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1304,6 +1450,13 @@ func (x *CreateContainerRequest) GetGpus() []string {
 	return nil
 }
 
+func (x *CreateContainerRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
 // CreateContainerResponse is the response from creating a container
 type CreateContainerResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1548,9 +1701,11 @@ type GetContainerResponse struct {
 	// The requested container
 	Container *Container `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
 	// Current metrics for the container (optional)
-	Metrics       *ContainerMetrics `protobuf:"bytes,2,opt,name=metrics,proto3" json:"metrics,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Metrics *ContainerMetrics `protobuf:"bytes,2,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	// Bandwidth limit in force on the container, if any
+	BandwidthLimit *BandwidthLimit `protobuf:"bytes,3,opt,name=bandwidth_limit,json=bandwidthLimit,proto3" json:"bandwidth_limit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetContainerResponse) Reset() {
@@ -1597,6 +1752,13 @@ func (x *GetContainerResponse) GetMetrics() *ContainerMetrics {
 	return nil
 }
 
+func (x *GetContainerResponse) GetBandwidthLimit() *BandwidthLimit {
+	if x != nil {
+		return x.BandwidthLimit
+	}
+	return nil
+}
+
 // DebugContainerRequest is the request to debug a container's SSH path
 type DebugContainerRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1786,8 +1948,15 @@ type DeleteContainerRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Username of the container to delete
 	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	// Force delete even if container is running
-	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// Force delete even if container is running. Also continues the teardown
+	// past dependent resources that fail to be removed; those are reported in
+	// DeleteContainerResponse.left_behind instead of aborting the delete.
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// Validate and plan the delete without changing anything. The response's
+	// removed lists what the delete would remove, in teardown order (the
+	// container and its host account included); left_behind lists registries
+	// a forced delete could not enumerate.
+	DryRun        bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1836,6 +2005,13 @@ func (x *DeleteContainerRequest) GetForce() bool {
 	return false
 }
 
+func (x *DeleteContainerRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 // DeleteContainerResponse is the response from deleting a container
 type DeleteContainerResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1843,6 +2019,14 @@ type DeleteContainerResponse struct {
 	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	// Name of the deleted container
 	ContainerName string `protobuf:"bytes,2,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Dependent resources the teardown removed, in teardown order.
+	Removed []*TeardownItem `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+	// Dependent resources the teardown could not remove. Only non-empty for a
+	// forced delete, or for steps that run after the container itself is gone.
+	// `containarium gc` finds and retries them.
+	LeftBehind []*TeardownItem `protobuf:"bytes,4,rep,name=left_behind,json=leftBehind,proto3" json:"left_behind,omitempty"`
+	// Echoes the request's dry_run.
+	DryRun        bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1891,6 +2075,205 @@ func (x *DeleteContainerResponse) GetContainerName() string {
 	return ""
 }
 
+func (x *DeleteContainerResponse) GetRemoved() []*TeardownItem {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *DeleteContainerResponse) GetLeftBehind() []*TeardownItem {
+	if x != nil {
+		return x.LeftBehind
+	}
+	return nil
+}
+
+func (x *DeleteContainerResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// TeardownItem is one resource that depends on a container: a Caddy route,
+// a passthrough route, a collaborator, or a host (sshpiper) account.
+type TeardownItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resource kind: "route", "passthrough", "collaborator", "container" or
+	// "host-account".
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Resource identifier within its kind (domain, port/protocol, account).
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Container the resource belongs to.
+	ContainerName string `protobuf:"bytes,3,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Why the resource could not be removed; empty on success.
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeardownItem) Reset() {
+	*x = TeardownItem{}
+	mi := &file_containarium_v1_container_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeardownItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeardownItem) ProtoMessage() {}
+
+func (x *TeardownItem) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeardownItem.ProtoReflect.Descriptor instead.
+func (*TeardownItem) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TeardownItem) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *TeardownItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TeardownItem) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *TeardownItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GarbageCollectRequest asks the daemon to find dependent resources whose
+// container no longer exists.
+type GarbageCollectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only report orphans; remove nothing.
+	DryRun        bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GarbageCollectRequest) Reset() {
+	*x = GarbageCollectRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GarbageCollectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GarbageCollectRequest) ProtoMessage() {}
+
+func (x *GarbageCollectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GarbageCollectRequest.ProtoReflect.Descriptor instead.
+func (*GarbageCollectRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GarbageCollectRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// GarbageCollectResponse lists the orphaned dependents found.
+type GarbageCollectResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Orphaned resources. Without dry_run each was removed unless its error
+	// is set.
+	Orphans []*TeardownItem `protobuf:"bytes,1,rep,name=orphans,proto3" json:"orphans,omitempty"`
+	// Echoes the request's dry_run.
+	DryRun        bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GarbageCollectResponse) Reset() {
+	*x = GarbageCollectResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GarbageCollectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GarbageCollectResponse) ProtoMessage() {}
+
+func (x *GarbageCollectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GarbageCollectResponse.ProtoReflect.Descriptor instead.
+func (*GarbageCollectResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GarbageCollectResponse) GetOrphans() []*TeardownItem {
+	if x != nil {
+		return x.Orphans
+	}
+	return nil
+}
+
+func (x *GarbageCollectResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 // StartContainerRequest is the request to start a container
 type StartContainerRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1912,7 +2295,7 @@ type StartContainerRequest struct {
 
 func (x *StartContainerRequest) Reset() {
 	*x = StartContainerRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[14]
+	mi := &file_containarium_v1_container_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1924,7 +2307,7 @@ func (x *StartContainerRequest) String() string {
 func (*StartContainerRequest) ProtoMessage() {}
 
 func (x *StartContainerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[14]
+	mi := &file_containarium_v1_container_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1937,7 +2320,7 @@ func (x *StartContainerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartContainerRequest.ProtoReflect.Descriptor instead.
 func (*StartContainerRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{14}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *StartContainerRequest) GetUsername() string {
@@ -1979,7 +2362,7 @@ type StartContainerResponse struct {
 
 func (x *StartContainerResponse) Reset() {
 	*x = StartContainerResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[15]
+	mi := &file_containarium_v1_container_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1991,7 +2374,7 @@ func (x *StartContainerResponse) String() string {
 func (*StartContainerResponse) ProtoMessage() {}
 
 func (x *StartContainerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[15]
+	mi := &file_containarium_v1_container_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2004,7 +2387,7 @@ func (x *StartContainerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartContainerResponse.ProtoReflect.Descriptor instead.
 func (*StartContainerResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{15}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *StartContainerResponse) GetMessage() string {
@@ -2043,7 +2426,7 @@ type StopContainerRequest struct {
 
 func (x *StopContainerRequest) Reset() {
 	*x = StopContainerRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[16]
+	mi := &file_containarium_v1_container_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2055,7 +2438,7 @@ func (x *StopContainerRequest) String() string {
 func (*StopContainerRequest) ProtoMessage() {}
 
 func (x *StopContainerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[16]
+	mi := &file_containarium_v1_container_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2068,7 +2451,7 @@ func (x *StopContainerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopContainerRequest.ProtoReflect.Descriptor instead.
 func (*StopContainerRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{16}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *StopContainerRequest) GetUsername() string {
@@ -2105,7 +2488,7 @@ type StopContainerResponse struct {
 
 func (x *StopContainerResponse) Reset() {
 	*x = StopContainerResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[17]
+	mi := &file_containarium_v1_container_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2117,7 +2500,7 @@ func (x *StopContainerResponse) String() string {
 func (*StopContainerResponse) ProtoMessage() {}
 
 func (x *StopContainerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[17]
+	mi := &file_containarium_v1_container_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2130,7 +2513,7 @@ func (x *StopContainerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopContainerResponse.ProtoReflect.Descriptor instead.
 func (*StopContainerResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{17}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *StopContainerResponse) GetMessage() string {
@@ -2165,7 +2548,7 @@ type ToggleMonitoringRequest struct {
 
 func (x *ToggleMonitoringRequest) Reset() {
 	*x = ToggleMonitoringRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[18]
+	mi := &file_containarium_v1_container_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2177,7 +2560,7 @@ func (x *ToggleMonitoringRequest) String() string {
 func (*ToggleMonitoringRequest) ProtoMessage() {}
 
 func (x *ToggleMonitoringRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[18]
+	mi := &file_containarium_v1_container_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2190,7 +2573,7 @@ func (x *ToggleMonitoringRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleMonitoringRequest.ProtoReflect.Descriptor instead.
 func (*ToggleMonitoringRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{18}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ToggleMonitoringRequest) GetUsername() string {
@@ -2223,7 +2606,7 @@ type ToggleMonitoringResponse struct {
 
 func (x *ToggleMonitoringResponse) Reset() {
 	*x = ToggleMonitoringResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[19]
+	mi := &file_containarium_v1_container_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2235,7 +2618,7 @@ func (x *ToggleMonitoringResponse) String() string {
 func (*ToggleMonitoringResponse) ProtoMessage() {}
 
 func (x *ToggleMonitoringResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[19]
+	mi := &file_containarium_v1_container_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2248,7 +2631,7 @@ func (x *ToggleMonitoringResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleMonitoringResponse.ProtoReflect.Descriptor instead.
 func (*ToggleMonitoringResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{19}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ToggleMonitoringResponse) GetMessage() string {
@@ -2286,7 +2669,7 @@ type ToggleAutoSleepRequest struct {
 
 func (x *ToggleAutoSleepRequest) Reset() {
 	*x = ToggleAutoSleepRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[20]
+	mi := &file_containarium_v1_container_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2298,7 +2681,7 @@ func (x *ToggleAutoSleepRequest) String() string {
 func (*ToggleAutoSleepRequest) ProtoMessage() {}
 
 func (x *ToggleAutoSleepRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[20]
+	mi := &file_containarium_v1_container_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2311,7 +2694,7 @@ func (x *ToggleAutoSleepRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleAutoSleepRequest.ProtoReflect.Descriptor instead.
 func (*ToggleAutoSleepRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{20}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ToggleAutoSleepRequest) GetUsername() string {
@@ -2350,7 +2733,7 @@ type ToggleAutoSleepResponse struct {
 
 func (x *ToggleAutoSleepResponse) Reset() {
 	*x = ToggleAutoSleepResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[21]
+	mi := &file_containarium_v1_container_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2362,7 +2745,7 @@ func (x *ToggleAutoSleepResponse) String() string {
 func (*ToggleAutoSleepResponse) ProtoMessage() {}
 
 func (x *ToggleAutoSleepResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[21]
+	mi := &file_containarium_v1_container_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2375,7 +2758,7 @@ func (x *ToggleAutoSleepResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleAutoSleepResponse.ProtoReflect.Descriptor instead.
 func (*ToggleAutoSleepResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{21}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ToggleAutoSleepResponse) GetMessage() string {
@@ -2399,6 +2782,119 @@ func (x *ToggleAutoSleepResponse) GetIdleThresholdMinutes() int32 {
 	return 0
 }
 
+// ToggleDNSLoggingRequest opts a container into (or out of) DNS query
+// logging. The flag is stored as the Incus user.containarium.dns_log
+// config key.
+type ToggleDNSLoggingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Desired state. true → write user.containarium.dns_log = "true".
+	// false → write "false".
+	Enabled       bool `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleDNSLoggingRequest) Reset() {
+	*x = ToggleDNSLoggingRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleDNSLoggingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleDNSLoggingRequest) ProtoMessage() {}
+
+func (x *ToggleDNSLoggingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleDNSLoggingRequest.ProtoReflect.Descriptor instead.
+func (*ToggleDNSLoggingRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ToggleDNSLoggingRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ToggleDNSLoggingRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// ToggleDNSLoggingResponse reports the effective DNS logging state.
+type ToggleDNSLoggingResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operator-facing summary (e.g. "DNS logging enabled").
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The effective dns_logging_enabled state after the toggle.
+	DnsLoggingEnabled bool `protobuf:"varint,2,opt,name=dns_logging_enabled,json=dnsLoggingEnabled,proto3" json:"dns_logging_enabled,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ToggleDNSLoggingResponse) Reset() {
+	*x = ToggleDNSLoggingResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleDNSLoggingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleDNSLoggingResponse) ProtoMessage() {}
+
+func (x *ToggleDNSLoggingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleDNSLoggingResponse.ProtoReflect.Descriptor instead.
+func (*ToggleDNSLoggingResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ToggleDNSLoggingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ToggleDNSLoggingResponse) GetDnsLoggingEnabled() bool {
+	if x != nil {
+		return x.DnsLoggingEnabled
+	}
+	return false
+}
+
 // SetContainerTTLRequest schedules or clears a container's auto-delete
 // time. The daemon's ttlsweeper goroutine consumes ttl_expires_at and
 // force-deletes once the wall clock crosses it.
@@ -2421,7 +2917,7 @@ type SetContainerTTLRequest struct {
 
 func (x *SetContainerTTLRequest) Reset() {
 	*x = SetContainerTTLRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[22]
+	mi := &file_containarium_v1_container_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2433,7 +2929,7 @@ func (x *SetContainerTTLRequest) String() string {
 func (*SetContainerTTLRequest) ProtoMessage() {}
 
 func (x *SetContainerTTLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[22]
+	mi := &file_containarium_v1_container_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2446,7 +2942,7 @@ func (x *SetContainerTTLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetContainerTTLRequest.ProtoReflect.Descriptor instead.
 func (*SetContainerTTLRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{22}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *SetContainerTTLRequest) GetName() string {
@@ -2475,7 +2971,7 @@ type SetContainerTTLResponse struct {
 
 func (x *SetContainerTTLResponse) Reset() {
 	*x = SetContainerTTLResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[23]
+	mi := &file_containarium_v1_container_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2487,7 +2983,7 @@ func (x *SetContainerTTLResponse) String() string {
 func (*SetContainerTTLResponse) ProtoMessage() {}
 
 func (x *SetContainerTTLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[23]
+	mi := &file_containarium_v1_container_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2500,7 +2996,7 @@ func (x *SetContainerTTLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetContainerTTLResponse.ProtoReflect.Descriptor instead.
 func (*SetContainerTTLResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{23}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *SetContainerTTLResponse) GetTtlExpiresAt() *timestamppb.Timestamp {
@@ -2527,7 +3023,7 @@ type SetContainerDeletePolicyRequest struct {
 
 func (x *SetContainerDeletePolicyRequest) Reset() {
 	*x = SetContainerDeletePolicyRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[24]
+	mi := &file_containarium_v1_container_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2539,7 +3035,7 @@ func (x *SetContainerDeletePolicyRequest) String() string {
 func (*SetContainerDeletePolicyRequest) ProtoMessage() {}
 
 func (x *SetContainerDeletePolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[24]
+	mi := &file_containarium_v1_container_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2552,7 +3048,7 @@ func (x *SetContainerDeletePolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetContainerDeletePolicyRequest.ProtoReflect.Descriptor instead.
 func (*SetContainerDeletePolicyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{24}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *SetContainerDeletePolicyRequest) GetName() string {
@@ -2580,7 +3076,7 @@ type SetContainerDeletePolicyResponse struct {
 
 func (x *SetContainerDeletePolicyResponse) Reset() {
 	*x = SetContainerDeletePolicyResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[25]
+	mi := &file_containarium_v1_container_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2592,7 +3088,7 @@ func (x *SetContainerDeletePolicyResponse) String() string {
 func (*SetContainerDeletePolicyResponse) ProtoMessage() {}
 
 func (x *SetContainerDeletePolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[25]
+	mi := &file_containarium_v1_container_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2605,7 +3101,7 @@ func (x *SetContainerDeletePolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetContainerDeletePolicyResponse.ProtoReflect.Descriptor instead.
 func (*SetContainerDeletePolicyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{25}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SetContainerDeletePolicyResponse) GetDeletePolicy() DeletePolicy {
@@ -2633,7 +3129,7 @@ type SetContainerAttributionRequest struct {
 
 func (x *SetContainerAttributionRequest) Reset() {
 	*x = SetContainerAttributionRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[26]
+	mi := &file_containarium_v1_container_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2645,7 +3141,7 @@ func (x *SetContainerAttributionRequest) String() string {
 func (*SetContainerAttributionRequest) ProtoMessage() {}
 
 func (x *SetContainerAttributionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[26]
+	mi := &file_containarium_v1_container_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2658,7 +3154,7 @@ func (x *SetContainerAttributionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetContainerAttributionRequest.ProtoReflect.Descriptor instead.
 func (*SetContainerAttributionRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{26}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *SetContainerAttributionRequest) GetName() string {
@@ -2686,7 +3182,7 @@ type SetContainerAttributionResponse struct {
 
 func (x *SetContainerAttributionResponse) Reset() {
 	*x = SetContainerAttributionResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[27]
+	mi := &file_containarium_v1_container_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2698,7 +3194,7 @@ func (x *SetContainerAttributionResponse) String() string {
 func (*SetContainerAttributionResponse) ProtoMessage() {}
 
 func (x *SetContainerAttributionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[27]
+	mi := &file_containarium_v1_container_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2711,7 +3207,7 @@ func (x *SetContainerAttributionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetContainerAttributionResponse.ProtoReflect.Descriptor instead.
 func (*SetContainerAttributionResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{27}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *SetContainerAttributionResponse) GetLabels() map[string]string {
@@ -2734,7 +3230,7 @@ type AddSSHKeyRequest struct {
 
 func (x *AddSSHKeyRequest) Reset() {
 	*x = AddSSHKeyRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[28]
+	mi := &file_containarium_v1_container_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2746,7 +3242,7 @@ func (x *AddSSHKeyRequest) String() string {
 func (*AddSSHKeyRequest) ProtoMessage() {}
 
 func (x *AddSSHKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[28]
+	mi := &file_containarium_v1_container_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2759,7 +3255,7 @@ func (x *AddSSHKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddSSHKeyRequest.ProtoReflect.Descriptor instead.
 func (*AddSSHKeyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{28}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *AddSSHKeyRequest) GetUsername() string {
@@ -2789,7 +3285,7 @@ type AddSSHKeyResponse struct {
 
 func (x *AddSSHKeyResponse) Reset() {
 	*x = AddSSHKeyResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[29]
+	mi := &file_containarium_v1_container_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2801,7 +3297,7 @@ func (x *AddSSHKeyResponse) String() string {
 func (*AddSSHKeyResponse) ProtoMessage() {}
 
 func (x *AddSSHKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[29]
+	mi := &file_containarium_v1_container_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2814,7 +3310,7 @@ func (x *AddSSHKeyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddSSHKeyResponse.ProtoReflect.Descriptor instead.
 func (*AddSSHKeyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{29}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *AddSSHKeyResponse) GetMessage() string {
@@ -2844,7 +3340,7 @@ type RemoveSSHKeyRequest struct {
 
 func (x *RemoveSSHKeyRequest) Reset() {
 	*x = RemoveSSHKeyRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[30]
+	mi := &file_containarium_v1_container_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2856,7 +3352,7 @@ func (x *RemoveSSHKeyRequest) String() string {
 func (*RemoveSSHKeyRequest) ProtoMessage() {}
 
 func (x *RemoveSSHKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[30]
+	mi := &file_containarium_v1_container_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2869,7 +3365,7 @@ func (x *RemoveSSHKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveSSHKeyRequest.ProtoReflect.Descriptor instead.
 func (*RemoveSSHKeyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{30}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *RemoveSSHKeyRequest) GetUsername() string {
@@ -2899,7 +3395,7 @@ type RemoveSSHKeyResponse struct {
 
 func (x *RemoveSSHKeyResponse) Reset() {
 	*x = RemoveSSHKeyResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[31]
+	mi := &file_containarium_v1_container_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2911,7 +3407,7 @@ func (x *RemoveSSHKeyResponse) String() string {
 func (*RemoveSSHKeyResponse) ProtoMessage() {}
 
 func (x *RemoveSSHKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[31]
+	mi := &file_containarium_v1_container_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2924,7 +3420,7 @@ func (x *RemoveSSHKeyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveSSHKeyResponse.ProtoReflect.Descriptor instead.
 func (*RemoveSSHKeyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{31}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *RemoveSSHKeyResponse) GetMessage() string {
@@ -2945,14 +3441,19 @@ func (x *RemoveSSHKeyResponse) GetTotalKeys() int32 {
 type GetMetricsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Username of the container (empty for all containers)
-	Username      string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Rank the result, highest first: "cpu" (cumulative CPU seconds) or
+	// "memory" (current usage). Empty keeps the default order.
+	SortBy string `protobuf:"bytes,2,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// Return at most this many containers, after sorting. 0 returns all.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetMetricsRequest) Reset() {
 	*x = GetMetricsRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[32]
+	mi := &file_containarium_v1_container_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2964,7 +3465,7 @@ func (x *GetMetricsRequest) String() string {
 func (*GetMetricsRequest) ProtoMessage() {}
 
 func (x *GetMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[32]
+	mi := &file_containarium_v1_container_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2977,7 +3478,7 @@ func (x *GetMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetricsRequest.ProtoReflect.Descriptor instead.
 func (*GetMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{32}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GetMetricsRequest) GetUsername() string {
@@ -2987,6 +3488,20 @@ func (x *GetMetricsRequest) GetUsername() string {
 	return ""
 }
 
+func (x *GetMetricsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *GetMetricsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 // GetMetricsResponse is the response from getting metrics
 type GetMetricsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2998,7 +3513,7 @@ type GetMetricsResponse struct {
 
 func (x *GetMetricsResponse) Reset() {
 	*x = GetMetricsResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[33]
+	mi := &file_containarium_v1_container_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3010,7 +3525,7 @@ func (x *GetMetricsResponse) String() string {
 func (*GetMetricsResponse) ProtoMessage() {}
 
 func (x *GetMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[33]
+	mi := &file_containarium_v1_container_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3023,7 +3538,7 @@ func (x *GetMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetricsResponse.ProtoReflect.Descriptor instead.
 func (*GetMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{33}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *GetMetricsResponse) GetMetrics() []*ContainerMetrics {
@@ -3051,7 +3566,7 @@ type ResizeContainerRequest struct {
 
 func (x *ResizeContainerRequest) Reset() {
 	*x = ResizeContainerRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[34]
+	mi := &file_containarium_v1_container_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3063,7 +3578,7 @@ func (x *ResizeContainerRequest) String() string {
 func (*ResizeContainerRequest) ProtoMessage() {}
 
 func (x *ResizeContainerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[34]
+	mi := &file_containarium_v1_container_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3076,7 +3591,7 @@ func (x *ResizeContainerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResizeContainerRequest.ProtoReflect.Descriptor instead.
 func (*ResizeContainerRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{34}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ResizeContainerRequest) GetUsername() string {
@@ -3120,7 +3635,7 @@ type ResizeContainerResponse struct {
 
 func (x *ResizeContainerResponse) Reset() {
 	*x = ResizeContainerResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[35]
+	mi := &file_containarium_v1_container_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3132,7 +3647,7 @@ func (x *ResizeContainerResponse) String() string {
 func (*ResizeContainerResponse) ProtoMessage() {}
 
 func (x *ResizeContainerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[35]
+	mi := &file_containarium_v1_container_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3145,7 +3660,7 @@ func (x *ResizeContainerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResizeContainerResponse.ProtoReflect.Descriptor instead.
 func (*ResizeContainerResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{35}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ResizeContainerResponse) GetMessage() string {
@@ -3191,7 +3706,7 @@ type Collaborator struct {
 
 func (x *Collaborator) Reset() {
 	*x = Collaborator{}
-	mi := &file_containarium_v1_container_proto_msgTypes[36]
+	mi := &file_containarium_v1_container_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3203,7 +3718,7 @@ func (x *Collaborator) String() string {
 func (*Collaborator) ProtoMessage() {}
 
 func (x *Collaborator) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[36]
+	mi := &file_containarium_v1_container_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3216,7 +3731,7 @@ func (x *Collaborator) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Collaborator.ProtoReflect.Descriptor instead.
 func (*Collaborator) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{36}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *Collaborator) GetId() string {
@@ -3314,7 +3829,7 @@ type AddCollaboratorRequest struct {
 
 func (x *AddCollaboratorRequest) Reset() {
 	*x = AddCollaboratorRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[37]
+	mi := &file_containarium_v1_container_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3326,7 +3841,7 @@ func (x *AddCollaboratorRequest) String() string {
 func (*AddCollaboratorRequest) ProtoMessage() {}
 
 func (x *AddCollaboratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[37]
+	mi := &file_containarium_v1_container_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3339,7 +3854,7 @@ func (x *AddCollaboratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddCollaboratorRequest.ProtoReflect.Descriptor instead.
 func (*AddCollaboratorRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{37}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *AddCollaboratorRequest) GetOwnerUsername() string {
@@ -3399,7 +3914,7 @@ type AddCollaboratorResponse struct {
 
 func (x *AddCollaboratorResponse) Reset() {
 	*x = AddCollaboratorResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[38]
+	mi := &file_containarium_v1_container_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3411,7 +3926,7 @@ func (x *AddCollaboratorResponse) String() string {
 func (*AddCollaboratorResponse) ProtoMessage() {}
 
 func (x *AddCollaboratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[38]
+	mi := &file_containarium_v1_container_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3424,7 +3939,7 @@ func (x *AddCollaboratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddCollaboratorResponse.ProtoReflect.Descriptor instead.
 func (*AddCollaboratorResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{38}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *AddCollaboratorResponse) GetMessage() string {
@@ -3461,7 +3976,7 @@ type RemoveCollaboratorRequest struct {
 
 func (x *RemoveCollaboratorRequest) Reset() {
 	*x = RemoveCollaboratorRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[39]
+	mi := &file_containarium_v1_container_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3473,7 +3988,7 @@ func (x *RemoveCollaboratorRequest) String() string {
 func (*RemoveCollaboratorRequest) ProtoMessage() {}
 
 func (x *RemoveCollaboratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[39]
+	mi := &file_containarium_v1_container_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3486,7 +4001,7 @@ func (x *RemoveCollaboratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveCollaboratorRequest.ProtoReflect.Descriptor instead.
 func (*RemoveCollaboratorRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{39}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *RemoveCollaboratorRequest) GetOwnerUsername() string {
@@ -3514,7 +4029,7 @@ type RemoveCollaboratorResponse struct {
 
 func (x *RemoveCollaboratorResponse) Reset() {
 	*x = RemoveCollaboratorResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[40]
+	mi := &file_containarium_v1_container_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3526,7 +4041,7 @@ func (x *RemoveCollaboratorResponse) String() string {
 func (*RemoveCollaboratorResponse) ProtoMessage() {}
 
 func (x *RemoveCollaboratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[40]
+	mi := &file_containarium_v1_container_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3539,7 +4054,7 @@ func (x *RemoveCollaboratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveCollaboratorResponse.ProtoReflect.Descriptor instead.
 func (*RemoveCollaboratorResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{40}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *RemoveCollaboratorResponse) GetMessage() string {
@@ -3560,7 +4075,7 @@ type ListCollaboratorsRequest struct {
 
 func (x *ListCollaboratorsRequest) Reset() {
 	*x = ListCollaboratorsRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[41]
+	mi := &file_containarium_v1_container_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3572,7 +4087,7 @@ func (x *ListCollaboratorsRequest) String() string {
 func (*ListCollaboratorsRequest) ProtoMessage() {}
 
 func (x *ListCollaboratorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[41]
+	mi := &file_containarium_v1_container_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3585,7 +4100,7 @@ func (x *ListCollaboratorsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCollaboratorsRequest.ProtoReflect.Descriptor instead.
 func (*ListCollaboratorsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{41}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *ListCollaboratorsRequest) GetOwnerUsername() string {
@@ -3608,7 +4123,7 @@ type ListCollaboratorsResponse struct {
 
 func (x *ListCollaboratorsResponse) Reset() {
 	*x = ListCollaboratorsResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[42]
+	mi := &file_containarium_v1_container_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3620,7 +4135,7 @@ func (x *ListCollaboratorsResponse) String() string {
 func (*ListCollaboratorsResponse) ProtoMessage() {}
 
 func (x *ListCollaboratorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[42]
+	mi := &file_containarium_v1_container_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3633,7 +4148,7 @@ func (x *ListCollaboratorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCollaboratorsResponse.ProtoReflect.Descriptor instead.
 func (*ListCollaboratorsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{42}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *ListCollaboratorsResponse) GetCollaborators() []*Collaborator {
@@ -3661,7 +4176,7 @@ type CleanupDiskRequest struct {
 
 func (x *CleanupDiskRequest) Reset() {
 	*x = CleanupDiskRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[43]
+	mi := &file_containarium_v1_container_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3673,7 +4188,7 @@ func (x *CleanupDiskRequest) String() string {
 func (*CleanupDiskRequest) ProtoMessage() {}
 
 func (x *CleanupDiskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[43]
+	mi := &file_containarium_v1_container_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3686,7 +4201,7 @@ func (x *CleanupDiskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupDiskRequest.ProtoReflect.Descriptor instead.
 func (*CleanupDiskRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{43}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *CleanupDiskRequest) GetUsername() string {
@@ -3711,7 +4226,7 @@ type CleanupDiskResponse struct {
 
 func (x *CleanupDiskResponse) Reset() {
 	*x = CleanupDiskResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[44]
+	mi := &file_containarium_v1_container_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3723,7 +4238,7 @@ func (x *CleanupDiskResponse) String() string {
 func (*CleanupDiskResponse) ProtoMessage() {}
 
 func (x *CleanupDiskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[44]
+	mi := &file_containarium_v1_container_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3736,7 +4251,7 @@ func (x *CleanupDiskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupDiskResponse.ProtoReflect.Descriptor instead.
 func (*CleanupDiskResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{44}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *CleanupDiskResponse) GetMessage() string {
@@ -3760,32 +4275,33 @@ func (x *CleanupDiskResponse) GetContainer() *Container {
 	return nil
 }
 
-// InstallStackRequest is the request to install a stack on a running container
-type InstallStackRequest struct {
+// GetContainerProcessesRequest lists the busiest processes in a container
+type GetContainerProcessesRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Username of the container
 	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	// Stack or base script ID to install (e.g., "nodejs", "clamav")
-	StackId       string `protobuf:"bytes,2,opt,name=stack_id,json=stackId,proto3" json:"stack_id,omitempty"`
+	// Maximum number of processes to return, busiest first (default 20,
+	// max 200)
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InstallStackRequest) Reset() {
-	*x = InstallStackRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[45]
+func (x *GetContainerProcessesRequest) Reset() {
+	*x = GetContainerProcessesRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InstallStackRequest) String() string {
+func (x *GetContainerProcessesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstallStackRequest) ProtoMessage() {}
+func (*GetContainerProcessesRequest) ProtoMessage() {}
 
-func (x *InstallStackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[45]
+func (x *GetContainerProcessesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3796,51 +4312,58 @@ func (x *InstallStackRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstallStackRequest.ProtoReflect.Descriptor instead.
-func (*InstallStackRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use GetContainerProcessesRequest.ProtoReflect.Descriptor instead.
+func (*GetContainerProcessesRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *InstallStackRequest) GetUsername() string {
+func (x *GetContainerProcessesRequest) GetUsername() string {
 	if x != nil {
 		return x.Username
 	}
 	return ""
 }
 
-func (x *InstallStackRequest) GetStackId() string {
+func (x *GetContainerProcessesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.StackId
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-// InstallStackResponse is the response from installing a stack
-type InstallStackResponse struct {
+// ContainerProcess is one process running inside a container, as ps sees it
+type ContainerProcess struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Human-readable result message
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	// Updated container info after installation
-	Container     *Container `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Pid   int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	// User the process runs as
+	User string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// CPU use over the process's lifetime, percent of one core
+	CpuPercent float64 `protobuf:"fixed64,3,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	// Resident memory, percent of the container's memory
+	MemoryPercent float64 `protobuf:"fixed64,4,opt,name=memory_percent,json=memoryPercent,proto3" json:"memory_percent,omitempty"`
+	// Seconds since the process started
+	ElapsedSeconds int64 `protobuf:"varint,5,opt,name=elapsed_seconds,json=elapsedSeconds,proto3" json:"elapsed_seconds,omitempty"`
+	// Executable name (ps comm, at most 15 characters)
+	Command       string `protobuf:"bytes,6,opt,name=command,proto3" json:"command,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InstallStackResponse) Reset() {
-	*x = InstallStackResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[46]
+func (x *ContainerProcess) Reset() {
+	*x = ContainerProcess{}
+	mi := &file_containarium_v1_container_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InstallStackResponse) String() string {
+func (x *ContainerProcess) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstallStackResponse) ProtoMessage() {}
+func (*ContainerProcess) ProtoMessage() {}
 
-func (x *InstallStackResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[46]
+func (x *ContainerProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3851,61 +4374,81 @@ func (x *InstallStackResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstallStackResponse.ProtoReflect.Descriptor instead.
-func (*InstallStackResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use ContainerProcess.ProtoReflect.Descriptor instead.
+func (*ContainerProcess) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *InstallStackResponse) GetMessage() string {
+func (x *ContainerProcess) GetPid() int32 {
 	if x != nil {
-		return x.Message
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ContainerProcess) GetUser() string {
+	if x != nil {
+		return x.User
 	}
 	return ""
 }
 
-func (x *InstallStackResponse) GetContainer() *Container {
+func (x *ContainerProcess) GetCpuPercent() float64 {
 	if x != nil {
-		return x.Container
+		return x.CpuPercent
 	}
-	return nil
+	return 0
 }
 
-// StackParameter describes an input the stack accepts at install time.
-// Returned by ListStacks so the web UI can render appropriate form fields.
-type StackParameter struct {
+func (x *ContainerProcess) GetMemoryPercent() float64 {
+	if x != nil {
+		return x.MemoryPercent
+	}
+	return 0
+}
+
+func (x *ContainerProcess) GetElapsedSeconds() int64 {
+	if x != nil {
+		return x.ElapsedSeconds
+	}
+	return 0
+}
+
+func (x *ContainerProcess) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+// GetContainerProcessesResponse is the response from listing a container's processes
+type GetContainerProcessesResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Parameter key. The env var seen by install scripts is
-	// CONTAINARIUM_STACK_<name>.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Human-readable label shown in the web UI.
-	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	// Optional helper text shown below the field.
-	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	// "string" | "password" | "number" | "boolean"
-	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	// Default value if the user leaves the field blank.
-	Default string `protobuf:"bytes,5,opt,name=default,proto3" json:"default,omitempty"`
-	// Whether the field is required.
-	Required      bool `protobuf:"varint,6,opt,name=required,proto3" json:"required,omitempty"`
+	// Processes ordered by CPU use, highest first
+	Processes []*ContainerProcess `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	// Number of processes in the container (may exceed the ones returned)
+	TotalProcesses int32 `protobuf:"varint,2,opt,name=total_processes,json=totalProcesses,proto3" json:"total_processes,omitempty"`
+	// Container-level CPU and memory usage from Incus, for context
+	Metrics       *ContainerMetrics `protobuf:"bytes,3,opt,name=metrics,proto3" json:"metrics,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StackParameter) Reset() {
-	*x = StackParameter{}
-	mi := &file_containarium_v1_container_proto_msgTypes[47]
+func (x *GetContainerProcessesResponse) Reset() {
+	*x = GetContainerProcessesResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StackParameter) String() string {
+func (x *GetContainerProcessesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StackParameter) ProtoMessage() {}
+func (*GetContainerProcessesResponse) ProtoMessage() {}
 
-func (x *StackParameter) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[47]
+func (x *GetContainerProcessesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3916,80 +4459,136 @@ func (x *StackParameter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StackParameter.ProtoReflect.Descriptor instead.
-func (*StackParameter) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use GetContainerProcessesResponse.ProtoReflect.Descriptor instead.
+func (*GetContainerProcessesResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *StackParameter) GetName() string {
+func (x *GetContainerProcessesResponse) GetProcesses() []*ContainerProcess {
 	if x != nil {
-		return x.Name
+		return x.Processes
 	}
-	return ""
+	return nil
 }
 
-func (x *StackParameter) GetLabel() string {
+func (x *GetContainerProcessesResponse) GetTotalProcesses() int32 {
 	if x != nil {
-		return x.Label
+		return x.TotalProcesses
 	}
-	return ""
+	return 0
 }
 
-func (x *StackParameter) GetDescription() string {
+func (x *GetContainerProcessesResponse) GetMetrics() *ContainerMetrics {
 	if x != nil {
-		return x.Description
+		return x.Metrics
 	}
-	return ""
+	return nil
 }
 
-func (x *StackParameter) GetType() string {
+// ReadContainerFileRequest reads a file inside a container
+type ReadContainerFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Absolute path inside the container. Paths with ".." and paths under
+	// /proc, /sys and /dev are refused.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Most bytes of content to return (default 64 KiB, max 1 MiB); a longer
+	// file comes back truncated
+	MaxBytes      int64 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadContainerFileRequest) Reset() {
+	*x = ReadContainerFileRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadContainerFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadContainerFileRequest) ProtoMessage() {}
+
+func (x *ReadContainerFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[53]
 	if x != nil {
-		return x.Type
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadContainerFileRequest.ProtoReflect.Descriptor instead.
+func (*ReadContainerFileRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ReadContainerFileRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
 	return ""
 }
 
-func (x *StackParameter) GetDefault() string {
+func (x *ReadContainerFileRequest) GetPath() string {
 	if x != nil {
-		return x.Default
+		return x.Path
 	}
 	return ""
 }
 
-func (x *StackParameter) GetRequired() bool {
+func (x *ReadContainerFileRequest) GetMaxBytes() int64 {
 	if x != nil {
-		return x.Required
+		return x.MaxBytes
 	}
-	return false
+	return 0
 }
 
-// StackInfo describes a single software stack.
-type StackInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Icon          string                 `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
-	Parameters    []*StackParameter      `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+// ReadContainerFileResponse is the response from reading a container file
+type ReadContainerFileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The normalized path that was read
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// "file", or "symlink" when the path is a symlink: it is not followed,
+	// and content is the link's target
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Permission bits in octal, e.g. "0644"
+	Mode string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Numeric owner inside the container
+	Uid int64 `protobuf:"varint,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid int64 `protobuf:"varint,5,opt,name=gid,proto3" json:"gid,omitempty"`
+	// The content, at most max_bytes of it
+	Content []byte `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	// The file is longer than max_bytes
+	Truncated bool `protobuf:"varint,7,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// The content is not text: it has NUL bytes or is not valid UTF-8
+	Binary        bool `protobuf:"varint,8,opt,name=binary,proto3" json:"binary,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StackInfo) Reset() {
-	*x = StackInfo{}
-	mi := &file_containarium_v1_container_proto_msgTypes[48]
+func (x *ReadContainerFileResponse) Reset() {
+	*x = ReadContainerFileResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StackInfo) String() string {
+func (x *ReadContainerFileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StackInfo) ProtoMessage() {}
+func (*ReadContainerFileResponse) ProtoMessage() {}
 
-func (x *StackInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[48]
+func (x *ReadContainerFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4000,106 +4599,102 @@ func (x *StackInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StackInfo.ProtoReflect.Descriptor instead.
-func (*StackInfo) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use ReadContainerFileResponse.ProtoReflect.Descriptor instead.
+func (*ReadContainerFileResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *StackInfo) GetId() string {
+func (x *ReadContainerFileResponse) GetPath() string {
 	if x != nil {
-		return x.Id
+		return x.Path
 	}
 	return ""
 }
 
-func (x *StackInfo) GetName() string {
+func (x *ReadContainerFileResponse) GetType() string {
 	if x != nil {
-		return x.Name
+		return x.Type
 	}
 	return ""
 }
 
-func (x *StackInfo) GetDescription() string {
+func (x *ReadContainerFileResponse) GetMode() string {
 	if x != nil {
-		return x.Description
+		return x.Mode
 	}
 	return ""
 }
 
-func (x *StackInfo) GetIcon() string {
+func (x *ReadContainerFileResponse) GetUid() int64 {
 	if x != nil {
-		return x.Icon
+		return x.Uid
 	}
-	return ""
+	return 0
 }
 
-func (x *StackInfo) GetParameters() []*StackParameter {
+func (x *ReadContainerFileResponse) GetGid() int64 {
 	if x != nil {
-		return x.Parameters
+		return x.Gid
 	}
-	return nil
-}
-
-// ListStacksRequest requests the catalog of available stacks.
-type ListStacksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListStacksRequest) Reset() {
-	*x = ListStacksRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[49]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return 0
 }
 
-func (x *ListStacksRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ReadContainerFileResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
 }
 
-func (*ListStacksRequest) ProtoMessage() {}
-
-func (x *ListStacksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[49]
+func (x *ReadContainerFileResponse) GetTruncated() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Truncated
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use ListStacksRequest.ProtoReflect.Descriptor instead.
-func (*ListStacksRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{49}
+func (x *ReadContainerFileResponse) GetBinary() bool {
+	if x != nil {
+		return x.Binary
+	}
+	return false
 }
 
-// ListStacksResponse returns all configured software stacks.
-type ListStacksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Stacks        []*StackInfo           `protobuf:"bytes,1,rep,name=stacks,proto3" json:"stacks,omitempty"`
+// WriteContainerFileRequest writes a file inside a container
+type WriteContainerFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Absolute path inside the container; the parent directory must exist.
+	// Paths with ".." and paths under /proc, /sys and /dev are refused.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// The file's content (at most 1 MiB). An existing file is replaced.
+	Content []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Permission bits in octal, up to 0777, e.g. "0600" (default "0644");
+	// setuid, setgid and sticky bits are refused
+	Mode string `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Owner as numeric "uid" or "uid:gid" inside the container (default
+	// "0:0"; with only a uid, the gid is the same number)
+	Owner         string `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListStacksResponse) Reset() {
-	*x = ListStacksResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[50]
+func (x *WriteContainerFileRequest) Reset() {
+	*x = WriteContainerFileRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListStacksResponse) String() string {
+func (x *WriteContainerFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListStacksResponse) ProtoMessage() {}
+func (*WriteContainerFileRequest) ProtoMessage() {}
 
-func (x *ListStacksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[50]
+func (x *WriteContainerFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4110,83 +4705,76 @@ func (x *ListStacksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListStacksResponse.ProtoReflect.Descriptor instead.
-func (*ListStacksResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use WriteContainerFileRequest.ProtoReflect.Descriptor instead.
+func (*WriteContainerFileRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *ListStacksResponse) GetStacks() []*StackInfo {
+func (x *WriteContainerFileRequest) GetUsername() string {
 	if x != nil {
-		return x.Stacks
+		return x.Username
 	}
-	return nil
+	return ""
 }
 
-// GetMonitoringInfoRequest is the request to get monitoring info
-type GetMonitoringInfoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WriteContainerFileRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
 }
 
-func (x *GetMonitoringInfoRequest) Reset() {
-	*x = GetMonitoringInfoRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[51]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetMonitoringInfoRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *WriteContainerFileRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
 }
 
-func (*GetMonitoringInfoRequest) ProtoMessage() {}
-
-func (x *GetMonitoringInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[51]
+func (x *WriteContainerFileRequest) GetMode() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Mode
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetMonitoringInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetMonitoringInfoRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{51}
+func (x *WriteContainerFileRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
 }
 
-// GetMonitoringInfoResponse is the response with monitoring configuration
-type GetMonitoringInfoResponse struct {
+// WriteContainerFileResponse is the response from writing a container file
+type WriteContainerFileResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Whether monitoring is enabled
-	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	// Grafana URL for dashboard access
-	GrafanaUrl string `protobuf:"bytes,2,opt,name=grafana_url,json=grafanaUrl,proto3" json:"grafana_url,omitempty"`
-	// Victoria Metrics URL for metrics API
-	VictoriaMetricsUrl string `protobuf:"bytes,3,opt,name=victoria_metrics_url,json=victoriaMetricsUrl,proto3" json:"victoria_metrics_url,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// The normalized path that was written
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Bytes written
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Permission bits and owner the file was written with
+	Mode          string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Uid           int64  `protobuf:"varint,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid           int64  `protobuf:"varint,5,opt,name=gid,proto3" json:"gid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMonitoringInfoResponse) Reset() {
-	*x = GetMonitoringInfoResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[52]
+func (x *WriteContainerFileResponse) Reset() {
+	*x = WriteContainerFileResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMonitoringInfoResponse) String() string {
+func (x *WriteContainerFileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMonitoringInfoResponse) ProtoMessage() {}
+func (*WriteContainerFileResponse) ProtoMessage() {}
 
-func (x *GetMonitoringInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[52]
+func (x *WriteContainerFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4197,71 +4785,73 @@ func (x *GetMonitoringInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMonitoringInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetMonitoringInfoResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{52}
+// Deprecated: Use WriteContainerFileResponse.ProtoReflect.Descriptor instead.
+func (*WriteContainerFileResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *GetMonitoringInfoResponse) GetEnabled() bool {
+func (x *WriteContainerFileResponse) GetPath() string {
 	if x != nil {
-		return x.Enabled
+		return x.Path
 	}
-	return false
+	return ""
 }
 
-func (x *GetMonitoringInfoResponse) GetGrafanaUrl() string {
+func (x *WriteContainerFileResponse) GetSize() int64 {
 	if x != nil {
-		return x.GrafanaUrl
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-func (x *GetMonitoringInfoResponse) GetVictoriaMetricsUrl() string {
+func (x *WriteContainerFileResponse) GetMode() string {
 	if x != nil {
-		return x.VictoriaMetricsUrl
+		return x.Mode
 	}
 	return ""
 }
 
-// SetMetricsExportRequest enables or disables cloud-native metrics export.
-// Enabling with provider=GCP triggers a synchronous Application Default
-// Credentials probe (monitoring-write scope) before anything is persisted
-// or started; enabling with an unresolvable ADC fails the call and leaves
-// the prior config untouched.
-type SetMetricsExportRequest struct {
+func (x *WriteContainerFileResponse) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *WriteContainerFileResponse) GetGid() int64 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
+// ListContainerDirRequest lists a directory inside a container
+type ListContainerDirRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Desired state. true enables export (provider is required and is
-	// probed before persisting). false disables it; provider is ignored.
-	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	// Target cloud provider. Required when enabled=true. Must not be
-	// CLOUD_METRICS_PROVIDER_UNSPECIFIED (INVALID_ARGUMENT) or
-	// CLOUD_METRICS_PROVIDER_AWS (UNIMPLEMENTED — MVP is GCP-only).
-	Provider CloudMetricsProvider `protobuf:"varint,2,opt,name=provider,proto3,enum=containarium.v1.CloudMetricsProvider" json:"provider,omitempty"`
-	// The metric groups to export (#1081). Enables exactly these groups;
-	// an empty list defaults to [CLOUD_METRICS_GROUP_HOST], preserving the
-	// v0.60.0 host-only behavior. Every element must be a defined group
-	// other than CLOUD_METRICS_GROUP_UNSPECIFIED (INVALID_ARGUMENT
-	// otherwise). Ignored when enabled=false.
-	Groups        []CloudMetricsGroup `protobuf:"varint,3,rep,packed,name=groups,proto3,enum=containarium.v1.CloudMetricsGroup" json:"groups,omitempty"`
+	// Username of the container
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Absolute path of the directory inside the container. Paths with ".."
+	// and paths under /proc, /sys and /dev are refused.
+	Path          string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetMetricsExportRequest) Reset() {
-	*x = SetMetricsExportRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[53]
+func (x *ListContainerDirRequest) Reset() {
+	*x = ListContainerDirRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetMetricsExportRequest) String() string {
+func (x *ListContainerDirRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetMetricsExportRequest) ProtoMessage() {}
+func (*ListContainerDirRequest) ProtoMessage() {}
 
-func (x *SetMetricsExportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[53]
+func (x *ListContainerDirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4272,65 +4862,55 @@ func (x *SetMetricsExportRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetMetricsExportRequest.ProtoReflect.Descriptor instead.
-func (*SetMetricsExportRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{53}
-}
-
-func (x *SetMetricsExportRequest) GetEnabled() bool {
-	if x != nil {
-		return x.Enabled
-	}
-	return false
+// Deprecated: Use ListContainerDirRequest.ProtoReflect.Descriptor instead.
+func (*ListContainerDirRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *SetMetricsExportRequest) GetProvider() CloudMetricsProvider {
+func (x *ListContainerDirRequest) GetUsername() string {
 	if x != nil {
-		return x.Provider
+		return x.Username
 	}
-	return CloudMetricsProvider_CLOUD_METRICS_PROVIDER_UNSPECIFIED
+	return ""
 }
 
-func (x *SetMetricsExportRequest) GetGroups() []CloudMetricsGroup {
+func (x *ListContainerDirRequest) GetPath() string {
 	if x != nil {
-		return x.Groups
+		return x.Path
 	}
-	return nil
+	return ""
 }
 
-// SetMetricsExportResponse reports the effective state after the toggle.
-type SetMetricsExportResponse struct {
+// ListContainerDirResponse is the response from listing a container directory
+type ListContainerDirResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Operator-facing summary of what changed (e.g. "cloud metrics export
-	// enabled for gcp").
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	// The effective config after the call — mirrors GetMetricsExportResponse.
-	Enabled         bool                 `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Provider        CloudMetricsProvider `protobuf:"varint,3,opt,name=provider,proto3,enum=containarium.v1.CloudMetricsProvider" json:"provider,omitempty"`
-	IntervalSeconds int32                `protobuf:"varint,4,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
-	// The effective set of enabled metric groups after the call (#1081),
-	// normalized: an omitted/empty request list resolves to
-	// [CLOUD_METRICS_GROUP_HOST].
-	Groups        []CloudMetricsGroup `protobuf:"varint,5,rep,packed,name=groups,proto3,enum=containarium.v1.CloudMetricsGroup" json:"groups,omitempty"`
+	// The normalized path that was listed
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// The directory's permission bits in octal and numeric owner
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Uid  int64  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid  int64  `protobuf:"varint,4,opt,name=gid,proto3" json:"gid,omitempty"`
+	// Entry names, sorted
+	Entries       []string `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetMetricsExportResponse) Reset() {
-	*x = SetMetricsExportResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[54]
+func (x *ListContainerDirResponse) Reset() {
+	*x = ListContainerDirResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetMetricsExportResponse) String() string {
+func (x *ListContainerDirResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetMetricsExportResponse) ProtoMessage() {}
+func (*ListContainerDirResponse) ProtoMessage() {}
 
-func (x *SetMetricsExportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[54]
+func (x *ListContainerDirResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4341,69 +4921,74 @@ func (x *SetMetricsExportResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetMetricsExportResponse.ProtoReflect.Descriptor instead.
-func (*SetMetricsExportResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{54}
+// Deprecated: Use ListContainerDirResponse.ProtoReflect.Descriptor instead.
+func (*ListContainerDirResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *SetMetricsExportResponse) GetMessage() string {
+func (x *ListContainerDirResponse) GetPath() string {
 	if x != nil {
-		return x.Message
+		return x.Path
 	}
 	return ""
 }
 
-func (x *SetMetricsExportResponse) GetEnabled() bool {
+func (x *ListContainerDirResponse) GetMode() string {
 	if x != nil {
-		return x.Enabled
+		return x.Mode
 	}
-	return false
+	return ""
 }
 
-func (x *SetMetricsExportResponse) GetProvider() CloudMetricsProvider {
+func (x *ListContainerDirResponse) GetUid() int64 {
 	if x != nil {
-		return x.Provider
+		return x.Uid
 	}
-	return CloudMetricsProvider_CLOUD_METRICS_PROVIDER_UNSPECIFIED
+	return 0
 }
 
-func (x *SetMetricsExportResponse) GetIntervalSeconds() int32 {
+func (x *ListContainerDirResponse) GetGid() int64 {
 	if x != nil {
-		return x.IntervalSeconds
+		return x.Gid
 	}
 	return 0
 }
 
-func (x *SetMetricsExportResponse) GetGroups() []CloudMetricsGroup {
+func (x *ListContainerDirResponse) GetEntries() []string {
 	if x != nil {
-		return x.Groups
+		return x.Entries
 	}
 	return nil
 }
 
-// GetMetricsExportRequest requests the current cloud-native metrics
-// export configuration and health.
-type GetMetricsExportRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// DiffContainersRequest compares two users' containers
+type DiffContainersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Usernames of the two containers to compare
+	UsernameA string `protobuf:"bytes,1,opt,name=username_a,json=usernameA,proto3" json:"username_a,omitempty"`
+	UsernameB string `protobuf:"bytes,2,opt,name=username_b,json=usernameB,proto3" json:"username_b,omitempty"`
+	// Also compare the installed dpkg packages. Off by default: the lists
+	// are long and take an exec each.
+	IncludePackages bool `protobuf:"varint,3,opt,name=include_packages,json=includePackages,proto3" json:"include_packages,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetMetricsExportRequest) Reset() {
-	*x = GetMetricsExportRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[55]
+func (x *DiffContainersRequest) Reset() {
+	*x = DiffContainersRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMetricsExportRequest) String() string {
+func (x *DiffContainersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMetricsExportRequest) ProtoMessage() {}
+func (*DiffContainersRequest) ProtoMessage() {}
 
-func (x *GetMetricsExportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[55]
+func (x *DiffContainersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4414,55 +4999,63 @@ func (x *GetMetricsExportRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMetricsExportRequest.ProtoReflect.Descriptor instead.
-func (*GetMetricsExportRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use DiffContainersRequest.ProtoReflect.Descriptor instead.
+func (*DiffContainersRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{59}
 }
 
-// GetMetricsExportResponse reports the current cloud-native metrics
-// export configuration and last-known health. last_success_at,
-// last_error, and export_failures are zero-valued until the collector
-// (#1070/#1071) is wired — #1069 delivers the toggle, config
-// persistence, and enable-time credential probe only.
-type GetMetricsExportResponse struct {
+func (x *DiffContainersRequest) GetUsernameA() string {
+	if x != nil {
+		return x.UsernameA
+	}
+	return ""
+}
+
+func (x *DiffContainersRequest) GetUsernameB() string {
+	if x != nil {
+		return x.UsernameB
+	}
+	return ""
+}
+
+func (x *DiffContainersRequest) GetIncludePackages() bool {
+	if x != nil {
+		return x.IncludePackages
+	}
+	return false
+}
+
+// ContainerDifference is one fact that differs between the two containers.
+// An empty value means the container doesn't have it.
+type ContainerDifference struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Whether export is currently enabled.
-	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	// The configured provider. CLOUD_METRICS_PROVIDER_UNSPECIFIED when
-	// export has never been configured on this host.
-	Provider CloudMetricsProvider `protobuf:"varint,2,opt,name=provider,proto3,enum=containarium.v1.CloudMetricsProvider" json:"provider,omitempty"`
-	// Export interval in seconds. Fixed default 60 in the MVP; #1069 does
-	// not expose a knob to change it.
-	IntervalSeconds int32 `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
-	// Wall-clock time of the last successful export batch.
-	LastSuccessAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_success_at,json=lastSuccessAt,proto3" json:"last_success_at,omitempty"`
-	// Human-readable detail of the most recent export failure, if any.
-	LastError string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
-	// Count of failed export batches since the exporter was last (re)built.
-	ExportFailures int64 `protobuf:"varint,6,opt,name=export_failures,json=exportFailures,proto3" json:"export_failures,omitempty"`
-	// The set of enabled metric groups (#1081). Normalized: a host that has
-	// never configured groups (or a v0.60.0 config with none persisted)
-	// reports [CLOUD_METRICS_GROUP_HOST].
-	Groups        []CloudMetricsGroup `protobuf:"varint,7,rep,packed,name=groups,proto3,enum=containarium.v1.CloudMetricsGroup" json:"groups,omitempty"`
+	// What kind of fact: "config", "device", "system", "listening_port" or
+	// "package"
+	Section string `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	// The fact within its section, e.g. "limits.memory", "root.size",
+	// "kernel", "tcp 0.0.0.0:5432" or "libssl3"
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	ValueA        string `protobuf:"bytes,3,opt,name=value_a,json=valueA,proto3" json:"value_a,omitempty"`
+	ValueB        string `protobuf:"bytes,4,opt,name=value_b,json=valueB,proto3" json:"value_b,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMetricsExportResponse) Reset() {
-	*x = GetMetricsExportResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[56]
+func (x *ContainerDifference) Reset() {
+	*x = ContainerDifference{}
+	mi := &file_containarium_v1_container_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMetricsExportResponse) String() string {
+func (x *ContainerDifference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMetricsExportResponse) ProtoMessage() {}
+func (*ContainerDifference) ProtoMessage() {}
 
-func (x *GetMetricsExportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[56]
+func (x *ContainerDifference) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4473,108 +5066,2936 @@ func (x *GetMetricsExportResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMetricsExportResponse.ProtoReflect.Descriptor instead.
-func (*GetMetricsExportResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{56}
+// Deprecated: Use ContainerDifference.ProtoReflect.Descriptor instead.
+func (*ContainerDifference) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *GetMetricsExportResponse) GetEnabled() bool {
+func (x *ContainerDifference) GetSection() string {
 	if x != nil {
-		return x.Enabled
+		return x.Section
 	}
-	return false
+	return ""
 }
 
-func (x *GetMetricsExportResponse) GetProvider() CloudMetricsProvider {
+func (x *ContainerDifference) GetKey() string {
 	if x != nil {
-		return x.Provider
+		return x.Key
 	}
-	return CloudMetricsProvider_CLOUD_METRICS_PROVIDER_UNSPECIFIED
+	return ""
 }
 
-func (x *GetMetricsExportResponse) GetIntervalSeconds() int32 {
+func (x *ContainerDifference) GetValueA() string {
 	if x != nil {
-		return x.IntervalSeconds
+		return x.ValueA
 	}
-	return 0
+	return ""
 }
 
-func (x *GetMetricsExportResponse) GetLastSuccessAt() *timestamppb.Timestamp {
+func (x *ContainerDifference) GetValueB() string {
 	if x != nil {
-		return x.LastSuccessAt
+		return x.ValueB
 	}
-	return nil
+	return ""
 }
 
-func (x *GetMetricsExportResponse) GetLastError() string {
-	if x != nil {
-		return x.LastError
+// DiffContainersResponse lists only the facts that differ
+type DiffContainersResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UsernameA string                 `protobuf:"bytes,1,opt,name=username_a,json=usernameA,proto3" json:"username_a,omitempty"`
+	UsernameB string                 `protobuf:"bytes,2,opt,name=username_b,json=usernameB,proto3" json:"username_b,omitempty"`
+	// Incus states of the two containers
+	StateA string `protobuf:"bytes,3,opt,name=state_a,json=stateA,proto3" json:"state_a,omitempty"`
+	StateB string `protobuf:"bytes,4,opt,name=state_b,json=stateB,proto3" json:"state_b,omitempty"`
+	// Differences ordered by section, then key
+	Differences []*ContainerDifference `protobuf:"bytes,5,rep,name=differences,proto3" json:"differences,omitempty"`
+	// Sections or facts left out of the comparison and why, e.g. a stopped
+	// container whose OS facts can't be read
+	Notes         []string `protobuf:"bytes,6,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffContainersResponse) Reset() {
+	*x = DiffContainersResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffContainersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffContainersResponse) ProtoMessage() {}
+
+func (x *DiffContainersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffContainersResponse.ProtoReflect.Descriptor instead.
+func (*DiffContainersResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DiffContainersResponse) GetUsernameA() string {
+	if x != nil {
+		return x.UsernameA
+	}
+	return ""
+}
+
+func (x *DiffContainersResponse) GetUsernameB() string {
+	if x != nil {
+		return x.UsernameB
+	}
+	return ""
+}
+
+func (x *DiffContainersResponse) GetStateA() string {
+	if x != nil {
+		return x.StateA
+	}
+	return ""
+}
+
+func (x *DiffContainersResponse) GetStateB() string {
+	if x != nil {
+		return x.StateB
+	}
+	return ""
+}
+
+func (x *DiffContainersResponse) GetDifferences() []*ContainerDifference {
+	if x != nil {
+		return x.Differences
+	}
+	return nil
+}
+
+func (x *DiffContainersResponse) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// TestConnectivityRequest probes a target from inside a user's container
+type TestConnectivityRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// host:port, a bare host (dns, ping) or an http(s) URL
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// "tcp", "http", "dns" or "ping". Empty picks from the target: http for
+	// a URL, tcp for host:port, dns for a bare host.
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	// Timeout of each probe step inside the container (default 5, at most 30)
+	TimeoutSeconds int32 `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TestConnectivityRequest) Reset() {
+	*x = TestConnectivityRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestConnectivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestConnectivityRequest) ProtoMessage() {}
+
+func (x *TestConnectivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestConnectivityRequest.ProtoReflect.Descriptor instead.
+func (*TestConnectivityRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TestConnectivityRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TestConnectivityRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TestConnectivityRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *TestConnectivityRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// ConnectivityPolicyVerdict is what the container's egress network policy
+// does with traffic to the probed address
+type ConnectivityPolicyVerdict struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A verdict was reached; when false, note says why not
+	Evaluated bool `protobuf:"varint,1,opt,name=evaluated,proto3" json:"evaluated,omitempty"`
+	// Tenant whose policy applies
+	Tenant string `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Address checked: the first IPv4 address the target resolved to
+	Address string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Allowed bool   `protobuf:"varint,4,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	// A deny drops the traffic: the policy is in ENFORCE mode and the daemon
+	// armed enforcement. Otherwise a deny is only logged.
+	Enforced bool `protobuf:"varint,5,opt,name=enforced,proto3" json:"enforced,omitempty"`
+	// Part of the policy that decided, e.g. "egress CIDR 10.0.0.0/8"
+	Rule string `protobuf:"bytes,6,opt,name=rule,proto3" json:"rule,omitempty"`
+	// An explicit deny rule (virtual patch) blocked the address
+	VirtualPatch bool `protobuf:"varint,7,opt,name=virtual_patch,json=virtualPatch,proto3" json:"virtual_patch,omitempty"`
+	// The tenant has no stored policy; the default (log-only, nothing
+	// allowed) applies
+	DefaultPolicy bool   `protobuf:"varint,8,opt,name=default_policy,json=defaultPolicy,proto3" json:"default_policy,omitempty"`
+	Note          string `protobuf:"bytes,9,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectivityPolicyVerdict) Reset() {
+	*x = ConnectivityPolicyVerdict{}
+	mi := &file_containarium_v1_container_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectivityPolicyVerdict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectivityPolicyVerdict) ProtoMessage() {}
+
+func (x *ConnectivityPolicyVerdict) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectivityPolicyVerdict.ProtoReflect.Descriptor instead.
+func (*ConnectivityPolicyVerdict) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ConnectivityPolicyVerdict) GetEvaluated() bool {
+	if x != nil {
+		return x.Evaluated
+	}
+	return false
+}
+
+func (x *ConnectivityPolicyVerdict) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *ConnectivityPolicyVerdict) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ConnectivityPolicyVerdict) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *ConnectivityPolicyVerdict) GetEnforced() bool {
+	if x != nil {
+		return x.Enforced
+	}
+	return false
+}
+
+func (x *ConnectivityPolicyVerdict) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *ConnectivityPolicyVerdict) GetVirtualPatch() bool {
+	if x != nil {
+		return x.VirtualPatch
+	}
+	return false
+}
+
+func (x *ConnectivityPolicyVerdict) GetDefaultPolicy() bool {
+	if x != nil {
+		return x.DefaultPolicy
+	}
+	return false
+}
+
+func (x *ConnectivityPolicyVerdict) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// TestConnectivityResponse is the outcome of a connectivity test. An
+// unreachable target is a response with failure_stage set, not an error.
+type TestConnectivityResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Username     string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Target       string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Method       string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Reachable    bool                   `protobuf:"varint,4,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	FailureStage ConnectivityStage      `protobuf:"varint,5,opt,name=failure_stage,json=failureStage,proto3,enum=containarium.v1.ConnectivityStage" json:"failure_stage,omitempty"`
+	Error        string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Addresses the target resolves to inside the container
+	ResolvedIps []string `protobuf:"bytes,7,rep,name=resolved_ips,json=resolvedIps,proto3" json:"resolved_ips,omitempty"`
+	// Address the probe dialed
+	RemoteIp string `protobuf:"bytes,8,opt,name=remote_ip,json=remoteIp,proto3" json:"remote_ip,omitempty"`
+	// HTTP status code (http only)
+	HttpStatus int32 `protobuf:"varint,9,opt,name=http_status,json=httpStatus,proto3" json:"http_status,omitempty"`
+	// Time to connect (tcp), to complete the request (http), the echo round
+	// trip (ping), or to resolve including the exec round trip (dns)
+	LatencyMs     float64                    `protobuf:"fixed64,10,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Policy        *ConnectivityPolicyVerdict `protobuf:"bytes,11,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestConnectivityResponse) Reset() {
+	*x = TestConnectivityResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestConnectivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestConnectivityResponse) ProtoMessage() {}
+
+func (x *TestConnectivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestConnectivityResponse.ProtoReflect.Descriptor instead.
+func (*TestConnectivityResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *TestConnectivityResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TestConnectivityResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TestConnectivityResponse) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *TestConnectivityResponse) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *TestConnectivityResponse) GetFailureStage() ConnectivityStage {
+	if x != nil {
+		return x.FailureStage
+	}
+	return ConnectivityStage_CONNECTIVITY_STAGE_UNSPECIFIED
+}
+
+func (x *TestConnectivityResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TestConnectivityResponse) GetResolvedIps() []string {
+	if x != nil {
+		return x.ResolvedIps
+	}
+	return nil
+}
+
+func (x *TestConnectivityResponse) GetRemoteIp() string {
+	if x != nil {
+		return x.RemoteIp
+	}
+	return ""
+}
+
+func (x *TestConnectivityResponse) GetHttpStatus() int32 {
+	if x != nil {
+		return x.HttpStatus
+	}
+	return 0
+}
+
+func (x *TestConnectivityResponse) GetLatencyMs() float64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *TestConnectivityResponse) GetPolicy() *ConnectivityPolicyVerdict {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// ContainerSnapshot is a point-in-time incus snapshot of a container
+type ContainerSnapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Snapshot name (e.g., "before-upgrade")
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Unix timestamp when the snapshot was taken
+	CreatedAt int64 `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Snapshot size in bytes (0 if the storage driver doesn't report it)
+	SizeBytes int64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Whether the snapshot includes runtime (memory) state
+	Stateful      bool `protobuf:"varint,4,opt,name=stateful,proto3" json:"stateful,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerSnapshot) Reset() {
+	*x = ContainerSnapshot{}
+	mi := &file_containarium_v1_container_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerSnapshot) ProtoMessage() {}
+
+func (x *ContainerSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerSnapshot.ProtoReflect.Descriptor instead.
+func (*ContainerSnapshot) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ContainerSnapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerSnapshot) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ContainerSnapshot) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ContainerSnapshot) GetStateful() bool {
+	if x != nil {
+		return x.Stateful
+	}
+	return false
+}
+
+// CreateSnapshotRequest is the request to snapshot a container
+type CreateSnapshotRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Name for the new snapshot
+	SnapshotName  string `protobuf:"bytes,2,opt,name=snapshot_name,json=snapshotName,proto3" json:"snapshot_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CreateSnapshotRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetSnapshotName() string {
+	if x != nil {
+		return x.SnapshotName
+	}
+	return ""
+}
+
+// CreateSnapshotResponse is the response from snapshotting a container
+type CreateSnapshotResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human-readable result message
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The snapshot that was created
+	Snapshot      *ContainerSnapshot `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSnapshotResponse) Reset() {
+	*x = CreateSnapshotResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotResponse) ProtoMessage() {}
+
+func (x *CreateSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CreateSnapshotResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateSnapshotResponse) GetSnapshot() *ContainerSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// ListSnapshotsRequest is the request to list a container's snapshots
+type ListSnapshotsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container
+	Username      string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListSnapshotsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// ListSnapshotsResponse is the response from listing a container's snapshots
+type ListSnapshotsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Snapshots, oldest first
+	Snapshots     []*ContainerSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*ContainerSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+// RestoreSnapshotRequest is the request to roll a container back to a snapshot
+type RestoreSnapshotRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Name of the snapshot to restore
+	SnapshotName  string `protobuf:"bytes,2,opt,name=snapshot_name,json=snapshotName,proto3" json:"snapshot_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreSnapshotRequest) Reset() {
+	*x = RestoreSnapshotRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotRequest) ProtoMessage() {}
+
+func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *RestoreSnapshotRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetSnapshotName() string {
+	if x != nil {
+		return x.SnapshotName
+	}
+	return ""
+}
+
+// RestoreSnapshotResponse is the response from restoring a snapshot
+type RestoreSnapshotResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human-readable result message
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The snapshot the container was restored to
+	Snapshot      *ContainerSnapshot `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreSnapshotResponse) Reset() {
+	*x = RestoreSnapshotResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotResponse) ProtoMessage() {}
+
+func (x *RestoreSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RestoreSnapshotResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotResponse) GetSnapshot() *ContainerSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// GetContainerActivityRequest asks for a summary of one container's
+// recent activity
+type GetContainerActivityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Look-back window in seconds. 0 means 7 days; capped at 30 days.
+	WindowSeconds int64 `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContainerActivityRequest) Reset() {
+	*x = GetContainerActivityRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContainerActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerActivityRequest) ProtoMessage() {}
+
+func (x *GetContainerActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerActivityRequest.ProtoReflect.Descriptor instead.
+func (*GetContainerActivityRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetContainerActivityRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetContainerActivityRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+// ContainerActivityEvent is one lifecycle event (created, started,
+// stopped, ...) from the durable event log
+type ContainerActivityEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Event type, e.g. "EVENT_TYPE_CONTAINER_STARTED"
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Brief payload description, e.g. "image=ubuntu:24.04 state=CONTAINER_STATE_RUNNING"
+	Detail        string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerActivityEvent) Reset() {
+	*x = ContainerActivityEvent{}
+	mi := &file_containarium_v1_container_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerActivityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerActivityEvent) ProtoMessage() {}
+
+func (x *ContainerActivityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerActivityEvent.ProtoReflect.Descriptor instead.
+func (*ContainerActivityEvent) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ContainerActivityEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ContainerActivityEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ContainerActivityEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// ContainerActivityChange is one mutating API call against the container
+// recorded in the audit log
+type ContainerActivityChange struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Who made the call
+	Actor string `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	// HTTP method and path, e.g. "PUT /v1/containers/bob/resize"
+	Request string `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	// HTTP status the call returned
+	StatusCode    int32 `protobuf:"varint,4,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerActivityChange) Reset() {
+	*x = ContainerActivityChange{}
+	mi := &file_containarium_v1_container_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerActivityChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerActivityChange) ProtoMessage() {}
+
+func (x *ContainerActivityChange) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerActivityChange.ProtoReflect.Descriptor instead.
+func (*ContainerActivityChange) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ContainerActivityChange) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ContainerActivityChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ContainerActivityChange) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
+
+func (x *ContainerActivityChange) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+// ContainerActivityMetrics is resource usage over the window. The trend
+// fields come from the metrics store and are zero when it is not
+// configured; current is always the live reading when the container runs.
+type ContainerActivityMetrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Live usage at report time
+	Current *ContainerMetrics `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	// Average CPU use over the window, in cores
+	AvgCpuCores float64 `protobuf:"fixed64,2,opt,name=avg_cpu_cores,json=avgCpuCores,proto3" json:"avg_cpu_cores,omitempty"`
+	// Average and peak memory over the window
+	AvgMemoryBytes  int64 `protobuf:"varint,3,opt,name=avg_memory_bytes,json=avgMemoryBytes,proto3" json:"avg_memory_bytes,omitempty"`
+	PeakMemoryBytes int64 `protobuf:"varint,4,opt,name=peak_memory_bytes,json=peakMemoryBytes,proto3" json:"peak_memory_bytes,omitempty"`
+	// Root filesystem growth over the window (negative if it shrank)
+	DiskGrowthBytes int64 `protobuf:"varint,5,opt,name=disk_growth_bytes,json=diskGrowthBytes,proto3" json:"disk_growth_bytes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ContainerActivityMetrics) Reset() {
+	*x = ContainerActivityMetrics{}
+	mi := &file_containarium_v1_container_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerActivityMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerActivityMetrics) ProtoMessage() {}
+
+func (x *ContainerActivityMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerActivityMetrics.ProtoReflect.Descriptor instead.
+func (*ContainerActivityMetrics) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ContainerActivityMetrics) GetCurrent() *ContainerMetrics {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *ContainerActivityMetrics) GetAvgCpuCores() float64 {
+	if x != nil {
+		return x.AvgCpuCores
+	}
+	return 0
+}
+
+func (x *ContainerActivityMetrics) GetAvgMemoryBytes() int64 {
+	if x != nil {
+		return x.AvgMemoryBytes
+	}
+	return 0
+}
+
+func (x *ContainerActivityMetrics) GetPeakMemoryBytes() int64 {
+	if x != nil {
+		return x.PeakMemoryBytes
+	}
+	return 0
+}
+
+func (x *ContainerActivityMetrics) GetDiskGrowthBytes() int64 {
+	if x != nil {
+		return x.DiskGrowthBytes
+	}
+	return 0
+}
+
+// ContainerActivityDestination is traffic to one remote address
+type ContainerActivityDestination struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DestIp          string                 `protobuf:"bytes,1,opt,name=dest_ip,json=destIp,proto3" json:"dest_ip,omitempty"`
+	BytesSent       int64                  `protobuf:"varint,2,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived   int64                  `protobuf:"varint,3,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	ConnectionCount int32                  `protobuf:"varint,4,opt,name=connection_count,json=connectionCount,proto3" json:"connection_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ContainerActivityDestination) Reset() {
+	*x = ContainerActivityDestination{}
+	mi := &file_containarium_v1_container_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerActivityDestination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerActivityDestination) ProtoMessage() {}
+
+func (x *ContainerActivityDestination) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerActivityDestination.ProtoReflect.Descriptor instead.
+func (*ContainerActivityDestination) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ContainerActivityDestination) GetDestIp() string {
+	if x != nil {
+		return x.DestIp
+	}
+	return ""
+}
+
+func (x *ContainerActivityDestination) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *ContainerActivityDestination) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *ContainerActivityDestination) GetConnectionCount() int32 {
+	if x != nil {
+		return x.ConnectionCount
+	}
+	return 0
+}
+
+// ContainerActivityTraffic is traffic totals over the window
+type ContainerActivityTraffic struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BytesSent       int64                  `protobuf:"varint,1,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived   int64                  `protobuf:"varint,2,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	ConnectionCount int64                  `protobuf:"varint,3,opt,name=connection_count,json=connectionCount,proto3" json:"connection_count,omitempty"`
+	// Busiest remote addresses by total bytes, largest first
+	TopDestinations []*ContainerActivityDestination `protobuf:"bytes,4,rep,name=top_destinations,json=topDestinations,proto3" json:"top_destinations,omitempty"`
+	// Total bytes split by connection direction. Connections without a
+	// recorded direction count toward neither.
+	IngressBytes  int64 `protobuf:"varint,5,opt,name=ingress_bytes,json=ingressBytes,proto3" json:"ingress_bytes,omitempty"`
+	EgressBytes   int64 `protobuf:"varint,6,opt,name=egress_bytes,json=egressBytes,proto3" json:"egress_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerActivityTraffic) Reset() {
+	*x = ContainerActivityTraffic{}
+	mi := &file_containarium_v1_container_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerActivityTraffic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerActivityTraffic) ProtoMessage() {}
+
+func (x *ContainerActivityTraffic) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerActivityTraffic.ProtoReflect.Descriptor instead.
+func (*ContainerActivityTraffic) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ContainerActivityTraffic) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *ContainerActivityTraffic) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *ContainerActivityTraffic) GetConnectionCount() int64 {
+	if x != nil {
+		return x.ConnectionCount
+	}
+	return 0
+}
+
+func (x *ContainerActivityTraffic) GetTopDestinations() []*ContainerActivityDestination {
+	if x != nil {
+		return x.TopDestinations
+	}
+	return nil
+}
+
+func (x *ContainerActivityTraffic) GetIngressBytes() int64 {
+	if x != nil {
+		return x.IngressBytes
+	}
+	return 0
+}
+
+func (x *ContainerActivityTraffic) GetEgressBytes() int64 {
+	if x != nil {
+		return x.EgressBytes
+	}
+	return 0
+}
+
+// ContainerActivityListeners is what the container listens on now and how
+// that changed over the window
+type ContainerActivityListeners struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Listeners found by the latest scan
+	Current []*ListeningPort `protobuf:"bytes,1,rep,name=current,proto3" json:"current,omitempty"`
+	// Listeners opened or closed within the window, oldest first
+	Changes       []*ListenerChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerActivityListeners) Reset() {
+	*x = ContainerActivityListeners{}
+	mi := &file_containarium_v1_container_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerActivityListeners) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerActivityListeners) ProtoMessage() {}
+
+func (x *ContainerActivityListeners) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerActivityListeners.ProtoReflect.Descriptor instead.
+func (*ContainerActivityListeners) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ContainerActivityListeners) GetCurrent() []*ListeningPort {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *ContainerActivityListeners) GetChanges() []*ListenerChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// GetContainerActivityResponse is the joined activity summary. Each
+// section is best-effort: when its data source is unavailable (no audit
+// store, traffic persistence disabled, no metrics store) the section is
+// left empty and a line in notes says why.
+type GetContainerActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	ContainerName string                 `protobuf:"bytes,2,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	WindowStart   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	// Current container state
+	State ContainerState `protobuf:"varint,5,opt,name=state,proto3,enum=containarium.v1.ContainerState" json:"state,omitempty"`
+	// Lifecycle events in the window, oldest first
+	LifecycleEvents []*ContainerActivityEvent `protobuf:"bytes,6,rep,name=lifecycle_events,json=lifecycleEvents,proto3" json:"lifecycle_events,omitempty"`
+	Metrics         *ContainerActivityMetrics `protobuf:"bytes,7,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	Traffic         *ContainerActivityTraffic `protobuf:"bytes,8,opt,name=traffic,proto3" json:"traffic,omitempty"`
+	// Snapshots taken within the window, oldest first
+	Snapshots []*ContainerSnapshot `protobuf:"bytes,9,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	// Mutating API calls against the container, oldest first
+	Changes []*ContainerActivityChange `protobuf:"bytes,10,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Human-readable notes on sections that could not be filled
+	Notes []string `protobuf:"bytes,11,rep,name=notes,proto3" json:"notes,omitempty"`
+	// Listening ports now and listener changes in the window
+	ListeningPorts *ContainerActivityListeners `protobuf:"bytes,12,opt,name=listening_ports,json=listeningPorts,proto3" json:"listening_ports,omitempty"`
+	// SSH logins active in the window, newest first, with the bytes each
+	// carried (needs SSH session logging)
+	SshSessions   []*SSHSession `protobuf:"bytes,13,rep,name=ssh_sessions,json=sshSessions,proto3" json:"ssh_sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContainerActivityResponse) Reset() {
+	*x = GetContainerActivityResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContainerActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerActivityResponse) ProtoMessage() {}
+
+func (x *GetContainerActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerActivityResponse.ProtoReflect.Descriptor instead.
+func (*GetContainerActivityResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetContainerActivityResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetContainerActivityResponse) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *GetContainerActivityResponse) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetState() ContainerState {
+	if x != nil {
+		return x.State
+	}
+	return ContainerState_CONTAINER_STATE_UNSPECIFIED
+}
+
+func (x *GetContainerActivityResponse) GetLifecycleEvents() []*ContainerActivityEvent {
+	if x != nil {
+		return x.LifecycleEvents
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetMetrics() *ContainerActivityMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetTraffic() *ContainerActivityTraffic {
+	if x != nil {
+		return x.Traffic
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetSnapshots() []*ContainerSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetChanges() []*ContainerActivityChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetListeningPorts() *ContainerActivityListeners {
+	if x != nil {
+		return x.ListeningPorts
+	}
+	return nil
+}
+
+func (x *GetContainerActivityResponse) GetSshSessions() []*SSHSession {
+	if x != nil {
+		return x.SshSessions
+	}
+	return nil
+}
+
+// ProvisionStep is one step of a container's provisioning, as recorded by
+// the daemon while it creates the container
+type ProvisionStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Step name, e.g. "network", "packages", "ssh-keys"
+	Name  string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State ProvisionStepState `protobuf:"varint,2,opt,name=state,proto3,enum=containarium.v1.ProvisionStepState" json:"state,omitempty"`
+	// Why the step failed, when state is FAILED
+	Error     string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Unset while the step is running
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProvisionStep) Reset() {
+	*x = ProvisionStep{}
+	mi := &file_containarium_v1_container_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisionStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisionStep) ProtoMessage() {}
+
+func (x *ProvisionStep) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisionStep.ProtoReflect.Descriptor instead.
+func (*ProvisionStep) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ProvisionStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProvisionStep) GetState() ProvisionStepState {
+	if x != nil {
+		return x.State
+	}
+	return ProvisionStepState_PROVISION_STEP_STATE_UNSPECIFIED
+}
+
+func (x *ProvisionStep) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProvisionStep) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ProvisionStep) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// ReadinessCheck is one live probe of a container's readiness
+type ReadinessCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Check name: "running", "ip-assigned" or "sshd"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Ok   bool   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	// What was observed, e.g. the IP address or the dial error
+	Detail        string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadinessCheck) Reset() {
+	*x = ReadinessCheck{}
+	mi := &file_containarium_v1_container_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadinessCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadinessCheck) ProtoMessage() {}
+
+func (x *ReadinessCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadinessCheck.ProtoReflect.Descriptor instead.
+func (*ReadinessCheck) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ReadinessCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReadinessCheck) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ReadinessCheck) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// GetContainerReadinessRequest asks whether a container is ready for use
+type GetContainerReadinessRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container
+	Username      string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContainerReadinessRequest) Reset() {
+	*x = GetContainerReadinessRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContainerReadinessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerReadinessRequest) ProtoMessage() {}
+
+func (x *GetContainerReadinessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerReadinessRequest.ProtoReflect.Descriptor instead.
+func (*GetContainerReadinessRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetContainerReadinessRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// GetContainerReadinessResponse reports a container's provisioning progress
+// and live readiness checks. ready is true once every recorded step is done
+// and every check passes.
+type GetContainerReadinessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	ContainerName string                 `protobuf:"bytes,2,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	Ready         bool                   `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	// Current container state (CREATING while the instance doesn't exist yet)
+	State ContainerState `protobuf:"varint,4,opt,name=state,proto3,enum=containarium.v1.ContainerState" json:"state,omitempty"`
+	// Provisioning steps in the order they ran. Empty for containers created
+	// before step tracking existed.
+	Steps []*ProvisionStep `protobuf:"bytes,5,rep,name=steps,proto3" json:"steps,omitempty"`
+	// Live checks: running, ip-assigned, sshd
+	Checks        []*ReadinessCheck `protobuf:"bytes,6,rep,name=checks,proto3" json:"checks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContainerReadinessResponse) Reset() {
+	*x = GetContainerReadinessResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContainerReadinessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerReadinessResponse) ProtoMessage() {}
+
+func (x *GetContainerReadinessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerReadinessResponse.ProtoReflect.Descriptor instead.
+func (*GetContainerReadinessResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetContainerReadinessResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetContainerReadinessResponse) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *GetContainerReadinessResponse) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *GetContainerReadinessResponse) GetState() ContainerState {
+	if x != nil {
+		return x.State
+	}
+	return ContainerState_CONTAINER_STATE_UNSPECIFIED
+}
+
+func (x *GetContainerReadinessResponse) GetSteps() []*ProvisionStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *GetContainerReadinessResponse) GetChecks() []*ReadinessCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+// InstallStackRequest is the request to install a stack on a running container
+type InstallStackRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Stack or base script ID to install (e.g., "nodejs", "clamav")
+	StackId       string `protobuf:"bytes,2,opt,name=stack_id,json=stackId,proto3" json:"stack_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallStackRequest) Reset() {
+	*x = InstallStackRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallStackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallStackRequest) ProtoMessage() {}
+
+func (x *InstallStackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallStackRequest.ProtoReflect.Descriptor instead.
+func (*InstallStackRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *InstallStackRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *InstallStackRequest) GetStackId() string {
+	if x != nil {
+		return x.StackId
+	}
+	return ""
+}
+
+// InstallStackResponse is the response from installing a stack
+type InstallStackResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human-readable result message
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Updated container info after installation
+	Container     *Container `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallStackResponse) Reset() {
+	*x = InstallStackResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallStackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallStackResponse) ProtoMessage() {}
+
+func (x *InstallStackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallStackResponse.ProtoReflect.Descriptor instead.
+func (*InstallStackResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *InstallStackResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InstallStackResponse) GetContainer() *Container {
+	if x != nil {
+		return x.Container
+	}
+	return nil
+}
+
+// StackParameter describes an input the stack accepts at install time.
+// Returned by ListStacks so the web UI can render appropriate form fields.
+type StackParameter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Parameter key. The env var seen by install scripts is
+	// CONTAINARIUM_STACK_<name>.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Human-readable label shown in the web UI.
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Optional helper text shown below the field.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// "string" | "password" | "number" | "boolean"
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// Default value if the user leaves the field blank.
+	Default string `protobuf:"bytes,5,opt,name=default,proto3" json:"default,omitempty"`
+	// Whether the field is required.
+	Required      bool `protobuf:"varint,6,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StackParameter) Reset() {
+	*x = StackParameter{}
+	mi := &file_containarium_v1_container_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StackParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StackParameter) ProtoMessage() {}
+
+func (x *StackParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StackParameter.ProtoReflect.Descriptor instead.
+func (*StackParameter) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *StackParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StackParameter) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *StackParameter) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StackParameter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StackParameter) GetDefault() string {
+	if x != nil {
+		return x.Default
+	}
+	return ""
+}
+
+func (x *StackParameter) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+// StackInfo describes a single software stack.
+type StackInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Icon          string                 `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	Parameters    []*StackParameter      `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StackInfo) Reset() {
+	*x = StackInfo{}
+	mi := &file_containarium_v1_container_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StackInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StackInfo) ProtoMessage() {}
+
+func (x *StackInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StackInfo.ProtoReflect.Descriptor instead.
+func (*StackInfo) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *StackInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StackInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StackInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StackInfo) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *StackInfo) GetParameters() []*StackParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// ListStacksRequest requests the catalog of available stacks.
+type ListStacksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStacksRequest) Reset() {
+	*x = ListStacksRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStacksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStacksRequest) ProtoMessage() {}
+
+func (x *ListStacksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStacksRequest.ProtoReflect.Descriptor instead.
+func (*ListStacksRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{88}
+}
+
+// ListStacksResponse returns all configured software stacks.
+type ListStacksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stacks        []*StackInfo           `protobuf:"bytes,1,rep,name=stacks,proto3" json:"stacks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStacksResponse) Reset() {
+	*x = ListStacksResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStacksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStacksResponse) ProtoMessage() {}
+
+func (x *ListStacksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStacksResponse.ProtoReflect.Descriptor instead.
+func (*ListStacksResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListStacksResponse) GetStacks() []*StackInfo {
+	if x != nil {
+		return x.Stacks
+	}
+	return nil
+}
+
+// GetMonitoringInfoRequest is the request to get monitoring info
+type GetMonitoringInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMonitoringInfoRequest) Reset() {
+	*x = GetMonitoringInfoRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMonitoringInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMonitoringInfoRequest) ProtoMessage() {}
+
+func (x *GetMonitoringInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMonitoringInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetMonitoringInfoRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{90}
+}
+
+// GetMonitoringInfoResponse is the response with monitoring configuration
+type GetMonitoringInfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether monitoring is enabled
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Grafana URL for dashboard access
+	GrafanaUrl string `protobuf:"bytes,2,opt,name=grafana_url,json=grafanaUrl,proto3" json:"grafana_url,omitempty"`
+	// Victoria Metrics URL for metrics API
+	VictoriaMetricsUrl string `protobuf:"bytes,3,opt,name=victoria_metrics_url,json=victoriaMetricsUrl,proto3" json:"victoria_metrics_url,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetMonitoringInfoResponse) Reset() {
+	*x = GetMonitoringInfoResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMonitoringInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMonitoringInfoResponse) ProtoMessage() {}
+
+func (x *GetMonitoringInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMonitoringInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetMonitoringInfoResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetMonitoringInfoResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetMonitoringInfoResponse) GetGrafanaUrl() string {
+	if x != nil {
+		return x.GrafanaUrl
+	}
+	return ""
+}
+
+func (x *GetMonitoringInfoResponse) GetVictoriaMetricsUrl() string {
+	if x != nil {
+		return x.VictoriaMetricsUrl
+	}
+	return ""
+}
+
+// SetMetricsExportRequest enables or disables cloud-native metrics export.
+// Enabling with provider=GCP triggers a synchronous Application Default
+// Credentials probe (monitoring-write scope) before anything is persisted
+// or started; enabling with an unresolvable ADC fails the call and leaves
+// the prior config untouched.
+type SetMetricsExportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Desired state. true enables export (provider is required and is
+	// probed before persisting). false disables it; provider is ignored.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Target cloud provider. Required when enabled=true. Must not be
+	// CLOUD_METRICS_PROVIDER_UNSPECIFIED (INVALID_ARGUMENT) or
+	// CLOUD_METRICS_PROVIDER_AWS (UNIMPLEMENTED — MVP is GCP-only).
+	Provider CloudMetricsProvider `protobuf:"varint,2,opt,name=provider,proto3,enum=containarium.v1.CloudMetricsProvider" json:"provider,omitempty"`
+	// The metric groups to export (#1081). Enables exactly these groups;
+	// an empty list defaults to [CLOUD_METRICS_GROUP_HOST], preserving the
+	// v0.60.0 host-only behavior. Every element must be a defined group
+	// other than CLOUD_METRICS_GROUP_UNSPECIFIED (INVALID_ARGUMENT
+	// otherwise). Ignored when enabled=false.
+	Groups        []CloudMetricsGroup `protobuf:"varint,3,rep,packed,name=groups,proto3,enum=containarium.v1.CloudMetricsGroup" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMetricsExportRequest) Reset() {
+	*x = SetMetricsExportRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMetricsExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMetricsExportRequest) ProtoMessage() {}
+
+func (x *SetMetricsExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMetricsExportRequest.ProtoReflect.Descriptor instead.
+func (*SetMetricsExportRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *SetMetricsExportRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetMetricsExportRequest) GetProvider() CloudMetricsProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return CloudMetricsProvider_CLOUD_METRICS_PROVIDER_UNSPECIFIED
+}
+
+func (x *SetMetricsExportRequest) GetGroups() []CloudMetricsGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// SetMetricsExportResponse reports the effective state after the toggle.
+type SetMetricsExportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operator-facing summary of what changed (e.g. "cloud metrics export
+	// enabled for gcp").
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The effective config after the call — mirrors GetMetricsExportResponse.
+	Enabled         bool                 `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Provider        CloudMetricsProvider `protobuf:"varint,3,opt,name=provider,proto3,enum=containarium.v1.CloudMetricsProvider" json:"provider,omitempty"`
+	IntervalSeconds int32                `protobuf:"varint,4,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	// The effective set of enabled metric groups after the call (#1081),
+	// normalized: an omitted/empty request list resolves to
+	// [CLOUD_METRICS_GROUP_HOST].
+	Groups        []CloudMetricsGroup `protobuf:"varint,5,rep,packed,name=groups,proto3,enum=containarium.v1.CloudMetricsGroup" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMetricsExportResponse) Reset() {
+	*x = SetMetricsExportResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMetricsExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMetricsExportResponse) ProtoMessage() {}
+
+func (x *SetMetricsExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMetricsExportResponse.ProtoReflect.Descriptor instead.
+func (*SetMetricsExportResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *SetMetricsExportResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetMetricsExportResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetMetricsExportResponse) GetProvider() CloudMetricsProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return CloudMetricsProvider_CLOUD_METRICS_PROVIDER_UNSPECIFIED
+}
+
+func (x *SetMetricsExportResponse) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *SetMetricsExportResponse) GetGroups() []CloudMetricsGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// GetMetricsExportRequest requests the current cloud-native metrics
+// export configuration and health.
+type GetMetricsExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetricsExportRequest) Reset() {
+	*x = GetMetricsExportRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetricsExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetricsExportRequest) ProtoMessage() {}
+
+func (x *GetMetricsExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetricsExportRequest.ProtoReflect.Descriptor instead.
+func (*GetMetricsExportRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{94}
+}
+
+// GetMetricsExportResponse reports the current cloud-native metrics
+// export configuration and last-known health. last_success_at,
+// last_error, and export_failures are zero-valued until the collector
+// (#1070/#1071) is wired — #1069 delivers the toggle, config
+// persistence, and enable-time credential probe only.
+type GetMetricsExportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether export is currently enabled.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// The configured provider. CLOUD_METRICS_PROVIDER_UNSPECIFIED when
+	// export has never been configured on this host.
+	Provider CloudMetricsProvider `protobuf:"varint,2,opt,name=provider,proto3,enum=containarium.v1.CloudMetricsProvider" json:"provider,omitempty"`
+	// Export interval in seconds. Fixed default 60 in the MVP; #1069 does
+	// not expose a knob to change it.
+	IntervalSeconds int32 `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	// Wall-clock time of the last successful export batch.
+	LastSuccessAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_success_at,json=lastSuccessAt,proto3" json:"last_success_at,omitempty"`
+	// Human-readable detail of the most recent export failure, if any.
+	LastError string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// Count of failed export batches since the exporter was last (re)built.
+	ExportFailures int64 `protobuf:"varint,6,opt,name=export_failures,json=exportFailures,proto3" json:"export_failures,omitempty"`
+	// The set of enabled metric groups (#1081). Normalized: a host that has
+	// never configured groups (or a v0.60.0 config with none persisted)
+	// reports [CLOUD_METRICS_GROUP_HOST].
+	Groups        []CloudMetricsGroup `protobuf:"varint,7,rep,packed,name=groups,proto3,enum=containarium.v1.CloudMetricsGroup" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetricsExportResponse) Reset() {
+	*x = GetMetricsExportResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetricsExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetricsExportResponse) ProtoMessage() {}
+
+func (x *GetMetricsExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetricsExportResponse.ProtoReflect.Descriptor instead.
+func (*GetMetricsExportResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetMetricsExportResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetMetricsExportResponse) GetProvider() CloudMetricsProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return CloudMetricsProvider_CLOUD_METRICS_PROVIDER_UNSPECIFIED
+}
+
+func (x *GetMetricsExportResponse) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *GetMetricsExportResponse) GetLastSuccessAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccessAt
+	}
+	return nil
+}
+
+func (x *GetMetricsExportResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *GetMetricsExportResponse) GetExportFailures() int64 {
+	if x != nil {
+		return x.ExportFailures
+	}
+	return 0
+}
+
+func (x *GetMetricsExportResponse) GetGroups() []CloudMetricsGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// MoveContainerRequest is the source-side request to migrate a
+// container to a peer daemon.
+type MoveContainerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container to move (required). Must exist on the
+	// local daemon — moving a container the caller doesn't own returns
+	// PermissionDenied.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Target backend ID — the peer daemon to migrate to. Must appear in
+	// the source daemon's PeerPool. Trying to move to the local backend
+	// is a no-op error.
+	TargetBackendId string `protobuf:"bytes,2,opt,name=target_backend_id,json=targetBackendId,proto3" json:"target_backend_id,omitempty"`
+	// Maximum pre-copy delta iterations before forcing the cutover.
+	// Each iteration copies the delta since the previous snapshot; with
+	// each iteration the dirty set should shrink, reducing the final
+	// cutover downtime. Range [0, 10]. Zero disables iterative delta
+	// and goes straight to stop + full copy. Default 3.
+	MaxIterations int32 `protobuf:"varint,3,opt,name=max_iterations,json=maxIterations,proto3" json:"max_iterations,omitempty"`
+	// If a delta iteration completes in less than this many seconds,
+	// the caller decides the dirty set is small enough — skip remaining
+	// iterations and go straight to cutover. Range [1, 60]. Default 5.
+	DeltaThresholdSeconds int32 `protobuf:"varint,4,opt,name=delta_threshold_seconds,json=deltaThresholdSeconds,proto3" json:"delta_threshold_seconds,omitempty"`
+	// When true, attempt CRIU-based live migration via `incus move
+	// --stateful` — preserves process memory state and open sockets,
+	// sub-second downtime even on active workloads. Requires CRIU
+	// installed on both source and target, and is not supported for
+	// containers running podman/docker-in-LXC. Defaults to false
+	// (stop-and-copy) which is more reliable.
+	Stateful      bool `protobuf:"varint,5,opt,name=stateful,proto3" json:"stateful,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveContainerRequest) Reset() {
+	*x = MoveContainerRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveContainerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveContainerRequest) ProtoMessage() {}
+
+func (x *MoveContainerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveContainerRequest.ProtoReflect.Descriptor instead.
+func (*MoveContainerRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *MoveContainerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
 	return ""
 }
 
-func (x *GetMetricsExportResponse) GetExportFailures() int64 {
+func (x *MoveContainerRequest) GetTargetBackendId() string {
 	if x != nil {
-		return x.ExportFailures
+		return x.TargetBackendId
+	}
+	return ""
+}
+
+func (x *MoveContainerRequest) GetMaxIterations() int32 {
+	if x != nil {
+		return x.MaxIterations
+	}
+	return 0
+}
+
+func (x *MoveContainerRequest) GetDeltaThresholdSeconds() int32 {
+	if x != nil {
+		return x.DeltaThresholdSeconds
+	}
+	return 0
+}
+
+func (x *MoveContainerRequest) GetStateful() bool {
+	if x != nil {
+		return x.Stateful
+	}
+	return false
+}
+
+// MoveContainerResponse summarizes the migration outcome.
+type MoveContainerResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human-readable success message.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The container's new IP address on the target daemon, after start.
+	NewIpAddress string `protobuf:"bytes,2,opt,name=new_ip_address,json=newIpAddress,proto3" json:"new_ip_address,omitempty"`
+	// Backend the container now lives on (target_backend_id from req).
+	TargetBackendId string `protobuf:"bytes,3,opt,name=target_backend_id,json=targetBackendId,proto3" json:"target_backend_id,omitempty"`
+	// Total iterations performed (initial copy + N deltas + final).
+	// Helpful for tuning max_iterations on subsequent moves of similar
+	// workloads.
+	IterationsRun int32 `protobuf:"varint,4,opt,name=iterations_run,json=iterationsRun,proto3" json:"iterations_run,omitempty"`
+	// Total wall-clock seconds the container was unreachable during the
+	// cutover (between stop on source and start on target with route
+	// updated). With pre-copy + ZFS this should be < 5s; on dir-pool or
+	// active workloads it may be tens of seconds. -1 if stateful=true
+	// succeeded and there was no observable downtime.
+	DowntimeSeconds int32 `protobuf:"varint,5,opt,name=downtime_seconds,json=downtimeSeconds,proto3" json:"downtime_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MoveContainerResponse) Reset() {
+	*x = MoveContainerResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveContainerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveContainerResponse) ProtoMessage() {}
+
+func (x *MoveContainerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveContainerResponse.ProtoReflect.Descriptor instead.
+func (*MoveContainerResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *MoveContainerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MoveContainerResponse) GetNewIpAddress() string {
+	if x != nil {
+		return x.NewIpAddress
+	}
+	return ""
+}
+
+func (x *MoveContainerResponse) GetTargetBackendId() string {
+	if x != nil {
+		return x.TargetBackendId
+	}
+	return ""
+}
+
+func (x *MoveContainerResponse) GetIterationsRun() int32 {
+	if x != nil {
+		return x.IterationsRun
+	}
+	return 0
+}
+
+func (x *MoveContainerResponse) GetDowntimeSeconds() int32 {
+	if x != nil {
+		return x.DowntimeSeconds
 	}
 	return 0
 }
 
-func (x *GetMetricsExportResponse) GetGroups() []CloudMetricsGroup {
+// AdoptMigratedContainerRequest is sent by the source daemon's
+// MoveContainer to the destination daemon AFTER `incus copy` has
+// completed and the LXC exists on the destination's incusd. The
+// destination then registers the container with its own Containarium
+// state (host user account, route record at the new IP).
+//
+// Internal use only — exposed via REST for daemon-to-daemon coordination
+// over the same PeerPool channel used by other forwarding RPCs.
+type AdoptMigratedContainerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the migrated container.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The source daemon's record of which routes belonged to this
+	// container, so the destination can recreate them at its own
+	// container IP. Each entry is "<full-domain>|<port>|<protocol>".
+	// Empty for stateless containers with no exposed ports.
+	SourceRoutes  []string `protobuf:"bytes,2,rep,name=source_routes,json=sourceRoutes,proto3" json:"source_routes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdoptMigratedContainerRequest) Reset() {
+	*x = AdoptMigratedContainerRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdoptMigratedContainerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdoptMigratedContainerRequest) ProtoMessage() {}
+
+func (x *AdoptMigratedContainerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdoptMigratedContainerRequest.ProtoReflect.Descriptor instead.
+func (*AdoptMigratedContainerRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *AdoptMigratedContainerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AdoptMigratedContainerRequest) GetSourceRoutes() []string {
+	if x != nil {
+		return x.SourceRoutes
+	}
+	return nil
+}
+
+// AdoptMigratedContainerResponse confirms the destination registered
+// the container and returns its IP for the source's route swap.
+type AdoptMigratedContainerResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Success message.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The migrated container's new IP address on the destination's
+	// incusbr0. The source uses this for the final route store update.
+	NewIpAddress  string `protobuf:"bytes,2,opt,name=new_ip_address,json=newIpAddress,proto3" json:"new_ip_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdoptMigratedContainerResponse) Reset() {
+	*x = AdoptMigratedContainerResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdoptMigratedContainerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdoptMigratedContainerResponse) ProtoMessage() {}
+
+func (x *AdoptMigratedContainerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdoptMigratedContainerResponse.ProtoReflect.Descriptor instead.
+func (*AdoptMigratedContainerResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *AdoptMigratedContainerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AdoptMigratedContainerResponse) GetNewIpAddress() string {
+	if x != nil {
+		return x.NewIpAddress
+	}
+	return ""
+}
+
+// ContainerTemplateRoute is a proxy route added to every box created from
+// a template.
+type ContainerTemplateRoute struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Subdomain to expose the port under; prefixed with the username, so
+	// "web" on alice's box becomes "alice-web".
+	Subdomain string `protobuf:"bytes,1,opt,name=subdomain,proto3" json:"subdomain,omitempty"`
+	// Port the app listens on inside the container.
+	ContainerPort int32 `protobuf:"varint,2,opt,name=container_port,json=containerPort,proto3" json:"container_port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerTemplateRoute) Reset() {
+	*x = ContainerTemplateRoute{}
+	mi := &file_containarium_v1_container_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerTemplateRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerTemplateRoute) ProtoMessage() {}
+
+func (x *ContainerTemplateRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerTemplateRoute.ProtoReflect.Descriptor instead.
+func (*ContainerTemplateRoute) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ContainerTemplateRoute) GetSubdomain() string {
+	if x != nil {
+		return x.Subdomain
+	}
+	return ""
+}
+
+func (x *ContainerTemplateRoute) GetContainerPort() int32 {
 	if x != nil {
-		return x.Groups
+		return x.ContainerPort
 	}
-	return nil
+	return 0
 }
 
-// MoveContainerRequest is the source-side request to migrate a
-// container to a peer daemon.
-type MoveContainerRequest struct {
+// ContainerTemplate is a named preset of create settings ("small dev
+// box"), expanded by CreateContainerRequest.template.
+type ContainerTemplate struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Username of the container to move (required). Must exist on the
-	// local daemon — moving a container the caller doesn't own returns
-	// PermissionDenied.
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	// Target backend ID — the peer daemon to migrate to. Must appear in
-	// the source daemon's PeerPool. Trying to move to the local backend
-	// is a no-op error.
-	TargetBackendId string `protobuf:"bytes,2,opt,name=target_backend_id,json=targetBackendId,proto3" json:"target_backend_id,omitempty"`
-	// Maximum pre-copy delta iterations before forcing the cutover.
-	// Each iteration copies the delta since the previous snapshot; with
-	// each iteration the dirty set should shrink, reducing the final
-	// cutover downtime. Range [0, 10]. Zero disables iterative delta
-	// and goes straight to stop + full copy. Default 3.
-	MaxIterations int32 `protobuf:"varint,3,opt,name=max_iterations,json=maxIterations,proto3" json:"max_iterations,omitempty"`
-	// If a delta iteration completes in less than this many seconds,
-	// the caller decides the dirty set is small enough — skip remaining
-	// iterations and go straight to cutover. Range [1, 60]. Default 5.
-	DeltaThresholdSeconds int32 `protobuf:"varint,4,opt,name=delta_threshold_seconds,json=deltaThresholdSeconds,proto3" json:"delta_threshold_seconds,omitempty"`
-	// When true, attempt CRIU-based live migration via `incus move
-	// --stateful` — preserves process memory state and open sockets,
-	// sub-second downtime even on active workloads. Requires CRIU
-	// installed on both source and target, and is not supported for
-	// containers running podman/docker-in-LXC. Defaults to false
-	// (stop-and-copy) which is more reliable.
-	Stateful      bool `protobuf:"varint,5,opt,name=stateful,proto3" json:"stateful,omitempty"`
+	// Template name, e.g. "small": lowercase letters, digits and dashes.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// What the template is for.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Resource limits; empty fields keep the daemon defaults.
+	Resources *ResourceLimits `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
+	// Container image (e.g. "images:ubuntu/24.04").
+	Image string `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	// SSH public keys installed for the user.
+	SshKeys []string `protobuf:"bytes,5,rep,name=ssh_keys,json=sshKeys,proto3" json:"ssh_keys,omitempty"`
+	// Enable Podman/Docker support.
+	EnablePodman bool `protobuf:"varint,6,opt,name=enable_podman,json=enablePodman,proto3" json:"enable_podman,omitempty"`
+	// Software stack to install (see ListStacks).
+	Stack string `protobuf:"bytes,7,opt,name=stack,proto3" json:"stack,omitempty"`
+	// cloud-init user-data applied on first boot.
+	CloudInit string `protobuf:"bytes,8,opt,name=cloud_init,json=cloudInit,proto3" json:"cloud_init,omitempty"`
+	// Proxy routes added once the box is up.
+	Routes []*ContainerTemplateRoute `protobuf:"bytes,9,rep,name=routes,proto3" json:"routes,omitempty"`
+	// Labels put on the box.
+	Labels map[string]string `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Output only. Set on templates from the daemon's
+	// --container-templates file, which can't be changed through the API.
+	ReadOnly      bool `protobuf:"varint,11,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MoveContainerRequest) Reset() {
-	*x = MoveContainerRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[57]
+func (x *ContainerTemplate) Reset() {
+	*x = ContainerTemplate{}
+	mi := &file_containarium_v1_container_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MoveContainerRequest) String() string {
+func (x *ContainerTemplate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveContainerRequest) ProtoMessage() {}
+func (*ContainerTemplate) ProtoMessage() {}
 
-func (x *MoveContainerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[57]
+func (x *ContainerTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4585,84 +8006,110 @@ func (x *MoveContainerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveContainerRequest.ProtoReflect.Descriptor instead.
-func (*MoveContainerRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{57}
+// Deprecated: Use ContainerTemplate.ProtoReflect.Descriptor instead.
+func (*ContainerTemplate) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *MoveContainerRequest) GetUsername() string {
+func (x *ContainerTemplate) GetName() string {
 	if x != nil {
-		return x.Username
+		return x.Name
 	}
 	return ""
 }
 
-func (x *MoveContainerRequest) GetTargetBackendId() string {
+func (x *ContainerTemplate) GetDescription() string {
 	if x != nil {
-		return x.TargetBackendId
+		return x.Description
 	}
 	return ""
 }
 
-func (x *MoveContainerRequest) GetMaxIterations() int32 {
+func (x *ContainerTemplate) GetResources() *ResourceLimits {
 	if x != nil {
-		return x.MaxIterations
+		return x.Resources
 	}
-	return 0
+	return nil
 }
 
-func (x *MoveContainerRequest) GetDeltaThresholdSeconds() int32 {
+func (x *ContainerTemplate) GetImage() string {
 	if x != nil {
-		return x.DeltaThresholdSeconds
+		return x.Image
 	}
-	return 0
+	return ""
 }
 
-func (x *MoveContainerRequest) GetStateful() bool {
+func (x *ContainerTemplate) GetSshKeys() []string {
 	if x != nil {
-		return x.Stateful
+		return x.SshKeys
+	}
+	return nil
+}
+
+func (x *ContainerTemplate) GetEnablePodman() bool {
+	if x != nil {
+		return x.EnablePodman
 	}
 	return false
 }
 
-// MoveContainerResponse summarizes the migration outcome.
-type MoveContainerResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Human-readable success message.
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	// The container's new IP address on the target daemon, after start.
-	NewIpAddress string `protobuf:"bytes,2,opt,name=new_ip_address,json=newIpAddress,proto3" json:"new_ip_address,omitempty"`
-	// Backend the container now lives on (target_backend_id from req).
-	TargetBackendId string `protobuf:"bytes,3,opt,name=target_backend_id,json=targetBackendId,proto3" json:"target_backend_id,omitempty"`
-	// Total iterations performed (initial copy + N deltas + final).
-	// Helpful for tuning max_iterations on subsequent moves of similar
-	// workloads.
-	IterationsRun int32 `protobuf:"varint,4,opt,name=iterations_run,json=iterationsRun,proto3" json:"iterations_run,omitempty"`
-	// Total wall-clock seconds the container was unreachable during the
-	// cutover (between stop on source and start on target with route
-	// updated). With pre-copy + ZFS this should be < 5s; on dir-pool or
-	// active workloads it may be tens of seconds. -1 if stateful=true
-	// succeeded and there was no observable downtime.
-	DowntimeSeconds int32 `protobuf:"varint,5,opt,name=downtime_seconds,json=downtimeSeconds,proto3" json:"downtime_seconds,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *ContainerTemplate) GetStack() string {
+	if x != nil {
+		return x.Stack
+	}
+	return ""
 }
 
-func (x *MoveContainerResponse) Reset() {
-	*x = MoveContainerResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[58]
+func (x *ContainerTemplate) GetCloudInit() string {
+	if x != nil {
+		return x.CloudInit
+	}
+	return ""
+}
+
+func (x *ContainerTemplate) GetRoutes() []*ContainerTemplateRoute {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *ContainerTemplate) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ContainerTemplate) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+// ListContainerTemplatesRequest lists the daemon's container templates.
+type ListContainerTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainerTemplatesRequest) Reset() {
+	*x = ListContainerTemplatesRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MoveContainerResponse) String() string {
+func (x *ListContainerTemplatesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveContainerResponse) ProtoMessage() {}
+func (*ListContainerTemplatesRequest) ProtoMessage() {}
 
-func (x *MoveContainerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[58]
+func (x *ListContainerTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4673,82 +8120,79 @@ func (x *MoveContainerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveContainerResponse.ProtoReflect.Descriptor instead.
-func (*MoveContainerResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{58}
+// Deprecated: Use ListContainerTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListContainerTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *MoveContainerResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// ListContainerTemplatesResponse holds the templates, sorted by name.
+type ListContainerTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*ContainerTemplate   `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MoveContainerResponse) GetNewIpAddress() string {
-	if x != nil {
-		return x.NewIpAddress
-	}
-	return ""
+func (x *ListContainerTemplatesResponse) Reset() {
+	*x = ListContainerTemplatesResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *MoveContainerResponse) GetTargetBackendId() string {
-	if x != nil {
-		return x.TargetBackendId
-	}
-	return ""
+func (x *ListContainerTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *MoveContainerResponse) GetIterationsRun() int32 {
+func (*ListContainerTemplatesResponse) ProtoMessage() {}
+
+func (x *ListContainerTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[103]
 	if x != nil {
-		return x.IterationsRun
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *MoveContainerResponse) GetDowntimeSeconds() int32 {
+// Deprecated: Use ListContainerTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListContainerTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *ListContainerTemplatesResponse) GetTemplates() []*ContainerTemplate {
 	if x != nil {
-		return x.DowntimeSeconds
+		return x.Templates
 	}
-	return 0
+	return nil
 }
 
-// AdoptMigratedContainerRequest is sent by the source daemon's
-// MoveContainer to the destination daemon AFTER `incus copy` has
-// completed and the LXC exists on the destination's incusd. The
-// destination then registers the container with its own Containarium
-// state (host user account, route record at the new IP).
-//
-// Internal use only — exposed via REST for daemon-to-daemon coordination
-// over the same PeerPool channel used by other forwarding RPCs.
-type AdoptMigratedContainerRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Username of the migrated container.
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	// The source daemon's record of which routes belonged to this
-	// container, so the destination can recreate them at its own
-	// container IP. Each entry is "<full-domain>|<port>|<protocol>".
-	// Empty for stateless containers with no exposed ports.
-	SourceRoutes  []string `protobuf:"bytes,2,rep,name=source_routes,json=sourceRoutes,proto3" json:"source_routes,omitempty"`
+// GetContainerTemplateRequest names the template to return.
+type GetContainerTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdoptMigratedContainerRequest) Reset() {
-	*x = AdoptMigratedContainerRequest{}
-	mi := &file_containarium_v1_container_proto_msgTypes[59]
+func (x *GetContainerTemplateRequest) Reset() {
+	*x = GetContainerTemplateRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdoptMigratedContainerRequest) String() string {
+func (x *GetContainerTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdoptMigratedContainerRequest) ProtoMessage() {}
+func (*GetContainerTemplateRequest) ProtoMessage() {}
 
-func (x *AdoptMigratedContainerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[59]
+func (x *GetContainerTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4759,53 +8203,41 @@ func (x *AdoptMigratedContainerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdoptMigratedContainerRequest.ProtoReflect.Descriptor instead.
-func (*AdoptMigratedContainerRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use GetContainerTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetContainerTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *AdoptMigratedContainerRequest) GetUsername() string {
+func (x *GetContainerTemplateRequest) GetName() string {
 	if x != nil {
-		return x.Username
+		return x.Name
 	}
 	return ""
 }
 
-func (x *AdoptMigratedContainerRequest) GetSourceRoutes() []string {
-	if x != nil {
-		return x.SourceRoutes
-	}
-	return nil
-}
-
-// AdoptMigratedContainerResponse confirms the destination registered
-// the container and returns its IP for the source's route swap.
-type AdoptMigratedContainerResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Success message.
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	// The migrated container's new IP address on the destination's
-	// incusbr0. The source uses this for the final route store update.
-	NewIpAddress  string `protobuf:"bytes,2,opt,name=new_ip_address,json=newIpAddress,proto3" json:"new_ip_address,omitempty"`
+// GetContainerTemplateResponse holds the named template.
+type GetContainerTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *ContainerTemplate     `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdoptMigratedContainerResponse) Reset() {
-	*x = AdoptMigratedContainerResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[60]
+func (x *GetContainerTemplateResponse) Reset() {
+	*x = GetContainerTemplateResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdoptMigratedContainerResponse) String() string {
+func (x *GetContainerTemplateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdoptMigratedContainerResponse) ProtoMessage() {}
+func (*GetContainerTemplateResponse) ProtoMessage() {}
 
-func (x *AdoptMigratedContainerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[60]
+func (x *GetContainerTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4816,23 +8248,16 @@ func (x *AdoptMigratedContainerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdoptMigratedContainerResponse.ProtoReflect.Descriptor instead.
-func (*AdoptMigratedContainerResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{60}
-}
-
-func (x *AdoptMigratedContainerResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use GetContainerTemplateResponse.ProtoReflect.Descriptor instead.
+func (*GetContainerTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *AdoptMigratedContainerResponse) GetNewIpAddress() string {
+func (x *GetContainerTemplateResponse) GetTemplate() *ContainerTemplate {
 	if x != nil {
-		return x.NewIpAddress
+		return x.Template
 	}
-	return ""
+	return nil
 }
 
 var file_containarium_v1_container_proto_extTypes = []protoimpl.ExtensionInfo{
@@ -4856,7 +8281,7 @@ var File_containarium_v1_container_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_container_proto_rawDesc = "" +
 	"\n" +
-	"\x1fcontainarium/v1/container.proto\x12\x0fcontainarium.v1\x1a google/protobuf/descriptor.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x99\x01\n" +
+	"\x1fcontainarium/v1/container.proto\x12\x0fcontainarium.v1\x1a google/protobuf/descriptor.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1dcontainarium/v1/traffic.proto\"\x99\x01\n" +
 	"\x0eResourceLimits\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\tR\x03cpu\x12\x16\n" +
 	"\x06memory\x18\x02 \x01(\tR\x06memory\x12\x12\n" +
@@ -4910,7 +8335,7 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"gpuDevices\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcf\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x03\n" +
 	"\x10ContainerMetrics\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
 	"\x11cpu_usage_seconds\x18\x02 \x01(\x03R\x0fcpuUsageSeconds\x12,\n" +
@@ -4919,7 +8344,10 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"\x10disk_usage_bytes\x18\x05 \x01(\x03R\x0ediskUsageBytes\x12(\n" +
 	"\x10network_rx_bytes\x18\x06 \x01(\x03R\x0enetworkRxBytes\x12(\n" +
 	"\x10network_tx_bytes\x18\a \x01(\x03R\x0enetworkTxBytes\x12#\n" +
-	"\rprocess_count\x18\b \x01(\x05R\fprocessCount\"\x86\b\n" +
+	"\rprocess_count\x18\b \x01(\x05R\fprocessCount\x12,\n" +
+	"\x12network_bytes_sent\x18\t \x01(\x03R\x10networkBytesSent\x124\n" +
+	"\x16network_bytes_received\x18\n" +
+	" \x01(\x03R\x14networkBytesReceived\"\xa2\b\n" +
 	"\x16CreateContainerRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12=\n" +
 	"\tresources\x18\x02 \x01(\v2\x1f.containarium.v1.ResourceLimitsR\tresources\x12\x19\n" +
@@ -4949,7 +8377,8 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"ttlSeconds\x12*\n" +
 	"\x11idle_stop_minutes\x18\x15 \x01(\x05R\x0fidleStopMinutes\x12?\n" +
 	"\x1cdelete_after_stopped_seconds\x18\x16 \x01(\x03R\x19deleteAfterStoppedSeconds\x12\x12\n" +
-	"\x04gpus\x18\x17 \x03(\tR\x04gpus\x1a9\n" +
+	"\x04gpus\x18\x17 \x03(\tR\x04gpus\x12\x1a\n" +
+	"\btemplate\x18\x18 \x01(\tR\btemplate\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aB\n" +
@@ -4977,10 +8406,11 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\"1\n" +
 	"\x13GetContainerRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"\x8d\x01\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\xd7\x01\n" +
 	"\x14GetContainerResponse\x128\n" +
 	"\tcontainer\x18\x01 \x01(\v2\x1a.containarium.v1.ContainerR\tcontainer\x12;\n" +
-	"\ametrics\x18\x02 \x01(\v2!.containarium.v1.ContainerMetricsR\ametrics\"3\n" +
+	"\ametrics\x18\x02 \x01(\v2!.containarium.v1.ContainerMetricsR\ametrics\x12H\n" +
+	"\x0fbandwidth_limit\x18\x03 \x01(\v2\x1f.containarium.v1.BandwidthLimitR\x0ebandwidthLimit\"3\n" +
 	"\x15DebugContainerRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\"\xb6\x03\n" +
 	"\x16DebugContainerResponse\x12'\n" +
@@ -4995,13 +8425,28 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"sourceRepo\x12%\n" +
 	"\x0edaemon_version\x18\t \x01(\tR\rdaemonVersion\x12(\n" +
 	"\x10ssh_ingress_host\x18\n" +
-	" \x01(\tR\x0esshIngressHost\"J\n" +
+	" \x01(\tR\x0esshIngressHost\"c\n" +
 	"\x16DeleteContainerRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
-	"\x05force\x18\x02 \x01(\bR\x05force\"Z\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\xec\x01\n" +
 	"\x17DeleteContainerResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12%\n" +
-	"\x0econtainer_name\x18\x02 \x01(\tR\rcontainerName\"\x8d\x01\n" +
+	"\x0econtainer_name\x18\x02 \x01(\tR\rcontainerName\x127\n" +
+	"\aremoved\x18\x03 \x03(\v2\x1d.containarium.v1.TeardownItemR\aremoved\x12>\n" +
+	"\vleft_behind\x18\x04 \x03(\v2\x1d.containarium.v1.TeardownItemR\n" +
+	"leftBehind\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"{\n" +
+	"\fTeardownItem\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\x0econtainer_name\x18\x03 \x01(\tR\rcontainerName\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"0\n" +
+	"\x15GarbageCollectRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"j\n" +
+	"\x16GarbageCollectResponse\x127\n" +
+	"\aorphans\x18\x01 \x03(\v2\x1d.containarium.v1.TeardownItemR\aorphans\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\x8d\x01\n" +
 	"\x15StartContainerRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12$\n" +
 	"\x0ewait_for_ready\x18\x02 \x01(\bR\fwaitForReady\x122\n" +
@@ -5030,7 +8475,13 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"\x17ToggleAutoSleepResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12,\n" +
 	"\x12auto_sleep_enabled\x18\x02 \x01(\bR\x10autoSleepEnabled\x124\n" +
-	"\x16idle_threshold_minutes\x18\x03 \x01(\x05R\x14idleThresholdMinutes\"W\n" +
+	"\x16idle_threshold_minutes\x18\x03 \x01(\x05R\x14idleThresholdMinutes\"O\n" +
+	"\x17ToggleDNSLoggingRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"d\n" +
+	"\x18ToggleDNSLoggingResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12.\n" +
+	"\x13dns_logging_enabled\x18\x02 \x01(\bR\x11dnsLoggingEnabled\"W\n" +
 	"\x16SetContainerTTLRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
 	"\x10duration_seconds\x18\x02 \x01(\x03R\x0fdurationSeconds\"[\n" +
@@ -5065,9 +8516,11 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"\x14RemoveSSHKeyResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1d\n" +
 	"\n" +
-	"total_keys\x18\x02 \x01(\x05R\ttotalKeys\"/\n" +
+	"total_keys\x18\x02 \x01(\x05R\ttotalKeys\"^\n" +
 	"\x11GetMetricsRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"Q\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x17\n" +
+	"\asort_by\x18\x02 \x01(\tR\x06sortBy\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Q\n" +
 	"\x12GetMetricsResponse\x12;\n" +
 	"\ametrics\x18\x01 \x03(\v2!.containarium.v1.ContainerMetricsR\ametrics\"r\n" +
 	"\x16ResizeContainerRequest\x12\x1a\n" +
@@ -5121,7 +8574,202 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1f\n" +
 	"\vfreed_bytes\x18\x02 \x01(\x03R\n" +
 	"freedBytes\x128\n" +
-	"\tcontainer\x18\x03 \x01(\v2\x1a.containarium.v1.ContainerR\tcontainer\"L\n" +
+	"\tcontainer\x18\x03 \x01(\v2\x1a.containarium.v1.ContainerR\tcontainer\"P\n" +
+	"\x1cGetContainerProcessesRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xc3\x01\n" +
+	"\x10ContainerProcess\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12\x1f\n" +
+	"\vcpu_percent\x18\x03 \x01(\x01R\n" +
+	"cpuPercent\x12%\n" +
+	"\x0ememory_percent\x18\x04 \x01(\x01R\rmemoryPercent\x12'\n" +
+	"\x0felapsed_seconds\x18\x05 \x01(\x03R\x0eelapsedSeconds\x12\x18\n" +
+	"\acommand\x18\x06 \x01(\tR\acommand\"\xc6\x01\n" +
+	"\x1dGetContainerProcessesResponse\x12?\n" +
+	"\tprocesses\x18\x01 \x03(\v2!.containarium.v1.ContainerProcessR\tprocesses\x12'\n" +
+	"\x0ftotal_processes\x18\x02 \x01(\x05R\x0etotalProcesses\x12;\n" +
+	"\ametrics\x18\x03 \x01(\v2!.containarium.v1.ContainerMetricsR\ametrics\"g\n" +
+	"\x18ReadContainerFileRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1b\n" +
+	"\tmax_bytes\x18\x03 \x01(\x03R\bmaxBytes\"\xcb\x01\n" +
+	"\x19ReadContainerFileResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x10\n" +
+	"\x03uid\x18\x04 \x01(\x03R\x03uid\x12\x10\n" +
+	"\x03gid\x18\x05 \x01(\x03R\x03gid\x12\x18\n" +
+	"\acontent\x18\x06 \x01(\fR\acontent\x12\x1c\n" +
+	"\ttruncated\x18\a \x01(\bR\ttruncated\x12\x16\n" +
+	"\x06binary\x18\b \x01(\bR\x06binary\"\x8f\x01\n" +
+	"\x19WriteContainerFileRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\x12\x12\n" +
+	"\x04mode\x18\x04 \x01(\tR\x04mode\x12\x14\n" +
+	"\x05owner\x18\x05 \x01(\tR\x05owner\"|\n" +
+	"\x1aWriteContainerFileResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x10\n" +
+	"\x03uid\x18\x04 \x01(\x03R\x03uid\x12\x10\n" +
+	"\x03gid\x18\x05 \x01(\x03R\x03gid\"I\n" +
+	"\x17ListContainerDirRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"\x80\x01\n" +
+	"\x18ListContainerDirResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x10\n" +
+	"\x03uid\x18\x03 \x01(\x03R\x03uid\x12\x10\n" +
+	"\x03gid\x18\x04 \x01(\x03R\x03gid\x12\x18\n" +
+	"\aentries\x18\x05 \x03(\tR\aentries\"\x80\x01\n" +
+	"\x15DiffContainersRequest\x12\x1d\n" +
+	"\n" +
+	"username_a\x18\x01 \x01(\tR\tusernameA\x12\x1d\n" +
+	"\n" +
+	"username_b\x18\x02 \x01(\tR\tusernameB\x12)\n" +
+	"\x10include_packages\x18\x03 \x01(\bR\x0fincludePackages\"s\n" +
+	"\x13ContainerDifference\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x17\n" +
+	"\avalue_a\x18\x03 \x01(\tR\x06valueA\x12\x17\n" +
+	"\avalue_b\x18\x04 \x01(\tR\x06valueB\"\xe6\x01\n" +
+	"\x16DiffContainersResponse\x12\x1d\n" +
+	"\n" +
+	"username_a\x18\x01 \x01(\tR\tusernameA\x12\x1d\n" +
+	"\n" +
+	"username_b\x18\x02 \x01(\tR\tusernameB\x12\x17\n" +
+	"\astate_a\x18\x03 \x01(\tR\x06stateA\x12\x17\n" +
+	"\astate_b\x18\x04 \x01(\tR\x06stateB\x12F\n" +
+	"\vdifferences\x18\x05 \x03(\v2$.containarium.v1.ContainerDifferenceR\vdifferences\x12\x14\n" +
+	"\x05notes\x18\x06 \x03(\tR\x05notes\"\x8e\x01\n" +
+	"\x17TestConnectivityRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12'\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\"\x95\x02\n" +
+	"\x19ConnectivityPolicyVerdict\x12\x1c\n" +
+	"\tevaluated\x18\x01 \x01(\bR\tevaluated\x12\x16\n" +
+	"\x06tenant\x18\x02 \x01(\tR\x06tenant\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x18\n" +
+	"\aallowed\x18\x04 \x01(\bR\aallowed\x12\x1a\n" +
+	"\benforced\x18\x05 \x01(\bR\benforced\x12\x12\n" +
+	"\x04rule\x18\x06 \x01(\tR\x04rule\x12#\n" +
+	"\rvirtual_patch\x18\a \x01(\bR\fvirtualPatch\x12%\n" +
+	"\x0edefault_policy\x18\b \x01(\bR\rdefaultPolicy\x12\x12\n" +
+	"\x04note\x18\t \x01(\tR\x04note\"\xa7\x03\n" +
+	"\x18TestConnectivityResponse\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x1c\n" +
+	"\treachable\x18\x04 \x01(\bR\treachable\x12G\n" +
+	"\rfailure_stage\x18\x05 \x01(\x0e2\".containarium.v1.ConnectivityStageR\ffailureStage\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12!\n" +
+	"\fresolved_ips\x18\a \x03(\tR\vresolvedIps\x12\x1b\n" +
+	"\tremote_ip\x18\b \x01(\tR\bremoteIp\x12\x1f\n" +
+	"\vhttp_status\x18\t \x01(\x05R\n" +
+	"httpStatus\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\n" +
+	" \x01(\x01R\tlatencyMs\x12B\n" +
+	"\x06policy\x18\v \x01(\v2*.containarium.v1.ConnectivityPolicyVerdictR\x06policy\"\x81\x01\n" +
+	"\x11ContainerSnapshot\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x1a\n" +
+	"\bstateful\x18\x04 \x01(\bR\bstateful\"X\n" +
+	"\x15CreateSnapshotRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12#\n" +
+	"\rsnapshot_name\x18\x02 \x01(\tR\fsnapshotName\"r\n" +
+	"\x16CreateSnapshotResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12>\n" +
+	"\bsnapshot\x18\x02 \x01(\v2\".containarium.v1.ContainerSnapshotR\bsnapshot\"2\n" +
+	"\x14ListSnapshotsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"Y\n" +
+	"\x15ListSnapshotsResponse\x12@\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\".containarium.v1.ContainerSnapshotR\tsnapshots\"Y\n" +
+	"\x16RestoreSnapshotRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12#\n" +
+	"\rsnapshot_name\x18\x02 \x01(\tR\fsnapshotName\"s\n" +
+	"\x17RestoreSnapshotResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12>\n" +
+	"\bsnapshot\x18\x02 \x01(\v2\".containarium.v1.ContainerSnapshotR\bsnapshot\"`\n" +
+	"\x1bGetContainerActivityRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12%\n" +
+	"\x0ewindow_seconds\x18\x02 \x01(\x03R\rwindowSeconds\"~\n" +
+	"\x16ContainerActivityEvent\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"\xa4\x01\n" +
+	"\x17ContainerActivityChange\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x18\n" +
+	"\arequest\x18\x03 \x01(\tR\arequest\x12\x1f\n" +
+	"\vstatus_code\x18\x04 \x01(\x05R\n" +
+	"statusCode\"\xfd\x01\n" +
+	"\x18ContainerActivityMetrics\x12;\n" +
+	"\acurrent\x18\x01 \x01(\v2!.containarium.v1.ContainerMetricsR\acurrent\x12\"\n" +
+	"\ravg_cpu_cores\x18\x02 \x01(\x01R\vavgCpuCores\x12(\n" +
+	"\x10avg_memory_bytes\x18\x03 \x01(\x03R\x0eavgMemoryBytes\x12*\n" +
+	"\x11peak_memory_bytes\x18\x04 \x01(\x03R\x0fpeakMemoryBytes\x12*\n" +
+	"\x11disk_growth_bytes\x18\x05 \x01(\x03R\x0fdiskGrowthBytes\"\xa8\x01\n" +
+	"\x1cContainerActivityDestination\x12\x17\n" +
+	"\adest_ip\x18\x01 \x01(\tR\x06destIp\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x02 \x01(\x03R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x03 \x01(\x03R\rbytesReceived\x12)\n" +
+	"\x10connection_count\x18\x04 \x01(\x05R\x0fconnectionCount\"\xad\x02\n" +
+	"\x18ContainerActivityTraffic\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x01 \x01(\x03R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x02 \x01(\x03R\rbytesReceived\x12)\n" +
+	"\x10connection_count\x18\x03 \x01(\x03R\x0fconnectionCount\x12X\n" +
+	"\x10top_destinations\x18\x04 \x03(\v2-.containarium.v1.ContainerActivityDestinationR\x0ftopDestinations\x12#\n" +
+	"\ringress_bytes\x18\x05 \x01(\x03R\fingressBytes\x12!\n" +
+	"\fegress_bytes\x18\x06 \x01(\x03R\vegressBytes\"\x91\x01\n" +
+	"\x1aContainerActivityListeners\x128\n" +
+	"\acurrent\x18\x01 \x03(\v2\x1e.containarium.v1.ListeningPortR\acurrent\x129\n" +
+	"\achanges\x18\x02 \x03(\v2\x1f.containarium.v1.ListenerChangeR\achanges\"\xa2\x06\n" +
+	"\x1cGetContainerActivityResponse\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12%\n" +
+	"\x0econtainer_name\x18\x02 \x01(\tR\rcontainerName\x12=\n" +
+	"\fwindow_start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vwindowStart\x129\n" +
+	"\n" +
+	"window_end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\twindowEnd\x125\n" +
+	"\x05state\x18\x05 \x01(\x0e2\x1f.containarium.v1.ContainerStateR\x05state\x12R\n" +
+	"\x10lifecycle_events\x18\x06 \x03(\v2'.containarium.v1.ContainerActivityEventR\x0flifecycleEvents\x12C\n" +
+	"\ametrics\x18\a \x01(\v2).containarium.v1.ContainerActivityMetricsR\ametrics\x12C\n" +
+	"\atraffic\x18\b \x01(\v2).containarium.v1.ContainerActivityTrafficR\atraffic\x12@\n" +
+	"\tsnapshots\x18\t \x03(\v2\".containarium.v1.ContainerSnapshotR\tsnapshots\x12B\n" +
+	"\achanges\x18\n" +
+	" \x03(\v2(.containarium.v1.ContainerActivityChangeR\achanges\x12\x14\n" +
+	"\x05notes\x18\v \x03(\tR\x05notes\x12T\n" +
+	"\x0flistening_ports\x18\f \x01(\v2+.containarium.v1.ContainerActivityListenersR\x0elisteningPorts\x12>\n" +
+	"\fssh_sessions\x18\r \x03(\v2\x1b.containarium.v1.SSHSessionR\vsshSessions\"\xec\x01\n" +
+	"\rProvisionStep\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\x05state\x18\x02 \x01(\x0e2#.containarium.v1.ProvisionStepStateR\x05state\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"L\n" +
+	"\x0eReadinessCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\":\n" +
+	"\x1cGetContainerReadinessRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x9e\x02\n" +
+	"\x1dGetContainerReadinessResponse\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12%\n" +
+	"\x0econtainer_name\x18\x02 \x01(\tR\rcontainerName\x12\x14\n" +
+	"\x05ready\x18\x03 \x01(\bR\x05ready\x125\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x1f.containarium.v1.ContainerStateR\x05state\x124\n" +
+	"\x05steps\x18\x05 \x03(\v2\x1e.containarium.v1.ProvisionStepR\x05steps\x127\n" +
+	"\x06checks\x18\x06 \x03(\v2\x1f.containarium.v1.ReadinessCheckR\x06checks\"L\n" +
 	"\x13InstallStackRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x19\n" +
 	"\bstack_id\x18\x02 \x01(\tR\astackId\"j\n" +
@@ -5189,7 +8837,34 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"\rsource_routes\x18\x02 \x03(\tR\fsourceRoutes\"`\n" +
 	"\x1eAdoptMigratedContainerResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12$\n" +
-	"\x0enew_ip_address\x18\x02 \x01(\tR\fnewIpAddress*}\n" +
+	"\x0enew_ip_address\x18\x02 \x01(\tR\fnewIpAddress\"]\n" +
+	"\x16ContainerTemplateRoute\x12\x1c\n" +
+	"\tsubdomain\x18\x01 \x01(\tR\tsubdomain\x12%\n" +
+	"\x0econtainer_port\x18\x02 \x01(\x05R\rcontainerPort\"\xf4\x03\n" +
+	"\x11ContainerTemplate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12=\n" +
+	"\tresources\x18\x03 \x01(\v2\x1f.containarium.v1.ResourceLimitsR\tresources\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x12\x19\n" +
+	"\bssh_keys\x18\x05 \x03(\tR\asshKeys\x12#\n" +
+	"\renable_podman\x18\x06 \x01(\bR\fenablePodman\x12\x14\n" +
+	"\x05stack\x18\a \x01(\tR\x05stack\x12\x1d\n" +
+	"\n" +
+	"cloud_init\x18\b \x01(\tR\tcloudInit\x12?\n" +
+	"\x06routes\x18\t \x03(\v2'.containarium.v1.ContainerTemplateRouteR\x06routes\x12F\n" +
+	"\x06labels\x18\n" +
+	" \x03(\v2..containarium.v1.ContainerTemplate.LabelsEntryR\x06labels\x12\x1b\n" +
+	"\tread_only\x18\v \x01(\bR\breadOnly\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x1f\n" +
+	"\x1dListContainerTemplatesRequest\"b\n" +
+	"\x1eListContainerTemplatesResponse\x12@\n" +
+	"\ttemplates\x18\x01 \x03(\v2\".containarium.v1.ContainerTemplateR\ttemplates\"1\n" +
+	"\x1bGetContainerTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"^\n" +
+	"\x1cGetContainerTemplateResponse\x12>\n" +
+	"\btemplate\x18\x01 \x01(\v2\".containarium.v1.ContainerTemplateR\btemplate*}\n" +
 	"\x06OSType\x12\x17\n" +
 	"\x13OS_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13OS_TYPE_UBUNTU_2404\x10\x01\x12\x13\n" +
@@ -5211,7 +8886,20 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"\x1cCONTAINER_STATE_PROVISIONING\x10\x06\x1a\x10\x8a\xb5\x18\fProvisioning*J\n" +
 	"\fDeletePolicy\x12\x1d\n" +
 	"\x19DELETE_POLICY_UNSPECIFIED\x10\x00\x12\x1b\n" +
-	"\x17DELETE_POLICY_PROTECTED\x10\x01*~\n" +
+	"\x17DELETE_POLICY_PROTECTED\x10\x01*\xe9\x01\n" +
+	"\x11ConnectivityStage\x12\"\n" +
+	"\x1eCONNECTIVITY_STAGE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19CONNECTIVITY_STAGE_POLICY\x10\x01\x12\x1a\n" +
+	"\x16CONNECTIVITY_STAGE_DNS\x10\x02\x12\x1e\n" +
+	"\x1aCONNECTIVITY_STAGE_CONNECT\x10\x03\x12\x1a\n" +
+	"\x16CONNECTIVITY_STAGE_TLS\x10\x04\x12\x1b\n" +
+	"\x17CONNECTIVITY_STAGE_HTTP\x10\x05\x12\x1c\n" +
+	"\x18CONNECTIVITY_STAGE_PROBE\x10\x06*\x9c\x01\n" +
+	"\x12ProvisionStepState\x12$\n" +
+	" PROVISION_STEP_STATE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cPROVISION_STEP_STATE_RUNNING\x10\x01\x12\x1d\n" +
+	"\x19PROVISION_STEP_STATE_DONE\x10\x02\x12\x1f\n" +
+	"\x1bPROVISION_STEP_STATE_FAILED\x10\x03*~\n" +
 	"\x14CloudMetricsProvider\x12&\n" +
 	"\"CLOUD_METRICS_PROVIDER_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aCLOUD_METRICS_PROVIDER_GCP\x10\x01\x12\x1e\n" +
@@ -5236,133 +8924,224 @@ func file_containarium_v1_container_proto_rawDescGZIP() []byte {
 	return file_containarium_v1_container_proto_rawDescData
 }
 
-var file_containarium_v1_container_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_containarium_v1_container_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
+var file_containarium_v1_container_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_containarium_v1_container_proto_msgTypes = make([]protoimpl.MessageInfo, 113)
 var file_containarium_v1_container_proto_goTypes = []any{
 	(OSType)(0),                              // 0: containarium.v1.OSType
 	(AccessType)(0),                          // 1: containarium.v1.AccessType
 	(ContainerState)(0),                      // 2: containarium.v1.ContainerState
 	(DeletePolicy)(0),                        // 3: containarium.v1.DeletePolicy
-	(CloudMetricsProvider)(0),                // 4: containarium.v1.CloudMetricsProvider
-	(CloudMetricsGroup)(0),                   // 5: containarium.v1.CloudMetricsGroup
-	(*ResourceLimits)(nil),                   // 6: containarium.v1.ResourceLimits
-	(*NetworkInfo)(nil),                      // 7: containarium.v1.NetworkInfo
-	(*Container)(nil),                        // 8: containarium.v1.Container
-	(*ContainerMetrics)(nil),                 // 9: containarium.v1.ContainerMetrics
-	(*CreateContainerRequest)(nil),           // 10: containarium.v1.CreateContainerRequest
-	(*CreateContainerResponse)(nil),          // 11: containarium.v1.CreateContainerResponse
-	(*ListContainersRequest)(nil),            // 12: containarium.v1.ListContainersRequest
-	(*ListContainersResponse)(nil),           // 13: containarium.v1.ListContainersResponse
-	(*GetContainerRequest)(nil),              // 14: containarium.v1.GetContainerRequest
-	(*GetContainerResponse)(nil),             // 15: containarium.v1.GetContainerResponse
-	(*DebugContainerRequest)(nil),            // 16: containarium.v1.DebugContainerRequest
-	(*DebugContainerResponse)(nil),           // 17: containarium.v1.DebugContainerResponse
-	(*DeleteContainerRequest)(nil),           // 18: containarium.v1.DeleteContainerRequest
-	(*DeleteContainerResponse)(nil),          // 19: containarium.v1.DeleteContainerResponse
-	(*StartContainerRequest)(nil),            // 20: containarium.v1.StartContainerRequest
-	(*StartContainerResponse)(nil),           // 21: containarium.v1.StartContainerResponse
-	(*StopContainerRequest)(nil),             // 22: containarium.v1.StopContainerRequest
-	(*StopContainerResponse)(nil),            // 23: containarium.v1.StopContainerResponse
-	(*ToggleMonitoringRequest)(nil),          // 24: containarium.v1.ToggleMonitoringRequest
-	(*ToggleMonitoringResponse)(nil),         // 25: containarium.v1.ToggleMonitoringResponse
-	(*ToggleAutoSleepRequest)(nil),           // 26: containarium.v1.ToggleAutoSleepRequest
-	(*ToggleAutoSleepResponse)(nil),          // 27: containarium.v1.ToggleAutoSleepResponse
-	(*SetContainerTTLRequest)(nil),           // 28: containarium.v1.SetContainerTTLRequest
-	(*SetContainerTTLResponse)(nil),          // 29: containarium.v1.SetContainerTTLResponse
-	(*SetContainerDeletePolicyRequest)(nil),  // 30: containarium.v1.SetContainerDeletePolicyRequest
-	(*SetContainerDeletePolicyResponse)(nil), // 31: containarium.v1.SetContainerDeletePolicyResponse
-	(*SetContainerAttributionRequest)(nil),   // 32: containarium.v1.SetContainerAttributionRequest
-	(*SetContainerAttributionResponse)(nil),  // 33: containarium.v1.SetContainerAttributionResponse
-	(*AddSSHKeyRequest)(nil),                 // 34: containarium.v1.AddSSHKeyRequest
-	(*AddSSHKeyResponse)(nil),                // 35: containarium.v1.AddSSHKeyResponse
-	(*RemoveSSHKeyRequest)(nil),              // 36: containarium.v1.RemoveSSHKeyRequest
-	(*RemoveSSHKeyResponse)(nil),             // 37: containarium.v1.RemoveSSHKeyResponse
-	(*GetMetricsRequest)(nil),                // 38: containarium.v1.GetMetricsRequest
-	(*GetMetricsResponse)(nil),               // 39: containarium.v1.GetMetricsResponse
-	(*ResizeContainerRequest)(nil),           // 40: containarium.v1.ResizeContainerRequest
-	(*ResizeContainerResponse)(nil),          // 41: containarium.v1.ResizeContainerResponse
-	(*Collaborator)(nil),                     // 42: containarium.v1.Collaborator
-	(*AddCollaboratorRequest)(nil),           // 43: containarium.v1.AddCollaboratorRequest
-	(*AddCollaboratorResponse)(nil),          // 44: containarium.v1.AddCollaboratorResponse
-	(*RemoveCollaboratorRequest)(nil),        // 45: containarium.v1.RemoveCollaboratorRequest
-	(*RemoveCollaboratorResponse)(nil),       // 46: containarium.v1.RemoveCollaboratorResponse
-	(*ListCollaboratorsRequest)(nil),         // 47: containarium.v1.ListCollaboratorsRequest
-	(*ListCollaboratorsResponse)(nil),        // 48: containarium.v1.ListCollaboratorsResponse
-	(*CleanupDiskRequest)(nil),               // 49: containarium.v1.CleanupDiskRequest
-	(*CleanupDiskResponse)(nil),              // 50: containarium.v1.CleanupDiskResponse
-	(*InstallStackRequest)(nil),              // 51: containarium.v1.InstallStackRequest
-	(*InstallStackResponse)(nil),             // 52: containarium.v1.InstallStackResponse
-	(*StackParameter)(nil),                   // 53: containarium.v1.StackParameter
-	(*StackInfo)(nil),                        // 54: containarium.v1.StackInfo
-	(*ListStacksRequest)(nil),                // 55: containarium.v1.ListStacksRequest
-	(*ListStacksResponse)(nil),               // 56: containarium.v1.ListStacksResponse
-	(*GetMonitoringInfoRequest)(nil),         // 57: containarium.v1.GetMonitoringInfoRequest
-	(*GetMonitoringInfoResponse)(nil),        // 58: containarium.v1.GetMonitoringInfoResponse
-	(*SetMetricsExportRequest)(nil),          // 59: containarium.v1.SetMetricsExportRequest
-	(*SetMetricsExportResponse)(nil),         // 60: containarium.v1.SetMetricsExportResponse
-	(*GetMetricsExportRequest)(nil),          // 61: containarium.v1.GetMetricsExportRequest
-	(*GetMetricsExportResponse)(nil),         // 62: containarium.v1.GetMetricsExportResponse
-	(*MoveContainerRequest)(nil),             // 63: containarium.v1.MoveContainerRequest
-	(*MoveContainerResponse)(nil),            // 64: containarium.v1.MoveContainerResponse
-	(*AdoptMigratedContainerRequest)(nil),    // 65: containarium.v1.AdoptMigratedContainerRequest
-	(*AdoptMigratedContainerResponse)(nil),   // 66: containarium.v1.AdoptMigratedContainerResponse
-	nil,                                      // 67: containarium.v1.Container.LabelsEntry
-	nil,                                      // 68: containarium.v1.CreateContainerRequest.LabelsEntry
-	nil,                                      // 69: containarium.v1.CreateContainerRequest.StackParametersEntry
-	nil,                                      // 70: containarium.v1.ListContainersRequest.LabelFilterEntry
-	nil,                                      // 71: containarium.v1.SetContainerAttributionRequest.LabelsEntry
-	nil,                                      // 72: containarium.v1.SetContainerAttributionResponse.LabelsEntry
-	(*timestamppb.Timestamp)(nil),            // 73: google.protobuf.Timestamp
-	(*descriptorpb.EnumValueOptions)(nil),    // 74: google.protobuf.EnumValueOptions
+	(ConnectivityStage)(0),                   // 4: containarium.v1.ConnectivityStage
+	(ProvisionStepState)(0),                  // 5: containarium.v1.ProvisionStepState
+	(CloudMetricsProvider)(0),                // 6: containarium.v1.CloudMetricsProvider
+	(CloudMetricsGroup)(0),                   // 7: containarium.v1.CloudMetricsGroup
+	(*ResourceLimits)(nil),                   // 8: containarium.v1.ResourceLimits
+	(*NetworkInfo)(nil),                      // 9: containarium.v1.NetworkInfo
+	(*Container)(nil),                        // 10: containarium.v1.Container
+	(*ContainerMetrics)(nil),                 // 11: containarium.v1.ContainerMetrics
+	(*CreateContainerRequest)(nil),           // 12: containarium.v1.CreateContainerRequest
+	(*CreateContainerResponse)(nil),          // 13: containarium.v1.CreateContainerResponse
+	(*ListContainersRequest)(nil),            // 14: containarium.v1.ListContainersRequest
+	(*ListContainersResponse)(nil),           // 15: containarium.v1.ListContainersResponse
+	(*GetContainerRequest)(nil),              // 16: containarium.v1.GetContainerRequest
+	(*GetContainerResponse)(nil),             // 17: containarium.v1.GetContainerResponse
+	(*DebugContainerRequest)(nil),            // 18: containarium.v1.DebugContainerRequest
+	(*DebugContainerResponse)(nil),           // 19: containarium.v1.DebugContainerResponse
+	(*DeleteContainerRequest)(nil),           // 20: containarium.v1.DeleteContainerRequest
+	(*DeleteContainerResponse)(nil),          // 21: containarium.v1.DeleteContainerResponse
+	(*TeardownItem)(nil),                     // 22: containarium.v1.TeardownItem
+	(*GarbageCollectRequest)(nil),            // 23: containarium.v1.GarbageCollectRequest
+	(*GarbageCollectResponse)(nil),           // 24: containarium.v1.GarbageCollectResponse
+	(*StartContainerRequest)(nil),            // 25: containarium.v1.StartContainerRequest
+	(*StartContainerResponse)(nil),           // 26: containarium.v1.StartContainerResponse
+	(*StopContainerRequest)(nil),             // 27: containarium.v1.StopContainerRequest
+	(*StopContainerResponse)(nil),            // 28: containarium.v1.StopContainerResponse
+	(*ToggleMonitoringRequest)(nil),          // 29: containarium.v1.ToggleMonitoringRequest
+	(*ToggleMonitoringResponse)(nil),         // 30: containarium.v1.ToggleMonitoringResponse
+	(*ToggleAutoSleepRequest)(nil),           // 31: containarium.v1.ToggleAutoSleepRequest
+	(*ToggleAutoSleepResponse)(nil),          // 32: containarium.v1.ToggleAutoSleepResponse
+	(*ToggleDNSLoggingRequest)(nil),          // 33: containarium.v1.ToggleDNSLoggingRequest
+	(*ToggleDNSLoggingResponse)(nil),         // 34: containarium.v1.ToggleDNSLoggingResponse
+	(*SetContainerTTLRequest)(nil),           // 35: containarium.v1.SetContainerTTLRequest
+	(*SetContainerTTLResponse)(nil),          // 36: containarium.v1.SetContainerTTLResponse
+	(*SetContainerDeletePolicyRequest)(nil),  // 37: containarium.v1.SetContainerDeletePolicyRequest
+	(*SetContainerDeletePolicyResponse)(nil), // 38: containarium.v1.SetContainerDeletePolicyResponse
+	(*SetContainerAttributionRequest)(nil),   // 39: containarium.v1.SetContainerAttributionRequest
+	(*SetContainerAttributionResponse)(nil),  // 40: containarium.v1.SetContainerAttributionResponse
+	(*AddSSHKeyRequest)(nil),                 // 41: containarium.v1.AddSSHKeyRequest
+	(*AddSSHKeyResponse)(nil),                // 42: containarium.v1.AddSSHKeyResponse
+	(*RemoveSSHKeyRequest)(nil),              // 43: containarium.v1.RemoveSSHKeyRequest
+	(*RemoveSSHKeyResponse)(nil),             // 44: containarium.v1.RemoveSSHKeyResponse
+	(*GetMetricsRequest)(nil),                // 45: containarium.v1.GetMetricsRequest
+	(*GetMetricsResponse)(nil),               // 46: containarium.v1.GetMetricsResponse
+	(*ResizeContainerRequest)(nil),           // 47: containarium.v1.ResizeContainerRequest
+	(*ResizeContainerResponse)(nil),          // 48: containarium.v1.ResizeContainerResponse
+	(*Collaborator)(nil),                     // 49: containarium.v1.Collaborator
+	(*AddCollaboratorRequest)(nil),           // 50: containarium.v1.AddCollaboratorRequest
+	(*AddCollaboratorResponse)(nil),          // 51: containarium.v1.AddCollaboratorResponse
+	(*RemoveCollaboratorRequest)(nil),        // 52: containarium.v1.RemoveCollaboratorRequest
+	(*RemoveCollaboratorResponse)(nil),       // 53: containarium.v1.RemoveCollaboratorResponse
+	(*ListCollaboratorsRequest)(nil),         // 54: containarium.v1.ListCollaboratorsRequest
+	(*ListCollaboratorsResponse)(nil),        // 55: containarium.v1.ListCollaboratorsResponse
+	(*CleanupDiskRequest)(nil),               // 56: containarium.v1.CleanupDiskRequest
+	(*CleanupDiskResponse)(nil),              // 57: containarium.v1.CleanupDiskResponse
+	(*GetContainerProcessesRequest)(nil),     // 58: containarium.v1.GetContainerProcessesRequest
+	(*ContainerProcess)(nil),                 // 59: containarium.v1.ContainerProcess
+	(*GetContainerProcessesResponse)(nil),    // 60: containarium.v1.GetContainerProcessesResponse
+	(*ReadContainerFileRequest)(nil),         // 61: containarium.v1.ReadContainerFileRequest
+	(*ReadContainerFileResponse)(nil),        // 62: containarium.v1.ReadContainerFileResponse
+	(*WriteContainerFileRequest)(nil),        // 63: containarium.v1.WriteContainerFileRequest
+	(*WriteContainerFileResponse)(nil),       // 64: containarium.v1.WriteContainerFileResponse
+	(*ListContainerDirRequest)(nil),          // 65: containarium.v1.ListContainerDirRequest
+	(*ListContainerDirResponse)(nil),         // 66: containarium.v1.ListContainerDirResponse
+	(*DiffContainersRequest)(nil),            // 67: containarium.v1.DiffContainersRequest
+	(*ContainerDifference)(nil),              // 68: containarium.v1.ContainerDifference
+	(*DiffContainersResponse)(nil),           // 69: containarium.v1.DiffContainersResponse
+	(*TestConnectivityRequest)(nil),          // 70: containarium.v1.TestConnectivityRequest
+	(*ConnectivityPolicyVerdict)(nil),        // 71: containarium.v1.ConnectivityPolicyVerdict
+	(*TestConnectivityResponse)(nil),         // 72: containarium.v1.TestConnectivityResponse
+	(*ContainerSnapshot)(nil),                // 73: containarium.v1.ContainerSnapshot
+	(*CreateSnapshotRequest)(nil),            // 74: containarium.v1.CreateSnapshotRequest
+	(*CreateSnapshotResponse)(nil),           // 75: containarium.v1.CreateSnapshotResponse
+	(*ListSnapshotsRequest)(nil),             // 76: containarium.v1.ListSnapshotsRequest
+	(*ListSnapshotsResponse)(nil),            // 77: containarium.v1.ListSnapshotsResponse
+	(*RestoreSnapshotRequest)(nil),           // 78: containarium.v1.RestoreSnapshotRequest
+	(*RestoreSnapshotResponse)(nil),          // 79: containarium.v1.RestoreSnapshotResponse
+	(*GetContainerActivityRequest)(nil),      // 80: containarium.v1.GetContainerActivityRequest
+	(*ContainerActivityEvent)(nil),           // 81: containarium.v1.ContainerActivityEvent
+	(*ContainerActivityChange)(nil),          // 82: containarium.v1.ContainerActivityChange
+	(*ContainerActivityMetrics)(nil),         // 83: containarium.v1.ContainerActivityMetrics
+	(*ContainerActivityDestination)(nil),     // 84: containarium.v1.ContainerActivityDestination
+	(*ContainerActivityTraffic)(nil),         // 85: containarium.v1.ContainerActivityTraffic
+	(*ContainerActivityListeners)(nil),       // 86: containarium.v1.ContainerActivityListeners
+	(*GetContainerActivityResponse)(nil),     // 87: containarium.v1.GetContainerActivityResponse
+	(*ProvisionStep)(nil),                    // 88: containarium.v1.ProvisionStep
+	(*ReadinessCheck)(nil),                   // 89: containarium.v1.ReadinessCheck
+	(*GetContainerReadinessRequest)(nil),     // 90: containarium.v1.GetContainerReadinessRequest
+	(*GetContainerReadinessResponse)(nil),    // 91: containarium.v1.GetContainerReadinessResponse
+	(*InstallStackRequest)(nil),              // 92: containarium.v1.InstallStackRequest
+	(*InstallStackResponse)(nil),             // 93: containarium.v1.InstallStackResponse
+	(*StackParameter)(nil),                   // 94: containarium.v1.StackParameter
+	(*StackInfo)(nil),                        // 95: containarium.v1.StackInfo
+	(*ListStacksRequest)(nil),                // 96: containarium.v1.ListStacksRequest
+	(*ListStacksResponse)(nil),               // 97: containarium.v1.ListStacksResponse
+	(*GetMonitoringInfoRequest)(nil),         // 98: containarium.v1.GetMonitoringInfoRequest
+	(*GetMonitoringInfoResponse)(nil),        // 99: containarium.v1.GetMonitoringInfoResponse
+	(*SetMetricsExportRequest)(nil),          // 100: containarium.v1.SetMetricsExportRequest
+	(*SetMetricsExportResponse)(nil),         // 101: containarium.v1.SetMetricsExportResponse
+	(*GetMetricsExportRequest)(nil),          // 102: containarium.v1.GetMetricsExportRequest
+	(*GetMetricsExportResponse)(nil),         // 103: containarium.v1.GetMetricsExportResponse
+	(*MoveContainerRequest)(nil),             // 104: containarium.v1.MoveContainerRequest
+	(*MoveContainerResponse)(nil),            // 105: containarium.v1.MoveContainerResponse
+	(*AdoptMigratedContainerRequest)(nil),    // 106: containarium.v1.AdoptMigratedContainerRequest
+	(*AdoptMigratedContainerResponse)(nil),   // 107: containarium.v1.AdoptMigratedContainerResponse
+	(*ContainerTemplateRoute)(nil),           // 108: containarium.v1.ContainerTemplateRoute
+	(*ContainerTemplate)(nil),                // 109: containarium.v1.ContainerTemplate
+	(*ListContainerTemplatesRequest)(nil),    // 110: containarium.v1.ListContainerTemplatesRequest
+	(*ListContainerTemplatesResponse)(nil),   // 111: containarium.v1.ListContainerTemplatesResponse
+	(*GetContainerTemplateRequest)(nil),      // 112: containarium.v1.GetContainerTemplateRequest
+	(*GetContainerTemplateResponse)(nil),     // 113: containarium.v1.GetContainerTemplateResponse
+	nil,                                      // 114: containarium.v1.Container.LabelsEntry
+	nil,                                      // 115: containarium.v1.CreateContainerRequest.LabelsEntry
+	nil,                                      // 116: containarium.v1.CreateContainerRequest.StackParametersEntry
+	nil,                                      // 117: containarium.v1.ListContainersRequest.LabelFilterEntry
+	nil,                                      // 118: containarium.v1.SetContainerAttributionRequest.LabelsEntry
+	nil,                                      // 119: containarium.v1.SetContainerAttributionResponse.LabelsEntry
+	nil,                                      // 120: containarium.v1.ContainerTemplate.LabelsEntry
+	(*timestamppb.Timestamp)(nil),            // 121: google.protobuf.Timestamp
+	(*BandwidthLimit)(nil),                   // 122: containarium.v1.BandwidthLimit
+	(*ListeningPort)(nil),                    // 123: containarium.v1.ListeningPort
+	(*ListenerChange)(nil),                   // 124: containarium.v1.ListenerChange
+	(*SSHSession)(nil),                       // 125: containarium.v1.SSHSession
+	(*descriptorpb.EnumValueOptions)(nil),    // 126: google.protobuf.EnumValueOptions
 }
 var file_containarium_v1_container_proto_depIdxs = []int32{
-	2,  // 0: containarium.v1.Container.state:type_name -> containarium.v1.ContainerState
-	6,  // 1: containarium.v1.Container.resources:type_name -> containarium.v1.ResourceLimits
-	7,  // 2: containarium.v1.Container.network:type_name -> containarium.v1.NetworkInfo
-	67, // 3: containarium.v1.Container.labels:type_name -> containarium.v1.Container.LabelsEntry
-	0,  // 4: containarium.v1.Container.os_type:type_name -> containarium.v1.OSType
-	1,  // 5: containarium.v1.Container.access_type:type_name -> containarium.v1.AccessType
-	73, // 6: containarium.v1.Container.ttl_expires_at:type_name -> google.protobuf.Timestamp
-	73, // 7: containarium.v1.Container.stopped_at:type_name -> google.protobuf.Timestamp
-	3,  // 8: containarium.v1.Container.delete_policy:type_name -> containarium.v1.DeletePolicy
-	6,  // 9: containarium.v1.CreateContainerRequest.resources:type_name -> containarium.v1.ResourceLimits
-	68, // 10: containarium.v1.CreateContainerRequest.labels:type_name -> containarium.v1.CreateContainerRequest.LabelsEntry
-	0,  // 11: containarium.v1.CreateContainerRequest.os_type:type_name -> containarium.v1.OSType
-	69, // 12: containarium.v1.CreateContainerRequest.stack_parameters:type_name -> containarium.v1.CreateContainerRequest.StackParametersEntry
-	8,  // 13: containarium.v1.CreateContainerResponse.container:type_name -> containarium.v1.Container
-	2,  // 14: containarium.v1.ListContainersRequest.state:type_name -> containarium.v1.ContainerState
-	70, // 15: containarium.v1.ListContainersRequest.label_filter:type_name -> containarium.v1.ListContainersRequest.LabelFilterEntry
-	8,  // 16: containarium.v1.ListContainersResponse.containers:type_name -> containarium.v1.Container
-	8,  // 17: containarium.v1.GetContainerResponse.container:type_name -> containarium.v1.Container
-	9,  // 18: containarium.v1.GetContainerResponse.metrics:type_name -> containarium.v1.ContainerMetrics
-	8,  // 19: containarium.v1.StartContainerResponse.container:type_name -> containarium.v1.Container
-	8,  // 20: containarium.v1.StopContainerResponse.container:type_name -> containarium.v1.Container
-	73, // 21: containarium.v1.SetContainerTTLResponse.ttl_expires_at:type_name -> google.protobuf.Timestamp
-	3,  // 22: containarium.v1.SetContainerDeletePolicyRequest.delete_policy:type_name -> containarium.v1.DeletePolicy
-	3,  // 23: containarium.v1.SetContainerDeletePolicyResponse.delete_policy:type_name -> containarium.v1.DeletePolicy
-	71, // 24: containarium.v1.SetContainerAttributionRequest.labels:type_name -> containarium.v1.SetContainerAttributionRequest.LabelsEntry
-	72, // 25: containarium.v1.SetContainerAttributionResponse.labels:type_name -> containarium.v1.SetContainerAttributionResponse.LabelsEntry
-	9,  // 26: containarium.v1.GetMetricsResponse.metrics:type_name -> containarium.v1.ContainerMetrics
-	8,  // 27: containarium.v1.ResizeContainerResponse.container:type_name -> containarium.v1.Container
-	42, // 28: containarium.v1.AddCollaboratorResponse.collaborator:type_name -> containarium.v1.Collaborator
-	42, // 29: containarium.v1.ListCollaboratorsResponse.collaborators:type_name -> containarium.v1.Collaborator
-	8,  // 30: containarium.v1.CleanupDiskResponse.container:type_name -> containarium.v1.Container
-	8,  // 31: containarium.v1.InstallStackResponse.container:type_name -> containarium.v1.Container
-	53, // 32: containarium.v1.StackInfo.parameters:type_name -> containarium.v1.StackParameter
-	54, // 33: containarium.v1.ListStacksResponse.stacks:type_name -> containarium.v1.StackInfo
-	4,  // 34: containarium.v1.SetMetricsExportRequest.provider:type_name -> containarium.v1.CloudMetricsProvider
-	5,  // 35: containarium.v1.SetMetricsExportRequest.groups:type_name -> containarium.v1.CloudMetricsGroup
-	4,  // 36: containarium.v1.SetMetricsExportResponse.provider:type_name -> containarium.v1.CloudMetricsProvider
-	5,  // 37: containarium.v1.SetMetricsExportResponse.groups:type_name -> containarium.v1.CloudMetricsGroup
-	4,  // 38: containarium.v1.GetMetricsExportResponse.provider:type_name -> containarium.v1.CloudMetricsProvider
-	73, // 39: containarium.v1.GetMetricsExportResponse.last_success_at:type_name -> google.protobuf.Timestamp
-	5,  // 40: containarium.v1.GetMetricsExportResponse.groups:type_name -> containarium.v1.CloudMetricsGroup
-	74, // 41: containarium.v1.state_name:extendee -> google.protobuf.EnumValueOptions
-	42, // [42:42] is the sub-list for method output_type
-	42, // [42:42] is the sub-list for method input_type
-	42, // [42:42] is the sub-list for extension type_name
-	41, // [41:42] is the sub-list for extension extendee
-	0,  // [0:41] is the sub-list for field type_name
+	2,   // 0: containarium.v1.Container.state:type_name -> containarium.v1.ContainerState
+	8,   // 1: containarium.v1.Container.resources:type_name -> containarium.v1.ResourceLimits
+	9,   // 2: containarium.v1.Container.network:type_name -> containarium.v1.NetworkInfo
+	114, // 3: containarium.v1.Container.labels:type_name -> containarium.v1.Container.LabelsEntry
+	0,   // 4: containarium.v1.Container.os_type:type_name -> containarium.v1.OSType
+	1,   // 5: containarium.v1.Container.access_type:type_name -> containarium.v1.AccessType
+	121, // 6: containarium.v1.Container.ttl_expires_at:type_name -> google.protobuf.Timestamp
+	121, // 7: containarium.v1.Container.stopped_at:type_name -> google.protobuf.Timestamp
+	3,   // 8: containarium.v1.Container.delete_policy:type_name -> containarium.v1.DeletePolicy
+	8,   // 9: containarium.v1.CreateContainerRequest.resources:type_name -> containarium.v1.ResourceLimits
+	115, // 10: containarium.v1.CreateContainerRequest.labels:type_name -> containarium.v1.CreateContainerRequest.LabelsEntry
+	0,   // 11: containarium.v1.CreateContainerRequest.os_type:type_name -> containarium.v1.OSType
+	116, // 12: containarium.v1.CreateContainerRequest.stack_parameters:type_name -> containarium.v1.CreateContainerRequest.StackParametersEntry
+	10,  // 13: containarium.v1.CreateContainerResponse.container:type_name -> containarium.v1.Container
+	2,   // 14: containarium.v1.ListContainersRequest.state:type_name -> containarium.v1.ContainerState
+	117, // 15: containarium.v1.ListContainersRequest.label_filter:type_name -> containarium.v1.ListContainersRequest.LabelFilterEntry
+	10,  // 16: containarium.v1.ListContainersResponse.containers:type_name -> containarium.v1.Container
+	10,  // 17: containarium.v1.GetContainerResponse.container:type_name -> containarium.v1.Container
+	11,  // 18: containarium.v1.GetContainerResponse.metrics:type_name -> containarium.v1.ContainerMetrics
+	122, // 19: containarium.v1.GetContainerResponse.bandwidth_limit:type_name -> containarium.v1.BandwidthLimit
+	22,  // 20: containarium.v1.DeleteContainerResponse.removed:type_name -> containarium.v1.TeardownItem
+	22,  // 21: containarium.v1.DeleteContainerResponse.left_behind:type_name -> containarium.v1.TeardownItem
+	22,  // 22: containarium.v1.GarbageCollectResponse.orphans:type_name -> containarium.v1.TeardownItem
+	10,  // 23: containarium.v1.StartContainerResponse.container:type_name -> containarium.v1.Container
+	10,  // 24: containarium.v1.StopContainerResponse.container:type_name -> containarium.v1.Container
+	121, // 25: containarium.v1.SetContainerTTLResponse.ttl_expires_at:type_name -> google.protobuf.Timestamp
+	3,   // 26: containarium.v1.SetContainerDeletePolicyRequest.delete_policy:type_name -> containarium.v1.DeletePolicy
+	3,   // 27: containarium.v1.SetContainerDeletePolicyResponse.delete_policy:type_name -> containarium.v1.DeletePolicy
+	118, // 28: containarium.v1.SetContainerAttributionRequest.labels:type_name -> containarium.v1.SetContainerAttributionRequest.LabelsEntry
+	119, // 29: containarium.v1.SetContainerAttributionResponse.labels:type_name -> containarium.v1.SetContainerAttributionResponse.LabelsEntry
+	11,  // 30: containarium.v1.GetMetricsResponse.metrics:type_name -> containarium.v1.ContainerMetrics
+	10,  // 31: containarium.v1.ResizeContainerResponse.container:type_name -> containarium.v1.Container
+	49,  // 32: containarium.v1.AddCollaboratorResponse.collaborator:type_name -> containarium.v1.Collaborator
+	49,  // 33: containarium.v1.ListCollaboratorsResponse.collaborators:type_name -> containarium.v1.Collaborator
+	10,  // 34: containarium.v1.CleanupDiskResponse.container:type_name -> containarium.v1.Container
+	59,  // 35: containarium.v1.GetContainerProcessesResponse.processes:type_name -> containarium.v1.ContainerProcess
+	11,  // 36: containarium.v1.GetContainerProcessesResponse.metrics:type_name -> containarium.v1.ContainerMetrics
+	68,  // 37: containarium.v1.DiffContainersResponse.differences:type_name -> containarium.v1.ContainerDifference
+	4,   // 38: containarium.v1.TestConnectivityResponse.failure_stage:type_name -> containarium.v1.ConnectivityStage
+	71,  // 39: containarium.v1.TestConnectivityResponse.policy:type_name -> containarium.v1.ConnectivityPolicyVerdict
+	73,  // 40: containarium.v1.CreateSnapshotResponse.snapshot:type_name -> containarium.v1.ContainerSnapshot
+	73,  // 41: containarium.v1.ListSnapshotsResponse.snapshots:type_name -> containarium.v1.ContainerSnapshot
+	73,  // 42: containarium.v1.RestoreSnapshotResponse.snapshot:type_name -> containarium.v1.ContainerSnapshot
+	121, // 43: containarium.v1.ContainerActivityEvent.timestamp:type_name -> google.protobuf.Timestamp
+	121, // 44: containarium.v1.ContainerActivityChange.timestamp:type_name -> google.protobuf.Timestamp
+	11,  // 45: containarium.v1.ContainerActivityMetrics.current:type_name -> containarium.v1.ContainerMetrics
+	84,  // 46: containarium.v1.ContainerActivityTraffic.top_destinations:type_name -> containarium.v1.ContainerActivityDestination
+	123, // 47: containarium.v1.ContainerActivityListeners.current:type_name -> containarium.v1.ListeningPort
+	124, // 48: containarium.v1.ContainerActivityListeners.changes:type_name -> containarium.v1.ListenerChange
+	121, // 49: containarium.v1.GetContainerActivityResponse.window_start:type_name -> google.protobuf.Timestamp
+	121, // 50: containarium.v1.GetContainerActivityResponse.window_end:type_name -> google.protobuf.Timestamp
+	2,   // 51: containarium.v1.GetContainerActivityResponse.state:type_name -> containarium.v1.ContainerState
+	81,  // 52: containarium.v1.GetContainerActivityResponse.lifecycle_events:type_name -> containarium.v1.ContainerActivityEvent
+	83,  // 53: containarium.v1.GetContainerActivityResponse.metrics:type_name -> containarium.v1.ContainerActivityMetrics
+	85,  // 54: containarium.v1.GetContainerActivityResponse.traffic:type_name -> containarium.v1.ContainerActivityTraffic
+	73,  // 55: containarium.v1.GetContainerActivityResponse.snapshots:type_name -> containarium.v1.ContainerSnapshot
+	82,  // 56: containarium.v1.GetContainerActivityResponse.changes:type_name -> containarium.v1.ContainerActivityChange
+	86,  // 57: containarium.v1.GetContainerActivityResponse.listening_ports:type_name -> containarium.v1.ContainerActivityListeners
+	125, // 58: containarium.v1.GetContainerActivityResponse.ssh_sessions:type_name -> containarium.v1.SSHSession
+	5,   // 59: containarium.v1.ProvisionStep.state:type_name -> containarium.v1.ProvisionStepState
+	121, // 60: containarium.v1.ProvisionStep.started_at:type_name -> google.protobuf.Timestamp
+	121, // 61: containarium.v1.ProvisionStep.finished_at:type_name -> google.protobuf.Timestamp
+	2,   // 62: containarium.v1.GetContainerReadinessResponse.state:type_name -> containarium.v1.ContainerState
+	88,  // 63: containarium.v1.GetContainerReadinessResponse.steps:type_name -> containarium.v1.ProvisionStep
+	89,  // 64: containarium.v1.GetContainerReadinessResponse.checks:type_name -> containarium.v1.ReadinessCheck
+	10,  // 65: containarium.v1.InstallStackResponse.container:type_name -> containarium.v1.Container
+	94,  // 66: containarium.v1.StackInfo.parameters:type_name -> containarium.v1.StackParameter
+	95,  // 67: containarium.v1.ListStacksResponse.stacks:type_name -> containarium.v1.StackInfo
+	6,   // 68: containarium.v1.SetMetricsExportRequest.provider:type_name -> containarium.v1.CloudMetricsProvider
+	7,   // 69: containarium.v1.SetMetricsExportRequest.groups:type_name -> containarium.v1.CloudMetricsGroup
+	6,   // 70: containarium.v1.SetMetricsExportResponse.provider:type_name -> containarium.v1.CloudMetricsProvider
+	7,   // 71: containarium.v1.SetMetricsExportResponse.groups:type_name -> containarium.v1.CloudMetricsGroup
+	6,   // 72: containarium.v1.GetMetricsExportResponse.provider:type_name -> containarium.v1.CloudMetricsProvider
+	121, // 73: containarium.v1.GetMetricsExportResponse.last_success_at:type_name -> google.protobuf.Timestamp
+	7,   // 74: containarium.v1.GetMetricsExportResponse.groups:type_name -> containarium.v1.CloudMetricsGroup
+	8,   // 75: containarium.v1.ContainerTemplate.resources:type_name -> containarium.v1.ResourceLimits
+	108, // 76: containarium.v1.ContainerTemplate.routes:type_name -> containarium.v1.ContainerTemplateRoute
+	120, // 77: containarium.v1.ContainerTemplate.labels:type_name -> containarium.v1.ContainerTemplate.LabelsEntry
+	109, // 78: containarium.v1.ListContainerTemplatesResponse.templates:type_name -> containarium.v1.ContainerTemplate
+	109, // 79: containarium.v1.GetContainerTemplateResponse.template:type_name -> containarium.v1.ContainerTemplate
+	126, // 80: containarium.v1.state_name:extendee -> google.protobuf.EnumValueOptions
+	81,  // [81:81] is the sub-list for method output_type
+	81,  // [81:81] is the sub-list for method input_type
+	81,  // [81:81] is the sub-list for extension type_name
+	80,  // [80:81] is the sub-list for extension extendee
+	0,   // [0:80] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_container_proto_init() }
@@ -5370,13 +9149,15 @@ func file_containarium_v1_container_proto_init() {
 	if File_containarium_v1_container_proto != nil {
 		return
 	}
+	file_containarium_v1_network_proto_init()
+	file_containarium_v1_traffic_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_container_proto_rawDesc), len(file_containarium_v1_container_proto_rawDesc)),
-			NumEnums:      6,
-			NumMessages:   67,
+			NumEnums:      8,
+			NumMessages:   113,
 			NumExtensions: 1,
 			NumServices:   0,
 		},
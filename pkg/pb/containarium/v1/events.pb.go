@@ -41,6 +41,12 @@ const (
 	EventType_EVENT_TYPE_CONTAINER_STOPPED EventType = 4
 	// Container state changed
 	EventType_EVENT_TYPE_CONTAINER_STATE_CHANGED EventType = 5
+	// Container creation was accepted and provisioning has begun
+	EventType_EVENT_TYPE_CONTAINER_CREATING EventType = 6
+	// Container was changed in place (resize, SSH keys, snapshot)
+	EventType_EVENT_TYPE_CONTAINER_UPDATED EventType = 7
+	// Container readiness (running, reachable, provisioned) changed
+	EventType_EVENT_TYPE_CONTAINER_HEALTH_CHANGED EventType = 8
 	// App events (10-19)
 	// App was deployed
 	EventType_EVENT_TYPE_APP_DEPLOYED EventType = 10
@@ -63,6 +69,8 @@ const (
 	// Traffic events (40-49)
 	// Traffic/connection update
 	EventType_EVENT_TYPE_TRAFFIC_UPDATE EventType = 40
+	// A container started or stopped listening on a port
+	EventType_EVENT_TYPE_LISTENER_CHANGED EventType = 41
 )
 
 // Enum value maps for EventType.
@@ -74,6 +82,9 @@ var (
 		3:  "EVENT_TYPE_CONTAINER_STARTED",
 		4:  "EVENT_TYPE_CONTAINER_STOPPED",
 		5:  "EVENT_TYPE_CONTAINER_STATE_CHANGED",
+		6:  "EVENT_TYPE_CONTAINER_CREATING",
+		7:  "EVENT_TYPE_CONTAINER_UPDATED",
+		8:  "EVENT_TYPE_CONTAINER_HEALTH_CHANGED",
 		10: "EVENT_TYPE_APP_DEPLOYED",
 		11: "EVENT_TYPE_APP_DELETED",
 		12: "EVENT_TYPE_APP_STARTED",
@@ -83,23 +94,28 @@ var (
 		21: "EVENT_TYPE_ROUTE_DELETED",
 		30: "EVENT_TYPE_METRICS_UPDATE",
 		40: "EVENT_TYPE_TRAFFIC_UPDATE",
+		41: "EVENT_TYPE_LISTENER_CHANGED",
 	}
 	EventType_value = map[string]int32{
-		"EVENT_TYPE_UNSPECIFIED":             0,
-		"EVENT_TYPE_CONTAINER_CREATED":       1,
-		"EVENT_TYPE_CONTAINER_DELETED":       2,
-		"EVENT_TYPE_CONTAINER_STARTED":       3,
-		"EVENT_TYPE_CONTAINER_STOPPED":       4,
-		"EVENT_TYPE_CONTAINER_STATE_CHANGED": 5,
-		"EVENT_TYPE_APP_DEPLOYED":            10,
-		"EVENT_TYPE_APP_DELETED":             11,
-		"EVENT_TYPE_APP_STARTED":             12,
-		"EVENT_TYPE_APP_STOPPED":             13,
-		"EVENT_TYPE_APP_STATE_CHANGED":       14,
-		"EVENT_TYPE_ROUTE_ADDED":             20,
-		"EVENT_TYPE_ROUTE_DELETED":           21,
-		"EVENT_TYPE_METRICS_UPDATE":          30,
-		"EVENT_TYPE_TRAFFIC_UPDATE":          40,
+		"EVENT_TYPE_UNSPECIFIED":              0,
+		"EVENT_TYPE_CONTAINER_CREATED":        1,
+		"EVENT_TYPE_CONTAINER_DELETED":        2,
+		"EVENT_TYPE_CONTAINER_STARTED":        3,
+		"EVENT_TYPE_CONTAINER_STOPPED":        4,
+		"EVENT_TYPE_CONTAINER_STATE_CHANGED":  5,
+		"EVENT_TYPE_CONTAINER_CREATING":       6,
+		"EVENT_TYPE_CONTAINER_UPDATED":        7,
+		"EVENT_TYPE_CONTAINER_HEALTH_CHANGED": 8,
+		"EVENT_TYPE_APP_DEPLOYED":             10,
+		"EVENT_TYPE_APP_DELETED":              11,
+		"EVENT_TYPE_APP_STARTED":              12,
+		"EVENT_TYPE_APP_STOPPED":              13,
+		"EVENT_TYPE_APP_STATE_CHANGED":        14,
+		"EVENT_TYPE_ROUTE_ADDED":              20,
+		"EVENT_TYPE_ROUTE_DELETED":            21,
+		"EVENT_TYPE_METRICS_UPDATE":           30,
+		"EVENT_TYPE_TRAFFIC_UPDATE":           40,
+		"EVENT_TYPE_LISTENER_CHANGED":         41,
 	}
 )
 
@@ -195,13 +211,109 @@ func (ResourceType) EnumDescriptor() ([]byte, []int) {
 	return file_containarium_v1_events_proto_rawDescGZIP(), []int{1}
 }
 
+// ContainerEventType is the lifecycle operation a ContainerEvent records.
+// Event.type carries the coarser EventType derived from it, so subscribers
+// written against EventType keep working.
+type ContainerEventType int32
+
+const (
+	ContainerEventType_CONTAINER_EVENT_TYPE_UNSPECIFIED ContainerEventType = 0
+	// Creation accepted; provisioning in progress
+	ContainerEventType_CONTAINER_EVENT_TYPE_CREATING ContainerEventType = 1
+	// Creation finished
+	ContainerEventType_CONTAINER_EVENT_TYPE_CREATED ContainerEventType = 2
+	// Container started
+	ContainerEventType_CONTAINER_EVENT_TYPE_START ContainerEventType = 3
+	// Container stopped
+	ContainerEventType_CONTAINER_EVENT_TYPE_STOP ContainerEventType = 4
+	// Container deleted
+	ContainerEventType_CONTAINER_EVENT_TYPE_DELETE ContainerEventType = 5
+	// CPU, memory or disk limits changed
+	ContainerEventType_CONTAINER_EVENT_TYPE_RESIZE ContainerEventType = 6
+	// SSH public key added
+	ContainerEventType_CONTAINER_EVENT_TYPE_KEY_ADDED ContainerEventType = 7
+	// SSH public key removed
+	ContainerEventType_CONTAINER_EVENT_TYPE_KEY_REMOVED ContainerEventType = 8
+	// Snapshot created or restored
+	ContainerEventType_CONTAINER_EVENT_TYPE_SNAPSHOT ContainerEventType = 9
+	// Readiness changed (details["ready"] is "true" or "false")
+	ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH_CHANGED ContainerEventType = 10
+)
+
+// Enum value maps for ContainerEventType.
+var (
+	ContainerEventType_name = map[int32]string{
+		0:  "CONTAINER_EVENT_TYPE_UNSPECIFIED",
+		1:  "CONTAINER_EVENT_TYPE_CREATING",
+		2:  "CONTAINER_EVENT_TYPE_CREATED",
+		3:  "CONTAINER_EVENT_TYPE_START",
+		4:  "CONTAINER_EVENT_TYPE_STOP",
+		5:  "CONTAINER_EVENT_TYPE_DELETE",
+		6:  "CONTAINER_EVENT_TYPE_RESIZE",
+		7:  "CONTAINER_EVENT_TYPE_KEY_ADDED",
+		8:  "CONTAINER_EVENT_TYPE_KEY_REMOVED",
+		9:  "CONTAINER_EVENT_TYPE_SNAPSHOT",
+		10: "CONTAINER_EVENT_TYPE_HEALTH_CHANGED",
+	}
+	ContainerEventType_value = map[string]int32{
+		"CONTAINER_EVENT_TYPE_UNSPECIFIED":    0,
+		"CONTAINER_EVENT_TYPE_CREATING":       1,
+		"CONTAINER_EVENT_TYPE_CREATED":        2,
+		"CONTAINER_EVENT_TYPE_START":          3,
+		"CONTAINER_EVENT_TYPE_STOP":           4,
+		"CONTAINER_EVENT_TYPE_DELETE":         5,
+		"CONTAINER_EVENT_TYPE_RESIZE":         6,
+		"CONTAINER_EVENT_TYPE_KEY_ADDED":      7,
+		"CONTAINER_EVENT_TYPE_KEY_REMOVED":    8,
+		"CONTAINER_EVENT_TYPE_SNAPSHOT":       9,
+		"CONTAINER_EVENT_TYPE_HEALTH_CHANGED": 10,
+	}
+)
+
+func (x ContainerEventType) Enum() *ContainerEventType {
+	p := new(ContainerEventType)
+	*p = x
+	return p
+}
+
+func (x ContainerEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContainerEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_events_proto_enumTypes[2].Descriptor()
+}
+
+func (ContainerEventType) Type() protoreflect.EnumType {
+	return &file_containarium_v1_events_proto_enumTypes[2]
+}
+
+func (x ContainerEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContainerEventType.Descriptor instead.
+func (ContainerEventType) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
 // ContainerEvent contains container-specific event data
 type ContainerEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The container that was affected
+	// The container that was affected. For deletes only name and username
+	// are set.
 	Container *Container `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
 	// Previous state (for state change events)
 	PreviousState ContainerState `protobuf:"varint,2,opt,name=previous_state,json=previousState,proto3,enum=containarium.v1.ContainerState" json:"previous_state,omitempty"`
+	// Lifecycle operation. UNSPECIFIED only on events from releases that
+	// predate typed events; derive it from Event.type in that case.
+	Type ContainerEventType `protobuf:"varint,3,opt,name=type,proto3,enum=containarium.v1.ContainerEventType" json:"type,omitempty"`
+	// Subject that performed the operation ("_system" for daemon-internal
+	// actions such as auto-sleep); empty when unknown.
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Operation-specific details, e.g. "cpu"/"memory"/"disk" for a resize,
+	// "fingerprint" for key events, "snapshot" and "action" for snapshots.
+	Details       map[string]string `protobuf:"bytes,5,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -250,6 +362,27 @@ func (x *ContainerEvent) GetPreviousState() ContainerState {
 	return ContainerState_CONTAINER_STATE_UNSPECIFIED
 }
 
+func (x *ContainerEvent) GetType() ContainerEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ContainerEventType_CONTAINER_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ContainerEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ContainerEvent) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
 // AppEvent contains app-specific event data
 type AppEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -419,6 +552,7 @@ type Event struct {
 	//	*Event_RouteEvent
 	//	*Event_MetricsEvent
 	//	*Event_TrafficEvent
+	//	*Event_ListenerChange
 	Payload       isEvent_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -541,6 +675,15 @@ func (x *Event) GetTrafficEvent() *TrafficEvent {
 	return nil
 }
 
+func (x *Event) GetListenerChange() *ListenerChange {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ListenerChange); ok {
+			return x.ListenerChange
+		}
+	}
+	return nil
+}
+
 type isEvent_Payload interface {
 	isEvent_Payload()
 }
@@ -565,6 +708,10 @@ type Event_TrafficEvent struct {
 	TrafficEvent *TrafficEvent `protobuf:"bytes,14,opt,name=traffic_event,json=trafficEvent,proto3,oneof"`
 }
 
+type Event_ListenerChange struct {
+	ListenerChange *ListenerChange `protobuf:"bytes,15,opt,name=listener_change,json=listenerChange,proto3,oneof"`
+}
+
 func (*Event_ContainerEvent) isEvent_Payload() {}
 
 func (*Event_AppEvent) isEvent_Payload() {}
@@ -575,6 +722,8 @@ func (*Event_MetricsEvent) isEvent_Payload() {}
 
 func (*Event_TrafficEvent) isEvent_Payload() {}
 
+func (*Event_ListenerChange) isEvent_Payload() {}
+
 // SubscribeEventsRequest configures the event subscription
 type SubscribeEventsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -584,8 +733,11 @@ type SubscribeEventsRequest struct {
 	IncludeMetrics bool `protobuf:"varint,2,opt,name=include_metrics,json=includeMetrics,proto3" json:"include_metrics,omitempty"`
 	// Metrics interval in seconds (default: 5, min: 1, max: 60)
 	MetricsIntervalSeconds int32 `protobuf:"varint,3,opt,name=metrics_interval_seconds,json=metricsIntervalSeconds,proto3" json:"metrics_interval_seconds,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// Filter container events by lifecycle type (empty = all). Events of
+	// other resource types are unaffected.
+	ContainerEventTypes []ContainerEventType `protobuf:"varint,4,rep,packed,name=container_event_types,json=containerEventTypes,proto3,enum=containarium.v1.ContainerEventType" json:"container_event_types,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *SubscribeEventsRequest) Reset() {
@@ -639,14 +791,27 @@ func (x *SubscribeEventsRequest) GetMetricsIntervalSeconds() int32 {
 	return 0
 }
 
+func (x *SubscribeEventsRequest) GetContainerEventTypes() []ContainerEventType {
+	if x != nil {
+		return x.ContainerEventTypes
+	}
+	return nil
+}
+
 var File_containarium_v1_events_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_events_proto_rawDesc = "" +
 	"\n" +
-	"\x1ccontainarium/v1/events.proto\x12\x0fcontainarium.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x1fcontainarium/v1/container.proto\x1a\x19containarium/v1/app.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1dcontainarium/v1/traffic.proto\"\x92\x01\n" +
+	"\x1ccontainarium/v1/events.proto\x12\x0fcontainarium.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x1fcontainarium/v1/container.proto\x1a\x19containarium/v1/app.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1dcontainarium/v1/traffic.proto\"\xe5\x02\n" +
 	"\x0eContainerEvent\x128\n" +
 	"\tcontainer\x18\x01 \x01(\v2\x1a.containarium.v1.ContainerR\tcontainer\x12F\n" +
-	"\x0eprevious_state\x18\x02 \x01(\x0e2\x1f.containarium.v1.ContainerStateR\rpreviousState\"t\n" +
+	"\x0eprevious_state\x18\x02 \x01(\x0e2\x1f.containarium.v1.ContainerStateR\rpreviousState\x127\n" +
+	"\x04type\x18\x03 \x01(\x0e2#.containarium.v1.ContainerEventTypeR\x04type\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12F\n" +
+	"\adetails\x18\x05 \x03(\v2,.containarium.v1.ContainerEvent.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"t\n" +
 	"\bAppEvent\x12&\n" +
 	"\x03app\x18\x01 \x01(\v2\x14.containarium.v1.AppR\x03app\x12@\n" +
 	"\x0eprevious_state\x18\x02 \x01(\x0e2\x19.containarium.v1.AppStateR\rpreviousState\"?\n" +
@@ -654,7 +819,7 @@ const file_containarium_v1_events_proto_rawDesc = "" +
 	"RouteEvent\x121\n" +
 	"\x05route\x18\x01 \x01(\v2\x1b.containarium.v1.ProxyRouteR\x05route\"K\n" +
 	"\fMetricsEvent\x12;\n" +
-	"\ametrics\x18\x01 \x03(\v2!.containarium.v1.ContainerMetricsR\ametrics\"\xc3\x04\n" +
+	"\ametrics\x18\x01 \x03(\v2!.containarium.v1.ContainerMetricsR\ametrics\"\x8f\x05\n" +
 	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x1a.containarium.v1.EventTypeR\x04type\x12B\n" +
@@ -668,19 +833,24 @@ const file_containarium_v1_events_proto_rawDesc = "" +
 	"\vroute_event\x18\f \x01(\v2\x1b.containarium.v1.RouteEventH\x00R\n" +
 	"routeEvent\x12D\n" +
 	"\rmetrics_event\x18\r \x01(\v2\x1d.containarium.v1.MetricsEventH\x00R\fmetricsEvent\x12D\n" +
-	"\rtraffic_event\x18\x0e \x01(\v2\x1d.containarium.v1.TrafficEventH\x00R\ftrafficEventB\t\n" +
-	"\apayload\"\xc1\x01\n" +
+	"\rtraffic_event\x18\x0e \x01(\v2\x1d.containarium.v1.TrafficEventH\x00R\ftrafficEvent\x12J\n" +
+	"\x0flistener_change\x18\x0f \x01(\v2\x1f.containarium.v1.ListenerChangeH\x00R\x0elistenerChangeB\t\n" +
+	"\apayload\"\x9a\x02\n" +
 	"\x16SubscribeEventsRequest\x12D\n" +
 	"\x0eresource_types\x18\x01 \x03(\x0e2\x1d.containarium.v1.ResourceTypeR\rresourceTypes\x12'\n" +
 	"\x0finclude_metrics\x18\x02 \x01(\bR\x0eincludeMetrics\x128\n" +
-	"\x18metrics_interval_seconds\x18\x03 \x01(\x05R\x16metricsIntervalSeconds*\xe2\x03\n" +
+	"\x18metrics_interval_seconds\x18\x03 \x01(\x05R\x16metricsIntervalSeconds\x12W\n" +
+	"\x15container_event_types\x18\x04 \x03(\x0e2#.containarium.v1.ContainerEventTypeR\x13containerEventTypes*\xf1\x04\n" +
 	"\tEventType\x12\x1a\n" +
 	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
 	"\x1cEVENT_TYPE_CONTAINER_CREATED\x10\x01\x12 \n" +
 	"\x1cEVENT_TYPE_CONTAINER_DELETED\x10\x02\x12 \n" +
 	"\x1cEVENT_TYPE_CONTAINER_STARTED\x10\x03\x12 \n" +
 	"\x1cEVENT_TYPE_CONTAINER_STOPPED\x10\x04\x12&\n" +
-	"\"EVENT_TYPE_CONTAINER_STATE_CHANGED\x10\x05\x12\x1b\n" +
+	"\"EVENT_TYPE_CONTAINER_STATE_CHANGED\x10\x05\x12!\n" +
+	"\x1dEVENT_TYPE_CONTAINER_CREATING\x10\x06\x12 \n" +
+	"\x1cEVENT_TYPE_CONTAINER_UPDATED\x10\a\x12'\n" +
+	"#EVENT_TYPE_CONTAINER_HEALTH_CHANGED\x10\b\x12\x1b\n" +
 	"\x17EVENT_TYPE_APP_DEPLOYED\x10\n" +
 	"\x12\x1a\n" +
 	"\x16EVENT_TYPE_APP_DELETED\x10\v\x12\x1a\n" +
@@ -690,14 +860,28 @@ const file_containarium_v1_events_proto_rawDesc = "" +
 	"\x16EVENT_TYPE_ROUTE_ADDED\x10\x14\x12\x1c\n" +
 	"\x18EVENT_TYPE_ROUTE_DELETED\x10\x15\x12\x1d\n" +
 	"\x19EVENT_TYPE_METRICS_UPDATE\x10\x1e\x12\x1d\n" +
-	"\x19EVENT_TYPE_TRAFFIC_UPDATE\x10(*\xb0\x01\n" +
+	"\x19EVENT_TYPE_TRAFFIC_UPDATE\x10(\x12\x1f\n" +
+	"\x1bEVENT_TYPE_LISTENER_CHANGED\x10)*\xb0\x01\n" +
 	"\fResourceType\x12\x1d\n" +
 	"\x19RESOURCE_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17RESOURCE_TYPE_CONTAINER\x10\x01\x12\x15\n" +
 	"\x11RESOURCE_TYPE_APP\x10\x02\x12\x17\n" +
 	"\x13RESOURCE_TYPE_ROUTE\x10\x03\x12\x19\n" +
 	"\x15RESOURCE_TYPE_METRICS\x10\x04\x12\x19\n" +
-	"\x15RESOURCE_TYPE_TRAFFIC\x10\x052\xa3\x02\n" +
+	"\x15RESOURCE_TYPE_TRAFFIC\x10\x05*\x96\x03\n" +
+	"\x12ContainerEventType\x12$\n" +
+	" CONTAINER_EVENT_TYPE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dCONTAINER_EVENT_TYPE_CREATING\x10\x01\x12 \n" +
+	"\x1cCONTAINER_EVENT_TYPE_CREATED\x10\x02\x12\x1e\n" +
+	"\x1aCONTAINER_EVENT_TYPE_START\x10\x03\x12\x1d\n" +
+	"\x19CONTAINER_EVENT_TYPE_STOP\x10\x04\x12\x1f\n" +
+	"\x1bCONTAINER_EVENT_TYPE_DELETE\x10\x05\x12\x1f\n" +
+	"\x1bCONTAINER_EVENT_TYPE_RESIZE\x10\x06\x12\"\n" +
+	"\x1eCONTAINER_EVENT_TYPE_KEY_ADDED\x10\a\x12$\n" +
+	" CONTAINER_EVENT_TYPE_KEY_REMOVED\x10\b\x12!\n" +
+	"\x1dCONTAINER_EVENT_TYPE_SNAPSHOT\x10\t\x12'\n" +
+	"#CONTAINER_EVENT_TYPE_HEALTH_CHANGED\x10\n" +
+	"2\xa3\x02\n" +
 	"\fEventService\x12\x92\x02\n" +
 	"\x0fSubscribeEvents\x12'.containarium.v1.SubscribeEventsRequest\x1a\x16.containarium.v1.Event\"\xbb\x01\x92A\x9b\x01\n" +
 	"\x06Events\x12\x1dSubscribe to real-time events\x1arOpens a Server-Sent Events stream for real-time resource updates. Filter by resource types using query parameters.\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/events/subscribe0\x01BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
@@ -714,49 +898,56 @@ func file_containarium_v1_events_proto_rawDescGZIP() []byte {
 	return file_containarium_v1_events_proto_rawDescData
 }
 
-var file_containarium_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_containarium_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_containarium_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_containarium_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_containarium_v1_events_proto_goTypes = []any{
 	(EventType)(0),                 // 0: containarium.v1.EventType
 	(ResourceType)(0),              // 1: containarium.v1.ResourceType
-	(*ContainerEvent)(nil),         // 2: containarium.v1.ContainerEvent
-	(*AppEvent)(nil),               // 3: containarium.v1.AppEvent
-	(*RouteEvent)(nil),             // 4: containarium.v1.RouteEvent
-	(*MetricsEvent)(nil),           // 5: containarium.v1.MetricsEvent
-	(*Event)(nil),                  // 6: containarium.v1.Event
-	(*SubscribeEventsRequest)(nil), // 7: containarium.v1.SubscribeEventsRequest
-	(*Container)(nil),              // 8: containarium.v1.Container
-	(ContainerState)(0),            // 9: containarium.v1.ContainerState
-	(*App)(nil),                    // 10: containarium.v1.App
-	(AppState)(0),                  // 11: containarium.v1.AppState
-	(*ProxyRoute)(nil),             // 12: containarium.v1.ProxyRoute
-	(*ContainerMetrics)(nil),       // 13: containarium.v1.ContainerMetrics
-	(*timestamppb.Timestamp)(nil),  // 14: google.protobuf.Timestamp
-	(*TrafficEvent)(nil),           // 15: containarium.v1.TrafficEvent
+	(ContainerEventType)(0),        // 2: containarium.v1.ContainerEventType
+	(*ContainerEvent)(nil),         // 3: containarium.v1.ContainerEvent
+	(*AppEvent)(nil),               // 4: containarium.v1.AppEvent
+	(*RouteEvent)(nil),             // 5: containarium.v1.RouteEvent
+	(*MetricsEvent)(nil),           // 6: containarium.v1.MetricsEvent
+	(*Event)(nil),                  // 7: containarium.v1.Event
+	(*SubscribeEventsRequest)(nil), // 8: containarium.v1.SubscribeEventsRequest
+	nil,                            // 9: containarium.v1.ContainerEvent.DetailsEntry
+	(*Container)(nil),              // 10: containarium.v1.Container
+	(ContainerState)(0),            // 11: containarium.v1.ContainerState
+	(*App)(nil),                    // 12: containarium.v1.App
+	(AppState)(0),                  // 13: containarium.v1.AppState
+	(*ProxyRoute)(nil),             // 14: containarium.v1.ProxyRoute
+	(*ContainerMetrics)(nil),       // 15: containarium.v1.ContainerMetrics
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
+	(*TrafficEvent)(nil),           // 17: containarium.v1.TrafficEvent
+	(*ListenerChange)(nil),         // 18: containarium.v1.ListenerChange
 }
 var file_containarium_v1_events_proto_depIdxs = []int32{
-	8,  // 0: containarium.v1.ContainerEvent.container:type_name -> containarium.v1.Container
-	9,  // 1: containarium.v1.ContainerEvent.previous_state:type_name -> containarium.v1.ContainerState
-	10, // 2: containarium.v1.AppEvent.app:type_name -> containarium.v1.App
-	11, // 3: containarium.v1.AppEvent.previous_state:type_name -> containarium.v1.AppState
-	12, // 4: containarium.v1.RouteEvent.route:type_name -> containarium.v1.ProxyRoute
-	13, // 5: containarium.v1.MetricsEvent.metrics:type_name -> containarium.v1.ContainerMetrics
-	0,  // 6: containarium.v1.Event.type:type_name -> containarium.v1.EventType
-	1,  // 7: containarium.v1.Event.resource_type:type_name -> containarium.v1.ResourceType
-	14, // 8: containarium.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
-	2,  // 9: containarium.v1.Event.container_event:type_name -> containarium.v1.ContainerEvent
-	3,  // 10: containarium.v1.Event.app_event:type_name -> containarium.v1.AppEvent
-	4,  // 11: containarium.v1.Event.route_event:type_name -> containarium.v1.RouteEvent
-	5,  // 12: containarium.v1.Event.metrics_event:type_name -> containarium.v1.MetricsEvent
-	15, // 13: containarium.v1.Event.traffic_event:type_name -> containarium.v1.TrafficEvent
-	1,  // 14: containarium.v1.SubscribeEventsRequest.resource_types:type_name -> containarium.v1.ResourceType
-	7,  // 15: containarium.v1.EventService.SubscribeEvents:input_type -> containarium.v1.SubscribeEventsRequest
-	6,  // 16: containarium.v1.EventService.SubscribeEvents:output_type -> containarium.v1.Event
-	16, // [16:17] is the sub-list for method output_type
-	15, // [15:16] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	10, // 0: containarium.v1.ContainerEvent.container:type_name -> containarium.v1.Container
+	11, // 1: containarium.v1.ContainerEvent.previous_state:type_name -> containarium.v1.ContainerState
+	2,  // 2: containarium.v1.ContainerEvent.type:type_name -> containarium.v1.ContainerEventType
+	9,  // 3: containarium.v1.ContainerEvent.details:type_name -> containarium.v1.ContainerEvent.DetailsEntry
+	12, // 4: containarium.v1.AppEvent.app:type_name -> containarium.v1.App
+	13, // 5: containarium.v1.AppEvent.previous_state:type_name -> containarium.v1.AppState
+	14, // 6: containarium.v1.RouteEvent.route:type_name -> containarium.v1.ProxyRoute
+	15, // 7: containarium.v1.MetricsEvent.metrics:type_name -> containarium.v1.ContainerMetrics
+	0,  // 8: containarium.v1.Event.type:type_name -> containarium.v1.EventType
+	1,  // 9: containarium.v1.Event.resource_type:type_name -> containarium.v1.ResourceType
+	16, // 10: containarium.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 11: containarium.v1.Event.container_event:type_name -> containarium.v1.ContainerEvent
+	4,  // 12: containarium.v1.Event.app_event:type_name -> containarium.v1.AppEvent
+	5,  // 13: containarium.v1.Event.route_event:type_name -> containarium.v1.RouteEvent
+	6,  // 14: containarium.v1.Event.metrics_event:type_name -> containarium.v1.MetricsEvent
+	17, // 15: containarium.v1.Event.traffic_event:type_name -> containarium.v1.TrafficEvent
+	18, // 16: containarium.v1.Event.listener_change:type_name -> containarium.v1.ListenerChange
+	1,  // 17: containarium.v1.SubscribeEventsRequest.resource_types:type_name -> containarium.v1.ResourceType
+	2,  // 18: containarium.v1.SubscribeEventsRequest.container_event_types:type_name -> containarium.v1.ContainerEventType
+	8,  // 19: containarium.v1.EventService.SubscribeEvents:input_type -> containarium.v1.SubscribeEventsRequest
+	7,  // 20: containarium.v1.EventService.SubscribeEvents:output_type -> containarium.v1.Event
+	20, // [20:21] is the sub-list for method output_type
+	19, // [19:20] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_events_proto_init() }
@@ -774,14 +965,15 @@ func file_containarium_v1_events_proto_init() {
 		(*Event_RouteEvent)(nil),
 		(*Event_MetricsEvent)(nil),
 		(*Event_TrafficEvent)(nil),
+		(*Event_ListenerChange)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_events_proto_rawDesc), len(file_containarium_v1_events_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   6,
+			NumEnums:      3,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
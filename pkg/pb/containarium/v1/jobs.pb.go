@@ -0,0 +1,723 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: containarium/v1/jobs.proto
+
+package containariumv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// JobTrigger is what started a run.
+type JobTrigger int32
+
+const (
+	JobTrigger_JOB_TRIGGER_UNSPECIFIED JobTrigger = 0
+	// The job's schedule.
+	JobTrigger_JOB_TRIGGER_SCHEDULE JobTrigger = 1
+	// RunJob.
+	JobTrigger_JOB_TRIGGER_MANUAL JobTrigger = 2
+)
+
+// Enum value maps for JobTrigger.
+var (
+	JobTrigger_name = map[int32]string{
+		0: "JOB_TRIGGER_UNSPECIFIED",
+		1: "JOB_TRIGGER_SCHEDULE",
+		2: "JOB_TRIGGER_MANUAL",
+	}
+	JobTrigger_value = map[string]int32{
+		"JOB_TRIGGER_UNSPECIFIED": 0,
+		"JOB_TRIGGER_SCHEDULE":    1,
+		"JOB_TRIGGER_MANUAL":      2,
+	}
+)
+
+func (x JobTrigger) Enum() *JobTrigger {
+	p := new(JobTrigger)
+	*p = x
+	return p
+}
+
+func (x JobTrigger) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobTrigger) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_jobs_proto_enumTypes[0].Descriptor()
+}
+
+func (JobTrigger) Type() protoreflect.EnumType {
+	return &file_containarium_v1_jobs_proto_enumTypes[0]
+}
+
+func (x JobTrigger) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobTrigger.Descriptor instead.
+func (JobTrigger) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{0}
+}
+
+// JobResult is how a run turned out.
+type JobResult int32
+
+const (
+	JobResult_JOB_RESULT_UNSPECIFIED JobResult = 0
+	JobResult_JOB_RESULT_RUNNING     JobResult = 1
+	JobResult_JOB_RESULT_SUCCEEDED   JobResult = 2
+	JobResult_JOB_RESULT_FAILED      JobResult = 3
+	// A scheduled run that came due while the job was already running.
+	JobResult_JOB_RESULT_SKIPPED JobResult = 4
+)
+
+// Enum value maps for JobResult.
+var (
+	JobResult_name = map[int32]string{
+		0: "JOB_RESULT_UNSPECIFIED",
+		1: "JOB_RESULT_RUNNING",
+		2: "JOB_RESULT_SUCCEEDED",
+		3: "JOB_RESULT_FAILED",
+		4: "JOB_RESULT_SKIPPED",
+	}
+	JobResult_value = map[string]int32{
+		"JOB_RESULT_UNSPECIFIED": 0,
+		"JOB_RESULT_RUNNING":     1,
+		"JOB_RESULT_SUCCEEDED":   2,
+		"JOB_RESULT_FAILED":      3,
+		"JOB_RESULT_SKIPPED":     4,
+	}
+)
+
+func (x JobResult) Enum() *JobResult {
+	p := new(JobResult)
+	*p = x
+	return p
+}
+
+func (x JobResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_jobs_proto_enumTypes[1].Descriptor()
+}
+
+func (JobResult) Type() protoreflect.EnumType {
+	return &file_containarium_v1_jobs_proto_enumTypes[1]
+}
+
+func (x JobResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobResult.Descriptor instead.
+func (JobResult) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{1}
+}
+
+// Job is one of the daemon's scheduled jobs.
+type Job struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// e.g. "traffic-cleanup".
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Cron expression ("0 3 * * *"), @hourly/@daily/@weekly,
+	// "@every <duration>", or "off" for a job only run by hand.
+	Schedule string `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// When the job is next due; unset when it has no schedule.
+	NextRun *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	Running bool                   `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	// The most recent run since the daemon started; unset before the first.
+	LastRun       *JobRun `protobuf:"bytes,6,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_containarium_v1_jobs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_jobs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Job) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Job) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Job) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Job) GetNextRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRun
+	}
+	return nil
+}
+
+func (x *Job) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *Job) GetLastRun() *JobRun {
+	if x != nil {
+		return x.LastRun
+	}
+	return nil
+}
+
+// JobRun is one run of a job.
+type JobRun struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Job       string                 `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	Trigger   JobTrigger             `protobuf:"varint,3,opt,name=trigger,proto3,enum=containarium.v1.JobTrigger" json:"trigger,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Unset while running.
+	EndedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	Result  JobResult              `protobuf:"varint,6,opt,name=result,proto3,enum=containarium.v1.JobResult" json:"result,omitempty"`
+	// Rows (records, connections, resources) the run deleted, wrote or
+	// removed.
+	RowsAffected int64 `protobuf:"varint,7,opt,name=rows_affected,json=rowsAffected,proto3" json:"rows_affected,omitempty"`
+	// Why the run failed or was skipped.
+	Error         string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobRun) Reset() {
+	*x = JobRun{}
+	mi := &file_containarium_v1_jobs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRun) ProtoMessage() {}
+
+func (x *JobRun) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_jobs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRun.ProtoReflect.Descriptor instead.
+func (*JobRun) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *JobRun) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JobRun) GetJob() string {
+	if x != nil {
+		return x.Job
+	}
+	return ""
+}
+
+func (x *JobRun) GetTrigger() JobTrigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return JobTrigger_JOB_TRIGGER_UNSPECIFIED
+}
+
+func (x *JobRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *JobRun) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *JobRun) GetResult() JobResult {
+	if x != nil {
+		return x.Result
+	}
+	return JobResult_JOB_RESULT_UNSPECIFIED
+}
+
+func (x *JobRun) GetRowsAffected() int64 {
+	if x != nil {
+		return x.RowsAffected
+	}
+	return 0
+}
+
+func (x *JobRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_containarium_v1_jobs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_jobs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{2}
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_containarium_v1_jobs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_jobs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type RunJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunJobRequest) Reset() {
+	*x = RunJobRequest{}
+	mi := &file_containarium_v1_jobs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunJobRequest) ProtoMessage() {}
+
+func (x *RunJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_jobs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunJobRequest.ProtoReflect.Descriptor instead.
+func (*RunJobRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RunJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RunJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Run           *JobRun                `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunJobResponse) Reset() {
+	*x = RunJobResponse{}
+	mi := &file_containarium_v1_jobs_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunJobResponse) ProtoMessage() {}
+
+func (x *RunJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_jobs_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunJobResponse.ProtoReflect.Descriptor instead.
+func (*RunJobResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RunJobResponse) GetRun() *JobRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+type GetJobHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Maximum runs to return (default 20).
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobHistoryRequest) Reset() {
+	*x = GetJobHistoryRequest{}
+	mi := &file_containarium_v1_jobs_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobHistoryRequest) ProtoMessage() {}
+
+func (x *GetJobHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_jobs_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetJobHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetJobHistoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetJobHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetJobHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*JobRun              `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobHistoryResponse) Reset() {
+	*x = GetJobHistoryResponse{}
+	mi := &file_containarium_v1_jobs_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobHistoryResponse) ProtoMessage() {}
+
+func (x *GetJobHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_jobs_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetJobHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_jobs_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetJobHistoryResponse) GetRuns() []*JobRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+var File_containarium_v1_jobs_proto protoreflect.FileDescriptor
+
+const file_containarium_v1_jobs_proto_rawDesc = "" +
+	"\n" +
+	"\x1acontainarium/v1/jobs.proto\x12\x0fcontainarium.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xdc\x01\n" +
+	"\x03Job\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bschedule\x18\x03 \x01(\tR\bschedule\x125\n" +
+	"\bnext_run\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\anextRun\x12\x18\n" +
+	"\arunning\x18\x05 \x01(\bR\arunning\x122\n" +
+	"\blast_run\x18\x06 \x01(\v2\x17.containarium.v1.JobRunR\alastRun\"\xc2\x02\n" +
+	"\x06JobRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03job\x18\x02 \x01(\tR\x03job\x125\n" +
+	"\atrigger\x18\x03 \x01(\x0e2\x1b.containarium.v1.JobTriggerR\atrigger\x129\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x122\n" +
+	"\x06result\x18\x06 \x01(\x0e2\x1a.containarium.v1.JobResultR\x06result\x12#\n" +
+	"\rrows_affected\x18\a \x01(\x03R\frowsAffected\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"\x11\n" +
+	"\x0fListJobsRequest\"<\n" +
+	"\x10ListJobsResponse\x12(\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x14.containarium.v1.JobR\x04jobs\"#\n" +
+	"\rRunJobRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\";\n" +
+	"\x0eRunJobResponse\x12)\n" +
+	"\x03run\x18\x01 \x01(\v2\x17.containarium.v1.JobRunR\x03run\"@\n" +
+	"\x14GetJobHistoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"D\n" +
+	"\x15GetJobHistoryResponse\x12+\n" +
+	"\x04runs\x18\x01 \x03(\v2\x17.containarium.v1.JobRunR\x04runs*[\n" +
+	"\n" +
+	"JobTrigger\x12\x1b\n" +
+	"\x17JOB_TRIGGER_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14JOB_TRIGGER_SCHEDULE\x10\x01\x12\x16\n" +
+	"\x12JOB_TRIGGER_MANUAL\x10\x02*\x88\x01\n" +
+	"\tJobResult\x12\x1a\n" +
+	"\x16JOB_RESULT_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12JOB_RESULT_RUNNING\x10\x01\x12\x18\n" +
+	"\x14JOB_RESULT_SUCCEEDED\x10\x02\x12\x15\n" +
+	"\x11JOB_RESULT_FAILED\x10\x03\x12\x16\n" +
+	"\x12JOB_RESULT_SKIPPED\x10\x042\x98\b\n" +
+	"\vJobsService\x12\xc4\x02\n" +
+	"\bListJobs\x12 .containarium.v1.ListJobsRequest\x1a!.containarium.v1.ListJobsResponse\"\xf2\x01\x92A\xde\x01\n" +
+	"\x04Jobs\x12 List the daemon's scheduled jobs\x1a\xb3\x01Returns each job's name, description, schedule (a cron expression, @every <duration>, or off), next due time, whether it is running and its latest run. Admin + daemon:admin scope.\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/jobs\x12\xde\x02\n" +
+	"\x06RunJob\x12\x1e.containarium.v1.RunJobRequest\x1a\x1f.containarium.v1.RunJobResponse\"\x92\x02\x92A\xf0\x01\n" +
+	"\x04Jobs\x12\rRun a job now\x1a\xd8\x01Starts the job outside its schedule. A job never runs twice at once: this fails with 409 while it is running, and a scheduled run that comes due during a manual one is recorded as skipped. Admin + daemon:admin scope.\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/jobs/{name}/run\x12\xe0\x02\n" +
+	"\rGetJobHistory\x12%.containarium.v1.GetJobHistoryRequest\x1a&.containarium.v1.GetJobHistoryResponse\"\xff\x01\x92A\xdc\x01\n" +
+	"\x04Jobs\x12\x18Show a job's run history\x1a\xb9\x01Returns the job's recent runs from the jobs_history table (in memory on daemons without PostgreSQL): trigger, start and end, result, rows affected and error. Admin + daemon:admin scope.\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/jobs/{name}/historyBKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
+
+var (
+	file_containarium_v1_jobs_proto_rawDescOnce sync.Once
+	file_containarium_v1_jobs_proto_rawDescData []byte
+)
+
+func file_containarium_v1_jobs_proto_rawDescGZIP() []byte {
+	file_containarium_v1_jobs_proto_rawDescOnce.Do(func() {
+		file_containarium_v1_jobs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_containarium_v1_jobs_proto_rawDesc), len(file_containarium_v1_jobs_proto_rawDesc)))
+	})
+	return file_containarium_v1_jobs_proto_rawDescData
+}
+
+var file_containarium_v1_jobs_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_containarium_v1_jobs_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_containarium_v1_jobs_proto_goTypes = []any{
+	(JobTrigger)(0),               // 0: containarium.v1.JobTrigger
+	(JobResult)(0),                // 1: containarium.v1.JobResult
+	(*Job)(nil),                   // 2: containarium.v1.Job
+	(*JobRun)(nil),                // 3: containarium.v1.JobRun
+	(*ListJobsRequest)(nil),       // 4: containarium.v1.ListJobsRequest
+	(*ListJobsResponse)(nil),      // 5: containarium.v1.ListJobsResponse
+	(*RunJobRequest)(nil),         // 6: containarium.v1.RunJobRequest
+	(*RunJobResponse)(nil),        // 7: containarium.v1.RunJobResponse
+	(*GetJobHistoryRequest)(nil),  // 8: containarium.v1.GetJobHistoryRequest
+	(*GetJobHistoryResponse)(nil), // 9: containarium.v1.GetJobHistoryResponse
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_containarium_v1_jobs_proto_depIdxs = []int32{
+	10, // 0: containarium.v1.Job.next_run:type_name -> google.protobuf.Timestamp
+	3,  // 1: containarium.v1.Job.last_run:type_name -> containarium.v1.JobRun
+	0,  // 2: containarium.v1.JobRun.trigger:type_name -> containarium.v1.JobTrigger
+	10, // 3: containarium.v1.JobRun.started_at:type_name -> google.protobuf.Timestamp
+	10, // 4: containarium.v1.JobRun.ended_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: containarium.v1.JobRun.result:type_name -> containarium.v1.JobResult
+	2,  // 6: containarium.v1.ListJobsResponse.jobs:type_name -> containarium.v1.Job
+	3,  // 7: containarium.v1.RunJobResponse.run:type_name -> containarium.v1.JobRun
+	3,  // 8: containarium.v1.GetJobHistoryResponse.runs:type_name -> containarium.v1.JobRun
+	4,  // 9: containarium.v1.JobsService.ListJobs:input_type -> containarium.v1.ListJobsRequest
+	6,  // 10: containarium.v1.JobsService.RunJob:input_type -> containarium.v1.RunJobRequest
+	8,  // 11: containarium.v1.JobsService.GetJobHistory:input_type -> containarium.v1.GetJobHistoryRequest
+	5,  // 12: containarium.v1.JobsService.ListJobs:output_type -> containarium.v1.ListJobsResponse
+	7,  // 13: containarium.v1.JobsService.RunJob:output_type -> containarium.v1.RunJobResponse
+	9,  // 14: containarium.v1.JobsService.GetJobHistory:output_type -> containarium.v1.GetJobHistoryResponse
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_containarium_v1_jobs_proto_init() }
+func file_containarium_v1_jobs_proto_init() {
+	if File_containarium_v1_jobs_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_jobs_proto_rawDesc), len(file_containarium_v1_jobs_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_containarium_v1_jobs_proto_goTypes,
+		DependencyIndexes: file_containarium_v1_jobs_proto_depIdxs,
+		EnumInfos:         file_containarium_v1_jobs_proto_enumTypes,
+		MessageInfos:      file_containarium_v1_jobs_proto_msgTypes,
+	}.Build()
+	File_containarium_v1_jobs_proto = out.File
+	file_containarium_v1_jobs_proto_goTypes = nil
+	file_containarium_v1_jobs_proto_depIdxs = nil
+}
@@ -0,0 +1,223 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: containarium/v1/jobs.proto
+
+package containariumv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	JobsService_ListJobs_FullMethodName      = "/containarium.v1.JobsService/ListJobs"
+	JobsService_RunJob_FullMethodName        = "/containarium.v1.JobsService/RunJob"
+	JobsService_GetJobHistory_FullMethodName = "/containarium.v1.JobsService/GetJobHistory"
+)
+
+// JobsServiceClient is the client API for JobsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// JobsService exposes the daemon's scheduled maintenance jobs — traffic
+// history cleanup, the usage rollup, history compaction, the conntrack
+// snapshot and orphan garbage collection — with each job's schedule, its
+// run history and a way to run it now. Schedules come from the daemon's
+// --job-schedule flag. Every RPC requires the `daemon:admin` scope AND the admin role.
+type JobsServiceClient interface {
+	// ListJobs returns every job the daemon runs, with its schedule, when
+	// it is next due and its most recent run.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// RunJob starts a run of the job now and returns it as recorded at its
+	// start; the run continues in the background, and GetJobHistory shows
+	// how it ended. Fails with Aborted while the job is already running,
+	// whether started by its schedule or by hand.
+	RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error)
+	// GetJobHistory returns the job's most recent runs, newest first.
+	GetJobHistory(ctx context.Context, in *GetJobHistoryRequest, opts ...grpc.CallOption) (*GetJobHistoryResponse, error)
+}
+
+type jobsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJobsServiceClient(cc grpc.ClientConnInterface) JobsServiceClient {
+	return &jobsServiceClient{cc}
+}
+
+func (c *jobsServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, JobsService_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsServiceClient) RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunJobResponse)
+	err := c.cc.Invoke(ctx, JobsService_RunJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsServiceClient) GetJobHistory(ctx context.Context, in *GetJobHistoryRequest, opts ...grpc.CallOption) (*GetJobHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJobHistoryResponse)
+	err := c.cc.Invoke(ctx, JobsService_GetJobHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobsServiceServer is the server API for JobsService service.
+// All implementations must embed UnimplementedJobsServiceServer
+// for forward compatibility.
+//
+// JobsService exposes the daemon's scheduled maintenance jobs — traffic
+// history cleanup, the usage rollup, history compaction, the conntrack
+// snapshot and orphan garbage collection — with each job's schedule, its
+// run history and a way to run it now. Schedules come from the daemon's
+// --job-schedule flag. Every RPC requires the `daemon:admin` scope AND the admin role.
+type JobsServiceServer interface {
+	// ListJobs returns every job the daemon runs, with its schedule, when
+	// it is next due and its most recent run.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// RunJob starts a run of the job now and returns it as recorded at its
+	// start; the run continues in the background, and GetJobHistory shows
+	// how it ended. Fails with Aborted while the job is already running,
+	// whether started by its schedule or by hand.
+	RunJob(context.Context, *RunJobRequest) (*RunJobResponse, error)
+	// GetJobHistory returns the job's most recent runs, newest first.
+	GetJobHistory(context.Context, *GetJobHistoryRequest) (*GetJobHistoryResponse, error)
+	mustEmbedUnimplementedJobsServiceServer()
+}
+
+// UnimplementedJobsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJobsServiceServer struct{}
+
+func (UnimplementedJobsServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedJobsServiceServer) RunJob(context.Context, *RunJobRequest) (*RunJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunJob not implemented")
+}
+func (UnimplementedJobsServiceServer) GetJobHistory(context.Context, *GetJobHistoryRequest) (*GetJobHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetJobHistory not implemented")
+}
+func (UnimplementedJobsServiceServer) mustEmbedUnimplementedJobsServiceServer() {}
+func (UnimplementedJobsServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeJobsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JobsServiceServer will
+// result in compilation errors.
+type UnsafeJobsServiceServer interface {
+	mustEmbedUnimplementedJobsServiceServer()
+}
+
+func RegisterJobsServiceServer(s grpc.ServiceRegistrar, srv JobsServiceServer) {
+	// If the following call panics, it indicates UnimplementedJobsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JobsService_ServiceDesc, srv)
+}
+
+func _JobsService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobsService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobsService_RunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServiceServer).RunJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobsService_RunJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServiceServer).RunJob(ctx, req.(*RunJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobsService_GetJobHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServiceServer).GetJobHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobsService_GetJobHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServiceServer).GetJobHistory(ctx, req.(*GetJobHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JobsService_ServiceDesc is the grpc.ServiceDesc for JobsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JobsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "containarium.v1.JobsService",
+	HandlerType: (*JobsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListJobs",
+			Handler:    _JobsService_ListJobs_Handler,
+		},
+		{
+			MethodName: "RunJob",
+			Handler:    _JobsService_RunJob_Handler,
+		},
+		{
+			MethodName: "GetJobHistory",
+			Handler:    _JobsService_GetJobHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "containarium/v1/jobs.proto",
+}
@@ -11,6 +11,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -259,6 +260,63 @@ func (ACLPreset) EnumDescriptor() ([]byte, []int) {
 	return file_containarium_v1_network_proto_rawDescGZIP(), []int{3}
 }
 
+// CertificateStatus is where a route's certificate is in its lifecycle.
+type CertificateStatus int32
+
+const (
+	CertificateStatus_CERTIFICATE_STATUS_UNSPECIFIED CertificateStatus = 0
+	// Caddy has not obtained a certificate for the domain yet (issuance in
+	// progress, or failing — check Caddy's log and the domain's DNS).
+	CertificateStatus_CERTIFICATE_STATUS_PENDING CertificateStatus = 1
+	// A valid certificate is in place.
+	CertificateStatus_CERTIFICATE_STATUS_ISSUED CertificateStatus = 2
+	// The stored certificate has expired and was not renewed.
+	CertificateStatus_CERTIFICATE_STATUS_EXPIRED CertificateStatus = 3
+)
+
+// Enum value maps for CertificateStatus.
+var (
+	CertificateStatus_name = map[int32]string{
+		0: "CERTIFICATE_STATUS_UNSPECIFIED",
+		1: "CERTIFICATE_STATUS_PENDING",
+		2: "CERTIFICATE_STATUS_ISSUED",
+		3: "CERTIFICATE_STATUS_EXPIRED",
+	}
+	CertificateStatus_value = map[string]int32{
+		"CERTIFICATE_STATUS_UNSPECIFIED": 0,
+		"CERTIFICATE_STATUS_PENDING":     1,
+		"CERTIFICATE_STATUS_ISSUED":      2,
+		"CERTIFICATE_STATUS_EXPIRED":     3,
+	}
+)
+
+func (x CertificateStatus) Enum() *CertificateStatus {
+	p := new(CertificateStatus)
+	*p = x
+	return p
+}
+
+func (x CertificateStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CertificateStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_network_proto_enumTypes[4].Descriptor()
+}
+
+func (CertificateStatus) Type() protoreflect.EnumType {
+	return &file_containarium_v1_network_proto_enumTypes[4]
+}
+
+func (x CertificateStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CertificateStatus.Descriptor instead.
+func (CertificateStatus) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{4}
+}
+
 // ACLRule represents a single firewall rule
 type ACLRule struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -495,6 +553,10 @@ type ProxyRoute struct {
 	// name): a multi-tenant control plane keys its route reconciler on the
 	// container, so it needs the box identity, not just the display label.
 	ContainerName string `protobuf:"bytes,10,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// TLS certificate Caddy holds for full_domain. Unset when the daemon
+	// can't read Caddy's certificate storage (--caddy-cert-dir) or the
+	// route is TLS passthrough (the container terminates TLS itself).
+	Certificate   *RouteCertificate `protobuf:"bytes,11,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -599,6 +661,87 @@ func (x *ProxyRoute) GetContainerName() string {
 	return ""
 }
 
+func (x *ProxyRoute) GetCertificate() *RouteCertificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+// RouteCertificate describes the certificate serving a proxy route.
+type RouteCertificate struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status CertificateStatus      `protobuf:"varint,1,opt,name=status,proto3,enum=containarium.v1.CertificateStatus" json:"status,omitempty"`
+	// Name the certificate was issued for: the route's domain, or the
+	// wildcard covering it (e.g. "*.example.com").
+	Subject string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Issuer directory in Caddy's storage, e.g.
+	// "acme-v02.api.letsencrypt.org-directory".
+	Issuer string `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// Expiry of the certificate (unset while pending).
+	NotAfter      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteCertificate) Reset() {
+	*x = RouteCertificate{}
+	mi := &file_containarium_v1_network_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteCertificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteCertificate) ProtoMessage() {}
+
+func (x *RouteCertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteCertificate.ProtoReflect.Descriptor instead.
+func (*RouteCertificate) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RouteCertificate) GetStatus() CertificateStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CertificateStatus_CERTIFICATE_STATUS_UNSPECIFIED
+}
+
+func (x *RouteCertificate) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *RouteCertificate) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *RouteCertificate) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
 // PassthroughRoute represents a direct TCP/UDP port forwarding rule
 type PassthroughRoute struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -615,14 +758,22 @@ type PassthroughRoute struct {
 	// Associated container name (for display)
 	ContainerName string `protobuf:"bytes,6,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
 	// Description
-	Description   string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Description string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	// Interface the DNAT rule is bound to (iptables -i); empty matches any
+	// interface.
+	InInterface string `protobuf:"bytes,8,opt,name=in_interface,json=inInterface,proto3" json:"in_interface,omitempty"`
+	// Source address rewritten onto forwarded packets (SNAT --to-source);
+	// empty means MASQUERADE.
+	SnatSource string `protobuf:"bytes,9,opt,name=snat_source,json=snatSource,proto3" json:"snat_source,omitempty"`
+	// Source CIDRs allowed to use the route; empty means any source.
+	AllowSources  []string `protobuf:"bytes,10,rep,name=allow_sources,json=allowSources,proto3" json:"allow_sources,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PassthroughRoute) Reset() {
 	*x = PassthroughRoute{}
-	mi := &file_containarium_v1_network_proto_msgTypes[3]
+	mi := &file_containarium_v1_network_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -634,7 +785,7 @@ func (x *PassthroughRoute) String() string {
 func (*PassthroughRoute) ProtoMessage() {}
 
 func (x *PassthroughRoute) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[3]
+	mi := &file_containarium_v1_network_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,7 +798,7 @@ func (x *PassthroughRoute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PassthroughRoute.ProtoReflect.Descriptor instead.
 func (*PassthroughRoute) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{3}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PassthroughRoute) GetExternalPort() int32 {
@@ -699,6 +850,27 @@ func (x *PassthroughRoute) GetDescription() string {
 	return ""
 }
 
+func (x *PassthroughRoute) GetInInterface() string {
+	if x != nil {
+		return x.InInterface
+	}
+	return ""
+}
+
+func (x *PassthroughRoute) GetSnatSource() string {
+	if x != nil {
+		return x.SnatSource
+	}
+	return ""
+}
+
+func (x *PassthroughRoute) GetAllowSources() []string {
+	if x != nil {
+		return x.AllowSources
+	}
+	return nil
+}
+
 // NetworkNode represents a node in the network topology
 type NetworkNode struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -720,7 +892,7 @@ type NetworkNode struct {
 
 func (x *NetworkNode) Reset() {
 	*x = NetworkNode{}
-	mi := &file_containarium_v1_network_proto_msgTypes[4]
+	mi := &file_containarium_v1_network_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -732,7 +904,7 @@ func (x *NetworkNode) String() string {
 func (*NetworkNode) ProtoMessage() {}
 
 func (x *NetworkNode) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[4]
+	mi := &file_containarium_v1_network_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -745,7 +917,7 @@ func (x *NetworkNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkNode.ProtoReflect.Descriptor instead.
 func (*NetworkNode) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{4}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NetworkNode) GetId() string {
@@ -809,7 +981,7 @@ type NetworkEdge struct {
 
 func (x *NetworkEdge) Reset() {
 	*x = NetworkEdge{}
-	mi := &file_containarium_v1_network_proto_msgTypes[5]
+	mi := &file_containarium_v1_network_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -821,7 +993,7 @@ func (x *NetworkEdge) String() string {
 func (*NetworkEdge) ProtoMessage() {}
 
 func (x *NetworkEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[5]
+	mi := &file_containarium_v1_network_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -834,7 +1006,7 @@ func (x *NetworkEdge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkEdge.ProtoReflect.Descriptor instead.
 func (*NetworkEdge) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{5}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *NetworkEdge) GetSource() string {
@@ -889,7 +1061,7 @@ type NetworkTopology struct {
 
 func (x *NetworkTopology) Reset() {
 	*x = NetworkTopology{}
-	mi := &file_containarium_v1_network_proto_msgTypes[6]
+	mi := &file_containarium_v1_network_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -901,7 +1073,7 @@ func (x *NetworkTopology) String() string {
 func (*NetworkTopology) ProtoMessage() {}
 
 func (x *NetworkTopology) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[6]
+	mi := &file_containarium_v1_network_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -914,7 +1086,7 @@ func (x *NetworkTopology) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkTopology.ProtoReflect.Descriptor instead.
 func (*NetworkTopology) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{6}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *NetworkTopology) GetNodes() []*NetworkNode {
@@ -958,7 +1130,7 @@ type GetRoutesRequest struct {
 
 func (x *GetRoutesRequest) Reset() {
 	*x = GetRoutesRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[7]
+	mi := &file_containarium_v1_network_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -970,7 +1142,7 @@ func (x *GetRoutesRequest) String() string {
 func (*GetRoutesRequest) ProtoMessage() {}
 
 func (x *GetRoutesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[7]
+	mi := &file_containarium_v1_network_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -983,7 +1155,7 @@ func (x *GetRoutesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRoutesRequest.ProtoReflect.Descriptor instead.
 func (*GetRoutesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{7}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetRoutesRequest) GetUsername() string {
@@ -1012,7 +1184,7 @@ type GetRoutesResponse struct {
 
 func (x *GetRoutesResponse) Reset() {
 	*x = GetRoutesResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[8]
+	mi := &file_containarium_v1_network_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1024,7 +1196,7 @@ func (x *GetRoutesResponse) String() string {
 func (*GetRoutesResponse) ProtoMessage() {}
 
 func (x *GetRoutesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[8]
+	mi := &file_containarium_v1_network_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1037,7 +1209,7 @@ func (x *GetRoutesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRoutesResponse.ProtoReflect.Descriptor instead.
 func (*GetRoutesResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{8}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetRoutesResponse) GetRoutes() []*ProxyRoute {
@@ -1059,11 +1231,14 @@ type AddRouteRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Domain name (e.g., "test.example.com" or subdomain like "myapp")
 	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
-	// Target container IP address
+	// Target container IP address. Optional when container_name is set:
+	// the daemon resolves the container's current IP, and keeps the route
+	// pointed at it when the IP changes.
 	TargetIp string `protobuf:"bytes,2,opt,name=target_ip,json=targetIp,proto3" json:"target_ip,omitempty"`
 	// Target port on the container
 	TargetPort int32 `protobuf:"varint,3,opt,name=target_port,json=targetPort,proto3" json:"target_port,omitempty"`
-	// Optional: Associated container name (for display purposes)
+	// Container the route forwards to (a username is accepted, as for
+	// passthrough routes). Required when target_ip is empty.
 	ContainerName string `protobuf:"bytes,4,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
 	// Optional: Description
 	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
@@ -1075,7 +1250,7 @@ type AddRouteRequest struct {
 
 func (x *AddRouteRequest) Reset() {
 	*x = AddRouteRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[9]
+	mi := &file_containarium_v1_network_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1087,7 +1262,7 @@ func (x *AddRouteRequest) String() string {
 func (*AddRouteRequest) ProtoMessage() {}
 
 func (x *AddRouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[9]
+	mi := &file_containarium_v1_network_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1100,7 +1275,7 @@ func (x *AddRouteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddRouteRequest.ProtoReflect.Descriptor instead.
 func (*AddRouteRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{9}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AddRouteRequest) GetDomain() string {
@@ -1157,7 +1332,7 @@ type AddRouteResponse struct {
 
 func (x *AddRouteResponse) Reset() {
 	*x = AddRouteResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[10]
+	mi := &file_containarium_v1_network_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1169,7 +1344,7 @@ func (x *AddRouteResponse) String() string {
 func (*AddRouteResponse) ProtoMessage() {}
 
 func (x *AddRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[10]
+	mi := &file_containarium_v1_network_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1182,7 +1357,7 @@ func (x *AddRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddRouteResponse.ProtoReflect.Descriptor instead.
 func (*AddRouteResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{10}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AddRouteResponse) GetRoute() *ProxyRoute {
@@ -1222,7 +1397,7 @@ type UpdateRouteRequest struct {
 
 func (x *UpdateRouteRequest) Reset() {
 	*x = UpdateRouteRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[11]
+	mi := &file_containarium_v1_network_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1234,7 +1409,7 @@ func (x *UpdateRouteRequest) String() string {
 func (*UpdateRouteRequest) ProtoMessage() {}
 
 func (x *UpdateRouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[11]
+	mi := &file_containarium_v1_network_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1247,7 +1422,7 @@ func (x *UpdateRouteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRouteRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRouteRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{11}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateRouteRequest) GetDomain() string {
@@ -1311,7 +1486,7 @@ type UpdateRouteResponse struct {
 
 func (x *UpdateRouteResponse) Reset() {
 	*x = UpdateRouteResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[12]
+	mi := &file_containarium_v1_network_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1323,7 +1498,7 @@ func (x *UpdateRouteResponse) String() string {
 func (*UpdateRouteResponse) ProtoMessage() {}
 
 func (x *UpdateRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[12]
+	mi := &file_containarium_v1_network_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1336,7 +1511,7 @@ func (x *UpdateRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRouteResponse.ProtoReflect.Descriptor instead.
 func (*UpdateRouteResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{12}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UpdateRouteResponse) GetRoute() *ProxyRoute {
@@ -1364,7 +1539,7 @@ type DeleteRouteRequest struct {
 
 func (x *DeleteRouteRequest) Reset() {
 	*x = DeleteRouteRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[13]
+	mi := &file_containarium_v1_network_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1376,7 +1551,7 @@ func (x *DeleteRouteRequest) String() string {
 func (*DeleteRouteRequest) ProtoMessage() {}
 
 func (x *DeleteRouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[13]
+	mi := &file_containarium_v1_network_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1389,7 +1564,7 @@ func (x *DeleteRouteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRouteRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRouteRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{13}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeleteRouteRequest) GetDomain() string {
@@ -1409,7 +1584,7 @@ type DeleteRouteResponse struct {
 
 func (x *DeleteRouteResponse) Reset() {
 	*x = DeleteRouteResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[14]
+	mi := &file_containarium_v1_network_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1421,7 +1596,7 @@ func (x *DeleteRouteResponse) String() string {
 func (*DeleteRouteResponse) ProtoMessage() {}
 
 func (x *DeleteRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[14]
+	mi := &file_containarium_v1_network_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1434,7 +1609,7 @@ func (x *DeleteRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRouteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteRouteResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{14}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DeleteRouteResponse) GetMessage() string {
@@ -1453,7 +1628,7 @@ type ListPassthroughRoutesRequest struct {
 
 func (x *ListPassthroughRoutesRequest) Reset() {
 	*x = ListPassthroughRoutesRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[15]
+	mi := &file_containarium_v1_network_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1465,7 +1640,7 @@ func (x *ListPassthroughRoutesRequest) String() string {
 func (*ListPassthroughRoutesRequest) ProtoMessage() {}
 
 func (x *ListPassthroughRoutesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[15]
+	mi := &file_containarium_v1_network_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1478,7 +1653,7 @@ func (x *ListPassthroughRoutesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPassthroughRoutesRequest.ProtoReflect.Descriptor instead.
 func (*ListPassthroughRoutesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{15}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{16}
 }
 
 type ListPassthroughRoutesResponse struct {
@@ -1493,7 +1668,7 @@ type ListPassthroughRoutesResponse struct {
 
 func (x *ListPassthroughRoutesResponse) Reset() {
 	*x = ListPassthroughRoutesResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[16]
+	mi := &file_containarium_v1_network_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1505,7 +1680,7 @@ func (x *ListPassthroughRoutesResponse) String() string {
 func (*ListPassthroughRoutesResponse) ProtoMessage() {}
 
 func (x *ListPassthroughRoutesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[16]
+	mi := &file_containarium_v1_network_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1518,7 +1693,7 @@ func (x *ListPassthroughRoutesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPassthroughRoutesResponse.ProtoReflect.Descriptor instead.
 func (*ListPassthroughRoutesResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{16}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ListPassthroughRoutesResponse) GetRoutes() []*PassthroughRoute {
@@ -1540,23 +1715,42 @@ type AddPassthroughRouteRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// External port on the host
 	ExternalPort int32 `protobuf:"varint,1,opt,name=external_port,json=externalPort,proto3" json:"external_port,omitempty"`
-	// Target container IP address
+	// Target container IP address. Optional when container_name is set; if
+	// both are given they must agree.
 	TargetIp string `protobuf:"bytes,2,opt,name=target_ip,json=targetIp,proto3" json:"target_ip,omitempty"`
 	// Target port on the container
 	TargetPort int32 `protobuf:"varint,3,opt,name=target_port,json=targetPort,proto3" json:"target_port,omitempty"`
 	// Protocol: TCP or UDP (defaults to TCP)
 	Protocol RouteProtocol `protobuf:"varint,4,opt,name=protocol,proto3,enum=containarium.v1.RouteProtocol" json:"protocol,omitempty"`
-	// Optional: Associated container name (for display)
+	// Container to route to (username or full name). The daemon resolves its
+	// current IP and records the association. Required for non-admin callers,
+	// who may only target their own container.
 	ContainerName string `protobuf:"bytes,5,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
 	// Optional: Description
-	Description   string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	// Add the route even if the external port is already in use on the host
+	// (a listening socket, or a NAT rule Containarium didn't create). Without
+	// it such a port is rejected with FAILED_PRECONDITION.
+	Force bool `protobuf:"varint,7,opt,name=force,proto3" json:"force,omitempty"`
+	// Only DNAT traffic arriving on this interface (e.g. "eth0"), so the
+	// port isn't also exposed on internal or VPN interfaces. "auto" uses the
+	// interface of the host's default route. Empty matches any interface.
+	InInterface string `protobuf:"bytes,8,opt,name=in_interface,json=inInterface,proto3" json:"in_interface,omitempty"`
+	// Rewrite the source of forwarded packets to this IPv4 address instead
+	// of MASQUERADE. "host" uses the host's address on the container
+	// network. Empty keeps MASQUERADE.
+	SnatSource string `protobuf:"bytes,9,opt,name=snat_source,json=snatSource,proto3" json:"snat_source,omitempty"`
+	// Only forward connections from these IPv4 addresses or CIDRs (e.g. a
+	// partner's network); others never reach the container. Empty leaves
+	// the route open to any source.
+	AllowSources  []string `protobuf:"bytes,10,rep,name=allow_sources,json=allowSources,proto3" json:"allow_sources,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AddPassthroughRouteRequest) Reset() {
 	*x = AddPassthroughRouteRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[17]
+	mi := &file_containarium_v1_network_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1568,7 +1762,7 @@ func (x *AddPassthroughRouteRequest) String() string {
 func (*AddPassthroughRouteRequest) ProtoMessage() {}
 
 func (x *AddPassthroughRouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[17]
+	mi := &file_containarium_v1_network_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1581,7 +1775,7 @@ func (x *AddPassthroughRouteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPassthroughRouteRequest.ProtoReflect.Descriptor instead.
 func (*AddPassthroughRouteRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{17}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *AddPassthroughRouteRequest) GetExternalPort() int32 {
@@ -1626,6 +1820,34 @@ func (x *AddPassthroughRouteRequest) GetDescription() string {
 	return ""
 }
 
+func (x *AddPassthroughRouteRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *AddPassthroughRouteRequest) GetInInterface() string {
+	if x != nil {
+		return x.InInterface
+	}
+	return ""
+}
+
+func (x *AddPassthroughRouteRequest) GetSnatSource() string {
+	if x != nil {
+		return x.SnatSource
+	}
+	return ""
+}
+
+func (x *AddPassthroughRouteRequest) GetAllowSources() []string {
+	if x != nil {
+		return x.AllowSources
+	}
+	return nil
+}
+
 type AddPassthroughRouteResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The created route
@@ -1638,7 +1860,7 @@ type AddPassthroughRouteResponse struct {
 
 func (x *AddPassthroughRouteResponse) Reset() {
 	*x = AddPassthroughRouteResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[18]
+	mi := &file_containarium_v1_network_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1650,7 +1872,7 @@ func (x *AddPassthroughRouteResponse) String() string {
 func (*AddPassthroughRouteResponse) ProtoMessage() {}
 
 func (x *AddPassthroughRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[18]
+	mi := &file_containarium_v1_network_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1663,7 +1885,7 @@ func (x *AddPassthroughRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPassthroughRouteResponse.ProtoReflect.Descriptor instead.
 func (*AddPassthroughRouteResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{18}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *AddPassthroughRouteResponse) GetRoute() *PassthroughRoute {
@@ -1686,14 +1908,16 @@ type DeletePassthroughRouteRequest struct {
 	// External port to remove
 	ExternalPort int32 `protobuf:"varint,1,opt,name=external_port,json=externalPort,proto3" json:"external_port,omitempty"`
 	// Protocol: TCP or UDP
-	Protocol      RouteProtocol `protobuf:"varint,2,opt,name=protocol,proto3,enum=containarium.v1.RouteProtocol" json:"protocol,omitempty"`
+	Protocol RouteProtocol `protobuf:"varint,2,opt,name=protocol,proto3,enum=containarium.v1.RouteProtocol" json:"protocol,omitempty"`
+	// Check that the route exists and report it without removing it.
+	DryRun        bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeletePassthroughRouteRequest) Reset() {
 	*x = DeletePassthroughRouteRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[19]
+	mi := &file_containarium_v1_network_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1705,7 +1929,7 @@ func (x *DeletePassthroughRouteRequest) String() string {
 func (*DeletePassthroughRouteRequest) ProtoMessage() {}
 
 func (x *DeletePassthroughRouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[19]
+	mi := &file_containarium_v1_network_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1718,7 +1942,7 @@ func (x *DeletePassthroughRouteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePassthroughRouteRequest.ProtoReflect.Descriptor instead.
 func (*DeletePassthroughRouteRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{19}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DeletePassthroughRouteRequest) GetExternalPort() int32 {
@@ -1735,17 +1959,29 @@ func (x *DeletePassthroughRouteRequest) GetProtocol() RouteProtocol {
 	return RouteProtocol_ROUTE_PROTOCOL_UNSPECIFIED
 }
 
+func (x *DeletePassthroughRouteRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 type DeletePassthroughRouteResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Status message
-	Message       string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The route removed (or, with dry_run, that would be). Unset when the
+	// daemon has no record of it (a legacy iptables-only route).
+	Route *PassthroughRoute `protobuf:"bytes,2,opt,name=route,proto3" json:"route,omitempty"`
+	// Echoes the request's dry_run.
+	DryRun        bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeletePassthroughRouteResponse) Reset() {
 	*x = DeletePassthroughRouteResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[20]
+	mi := &file_containarium_v1_network_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1757,7 +1993,7 @@ func (x *DeletePassthroughRouteResponse) String() string {
 func (*DeletePassthroughRouteResponse) ProtoMessage() {}
 
 func (x *DeletePassthroughRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[20]
+	mi := &file_containarium_v1_network_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1770,7 +2006,7 @@ func (x *DeletePassthroughRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePassthroughRouteResponse.ProtoReflect.Descriptor instead.
 func (*DeletePassthroughRouteResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{20}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DeletePassthroughRouteResponse) GetMessage() string {
@@ -1780,6 +2016,20 @@ func (x *DeletePassthroughRouteResponse) GetMessage() string {
 	return ""
 }
 
+func (x *DeletePassthroughRouteResponse) GetRoute() *PassthroughRoute {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *DeletePassthroughRouteResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 // UpdatePassthroughRouteRequest updates an existing passthrough route
 type UpdatePassthroughRouteRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1791,19 +2041,26 @@ type UpdatePassthroughRouteRequest struct {
 	TargetIp string `protobuf:"bytes,3,opt,name=target_ip,json=targetIp,proto3" json:"target_ip,omitempty"`
 	// New target port (optional, 0 means no change)
 	TargetPort int32 `protobuf:"varint,4,opt,name=target_port,json=targetPort,proto3" json:"target_port,omitempty"`
-	// Optional: Associated container name (for display)
+	// Container to route to (username or full name); its current IP is
+	// re-resolved. Same rules as AddPassthroughRouteRequest.container_name.
 	ContainerName string `protobuf:"bytes,5,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
 	// Optional: Description
 	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
 	// Enable or disable the route (optional, only updated if set)
-	Active        *bool `protobuf:"varint,7,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	Active *bool `protobuf:"varint,7,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	// New interface binding (optional, only updated if set; "" clears it).
+	// Same values as AddPassthroughRouteRequest.in_interface.
+	InInterface *string `protobuf:"bytes,8,opt,name=in_interface,json=inInterface,proto3,oneof" json:"in_interface,omitempty"`
+	// New SNAT source (optional, only updated if set; "" restores
+	// MASQUERADE). Same values as AddPassthroughRouteRequest.snat_source.
+	SnatSource    *string `protobuf:"bytes,9,opt,name=snat_source,json=snatSource,proto3,oneof" json:"snat_source,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdatePassthroughRouteRequest) Reset() {
 	*x = UpdatePassthroughRouteRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[21]
+	mi := &file_containarium_v1_network_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1815,7 +2072,7 @@ func (x *UpdatePassthroughRouteRequest) String() string {
 func (*UpdatePassthroughRouteRequest) ProtoMessage() {}
 
 func (x *UpdatePassthroughRouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[21]
+	mi := &file_containarium_v1_network_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1828,7 +2085,7 @@ func (x *UpdatePassthroughRouteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePassthroughRouteRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePassthroughRouteRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{21}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *UpdatePassthroughRouteRequest) GetExternalPort() int32 {
@@ -1880,6 +2137,20 @@ func (x *UpdatePassthroughRouteRequest) GetActive() bool {
 	return false
 }
 
+func (x *UpdatePassthroughRouteRequest) GetInInterface() string {
+	if x != nil && x.InInterface != nil {
+		return *x.InInterface
+	}
+	return ""
+}
+
+func (x *UpdatePassthroughRouteRequest) GetSnatSource() string {
+	if x != nil && x.SnatSource != nil {
+		return *x.SnatSource
+	}
+	return ""
+}
+
 type UpdatePassthroughRouteResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The updated route
@@ -1892,7 +2163,7 @@ type UpdatePassthroughRouteResponse struct {
 
 func (x *UpdatePassthroughRouteResponse) Reset() {
 	*x = UpdatePassthroughRouteResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[22]
+	mi := &file_containarium_v1_network_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1904,7 +2175,7 @@ func (x *UpdatePassthroughRouteResponse) String() string {
 func (*UpdatePassthroughRouteResponse) ProtoMessage() {}
 
 func (x *UpdatePassthroughRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[22]
+	mi := &file_containarium_v1_network_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1917,7 +2188,7 @@ func (x *UpdatePassthroughRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePassthroughRouteResponse.ProtoReflect.Descriptor instead.
 func (*UpdatePassthroughRouteResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{22}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *UpdatePassthroughRouteResponse) GetRoute() *PassthroughRoute {
@@ -1934,6 +2205,145 @@ func (x *UpdatePassthroughRouteResponse) GetMessage() string {
 	return ""
 }
 
+// AdoptPassthroughRouteRequest brings an existing, unmanaged DNAT rule
+// (written by hand or by another tool) under management.
+type AdoptPassthroughRouteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// External port the rule forwards
+	ExternalPort int32 `protobuf:"varint,1,opt,name=external_port,json=externalPort,proto3" json:"external_port,omitempty"`
+	// Protocol: TCP or UDP (defaults to TCP)
+	Protocol RouteProtocol `protobuf:"varint,2,opt,name=protocol,proto3,enum=containarium.v1.RouteProtocol" json:"protocol,omitempty"`
+	// Container the rule forwards to (username or full name); its current IP
+	// must be the rule's target. Optional: the container is otherwise found
+	// by the target IP.
+	ContainerName string `protobuf:"bytes,3,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Adopt a rule whose target is not a known container's IP.
+	AllowExternalTarget bool `protobuf:"varint,4,opt,name=allow_external_target,json=allowExternalTarget,proto3" json:"allow_external_target,omitempty"`
+	// Optional: Description
+	Description   string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdoptPassthroughRouteRequest) Reset() {
+	*x = AdoptPassthroughRouteRequest{}
+	mi := &file_containarium_v1_network_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdoptPassthroughRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdoptPassthroughRouteRequest) ProtoMessage() {}
+
+func (x *AdoptPassthroughRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdoptPassthroughRouteRequest.ProtoReflect.Descriptor instead.
+func (*AdoptPassthroughRouteRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AdoptPassthroughRouteRequest) GetExternalPort() int32 {
+	if x != nil {
+		return x.ExternalPort
+	}
+	return 0
+}
+
+func (x *AdoptPassthroughRouteRequest) GetProtocol() RouteProtocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return RouteProtocol_ROUTE_PROTOCOL_UNSPECIFIED
+}
+
+func (x *AdoptPassthroughRouteRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *AdoptPassthroughRouteRequest) GetAllowExternalTarget() bool {
+	if x != nil {
+		return x.AllowExternalTarget
+	}
+	return false
+}
+
+func (x *AdoptPassthroughRouteRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type AdoptPassthroughRouteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The adopted route
+	Route *PassthroughRoute `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	// Status message
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdoptPassthroughRouteResponse) Reset() {
+	*x = AdoptPassthroughRouteResponse{}
+	mi := &file_containarium_v1_network_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdoptPassthroughRouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdoptPassthroughRouteResponse) ProtoMessage() {}
+
+func (x *AdoptPassthroughRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdoptPassthroughRouteResponse.ProtoReflect.Descriptor instead.
+func (*AdoptPassthroughRouteResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AdoptPassthroughRouteResponse) GetRoute() *PassthroughRoute {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *AdoptPassthroughRouteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 // DNSRecord represents a DNS record
 type DNSRecord struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1951,7 +2361,7 @@ type DNSRecord struct {
 
 func (x *DNSRecord) Reset() {
 	*x = DNSRecord{}
-	mi := &file_containarium_v1_network_proto_msgTypes[23]
+	mi := &file_containarium_v1_network_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1963,7 +2373,7 @@ func (x *DNSRecord) String() string {
 func (*DNSRecord) ProtoMessage() {}
 
 func (x *DNSRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[23]
+	mi := &file_containarium_v1_network_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1976,7 +2386,7 @@ func (x *DNSRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DNSRecord.ProtoReflect.Descriptor instead.
 func (*DNSRecord) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{23}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DNSRecord) GetType() string {
@@ -2018,7 +2428,7 @@ type ListDNSRecordsRequest struct {
 
 func (x *ListDNSRecordsRequest) Reset() {
 	*x = ListDNSRecordsRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[24]
+	mi := &file_containarium_v1_network_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2030,7 +2440,7 @@ func (x *ListDNSRecordsRequest) String() string {
 func (*ListDNSRecordsRequest) ProtoMessage() {}
 
 func (x *ListDNSRecordsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[24]
+	mi := &file_containarium_v1_network_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2043,7 +2453,7 @@ func (x *ListDNSRecordsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDNSRecordsRequest.ProtoReflect.Descriptor instead.
 func (*ListDNSRecordsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{24}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ListDNSRecordsRequest) GetRecordType() string {
@@ -2067,7 +2477,7 @@ type ListDNSRecordsResponse struct {
 
 func (x *ListDNSRecordsResponse) Reset() {
 	*x = ListDNSRecordsResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[25]
+	mi := &file_containarium_v1_network_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2079,7 +2489,7 @@ func (x *ListDNSRecordsResponse) String() string {
 func (*ListDNSRecordsResponse) ProtoMessage() {}
 
 func (x *ListDNSRecordsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[25]
+	mi := &file_containarium_v1_network_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2092,7 +2502,7 @@ func (x *ListDNSRecordsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDNSRecordsResponse.ProtoReflect.Descriptor instead.
 func (*ListDNSRecordsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{25}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ListDNSRecordsResponse) GetRecords() []*DNSRecord {
@@ -2127,7 +2537,7 @@ type GetContainerACLRequest struct {
 
 func (x *GetContainerACLRequest) Reset() {
 	*x = GetContainerACLRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[26]
+	mi := &file_containarium_v1_network_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2139,7 +2549,7 @@ func (x *GetContainerACLRequest) String() string {
 func (*GetContainerACLRequest) ProtoMessage() {}
 
 func (x *GetContainerACLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[26]
+	mi := &file_containarium_v1_network_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2152,7 +2562,7 @@ func (x *GetContainerACLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetContainerACLRequest.ProtoReflect.Descriptor instead.
 func (*GetContainerACLRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{26}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetContainerACLRequest) GetUsername() string {
@@ -2172,7 +2582,7 @@ type GetContainerACLResponse struct {
 
 func (x *GetContainerACLResponse) Reset() {
 	*x = GetContainerACLResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[27]
+	mi := &file_containarium_v1_network_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2184,7 +2594,7 @@ func (x *GetContainerACLResponse) String() string {
 func (*GetContainerACLResponse) ProtoMessage() {}
 
 func (x *GetContainerACLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[27]
+	mi := &file_containarium_v1_network_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2197,7 +2607,7 @@ func (x *GetContainerACLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetContainerACLResponse.ProtoReflect.Descriptor instead.
 func (*GetContainerACLResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{27}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *GetContainerACLResponse) GetAcl() *NetworkACL {
@@ -2224,7 +2634,7 @@ type UpdateContainerACLRequest struct {
 
 func (x *UpdateContainerACLRequest) Reset() {
 	*x = UpdateContainerACLRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[28]
+	mi := &file_containarium_v1_network_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2236,7 +2646,7 @@ func (x *UpdateContainerACLRequest) String() string {
 func (*UpdateContainerACLRequest) ProtoMessage() {}
 
 func (x *UpdateContainerACLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[28]
+	mi := &file_containarium_v1_network_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2249,7 +2659,7 @@ func (x *UpdateContainerACLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateContainerACLRequest.ProtoReflect.Descriptor instead.
 func (*UpdateContainerACLRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{28}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *UpdateContainerACLRequest) GetUsername() string {
@@ -2292,7 +2702,7 @@ type UpdateContainerACLResponse struct {
 
 func (x *UpdateContainerACLResponse) Reset() {
 	*x = UpdateContainerACLResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[29]
+	mi := &file_containarium_v1_network_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2304,7 +2714,7 @@ func (x *UpdateContainerACLResponse) String() string {
 func (*UpdateContainerACLResponse) ProtoMessage() {}
 
 func (x *UpdateContainerACLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[29]
+	mi := &file_containarium_v1_network_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2317,7 +2727,7 @@ func (x *UpdateContainerACLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateContainerACLResponse.ProtoReflect.Descriptor instead.
 func (*UpdateContainerACLResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{29}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *UpdateContainerACLResponse) GetAcl() *NetworkACL {
@@ -2345,7 +2755,7 @@ type GetNetworkTopologyRequest struct {
 
 func (x *GetNetworkTopologyRequest) Reset() {
 	*x = GetNetworkTopologyRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[30]
+	mi := &file_containarium_v1_network_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2357,7 +2767,7 @@ func (x *GetNetworkTopologyRequest) String() string {
 func (*GetNetworkTopologyRequest) ProtoMessage() {}
 
 func (x *GetNetworkTopologyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[30]
+	mi := &file_containarium_v1_network_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2370,7 +2780,7 @@ func (x *GetNetworkTopologyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworkTopologyRequest.ProtoReflect.Descriptor instead.
 func (*GetNetworkTopologyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{30}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GetNetworkTopologyRequest) GetIncludeStopped() bool {
@@ -2390,7 +2800,7 @@ type GetNetworkTopologyResponse struct {
 
 func (x *GetNetworkTopologyResponse) Reset() {
 	*x = GetNetworkTopologyResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[31]
+	mi := &file_containarium_v1_network_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2402,7 +2812,7 @@ func (x *GetNetworkTopologyResponse) String() string {
 func (*GetNetworkTopologyResponse) ProtoMessage() {}
 
 func (x *GetNetworkTopologyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[31]
+	mi := &file_containarium_v1_network_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2415,7 +2825,7 @@ func (x *GetNetworkTopologyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworkTopologyResponse.ProtoReflect.Descriptor instead.
 func (*GetNetworkTopologyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{31}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetNetworkTopologyResponse) GetTopology() *NetworkTopology {
@@ -2434,7 +2844,7 @@ type ListACLPresetsRequest struct {
 
 func (x *ListACLPresetsRequest) Reset() {
 	*x = ListACLPresetsRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[32]
+	mi := &file_containarium_v1_network_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2446,7 +2856,7 @@ func (x *ListACLPresetsRequest) String() string {
 func (*ListACLPresetsRequest) ProtoMessage() {}
 
 func (x *ListACLPresetsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[32]
+	mi := &file_containarium_v1_network_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2459,7 +2869,7 @@ func (x *ListACLPresetsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListACLPresetsRequest.ProtoReflect.Descriptor instead.
 func (*ListACLPresetsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{32}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{35}
 }
 
 type ACLPresetInfo struct {
@@ -2480,7 +2890,7 @@ type ACLPresetInfo struct {
 
 func (x *ACLPresetInfo) Reset() {
 	*x = ACLPresetInfo{}
-	mi := &file_containarium_v1_network_proto_msgTypes[33]
+	mi := &file_containarium_v1_network_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2492,7 +2902,7 @@ func (x *ACLPresetInfo) String() string {
 func (*ACLPresetInfo) ProtoMessage() {}
 
 func (x *ACLPresetInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[33]
+	mi := &file_containarium_v1_network_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2505,7 +2915,7 @@ func (x *ACLPresetInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ACLPresetInfo.ProtoReflect.Descriptor instead.
 func (*ACLPresetInfo) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{33}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ACLPresetInfo) GetPreset() ACLPreset {
@@ -2553,7 +2963,7 @@ type ListACLPresetsResponse struct {
 
 func (x *ListACLPresetsResponse) Reset() {
 	*x = ListACLPresetsResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[34]
+	mi := &file_containarium_v1_network_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2565,7 +2975,7 @@ func (x *ListACLPresetsResponse) String() string {
 func (*ListACLPresetsResponse) ProtoMessage() {}
 
 func (x *ListACLPresetsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[34]
+	mi := &file_containarium_v1_network_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2578,7 +2988,7 @@ func (x *ListACLPresetsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListACLPresetsResponse.ProtoReflect.Descriptor instead.
 func (*ListACLPresetsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{34}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ListACLPresetsResponse) GetPresets() []*ACLPresetInfo {
@@ -2607,7 +3017,7 @@ type StartEgressProxyRequest struct {
 
 func (x *StartEgressProxyRequest) Reset() {
 	*x = StartEgressProxyRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[35]
+	mi := &file_containarium_v1_network_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2619,7 +3029,7 @@ func (x *StartEgressProxyRequest) String() string {
 func (*StartEgressProxyRequest) ProtoMessage() {}
 
 func (x *StartEgressProxyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[35]
+	mi := &file_containarium_v1_network_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2632,7 +3042,7 @@ func (x *StartEgressProxyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartEgressProxyRequest.ProtoReflect.Descriptor instead.
 func (*StartEgressProxyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{35}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *StartEgressProxyRequest) GetContainerName() string {
@@ -2669,7 +3079,7 @@ type StartEgressProxyResponse struct {
 
 func (x *StartEgressProxyResponse) Reset() {
 	*x = StartEgressProxyResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[36]
+	mi := &file_containarium_v1_network_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2681,7 +3091,7 @@ func (x *StartEgressProxyResponse) String() string {
 func (*StartEgressProxyResponse) ProtoMessage() {}
 
 func (x *StartEgressProxyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[36]
+	mi := &file_containarium_v1_network_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2694,7 +3104,7 @@ func (x *StartEgressProxyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartEgressProxyResponse.ProtoReflect.Descriptor instead.
 func (*StartEgressProxyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{36}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *StartEgressProxyResponse) GetSocksAddress() string {
@@ -2714,7 +3124,7 @@ type StopEgressProxyRequest struct {
 
 func (x *StopEgressProxyRequest) Reset() {
 	*x = StopEgressProxyRequest{}
-	mi := &file_containarium_v1_network_proto_msgTypes[37]
+	mi := &file_containarium_v1_network_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2726,7 +3136,7 @@ func (x *StopEgressProxyRequest) String() string {
 func (*StopEgressProxyRequest) ProtoMessage() {}
 
 func (x *StopEgressProxyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[37]
+	mi := &file_containarium_v1_network_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2739,7 +3149,7 @@ func (x *StopEgressProxyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopEgressProxyRequest.ProtoReflect.Descriptor instead.
 func (*StopEgressProxyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{37}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *StopEgressProxyRequest) GetContainerName() string {
@@ -2759,7 +3169,7 @@ type StopEgressProxyResponse struct {
 
 func (x *StopEgressProxyResponse) Reset() {
 	*x = StopEgressProxyResponse{}
-	mi := &file_containarium_v1_network_proto_msgTypes[38]
+	mi := &file_containarium_v1_network_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2771,7 +3181,7 @@ func (x *StopEgressProxyResponse) String() string {
 func (*StopEgressProxyResponse) ProtoMessage() {}
 
 func (x *StopEgressProxyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_network_proto_msgTypes[38]
+	mi := &file_containarium_v1_network_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2784,7 +3194,7 @@ func (x *StopEgressProxyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopEgressProxyResponse.ProtoReflect.Descriptor instead.
 func (*StopEgressProxyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_network_proto_rawDescGZIP(), []int{38}
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *StopEgressProxyResponse) GetStopped() bool {
@@ -2794,11 +3204,407 @@ func (x *StopEgressProxyResponse) GetStopped() bool {
 	return false
 }
 
+// BandwidthLimit caps a container's throughput, enforced with tc on its
+// host-side veth. Zero leaves a direction unlimited.
+type BandwidthLimit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container the limit applies to (e.g. "alice-container")
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Cap on traffic toward the container (downloads), in Mbit/s
+	IngressMbps int32 `protobuf:"varint,2,opt,name=ingress_mbps,json=ingressMbps,proto3" json:"ingress_mbps,omitempty"`
+	// Cap on traffic leaving the container (uploads), in Mbit/s
+	EgressMbps int32 `protobuf:"varint,3,opt,name=egress_mbps,json=egressMbps,proto3" json:"egress_mbps,omitempty"`
+	// Why the limit exists: "manual", or "alert:<name>" when an alert rule set it
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Who set the limit
+	CreatedBy string `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// When a temporary limit is lifted; unset for a permanent one
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Whether tc enforces the limit now. False while the container is
+	// stopped; it is applied when the container starts.
+	Applied       bool                   `protobuf:"varint,7,opt,name=applied,proto3" json:"applied,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BandwidthLimit) Reset() {
+	*x = BandwidthLimit{}
+	mi := &file_containarium_v1_network_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BandwidthLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BandwidthLimit) ProtoMessage() {}
+
+func (x *BandwidthLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BandwidthLimit.ProtoReflect.Descriptor instead.
+func (*BandwidthLimit) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BandwidthLimit) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *BandwidthLimit) GetIngressMbps() int32 {
+	if x != nil {
+		return x.IngressMbps
+	}
+	return 0
+}
+
+func (x *BandwidthLimit) GetEgressMbps() int32 {
+	if x != nil {
+		return x.EgressMbps
+	}
+	return 0
+}
+
+func (x *BandwidthLimit) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BandwidthLimit) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *BandwidthLimit) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *BandwidthLimit) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *BandwidthLimit) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// SetBandwidthLimitRequest sets or replaces a container's bandwidth limit.
+type SetBandwidthLimitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name, or the username owning "<username>-container"
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Ingress cap in Mbit/s; 0 = unlimited
+	IngressMbps int32 `protobuf:"varint,2,opt,name=ingress_mbps,json=ingressMbps,proto3" json:"ingress_mbps,omitempty"`
+	// Egress cap in Mbit/s; 0 = unlimited
+	EgressMbps int32 `protobuf:"varint,3,opt,name=egress_mbps,json=egressMbps,proto3" json:"egress_mbps,omitempty"`
+	// Lift the limit after this many seconds; 0 = permanent
+	DurationSeconds int64 `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetBandwidthLimitRequest) Reset() {
+	*x = SetBandwidthLimitRequest{}
+	mi := &file_containarium_v1_network_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBandwidthLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBandwidthLimitRequest) ProtoMessage() {}
+
+func (x *SetBandwidthLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBandwidthLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetBandwidthLimitRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetBandwidthLimitRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *SetBandwidthLimitRequest) GetIngressMbps() int32 {
+	if x != nil {
+		return x.IngressMbps
+	}
+	return 0
+}
+
+func (x *SetBandwidthLimitRequest) GetEgressMbps() int32 {
+	if x != nil {
+		return x.EgressMbps
+	}
+	return 0
+}
+
+func (x *SetBandwidthLimitRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type SetBandwidthLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         *BandwidthLimit        `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBandwidthLimitResponse) Reset() {
+	*x = SetBandwidthLimitResponse{}
+	mi := &file_containarium_v1_network_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBandwidthLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBandwidthLimitResponse) ProtoMessage() {}
+
+func (x *SetBandwidthLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBandwidthLimitResponse.ProtoReflect.Descriptor instead.
+func (*SetBandwidthLimitResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SetBandwidthLimitResponse) GetLimit() *BandwidthLimit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+type ListBandwidthLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBandwidthLimitsRequest) Reset() {
+	*x = ListBandwidthLimitsRequest{}
+	mi := &file_containarium_v1_network_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBandwidthLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBandwidthLimitsRequest) ProtoMessage() {}
+
+func (x *ListBandwidthLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBandwidthLimitsRequest.ProtoReflect.Descriptor instead.
+func (*ListBandwidthLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{45}
+}
+
+type ListBandwidthLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        []*BandwidthLimit      `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBandwidthLimitsResponse) Reset() {
+	*x = ListBandwidthLimitsResponse{}
+	mi := &file_containarium_v1_network_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBandwidthLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBandwidthLimitsResponse) ProtoMessage() {}
+
+func (x *ListBandwidthLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBandwidthLimitsResponse.ProtoReflect.Descriptor instead.
+func (*ListBandwidthLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListBandwidthLimitsResponse) GetLimits() []*BandwidthLimit {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type ClearBandwidthLimitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name, or the username owning "<username>-container"
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearBandwidthLimitRequest) Reset() {
+	*x = ClearBandwidthLimitRequest{}
+	mi := &file_containarium_v1_network_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearBandwidthLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearBandwidthLimitRequest) ProtoMessage() {}
+
+func (x *ClearBandwidthLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearBandwidthLimitRequest.ProtoReflect.Descriptor instead.
+func (*ClearBandwidthLimitRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ClearBandwidthLimitRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+type ClearBandwidthLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearBandwidthLimitResponse) Reset() {
+	*x = ClearBandwidthLimitResponse{}
+	mi := &file_containarium_v1_network_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearBandwidthLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearBandwidthLimitResponse) ProtoMessage() {}
+
+func (x *ClearBandwidthLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_network_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearBandwidthLimitResponse.ProtoReflect.Descriptor instead.
+func (*ClearBandwidthLimitResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_network_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ClearBandwidthLimitResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_containarium_v1_network_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_network_proto_rawDesc = "" +
 	"\n" +
-	"\x1dcontainarium/v1/network.proto\x12\x0fcontainarium.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xfc\x01\n" +
+	"\x1dcontainarium/v1/network.proto\x12\x0fcontainarium.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xfc\x01\n" +
 	"\aACLRule\x12\x1a\n" +
 	"\bpriority\x18\x01 \x01(\x05R\bpriority\x122\n" +
 	"\x06action\x18\x02 \x01(\x0e2\x1a.containarium.v1.ACLActionR\x06action\x12\x16\n" +
@@ -2816,7 +3622,7 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\ringress_rules\x18\x05 \x03(\v2\x18.containarium.v1.ACLRuleR\fingressRules\x12;\n" +
 	"\fegress_rules\x18\x06 \x03(\v2\x18.containarium.v1.ACLRuleR\vegressRules\x12\x15\n" +
 	"\x06app_id\x18\a \x01(\tR\x05appId\x12%\n" +
-	"\x0econtainer_name\x18\b \x01(\tR\rcontainerName\"\xcb\x02\n" +
+	"\x0econtainer_name\x18\b \x01(\tR\rcontainerName\"\x90\x03\n" +
 	"\n" +
 	"ProxyRoute\x12\x1c\n" +
 	"\tsubdomain\x18\x01 \x01(\tR\tsubdomain\x12\x1f\n" +
@@ -2830,7 +3636,13 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\busername\x18\b \x01(\tR\busername\x12:\n" +
 	"\bprotocol\x18\t \x01(\x0e2\x1e.containarium.v1.RouteProtocolR\bprotocol\x12%\n" +
 	"\x0econtainer_name\x18\n" +
-	" \x01(\tR\rcontainerName\"\x92\x02\n" +
+	" \x01(\tR\rcontainerName\x12C\n" +
+	"\vcertificate\x18\v \x01(\v2!.containarium.v1.RouteCertificateR\vcertificate\"\xb9\x01\n" +
+	"\x10RouteCertificate\x12:\n" +
+	"\x06status\x18\x01 \x01(\x0e2\".containarium.v1.CertificateStatusR\x06status\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x16\n" +
+	"\x06issuer\x18\x03 \x01(\tR\x06issuer\x127\n" +
+	"\tnot_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bnotAfter\"\xfb\x02\n" +
 	"\x10PassthroughRoute\x12#\n" +
 	"\rexternal_port\x18\x01 \x01(\x05R\fexternalPort\x12\x1b\n" +
 	"\ttarget_ip\x18\x02 \x01(\tR\btargetIp\x12\x1f\n" +
@@ -2839,7 +3651,12 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\bprotocol\x18\x04 \x01(\x0e2\x1e.containarium.v1.RouteProtocolR\bprotocol\x12\x16\n" +
 	"\x06active\x18\x05 \x01(\bR\x06active\x12%\n" +
 	"\x0econtainer_name\x18\x06 \x01(\tR\rcontainerName\x12 \n" +
-	"\vdescription\x18\a \x01(\tR\vdescription\"\x95\x01\n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12!\n" +
+	"\fin_interface\x18\b \x01(\tR\vinInterface\x12\x1f\n" +
+	"\vsnat_source\x18\t \x01(\tR\n" +
+	"snatSource\x12#\n" +
+	"\rallow_sources\x18\n" +
+	" \x03(\tR\fallowSources\"\x95\x01\n" +
 	"\vNetworkNode\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
@@ -2900,7 +3717,7 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\x1dListPassthroughRoutesResponse\x129\n" +
 	"\x06routes\x18\x01 \x03(\v2!.containarium.v1.PassthroughRouteR\x06routes\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\x84\x02\n" +
+	"totalCount\"\x83\x03\n" +
 	"\x1aAddPassthroughRouteRequest\x12#\n" +
 	"\rexternal_port\x18\x01 \x01(\x05R\fexternalPort\x12\x1b\n" +
 	"\ttarget_ip\x18\x02 \x01(\tR\btargetIp\x12\x1f\n" +
@@ -2908,15 +3725,24 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"targetPort\x12:\n" +
 	"\bprotocol\x18\x04 \x01(\x0e2\x1e.containarium.v1.RouteProtocolR\bprotocol\x12%\n" +
 	"\x0econtainer_name\x18\x05 \x01(\tR\rcontainerName\x12 \n" +
-	"\vdescription\x18\x06 \x01(\tR\vdescription\"p\n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05force\x18\a \x01(\bR\x05force\x12!\n" +
+	"\fin_interface\x18\b \x01(\tR\vinInterface\x12\x1f\n" +
+	"\vsnat_source\x18\t \x01(\tR\n" +
+	"snatSource\x12#\n" +
+	"\rallow_sources\x18\n" +
+	" \x03(\tR\fallowSources\"p\n" +
 	"\x1bAddPassthroughRouteResponse\x127\n" +
 	"\x05route\x18\x01 \x01(\v2!.containarium.v1.PassthroughRouteR\x05route\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x80\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x99\x01\n" +
 	"\x1dDeletePassthroughRouteRequest\x12#\n" +
 	"\rexternal_port\x18\x01 \x01(\x05R\fexternalPort\x12:\n" +
-	"\bprotocol\x18\x02 \x01(\x0e2\x1e.containarium.v1.RouteProtocolR\bprotocol\":\n" +
+	"\bprotocol\x18\x02 \x01(\x0e2\x1e.containarium.v1.RouteProtocolR\bprotocol\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\x8c\x01\n" +
 	"\x1eDeletePassthroughRouteResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"\xaf\x02\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x127\n" +
+	"\x05route\x18\x02 \x01(\v2!.containarium.v1.PassthroughRouteR\x05route\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\x9e\x03\n" +
 	"\x1dUpdatePassthroughRouteRequest\x12#\n" +
 	"\rexternal_port\x18\x01 \x01(\x05R\fexternalPort\x12:\n" +
 	"\bprotocol\x18\x02 \x01(\x0e2\x1e.containarium.v1.RouteProtocolR\bprotocol\x12\x1b\n" +
@@ -2925,10 +3751,24 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"targetPort\x12%\n" +
 	"\x0econtainer_name\x18\x05 \x01(\tR\rcontainerName\x12 \n" +
 	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1b\n" +
-	"\x06active\x18\a \x01(\bH\x00R\x06active\x88\x01\x01B\t\n" +
-	"\a_active\"s\n" +
+	"\x06active\x18\a \x01(\bH\x00R\x06active\x88\x01\x01\x12&\n" +
+	"\fin_interface\x18\b \x01(\tH\x01R\vinInterface\x88\x01\x01\x12$\n" +
+	"\vsnat_source\x18\t \x01(\tH\x02R\n" +
+	"snatSource\x88\x01\x01B\t\n" +
+	"\a_activeB\x0f\n" +
+	"\r_in_interfaceB\x0e\n" +
+	"\f_snat_source\"s\n" +
 	"\x1eUpdatePassthroughRouteResponse\x127\n" +
 	"\x05route\x18\x01 \x01(\v2!.containarium.v1.PassthroughRouteR\x05route\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xfc\x01\n" +
+	"\x1cAdoptPassthroughRouteRequest\x12#\n" +
+	"\rexternal_port\x18\x01 \x01(\x05R\fexternalPort\x12:\n" +
+	"\bprotocol\x18\x02 \x01(\x0e2\x1e.containarium.v1.RouteProtocolR\bprotocol\x12%\n" +
+	"\x0econtainer_name\x18\x03 \x01(\tR\rcontainerName\x122\n" +
+	"\x15allow_external_target\x18\x04 \x01(\bR\x13allowExternalTarget\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"r\n" +
+	"\x1dAdoptPassthroughRouteResponse\x127\n" +
+	"\x05route\x18\x01 \x01(\v2!.containarium.v1.PassthroughRouteR\x05route\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"Y\n" +
 	"\tDNSRecord\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
@@ -2979,7 +3819,35 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\x16StopEgressProxyRequest\x12%\n" +
 	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\"3\n" +
 	"\x17StopEgressProxyResponse\x12\x18\n" +
-	"\astopped\x18\x01 \x01(\bR\astopped*Y\n" +
+	"\astopped\x18\x01 \x01(\bR\astopped\"\xc2\x02\n" +
+	"\x0eBandwidthLimit\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12!\n" +
+	"\fingress_mbps\x18\x02 \x01(\x05R\vingressMbps\x12\x1f\n" +
+	"\vegress_mbps\x18\x03 \x01(\x05R\n" +
+	"egressMbps\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\aapplied\x18\a \x01(\bR\aapplied\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb0\x01\n" +
+	"\x18SetBandwidthLimitRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12!\n" +
+	"\fingress_mbps\x18\x02 \x01(\x05R\vingressMbps\x12\x1f\n" +
+	"\vegress_mbps\x18\x03 \x01(\x05R\n" +
+	"egressMbps\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x03R\x0fdurationSeconds\"R\n" +
+	"\x19SetBandwidthLimitResponse\x125\n" +
+	"\x05limit\x18\x01 \x01(\v2\x1f.containarium.v1.BandwidthLimitR\x05limit\"\x1c\n" +
+	"\x1aListBandwidthLimitsRequest\"V\n" +
+	"\x1bListBandwidthLimitsResponse\x127\n" +
+	"\x06limits\x18\x01 \x03(\v2\x1f.containarium.v1.BandwidthLimitR\x06limits\"C\n" +
+	"\x1aClearBandwidthLimitRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\"7\n" +
+	"\x1bClearBandwidthLimitResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage*Y\n" +
 	"\tRouteType\x12\x1a\n" +
 	"\x16ROUTE_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10ROUTE_TYPE_PROXY\x10\x01\x12\x1a\n" +
@@ -3001,7 +3869,12 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\x19ACL_PRESET_FULL_ISOLATION\x10\x01\x12\x18\n" +
 	"\x14ACL_PRESET_HTTP_ONLY\x10\x02\x12\x19\n" +
 	"\x15ACL_PRESET_PERMISSIVE\x10\x03\x12\x15\n" +
-	"\x11ACL_PRESET_CUSTOM\x10\x042\xe9 \n" +
+	"\x11ACL_PRESET_CUSTOM\x10\x04*\x96\x01\n" +
+	"\x11CertificateStatus\x12\"\n" +
+	"\x1eCERTIFICATE_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aCERTIFICATE_STATUS_PENDING\x10\x01\x12\x1d\n" +
+	"\x19CERTIFICATE_STATUS_ISSUED\x10\x02\x12\x1e\n" +
+	"\x1aCERTIFICATE_STATUS_EXPIRED\x10\x032\x81,\n" +
 	"\x0eNetworkService\x12\xdd\x01\n" +
 	"\tGetRoutes\x12!.containarium.v1.GetRoutesRequest\x1a\".containarium.v1.GetRoutesResponse\"\x88\x01\x92Ak\n" +
 	"\aNetwork\x12\x11List proxy routes\x1aMReturns all DNS/domain to container mappings configured in the reverse proxy.\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/network/routes\x12\xd0\x01\n" +
@@ -3020,7 +3893,9 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\x16DeletePassthroughRoute\x12..containarium.v1.DeletePassthroughRouteRequest\x1a/.containarium.v1.DeletePassthroughRouteResponse\"\x8c\x01\x92AZ\n" +
 	"\aNetwork\x12\x18Delete passthrough route\x1a5Removes a TCP/UDP port forwarding rule from iptables.\x82\xd3\xe4\x93\x02)*'/v1/network/passthrough/{external_port}\x12\xc6\x02\n" +
 	"\x16UpdatePassthroughRoute\x12..containarium.v1.UpdatePassthroughRouteRequest\x1a/.containarium.v1.UpdatePassthroughRouteResponse\"\xca\x01\x92A\x94\x01\n" +
-	"\aNetwork\x12\x18Update passthrough route\x1aoUpdates an existing TCP/UDP port forwarding rule. Can be used to enable/disable the route or change the target.\x82\xd3\xe4\x93\x02,:\x01*\x1a'/v1/network/passthrough/{external_port}\x12\x8a\x02\n" +
+	"\aNetwork\x12\x18Update passthrough route\x1aoUpdates an existing TCP/UDP port forwarding rule. Can be used to enable/disable the route or change the target.\x82\xd3\xe4\x93\x02,:\x01*\x1a'/v1/network/passthrough/{external_port}\x12\x93\x04\n" +
+	"\x15AdoptPassthroughRoute\x12-.containarium.v1.AdoptPassthroughRouteRequest\x1a..containarium.v1.AdoptPassthroughRouteResponse\"\x9a\x03\x92A\xde\x02\n" +
+	"\aNetwork\x12\x17Adopt passthrough route\x1a\xb9\x02Finds the unmanaged PREROUTING DNAT rule on the port, replaces it with the equivalent tagged rule without interrupting forwarding, and records it in the route registry, after which it is managed like any passthrough route. The target must be a known container's IP unless allow_external_target is set. Admin only.\x82\xd3\xe4\x93\x022:\x01*\"-/v1/network/passthrough/{external_port}/adopt\x12\x8a\x02\n" +
 	"\x0fGetContainerACL\x12'.containarium.v1.GetContainerACLRequest\x1a(.containarium.v1.GetContainerACLResponse\"\xa3\x01\x92A{\n" +
 	"\aNetwork\x12\x1cGet container firewall rules\x1aRReturns the network ACL (firewall rules) configured for a user's DevBox container.\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/containers/{username}/acl\x12\xb7\x02\n" +
 	"\x12UpdateContainerACL\x12*.containarium.v1.UpdateContainerACLRequest\x1a+.containarium.v1.UpdateContainerACLResponse\"\xc7\x01\x92A\x9b\x01\n" +
@@ -3032,7 +3907,13 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\x10StartEgressProxy\x12(.containarium.v1.StartEgressProxyRequest\x1a).containarium.v1.StartEgressProxyResponse\"\xfc\x01\x92A\xd5\x01\n" +
 	"\aNetwork\x12\x1dStart egress-via-client proxy\x1a\xaa\x01Bridges a host-loopback SOCKS (exposed by the caller via ssh -R) into a box's network namespace via a source-restricted relay, so the box egresses with the operator's IP.\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/network/egress-proxy\x12\x84\x02\n" +
 	"\x0fStopEgressProxy\x12'.containarium.v1.StopEgressProxyRequest\x1a(.containarium.v1.StopEgressProxyResponse\"\x9d\x01\x92Ai\n" +
-	"\aNetwork\x12\x1cStop egress-via-client proxy\x1a@Tears down the source-restricted egress relay for the named box.\x82\xd3\xe4\x93\x02+*)/v1/network/egress-proxy/{container_name}BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
+	"\aNetwork\x12\x1cStop egress-via-client proxy\x1a@Tears down the source-restricted egress relay for the named box.\x82\xd3\xe4\x93\x02+*)/v1/network/egress-proxy/{container_name}\x12\xd3\x02\n" +
+	"\x11SetBandwidthLimit\x12).containarium.v1.SetBandwidthLimitRequest\x1a*.containarium.v1.SetBandwidthLimitResponse\"\xe6\x01\x92A\xbb\x01\n" +
+	"\aNetwork\x12\x13Set bandwidth limit\x1a\x9a\x01Caps a container's bandwidth with tc on its host veth. The limit is persisted and re-applied whenever the container starts; a duration makes it temporary.\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/network/bandwidth-limits\x12\xa1\x02\n" +
+	"\x13ListBandwidthLimits\x12+.containarium.v1.ListBandwidthLimitsRequest\x1a,.containarium.v1.ListBandwidthLimitsResponse\"\xae\x01\x92A\x86\x01\n" +
+	"\aNetwork\x12\x15List bandwidth limits\x1adReturns every container bandwidth limit that has not expired, and whether each is currently applied.\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/network/bandwidth-limits\x12\x85\x02\n" +
+	"\x13ClearBandwidthLimit\x12+.containarium.v1.ClearBandwidthLimitRequest\x1a,.containarium.v1.ClearBandwidthLimitResponse\"\x92\x01\x92AZ\n" +
+	"\aNetwork\x12\x15Clear bandwidth limit\x1a8Removes a container's bandwidth limit and its tc qdiscs.\x82\xd3\xe4\x93\x02/*-/v1/network/bandwidth-limits/{container_name}BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
 
 var (
 	file_containarium_v1_network_proto_rawDescOnce sync.Once
@@ -3046,119 +3927,149 @@ func file_containarium_v1_network_proto_rawDescGZIP() []byte {
 	return file_containarium_v1_network_proto_rawDescData
 }
 
-var file_containarium_v1_network_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_containarium_v1_network_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_containarium_v1_network_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_containarium_v1_network_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_containarium_v1_network_proto_goTypes = []any{
 	(RouteType)(0),                         // 0: containarium.v1.RouteType
 	(RouteProtocol)(0),                     // 1: containarium.v1.RouteProtocol
 	(ACLAction)(0),                         // 2: containarium.v1.ACLAction
 	(ACLPreset)(0),                         // 3: containarium.v1.ACLPreset
-	(*ACLRule)(nil),                        // 4: containarium.v1.ACLRule
-	(*NetworkACL)(nil),                     // 5: containarium.v1.NetworkACL
-	(*ProxyRoute)(nil),                     // 6: containarium.v1.ProxyRoute
-	(*PassthroughRoute)(nil),               // 7: containarium.v1.PassthroughRoute
-	(*NetworkNode)(nil),                    // 8: containarium.v1.NetworkNode
-	(*NetworkEdge)(nil),                    // 9: containarium.v1.NetworkEdge
-	(*NetworkTopology)(nil),                // 10: containarium.v1.NetworkTopology
-	(*GetRoutesRequest)(nil),               // 11: containarium.v1.GetRoutesRequest
-	(*GetRoutesResponse)(nil),              // 12: containarium.v1.GetRoutesResponse
-	(*AddRouteRequest)(nil),                // 13: containarium.v1.AddRouteRequest
-	(*AddRouteResponse)(nil),               // 14: containarium.v1.AddRouteResponse
-	(*UpdateRouteRequest)(nil),             // 15: containarium.v1.UpdateRouteRequest
-	(*UpdateRouteResponse)(nil),            // 16: containarium.v1.UpdateRouteResponse
-	(*DeleteRouteRequest)(nil),             // 17: containarium.v1.DeleteRouteRequest
-	(*DeleteRouteResponse)(nil),            // 18: containarium.v1.DeleteRouteResponse
-	(*ListPassthroughRoutesRequest)(nil),   // 19: containarium.v1.ListPassthroughRoutesRequest
-	(*ListPassthroughRoutesResponse)(nil),  // 20: containarium.v1.ListPassthroughRoutesResponse
-	(*AddPassthroughRouteRequest)(nil),     // 21: containarium.v1.AddPassthroughRouteRequest
-	(*AddPassthroughRouteResponse)(nil),    // 22: containarium.v1.AddPassthroughRouteResponse
-	(*DeletePassthroughRouteRequest)(nil),  // 23: containarium.v1.DeletePassthroughRouteRequest
-	(*DeletePassthroughRouteResponse)(nil), // 24: containarium.v1.DeletePassthroughRouteResponse
-	(*UpdatePassthroughRouteRequest)(nil),  // 25: containarium.v1.UpdatePassthroughRouteRequest
-	(*UpdatePassthroughRouteResponse)(nil), // 26: containarium.v1.UpdatePassthroughRouteResponse
-	(*DNSRecord)(nil),                      // 27: containarium.v1.DNSRecord
-	(*ListDNSRecordsRequest)(nil),          // 28: containarium.v1.ListDNSRecordsRequest
-	(*ListDNSRecordsResponse)(nil),         // 29: containarium.v1.ListDNSRecordsResponse
-	(*GetContainerACLRequest)(nil),         // 30: containarium.v1.GetContainerACLRequest
-	(*GetContainerACLResponse)(nil),        // 31: containarium.v1.GetContainerACLResponse
-	(*UpdateContainerACLRequest)(nil),      // 32: containarium.v1.UpdateContainerACLRequest
-	(*UpdateContainerACLResponse)(nil),     // 33: containarium.v1.UpdateContainerACLResponse
-	(*GetNetworkTopologyRequest)(nil),      // 34: containarium.v1.GetNetworkTopologyRequest
-	(*GetNetworkTopologyResponse)(nil),     // 35: containarium.v1.GetNetworkTopologyResponse
-	(*ListACLPresetsRequest)(nil),          // 36: containarium.v1.ListACLPresetsRequest
-	(*ACLPresetInfo)(nil),                  // 37: containarium.v1.ACLPresetInfo
-	(*ListACLPresetsResponse)(nil),         // 38: containarium.v1.ListACLPresetsResponse
-	(*StartEgressProxyRequest)(nil),        // 39: containarium.v1.StartEgressProxyRequest
-	(*StartEgressProxyResponse)(nil),       // 40: containarium.v1.StartEgressProxyResponse
-	(*StopEgressProxyRequest)(nil),         // 41: containarium.v1.StopEgressProxyRequest
-	(*StopEgressProxyResponse)(nil),        // 42: containarium.v1.StopEgressProxyResponse
+	(CertificateStatus)(0),                 // 4: containarium.v1.CertificateStatus
+	(*ACLRule)(nil),                        // 5: containarium.v1.ACLRule
+	(*NetworkACL)(nil),                     // 6: containarium.v1.NetworkACL
+	(*ProxyRoute)(nil),                     // 7: containarium.v1.ProxyRoute
+	(*RouteCertificate)(nil),               // 8: containarium.v1.RouteCertificate
+	(*PassthroughRoute)(nil),               // 9: containarium.v1.PassthroughRoute
+	(*NetworkNode)(nil),                    // 10: containarium.v1.NetworkNode
+	(*NetworkEdge)(nil),                    // 11: containarium.v1.NetworkEdge
+	(*NetworkTopology)(nil),                // 12: containarium.v1.NetworkTopology
+	(*GetRoutesRequest)(nil),               // 13: containarium.v1.GetRoutesRequest
+	(*GetRoutesResponse)(nil),              // 14: containarium.v1.GetRoutesResponse
+	(*AddRouteRequest)(nil),                // 15: containarium.v1.AddRouteRequest
+	(*AddRouteResponse)(nil),               // 16: containarium.v1.AddRouteResponse
+	(*UpdateRouteRequest)(nil),             // 17: containarium.v1.UpdateRouteRequest
+	(*UpdateRouteResponse)(nil),            // 18: containarium.v1.UpdateRouteResponse
+	(*DeleteRouteRequest)(nil),             // 19: containarium.v1.DeleteRouteRequest
+	(*DeleteRouteResponse)(nil),            // 20: containarium.v1.DeleteRouteResponse
+	(*ListPassthroughRoutesRequest)(nil),   // 21: containarium.v1.ListPassthroughRoutesRequest
+	(*ListPassthroughRoutesResponse)(nil),  // 22: containarium.v1.ListPassthroughRoutesResponse
+	(*AddPassthroughRouteRequest)(nil),     // 23: containarium.v1.AddPassthroughRouteRequest
+	(*AddPassthroughRouteResponse)(nil),    // 24: containarium.v1.AddPassthroughRouteResponse
+	(*DeletePassthroughRouteRequest)(nil),  // 25: containarium.v1.DeletePassthroughRouteRequest
+	(*DeletePassthroughRouteResponse)(nil), // 26: containarium.v1.DeletePassthroughRouteResponse
+	(*UpdatePassthroughRouteRequest)(nil),  // 27: containarium.v1.UpdatePassthroughRouteRequest
+	(*UpdatePassthroughRouteResponse)(nil), // 28: containarium.v1.UpdatePassthroughRouteResponse
+	(*AdoptPassthroughRouteRequest)(nil),   // 29: containarium.v1.AdoptPassthroughRouteRequest
+	(*AdoptPassthroughRouteResponse)(nil),  // 30: containarium.v1.AdoptPassthroughRouteResponse
+	(*DNSRecord)(nil),                      // 31: containarium.v1.DNSRecord
+	(*ListDNSRecordsRequest)(nil),          // 32: containarium.v1.ListDNSRecordsRequest
+	(*ListDNSRecordsResponse)(nil),         // 33: containarium.v1.ListDNSRecordsResponse
+	(*GetContainerACLRequest)(nil),         // 34: containarium.v1.GetContainerACLRequest
+	(*GetContainerACLResponse)(nil),        // 35: containarium.v1.GetContainerACLResponse
+	(*UpdateContainerACLRequest)(nil),      // 36: containarium.v1.UpdateContainerACLRequest
+	(*UpdateContainerACLResponse)(nil),     // 37: containarium.v1.UpdateContainerACLResponse
+	(*GetNetworkTopologyRequest)(nil),      // 38: containarium.v1.GetNetworkTopologyRequest
+	(*GetNetworkTopologyResponse)(nil),     // 39: containarium.v1.GetNetworkTopologyResponse
+	(*ListACLPresetsRequest)(nil),          // 40: containarium.v1.ListACLPresetsRequest
+	(*ACLPresetInfo)(nil),                  // 41: containarium.v1.ACLPresetInfo
+	(*ListACLPresetsResponse)(nil),         // 42: containarium.v1.ListACLPresetsResponse
+	(*StartEgressProxyRequest)(nil),        // 43: containarium.v1.StartEgressProxyRequest
+	(*StartEgressProxyResponse)(nil),       // 44: containarium.v1.StartEgressProxyResponse
+	(*StopEgressProxyRequest)(nil),         // 45: containarium.v1.StopEgressProxyRequest
+	(*StopEgressProxyResponse)(nil),        // 46: containarium.v1.StopEgressProxyResponse
+	(*BandwidthLimit)(nil),                 // 47: containarium.v1.BandwidthLimit
+	(*SetBandwidthLimitRequest)(nil),       // 48: containarium.v1.SetBandwidthLimitRequest
+	(*SetBandwidthLimitResponse)(nil),      // 49: containarium.v1.SetBandwidthLimitResponse
+	(*ListBandwidthLimitsRequest)(nil),     // 50: containarium.v1.ListBandwidthLimitsRequest
+	(*ListBandwidthLimitsResponse)(nil),    // 51: containarium.v1.ListBandwidthLimitsResponse
+	(*ClearBandwidthLimitRequest)(nil),     // 52: containarium.v1.ClearBandwidthLimitRequest
+	(*ClearBandwidthLimitResponse)(nil),    // 53: containarium.v1.ClearBandwidthLimitResponse
+	(*timestamppb.Timestamp)(nil),          // 54: google.protobuf.Timestamp
 }
 var file_containarium_v1_network_proto_depIdxs = []int32{
 	2,  // 0: containarium.v1.ACLRule.action:type_name -> containarium.v1.ACLAction
 	3,  // 1: containarium.v1.NetworkACL.preset:type_name -> containarium.v1.ACLPreset
-	4,  // 2: containarium.v1.NetworkACL.ingress_rules:type_name -> containarium.v1.ACLRule
-	4,  // 3: containarium.v1.NetworkACL.egress_rules:type_name -> containarium.v1.ACLRule
+	5,  // 2: containarium.v1.NetworkACL.ingress_rules:type_name -> containarium.v1.ACLRule
+	5,  // 3: containarium.v1.NetworkACL.egress_rules:type_name -> containarium.v1.ACLRule
 	1,  // 4: containarium.v1.ProxyRoute.protocol:type_name -> containarium.v1.RouteProtocol
-	1,  // 5: containarium.v1.PassthroughRoute.protocol:type_name -> containarium.v1.RouteProtocol
-	8,  // 6: containarium.v1.NetworkTopology.nodes:type_name -> containarium.v1.NetworkNode
-	9,  // 7: containarium.v1.NetworkTopology.edges:type_name -> containarium.v1.NetworkEdge
-	6,  // 8: containarium.v1.GetRoutesResponse.routes:type_name -> containarium.v1.ProxyRoute
-	1,  // 9: containarium.v1.AddRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
-	6,  // 10: containarium.v1.AddRouteResponse.route:type_name -> containarium.v1.ProxyRoute
-	1,  // 11: containarium.v1.UpdateRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
-	6,  // 12: containarium.v1.UpdateRouteResponse.route:type_name -> containarium.v1.ProxyRoute
-	7,  // 13: containarium.v1.ListPassthroughRoutesResponse.routes:type_name -> containarium.v1.PassthroughRoute
-	1,  // 14: containarium.v1.AddPassthroughRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
-	7,  // 15: containarium.v1.AddPassthroughRouteResponse.route:type_name -> containarium.v1.PassthroughRoute
-	1,  // 16: containarium.v1.DeletePassthroughRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
-	1,  // 17: containarium.v1.UpdatePassthroughRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
-	7,  // 18: containarium.v1.UpdatePassthroughRouteResponse.route:type_name -> containarium.v1.PassthroughRoute
-	27, // 19: containarium.v1.ListDNSRecordsResponse.records:type_name -> containarium.v1.DNSRecord
-	5,  // 20: containarium.v1.GetContainerACLResponse.acl:type_name -> containarium.v1.NetworkACL
-	3,  // 21: containarium.v1.UpdateContainerACLRequest.preset:type_name -> containarium.v1.ACLPreset
-	4,  // 22: containarium.v1.UpdateContainerACLRequest.ingress_rules:type_name -> containarium.v1.ACLRule
-	4,  // 23: containarium.v1.UpdateContainerACLRequest.egress_rules:type_name -> containarium.v1.ACLRule
-	5,  // 24: containarium.v1.UpdateContainerACLResponse.acl:type_name -> containarium.v1.NetworkACL
-	10, // 25: containarium.v1.GetNetworkTopologyResponse.topology:type_name -> containarium.v1.NetworkTopology
-	3,  // 26: containarium.v1.ACLPresetInfo.preset:type_name -> containarium.v1.ACLPreset
-	4,  // 27: containarium.v1.ACLPresetInfo.default_ingress_rules:type_name -> containarium.v1.ACLRule
-	4,  // 28: containarium.v1.ACLPresetInfo.default_egress_rules:type_name -> containarium.v1.ACLRule
-	37, // 29: containarium.v1.ListACLPresetsResponse.presets:type_name -> containarium.v1.ACLPresetInfo
-	11, // 30: containarium.v1.NetworkService.GetRoutes:input_type -> containarium.v1.GetRoutesRequest
-	13, // 31: containarium.v1.NetworkService.AddRoute:input_type -> containarium.v1.AddRouteRequest
-	15, // 32: containarium.v1.NetworkService.UpdateRoute:input_type -> containarium.v1.UpdateRouteRequest
-	17, // 33: containarium.v1.NetworkService.DeleteRoute:input_type -> containarium.v1.DeleteRouteRequest
-	28, // 34: containarium.v1.NetworkService.ListDNSRecords:input_type -> containarium.v1.ListDNSRecordsRequest
-	19, // 35: containarium.v1.NetworkService.ListPassthroughRoutes:input_type -> containarium.v1.ListPassthroughRoutesRequest
-	21, // 36: containarium.v1.NetworkService.AddPassthroughRoute:input_type -> containarium.v1.AddPassthroughRouteRequest
-	23, // 37: containarium.v1.NetworkService.DeletePassthroughRoute:input_type -> containarium.v1.DeletePassthroughRouteRequest
-	25, // 38: containarium.v1.NetworkService.UpdatePassthroughRoute:input_type -> containarium.v1.UpdatePassthroughRouteRequest
-	30, // 39: containarium.v1.NetworkService.GetContainerACL:input_type -> containarium.v1.GetContainerACLRequest
-	32, // 40: containarium.v1.NetworkService.UpdateContainerACL:input_type -> containarium.v1.UpdateContainerACLRequest
-	34, // 41: containarium.v1.NetworkService.GetNetworkTopology:input_type -> containarium.v1.GetNetworkTopologyRequest
-	36, // 42: containarium.v1.NetworkService.ListACLPresets:input_type -> containarium.v1.ListACLPresetsRequest
-	39, // 43: containarium.v1.NetworkService.StartEgressProxy:input_type -> containarium.v1.StartEgressProxyRequest
-	41, // 44: containarium.v1.NetworkService.StopEgressProxy:input_type -> containarium.v1.StopEgressProxyRequest
-	12, // 45: containarium.v1.NetworkService.GetRoutes:output_type -> containarium.v1.GetRoutesResponse
-	14, // 46: containarium.v1.NetworkService.AddRoute:output_type -> containarium.v1.AddRouteResponse
-	16, // 47: containarium.v1.NetworkService.UpdateRoute:output_type -> containarium.v1.UpdateRouteResponse
-	18, // 48: containarium.v1.NetworkService.DeleteRoute:output_type -> containarium.v1.DeleteRouteResponse
-	29, // 49: containarium.v1.NetworkService.ListDNSRecords:output_type -> containarium.v1.ListDNSRecordsResponse
-	20, // 50: containarium.v1.NetworkService.ListPassthroughRoutes:output_type -> containarium.v1.ListPassthroughRoutesResponse
-	22, // 51: containarium.v1.NetworkService.AddPassthroughRoute:output_type -> containarium.v1.AddPassthroughRouteResponse
-	24, // 52: containarium.v1.NetworkService.DeletePassthroughRoute:output_type -> containarium.v1.DeletePassthroughRouteResponse
-	26, // 53: containarium.v1.NetworkService.UpdatePassthroughRoute:output_type -> containarium.v1.UpdatePassthroughRouteResponse
-	31, // 54: containarium.v1.NetworkService.GetContainerACL:output_type -> containarium.v1.GetContainerACLResponse
-	33, // 55: containarium.v1.NetworkService.UpdateContainerACL:output_type -> containarium.v1.UpdateContainerACLResponse
-	35, // 56: containarium.v1.NetworkService.GetNetworkTopology:output_type -> containarium.v1.GetNetworkTopologyResponse
-	38, // 57: containarium.v1.NetworkService.ListACLPresets:output_type -> containarium.v1.ListACLPresetsResponse
-	40, // 58: containarium.v1.NetworkService.StartEgressProxy:output_type -> containarium.v1.StartEgressProxyResponse
-	42, // 59: containarium.v1.NetworkService.StopEgressProxy:output_type -> containarium.v1.StopEgressProxyResponse
-	45, // [45:60] is the sub-list for method output_type
-	30, // [30:45] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	8,  // 5: containarium.v1.ProxyRoute.certificate:type_name -> containarium.v1.RouteCertificate
+	4,  // 6: containarium.v1.RouteCertificate.status:type_name -> containarium.v1.CertificateStatus
+	54, // 7: containarium.v1.RouteCertificate.not_after:type_name -> google.protobuf.Timestamp
+	1,  // 8: containarium.v1.PassthroughRoute.protocol:type_name -> containarium.v1.RouteProtocol
+	10, // 9: containarium.v1.NetworkTopology.nodes:type_name -> containarium.v1.NetworkNode
+	11, // 10: containarium.v1.NetworkTopology.edges:type_name -> containarium.v1.NetworkEdge
+	7,  // 11: containarium.v1.GetRoutesResponse.routes:type_name -> containarium.v1.ProxyRoute
+	1,  // 12: containarium.v1.AddRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
+	7,  // 13: containarium.v1.AddRouteResponse.route:type_name -> containarium.v1.ProxyRoute
+	1,  // 14: containarium.v1.UpdateRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
+	7,  // 15: containarium.v1.UpdateRouteResponse.route:type_name -> containarium.v1.ProxyRoute
+	9,  // 16: containarium.v1.ListPassthroughRoutesResponse.routes:type_name -> containarium.v1.PassthroughRoute
+	1,  // 17: containarium.v1.AddPassthroughRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
+	9,  // 18: containarium.v1.AddPassthroughRouteResponse.route:type_name -> containarium.v1.PassthroughRoute
+	1,  // 19: containarium.v1.DeletePassthroughRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
+	9,  // 20: containarium.v1.DeletePassthroughRouteResponse.route:type_name -> containarium.v1.PassthroughRoute
+	1,  // 21: containarium.v1.UpdatePassthroughRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
+	9,  // 22: containarium.v1.UpdatePassthroughRouteResponse.route:type_name -> containarium.v1.PassthroughRoute
+	1,  // 23: containarium.v1.AdoptPassthroughRouteRequest.protocol:type_name -> containarium.v1.RouteProtocol
+	9,  // 24: containarium.v1.AdoptPassthroughRouteResponse.route:type_name -> containarium.v1.PassthroughRoute
+	31, // 25: containarium.v1.ListDNSRecordsResponse.records:type_name -> containarium.v1.DNSRecord
+	6,  // 26: containarium.v1.GetContainerACLResponse.acl:type_name -> containarium.v1.NetworkACL
+	3,  // 27: containarium.v1.UpdateContainerACLRequest.preset:type_name -> containarium.v1.ACLPreset
+	5,  // 28: containarium.v1.UpdateContainerACLRequest.ingress_rules:type_name -> containarium.v1.ACLRule
+	5,  // 29: containarium.v1.UpdateContainerACLRequest.egress_rules:type_name -> containarium.v1.ACLRule
+	6,  // 30: containarium.v1.UpdateContainerACLResponse.acl:type_name -> containarium.v1.NetworkACL
+	12, // 31: containarium.v1.GetNetworkTopologyResponse.topology:type_name -> containarium.v1.NetworkTopology
+	3,  // 32: containarium.v1.ACLPresetInfo.preset:type_name -> containarium.v1.ACLPreset
+	5,  // 33: containarium.v1.ACLPresetInfo.default_ingress_rules:type_name -> containarium.v1.ACLRule
+	5,  // 34: containarium.v1.ACLPresetInfo.default_egress_rules:type_name -> containarium.v1.ACLRule
+	41, // 35: containarium.v1.ListACLPresetsResponse.presets:type_name -> containarium.v1.ACLPresetInfo
+	54, // 36: containarium.v1.BandwidthLimit.expires_at:type_name -> google.protobuf.Timestamp
+	54, // 37: containarium.v1.BandwidthLimit.updated_at:type_name -> google.protobuf.Timestamp
+	47, // 38: containarium.v1.SetBandwidthLimitResponse.limit:type_name -> containarium.v1.BandwidthLimit
+	47, // 39: containarium.v1.ListBandwidthLimitsResponse.limits:type_name -> containarium.v1.BandwidthLimit
+	13, // 40: containarium.v1.NetworkService.GetRoutes:input_type -> containarium.v1.GetRoutesRequest
+	15, // 41: containarium.v1.NetworkService.AddRoute:input_type -> containarium.v1.AddRouteRequest
+	17, // 42: containarium.v1.NetworkService.UpdateRoute:input_type -> containarium.v1.UpdateRouteRequest
+	19, // 43: containarium.v1.NetworkService.DeleteRoute:input_type -> containarium.v1.DeleteRouteRequest
+	32, // 44: containarium.v1.NetworkService.ListDNSRecords:input_type -> containarium.v1.ListDNSRecordsRequest
+	21, // 45: containarium.v1.NetworkService.ListPassthroughRoutes:input_type -> containarium.v1.ListPassthroughRoutesRequest
+	23, // 46: containarium.v1.NetworkService.AddPassthroughRoute:input_type -> containarium.v1.AddPassthroughRouteRequest
+	25, // 47: containarium.v1.NetworkService.DeletePassthroughRoute:input_type -> containarium.v1.DeletePassthroughRouteRequest
+	27, // 48: containarium.v1.NetworkService.UpdatePassthroughRoute:input_type -> containarium.v1.UpdatePassthroughRouteRequest
+	29, // 49: containarium.v1.NetworkService.AdoptPassthroughRoute:input_type -> containarium.v1.AdoptPassthroughRouteRequest
+	34, // 50: containarium.v1.NetworkService.GetContainerACL:input_type -> containarium.v1.GetContainerACLRequest
+	36, // 51: containarium.v1.NetworkService.UpdateContainerACL:input_type -> containarium.v1.UpdateContainerACLRequest
+	38, // 52: containarium.v1.NetworkService.GetNetworkTopology:input_type -> containarium.v1.GetNetworkTopologyRequest
+	40, // 53: containarium.v1.NetworkService.ListACLPresets:input_type -> containarium.v1.ListACLPresetsRequest
+	43, // 54: containarium.v1.NetworkService.StartEgressProxy:input_type -> containarium.v1.StartEgressProxyRequest
+	45, // 55: containarium.v1.NetworkService.StopEgressProxy:input_type -> containarium.v1.StopEgressProxyRequest
+	48, // 56: containarium.v1.NetworkService.SetBandwidthLimit:input_type -> containarium.v1.SetBandwidthLimitRequest
+	50, // 57: containarium.v1.NetworkService.ListBandwidthLimits:input_type -> containarium.v1.ListBandwidthLimitsRequest
+	52, // 58: containarium.v1.NetworkService.ClearBandwidthLimit:input_type -> containarium.v1.ClearBandwidthLimitRequest
+	14, // 59: containarium.v1.NetworkService.GetRoutes:output_type -> containarium.v1.GetRoutesResponse
+	16, // 60: containarium.v1.NetworkService.AddRoute:output_type -> containarium.v1.AddRouteResponse
+	18, // 61: containarium.v1.NetworkService.UpdateRoute:output_type -> containarium.v1.UpdateRouteResponse
+	20, // 62: containarium.v1.NetworkService.DeleteRoute:output_type -> containarium.v1.DeleteRouteResponse
+	33, // 63: containarium.v1.NetworkService.ListDNSRecords:output_type -> containarium.v1.ListDNSRecordsResponse
+	22, // 64: containarium.v1.NetworkService.ListPassthroughRoutes:output_type -> containarium.v1.ListPassthroughRoutesResponse
+	24, // 65: containarium.v1.NetworkService.AddPassthroughRoute:output_type -> containarium.v1.AddPassthroughRouteResponse
+	26, // 66: containarium.v1.NetworkService.DeletePassthroughRoute:output_type -> containarium.v1.DeletePassthroughRouteResponse
+	28, // 67: containarium.v1.NetworkService.UpdatePassthroughRoute:output_type -> containarium.v1.UpdatePassthroughRouteResponse
+	30, // 68: containarium.v1.NetworkService.AdoptPassthroughRoute:output_type -> containarium.v1.AdoptPassthroughRouteResponse
+	35, // 69: containarium.v1.NetworkService.GetContainerACL:output_type -> containarium.v1.GetContainerACLResponse
+	37, // 70: containarium.v1.NetworkService.UpdateContainerACL:output_type -> containarium.v1.UpdateContainerACLResponse
+	39, // 71: containarium.v1.NetworkService.GetNetworkTopology:output_type -> containarium.v1.GetNetworkTopologyResponse
+	42, // 72: containarium.v1.NetworkService.ListACLPresets:output_type -> containarium.v1.ListACLPresetsResponse
+	44, // 73: containarium.v1.NetworkService.StartEgressProxy:output_type -> containarium.v1.StartEgressProxyResponse
+	46, // 74: containarium.v1.NetworkService.StopEgressProxy:output_type -> containarium.v1.StopEgressProxyResponse
+	49, // 75: containarium.v1.NetworkService.SetBandwidthLimit:output_type -> containarium.v1.SetBandwidthLimitResponse
+	51, // 76: containarium.v1.NetworkService.ListBandwidthLimits:output_type -> containarium.v1.ListBandwidthLimitsResponse
+	53, // 77: containarium.v1.NetworkService.ClearBandwidthLimit:output_type -> containarium.v1.ClearBandwidthLimitResponse
+	59, // [59:78] is the sub-list for method output_type
+	40, // [40:59] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_network_proto_init() }
@@ -3166,15 +4077,15 @@ func file_containarium_v1_network_proto_init() {
 	if File_containarium_v1_network_proto != nil {
 		return
 	}
-	file_containarium_v1_network_proto_msgTypes[11].OneofWrappers = []any{}
-	file_containarium_v1_network_proto_msgTypes[21].OneofWrappers = []any{}
+	file_containarium_v1_network_proto_msgTypes[12].OneofWrappers = []any{}
+	file_containarium_v1_network_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_network_proto_rawDesc), len(file_containarium_v1_network_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   39,
+			NumEnums:      5,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -28,12 +28,16 @@ const (
 	NetworkService_AddPassthroughRoute_FullMethodName    = "/containarium.v1.NetworkService/AddPassthroughRoute"
 	NetworkService_DeletePassthroughRoute_FullMethodName = "/containarium.v1.NetworkService/DeletePassthroughRoute"
 	NetworkService_UpdatePassthroughRoute_FullMethodName = "/containarium.v1.NetworkService/UpdatePassthroughRoute"
+	NetworkService_AdoptPassthroughRoute_FullMethodName  = "/containarium.v1.NetworkService/AdoptPassthroughRoute"
 	NetworkService_GetContainerACL_FullMethodName        = "/containarium.v1.NetworkService/GetContainerACL"
 	NetworkService_UpdateContainerACL_FullMethodName     = "/containarium.v1.NetworkService/UpdateContainerACL"
 	NetworkService_GetNetworkTopology_FullMethodName     = "/containarium.v1.NetworkService/GetNetworkTopology"
 	NetworkService_ListACLPresets_FullMethodName         = "/containarium.v1.NetworkService/ListACLPresets"
 	NetworkService_StartEgressProxy_FullMethodName       = "/containarium.v1.NetworkService/StartEgressProxy"
 	NetworkService_StopEgressProxy_FullMethodName        = "/containarium.v1.NetworkService/StopEgressProxy"
+	NetworkService_SetBandwidthLimit_FullMethodName      = "/containarium.v1.NetworkService/SetBandwidthLimit"
+	NetworkService_ListBandwidthLimits_FullMethodName    = "/containarium.v1.NetworkService/ListBandwidthLimits"
+	NetworkService_ClearBandwidthLimit_FullMethodName    = "/containarium.v1.NetworkService/ClearBandwidthLimit"
 )
 
 // NetworkServiceClient is the client API for NetworkService service.
@@ -60,6 +64,9 @@ type NetworkServiceClient interface {
 	DeletePassthroughRoute(ctx context.Context, in *DeletePassthroughRouteRequest, opts ...grpc.CallOption) (*DeletePassthroughRouteResponse, error)
 	// UpdatePassthroughRoute updates an existing TCP/UDP passthrough route
 	UpdatePassthroughRoute(ctx context.Context, in *UpdatePassthroughRouteRequest, opts ...grpc.CallOption) (*UpdatePassthroughRouteResponse, error)
+	// AdoptPassthroughRoute brings an existing unmanaged DNAT rule under
+	// management as a passthrough route
+	AdoptPassthroughRoute(ctx context.Context, in *AdoptPassthroughRouteRequest, opts ...grpc.CallOption) (*AdoptPassthroughRouteResponse, error)
 	// GetContainerACL gets firewall rules for a DevBox container
 	GetContainerACL(ctx context.Context, in *GetContainerACLRequest, opts ...grpc.CallOption) (*GetContainerACLResponse, error)
 	// UpdateContainerACL updates firewall rules for a DevBox container
@@ -76,6 +83,12 @@ type NetworkServiceClient interface {
 	StartEgressProxy(ctx context.Context, in *StartEgressProxyRequest, opts ...grpc.CallOption) (*StartEgressProxyResponse, error)
 	// StopEgressProxy stops the egress-via-client relay for a box.
 	StopEgressProxy(ctx context.Context, in *StopEgressProxyRequest, opts ...grpc.CallOption) (*StopEgressProxyResponse, error)
+	// SetBandwidthLimit caps a container's ingress and/or egress bandwidth
+	SetBandwidthLimit(ctx context.Context, in *SetBandwidthLimitRequest, opts ...grpc.CallOption) (*SetBandwidthLimitResponse, error)
+	// ListBandwidthLimits lists the bandwidth limits in force
+	ListBandwidthLimits(ctx context.Context, in *ListBandwidthLimitsRequest, opts ...grpc.CallOption) (*ListBandwidthLimitsResponse, error)
+	// ClearBandwidthLimit removes a container's bandwidth limit
+	ClearBandwidthLimit(ctx context.Context, in *ClearBandwidthLimitRequest, opts ...grpc.CallOption) (*ClearBandwidthLimitResponse, error)
 }
 
 type networkServiceClient struct {
@@ -176,6 +189,16 @@ func (c *networkServiceClient) UpdatePassthroughRoute(ctx context.Context, in *U
 	return out, nil
 }
 
+func (c *networkServiceClient) AdoptPassthroughRoute(ctx context.Context, in *AdoptPassthroughRouteRequest, opts ...grpc.CallOption) (*AdoptPassthroughRouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdoptPassthroughRouteResponse)
+	err := c.cc.Invoke(ctx, NetworkService_AdoptPassthroughRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServiceClient) GetContainerACL(ctx context.Context, in *GetContainerACLRequest, opts ...grpc.CallOption) (*GetContainerACLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetContainerACLResponse)
@@ -236,6 +259,36 @@ func (c *networkServiceClient) StopEgressProxy(ctx context.Context, in *StopEgre
 	return out, nil
 }
 
+func (c *networkServiceClient) SetBandwidthLimit(ctx context.Context, in *SetBandwidthLimitRequest, opts ...grpc.CallOption) (*SetBandwidthLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBandwidthLimitResponse)
+	err := c.cc.Invoke(ctx, NetworkService_SetBandwidthLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServiceClient) ListBandwidthLimits(ctx context.Context, in *ListBandwidthLimitsRequest, opts ...grpc.CallOption) (*ListBandwidthLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBandwidthLimitsResponse)
+	err := c.cc.Invoke(ctx, NetworkService_ListBandwidthLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServiceClient) ClearBandwidthLimit(ctx context.Context, in *ClearBandwidthLimitRequest, opts ...grpc.CallOption) (*ClearBandwidthLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearBandwidthLimitResponse)
+	err := c.cc.Invoke(ctx, NetworkService_ClearBandwidthLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServiceServer is the server API for NetworkService service.
 // All implementations must embed UnimplementedNetworkServiceServer
 // for forward compatibility.
@@ -260,6 +313,9 @@ type NetworkServiceServer interface {
 	DeletePassthroughRoute(context.Context, *DeletePassthroughRouteRequest) (*DeletePassthroughRouteResponse, error)
 	// UpdatePassthroughRoute updates an existing TCP/UDP passthrough route
 	UpdatePassthroughRoute(context.Context, *UpdatePassthroughRouteRequest) (*UpdatePassthroughRouteResponse, error)
+	// AdoptPassthroughRoute brings an existing unmanaged DNAT rule under
+	// management as a passthrough route
+	AdoptPassthroughRoute(context.Context, *AdoptPassthroughRouteRequest) (*AdoptPassthroughRouteResponse, error)
 	// GetContainerACL gets firewall rules for a DevBox container
 	GetContainerACL(context.Context, *GetContainerACLRequest) (*GetContainerACLResponse, error)
 	// UpdateContainerACL updates firewall rules for a DevBox container
@@ -276,6 +332,12 @@ type NetworkServiceServer interface {
 	StartEgressProxy(context.Context, *StartEgressProxyRequest) (*StartEgressProxyResponse, error)
 	// StopEgressProxy stops the egress-via-client relay for a box.
 	StopEgressProxy(context.Context, *StopEgressProxyRequest) (*StopEgressProxyResponse, error)
+	// SetBandwidthLimit caps a container's ingress and/or egress bandwidth
+	SetBandwidthLimit(context.Context, *SetBandwidthLimitRequest) (*SetBandwidthLimitResponse, error)
+	// ListBandwidthLimits lists the bandwidth limits in force
+	ListBandwidthLimits(context.Context, *ListBandwidthLimitsRequest) (*ListBandwidthLimitsResponse, error)
+	// ClearBandwidthLimit removes a container's bandwidth limit
+	ClearBandwidthLimit(context.Context, *ClearBandwidthLimitRequest) (*ClearBandwidthLimitResponse, error)
 	mustEmbedUnimplementedNetworkServiceServer()
 }
 
@@ -313,6 +375,9 @@ func (UnimplementedNetworkServiceServer) DeletePassthroughRoute(context.Context,
 func (UnimplementedNetworkServiceServer) UpdatePassthroughRoute(context.Context, *UpdatePassthroughRouteRequest) (*UpdatePassthroughRouteResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdatePassthroughRoute not implemented")
 }
+func (UnimplementedNetworkServiceServer) AdoptPassthroughRoute(context.Context, *AdoptPassthroughRouteRequest) (*AdoptPassthroughRouteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdoptPassthroughRoute not implemented")
+}
 func (UnimplementedNetworkServiceServer) GetContainerACL(context.Context, *GetContainerACLRequest) (*GetContainerACLResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetContainerACL not implemented")
 }
@@ -331,6 +396,15 @@ func (UnimplementedNetworkServiceServer) StartEgressProxy(context.Context, *Star
 func (UnimplementedNetworkServiceServer) StopEgressProxy(context.Context, *StopEgressProxyRequest) (*StopEgressProxyResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method StopEgressProxy not implemented")
 }
+func (UnimplementedNetworkServiceServer) SetBandwidthLimit(context.Context, *SetBandwidthLimitRequest) (*SetBandwidthLimitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetBandwidthLimit not implemented")
+}
+func (UnimplementedNetworkServiceServer) ListBandwidthLimits(context.Context, *ListBandwidthLimitsRequest) (*ListBandwidthLimitsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBandwidthLimits not implemented")
+}
+func (UnimplementedNetworkServiceServer) ClearBandwidthLimit(context.Context, *ClearBandwidthLimitRequest) (*ClearBandwidthLimitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearBandwidthLimit not implemented")
+}
 func (UnimplementedNetworkServiceServer) mustEmbedUnimplementedNetworkServiceServer() {}
 func (UnimplementedNetworkServiceServer) testEmbeddedByValue()                        {}
 
@@ -514,6 +588,24 @@ func _NetworkService_UpdatePassthroughRoute_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkService_AdoptPassthroughRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdoptPassthroughRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).AdoptPassthroughRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkService_AdoptPassthroughRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).AdoptPassthroughRoute(ctx, req.(*AdoptPassthroughRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkService_GetContainerACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetContainerACLRequest)
 	if err := dec(in); err != nil {
@@ -622,6 +714,60 @@ func _NetworkService_StopEgressProxy_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkService_SetBandwidthLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBandwidthLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).SetBandwidthLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkService_SetBandwidthLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).SetBandwidthLimit(ctx, req.(*SetBandwidthLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkService_ListBandwidthLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBandwidthLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).ListBandwidthLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkService_ListBandwidthLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).ListBandwidthLimits(ctx, req.(*ListBandwidthLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkService_ClearBandwidthLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearBandwidthLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).ClearBandwidthLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkService_ClearBandwidthLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).ClearBandwidthLimit(ctx, req.(*ClearBandwidthLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NetworkService_ServiceDesc is the grpc.ServiceDesc for NetworkService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -665,6 +811,10 @@ var NetworkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdatePassthroughRoute",
 			Handler:    _NetworkService_UpdatePassthroughRoute_Handler,
 		},
+		{
+			MethodName: "AdoptPassthroughRoute",
+			Handler:    _NetworkService_AdoptPassthroughRoute_Handler,
+		},
 		{
 			MethodName: "GetContainerACL",
 			Handler:    _NetworkService_GetContainerACL_Handler,
@@ -689,6 +839,18 @@ var NetworkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StopEgressProxy",
 			Handler:    _NetworkService_StopEgressProxy_Handler,
 		},
+		{
+			MethodName: "SetBandwidthLimit",
+			Handler:    _NetworkService_SetBandwidthLimit_Handler,
+		},
+		{
+			MethodName: "ListBandwidthLimits",
+			Handler:    _NetworkService_ListBandwidthLimits_Handler,
+		},
+		{
+			MethodName: "ClearBandwidthLimit",
+			Handler:    _NetworkService_ClearBandwidthLimit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "containarium/v1/network.proto",
@@ -671,6 +671,414 @@ func (x *RefreshSecretsResponse) GetStamped() int32 {
 	return 0
 }
 
+// ContainerSecret is a tenant secret as it appears inside the
+// tenant's container. Never carries the value.
+type ContainerSecret struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Secret name (env-var style, as in SecretMetadata).
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Bumps on every set; see SecretMetadata.version.
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// "user-env" (the container's environment), "file" (the default
+	// /run/secrets/<NAME> or a tenant-chosen path) or "compose" (the
+	// shared dotenv file).
+	Scope string `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	// Where the value is materialized inside <username>-container:
+	// "environment.<NAME>" for user-env, otherwise the file path.
+	Location string `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// RFC3339 timestamp of the last set.
+	UpdatedAt     string `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerSecret) Reset() {
+	*x = ContainerSecret{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerSecret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerSecret) ProtoMessage() {}
+
+func (x *ContainerSecret) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerSecret.ProtoReflect.Descriptor instead.
+func (*ContainerSecret) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ContainerSecret) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerSecret) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ContainerSecret) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *ContainerSecret) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ContainerSecret) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// SetContainerSecretRequest stores a secret and stamps it into the
+// tenant's container straight away. The set is audit-logged with the
+// caller and scope, never the value.
+type SetContainerSecretRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username (tenant) that owns the secret and the container.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Env-var-style name: ^[A-Z_][A-Z0-9_]*$, up to 128 chars.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Plaintext value; encrypted at rest and never logged or returned.
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// "user-env" (default) stamps environment.<NAME> on the container.
+	// An absolute path writes the value to that file, mode 0600 and
+	// owned by the tenant; it must sit under /home/<username>, /run,
+	// /srv or /opt.
+	Scope         string `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetContainerSecretRequest) Reset() {
+	*x = SetContainerSecretRequest{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetContainerSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerSecretRequest) ProtoMessage() {}
+
+func (x *SetContainerSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerSecretRequest.ProtoReflect.Descriptor instead.
+func (*SetContainerSecretRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetContainerSecretRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SetContainerSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetContainerSecretRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SetContainerSecretRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+type SetContainerSecretResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operator-facing summary, including where the secret landed.
+	Message       string           `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Secret        *ContainerSecret `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetContainerSecretResponse) Reset() {
+	*x = SetContainerSecretResponse{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetContainerSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerSecretResponse) ProtoMessage() {}
+
+func (x *SetContainerSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerSecretResponse.ProtoReflect.Descriptor instead.
+func (*SetContainerSecretResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetContainerSecretResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetContainerSecretResponse) GetSecret() *ContainerSecret {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+// ListContainerSecretsRequest lists a tenant's secrets with where each
+// is materialized. Names and locations only — never values.
+type ListContainerSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainerSecretsRequest) Reset() {
+	*x = ListContainerSecretsRequest{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainerSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainerSecretsRequest) ProtoMessage() {}
+
+func (x *ListContainerSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainerSecretsRequest.ProtoReflect.Descriptor instead.
+func (*ListContainerSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListContainerSecretsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ListContainerSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       []*ContainerSecret     `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainerSecretsResponse) Reset() {
+	*x = ListContainerSecretsResponse{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainerSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainerSecretsResponse) ProtoMessage() {}
+
+func (x *ListContainerSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainerSecretsResponse.ProtoReflect.Descriptor instead.
+func (*ListContainerSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListContainerSecretsResponse) GetSecrets() []*ContainerSecret {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+// RemoveContainerSecretRequest deletes a secret and removes it from
+// the tenant's container: the environment key is unset or the file
+// deleted. Audit-logged.
+type RemoveContainerSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveContainerSecretRequest) Reset() {
+	*x = RemoveContainerSecretRequest{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveContainerSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveContainerSecretRequest) ProtoMessage() {}
+
+func (x *RemoveContainerSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveContainerSecretRequest.ProtoReflect.Descriptor instead.
+func (*RemoveContainerSecretRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RemoveContainerSecretRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RemoveContainerSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RemoveContainerSecretResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Summary, noting a location that could not be cleaned up (e.g. the
+	// container is stopped).
+	Message       string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveContainerSecretResponse) Reset() {
+	*x = RemoveContainerSecretResponse{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveContainerSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveContainerSecretResponse) ProtoMessage() {}
+
+func (x *RemoveContainerSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveContainerSecretResponse.ProtoReflect.Descriptor instead.
+func (*RemoveContainerSecretResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveContainerSecretResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_containarium_v1_secrets_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_secrets_proto_rawDesc = "" +
@@ -712,7 +1120,31 @@ const file_containarium_v1_secrets_proto_rawDesc = "" +
 	"\busername\x18\x01 \x01(\tR\busername\"L\n" +
 	"\x16RefreshSecretsResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\astamped\x18\x02 \x01(\x05R\astampedBKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
+	"\astamped\x18\x02 \x01(\x05R\astamped\"\x90\x01\n" +
+	"\x0fContainerSecret\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x14\n" +
+	"\x05scope\x18\x03 \x01(\tR\x05scope\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"w\n" +
+	"\x19SetContainerSecretRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x14\n" +
+	"\x05scope\x18\x04 \x01(\tR\x05scope\"p\n" +
+	"\x1aSetContainerSecretResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x128\n" +
+	"\x06secret\x18\x02 \x01(\v2 .containarium.v1.ContainerSecretR\x06secret\"9\n" +
+	"\x1bListContainerSecretsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"Z\n" +
+	"\x1cListContainerSecretsResponse\x12:\n" +
+	"\asecrets\x18\x01 \x03(\v2 .containarium.v1.ContainerSecretR\asecrets\"N\n" +
+	"\x1cRemoveContainerSecretRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"9\n" +
+	"\x1dRemoveContainerSecretResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessageBKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
 
 var (
 	file_containarium_v1_secrets_proto_rawDescOnce sync.Once
@@ -726,29 +1158,38 @@ func file_containarium_v1_secrets_proto_rawDescGZIP() []byte {
 	return file_containarium_v1_secrets_proto_rawDescData
 }
 
-var file_containarium_v1_secrets_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_containarium_v1_secrets_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_containarium_v1_secrets_proto_goTypes = []any{
-	(*SecretMetadata)(nil),         // 0: containarium.v1.SecretMetadata
-	(*SetSecretRequest)(nil),       // 1: containarium.v1.SetSecretRequest
-	(*SetSecretResponse)(nil),      // 2: containarium.v1.SetSecretResponse
-	(*GetSecretRequest)(nil),       // 3: containarium.v1.GetSecretRequest
-	(*GetSecretResponse)(nil),      // 4: containarium.v1.GetSecretResponse
-	(*ListSecretsRequest)(nil),     // 5: containarium.v1.ListSecretsRequest
-	(*ListSecretsResponse)(nil),    // 6: containarium.v1.ListSecretsResponse
-	(*DeleteSecretRequest)(nil),    // 7: containarium.v1.DeleteSecretRequest
-	(*DeleteSecretResponse)(nil),   // 8: containarium.v1.DeleteSecretResponse
-	(*RefreshSecretsRequest)(nil),  // 9: containarium.v1.RefreshSecretsRequest
-	(*RefreshSecretsResponse)(nil), // 10: containarium.v1.RefreshSecretsResponse
+	(*SecretMetadata)(nil),                // 0: containarium.v1.SecretMetadata
+	(*SetSecretRequest)(nil),              // 1: containarium.v1.SetSecretRequest
+	(*SetSecretResponse)(nil),             // 2: containarium.v1.SetSecretResponse
+	(*GetSecretRequest)(nil),              // 3: containarium.v1.GetSecretRequest
+	(*GetSecretResponse)(nil),             // 4: containarium.v1.GetSecretResponse
+	(*ListSecretsRequest)(nil),            // 5: containarium.v1.ListSecretsRequest
+	(*ListSecretsResponse)(nil),           // 6: containarium.v1.ListSecretsResponse
+	(*DeleteSecretRequest)(nil),           // 7: containarium.v1.DeleteSecretRequest
+	(*DeleteSecretResponse)(nil),          // 8: containarium.v1.DeleteSecretResponse
+	(*RefreshSecretsRequest)(nil),         // 9: containarium.v1.RefreshSecretsRequest
+	(*RefreshSecretsResponse)(nil),        // 10: containarium.v1.RefreshSecretsResponse
+	(*ContainerSecret)(nil),               // 11: containarium.v1.ContainerSecret
+	(*SetContainerSecretRequest)(nil),     // 12: containarium.v1.SetContainerSecretRequest
+	(*SetContainerSecretResponse)(nil),    // 13: containarium.v1.SetContainerSecretResponse
+	(*ListContainerSecretsRequest)(nil),   // 14: containarium.v1.ListContainerSecretsRequest
+	(*ListContainerSecretsResponse)(nil),  // 15: containarium.v1.ListContainerSecretsResponse
+	(*RemoveContainerSecretRequest)(nil),  // 16: containarium.v1.RemoveContainerSecretRequest
+	(*RemoveContainerSecretResponse)(nil), // 17: containarium.v1.RemoveContainerSecretResponse
 }
 var file_containarium_v1_secrets_proto_depIdxs = []int32{
-	0, // 0: containarium.v1.SetSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
-	0, // 1: containarium.v1.GetSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
-	0, // 2: containarium.v1.ListSecretsResponse.secrets:type_name -> containarium.v1.SecretMetadata
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: containarium.v1.SetSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
+	0,  // 1: containarium.v1.GetSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
+	0,  // 2: containarium.v1.ListSecretsResponse.secrets:type_name -> containarium.v1.SecretMetadata
+	11, // 3: containarium.v1.SetContainerSecretResponse.secret:type_name -> containarium.v1.ContainerSecret
+	11, // 4: containarium.v1.ListContainerSecretsResponse.secrets:type_name -> containarium.v1.ContainerSecret
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_secrets_proto_init() }
@@ -762,7 +1203,7 @@ func file_containarium_v1_secrets_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_secrets_proto_rawDesc), len(file_containarium_v1_secrets_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
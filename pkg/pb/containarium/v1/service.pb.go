@@ -26,7 +26,7 @@ var File_containarium_v1_service_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1dcontainarium/v1/service.proto\x12\x0fcontainarium.v1\x1a\x1fcontainarium/v1/container.proto\x1a\x1ccontainarium/v1/config.proto\x1a\x19containarium/v1/app.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1bcontainarium/v1/alert.proto\x1a\x1dcontainarium/v1/secrets.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xac\x92\x01\n" +
+	"\x1dcontainarium/v1/service.proto\x12\x0fcontainarium.v1\x1a\x1fcontainarium/v1/container.proto\x1a\x1ccontainarium/v1/config.proto\x1a\x19containarium/v1/app.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1bcontainarium/v1/alert.proto\x1a\x1dcontainarium/v1/secrets.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xac\xce\x01\n" +
 	"\x10ContainerService\x12\xae\x02\n" +
 	"\x0fCreateContainer\x12'.containarium.v1.CreateContainerRequest\x1a(.containarium.v1.CreateContainerResponse\"\xc7\x01\x92A\xaa\x01\n" +
 	"\n" +
@@ -39,10 +39,13 @@ const file_containarium_v1_service_proto_rawDesc = "" +
 	"Containers\x12\x15Get container details\x1avReturns detailed information about a specific container including state, resources, network info, and current metrics.\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/containers/{username}\x12\xd6\x03\n" +
 	"\x0eDebugContainer\x12&.containarium.v1.DebugContainerRequest\x1a'.containarium.v1.DebugContainerResponse\"\xf2\x02\x92A\xc7\x02\n" +
 	"\n" +
-	"Containers\x12\x1cDebug a container's SSH path\x1a\x9a\x02Inspects backend-local state for the container: container runtime state, host /etc/passwd entry, whether the shell wrapper exists, and recent sshd journal lines matching the username. Returns a structured diagnosis with likely_cause and ordered next_actions for the caller to apply.\x82\xd3\xe4\x93\x02!\x12\x1f/v1/containers/{username}/debug\x12\x8a\x02\n" +
-	"\x0fDeleteContainer\x12'.containarium.v1.DeleteContainerRequest\x1a(.containarium.v1.DeleteContainerResponse\"\xa3\x01\x92A\x7f\n" +
+	"Containers\x12\x1cDebug a container's SSH path\x1a\x9a\x02Inspects backend-local state for the container: container runtime state, host /etc/passwd entry, whether the shell wrapper exists, and recent sshd journal lines matching the username. Returns a structured diagnosis with likely_cause and ordered next_actions for the caller to apply.\x82\xd3\xe4\x93\x02!\x12\x1f/v1/containers/{username}/debug\x12\xbe\x04\n" +
+	"\x0fDeleteContainer\x12'.containarium.v1.DeleteContainerRequest\x1a(.containarium.v1.DeleteContainerResponse\"\xd7\x03\x92A\xb2\x03\n" +
 	"\n" +
-	"Containers\x12\x12Delete a container\x1a]Permanently deletes a container. Use force=true query parameter to delete running containers.\x82\xd3\xe4\x93\x02\x1b*\x19/v1/containers/{username}\x12\x89\x02\n" +
+	"Containers\x12\x12Delete a container\x1a\x8f\x03Permanently deletes a container and, in order, the resources that depend on it: Caddy routes and TLS subjects, passthrough routes, collaborators, and the host (sshpiper) account. A dependent that fails to be removed aborts the delete before the container is touched; use force=true to continue past it (and to delete running containers). The response lists what was removed and what was left behind.\x82\xd3\xe4\x93\x02\x1b*\x19/v1/containers/{username}\x12\xbb\x03\n" +
+	"\x0eGarbageCollect\x12&.containarium.v1.GarbageCollectRequest\x1a'.containarium.v1.GarbageCollectResponse\"\xd7\x02\x92A\xc2\x02\n" +
+	"\n" +
+	"Containers\x12,Garbage-collect orphaned container resources\x1a\x85\x02Cross-references the route, passthrough and collaborator registries and the host accounts against the containers that exist (locally and on healthy peers) and reports dependents whose container is gone. Unless dry_run is set, each orphan is removed. Admin-only.\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/v1/gc\x12\x89\x02\n" +
 	"\x0eStartContainer\x12&.containarium.v1.StartContainerRequest\x1a'.containarium.v1.StartContainerResponse\"\xa5\x01\x92Ax\n" +
 	"\x14Container Operations\x12\x11Start a container\x1aMStarts a stopped container. The container must exist and be in STOPPED state.\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/containers/{username}/start\x12\xb2\x02\n" +
 	"\rStopContainer\x12%.containarium.v1.StopContainerRequest\x1a&.containarium.v1.StopContainerResponse\"\xd1\x01\x92A\xa4\x01\n" +
@@ -56,7 +59,9 @@ const file_containarium_v1_service_proto_rawDesc = "" +
 	"\x10ToggleMonitoring\x12(.containarium.v1.ToggleMonitoringRequest\x1a).containarium.v1.ToggleMonitoringResponse\"\xec\x02\x92A\xb9\x02\n" +
 	"\x14Container Operations\x120Enable or disable OTel monitoring on a container\x1a\xee\x01Live-flip OTEL_EXPORTER_OTLP_ENDPOINT and related env vars on an existing container and restart it so the change reaches the app. Use this to retrofit monitoring onto containers created before --monitoring was wired into create_container.\x82\xd3\xe4\x93\x02):\x01*\"$/v1/containers/{username}/monitoring\x12\xb9\x03\n" +
 	"\x0fToggleAutoSleep\x12'.containarium.v1.ToggleAutoSleepRequest\x1a(.containarium.v1.ToggleAutoSleepResponse\"\xd2\x02\x92A\x9f\x02\n" +
-	"\x14Container Operations\x12+Enable or disable auto-sleep on a container\x1a\xd9\x01Writes the per-container auto-sleep opt-in metadata (Incus user.* keys). Does not itself stop the container — use stop_container for that. Phase 2 (the actual idle-tick) and Phase 3 (wake-on-HTTP) consume this flag.\x82\xd3\xe4\x93\x02):\x01*\"$/v1/containers/{username}/auto-sleep\x12\xec\x03\n" +
+	"\x14Container Operations\x12+Enable or disable auto-sleep on a container\x1a\xd9\x01Writes the per-container auto-sleep opt-in metadata (Incus user.* keys). Does not itself stop the container — use stop_container for that. Phase 2 (the actual idle-tick) and Phase 3 (wake-on-HTTP) consume this flag.\x82\xd3\xe4\x93\x02):\x01*\"$/v1/containers/{username}/auto-sleep\x12\x9e\x03\n" +
+	"\x10ToggleDNSLogging\x12(.containarium.v1.ToggleDNSLoggingRequest\x1a).containarium.v1.ToggleDNSLoggingResponse\"\xb4\x02\x92A\x80\x02\n" +
+	"\x14Container Operations\x122Enable or disable DNS query logging on a container\x1a\xb3\x01Writes the per-container DNS logging opt-in (Incus user.* key). The daemon records a box's DNS queries only while it is set and the daemon follows the bridge resolver's query log.\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/containers/{username}/dns-logging\x12\xec\x03\n" +
 	"\x0fSetContainerTTL\x12'.containarium.v1.SetContainerTTLRequest\x1a(.containarium.v1.SetContainerTTLResponse\"\x85\x03\x92A\xdd\x02\n" +
 	"\x14Container Operations\x12/Schedule or clear a container's auto-delete TTL\x1a\x93\x02Stamps the container with a wall-clock auto-delete time consumed by the daemon's TTL sweeper. duration_seconds=0 clears any existing TTL; > 0 sets it to now()+duration. Capped at 604800 (7 days). Used by the containarium-run GitHub Action to auto-clean failed-CI debug boxes.\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/containers/{name}/ttl\x12\xae\x04\n" +
 	"\x18SetContainerDeletePolicy\x120.containarium.v1.SetContainerDeletePolicyRequest\x1a1.containarium.v1.SetContainerDeletePolicyResponse\"\xac\x03\x92A\xfa\x02\n" +
@@ -78,7 +83,32 @@ const file_containarium_v1_service_proto_rawDesc = "" +
 	"\n" +
 	"Monitoring\x12\x15Get container metrics\x1a\xa3\x01Returns runtime metrics (CPU, memory, disk, network usage) for containers. Specify username to get metrics for a specific container, or omit to get all containers.\x82\xd3\xe4\x93\x02'Z\x18\x12\x16/v1/metrics/{username}\x12\v/v1/metrics\x12\xe6\x02\n" +
 	"\vCleanupDisk\x12#.containarium.v1.CleanupDiskRequest\x1a$.containarium.v1.CleanupDiskResponse\"\x8b\x02\x92A\xd6\x01\n" +
-	"\x14Container Operations\x12\x1dClean up container disk space\x1a\x9e\x01Frees disk space inside a container by removing temporary files, package manager caches, and trimming journal logs. Useful when disk is full and resize fails.\x82\xd3\xe4\x93\x02+:\x01*\"&/v1/containers/{username}/cleanup-disk\x12\xae\x02\n" +
+	"\x14Container Operations\x12\x1dClean up container disk space\x1a\x9e\x01Frees disk space inside a container by removing temporary files, package manager caches, and trimming journal logs. Useful when disk is full and resize fails.\x82\xd3\xe4\x93\x02+:\x01*\"&/v1/containers/{username}/cleanup-disk\x12\xb2\x03\n" +
+	"\x15GetContainerProcesses\x12-.containarium.v1.GetContainerProcessesRequest\x1a..containarium.v1.GetContainerProcessesResponse\"\xb9\x02\x92A\x8a\x02\n" +
+	"\n" +
+	"Monitoring\x12\x18List container processes\x1a\xe1\x01Returns the processes running inside a container ordered by CPU use (a bounded `ps` run through the Incus exec API), with container-level CPU and memory usage. Fails with FAILED_PRECONDITION when the container is not running.\x82\xd3\xe4\x93\x02%\x12#/v1/containers/{username}/processes\x12\xe6\x03\n" +
+	"\x11ReadContainerFile\x12).containarium.v1.ReadContainerFileRequest\x1a*.containarium.v1.ReadContainerFileResponse\"\xf9\x02\x92A\xce\x02\n" +
+	"\x14Container Operations\x12\x15Read a container file\x1a\x9e\x02Reads a file inside a container through the Incus file API (no exec, no shell), up to max_bytes, flagging content that is not text. A symlink is returned as its target, not followed. The path must be absolute, without \"..\", and outside /proc, /sys and /dev. Works on stopped containers.\x82\xd3\xe4\x93\x02!\x12\x1f/v1/containers/{username}/files\x12\xe6\x03\n" +
+	"\x12WriteContainerFile\x12*.containarium.v1.WriteContainerFileRequest\x1a+.containarium.v1.WriteContainerFileResponse\"\xf6\x02\x92A\xc8\x02\n" +
+	"\x14Container Operations\x12\x16Write a container file\x1a\x97\x02Writes a file inside a container through the Incus file API (no exec, no shell), replacing any existing file, with the given mode and numeric owner. Content over 1 MiB is rejected. The path must be absolute, without \"..\", and outside /proc, /sys and /dev. Every write is audited.\x82\xd3\xe4\x93\x02$:\x01*\x1a\x1f/v1/containers/{username}/files\x12\xe8\x02\n" +
+	"\x10ListContainerDir\x12(.containarium.v1.ListContainerDirRequest\x1a).containarium.v1.ListContainerDirResponse\"\xfe\x01\x92A\xd4\x01\n" +
+	"\x14Container Operations\x12\x1aList a container directory\x1a\x9f\x01Lists a directory inside a container through the Incus file API (no exec, no shell). The path must be absolute, without \"..\", and outside /proc, /sys and /dev.\x82\xd3\xe4\x93\x02 \x12\x1e/v1/containers/{username}/dirs\x12\xdc\x03\n" +
+	"\x0eDiffContainers\x12&.containarium.v1.DiffContainersRequest\x1a'.containarium.v1.DiffContainersResponse\"\xf8\x02\x92A\xbf\x02\n" +
+	"\n" +
+	"Monitoring\x12\x13Diff two containers\x1a\x9b\x02Compares two containers on this backend: Incus config, limits and devices, OS release, kernel, Docker version, listening ports and, with include_packages, installed dpkg packages. Returns only the differences. A stopped container yields a partial diff with notes instead of an error.\x82\xd3\xe4\x93\x02/\x12-/v1/containers/{username_a}/diff/{username_b}\x12\xb9\x04\n" +
+	"\x10TestConnectivity\x12(.containarium.v1.TestConnectivityRequest\x1a).containarium.v1.TestConnectivityResponse\"\xcf\x03\x92A\x95\x03\n" +
+	"\n" +
+	"Monitoring\x12\"Test connectivity from a container\x1a\xe2\x02Resolves the target inside the container, checks the address against the egress network policy, then dials it (tcp via bash /dev/tcp, http via curl, ping) with a bounded timeout. Reports resolved addresses, latency, the HTTP status, and the stage a failure happened at: policy, dns, connect, tls or http. A target the enforced policy drops is not dialed.\x82\xd3\xe4\x93\x020:\x01*\"+/v1/containers/{username}/connectivity-test\x12\xbe\x02\n" +
+	"\x0eCreateSnapshot\x12&.containarium.v1.CreateSnapshotRequest\x1a'.containarium.v1.CreateSnapshotResponse\"\xda\x01\x92A\xa8\x01\n" +
+	"\x14Container Operations\x12\x14Snapshot a container\x1azTakes a point-in-time snapshot of the container's filesystem. Useful before risky changes; roll back with RestoreSnapshot.\x82\xd3\xe4\x93\x02(:\x01*\"#/v1/containers/{username}/snapshots\x12\xac\x02\n" +
+	"\rListSnapshots\x12%.containarium.v1.ListSnapshotsRequest\x1a&.containarium.v1.ListSnapshotsResponse\"\xcb\x01\x92A\x9c\x01\n" +
+	"\x14Container Operations\x12\x18List container snapshots\x1ajReturns the container's snapshots with name, creation time, and size (when the storage driver reports it).\x82\xd3\xe4\x93\x02%\x12#/v1/containers/{username}/snapshots\x12\xd2\x02\n" +
+	"\x0fRestoreSnapshot\x12'.containarium.v1.RestoreSnapshotRequest\x1a(.containarium.v1.RestoreSnapshotResponse\"\xeb\x01\x92A\xa1\x01\n" +
+	"\x14Container Operations\x12\x1cRestore a container snapshot\x1akRolls the container's filesystem back to the named snapshot. Changes made after the snapshot are discarded.\x82\xd3\xe4\x93\x02@:\x01*\";/v1/containers/{username}/snapshots/{snapshot_name}/restore\x12\xd3\x03\n" +
+	"\x14GetContainerActivity\x12,.containarium.v1.GetContainerActivityRequest\x1a-.containarium.v1.GetContainerActivityResponse\"\xdd\x02\x92A\xaf\x02\n" +
+	"\x14Container Operations\x12\x1cSummarize container activity\x1a\xf8\x01Joins lifecycle events, resource usage trends, traffic totals and top destinations, snapshots taken, and audited API changes for one container over a look-back window. Sections whose data source is unavailable are left empty and explained in notes.\x82\xd3\xe4\x93\x02$\x12\"/v1/containers/{username}/activity\x12\xef\x03\n" +
+	"\x15GetContainerReadiness\x12-.containarium.v1.GetContainerReadinessRequest\x1a..containarium.v1.GetContainerReadinessResponse\"\xf6\x02\x92A\xc7\x02\n" +
+	"\x14Container Operations\x12\x17Get container readiness\x1a\x95\x02Returns the provisioning steps the daemon recorded while creating the container (persisted on the instance, so they survive a daemon restart) together with live checks: running state, IP assigned and sshd reachable. ready is true once every step is done and every check passes.\x82\xd3\xe4\x93\x02%\x12#/v1/containers/{username}/readiness\x12\xae\x02\n" +
 	"\fInstallStack\x12$.containarium.v1.InstallStackRequest\x1a%.containarium.v1.InstallStackResponse\"\xd0\x01\x92A\x9a\x01\n" +
 	"\x14Container Operations\x12'Install a software stack on a container\x1aYInstalls a pre-configured software stack or base script on an existing running container.\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/containers/{username}/install-stack\x12\xad\x02\n" +
 	"\n" +
@@ -149,7 +179,19 @@ const file_containarium_v1_service_proto_rawDesc = "" +
 	"\fDeleteSecret\x12$.containarium.v1.DeleteSecretRequest\x1a%.containarium.v1.DeleteSecretResponse\"\xdf\x01\x92A\xb6\x01\n" +
 	"\aSecrets\x12\x16Delete a tenant secret\x1a\x92\x01Removes the secret from Postgres. Does NOT cascade to env-var stamps on running containers — call RefreshSecrets to re-stamp without restarting.\x82\xd3\xe4\x93\x02\x1f*\x1d/v1/secrets/{username}/{name}\x12\x90\x03\n" +
 	"\x0eRefreshSecrets\x12&.containarium.v1.RefreshSecretsRequest\x1a'.containarium.v1.RefreshSecretsResponse\"\xac\x02\x92A\xff\x01\n" +
-	"\aSecrets\x12(Re-stamp tenant secrets into the LXC env\x1a\xc9\x01Reads all of the tenant's secrets from the DB, decrypts, and updates the LXC's environment.<NAME> config keys to match. Running processes keep their old env (POSIX); new execs see the refreshed values.\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/secrets/{username}/refreshB\xa4\x04\x92A\xd5\x03\x12\xc4\x02\n" +
+	"\aSecrets\x12(Re-stamp tenant secrets into the LXC env\x1a\xc9\x01Reads all of the tenant's secrets from the DB, decrypts, and updates the LXC's environment.<NAME> config keys to match. Running processes keep their old env (POSIX); new execs see the refreshed values.\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/secrets/{username}/refresh\x12\xdc\x03\n" +
+	"\x12SetContainerSecret\x12*.containarium.v1.SetContainerSecretRequest\x1a+.containarium.v1.SetContainerSecretResponse\"\xec\x02\x92A\xbc\x02\n" +
+	"\aSecrets\x12(Set a secret inside a tenant's container\x1a\x86\x02Stores the secret encrypted and materializes it in <username>-container: scope user-env stamps environment.<NAME>; an absolute path under /home/<username>, /run, /srv or /opt writes a 0600 file owned by the tenant. The value is never logged, returned or audited.\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/containers/{username}/secrets\x12\xca\x02\n" +
+	"\x14ListContainerSecrets\x12,.containarium.v1.ListContainerSecretsRequest\x1a-.containarium.v1.ListContainerSecretsResponse\"\xd4\x01\x92A\xa7\x01\n" +
+	"\aSecrets\x12(List secrets inside a tenant's container\x1arReturns each secret's name, version, scope and location (environment key or file path). Values are never returned.\x82\xd3\xe4\x93\x02#\x12!/v1/containers/{username}/secrets\x12\x90\x03\n" +
+	"\x15RemoveContainerSecret\x12-.containarium.v1.RemoveContainerSecretRequest\x1a..containarium.v1.RemoveContainerSecretResponse\"\x97\x02\x92A\xe3\x01\n" +
+	"\aSecrets\x12)Remove a secret from a tenant's container\x1a\xac\x01Deletes the secret from the store, then unsets its environment key or deletes its file in <username>-container. Cleanup inside a stopped container is reported, not retried.\x82\xd3\xe4\x93\x02**(/v1/containers/{username}/secrets/{name}\x12\xca\x02\n" +
+	"\x16ListContainerTemplates\x12..containarium.v1.ListContainerTemplatesRequest\x1a/.containarium.v1.ListContainerTemplatesResponse\"\xce\x01\x92A\xab\x01\n" +
+	"\n" +
+	"Containers\x12\x18List container templates\x1a\x82\x01Returns the daemon's container templates: those from its --container-templates file (read_only) and those managed through the API.\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/container-templates\x12\xc4\x01\n" +
+	"\x14GetContainerTemplate\x12,.containarium.v1.GetContainerTemplateRequest\x1a-.containarium.v1.GetContainerTemplateResponse\"O\x92A&\n" +
+	"\n" +
+	"Containers\x12\x18Get a container template\x82\xd3\xe4\x93\x02 \x12\x1e/v1/container-templates/{name}B\xa4\x04\x92A\xd5\x03\x12\xc4\x02\n" +
 	"\x10Containarium API\x12\xa0\x01Container management API for LXC-based development environments. Provides both gRPC and REST interfaces for managing containers, SSH keys, and system resources.\";\n" +
 	"\fContainarium\x12+https://github.com/footprintai/containarium*K\n" +
 	"\n" +
@@ -166,109 +208,145 @@ var file_containarium_v1_service_proto_goTypes = []any{
 	(*GetContainerRequest)(nil),              // 2: containarium.v1.GetContainerRequest
 	(*DebugContainerRequest)(nil),            // 3: containarium.v1.DebugContainerRequest
 	(*DeleteContainerRequest)(nil),           // 4: containarium.v1.DeleteContainerRequest
-	(*StartContainerRequest)(nil),            // 5: containarium.v1.StartContainerRequest
-	(*StopContainerRequest)(nil),             // 6: containarium.v1.StopContainerRequest
-	(*ResizeContainerRequest)(nil),           // 7: containarium.v1.ResizeContainerRequest
-	(*MoveContainerRequest)(nil),             // 8: containarium.v1.MoveContainerRequest
-	(*AdoptMigratedContainerRequest)(nil),    // 9: containarium.v1.AdoptMigratedContainerRequest
-	(*ToggleMonitoringRequest)(nil),          // 10: containarium.v1.ToggleMonitoringRequest
-	(*ToggleAutoSleepRequest)(nil),           // 11: containarium.v1.ToggleAutoSleepRequest
-	(*SetContainerTTLRequest)(nil),           // 12: containarium.v1.SetContainerTTLRequest
-	(*SetContainerDeletePolicyRequest)(nil),  // 13: containarium.v1.SetContainerDeletePolicyRequest
-	(*SetContainerAttributionRequest)(nil),   // 14: containarium.v1.SetContainerAttributionRequest
-	(*AddSSHKeyRequest)(nil),                 // 15: containarium.v1.AddSSHKeyRequest
-	(*RemoveSSHKeyRequest)(nil),              // 16: containarium.v1.RemoveSSHKeyRequest
-	(*AddCollaboratorRequest)(nil),           // 17: containarium.v1.AddCollaboratorRequest
-	(*RemoveCollaboratorRequest)(nil),        // 18: containarium.v1.RemoveCollaboratorRequest
-	(*ListCollaboratorsRequest)(nil),         // 19: containarium.v1.ListCollaboratorsRequest
-	(*GetMetricsRequest)(nil),                // 20: containarium.v1.GetMetricsRequest
-	(*CleanupDiskRequest)(nil),               // 21: containarium.v1.CleanupDiskRequest
-	(*InstallStackRequest)(nil),              // 22: containarium.v1.InstallStackRequest
-	(*ListStacksRequest)(nil),                // 23: containarium.v1.ListStacksRequest
-	(*GetSystemInfoRequest)(nil),             // 24: containarium.v1.GetSystemInfoRequest
-	(*ListBackendsRequest)(nil),              // 25: containarium.v1.ListBackendsRequest
-	(*AdvertiseCapacityRequest)(nil),         // 26: containarium.v1.AdvertiseCapacityRequest
-	(*WithdrawCapacityRequest)(nil),          // 27: containarium.v1.WithdrawCapacityRequest
-	(*GetCapacityHeadroomRequest)(nil),       // 28: containarium.v1.GetCapacityHeadroomRequest
-	(*ProfileBackendRequest)(nil),            // 29: containarium.v1.ProfileBackendRequest
-	(*GetCapabilityProfileRequest)(nil),      // 30: containarium.v1.GetCapabilityProfileRequest
-	(*GetSelfMeasurementRequest)(nil),        // 31: containarium.v1.GetSelfMeasurementRequest
-	(*GetLatestReleaseRequest)(nil),          // 32: containarium.v1.GetLatestReleaseRequest
-	(*ValidateGPURequest)(nil),               // 33: containarium.v1.ValidateGPURequest
-	(*TriggerUpgradeRequest)(nil),            // 34: containarium.v1.TriggerUpgradeRequest
-	(*GetUpgradeStatusRequest)(nil),          // 35: containarium.v1.GetUpgradeStatusRequest
-	(*GetMonitoringInfoRequest)(nil),         // 36: containarium.v1.GetMonitoringInfoRequest
-	(*SetMetricsExportRequest)(nil),          // 37: containarium.v1.SetMetricsExportRequest
-	(*GetMetricsExportRequest)(nil),          // 38: containarium.v1.GetMetricsExportRequest
-	(*CreateAlertRuleRequest)(nil),           // 39: containarium.v1.CreateAlertRuleRequest
-	(*ListAlertRulesRequest)(nil),            // 40: containarium.v1.ListAlertRulesRequest
-	(*GetAlertRuleRequest)(nil),              // 41: containarium.v1.GetAlertRuleRequest
-	(*UpdateAlertRuleRequest)(nil),           // 42: containarium.v1.UpdateAlertRuleRequest
-	(*DeleteAlertRuleRequest)(nil),           // 43: containarium.v1.DeleteAlertRuleRequest
-	(*GetAlertingInfoRequest)(nil),           // 44: containarium.v1.GetAlertingInfoRequest
-	(*ListDefaultAlertRulesRequest)(nil),     // 45: containarium.v1.ListDefaultAlertRulesRequest
-	(*UpdateAlertingConfigRequest)(nil),      // 46: containarium.v1.UpdateAlertingConfigRequest
-	(*TestWebhookRequest)(nil),               // 47: containarium.v1.TestWebhookRequest
-	(*ListWebhookDeliveriesRequest)(nil),     // 48: containarium.v1.ListWebhookDeliveriesRequest
-	(*SetSecretRequest)(nil),                 // 49: containarium.v1.SetSecretRequest
-	(*GetSecretRequest)(nil),                 // 50: containarium.v1.GetSecretRequest
-	(*ListSecretsRequest)(nil),               // 51: containarium.v1.ListSecretsRequest
-	(*DeleteSecretRequest)(nil),              // 52: containarium.v1.DeleteSecretRequest
-	(*RefreshSecretsRequest)(nil),            // 53: containarium.v1.RefreshSecretsRequest
-	(*CreateContainerResponse)(nil),          // 54: containarium.v1.CreateContainerResponse
-	(*ListContainersResponse)(nil),           // 55: containarium.v1.ListContainersResponse
-	(*GetContainerResponse)(nil),             // 56: containarium.v1.GetContainerResponse
-	(*DebugContainerResponse)(nil),           // 57: containarium.v1.DebugContainerResponse
-	(*DeleteContainerResponse)(nil),          // 58: containarium.v1.DeleteContainerResponse
-	(*StartContainerResponse)(nil),           // 59: containarium.v1.StartContainerResponse
-	(*StopContainerResponse)(nil),            // 60: containarium.v1.StopContainerResponse
-	(*ResizeContainerResponse)(nil),          // 61: containarium.v1.ResizeContainerResponse
-	(*MoveContainerResponse)(nil),            // 62: containarium.v1.MoveContainerResponse
-	(*AdoptMigratedContainerResponse)(nil),   // 63: containarium.v1.AdoptMigratedContainerResponse
-	(*ToggleMonitoringResponse)(nil),         // 64: containarium.v1.ToggleMonitoringResponse
-	(*ToggleAutoSleepResponse)(nil),          // 65: containarium.v1.ToggleAutoSleepResponse
-	(*SetContainerTTLResponse)(nil),          // 66: containarium.v1.SetContainerTTLResponse
-	(*SetContainerDeletePolicyResponse)(nil), // 67: containarium.v1.SetContainerDeletePolicyResponse
-	(*SetContainerAttributionResponse)(nil),  // 68: containarium.v1.SetContainerAttributionResponse
-	(*AddSSHKeyResponse)(nil),                // 69: containarium.v1.AddSSHKeyResponse
-	(*RemoveSSHKeyResponse)(nil),             // 70: containarium.v1.RemoveSSHKeyResponse
-	(*AddCollaboratorResponse)(nil),          // 71: containarium.v1.AddCollaboratorResponse
-	(*RemoveCollaboratorResponse)(nil),       // 72: containarium.v1.RemoveCollaboratorResponse
-	(*ListCollaboratorsResponse)(nil),        // 73: containarium.v1.ListCollaboratorsResponse
-	(*GetMetricsResponse)(nil),               // 74: containarium.v1.GetMetricsResponse
-	(*CleanupDiskResponse)(nil),              // 75: containarium.v1.CleanupDiskResponse
-	(*InstallStackResponse)(nil),             // 76: containarium.v1.InstallStackResponse
-	(*ListStacksResponse)(nil),               // 77: containarium.v1.ListStacksResponse
-	(*GetSystemInfoResponse)(nil),            // 78: containarium.v1.GetSystemInfoResponse
-	(*ListBackendsResponse)(nil),             // 79: containarium.v1.ListBackendsResponse
-	(*AdvertiseCapacityResponse)(nil),        // 80: containarium.v1.AdvertiseCapacityResponse
-	(*WithdrawCapacityResponse)(nil),         // 81: containarium.v1.WithdrawCapacityResponse
-	(*GetCapacityHeadroomResponse)(nil),      // 82: containarium.v1.GetCapacityHeadroomResponse
-	(*ProfileBackendResponse)(nil),           // 83: containarium.v1.ProfileBackendResponse
-	(*GetCapabilityProfileResponse)(nil),     // 84: containarium.v1.GetCapabilityProfileResponse
-	(*GetSelfMeasurementResponse)(nil),       // 85: containarium.v1.GetSelfMeasurementResponse
-	(*GetLatestReleaseResponse)(nil),         // 86: containarium.v1.GetLatestReleaseResponse
-	(*ValidateGPUResponse)(nil),              // 87: containarium.v1.ValidateGPUResponse
-	(*TriggerUpgradeResponse)(nil),           // 88: containarium.v1.TriggerUpgradeResponse
-	(*GetUpgradeStatusResponse)(nil),         // 89: containarium.v1.GetUpgradeStatusResponse
-	(*GetMonitoringInfoResponse)(nil),        // 90: containarium.v1.GetMonitoringInfoResponse
-	(*SetMetricsExportResponse)(nil),         // 91: containarium.v1.SetMetricsExportResponse
-	(*GetMetricsExportResponse)(nil),         // 92: containarium.v1.GetMetricsExportResponse
-	(*CreateAlertRuleResponse)(nil),          // 93: containarium.v1.CreateAlertRuleResponse
-	(*ListAlertRulesResponse)(nil),           // 94: containarium.v1.ListAlertRulesResponse
-	(*GetAlertRuleResponse)(nil),             // 95: containarium.v1.GetAlertRuleResponse
-	(*UpdateAlertRuleResponse)(nil),          // 96: containarium.v1.UpdateAlertRuleResponse
-	(*DeleteAlertRuleResponse)(nil),          // 97: containarium.v1.DeleteAlertRuleResponse
-	(*GetAlertingInfoResponse)(nil),          // 98: containarium.v1.GetAlertingInfoResponse
-	(*ListDefaultAlertRulesResponse)(nil),    // 99: containarium.v1.ListDefaultAlertRulesResponse
-	(*UpdateAlertingConfigResponse)(nil),     // 100: containarium.v1.UpdateAlertingConfigResponse
-	(*TestWebhookResponse)(nil),              // 101: containarium.v1.TestWebhookResponse
-	(*ListWebhookDeliveriesResponse)(nil),    // 102: containarium.v1.ListWebhookDeliveriesResponse
-	(*SetSecretResponse)(nil),                // 103: containarium.v1.SetSecretResponse
-	(*GetSecretResponse)(nil),                // 104: containarium.v1.GetSecretResponse
-	(*ListSecretsResponse)(nil),              // 105: containarium.v1.ListSecretsResponse
-	(*DeleteSecretResponse)(nil),             // 106: containarium.v1.DeleteSecretResponse
-	(*RefreshSecretsResponse)(nil),           // 107: containarium.v1.RefreshSecretsResponse
+	(*GarbageCollectRequest)(nil),            // 5: containarium.v1.GarbageCollectRequest
+	(*StartContainerRequest)(nil),            // 6: containarium.v1.StartContainerRequest
+	(*StopContainerRequest)(nil),             // 7: containarium.v1.StopContainerRequest
+	(*ResizeContainerRequest)(nil),           // 8: containarium.v1.ResizeContainerRequest
+	(*MoveContainerRequest)(nil),             // 9: containarium.v1.MoveContainerRequest
+	(*AdoptMigratedContainerRequest)(nil),    // 10: containarium.v1.AdoptMigratedContainerRequest
+	(*ToggleMonitoringRequest)(nil),          // 11: containarium.v1.ToggleMonitoringRequest
+	(*ToggleAutoSleepRequest)(nil),           // 12: containarium.v1.ToggleAutoSleepRequest
+	(*ToggleDNSLoggingRequest)(nil),          // 13: containarium.v1.ToggleDNSLoggingRequest
+	(*SetContainerTTLRequest)(nil),           // 14: containarium.v1.SetContainerTTLRequest
+	(*SetContainerDeletePolicyRequest)(nil),  // 15: containarium.v1.SetContainerDeletePolicyRequest
+	(*SetContainerAttributionRequest)(nil),   // 16: containarium.v1.SetContainerAttributionRequest
+	(*AddSSHKeyRequest)(nil),                 // 17: containarium.v1.AddSSHKeyRequest
+	(*RemoveSSHKeyRequest)(nil),              // 18: containarium.v1.RemoveSSHKeyRequest
+	(*AddCollaboratorRequest)(nil),           // 19: containarium.v1.AddCollaboratorRequest
+	(*RemoveCollaboratorRequest)(nil),        // 20: containarium.v1.RemoveCollaboratorRequest
+	(*ListCollaboratorsRequest)(nil),         // 21: containarium.v1.ListCollaboratorsRequest
+	(*GetMetricsRequest)(nil),                // 22: containarium.v1.GetMetricsRequest
+	(*CleanupDiskRequest)(nil),               // 23: containarium.v1.CleanupDiskRequest
+	(*GetContainerProcessesRequest)(nil),     // 24: containarium.v1.GetContainerProcessesRequest
+	(*ReadContainerFileRequest)(nil),         // 25: containarium.v1.ReadContainerFileRequest
+	(*WriteContainerFileRequest)(nil),        // 26: containarium.v1.WriteContainerFileRequest
+	(*ListContainerDirRequest)(nil),          // 27: containarium.v1.ListContainerDirRequest
+	(*DiffContainersRequest)(nil),            // 28: containarium.v1.DiffContainersRequest
+	(*TestConnectivityRequest)(nil),          // 29: containarium.v1.TestConnectivityRequest
+	(*CreateSnapshotRequest)(nil),            // 30: containarium.v1.CreateSnapshotRequest
+	(*ListSnapshotsRequest)(nil),             // 31: containarium.v1.ListSnapshotsRequest
+	(*RestoreSnapshotRequest)(nil),           // 32: containarium.v1.RestoreSnapshotRequest
+	(*GetContainerActivityRequest)(nil),      // 33: containarium.v1.GetContainerActivityRequest
+	(*GetContainerReadinessRequest)(nil),     // 34: containarium.v1.GetContainerReadinessRequest
+	(*InstallStackRequest)(nil),              // 35: containarium.v1.InstallStackRequest
+	(*ListStacksRequest)(nil),                // 36: containarium.v1.ListStacksRequest
+	(*GetSystemInfoRequest)(nil),             // 37: containarium.v1.GetSystemInfoRequest
+	(*ListBackendsRequest)(nil),              // 38: containarium.v1.ListBackendsRequest
+	(*AdvertiseCapacityRequest)(nil),         // 39: containarium.v1.AdvertiseCapacityRequest
+	(*WithdrawCapacityRequest)(nil),          // 40: containarium.v1.WithdrawCapacityRequest
+	(*GetCapacityHeadroomRequest)(nil),       // 41: containarium.v1.GetCapacityHeadroomRequest
+	(*ProfileBackendRequest)(nil),            // 42: containarium.v1.ProfileBackendRequest
+	(*GetCapabilityProfileRequest)(nil),      // 43: containarium.v1.GetCapabilityProfileRequest
+	(*GetSelfMeasurementRequest)(nil),        // 44: containarium.v1.GetSelfMeasurementRequest
+	(*GetLatestReleaseRequest)(nil),          // 45: containarium.v1.GetLatestReleaseRequest
+	(*ValidateGPURequest)(nil),               // 46: containarium.v1.ValidateGPURequest
+	(*TriggerUpgradeRequest)(nil),            // 47: containarium.v1.TriggerUpgradeRequest
+	(*GetUpgradeStatusRequest)(nil),          // 48: containarium.v1.GetUpgradeStatusRequest
+	(*GetMonitoringInfoRequest)(nil),         // 49: containarium.v1.GetMonitoringInfoRequest
+	(*SetMetricsExportRequest)(nil),          // 50: containarium.v1.SetMetricsExportRequest
+	(*GetMetricsExportRequest)(nil),          // 51: containarium.v1.GetMetricsExportRequest
+	(*CreateAlertRuleRequest)(nil),           // 52: containarium.v1.CreateAlertRuleRequest
+	(*ListAlertRulesRequest)(nil),            // 53: containarium.v1.ListAlertRulesRequest
+	(*GetAlertRuleRequest)(nil),              // 54: containarium.v1.GetAlertRuleRequest
+	(*UpdateAlertRuleRequest)(nil),           // 55: containarium.v1.UpdateAlertRuleRequest
+	(*DeleteAlertRuleRequest)(nil),           // 56: containarium.v1.DeleteAlertRuleRequest
+	(*GetAlertingInfoRequest)(nil),           // 57: containarium.v1.GetAlertingInfoRequest
+	(*ListDefaultAlertRulesRequest)(nil),     // 58: containarium.v1.ListDefaultAlertRulesRequest
+	(*UpdateAlertingConfigRequest)(nil),      // 59: containarium.v1.UpdateAlertingConfigRequest
+	(*TestWebhookRequest)(nil),               // 60: containarium.v1.TestWebhookRequest
+	(*ListWebhookDeliveriesRequest)(nil),     // 61: containarium.v1.ListWebhookDeliveriesRequest
+	(*SetSecretRequest)(nil),                 // 62: containarium.v1.SetSecretRequest
+	(*GetSecretRequest)(nil),                 // 63: containarium.v1.GetSecretRequest
+	(*ListSecretsRequest)(nil),               // 64: containarium.v1.ListSecretsRequest
+	(*DeleteSecretRequest)(nil),              // 65: containarium.v1.DeleteSecretRequest
+	(*RefreshSecretsRequest)(nil),            // 66: containarium.v1.RefreshSecretsRequest
+	(*SetContainerSecretRequest)(nil),        // 67: containarium.v1.SetContainerSecretRequest
+	(*ListContainerSecretsRequest)(nil),      // 68: containarium.v1.ListContainerSecretsRequest
+	(*RemoveContainerSecretRequest)(nil),     // 69: containarium.v1.RemoveContainerSecretRequest
+	(*ListContainerTemplatesRequest)(nil),    // 70: containarium.v1.ListContainerTemplatesRequest
+	(*GetContainerTemplateRequest)(nil),      // 71: containarium.v1.GetContainerTemplateRequest
+	(*CreateContainerResponse)(nil),          // 72: containarium.v1.CreateContainerResponse
+	(*ListContainersResponse)(nil),           // 73: containarium.v1.ListContainersResponse
+	(*GetContainerResponse)(nil),             // 74: containarium.v1.GetContainerResponse
+	(*DebugContainerResponse)(nil),           // 75: containarium.v1.DebugContainerResponse
+	(*DeleteContainerResponse)(nil),          // 76: containarium.v1.DeleteContainerResponse
+	(*GarbageCollectResponse)(nil),           // 77: containarium.v1.GarbageCollectResponse
+	(*StartContainerResponse)(nil),           // 78: containarium.v1.StartContainerResponse
+	(*StopContainerResponse)(nil),            // 79: containarium.v1.StopContainerResponse
+	(*ResizeContainerResponse)(nil),          // 80: containarium.v1.ResizeContainerResponse
+	(*MoveContainerResponse)(nil),            // 81: containarium.v1.MoveContainerResponse
+	(*AdoptMigratedContainerResponse)(nil),   // 82: containarium.v1.AdoptMigratedContainerResponse
+	(*ToggleMonitoringResponse)(nil),         // 83: containarium.v1.ToggleMonitoringResponse
+	(*ToggleAutoSleepResponse)(nil),          // 84: containarium.v1.ToggleAutoSleepResponse
+	(*ToggleDNSLoggingResponse)(nil),         // 85: containarium.v1.ToggleDNSLoggingResponse
+	(*SetContainerTTLResponse)(nil),          // 86: containarium.v1.SetContainerTTLResponse
+	(*SetContainerDeletePolicyResponse)(nil), // 87: containarium.v1.SetContainerDeletePolicyResponse
+	(*SetContainerAttributionResponse)(nil),  // 88: containarium.v1.SetContainerAttributionResponse
+	(*AddSSHKeyResponse)(nil),                // 89: containarium.v1.AddSSHKeyResponse
+	(*RemoveSSHKeyResponse)(nil),             // 90: containarium.v1.RemoveSSHKeyResponse
+	(*AddCollaboratorResponse)(nil),          // 91: containarium.v1.AddCollaboratorResponse
+	(*RemoveCollaboratorResponse)(nil),       // 92: containarium.v1.RemoveCollaboratorResponse
+	(*ListCollaboratorsResponse)(nil),        // 93: containarium.v1.ListCollaboratorsResponse
+	(*GetMetricsResponse)(nil),               // 94: containarium.v1.GetMetricsResponse
+	(*CleanupDiskResponse)(nil),              // 95: containarium.v1.CleanupDiskResponse
+	(*GetContainerProcessesResponse)(nil),    // 96: containarium.v1.GetContainerProcessesResponse
+	(*ReadContainerFileResponse)(nil),        // 97: containarium.v1.ReadContainerFileResponse
+	(*WriteContainerFileResponse)(nil),       // 98: containarium.v1.WriteContainerFileResponse
+	(*ListContainerDirResponse)(nil),         // 99: containarium.v1.ListContainerDirResponse
+	(*DiffContainersResponse)(nil),           // 100: containarium.v1.DiffContainersResponse
+	(*TestConnectivityResponse)(nil),         // 101: containarium.v1.TestConnectivityResponse
+	(*CreateSnapshotResponse)(nil),           // 102: containarium.v1.CreateSnapshotResponse
+	(*ListSnapshotsResponse)(nil),            // 103: containarium.v1.ListSnapshotsResponse
+	(*RestoreSnapshotResponse)(nil),          // 104: containarium.v1.RestoreSnapshotResponse
+	(*GetContainerActivityResponse)(nil),     // 105: containarium.v1.GetContainerActivityResponse
+	(*GetContainerReadinessResponse)(nil),    // 106: containarium.v1.GetContainerReadinessResponse
+	(*InstallStackResponse)(nil),             // 107: containarium.v1.InstallStackResponse
+	(*ListStacksResponse)(nil),               // 108: containarium.v1.ListStacksResponse
+	(*GetSystemInfoResponse)(nil),            // 109: containarium.v1.GetSystemInfoResponse
+	(*ListBackendsResponse)(nil),             // 110: containarium.v1.ListBackendsResponse
+	(*AdvertiseCapacityResponse)(nil),        // 111: containarium.v1.AdvertiseCapacityResponse
+	(*WithdrawCapacityResponse)(nil),         // 112: containarium.v1.WithdrawCapacityResponse
+	(*GetCapacityHeadroomResponse)(nil),      // 113: containarium.v1.GetCapacityHeadroomResponse
+	(*ProfileBackendResponse)(nil),           // 114: containarium.v1.ProfileBackendResponse
+	(*GetCapabilityProfileResponse)(nil),     // 115: containarium.v1.GetCapabilityProfileResponse
+	(*GetSelfMeasurementResponse)(nil),       // 116: containarium.v1.GetSelfMeasurementResponse
+	(*GetLatestReleaseResponse)(nil),         // 117: containarium.v1.GetLatestReleaseResponse
+	(*ValidateGPUResponse)(nil),              // 118: containarium.v1.ValidateGPUResponse
+	(*TriggerUpgradeResponse)(nil),           // 119: containarium.v1.TriggerUpgradeResponse
+	(*GetUpgradeStatusResponse)(nil),         // 120: containarium.v1.GetUpgradeStatusResponse
+	(*GetMonitoringInfoResponse)(nil),        // 121: containarium.v1.GetMonitoringInfoResponse
+	(*SetMetricsExportResponse)(nil),         // 122: containarium.v1.SetMetricsExportResponse
+	(*GetMetricsExportResponse)(nil),         // 123: containarium.v1.GetMetricsExportResponse
+	(*CreateAlertRuleResponse)(nil),          // 124: containarium.v1.CreateAlertRuleResponse
+	(*ListAlertRulesResponse)(nil),           // 125: containarium.v1.ListAlertRulesResponse
+	(*GetAlertRuleResponse)(nil),             // 126: containarium.v1.GetAlertRuleResponse
+	(*UpdateAlertRuleResponse)(nil),          // 127: containarium.v1.UpdateAlertRuleResponse
+	(*DeleteAlertRuleResponse)(nil),          // 128: containarium.v1.DeleteAlertRuleResponse
+	(*GetAlertingInfoResponse)(nil),          // 129: containarium.v1.GetAlertingInfoResponse
+	(*ListDefaultAlertRulesResponse)(nil),    // 130: containarium.v1.ListDefaultAlertRulesResponse
+	(*UpdateAlertingConfigResponse)(nil),     // 131: containarium.v1.UpdateAlertingConfigResponse
+	(*TestWebhookResponse)(nil),              // 132: containarium.v1.TestWebhookResponse
+	(*ListWebhookDeliveriesResponse)(nil),    // 133: containarium.v1.ListWebhookDeliveriesResponse
+	(*SetSecretResponse)(nil),                // 134: containarium.v1.SetSecretResponse
+	(*GetSecretResponse)(nil),                // 135: containarium.v1.GetSecretResponse
+	(*ListSecretsResponse)(nil),              // 136: containarium.v1.ListSecretsResponse
+	(*DeleteSecretResponse)(nil),             // 137: containarium.v1.DeleteSecretResponse
+	(*RefreshSecretsResponse)(nil),           // 138: containarium.v1.RefreshSecretsResponse
+	(*SetContainerSecretResponse)(nil),       // 139: containarium.v1.SetContainerSecretResponse
+	(*ListContainerSecretsResponse)(nil),     // 140: containarium.v1.ListContainerSecretsResponse
+	(*RemoveContainerSecretResponse)(nil),    // 141: containarium.v1.RemoveContainerSecretResponse
+	(*ListContainerTemplatesResponse)(nil),   // 142: containarium.v1.ListContainerTemplatesResponse
+	(*GetContainerTemplateResponse)(nil),     // 143: containarium.v1.GetContainerTemplateResponse
 }
 var file_containarium_v1_service_proto_depIdxs = []int32{
 	0,   // 0: containarium.v1.ContainerService.CreateContainer:input_type -> containarium.v1.CreateContainerRequest
@@ -276,111 +354,147 @@ var file_containarium_v1_service_proto_depIdxs = []int32{
 	2,   // 2: containarium.v1.ContainerService.GetContainer:input_type -> containarium.v1.GetContainerRequest
 	3,   // 3: containarium.v1.ContainerService.DebugContainer:input_type -> containarium.v1.DebugContainerRequest
 	4,   // 4: containarium.v1.ContainerService.DeleteContainer:input_type -> containarium.v1.DeleteContainerRequest
-	5,   // 5: containarium.v1.ContainerService.StartContainer:input_type -> containarium.v1.StartContainerRequest
-	6,   // 6: containarium.v1.ContainerService.StopContainer:input_type -> containarium.v1.StopContainerRequest
-	7,   // 7: containarium.v1.ContainerService.ResizeContainer:input_type -> containarium.v1.ResizeContainerRequest
-	8,   // 8: containarium.v1.ContainerService.MoveContainer:input_type -> containarium.v1.MoveContainerRequest
-	9,   // 9: containarium.v1.ContainerService.AdoptMigratedContainer:input_type -> containarium.v1.AdoptMigratedContainerRequest
-	10,  // 10: containarium.v1.ContainerService.ToggleMonitoring:input_type -> containarium.v1.ToggleMonitoringRequest
-	11,  // 11: containarium.v1.ContainerService.ToggleAutoSleep:input_type -> containarium.v1.ToggleAutoSleepRequest
-	12,  // 12: containarium.v1.ContainerService.SetContainerTTL:input_type -> containarium.v1.SetContainerTTLRequest
-	13,  // 13: containarium.v1.ContainerService.SetContainerDeletePolicy:input_type -> containarium.v1.SetContainerDeletePolicyRequest
-	14,  // 14: containarium.v1.ContainerService.SetContainerAttribution:input_type -> containarium.v1.SetContainerAttributionRequest
-	15,  // 15: containarium.v1.ContainerService.AddSSHKey:input_type -> containarium.v1.AddSSHKeyRequest
-	16,  // 16: containarium.v1.ContainerService.RemoveSSHKey:input_type -> containarium.v1.RemoveSSHKeyRequest
-	17,  // 17: containarium.v1.ContainerService.AddCollaborator:input_type -> containarium.v1.AddCollaboratorRequest
-	18,  // 18: containarium.v1.ContainerService.RemoveCollaborator:input_type -> containarium.v1.RemoveCollaboratorRequest
-	19,  // 19: containarium.v1.ContainerService.ListCollaborators:input_type -> containarium.v1.ListCollaboratorsRequest
-	20,  // 20: containarium.v1.ContainerService.GetMetrics:input_type -> containarium.v1.GetMetricsRequest
-	21,  // 21: containarium.v1.ContainerService.CleanupDisk:input_type -> containarium.v1.CleanupDiskRequest
-	22,  // 22: containarium.v1.ContainerService.InstallStack:input_type -> containarium.v1.InstallStackRequest
-	23,  // 23: containarium.v1.ContainerService.ListStacks:input_type -> containarium.v1.ListStacksRequest
-	24,  // 24: containarium.v1.ContainerService.GetSystemInfo:input_type -> containarium.v1.GetSystemInfoRequest
-	25,  // 25: containarium.v1.ContainerService.ListBackends:input_type -> containarium.v1.ListBackendsRequest
-	26,  // 26: containarium.v1.ContainerService.AdvertiseCapacity:input_type -> containarium.v1.AdvertiseCapacityRequest
-	27,  // 27: containarium.v1.ContainerService.WithdrawCapacity:input_type -> containarium.v1.WithdrawCapacityRequest
-	28,  // 28: containarium.v1.ContainerService.GetCapacityHeadroom:input_type -> containarium.v1.GetCapacityHeadroomRequest
-	29,  // 29: containarium.v1.ContainerService.ProfileBackend:input_type -> containarium.v1.ProfileBackendRequest
-	30,  // 30: containarium.v1.ContainerService.GetCapabilityProfile:input_type -> containarium.v1.GetCapabilityProfileRequest
-	31,  // 31: containarium.v1.ContainerService.GetSelfMeasurement:input_type -> containarium.v1.GetSelfMeasurementRequest
-	32,  // 32: containarium.v1.ContainerService.GetLatestRelease:input_type -> containarium.v1.GetLatestReleaseRequest
-	33,  // 33: containarium.v1.ContainerService.ValidateGPU:input_type -> containarium.v1.ValidateGPURequest
-	34,  // 34: containarium.v1.ContainerService.TriggerUpgrade:input_type -> containarium.v1.TriggerUpgradeRequest
-	35,  // 35: containarium.v1.ContainerService.GetUpgradeStatus:input_type -> containarium.v1.GetUpgradeStatusRequest
-	36,  // 36: containarium.v1.ContainerService.GetMonitoringInfo:input_type -> containarium.v1.GetMonitoringInfoRequest
-	37,  // 37: containarium.v1.ContainerService.SetMetricsExport:input_type -> containarium.v1.SetMetricsExportRequest
-	38,  // 38: containarium.v1.ContainerService.GetMetricsExport:input_type -> containarium.v1.GetMetricsExportRequest
-	39,  // 39: containarium.v1.ContainerService.CreateAlertRule:input_type -> containarium.v1.CreateAlertRuleRequest
-	40,  // 40: containarium.v1.ContainerService.ListAlertRules:input_type -> containarium.v1.ListAlertRulesRequest
-	41,  // 41: containarium.v1.ContainerService.GetAlertRule:input_type -> containarium.v1.GetAlertRuleRequest
-	42,  // 42: containarium.v1.ContainerService.UpdateAlertRule:input_type -> containarium.v1.UpdateAlertRuleRequest
-	43,  // 43: containarium.v1.ContainerService.DeleteAlertRule:input_type -> containarium.v1.DeleteAlertRuleRequest
-	44,  // 44: containarium.v1.ContainerService.GetAlertingInfo:input_type -> containarium.v1.GetAlertingInfoRequest
-	45,  // 45: containarium.v1.ContainerService.ListDefaultAlertRules:input_type -> containarium.v1.ListDefaultAlertRulesRequest
-	46,  // 46: containarium.v1.ContainerService.UpdateAlertingConfig:input_type -> containarium.v1.UpdateAlertingConfigRequest
-	47,  // 47: containarium.v1.ContainerService.TestWebhook:input_type -> containarium.v1.TestWebhookRequest
-	48,  // 48: containarium.v1.ContainerService.ListWebhookDeliveries:input_type -> containarium.v1.ListWebhookDeliveriesRequest
-	49,  // 49: containarium.v1.ContainerService.SetSecret:input_type -> containarium.v1.SetSecretRequest
-	50,  // 50: containarium.v1.ContainerService.GetSecret:input_type -> containarium.v1.GetSecretRequest
-	51,  // 51: containarium.v1.ContainerService.ListSecrets:input_type -> containarium.v1.ListSecretsRequest
-	52,  // 52: containarium.v1.ContainerService.DeleteSecret:input_type -> containarium.v1.DeleteSecretRequest
-	53,  // 53: containarium.v1.ContainerService.RefreshSecrets:input_type -> containarium.v1.RefreshSecretsRequest
-	54,  // 54: containarium.v1.ContainerService.CreateContainer:output_type -> containarium.v1.CreateContainerResponse
-	55,  // 55: containarium.v1.ContainerService.ListContainers:output_type -> containarium.v1.ListContainersResponse
-	56,  // 56: containarium.v1.ContainerService.GetContainer:output_type -> containarium.v1.GetContainerResponse
-	57,  // 57: containarium.v1.ContainerService.DebugContainer:output_type -> containarium.v1.DebugContainerResponse
-	58,  // 58: containarium.v1.ContainerService.DeleteContainer:output_type -> containarium.v1.DeleteContainerResponse
-	59,  // 59: containarium.v1.ContainerService.StartContainer:output_type -> containarium.v1.StartContainerResponse
-	60,  // 60: containarium.v1.ContainerService.StopContainer:output_type -> containarium.v1.StopContainerResponse
-	61,  // 61: containarium.v1.ContainerService.ResizeContainer:output_type -> containarium.v1.ResizeContainerResponse
-	62,  // 62: containarium.v1.ContainerService.MoveContainer:output_type -> containarium.v1.MoveContainerResponse
-	63,  // 63: containarium.v1.ContainerService.AdoptMigratedContainer:output_type -> containarium.v1.AdoptMigratedContainerResponse
-	64,  // 64: containarium.v1.ContainerService.ToggleMonitoring:output_type -> containarium.v1.ToggleMonitoringResponse
-	65,  // 65: containarium.v1.ContainerService.ToggleAutoSleep:output_type -> containarium.v1.ToggleAutoSleepResponse
-	66,  // 66: containarium.v1.ContainerService.SetContainerTTL:output_type -> containarium.v1.SetContainerTTLResponse
-	67,  // 67: containarium.v1.ContainerService.SetContainerDeletePolicy:output_type -> containarium.v1.SetContainerDeletePolicyResponse
-	68,  // 68: containarium.v1.ContainerService.SetContainerAttribution:output_type -> containarium.v1.SetContainerAttributionResponse
-	69,  // 69: containarium.v1.ContainerService.AddSSHKey:output_type -> containarium.v1.AddSSHKeyResponse
-	70,  // 70: containarium.v1.ContainerService.RemoveSSHKey:output_type -> containarium.v1.RemoveSSHKeyResponse
-	71,  // 71: containarium.v1.ContainerService.AddCollaborator:output_type -> containarium.v1.AddCollaboratorResponse
-	72,  // 72: containarium.v1.ContainerService.RemoveCollaborator:output_type -> containarium.v1.RemoveCollaboratorResponse
-	73,  // 73: containarium.v1.ContainerService.ListCollaborators:output_type -> containarium.v1.ListCollaboratorsResponse
-	74,  // 74: containarium.v1.ContainerService.GetMetrics:output_type -> containarium.v1.GetMetricsResponse
-	75,  // 75: containarium.v1.ContainerService.CleanupDisk:output_type -> containarium.v1.CleanupDiskResponse
-	76,  // 76: containarium.v1.ContainerService.InstallStack:output_type -> containarium.v1.InstallStackResponse
-	77,  // 77: containarium.v1.ContainerService.ListStacks:output_type -> containarium.v1.ListStacksResponse
-	78,  // 78: containarium.v1.ContainerService.GetSystemInfo:output_type -> containarium.v1.GetSystemInfoResponse
-	79,  // 79: containarium.v1.ContainerService.ListBackends:output_type -> containarium.v1.ListBackendsResponse
-	80,  // 80: containarium.v1.ContainerService.AdvertiseCapacity:output_type -> containarium.v1.AdvertiseCapacityResponse
-	81,  // 81: containarium.v1.ContainerService.WithdrawCapacity:output_type -> containarium.v1.WithdrawCapacityResponse
-	82,  // 82: containarium.v1.ContainerService.GetCapacityHeadroom:output_type -> containarium.v1.GetCapacityHeadroomResponse
-	83,  // 83: containarium.v1.ContainerService.ProfileBackend:output_type -> containarium.v1.ProfileBackendResponse
-	84,  // 84: containarium.v1.ContainerService.GetCapabilityProfile:output_type -> containarium.v1.GetCapabilityProfileResponse
-	85,  // 85: containarium.v1.ContainerService.GetSelfMeasurement:output_type -> containarium.v1.GetSelfMeasurementResponse
-	86,  // 86: containarium.v1.ContainerService.GetLatestRelease:output_type -> containarium.v1.GetLatestReleaseResponse
-	87,  // 87: containarium.v1.ContainerService.ValidateGPU:output_type -> containarium.v1.ValidateGPUResponse
-	88,  // 88: containarium.v1.ContainerService.TriggerUpgrade:output_type -> containarium.v1.TriggerUpgradeResponse
-	89,  // 89: containarium.v1.ContainerService.GetUpgradeStatus:output_type -> containarium.v1.GetUpgradeStatusResponse
-	90,  // 90: containarium.v1.ContainerService.GetMonitoringInfo:output_type -> containarium.v1.GetMonitoringInfoResponse
-	91,  // 91: containarium.v1.ContainerService.SetMetricsExport:output_type -> containarium.v1.SetMetricsExportResponse
-	92,  // 92: containarium.v1.ContainerService.GetMetricsExport:output_type -> containarium.v1.GetMetricsExportResponse
-	93,  // 93: containarium.v1.ContainerService.CreateAlertRule:output_type -> containarium.v1.CreateAlertRuleResponse
-	94,  // 94: containarium.v1.ContainerService.ListAlertRules:output_type -> containarium.v1.ListAlertRulesResponse
-	95,  // 95: containarium.v1.ContainerService.GetAlertRule:output_type -> containarium.v1.GetAlertRuleResponse
-	96,  // 96: containarium.v1.ContainerService.UpdateAlertRule:output_type -> containarium.v1.UpdateAlertRuleResponse
-	97,  // 97: containarium.v1.ContainerService.DeleteAlertRule:output_type -> containarium.v1.DeleteAlertRuleResponse
-	98,  // 98: containarium.v1.ContainerService.GetAlertingInfo:output_type -> containarium.v1.GetAlertingInfoResponse
-	99,  // 99: containarium.v1.ContainerService.ListDefaultAlertRules:output_type -> containarium.v1.ListDefaultAlertRulesResponse
-	100, // 100: containarium.v1.ContainerService.UpdateAlertingConfig:output_type -> containarium.v1.UpdateAlertingConfigResponse
-	101, // 101: containarium.v1.ContainerService.TestWebhook:output_type -> containarium.v1.TestWebhookResponse
-	102, // 102: containarium.v1.ContainerService.ListWebhookDeliveries:output_type -> containarium.v1.ListWebhookDeliveriesResponse
-	103, // 103: containarium.v1.ContainerService.SetSecret:output_type -> containarium.v1.SetSecretResponse
-	104, // 104: containarium.v1.ContainerService.GetSecret:output_type -> containarium.v1.GetSecretResponse
-	105, // 105: containarium.v1.ContainerService.ListSecrets:output_type -> containarium.v1.ListSecretsResponse
-	106, // 106: containarium.v1.ContainerService.DeleteSecret:output_type -> containarium.v1.DeleteSecretResponse
-	107, // 107: containarium.v1.ContainerService.RefreshSecrets:output_type -> containarium.v1.RefreshSecretsResponse
-	54,  // [54:108] is the sub-list for method output_type
-	0,   // [0:54] is the sub-list for method input_type
+	5,   // 5: containarium.v1.ContainerService.GarbageCollect:input_type -> containarium.v1.GarbageCollectRequest
+	6,   // 6: containarium.v1.ContainerService.StartContainer:input_type -> containarium.v1.StartContainerRequest
+	7,   // 7: containarium.v1.ContainerService.StopContainer:input_type -> containarium.v1.StopContainerRequest
+	8,   // 8: containarium.v1.ContainerService.ResizeContainer:input_type -> containarium.v1.ResizeContainerRequest
+	9,   // 9: containarium.v1.ContainerService.MoveContainer:input_type -> containarium.v1.MoveContainerRequest
+	10,  // 10: containarium.v1.ContainerService.AdoptMigratedContainer:input_type -> containarium.v1.AdoptMigratedContainerRequest
+	11,  // 11: containarium.v1.ContainerService.ToggleMonitoring:input_type -> containarium.v1.ToggleMonitoringRequest
+	12,  // 12: containarium.v1.ContainerService.ToggleAutoSleep:input_type -> containarium.v1.ToggleAutoSleepRequest
+	13,  // 13: containarium.v1.ContainerService.ToggleDNSLogging:input_type -> containarium.v1.ToggleDNSLoggingRequest
+	14,  // 14: containarium.v1.ContainerService.SetContainerTTL:input_type -> containarium.v1.SetContainerTTLRequest
+	15,  // 15: containarium.v1.ContainerService.SetContainerDeletePolicy:input_type -> containarium.v1.SetContainerDeletePolicyRequest
+	16,  // 16: containarium.v1.ContainerService.SetContainerAttribution:input_type -> containarium.v1.SetContainerAttributionRequest
+	17,  // 17: containarium.v1.ContainerService.AddSSHKey:input_type -> containarium.v1.AddSSHKeyRequest
+	18,  // 18: containarium.v1.ContainerService.RemoveSSHKey:input_type -> containarium.v1.RemoveSSHKeyRequest
+	19,  // 19: containarium.v1.ContainerService.AddCollaborator:input_type -> containarium.v1.AddCollaboratorRequest
+	20,  // 20: containarium.v1.ContainerService.RemoveCollaborator:input_type -> containarium.v1.RemoveCollaboratorRequest
+	21,  // 21: containarium.v1.ContainerService.ListCollaborators:input_type -> containarium.v1.ListCollaboratorsRequest
+	22,  // 22: containarium.v1.ContainerService.GetMetrics:input_type -> containarium.v1.GetMetricsRequest
+	23,  // 23: containarium.v1.ContainerService.CleanupDisk:input_type -> containarium.v1.CleanupDiskRequest
+	24,  // 24: containarium.v1.ContainerService.GetContainerProcesses:input_type -> containarium.v1.GetContainerProcessesRequest
+	25,  // 25: containarium.v1.ContainerService.ReadContainerFile:input_type -> containarium.v1.ReadContainerFileRequest
+	26,  // 26: containarium.v1.ContainerService.WriteContainerFile:input_type -> containarium.v1.WriteContainerFileRequest
+	27,  // 27: containarium.v1.ContainerService.ListContainerDir:input_type -> containarium.v1.ListContainerDirRequest
+	28,  // 28: containarium.v1.ContainerService.DiffContainers:input_type -> containarium.v1.DiffContainersRequest
+	29,  // 29: containarium.v1.ContainerService.TestConnectivity:input_type -> containarium.v1.TestConnectivityRequest
+	30,  // 30: containarium.v1.ContainerService.CreateSnapshot:input_type -> containarium.v1.CreateSnapshotRequest
+	31,  // 31: containarium.v1.ContainerService.ListSnapshots:input_type -> containarium.v1.ListSnapshotsRequest
+	32,  // 32: containarium.v1.ContainerService.RestoreSnapshot:input_type -> containarium.v1.RestoreSnapshotRequest
+	33,  // 33: containarium.v1.ContainerService.GetContainerActivity:input_type -> containarium.v1.GetContainerActivityRequest
+	34,  // 34: containarium.v1.ContainerService.GetContainerReadiness:input_type -> containarium.v1.GetContainerReadinessRequest
+	35,  // 35: containarium.v1.ContainerService.InstallStack:input_type -> containarium.v1.InstallStackRequest
+	36,  // 36: containarium.v1.ContainerService.ListStacks:input_type -> containarium.v1.ListStacksRequest
+	37,  // 37: containarium.v1.ContainerService.GetSystemInfo:input_type -> containarium.v1.GetSystemInfoRequest
+	38,  // 38: containarium.v1.ContainerService.ListBackends:input_type -> containarium.v1.ListBackendsRequest
+	39,  // 39: containarium.v1.ContainerService.AdvertiseCapacity:input_type -> containarium.v1.AdvertiseCapacityRequest
+	40,  // 40: containarium.v1.ContainerService.WithdrawCapacity:input_type -> containarium.v1.WithdrawCapacityRequest
+	41,  // 41: containarium.v1.ContainerService.GetCapacityHeadroom:input_type -> containarium.v1.GetCapacityHeadroomRequest
+	42,  // 42: containarium.v1.ContainerService.ProfileBackend:input_type -> containarium.v1.ProfileBackendRequest
+	43,  // 43: containarium.v1.ContainerService.GetCapabilityProfile:input_type -> containarium.v1.GetCapabilityProfileRequest
+	44,  // 44: containarium.v1.ContainerService.GetSelfMeasurement:input_type -> containarium.v1.GetSelfMeasurementRequest
+	45,  // 45: containarium.v1.ContainerService.GetLatestRelease:input_type -> containarium.v1.GetLatestReleaseRequest
+	46,  // 46: containarium.v1.ContainerService.ValidateGPU:input_type -> containarium.v1.ValidateGPURequest
+	47,  // 47: containarium.v1.ContainerService.TriggerUpgrade:input_type -> containarium.v1.TriggerUpgradeRequest
+	48,  // 48: containarium.v1.ContainerService.GetUpgradeStatus:input_type -> containarium.v1.GetUpgradeStatusRequest
+	49,  // 49: containarium.v1.ContainerService.GetMonitoringInfo:input_type -> containarium.v1.GetMonitoringInfoRequest
+	50,  // 50: containarium.v1.ContainerService.SetMetricsExport:input_type -> containarium.v1.SetMetricsExportRequest
+	51,  // 51: containarium.v1.ContainerService.GetMetricsExport:input_type -> containarium.v1.GetMetricsExportRequest
+	52,  // 52: containarium.v1.ContainerService.CreateAlertRule:input_type -> containarium.v1.CreateAlertRuleRequest
+	53,  // 53: containarium.v1.ContainerService.ListAlertRules:input_type -> containarium.v1.ListAlertRulesRequest
+	54,  // 54: containarium.v1.ContainerService.GetAlertRule:input_type -> containarium.v1.GetAlertRuleRequest
+	55,  // 55: containarium.v1.ContainerService.UpdateAlertRule:input_type -> containarium.v1.UpdateAlertRuleRequest
+	56,  // 56: containarium.v1.ContainerService.DeleteAlertRule:input_type -> containarium.v1.DeleteAlertRuleRequest
+	57,  // 57: containarium.v1.ContainerService.GetAlertingInfo:input_type -> containarium.v1.GetAlertingInfoRequest
+	58,  // 58: containarium.v1.ContainerService.ListDefaultAlertRules:input_type -> containarium.v1.ListDefaultAlertRulesRequest
+	59,  // 59: containarium.v1.ContainerService.UpdateAlertingConfig:input_type -> containarium.v1.UpdateAlertingConfigRequest
+	60,  // 60: containarium.v1.ContainerService.TestWebhook:input_type -> containarium.v1.TestWebhookRequest
+	61,  // 61: containarium.v1.ContainerService.ListWebhookDeliveries:input_type -> containarium.v1.ListWebhookDeliveriesRequest
+	62,  // 62: containarium.v1.ContainerService.SetSecret:input_type -> containarium.v1.SetSecretRequest
+	63,  // 63: containarium.v1.ContainerService.GetSecret:input_type -> containarium.v1.GetSecretRequest
+	64,  // 64: containarium.v1.ContainerService.ListSecrets:input_type -> containarium.v1.ListSecretsRequest
+	65,  // 65: containarium.v1.ContainerService.DeleteSecret:input_type -> containarium.v1.DeleteSecretRequest
+	66,  // 66: containarium.v1.ContainerService.RefreshSecrets:input_type -> containarium.v1.RefreshSecretsRequest
+	67,  // 67: containarium.v1.ContainerService.SetContainerSecret:input_type -> containarium.v1.SetContainerSecretRequest
+	68,  // 68: containarium.v1.ContainerService.ListContainerSecrets:input_type -> containarium.v1.ListContainerSecretsRequest
+	69,  // 69: containarium.v1.ContainerService.RemoveContainerSecret:input_type -> containarium.v1.RemoveContainerSecretRequest
+	70,  // 70: containarium.v1.ContainerService.ListContainerTemplates:input_type -> containarium.v1.ListContainerTemplatesRequest
+	71,  // 71: containarium.v1.ContainerService.GetContainerTemplate:input_type -> containarium.v1.GetContainerTemplateRequest
+	72,  // 72: containarium.v1.ContainerService.CreateContainer:output_type -> containarium.v1.CreateContainerResponse
+	73,  // 73: containarium.v1.ContainerService.ListContainers:output_type -> containarium.v1.ListContainersResponse
+	74,  // 74: containarium.v1.ContainerService.GetContainer:output_type -> containarium.v1.GetContainerResponse
+	75,  // 75: containarium.v1.ContainerService.DebugContainer:output_type -> containarium.v1.DebugContainerResponse
+	76,  // 76: containarium.v1.ContainerService.DeleteContainer:output_type -> containarium.v1.DeleteContainerResponse
+	77,  // 77: containarium.v1.ContainerService.GarbageCollect:output_type -> containarium.v1.GarbageCollectResponse
+	78,  // 78: containarium.v1.ContainerService.StartContainer:output_type -> containarium.v1.StartContainerResponse
+	79,  // 79: containarium.v1.ContainerService.StopContainer:output_type -> containarium.v1.StopContainerResponse
+	80,  // 80: containarium.v1.ContainerService.ResizeContainer:output_type -> containarium.v1.ResizeContainerResponse
+	81,  // 81: containarium.v1.ContainerService.MoveContainer:output_type -> containarium.v1.MoveContainerResponse
+	82,  // 82: containarium.v1.ContainerService.AdoptMigratedContainer:output_type -> containarium.v1.AdoptMigratedContainerResponse
+	83,  // 83: containarium.v1.ContainerService.ToggleMonitoring:output_type -> containarium.v1.ToggleMonitoringResponse
+	84,  // 84: containarium.v1.ContainerService.ToggleAutoSleep:output_type -> containarium.v1.ToggleAutoSleepResponse
+	85,  // 85: containarium.v1.ContainerService.ToggleDNSLogging:output_type -> containarium.v1.ToggleDNSLoggingResponse
+	86,  // 86: containarium.v1.ContainerService.SetContainerTTL:output_type -> containarium.v1.SetContainerTTLResponse
+	87,  // 87: containarium.v1.ContainerService.SetContainerDeletePolicy:output_type -> containarium.v1.SetContainerDeletePolicyResponse
+	88,  // 88: containarium.v1.ContainerService.SetContainerAttribution:output_type -> containarium.v1.SetContainerAttributionResponse
+	89,  // 89: containarium.v1.ContainerService.AddSSHKey:output_type -> containarium.v1.AddSSHKeyResponse
+	90,  // 90: containarium.v1.ContainerService.RemoveSSHKey:output_type -> containarium.v1.RemoveSSHKeyResponse
+	91,  // 91: containarium.v1.ContainerService.AddCollaborator:output_type -> containarium.v1.AddCollaboratorResponse
+	92,  // 92: containarium.v1.ContainerService.RemoveCollaborator:output_type -> containarium.v1.RemoveCollaboratorResponse
+	93,  // 93: containarium.v1.ContainerService.ListCollaborators:output_type -> containarium.v1.ListCollaboratorsResponse
+	94,  // 94: containarium.v1.ContainerService.GetMetrics:output_type -> containarium.v1.GetMetricsResponse
+	95,  // 95: containarium.v1.ContainerService.CleanupDisk:output_type -> containarium.v1.CleanupDiskResponse
+	96,  // 96: containarium.v1.ContainerService.GetContainerProcesses:output_type -> containarium.v1.GetContainerProcessesResponse
+	97,  // 97: containarium.v1.ContainerService.ReadContainerFile:output_type -> containarium.v1.ReadContainerFileResponse
+	98,  // 98: containarium.v1.ContainerService.WriteContainerFile:output_type -> containarium.v1.WriteContainerFileResponse
+	99,  // 99: containarium.v1.ContainerService.ListContainerDir:output_type -> containarium.v1.ListContainerDirResponse
+	100, // 100: containarium.v1.ContainerService.DiffContainers:output_type -> containarium.v1.DiffContainersResponse
+	101, // 101: containarium.v1.ContainerService.TestConnectivity:output_type -> containarium.v1.TestConnectivityResponse
+	102, // 102: containarium.v1.ContainerService.CreateSnapshot:output_type -> containarium.v1.CreateSnapshotResponse
+	103, // 103: containarium.v1.ContainerService.ListSnapshots:output_type -> containarium.v1.ListSnapshotsResponse
+	104, // 104: containarium.v1.ContainerService.RestoreSnapshot:output_type -> containarium.v1.RestoreSnapshotResponse
+	105, // 105: containarium.v1.ContainerService.GetContainerActivity:output_type -> containarium.v1.GetContainerActivityResponse
+	106, // 106: containarium.v1.ContainerService.GetContainerReadiness:output_type -> containarium.v1.GetContainerReadinessResponse
+	107, // 107: containarium.v1.ContainerService.InstallStack:output_type -> containarium.v1.InstallStackResponse
+	108, // 108: containarium.v1.ContainerService.ListStacks:output_type -> containarium.v1.ListStacksResponse
+	109, // 109: containarium.v1.ContainerService.GetSystemInfo:output_type -> containarium.v1.GetSystemInfoResponse
+	110, // 110: containarium.v1.ContainerService.ListBackends:output_type -> containarium.v1.ListBackendsResponse
+	111, // 111: containarium.v1.ContainerService.AdvertiseCapacity:output_type -> containarium.v1.AdvertiseCapacityResponse
+	112, // 112: containarium.v1.ContainerService.WithdrawCapacity:output_type -> containarium.v1.WithdrawCapacityResponse
+	113, // 113: containarium.v1.ContainerService.GetCapacityHeadroom:output_type -> containarium.v1.GetCapacityHeadroomResponse
+	114, // 114: containarium.v1.ContainerService.ProfileBackend:output_type -> containarium.v1.ProfileBackendResponse
+	115, // 115: containarium.v1.ContainerService.GetCapabilityProfile:output_type -> containarium.v1.GetCapabilityProfileResponse
+	116, // 116: containarium.v1.ContainerService.GetSelfMeasurement:output_type -> containarium.v1.GetSelfMeasurementResponse
+	117, // 117: containarium.v1.ContainerService.GetLatestRelease:output_type -> containarium.v1.GetLatestReleaseResponse
+	118, // 118: containarium.v1.ContainerService.ValidateGPU:output_type -> containarium.v1.ValidateGPUResponse
+	119, // 119: containarium.v1.ContainerService.TriggerUpgrade:output_type -> containarium.v1.TriggerUpgradeResponse
+	120, // 120: containarium.v1.ContainerService.GetUpgradeStatus:output_type -> containarium.v1.GetUpgradeStatusResponse
+	121, // 121: containarium.v1.ContainerService.GetMonitoringInfo:output_type -> containarium.v1.GetMonitoringInfoResponse
+	122, // 122: containarium.v1.ContainerService.SetMetricsExport:output_type -> containarium.v1.SetMetricsExportResponse
+	123, // 123: containarium.v1.ContainerService.GetMetricsExport:output_type -> containarium.v1.GetMetricsExportResponse
+	124, // 124: containarium.v1.ContainerService.CreateAlertRule:output_type -> containarium.v1.CreateAlertRuleResponse
+	125, // 125: containarium.v1.ContainerService.ListAlertRules:output_type -> containarium.v1.ListAlertRulesResponse
+	126, // 126: containarium.v1.ContainerService.GetAlertRule:output_type -> containarium.v1.GetAlertRuleResponse
+	127, // 127: containarium.v1.ContainerService.UpdateAlertRule:output_type -> containarium.v1.UpdateAlertRuleResponse
+	128, // 128: containarium.v1.ContainerService.DeleteAlertRule:output_type -> containarium.v1.DeleteAlertRuleResponse
+	129, // 129: containarium.v1.ContainerService.GetAlertingInfo:output_type -> containarium.v1.GetAlertingInfoResponse
+	130, // 130: containarium.v1.ContainerService.ListDefaultAlertRules:output_type -> containarium.v1.ListDefaultAlertRulesResponse
+	131, // 131: containarium.v1.ContainerService.UpdateAlertingConfig:output_type -> containarium.v1.UpdateAlertingConfigResponse
+	132, // 132: containarium.v1.ContainerService.TestWebhook:output_type -> containarium.v1.TestWebhookResponse
+	133, // 133: containarium.v1.ContainerService.ListWebhookDeliveries:output_type -> containarium.v1.ListWebhookDeliveriesResponse
+	134, // 134: containarium.v1.ContainerService.SetSecret:output_type -> containarium.v1.SetSecretResponse
+	135, // 135: containarium.v1.ContainerService.GetSecret:output_type -> containarium.v1.GetSecretResponse
+	136, // 136: containarium.v1.ContainerService.ListSecrets:output_type -> containarium.v1.ListSecretsResponse
+	137, // 137: containarium.v1.ContainerService.DeleteSecret:output_type -> containarium.v1.DeleteSecretResponse
+	138, // 138: containarium.v1.ContainerService.RefreshSecrets:output_type -> containarium.v1.RefreshSecretsResponse
+	139, // 139: containarium.v1.ContainerService.SetContainerSecret:output_type -> containarium.v1.SetContainerSecretResponse
+	140, // 140: containarium.v1.ContainerService.ListContainerSecrets:output_type -> containarium.v1.ListContainerSecretsResponse
+	141, // 141: containarium.v1.ContainerService.RemoveContainerSecret:output_type -> containarium.v1.RemoveContainerSecretResponse
+	142, // 142: containarium.v1.ContainerService.ListContainerTemplates:output_type -> containarium.v1.ListContainerTemplatesResponse
+	143, // 143: containarium.v1.ContainerService.GetContainerTemplate:output_type -> containarium.v1.GetContainerTemplateResponse
+	72,  // [72:144] is the sub-list for method output_type
+	0,   // [0:72] is the sub-list for method input_type
 	0,   // [0:0] is the sub-list for extension type_name
 	0,   // [0:0] is the sub-list for extension extendee
 	0,   // [0:0] is the sub-list for field type_name
@@ -24,6 +24,7 @@ const (
 	ContainerService_GetContainer_FullMethodName             = "/containarium.v1.ContainerService/GetContainer"
 	ContainerService_DebugContainer_FullMethodName           = "/containarium.v1.ContainerService/DebugContainer"
 	ContainerService_DeleteContainer_FullMethodName          = "/containarium.v1.ContainerService/DeleteContainer"
+	ContainerService_GarbageCollect_FullMethodName           = "/containarium.v1.ContainerService/GarbageCollect"
 	ContainerService_StartContainer_FullMethodName           = "/containarium.v1.ContainerService/StartContainer"
 	ContainerService_StopContainer_FullMethodName            = "/containarium.v1.ContainerService/StopContainer"
 	ContainerService_ResizeContainer_FullMethodName          = "/containarium.v1.ContainerService/ResizeContainer"
@@ -31,6 +32,7 @@ const (
 	ContainerService_AdoptMigratedContainer_FullMethodName   = "/containarium.v1.ContainerService/AdoptMigratedContainer"
 	ContainerService_ToggleMonitoring_FullMethodName         = "/containarium.v1.ContainerService/ToggleMonitoring"
 	ContainerService_ToggleAutoSleep_FullMethodName          = "/containarium.v1.ContainerService/ToggleAutoSleep"
+	ContainerService_ToggleDNSLogging_FullMethodName         = "/containarium.v1.ContainerService/ToggleDNSLogging"
 	ContainerService_SetContainerTTL_FullMethodName          = "/containarium.v1.ContainerService/SetContainerTTL"
 	ContainerService_SetContainerDeletePolicy_FullMethodName = "/containarium.v1.ContainerService/SetContainerDeletePolicy"
 	ContainerService_SetContainerAttribution_FullMethodName  = "/containarium.v1.ContainerService/SetContainerAttribution"
@@ -41,6 +43,17 @@ const (
 	ContainerService_ListCollaborators_FullMethodName        = "/containarium.v1.ContainerService/ListCollaborators"
 	ContainerService_GetMetrics_FullMethodName               = "/containarium.v1.ContainerService/GetMetrics"
 	ContainerService_CleanupDisk_FullMethodName              = "/containarium.v1.ContainerService/CleanupDisk"
+	ContainerService_GetContainerProcesses_FullMethodName    = "/containarium.v1.ContainerService/GetContainerProcesses"
+	ContainerService_ReadContainerFile_FullMethodName        = "/containarium.v1.ContainerService/ReadContainerFile"
+	ContainerService_WriteContainerFile_FullMethodName       = "/containarium.v1.ContainerService/WriteContainerFile"
+	ContainerService_ListContainerDir_FullMethodName         = "/containarium.v1.ContainerService/ListContainerDir"
+	ContainerService_DiffContainers_FullMethodName           = "/containarium.v1.ContainerService/DiffContainers"
+	ContainerService_TestConnectivity_FullMethodName         = "/containarium.v1.ContainerService/TestConnectivity"
+	ContainerService_CreateSnapshot_FullMethodName           = "/containarium.v1.ContainerService/CreateSnapshot"
+	ContainerService_ListSnapshots_FullMethodName            = "/containarium.v1.ContainerService/ListSnapshots"
+	ContainerService_RestoreSnapshot_FullMethodName          = "/containarium.v1.ContainerService/RestoreSnapshot"
+	ContainerService_GetContainerActivity_FullMethodName     = "/containarium.v1.ContainerService/GetContainerActivity"
+	ContainerService_GetContainerReadiness_FullMethodName    = "/containarium.v1.ContainerService/GetContainerReadiness"
 	ContainerService_InstallStack_FullMethodName             = "/containarium.v1.ContainerService/InstallStack"
 	ContainerService_ListStacks_FullMethodName               = "/containarium.v1.ContainerService/ListStacks"
 	ContainerService_GetSystemInfo_FullMethodName            = "/containarium.v1.ContainerService/GetSystemInfo"
@@ -73,6 +86,11 @@ const (
 	ContainerService_ListSecrets_FullMethodName              = "/containarium.v1.ContainerService/ListSecrets"
 	ContainerService_DeleteSecret_FullMethodName             = "/containarium.v1.ContainerService/DeleteSecret"
 	ContainerService_RefreshSecrets_FullMethodName           = "/containarium.v1.ContainerService/RefreshSecrets"
+	ContainerService_SetContainerSecret_FullMethodName       = "/containarium.v1.ContainerService/SetContainerSecret"
+	ContainerService_ListContainerSecrets_FullMethodName     = "/containarium.v1.ContainerService/ListContainerSecrets"
+	ContainerService_RemoveContainerSecret_FullMethodName    = "/containarium.v1.ContainerService/RemoveContainerSecret"
+	ContainerService_ListContainerTemplates_FullMethodName   = "/containarium.v1.ContainerService/ListContainerTemplates"
+	ContainerService_GetContainerTemplate_FullMethodName     = "/containarium.v1.ContainerService/GetContainerTemplate"
 )
 
 // ContainerServiceClient is the client API for ContainerService service.
@@ -92,6 +110,8 @@ type ContainerServiceClient interface {
 	DebugContainer(ctx context.Context, in *DebugContainerRequest, opts ...grpc.CallOption) (*DebugContainerResponse, error)
 	// DeleteContainer deletes a container
 	DeleteContainer(ctx context.Context, in *DeleteContainerRequest, opts ...grpc.CallOption) (*DeleteContainerResponse, error)
+	// GarbageCollect finds resources left behind by deleted containers
+	GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error)
 	// StartContainer starts a stopped container
 	StartContainer(ctx context.Context, in *StartContainerRequest, opts ...grpc.CallOption) (*StartContainerResponse, error)
 	// StopContainer stops a running container
@@ -134,6 +154,12 @@ type ContainerServiceClient interface {
 	// HTTP wake proxy are separate work — this RPC only sets the flag
 	// they will consume.
 	ToggleAutoSleep(ctx context.Context, in *ToggleAutoSleepRequest, opts ...grpc.CallOption) (*ToggleAutoSleepResponse, error)
+	// ToggleDNSLogging opts a container into (or out of) DNS query
+	// logging. Writes the per-container Incus user.* key the traffic
+	// collector reads on its next cache refresh; queries from boxes that
+	// haven't opted in are dropped even while the daemon follows the
+	// resolver log (--traffic-dns-log).
+	ToggleDNSLogging(ctx context.Context, in *ToggleDNSLoggingRequest, opts ...grpc.CallOption) (*ToggleDNSLoggingResponse, error)
 	// SetContainerTTL schedules or clears a container's auto-delete time.
 	// duration_seconds == 0 clears any existing TTL; > 0 sets
 	// ttl_expires_at to now() + duration. duration_seconds is capped at
@@ -177,6 +203,30 @@ type ContainerServiceClient interface {
 	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error)
 	// CleanupDisk frees disk space inside a container by removing temp files, package caches, and old logs
 	CleanupDisk(ctx context.Context, in *CleanupDiskRequest, opts ...grpc.CallOption) (*CleanupDiskResponse, error)
+	// GetContainerProcesses lists the busiest processes inside a running container
+	GetContainerProcesses(ctx context.Context, in *GetContainerProcessesRequest, opts ...grpc.CallOption) (*GetContainerProcessesResponse, error)
+	// ReadContainerFile reads a file inside a container through the Incus file API
+	ReadContainerFile(ctx context.Context, in *ReadContainerFileRequest, opts ...grpc.CallOption) (*ReadContainerFileResponse, error)
+	// WriteContainerFile writes a file inside a container through the Incus file API
+	WriteContainerFile(ctx context.Context, in *WriteContainerFileRequest, opts ...grpc.CallOption) (*WriteContainerFileResponse, error)
+	// ListContainerDir lists a directory inside a container through the Incus file API
+	ListContainerDir(ctx context.Context, in *ListContainerDirRequest, opts ...grpc.CallOption) (*ListContainerDirResponse, error)
+	// DiffContainers compares two containers' configuration and software
+	DiffContainers(ctx context.Context, in *DiffContainersRequest, opts ...grpc.CallOption) (*DiffContainersResponse, error)
+	// TestConnectivity probes a target from inside a container
+	TestConnectivity(ctx context.Context, in *TestConnectivityRequest, opts ...grpc.CallOption) (*TestConnectivityResponse, error)
+	// CreateSnapshot takes an incus snapshot of a container
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotResponse, error)
+	// ListSnapshots lists a container's snapshots
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	// RestoreSnapshot rolls a container back to a snapshot
+	RestoreSnapshot(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*RestoreSnapshotResponse, error)
+	// GetContainerActivity summarizes what happened to a container over a
+	// recent window
+	GetContainerActivity(ctx context.Context, in *GetContainerActivityRequest, opts ...grpc.CallOption) (*GetContainerActivityResponse, error)
+	// GetContainerReadiness reports a container's provisioning progress and
+	// whether it is ready to use
+	GetContainerReadiness(ctx context.Context, in *GetContainerReadinessRequest, opts ...grpc.CallOption) (*GetContainerReadinessResponse, error)
 	// InstallStack installs a software stack or base script on a running container
 	InstallStack(ctx context.Context, in *InstallStackRequest, opts ...grpc.CallOption) (*InstallStackResponse, error)
 	// ListStacks returns all available software stacks and their parameter schemas.
@@ -303,6 +353,21 @@ type ContainerServiceClient interface {
 	// useful after rotation when the next exec'd process should see
 	// the new value without a full container restart.
 	RefreshSecrets(ctx context.Context, in *RefreshSecretsRequest, opts ...grpc.CallOption) (*RefreshSecretsResponse, error)
+	// SetContainerSecret stores a tenant secret and stamps it into the
+	// tenant's container at once: as an environment key, or as a 0600
+	// file at a path the tenant chooses. Audit-logged without the value.
+	SetContainerSecret(ctx context.Context, in *SetContainerSecretRequest, opts ...grpc.CallOption) (*SetContainerSecretResponse, error)
+	// ListContainerSecrets lists a tenant's secrets and where each one
+	// is materialized inside the container. Never returns values.
+	ListContainerSecrets(ctx context.Context, in *ListContainerSecretsRequest, opts ...grpc.CallOption) (*ListContainerSecretsResponse, error)
+	// RemoveContainerSecret deletes a tenant secret and removes it from
+	// the container. Audit-logged.
+	RemoveContainerSecret(ctx context.Context, in *RemoveContainerSecretRequest, opts ...grpc.CallOption) (*RemoveContainerSecretResponse, error)
+	// ListContainerTemplates lists the presets CreateContainer's template
+	// field accepts.
+	ListContainerTemplates(ctx context.Context, in *ListContainerTemplatesRequest, opts ...grpc.CallOption) (*ListContainerTemplatesResponse, error)
+	// GetContainerTemplate returns one template.
+	GetContainerTemplate(ctx context.Context, in *GetContainerTemplateRequest, opts ...grpc.CallOption) (*GetContainerTemplateResponse, error)
 }
 
 type containerServiceClient struct {
@@ -363,6 +428,16 @@ func (c *containerServiceClient) DeleteContainer(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *containerServiceClient) GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GarbageCollectResponse)
+	err := c.cc.Invoke(ctx, ContainerService_GarbageCollect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *containerServiceClient) StartContainer(ctx context.Context, in *StartContainerRequest, opts ...grpc.CallOption) (*StartContainerResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StartContainerResponse)
@@ -433,6 +508,16 @@ func (c *containerServiceClient) ToggleAutoSleep(ctx context.Context, in *Toggle
 	return out, nil
 }
 
+func (c *containerServiceClient) ToggleDNSLogging(ctx context.Context, in *ToggleDNSLoggingRequest, opts ...grpc.CallOption) (*ToggleDNSLoggingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ToggleDNSLoggingResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ToggleDNSLogging_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *containerServiceClient) SetContainerTTL(ctx context.Context, in *SetContainerTTLRequest, opts ...grpc.CallOption) (*SetContainerTTLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SetContainerTTLResponse)
@@ -533,6 +618,116 @@ func (c *containerServiceClient) CleanupDisk(ctx context.Context, in *CleanupDis
 	return out, nil
 }
 
+func (c *containerServiceClient) GetContainerProcesses(ctx context.Context, in *GetContainerProcessesRequest, opts ...grpc.CallOption) (*GetContainerProcessesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContainerProcessesResponse)
+	err := c.cc.Invoke(ctx, ContainerService_GetContainerProcesses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ReadContainerFile(ctx context.Context, in *ReadContainerFileRequest, opts ...grpc.CallOption) (*ReadContainerFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadContainerFileResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ReadContainerFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) WriteContainerFile(ctx context.Context, in *WriteContainerFileRequest, opts ...grpc.CallOption) (*WriteContainerFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteContainerFileResponse)
+	err := c.cc.Invoke(ctx, ContainerService_WriteContainerFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ListContainerDir(ctx context.Context, in *ListContainerDirRequest, opts ...grpc.CallOption) (*ListContainerDirResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListContainerDirResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ListContainerDir_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) DiffContainers(ctx context.Context, in *DiffContainersRequest, opts ...grpc.CallOption) (*DiffContainersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffContainersResponse)
+	err := c.cc.Invoke(ctx, ContainerService_DiffContainers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) TestConnectivity(ctx context.Context, in *TestConnectivityRequest, opts ...grpc.CallOption) (*TestConnectivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestConnectivityResponse)
+	err := c.cc.Invoke(ctx, ContainerService_TestConnectivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSnapshotResponse)
+	err := c.cc.Invoke(ctx, ContainerService_CreateSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ListSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) RestoreSnapshot(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*RestoreSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreSnapshotResponse)
+	err := c.cc.Invoke(ctx, ContainerService_RestoreSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) GetContainerActivity(ctx context.Context, in *GetContainerActivityRequest, opts ...grpc.CallOption) (*GetContainerActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContainerActivityResponse)
+	err := c.cc.Invoke(ctx, ContainerService_GetContainerActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) GetContainerReadiness(ctx context.Context, in *GetContainerReadinessRequest, opts ...grpc.CallOption) (*GetContainerReadinessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContainerReadinessResponse)
+	err := c.cc.Invoke(ctx, ContainerService_GetContainerReadiness_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *containerServiceClient) InstallStack(ctx context.Context, in *InstallStackRequest, opts ...grpc.CallOption) (*InstallStackResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(InstallStackResponse)
@@ -853,6 +1048,56 @@ func (c *containerServiceClient) RefreshSecrets(ctx context.Context, in *Refresh
 	return out, nil
 }
 
+func (c *containerServiceClient) SetContainerSecret(ctx context.Context, in *SetContainerSecretRequest, opts ...grpc.CallOption) (*SetContainerSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetContainerSecretResponse)
+	err := c.cc.Invoke(ctx, ContainerService_SetContainerSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ListContainerSecrets(ctx context.Context, in *ListContainerSecretsRequest, opts ...grpc.CallOption) (*ListContainerSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListContainerSecretsResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ListContainerSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) RemoveContainerSecret(ctx context.Context, in *RemoveContainerSecretRequest, opts ...grpc.CallOption) (*RemoveContainerSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveContainerSecretResponse)
+	err := c.cc.Invoke(ctx, ContainerService_RemoveContainerSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ListContainerTemplates(ctx context.Context, in *ListContainerTemplatesRequest, opts ...grpc.CallOption) (*ListContainerTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListContainerTemplatesResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ListContainerTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) GetContainerTemplate(ctx context.Context, in *GetContainerTemplateRequest, opts ...grpc.CallOption) (*GetContainerTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContainerTemplateResponse)
+	err := c.cc.Invoke(ctx, ContainerService_GetContainerTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ContainerServiceServer is the server API for ContainerService service.
 // All implementations must embed UnimplementedContainerServiceServer
 // for forward compatibility.
@@ -870,6 +1115,8 @@ type ContainerServiceServer interface {
 	DebugContainer(context.Context, *DebugContainerRequest) (*DebugContainerResponse, error)
 	// DeleteContainer deletes a container
 	DeleteContainer(context.Context, *DeleteContainerRequest) (*DeleteContainerResponse, error)
+	// GarbageCollect finds resources left behind by deleted containers
+	GarbageCollect(context.Context, *GarbageCollectRequest) (*GarbageCollectResponse, error)
 	// StartContainer starts a stopped container
 	StartContainer(context.Context, *StartContainerRequest) (*StartContainerResponse, error)
 	// StopContainer stops a running container
@@ -912,6 +1159,12 @@ type ContainerServiceServer interface {
 	// HTTP wake proxy are separate work — this RPC only sets the flag
 	// they will consume.
 	ToggleAutoSleep(context.Context, *ToggleAutoSleepRequest) (*ToggleAutoSleepResponse, error)
+	// ToggleDNSLogging opts a container into (or out of) DNS query
+	// logging. Writes the per-container Incus user.* key the traffic
+	// collector reads on its next cache refresh; queries from boxes that
+	// haven't opted in are dropped even while the daemon follows the
+	// resolver log (--traffic-dns-log).
+	ToggleDNSLogging(context.Context, *ToggleDNSLoggingRequest) (*ToggleDNSLoggingResponse, error)
 	// SetContainerTTL schedules or clears a container's auto-delete time.
 	// duration_seconds == 0 clears any existing TTL; > 0 sets
 	// ttl_expires_at to now() + duration. duration_seconds is capped at
@@ -955,6 +1208,30 @@ type ContainerServiceServer interface {
 	GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error)
 	// CleanupDisk frees disk space inside a container by removing temp files, package caches, and old logs
 	CleanupDisk(context.Context, *CleanupDiskRequest) (*CleanupDiskResponse, error)
+	// GetContainerProcesses lists the busiest processes inside a running container
+	GetContainerProcesses(context.Context, *GetContainerProcessesRequest) (*GetContainerProcessesResponse, error)
+	// ReadContainerFile reads a file inside a container through the Incus file API
+	ReadContainerFile(context.Context, *ReadContainerFileRequest) (*ReadContainerFileResponse, error)
+	// WriteContainerFile writes a file inside a container through the Incus file API
+	WriteContainerFile(context.Context, *WriteContainerFileRequest) (*WriteContainerFileResponse, error)
+	// ListContainerDir lists a directory inside a container through the Incus file API
+	ListContainerDir(context.Context, *ListContainerDirRequest) (*ListContainerDirResponse, error)
+	// DiffContainers compares two containers' configuration and software
+	DiffContainers(context.Context, *DiffContainersRequest) (*DiffContainersResponse, error)
+	// TestConnectivity probes a target from inside a container
+	TestConnectivity(context.Context, *TestConnectivityRequest) (*TestConnectivityResponse, error)
+	// CreateSnapshot takes an incus snapshot of a container
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotResponse, error)
+	// ListSnapshots lists a container's snapshots
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error)
+	// RestoreSnapshot rolls a container back to a snapshot
+	RestoreSnapshot(context.Context, *RestoreSnapshotRequest) (*RestoreSnapshotResponse, error)
+	// GetContainerActivity summarizes what happened to a container over a
+	// recent window
+	GetContainerActivity(context.Context, *GetContainerActivityRequest) (*GetContainerActivityResponse, error)
+	// GetContainerReadiness reports a container's provisioning progress and
+	// whether it is ready to use
+	GetContainerReadiness(context.Context, *GetContainerReadinessRequest) (*GetContainerReadinessResponse, error)
 	// InstallStack installs a software stack or base script on a running container
 	InstallStack(context.Context, *InstallStackRequest) (*InstallStackResponse, error)
 	// ListStacks returns all available software stacks and their parameter schemas.
@@ -1081,6 +1358,21 @@ type ContainerServiceServer interface {
 	// useful after rotation when the next exec'd process should see
 	// the new value without a full container restart.
 	RefreshSecrets(context.Context, *RefreshSecretsRequest) (*RefreshSecretsResponse, error)
+	// SetContainerSecret stores a tenant secret and stamps it into the
+	// tenant's container at once: as an environment key, or as a 0600
+	// file at a path the tenant chooses. Audit-logged without the value.
+	SetContainerSecret(context.Context, *SetContainerSecretRequest) (*SetContainerSecretResponse, error)
+	// ListContainerSecrets lists a tenant's secrets and where each one
+	// is materialized inside the container. Never returns values.
+	ListContainerSecrets(context.Context, *ListContainerSecretsRequest) (*ListContainerSecretsResponse, error)
+	// RemoveContainerSecret deletes a tenant secret and removes it from
+	// the container. Audit-logged.
+	RemoveContainerSecret(context.Context, *RemoveContainerSecretRequest) (*RemoveContainerSecretResponse, error)
+	// ListContainerTemplates lists the presets CreateContainer's template
+	// field accepts.
+	ListContainerTemplates(context.Context, *ListContainerTemplatesRequest) (*ListContainerTemplatesResponse, error)
+	// GetContainerTemplate returns one template.
+	GetContainerTemplate(context.Context, *GetContainerTemplateRequest) (*GetContainerTemplateResponse, error)
 	mustEmbedUnimplementedContainerServiceServer()
 }
 
@@ -1106,6 +1398,9 @@ func (UnimplementedContainerServiceServer) DebugContainer(context.Context, *Debu
 func (UnimplementedContainerServiceServer) DeleteContainer(context.Context, *DeleteContainerRequest) (*DeleteContainerResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteContainer not implemented")
 }
+func (UnimplementedContainerServiceServer) GarbageCollect(context.Context, *GarbageCollectRequest) (*GarbageCollectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GarbageCollect not implemented")
+}
 func (UnimplementedContainerServiceServer) StartContainer(context.Context, *StartContainerRequest) (*StartContainerResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method StartContainer not implemented")
 }
@@ -1127,6 +1422,9 @@ func (UnimplementedContainerServiceServer) ToggleMonitoring(context.Context, *To
 func (UnimplementedContainerServiceServer) ToggleAutoSleep(context.Context, *ToggleAutoSleepRequest) (*ToggleAutoSleepResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ToggleAutoSleep not implemented")
 }
+func (UnimplementedContainerServiceServer) ToggleDNSLogging(context.Context, *ToggleDNSLoggingRequest) (*ToggleDNSLoggingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ToggleDNSLogging not implemented")
+}
 func (UnimplementedContainerServiceServer) SetContainerTTL(context.Context, *SetContainerTTLRequest) (*SetContainerTTLResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SetContainerTTL not implemented")
 }
@@ -1157,6 +1455,39 @@ func (UnimplementedContainerServiceServer) GetMetrics(context.Context, *GetMetri
 func (UnimplementedContainerServiceServer) CleanupDisk(context.Context, *CleanupDiskRequest) (*CleanupDiskResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CleanupDisk not implemented")
 }
+func (UnimplementedContainerServiceServer) GetContainerProcesses(context.Context, *GetContainerProcessesRequest) (*GetContainerProcessesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetContainerProcesses not implemented")
+}
+func (UnimplementedContainerServiceServer) ReadContainerFile(context.Context, *ReadContainerFileRequest) (*ReadContainerFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadContainerFile not implemented")
+}
+func (UnimplementedContainerServiceServer) WriteContainerFile(context.Context, *WriteContainerFileRequest) (*WriteContainerFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WriteContainerFile not implemented")
+}
+func (UnimplementedContainerServiceServer) ListContainerDir(context.Context, *ListContainerDirRequest) (*ListContainerDirResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListContainerDir not implemented")
+}
+func (UnimplementedContainerServiceServer) DiffContainers(context.Context, *DiffContainersRequest) (*DiffContainersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffContainers not implemented")
+}
+func (UnimplementedContainerServiceServer) TestConnectivity(context.Context, *TestConnectivityRequest) (*TestConnectivityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TestConnectivity not implemented")
+}
+func (UnimplementedContainerServiceServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (UnimplementedContainerServiceServer) ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (UnimplementedContainerServiceServer) RestoreSnapshot(context.Context, *RestoreSnapshotRequest) (*RestoreSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreSnapshot not implemented")
+}
+func (UnimplementedContainerServiceServer) GetContainerActivity(context.Context, *GetContainerActivityRequest) (*GetContainerActivityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetContainerActivity not implemented")
+}
+func (UnimplementedContainerServiceServer) GetContainerReadiness(context.Context, *GetContainerReadinessRequest) (*GetContainerReadinessResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetContainerReadiness not implemented")
+}
 func (UnimplementedContainerServiceServer) InstallStack(context.Context, *InstallStackRequest) (*InstallStackResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method InstallStack not implemented")
 }
@@ -1253,6 +1584,21 @@ func (UnimplementedContainerServiceServer) DeleteSecret(context.Context, *Delete
 func (UnimplementedContainerServiceServer) RefreshSecrets(context.Context, *RefreshSecretsRequest) (*RefreshSecretsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RefreshSecrets not implemented")
 }
+func (UnimplementedContainerServiceServer) SetContainerSecret(context.Context, *SetContainerSecretRequest) (*SetContainerSecretResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetContainerSecret not implemented")
+}
+func (UnimplementedContainerServiceServer) ListContainerSecrets(context.Context, *ListContainerSecretsRequest) (*ListContainerSecretsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListContainerSecrets not implemented")
+}
+func (UnimplementedContainerServiceServer) RemoveContainerSecret(context.Context, *RemoveContainerSecretRequest) (*RemoveContainerSecretResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveContainerSecret not implemented")
+}
+func (UnimplementedContainerServiceServer) ListContainerTemplates(context.Context, *ListContainerTemplatesRequest) (*ListContainerTemplatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListContainerTemplates not implemented")
+}
+func (UnimplementedContainerServiceServer) GetContainerTemplate(context.Context, *GetContainerTemplateRequest) (*GetContainerTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetContainerTemplate not implemented")
+}
 func (UnimplementedContainerServiceServer) mustEmbedUnimplementedContainerServiceServer() {}
 func (UnimplementedContainerServiceServer) testEmbeddedByValue()                          {}
 
@@ -1364,6 +1710,24 @@ func _ContainerService_DeleteContainer_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_GarbageCollect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GarbageCollectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).GarbageCollect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_GarbageCollect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).GarbageCollect(ctx, req.(*GarbageCollectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ContainerService_StartContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartContainerRequest)
 	if err := dec(in); err != nil {
@@ -1490,6 +1854,24 @@ func _ContainerService_ToggleAutoSleep_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_ToggleDNSLogging_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleDNSLoggingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ToggleDNSLogging(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ToggleDNSLogging_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ToggleDNSLogging(ctx, req.(*ToggleDNSLoggingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ContainerService_SetContainerTTL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetContainerTTLRequest)
 	if err := dec(in); err != nil {
@@ -1670,6 +2052,204 @@ func _ContainerService_CleanupDisk_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_GetContainerProcesses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerProcessesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).GetContainerProcesses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_GetContainerProcesses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).GetContainerProcesses(ctx, req.(*GetContainerProcessesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ReadContainerFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadContainerFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ReadContainerFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ReadContainerFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ReadContainerFile(ctx, req.(*ReadContainerFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_WriteContainerFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteContainerFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).WriteContainerFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_WriteContainerFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).WriteContainerFile(ctx, req.(*WriteContainerFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ListContainerDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainerDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ListContainerDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ListContainerDir_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ListContainerDir(ctx, req.(*ListContainerDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_DiffContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).DiffContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_DiffContainers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).DiffContainers(ctx, req.(*DiffContainersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_TestConnectivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestConnectivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).TestConnectivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_TestConnectivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).TestConnectivity(ctx, req.(*TestConnectivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_CreateSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_RestoreSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).RestoreSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_RestoreSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).RestoreSnapshot(ctx, req.(*RestoreSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_GetContainerActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).GetContainerActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_GetContainerActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).GetContainerActivity(ctx, req.(*GetContainerActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_GetContainerReadiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerReadinessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).GetContainerReadiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_GetContainerReadiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).GetContainerReadiness(ctx, req.(*GetContainerReadinessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ContainerService_InstallStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InstallStackRequest)
 	if err := dec(in); err != nil {
@@ -2246,6 +2826,96 @@ func _ContainerService_RefreshSecrets_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_SetContainerSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContainerSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).SetContainerSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_SetContainerSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).SetContainerSecret(ctx, req.(*SetContainerSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ListContainerSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainerSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ListContainerSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ListContainerSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ListContainerSecrets(ctx, req.(*ListContainerSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_RemoveContainerSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveContainerSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).RemoveContainerSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_RemoveContainerSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).RemoveContainerSecret(ctx, req.(*RemoveContainerSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ListContainerTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainerTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ListContainerTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ListContainerTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ListContainerTemplates(ctx, req.(*ListContainerTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_GetContainerTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).GetContainerTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_GetContainerTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).GetContainerTemplate(ctx, req.(*GetContainerTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ContainerService_ServiceDesc is the grpc.ServiceDesc for ContainerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2273,6 +2943,10 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteContainer",
 			Handler:    _ContainerService_DeleteContainer_Handler,
 		},
+		{
+			MethodName: "GarbageCollect",
+			Handler:    _ContainerService_GarbageCollect_Handler,
+		},
 		{
 			MethodName: "StartContainer",
 			Handler:    _ContainerService_StartContainer_Handler,
@@ -2301,6 +2975,10 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ToggleAutoSleep",
 			Handler:    _ContainerService_ToggleAutoSleep_Handler,
 		},
+		{
+			MethodName: "ToggleDNSLogging",
+			Handler:    _ContainerService_ToggleDNSLogging_Handler,
+		},
 		{
 			MethodName: "SetContainerTTL",
 			Handler:    _ContainerService_SetContainerTTL_Handler,
@@ -2341,6 +3019,50 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CleanupDisk",
 			Handler:    _ContainerService_CleanupDisk_Handler,
 		},
+		{
+			MethodName: "GetContainerProcesses",
+			Handler:    _ContainerService_GetContainerProcesses_Handler,
+		},
+		{
+			MethodName: "ReadContainerFile",
+			Handler:    _ContainerService_ReadContainerFile_Handler,
+		},
+		{
+			MethodName: "WriteContainerFile",
+			Handler:    _ContainerService_WriteContainerFile_Handler,
+		},
+		{
+			MethodName: "ListContainerDir",
+			Handler:    _ContainerService_ListContainerDir_Handler,
+		},
+		{
+			MethodName: "DiffContainers",
+			Handler:    _ContainerService_DiffContainers_Handler,
+		},
+		{
+			MethodName: "TestConnectivity",
+			Handler:    _ContainerService_TestConnectivity_Handler,
+		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _ContainerService_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _ContainerService_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "RestoreSnapshot",
+			Handler:    _ContainerService_RestoreSnapshot_Handler,
+		},
+		{
+			MethodName: "GetContainerActivity",
+			Handler:    _ContainerService_GetContainerActivity_Handler,
+		},
+		{
+			MethodName: "GetContainerReadiness",
+			Handler:    _ContainerService_GetContainerReadiness_Handler,
+		},
 		{
 			MethodName: "InstallStack",
 			Handler:    _ContainerService_InstallStack_Handler,
@@ -2469,6 +3191,26 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshSecrets",
 			Handler:    _ContainerService_RefreshSecrets_Handler,
 		},
+		{
+			MethodName: "SetContainerSecret",
+			Handler:    _ContainerService_SetContainerSecret_Handler,
+		},
+		{
+			MethodName: "ListContainerSecrets",
+			Handler:    _ContainerService_ListContainerSecrets_Handler,
+		},
+		{
+			MethodName: "RemoveContainerSecret",
+			Handler:    _ContainerService_RemoveContainerSecret_Handler,
+		},
+		{
+			MethodName: "ListContainerTemplates",
+			Handler:    _ContainerService_ListContainerTemplates_Handler,
+		},
+		{
+			MethodName: "GetContainerTemplate",
+			Handler:    _ContainerService_GetContainerTemplate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "containarium/v1/service.proto",
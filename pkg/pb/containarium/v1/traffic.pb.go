@@ -36,6 +36,16 @@ const (
 	Protocol_PROTOCOL_UDP Protocol = 2
 	// ICMP protocol
 	Protocol_PROTOCOL_ICMP Protocol = 3
+	// SCTP protocol
+	Protocol_PROTOCOL_SCTP Protocol = 4
+	// ICMPv6 protocol
+	Protocol_PROTOCOL_ICMPV6 Protocol = 5
+	// DCCP protocol
+	Protocol_PROTOCOL_DCCP Protocol = 6
+	// UDP-Lite protocol
+	Protocol_PROTOCOL_UDPLITE Protocol = 7
+	// GRE tunnels
+	Protocol_PROTOCOL_GRE Protocol = 8
 )
 
 // Enum value maps for Protocol.
@@ -45,12 +55,22 @@ var (
 		1: "PROTOCOL_TCP",
 		2: "PROTOCOL_UDP",
 		3: "PROTOCOL_ICMP",
+		4: "PROTOCOL_SCTP",
+		5: "PROTOCOL_ICMPV6",
+		6: "PROTOCOL_DCCP",
+		7: "PROTOCOL_UDPLITE",
+		8: "PROTOCOL_GRE",
 	}
 	Protocol_value = map[string]int32{
 		"PROTOCOL_UNSPECIFIED": 0,
 		"PROTOCOL_TCP":         1,
 		"PROTOCOL_UDP":         2,
 		"PROTOCOL_ICMP":        3,
+		"PROTOCOL_SCTP":        4,
+		"PROTOCOL_ICMPV6":      5,
+		"PROTOCOL_DCCP":        6,
+		"PROTOCOL_UDPLITE":     7,
+		"PROTOCOL_GRE":         8,
 	}
 )
 
@@ -227,6 +247,11 @@ const (
 	TrafficEventType_TRAFFIC_EVENT_TYPE_UPDATE TrafficEventType = 2
 	// Connection terminated
 	TrafficEventType_TRAFFIC_EVENT_TYPE_DESTROY TrafficEventType = 3
+	// Connection attempt dropped by the container's network policy
+	TrafficEventType_TRAFFIC_EVENT_TYPE_BLOCKED TrafficEventType = 4
+	// Egress connection to a destination outside the container network and
+	// the egress allowlist; sent once per connection, when first seen
+	TrafficEventType_TRAFFIC_EVENT_TYPE_POLICY_VIOLATION TrafficEventType = 5
 )
 
 // Enum value maps for TrafficEventType.
@@ -236,12 +261,16 @@ var (
 		1: "TRAFFIC_EVENT_TYPE_NEW",
 		2: "TRAFFIC_EVENT_TYPE_UPDATE",
 		3: "TRAFFIC_EVENT_TYPE_DESTROY",
+		4: "TRAFFIC_EVENT_TYPE_BLOCKED",
+		5: "TRAFFIC_EVENT_TYPE_POLICY_VIOLATION",
 	}
 	TrafficEventType_value = map[string]int32{
-		"TRAFFIC_EVENT_TYPE_UNSPECIFIED": 0,
-		"TRAFFIC_EVENT_TYPE_NEW":         1,
-		"TRAFFIC_EVENT_TYPE_UPDATE":      2,
-		"TRAFFIC_EVENT_TYPE_DESTROY":     3,
+		"TRAFFIC_EVENT_TYPE_UNSPECIFIED":      0,
+		"TRAFFIC_EVENT_TYPE_NEW":              1,
+		"TRAFFIC_EVENT_TYPE_UPDATE":           2,
+		"TRAFFIC_EVENT_TYPE_DESTROY":          3,
+		"TRAFFIC_EVENT_TYPE_BLOCKED":          4,
+		"TRAFFIC_EVENT_TYPE_POLICY_VIOLATION": 5,
 	}
 )
 
@@ -272,6 +301,59 @@ func (TrafficEventType) EnumDescriptor() ([]byte, []int) {
 	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{3}
 }
 
+// ListenerChangeType says whether a listener appeared or went away
+type ListenerChangeType int32
+
+const (
+	// Unspecified change type
+	ListenerChangeType_LISTENER_CHANGE_TYPE_UNSPECIFIED ListenerChangeType = 0
+	// A listener appeared since the previous scan
+	ListenerChangeType_LISTENER_CHANGE_TYPE_OPENED ListenerChangeType = 1
+	// A listener was gone at this scan
+	ListenerChangeType_LISTENER_CHANGE_TYPE_CLOSED ListenerChangeType = 2
+)
+
+// Enum value maps for ListenerChangeType.
+var (
+	ListenerChangeType_name = map[int32]string{
+		0: "LISTENER_CHANGE_TYPE_UNSPECIFIED",
+		1: "LISTENER_CHANGE_TYPE_OPENED",
+		2: "LISTENER_CHANGE_TYPE_CLOSED",
+	}
+	ListenerChangeType_value = map[string]int32{
+		"LISTENER_CHANGE_TYPE_UNSPECIFIED": 0,
+		"LISTENER_CHANGE_TYPE_OPENED":      1,
+		"LISTENER_CHANGE_TYPE_CLOSED":      2,
+	}
+)
+
+func (x ListenerChangeType) Enum() *ListenerChangeType {
+	p := new(ListenerChangeType)
+	*p = x
+	return p
+}
+
+func (x ListenerChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListenerChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_traffic_proto_enumTypes[4].Descriptor()
+}
+
+func (ListenerChangeType) Type() protoreflect.EnumType {
+	return &file_containarium_v1_traffic_proto_enumTypes[4]
+}
+
+func (x ListenerChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListenerChangeType.Descriptor instead.
+func (ListenerChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{4}
+}
+
 // Connection represents an active or recent network connection
 type Connection struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -309,8 +391,61 @@ type Connection struct {
 	LastSeen *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
 	// TTL remaining in conntrack (seconds)
 	TimeoutSeconds int32 `protobuf:"varint,17,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Name of the process inside the container that owns the socket.
+	// Only populated by DescribeConnection (empty if it couldn't be resolved).
+	ProcessName string `protobuf:"bytes,18,opt,name=process_name,json=processName,proto3" json:"process_name,omitempty"`
+	// PID of that process in the container's PID namespace (0 if unknown)
+	Pid int32 `protobuf:"varint,19,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Conntrack zone the flow was tracked in (0 = default zone). Boxes
+	// isolated in separate zones can reuse the same private addresses, so
+	// the zone is part of what makes a connection unique.
+	Zone uint32 `protobuf:"varint,20,opt,name=zone,proto3" json:"zone,omitempty"`
+	// Username that owns the container (e.g. "alice" for alice-container),
+	// from the container's Incus metadata.
+	Username string `protobuf:"bytes,21,opt,name=username,proto3" json:"username,omitempty"`
+	// How many flows this row stands for when the daemon samples short
+	// flows (1-in-N). 0 or 1 means the flow was recorded as itself.
+	SampleWeight uint32 `protobuf:"varint,22,opt,name=sample_weight,json=sampleWeight,proto3" json:"sample_weight,omitempty"`
+	// Name the container resolved to dest_ip, taken from its own DNS queries
+	// when DNS logging is on (daemon --traffic-dns-log). Empty when the
+	// container never looked the address up or logging is off.
+	DestHostname string `protobuf:"bytes,23,opt,name=dest_hostname,json=destHostname,proto3" json:"dest_hostname,omitempty"`
+	// Bytes per second the container sent over the last snapshot interval
+	// (GetConnectionsResponse.snapshot_interval_seconds). Only set by
+	// GetConnections, once the connection is in two successive snapshots.
+	BytesSentPerSec *float64 `protobuf:"fixed64,24,opt,name=bytes_sent_per_sec,json=bytesSentPerSec,proto3,oneof" json:"bytes_sent_per_sec,omitempty"`
+	// Bytes per second the container received over the same interval
+	BytesReceivedPerSec *float64 `protobuf:"fixed64,25,opt,name=bytes_received_per_sec,json=bytesReceivedPerSec,proto3,oneof" json:"bytes_received_per_sec,omitempty"`
+	// Well-known service on dest_port, e.g. "https" or "ssh", from the
+	// daemon's port map (extended by --traffic-services-file). Looked up
+	// when the response is built, never stored; empty for unknown ports.
+	ServiceName string `protobuf:"bytes,26,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// ICMP type of an ICMP or ICMPv6 flow's first packet (8 for an echo
+	// request), from the conntrack tuple. ICMP has no ports, so this, the
+	// code and the identifier tell such flows apart.
+	IcmpType uint32 `protobuf:"varint,27,opt,name=icmp_type,json=icmpType,proto3" json:"icmp_type,omitempty"`
+	// ICMP code of an ICMP or ICMPv6 flow
+	IcmpCode uint32 `protobuf:"varint,28,opt,name=icmp_code,json=icmpCode,proto3" json:"icmp_code,omitempty"`
+	// ICMP identifier of an ICMP or ICMPv6 flow, e.g. the ping session
+	IcmpId uint32 `protobuf:"varint,29,opt,name=icmp_id,json=icmpId,proto3" json:"icmp_id,omitempty"`
+	// The container's network policy dropped this flow in enforce mode: it
+	// was attempted, and nothing got through. Only eBPF-sourced flows carry it.
+	Blocked bool `protobuf:"varint,30,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	// ISO 3166-1 alpha-2 country of the remote end (dest_ip for egress,
+	// source_ip for ingress), from the daemon's GeoIP database. Set when the
+	// connection closes, and only when a database knew the address.
+	RemoteCountry string `protobuf:"bytes,31,opt,name=remote_country,json=remoteCountry,proto3" json:"remote_country,omitempty"`
+	// Autonomous system announcing the remote end's address, set like
+	// remote_country (0 = unknown)
+	RemoteAsn uint32 `protobuf:"varint,32,opt,name=remote_asn,json=remoteAsn,proto3" json:"remote_asn,omitempty"`
+	// Organization remote_asn is registered to
+	RemoteAsOrg string `protobuf:"bytes,33,opt,name=remote_as_org,json=remoteAsOrg,proto3" json:"remote_as_org,omitempty"`
+	// An egress connection to a destination outside both the container
+	// network and the daemon's egress allowlist (--traffic-egress-allowlist).
+	// Always false when no allowlist is configured.
+	PolicyViolation bool `protobuf:"varint,34,opt,name=policy_violation,json=policyViolation,proto3" json:"policy_violation,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Connection) Reset() {
@@ -462,6 +597,125 @@ func (x *Connection) GetTimeoutSeconds() int32 {
 	return 0
 }
 
+func (x *Connection) GetProcessName() string {
+	if x != nil {
+		return x.ProcessName
+	}
+	return ""
+}
+
+func (x *Connection) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *Connection) GetZone() uint32 {
+	if x != nil {
+		return x.Zone
+	}
+	return 0
+}
+
+func (x *Connection) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Connection) GetSampleWeight() uint32 {
+	if x != nil {
+		return x.SampleWeight
+	}
+	return 0
+}
+
+func (x *Connection) GetDestHostname() string {
+	if x != nil {
+		return x.DestHostname
+	}
+	return ""
+}
+
+func (x *Connection) GetBytesSentPerSec() float64 {
+	if x != nil && x.BytesSentPerSec != nil {
+		return *x.BytesSentPerSec
+	}
+	return 0
+}
+
+func (x *Connection) GetBytesReceivedPerSec() float64 {
+	if x != nil && x.BytesReceivedPerSec != nil {
+		return *x.BytesReceivedPerSec
+	}
+	return 0
+}
+
+func (x *Connection) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Connection) GetIcmpType() uint32 {
+	if x != nil {
+		return x.IcmpType
+	}
+	return 0
+}
+
+func (x *Connection) GetIcmpCode() uint32 {
+	if x != nil {
+		return x.IcmpCode
+	}
+	return 0
+}
+
+func (x *Connection) GetIcmpId() uint32 {
+	if x != nil {
+		return x.IcmpId
+	}
+	return 0
+}
+
+func (x *Connection) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *Connection) GetRemoteCountry() string {
+	if x != nil {
+		return x.RemoteCountry
+	}
+	return ""
+}
+
+func (x *Connection) GetRemoteAsn() uint32 {
+	if x != nil {
+		return x.RemoteAsn
+	}
+	return 0
+}
+
+func (x *Connection) GetRemoteAsOrg() string {
+	if x != nil {
+		return x.RemoteAsOrg
+	}
+	return ""
+}
+
+func (x *Connection) GetPolicyViolation() bool {
+	if x != nil {
+		return x.PolicyViolation
+	}
+	return false
+}
+
 // TrafficEvent represents a real-time connection event
 type TrafficEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -543,8 +797,27 @@ type ConnectionSummary struct {
 	TotalBytesReceived int64 `protobuf:"varint,6,opt,name=total_bytes_received,json=totalBytesReceived,proto3" json:"total_bytes_received,omitempty"`
 	// Top destination IPs by connection count
 	TopDestinations []*DestinationStats `protobuf:"bytes,7,rep,name=top_destinations,json=topDestinations,proto3" json:"top_destinations,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Connections grouped by destination port
+	TopServices []*ServiceStats `protobuf:"bytes,8,rep,name=top_services,json=topServices,proto3" json:"top_services,omitempty"`
+	// DNS traffic (port 53, TCP or UDP) as one aggregated line. Set only when
+	// the request asked for separate_dns, in which case DNS is left out of
+	// every other field.
+	Dns *DNSStats `protobuf:"bytes,9,opt,name=dns,proto3" json:"dns,omitempty"`
+	// Active connections per state, keyed by the ConnectionState name
+	// without its prefix, e.g. "ESTABLISHED", "SYN_SENT", "TIME_WAIT".
+	// Connections conntrack tracks no state for (UDP, ICMP) are not counted.
+	// A SYN_SENT pileup means the box keeps failing to reach something.
+	StateCounts map[string]int32 `protobuf:"bytes,10,rep,name=state_counts,json=stateCounts,proto3" json:"state_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// Active ICMP connections, IPv4 and IPv6
+	IcmpConnections int32 `protobuf:"varint,11,opt,name=icmp_connections,json=icmpConnections,proto3" json:"icmp_connections,omitempty"`
+	// Bytes per second the container sends, summed over the connections in
+	// the two latest snapshots (see Connection.bytes_sent_per_sec); a
+	// connection seen only once adds nothing yet.
+	BytesSentPerSec float64 `protobuf:"fixed64,12,opt,name=bytes_sent_per_sec,json=bytesSentPerSec,proto3" json:"bytes_sent_per_sec,omitempty"`
+	// Bytes per second the container receives, summed the same way
+	BytesReceivedPerSec float64 `protobuf:"fixed64,13,opt,name=bytes_received_per_sec,json=bytesReceivedPerSec,proto3" json:"bytes_received_per_sec,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *ConnectionSummary) Reset() {
@@ -626,6 +899,194 @@ func (x *ConnectionSummary) GetTopDestinations() []*DestinationStats {
 	return nil
 }
 
+func (x *ConnectionSummary) GetTopServices() []*ServiceStats {
+	if x != nil {
+		return x.TopServices
+	}
+	return nil
+}
+
+func (x *ConnectionSummary) GetDns() *DNSStats {
+	if x != nil {
+		return x.Dns
+	}
+	return nil
+}
+
+func (x *ConnectionSummary) GetStateCounts() map[string]int32 {
+	if x != nil {
+		return x.StateCounts
+	}
+	return nil
+}
+
+func (x *ConnectionSummary) GetIcmpConnections() int32 {
+	if x != nil {
+		return x.IcmpConnections
+	}
+	return 0
+}
+
+func (x *ConnectionSummary) GetBytesSentPerSec() float64 {
+	if x != nil {
+		return x.BytesSentPerSec
+	}
+	return 0
+}
+
+func (x *ConnectionSummary) GetBytesReceivedPerSec() float64 {
+	if x != nil {
+		return x.BytesReceivedPerSec
+	}
+	return 0
+}
+
+// DNSStats aggregates a container's DNS lookups in a connection summary
+type DNSStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Active DNS connections
+	ConnectionCount int32 `protobuf:"varint,1,opt,name=connection_count,json=connectionCount,proto3" json:"connection_count,omitempty"`
+	// Total bytes transferred over them
+	BytesTotal int64 `protobuf:"varint,2,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	// Resolvers the container queried, sorted
+	Resolvers     []string `protobuf:"bytes,3,rep,name=resolvers,proto3" json:"resolvers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DNSStats) Reset() {
+	*x = DNSStats{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DNSStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSStats) ProtoMessage() {}
+
+func (x *DNSStats) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSStats.ProtoReflect.Descriptor instead.
+func (*DNSStats) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DNSStats) GetConnectionCount() int32 {
+	if x != nil {
+		return x.ConnectionCount
+	}
+	return 0
+}
+
+func (x *DNSStats) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+func (x *DNSStats) GetResolvers() []string {
+	if x != nil {
+		return x.Resolvers
+	}
+	return nil
+}
+
+// ServiceStats provides traffic statistics for a destination port
+type ServiceStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Destination port
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// Protocol of the connections
+	Protocol Protocol `protobuf:"varint,2,opt,name=protocol,proto3,enum=containarium.v1.Protocol" json:"protocol,omitempty"`
+	// Well-known service on the port (see Connection.service_name)
+	ServiceName string `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Number of connections to this port
+	ConnectionCount int32 `protobuf:"varint,4,opt,name=connection_count,json=connectionCount,proto3" json:"connection_count,omitempty"`
+	// Total bytes transferred over them
+	BytesTotal    int64 `protobuf:"varint,5,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceStats) Reset() {
+	*x = ServiceStats{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceStats) ProtoMessage() {}
+
+func (x *ServiceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceStats.ProtoReflect.Descriptor instead.
+func (*ServiceStats) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ServiceStats) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ServiceStats) GetProtocol() Protocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return Protocol_PROTOCOL_UNSPECIFIED
+}
+
+func (x *ServiceStats) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceStats) GetConnectionCount() int32 {
+	if x != nil {
+		return x.ConnectionCount
+	}
+	return 0
+}
+
+func (x *ServiceStats) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
 // DestinationStats provides traffic statistics for a destination
 type DestinationStats struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -641,7 +1102,7 @@ type DestinationStats struct {
 
 func (x *DestinationStats) Reset() {
 	*x = DestinationStats{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[3]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -653,7 +1114,7 @@ func (x *DestinationStats) String() string {
 func (*DestinationStats) ProtoMessage() {}
 
 func (x *DestinationStats) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[3]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -666,7 +1127,7 @@ func (x *DestinationStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DestinationStats.ProtoReflect.Descriptor instead.
 func (*DestinationStats) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{3}
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DestinationStats) GetDestIp() string {
@@ -719,13 +1180,47 @@ type HistoricalConnection struct {
 	EndedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
 	// Duration in seconds
 	DurationSeconds int64 `protobuf:"varint,13,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// TCP state when the connection closed (UNSPECIFIED for UDP/ICMP, rows
+	// recorded before the column existed, and still-open connections).
+	// SYN_SENT means the destination never answered.
+	FinalState ConnectionState `protobuf:"varint,14,opt,name=final_state,json=finalState,proto3,enum=containarium.v1.ConnectionState" json:"final_state,omitempty"`
+	// Why the connection ended, inferred from final_state and the reply
+	// counters: completed, idle_timeout, refused_by_peer,
+	// refused_by_container, no_reply_from_peer, no_reply_from_container,
+	// handshake_incomplete, blocked_by_policy. Empty when unknown.
+	CloseReason string `protobuf:"bytes,15,opt,name=close_reason,json=closeReason,proto3" json:"close_reason,omitempty"`
+	// Conntrack zone the flow was tracked in (0 = default zone)
+	Zone uint32 `protobuf:"varint,16,opt,name=zone,proto3" json:"zone,omitempty"`
+	// Username that owns the container
+	Username string `protobuf:"bytes,17,opt,name=username,proto3" json:"username,omitempty"`
+	// Number of flows this row represents: 1 unless the flow was kept by
+	// 1-in-N sampling, in which case its bytes were scaled by this weight
+	// in aggregates.
+	SampleWeight uint32 `protobuf:"varint,18,opt,name=sample_weight,json=sampleWeight,proto3" json:"sample_weight,omitempty"`
+	// Name the container resolved dest_ip from, when DNS logging was on
+	// (see Connection.dest_hostname)
+	DestHostname string `protobuf:"bytes,19,opt,name=dest_hostname,json=destHostname,proto3" json:"dest_hostname,omitempty"`
+	// SSH login this connection carried, for ingress connections to port
+	// 22 when SSH session logging is on (daemon --traffic-ssh-log). Matched
+	// when read, by container, client address and time.
+	SshSession *SSHSession `protobuf:"bytes,20,opt,name=ssh_session,json=sshSession,proto3" json:"ssh_session,omitempty"`
+	// Country of the remote end when the connection closed (see
+	// Connection.remote_country); empty when it wasn't enriched
+	RemoteCountry string `protobuf:"bytes,21,opt,name=remote_country,json=remoteCountry,proto3" json:"remote_country,omitempty"`
+	// Autonomous system of the remote end (0 = not enriched)
+	RemoteAsn uint32 `protobuf:"varint,22,opt,name=remote_asn,json=remoteAsn,proto3" json:"remote_asn,omitempty"`
+	// Organization remote_asn is registered to
+	RemoteAsOrg string `protobuf:"bytes,23,opt,name=remote_as_org,json=remoteAsOrg,proto3" json:"remote_as_org,omitempty"`
+	// The connection went outside the egress allowlist (see
+	// Connection.policy_violation)
+	PolicyViolation bool `protobuf:"varint,24,opt,name=policy_violation,json=policyViolation,proto3" json:"policy_violation,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *HistoricalConnection) Reset() {
 	*x = HistoricalConnection{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[4]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -737,7 +1232,7 @@ func (x *HistoricalConnection) String() string {
 func (*HistoricalConnection) ProtoMessage() {}
 
 func (x *HistoricalConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[4]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +1245,7 @@ func (x *HistoricalConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HistoricalConnection.ProtoReflect.Descriptor instead.
 func (*HistoricalConnection) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{4}
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *HistoricalConnection) GetId() int64 {
@@ -844,6 +1339,83 @@ func (x *HistoricalConnection) GetDurationSeconds() int64 {
 	return 0
 }
 
+func (x *HistoricalConnection) GetFinalState() ConnectionState {
+	if x != nil {
+		return x.FinalState
+	}
+	return ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+func (x *HistoricalConnection) GetCloseReason() string {
+	if x != nil {
+		return x.CloseReason
+	}
+	return ""
+}
+
+func (x *HistoricalConnection) GetZone() uint32 {
+	if x != nil {
+		return x.Zone
+	}
+	return 0
+}
+
+func (x *HistoricalConnection) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *HistoricalConnection) GetSampleWeight() uint32 {
+	if x != nil {
+		return x.SampleWeight
+	}
+	return 0
+}
+
+func (x *HistoricalConnection) GetDestHostname() string {
+	if x != nil {
+		return x.DestHostname
+	}
+	return ""
+}
+
+func (x *HistoricalConnection) GetSshSession() *SSHSession {
+	if x != nil {
+		return x.SshSession
+	}
+	return nil
+}
+
+func (x *HistoricalConnection) GetRemoteCountry() string {
+	if x != nil {
+		return x.RemoteCountry
+	}
+	return ""
+}
+
+func (x *HistoricalConnection) GetRemoteAsn() uint32 {
+	if x != nil {
+		return x.RemoteAsn
+	}
+	return 0
+}
+
+func (x *HistoricalConnection) GetRemoteAsOrg() string {
+	if x != nil {
+		return x.RemoteAsOrg
+	}
+	return ""
+}
+
+func (x *HistoricalConnection) GetPolicyViolation() bool {
+	if x != nil {
+		return x.PolicyViolation
+	}
+	return false
+}
+
 // TrafficAggregate provides time-series aggregated traffic data
 type TrafficAggregate struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -859,13 +1431,36 @@ type TrafficAggregate struct {
 	BytesReceived int64 `protobuf:"varint,5,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
 	// Number of connections in this interval
 	ConnectionCount int32 `protobuf:"varint,6,opt,name=connection_count,json=connectionCount,proto3" json:"connection_count,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Connection direction (if grouped by direction). Connections recorded
+	// without a direction stay UNSPECIFIED; they are never reclassified.
+	Direction TrafficDirection `protobuf:"varint,7,opt,name=direction,proto3,enum=containarium.v1.TrafficDirection" json:"direction,omitempty"`
+	// Bytes (sent + received) on connections initiated towards the container
+	IngressBytes int64 `protobuf:"varint,8,opt,name=ingress_bytes,json=ingressBytes,proto3" json:"ingress_bytes,omitempty"`
+	// Bytes (sent + received) on connections the container initiated
+	EgressBytes int64 `protobuf:"varint,9,opt,name=egress_bytes,json=egressBytes,proto3" json:"egress_bytes,omitempty"`
+	// Container the row covers; set only when several containers were
+	// requested, each of which gets its own rows.
+	ContainerName string `protobuf:"bytes,10,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Name the destination was resolved from (see Connection.dest_hostname),
+	// when grouped by dest_ip and DNS logging supplied one
+	DestHostname string `protobuf:"bytes,11,opt,name=dest_hostname,json=destHostname,proto3" json:"dest_hostname,omitempty"`
+	// Country of the remote end (if grouped by country). Empty for
+	// connections that weren't enriched: internal peers, addresses the GeoIP
+	// database doesn't know, and connections that closed while no database
+	// was loaded.
+	RemoteCountry string `protobuf:"bytes,12,opt,name=remote_country,json=remoteCountry,proto3" json:"remote_country,omitempty"`
+	// Autonomous system of the remote end (if grouped by ASN; 0 = not
+	// enriched)
+	RemoteAsn uint32 `protobuf:"varint,13,opt,name=remote_asn,json=remoteAsn,proto3" json:"remote_asn,omitempty"`
+	// Organization remote_asn is registered to (if grouped by ASN)
+	RemoteAsOrg   string `protobuf:"bytes,14,opt,name=remote_as_org,json=remoteAsOrg,proto3" json:"remote_as_org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TrafficAggregate) Reset() {
 	*x = TrafficAggregate{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[5]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -877,7 +1472,7 @@ func (x *TrafficAggregate) String() string {
 func (*TrafficAggregate) ProtoMessage() {}
 
 func (x *TrafficAggregate) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[5]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -890,7 +1485,7 @@ func (x *TrafficAggregate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrafficAggregate.ProtoReflect.Descriptor instead.
 func (*TrafficAggregate) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{5}
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TrafficAggregate) GetTimestamp() *timestamppb.Timestamp {
@@ -935,6 +1530,62 @@ func (x *TrafficAggregate) GetConnectionCount() int32 {
 	return 0
 }
 
+func (x *TrafficAggregate) GetDirection() TrafficDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return TrafficDirection_TRAFFIC_DIRECTION_UNSPECIFIED
+}
+
+func (x *TrafficAggregate) GetIngressBytes() int64 {
+	if x != nil {
+		return x.IngressBytes
+	}
+	return 0
+}
+
+func (x *TrafficAggregate) GetEgressBytes() int64 {
+	if x != nil {
+		return x.EgressBytes
+	}
+	return 0
+}
+
+func (x *TrafficAggregate) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *TrafficAggregate) GetDestHostname() string {
+	if x != nil {
+		return x.DestHostname
+	}
+	return ""
+}
+
+func (x *TrafficAggregate) GetRemoteCountry() string {
+	if x != nil {
+		return x.RemoteCountry
+	}
+	return ""
+}
+
+func (x *TrafficAggregate) GetRemoteAsn() uint32 {
+	if x != nil {
+		return x.RemoteAsn
+	}
+	return 0
+}
+
+func (x *TrafficAggregate) GetRemoteAsOrg() string {
+	if x != nil {
+		return x.RemoteAsOrg
+	}
+	return ""
+}
+
 // GetConnectionsRequest retrieves active connections for a container
 type GetConnectionsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -947,14 +1598,35 @@ type GetConnectionsRequest struct {
 	// Filter by destination port (optional, 0 = all)
 	DestPort uint32 `protobuf:"varint,4,opt,name=dest_port,json=destPort,proto3" json:"dest_port,omitempty"`
 	// Maximum number of connections to return (default: 100)
-	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only connections whose bytes sent + received reach this (optional,
+	// 0 = all). When set, the result is ordered heaviest first, so with
+	// limit it returns the top talkers.
+	MinBytes int64 `protobuf:"varint,6,opt,name=min_bytes,json=minBytes,proto3" json:"min_bytes,omitempty"`
+	// Only connections open at least this long, measured from first_seen
+	// (optional, 0 = all). Finds stuck or unexpectedly long-lived flows.
+	MinAgeSeconds int64 `protobuf:"varint,7,opt,name=min_age_seconds,json=minAgeSeconds,proto3" json:"min_age_seconds,omitempty"`
+	// Only connections moving at least this many bytes per second, sent +
+	// received (optional, 0 = all). Connections without a rate yet are left
+	// out. When set, the result is ordered fastest first.
+	MinRateBps float64 `protobuf:"fixed64,8,opt,name=min_rate_bps,json=minRateBps,proto3" json:"min_rate_bps,omitempty"`
+	// Only connections to this well-known service, e.g. "dns" or "https"
+	// (optional). Resolved against the same port map as
+	// Connection.service_name, case-insensitively; a name the map doesn't
+	// know is an error. Combine with protocol for one side of a service that
+	// runs over both, such as dns.
+	ServiceName string `protobuf:"bytes,9,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Take a conntrack snapshot for this query even if one was taken within
+	// the daemon's snapshot debounce, for when the listing must be current
+	// rather than up to a debounce window old (optional).
+	Fresh         bool `protobuf:"varint,10,opt,name=fresh,proto3" json:"fresh,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetConnectionsRequest) Reset() {
 	*x = GetConnectionsRequest{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[6]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -966,7 +1638,7 @@ func (x *GetConnectionsRequest) String() string {
 func (*GetConnectionsRequest) ProtoMessage() {}
 
 func (x *GetConnectionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[6]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -979,7 +1651,7 @@ func (x *GetConnectionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConnectionsRequest.ProtoReflect.Descriptor instead.
 func (*GetConnectionsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{6}
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetConnectionsRequest) GetContainerName() string {
@@ -1017,19 +1689,62 @@ func (x *GetConnectionsRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *GetConnectionsRequest) GetMinBytes() int64 {
+	if x != nil {
+		return x.MinBytes
+	}
+	return 0
+}
+
+func (x *GetConnectionsRequest) GetMinAgeSeconds() int64 {
+	if x != nil {
+		return x.MinAgeSeconds
+	}
+	return 0
+}
+
+func (x *GetConnectionsRequest) GetMinRateBps() float64 {
+	if x != nil {
+		return x.MinRateBps
+	}
+	return 0
+}
+
+func (x *GetConnectionsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *GetConnectionsRequest) GetFresh() bool {
+	if x != nil {
+		return x.Fresh
+	}
+	return false
+}
+
 type GetConnectionsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Active connections
 	Connections []*Connection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
 	// Total count (may be more than returned if limit applied)
-	TotalCount    int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	TotalCount int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// State of the container listing connections are attributed from
+	Cache *ContainerCacheStatus `protobuf:"bytes,3,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Seconds between the last two conntrack snapshots: the window the
+	// connections' per-second rates are measured over. 0 until two
+	// snapshots have been taken. Snapshots are taken on each query (at most
+	// one a second) and periodically, so the window follows how often
+	// clients poll.
+	SnapshotIntervalSeconds float64 `protobuf:"fixed64,4,opt,name=snapshot_interval_seconds,json=snapshotIntervalSeconds,proto3" json:"snapshot_interval_seconds,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *GetConnectionsResponse) Reset() {
 	*x = GetConnectionsResponse{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[7]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1041,7 +1756,7 @@ func (x *GetConnectionsResponse) String() string {
 func (*GetConnectionsResponse) ProtoMessage() {}
 
 func (x *GetConnectionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[7]
+	mi := &file_containarium_v1_traffic_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1054,7 +1769,7 @@ func (x *GetConnectionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConnectionsResponse.ProtoReflect.Descriptor instead.
 func (*GetConnectionsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{7}
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetConnectionsResponse) GetConnections() []*Connection {
@@ -1071,30 +1786,62 @@ func (x *GetConnectionsResponse) GetTotalCount() int32 {
 	return 0
 }
 
-// GetConnectionSummaryRequest retrieves aggregate connection statistics
-type GetConnectionSummaryRequest struct {
+func (x *GetConnectionsResponse) GetCache() *ContainerCacheStatus {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+func (x *GetConnectionsResponse) GetSnapshotIntervalSeconds() float64 {
+	if x != nil {
+		return x.SnapshotIntervalSeconds
+	}
+	return 0
+}
+
+// ContainerCacheStatus describes the daemon's cached Incus container
+// listing, which maps connection IPs to containers. When Incus fails
+// repeatedly a circuit breaker stops calling it for a cooldown and the
+// last good listing keeps being used.
+type ContainerCacheStatus struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Container name (required)
-	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Refreshes are failing; attribution uses the listing taken at
+	// last_refresh_time, so containers created or re-addressed since are
+	// missed
+	Stale bool `protobuf:"varint,1,opt,name=stale,proto3" json:"stale,omitempty"`
+	// The breaker is open: refreshes fail without calling Incus until
+	// retry_time
+	BreakerOpen bool `protobuf:"varint,2,opt,name=breaker_open,json=breakerOpen,proto3" json:"breaker_open,omitempty"`
+	// Refreshes failed in a row
+	ConsecutiveFailures int32 `protobuf:"varint,3,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// Last successful refresh (unset before the first)
+	LastRefreshTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_refresh_time,json=lastRefreshTime,proto3" json:"last_refresh_time,omitempty"`
+	// When the open breaker next lets a refresh through
+	RetryTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=retry_time,json=retryTime,proto3" json:"retry_time,omitempty"`
+	// Error from the last failed refresh (admins only)
+	LastError string `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// Containers in the listing
+	Containers    int32 `protobuf:"varint,7,opt,name=containers,proto3" json:"containers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConnectionSummaryRequest) Reset() {
-	*x = GetConnectionSummaryRequest{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[8]
+func (x *ContainerCacheStatus) Reset() {
+	*x = ContainerCacheStatus{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConnectionSummaryRequest) String() string {
+func (x *ContainerCacheStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConnectionSummaryRequest) ProtoMessage() {}
+func (*ContainerCacheStatus) ProtoMessage() {}
 
-func (x *GetConnectionSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[8]
+func (x *ContainerCacheStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1105,41 +1852,83 @@ func (x *GetConnectionSummaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConnectionSummaryRequest.ProtoReflect.Descriptor instead.
-func (*GetConnectionSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ContainerCacheStatus.ProtoReflect.Descriptor instead.
+func (*ContainerCacheStatus) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetConnectionSummaryRequest) GetContainerName() string {
+func (x *ContainerCacheStatus) GetStale() bool {
 	if x != nil {
-		return x.ContainerName
+		return x.Stale
+	}
+	return false
+}
+
+func (x *ContainerCacheStatus) GetBreakerOpen() bool {
+	if x != nil {
+		return x.BreakerOpen
+	}
+	return false
+}
+
+func (x *ContainerCacheStatus) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *ContainerCacheStatus) GetLastRefreshTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRefreshTime
+	}
+	return nil
+}
+
+func (x *ContainerCacheStatus) GetRetryTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetryTime
+	}
+	return nil
+}
+
+func (x *ContainerCacheStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
 	}
 	return ""
 }
 
-type GetConnectionSummaryResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Connection summary
-	Summary       *ConnectionSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+func (x *ContainerCacheStatus) GetContainers() int32 {
+	if x != nil {
+		return x.Containers
+	}
+	return 0
+}
+
+// ListTrafficContainersRequest lists the containers the traffic collector
+// attributes connections to
+type ListTrafficContainersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConnectionSummaryResponse) Reset() {
-	*x = GetConnectionSummaryResponse{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[9]
+func (x *ListTrafficContainersRequest) Reset() {
+	*x = ListTrafficContainersRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConnectionSummaryResponse) String() string {
+func (x *ListTrafficContainersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConnectionSummaryResponse) ProtoMessage() {}
+func (*ListTrafficContainersRequest) ProtoMessage() {}
 
-func (x *GetConnectionSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[9]
+func (x *ListTrafficContainersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1150,46 +1939,37 @@ func (x *GetConnectionSummaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConnectionSummaryResponse.ProtoReflect.Descriptor instead.
-func (*GetConnectionSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *GetConnectionSummaryResponse) GetSummary() *ConnectionSummary {
-	if x != nil {
-		return x.Summary
-	}
-	return nil
+// Deprecated: Use ListTrafficContainersRequest.ProtoReflect.Descriptor instead.
+func (*ListTrafficContainersRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{11}
 }
 
-// SubscribeTrafficRequest configures real-time traffic event subscription
-type SubscribeTrafficRequest struct {
+type ListTrafficContainersResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Container name (optional, empty = all containers)
-	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
-	// Event types to include (empty = all types)
-	EventTypes []TrafficEventType `protobuf:"varint,2,rep,packed,name=event_types,json=eventTypes,proto3,enum=containarium.v1.TrafficEventType" json:"event_types,omitempty"`
-	// Only include external traffic (exclude inter-container)
-	ExternalOnly  bool `protobuf:"varint,3,opt,name=external_only,json=externalOnly,proto3" json:"external_only,omitempty"`
+	// Containers in the collector's listing, sorted by name. Tenants see
+	// only their own.
+	Containers []*TrafficContainer `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	// State of the listing, including when it was last refreshed
+	Cache         *ContainerCacheStatus `protobuf:"bytes,2,opt,name=cache,proto3" json:"cache,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeTrafficRequest) Reset() {
-	*x = SubscribeTrafficRequest{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[10]
+func (x *ListTrafficContainersResponse) Reset() {
+	*x = ListTrafficContainersResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeTrafficRequest) String() string {
+func (x *ListTrafficContainersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeTrafficRequest) ProtoMessage() {}
+func (*ListTrafficContainersResponse) ProtoMessage() {}
 
-func (x *SubscribeTrafficRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[10]
+func (x *ListTrafficContainersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1200,68 +1980,2521 @@ func (x *SubscribeTrafficRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeTrafficRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeTrafficRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListTrafficContainersResponse.ProtoReflect.Descriptor instead.
+func (*ListTrafficContainersResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SubscribeTrafficRequest) GetContainerName() string {
+func (x *ListTrafficContainersResponse) GetContainers() []*TrafficContainer {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *ListTrafficContainersResponse) GetCache() *ContainerCacheStatus {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+// TrafficContainer is a container the traffic collector tracks
+type TrafficContainer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Container IP address connections are attributed by
+	IpAddress     string `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrafficContainer) Reset() {
+	*x = TrafficContainer{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficContainer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficContainer) ProtoMessage() {}
+
+func (x *TrafficContainer) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficContainer.ProtoReflect.Descriptor instead.
+func (*TrafficContainer) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TrafficContainer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrafficContainer) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+// GetTrafficStatusRequest reports the state of the traffic collector
+type GetTrafficStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrafficStatusRequest) Reset() {
+	*x = GetTrafficStatusRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrafficStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrafficStatusRequest) ProtoMessage() {}
+
+func (x *GetTrafficStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrafficStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTrafficStatusRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{14}
+}
+
+type GetTrafficStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Conntrack monitoring is running on this backend
+	Available bool `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	// Why monitoring is unavailable, when it isn't
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Closed connections are kept in the history store
+	PersistenceEnabled bool `protobuf:"varint,3,opt,name=persistence_enabled,json=persistenceEnabled,proto3" json:"persistence_enabled,omitempty"`
+	// GeoIP enrichment of closed connections
+	Geoip *GeoIPStatus `protobuf:"bytes,4,opt,name=geoip,proto3" json:"geoip,omitempty"`
+	// Problems an operator should look at, e.g. a missing or stale GeoIP
+	// database. None of them stop connections from being recorded.
+	Warnings      []string `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrafficStatusResponse) Reset() {
+	*x = GetTrafficStatusResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrafficStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrafficStatusResponse) ProtoMessage() {}
+
+func (x *GetTrafficStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrafficStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetTrafficStatusResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTrafficStatusResponse) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *GetTrafficStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetTrafficStatusResponse) GetPersistenceEnabled() bool {
+	if x != nil {
+		return x.PersistenceEnabled
+	}
+	return false
+}
+
+func (x *GetTrafficStatusResponse) GetGeoip() *GeoIPStatus {
+	if x != nil {
+		return x.Geoip
+	}
+	return nil
+}
+
+func (x *GetTrafficStatusResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// GeoIPStatus describes the GeoIP databases that annotate closed
+// connections with the remote end's country and autonomous system
+type GeoIPStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Databases are configured (daemon --traffic-geoip-db)
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// At least one database is loaded and annotating connections
+	Active bool `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	// Each configured database
+	Databases     []*GeoIPDatabase `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoIPStatus) Reset() {
+	*x = GeoIPStatus{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoIPStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoIPStatus) ProtoMessage() {}
+
+func (x *GeoIPStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoIPStatus.ProtoReflect.Descriptor instead.
+func (*GeoIPStatus) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GeoIPStatus) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GeoIPStatus) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *GeoIPStatus) GetDatabases() []*GeoIPDatabase {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+// GeoIPDatabase is one configured MMDB file
+type GeoIPDatabase struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// File path on the daemon host
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// The file's database_type, e.g. "GeoLite2-ASN"
+	DatabaseType string `protobuf:"bytes,2,opt,name=database_type,json=databaseType,proto3" json:"database_type,omitempty"`
+	// When the database was built
+	BuildTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	// The database is in use. False when it is missing, unreadable or
+	// stale; connections then close without what it would have added.
+	Loaded bool `protobuf:"varint,4,opt,name=loaded,proto3" json:"loaded,omitempty"`
+	// The database is older than the daemon accepts and is not used
+	Stale bool `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`
+	// Why the database is not loaded
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// When the file was last (re)loaded
+	LoadedTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=loaded_time,json=loadedTime,proto3" json:"loaded_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoIPDatabase) Reset() {
+	*x = GeoIPDatabase{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoIPDatabase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoIPDatabase) ProtoMessage() {}
+
+func (x *GeoIPDatabase) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoIPDatabase.ProtoReflect.Descriptor instead.
+func (*GeoIPDatabase) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GeoIPDatabase) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GeoIPDatabase) GetDatabaseType() string {
+	if x != nil {
+		return x.DatabaseType
+	}
+	return ""
+}
+
+func (x *GeoIPDatabase) GetBuildTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildTime
+	}
+	return nil
+}
+
+func (x *GeoIPDatabase) GetLoaded() bool {
+	if x != nil {
+		return x.Loaded
+	}
+	return false
+}
+
+func (x *GeoIPDatabase) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GeoIPDatabase) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GeoIPDatabase) GetLoadedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LoadedTime
+	}
+	return nil
+}
+
+// GetConnectionSummaryRequest retrieves aggregate connection statistics
+type GetConnectionSummaryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name (required)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Report DNS (port 53) as its own summary.dns line instead of counting
+	// it in the active connections, byte totals, top destinations and top
+	// services, where constant lookups drown out the interesting peers
+	SeparateDns   bool `protobuf:"varint,2,opt,name=separate_dns,json=separateDns,proto3" json:"separate_dns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionSummaryRequest) Reset() {
+	*x = GetConnectionSummaryRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionSummaryRequest) ProtoMessage() {}
+
+func (x *GetConnectionSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetConnectionSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetConnectionSummaryRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *GetConnectionSummaryRequest) GetSeparateDns() bool {
+	if x != nil {
+		return x.SeparateDns
+	}
+	return false
+}
+
+type GetConnectionSummaryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Connection summary
+	Summary       *ConnectionSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionSummaryResponse) Reset() {
+	*x = GetConnectionSummaryResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionSummaryResponse) ProtoMessage() {}
+
+func (x *GetConnectionSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetConnectionSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetConnectionSummaryResponse) GetSummary() *ConnectionSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+// DescribeConnectionRequest resolves which process inside the container
+// owns an active connection
+type DescribeConnectionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name (required)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Connection ID as returned by GetConnections (required)
+	ConnectionId  string `protobuf:"bytes,2,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeConnectionRequest) Reset() {
+	*x = DescribeConnectionRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeConnectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeConnectionRequest) ProtoMessage() {}
+
+func (x *DescribeConnectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeConnectionRequest.ProtoReflect.Descriptor instead.
+func (*DescribeConnectionRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DescribeConnectionRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *DescribeConnectionRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type DescribeConnectionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The connection with process_name / pid populated when resolvable
+	Connection    *Connection `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeConnectionResponse) Reset() {
+	*x = DescribeConnectionResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeConnectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeConnectionResponse) ProtoMessage() {}
+
+func (x *DescribeConnectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeConnectionResponse.ProtoReflect.Descriptor instead.
+func (*DescribeConnectionResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DescribeConnectionResponse) GetConnection() *Connection {
+	if x != nil {
+		return x.Connection
+	}
+	return nil
+}
+
+// ConnectionStateChange is one recorded state of a connection
+type ConnectionStateChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State the connection entered
+	State ConnectionState `protobuf:"varint,1,opt,name=state,proto3,enum=containarium.v1.ConnectionState" json:"state,omitempty"`
+	// When conntrack reported it
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectionStateChange) Reset() {
+	*x = ConnectionStateChange{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionStateChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionStateChange) ProtoMessage() {}
+
+func (x *ConnectionStateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionStateChange.ProtoReflect.Descriptor instead.
+func (*ConnectionStateChange) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ConnectionStateChange) GetState() ConnectionState {
+	if x != nil {
+		return x.State
+	}
+	return ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+func (x *ConnectionStateChange) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// GetConnectionTimelineRequest asks for a connection's recorded states
+type GetConnectionTimelineRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name (required)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Conntrack ID of the connection, as in Connection.id and history rows
+	// (required)
+	ConntrackId   string `protobuf:"bytes,2,opt,name=conntrack_id,json=conntrackId,proto3" json:"conntrack_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionTimelineRequest) Reset() {
+	*x = GetConnectionTimelineRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionTimelineRequest) ProtoMessage() {}
+
+func (x *GetConnectionTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetConnectionTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetConnectionTimelineRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *GetConnectionTimelineRequest) GetConntrackId() string {
+	if x != nil {
+		return x.ConntrackId
+	}
+	return ""
+}
+
+type GetConnectionTimelineResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State changes, oldest first. Empty when the connection was never seen
+	// or has aged out of history.
+	Changes       []*ConnectionStateChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionTimelineResponse) Reset() {
+	*x = GetConnectionTimelineResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionTimelineResponse) ProtoMessage() {}
+
+func (x *GetConnectionTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GetConnectionTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetConnectionTimelineResponse) GetChanges() []*ConnectionStateChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// DNSQuery is one DNS lookup a container made through the host resolver
+type DNSQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container that sent the query
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Address the query came from (the container's IP)
+	ClientIp string `protobuf:"bytes,2,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	// Queried name, e.g. "api.example.com"
+	Qname string `protobuf:"bytes,3,opt,name=qname,proto3" json:"qname,omitempty"`
+	// Query type, e.g. "A", "AAAA", "MX"
+	Qtype string `protobuf:"bytes,4,opt,name=qtype,proto3" json:"qtype,omitempty"`
+	// Addresses in the answer, across any CNAME chain. Empty for NXDOMAIN,
+	// NODATA and non-address queries.
+	Answers []string `protobuf:"bytes,5,rep,name=answers,proto3" json:"answers,omitempty"`
+	// Non-address outcome reported by the resolver, e.g. "NXDOMAIN" or
+	// "NODATA" (empty when the name resolved)
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// When the query was observed
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DNSQuery) Reset() {
+	*x = DNSQuery{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DNSQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSQuery) ProtoMessage() {}
+
+func (x *DNSQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSQuery.ProtoReflect.Descriptor instead.
+func (*DNSQuery) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DNSQuery) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *DNSQuery) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *DNSQuery) GetQname() string {
+	if x != nil {
+		return x.Qname
+	}
+	return ""
+}
+
+func (x *DNSQuery) GetQtype() string {
+	if x != nil {
+		return x.Qtype
+	}
+	return ""
+}
+
+func (x *DNSQuery) GetAnswers() []string {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *DNSQuery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DNSQuery) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// QueryDNSHistoryRequest retrieves a container's recorded DNS queries
+type QueryDNSHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name (required)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Start time for query range
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End time for query range
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Only queries whose name contains this (optional, case-insensitive)
+	Qname string `protobuf:"bytes,4,opt,name=qname,proto3" json:"qname,omitempty"`
+	// Only queries that answered with this address (optional), e.g. to
+	// find which name led to a connection's dest_ip
+	AnswerIp string `protobuf:"bytes,5,opt,name=answer_ip,json=answerIp,proto3" json:"answer_ip,omitempty"`
+	// Max queries to return, newest first (default: 100, max: 1000)
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryDNSHistoryRequest) Reset() {
+	*x = QueryDNSHistoryRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryDNSHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDNSHistoryRequest) ProtoMessage() {}
+
+func (x *QueryDNSHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDNSHistoryRequest.ProtoReflect.Descriptor instead.
+func (*QueryDNSHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *QueryDNSHistoryRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *QueryDNSHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *QueryDNSHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *QueryDNSHistoryRequest) GetQname() string {
+	if x != nil {
+		return x.Qname
+	}
+	return ""
+}
+
+func (x *QueryDNSHistoryRequest) GetAnswerIp() string {
+	if x != nil {
+		return x.AnswerIp
+	}
+	return ""
+}
+
+func (x *QueryDNSHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryDNSHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matching queries, newest first
+	Queries       []*DNSQuery `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryDNSHistoryResponse) Reset() {
+	*x = QueryDNSHistoryResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryDNSHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDNSHistoryResponse) ProtoMessage() {}
+
+func (x *QueryDNSHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDNSHistoryResponse.ProtoReflect.Descriptor instead.
+func (*QueryDNSHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QueryDNSHistoryResponse) GetQueries() []*DNSQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+// SSHSession is one SSH login to a container, from the sshd auth log the
+// collector follows (daemon --traffic-ssh-log)
+type SSHSession struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container logged in to
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Account logged in as inside the box
+	BoxUser string `protobuf:"bytes,2,opt,name=box_user,json=boxUser,proto3" json:"box_user,omitempty"`
+	// Address and port the login came from
+	ClientIp   string `protobuf:"bytes,3,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	ClientPort uint32 `protobuf:"varint,4,opt,name=client_port,json=clientPort,proto3" json:"client_port,omitempty"`
+	// How the user authenticated, e.g. "publickey", "password"
+	AuthMethod string `protobuf:"bytes,5,opt,name=auth_method,json=authMethod,proto3" json:"auth_method,omitempty"`
+	// Key type and fingerprint for publickey logins, e.g. "ED25519" and
+	// "SHA256:..."
+	KeyType        string                 `protobuf:"bytes,6,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	KeyFingerprint string                 `protobuf:"bytes,7,opt,name=key_fingerprint,json=keyFingerprint,proto3" json:"key_fingerprint,omitempty"`
+	StartedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// When the client disconnected (unset while the session is open, or
+	// when its end was never logged)
+	EndedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	// Totals over the port-22 connections matched to the session, from the
+	// box's side like Connection: bytes_received is what the client
+	// uploaded. Computed when read; zero without traffic persistence.
+	BytesSent       int64 `protobuf:"varint,10,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived   int64 `protobuf:"varint,11,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	ConnectionCount int32 `protobuf:"varint,12,opt,name=connection_count,json=connectionCount,proto3" json:"connection_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SSHSession) Reset() {
+	*x = SSHSession{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SSHSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SSHSession) ProtoMessage() {}
+
+func (x *SSHSession) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SSHSession.ProtoReflect.Descriptor instead.
+func (*SSHSession) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SSHSession) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *SSHSession) GetBoxUser() string {
+	if x != nil {
+		return x.BoxUser
+	}
+	return ""
+}
+
+func (x *SSHSession) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *SSHSession) GetClientPort() uint32 {
+	if x != nil {
+		return x.ClientPort
+	}
+	return 0
+}
+
+func (x *SSHSession) GetAuthMethod() string {
+	if x != nil {
+		return x.AuthMethod
+	}
+	return ""
+}
+
+func (x *SSHSession) GetKeyType() string {
+	if x != nil {
+		return x.KeyType
+	}
+	return ""
+}
+
+func (x *SSHSession) GetKeyFingerprint() string {
+	if x != nil {
+		return x.KeyFingerprint
+	}
+	return ""
+}
+
+func (x *SSHSession) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *SSHSession) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *SSHSession) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *SSHSession) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *SSHSession) GetConnectionCount() int32 {
+	if x != nil {
+		return x.ConnectionCount
+	}
+	return 0
+}
+
+// GetSSHSessionsRequest retrieves the SSH logins to a container
+type GetSSHSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name (required)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Sessions active at any point in [start_time, end_time] (default: the
+	// last 24 hours)
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Only sessions from this address (optional)
+	ClientIp string `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	// Max sessions to return, newest first (default: 100, max: 1000)
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSSHSessionsRequest) Reset() {
+	*x = GetSSHSessionsRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSSHSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSSHSessionsRequest) ProtoMessage() {}
+
+func (x *GetSSHSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSSHSessionsRequest.ProtoReflect.Descriptor instead.
+func (*GetSSHSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetSSHSessionsRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *GetSSHSessionsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetSSHSessionsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetSSHSessionsRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *GetSSHSessionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetSSHSessionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matching sessions, newest first
+	Sessions      []*SSHSession `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSSHSessionsResponse) Reset() {
+	*x = GetSSHSessionsResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSSHSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSSHSessionsResponse) ProtoMessage() {}
+
+func (x *GetSSHSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSSHSessionsResponse.ProtoReflect.Descriptor instead.
+func (*GetSSHSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetSSHSessionsResponse) GetSessions() []*SSHSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// ListeningPort is a socket a container accepts connections (TCP) or
+// datagrams (UDP) on, as found by the collector's listener scan
+type ListeningPort struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container the socket belongs to
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// TCP or UDP
+	Protocol Protocol `protobuf:"varint,2,opt,name=protocol,proto3,enum=containarium.v1.Protocol" json:"protocol,omitempty"`
+	// Address the socket is bound to: "0.0.0.0" or "::" for every
+	// interface, "*" for a dual-stack wildcard, or a single address such as
+	// "127.0.0.1" for a loopback-only service
+	Address string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// Port the socket is bound to
+	Port uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	// Process that owns the socket. Empty when the container has no ss and
+	// the scan fell back to /proc/net, or the owner was not visible.
+	ProcessName string `protobuf:"bytes,5,opt,name=process_name,json=processName,proto3" json:"process_name,omitempty"`
+	Pid         int32  `protobuf:"varint,6,opt,name=pid,proto3" json:"pid,omitempty"`
+	// When the listener was first seen. Listeners already open at a
+	// container's first scan after the daemon started carry that scan's time.
+	FirstSeen     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListeningPort) Reset() {
+	*x = ListeningPort{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListeningPort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListeningPort) ProtoMessage() {}
+
+func (x *ListeningPort) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListeningPort.ProtoReflect.Descriptor instead.
+func (*ListeningPort) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListeningPort) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *ListeningPort) GetProtocol() Protocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return Protocol_PROTOCOL_UNSPECIFIED
+}
+
+func (x *ListeningPort) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListeningPort) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ListeningPort) GetProcessName() string {
+	if x != nil {
+		return x.ProcessName
+	}
+	return ""
+}
+
+func (x *ListeningPort) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ListeningPort) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+// ListenerChange is a difference between two listener scans of a container
+type ListenerChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Opened or closed
+	Type ListenerChangeType `protobuf:"varint,1,opt,name=type,proto3,enum=containarium.v1.ListenerChangeType" json:"type,omitempty"`
+	// The listener; for CLOSED, as it was last seen
+	Listener *ListeningPort `protobuf:"bytes,2,opt,name=listener,proto3" json:"listener,omitempty"`
+	// When the scan that noticed the change ran
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenerChange) Reset() {
+	*x = ListenerChange{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenerChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerChange) ProtoMessage() {}
+
+func (x *ListenerChange) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerChange.ProtoReflect.Descriptor instead.
+func (*ListenerChange) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListenerChange) GetType() ListenerChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ListenerChangeType_LISTENER_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ListenerChange) GetListener() *ListeningPort {
+	if x != nil {
+		return x.Listener
+	}
+	return nil
+}
+
+func (x *ListenerChange) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// GetListeningPortsRequest asks what a container is listening on
+type GetListeningPortsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name (required)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Also return the changes recorded since this time (optional; no history
+	// is returned when unset)
+	HistorySince *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=history_since,json=historySince,proto3" json:"history_since,omitempty"`
+	// Max changes to return, newest first (default: 100, max: 1000)
+	HistoryLimit  int32 `protobuf:"varint,3,opt,name=history_limit,json=historyLimit,proto3" json:"history_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListeningPortsRequest) Reset() {
+	*x = GetListeningPortsRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListeningPortsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListeningPortsRequest) ProtoMessage() {}
+
+func (x *GetListeningPortsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListeningPortsRequest.ProtoReflect.Descriptor instead.
+func (*GetListeningPortsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetListeningPortsRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *GetListeningPortsRequest) GetHistorySince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HistorySince
+	}
+	return nil
+}
+
+func (x *GetListeningPortsRequest) GetHistoryLimit() int32 {
+	if x != nil {
+		return x.HistoryLimit
+	}
+	return 0
+}
+
+type GetListeningPortsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Listeners found by the latest scan, by protocol, port and address
+	Listeners []*ListeningPort `protobuf:"bytes,1,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	// When the latest scan ran; unset until the container has been scanned
+	// (it is not running, or the daemon only just started)
+	ScannedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=scanned_at,json=scannedAt,proto3" json:"scanned_at,omitempty"`
+	// Changes since history_since, newest first
+	Changes       []*ListenerChange `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListeningPortsResponse) Reset() {
+	*x = GetListeningPortsResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListeningPortsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListeningPortsResponse) ProtoMessage() {}
+
+func (x *GetListeningPortsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListeningPortsResponse.ProtoReflect.Descriptor instead.
+func (*GetListeningPortsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetListeningPortsResponse) GetListeners() []*ListeningPort {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *GetListeningPortsResponse) GetScannedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScannedAt
+	}
+	return nil
+}
+
+func (x *GetListeningPortsResponse) GetChanges() []*ListenerChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// QueryByDestinationRequest asks which containers connected to an address
+// or network (admin only)
+type QueryByDestinationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Destination address or CIDR (required), e.g. "203.0.113.7" or
+	// "203.0.113.0/24"
+	Destination string `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Start time for query range (default: 7 days before end_time)
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End time for query range (default: now)
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Filter by destination port (optional, 0 = all)
+	DestPort      uint32 `protobuf:"varint,4,opt,name=dest_port,json=destPort,proto3" json:"dest_port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryByDestinationRequest) Reset() {
+	*x = QueryByDestinationRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryByDestinationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryByDestinationRequest) ProtoMessage() {}
+
+func (x *QueryByDestinationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryByDestinationRequest.ProtoReflect.Descriptor instead.
+func (*QueryByDestinationRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *QueryByDestinationRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *QueryByDestinationRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *QueryByDestinationRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *QueryByDestinationRequest) GetDestPort() uint32 {
+	if x != nil {
+		return x.DestPort
+	}
+	return 0
+}
+
+// DestinationContact is one container's recorded connections to the
+// queried destination
+type DestinationContact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container that opened the connections
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Username that owns the container
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// Connections matched (sampled flows counted by their weight)
+	ConnectionCount int64 `protobuf:"varint,3,opt,name=connection_count,json=connectionCount,proto3" json:"connection_count,omitempty"`
+	// Bytes sent and received by the container on those connections
+	BytesSent     int64 `protobuf:"varint,4,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived int64 `protobuf:"varint,5,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	// Start of the earliest matching connection
+	FirstSeen *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	// End of the latest matching connection (its start while still open)
+	LastSeen *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	// Distinct destination addresses matched, for CIDR queries
+	DestIps       []string `protobuf:"bytes,8,rep,name=dest_ips,json=destIps,proto3" json:"dest_ips,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestinationContact) Reset() {
+	*x = DestinationContact{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestinationContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestinationContact) ProtoMessage() {}
+
+func (x *DestinationContact) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestinationContact.ProtoReflect.Descriptor instead.
+func (*DestinationContact) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DestinationContact) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *DestinationContact) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *DestinationContact) GetConnectionCount() int64 {
+	if x != nil {
+		return x.ConnectionCount
+	}
+	return 0
+}
+
+func (x *DestinationContact) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *DestinationContact) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *DestinationContact) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *DestinationContact) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *DestinationContact) GetDestIps() []string {
+	if x != nil {
+		return x.DestIps
+	}
+	return nil
+}
+
+type QueryByDestinationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One entry per container, most recently seen first
+	Containers    []*DestinationContact `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryByDestinationResponse) Reset() {
+	*x = QueryByDestinationResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryByDestinationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryByDestinationResponse) ProtoMessage() {}
+
+func (x *QueryByDestinationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryByDestinationResponse.ProtoReflect.Descriptor instead.
+func (*QueryByDestinationResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *QueryByDestinationResponse) GetContainers() []*DestinationContact {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+// SubscribeTrafficRequest configures real-time traffic event subscription
+type SubscribeTrafficRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name (optional, empty = all containers)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Event types to include (empty = all types)
+	EventTypes []TrafficEventType `protobuf:"varint,2,rep,packed,name=event_types,json=eventTypes,proto3,enum=containarium.v1.TrafficEventType" json:"event_types,omitempty"`
+	// Only include external traffic (exclude inter-container)
+	ExternalOnly  bool `protobuf:"varint,3,opt,name=external_only,json=externalOnly,proto3" json:"external_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeTrafficRequest) Reset() {
+	*x = SubscribeTrafficRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeTrafficRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTrafficRequest) ProtoMessage() {}
+
+func (x *SubscribeTrafficRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTrafficRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTrafficRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SubscribeTrafficRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *SubscribeTrafficRequest) GetEventTypes() []TrafficEventType {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *SubscribeTrafficRequest) GetExternalOnly() bool {
+	if x != nil {
+		return x.ExternalOnly
+	}
+	return false
+}
+
+// QueryTrafficHistoryRequest queries persisted traffic data
+type QueryTrafficHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name. With neither container_name nor username the query
+	// searches every container: admin only, and dest_ip or source_ip is
+	// required.
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Start time for query range
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End time for query range
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Filter by destination IP (optional)
+	DestIp string `protobuf:"bytes,4,opt,name=dest_ip,json=destIp,proto3" json:"dest_ip,omitempty"`
+	// Filter by destination port (optional, 0 = all)
+	DestPort uint32 `protobuf:"varint,5,opt,name=dest_port,json=destPort,proto3" json:"dest_port,omitempty"`
+	// Pagination: offset
+	Offset int32 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Pagination: limit (default: 100, max: 1000)
+	Limit int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Include still-open connections that have been checkpointed by the
+	// collector (ended_at unset). Default: closed connections only.
+	IncludeOpen bool `protobuf:"varint,8,opt,name=include_open,json=includeOpen,proto3" json:"include_open,omitempty"`
+	// Filter by final TCP state (optional, UNSPECIFIED = all). E.g.
+	// SYN_SENT lists connections whose destination never answered.
+	State ConnectionState `protobuf:"varint,9,opt,name=state,proto3,enum=containarium.v1.ConnectionState" json:"state,omitempty"`
+	// Filter by the owning username (e.g. "alice") instead of, or in
+	// addition to, container_name.
+	Username string `protobuf:"bytes,10,opt,name=username,proto3" json:"username,omitempty"`
+	// Filter by source IP (optional)
+	SourceIp string `protobuf:"bytes,11,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	// Match every container whose name starts with this, e.g. "acme-",
+	// instead of one container_name. Without username it is admin only.
+	ContainerNamePrefix string `protobuf:"bytes,12,opt,name=container_name_prefix,json=containerNamePrefix,proto3" json:"container_name_prefix,omitempty"`
+	// Query several containers at once, alongside or instead of
+	// container_name. Containers the caller may not read are left out and
+	// listed in omitted_containers; the query fails only when none remain.
+	ContainerNames []string `protobuf:"bytes,13,rep,name=container_names,json=containerNames,proto3" json:"container_names,omitempty"`
+	// Only connections that violated the egress allowlist
+	PolicyViolationsOnly bool `protobuf:"varint,14,opt,name=policy_violations_only,json=policyViolationsOnly,proto3" json:"policy_violations_only,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *QueryTrafficHistoryRequest) Reset() {
+	*x = QueryTrafficHistoryRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryTrafficHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryTrafficHistoryRequest) ProtoMessage() {}
+
+func (x *QueryTrafficHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryTrafficHistoryRequest.ProtoReflect.Descriptor instead.
+func (*QueryTrafficHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *QueryTrafficHistoryRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *QueryTrafficHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *QueryTrafficHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *QueryTrafficHistoryRequest) GetDestIp() string {
+	if x != nil {
+		return x.DestIp
+	}
+	return ""
+}
+
+func (x *QueryTrafficHistoryRequest) GetDestPort() uint32 {
+	if x != nil {
+		return x.DestPort
+	}
+	return 0
+}
+
+func (x *QueryTrafficHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *QueryTrafficHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QueryTrafficHistoryRequest) GetIncludeOpen() bool {
+	if x != nil {
+		return x.IncludeOpen
+	}
+	return false
+}
+
+func (x *QueryTrafficHistoryRequest) GetState() ConnectionState {
+	if x != nil {
+		return x.State
+	}
+	return ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+func (x *QueryTrafficHistoryRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *QueryTrafficHistoryRequest) GetSourceIp() string {
+	if x != nil {
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *QueryTrafficHistoryRequest) GetContainerNamePrefix() string {
+	if x != nil {
+		return x.ContainerNamePrefix
+	}
+	return ""
+}
+
+func (x *QueryTrafficHistoryRequest) GetContainerNames() []string {
+	if x != nil {
+		return x.ContainerNames
+	}
+	return nil
+}
+
+func (x *QueryTrafficHistoryRequest) GetPolicyViolationsOnly() bool {
+	if x != nil {
+		return x.PolicyViolationsOnly
+	}
+	return false
+}
+
+type QueryTrafficHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Historical connections
+	Connections []*HistoricalConnection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	// Total count matching query
+	TotalCount int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Requested containers the caller may not read, which were skipped
+	OmittedContainers []string `protobuf:"bytes,3,rep,name=omitted_containers,json=omittedContainers,proto3" json:"omitted_containers,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *QueryTrafficHistoryResponse) Reset() {
+	*x = QueryTrafficHistoryResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryTrafficHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryTrafficHistoryResponse) ProtoMessage() {}
+
+func (x *QueryTrafficHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryTrafficHistoryResponse.ProtoReflect.Descriptor instead.
+func (*QueryTrafficHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *QueryTrafficHistoryResponse) GetConnections() []*HistoricalConnection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+func (x *QueryTrafficHistoryResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *QueryTrafficHistoryResponse) GetOmittedContainers() []string {
+	if x != nil {
+		return x.OmittedContainers
+	}
+	return nil
+}
+
+// StreamTrafficHistoryRequest selects the connections to stream. The
+// filters match QueryTrafficHistoryRequest's; there is no offset or limit
+// because the whole range is streamed.
+type StreamTrafficHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name. As with QueryTrafficHistory, an admin may leave both
+	// container_name and username empty to search every container by
+	// dest_ip or source_ip.
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Filter by the owning username (e.g. "alice") instead of, or in
+	// addition to, container_name.
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// Start time for query range
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End time for query range
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Filter by destination IP (optional)
+	DestIp string `protobuf:"bytes,5,opt,name=dest_ip,json=destIp,proto3" json:"dest_ip,omitempty"`
+	// Filter by destination port (optional, 0 = all)
+	DestPort uint32 `protobuf:"varint,6,opt,name=dest_port,json=destPort,proto3" json:"dest_port,omitempty"`
+	// Include still-open connections that have been checkpointed by the
+	// collector (ended_at unset). Default: closed connections only.
+	IncludeOpen bool `protobuf:"varint,7,opt,name=include_open,json=includeOpen,proto3" json:"include_open,omitempty"`
+	// Filter by final TCP state (optional, UNSPECIFIED = all)
+	State ConnectionState `protobuf:"varint,8,opt,name=state,proto3,enum=containarium.v1.ConnectionState" json:"state,omitempty"`
+	// Connections per streamed batch (default: 500, max: 1000)
+	BatchSize int32 `protobuf:"varint,9,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Filter by source IP (optional)
+	SourceIp string `protobuf:"bytes,10,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	// Match every container whose name starts with this, as in
+	// QueryTrafficHistoryRequest.
+	ContainerNamePrefix string `protobuf:"bytes,11,opt,name=container_name_prefix,json=containerNamePrefix,proto3" json:"container_name_prefix,omitempty"`
+	// Several containers at once, as in QueryTrafficHistoryRequest.
+	ContainerNames []string `protobuf:"bytes,12,rep,name=container_names,json=containerNames,proto3" json:"container_names,omitempty"`
+	// Only connections that violated the egress allowlist
+	PolicyViolationsOnly bool `protobuf:"varint,13,opt,name=policy_violations_only,json=policyViolationsOnly,proto3" json:"policy_violations_only,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *StreamTrafficHistoryRequest) Reset() {
+	*x = StreamTrafficHistoryRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTrafficHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTrafficHistoryRequest) ProtoMessage() {}
+
+func (x *StreamTrafficHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTrafficHistoryRequest.ProtoReflect.Descriptor instead.
+func (*StreamTrafficHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StreamTrafficHistoryRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *StreamTrafficHistoryRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *StreamTrafficHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *StreamTrafficHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *StreamTrafficHistoryRequest) GetDestIp() string {
+	if x != nil {
+		return x.DestIp
+	}
+	return ""
+}
+
+func (x *StreamTrafficHistoryRequest) GetDestPort() uint32 {
+	if x != nil {
+		return x.DestPort
+	}
+	return 0
+}
+
+func (x *StreamTrafficHistoryRequest) GetIncludeOpen() bool {
+	if x != nil {
+		return x.IncludeOpen
+	}
+	return false
+}
+
+func (x *StreamTrafficHistoryRequest) GetState() ConnectionState {
+	if x != nil {
+		return x.State
+	}
+	return ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+func (x *StreamTrafficHistoryRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *StreamTrafficHistoryRequest) GetSourceIp() string {
+	if x != nil {
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *StreamTrafficHistoryRequest) GetContainerNamePrefix() string {
+	if x != nil {
+		return x.ContainerNamePrefix
+	}
+	return ""
+}
+
+func (x *StreamTrafficHistoryRequest) GetContainerNames() []string {
+	if x != nil {
+		return x.ContainerNames
+	}
+	return nil
+}
+
+func (x *StreamTrafficHistoryRequest) GetPolicyViolationsOnly() bool {
+	if x != nil {
+		return x.PolicyViolationsOnly
+	}
+	return false
+}
+
+// TrafficHistoryBatch is one page of a StreamTrafficHistory stream.
+type TrafficHistoryBatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Historical connections, newest first across the whole stream
+	Connections []*HistoricalConnection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	// Total count matching the query; set on the first batch only
+	TotalCount int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Requested containers the caller may not read, which were skipped;
+	// set on the first batch only
+	OmittedContainers []string `protobuf:"bytes,3,rep,name=omitted_containers,json=omittedContainers,proto3" json:"omitted_containers,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TrafficHistoryBatch) Reset() {
+	*x = TrafficHistoryBatch{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficHistoryBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficHistoryBatch) ProtoMessage() {}
+
+func (x *TrafficHistoryBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficHistoryBatch.ProtoReflect.Descriptor instead.
+func (*TrafficHistoryBatch) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TrafficHistoryBatch) GetConnections() []*HistoricalConnection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+func (x *TrafficHistoryBatch) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *TrafficHistoryBatch) GetOmittedContainers() []string {
+	if x != nil {
+		return x.OmittedContainers
+	}
+	return nil
+}
+
+// GetTrafficAggregatesRequest retrieves time-series traffic aggregates
+type GetTrafficAggregatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name. container_name or container_names is required.
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Start time
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End time
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Aggregation interval (e.g., "1m", "5m", "1h", "1d")
+	Interval string `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Group by destination IP
+	GroupByDestIp bool `protobuf:"varint,5,opt,name=group_by_dest_ip,json=groupByDestIp,proto3" json:"group_by_dest_ip,omitempty"`
+	// Group by destination port
+	GroupByDestPort bool `protobuf:"varint,6,opt,name=group_by_dest_port,json=groupByDestPort,proto3" json:"group_by_dest_port,omitempty"`
+	// Group by connection direction (ingress / egress / unspecified)
+	GroupByDirection bool `protobuf:"varint,7,opt,name=group_by_direction,json=groupByDirection,proto3" json:"group_by_direction,omitempty"`
+	// IANA time zone the buckets align to, e.g. "Europe/Berlin" (default:
+	// UTC). With interval "1d" each bucket then runs from local midnight to
+	// local midnight; bucket timestamps are still absolute instants.
+	Timezone string `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Aggregate several containers at once, alongside or instead of
+	// container_name. With more than one, rows are split per container
+	// (TrafficAggregate.container_name). Containers the caller may not read
+	// are left out and listed in omitted_containers.
+	ContainerNames []string `protobuf:"bytes,9,rep,name=container_names,json=containerNames,proto3" json:"container_names,omitempty"`
+	// Group by the remote end's country (see Connection.remote_country)
+	GroupByCountry bool `protobuf:"varint,10,opt,name=group_by_country,json=groupByCountry,proto3" json:"group_by_country,omitempty"`
+	// Group by the remote end's autonomous system
+	GroupByAsn    bool `protobuf:"varint,11,opt,name=group_by_asn,json=groupByAsn,proto3" json:"group_by_asn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrafficAggregatesRequest) Reset() {
+	*x = GetTrafficAggregatesRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrafficAggregatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrafficAggregatesRequest) ProtoMessage() {}
+
+func (x *GetTrafficAggregatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrafficAggregatesRequest.ProtoReflect.Descriptor instead.
+func (*GetTrafficAggregatesRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetTrafficAggregatesRequest) GetContainerName() string {
 	if x != nil {
 		return x.ContainerName
 	}
 	return ""
 }
 
-func (x *SubscribeTrafficRequest) GetEventTypes() []TrafficEventType {
+func (x *GetTrafficAggregatesRequest) GetStartTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.EventTypes
+		return x.StartTime
 	}
 	return nil
 }
 
-func (x *SubscribeTrafficRequest) GetExternalOnly() bool {
+func (x *GetTrafficAggregatesRequest) GetEndTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ExternalOnly
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetTrafficAggregatesRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetTrafficAggregatesRequest) GetGroupByDestIp() bool {
+	if x != nil {
+		return x.GroupByDestIp
 	}
 	return false
 }
 
-// QueryTrafficHistoryRequest queries persisted traffic data
-type QueryTrafficHistoryRequest struct {
+func (x *GetTrafficAggregatesRequest) GetGroupByDestPort() bool {
+	if x != nil {
+		return x.GroupByDestPort
+	}
+	return false
+}
+
+func (x *GetTrafficAggregatesRequest) GetGroupByDirection() bool {
+	if x != nil {
+		return x.GroupByDirection
+	}
+	return false
+}
+
+func (x *GetTrafficAggregatesRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *GetTrafficAggregatesRequest) GetContainerNames() []string {
+	if x != nil {
+		return x.ContainerNames
+	}
+	return nil
+}
+
+func (x *GetTrafficAggregatesRequest) GetGroupByCountry() bool {
+	if x != nil {
+		return x.GroupByCountry
+	}
+	return false
+}
+
+func (x *GetTrafficAggregatesRequest) GetGroupByAsn() bool {
+	if x != nil {
+		return x.GroupByAsn
+	}
+	return false
+}
+
+type GetTrafficAggregatesResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Container name (required)
+	// Aggregated traffic data
+	Aggregates []*TrafficAggregate `protobuf:"bytes,1,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+	// Requested containers the caller may not read, which were skipped
+	OmittedContainers []string `protobuf:"bytes,2,rep,name=omitted_containers,json=omittedContainers,proto3" json:"omitted_containers,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetTrafficAggregatesResponse) Reset() {
+	*x = GetTrafficAggregatesResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrafficAggregatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrafficAggregatesResponse) ProtoMessage() {}
+
+func (x *GetTrafficAggregatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrafficAggregatesResponse.ProtoReflect.Descriptor instead.
+func (*GetTrafficAggregatesResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetTrafficAggregatesResponse) GetAggregates() []*TrafficAggregate {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *GetTrafficAggregatesResponse) GetOmittedContainers() []string {
+	if x != nil {
+		return x.OmittedContainers
+	}
+	return nil
+}
+
+// DailyUsage is one container's billed traffic for one UTC day, from the
+// traffic_daily rollup. Bytes are container-relative: in = received by the
+// container, out = sent by it. External bytes are those exchanged with
+// peers outside the container network.
+type DailyUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name
 	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
-	// Start time for query range
-	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	// End time for query range
-	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	// Filter by destination IP (optional)
-	DestIp string `protobuf:"bytes,4,opt,name=dest_ip,json=destIp,proto3" json:"dest_ip,omitempty"`
-	// Filter by destination port (optional, 0 = all)
-	DestPort uint32 `protobuf:"varint,5,opt,name=dest_port,json=destPort,proto3" json:"dest_port,omitempty"`
-	// Pagination: offset
-	Offset int32 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
-	// Pagination: limit (default: 100, max: 1000)
-	Limit         int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Username that owns the container
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// UTC day as YYYY-MM-DD (empty on a monthly total)
+	Day              string `protobuf:"bytes,3,opt,name=day,proto3" json:"day,omitempty"`
+	BytesIn          int64  `protobuf:"varint,4,opt,name=bytes_in,json=bytesIn,proto3" json:"bytes_in,omitempty"`
+	BytesOut         int64  `protobuf:"varint,5,opt,name=bytes_out,json=bytesOut,proto3" json:"bytes_out,omitempty"`
+	ExternalBytesIn  int64  `protobuf:"varint,6,opt,name=external_bytes_in,json=externalBytesIn,proto3" json:"external_bytes_in,omitempty"`
+	ExternalBytesOut int64  `protobuf:"varint,7,opt,name=external_bytes_out,json=externalBytesOut,proto3" json:"external_bytes_out,omitempty"`
+	// Connections first billed on this day
+	ConnectionCount int64 `protobuf:"varint,8,opt,name=connection_count,json=connectionCount,proto3" json:"connection_count,omitempty"`
+	// Highest number of concurrently open connections during the day (the
+	// maximum over the days on a monthly total)
+	PeakConnections int32 `protobuf:"varint,9,opt,name=peak_connections,json=peakConnections,proto3" json:"peak_connections,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *QueryTrafficHistoryRequest) Reset() {
-	*x = QueryTrafficHistoryRequest{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[11]
+func (x *DailyUsage) Reset() {
+	*x = DailyUsage{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryTrafficHistoryRequest) String() string {
+func (x *DailyUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryTrafficHistoryRequest) ProtoMessage() {}
+func (*DailyUsage) ProtoMessage() {}
 
-func (x *QueryTrafficHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[11]
+func (x *DailyUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1272,85 +4505,100 @@ func (x *QueryTrafficHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryTrafficHistoryRequest.ProtoReflect.Descriptor instead.
-func (*QueryTrafficHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DailyUsage.ProtoReflect.Descriptor instead.
+func (*DailyUsage) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *QueryTrafficHistoryRequest) GetContainerName() string {
+func (x *DailyUsage) GetContainerName() string {
 	if x != nil {
 		return x.ContainerName
 	}
 	return ""
 }
 
-func (x *QueryTrafficHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+func (x *DailyUsage) GetUsername() string {
 	if x != nil {
-		return x.StartTime
+		return x.Username
 	}
-	return nil
+	return ""
 }
 
-func (x *QueryTrafficHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+func (x *DailyUsage) GetDay() string {
 	if x != nil {
-		return x.EndTime
+		return x.Day
 	}
-	return nil
+	return ""
 }
 
-func (x *QueryTrafficHistoryRequest) GetDestIp() string {
+func (x *DailyUsage) GetBytesIn() int64 {
 	if x != nil {
-		return x.DestIp
+		return x.BytesIn
 	}
-	return ""
+	return 0
 }
 
-func (x *QueryTrafficHistoryRequest) GetDestPort() uint32 {
+func (x *DailyUsage) GetBytesOut() int64 {
 	if x != nil {
-		return x.DestPort
+		return x.BytesOut
 	}
 	return 0
 }
 
-func (x *QueryTrafficHistoryRequest) GetOffset() int32 {
+func (x *DailyUsage) GetExternalBytesIn() int64 {
 	if x != nil {
-		return x.Offset
+		return x.ExternalBytesIn
 	}
 	return 0
 }
 
-func (x *QueryTrafficHistoryRequest) GetLimit() int32 {
+func (x *DailyUsage) GetExternalBytesOut() int64 {
 	if x != nil {
-		return x.Limit
+		return x.ExternalBytesOut
 	}
 	return 0
 }
 
-type QueryTrafficHistoryResponse struct {
+func (x *DailyUsage) GetConnectionCount() int64 {
+	if x != nil {
+		return x.ConnectionCount
+	}
+	return 0
+}
+
+func (x *DailyUsage) GetPeakConnections() int32 {
+	if x != nil {
+		return x.PeakConnections
+	}
+	return 0
+}
+
+// GetDailyUsageRequest retrieves one container's daily usage for a month
+type GetDailyUsageRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Historical connections
-	Connections []*HistoricalConnection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
-	// Total count matching query
-	TotalCount    int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Container name (required)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Month as YYYY-MM (default: the current UTC month)
+	Month         string `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryTrafficHistoryResponse) Reset() {
-	*x = QueryTrafficHistoryResponse{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[12]
+func (x *GetDailyUsageRequest) Reset() {
+	*x = GetDailyUsageRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryTrafficHistoryResponse) String() string {
+func (x *GetDailyUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryTrafficHistoryResponse) ProtoMessage() {}
+func (*GetDailyUsageRequest) ProtoMessage() {}
 
-func (x *QueryTrafficHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[12]
+func (x *GetDailyUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1361,59 +4609,50 @@ func (x *QueryTrafficHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryTrafficHistoryResponse.ProtoReflect.Descriptor instead.
-func (*QueryTrafficHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetDailyUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetDailyUsageRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *QueryTrafficHistoryResponse) GetConnections() []*HistoricalConnection {
+func (x *GetDailyUsageRequest) GetContainerName() string {
 	if x != nil {
-		return x.Connections
+		return x.ContainerName
 	}
-	return nil
+	return ""
 }
 
-func (x *QueryTrafficHistoryResponse) GetTotalCount() int32 {
+func (x *GetDailyUsageRequest) GetMonth() string {
 	if x != nil {
-		return x.TotalCount
+		return x.Month
 	}
-	return 0
+	return ""
 }
 
-// GetTrafficAggregatesRequest retrieves time-series traffic aggregates
-type GetTrafficAggregatesRequest struct {
+type GetDailyUsageResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Container name (required)
-	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
-	// Start time
-	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	// End time
-	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	// Aggregation interval (e.g., "1m", "5m", "1h", "1d")
-	Interval string `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
-	// Group by destination IP
-	GroupByDestIp bool `protobuf:"varint,5,opt,name=group_by_dest_ip,json=groupByDestIp,proto3" json:"group_by_dest_ip,omitempty"`
-	// Group by destination port
-	GroupByDestPort bool `protobuf:"varint,6,opt,name=group_by_dest_port,json=groupByDestPort,proto3" json:"group_by_dest_port,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// One entry per day with traffic, oldest first
+	Days []*DailyUsage `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	// Monthly total
+	Total         *DailyUsage `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTrafficAggregatesRequest) Reset() {
-	*x = GetTrafficAggregatesRequest{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[13]
+func (x *GetDailyUsageResponse) Reset() {
+	*x = GetDailyUsageResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTrafficAggregatesRequest) String() string {
+func (x *GetDailyUsageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTrafficAggregatesRequest) ProtoMessage() {}
+func (*GetDailyUsageResponse) ProtoMessage() {}
 
-func (x *GetTrafficAggregatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[13]
+func (x *GetDailyUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1424,76 +4663,150 @@ func (x *GetTrafficAggregatesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTrafficAggregatesRequest.ProtoReflect.Descriptor instead.
-func (*GetTrafficAggregatesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetDailyUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetDailyUsageResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetTrafficAggregatesRequest) GetContainerName() string {
+func (x *GetDailyUsageResponse) GetDays() []*DailyUsage {
 	if x != nil {
-		return x.ContainerName
+		return x.Days
 	}
-	return ""
+	return nil
 }
 
-func (x *GetTrafficAggregatesRequest) GetStartTime() *timestamppb.Timestamp {
+func (x *GetDailyUsageResponse) GetTotal() *DailyUsage {
 	if x != nil {
-		return x.StartTime
+		return x.Total
 	}
 	return nil
 }
 
-func (x *GetTrafficAggregatesRequest) GetEndTime() *timestamppb.Timestamp {
+// GetAllDailyUsageRequest retrieves every container's daily usage for a
+// month (admin only)
+type GetAllDailyUsageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Month as YYYY-MM (default: the current UTC month)
+	Month         string `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllDailyUsageRequest) Reset() {
+	*x = GetAllDailyUsageRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllDailyUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllDailyUsageRequest) ProtoMessage() {}
+
+func (x *GetAllDailyUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[48]
 	if x != nil {
-		return x.EndTime
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetTrafficAggregatesRequest) GetInterval() string {
+// Deprecated: Use GetAllDailyUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetAllDailyUsageRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetAllDailyUsageRequest) GetMonth() string {
 	if x != nil {
-		return x.Interval
+		return x.Month
 	}
 	return ""
 }
 
-func (x *GetTrafficAggregatesRequest) GetGroupByDestIp() bool {
+type GetAllDailyUsageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One entry per container and day, ordered by container then day
+	Days []*DailyUsage `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	// Monthly total per container
+	Totals        []*DailyUsage `protobuf:"bytes,2,rep,name=totals,proto3" json:"totals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllDailyUsageResponse) Reset() {
+	*x = GetAllDailyUsageResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllDailyUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllDailyUsageResponse) ProtoMessage() {}
+
+func (x *GetAllDailyUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[49]
 	if x != nil {
-		return x.GroupByDestIp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *GetTrafficAggregatesRequest) GetGroupByDestPort() bool {
+// Deprecated: Use GetAllDailyUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetAllDailyUsageResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetAllDailyUsageResponse) GetDays() []*DailyUsage {
 	if x != nil {
-		return x.GroupByDestPort
+		return x.Days
 	}
-	return false
+	return nil
 }
 
-type GetTrafficAggregatesResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Aggregated traffic data
-	Aggregates    []*TrafficAggregate `protobuf:"bytes,1,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+func (x *GetAllDailyUsageResponse) GetTotals() []*DailyUsage {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+// BackfillDailyUsageRequest bills connections recorded before the daily
+// rollup existed (admin only). Safe to repeat: already-billed bytes are
+// never billed again.
+type BackfillDailyUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTrafficAggregatesResponse) Reset() {
-	*x = GetTrafficAggregatesResponse{}
-	mi := &file_containarium_v1_traffic_proto_msgTypes[14]
+func (x *BackfillDailyUsageRequest) Reset() {
+	*x = BackfillDailyUsageRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTrafficAggregatesResponse) String() string {
+func (x *BackfillDailyUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTrafficAggregatesResponse) ProtoMessage() {}
+func (*BackfillDailyUsageRequest) ProtoMessage() {}
 
-func (x *GetTrafficAggregatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_traffic_proto_msgTypes[14]
+func (x *BackfillDailyUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1504,23 +4817,71 @@ func (x *GetTrafficAggregatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTrafficAggregatesResponse.ProtoReflect.Descriptor instead.
-func (*GetTrafficAggregatesResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use BackfillDailyUsageRequest.ProtoReflect.Descriptor instead.
+func (*BackfillDailyUsageRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *GetTrafficAggregatesResponse) GetAggregates() []*TrafficAggregate {
+type BackfillDailyUsageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Connections whose bytes were added to the rollup
+	ConnectionsBilled int64 `protobuf:"varint,1,opt,name=connections_billed,json=connectionsBilled,proto3" json:"connections_billed,omitempty"`
+	// Days whose peak concurrency was recomputed
+	DaysRecomputed int32 `protobuf:"varint,2,opt,name=days_recomputed,json=daysRecomputed,proto3" json:"days_recomputed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BackfillDailyUsageResponse) Reset() {
+	*x = BackfillDailyUsageResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillDailyUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillDailyUsageResponse) ProtoMessage() {}
+
+func (x *BackfillDailyUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[51]
 	if x != nil {
-		return x.Aggregates
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillDailyUsageResponse.ProtoReflect.Descriptor instead.
+func (*BackfillDailyUsageResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *BackfillDailyUsageResponse) GetConnectionsBilled() int64 {
+	if x != nil {
+		return x.ConnectionsBilled
+	}
+	return 0
+}
+
+func (x *BackfillDailyUsageResponse) GetDaysRecomputed() int32 {
+	if x != nil {
+		return x.DaysRecomputed
+	}
+	return 0
 }
 
 var File_containarium_v1_traffic_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\n" +
-	"\x1dcontainarium/v1/traffic.proto\x12\x0fcontainarium.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xbb\x05\n" +
+	"\x1dcontainarium/v1/traffic.proto\x12\x0fcontainarium.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xad\n" +
+	"\n" +
 	"\n" +
 	"Connection\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
@@ -1543,13 +4904,33 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\n" +
 	"first_seen\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
 	"\tlast_seen\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12'\n" +
-	"\x0ftimeout_seconds\x18\x11 \x01(\x05R\x0etimeoutSeconds\"\xbc\x01\n" +
+	"\x0ftimeout_seconds\x18\x11 \x01(\x05R\x0etimeoutSeconds\x12!\n" +
+	"\fprocess_name\x18\x12 \x01(\tR\vprocessName\x12\x10\n" +
+	"\x03pid\x18\x13 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04zone\x18\x14 \x01(\rR\x04zone\x12\x1a\n" +
+	"\busername\x18\x15 \x01(\tR\busername\x12#\n" +
+	"\rsample_weight\x18\x16 \x01(\rR\fsampleWeight\x12#\n" +
+	"\rdest_hostname\x18\x17 \x01(\tR\fdestHostname\x120\n" +
+	"\x12bytes_sent_per_sec\x18\x18 \x01(\x01H\x00R\x0fbytesSentPerSec\x88\x01\x01\x128\n" +
+	"\x16bytes_received_per_sec\x18\x19 \x01(\x01H\x01R\x13bytesReceivedPerSec\x88\x01\x01\x12!\n" +
+	"\fservice_name\x18\x1a \x01(\tR\vserviceName\x12\x1b\n" +
+	"\ticmp_type\x18\x1b \x01(\rR\bicmpType\x12\x1b\n" +
+	"\ticmp_code\x18\x1c \x01(\rR\bicmpCode\x12\x17\n" +
+	"\aicmp_id\x18\x1d \x01(\rR\x06icmpId\x12\x18\n" +
+	"\ablocked\x18\x1e \x01(\bR\ablocked\x12%\n" +
+	"\x0eremote_country\x18\x1f \x01(\tR\rremoteCountry\x12\x1d\n" +
+	"\n" +
+	"remote_asn\x18  \x01(\rR\tremoteAsn\x12\"\n" +
+	"\rremote_as_org\x18! \x01(\tR\vremoteAsOrg\x12)\n" +
+	"\x10policy_violation\x18\" \x01(\bR\x0fpolicyViolationB\x15\n" +
+	"\x13_bytes_sent_per_secB\x19\n" +
+	"\x17_bytes_received_per_sec\"\xbc\x01\n" +
 	"\fTrafficEvent\x125\n" +
 	"\x04type\x18\x01 \x01(\x0e2!.containarium.v1.TrafficEventTypeR\x04type\x12;\n" +
 	"\n" +
 	"connection\x18\x02 \x01(\v2\x1b.containarium.v1.ConnectionR\n" +
 	"connection\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xe5\x02\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xf9\x05\n" +
 	"\x11ConnectionSummary\x12%\n" +
 	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12-\n" +
 	"\x12active_connections\x18\x02 \x01(\x05R\x11activeConnections\x12'\n" +
@@ -1557,12 +4938,34 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\x0fudp_connections\x18\x04 \x01(\x05R\x0eudpConnections\x12(\n" +
 	"\x10total_bytes_sent\x18\x05 \x01(\x03R\x0etotalBytesSent\x120\n" +
 	"\x14total_bytes_received\x18\x06 \x01(\x03R\x12totalBytesReceived\x12L\n" +
-	"\x10top_destinations\x18\a \x03(\v2!.containarium.v1.DestinationStatsR\x0ftopDestinations\"w\n" +
+	"\x10top_destinations\x18\a \x03(\v2!.containarium.v1.DestinationStatsR\x0ftopDestinations\x12@\n" +
+	"\ftop_services\x18\b \x03(\v2\x1d.containarium.v1.ServiceStatsR\vtopServices\x12+\n" +
+	"\x03dns\x18\t \x01(\v2\x19.containarium.v1.DNSStatsR\x03dns\x12V\n" +
+	"\fstate_counts\x18\n" +
+	" \x03(\v23.containarium.v1.ConnectionSummary.StateCountsEntryR\vstateCounts\x12)\n" +
+	"\x10icmp_connections\x18\v \x01(\x05R\x0ficmpConnections\x12+\n" +
+	"\x12bytes_sent_per_sec\x18\f \x01(\x01R\x0fbytesSentPerSec\x123\n" +
+	"\x16bytes_received_per_sec\x18\r \x01(\x01R\x13bytesReceivedPerSec\x1a>\n" +
+	"\x10StateCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"t\n" +
+	"\bDNSStats\x12)\n" +
+	"\x10connection_count\x18\x01 \x01(\x05R\x0fconnectionCount\x12\x1f\n" +
+	"\vbytes_total\x18\x02 \x01(\x03R\n" +
+	"bytesTotal\x12\x1c\n" +
+	"\tresolvers\x18\x03 \x03(\tR\tresolvers\"\xc8\x01\n" +
+	"\fServiceStats\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\rR\x04port\x125\n" +
+	"\bprotocol\x18\x02 \x01(\x0e2\x19.containarium.v1.ProtocolR\bprotocol\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12)\n" +
+	"\x10connection_count\x18\x04 \x01(\x05R\x0fconnectionCount\x12\x1f\n" +
+	"\vbytes_total\x18\x05 \x01(\x03R\n" +
+	"bytesTotal\"w\n" +
 	"\x10DestinationStats\x12\x17\n" +
 	"\adest_ip\x18\x01 \x01(\tR\x06destIp\x12)\n" +
 	"\x10connection_count\x18\x02 \x01(\x05R\x0fconnectionCount\x12\x1f\n" +
 	"\vbytes_total\x18\x03 \x01(\x03R\n" +
-	"bytesTotal\"\x9c\x04\n" +
+	"bytesTotal\"\xcf\a\n" +
 	"\x14HistoricalConnection\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12%\n" +
 	"\x0econtainer_name\x18\x02 \x01(\tR\rcontainerName\x125\n" +
@@ -1580,7 +4983,21 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
 	"\bended_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12)\n" +
-	"\x10duration_seconds\x18\r \x01(\x03R\x0fdurationSeconds\"\xf3\x01\n" +
+	"\x10duration_seconds\x18\r \x01(\x03R\x0fdurationSeconds\x12A\n" +
+	"\vfinal_state\x18\x0e \x01(\x0e2 .containarium.v1.ConnectionStateR\n" +
+	"finalState\x12!\n" +
+	"\fclose_reason\x18\x0f \x01(\tR\vcloseReason\x12\x12\n" +
+	"\x04zone\x18\x10 \x01(\rR\x04zone\x12\x1a\n" +
+	"\busername\x18\x11 \x01(\tR\busername\x12#\n" +
+	"\rsample_weight\x18\x12 \x01(\rR\fsampleWeight\x12#\n" +
+	"\rdest_hostname\x18\x13 \x01(\tR\fdestHostname\x12<\n" +
+	"\vssh_session\x18\x14 \x01(\v2\x1b.containarium.v1.SSHSessionR\n" +
+	"sshSession\x12%\n" +
+	"\x0eremote_country\x18\x15 \x01(\tR\rremoteCountry\x12\x1d\n" +
+	"\n" +
+	"remote_asn\x18\x16 \x01(\rR\tremoteAsn\x12\"\n" +
+	"\rremote_as_org\x18\x17 \x01(\tR\vremoteAsOrg\x12)\n" +
+	"\x10policy_violation\x18\x18 \x01(\bR\x0fpolicyViolation\"\xb2\x04\n" +
 	"\x10TrafficAggregate\x128\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x17\n" +
 	"\adest_ip\x18\x02 \x01(\tR\x06destIp\x12\x1b\n" +
@@ -1588,26 +5005,193 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\n" +
 	"bytes_sent\x18\x04 \x01(\x03R\tbytesSent\x12%\n" +
 	"\x0ebytes_received\x18\x05 \x01(\x03R\rbytesReceived\x12)\n" +
-	"\x10connection_count\x18\x06 \x01(\x05R\x0fconnectionCount\"\xce\x01\n" +
+	"\x10connection_count\x18\x06 \x01(\x05R\x0fconnectionCount\x12?\n" +
+	"\tdirection\x18\a \x01(\x0e2!.containarium.v1.TrafficDirectionR\tdirection\x12#\n" +
+	"\ringress_bytes\x18\b \x01(\x03R\fingressBytes\x12!\n" +
+	"\fegress_bytes\x18\t \x01(\x03R\vegressBytes\x12%\n" +
+	"\x0econtainer_name\x18\n" +
+	" \x01(\tR\rcontainerName\x12#\n" +
+	"\rdest_hostname\x18\v \x01(\tR\fdestHostname\x12%\n" +
+	"\x0eremote_country\x18\f \x01(\tR\rremoteCountry\x12\x1d\n" +
+	"\n" +
+	"remote_asn\x18\r \x01(\rR\tremoteAsn\x12\"\n" +
+	"\rremote_as_org\x18\x0e \x01(\tR\vremoteAsOrg\"\xee\x02\n" +
 	"\x15GetConnectionsRequest\x12%\n" +
 	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x125\n" +
 	"\bprotocol\x18\x02 \x01(\x0e2\x19.containarium.v1.ProtocolR\bprotocol\x12$\n" +
 	"\x0edest_ip_prefix\x18\x03 \x01(\tR\fdestIpPrefix\x12\x1b\n" +
 	"\tdest_port\x18\x04 \x01(\rR\bdestPort\x12\x14\n" +
-	"\x05limit\x18\x05 \x01(\x05R\x05limit\"x\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\tmin_bytes\x18\x06 \x01(\x03R\bminBytes\x12&\n" +
+	"\x0fmin_age_seconds\x18\a \x01(\x03R\rminAgeSeconds\x12 \n" +
+	"\fmin_rate_bps\x18\b \x01(\x01R\n" +
+	"minRateBps\x12!\n" +
+	"\fservice_name\x18\t \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05fresh\x18\n" +
+	" \x01(\bR\x05fresh\"\xf1\x01\n" +
 	"\x16GetConnectionsResponse\x12=\n" +
 	"\vconnections\x18\x01 \x03(\v2\x1b.containarium.v1.ConnectionR\vconnections\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"D\n" +
+	"totalCount\x12;\n" +
+	"\x05cache\x18\x03 \x01(\v2%.containarium.v1.ContainerCacheStatusR\x05cache\x12:\n" +
+	"\x19snapshot_interval_seconds\x18\x04 \x01(\x01R\x17snapshotIntervalSeconds\"\xc4\x02\n" +
+	"\x14ContainerCacheStatus\x12\x14\n" +
+	"\x05stale\x18\x01 \x01(\bR\x05stale\x12!\n" +
+	"\fbreaker_open\x18\x02 \x01(\bR\vbreakerOpen\x121\n" +
+	"\x14consecutive_failures\x18\x03 \x01(\x05R\x13consecutiveFailures\x12F\n" +
+	"\x11last_refresh_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastRefreshTime\x129\n" +
+	"\n" +
+	"retry_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tretryTime\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12\x1e\n" +
+	"\n" +
+	"containers\x18\a \x01(\x05R\n" +
+	"containers\"\x1e\n" +
+	"\x1cListTrafficContainersRequest\"\x9f\x01\n" +
+	"\x1dListTrafficContainersResponse\x12A\n" +
+	"\n" +
+	"containers\x18\x01 \x03(\v2!.containarium.v1.TrafficContainerR\n" +
+	"containers\x12;\n" +
+	"\x05cache\x18\x02 \x01(\v2%.containarium.v1.ContainerCacheStatusR\x05cache\"E\n" +
+	"\x10TrafficContainer\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x02 \x01(\tR\tipAddress\"\x19\n" +
+	"\x17GetTrafficStatusRequest\"\xcf\x01\n" +
+	"\x18GetTrafficStatusResponse\x12\x1c\n" +
+	"\tavailable\x18\x01 \x01(\bR\tavailable\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12/\n" +
+	"\x13persistence_enabled\x18\x03 \x01(\bR\x12persistenceEnabled\x122\n" +
+	"\x05geoip\x18\x04 \x01(\v2\x1c.containarium.v1.GeoIPStatusR\x05geoip\x12\x1a\n" +
+	"\bwarnings\x18\x05 \x03(\tR\bwarnings\"}\n" +
+	"\vGeoIPStatus\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\x12<\n" +
+	"\tdatabases\x18\x03 \x03(\v2\x1e.containarium.v1.GeoIPDatabaseR\tdatabases\"\x84\x02\n" +
+	"\rGeoIPDatabase\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12#\n" +
+	"\rdatabase_type\x18\x02 \x01(\tR\fdatabaseType\x129\n" +
+	"\n" +
+	"build_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tbuildTime\x12\x16\n" +
+	"\x06loaded\x18\x04 \x01(\bR\x06loaded\x12\x14\n" +
+	"\x05stale\x18\x05 \x01(\bR\x05stale\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12;\n" +
+	"\vloaded_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"loadedTime\"g\n" +
 	"\x1bGetConnectionSummaryRequest\x12%\n" +
-	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\"\\\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12!\n" +
+	"\fseparate_dns\x18\x02 \x01(\bR\vseparateDns\"\\\n" +
 	"\x1cGetConnectionSummaryResponse\x12<\n" +
-	"\asummary\x18\x01 \x01(\v2\".containarium.v1.ConnectionSummaryR\asummary\"\xa9\x01\n" +
+	"\asummary\x18\x01 \x01(\v2\".containarium.v1.ConnectionSummaryR\asummary\"g\n" +
+	"\x19DescribeConnectionRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12#\n" +
+	"\rconnection_id\x18\x02 \x01(\tR\fconnectionId\"Y\n" +
+	"\x1aDescribeConnectionResponse\x12;\n" +
+	"\n" +
+	"connection\x18\x01 \x01(\v2\x1b.containarium.v1.ConnectionR\n" +
+	"connection\"\x89\x01\n" +
+	"\x15ConnectionStateChange\x126\n" +
+	"\x05state\x18\x01 \x01(\x0e2 .containarium.v1.ConnectionStateR\x05state\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"h\n" +
+	"\x1cGetConnectionTimelineRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12!\n" +
+	"\fconntrack_id\x18\x02 \x01(\tR\vconntrackId\"a\n" +
+	"\x1dGetConnectionTimelineResponse\x12@\n" +
+	"\achanges\x18\x01 \x03(\v2&.containarium.v1.ConnectionStateChangeR\achanges\"\xe6\x01\n" +
+	"\bDNSQuery\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x1b\n" +
+	"\tclient_ip\x18\x02 \x01(\tR\bclientIp\x12\x14\n" +
+	"\x05qname\x18\x03 \x01(\tR\x05qname\x12\x14\n" +
+	"\x05qtype\x18\x04 \x01(\tR\x05qtype\x12\x18\n" +
+	"\aanswers\x18\x05 \x03(\tR\aanswers\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xfa\x01\n" +
+	"\x16QueryDNSHistoryRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05qname\x18\x04 \x01(\tR\x05qname\x12\x1b\n" +
+	"\tanswer_ip\x18\x05 \x01(\tR\banswerIp\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"N\n" +
+	"\x17QueryDNSHistoryResponse\x123\n" +
+	"\aqueries\x18\x01 \x03(\v2\x19.containarium.v1.DNSQueryR\aqueries\"\xd4\x03\n" +
+	"\n" +
+	"SSHSession\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x19\n" +
+	"\bbox_user\x18\x02 \x01(\tR\aboxUser\x12\x1b\n" +
+	"\tclient_ip\x18\x03 \x01(\tR\bclientIp\x12\x1f\n" +
+	"\vclient_port\x18\x04 \x01(\rR\n" +
+	"clientPort\x12\x1f\n" +
+	"\vauth_method\x18\x05 \x01(\tR\n" +
+	"authMethod\x12\x19\n" +
+	"\bkey_type\x18\x06 \x01(\tR\akeyType\x12'\n" +
+	"\x0fkey_fingerprint\x18\a \x01(\tR\x0ekeyFingerprint\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\n" +
+	" \x01(\x03R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\v \x01(\x03R\rbytesReceived\x12)\n" +
+	"\x10connection_count\x18\f \x01(\x05R\x0fconnectionCount\"\xe3\x01\n" +
+	"\x15GetSSHSessionsRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tclient_ip\x18\x04 \x01(\tR\bclientIp\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"Q\n" +
+	"\x16GetSSHSessionsResponse\x127\n" +
+	"\bsessions\x18\x01 \x03(\v2\x1b.containarium.v1.SSHSessionR\bsessions\"\x8b\x02\n" +
+	"\rListeningPort\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x125\n" +
+	"\bprotocol\x18\x02 \x01(\x0e2\x19.containarium.v1.ProtocolR\bprotocol\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\rR\x04port\x12!\n" +
+	"\fprocess_name\x18\x05 \x01(\tR\vprocessName\x12\x10\n" +
+	"\x03pid\x18\x06 \x01(\x05R\x03pid\x129\n" +
+	"\n" +
+	"first_seen\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\"\xbf\x01\n" +
+	"\x0eListenerChange\x127\n" +
+	"\x04type\x18\x01 \x01(\x0e2#.containarium.v1.ListenerChangeTypeR\x04type\x12:\n" +
+	"\blistener\x18\x02 \x01(\v2\x1e.containarium.v1.ListeningPortR\blistener\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xa7\x01\n" +
+	"\x18GetListeningPortsRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12?\n" +
+	"\rhistory_since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fhistorySince\x12#\n" +
+	"\rhistory_limit\x18\x03 \x01(\x05R\fhistoryLimit\"\xcf\x01\n" +
+	"\x19GetListeningPortsResponse\x12<\n" +
+	"\tlisteners\x18\x01 \x03(\v2\x1e.containarium.v1.ListeningPortR\tlisteners\x129\n" +
+	"\n" +
+	"scanned_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tscannedAt\x129\n" +
+	"\achanges\x18\x03 \x03(\v2\x1f.containarium.v1.ListenerChangeR\achanges\"\xcc\x01\n" +
+	"\x19QueryByDestinationRequest\x12 \n" +
+	"\vdestination\x18\x01 \x01(\tR\vdestination\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tdest_port\x18\x04 \x01(\rR\bdestPort\"\xd7\x02\n" +
+	"\x12DestinationContact\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12)\n" +
+	"\x10connection_count\x18\x03 \x01(\x03R\x0fconnectionCount\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x04 \x01(\x03R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x05 \x01(\x03R\rbytesReceived\x129\n" +
+	"\n" +
+	"first_seen\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x19\n" +
+	"\bdest_ips\x18\b \x03(\tR\adestIps\"a\n" +
+	"\x1aQueryByDestinationResponse\x12C\n" +
+	"\n" +
+	"containers\x18\x01 \x03(\v2#.containarium.v1.DestinationContactR\n" +
+	"containers\"\xa9\x01\n" +
 	"\x17SubscribeTrafficRequest\x12%\n" +
 	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12B\n" +
 	"\vevent_types\x18\x02 \x03(\x0e2!.containarium.v1.TrafficEventTypeR\n" +
 	"eventTypes\x12#\n" +
-	"\rexternal_only\x18\x03 \x01(\bR\fexternalOnly\"\x99\x02\n" +
+	"\rexternal_only\x18\x03 \x01(\bR\fexternalOnly\"\xc0\x04\n" +
 	"\x1aQueryTrafficHistoryRequest\x12%\n" +
 	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x129\n" +
 	"\n" +
@@ -1616,11 +5200,42 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\adest_ip\x18\x04 \x01(\tR\x06destIp\x12\x1b\n" +
 	"\tdest_port\x18\x05 \x01(\rR\bdestPort\x12\x16\n" +
 	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12\x14\n" +
-	"\x05limit\x18\a \x01(\x05R\x05limit\"\x87\x01\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12!\n" +
+	"\finclude_open\x18\b \x01(\bR\vincludeOpen\x126\n" +
+	"\x05state\x18\t \x01(\x0e2 .containarium.v1.ConnectionStateR\x05state\x12\x1a\n" +
+	"\busername\x18\n" +
+	" \x01(\tR\busername\x12\x1b\n" +
+	"\tsource_ip\x18\v \x01(\tR\bsourceIp\x122\n" +
+	"\x15container_name_prefix\x18\f \x01(\tR\x13containerNamePrefix\x12'\n" +
+	"\x0fcontainer_names\x18\r \x03(\tR\x0econtainerNames\x124\n" +
+	"\x16policy_violations_only\x18\x0e \x01(\bR\x14policyViolationsOnly\"\xb6\x01\n" +
 	"\x1bQueryTrafficHistoryResponse\x12G\n" +
 	"\vconnections\x18\x01 \x03(\v2%.containarium.v1.HistoricalConnectionR\vconnections\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xa8\x02\n" +
+	"totalCount\x12-\n" +
+	"\x12omitted_containers\x18\x03 \x03(\tR\x11omittedContainers\"\xb2\x04\n" +
+	"\x1bStreamTrafficHistoryRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x17\n" +
+	"\adest_ip\x18\x05 \x01(\tR\x06destIp\x12\x1b\n" +
+	"\tdest_port\x18\x06 \x01(\rR\bdestPort\x12!\n" +
+	"\finclude_open\x18\a \x01(\bR\vincludeOpen\x126\n" +
+	"\x05state\x18\b \x01(\x0e2 .containarium.v1.ConnectionStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\t \x01(\x05R\tbatchSize\x12\x1b\n" +
+	"\tsource_ip\x18\n" +
+	" \x01(\tR\bsourceIp\x122\n" +
+	"\x15container_name_prefix\x18\v \x01(\tR\x13containerNamePrefix\x12'\n" +
+	"\x0fcontainer_names\x18\f \x03(\tR\x0econtainerNames\x124\n" +
+	"\x16policy_violations_only\x18\r \x01(\bR\x14policyViolationsOnly\"\xae\x01\n" +
+	"\x13TrafficHistoryBatch\x12G\n" +
+	"\vconnections\x18\x01 \x03(\v2%.containarium.v1.HistoricalConnectionR\vconnections\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12-\n" +
+	"\x12omitted_containers\x18\x03 \x03(\tR\x11omittedContainers\"\xe7\x03\n" +
 	"\x1bGetTrafficAggregatesRequest\x12%\n" +
 	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x129\n" +
 	"\n" +
@@ -1628,16 +5243,55 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1a\n" +
 	"\binterval\x18\x04 \x01(\tR\binterval\x12'\n" +
 	"\x10group_by_dest_ip\x18\x05 \x01(\bR\rgroupByDestIp\x12+\n" +
-	"\x12group_by_dest_port\x18\x06 \x01(\bR\x0fgroupByDestPort\"a\n" +
+	"\x12group_by_dest_port\x18\x06 \x01(\bR\x0fgroupByDestPort\x12,\n" +
+	"\x12group_by_direction\x18\a \x01(\bR\x10groupByDirection\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12'\n" +
+	"\x0fcontainer_names\x18\t \x03(\tR\x0econtainerNames\x12(\n" +
+	"\x10group_by_country\x18\n" +
+	" \x01(\bR\x0egroupByCountry\x12 \n" +
+	"\fgroup_by_asn\x18\v \x01(\bR\n" +
+	"groupByAsn\"\x90\x01\n" +
 	"\x1cGetTrafficAggregatesResponse\x12A\n" +
 	"\n" +
 	"aggregates\x18\x01 \x03(\v2!.containarium.v1.TrafficAggregateR\n" +
-	"aggregates*[\n" +
+	"aggregates\x12-\n" +
+	"\x12omitted_containers\x18\x02 \x03(\tR\x11omittedContainers\"\xc9\x02\n" +
+	"\n" +
+	"DailyUsage\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\tR\x03day\x12\x19\n" +
+	"\bbytes_in\x18\x04 \x01(\x03R\abytesIn\x12\x1b\n" +
+	"\tbytes_out\x18\x05 \x01(\x03R\bbytesOut\x12*\n" +
+	"\x11external_bytes_in\x18\x06 \x01(\x03R\x0fexternalBytesIn\x12,\n" +
+	"\x12external_bytes_out\x18\a \x01(\x03R\x10externalBytesOut\x12)\n" +
+	"\x10connection_count\x18\b \x01(\x03R\x0fconnectionCount\x12)\n" +
+	"\x10peak_connections\x18\t \x01(\x05R\x0fpeakConnections\"S\n" +
+	"\x14GetDailyUsageRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\tR\x05month\"{\n" +
+	"\x15GetDailyUsageResponse\x12/\n" +
+	"\x04days\x18\x01 \x03(\v2\x1b.containarium.v1.DailyUsageR\x04days\x121\n" +
+	"\x05total\x18\x02 \x01(\v2\x1b.containarium.v1.DailyUsageR\x05total\"/\n" +
+	"\x17GetAllDailyUsageRequest\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\"\x80\x01\n" +
+	"\x18GetAllDailyUsageResponse\x12/\n" +
+	"\x04days\x18\x01 \x03(\v2\x1b.containarium.v1.DailyUsageR\x04days\x123\n" +
+	"\x06totals\x18\x02 \x03(\v2\x1b.containarium.v1.DailyUsageR\x06totals\"\x1b\n" +
+	"\x19BackfillDailyUsageRequest\"t\n" +
+	"\x1aBackfillDailyUsageResponse\x12-\n" +
+	"\x12connections_billed\x18\x01 \x01(\x03R\x11connectionsBilled\x12'\n" +
+	"\x0fdays_recomputed\x18\x02 \x01(\x05R\x0edaysRecomputed*\xbe\x01\n" +
 	"\bProtocol\x12\x18\n" +
 	"\x14PROTOCOL_UNSPECIFIED\x10\x00\x12\x10\n" +
 	"\fPROTOCOL_TCP\x10\x01\x12\x10\n" +
 	"\fPROTOCOL_UDP\x10\x02\x12\x11\n" +
-	"\rPROTOCOL_ICMP\x10\x03*\xc8\x02\n" +
+	"\rPROTOCOL_ICMP\x10\x03\x12\x11\n" +
+	"\rPROTOCOL_SCTP\x10\x04\x12\x13\n" +
+	"\x0fPROTOCOL_ICMPV6\x10\x05\x12\x11\n" +
+	"\rPROTOCOL_DCCP\x10\x06\x12\x14\n" +
+	"\x10PROTOCOL_UDPLITE\x10\a\x12\x10\n" +
+	"\fPROTOCOL_GRE\x10\b*\xc8\x02\n" +
 	"\x0fConnectionState\x12 \n" +
 	"\x1cCONNECTION_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14CONNECTION_STATE_NEW\x10\x01\x12 \n" +
@@ -1652,24 +5306,53 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\x10TrafficDirection\x12!\n" +
 	"\x1dTRAFFIC_DIRECTION_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19TRAFFIC_DIRECTION_INGRESS\x10\x01\x12\x1c\n" +
-	"\x18TRAFFIC_DIRECTION_EGRESS\x10\x02*\x91\x01\n" +
+	"\x18TRAFFIC_DIRECTION_EGRESS\x10\x02*\xda\x01\n" +
 	"\x10TrafficEventType\x12\"\n" +
 	"\x1eTRAFFIC_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16TRAFFIC_EVENT_TYPE_NEW\x10\x01\x12\x1d\n" +
 	"\x19TRAFFIC_EVENT_TYPE_UPDATE\x10\x02\x12\x1e\n" +
-	"\x1aTRAFFIC_EVENT_TYPE_DESTROY\x10\x032\xba\n" +
-	"\n" +
+	"\x1aTRAFFIC_EVENT_TYPE_DESTROY\x10\x03\x12\x1e\n" +
+	"\x1aTRAFFIC_EVENT_TYPE_BLOCKED\x10\x04\x12'\n" +
+	"#TRAFFIC_EVENT_TYPE_POLICY_VIOLATION\x10\x05*|\n" +
+	"\x12ListenerChangeType\x12$\n" +
+	" LISTENER_CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bLISTENER_CHANGE_TYPE_OPENED\x10\x01\x12\x1f\n" +
+	"\x1bLISTENER_CHANGE_TYPE_CLOSED\x10\x022\x9d4\n" +
 	"\x0eTrafficService\x12\x85\x02\n" +
 	"\x0eGetConnections\x12&.containarium.v1.GetConnectionsRequest\x1a'.containarium.v1.GetConnectionsResponse\"\xa1\x01\x92Ak\n" +
-	"\aTraffic\x12\x16Get active connections\x1aHReturns active network connections for a container tracked by conntrack.\x82\xd3\xe4\x93\x02-\x12+/v1/containers/{container_name}/connections\x12\x8f\x02\n" +
+	"\aTraffic\x12\x16Get active connections\x1aHReturns active network connections for a container tracked by conntrack.\x82\xd3\xe4\x93\x02-\x12+/v1/containers/{container_name}/connections\x12\xdd\x02\n" +
+	"\x15ListTrafficContainers\x12-.containarium.v1.ListTrafficContainersRequest\x1a..containarium.v1.ListTrafficContainersResponse\"\xe4\x01\x92A\xc2\x01\n" +
+	"\aTraffic\x12\x17List tracked containers\x1a\x9d\x01Returns the containers the traffic collector attributes connections to, with their IPs, from its cached Incus listing. Tenants see only their own containers.\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/traffic/containers\x12\xc3\x03\n" +
+	"\x10GetTrafficStatus\x12(.containarium.v1.GetTrafficStatusRequest\x1a).containarium.v1.GetTrafficStatusResponse\"\xd9\x02\x92A\xbb\x02\n" +
+	"\aTraffic\x12\x1cGet traffic collector status\x1a\x91\x02Reports whether conntrack monitoring is running, whether closed connections are persisted, and the state of each GeoIP database, with warnings for anything degraded. A missing or stale GeoIP database never blocks persistence: connections are stored without country and ASN.\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/traffic/status\x12\x8f\x02\n" +
 	"\x14GetConnectionSummary\x12,.containarium.v1.GetConnectionSummaryRequest\x1a-.containarium.v1.GetConnectionSummaryResponse\"\x99\x01\x92A[\n" +
-	"\aTraffic\x12\x16Get connection summary\x1a8Returns aggregate connection statistics for a container.\x82\xd3\xe4\x93\x025\x123/v1/containers/{container_name}/connections/summary\x12\xea\x01\n" +
+	"\aTraffic\x12\x16Get connection summary\x1a8Returns aggregate connection statistics for a container.\x82\xd3\xe4\x93\x025\x123/v1/containers/{container_name}/connections/summary\x12\x87\x03\n" +
+	"\x12DescribeConnection\x12*.containarium.v1.DescribeConnectionRequest\x1a+.containarium.v1.DescribeConnectionResponse\"\x97\x02\x92A\xc7\x01\n" +
+	"\aTraffic\x12\x15Describe a connection\x1a\xa4\x01Resolves the process inside the container that owns an active connection by inspecting its sockets (ss -tunap). Expensive: runs a command in the container per call.\x82\xd3\xe4\x93\x02F\x12D/v1/containers/{container_name}/connections/{connection_id}/describe\x12\x8b\x04\n" +
+	"\x15GetConnectionTimeline\x12-.containarium.v1.GetConnectionTimelineRequest\x1a..containarium.v1.GetConnectionTimelineResponse\"\x92\x03\x92A\xc3\x02\n" +
+	"\aTraffic\x12!Get a connection's state timeline\x1a\x94\x02Returns the ordered TCP state changes recorded for a connection, for debugging connections that flap. Recording is opt-in (daemon --traffic-record-states) because it writes a row per state change; FAILED_PRECONDITION when it is off or the traffic store cannot keep a timeline.\x82\xd3\xe4\x93\x02E\x12C/v1/containers/{container_name}/connections/{conntrack_id}/timeline\x12\xf4\x03\n" +
+	"\x0fQueryDNSHistory\x12'.containarium.v1.QueryDNSHistoryRequest\x1a(.containarium.v1.QueryDNSHistoryResponse\"\x8d\x03\x92A\xd6\x02\n" +
+	"\aTraffic\x12\x11Query DNS history\x1a\xb7\x02Returns the names a container looked up through the host resolver, with the addresses they resolved to, so destination IPs (often shared CDN addresses) can be tied back to domains. DNS logging is opt-in (daemon --traffic-dns-log); FAILED_PRECONDITION when it is off or the traffic store cannot keep DNS queries.\x82\xd3\xe4\x93\x02-\x12+/v1/containers/{container_name}/traffic/dns\x12\x84\x04\n" +
+	"\x0eGetSSHSessions\x12&.containarium.v1.GetSSHSessionsRequest\x1a'.containarium.v1.GetSSHSessionsResponse\"\xa0\x03\x92A\xe8\x02\n" +
+	"\aTraffic\x12\x10Get SSH sessions\x1a\xca\x02Returns who logged in to a container over SSH — box user, client address, key fingerprint, start and end — newest first, with the bytes moved by the port-22 connections each session carried. SSH session logging is opt-in (daemon --traffic-ssh-log); FAILED_PRECONDITION when it is off or the traffic store cannot keep sessions.\x82\xd3\xe4\x93\x02.\x12,/v1/containers/{container_name}/ssh/sessions\x12\xc4\x04\n" +
+	"\x11GetListeningPorts\x12).containarium.v1.GetListeningPortsRequest\x1a*.containarium.v1.GetListeningPortsResponse\"\xd7\x03\x92A\x9a\x03\n" +
+	"\aTraffic\x12\x13Get listening ports\x1a\xf9\x02Returns the TCP and UDP sockets a container is listening on, with the owning process, as of the collector's latest scan (ss inside the container, /proc/net when ss is missing). With history_since it also returns the listeners that opened or closed since then. FAILED_PRECONDITION when listener scanning is off, or when history is asked for and the traffic store cannot keep it.\x82\xd3\xe4\x93\x023\x121/v1/containers/{container_name}/traffic/listeners\x12\xea\x01\n" +
 	"\x10SubscribeTraffic\x12(.containarium.v1.SubscribeTrafficRequest\x1a\x1d.containarium.v1.TrafficEvent\"\x8a\x01\x92Aj\n" +
-	"\aTraffic\x12\x1bSubscribe to traffic events\x1aBOpens a Server-Sent Events stream for real-time connection events.\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/traffic/subscribe0\x01\x12\x8a\x02\n" +
-	"\x13QueryTrafficHistory\x12+.containarium.v1.QueryTrafficHistoryRequest\x1a,.containarium.v1.QueryTrafficHistoryResponse\"\x97\x01\x92A]\n" +
-	"\aTraffic\x12\x15Query traffic history\x1a;Returns historical connection data from persistent storage.\x82\xd3\xe4\x93\x021\x12//v1/containers/{container_name}/traffic/history\x12\x93\x02\n" +
+	"\aTraffic\x12\x1bSubscribe to traffic events\x1aBOpens a Server-Sent Events stream for real-time connection events.\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/traffic/subscribe0\x01\x12\xbf\x03\n" +
+	"\x13QueryTrafficHistory\x12+.containarium.v1.QueryTrafficHistoryRequest\x1a,.containarium.v1.QueryTrafficHistoryResponse\"\xcc\x02\x92A\xfa\x01\n" +
+	"\aTraffic\x12\x15Query traffic history\x1a\xd7\x01Returns historical connection data from persistent storage. Use /v1/traffic/history?username=... to query by owning username. Admins can search every container with /v1/traffic/history?dest_ip=... or ?source_ip=....\x82\xd3\xe4\x93\x02HZ\x15\x12\x13/v1/traffic/history\x12//v1/containers/{container_name}/traffic/history\x12\xfd\x03\n" +
+	"\x14StreamTrafficHistory\x12,.containarium.v1.StreamTrafficHistoryRequest\x1a$.containarium.v1.TrafficHistoryBatch\"\x8e\x03\x92A\xae\x02\n" +
+	"\aTraffic\x12\x16Stream traffic history\x1a\x8a\x02Streams every historical connection in the range, newest first, in batches of batch_size. The server pages through the store, so neither side holds the whole range and no message approaches the gRPC size limit. Over HTTP the batches arrive as newline-delimited JSON.\x82\xd3\xe4\x93\x02VZ\x1c\x12\x1a/v1/traffic/history/stream\x126/v1/containers/{container_name}/traffic/history/stream0\x01\x12\x9a\x03\n" +
+	"\x12QueryByDestination\x12*.containarium.v1.QueryByDestinationRequest\x1a+.containarium.v1.QueryByDestinationResponse\"\xaa\x02\x92A\x86\x02\n" +
+	"\aTraffic\x12\x1cQuery traffic by destination\x1a\xdc\x01Answers \"which containers talked to this address?\" from the traffic history: matching connections grouped by container, with totals and first/last seen. destination is an IP or a CIDR. Admin only, since it spans tenants.\x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/traffic/destinations\x12\x93\x02\n" +
 	"\x14GetTrafficAggregates\x12,.containarium.v1.GetTrafficAggregatesRequest\x1a-.containarium.v1.GetTrafficAggregatesResponse\"\x9d\x01\x92A`\n" +
-	"\aTraffic\x12\x16Get traffic aggregates\x1a=Returns aggregated traffic statistics over time for analysis.\x82\xd3\xe4\x93\x024\x122/v1/containers/{container_name}/traffic/aggregatesBKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
+	"\aTraffic\x12\x16Get traffic aggregates\x1a=Returns aggregated traffic statistics over time for analysis.\x82\xd3\xe4\x93\x024\x122/v1/containers/{container_name}/traffic/aggregates\x12\xaa\x02\n" +
+	"\rGetDailyUsage\x12%.containarium.v1.GetDailyUsageRequest\x1a&.containarium.v1.GetDailyUsageResponse\"\xc9\x01\x92A\x90\x01\n" +
+	"\aTraffic\x12\x17Get daily traffic usage\x1alReturns per-day bytes in/out, external bytes and peak concurrent connections for one container over a month.\x82\xd3\xe4\x93\x02/\x12-/v1/containers/{container_name}/traffic/usage\x12\x96\x02\n" +
+	"\x10GetAllDailyUsage\x12(.containarium.v1.GetAllDailyUsageRequest\x1a).containarium.v1.GetAllDailyUsageResponse\"\xac\x01\x92A\x8f\x01\n" +
+	"\aTraffic\x12*Get daily traffic usage for all containers\x1aXReturns per-day usage for every container over a month, with monthly totals. Admin only.\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/traffic/usage\x12\xc5\x02\n" +
+	"\x12BackfillDailyUsage\x12*.containarium.v1.BackfillDailyUsageRequest\x1a+.containarium.v1.BackfillDailyUsageResponse\"\xd5\x01\x92A\xac\x01\n" +
+	"\aTraffic\x12\x1cBackfill daily traffic usage\x1a\x82\x01One-shot admin operation that adds existing traffic history to the daily usage rollup. Already-billed bytes are not counted again.\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/traffic/usage/backfillBKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
 
 var (
 	file_containarium_v1_traffic_proto_rawDescOnce sync.Once
@@ -1683,70 +5366,185 @@ func file_containarium_v1_traffic_proto_rawDescGZIP() []byte {
 	return file_containarium_v1_traffic_proto_rawDescData
 }
 
-var file_containarium_v1_traffic_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_containarium_v1_traffic_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_containarium_v1_traffic_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_containarium_v1_traffic_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_containarium_v1_traffic_proto_goTypes = []any{
-	(Protocol)(0),                        // 0: containarium.v1.Protocol
-	(ConnectionState)(0),                 // 1: containarium.v1.ConnectionState
-	(TrafficDirection)(0),                // 2: containarium.v1.TrafficDirection
-	(TrafficEventType)(0),                // 3: containarium.v1.TrafficEventType
-	(*Connection)(nil),                   // 4: containarium.v1.Connection
-	(*TrafficEvent)(nil),                 // 5: containarium.v1.TrafficEvent
-	(*ConnectionSummary)(nil),            // 6: containarium.v1.ConnectionSummary
-	(*DestinationStats)(nil),             // 7: containarium.v1.DestinationStats
-	(*HistoricalConnection)(nil),         // 8: containarium.v1.HistoricalConnection
-	(*TrafficAggregate)(nil),             // 9: containarium.v1.TrafficAggregate
-	(*GetConnectionsRequest)(nil),        // 10: containarium.v1.GetConnectionsRequest
-	(*GetConnectionsResponse)(nil),       // 11: containarium.v1.GetConnectionsResponse
-	(*GetConnectionSummaryRequest)(nil),  // 12: containarium.v1.GetConnectionSummaryRequest
-	(*GetConnectionSummaryResponse)(nil), // 13: containarium.v1.GetConnectionSummaryResponse
-	(*SubscribeTrafficRequest)(nil),      // 14: containarium.v1.SubscribeTrafficRequest
-	(*QueryTrafficHistoryRequest)(nil),   // 15: containarium.v1.QueryTrafficHistoryRequest
-	(*QueryTrafficHistoryResponse)(nil),  // 16: containarium.v1.QueryTrafficHistoryResponse
-	(*GetTrafficAggregatesRequest)(nil),  // 17: containarium.v1.GetTrafficAggregatesRequest
-	(*GetTrafficAggregatesResponse)(nil), // 18: containarium.v1.GetTrafficAggregatesResponse
-	(*timestamppb.Timestamp)(nil),        // 19: google.protobuf.Timestamp
+	(Protocol)(0),                         // 0: containarium.v1.Protocol
+	(ConnectionState)(0),                  // 1: containarium.v1.ConnectionState
+	(TrafficDirection)(0),                 // 2: containarium.v1.TrafficDirection
+	(TrafficEventType)(0),                 // 3: containarium.v1.TrafficEventType
+	(ListenerChangeType)(0),               // 4: containarium.v1.ListenerChangeType
+	(*Connection)(nil),                    // 5: containarium.v1.Connection
+	(*TrafficEvent)(nil),                  // 6: containarium.v1.TrafficEvent
+	(*ConnectionSummary)(nil),             // 7: containarium.v1.ConnectionSummary
+	(*DNSStats)(nil),                      // 8: containarium.v1.DNSStats
+	(*ServiceStats)(nil),                  // 9: containarium.v1.ServiceStats
+	(*DestinationStats)(nil),              // 10: containarium.v1.DestinationStats
+	(*HistoricalConnection)(nil),          // 11: containarium.v1.HistoricalConnection
+	(*TrafficAggregate)(nil),              // 12: containarium.v1.TrafficAggregate
+	(*GetConnectionsRequest)(nil),         // 13: containarium.v1.GetConnectionsRequest
+	(*GetConnectionsResponse)(nil),        // 14: containarium.v1.GetConnectionsResponse
+	(*ContainerCacheStatus)(nil),          // 15: containarium.v1.ContainerCacheStatus
+	(*ListTrafficContainersRequest)(nil),  // 16: containarium.v1.ListTrafficContainersRequest
+	(*ListTrafficContainersResponse)(nil), // 17: containarium.v1.ListTrafficContainersResponse
+	(*TrafficContainer)(nil),              // 18: containarium.v1.TrafficContainer
+	(*GetTrafficStatusRequest)(nil),       // 19: containarium.v1.GetTrafficStatusRequest
+	(*GetTrafficStatusResponse)(nil),      // 20: containarium.v1.GetTrafficStatusResponse
+	(*GeoIPStatus)(nil),                   // 21: containarium.v1.GeoIPStatus
+	(*GeoIPDatabase)(nil),                 // 22: containarium.v1.GeoIPDatabase
+	(*GetConnectionSummaryRequest)(nil),   // 23: containarium.v1.GetConnectionSummaryRequest
+	(*GetConnectionSummaryResponse)(nil),  // 24: containarium.v1.GetConnectionSummaryResponse
+	(*DescribeConnectionRequest)(nil),     // 25: containarium.v1.DescribeConnectionRequest
+	(*DescribeConnectionResponse)(nil),    // 26: containarium.v1.DescribeConnectionResponse
+	(*ConnectionStateChange)(nil),         // 27: containarium.v1.ConnectionStateChange
+	(*GetConnectionTimelineRequest)(nil),  // 28: containarium.v1.GetConnectionTimelineRequest
+	(*GetConnectionTimelineResponse)(nil), // 29: containarium.v1.GetConnectionTimelineResponse
+	(*DNSQuery)(nil),                      // 30: containarium.v1.DNSQuery
+	(*QueryDNSHistoryRequest)(nil),        // 31: containarium.v1.QueryDNSHistoryRequest
+	(*QueryDNSHistoryResponse)(nil),       // 32: containarium.v1.QueryDNSHistoryResponse
+	(*SSHSession)(nil),                    // 33: containarium.v1.SSHSession
+	(*GetSSHSessionsRequest)(nil),         // 34: containarium.v1.GetSSHSessionsRequest
+	(*GetSSHSessionsResponse)(nil),        // 35: containarium.v1.GetSSHSessionsResponse
+	(*ListeningPort)(nil),                 // 36: containarium.v1.ListeningPort
+	(*ListenerChange)(nil),                // 37: containarium.v1.ListenerChange
+	(*GetListeningPortsRequest)(nil),      // 38: containarium.v1.GetListeningPortsRequest
+	(*GetListeningPortsResponse)(nil),     // 39: containarium.v1.GetListeningPortsResponse
+	(*QueryByDestinationRequest)(nil),     // 40: containarium.v1.QueryByDestinationRequest
+	(*DestinationContact)(nil),            // 41: containarium.v1.DestinationContact
+	(*QueryByDestinationResponse)(nil),    // 42: containarium.v1.QueryByDestinationResponse
+	(*SubscribeTrafficRequest)(nil),       // 43: containarium.v1.SubscribeTrafficRequest
+	(*QueryTrafficHistoryRequest)(nil),    // 44: containarium.v1.QueryTrafficHistoryRequest
+	(*QueryTrafficHistoryResponse)(nil),   // 45: containarium.v1.QueryTrafficHistoryResponse
+	(*StreamTrafficHistoryRequest)(nil),   // 46: containarium.v1.StreamTrafficHistoryRequest
+	(*TrafficHistoryBatch)(nil),           // 47: containarium.v1.TrafficHistoryBatch
+	(*GetTrafficAggregatesRequest)(nil),   // 48: containarium.v1.GetTrafficAggregatesRequest
+	(*GetTrafficAggregatesResponse)(nil),  // 49: containarium.v1.GetTrafficAggregatesResponse
+	(*DailyUsage)(nil),                    // 50: containarium.v1.DailyUsage
+	(*GetDailyUsageRequest)(nil),          // 51: containarium.v1.GetDailyUsageRequest
+	(*GetDailyUsageResponse)(nil),         // 52: containarium.v1.GetDailyUsageResponse
+	(*GetAllDailyUsageRequest)(nil),       // 53: containarium.v1.GetAllDailyUsageRequest
+	(*GetAllDailyUsageResponse)(nil),      // 54: containarium.v1.GetAllDailyUsageResponse
+	(*BackfillDailyUsageRequest)(nil),     // 55: containarium.v1.BackfillDailyUsageRequest
+	(*BackfillDailyUsageResponse)(nil),    // 56: containarium.v1.BackfillDailyUsageResponse
+	nil,                                   // 57: containarium.v1.ConnectionSummary.StateCountsEntry
+	(*timestamppb.Timestamp)(nil),         // 58: google.protobuf.Timestamp
 }
 var file_containarium_v1_traffic_proto_depIdxs = []int32{
 	0,  // 0: containarium.v1.Connection.protocol:type_name -> containarium.v1.Protocol
 	1,  // 1: containarium.v1.Connection.state:type_name -> containarium.v1.ConnectionState
 	2,  // 2: containarium.v1.Connection.direction:type_name -> containarium.v1.TrafficDirection
-	19, // 3: containarium.v1.Connection.first_seen:type_name -> google.protobuf.Timestamp
-	19, // 4: containarium.v1.Connection.last_seen:type_name -> google.protobuf.Timestamp
+	58, // 3: containarium.v1.Connection.first_seen:type_name -> google.protobuf.Timestamp
+	58, // 4: containarium.v1.Connection.last_seen:type_name -> google.protobuf.Timestamp
 	3,  // 5: containarium.v1.TrafficEvent.type:type_name -> containarium.v1.TrafficEventType
-	4,  // 6: containarium.v1.TrafficEvent.connection:type_name -> containarium.v1.Connection
-	19, // 7: containarium.v1.TrafficEvent.timestamp:type_name -> google.protobuf.Timestamp
-	7,  // 8: containarium.v1.ConnectionSummary.top_destinations:type_name -> containarium.v1.DestinationStats
-	0,  // 9: containarium.v1.HistoricalConnection.protocol:type_name -> containarium.v1.Protocol
-	2,  // 10: containarium.v1.HistoricalConnection.direction:type_name -> containarium.v1.TrafficDirection
-	19, // 11: containarium.v1.HistoricalConnection.started_at:type_name -> google.protobuf.Timestamp
-	19, // 12: containarium.v1.HistoricalConnection.ended_at:type_name -> google.protobuf.Timestamp
-	19, // 13: containarium.v1.TrafficAggregate.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 14: containarium.v1.GetConnectionsRequest.protocol:type_name -> containarium.v1.Protocol
-	4,  // 15: containarium.v1.GetConnectionsResponse.connections:type_name -> containarium.v1.Connection
-	6,  // 16: containarium.v1.GetConnectionSummaryResponse.summary:type_name -> containarium.v1.ConnectionSummary
-	3,  // 17: containarium.v1.SubscribeTrafficRequest.event_types:type_name -> containarium.v1.TrafficEventType
-	19, // 18: containarium.v1.QueryTrafficHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
-	19, // 19: containarium.v1.QueryTrafficHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
-	8,  // 20: containarium.v1.QueryTrafficHistoryResponse.connections:type_name -> containarium.v1.HistoricalConnection
-	19, // 21: containarium.v1.GetTrafficAggregatesRequest.start_time:type_name -> google.protobuf.Timestamp
-	19, // 22: containarium.v1.GetTrafficAggregatesRequest.end_time:type_name -> google.protobuf.Timestamp
-	9,  // 23: containarium.v1.GetTrafficAggregatesResponse.aggregates:type_name -> containarium.v1.TrafficAggregate
-	10, // 24: containarium.v1.TrafficService.GetConnections:input_type -> containarium.v1.GetConnectionsRequest
-	12, // 25: containarium.v1.TrafficService.GetConnectionSummary:input_type -> containarium.v1.GetConnectionSummaryRequest
-	14, // 26: containarium.v1.TrafficService.SubscribeTraffic:input_type -> containarium.v1.SubscribeTrafficRequest
-	15, // 27: containarium.v1.TrafficService.QueryTrafficHistory:input_type -> containarium.v1.QueryTrafficHistoryRequest
-	17, // 28: containarium.v1.TrafficService.GetTrafficAggregates:input_type -> containarium.v1.GetTrafficAggregatesRequest
-	11, // 29: containarium.v1.TrafficService.GetConnections:output_type -> containarium.v1.GetConnectionsResponse
-	13, // 30: containarium.v1.TrafficService.GetConnectionSummary:output_type -> containarium.v1.GetConnectionSummaryResponse
-	5,  // 31: containarium.v1.TrafficService.SubscribeTraffic:output_type -> containarium.v1.TrafficEvent
-	16, // 32: containarium.v1.TrafficService.QueryTrafficHistory:output_type -> containarium.v1.QueryTrafficHistoryResponse
-	18, // 33: containarium.v1.TrafficService.GetTrafficAggregates:output_type -> containarium.v1.GetTrafficAggregatesResponse
-	29, // [29:34] is the sub-list for method output_type
-	24, // [24:29] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	5,  // 6: containarium.v1.TrafficEvent.connection:type_name -> containarium.v1.Connection
+	58, // 7: containarium.v1.TrafficEvent.timestamp:type_name -> google.protobuf.Timestamp
+	10, // 8: containarium.v1.ConnectionSummary.top_destinations:type_name -> containarium.v1.DestinationStats
+	9,  // 9: containarium.v1.ConnectionSummary.top_services:type_name -> containarium.v1.ServiceStats
+	8,  // 10: containarium.v1.ConnectionSummary.dns:type_name -> containarium.v1.DNSStats
+	57, // 11: containarium.v1.ConnectionSummary.state_counts:type_name -> containarium.v1.ConnectionSummary.StateCountsEntry
+	0,  // 12: containarium.v1.ServiceStats.protocol:type_name -> containarium.v1.Protocol
+	0,  // 13: containarium.v1.HistoricalConnection.protocol:type_name -> containarium.v1.Protocol
+	2,  // 14: containarium.v1.HistoricalConnection.direction:type_name -> containarium.v1.TrafficDirection
+	58, // 15: containarium.v1.HistoricalConnection.started_at:type_name -> google.protobuf.Timestamp
+	58, // 16: containarium.v1.HistoricalConnection.ended_at:type_name -> google.protobuf.Timestamp
+	1,  // 17: containarium.v1.HistoricalConnection.final_state:type_name -> containarium.v1.ConnectionState
+	33, // 18: containarium.v1.HistoricalConnection.ssh_session:type_name -> containarium.v1.SSHSession
+	58, // 19: containarium.v1.TrafficAggregate.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 20: containarium.v1.TrafficAggregate.direction:type_name -> containarium.v1.TrafficDirection
+	0,  // 21: containarium.v1.GetConnectionsRequest.protocol:type_name -> containarium.v1.Protocol
+	5,  // 22: containarium.v1.GetConnectionsResponse.connections:type_name -> containarium.v1.Connection
+	15, // 23: containarium.v1.GetConnectionsResponse.cache:type_name -> containarium.v1.ContainerCacheStatus
+	58, // 24: containarium.v1.ContainerCacheStatus.last_refresh_time:type_name -> google.protobuf.Timestamp
+	58, // 25: containarium.v1.ContainerCacheStatus.retry_time:type_name -> google.protobuf.Timestamp
+	18, // 26: containarium.v1.ListTrafficContainersResponse.containers:type_name -> containarium.v1.TrafficContainer
+	15, // 27: containarium.v1.ListTrafficContainersResponse.cache:type_name -> containarium.v1.ContainerCacheStatus
+	21, // 28: containarium.v1.GetTrafficStatusResponse.geoip:type_name -> containarium.v1.GeoIPStatus
+	22, // 29: containarium.v1.GeoIPStatus.databases:type_name -> containarium.v1.GeoIPDatabase
+	58, // 30: containarium.v1.GeoIPDatabase.build_time:type_name -> google.protobuf.Timestamp
+	58, // 31: containarium.v1.GeoIPDatabase.loaded_time:type_name -> google.protobuf.Timestamp
+	7,  // 32: containarium.v1.GetConnectionSummaryResponse.summary:type_name -> containarium.v1.ConnectionSummary
+	5,  // 33: containarium.v1.DescribeConnectionResponse.connection:type_name -> containarium.v1.Connection
+	1,  // 34: containarium.v1.ConnectionStateChange.state:type_name -> containarium.v1.ConnectionState
+	58, // 35: containarium.v1.ConnectionStateChange.timestamp:type_name -> google.protobuf.Timestamp
+	27, // 36: containarium.v1.GetConnectionTimelineResponse.changes:type_name -> containarium.v1.ConnectionStateChange
+	58, // 37: containarium.v1.DNSQuery.timestamp:type_name -> google.protobuf.Timestamp
+	58, // 38: containarium.v1.QueryDNSHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	58, // 39: containarium.v1.QueryDNSHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	30, // 40: containarium.v1.QueryDNSHistoryResponse.queries:type_name -> containarium.v1.DNSQuery
+	58, // 41: containarium.v1.SSHSession.started_at:type_name -> google.protobuf.Timestamp
+	58, // 42: containarium.v1.SSHSession.ended_at:type_name -> google.protobuf.Timestamp
+	58, // 43: containarium.v1.GetSSHSessionsRequest.start_time:type_name -> google.protobuf.Timestamp
+	58, // 44: containarium.v1.GetSSHSessionsRequest.end_time:type_name -> google.protobuf.Timestamp
+	33, // 45: containarium.v1.GetSSHSessionsResponse.sessions:type_name -> containarium.v1.SSHSession
+	0,  // 46: containarium.v1.ListeningPort.protocol:type_name -> containarium.v1.Protocol
+	58, // 47: containarium.v1.ListeningPort.first_seen:type_name -> google.protobuf.Timestamp
+	4,  // 48: containarium.v1.ListenerChange.type:type_name -> containarium.v1.ListenerChangeType
+	36, // 49: containarium.v1.ListenerChange.listener:type_name -> containarium.v1.ListeningPort
+	58, // 50: containarium.v1.ListenerChange.timestamp:type_name -> google.protobuf.Timestamp
+	58, // 51: containarium.v1.GetListeningPortsRequest.history_since:type_name -> google.protobuf.Timestamp
+	36, // 52: containarium.v1.GetListeningPortsResponse.listeners:type_name -> containarium.v1.ListeningPort
+	58, // 53: containarium.v1.GetListeningPortsResponse.scanned_at:type_name -> google.protobuf.Timestamp
+	37, // 54: containarium.v1.GetListeningPortsResponse.changes:type_name -> containarium.v1.ListenerChange
+	58, // 55: containarium.v1.QueryByDestinationRequest.start_time:type_name -> google.protobuf.Timestamp
+	58, // 56: containarium.v1.QueryByDestinationRequest.end_time:type_name -> google.protobuf.Timestamp
+	58, // 57: containarium.v1.DestinationContact.first_seen:type_name -> google.protobuf.Timestamp
+	58, // 58: containarium.v1.DestinationContact.last_seen:type_name -> google.protobuf.Timestamp
+	41, // 59: containarium.v1.QueryByDestinationResponse.containers:type_name -> containarium.v1.DestinationContact
+	3,  // 60: containarium.v1.SubscribeTrafficRequest.event_types:type_name -> containarium.v1.TrafficEventType
+	58, // 61: containarium.v1.QueryTrafficHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	58, // 62: containarium.v1.QueryTrafficHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 63: containarium.v1.QueryTrafficHistoryRequest.state:type_name -> containarium.v1.ConnectionState
+	11, // 64: containarium.v1.QueryTrafficHistoryResponse.connections:type_name -> containarium.v1.HistoricalConnection
+	58, // 65: containarium.v1.StreamTrafficHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	58, // 66: containarium.v1.StreamTrafficHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 67: containarium.v1.StreamTrafficHistoryRequest.state:type_name -> containarium.v1.ConnectionState
+	11, // 68: containarium.v1.TrafficHistoryBatch.connections:type_name -> containarium.v1.HistoricalConnection
+	58, // 69: containarium.v1.GetTrafficAggregatesRequest.start_time:type_name -> google.protobuf.Timestamp
+	58, // 70: containarium.v1.GetTrafficAggregatesRequest.end_time:type_name -> google.protobuf.Timestamp
+	12, // 71: containarium.v1.GetTrafficAggregatesResponse.aggregates:type_name -> containarium.v1.TrafficAggregate
+	50, // 72: containarium.v1.GetDailyUsageResponse.days:type_name -> containarium.v1.DailyUsage
+	50, // 73: containarium.v1.GetDailyUsageResponse.total:type_name -> containarium.v1.DailyUsage
+	50, // 74: containarium.v1.GetAllDailyUsageResponse.days:type_name -> containarium.v1.DailyUsage
+	50, // 75: containarium.v1.GetAllDailyUsageResponse.totals:type_name -> containarium.v1.DailyUsage
+	13, // 76: containarium.v1.TrafficService.GetConnections:input_type -> containarium.v1.GetConnectionsRequest
+	16, // 77: containarium.v1.TrafficService.ListTrafficContainers:input_type -> containarium.v1.ListTrafficContainersRequest
+	19, // 78: containarium.v1.TrafficService.GetTrafficStatus:input_type -> containarium.v1.GetTrafficStatusRequest
+	23, // 79: containarium.v1.TrafficService.GetConnectionSummary:input_type -> containarium.v1.GetConnectionSummaryRequest
+	25, // 80: containarium.v1.TrafficService.DescribeConnection:input_type -> containarium.v1.DescribeConnectionRequest
+	28, // 81: containarium.v1.TrafficService.GetConnectionTimeline:input_type -> containarium.v1.GetConnectionTimelineRequest
+	31, // 82: containarium.v1.TrafficService.QueryDNSHistory:input_type -> containarium.v1.QueryDNSHistoryRequest
+	34, // 83: containarium.v1.TrafficService.GetSSHSessions:input_type -> containarium.v1.GetSSHSessionsRequest
+	38, // 84: containarium.v1.TrafficService.GetListeningPorts:input_type -> containarium.v1.GetListeningPortsRequest
+	43, // 85: containarium.v1.TrafficService.SubscribeTraffic:input_type -> containarium.v1.SubscribeTrafficRequest
+	44, // 86: containarium.v1.TrafficService.QueryTrafficHistory:input_type -> containarium.v1.QueryTrafficHistoryRequest
+	46, // 87: containarium.v1.TrafficService.StreamTrafficHistory:input_type -> containarium.v1.StreamTrafficHistoryRequest
+	40, // 88: containarium.v1.TrafficService.QueryByDestination:input_type -> containarium.v1.QueryByDestinationRequest
+	48, // 89: containarium.v1.TrafficService.GetTrafficAggregates:input_type -> containarium.v1.GetTrafficAggregatesRequest
+	51, // 90: containarium.v1.TrafficService.GetDailyUsage:input_type -> containarium.v1.GetDailyUsageRequest
+	53, // 91: containarium.v1.TrafficService.GetAllDailyUsage:input_type -> containarium.v1.GetAllDailyUsageRequest
+	55, // 92: containarium.v1.TrafficService.BackfillDailyUsage:input_type -> containarium.v1.BackfillDailyUsageRequest
+	14, // 93: containarium.v1.TrafficService.GetConnections:output_type -> containarium.v1.GetConnectionsResponse
+	17, // 94: containarium.v1.TrafficService.ListTrafficContainers:output_type -> containarium.v1.ListTrafficContainersResponse
+	20, // 95: containarium.v1.TrafficService.GetTrafficStatus:output_type -> containarium.v1.GetTrafficStatusResponse
+	24, // 96: containarium.v1.TrafficService.GetConnectionSummary:output_type -> containarium.v1.GetConnectionSummaryResponse
+	26, // 97: containarium.v1.TrafficService.DescribeConnection:output_type -> containarium.v1.DescribeConnectionResponse
+	29, // 98: containarium.v1.TrafficService.GetConnectionTimeline:output_type -> containarium.v1.GetConnectionTimelineResponse
+	32, // 99: containarium.v1.TrafficService.QueryDNSHistory:output_type -> containarium.v1.QueryDNSHistoryResponse
+	35, // 100: containarium.v1.TrafficService.GetSSHSessions:output_type -> containarium.v1.GetSSHSessionsResponse
+	39, // 101: containarium.v1.TrafficService.GetListeningPorts:output_type -> containarium.v1.GetListeningPortsResponse
+	6,  // 102: containarium.v1.TrafficService.SubscribeTraffic:output_type -> containarium.v1.TrafficEvent
+	45, // 103: containarium.v1.TrafficService.QueryTrafficHistory:output_type -> containarium.v1.QueryTrafficHistoryResponse
+	47, // 104: containarium.v1.TrafficService.StreamTrafficHistory:output_type -> containarium.v1.TrafficHistoryBatch
+	42, // 105: containarium.v1.TrafficService.QueryByDestination:output_type -> containarium.v1.QueryByDestinationResponse
+	49, // 106: containarium.v1.TrafficService.GetTrafficAggregates:output_type -> containarium.v1.GetTrafficAggregatesResponse
+	52, // 107: containarium.v1.TrafficService.GetDailyUsage:output_type -> containarium.v1.GetDailyUsageResponse
+	54, // 108: containarium.v1.TrafficService.GetAllDailyUsage:output_type -> containarium.v1.GetAllDailyUsageResponse
+	56, // 109: containarium.v1.TrafficService.BackfillDailyUsage:output_type -> containarium.v1.BackfillDailyUsageResponse
+	93, // [93:110] is the sub-list for method output_type
+	76, // [76:93] is the sub-list for method input_type
+	76, // [76:76] is the sub-list for extension type_name
+	76, // [76:76] is the sub-list for extension extendee
+	0,  // [0:76] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_traffic_proto_init() }
@@ -1754,13 +5552,14 @@ func file_containarium_v1_traffic_proto_init() {
 	if File_containarium_v1_traffic_proto != nil {
 		return
 	}
+	file_containarium_v1_traffic_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_traffic_proto_rawDesc), len(file_containarium_v1_traffic_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   15,
+			NumEnums:      5,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
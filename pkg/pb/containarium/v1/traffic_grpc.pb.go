@@ -19,11 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TrafficService_GetConnections_FullMethodName       = "/containarium.v1.TrafficService/GetConnections"
-	TrafficService_GetConnectionSummary_FullMethodName = "/containarium.v1.TrafficService/GetConnectionSummary"
-	TrafficService_SubscribeTraffic_FullMethodName     = "/containarium.v1.TrafficService/SubscribeTraffic"
-	TrafficService_QueryTrafficHistory_FullMethodName  = "/containarium.v1.TrafficService/QueryTrafficHistory"
-	TrafficService_GetTrafficAggregates_FullMethodName = "/containarium.v1.TrafficService/GetTrafficAggregates"
+	TrafficService_GetConnections_FullMethodName        = "/containarium.v1.TrafficService/GetConnections"
+	TrafficService_ListTrafficContainers_FullMethodName = "/containarium.v1.TrafficService/ListTrafficContainers"
+	TrafficService_GetTrafficStatus_FullMethodName      = "/containarium.v1.TrafficService/GetTrafficStatus"
+	TrafficService_GetConnectionSummary_FullMethodName  = "/containarium.v1.TrafficService/GetConnectionSummary"
+	TrafficService_DescribeConnection_FullMethodName    = "/containarium.v1.TrafficService/DescribeConnection"
+	TrafficService_GetConnectionTimeline_FullMethodName = "/containarium.v1.TrafficService/GetConnectionTimeline"
+	TrafficService_QueryDNSHistory_FullMethodName       = "/containarium.v1.TrafficService/QueryDNSHistory"
+	TrafficService_GetSSHSessions_FullMethodName        = "/containarium.v1.TrafficService/GetSSHSessions"
+	TrafficService_GetListeningPorts_FullMethodName     = "/containarium.v1.TrafficService/GetListeningPorts"
+	TrafficService_SubscribeTraffic_FullMethodName      = "/containarium.v1.TrafficService/SubscribeTraffic"
+	TrafficService_QueryTrafficHistory_FullMethodName   = "/containarium.v1.TrafficService/QueryTrafficHistory"
+	TrafficService_StreamTrafficHistory_FullMethodName  = "/containarium.v1.TrafficService/StreamTrafficHistory"
+	TrafficService_QueryByDestination_FullMethodName    = "/containarium.v1.TrafficService/QueryByDestination"
+	TrafficService_GetTrafficAggregates_FullMethodName  = "/containarium.v1.TrafficService/GetTrafficAggregates"
+	TrafficService_GetDailyUsage_FullMethodName         = "/containarium.v1.TrafficService/GetDailyUsage"
+	TrafficService_GetAllDailyUsage_FullMethodName      = "/containarium.v1.TrafficService/GetAllDailyUsage"
+	TrafficService_BackfillDailyUsage_FullMethodName    = "/containarium.v1.TrafficService/BackfillDailyUsage"
 )
 
 // TrafficServiceClient is the client API for TrafficService service.
@@ -34,14 +46,47 @@ const (
 type TrafficServiceClient interface {
 	// GetConnections returns active connections for a container
 	GetConnections(ctx context.Context, in *GetConnectionsRequest, opts ...grpc.CallOption) (*GetConnectionsResponse, error)
+	// ListTrafficContainers returns the containers the collector tracks
+	ListTrafficContainers(ctx context.Context, in *ListTrafficContainersRequest, opts ...grpc.CallOption) (*ListTrafficContainersResponse, error)
+	// GetTrafficStatus reports whether monitoring, persistence and GeoIP
+	// enrichment are working
+	GetTrafficStatus(ctx context.Context, in *GetTrafficStatusRequest, opts ...grpc.CallOption) (*GetTrafficStatusResponse, error)
 	// GetConnectionSummary returns aggregate connection statistics
 	GetConnectionSummary(ctx context.Context, in *GetConnectionSummaryRequest, opts ...grpc.CallOption) (*GetConnectionSummaryResponse, error)
+	// DescribeConnection attributes an active connection to the process
+	// inside the container that owns it. On-demand because it execs into
+	// the container.
+	DescribeConnection(ctx context.Context, in *DescribeConnectionRequest, opts ...grpc.CallOption) (*DescribeConnectionResponse, error)
+	// GetConnectionTimeline returns the sequence of states a connection went
+	// through. Requires the daemon to record them (--traffic-record-states).
+	GetConnectionTimeline(ctx context.Context, in *GetConnectionTimelineRequest, opts ...grpc.CallOption) (*GetConnectionTimelineResponse, error)
+	// QueryDNSHistory returns the DNS queries a container made. Requires
+	// the daemon to log them (--traffic-dns-log).
+	QueryDNSHistory(ctx context.Context, in *QueryDNSHistoryRequest, opts ...grpc.CallOption) (*QueryDNSHistoryResponse, error)
+	// GetSSHSessions returns the SSH logins to a container recorded from
+	// the sshd auth log (--traffic-ssh-log).
+	GetSSHSessions(ctx context.Context, in *GetSSHSessionsRequest, opts ...grpc.CallOption) (*GetSSHSessionsResponse, error)
+	// GetListeningPorts returns the ports a container is listening on, from
+	// the collector's periodic listener scan (--traffic-listener-interval).
+	GetListeningPorts(ctx context.Context, in *GetListeningPortsRequest, opts ...grpc.CallOption) (*GetListeningPortsResponse, error)
 	// SubscribeTraffic opens a streaming connection for real-time traffic events
 	SubscribeTraffic(ctx context.Context, in *SubscribeTrafficRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrafficEvent], error)
 	// QueryTrafficHistory queries persisted traffic data
 	QueryTrafficHistory(ctx context.Context, in *QueryTrafficHistoryRequest, opts ...grpc.CallOption) (*QueryTrafficHistoryResponse, error)
+	// StreamTrafficHistory streams persisted traffic data in batches, for
+	// ranges too large for one QueryTrafficHistory response
+	StreamTrafficHistory(ctx context.Context, in *StreamTrafficHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrafficHistoryBatch], error)
+	// QueryByDestination lists the containers that connected to an address
+	// or network, across all containers (admin only)
+	QueryByDestination(ctx context.Context, in *QueryByDestinationRequest, opts ...grpc.CallOption) (*QueryByDestinationResponse, error)
 	// GetTrafficAggregates returns time-series traffic aggregates
 	GetTrafficAggregates(ctx context.Context, in *GetTrafficAggregatesRequest, opts ...grpc.CallOption) (*GetTrafficAggregatesResponse, error)
+	// GetDailyUsage returns a container's billed traffic per day for a month
+	GetDailyUsage(ctx context.Context, in *GetDailyUsageRequest, opts ...grpc.CallOption) (*GetDailyUsageResponse, error)
+	// GetAllDailyUsage returns every container's billed traffic per day for a month
+	GetAllDailyUsage(ctx context.Context, in *GetAllDailyUsageRequest, opts ...grpc.CallOption) (*GetAllDailyUsageResponse, error)
+	// BackfillDailyUsage bills traffic recorded before the daily rollup existed
+	BackfillDailyUsage(ctx context.Context, in *BackfillDailyUsageRequest, opts ...grpc.CallOption) (*BackfillDailyUsageResponse, error)
 }
 
 type trafficServiceClient struct {
@@ -62,6 +107,26 @@ func (c *trafficServiceClient) GetConnections(ctx context.Context, in *GetConnec
 	return out, nil
 }
 
+func (c *trafficServiceClient) ListTrafficContainers(ctx context.Context, in *ListTrafficContainersRequest, opts ...grpc.CallOption) (*ListTrafficContainersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrafficContainersResponse)
+	err := c.cc.Invoke(ctx, TrafficService_ListTrafficContainers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) GetTrafficStatus(ctx context.Context, in *GetTrafficStatusRequest, opts ...grpc.CallOption) (*GetTrafficStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrafficStatusResponse)
+	err := c.cc.Invoke(ctx, TrafficService_GetTrafficStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *trafficServiceClient) GetConnectionSummary(ctx context.Context, in *GetConnectionSummaryRequest, opts ...grpc.CallOption) (*GetConnectionSummaryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetConnectionSummaryResponse)
@@ -72,6 +137,56 @@ func (c *trafficServiceClient) GetConnectionSummary(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *trafficServiceClient) DescribeConnection(ctx context.Context, in *DescribeConnectionRequest, opts ...grpc.CallOption) (*DescribeConnectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeConnectionResponse)
+	err := c.cc.Invoke(ctx, TrafficService_DescribeConnection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) GetConnectionTimeline(ctx context.Context, in *GetConnectionTimelineRequest, opts ...grpc.CallOption) (*GetConnectionTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConnectionTimelineResponse)
+	err := c.cc.Invoke(ctx, TrafficService_GetConnectionTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) QueryDNSHistory(ctx context.Context, in *QueryDNSHistoryRequest, opts ...grpc.CallOption) (*QueryDNSHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryDNSHistoryResponse)
+	err := c.cc.Invoke(ctx, TrafficService_QueryDNSHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) GetSSHSessions(ctx context.Context, in *GetSSHSessionsRequest, opts ...grpc.CallOption) (*GetSSHSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSSHSessionsResponse)
+	err := c.cc.Invoke(ctx, TrafficService_GetSSHSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) GetListeningPorts(ctx context.Context, in *GetListeningPortsRequest, opts ...grpc.CallOption) (*GetListeningPortsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetListeningPortsResponse)
+	err := c.cc.Invoke(ctx, TrafficService_GetListeningPorts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *trafficServiceClient) SubscribeTraffic(ctx context.Context, in *SubscribeTrafficRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrafficEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &TrafficService_ServiceDesc.Streams[0], TrafficService_SubscribeTraffic_FullMethodName, cOpts...)
@@ -101,6 +216,35 @@ func (c *trafficServiceClient) QueryTrafficHistory(ctx context.Context, in *Quer
 	return out, nil
 }
 
+func (c *trafficServiceClient) StreamTrafficHistory(ctx context.Context, in *StreamTrafficHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrafficHistoryBatch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TrafficService_ServiceDesc.Streams[1], TrafficService_StreamTrafficHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTrafficHistoryRequest, TrafficHistoryBatch]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrafficService_StreamTrafficHistoryClient = grpc.ServerStreamingClient[TrafficHistoryBatch]
+
+func (c *trafficServiceClient) QueryByDestination(ctx context.Context, in *QueryByDestinationRequest, opts ...grpc.CallOption) (*QueryByDestinationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryByDestinationResponse)
+	err := c.cc.Invoke(ctx, TrafficService_QueryByDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *trafficServiceClient) GetTrafficAggregates(ctx context.Context, in *GetTrafficAggregatesRequest, opts ...grpc.CallOption) (*GetTrafficAggregatesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTrafficAggregatesResponse)
@@ -111,6 +255,36 @@ func (c *trafficServiceClient) GetTrafficAggregates(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *trafficServiceClient) GetDailyUsage(ctx context.Context, in *GetDailyUsageRequest, opts ...grpc.CallOption) (*GetDailyUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDailyUsageResponse)
+	err := c.cc.Invoke(ctx, TrafficService_GetDailyUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) GetAllDailyUsage(ctx context.Context, in *GetAllDailyUsageRequest, opts ...grpc.CallOption) (*GetAllDailyUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllDailyUsageResponse)
+	err := c.cc.Invoke(ctx, TrafficService_GetAllDailyUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) BackfillDailyUsage(ctx context.Context, in *BackfillDailyUsageRequest, opts ...grpc.CallOption) (*BackfillDailyUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackfillDailyUsageResponse)
+	err := c.cc.Invoke(ctx, TrafficService_BackfillDailyUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrafficServiceServer is the server API for TrafficService service.
 // All implementations must embed UnimplementedTrafficServiceServer
 // for forward compatibility.
@@ -119,14 +293,47 @@ func (c *trafficServiceClient) GetTrafficAggregates(ctx context.Context, in *Get
 type TrafficServiceServer interface {
 	// GetConnections returns active connections for a container
 	GetConnections(context.Context, *GetConnectionsRequest) (*GetConnectionsResponse, error)
+	// ListTrafficContainers returns the containers the collector tracks
+	ListTrafficContainers(context.Context, *ListTrafficContainersRequest) (*ListTrafficContainersResponse, error)
+	// GetTrafficStatus reports whether monitoring, persistence and GeoIP
+	// enrichment are working
+	GetTrafficStatus(context.Context, *GetTrafficStatusRequest) (*GetTrafficStatusResponse, error)
 	// GetConnectionSummary returns aggregate connection statistics
 	GetConnectionSummary(context.Context, *GetConnectionSummaryRequest) (*GetConnectionSummaryResponse, error)
+	// DescribeConnection attributes an active connection to the process
+	// inside the container that owns it. On-demand because it execs into
+	// the container.
+	DescribeConnection(context.Context, *DescribeConnectionRequest) (*DescribeConnectionResponse, error)
+	// GetConnectionTimeline returns the sequence of states a connection went
+	// through. Requires the daemon to record them (--traffic-record-states).
+	GetConnectionTimeline(context.Context, *GetConnectionTimelineRequest) (*GetConnectionTimelineResponse, error)
+	// QueryDNSHistory returns the DNS queries a container made. Requires
+	// the daemon to log them (--traffic-dns-log).
+	QueryDNSHistory(context.Context, *QueryDNSHistoryRequest) (*QueryDNSHistoryResponse, error)
+	// GetSSHSessions returns the SSH logins to a container recorded from
+	// the sshd auth log (--traffic-ssh-log).
+	GetSSHSessions(context.Context, *GetSSHSessionsRequest) (*GetSSHSessionsResponse, error)
+	// GetListeningPorts returns the ports a container is listening on, from
+	// the collector's periodic listener scan (--traffic-listener-interval).
+	GetListeningPorts(context.Context, *GetListeningPortsRequest) (*GetListeningPortsResponse, error)
 	// SubscribeTraffic opens a streaming connection for real-time traffic events
 	SubscribeTraffic(*SubscribeTrafficRequest, grpc.ServerStreamingServer[TrafficEvent]) error
 	// QueryTrafficHistory queries persisted traffic data
 	QueryTrafficHistory(context.Context, *QueryTrafficHistoryRequest) (*QueryTrafficHistoryResponse, error)
+	// StreamTrafficHistory streams persisted traffic data in batches, for
+	// ranges too large for one QueryTrafficHistory response
+	StreamTrafficHistory(*StreamTrafficHistoryRequest, grpc.ServerStreamingServer[TrafficHistoryBatch]) error
+	// QueryByDestination lists the containers that connected to an address
+	// or network, across all containers (admin only)
+	QueryByDestination(context.Context, *QueryByDestinationRequest) (*QueryByDestinationResponse, error)
 	// GetTrafficAggregates returns time-series traffic aggregates
 	GetTrafficAggregates(context.Context, *GetTrafficAggregatesRequest) (*GetTrafficAggregatesResponse, error)
+	// GetDailyUsage returns a container's billed traffic per day for a month
+	GetDailyUsage(context.Context, *GetDailyUsageRequest) (*GetDailyUsageResponse, error)
+	// GetAllDailyUsage returns every container's billed traffic per day for a month
+	GetAllDailyUsage(context.Context, *GetAllDailyUsageRequest) (*GetAllDailyUsageResponse, error)
+	// BackfillDailyUsage bills traffic recorded before the daily rollup existed
+	BackfillDailyUsage(context.Context, *BackfillDailyUsageRequest) (*BackfillDailyUsageResponse, error)
 	mustEmbedUnimplementedTrafficServiceServer()
 }
 
@@ -140,18 +347,54 @@ type UnimplementedTrafficServiceServer struct{}
 func (UnimplementedTrafficServiceServer) GetConnections(context.Context, *GetConnectionsRequest) (*GetConnectionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetConnections not implemented")
 }
+func (UnimplementedTrafficServiceServer) ListTrafficContainers(context.Context, *ListTrafficContainersRequest) (*ListTrafficContainersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTrafficContainers not implemented")
+}
+func (UnimplementedTrafficServiceServer) GetTrafficStatus(context.Context, *GetTrafficStatusRequest) (*GetTrafficStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTrafficStatus not implemented")
+}
 func (UnimplementedTrafficServiceServer) GetConnectionSummary(context.Context, *GetConnectionSummaryRequest) (*GetConnectionSummaryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetConnectionSummary not implemented")
 }
+func (UnimplementedTrafficServiceServer) DescribeConnection(context.Context, *DescribeConnectionRequest) (*DescribeConnectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DescribeConnection not implemented")
+}
+func (UnimplementedTrafficServiceServer) GetConnectionTimeline(context.Context, *GetConnectionTimelineRequest) (*GetConnectionTimelineResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConnectionTimeline not implemented")
+}
+func (UnimplementedTrafficServiceServer) QueryDNSHistory(context.Context, *QueryDNSHistoryRequest) (*QueryDNSHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QueryDNSHistory not implemented")
+}
+func (UnimplementedTrafficServiceServer) GetSSHSessions(context.Context, *GetSSHSessionsRequest) (*GetSSHSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSSHSessions not implemented")
+}
+func (UnimplementedTrafficServiceServer) GetListeningPorts(context.Context, *GetListeningPortsRequest) (*GetListeningPortsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetListeningPorts not implemented")
+}
 func (UnimplementedTrafficServiceServer) SubscribeTraffic(*SubscribeTrafficRequest, grpc.ServerStreamingServer[TrafficEvent]) error {
 	return status.Error(codes.Unimplemented, "method SubscribeTraffic not implemented")
 }
 func (UnimplementedTrafficServiceServer) QueryTrafficHistory(context.Context, *QueryTrafficHistoryRequest) (*QueryTrafficHistoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method QueryTrafficHistory not implemented")
 }
+func (UnimplementedTrafficServiceServer) StreamTrafficHistory(*StreamTrafficHistoryRequest, grpc.ServerStreamingServer[TrafficHistoryBatch]) error {
+	return status.Error(codes.Unimplemented, "method StreamTrafficHistory not implemented")
+}
+func (UnimplementedTrafficServiceServer) QueryByDestination(context.Context, *QueryByDestinationRequest) (*QueryByDestinationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QueryByDestination not implemented")
+}
 func (UnimplementedTrafficServiceServer) GetTrafficAggregates(context.Context, *GetTrafficAggregatesRequest) (*GetTrafficAggregatesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTrafficAggregates not implemented")
 }
+func (UnimplementedTrafficServiceServer) GetDailyUsage(context.Context, *GetDailyUsageRequest) (*GetDailyUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDailyUsage not implemented")
+}
+func (UnimplementedTrafficServiceServer) GetAllDailyUsage(context.Context, *GetAllDailyUsageRequest) (*GetAllDailyUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAllDailyUsage not implemented")
+}
+func (UnimplementedTrafficServiceServer) BackfillDailyUsage(context.Context, *BackfillDailyUsageRequest) (*BackfillDailyUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BackfillDailyUsage not implemented")
+}
 func (UnimplementedTrafficServiceServer) mustEmbedUnimplementedTrafficServiceServer() {}
 func (UnimplementedTrafficServiceServer) testEmbeddedByValue()                        {}
 
@@ -191,6 +434,42 @@ func _TrafficService_GetConnections_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrafficService_ListTrafficContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrafficContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).ListTrafficContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_ListTrafficContainers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).ListTrafficContainers(ctx, req.(*ListTrafficContainersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_GetTrafficStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrafficStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).GetTrafficStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_GetTrafficStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).GetTrafficStatus(ctx, req.(*GetTrafficStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TrafficService_GetConnectionSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetConnectionSummaryRequest)
 	if err := dec(in); err != nil {
@@ -209,6 +488,96 @@ func _TrafficService_GetConnectionSummary_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrafficService_DescribeConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).DescribeConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_DescribeConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).DescribeConnection(ctx, req.(*DescribeConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_GetConnectionTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectionTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).GetConnectionTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_GetConnectionTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).GetConnectionTimeline(ctx, req.(*GetConnectionTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_QueryDNSHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDNSHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).QueryDNSHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_QueryDNSHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).QueryDNSHistory(ctx, req.(*QueryDNSHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_GetSSHSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSSHSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).GetSSHSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_GetSSHSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).GetSSHSessions(ctx, req.(*GetSSHSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_GetListeningPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListeningPortsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).GetListeningPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_GetListeningPorts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).GetListeningPorts(ctx, req.(*GetListeningPortsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TrafficService_SubscribeTraffic_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeTrafficRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -238,6 +607,35 @@ func _TrafficService_QueryTrafficHistory_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrafficService_StreamTrafficHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTrafficHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrafficServiceServer).StreamTrafficHistory(m, &grpc.GenericServerStream[StreamTrafficHistoryRequest, TrafficHistoryBatch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrafficService_StreamTrafficHistoryServer = grpc.ServerStreamingServer[TrafficHistoryBatch]
+
+func _TrafficService_QueryByDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryByDestinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).QueryByDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_QueryByDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).QueryByDestination(ctx, req.(*QueryByDestinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TrafficService_GetTrafficAggregates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTrafficAggregatesRequest)
 	if err := dec(in); err != nil {
@@ -256,6 +654,60 @@ func _TrafficService_GetTrafficAggregates_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrafficService_GetDailyUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).GetDailyUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_GetDailyUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).GetDailyUsage(ctx, req.(*GetDailyUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_GetAllDailyUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllDailyUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).GetAllDailyUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_GetAllDailyUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).GetAllDailyUsage(ctx, req.(*GetAllDailyUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_BackfillDailyUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillDailyUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).BackfillDailyUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_BackfillDailyUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).BackfillDailyUsage(ctx, req.(*BackfillDailyUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrafficService_ServiceDesc is the grpc.ServiceDesc for TrafficService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,18 +719,62 @@ var TrafficService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConnections",
 			Handler:    _TrafficService_GetConnections_Handler,
 		},
+		{
+			MethodName: "ListTrafficContainers",
+			Handler:    _TrafficService_ListTrafficContainers_Handler,
+		},
+		{
+			MethodName: "GetTrafficStatus",
+			Handler:    _TrafficService_GetTrafficStatus_Handler,
+		},
 		{
 			MethodName: "GetConnectionSummary",
 			Handler:    _TrafficService_GetConnectionSummary_Handler,
 		},
+		{
+			MethodName: "DescribeConnection",
+			Handler:    _TrafficService_DescribeConnection_Handler,
+		},
+		{
+			MethodName: "GetConnectionTimeline",
+			Handler:    _TrafficService_GetConnectionTimeline_Handler,
+		},
+		{
+			MethodName: "QueryDNSHistory",
+			Handler:    _TrafficService_QueryDNSHistory_Handler,
+		},
+		{
+			MethodName: "GetSSHSessions",
+			Handler:    _TrafficService_GetSSHSessions_Handler,
+		},
+		{
+			MethodName: "GetListeningPorts",
+			Handler:    _TrafficService_GetListeningPorts_Handler,
+		},
 		{
 			MethodName: "QueryTrafficHistory",
 			Handler:    _TrafficService_QueryTrafficHistory_Handler,
 		},
+		{
+			MethodName: "QueryByDestination",
+			Handler:    _TrafficService_QueryByDestination_Handler,
+		},
 		{
 			MethodName: "GetTrafficAggregates",
 			Handler:    _TrafficService_GetTrafficAggregates_Handler,
 		},
+		{
+			MethodName: "GetDailyUsage",
+			Handler:    _TrafficService_GetDailyUsage_Handler,
+		},
+		{
+			MethodName: "GetAllDailyUsage",
+			Handler:    _TrafficService_GetAllDailyUsage_Handler,
+		},
+		{
+			MethodName: "BackfillDailyUsage",
+			Handler:    _TrafficService_BackfillDailyUsage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -286,6 +782,11 @@ var TrafficService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TrafficService_SubscribeTraffic_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamTrafficHistory",
+			Handler:       _TrafficService_StreamTrafficHistory_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "containarium/v1/traffic.proto",
 }
@@ -57,7 +57,10 @@ export default function ConnectionsTable({ connections, isLoading }: Connections
               <td className="px-3 py-2.5 font-mono text-[var(--text-secondary)]" title={`Source: ${conn.sourceIp}:${conn.sourcePort}`}>
                 {conn.destIp}
               </td>
-              <td className="px-3 py-2.5 font-mono text-[var(--text-secondary)]">{conn.destPort}</td>
+              <td className="px-3 py-2.5 font-mono text-[var(--text-secondary)]">
+                {conn.destPort}
+                {conn.serviceName && <span className="ml-1.5 text-[var(--text-muted)]">{conn.serviceName}</span>}
+              </td>
               <td className="px-3 py-2.5">
                 <span className={`rounded-full border px-2 py-0.5 text-[10px] font-medium ${stateBadge(conn.state)}`}>
                   {getStateLabel(conn.state)}
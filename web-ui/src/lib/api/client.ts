@@ -892,12 +892,14 @@ export class ContaineriumClient {
     protocol?: string;
     destIpPrefix?: string;
     destPort?: number;
+    serviceName?: string;
     limit?: number;
   }): Promise<GetConnectionsResponse> {
     const params: Record<string, unknown> = {};
     if (options?.protocol) params.protocol = options.protocol;
     if (options?.destIpPrefix) params.destIpPrefix = options.destIpPrefix;
     if (options?.destPort) params.destPort = options.destPort;
+    if (options?.serviceName) params.serviceName = options.serviceName;
     if (options?.limit) params.limit = options.limit;
 
     const response = await this.client.get<GetConnectionsResponse>(
@@ -967,6 +969,7 @@ export class ContaineriumClient {
     interval?: string;
     groupByDestIp?: boolean;
     groupByDestPort?: boolean;
+    groupByDirection?: boolean;
   }): Promise<TrafficAggregate[]> {
     const params: Record<string, unknown> = {
       startTime: options.startTime,
@@ -975,6 +978,7 @@ export class ContaineriumClient {
     if (options.interval) params.interval = options.interval;
     if (options.groupByDestIp) params.groupByDestIp = options.groupByDestIp;
     if (options.groupByDestPort) params.groupByDestPort = options.groupByDestPort;
+    if (options.groupByDirection) params.groupByDirection = options.groupByDirection;
 
     const response = await this.client.get<GetTrafficAggregatesResponse>(
       `/containers/${containerName}/traffic/aggregates`,
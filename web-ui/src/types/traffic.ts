@@ -1,7 +1,16 @@
 /**
  * Network protocol types
  */
-export type Protocol = 'UNSPECIFIED' | 'TCP' | 'UDP' | 'ICMP';
+export type Protocol =
+  | 'UNSPECIFIED'
+  | 'TCP'
+  | 'UDP'
+  | 'ICMP'
+  | 'SCTP'
+  | 'ICMPV6'
+  | 'DCCP'
+  | 'UDPLITE'
+  | 'GRE';
 
 /**
  * Connection state (primarily for TCP)
@@ -34,6 +43,7 @@ export type TrafficEventType = 'UNSPECIFIED' | 'NEW' | 'UPDATE' | 'DESTROY';
 export interface Connection {
   id: string;
   containerName: string;
+  username?: string; // owning user, e.g. "alice" for alice-container
   containerIp: string;
   protocol: Protocol;
   sourceIp: string;
@@ -49,6 +59,12 @@ export interface Connection {
   firstSeen: string; // ISO timestamp
   lastSeen: string; // ISO timestamp
   timeoutSeconds: number;
+  processName?: string; // only set by DescribeConnection
+  pid?: number;
+  serviceName?: string; // well-known service on destPort, e.g. "https"
+  icmpType?: number; // ICMP/ICMPv6 only, in place of ports
+  icmpCode?: number;
+  icmpId?: number; // echo identifier
 }
 
 /**
@@ -71,6 +87,7 @@ export interface ConnectionSummary {
   totalBytesSent: number;
   totalBytesReceived: number;
   topDestinations: DestinationStats[];
+  topServices?: ServiceStats[];
 }
 
 /**
@@ -82,12 +99,24 @@ export interface DestinationStats {
   bytesTotal: number;
 }
 
+/**
+ * Statistics for a destination port
+ */
+export interface ServiceStats {
+  port: number;
+  protocol: Protocol;
+  serviceName?: string; // well-known service on the port, e.g. "ssh"
+  connectionCount: number;
+  bytesTotal: number;
+}
+
 /**
  * Historical connection record
  */
 export interface HistoricalConnection {
   id: number;
   containerName: string;
+  username?: string;
   protocol: Protocol;
   sourceIp: string;
   sourcePort: number;
@@ -99,6 +128,8 @@ export interface HistoricalConnection {
   startedAt: string; // ISO timestamp
   endedAt?: string; // ISO timestamp (null if still active)
   durationSeconds: number;
+  finalState?: ConnectionState; // TCP state at close
+  closeReason?: string; // e.g. completed, refused_by_peer, no_reply_from_peer
 }
 
 /**
@@ -106,11 +137,15 @@ export interface HistoricalConnection {
  */
 export interface TrafficAggregate {
   timestamp: string; // ISO timestamp
+  containerName?: string; // set when several containers were requested
   destIp?: string;
   destPort?: number;
   bytesSent: number;
   bytesReceived: number;
   connectionCount: number;
+  direction?: TrafficDirection; // set when grouped by direction
+  ingressBytes?: number;
+  egressBytes?: number;
 }
 
 /**
@@ -150,6 +185,7 @@ export interface QueryTrafficHistoryRequest {
   destPort?: number;
   offset?: number;
   limit?: number;
+  state?: ConnectionState; // filter by TCP state at close
 }
 
 /**
@@ -170,6 +206,7 @@ export interface GetTrafficAggregatesRequest {
   interval?: string; // e.g., "1m", "5m", "1h", "1d"
   groupByDestIp?: boolean;
   groupByDestPort?: boolean;
+  groupByDirection?: boolean;
 }
 
 /**
@@ -219,6 +256,16 @@ export function getProtocolLabel(protocol: Protocol): string {
       return 'UDP';
     case 'ICMP':
       return 'ICMP';
+    case 'SCTP':
+      return 'SCTP';
+    case 'ICMPV6':
+      return 'ICMPv6';
+    case 'DCCP':
+      return 'DCCP';
+    case 'UDPLITE':
+      return 'UDP-Lite';
+    case 'GRE':
+      return 'GRE';
     default:
       return '?';
   }